@@ -0,0 +1,415 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/pkg/client"
+)
+
+// commands lists the subcommands of the CLI.
+var commands = []command{
+	{name: "login", args: "[-email e] [-password p]", summary: "Log in and store the access token in the profile", run: runLogin},
+	{name: "profile list", summary: "List the configured profiles", run: runProfileList},
+	{name: "profile set", args: "<name> [-url u] [-output o]", summary: "Create or update a profile", run: runProfileSet},
+	{name: "profile use", args: "<name>", summary: "Make a profile the current one", run: runProfileUse},
+	{name: "alerts list", args: "[filters]", summary: "List alerts", run: runAlertsList},
+	{name: "alerts ack", args: "<id>...", summary: "Acknowledge alerts", run: runAlertsAck},
+	{name: "alerts resolve", args: "<id>...", summary: "Resolve alerts", run: runAlertsResolve},
+	{name: "rules apply", args: "-f <file> [-dry-run]", summary: "Apply a YAML or JSON bundle of alert rules", run: runRulesApply},
+	{name: "channels list", summary: "List the enabled notification channels", run: runChannelsList},
+	{name: "channels test", args: "<channel>", summary: "Send a test notification through a channel", run: runChannelsTest},
+	{name: "dlq list", args: "[-page n]", summary: "List the events in the dead letter queue", run: runDLQList},
+	{name: "dlq retry", args: "<id>... | -all [-type t] [-dry-run]", summary: "Retry dead-lettered events", run: runDLQRetry},
+}
+
+// newFlags creates the flag set of a command.
+func newFlags(name string) *flag.FlagSet {
+	return flag.NewFlagSet("alertctl "+name, flag.ContinueOnError)
+}
+
+func runLogin(ctx context.Context, a *app, args []string) error {
+	flags := newFlags("login")
+	email := flags.String("email", os.Getenv("ALERTCTL_EMAIL"), "account email")
+	password := flags.String("password", os.Getenv("ALERTCTL_PASSWORD"), "account password (default: read from stdin)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := a.requireURL(); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("email is required")
+	}
+
+	if *password == "" {
+		_, _ = fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(a.stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		*password = strings.TrimSpace(line)
+	}
+
+	resp, err := a.client.Login(ctx, *email, *password)
+	if err != nil {
+		return err
+	}
+
+	a.profile.Token = resp.AccessToken
+	if err := a.cfg.setProfile(a.profileName, a.profile); err != nil {
+		return err
+	}
+	if a.cfg.CurrentProfile == "" {
+		if err := a.cfg.useProfile(a.profileName); err != nil {
+			return err
+		}
+	}
+
+	return a.out.message("Logged in as %s (%s) on profile %s, token expires at %s",
+		resp.User.Email, resp.User.Role, a.profileName, formatTime(resp.ExpiresAt))
+}
+
+func runProfileList(_ context.Context, a *app, _ []string) error {
+	names := a.cfg.profileNames()
+
+	// Tokens are not printed
+	type profileSummary struct {
+		Name     string `json:"name"`
+		Current  bool   `json:"current"`
+		URL      string `json:"url"`
+		Output   string `json:"output,omitempty"`
+		LoggedIn bool   `json:"logged_in"`
+	}
+
+	current, _ := a.cfg.profile("")
+	summaries := make([]profileSummary, 0, len(names))
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		profile := a.cfg.Profiles[name]
+		marker := ""
+		if name == current {
+			marker = "*"
+		}
+		loggedIn := "no"
+		if profile.Token != "" {
+			loggedIn = "yes"
+		}
+		rows = append(rows, []string{marker, name, orDash(profile.URL), orDash(profile.Output), loggedIn})
+		summaries = append(summaries, profileSummary{
+			Name:     name,
+			Current:  name == current,
+			URL:      profile.URL,
+			Output:   profile.Output,
+			LoggedIn: profile.Token != "",
+		})
+	}
+
+	return a.out.print(summaries, []string{"CURRENT", "NAME", "URL", "OUTPUT", "LOGGED IN"}, rows)
+}
+
+func runProfileSet(_ context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return errors.New("profile name is required")
+	}
+	name := args[0]
+
+	flags := newFlags("profile set")
+	url := flags.String("url", "", "API base URL")
+	output := flags.String("output", "", "default output format: table or json")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *output != "" && !isValidOutput(*output) {
+		return fmt.Errorf("invalid output format %q, must be table or json", *output)
+	}
+
+	profile := a.cfg.Profiles[name]
+	if *url != "" && *url != profile.URL {
+		profile.URL = *url
+		profile.Token = ""
+	}
+	if *output != "" {
+		profile.Output = *output
+	}
+
+	if err := a.cfg.setProfile(name, profile); err != nil {
+		return err
+	}
+	if a.cfg.CurrentProfile == "" {
+		if err := a.cfg.useProfile(name); err != nil {
+			return err
+		}
+	}
+	return a.out.message("Profile %s saved", name)
+}
+
+func runProfileUse(_ context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("profile name is required")
+	}
+	if err := a.cfg.useProfile(args[0]); err != nil {
+		return err
+	}
+	return a.out.message("Using profile %s", args[0])
+}
+
+func runAlertsList(ctx context.Context, a *app, args []string) error {
+	flags := newFlags("alerts list")
+	status := flags.String("status", "", "comma-separated statuses (active, acknowledged, resolved, expired)")
+	severity := flags.String("severity", "", "comma-separated severities (critical, high, medium, low, info)")
+	source := flags.String("source", "", "alert source")
+	region := flags.String("region", "", "deployment region")
+	team := flags.String("team", "", "team ID")
+	search := flags.String("search", "", "search in title and message")
+	page := flags.Int("page", 1, "page number")
+	pageSize := flags.Int("page-size", 0, "page size (default: the server's)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := a.requireAuth(); err != nil {
+		return err
+	}
+
+	result, err := a.client.ListAlerts(ctx, client.ListAlertsParams{
+		Status:   splitList(*status),
+		Severity: splitList(*severity),
+		Source:   *source,
+		Region:   *region,
+		TeamID:   *team,
+		Search:   *search,
+		Page:     *page,
+		PageSize: *pageSize,
+	})
+	if err != nil {
+		return explain(err)
+	}
+
+	rows := make([][]string, 0, len(result.Items))
+	for _, alert := range result.Items {
+		rows = append(rows, []string{
+			alert.ID, alert.Severity, alert.Status, orDash(alert.Source),
+			truncate(alert.Title, 60), formatTime(alert.CreatedAt),
+		})
+	}
+
+	if err := a.out.print(result, []string{"ID", "SEVERITY", "STATUS", "SOURCE", "TITLE", "CREATED"}, rows); err != nil {
+		return err
+	}
+	if a.out.format == outputTable {
+		_, _ = fmt.Fprintf(a.out.w, "\nPage %d of %d (%d alerts)\n", result.CurrentPage, result.TotalPages, result.TotalItems)
+	}
+	return nil
+}
+
+func runAlertsAck(ctx context.Context, a *app, args []string) error {
+	return updateAlerts(ctx, a, args, a.client.AcknowledgeAlert, "acknowledged")
+}
+
+func runAlertsResolve(ctx context.Context, a *app, args []string) error {
+	return updateAlerts(ctx, a, args, a.client.ResolveAlert, "resolved")
+}
+
+// updateAlerts applies an update to each alert ID, stopping at the first failure.
+func updateAlerts(
+	ctx context.Context,
+	a *app,
+	ids []string,
+	update func(context.Context, string) (*dto.AlertResponse, error),
+	verb string,
+) error {
+	if len(ids) == 0 {
+		return errors.New("at least one alert ID is required")
+	}
+	if err := a.requireAuth(); err != nil {
+		return err
+	}
+
+	alerts := make([]*dto.AlertResponse, 0, len(ids))
+	rows := make([][]string, 0, len(ids))
+	for _, id := range ids {
+		alert, err := update(ctx, id)
+		if err != nil {
+			return fmt.Errorf("alert %s: %w", id, explain(err))
+		}
+		alerts = append(alerts, alert)
+		rows = append(rows, []string{alert.ID, alert.Status, truncate(alert.Title, 60)})
+	}
+
+	if a.out.format == outputTable {
+		_, _ = fmt.Fprintf(a.out.w, "%d alert(s) %s\n", len(alerts), verb)
+	}
+	return a.out.print(alerts, []string{"ID", "STATUS", "TITLE"}, rows)
+}
+
+func runRulesApply(ctx context.Context, a *app, args []string) error {
+	flags := newFlags("rules apply")
+	file := flags.String("f", "", "bundle file, YAML or JSON (- for stdin)")
+	dryRun := flags.Bool("dry-run", false, "report the changes without applying them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("a bundle file is required (-f)")
+	}
+	if err := a.requireAuth(); err != nil {
+		return err
+	}
+
+	var bundle []byte
+	var err error
+	if *file == "-" {
+		bundle, err = io.ReadAll(a.stdin)
+	} else {
+		bundle, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	contentType := "application/yaml"
+	if strings.EqualFold(filepath.Ext(*file), ".json") {
+		contentType = "application/json"
+	}
+
+	report, err := a.client.ApplyRules(ctx, bundle, contentType, *dryRun)
+	if err != nil {
+		return explain(err)
+	}
+
+	rows := make([][]string, 0, len(report.Changes))
+	for _, change := range report.Changes {
+		rows = append(rows, []string{change.Action, change.Kind, change.Name})
+	}
+
+	if err := a.out.print(report, []string{"ACTION", "KIND", "NAME"}, rows); err != nil {
+		return err
+	}
+	if a.out.format == outputTable {
+		verb := "Applied"
+		if report.DryRun {
+			verb = "Dry run"
+		}
+		_, _ = fmt.Fprintf(a.out.w, "\n%s: %d created, %d updated, %d deleted, %d unchanged\n",
+			verb, report.Created, report.Updated, report.Deleted, report.Unchanged)
+	}
+	return nil
+}
+
+func runChannelsList(ctx context.Context, a *app, _ []string) error {
+	if err := a.requireAuth(); err != nil {
+		return err
+	}
+
+	channels, err := a.client.ListNotificationChannels(ctx)
+	if err != nil {
+		return explain(err)
+	}
+
+	rows := make([][]string, 0, len(channels))
+	for _, channel := range channels {
+		rows = append(rows, []string{channel})
+	}
+	return a.out.print(channels, []string{"CHANNEL"}, rows)
+}
+
+func runChannelsTest(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("channel name is required")
+	}
+	if err := a.requireAuth(); err != nil {
+		return err
+	}
+
+	if err := a.client.TestNotificationChannel(ctx, args[0]); err != nil {
+		return explain(err)
+	}
+	return a.out.message("Test notification sent to %s", args[0])
+}
+
+func runDLQList(ctx context.Context, a *app, args []string) error {
+	flags := newFlags("dlq list")
+	page := flags.Int("page", 1, "page number")
+	pageSize := flags.Int("page-size", 0, "page size (default: the server's)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := a.requireAuth(); err != nil {
+		return err
+	}
+
+	result, err := a.client.ListFailedEvents(ctx, *page, *pageSize)
+	if err != nil {
+		return explain(err)
+	}
+
+	rows := make([][]string, 0, len(result.Items))
+	for _, event := range result.Items {
+		rows = append(rows, []string{
+			event.ID, event.EventType, event.Status, strconv.Itoa(event.Retries),
+			formatTime(event.FailedAt), truncate(orDash(event.LastError), 60),
+		})
+	}
+	return a.out.print(result, []string{"ID", "TYPE", "STATUS", "RETRIES", "FAILED", "LAST ERROR"}, rows)
+}
+
+func runDLQRetry(ctx context.Context, a *app, args []string) error {
+	flags := newFlags("dlq retry")
+	all := flags.Bool("all", false, "retry every pending event")
+	eventType := flags.String("type", "", "with -all, only retry events of this type")
+	dryRun := flags.Bool("dry-run", false, "with -all, report the events without retrying them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	ids := flags.Args()
+	if *all == (len(ids) > 0) {
+		return errors.New("either event IDs or -all is required")
+	}
+	if err := a.requireAuth(); err != nil {
+		return err
+	}
+
+	if *all {
+		result, err := a.client.RetryFailedEvents(ctx, dto.BulkFailedEventsRequest{
+			EventType: *eventType,
+			DryRun:    *dryRun,
+		})
+		if err != nil {
+			return explain(err)
+		}
+		if a.out.format == outputJSON {
+			return a.out.print(result, nil, nil)
+		}
+		if result.DryRun {
+			return a.out.message("%d event(s) would be retried", result.Affected)
+		}
+		return a.out.message("%d event(s) retried", result.Affected)
+	}
+
+	for _, id := range ids {
+		if err := a.client.RetryFailedEvent(ctx, id); err != nil {
+			return fmt.Errorf("event %s: %w", id, explain(err))
+		}
+	}
+	return a.out.message("%d event(s) retried", len(ids))
+}
+
+// splitList parses a comma-separated flag value.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+// defaultProfile is the profile used when none is selected.
+const defaultProfile = "default"
+
+// Profile holds the settings of an environment, e.g. staging or production.
+type Profile struct {
+	URL    string `mapstructure:"url"`
+	Token  string `mapstructure:"token"`
+	Output string `mapstructure:"output"`
+}
+
+// cliConfig is the CLI configuration file, holding a profile per environment.
+type cliConfig struct {
+	path  string
+	viper *viper.Viper
+
+	CurrentProfile string             `mapstructure:"current_profile"`
+	Profiles       map[string]Profile `mapstructure:"profiles"`
+}
+
+// configPath returns the path of the configuration file: ALERTCTL_CONFIG,
+// or ~/.alertctl.yaml.
+func configPath() (string, error) {
+	if path := os.Getenv("ALERTCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".alertctl.yaml"), nil
+}
+
+// loadConfig reads the configuration file. A missing file is an empty configuration.
+func loadConfig() (*cliConfig, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+
+	cfg := &cliConfig{path: path, viper: v}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]Profile)
+	}
+
+	return cfg, nil
+}
+
+// profile returns the named profile, or the current one if name is empty.
+func (c *cliConfig) profile(name string) (string, Profile) {
+	if name == "" {
+		name = c.CurrentProfile
+	}
+	if name == "" {
+		name = defaultProfile
+	}
+	return name, c.Profiles[name]
+}
+
+// profileNames returns the names of the profiles, sorted.
+func (c *cliConfig) profileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setProfile stores a profile and saves the configuration file.
+func (c *cliConfig) setProfile(name string, profile Profile) error {
+	c.Profiles[name] = profile
+	c.viper.Set("profiles."+name+".url", profile.URL)
+	c.viper.Set("profiles."+name+".token", profile.Token)
+	c.viper.Set("profiles."+name+".output", profile.Output)
+	return c.save()
+}
+
+// useProfile makes a profile the current one and saves the configuration file.
+func (c *cliConfig) useProfile(name string) error {
+	if _, ok := c.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+	c.CurrentProfile = name
+	c.viper.Set("current_profile", name)
+	return c.save()
+}
+
+// save writes the configuration file, readable by its owner only as it holds tokens.
+func (c *cliConfig) save() error {
+	if err := c.viper.WriteConfigAs(c.path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.path, err)
+	}
+	return os.Chmod(c.path, 0o600)
+}
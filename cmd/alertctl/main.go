@@ -0,0 +1,158 @@
+// Command alertctl manages the alerting system from the command line: alerts,
+// rules, notification channels and the dead letter queue, across the
+// environments configured as profiles in ~/.alertctl.yaml.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/pkg/client"
+)
+
+// requestTimeout bounds the time a command waits for the API.
+const requestTimeout = time.Minute
+
+// app is the state shared by the commands.
+type app struct {
+	cfg         *cliConfig
+	profileName string
+	profile     Profile
+	client      *client.Client
+	out         *printer
+	stdin       io.Reader
+}
+
+// command is a subcommand of the CLI, such as "alerts list".
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			_, _ = fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags and runs the selected command.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	global := flag.NewFlagSet("alertctl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	profileName := global.String("profile", os.Getenv("ALERTCTL_PROFILE"), "profile to use (default: the current profile)")
+	baseURL := global.String("url", os.Getenv("ALERTCTL_URL"), "API base URL, overriding the profile's")
+	token := global.String("token", os.Getenv("ALERTCTL_TOKEN"), "access token, overriding the profile's")
+	output := global.String("o", "", "output format: table or json (default: the profile's, or table)")
+	global.Usage = func() { usage(stderr, global) }
+
+	if err := global.Parse(args); err != nil {
+		return err
+	}
+
+	cmd, cmdArgs := findCommand(global.Args())
+	if cmd == nil {
+		usage(stderr, global)
+		return flag.ErrHelp
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	a := &app{cfg: cfg, stdin: stdin}
+	a.profileName, a.profile = cfg.profile(*profileName)
+	if *baseURL != "" {
+		a.profile.URL = *baseURL
+	}
+	if *token != "" {
+		a.profile.Token = *token
+	}
+
+	format := *output
+	if format == "" {
+		format = a.profile.Output
+	}
+	if format == "" {
+		format = outputTable
+	}
+	if !isValidOutput(format) {
+		return fmt.Errorf("invalid output format %q, must be table or json", format)
+	}
+	a.out = &printer{w: stdout, format: format}
+	a.client = client.New(a.profile.URL, client.WithToken(a.profile.Token))
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	return cmd.run(ctx, a, cmdArgs)
+}
+
+// findCommand returns the command named by the first one or two arguments,
+// and the remaining arguments.
+func findCommand(args []string) (*command, []string) {
+	for i := range commands {
+		name := strings.Fields(commands[i].name)
+		if len(args) >= len(name) && strings.Join(args[:len(name)], " ") == commands[i].name {
+			return &commands[i], args[len(name):]
+		}
+	}
+	return nil, nil
+}
+
+// usage writes the help of the CLI.
+func usage(w io.Writer, global *flag.FlagSet) {
+	_, _ = fmt.Fprintln(w, "Usage: alertctl [global flags] <command> [flags] [args]")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(w, "  %-46s %s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.summary)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Global flags:")
+	global.PrintDefaults()
+}
+
+// requireURL checks that the API URL is configured.
+func (a *app) requireURL() error {
+	if a.profile.URL == "" {
+		return fmt.Errorf("no API URL for profile %q: run alertctl profile set %s -url <url>", a.profileName, a.profileName)
+	}
+	return nil
+}
+
+// requireAuth checks that the API URL and an access token are configured.
+func (a *app) requireAuth() error {
+	if err := a.requireURL(); err != nil {
+		return err
+	}
+	if a.profile.Token == "" {
+		return fmt.Errorf("not logged in to profile %q: run alertctl login", a.profileName)
+	}
+	return nil
+}
+
+// explain adds a hint to errors the user can act on.
+func explain(err error) error {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 401 {
+		return fmt.Errorf("%w (the access token may have expired: run alertctl login)", err)
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// apiRequest is what the test API saw of a request.
+type apiRequest struct {
+	method string
+	path   string
+	query  string
+	body   map[string]interface{}
+}
+
+// testAPI starts an API answering every request with body, records the
+// requests it receives and points the CLI at a fresh configuration file.
+func testAPI(t *testing.T, body string) (string, *[]apiRequest) {
+	t.Helper()
+
+	t.Setenv("ALERTCTL_CONFIG", filepath.Join(t.TempDir(), "alertctl.yaml"))
+	for _, name := range []string{"ALERTCTL_PROFILE", "ALERTCTL_URL", "ALERTCTL_TOKEN", "ALERTCTL_EMAIL", "ALERTCTL_PASSWORD"} {
+		t.Setenv(name, "")
+	}
+
+	var requests []apiRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := apiRequest{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			_ = json.Unmarshal(data, &req.body)
+		}
+		requests = append(requests, req)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, &requests
+}
+
+func TestFindCommand(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		wantName string
+		wantArgs []string
+	}{
+		{name: "single word", args: []string{"login", "-email", "a@example.com"}, wantName: "login", wantArgs: []string{"-email", "a@example.com"}},
+		{name: "two words", args: []string{"alerts", "ack", "1", "2"}, wantName: "alerts ack", wantArgs: []string{"1", "2"}},
+		{name: "no arguments", args: []string{"channels", "list"}, wantName: "channels list", wantArgs: []string{}},
+		{name: "group only", args: []string{"alerts"}},
+		{name: "unknown command", args: []string{"alerts", "delete", "1"}},
+		{name: "nothing", args: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, args := findCommand(tc.args)
+			if tc.wantName == "" {
+				assert.Nil(t, cmd)
+				return
+			}
+			require.NotNil(t, cmd)
+			assert.Equal(t, tc.wantName, cmd.name)
+			assert.Equal(t, tc.wantArgs, args)
+		})
+	}
+}
+
+func TestRun_ParsesCommands(t *testing.T) {
+	testCases := []struct {
+		name      string
+		args      []string
+		response  string
+		wantPath  string
+		wantQuery string
+		wantBody  map[string]interface{}
+	}{
+		{
+			name:      "alerts list filters",
+			args:      []string{"alerts", "list", "-status", "active, acknowledged", "-severity", "critical", "-page", "2", "-page-size", "10"},
+			response:  `{"items":[],"current_page":2,"total_pages":2}`,
+			wantPath:  "/api/v1/alerts",
+			wantQuery: "page=2&page_size=10&severity=critical&status=active&status=acknowledged",
+		},
+		{
+			name:     "alerts ack each ID",
+			args:     []string{"alerts", "ack", "1"},
+			response: `{"id":"1","status":"acknowledged"}`,
+			wantPath: "/api/v1/alerts/1/acknowledge",
+		},
+		{
+			name:      "dlq list page",
+			args:      []string{"dlq", "list", "-page", "3"},
+			response:  `{"items":[]}`,
+			wantPath:  "/api/v1/admin/failed-events",
+			wantQuery: "page=3",
+		},
+		{
+			name:     "dlq retry all",
+			args:     []string{"dlq", "retry", "-all", "-type", "alert.created", "-dry-run"},
+			response: `{"affected":2,"dry_run":true}`,
+			wantPath: "/api/v1/admin/failed-events/retry",
+			wantBody: map[string]interface{}{"event_type": "alert.created", "dry_run": true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url, requests := testAPI(t, tc.response)
+
+			var stdout bytes.Buffer
+			args := append([]string{"-url", url, "-token", "token", "-o", "json"}, tc.args...)
+			require.NoError(t, run(context.Background(), args, strings.NewReader(""), &stdout, io.Discard))
+
+			require.Len(t, *requests, 1)
+			assert.Equal(t, tc.wantPath, (*requests)[0].path)
+			assert.Equal(t, tc.wantQuery, (*requests)[0].query)
+			for key, value := range tc.wantBody {
+				assert.Equal(t, value, (*requests)[0].body[key], key)
+			}
+			assert.True(t, json.Valid(stdout.Bytes()), stdout.String())
+		})
+	}
+}
+
+func TestRun_RejectsInvalidArguments(t *testing.T) {
+	testCases := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "unknown output format", args: []string{"-o", "yaml", "alerts", "list"}, wantErr: `invalid output format "yaml", must be table or json`},
+		{name: "unknown flag", args: []string{"alerts", "list", "-owner", "me"}, wantErr: "flag provided but not defined: -owner"},
+		{name: "alerts ack without IDs", args: []string{"alerts", "ack"}, wantErr: "at least one alert ID is required"},
+		{name: "dlq retry without IDs", args: []string{"dlq", "retry"}, wantErr: "either event IDs or -all is required"},
+		{name: "dlq retry with IDs and -all", args: []string{"dlq", "retry", "-all", "e1"}, wantErr: "either event IDs or -all is required"},
+		{name: "rules apply without file", args: []string{"rules", "apply"}, wantErr: "a bundle file is required (-f)"},
+		{name: "channels test without channel", args: []string{"channels", "test"}, wantErr: "channel name is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url, requests := testAPI(t, `{}`)
+
+			args := append([]string{"-url", url, "-token", "token"}, tc.args...)
+			err := run(context.Background(), args, strings.NewReader(""), io.Discard, io.Discard)
+			assert.EqualError(t, err, tc.wantErr)
+			assert.Empty(t, *requests)
+		})
+	}
+}
+
+func TestRun_RequiresLogin(t *testing.T) {
+	url, requests := testAPI(t, `{}`)
+
+	err := run(context.Background(), []string{"-url", url, "alerts", "list"}, strings.NewReader(""), io.Discard, io.Discard)
+	assert.EqualError(t, err, `not logged in to profile "default": run alertctl login`)
+	assert.Empty(t, *requests)
+}
+
+func TestRun_UnknownCommandPrintsUsage(t *testing.T) {
+	testAPI(t, `{}`)
+
+	var stderr bytes.Buffer
+	err := run(context.Background(), []string{"alerts", "delete"}, strings.NewReader(""), io.Discard, &stderr)
+	assert.ErrorIs(t, err, flag.ErrHelp)
+	assert.Contains(t, stderr.String(), "Usage: alertctl")
+	assert.Contains(t, stderr.String(), "alerts list")
+}
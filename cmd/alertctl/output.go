@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// printer writes command results as tables or JSON.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// isValidOutput reports whether format is a supported output format.
+func isValidOutput(format string) bool {
+	return format == outputTable || format == outputJSON
+}
+
+// print writes a result: as indented JSON, or as a table of the given rows.
+func (p *printer) print(result interface{}, header []string, rows [][]string) error {
+	if p.format == outputJSON {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// message writes a confirmation, as {"message": ...} in JSON output.
+func (p *printer) message(format string, args ...interface{}) error {
+	text := fmt.Sprintf(format, args...)
+	if p.format == outputJSON {
+		return p.print(map[string]string{"message": text}, nil, nil)
+	}
+	_, err := fmt.Fprintln(p.w, text)
+	return err
+}
+
+// formatTime formats a timestamp for tables.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// truncate shortens text to max characters for tables.
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
+
+// orDash returns "-" for empty table cells.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
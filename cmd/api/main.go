@@ -22,9 +22,13 @@ package main
 
 import (
 	"context"
-
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 	"time"
 
@@ -34,13 +38,23 @@ import (
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/database"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/enrichment"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/messaging"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/prober"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/webhook"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/worker"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/handler"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/router"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/websocket"
 
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/event/handlers"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/circuitbreaker"
 	infranotification "github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/notification"
 )
@@ -58,10 +72,18 @@ func main() {
 	// Setup logger
 	setupLogger(cfg)
 
+	// Fail fast on a configuration that is incomplete or insecure
+	if err := cfg.Validate(); err != nil {
+		log.Fatal().Err(err).Msg("Invalid configuration")
+	}
+	log.Info().Interface("config", cfg.Redacted()).Msg("Effective configuration")
+
 	log.Info().
 		Str("app", cfg.App.Name).
 		Str("version", cfg.App.Version).
 		Str("env", cfg.App.Env).
+		Str("region", cfg.Deployment.Region).
+		Str("cluster", cfg.Deployment.Cluster).
 		Msg("Starting application...")
 
 	// Initialize PostgreSQL
@@ -84,8 +106,14 @@ func main() {
 		ServiceName:    cfg.App.Name,
 		ServiceVersion: cfg.App.Version,
 		Environment:    cfg.App.Env,
-		JaegerEndpoint: cfg.Tracing.JaegerEndpoint,
+		Region:         cfg.Deployment.Region,
+		Cluster:        cfg.Deployment.Cluster,
 		Enabled:        cfg.Tracing.Enabled,
+		Exporter:       cfg.Tracing.Exporter,
+		Endpoint:       cfg.Tracing.CollectorEndpoint(),
+		Insecure:       cfg.Tracing.Insecure,
+		Headers:        cfg.Tracing.Headers,
+		SampleRatio:    cfg.Tracing.SampleRatio,
 	})
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to initialize tracing, continuing without it")
@@ -99,73 +127,423 @@ func main() {
 	}
 
 	// Initialize repositories
-	userRepo := database.NewPostgresUserRepository(db)
-	alertRepo := database.NewPostgresAlertRepository(db)
-	cacheRepo := database.NewRedisCacheRepository(redisClient)
+	var cacheRepo repository.CacheRepository = database.NewRedisCacheRepository(redisClient)
+
+	// Serve users and revoked tokens from memory (optional)
+	var cacheRelay *messaging.RedisPubSubRelay
+	if cfg.Cache.Local.Enabled {
+		localCache := database.NewLocalCache(cfg.Cache.Local.Size, cfg.Cache.Local.TTL)
+		cacheRelay = messaging.NewRedisPubSubRelay(redisClient.GetClient(), cfg.Cache.Local.Channel, entity.NewID().String())
+		if err := localCache.SetRelay(context.Background(), cacheRelay); err != nil {
+			log.Error().Err(err).Msg("Failed to start cache invalidation relay, local cache disabled")
+		} else {
+			cacheRepo = database.NewTieredCacheRepository(cacheRepo, localCache, cfg.Cache.Local.Prefixes)
+			log.Info().Dur("ttl", cfg.Cache.Local.TTL).Msg("Local cache enabled")
+		}
+	}
+
+	// Users are read on every authenticated request, so they are cached
+	userRepo := database.NewCachedUserRepository(database.NewPostgresUserRepository(db), cacheRepo)
+	alertRepo := database.NewCachedAlertRepository(database.NewPostgresAlertRepository(db), cacheRepo)
+	webhookSourceRepo := database.NewPostgresWebhookSourceRepository(db)
+	heartbeatRepo := database.NewPostgresHeartbeatRepository(db)
+	sourceRepo := database.NewPostgresSourceRepository(db)
+	checkRepo := database.NewPostgresCheckRepository(db)
+	incidentRepo := database.NewPostgresIncidentRepository(db)
+	postmortemRepo := database.NewPostgresPostmortemRepository(db)
+	slaRepo := database.NewPostgresSLARepository(db)
+	maintenanceRepo := database.NewPostgresMaintenanceRepository(db)
+	topologyRepo := database.NewPostgresSourceDependencyRepository(db)
+	webhookSecretRepo := database.NewPostgresWebhookSecretRepository(db)
+	sessionRepo := database.NewRedisSessionRepository(redisClient)
+	tokenRevocationRepo := database.NewRedisTokenRevocationRepository(redisClient)
+	rateLimitRepo := database.NewRedisRateLimitRepository(redisClient)
+	quotaRepo := database.NewRedisQuotaRepository(redisClient)
+	teamRepo := database.NewPostgresTeamRepository(db)
+	alertRuleRepo := database.NewPostgresAlertRuleRepository(db)
+	alertViewRepo := database.NewPostgresAlertViewRepository(db)
+	severityRuleRepo := database.NewPostgresSeverityRuleRepository(db)
+	assignmentRuleRepo := database.NewPostgresAssignmentRuleRepository(db)
+	subscriptionRepo := database.NewPostgresSubscriptionRepository(db)
+	userIdentityRepo := database.NewPostgresUserIdentityRepository(db)
+	templateRepo := database.NewPostgresNotificationTemplateRepository(db)
+	routeRepo := database.NewPostgresNotificationRouteRepository(db)
+	failedEventRepo := database.NewPostgresFailedEventRepository(db)
+	txManager := database.NewPostgresTxManager(db)
 
 	// Initialize WebSocket hub
 	wsHub := websocket.NewHub()
+	slowClientPolicy := websocket.SlowClientPolicy(cfg.WebSocket.SlowClientPolicy)
+	if !slowClientPolicy.IsValid() {
+		log.Fatal().Str("policy", cfg.WebSocket.SlowClientPolicy).Msg("Invalid WebSocket slow client policy")
+	}
+	wsHub.SetSendPolicy(cfg.WebSocket.SendBufferSize, slowClientPolicy)
 	go wsHub.Run()
 	log.Info().Msg("WebSocket hub started")
 
+	// Relay WebSocket broadcasts between instances (optional)
+	var wsRelay *messaging.RedisPubSubRelay
+	if cfg.WebSocket.Relay.Enabled {
+		nodeID := entity.NewID().String()
+		wsRelay = messaging.NewRedisPubSubRelay(redisClient.GetClient(), cfg.WebSocket.Relay.Channel, nodeID)
+		if err := wsHub.SetRelay(context.Background(), wsRelay); err != nil {
+			log.Error().Err(err).Msg("Failed to start WebSocket relay, broadcasts stay on this instance")
+		} else {
+			log.Info().Str("node", nodeID).Msg("WebSocket relay enabled")
+		}
+	}
+
 	// Initialize Event Bus
-	eventBus := messaging.NewRedisStreamBus(redisClient.GetClient(), cfg.EventBus.ConsumerID)
-	retryConfig := messaging.RetryConfig{
-		MaxRetries:     cfg.EventBus.MaxRetries,
-		InitialBackoff: cfg.EventBus.InitialBackoff,
-		MaxBackoff:     cfg.EventBus.MaxBackoff,
-		Multiplier:     cfg.EventBus.Multiplier,
-		Jitter:         true,
+	eventBus, err := messaging.NewBus(cfg.EventBus, messaging.BusOptions{
+		RedisClient: redisClient.GetClient(),
+		Region:      cfg.Deployment.Region,
+		Cluster:     cfg.Deployment.Cluster,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Str("driver", cfg.EventBus.Driver).Msg("Failed to create event bus")
 	}
+	retryConfig := messaging.NewRetryConfig(cfg.EventBus)
 	retryableBus := messaging.NewRetryableBus(eventBus, retryConfig)
-	log.Info().Msg("Event bus initialized")
+	// Replay needs a bus that retains events; the in-memory bus does not
+	eventReader, _ := eventBus.(event.Reader)
+	lagInspector, _ := eventBus.(event.LagInspector)
+	log.Info().Str("driver", cfg.EventBus.Driver).Msg("Event bus initialized")
 
 	// Initialize circuit breaker registry
 	cbRegistry := circuitbreaker.NewRegistry()
 
 	// Initialize notification service
-	var notificationService *service.NotificationService
+	var notifiers []notification.Notifier
+	var slackResponder service.SlackResponder
 	if cfg.Notification.Slack.Enabled {
 		slackNotifier := infranotification.NewSlackNotifier(cfg.Notification.Slack, cfg.Notification.Timeout)
+		slackResponder = slackNotifier
 		slackCB := cbRegistry.GetWithConfig(circuitbreaker.Config{
 			Name:             "slack",
 			MaxFailures:      5,
 			Timeout:          30 * time.Second,
 			HalfOpenRequests: 3,
 		})
-		resilientSlack := infranotification.NewResilientNotifier(slackNotifier, slackCB)
-		notificationService = service.NewNotificationService(cfg.Notification, resilientSlack)
+		notifiers = append(notifiers, infranotification.NewResilientNotifier(slackNotifier, slackCB))
 		log.Info().Msg("Slack notifications enabled")
 	} else {
-		notificationService = service.NewNotificationService(cfg.Notification)
 		log.Info().Msg("Slack notifications disabled")
 	}
+	var telegramResponder service.TelegramResponder
+	if cfg.Notification.Telegram.Enabled {
+		telegramNotifier := infranotification.NewTelegramNotifier(cfg.Notification.Telegram, cfg.Notification.Timeout)
+		telegramResponder = telegramNotifier
+		telegramCB := cbRegistry.GetWithConfig(circuitbreaker.Config{
+			Name:             "telegram",
+			MaxFailures:      5,
+			Timeout:          30 * time.Second,
+			HalfOpenRequests: 3,
+		})
+		notifiers = append(notifiers, infranotification.NewResilientNotifier(telegramNotifier, telegramCB))
+		log.Info().Msg("Telegram notifications enabled")
+	}
+	notificationService := service.NewNotificationService(cfg.Notification, notifiers...)
+
+	// Render notification content with per-channel templates
+	templateService := service.NewNotificationTemplateService(templateRepo, cfg.Notification.AlertURL)
+	notificationService.SetTemplates(templateService)
+
+	// Deliver notifications through the routing tree
+	routeService := service.NewRouteService(routeRepo)
+	notificationService.SetRoutes(routeService)
+
+	// Apply per-channel quiet hours and digest mode
+	quietHours, err := quietHoursSchedules(cfg.Notification.QuietHours)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid quiet hours configuration")
+	}
+	notificationService.SetQuietHours(cacheRepo, quietHours...)
+
+	batches, err := notificationBatches(cfg.Notification.Batching)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid notification batching configuration")
+	}
+	notificationService.SetBatching(cacheRepo, batches...)
+
+	var notificationQueueWorker *worker.NotificationQueueWorker
+	if notificationService.HasQueues() {
+		log.Info().
+			Int("quiet_hours", len(quietHours)).
+			Int("batched", len(batches)).
+			Msg("Notification queues enabled")
+		notificationQueueWorker = worker.NewNotificationQueueWorker(notificationService, time.Minute)
+		if err := notificationQueueWorker.Start(); err != nil {
+			log.Error().Err(err).Msg("Failed to start notification queue worker")
+		}
+	}
+
+	// Initialize digest service for events missed while users are offline
+	mailer := infranotification.NewSMTPMailer(cfg.Notification.Email)
+	digestService := service.NewDigestService(cacheRepo, userRepo, cfg.Notification.Digest, mailer)
+
+	var digestWorker *worker.DigestWorker
+	if mailer.IsEnabled() {
+		log.Info().Msg("Email digests enabled")
+		digestWorker = worker.NewDigestWorker(digestService, cfg.Notification.Digest.EmailInterval)
+		if err := digestWorker.Start(); err != nil {
+			log.Error().Err(err).Msg("Failed to start digest worker")
+		}
+	}
+
+	// Periodic jobs, run by one instance at a time. The router registers
+	// the jobs of its services, and the scheduler starts once it is set up.
+	scheduler, err := worker.NewScheduler(cacheRepo, cfg.EventBus.ConsumerID, cfg.Scheduler.Schedules)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid scheduler configuration")
+	}
+	// Recount the alert counters behind statistics periodically
+	scheduler.Register(worker.JobStatisticsRebuild,
+		valueobject.EveryInterval(cfg.Alerts.StatisticsRebuildInterval), worker.StatisticsRebuildJob(alertRepo))
+	// Purge alerts kept in the trash past the retention period
+	scheduler.Register(worker.JobTrashPurge,
+		valueobject.EveryInterval(cfg.Alerts.TrashPurgeInterval), worker.TrashPurgeJob(alertRepo, cfg.Alerts.TrashRetention))
+
+	// Export the backlog of the event bus consumer groups
+	var lagWorker *worker.LagWorker
+	if lagInspector != nil {
+		lagWorker = worker.NewLagWorker(lagInspector, cfg.EventBus.LagInterval)
+		if err := lagWorker.Start(); err != nil {
+			log.Error().Err(err).Msg("Failed to start consumer lag worker")
+		}
+	}
+
+	// Push alert events to outbound webhook subscriptions
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, webhook.NewHTTPPoster(), cfg.Webhooks.Outbound)
+	subscriptionWorker := worker.NewSubscriptionWorker(subscriptionService,
+		cfg.Webhooks.Outbound.DispatchInterval, cfg.Webhooks.Outbound.Workers)
+	if err := subscriptionWorker.Start(); err != nil {
+		log.Error().Err(err).Msg("Failed to start subscription worker")
+	}
 
 	// Initialize Event Worker
-	eventWorker := worker.NewEventWorker(retryableBus, notificationService)
+	eventWorker := worker.NewEventWorker(retryableBus)
+	eventWorker.SetIdempotency(cacheRepo, cfg.EventBus.IdempotencyTTL)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	notificationHandler.SetSilencer(service.NewMaintenanceService(maintenanceRepo))
+	eventWorker.RegisterHandler("notification", notificationHandler)
+	eventWorker.RegisterHandler("cache", handlers.NewCacheInvalidationHandler(alertRepo))
+	eventWorker.RegisterHandler("subscription", handlers.NewSubscriptionHandler(subscriptionService))
+	// Mirror alerts as incidents of on-call platforms
+	forwardingService := service.NewForwardingService(alertRepo,
+		infranotification.NewOpsgenieNotifier(cfg.Notification.Opsgenie, cfg.Notification.Timeout),
+		infranotification.NewSplunkOnCallNotifier(cfg.Notification.SplunkOnCall, cfg.Notification.Timeout))
+	if forwardingService.IsEnabled() {
+		forwardingHandler := handlers.NewForwardingHandler(forwardingService)
+		forwardingHandler.SetSilencer(service.NewMaintenanceService(maintenanceRepo))
+		eventWorker.RegisterHandler("forwarding", forwardingHandler)
+	}
+	// File and resolve issue tracker tickets of alerts
+	ticketService := service.NewTicketService(alertRepo,
+		infranotification.NewJiraClient(cfg.Notification.Jira, cfg.Notification.Timeout), cfg.Notification.AlertURL)
+	if ticketService.IsEnabled() {
+		eventWorker.RegisterHandler("ticket", handlers.NewTicketHandler(ticketService))
+	}
+	// Keep the status page components in line with the alerts of their sources
+	statusPageComponents, err := valueobject.NewStatusPageComponents(cfg.Notification.Statuspage.Components)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid status page components configuration")
+	}
+	statusPageService := service.NewStatusPageService(alertRepo, cacheRepo,
+		infranotification.NewStatuspageClient(cfg.Notification.Statuspage, cfg.Notification.Timeout),
+		statusPageComponents, cfg.Notification.Statuspage.PageID)
+	if statusPageService.IsEnabled() {
+		eventWorker.RegisterHandler("statuspage", handlers.NewStatusPageHandler(statusPageService))
+		scheduler.Register(worker.JobStatusPageSync,
+			valueobject.EveryInterval(cfg.Notification.Statuspage.SyncInterval), worker.StatusPageSyncJob(statusPageService))
+	}
 	if err := eventWorker.Start(); err != nil {
 		log.Error().Err(err).Msg("Failed to start event worker")
 	}
 
 	// Initialize Dead Letter Processor
-	deadLetterProcessor := worker.NewDeadLetterProcessor(retryableBus, cacheRepo)
+	failedEventService := service.NewFailedEventService(failedEventRepo, retryableBus)
+	deadLetterProcessor := worker.NewDeadLetterProcessor(retryableBus, failedEventService)
 	if err := deadLetterProcessor.Start(); err != nil {
 		log.Error().Err(err).Msg("Failed to start dead letter processor")
 	}
 
+	// Initialize federation bridge (optional)
+	var federationBridge *worker.FederationBridge
+	var federationClient *database.RedisClient
+	if cfg.Federation.Enabled && cfg.EventBus.Driver != messaging.DriverRedisStream {
+		log.Error().Str("driver", cfg.EventBus.Driver).Msg("Federation requires the redis-stream event bus, federation disabled")
+	} else if cfg.Federation.Enabled {
+		federationClient, err = database.NewRedisClient(&cfg.Federation.Target)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to connect to federation target, federation disabled")
+		} else {
+			sourceBus := messaging.NewRedisStreamBus(redisClient.GetClient(), cfg.EventBus.ConsumerID)
+			sourceBus.SetOrigin(cfg.Deployment.Region, cfg.Deployment.Cluster)
+			sourceBus.SetClaim(cfg.EventBus.ClaimMinIdle, cfg.EventBus.ClaimInterval)
+			sourceBus.SetRetryPolicy(retryConfig, cfg.EventBus.DeadLetterAfter)
+			sourceBus.SetWorkers(cfg.EventBus.Workers, cfg.EventBus.OrderedEventTypes)
+			targetBus := messaging.NewRetryableBus(
+				messaging.NewRedisStreamBus(federationClient.GetClient(), cfg.EventBus.ConsumerID),
+				retryConfig,
+			)
+			federationBridge = worker.NewFederationBridge(sourceBus, targetBus, cfg.Federation, cfg.Deployment.Region, cfg.Deployment.Cluster)
+			if err := federationBridge.Start(); err != nil {
+				log.Error().Err(err).Msg("Failed to start federation bridge")
+			}
+		}
+	}
+
+	// Alert visibility restrictions
+	visibility, err := visibilityPolicy(cfg.Visibility)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid visibility configuration")
+	}
+
+	// List endpoint page size limits
+	pagination, err := valueobject.NewPaginationPolicy(cfg.Pagination.DefaultPageSize, cfg.Pagination.MaxPageSize)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid pagination configuration")
+	}
+
+	// Feature flag defaults and deprecated endpoints
+	features, err := valueobject.NewFeatureDefaults(cfg.Features.Flags)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid feature flag configuration")
+	}
+
+	deprecations, err := deprecatedEndpoints(cfg.Deprecation)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid deprecation configuration")
+	}
+
+	// API rate limits
+	rateLimits, err := rateLimitPolicy(cfg.RateLimit)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid rate limit configuration")
+	}
+
+	// Daily quotas of teams and webhook sources
+	quotas, err := quotaPolicy(cfg.Quota)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid quota configuration")
+	}
+	quotaService := service.NewQuotaService(quotaRepo, quotas)
+
+	// Account lockout after repeated failed logins
+	lockout, err := valueobject.NewLockoutPolicy(cfg.Lockout.MaxAttempts,
+		cfg.Lockout.BaseDelay, cfg.Lockout.MaxDelay, cfg.Lockout.ResetAfter)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid lockout configuration")
+	}
+
+	// Detection of alerts that keep firing and resolving
+	flapping, err := valueobject.NewFlappingPolicy(cfg.Alerts.Flapping.Threshold,
+		cfg.Alerts.Flapping.Window, cfg.Alerts.Flapping.Dampening)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid flapping configuration")
+	}
+
+	// Grouping of new alerts into incidents
+	incidentCorrelation, err := valueobject.NewIncidentCorrelationPolicy(
+		cfg.Alerts.Incidents.Correlation.GroupBy,
+		entity.AlertSeverity(cfg.Alerts.Incidents.Correlation.MinSeverity))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid incident correlation configuration")
+	}
+
+	// Networks allowed to use the webhook and admin routes
+	networkACLs, err := networkACLPolicy(cfg.NetworkACL)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid network ACL configuration")
+	}
+
+	// Enrichers adding context to new alerts
+	enrichers, err := enrichmentPipeline(cfg.Enrichment, cacheRepo)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid enrichment configuration")
+	}
+	if enrichers.Len() > 0 {
+		log.Info().Int("enrichers", enrichers.Len()).Msg("Alert enrichment enabled")
+	}
+
+	// The check worker gets its service from the router and starts once it
+	// is set up
+	checkWorker := worker.NewCheckWorker(cfg.Alerts.Checks.ScheduleInterval, cfg.Alerts.Checks.Workers)
+
+	// The revocation worker gets the auth service from the router too
+	revocationWorker := worker.NewRevocationWorker(cfg.JWT.RevocationRefreshInterval)
+
 	// Setup router with dependencies
 	app := router.Setup(router.Dependencies{
 		Config:              cfg,
 		UserRepo:            userRepo,
 		AlertRepo:           alertRepo,
+		WebhookSourceRepo:   webhookSourceRepo,
+		WebhookSecretRepo:   webhookSecretRepo,
+		HeartbeatRepo:       heartbeatRepo,
+		SourceRepo:          sourceRepo,
+		CheckRepo:           checkRepo,
+		IncidentRepo:        incidentRepo,
+		PostmortemRepo:      postmortemRepo,
+		SLARepo:             slaRepo,
+		MaintenanceRepo:     maintenanceRepo,
+		TopologyRepo:        topologyRepo,
 		CacheRepo:           cacheRepo,
+		SessionRepo:         sessionRepo,
+		TokenRevocationRepo: tokenRevocationRepo,
+		RateLimitRepo:       rateLimitRepo,
+		TeamRepo:            teamRepo,
+		AlertRuleRepo:       alertRuleRepo,
+		AlertViewRepo:       alertViewRepo,
+		SeverityRuleRepo:    severityRuleRepo,
+		AssignmentRuleRepo:  assignmentRuleRepo,
+		UserIdentityRepo:    userIdentityRepo,
+		TxManager:           txManager,
 		DBHealthCheck:       db,
 		WSHub:               wsHub,
 		EventBus:            retryableBus,
+		EventReader:         eventReader,
+		LagInspector:        lagInspector,
 		EventWorker:         eventWorker,
-		DeadLetterProcessor: deadLetterProcessor,
+		Scheduler:           scheduler,
+		CheckWorker:         checkWorker,
+		RevocationWorker:    revocationWorker,
+		CheckProber:         prober.NewHTTPProber(),
+		FailedEventService:  failedEventService,
+		DigestService:       digestService,
+		TemplateService:     templateService,
+		NotificationService: notificationService,
+		RouteService:        routeService,
+		SubscriptionService: subscriptionService,
+		TicketService:       ticketService,
+		StatusPageService:   statusPageService,
+		VoiceCaller:         infranotification.NewTwilioCaller(cfg.Notification.Twilio, cfg.Notification.Timeout),
+		SlackResponder:      slackResponder,
+		TelegramResponder:   telegramResponder,
+		QuotaService:        quotaService,
+		Mailer:              mailer,
+		Visibility:          visibility,
+		Pagination:          pagination,
+		Features:            features,
+		Deprecations:        deprecations,
+		RateLimits:          rateLimits,
+		Lockout:             lockout,
+		NetworkACLs:         networkACLs,
+		Flapping:            flapping,
+		IncidentCorrelation: incidentCorrelation,
+		Enrichment:          enrichers,
 	})
 
+	if err := scheduler.Start(); err != nil {
+		log.Error().Err(err).Msg("Failed to start scheduler")
+	}
+	if err := checkWorker.Start(); err != nil {
+		log.Error().Err(err).Msg("Failed to start check worker")
+	}
+	if err := revocationWorker.Start(); err != nil {
+		log.Error().Err(err).Msg("Failed to start revocation worker")
+	}
+
 	// Start server in goroutine
 	go func() {
 		log.Info().Str("address", cfg.Server.Address()).Msg("HTTP server started")
@@ -174,6 +552,22 @@ func main() {
 		}
 	}()
 
+	// Serve metrics on their own port, if configured
+	var metricsServer *http.Server
+	if addr := cfg.Server.MetricsAddress(); addr != "" {
+		metricsServer = &http.Server{
+			Addr:              addr,
+			Handler:           handler.MetricsHTTPHandler(cfg.Deployment.Labels()),
+			ReadHeaderTimeout: cfg.Server.ReadTimeout,
+		}
+		go func() {
+			log.Info().Str("address", addr).Msg("Metrics server started")
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal().Err(err).Msg("Metrics server failed")
+			}
+		}()
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -181,24 +575,288 @@ func main() {
 
 	log.Info().Msg("Shutting down...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
-	// Stop workers
-	_ = eventWorker.Stop()
-	_ = deadLetterProcessor.Stop()
-
-	if err := app.ShutdownWithContext(ctx); err != nil {
+	// Stop accepting requests. Hijacked WebSocket connections and event
+	// streams are not waited for, so their clients are disconnected meanwhile.
+	httpDone := make(chan error, 1)
+	go func() {
+		httpDone <- app.ShutdownWithContext(ctx)
+	}()
+	if err := wsHub.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("Error disconnecting WebSocket clients")
+	}
+	if err := <-httpDone; err != nil {
 		log.Error().Err(err).Msg("Error during shutdown")
 	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			log.Error().Err(err).Msg("Error during metrics server shutdown")
+		}
+	}
+
+	// Let the events being handled finish before the workers stop
+	if err := eventWorker.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("Error draining event worker")
+	}
+	_ = deadLetterProcessor.Stop()
+	if federationBridge != nil {
+		_ = federationBridge.Stop()
+	}
+	_ = scheduler.Stop()
+	_ = checkWorker.Stop()
+	_ = subscriptionWorker.Stop()
+	_ = revocationWorker.Stop()
+	if lagWorker != nil {
+		_ = lagWorker.Stop()
+	}
+	if digestWorker != nil {
+		_ = digestWorker.Stop()
+	}
+	if notificationQueueWorker != nil {
+		_ = notificationQueueWorker.Stop()
+	}
+	if wsRelay != nil {
+		_ = wsRelay.Close()
+	}
+	if cacheRelay != nil {
+		_ = cacheRelay.Close()
+	}
 
 	// Close connections
+	if federationClient != nil {
+		closeRedis(federationClient)
+	}
 	closeRedis(redisClient)
 	closeDB(db)
 
 	log.Info().Msg("Server stopped")
 }
 
+// visibilityPolicy builds the alert visibility policy from configuration.
+func visibilityPolicy(cfg config.VisibilityConfig) (valueobject.VisibilityPolicy, error) {
+	rules := make([]valueobject.VisibilityRule, 0, len(cfg.Rules))
+	for _, rc := range cfg.Rules {
+		labels, err := valueobject.ParseLabelSelector(rc.Labels)
+		if err != nil {
+			return valueobject.VisibilityPolicy{}, fmt.Errorf("visibility rule %q: %w", rc.Name, err)
+		}
+		if rc.Source == "" && len(labels) == 0 {
+			return valueobject.VisibilityPolicy{}, fmt.Errorf("visibility rule %q: source or labels required", rc.Name)
+		}
+
+		roles := make([]entity.UserRole, 0, len(rc.Roles))
+		for _, r := range rc.Roles {
+			role := entity.UserRole(r)
+			if !role.IsValid() {
+				return valueobject.VisibilityPolicy{}, fmt.Errorf("visibility rule %q: %w: %s", rc.Name, entity.ErrUserInvalidRole, r)
+			}
+			roles = append(roles, role)
+		}
+
+		rules = append(rules, valueobject.VisibilityRule{
+			Name:   rc.Name,
+			Source: rc.Source,
+			Labels: labels,
+			Roles:  roles,
+		})
+	}
+
+	return valueobject.NewVisibilityPolicy(rules...), nil
+}
+
+func deprecatedEndpoints(cfg config.DeprecationConfig) ([]valueobject.Deprecation, error) {
+	notices := make([]valueobject.Deprecation, 0, len(cfg.Endpoints))
+	for _, ec := range cfg.Endpoints {
+		since, err := parseConfigDate(ec.Since)
+		if err != nil {
+			return nil, fmt.Errorf("deprecated endpoint %q: since: %w", ec.Path, err)
+		}
+		sunset, err := parseConfigDate(ec.Sunset)
+		if err != nil {
+			return nil, fmt.Errorf("deprecated endpoint %q: sunset: %w", ec.Path, err)
+		}
+
+		notice, err := valueobject.NewDeprecation(ec.Method, ec.Path, since, sunset, ec.Link)
+		if err != nil {
+			return nil, fmt.Errorf("deprecated endpoint %q: %w", ec.Path, err)
+		}
+		notices = append(notices, notice)
+	}
+
+	return notices, nil
+}
+
+func rateLimitPolicy(cfg config.RateLimitConfig) (valueobject.RateLimitPolicy, error) {
+	api, err := rateLimit(cfg.API)
+	if err != nil {
+		return valueobject.RateLimitPolicy{}, fmt.Errorf("api: %w", err)
+	}
+	login, err := rateLimit(cfg.Login)
+	if err != nil {
+		return valueobject.RateLimitPolicy{}, fmt.Errorf("login: %w", err)
+	}
+
+	routes := make([]valueobject.RouteRateLimit, 0, len(cfg.Routes))
+	for _, rc := range cfg.Routes {
+		limit, err := rateLimit(config.RateLimitRuleConfig{Limit: rc.Limit, Window: rc.Window, Roles: rc.Roles})
+		if err != nil {
+			return valueobject.RateLimitPolicy{}, fmt.Errorf("route %q: %w", rc.Path, err)
+		}
+		route, err := valueobject.NewRouteRateLimit(rc.Method, rc.Path, limit)
+		if err != nil {
+			return valueobject.RateLimitPolicy{}, fmt.Errorf("route %q: %w", rc.Path, err)
+		}
+		routes = append(routes, route)
+	}
+
+	return valueobject.RateLimitPolicy{API: api, Login: login, Routes: routes}, nil
+}
+
+func rateLimit(cfg config.RateLimitRuleConfig) (valueobject.RateLimit, error) {
+	roles := make(map[entity.UserRole]int, len(cfg.Roles))
+	for role, limit := range cfg.Roles {
+		roles[entity.UserRole(role)] = limit
+	}
+
+	return valueobject.NewRateLimit(cfg.Limit, cfg.Window, roles)
+}
+
+func quotaPolicy(cfg config.QuotaConfig) (valueobject.QuotaPolicy, error) {
+	defaults, err := valueobject.NewQuotaLimits(cfg.RequestsPerDay, cfg.AlertsPerDay)
+	if err != nil {
+		return valueobject.QuotaPolicy{}, err
+	}
+
+	overrides := make(map[valueobject.QuotaSubject]valueobject.QuotaLimits, len(cfg.Teams)+len(cfg.Sources))
+	for kind, entries := range map[valueobject.QuotaSubjectKind]map[string]config.QuotaLimitConfig{
+		valueobject.QuotaSubjectTeam:   cfg.Teams,
+		valueobject.QuotaSubjectSource: cfg.Sources,
+	} {
+		for id, qc := range entries {
+			subject, err := valueobject.NewQuotaSubject(kind, id)
+			if err != nil {
+				return valueobject.QuotaPolicy{}, fmt.Errorf("%s %q: %w", kind, id, err)
+			}
+			limits, err := valueobject.NewQuotaLimits(qc.RequestsPerDay, qc.AlertsPerDay)
+			if err != nil {
+				return valueobject.QuotaPolicy{}, fmt.Errorf("%s %q: %w", kind, id, err)
+			}
+			overrides[subject] = limits
+		}
+	}
+
+	return valueobject.QuotaPolicy{Default: defaults, Overrides: overrides}, nil
+}
+
+func networkACLPolicy(cfg config.NetworkACLConfig) (valueobject.NetworkACLPolicy, error) {
+	webhooks, err := valueobject.NewNetworkACL(cfg.Webhooks.Allow, cfg.Webhooks.Deny)
+	if err != nil {
+		return valueobject.NetworkACLPolicy{}, fmt.Errorf("webhooks: %w", err)
+	}
+	admin, err := valueobject.NewNetworkACL(cfg.Admin.Allow, cfg.Admin.Deny)
+	if err != nil {
+		return valueobject.NetworkACLPolicy{}, fmt.Errorf("admin: %w", err)
+	}
+
+	return valueobject.NetworkACLPolicy{Webhooks: webhooks, Admin: admin}, nil
+}
+
+// enrichmentPipeline builds the enabled enrichers in the configured order;
+// enabled enrichers left out of the order run last.
+func enrichmentPipeline(
+	cfg config.EnrichmentConfig,
+	cacheRepo repository.CacheRepository,
+) (*service.EnrichmentPipeline, error) {
+	enabled := map[string]bool{
+		"geoip":   cfg.GeoIP.Enabled,
+		"runbook": cfg.Runbook.Enabled,
+		"cmdb":    cfg.CMDB.Enabled,
+	}
+
+	order := make([]string, 0, len(enabled))
+	for _, name := range append(cfg.Order, "geoip", "runbook", "cmdb") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, known := enabled[name]; !known {
+			return nil, fmt.Errorf("unknown enricher %q", name)
+		}
+		if !slices.Contains(order, name) {
+			order = append(order, name)
+		}
+	}
+
+	stages := make([]service.EnrichmentStage, 0, len(order))
+	for _, name := range order {
+		if !enabled[name] {
+			continue
+		}
+
+		switch name {
+		case "geoip":
+			geoIP, err := enrichment.NewGeoIPEnricher(cfg.GeoIP.Database)
+			if err != nil {
+				return nil, fmt.Errorf("geoip: %w", err)
+			}
+			stages = append(stages, service.EnrichmentStage{Enricher: geoIP, Timeout: cfg.GeoIP.Timeout})
+		case "runbook":
+			stages = append(stages, service.EnrichmentStage{
+				Enricher: enrichment.NewRunbookEnricher(cfg.Runbook.URLs),
+				Timeout:  cfg.Runbook.Timeout,
+			})
+		case "cmdb":
+			cmdb, err := enrichment.NewCMDBEnricher(cfg.CMDB.URL, cfg.CMDB.Token, cfg.CMDB.OwnerField,
+				cacheRepo, cfg.CMDB.CacheTTL)
+			if err != nil {
+				return nil, fmt.Errorf("cmdb: %w", err)
+			}
+			stages = append(stages, service.EnrichmentStage{Enricher: cmdb, Timeout: cfg.CMDB.Timeout})
+		}
+	}
+
+	return service.NewEnrichmentPipeline(stages...), nil
+}
+
+func quietHoursSchedules(cfg []config.QuietHoursConfig) ([]valueobject.QuietHours, error) {
+	schedules := make([]valueobject.QuietHours, 0, len(cfg))
+	for _, qc := range cfg {
+		schedule, err := valueobject.NewQuietHours(qc.Channel, qc.Start, qc.End, qc.Timezone, qc.Days, qc.BypassSeverity)
+		if err != nil {
+			return nil, fmt.Errorf("quiet hours %q: %w", qc.Channel, err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+func notificationBatches(cfg []config.BatchingConfig) ([]valueobject.NotificationBatch, error) {
+	batches := make([]valueobject.NotificationBatch, 0, len(cfg))
+	for _, bc := range cfg {
+		batch, err := valueobject.NewNotificationBatch(bc.Channel, bc.MaxSeverity, bc.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("notification batching %q: %w", bc.Channel, err)
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// parseConfigDate accepts a date (YYYY-MM-DD) or an RFC 3339 time; empty yields the zero time.
+func parseConfigDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 func setupLogger(cfg *config.Config) {
 	level, err := zerolog.ParseLevel(cfg.Logging.Level)
 	if err != nil {
@@ -4,6 +4,7 @@
 package dto
 
 import (
+	"strings"
 	"time"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
@@ -17,6 +18,13 @@ type CreateAlertRequest struct {
 	Severity string                 `json:"severity" validate:"required,oneof=critical high medium low info"`
 	Source   string                 `json:"source,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	TeamID   string                 `json:"team_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// CreateAlertBatchRequest represents the request payload for creating several alerts at once.
+// The batch is limited to 500 alerts per request.
+type CreateAlertBatchRequest struct {
+	Alerts []CreateAlertRequest `json:"alerts" validate:"required,min=1,max=500,dive"`
 }
 
 // UpdateAlertRequest represents the request payload for updating an existing alert.
@@ -45,10 +53,12 @@ type ResolveAlertRequest struct {
 // text search, and sorting options.
 type ListAlertsRequest struct {
 	Page      int      `query:"page" validate:"omitempty,min=1"`
-	PageSize  int      `query:"page_size" validate:"omitempty,min=1,max=100"`
+	PageSize  int      `query:"page_size" validate:"omitempty,min=1"`
 	Status    []string `query:"status" validate:"omitempty,dive,oneof=active acknowledged resolved expired"`
 	Severity  []string `query:"severity" validate:"omitempty,dive,oneof=critical high medium low info"`
 	Source    string   `query:"source"`
+	Region    string   `query:"region"`
+	TeamID    string   `query:"team_id"`
 	Search    string   `query:"search"`
 	FromDate  string   `query:"from_date"`
 	ToDate    string   `query:"to_date"`
@@ -59,21 +69,40 @@ type ListAlertsRequest struct {
 // AlertResponse represents the API response format for an alert.
 // It converts the internal domain entity to a client-friendly JSON structure.
 type AlertResponse struct {
-	ID             string                 `json:"id"`
-	RuleID         *string                `json:"rule_id,omitempty"`
-	Title          string                 `json:"title"`
-	Message        string                 `json:"message"`
-	Severity       string                 `json:"severity"`
-	Status         string                 `json:"status"`
-	Source         string                 `json:"source,omitempty"`
-	Metadata       map[string]interface{} `json:"metadata,omitempty"`
-	AcknowledgedBy *string                `json:"acknowledged_by,omitempty"`
-	AcknowledgedAt *time.Time             `json:"acknowledged_at,omitempty"`
-	ResolvedBy     *string                `json:"resolved_by,omitempty"`
-	ResolvedAt     *time.Time             `json:"resolved_at,omitempty"`
-	ExpiresAt      *time.Time             `json:"expires_at,omitempty"`
-	CreatedAt      time.Time              `json:"created_at"`
-	UpdatedAt      time.Time              `json:"updated_at"`
+	ID      string  `json:"id"`
+	RuleID  *string `json:"rule_id,omitempty"`
+	TeamID  *string `json:"team_id,omitempty"`
+	Title   string  `json:"title"`
+	Message string  `json:"message"`
+	// MessageTruncated is true when Message was shortened; the detail endpoint returns the full text.
+	MessageTruncated bool                   `json:"message_truncated,omitempty"`
+	Severity         string                 `json:"severity"`
+	Status           string                 `json:"status"`
+	Source           string                 `json:"source,omitempty"`
+	Region           string                 `json:"region,omitempty"`
+	Cluster          string                 `json:"cluster,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+	// Flapping is true when the alert's condition kept firing and resolving;
+	// its notifications are suppressed.
+	Flapping bool `json:"flapping"`
+	// Downstream is true when a source the alert's source depends on had an
+	// active critical alert when it was raised; its notifications are
+	// suppressed. The upstream alert is in the metadata.
+	Downstream bool `json:"downstream"`
+	// RunbookURL and Remediation tell responders what to do, from the rule
+	// that fired the alert or from enrichment; TicketKey is the key of the
+	// issue filed for the alert.
+	RunbookURL     string     `json:"runbook_url,omitempty"`
+	Remediation    string     `json:"remediation,omitempty"`
+	TicketKey      string     `json:"ticket_key,omitempty"`
+	AcknowledgedBy *string    `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	ResolvedBy     *string    `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
 // AlertFromEntity converts a domain Alert entity to an AlertResponse DTO.
@@ -81,16 +110,24 @@ type AlertResponse struct {
 // and properly handles optional fields (acknowledged/resolved information).
 func AlertFromEntity(a *entity.Alert) AlertResponse {
 	response := AlertResponse{
-		ID:        a.ID.String(),
-		Title:     a.Title,
-		Message:   a.Message,
-		Severity:  string(a.Severity),
-		Status:    string(a.Status),
-		Source:    a.Source,
-		Metadata:  a.Metadata,
-		ExpiresAt: a.ExpiresAt,
-		CreatedAt: a.CreatedAt,
-		UpdatedAt: a.UpdatedAt,
+		ID:          a.ID.String(),
+		Title:       a.Title,
+		Message:     a.Message,
+		Severity:    string(a.Severity),
+		Status:      string(a.Status),
+		Source:      a.Source,
+		Region:      a.Region,
+		Cluster:     a.Cluster,
+		Metadata:    a.Metadata,
+		Flapping:    a.IsFlapping(),
+		Downstream:  a.IsDownstream(),
+		RunbookURL:  a.RunbookURL(),
+		Remediation: a.Remediation(),
+		TicketKey:   a.TicketKey(),
+		ExpiresAt:   a.ExpiresAt,
+		DeletedAt:   a.DeletedAt,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
 	}
 
 	if a.RuleID != nil {
@@ -98,6 +135,11 @@ func AlertFromEntity(a *entity.Alert) AlertResponse {
 		response.RuleID = &ruleID
 	}
 
+	if a.TeamID != nil {
+		teamID := a.TeamID.String()
+		response.TeamID = &teamID
+	}
+
 	if a.AcknowledgedBy != nil {
 		ackBy := a.AcknowledgedBy.String()
 		response.AcknowledgedBy = &ackBy
@@ -107,12 +149,49 @@ func AlertFromEntity(a *entity.Alert) AlertResponse {
 	if a.ResolvedBy != nil {
 		resBy := a.ResolvedBy.String()
 		response.ResolvedBy = &resBy
-		response.ResolvedAt = a.ResolvedAt
 	}
+	response.ResolvedAt = a.ResolvedAt
+
+	return response
+}
 
+// AlertSummaryFromEntity converts an alert like AlertFromEntity but truncates
+// the message to maxLength characters, for list views and broadcasts.
+// A maxLength of zero or less disables truncation.
+func AlertSummaryFromEntity(a *entity.Alert, maxLength int) AlertResponse {
+	response := AlertFromEntity(a)
+	response.Message, response.MessageTruncated = TruncateMessage(a.Message, maxLength)
 	return response
 }
 
+// AlertSummariesFromEntities converts a slice of alerts with truncated messages.
+func AlertSummariesFromEntities(alerts []*entity.Alert, maxLength int) []AlertResponse {
+	result := make([]AlertResponse, len(alerts))
+	for i, a := range alerts {
+		result[i] = AlertSummaryFromEntity(a, maxLength)
+	}
+	return result
+}
+
+// TruncateMessage shortens message to at most maxLength characters, ending
+// with an ellipsis, and reports whether it was truncated.
+// A maxLength of zero or less disables truncation.
+func TruncateMessage(message string, maxLength int) (string, bool) {
+	if maxLength <= 0 {
+		return message, false
+	}
+
+	runes := []rune(message)
+	if len(runes) <= maxLength {
+		return message, false
+	}
+
+	if maxLength == 1 {
+		return "…", true
+	}
+	return strings.TrimRight(string(runes[:maxLength-1]), " \t\n") + "…", true
+}
+
 // AlertsFromEntities converts a slice of Alert entities to AlertResponse DTOs.
 // It is a convenience function for batch conversion of alert lists.
 func AlertsFromEntities(alerts []*entity.Alert) []AlertResponse {
@@ -123,6 +202,74 @@ func AlertsFromEntities(alerts []*entity.Alert) []AlertResponse {
 	return result
 }
 
+// RelatedAlertResponse represents an alert related to another, with the
+// reasons it is: fingerprint, source or rule.
+type RelatedAlertResponse struct {
+	AlertResponse
+	RelatedBy []string `json:"related_by"`
+}
+
+// AlertOccurrenceResponse represents a previous occurrence of an alert's
+// condition and how it was resolved.
+type AlertOccurrenceResponse struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Severity       string     `json:"severity"`
+	Status         string     `json:"status"`
+	AcknowledgedBy *string    `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	ResolvedBy     *string    `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	// TimeToResolveSeconds is how long the occurrence stayed open, when resolved.
+	TimeToResolveSeconds *int64    `json:"time_to_resolve_seconds,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// RelatedAlertsResponse represents an alert with the context responders need:
+// related alerts raised around it and the previous occurrences of its condition.
+type RelatedAlertsResponse struct {
+	Alert               AlertResponse             `json:"alert"`
+	Related             []RelatedAlertResponse    `json:"related"`
+	PreviousOccurrences []AlertOccurrenceResponse `json:"previous_occurrences"`
+}
+
+// AlertOccurrenceFromEntity converts an alert to an AlertOccurrenceResponse.
+func AlertOccurrenceFromEntity(a *entity.Alert) AlertOccurrenceResponse {
+	full := AlertFromEntity(a)
+	response := AlertOccurrenceResponse{
+		ID:             full.ID,
+		Title:          full.Title,
+		Severity:       full.Severity,
+		Status:         full.Status,
+		AcknowledgedBy: full.AcknowledgedBy,
+		AcknowledgedAt: full.AcknowledgedAt,
+		ResolvedBy:     full.ResolvedBy,
+		ResolvedAt:     full.ResolvedAt,
+		CreatedAt:      full.CreatedAt,
+	}
+
+	if a.ResolvedAt != nil {
+		seconds := int64(a.ResolvedAt.Sub(a.CreatedAt).Seconds())
+		response.TimeToResolveSeconds = &seconds
+	}
+
+	return response
+}
+
+// AlertBatchResponse represents the result of a batch alert creation.
+// In dry-run mode nothing is stored and Created is the number of alerts that would be.
+type AlertBatchResponse struct {
+	DryRun  bool            `json:"dry_run"`
+	Created int             `json:"created"`
+	Items   []AlertResponse `json:"items"`
+}
+
+// PurgeAlertsRequest represents query parameters for the alert retention purge.
+type PurgeAlertsRequest struct {
+	OlderThanDays int  `query:"older_than_days" validate:"required,min=1"`
+	DryRun        bool `query:"dry_run"`
+}
+
 // AlertStatisticsResponse represents aggregated alert statistics for dashboards.
 // It provides counts by status and breakdowns by severity and source.
 type AlertStatisticsResponse struct {
@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// ALERT VIEW REQUESTS
+// ===============================================
+
+// AlertViewCriteriaRequest represents the filters of a saved alert view.
+// Every set criterion must match.
+type AlertViewCriteriaRequest struct {
+	Statuses   []string          `json:"statuses,omitempty" validate:"omitempty,dive,oneof=active acknowledged resolved expired"`
+	Severities []string          `json:"severities,omitempty" validate:"omitempty,dive,oneof=critical high medium low info"`
+	Labels     map[string]string `json:"labels,omitempty" validate:"omitempty,max=20"`
+	Search     string            `json:"search,omitempty" validate:"omitempty,max=255"`
+}
+
+// CreateAlertViewRequest represents the request to save an alert view.
+// The view is private unless shared with one of the caller's teams.
+type CreateAlertViewRequest struct {
+	Name     string                   `json:"name" validate:"required,max=100"`
+	Criteria AlertViewCriteriaRequest `json:"criteria"`
+	TeamID   string                   `json:"team_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// UpdateAlertViewRequest represents the request to update an alert view.
+// An empty team_id makes a shared view private again.
+type UpdateAlertViewRequest struct {
+	Name     *string                   `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Criteria *AlertViewCriteriaRequest `json:"criteria,omitempty"`
+	TeamID   *string                   `json:"team_id,omitempty"`
+}
+
+// ToEntity converts the request to view criteria.
+func (r AlertViewCriteriaRequest) ToEntity() entity.AlertViewCriteria {
+	criteria := entity.AlertViewCriteria{
+		Labels: r.Labels,
+		Search: r.Search,
+	}
+	for _, status := range r.Statuses {
+		criteria.Statuses = append(criteria.Statuses, entity.AlertStatus(status))
+	}
+	for _, severity := range r.Severities {
+		criteria.Severities = append(criteria.Severities, entity.AlertSeverity(severity))
+	}
+	return criteria
+}
+
+// ===============================================
+// ALERT VIEW RESPONSES
+// ===============================================
+
+// AlertViewCriteriaResponse represents the filters of a saved alert view.
+type AlertViewCriteriaResponse struct {
+	Statuses   []string          `json:"statuses,omitempty"`
+	Severities []string          `json:"severities,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Search     string            `json:"search,omitempty"`
+}
+
+// AlertViewResponse represents a saved alert view in API responses.
+type AlertViewResponse struct {
+	ID        string                    `json:"id"`
+	Name      string                    `json:"name"`
+	Criteria  AlertViewCriteriaResponse `json:"criteria"`
+	OwnerID   string                    `json:"owner_id"`
+	TeamID    string                    `json:"team_id,omitempty"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+// AlertViewAlertsResponse represents a page of the alerts of a view.
+type AlertViewAlertsResponse struct {
+	View   AlertViewResponse                `json:"view"`
+	Alerts PaginatedResponse[AlertResponse] `json:"alerts"`
+}
+
+// AlertViewFromEntity converts a domain entity to a response DTO.
+func AlertViewFromEntity(v *entity.AlertView) AlertViewResponse {
+	response := AlertViewResponse{
+		ID:   v.ID.String(),
+		Name: v.Name,
+		Criteria: AlertViewCriteriaResponse{
+			Labels: v.Criteria.Labels,
+			Search: v.Criteria.Search,
+		},
+		OwnerID:   v.OwnerID.String(),
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+
+	for _, status := range v.Criteria.Statuses {
+		response.Criteria.Statuses = append(response.Criteria.Statuses, string(status))
+	}
+	for _, severity := range v.Criteria.Severities {
+		response.Criteria.Severities = append(response.Criteria.Severities, string(severity))
+	}
+
+	if v.TeamID != nil {
+		response.TeamID = v.TeamID.String()
+	}
+
+	return response
+}
+
+// AlertViewsFromEntities converts a slice of entities to response DTOs.
+func AlertViewsFromEntities(views []*entity.AlertView) []AlertViewResponse {
+	result := make([]AlertViewResponse, len(views))
+	for i, v := range views {
+		result[i] = AlertViewFromEntity(v)
+	}
+	return result
+}
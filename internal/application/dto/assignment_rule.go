@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// ASSIGNMENT RULE REQUESTS
+// ===============================================
+
+// AssignmentRuleMatchRequest represents the alerts an assignment rule applies
+// to. Every set criterion must match; at least one must be set.
+type AssignmentRuleMatchRequest struct {
+	Sources []string          `json:"sources,omitempty" validate:"omitempty,max=50,dive,min=1,max=100"`
+	Labels  map[string]string `json:"labels,omitempty" validate:"omitempty,max=20"`
+}
+
+// CreateAssignmentRuleRequest represents the request to create an assignment
+// rule. Rules are evaluated by ascending position and assign to a team, a
+// user or both.
+type CreateAssignmentRuleRequest struct {
+	Name     string                     `json:"name" validate:"required,max=100"`
+	Position int                        `json:"position" validate:"min=0"`
+	Match    AssignmentRuleMatchRequest `json:"match"`
+	TeamID   string                     `json:"team_id,omitempty" validate:"omitempty,uuid"`
+	UserID   string                     `json:"user_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// UpdateAssignmentRuleRequest represents the request to update an assignment
+// rule. An empty team_id or user_id stops assigning to a team or user.
+type UpdateAssignmentRuleRequest struct {
+	Name      *string                     `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Position  *int                        `json:"position,omitempty" validate:"omitempty,min=0"`
+	Match     *AssignmentRuleMatchRequest `json:"match,omitempty"`
+	TeamID    *string                     `json:"team_id,omitempty"`
+	UserID    *string                     `json:"user_id,omitempty"`
+	IsEnabled *bool                       `json:"is_enabled,omitempty"`
+}
+
+// ToEntity converts the request to an assignment rule matcher.
+func (r AssignmentRuleMatchRequest) ToEntity() entity.AssignmentRuleMatcher {
+	return entity.AssignmentRuleMatcher{
+		Sources: r.Sources,
+		Labels:  r.Labels,
+	}
+}
+
+// ===============================================
+// ASSIGNMENT RULE RESPONSES
+// ===============================================
+
+// AssignmentRuleMatchResponse represents the alerts an assignment rule applies to.
+type AssignmentRuleMatchResponse struct {
+	Sources []string          `json:"sources,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// AssignmentRuleResponse represents an assignment rule in API responses.
+type AssignmentRuleResponse struct {
+	ID        string                      `json:"id"`
+	Name      string                      `json:"name"`
+	Position  int                         `json:"position"`
+	Match     AssignmentRuleMatchResponse `json:"match"`
+	TeamID    string                      `json:"team_id,omitempty"`
+	UserID    string                      `json:"user_id,omitempty"`
+	IsEnabled bool                        `json:"is_enabled"`
+	CreatedBy string                      `json:"created_by,omitempty"`
+	CreatedAt time.Time                   `json:"created_at"`
+	UpdatedAt time.Time                   `json:"updated_at"`
+}
+
+// AssignmentRuleFromEntity converts a domain entity to a response DTO.
+func AssignmentRuleFromEntity(r *entity.AssignmentRule) AssignmentRuleResponse {
+	response := AssignmentRuleResponse{
+		ID:       r.ID.String(),
+		Name:     r.Name,
+		Position: r.Position,
+		Match: AssignmentRuleMatchResponse{
+			Sources: r.Match.Sources,
+			Labels:  r.Match.Labels,
+		},
+		IsEnabled: r.IsEnabled,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+
+	if r.TeamID != nil {
+		response.TeamID = r.TeamID.String()
+	}
+	if r.UserID != nil {
+		response.UserID = r.UserID.String()
+	}
+	if r.CreatedBy != nil {
+		response.CreatedBy = r.CreatedBy.String()
+	}
+
+	return response
+}
+
+// AssignmentRulesFromEntities converts a slice of domain entities to response DTOs.
+func AssignmentRulesFromEntities(rules []*entity.AssignmentRule) []AssignmentRuleResponse {
+	responses := make([]AssignmentRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = AssignmentRuleFromEntity(rule)
+	}
+	return responses
+}
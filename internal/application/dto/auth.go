@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
 
 // ===============================================
 // AUTH REQUESTS
@@ -34,6 +38,8 @@ type LoginResponse struct {
 	RefreshToken string       `json:"refresh_token"`
 	ExpiresAt    time.Time    `json:"expires_at"`
 	User         UserResponse `json:"user"`
+	// Digest summarizes events missed while the user was away, if any.
+	Digest *DigestResponse `json:"digest,omitempty"`
 }
 
 // TokenResponse represents a token refresh response.
@@ -42,3 +48,37 @@ type TokenResponse struct {
 	RefreshToken string    `json:"refresh_token"`
 	ExpiresAt    time.Time `json:"expires_at"`
 }
+
+// SessionResponse represents an active session.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	IssuedAt   time.Time `json:"issued_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Current marks the session of the request.
+	Current bool `json:"current"`
+}
+
+// SessionFromEntity converts a session entity to a response DTO.
+func SessionFromEntity(s *entity.Session, currentID string) SessionResponse {
+	return SessionResponse{
+		ID:         s.ID.String(),
+		Device:     s.Device,
+		IP:         s.IP,
+		IssuedAt:   s.IssuedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+		Current:    s.ID.String() == currentID,
+	}
+}
+
+// SessionsFromEntities converts a slice of session entities to response DTOs.
+func SessionsFromEntities(sessions []*entity.Session, currentID string) []SessionResponse {
+	result := make([]SessionResponse, len(sessions))
+	for i, s := range sessions {
+		result[i] = SessionFromEntity(s, currentID)
+	}
+	return result
+}
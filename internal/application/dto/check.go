@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// CHECK REQUESTS
+// ===============================================
+
+// CreateCheckRequest represents the request to create an uptime check.
+// Method defaults to GET, expected status to 200, timeout to 10 seconds,
+// failure threshold to 1 and severity to high.
+type CreateCheckRequest struct {
+	Name             string `json:"name" validate:"required,max=100"`
+	URL              string `json:"url" validate:"required,url"`
+	Method           string `json:"method,omitempty" validate:"omitempty,oneof=GET HEAD POST"`
+	ExpectedStatus   int    `json:"expected_status,omitempty" validate:"omitempty,min=100,max=599"`
+	IntervalSeconds  int    `json:"interval_seconds" validate:"required,min=30,max=86400"`
+	TimeoutSeconds   int    `json:"timeout_seconds,omitempty" validate:"omitempty,min=1,max=60"`
+	FailureThreshold int    `json:"failure_threshold,omitempty" validate:"omitempty,min=1,max=10"`
+	Severity         string `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	TeamID           string `json:"team_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// UpdateCheckRequest represents the request to update an uptime check.
+type UpdateCheckRequest struct {
+	Name             *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	URL              *string `json:"url,omitempty" validate:"omitempty,url"`
+	Method           *string `json:"method,omitempty" validate:"omitempty,oneof=GET HEAD POST"`
+	ExpectedStatus   *int    `json:"expected_status,omitempty" validate:"omitempty,min=100,max=599"`
+	IntervalSeconds  *int    `json:"interval_seconds,omitempty" validate:"omitempty,min=30,max=86400"`
+	TimeoutSeconds   *int    `json:"timeout_seconds,omitempty" validate:"omitempty,min=1,max=60"`
+	FailureThreshold *int    `json:"failure_threshold,omitempty" validate:"omitempty,min=1,max=10"`
+	Severity         *string `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	IsEnabled        *bool   `json:"is_enabled,omitempty"`
+}
+
+// ===============================================
+// CHECK RESPONSES
+// ===============================================
+
+// CheckResponse represents an uptime check in API responses.
+type CheckResponse struct {
+	ID                  string     `json:"id"`
+	Name                string     `json:"name"`
+	URL                 string     `json:"url"`
+	Method              string     `json:"method"`
+	ExpectedStatus      int        `json:"expected_status"`
+	IntervalSeconds     int        `json:"interval_seconds"`
+	TimeoutSeconds      int        `json:"timeout_seconds"`
+	FailureThreshold    int        `json:"failure_threshold"`
+	Severity            string     `json:"severity"`
+	Status              string     `json:"status"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastCheckedAt       *time.Time `json:"last_checked_at,omitempty"`
+	IsEnabled           bool       `json:"is_enabled"`
+	TeamID              string     `json:"team_id,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// CheckFromEntity converts a domain entity to a response DTO.
+func CheckFromEntity(c *entity.Check) CheckResponse {
+	response := CheckResponse{
+		ID:                  c.ID.String(),
+		Name:                c.Name,
+		URL:                 c.URL,
+		Method:              c.Method,
+		ExpectedStatus:      c.ExpectedStatus,
+		IntervalSeconds:     c.IntervalSeconds,
+		TimeoutSeconds:      c.TimeoutSeconds,
+		FailureThreshold:    c.FailureThreshold,
+		Severity:            string(c.Severity),
+		Status:              string(c.Status),
+		ConsecutiveFailures: c.ConsecutiveFailures,
+		LastCheckedAt:       c.LastCheckedAt,
+		IsEnabled:           c.IsEnabled,
+		CreatedAt:           c.CreatedAt,
+		UpdatedAt:           c.UpdatedAt,
+	}
+
+	if c.TeamID != nil {
+		response.TeamID = c.TeamID.String()
+	}
+
+	return response
+}
+
+// ChecksFromEntities converts a slice of entities to response DTOs.
+func ChecksFromEntities(checks []*entity.Check) []CheckResponse {
+	result := make([]CheckResponse, len(checks))
+	for i, c := range checks {
+		result[i] = CheckFromEntity(c)
+	}
+	return result
+}
+
+// CheckResultResponse represents a probe of an uptime check.
+type CheckResultResponse struct {
+	Success    bool      `json:"success"`
+	StatusCode int       `json:"status_code,omitempty"`
+	LatencyMs  int64     `json:"latency_ms"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// CheckResultsFromEntities converts probe results to response DTOs.
+func CheckResultsFromEntities(results []*entity.CheckResult) []CheckResultResponse {
+	responses := make([]CheckResultResponse, len(results))
+	for i, r := range results {
+		responses[i] = CheckResultResponse{
+			Success:    r.Success,
+			StatusCode: r.StatusCode,
+			LatencyMs:  r.LatencyMs,
+			Error:      r.Error,
+			CheckedAt:  r.CheckedAt,
+		}
+	}
+	return responses
+}
@@ -44,6 +44,14 @@ type ValidationErrorResponse struct {
 	Timestamp time.Time         `json:"timestamp"`
 }
 
+// BulkOperationResponse describes the items affected by a bulk or destructive operation.
+// When DryRun is true nothing was changed and Affected is what would have been.
+type BulkOperationResponse struct {
+	DryRun    bool     `json:"dry_run"`
+	Affected  int64    `json:"affected"`
+	SampleIDs []string `json:"sample_ids"`
+}
+
 // ===============================================
 // HEALTH RESPONSES
 // ===============================================
@@ -54,6 +62,7 @@ type HealthResponse struct {
 	Timestamp time.Time         `json:"timestamp"`
 	Version   string            `json:"version"`
 	Services  map[string]string `json:"services"`
+	Features  map[string]bool   `json:"features,omitempty"`
 }
 
 // ReadyResponse represents the readiness check response.
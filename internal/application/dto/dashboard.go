@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// SourceCountResponse is the number of alerts raised by a source.
+type SourceCountResponse struct {
+	Source string `json:"source"`
+	Count  int64  `json:"count"`
+}
+
+// OnCallResponse is a user commanding open incidents.
+type OnCallResponse struct {
+	UserID      string   `json:"user_id"`
+	Name        string   `json:"name"`
+	Email       string   `json:"email"`
+	IncidentIDs []string `json:"incident_ids"`
+}
+
+// DashboardResponse gathers what the dashboard shows on load.
+type DashboardResponse struct {
+	ActiveAlerts     int64                 `json:"active_alerts"`      // Alerts that are currently active
+	ActiveBySeverity map[string]int64      `json:"active_by_severity"` // Active alerts grouped by severity level
+	NoisySources     []SourceCountResponse `json:"noisy_sources"`      // Sources raising the most alerts in the last 24 hours
+	RecentAlerts     []AlertResponse       `json:"recent_alerts"`      // Latest alerts, newest first
+	OpenIncidents    int64                 `json:"open_incidents"`     // Incidents that are open
+	OnCall           []OnCallResponse      `json:"on_call"`            // Commanders of the open incidents
+	GeneratedAt      time.Time             `json:"generated_at"`       // When the summary was assembled
+}
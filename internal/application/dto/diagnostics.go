@@ -0,0 +1,66 @@
+package dto
+
+import "time"
+
+// RuntimeStatsResponse is a snapshot of the Go runtime of the instance.
+type RuntimeStatsResponse struct {
+	GoVersion     string      `json:"go_version"`
+	StartedAt     time.Time   `json:"started_at"`
+	UptimeSeconds float64     `json:"uptime_seconds"`
+	Goroutines    int         `json:"goroutines"`
+	GOMAXPROCS    int         `json:"gomaxprocs"`
+	NumCPU        int         `json:"num_cpu"`
+	Memory        MemoryStats `json:"memory"`
+	GC            GCStats     `json:"gc"`
+}
+
+// MemoryStats are the memory statistics of the runtime, in bytes.
+type MemoryStats struct {
+	HeapAlloc    uint64 `json:"heap_alloc"`
+	HeapInuse    uint64 `json:"heap_inuse"`
+	HeapIdle     uint64 `json:"heap_idle"`
+	HeapReleased uint64 `json:"heap_released"`
+	HeapObjects  uint64 `json:"heap_objects"`
+	StackInuse   uint64 `json:"stack_inuse"`
+	Sys          uint64 `json:"sys"`
+	TotalAlloc   uint64 `json:"total_alloc"`
+}
+
+// GCStats are the garbage collector statistics of the runtime.
+type GCStats struct {
+	NumGC        uint32     `json:"num_gc"`
+	LastGC       *time.Time `json:"last_gc,omitempty"`
+	PauseTotalMs float64    `json:"pause_total_ms"`
+	LastPauseMs  float64    `json:"last_pause_ms"`
+	NextGCBytes  uint64     `json:"next_gc_bytes"`
+	CPUFraction  float64    `json:"cpu_fraction"`
+}
+
+// LogLevelRequest changes the log level of the instance.
+type LogLevelRequest struct {
+	Level string `json:"level" validate:"required,oneof=trace debug info warn error fatal panic disabled"`
+}
+
+// LogLevelResponse is the log level of the instance.
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
+
+// JobResponse is the status of a scheduled job. The next run is that of the
+// instance answering; the last run may be another instance's.
+type JobResponse struct {
+	Name     string       `json:"name"`
+	Schedule string       `json:"schedule"`
+	NextRun  *time.Time   `json:"next_run,omitempty"`
+	Running  bool         `json:"running"`
+	LastRun  *JobRunStats `json:"last_run,omitempty"`
+}
+
+// JobRunStats describes a run of a scheduled job.
+type JobRunStats struct {
+	Instance   string    `json:"instance"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs float64   `json:"duration_ms"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// Digest item kinds for significant events addressed to a user.
+const (
+	DigestKindAssignment = "assignment"
+	DigestKindMention    = "mention"
+	DigestKindEscalation = "escalation"
+)
+
+// DigestItem represents a significant event addressed to a user while they were offline.
+type DigestItem struct {
+	Kind       string    `json:"kind"`
+	Title      string    `json:"title"`
+	Message    string    `json:"message,omitempty"`
+	AlertID    string    `json:"alert_id,omitempty"`
+	IncidentID string    `json:"incident_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// DigestResponse is the consolidated "while you were away" summary for a user.
+type DigestResponse struct {
+	Since  time.Time      `json:"since"`
+	Total  int            `json:"total"`
+	ByKind map[string]int `json:"by_kind"`
+	Items  []DigestItem   `json:"items"`
+}
+
+// NewDigestResponse builds a digest summary from the accumulated items.
+func NewDigestResponse(items []DigestItem) DigestResponse {
+	resp := DigestResponse{
+		Total:  len(items),
+		ByKind: make(map[string]int),
+		Items:  items,
+	}
+
+	for i, item := range items {
+		if i == 0 || item.OccurredAt.Before(resp.Since) {
+			resp.Since = item.OccurredAt
+		}
+		resp.ByKind[item.Kind]++
+	}
+
+	return resp
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// ReplayEventsRequest selects the events of a replay. To defaults to now and
+// Limit to 1000 events.
+type ReplayEventsRequest struct {
+	Stream     string     `json:"stream" validate:"required,oneof=alerts alerts-priority notifications"`
+	From       time.Time  `json:"from" validate:"required"`
+	To         *time.Time `json:"to"`
+	EventTypes []string   `json:"event_types"`
+	Limit      int        `json:"limit" validate:"omitempty,min=1,max=10000"`
+	DryRun     bool       `json:"dry_run"`
+}
+
+// ReplayEventsResponse describes the events a replay re-dispatched.
+// When DryRun is true nothing was published and Replayed is what would have been.
+type ReplayEventsResponse struct {
+	DryRun    bool     `json:"dry_run"`
+	Replayed  int      `json:"replayed"`
+	Truncated bool     `json:"truncated"`
+	SampleIDs []string `json:"sample_ids"`
+}
+
+// ConsumerLagResponse is the backlog of a consumer group on a stream. Lag is
+// -1 when the bus cannot tell how many events were not yet delivered.
+type ConsumerLagResponse struct {
+	Stream              string     `json:"stream"`
+	Group               string     `json:"group"`
+	Consumers           int        `json:"consumers"`
+	Length              int64      `json:"length"`
+	Lag                 int64      `json:"lag"`
+	Pending             int64      `json:"pending"`
+	OldestPendingAt     *time.Time `json:"oldest_pending_at,omitempty"`
+	OldestPendingAgeSec float64    `json:"oldest_pending_age_seconds"`
+}
@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ListFailedEventsRequest represents query parameters for listing, or
+// purging, dead-lettered events. Dates are RFC3339.
+type ListFailedEventsRequest struct {
+	Page      int      `query:"page" validate:"omitempty,min=1"`
+	PageSize  int      `query:"page_size" validate:"omitempty,min=1"`
+	EventType string   `query:"event_type"`
+	Status    []string `query:"status" validate:"omitempty,dive,oneof=pending retried ignored"`
+	FromDate  string   `query:"from_date"`
+	ToDate    string   `query:"to_date"`
+}
+
+// BulkFailedEventsRequest selects the dead-lettered events of a bulk retry or
+// ignore. Criteria are combined; an empty request selects every pending event.
+type BulkFailedEventsRequest struct {
+	EventIDs  []string   `json:"event_ids" validate:"omitempty,max=1000"`
+	EventType string     `json:"event_type"`
+	Status    []string   `json:"status" validate:"omitempty,dive,oneof=pending retried ignored"`
+	FromDate  *time.Time `json:"from_date"`
+	ToDate    *time.Time `json:"to_date"`
+	DryRun    bool       `json:"dry_run"`
+}
+
+// FailedEventResponse represents a dead-lettered event.
+type FailedEventResponse struct {
+	ID          string          `json:"id"`
+	EventID     string          `json:"event_id"`
+	EventType   string          `json:"event_type"`
+	Payload     json.RawMessage `json:"payload"`
+	Retries     int             `json:"retries"`
+	LastError   string          `json:"last_error,omitempty"`
+	Status      string          `json:"status"`
+	FailedAt    time.Time       `json:"failed_at"`
+	ProcessedAt *time.Time      `json:"processed_at,omitempty"`
+}
+
+// PaginatedFailedEventResponse represents a paginated list of failed events for Swagger.
+type PaginatedFailedEventResponse struct {
+	Items       []FailedEventResponse `json:"items"`
+	TotalItems  int64                 `json:"total_items"`
+	TotalPages  int                   `json:"total_pages"`
+	CurrentPage int                   `json:"current_page"`
+	PageSize    int                   `json:"page_size"`
+	HasNext     bool                  `json:"has_next"`
+	HasPrevious bool                  `json:"has_previous"`
+}
+
+// FailedEventFromEntity converts a failed event to its API representation.
+func FailedEventFromEntity(e *entity.FailedEvent) FailedEventResponse {
+	return FailedEventResponse{
+		ID:          e.ID.String(),
+		EventID:     e.EventID,
+		EventType:   e.EventType,
+		Payload:     e.Payload,
+		Retries:     e.Retries,
+		LastError:   e.LastError,
+		Status:      string(e.Status),
+		FailedAt:    e.FailedAt,
+		ProcessedAt: e.ProcessedAt,
+	}
+}
+
+// FailedEventsFromEntities converts failed events to their API representation.
+func FailedEventsFromEntities(events []*entity.FailedEvent) []FailedEventResponse {
+	result := make([]FailedEventResponse, len(events))
+	for i, e := range events {
+		result[i] = FailedEventFromEntity(e)
+	}
+	return result
+}
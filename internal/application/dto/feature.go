@@ -0,0 +1,45 @@
+package dto
+
+import "github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+
+// ===============================================
+// FEATURE FLAG REQUESTS
+// ===============================================
+
+// SetFeatureFlagRequest represents the request to override a feature flag.
+// Without org_id the override applies to the whole deployment.
+type SetFeatureFlagRequest struct {
+	Enabled *bool  `json:"enabled" validate:"required"`
+	OrgID   string `json:"org_id,omitempty" validate:"omitempty,max=100"`
+}
+
+// ===============================================
+// FEATURE FLAG RESPONSES
+// ===============================================
+
+// FeatureFlagResponse represents the evaluated state of a feature flag.
+type FeatureFlagResponse struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Source  string `json:"source"`
+	OrgID   string `json:"org_id,omitempty"`
+}
+
+// FeatureFlagFromValue converts an evaluated flag to a response DTO.
+func FeatureFlagFromValue(flag valueobject.FeatureFlag, orgID string) FeatureFlagResponse {
+	return FeatureFlagResponse{
+		Name:    flag.Name,
+		Enabled: flag.Enabled,
+		Source:  flag.Source,
+		OrgID:   orgID,
+	}
+}
+
+// FeatureFlagsFromValues converts evaluated flags to response DTOs.
+func FeatureFlagsFromValues(flags []valueobject.FeatureFlag, orgID string) []FeatureFlagResponse {
+	responses := make([]FeatureFlagResponse, len(flags))
+	for i, flag := range flags {
+		responses[i] = FeatureFlagFromValue(flag, orgID)
+	}
+	return responses
+}
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// HEARTBEAT REQUESTS
+// ===============================================
+
+// CreateHeartbeatRequest represents the request to create a heartbeat.
+// Severity defaults to high.
+type CreateHeartbeatRequest struct {
+	Name            string `json:"name" validate:"required,max=100"`
+	IntervalSeconds int    `json:"interval_seconds" validate:"required,min=60,max=2592000"`
+	GraceSeconds    int    `json:"grace_seconds" validate:"min=0,max=2592000"`
+	Severity        string `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	TeamID          string `json:"team_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// UpdateHeartbeatRequest represents the request to update a heartbeat.
+type UpdateHeartbeatRequest struct {
+	Name            *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	IntervalSeconds *int    `json:"interval_seconds,omitempty" validate:"omitempty,min=60,max=2592000"`
+	GraceSeconds    *int    `json:"grace_seconds,omitempty" validate:"omitempty,min=0,max=2592000"`
+	Severity        *string `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	IsEnabled       *bool   `json:"is_enabled,omitempty"`
+}
+
+// ===============================================
+// HEARTBEAT RESPONSES
+// ===============================================
+
+// HeartbeatResponse represents a heartbeat in API responses. Token and URL
+// are only present when a token is issued (creation or rotation).
+type HeartbeatResponse struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	IntervalSeconds int        `json:"interval_seconds"`
+	GraceSeconds    int        `json:"grace_seconds"`
+	Severity        string     `json:"severity"`
+	Status          string     `json:"status"`
+	IsEnabled       bool       `json:"is_enabled"`
+	LastPingAt      *time.Time `json:"last_ping_at,omitempty"`
+	NextPingDueAt   *time.Time `json:"next_ping_due_at,omitempty"`
+	TeamID          string     `json:"team_id,omitempty"`
+	Token           string     `json:"token,omitempty"`
+	URL             string     `json:"url,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// HeartbeatFromEntity converts a domain entity to a response DTO.
+func HeartbeatFromEntity(h *entity.Heartbeat) HeartbeatResponse {
+	response := HeartbeatResponse{
+		ID:              h.ID.String(),
+		Name:            h.Name,
+		IntervalSeconds: h.IntervalSeconds,
+		GraceSeconds:    h.GraceSeconds,
+		Severity:        string(h.Severity),
+		Status:          string(h.Status),
+		IsEnabled:       h.IsEnabled,
+		LastPingAt:      h.LastPingAt,
+		CreatedAt:       h.CreatedAt,
+		UpdatedAt:       h.UpdatedAt,
+	}
+
+	if deadline, ok := h.Deadline(); ok {
+		response.NextPingDueAt = &deadline
+	}
+	if h.TeamID != nil {
+		response.TeamID = h.TeamID.String()
+	}
+
+	return response
+}
+
+// HeartbeatsFromEntities converts a slice of entities to response DTOs.
+func HeartbeatsFromEntities(heartbeats []*entity.Heartbeat) []HeartbeatResponse {
+	result := make([]HeartbeatResponse, len(heartbeats))
+	for i, h := range heartbeats {
+		result[i] = HeartbeatFromEntity(h)
+	}
+	return result
+}
+
+// HeartbeatPingResponse acknowledges a heartbeat ping.
+type HeartbeatPingResponse struct {
+	Status        string     `json:"status"`
+	NextPingDueAt *time.Time `json:"next_ping_due_at,omitempty"`
+}
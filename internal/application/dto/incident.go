@@ -0,0 +1,147 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// INCIDENT REQUESTS
+// ===============================================
+
+// CreateIncidentRequest represents the request to open an incident.
+// Severity defaults to that of the most severe alert, or high without alerts.
+type CreateIncidentRequest struct {
+	Title       string   `json:"title" validate:"required,max=255"`
+	Summary     string   `json:"summary,omitempty" validate:"max=10000"`
+	Severity    string   `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	TeamID      string   `json:"team_id,omitempty" validate:"omitempty,uuid"`
+	CommanderID string   `json:"commander_id,omitempty" validate:"omitempty,uuid"`
+	AlertIDs    []string `json:"alert_ids,omitempty" validate:"omitempty,max=100,dive,uuid"`
+}
+
+// UpdateIncidentRequest represents the request to update an incident.
+type UpdateIncidentRequest struct {
+	Title    *string `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
+	Summary  *string `json:"summary,omitempty" validate:"omitempty,max=10000"`
+	Severity *string `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+}
+
+// IncidentTransitionRequest represents the optional note recorded with a
+// change of status of an incident.
+type IncidentTransitionRequest struct {
+	Note string `json:"note,omitempty" validate:"max=10000"`
+}
+
+// AssignCommanderRequest represents the request to assign the commander of
+// an incident. An empty user ID unassigns it.
+type AssignCommanderRequest struct {
+	UserID string `json:"user_id" validate:"omitempty,uuid"`
+}
+
+// AddIncidentAlertsRequest represents the request to group alerts into an incident.
+type AddIncidentAlertsRequest struct {
+	AlertIDs []string `json:"alert_ids" validate:"required,min=1,max=100,dive,uuid"`
+}
+
+// IncidentNoteRequest represents a note added to the timeline of an incident.
+type IncidentNoteRequest struct {
+	Message string `json:"message" validate:"required,max=10000"`
+}
+
+// ===============================================
+// INCIDENT RESPONSES
+// ===============================================
+
+// IncidentResponse represents an incident in API responses.
+type IncidentResponse struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Summary        string     `json:"summary,omitempty"`
+	Severity       string     `json:"severity"`
+	Status         string     `json:"status"`
+	CommanderID    string     `json:"commander_id,omitempty"`
+	TeamID         string     `json:"team_id,omitempty"`
+	CorrelationKey string     `json:"correlation_key,omitempty"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	MitigatedAt    *time.Time `json:"mitigated_at,omitempty"`
+	ClosedAt       *time.Time `json:"closed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// IncidentFromEntity converts a domain entity to a response DTO.
+func IncidentFromEntity(i *entity.Incident) IncidentResponse {
+	response := IncidentResponse{
+		ID:             i.ID.String(),
+		Title:          i.Title,
+		Summary:        i.Summary,
+		Severity:       string(i.Severity),
+		Status:         string(i.Status),
+		CorrelationKey: i.CorrelationKey,
+		MitigatedAt:    i.MitigatedAt,
+		ClosedAt:       i.ClosedAt,
+		CreatedAt:      i.CreatedAt,
+		UpdatedAt:      i.UpdatedAt,
+	}
+
+	if i.CommanderID != nil {
+		response.CommanderID = i.CommanderID.String()
+	}
+
+	if i.TeamID != nil {
+		response.TeamID = i.TeamID.String()
+	}
+
+	if i.CreatedBy != nil {
+		response.CreatedBy = i.CreatedBy.String()
+	}
+
+	return response
+}
+
+// IncidentsFromEntities converts a slice of entities to response DTOs.
+func IncidentsFromEntities(incidents []*entity.Incident) []IncidentResponse {
+	result := make([]IncidentResponse, len(incidents))
+	for i, incident := range incidents {
+		result[i] = IncidentFromEntity(incident)
+	}
+	return result
+}
+
+// IncidentEventResponse represents an entry of the timeline of an incident.
+type IncidentEventResponse struct {
+	ID         string    `json:"id"`
+	IncidentID string    `json:"incident_id"`
+	Kind       string    `json:"kind"`
+	Message    string    `json:"message"`
+	ActorID    string    `json:"actor_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// IncidentEventFromEntity converts a timeline entry to a response DTO.
+func IncidentEventFromEntity(e *entity.IncidentEvent) IncidentEventResponse {
+	response := IncidentEventResponse{
+		ID:         e.ID.String(),
+		IncidentID: e.IncidentID.String(),
+		Kind:       string(e.Kind),
+		Message:    e.Message,
+		CreatedAt:  e.CreatedAt,
+	}
+
+	if e.ActorID != nil {
+		response.ActorID = e.ActorID.String()
+	}
+
+	return response
+}
+
+// IncidentEventsFromEntities converts timeline entries to response DTOs.
+func IncidentEventsFromEntities(events []*entity.IncidentEvent) []IncidentEventResponse {
+	result := make([]IncidentEventResponse, len(events))
+	for i, e := range events {
+		result[i] = IncidentEventFromEntity(e)
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// MAINTENANCE REQUESTS
+// ===============================================
+
+// CreateMaintenanceWindowRequest represents the request to schedule a
+// maintenance window. Without a team the window is shared.
+type CreateMaintenanceWindowRequest struct {
+	Title       string    `json:"title" validate:"required,max=255"`
+	Description string    `json:"description,omitempty" validate:"max=10000"`
+	TeamID      string    `json:"team_id,omitempty" validate:"omitempty,uuid"`
+	Source      string    `json:"source,omitempty" validate:"max=255"`
+	StartsAt    time.Time `json:"starts_at" validate:"required"`
+	EndsAt      time.Time `json:"ends_at" validate:"required"`
+}
+
+// UpdateMaintenanceWindowRequest represents the request to update a
+// maintenance window. An empty source clears it.
+type UpdateMaintenanceWindowRequest struct {
+	Title       *string    `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
+	Description *string    `json:"description,omitempty" validate:"omitempty,max=10000"`
+	Source      *string    `json:"source,omitempty" validate:"omitempty,max=255"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+}
+
+// ===============================================
+// MAINTENANCE RESPONSES
+// ===============================================
+
+// MaintenanceWindowResponse represents a maintenance window in API responses.
+// The status is that of the time of the response.
+type MaintenanceWindowResponse struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	TeamID      string    `json:"team_id,omitempty"`
+	Source      string    `json:"source,omitempty"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Status      string    `json:"status"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// MaintenanceWindowFromEntity converts a domain entity to a response DTO.
+func MaintenanceWindowFromEntity(w *entity.MaintenanceWindow, now time.Time) MaintenanceWindowResponse {
+	response := MaintenanceWindowResponse{
+		ID:          w.ID.String(),
+		Title:       w.Title,
+		Description: w.Description,
+		Source:      w.Source,
+		StartsAt:    w.StartsAt,
+		EndsAt:      w.EndsAt,
+		Status:      string(w.Status(now)),
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+
+	if w.TeamID != nil {
+		response.TeamID = w.TeamID.String()
+	}
+
+	if w.CreatedBy != nil {
+		response.CreatedBy = w.CreatedBy.String()
+	}
+
+	return response
+}
+
+// MaintenanceCalendarResponse lists the maintenance windows overlapping a
+// period, by start.
+type MaintenanceCalendarResponse struct {
+	From    time.Time                   `json:"from"`
+	To      time.Time                   `json:"to"`
+	Windows []MaintenanceWindowResponse `json:"windows"`
+}
+
+// MaintenanceCalendarFromEntities converts the windows of a period to a
+// response DTO.
+func MaintenanceCalendarFromEntities(
+	from, to time.Time,
+	windows []*entity.MaintenanceWindow,
+	now time.Time,
+) MaintenanceCalendarResponse {
+	response := MaintenanceCalendarResponse{
+		From:    from,
+		To:      to,
+		Windows: make([]MaintenanceWindowResponse, len(windows)),
+	}
+	for i, window := range windows {
+		response.Windows[i] = MaintenanceWindowFromEntity(window, now)
+	}
+	return response
+}
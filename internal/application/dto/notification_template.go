@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// NOTIFICATION TEMPLATE REQUESTS
+// ===============================================
+
+// SetNotificationTemplateRequest represents the request to configure the
+// text/template content of a channel type's notifications. The same payload
+// is used to preview templates without saving them.
+type SetNotificationTemplateRequest struct {
+	Title string `json:"title" validate:"required,max=500"`
+	Body  string `json:"body" validate:"required,max=10000"`
+}
+
+// ===============================================
+// NOTIFICATION TEMPLATE RESPONSES
+// ===============================================
+
+// NotificationTemplateResponse represents the template of a channel type.
+type NotificationTemplateResponse struct {
+	Channel   string     `json:"channel"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	IsDefault bool       `json:"is_default"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// NotificationTemplatePreviewResponse represents templates rendered with sample data.
+type NotificationTemplatePreviewResponse struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// NotificationTemplateFromEntity converts a domain entity to a response DTO.
+func NotificationTemplateFromEntity(t *entity.NotificationTemplate) NotificationTemplateResponse {
+	response := NotificationTemplateResponse{
+		Channel:   string(t.Channel),
+		Title:     t.Title,
+		Body:      t.Body,
+		IsDefault: t.IsDefault(),
+	}
+
+	if !t.IsDefault() {
+		response.CreatedAt = &t.CreatedAt
+		response.UpdatedAt = &t.UpdatedAt
+	}
+
+	return response
+}
+
+// NotificationTemplatesFromEntities converts a slice of entities to response DTOs.
+func NotificationTemplatesFromEntities(templates []*entity.NotificationTemplate) []NotificationTemplateResponse {
+	result := make([]NotificationTemplateResponse, len(templates))
+	for i, t := range templates {
+		result[i] = NotificationTemplateFromEntity(t)
+	}
+	return result
+}
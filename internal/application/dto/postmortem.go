@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// POSTMORTEM REQUESTS
+// ===============================================
+
+// PostmortemActionItemRequest represents a follow-up task of a postmortem.
+type PostmortemActionItemRequest struct {
+	Description string     `json:"description" validate:"required,max=1000"`
+	OwnerID     string     `json:"owner_id,omitempty" validate:"omitempty,uuid"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	Done        bool       `json:"done"`
+}
+
+// CreatePostmortemRequest represents the request to write a postmortem.
+// The title defaults to that of the incident; a postmortem of an incident
+// belongs to the incident's team.
+type CreatePostmortemRequest struct {
+	IncidentID  string                        `json:"incident_id,omitempty" validate:"omitempty,uuid"`
+	TeamID      string                        `json:"team_id,omitempty" validate:"omitempty,uuid"`
+	Title       string                        `json:"title,omitempty" validate:"required_without=IncidentID,max=255"`
+	Summary     string                        `json:"summary,omitempty" validate:"max=50000"`
+	Impact      string                        `json:"impact,omitempty" validate:"max=50000"`
+	RootCause   string                        `json:"root_cause,omitempty" validate:"max=50000"`
+	ActionItems []PostmortemActionItemRequest `json:"action_items,omitempty" validate:"omitempty,max=50,dive"`
+	AlertIDs    []string                      `json:"alert_ids,omitempty" validate:"omitempty,max=100,dive,uuid"`
+}
+
+// UpdatePostmortemRequest represents the request to update a postmortem.
+// Action items and alerts, when given, replace the current ones.
+type UpdatePostmortemRequest struct {
+	Title       *string                       `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
+	Summary     *string                       `json:"summary,omitempty" validate:"omitempty,max=50000"`
+	Impact      *string                       `json:"impact,omitempty" validate:"omitempty,max=50000"`
+	RootCause   *string                       `json:"root_cause,omitempty" validate:"omitempty,max=50000"`
+	ActionItems []PostmortemActionItemRequest `json:"action_items,omitempty" validate:"omitempty,max=50,dive"`
+	AlertIDs    []string                      `json:"alert_ids,omitempty" validate:"omitempty,max=100,dive,uuid"`
+}
+
+// ===============================================
+// POSTMORTEM RESPONSES
+// ===============================================
+
+// PostmortemActionItemResponse represents a follow-up task of a postmortem.
+type PostmortemActionItemResponse struct {
+	Description string     `json:"description"`
+	OwnerID     string     `json:"owner_id,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	Done        bool       `json:"done"`
+}
+
+// PostmortemResponse represents a postmortem in API responses. Summary,
+// impact and root cause are markdown.
+type PostmortemResponse struct {
+	ID              string                         `json:"id"`
+	IncidentID      string                         `json:"incident_id,omitempty"`
+	TeamID          string                         `json:"team_id,omitempty"`
+	Title           string                         `json:"title"`
+	Summary         string                         `json:"summary,omitempty"`
+	Impact          string                         `json:"impact,omitempty"`
+	RootCause       string                         `json:"root_cause,omitempty"`
+	ActionItems     []PostmortemActionItemResponse `json:"action_items"`
+	OpenActionItems int                            `json:"open_action_items"`
+	AlertIDs        []string                       `json:"alert_ids"`
+	CreatedBy       string                         `json:"created_by,omitempty"`
+	CreatedAt       time.Time                      `json:"created_at"`
+	UpdatedAt       time.Time                      `json:"updated_at"`
+}
+
+// PostmortemFromEntity converts a domain entity to a response DTO.
+func PostmortemFromEntity(p *entity.Postmortem) PostmortemResponse {
+	response := PostmortemResponse{
+		ID:              p.ID.String(),
+		Title:           p.Title,
+		Summary:         p.Summary,
+		Impact:          p.Impact,
+		RootCause:       p.RootCause,
+		ActionItems:     make([]PostmortemActionItemResponse, len(p.ActionItems)),
+		OpenActionItems: p.OpenActionItems(),
+		AlertIDs:        make([]string, len(p.AlertIDs)),
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+
+	for i, item := range p.ActionItems {
+		response.ActionItems[i] = PostmortemActionItemResponse{
+			Description: item.Description,
+			DueDate:     item.DueDate,
+			Done:        item.Done,
+		}
+		if item.OwnerID != nil {
+			response.ActionItems[i].OwnerID = item.OwnerID.String()
+		}
+	}
+
+	for i, alertID := range p.AlertIDs {
+		response.AlertIDs[i] = alertID.String()
+	}
+
+	if p.IncidentID != nil {
+		response.IncidentID = p.IncidentID.String()
+	}
+
+	if p.TeamID != nil {
+		response.TeamID = p.TeamID.String()
+	}
+
+	if p.CreatedBy != nil {
+		response.CreatedBy = p.CreatedBy.String()
+	}
+
+	return response
+}
+
+// PostmortemsFromEntities converts a slice of entities to response DTOs.
+func PostmortemsFromEntities(postmortems []*entity.Postmortem) []PostmortemResponse {
+	result := make([]PostmortemResponse, len(postmortems))
+	for i, postmortem := range postmortems {
+		result[i] = PostmortemFromEntity(postmortem)
+	}
+	return result
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// QuotaUsageResponse is the usage of a daily quota. A zero limit is unlimited.
+type QuotaUsageResponse struct {
+	Subject   string    `json:"subject"`
+	Metric    string    `json:"metric"`
+	Used      int       `json:"used"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
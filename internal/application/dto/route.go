@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// ROUTE REQUESTS
+// ===============================================
+
+// RouteMatchRequest selects the notifications a route applies to.
+// Every set criterion must match; an empty matcher matches every notification.
+type RouteMatchRequest struct {
+	Severities []string          `json:"severities,omitempty" yaml:"severities,omitempty" validate:"omitempty,dive,oneof=critical high medium low info"`
+	Sources    []string          `json:"sources,omitempty" yaml:"sources,omitempty" validate:"omitempty,dive,min=1,max=100"`
+	Labels     map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	TeamID     string            `json:"team_id,omitempty" yaml:"team_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// RouteRequest represents the request to create or replace a notification route.
+// The parent is given by ID, or by external ID for routes managed by external tools.
+type RouteRequest struct {
+	Name             string            `json:"name" validate:"required,max=100"`
+	ParentID         string            `json:"parent_id,omitempty" validate:"omitempty,uuid"`
+	ParentExternalID string            `json:"parent_external_id,omitempty" validate:"omitempty,max=255,excluded_with=ParentID"`
+	Position         int               `json:"position"`
+	Match            RouteMatchRequest `json:"match"`
+	Channels         []string          `json:"channels" validate:"omitempty,dive,min=1,max=50"`
+	Continue         bool              `json:"continue"`
+}
+
+// ===============================================
+// ROUTE RESPONSES
+// ===============================================
+
+// RouteMatchResponse represents the matcher of a route.
+type RouteMatchResponse struct {
+	Severities []string          `json:"severities,omitempty"`
+	Sources    []string          `json:"sources,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	TeamID     string            `json:"team_id,omitempty"`
+}
+
+// RouteResponse represents a notification route. In the routing tree, Routes
+// holds the child routes in evaluation order.
+type RouteResponse struct {
+	ID         string             `json:"id"`
+	ExternalID string             `json:"external_id,omitempty"`
+	ParentID   string             `json:"parent_id,omitempty"`
+	Name       string             `json:"name"`
+	Position   int                `json:"position"`
+	Match      RouteMatchResponse `json:"match"`
+	Channels   []string           `json:"channels"`
+	Continue   bool               `json:"continue"`
+	Routes     []RouteResponse    `json:"routes,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+// RouteFromEntity converts a domain entity to a response DTO.
+func RouteFromEntity(r *entity.NotificationRoute) RouteResponse {
+	severities := make([]string, len(r.Match.Severities))
+	for i, severity := range r.Match.Severities {
+		severities[i] = string(severity)
+	}
+
+	response := RouteResponse{
+		ID:         r.ID.String(),
+		ExternalID: r.ExternalID,
+		Name:       r.Name,
+		Position:   r.Position,
+		Match: RouteMatchResponse{
+			Severities: severities,
+			Sources:    r.Match.Sources,
+			Labels:     r.Match.Labels,
+		},
+		Channels:  r.Channels,
+		Continue:  r.Continue,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+
+	if r.ParentID != nil {
+		response.ParentID = r.ParentID.String()
+	}
+	if r.Match.TeamID != nil {
+		response.Match.TeamID = r.Match.TeamID.String()
+	}
+
+	return response
+}
+
+// RouteTreeFromEntities nests a flat list of routes, ordered by position,
+// into a routing tree of response DTOs.
+func RouteTreeFromEntities(routes []*entity.NotificationRoute) []RouteResponse {
+	children := make(map[string][]*entity.NotificationRoute)
+	roots := make([]*entity.NotificationRoute, 0)
+	for _, r := range routes {
+		if r.ParentID == nil {
+			roots = append(roots, r)
+			continue
+		}
+		children[r.ParentID.String()] = append(children[r.ParentID.String()], r)
+	}
+
+	var build func(level []*entity.NotificationRoute) []RouteResponse
+	build = func(level []*entity.NotificationRoute) []RouteResponse {
+		result := make([]RouteResponse, len(level))
+		for i, r := range level {
+			result[i] = RouteFromEntity(r)
+			result[i].Routes = build(children[r.ID.String()])
+		}
+		return result
+	}
+
+	return build(roots)
+}
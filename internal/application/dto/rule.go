@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// RULE RESPONSES
+// ===============================================
+
+// RuleConditionResponse represents the condition that fires a rule, a
+// comparison or an expression tree over comparisons.
+type RuleConditionResponse struct {
+	Type          string                  `json:"type"`
+	Metric        string                  `json:"metric,omitempty"`
+	Operator      string                  `json:"operator,omitempty"`
+	Threshold     float64                 `json:"threshold"`
+	Deviations    float64                 `json:"deviations,omitempty"`
+	WindowSeconds int                     `json:"window_seconds,omitempty"`
+	ForSeconds    int                     `json:"for_seconds,omitempty"`
+	Conditions    []RuleConditionResponse `json:"conditions,omitempty"`
+	Consecutive   int                     `json:"consecutive"`
+	Expression    string                  `json:"expression"`
+}
+
+// RuleResponse represents an alert rule.
+type RuleResponse struct {
+	ID              string                `json:"id"`
+	ExternalID      string                `json:"external_id,omitempty"`
+	Name            string                `json:"name"`
+	Description     string                `json:"description,omitempty"`
+	Severity        string                `json:"severity"`
+	Enabled         bool                  `json:"enabled"`
+	CooldownMinutes int                   `json:"cooldown_minutes"`
+	TeamID          string                `json:"team_id,omitempty"`
+	Condition       RuleConditionResponse `json:"condition"`
+	RunbookURL      string                `json:"runbook_url,omitempty"`
+	Remediation     string                `json:"remediation,omitempty"`
+	CreateTicket    bool                  `json:"create_ticket"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+// RuleFromEntity converts a domain entity to a response DTO.
+func RuleFromEntity(r *entity.AlertRule) RuleResponse {
+	response := RuleResponse{
+		ID:              r.ID.String(),
+		ExternalID:      r.ExternalID,
+		Name:            r.Name,
+		Description:     r.Description,
+		Severity:        string(r.Severity),
+		Enabled:         r.IsEnabled,
+		CooldownMinutes: r.CooldownMinutes,
+		Condition:       RuleConditionFromEntity(r.Condition),
+		RunbookURL:      r.RunbookURL,
+		Remediation:     r.Remediation,
+		CreateTicket:    r.CreateTicket,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+
+	if r.TeamID != nil {
+		response.TeamID = r.TeamID.String()
+	}
+
+	return response
+}
+
+// RuleConditionFromEntity converts a rule condition, and its operands, to a
+// response DTO.
+func RuleConditionFromEntity(c entity.RuleCondition) RuleConditionResponse {
+	response := RuleConditionResponse{
+		Type:          c.Type,
+		Metric:        c.Metric,
+		Operator:      c.Operator,
+		Threshold:     c.Threshold,
+		Deviations:    c.Deviations,
+		WindowSeconds: c.WindowSeconds,
+		ForSeconds:    c.ForSeconds,
+		Consecutive:   c.Consecutive,
+		Expression:    c.String(),
+	}
+	if response.Type == "" {
+		response.Type = entity.ConditionThreshold
+	}
+	for _, operand := range c.Conditions {
+		response.Conditions = append(response.Conditions, RuleConditionFromEntity(operand))
+	}
+
+	return response
+}
+
+// ===============================================
+// RULE EVALUATION
+// ===============================================
+
+// MetricSampleRequest is a value of a metric for the series identified by its labels.
+type MetricSampleRequest struct {
+	Metric string            `json:"metric" validate:"required,max=255"`
+	Value  *float64          `json:"value" validate:"required"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// EvaluateRulesRequest represents samples to evaluate the enabled rules against.
+type EvaluateRulesRequest struct {
+	Samples []MetricSampleRequest `json:"samples" validate:"required,min=1,max=1000,dive"`
+}
+
+// RuleEvaluationResponse represents the outcome of evaluating a rule
+// against a sample: not_met, pending (fewer consecutive matches than
+// required), cooldown (fired recently for the series) or fired.
+type RuleEvaluationResponse struct {
+	RuleID  string            `json:"rule_id"`
+	Rule    string            `json:"rule"`
+	Metric  string            `json:"metric"`
+	Value   float64           `json:"value"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Outcome string            `json:"outcome"`
+	AlertID string            `json:"alert_id,omitempty"`
+}
+
+// EvaluateRulesResponse represents the outcomes of a rule evaluation.
+type EvaluateRulesResponse struct {
+	Evaluations []RuleEvaluationResponse `json:"evaluations"`
+	Fired       int                      `json:"fired"`
+}
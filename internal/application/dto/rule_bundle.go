@@ -0,0 +1,79 @@
+package dto
+
+// ===============================================
+// RULE BUNDLE REQUESTS
+// ===============================================
+
+// RuleBundleRequest declares the desired alert rules and notification routes,
+// in YAML or JSON. Each section that is present is authoritative: resources
+// it does not list are deleted. An omitted section leaves its resources as
+// they are. Resources are matched by name.
+type RuleBundleRequest struct {
+	Rules  *[]RuleSpecRequest  `json:"rules,omitempty" yaml:"rules,omitempty" validate:"omitempty,dive"`
+	Routes *[]RouteSpecRequest `json:"routes,omitempty" yaml:"routes,omitempty" validate:"omitempty,dive"`
+}
+
+// RuleConditionRequest is the condition that fires a rule: a comparison of
+// a metric (threshold, the default type), of its rate of change (rate), of
+// its deviation from its mean over WindowSeconds (anomaly) or of its absence
+// for ForSeconds (absent), or an and, or or not over Conditions.
+type RuleConditionRequest struct {
+	Type          string                 `json:"type,omitempty" yaml:"type,omitempty" validate:"omitempty,oneof=threshold rate anomaly absent and or not"`
+	Metric        string                 `json:"metric,omitempty" yaml:"metric,omitempty" validate:"max=255"`
+	Operator      string                 `json:"operator,omitempty" yaml:"operator,omitempty" validate:"omitempty,oneof=> < == >= <= !="`
+	Threshold     float64                `json:"threshold" yaml:"threshold"`
+	Deviations    float64                `json:"deviations,omitempty" yaml:"deviations,omitempty" validate:"min=0"`
+	WindowSeconds int                    `json:"window_seconds,omitempty" yaml:"window_seconds,omitempty" validate:"min=0,max=86400"`
+	ForSeconds    int                    `json:"for_seconds,omitempty" yaml:"for_seconds,omitempty" validate:"min=0,max=86400"`
+	Conditions    []RuleConditionRequest `json:"conditions,omitempty" yaml:"conditions,omitempty" validate:"omitempty,max=20,dive"`
+	Consecutive   int                    `json:"consecutive,omitempty" yaml:"consecutive,omitempty" validate:"min=0"`
+}
+
+// RuleSpecRequest declares an alert rule. Enabled defaults to true and
+// CooldownMinutes to 5.
+type RuleSpecRequest struct {
+	Name            string               `json:"name" yaml:"name" validate:"required,max=255"`
+	Description     string               `json:"description,omitempty" yaml:"description,omitempty"`
+	Severity        string               `json:"severity" yaml:"severity" validate:"required,oneof=critical high medium low info"`
+	Enabled         *bool                `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	CooldownMinutes *int                 `json:"cooldown_minutes,omitempty" yaml:"cooldown_minutes,omitempty" validate:"omitempty,min=0,max=1440"`
+	TeamID          string               `json:"team_id,omitempty" yaml:"team_id,omitempty" validate:"omitempty,uuid"`
+	Condition       RuleConditionRequest `json:"condition" yaml:"condition"`
+	RunbookURL      string               `json:"runbook_url,omitempty" yaml:"runbook_url,omitempty" validate:"omitempty,url,max=2048"`
+	Remediation     string               `json:"remediation,omitempty" yaml:"remediation,omitempty" validate:"max=4000"`
+	CreateTicket    bool                 `json:"create_ticket,omitempty" yaml:"create_ticket,omitempty"`
+}
+
+// RouteSpecRequest declares a notification route. Parent is the name of the
+// parent route, declared in the same bundle. Position defaults to the order
+// of the route in the bundle.
+type RouteSpecRequest struct {
+	Name     string            `json:"name" yaml:"name" validate:"required,max=100"`
+	Parent   string            `json:"parent,omitempty" yaml:"parent,omitempty" validate:"omitempty,max=100"`
+	Position *int              `json:"position,omitempty" yaml:"position,omitempty"`
+	Match    RouteMatchRequest `json:"match" yaml:"match"`
+	Channels []string          `json:"channels" yaml:"channels" validate:"omitempty,dive,min=1,max=50"`
+	Continue bool              `json:"continue" yaml:"continue"`
+}
+
+// ===============================================
+// RULE BUNDLE RESPONSES
+// ===============================================
+
+// BundleChangeResponse describes a change made, or to be made, by a bundle.
+type BundleChangeResponse struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+// RuleBundleResponse reports the changes made by applying a bundle.
+// When DryRun is true nothing was changed and Changes is what would have been.
+type RuleBundleResponse struct {
+	DryRun    bool                   `json:"dry_run"`
+	Created   int                    `json:"created"`
+	Updated   int                    `json:"updated"`
+	Deleted   int                    `json:"deleted"`
+	Unchanged int                    `json:"unchanged"`
+	Changes   []BundleChangeResponse `json:"changes"`
+}
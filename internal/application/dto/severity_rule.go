@@ -0,0 +1,131 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// SEVERITY RULE REQUESTS
+// ===============================================
+
+// SeverityRuleMatchRequest represents the alerts a severity rule applies to.
+// Every set criterion must match; at least one must be set.
+type SeverityRuleMatchRequest struct {
+	Sources      []string          `json:"sources,omitempty" validate:"omitempty,max=50,dive,min=1,max=100"`
+	Severities   []string          `json:"severities,omitempty" validate:"omitempty,dive,oneof=critical high medium low info"`
+	TitlePattern string            `json:"title_pattern,omitempty" validate:"omitempty,max=255"`
+	Labels       map[string]string `json:"labels,omitempty" validate:"omitempty,max=20"`
+}
+
+// CreateSeverityRuleRequest represents the request to create a severity rule.
+// Rules are evaluated by ascending position.
+type CreateSeverityRuleRequest struct {
+	Name     string                   `json:"name" validate:"required,max=100"`
+	Position int                      `json:"position" validate:"min=0"`
+	Match    SeverityRuleMatchRequest `json:"match"`
+	Severity string                   `json:"severity" validate:"required,oneof=critical high medium low info"`
+}
+
+// UpdateSeverityRuleRequest represents the request to update a severity rule.
+type UpdateSeverityRuleRequest struct {
+	Name      *string                   `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Position  *int                      `json:"position,omitempty" validate:"omitempty,min=0"`
+	Match     *SeverityRuleMatchRequest `json:"match,omitempty"`
+	Severity  *string                   `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	IsEnabled *bool                     `json:"is_enabled,omitempty"`
+}
+
+// SeverityRuleDryRunRequest represents a sample alert evaluated against the
+// severity rules.
+type SeverityRuleDryRunRequest struct {
+	Title    string            `json:"title" validate:"required,max=255"`
+	Source   string            `json:"source,omitempty" validate:"max=100"`
+	Severity string            `json:"severity" validate:"required,oneof=critical high medium low info"`
+	Labels   map[string]string `json:"labels,omitempty" validate:"omitempty,max=50"`
+}
+
+// ToEntity converts the request to a severity rule matcher.
+func (r SeverityRuleMatchRequest) ToEntity() entity.SeverityRuleMatcher {
+	match := entity.SeverityRuleMatcher{
+		Sources:      r.Sources,
+		TitlePattern: r.TitlePattern,
+		Labels:       r.Labels,
+	}
+	for _, severity := range r.Severities {
+		match.Severities = append(match.Severities, entity.AlertSeverity(severity))
+	}
+	return match
+}
+
+// ===============================================
+// SEVERITY RULE RESPONSES
+// ===============================================
+
+// SeverityRuleMatchResponse represents the alerts a severity rule applies to.
+type SeverityRuleMatchResponse struct {
+	Sources      []string          `json:"sources,omitempty"`
+	Severities   []string          `json:"severities,omitempty"`
+	TitlePattern string            `json:"title_pattern,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+// SeverityRuleResponse represents a severity rule in API responses.
+type SeverityRuleResponse struct {
+	ID        string                    `json:"id"`
+	Name      string                    `json:"name"`
+	Position  int                       `json:"position"`
+	Match     SeverityRuleMatchResponse `json:"match"`
+	Severity  string                    `json:"severity"`
+	IsEnabled bool                      `json:"is_enabled"`
+	CreatedBy string                    `json:"created_by,omitempty"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+// SeverityRuleDryRunResponse represents the outcome of a dry run: the rule
+// that would apply to the sample alert, if any, and its resulting severity.
+type SeverityRuleDryRunResponse struct {
+	Matched          bool                  `json:"matched"`
+	Rule             *SeverityRuleResponse `json:"rule,omitempty"`
+	OriginalSeverity string                `json:"original_severity"`
+	Severity         string                `json:"severity"`
+}
+
+// SeverityRuleFromEntity converts a domain entity to a response DTO.
+func SeverityRuleFromEntity(r *entity.SeverityRule) SeverityRuleResponse {
+	response := SeverityRuleResponse{
+		ID:       r.ID.String(),
+		Name:     r.Name,
+		Position: r.Position,
+		Match: SeverityRuleMatchResponse{
+			Sources:      r.Match.Sources,
+			TitlePattern: r.Match.TitlePattern,
+			Labels:       r.Match.Labels,
+		},
+		Severity:  string(r.Severity),
+		IsEnabled: r.IsEnabled,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+
+	for _, severity := range r.Match.Severities {
+		response.Match.Severities = append(response.Match.Severities, string(severity))
+	}
+
+	if r.CreatedBy != nil {
+		response.CreatedBy = r.CreatedBy.String()
+	}
+
+	return response
+}
+
+// SeverityRulesFromEntities converts a slice of domain entities to response DTOs.
+func SeverityRulesFromEntities(rules []*entity.SeverityRule) []SeverityRuleResponse {
+	responses := make([]SeverityRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = SeverityRuleFromEntity(rule)
+	}
+	return responses
+}
@@ -0,0 +1,150 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// ===============================================
+// SLA REQUESTS
+// ===============================================
+
+// CreateSLAPolicyRequest represents the request to create an SLA policy.
+// The policy applies to the alerts of the severity, team and source given,
+// any of them when omitted; breach severity defaults to high.
+type CreateSLAPolicyRequest struct {
+	Name                 string `json:"name" validate:"required,max=100"`
+	Severity             string `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	TeamID               string `json:"team_id,omitempty" validate:"omitempty,uuid"`
+	Source               string `json:"source,omitempty" validate:"max=255"`
+	AckWithinSeconds     int    `json:"ack_within_seconds" validate:"min=0,max=2592000"`
+	ResolveWithinSeconds int    `json:"resolve_within_seconds" validate:"min=0,max=2592000"`
+	BreachSeverity       string `json:"breach_severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+}
+
+// UpdateSLAPolicyRequest represents the request to update an SLA policy. An
+// empty severity or source makes the policy apply to any; a zero target
+// removes it.
+type UpdateSLAPolicyRequest struct {
+	Name                 *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Severity             *string `json:"severity,omitempty" validate:"omitempty,max=20"`
+	Source               *string `json:"source,omitempty" validate:"omitempty,max=255"`
+	AckWithinSeconds     *int    `json:"ack_within_seconds,omitempty" validate:"omitempty,min=0,max=2592000"`
+	ResolveWithinSeconds *int    `json:"resolve_within_seconds,omitempty" validate:"omitempty,min=0,max=2592000"`
+	BreachSeverity       *string `json:"breach_severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	IsEnabled            *bool   `json:"is_enabled,omitempty"`
+}
+
+// ===============================================
+// SLA RESPONSES
+// ===============================================
+
+// SLAPolicyResponse represents an SLA policy in API responses.
+type SLAPolicyResponse struct {
+	ID                   string    `json:"id"`
+	Name                 string    `json:"name"`
+	Severity             string    `json:"severity,omitempty"`
+	TeamID               string    `json:"team_id,omitempty"`
+	Source               string    `json:"source,omitempty"`
+	AckWithinSeconds     int       `json:"ack_within_seconds,omitempty"`
+	ResolveWithinSeconds int       `json:"resolve_within_seconds,omitempty"`
+	BreachSeverity       string    `json:"breach_severity"`
+	IsEnabled            bool      `json:"is_enabled"`
+	CreatedBy            string    `json:"created_by,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// SLAPolicyFromEntity converts a domain entity to a response DTO.
+func SLAPolicyFromEntity(p *entity.SLAPolicy) SLAPolicyResponse {
+	response := SLAPolicyResponse{
+		ID:                   p.ID.String(),
+		Name:                 p.Name,
+		Severity:             string(p.Severity),
+		Source:               p.Source,
+		AckWithinSeconds:     p.AckWithinSeconds,
+		ResolveWithinSeconds: p.ResolveWithinSeconds,
+		BreachSeverity:       string(p.BreachSeverity),
+		IsEnabled:            p.IsEnabled,
+		CreatedAt:            p.CreatedAt,
+		UpdatedAt:            p.UpdatedAt,
+	}
+
+	if p.TeamID != nil {
+		response.TeamID = p.TeamID.String()
+	}
+
+	if p.CreatedBy != nil {
+		response.CreatedBy = p.CreatedBy.String()
+	}
+
+	return response
+}
+
+// SLAPoliciesFromEntities converts a slice of entities to response DTOs.
+func SLAPoliciesFromEntities(policies []*entity.SLAPolicy) []SLAPolicyResponse {
+	result := make([]SLAPolicyResponse, len(policies))
+	for i, policy := range policies {
+		result[i] = SLAPolicyFromEntity(policy)
+	}
+	return result
+}
+
+// SLAReportResponse reports how the alerts created within a period met the
+// targets of SLA policies.
+type SLAReportResponse struct {
+	From     time.Time                 `json:"from"`
+	To       time.Time                 `json:"to"`
+	Policies []SLAPolicyReportResponse `json:"policies"`
+}
+
+// SLAPolicyReportResponse reports how alerts met the targets of a policy. A
+// target the policy does not set is omitted.
+type SLAPolicyReportResponse struct {
+	Policy  SLAPolicyResponse        `json:"policy"`
+	Ack     *SLATargetReportResponse `json:"ack,omitempty"`
+	Resolve *SLATargetReportResponse `json:"resolve,omitempty"`
+}
+
+// SLATargetReportResponse reports how alerts met a target. Pending alerts
+// are not due yet; the compliance is the percentage of the others that met
+// the target, and the mean is the time taken by those that met it, late or
+// not.
+type SLATargetReportResponse struct {
+	TargetSeconds     int     `json:"target_seconds"`
+	TotalAlerts       int64   `json:"total_alerts"`
+	MetAlerts         int64   `json:"met_alerts"`
+	BreachedAlerts    int64   `json:"breached_alerts"`
+	PendingAlerts     int64   `json:"pending_alerts"`
+	CompliancePercent float64 `json:"compliance_percent"`
+	MeanSeconds       float64 `json:"mean_seconds"`
+}
+
+// SLAPolicyReportFromCompliance converts the compliance with a policy to a
+// response DTO.
+func SLAPolicyReportFromCompliance(p *entity.SLAPolicy, compliance *repository.SLACompliance) SLAPolicyReportResponse {
+	return SLAPolicyReportResponse{
+		Policy:  SLAPolicyFromEntity(p),
+		Ack:     slaTargetReport(p.AckWithinSeconds, compliance.Ack),
+		Resolve: slaTargetReport(p.ResolveWithinSeconds, compliance.Resolve),
+	}
+}
+
+// slaTargetReport converts the compliance with a target, nil when the
+// policy does not set it.
+func slaTargetReport(targetSeconds int, compliance *repository.SLATargetCompliance) *SLATargetReportResponse {
+	if compliance == nil {
+		return nil
+	}
+	return &SLATargetReportResponse{
+		TargetSeconds:     targetSeconds,
+		TotalAlerts:       compliance.TotalAlerts,
+		MetAlerts:         compliance.MetAlerts,
+		BreachedAlerts:    compliance.BreachedAlerts,
+		PendingAlerts:     compliance.PendingAlerts,
+		CompliancePercent: compliance.CompliancePercent,
+		MeanSeconds:       compliance.MeanSeconds,
+	}
+}
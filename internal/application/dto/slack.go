@@ -0,0 +1,37 @@
+package dto
+
+// ===============================================
+// SLACK INTERACTION REQUESTS
+// ===============================================
+
+// SlackInteractionPayload represents the interaction Slack sends, as the
+// JSON "payload" form field, when a button of a message is clicked.
+type SlackInteractionPayload struct {
+	Type        string             `json:"type"`
+	User        SlackUser          `json:"user"`
+	Actions     []SlackActionValue `json:"actions"`
+	ResponseURL string             `json:"response_url"`
+}
+
+// SlackUser identifies the Slack user of an interaction.
+type SlackUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// SlackActionValue represents a clicked button.
+type SlackActionValue struct {
+	ActionID string `json:"action_id"`
+	Value    string `json:"value"`
+}
+
+// ===============================================
+// SLACK COMMAND RESPONSES
+// ===============================================
+
+// SlackCommandResponse represents the reply to a slash command, shown only
+// to the user who typed it.
+type SlackCommandResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
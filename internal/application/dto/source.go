@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// SOURCE REQUESTS
+// ===============================================
+
+// CreateSourceRequest represents the request to register a source.
+// Severity defaults to high; an expected heartbeat of 0 lets the source stay
+// silent.
+type CreateSourceRequest struct {
+	Name                     string `json:"name" validate:"required,max=100"`
+	Type                     string `json:"type" validate:"required,oneof=api webhook grafana datadog sentry cloudwatch other"`
+	Description              string `json:"description,omitempty" validate:"max=500"`
+	ExpectedHeartbeatSeconds int    `json:"expected_heartbeat_seconds" validate:"min=0,max=2592000"`
+	Severity                 string `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	TeamID                   string `json:"team_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// UpdateSourceRequest represents the request to update a source.
+type UpdateSourceRequest struct {
+	Name                     *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Type                     *string `json:"type,omitempty" validate:"omitempty,oneof=api webhook grafana datadog sentry cloudwatch other"`
+	Description              *string `json:"description,omitempty" validate:"omitempty,max=500"`
+	ExpectedHeartbeatSeconds *int    `json:"expected_heartbeat_seconds,omitempty" validate:"omitempty,min=0,max=2592000"`
+	Severity                 *string `json:"severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+}
+
+// ===============================================
+// SOURCE RESPONSES
+// ===============================================
+
+// SourceResponse represents a registered source in API responses, with the
+// activity of its alerts when listed.
+type SourceResponse struct {
+	ID                       string     `json:"id"`
+	Name                     string     `json:"name"`
+	Type                     string     `json:"type"`
+	Description              string     `json:"description,omitempty"`
+	ExpectedHeartbeatSeconds int        `json:"expected_heartbeat_seconds"`
+	Severity                 string     `json:"severity"`
+	Status                   string     `json:"status"`
+	SilentSince              *time.Time `json:"silent_since,omitempty"`
+	LastAlertAt              *time.Time `json:"last_alert_at,omitempty"`
+	AlertsLastHour           int64      `json:"alerts_last_hour"`
+	AlertsLastDay            int64      `json:"alerts_last_day"`
+	TeamID                   string     `json:"team_id,omitempty"`
+	CreatedAt                time.Time  `json:"created_at"`
+	UpdatedAt                time.Time  `json:"updated_at"`
+}
+
+// SourceFromEntity converts a domain entity to a response DTO.
+func SourceFromEntity(s *entity.Source) SourceResponse {
+	response := SourceResponse{
+		ID:                       s.ID.String(),
+		Name:                     s.Name,
+		Type:                     string(s.Type),
+		Description:              s.Description,
+		ExpectedHeartbeatSeconds: s.ExpectedHeartbeatSeconds,
+		Severity:                 string(s.Severity),
+		Status:                   string(s.Status),
+		SilentSince:              s.SilentSince,
+		CreatedAt:                s.CreatedAt,
+		UpdatedAt:                s.UpdatedAt,
+	}
+
+	if s.TeamID != nil {
+		response.TeamID = s.TeamID.String()
+	}
+
+	return response
+}
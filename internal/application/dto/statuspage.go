@@ -0,0 +1,48 @@
+package dto
+
+import "github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+
+// ===============================================
+// STATUS PAGE REQUESTS
+// ===============================================
+
+// SetComponentOverrideRequest represents the request to override the status
+// of a status page component.
+type SetComponentOverrideRequest struct {
+	Status string `json:"status" validate:"required,oneof=operational degraded_performance partial_outage major_outage under_maintenance"`
+}
+
+// ===============================================
+// STATUS PAGE RESPONSES
+// ===============================================
+
+// StatusPageComponentResponse represents the state of a status page
+// component: the status derived from the alerts of its sources, the status
+// set by hand if any, and the resulting status published.
+type StatusPageComponentResponse struct {
+	ID          string   `json:"id"`
+	Sources     []string `json:"sources"`
+	AlertStatus string   `json:"alert_status"`
+	Override    string   `json:"override,omitempty"`
+	Status      string   `json:"status"`
+}
+
+// StatusPageComponentFromValue converts a component state to a response DTO.
+func StatusPageComponentFromValue(c valueobject.StatusPageComponent) StatusPageComponentResponse {
+	return StatusPageComponentResponse{
+		ID:          c.ID,
+		Sources:     c.Sources,
+		AlertStatus: string(c.AlertStatus),
+		Override:    string(c.Override),
+		Status:      string(c.Status),
+	}
+}
+
+// StatusPageComponentsFromValues converts component states to response DTOs.
+func StatusPageComponentsFromValues(components []valueobject.StatusPageComponent) []StatusPageComponentResponse {
+	responses := make([]StatusPageComponentResponse, len(components))
+	for i, c := range components {
+		responses[i] = StatusPageComponentFromValue(c)
+	}
+	return responses
+}
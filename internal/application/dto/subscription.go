@@ -0,0 +1,194 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// ===============================================
+// SUBSCRIPTION REQUESTS
+// ===============================================
+
+// SubscriptionFilterRequest represents the alerts whose events are pushed
+// to a subscription. Every set criterion must match; an empty filter
+// matches every alert.
+type SubscriptionFilterRequest struct {
+	Severities []string          `json:"severities,omitempty" validate:"omitempty,dive,oneof=critical high medium low info"`
+	Sources    []string          `json:"sources,omitempty" validate:"omitempty,max=50,dive,min=1,max=100"`
+	Labels     map[string]string `json:"labels,omitempty" validate:"omitempty,max=20"`
+}
+
+// CreateSubscriptionRequest represents the request to register an outbound
+// webhook subscription. An empty secret is generated; the secret is only
+// returned in this response.
+type CreateSubscriptionRequest struct {
+	Name       string                    `json:"name" validate:"required,max=100"`
+	URL        string                    `json:"url" validate:"required,url,max=2048"`
+	Secret     string                    `json:"secret,omitempty" validate:"omitempty,min=32,max=255"`
+	EventTypes []string                  `json:"event_types" validate:"required,min=1,dive,oneof=alert.created alert.acknowledged alert.resolved alert.deleted alert.expired"`
+	Filter     SubscriptionFilterRequest `json:"filter"`
+}
+
+// UpdateSubscriptionRequest represents the request to update a subscription.
+// Setting secret rotates it; an empty secret is replaced by a generated one,
+// returned in this response.
+type UpdateSubscriptionRequest struct {
+	Name       *string                    `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	URL        *string                    `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	Secret     *string                    `json:"secret,omitempty" validate:"omitempty,max=255"`
+	EventTypes []string                   `json:"event_types,omitempty" validate:"omitempty,min=1,dive,oneof=alert.created alert.acknowledged alert.resolved alert.deleted alert.expired"`
+	Filter     *SubscriptionFilterRequest `json:"filter,omitempty"`
+	IsEnabled  *bool                      `json:"is_enabled,omitempty"`
+}
+
+// ToEntity converts the request to a subscription filter.
+func (r SubscriptionFilterRequest) ToEntity() entity.SubscriptionFilter {
+	filter := entity.SubscriptionFilter{
+		Sources: r.Sources,
+		Labels:  r.Labels,
+	}
+	for _, severity := range r.Severities {
+		filter.Severities = append(filter.Severities, entity.AlertSeverity(severity))
+	}
+	return filter
+}
+
+// ===============================================
+// SUBSCRIPTION RESPONSES
+// ===============================================
+
+// SubscriptionFilterResponse represents the alerts whose events are pushed
+// to a subscription.
+type SubscriptionFilterResponse struct {
+	Severities []string          `json:"severities,omitempty"`
+	Sources    []string          `json:"sources,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// SubscriptionResponse represents a subscription in API responses. The
+// secret is only set when it was just created or rotated.
+type SubscriptionResponse struct {
+	ID         string                     `json:"id"`
+	Name       string                     `json:"name"`
+	URL        string                     `json:"url"`
+	Secret     string                     `json:"secret,omitempty"`
+	EventTypes []string                   `json:"event_types"`
+	Filter     SubscriptionFilterResponse `json:"filter"`
+	IsEnabled  bool                       `json:"is_enabled"`
+	CreatedBy  string                     `json:"created_by,omitempty"`
+	CreatedAt  time.Time                  `json:"created_at"`
+	UpdatedAt  time.Time                  `json:"updated_at"`
+}
+
+// SubscriptionFromEntity converts a domain entity to a response DTO, without
+// its secret.
+func SubscriptionFromEntity(s *entity.Subscription) SubscriptionResponse {
+	response := SubscriptionResponse{
+		ID:         s.ID.String(),
+		Name:       s.Name,
+		URL:        s.URL,
+		EventTypes: s.EventTypes,
+		Filter: SubscriptionFilterResponse{
+			Sources: s.Filter.Sources,
+			Labels:  s.Filter.Labels,
+		},
+		IsEnabled: s.IsEnabled,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+
+	for _, severity := range s.Filter.Severities {
+		response.Filter.Severities = append(response.Filter.Severities, string(severity))
+	}
+	if s.CreatedBy != nil {
+		response.CreatedBy = s.CreatedBy.String()
+	}
+
+	return response
+}
+
+// SubscriptionsFromEntities converts a slice of domain entities to response DTOs.
+func SubscriptionsFromEntities(subscriptions []*entity.Subscription) []SubscriptionResponse {
+	responses := make([]SubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = SubscriptionFromEntity(subscription)
+	}
+	return responses
+}
+
+// SubscriptionStatsResponse represents the delivery statistics of a subscription.
+type SubscriptionStatsResponse struct {
+	Subscription    SubscriptionResponse `json:"subscription"`
+	Pending         int64                `json:"pending"`
+	Delivered       int64                `json:"delivered"`
+	Failed          int64                `json:"failed"`
+	Attempts        int64                `json:"attempts"`
+	LastDeliveredAt *time.Time           `json:"last_delivered_at,omitempty"`
+	LastError       string               `json:"last_error,omitempty"`
+}
+
+// SubscriptionStatsFromEntity converts a subscription and its delivery
+// statistics to a response DTO.
+func SubscriptionStatsFromEntity(s *entity.Subscription, stats repository.SubscriptionStats) SubscriptionStatsResponse {
+	return SubscriptionStatsResponse{
+		Subscription:    SubscriptionFromEntity(s),
+		Pending:         stats.Pending,
+		Delivered:       stats.Delivered,
+		Failed:          stats.Failed,
+		Attempts:        stats.Attempts,
+		LastDeliveredAt: stats.LastDeliveredAt,
+		LastError:       stats.LastError,
+	}
+}
+
+// SubscriptionDeliveryResponse represents the delivery of an event to a
+// subscription in API responses.
+type SubscriptionDeliveryResponse struct {
+	ID             string          `json:"id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// SubscriptionDeliveryFromEntity converts a domain entity to a response DTO.
+// The next attempt is only reported while the delivery is pending.
+func SubscriptionDeliveryFromEntity(d *entity.SubscriptionDelivery) SubscriptionDeliveryResponse {
+	response := SubscriptionDeliveryResponse{
+		ID:             d.ID.String(),
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+
+	if d.Status == entity.DeliveryStatusPending {
+		nextAttemptAt := d.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	return response
+}
+
+// SubscriptionDeliveriesFromEntities converts a slice of domain entities to response DTOs.
+func SubscriptionDeliveriesFromEntities(deliveries []*entity.SubscriptionDelivery) []SubscriptionDeliveryResponse {
+	responses := make([]SubscriptionDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = SubscriptionDeliveryFromEntity(delivery)
+	}
+	return responses
+}
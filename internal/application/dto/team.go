@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// TEAM REQUESTS
+// ===============================================
+
+// CreateTeamRequest represents the request to create a team.
+type CreateTeamRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description,omitempty" validate:"max=500"`
+}
+
+// UpdateTeamRequest represents the request to update a team.
+// Omitted fields keep their current value.
+type UpdateTeamRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=500"`
+}
+
+// SetTeamMemberRequest represents the request to add a team member or change their role.
+type SetTeamMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner member"`
+}
+
+// ===============================================
+// TEAM RESPONSES
+// ===============================================
+
+// TeamResponse represents a team in API responses.
+type TeamResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TeamFromEntity converts a domain entity to a response DTO.
+func TeamFromEntity(t *entity.Team) TeamResponse {
+	return TeamResponse{
+		ID:          t.ID.String(),
+		Name:        t.Name,
+		Description: t.Description,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// TeamsFromEntities converts a slice of teams to response DTOs.
+func TeamsFromEntities(teams []*entity.Team) []TeamResponse {
+	result := make([]TeamResponse, len(teams))
+	for i, t := range teams {
+		result[i] = TeamFromEntity(t)
+	}
+	return result
+}
+
+// TeamMemberResponse represents a team membership in API responses.
+type TeamMemberResponse struct {
+	TeamID   string    `json:"team_id"`
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// TeamMemberFromEntity converts a domain entity to a response DTO.
+func TeamMemberFromEntity(m *entity.TeamMember) TeamMemberResponse {
+	return TeamMemberResponse{
+		TeamID:   m.TeamID.String(),
+		UserID:   m.UserID.String(),
+		Role:     string(m.Role),
+		JoinedAt: m.JoinedAt,
+	}
+}
+
+// TeamMembersFromEntities converts a slice of memberships to response DTOs.
+func TeamMembersFromEntities(members []*entity.TeamMember) []TeamMemberResponse {
+	result := make([]TeamMemberResponse, len(members))
+	for i, m := range members {
+		result[i] = TeamMemberFromEntity(m)
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package dto
+
+// ===============================================
+// TELEGRAM WEBHOOK REQUESTS
+// ===============================================
+
+// TelegramUpdate represents an update Telegram sends to the bot's webhook.
+// Only callback queries, sent when a button of a message is clicked, are
+// handled.
+type TelegramUpdate struct {
+	UpdateID      int64                  `json:"update_id"`
+	CallbackQuery *TelegramCallbackQuery `json:"callback_query,omitempty"`
+}
+
+// TelegramCallbackQuery represents a click on a button of a message.
+type TelegramCallbackQuery struct {
+	ID      string           `json:"id"`
+	From    TelegramUser     `json:"from"`
+	Message *TelegramMessage `json:"message,omitempty"`
+	Data    string           `json:"data"`
+}
+
+// TelegramUser identifies the Telegram user of a callback query.
+type TelegramUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// TelegramMessage identifies the message a clicked button belongs to.
+type TelegramMessage struct {
+	MessageID int64        `json:"message_id"`
+	Chat      TelegramChat `json:"chat"`
+}
+
+// TelegramChat identifies a Telegram chat.
+type TelegramChat struct {
+	ID int64 `json:"id"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+// ===============================================
+// TICKET RESPONSES
+// ===============================================
+
+// TicketResponse represents the issue filed for an alert.
+type TicketResponse struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// TOPOLOGY REQUESTS
+// ===============================================
+
+// CreateSourceDependencyRequest represents the request to declare that a
+// source depends on another, e.g. a service on its database.
+type CreateSourceDependencyRequest struct {
+	Source    string `json:"source" validate:"required,max=255"`
+	DependsOn string `json:"depends_on" validate:"required,max=255"`
+}
+
+// ===============================================
+// TOPOLOGY RESPONSES
+// ===============================================
+
+// SourceDependencyResponse represents a source dependency in API responses.
+type SourceDependencyResponse struct {
+	ID        string    `json:"id"`
+	Source    string    `json:"source"`
+	DependsOn string    `json:"depends_on"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SourceDependencyFromEntity converts a domain entity to a response DTO.
+func SourceDependencyFromEntity(d *entity.SourceDependency) SourceDependencyResponse {
+	response := SourceDependencyResponse{
+		ID:        d.ID.String(),
+		Source:    d.Source,
+		DependsOn: d.DependsOn,
+		CreatedAt: d.CreatedAt,
+	}
+
+	if d.CreatedBy != nil {
+		response.CreatedBy = d.CreatedBy.String()
+	}
+
+	return response
+}
+
+// SourceDependenciesFromEntities converts a slice of entities to response DTOs.
+func SourceDependenciesFromEntities(dependencies []*entity.SourceDependency) []SourceDependencyResponse {
+	result := make([]SourceDependencyResponse, len(dependencies))
+	for i, dependency := range dependencies {
+		result[i] = SourceDependencyFromEntity(dependency)
+	}
+	return result
+}
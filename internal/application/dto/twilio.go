@@ -0,0 +1,14 @@
+package dto
+
+import "encoding/xml"
+
+// ===============================================
+// TWILIO VOICE RESPONSES
+// ===============================================
+
+// TwilioVoiceResponse represents the TwiML instructions answering the key
+// pressed during a call: a text read to the callee before hanging up.
+type TwilioVoiceResponse struct {
+	XMLName xml.Name `xml:"Response"`
+	Say     string   `xml:"Say"`
+}
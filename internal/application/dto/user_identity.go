@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// USER IDENTITY REQUESTS
+// ===============================================
+
+// LinkIdentityRequest represents the request to link a user to their account
+// on a chat provider.
+type LinkIdentityRequest struct {
+	ExternalID string `json:"external_id" validate:"required,max=100"`
+}
+
+// ===============================================
+// USER IDENTITY RESPONSES
+// ===============================================
+
+// UserIdentityResponse represents the link of a user to a provider account.
+type UserIdentityResponse struct {
+	UserID     string    `json:"user_id"`
+	Provider   string    `json:"provider"`
+	ExternalID string    `json:"external_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// UserIdentityFromEntity converts a domain entity to a response DTO.
+func UserIdentityFromEntity(i *entity.UserIdentity) UserIdentityResponse {
+	return UserIdentityResponse{
+		UserID:     i.UserID.String(),
+		Provider:   i.Provider,
+		ExternalID: i.ExternalID,
+		CreatedAt:  i.CreatedAt,
+		UpdatedAt:  i.UpdatedAt,
+	}
+}
+
+// UserIdentitiesFromEntities converts a slice of entities to response DTOs.
+func UserIdentitiesFromEntities(identities []*entity.UserIdentity) []UserIdentityResponse {
+	result := make([]UserIdentityResponse, len(identities))
+	for i, identity := range identities {
+		result[i] = UserIdentityFromEntity(identity)
+	}
+	return result
+}
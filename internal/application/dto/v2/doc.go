@@ -0,0 +1,3 @@
+// Package v2 holds the data transfer objects whose shape differs in version 2
+// of the API. Resources without a v2 shape are served with the dto package's.
+package v2
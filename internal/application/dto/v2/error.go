@@ -0,0 +1,27 @@
+package v2
+
+import "time"
+
+// ErrorResponse represents an API error response, an RFC 7807 problem
+// details document served as application/problem+json. Code is the machine
+// readable error code clients should branch on.
+type ErrorResponse struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	TraceID   string       `json:"trace_id,omitempty"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// FieldError is an invalid field of a request, with the validation rule it
+// failed.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+}
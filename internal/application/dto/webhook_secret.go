@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// WEBHOOK SECRET REQUESTS
+// ===============================================
+
+// SetWebhookSecretRequest represents the request to configure or rotate an
+// integration's signing secret. Without a secret, one is generated.
+type SetWebhookSecretRequest struct {
+	Secret string `json:"secret,omitempty" validate:"omitempty,min=32,max=256"`
+}
+
+// ===============================================
+// WEBHOOK SECRET RESPONSES
+// ===============================================
+
+// WebhookSecretResponse represents a configured signing secret.
+// Secret is only returned when it is set or rotated.
+type WebhookSecretResponse struct {
+	Integration string    `json:"integration"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookSecretFromEntity converts a domain entity to a response DTO without the secret.
+func WebhookSecretFromEntity(s *entity.WebhookSecret) WebhookSecretResponse {
+	return WebhookSecretResponse{
+		Integration: s.Integration,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// WebhookSecretsFromEntities converts a slice of entities to response DTOs.
+func WebhookSecretsFromEntities(secrets []*entity.WebhookSecret) []WebhookSecretResponse {
+	result := make([]WebhookSecretResponse, len(secrets))
+	for i, s := range secrets {
+		result[i] = WebhookSecretFromEntity(s)
+	}
+	return result
+}
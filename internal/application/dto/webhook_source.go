@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ===============================================
+// WEBHOOK SOURCE REQUESTS
+// ===============================================
+
+// WebhookMappingRequest describes how inbound payloads map to alerts.
+// Fields accept a JSON path ("$.alert.name"), a template ("{{host}}: {{check}}")
+// or a literal value.
+type WebhookMappingRequest struct {
+	Title           string            `json:"title" validate:"required"`
+	Message         string            `json:"message,omitempty"`
+	Severity        string            `json:"severity,omitempty"`
+	SeverityMap     map[string]string `json:"severity_map,omitempty" validate:"omitempty,dive,oneof=critical high medium low info"`
+	DefaultSeverity string            `json:"default_severity,omitempty" validate:"omitempty,oneof=critical high medium low info"`
+	DedupKey        string            `json:"dedup_key,omitempty"`
+	Status          string            `json:"status,omitempty"`
+	ResolvedValues  []string          `json:"resolved_values,omitempty"`
+}
+
+// ToEntity converts the request into a domain mapping.
+func (r WebhookMappingRequest) ToEntity() entity.WebhookMapping {
+	var severityMap map[string]entity.AlertSeverity
+	if len(r.SeverityMap) > 0 {
+		severityMap = make(map[string]entity.AlertSeverity, len(r.SeverityMap))
+		for raw, severity := range r.SeverityMap {
+			severityMap[raw] = entity.AlertSeverity(severity)
+		}
+	}
+
+	return entity.WebhookMapping{
+		Title:           r.Title,
+		Message:         r.Message,
+		Severity:        r.Severity,
+		SeverityMap:     severityMap,
+		DefaultSeverity: entity.AlertSeverity(r.DefaultSeverity),
+		DedupKey:        r.DedupKey,
+		Status:          r.Status,
+		ResolvedValues:  r.ResolvedValues,
+	}
+}
+
+// CreateWebhookSourceRequest represents the request to register an inbound webhook source.
+type CreateWebhookSourceRequest struct {
+	Name    string                `json:"name" validate:"required,max=100"`
+	Mapping WebhookMappingRequest `json:"mapping" validate:"required"`
+}
+
+// UpdateWebhookSourceRequest represents the request to update an inbound webhook source.
+type UpdateWebhookSourceRequest struct {
+	Mapping   *WebhookMappingRequest `json:"mapping,omitempty"`
+	IsEnabled *bool                  `json:"is_enabled,omitempty"`
+}
+
+// ===============================================
+// WEBHOOK SOURCE RESPONSES
+// ===============================================
+
+// WebhookSourceResponse represents an inbound webhook source in API responses.
+// Token and URL are only present when a token is issued (creation or rotation).
+type WebhookSourceResponse struct {
+	ID        string                `json:"id"`
+	Name      string                `json:"name"`
+	Mapping   entity.WebhookMapping `json:"mapping"`
+	IsEnabled bool                  `json:"is_enabled"`
+	Token     string                `json:"token,omitempty"`
+	URL       string                `json:"url,omitempty"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// WebhookSourceFromEntity converts a domain entity to a response DTO.
+func WebhookSourceFromEntity(s *entity.WebhookSource) WebhookSourceResponse {
+	return WebhookSourceResponse{
+		ID:        s.ID.String(),
+		Name:      s.Name,
+		Mapping:   s.Mapping,
+		IsEnabled: s.IsEnabled,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+// WebhookSourcesFromEntities converts a slice of entities to response DTOs.
+func WebhookSourcesFromEntities(sources []*entity.WebhookSource) []WebhookSourceResponse {
+	result := make([]WebhookSourceResponse, len(sources))
+	for i, s := range sources {
+		result[i] = WebhookSourceFromEntity(s)
+	}
+	return result
+}
+
+// InboundWebhookResponse reports what an inbound webhook payload did.
+type InboundWebhookResponse struct {
+	Status  string `json:"status"`
+	Action  string `json:"action"`
+	AlertID string `json:"alert_id,omitempty"`
+}
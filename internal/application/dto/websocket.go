@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// WebSocketClientStats represents the send statistics of a connected client.
+type WebSocketClientStats struct {
+	UserID      string     `json:"user_id,omitempty"`
+	Role        string     `json:"role,omitempty"`
+	Transport   string     `json:"transport"`
+	ConnectedAt time.Time  `json:"connected_at"`
+	Queued      int        `json:"queued"`
+	BufferSize  int        `json:"buffer_size"`
+	Sent        uint64     `json:"sent"`
+	Dropped     uint64     `json:"dropped"`
+	LastDropAt  *time.Time `json:"last_drop_at,omitempty"`
+}
+
+// PresenceResponse represents a user connected to the WebSocket hub.
+type PresenceResponse struct {
+	UserID      string    `json:"user_id"`
+	Role        string    `json:"role"`
+	Connections int       `json:"connections"`
+	Since       time.Time `json:"since"`
+}
+
+// PresenceEvent represents a user coming online or going offline.
+type PresenceEvent struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
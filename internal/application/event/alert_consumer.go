@@ -2,27 +2,60 @@ package event
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
 )
 
 // AlertConsumer consumes and processes alert events.
 type AlertConsumer struct {
-	handlers []AlertEventHandler
+	handlers       []registeredHandler
+	cacheRepo      repository.CacheRepository
+	idempotencyTTL time.Duration
+}
+
+// registeredHandler is an event handler with the name it is reported by in
+// logs and metrics.
+type registeredHandler struct {
+	name    string
+	handler AlertEventHandler
 }
 
 // NewAlertConsumer creates a new alert consumer.
 func NewAlertConsumer() *AlertConsumer {
 	return &AlertConsumer{
-		handlers: make([]AlertEventHandler, 0),
+		handlers: make([]registeredHandler, 0),
 	}
 }
 
-// RegisterHandler registers an event handler.
-func (c *AlertConsumer) RegisterHandler(handler AlertEventHandler) {
-	c.handlers = append(c.handlers, handler)
+// RegisterHandler registers an event handler under a name. Handlers run in
+// the order they were registered; the first failing one stops the event,
+// which is retried.
+func (c *AlertConsumer) RegisterHandler(name string, handler AlertEventHandler) {
+	c.handlers = append(c.handlers, registeredHandler{name: name, handler: handler})
+}
+
+// Handlers returns the names of the registered handlers, in order.
+func (c *AlertConsumer) Handlers() []string {
+	names := make([]string, len(c.handlers))
+	for i, registered := range c.handlers {
+		names[i] = registered.name
+	}
+	return names
+}
+
+// SetIdempotency makes every handler process an event ID at most once within
+// ttl, so retried or replayed events do not send notifications or count
+// metrics twice. A handler's claim on an event is released when it fails, so
+// the retry runs it again; a crash while handling leaves the claim until ttl.
+func (c *AlertConsumer) SetIdempotency(cacheRepo repository.CacheRepository, ttl time.Duration) {
+	c.cacheRepo = cacheRepo
+	c.idempotencyTTL = ttl
 }
 
 // Handle processes an event from the event bus.
@@ -57,14 +90,9 @@ func (c *AlertConsumer) handleAlertCreated(ctx context.Context, evt *event.Event
 		return err
 	}
 
-	for _, handler := range c.handlers {
-		if err := handler.HandleAlertCreated(ctx, payload); err != nil {
-			log.Error().Err(err).Str("alert_id", payload.ID).Msg("Handler failed for alert.created")
-			return err
-		}
-	}
-
-	return nil
+	return c.dispatch(ctx, evt, payload.ID, func(handler AlertEventHandler) error {
+		return handler.HandleAlertCreated(ctx, payload)
+	})
 }
 
 func (c *AlertConsumer) handleAlertAcknowledged(ctx context.Context, evt *event.Event) error {
@@ -74,14 +102,9 @@ func (c *AlertConsumer) handleAlertAcknowledged(ctx context.Context, evt *event.
 		return err
 	}
 
-	for _, handler := range c.handlers {
-		if err := handler.HandleAlertAcknowledged(ctx, payload); err != nil {
-			log.Error().Err(err).Str("alert_id", payload.ID).Msg("Handler failed for alert.acknowledged")
-			return err
-		}
-	}
-
-	return nil
+	return c.dispatch(ctx, evt, payload.ID, func(handler AlertEventHandler) error {
+		return handler.HandleAlertAcknowledged(ctx, payload)
+	})
 }
 
 func (c *AlertConsumer) handleAlertResolved(ctx context.Context, evt *event.Event) error {
@@ -91,14 +114,9 @@ func (c *AlertConsumer) handleAlertResolved(ctx context.Context, evt *event.Even
 		return err
 	}
 
-	for _, handler := range c.handlers {
-		if err := handler.HandleAlertResolved(ctx, payload); err != nil {
-			log.Error().Err(err).Str("alert_id", payload.ID).Msg("Handler failed for alert.resolved")
-			return err
-		}
-	}
-
-	return nil
+	return c.dispatch(ctx, evt, payload.ID, func(handler AlertEventHandler) error {
+		return handler.HandleAlertResolved(ctx, payload)
+	})
 }
 
 func (c *AlertConsumer) handleAlertDeleted(ctx context.Context, evt *event.Event) error {
@@ -108,14 +126,9 @@ func (c *AlertConsumer) handleAlertDeleted(ctx context.Context, evt *event.Event
 		return err
 	}
 
-	for _, handler := range c.handlers {
-		if err := handler.HandleAlertDeleted(ctx, payload); err != nil {
-			log.Error().Err(err).Str("alert_id", payload.ID).Msg("Handler failed for alert.deleted")
-			return err
-		}
-	}
-
-	return nil
+	return c.dispatch(ctx, evt, payload.ID, func(handler AlertEventHandler) error {
+		return handler.HandleAlertDeleted(ctx, payload)
+	})
 }
 
 func (c *AlertConsumer) handleAlertExpired(ctx context.Context, evt *event.Event) error {
@@ -125,12 +138,82 @@ func (c *AlertConsumer) handleAlertExpired(ctx context.Context, evt *event.Event
 		return err
 	}
 
-	for _, handler := range c.handlers {
-		if err := handler.HandleAlertExpired(ctx, payload); err != nil {
-			log.Error().Err(err).Str("alert_id", payload.ID).Msg("Handler failed for alert.expired")
+	return c.dispatch(ctx, evt, payload.ID, func(handler AlertEventHandler) error {
+		return handler.HandleAlertExpired(ctx, payload)
+	})
+}
+
+// dispatch runs the handlers on an event, in order, recording how long each
+// took and whether it succeeded, failed or skipped the event.
+func (c *AlertConsumer) dispatch(
+	ctx context.Context,
+	evt *event.Event,
+	alertID string,
+	handle func(handler AlertEventHandler) error,
+) error {
+	eventType := string(evt.Type)
+
+	for _, registered := range c.handlers {
+		start := time.Now()
+		ran, err := c.once(ctx, evt, registered.name, func() error { return handle(registered.handler) })
+		if !ran {
+			metrics.EventHandlerInvocationsTotal.WithLabelValues(registered.name, eventType, "skipped").Inc()
+			continue
+		}
+		metrics.EventHandlerDuration.WithLabelValues(registered.name, eventType).Observe(time.Since(start).Seconds())
+
+		if err != nil {
+			metrics.EventHandlerInvocationsTotal.WithLabelValues(registered.name, eventType, "error").Inc()
+			log.Error().
+				Err(err).
+				Str("handler", registered.name).
+				Str("alert_id", alertID).
+				Msgf("Handler failed for %s", eventType)
 			return err
 		}
+		metrics.EventHandlerInvocationsTotal.WithLabelValues(registered.name, eventType, "success").Inc()
 	}
 
 	return nil
 }
+
+// once runs fn unless the handler registered under name already processed the
+// event, and reports whether it ran. Without a cache, or when it is
+// unreachable, fn always runs.
+func (c *AlertConsumer) once(ctx context.Context, evt *event.Event, name string, fn func() error) (bool, error) {
+	if c.cacheRepo == nil || c.idempotencyTTL <= 0 {
+		return true, fn()
+	}
+
+	key := processedEventKey(evt.ID, name)
+	claimed, err := c.cacheRepo.SetNX(ctx, key, time.Now().UTC().Unix(), c.idempotencyTTL)
+	if err != nil {
+		log.Warn().Err(err).Str("event_id", evt.ID).Msg("Failed to claim event, handling it anyway")
+		return true, fn()
+	}
+
+	if !claimed {
+		log.Debug().
+			Str("event_id", evt.ID).
+			Str("handler", name).
+			Msg("Event already processed, skipped")
+		metrics.EventsDeduplicatedTotal.WithLabelValues(string(evt.Type)).Inc()
+		return false, nil
+	}
+
+	if err := fn(); err != nil {
+		if releaseErr := c.cacheRepo.Delete(ctx, key); releaseErr != nil {
+			log.Warn().Err(releaseErr).Str("event_id", evt.ID).Msg("Failed to release event claim")
+		}
+		return true, err
+	}
+
+	return true, nil
+}
+
+// processedEventKey returns the cache key marking an event processed by the
+// handler registered under name. Unlike the handler's type, the name tells
+// apart two handlers of the same type.
+func processedEventKey(eventID, name string) string {
+	return fmt.Sprintf("event:processed:%s:%s", eventID, name)
+}
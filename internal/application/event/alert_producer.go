@@ -32,6 +32,7 @@ func (p *AlertProducer) PublishAlertCreated(ctx context.Context, alert *entity.A
 		log.Error().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to create alert.created event")
 		return
 	}
+	evt.Priority = alertPriority(alert)
 
 	if err := p.bus.Publish(ctx, evt); err != nil {
 		log.Error().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to publish alert.created event")
@@ -47,6 +48,7 @@ func (p *AlertProducer) PublishAlertAcknowledged(ctx context.Context, alert *ent
 		log.Error().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to create alert.acknowledged event")
 		return
 	}
+	evt.Priority = alertPriority(alert)
 
 	if err := p.bus.Publish(ctx, evt); err != nil {
 		log.Error().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to publish alert.acknowledged event")
@@ -62,6 +64,7 @@ func (p *AlertProducer) PublishAlertResolved(ctx context.Context, alert *entity.
 		log.Error().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to create alert.resolved event")
 		return
 	}
+	evt.Priority = alertPriority(alert)
 
 	if err := p.bus.Publish(ctx, evt); err != nil {
 		log.Error().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to publish alert.resolved event")
@@ -96,25 +99,42 @@ func (p *AlertProducer) PublishAlertExpired(ctx context.Context, alert *entity.A
 		log.Error().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to create alert.expired event")
 		return
 	}
+	evt.Priority = alertPriority(alert)
 
 	if err := p.bus.Publish(ctx, evt); err != nil {
 		log.Error().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to publish alert.expired event")
 	}
 }
 
+// alertPriority returns the priority of the events of an alert: critical
+// alerts are published ahead of the backlog of the others.
+func alertPriority(alert *entity.Alert) event.Priority {
+	if alert.Severity == entity.AlertSeverityCritical {
+		return event.PriorityHigh
+	}
+	return event.PriorityNormal
+}
+
 // alertToPayload converts an alert entity to an event payload.
 func (p *AlertProducer) alertToPayload(alert *entity.Alert) event.AlertPayload {
 	payload := event.AlertPayload{
-		ID:        alert.ID.String(),
-		Title:     alert.Title,
-		Message:   alert.Message,
-		Severity:  string(alert.Severity),
-		Status:    string(alert.Status),
-		Source:    alert.Source,
-		Metadata:  alert.Metadata,
-		CreatedAt: alert.CreatedAt,
+		ID:         alert.ID.String(),
+		Title:      alert.Title,
+		Message:    alert.Message,
+		Severity:   string(alert.Severity),
+		Status:     string(alert.Status),
+		Source:     alert.Source,
+		Region:     alert.Region,
+		Cluster:    alert.Cluster,
+		Metadata:   alert.Metadata,
+		Flapping:   alert.IsFlapping(),
+		Downstream: alert.IsDownstream(),
+		CreatedAt:  alert.CreatedAt,
+	}
+
+	if alert.TeamID != nil {
+		payload.TeamID = alert.TeamID.String()
 	}
-
 	if alert.AcknowledgedBy != nil {
 		ackBy := alert.AcknowledgedBy.String()
 		payload.AcknowledgedBy = &ackBy
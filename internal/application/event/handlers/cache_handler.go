@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+)
+
+// AlertCache drops alerts from a cache, with the statistics of their team,
+// or of every team when it is not known.
+type AlertCache interface {
+	Invalidate(ctx context.Context, id entity.ID, teamID *entity.ID)
+	InvalidateRemoved(ctx context.Context, id entity.ID)
+}
+
+// CacheInvalidationHandler drops changed alerts from the cache, so that
+// changes written elsewhere, e.g. by another region, are not served stale.
+type CacheInvalidationHandler struct {
+	cache AlertCache
+}
+
+// NewCacheInvalidationHandler creates a new cache invalidation handler.
+func NewCacheInvalidationHandler(cache AlertCache) *CacheInvalidationHandler {
+	return &CacheInvalidationHandler{cache: cache}
+}
+
+// HandleAlertCreated invalidates the lists a new alert appears in.
+func (h *CacheInvalidationHandler) HandleAlertCreated(ctx context.Context, payload event.AlertPayload) error {
+	h.invalidate(ctx, payload)
+	return nil
+}
+
+// HandleAlertAcknowledged invalidates an acknowledged alert.
+func (h *CacheInvalidationHandler) HandleAlertAcknowledged(ctx context.Context, payload event.AlertPayload) error {
+	h.invalidate(ctx, payload)
+	return nil
+}
+
+// HandleAlertResolved invalidates a resolved alert.
+func (h *CacheInvalidationHandler) HandleAlertResolved(ctx context.Context, payload event.AlertPayload) error {
+	h.invalidate(ctx, payload)
+	return nil
+}
+
+// HandleAlertDeleted invalidates a deleted alert.
+func (h *CacheInvalidationHandler) HandleAlertDeleted(ctx context.Context, payload event.AlertDeletedPayload) error {
+	id, err := entity.ParseID(payload.ID)
+	if err != nil {
+		log.Warn().Str("alert_id", payload.ID).Msg("Cannot invalidate cached alert with invalid ID")
+		return nil
+	}
+	h.cache.InvalidateRemoved(ctx, id)
+	return nil
+}
+
+// HandleAlertExpired invalidates an expired alert.
+func (h *CacheInvalidationHandler) HandleAlertExpired(ctx context.Context, payload event.AlertPayload) error {
+	h.invalidate(ctx, payload)
+	return nil
+}
+
+func (h *CacheInvalidationHandler) invalidate(ctx context.Context, payload event.AlertPayload) {
+	id, err := entity.ParseID(payload.ID)
+	if err != nil {
+		log.Warn().Str("alert_id", payload.ID).Msg("Cannot invalidate cached alert with invalid ID")
+		return
+	}
+
+	if payload.TeamID == "" {
+		h.cache.Invalidate(ctx, id, nil)
+		return
+	}
+	teamID, err := entity.ParseID(payload.TeamID)
+	if err != nil {
+		h.cache.InvalidateRemoved(ctx, id)
+		return
+	}
+	h.cache.Invalidate(ctx, id, &teamID)
+}
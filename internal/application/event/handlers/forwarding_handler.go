@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+)
+
+// ForwardingHandler keeps the incidents mirroring alerts on on-call
+// platforms in lockstep with the alerts: opened when an alert fires,
+// acknowledged with it, and closed when it is resolved or expires.
+type ForwardingHandler struct {
+	forwardingService *service.ForwardingService
+	silencer          Silencer
+}
+
+// NewForwardingHandler creates a new forwarding handler.
+func NewForwardingHandler(forwardingService *service.ForwardingService) *ForwardingHandler {
+	return &ForwardingHandler{
+		forwardingService: forwardingService,
+	}
+}
+
+// SetSilencer stops alerts whose source is under maintenance from opening
+// incidents.
+func (h *ForwardingHandler) SetSilencer(silencer Silencer) {
+	h.silencer = silencer
+}
+
+// HandleAlertCreated opens the incidents of new alerts. Alerts that are
+// flapping, downstream of another alert or silenced open none, like they
+// send no notification.
+func (h *ForwardingHandler) HandleAlertCreated(ctx context.Context, payload event.AlertPayload) error {
+	if payload.Flapping || payload.Downstream || silenced(ctx, h.silencer, payload, payload.CreatedAt) {
+		return nil
+	}
+
+	msg := forwardedMessage(payload, payload.Message)
+	withRunbook(&msg, payload.Metadata)
+
+	return h.forwardingService.Open(ctx, msg, externalIncidents(payload))
+}
+
+// HandleAlertAcknowledged acknowledges the incidents of acknowledged alerts.
+func (h *ForwardingHandler) HandleAlertAcknowledged(ctx context.Context, payload event.AlertPayload) error {
+	text := "Alert has been acknowledged"
+	if payload.AcknowledgedBy != nil {
+		text = "Alert has been acknowledged by " + *payload.AcknowledgedBy
+	}
+
+	return h.forwardingService.Acknowledge(ctx, forwardedMessage(payload, text), externalIncidents(payload))
+}
+
+// HandleAlertResolved closes the incidents of resolved alerts.
+func (h *ForwardingHandler) HandleAlertResolved(ctx context.Context, payload event.AlertPayload) error {
+	text := "Alert has been resolved"
+	if payload.ResolvedBy != nil {
+		text = "Alert has been resolved by " + *payload.ResolvedBy
+	}
+
+	return h.forwardingService.Close(ctx, forwardedMessage(payload, text), externalIncidents(payload))
+}
+
+// HandleAlertDeleted leaves the incidents of deleted alerts open; the event
+// does not carry them.
+func (h *ForwardingHandler) HandleAlertDeleted(_ context.Context, _ event.AlertDeletedPayload) error {
+	return nil
+}
+
+// HandleAlertExpired closes the incidents of expired alerts.
+func (h *ForwardingHandler) HandleAlertExpired(ctx context.Context, payload event.AlertPayload) error {
+	msg := forwardedMessage(payload, "Alert has expired without resolution")
+	return h.forwardingService.Close(ctx, msg, externalIncidents(payload))
+}
+
+// forwardedMessage builds the message describing an alert event to on-call
+// platforms.
+func forwardedMessage(payload event.AlertPayload, text string) notification.Message {
+	alert := &entity.Alert{Metadata: payload.Metadata}
+
+	return notification.Message{
+		Title:    payload.Title,
+		Text:     text,
+		Severity: payload.Severity,
+		Status:   payload.Status,
+		AlertID:  payload.ID,
+		Source:   payload.Source,
+		Labels:   alert.Labels(),
+		TeamID:   payload.TeamID,
+	}
+}
+
+// externalIncidents returns the incidents already mirroring an alert, read
+// from its metadata like those of stored alerts.
+func externalIncidents(payload event.AlertPayload) map[string]string {
+	alert := &entity.Alert{Metadata: payload.Metadata}
+	return alert.ExternalIncidents()
+}
@@ -2,15 +2,28 @@ package handlers
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
 )
 
+// Silencer reports whether the notifications of an alert's source are
+// suppressed at a time.
+type Silencer interface {
+	IsSilenced(ctx context.Context, source string, teamID *entity.ID, at time.Time) (bool, error)
+}
+
 // NotificationHandler sends notifications for alert events.
 type NotificationHandler struct {
 	notificationService *service.NotificationService
+	silencer            Silencer
 }
 
 // NewNotificationHandler creates a new notification handler.
@@ -20,16 +33,30 @@ func NewNotificationHandler(notificationService *service.NotificationService) *N
 	}
 }
 
+// SetSilencer suppresses the notifications of alerts whose source is under
+// maintenance.
+func (h *NotificationHandler) SetSilencer(silencer Silencer) {
+	h.silencer = silencer
+}
+
 // HandleAlertCreated sends notification for new alerts.
 func (h *NotificationHandler) HandleAlertCreated(ctx context.Context, payload event.AlertPayload) error {
+	if suppressFlapping(payload) || suppressDownstream(payload) || h.suppressSilenced(ctx, payload, payload.CreatedAt) {
+		return nil
+	}
+
 	msg := notification.Message{
 		Title:    "🚨 New Alert: " + payload.Title,
 		Text:     payload.Message,
 		Severity: payload.Severity,
+		Status:   payload.Status,
 		AlertID:  payload.ID,
 		Source:   payload.Source,
+		Labels:   labels(payload.Metadata),
+		TeamID:   payload.TeamID,
 		Fields:   make(map[string]string),
 	}
+	withRunbook(&msg, payload.Metadata)
 
 	return h.notificationService.Notify(ctx, msg)
 }
@@ -45,18 +72,30 @@ func (h *NotificationHandler) HandleAlertAcknowledged(ctx context.Context, paylo
 		Title:    "✅ Alert Acknowledged: " + payload.Title,
 		Text:     "Alert has been acknowledged",
 		Severity: payload.Severity,
+		Status:   payload.Status,
 		AlertID:  payload.ID,
 		Source:   payload.Source,
+		Labels:   labels(payload.Metadata),
+		TeamID:   payload.TeamID,
 		Fields: map[string]string{
 			"Acknowledged By": acknowledgedBy,
 		},
 	}
+	withRunbook(&msg, payload.Metadata)
 
 	return h.notificationService.Notify(ctx, msg)
 }
 
 // HandleAlertResolved sends notification when alert is resolved.
 func (h *NotificationHandler) HandleAlertResolved(ctx context.Context, payload event.AlertPayload) error {
+	resolvedAt := time.Now()
+	if payload.ResolvedAt != nil {
+		resolvedAt = *payload.ResolvedAt
+	}
+	if suppressFlapping(payload) || suppressDownstream(payload) || h.suppressSilenced(ctx, payload, resolvedAt) {
+		return nil
+	}
+
 	resolvedBy := "unknown"
 	if payload.ResolvedBy != nil {
 		resolvedBy = *payload.ResolvedBy
@@ -66,8 +105,11 @@ func (h *NotificationHandler) HandleAlertResolved(ctx context.Context, payload e
 		Title:    "✔️ Alert Resolved: " + payload.Title,
 		Text:     "Alert has been resolved",
 		Severity: payload.Severity,
+		Status:   payload.Status,
 		AlertID:  payload.ID,
 		Source:   payload.Source,
+		Labels:   labels(payload.Metadata),
+		TeamID:   payload.TeamID,
 		Fields: map[string]string{
 			"Resolved By": resolvedBy,
 		},
@@ -88,9 +130,90 @@ func (h *NotificationHandler) HandleAlertExpired(ctx context.Context, payload ev
 		Title:    "⏰ Alert Expired: " + payload.Title,
 		Text:     "Alert has expired without resolution",
 		Severity: payload.Severity,
+		Status:   payload.Status,
 		AlertID:  payload.ID,
 		Source:   payload.Source,
+		Labels:   labels(payload.Metadata),
+		TeamID:   payload.TeamID,
 	}
+	withRunbook(&msg, payload.Metadata)
 
 	return h.notificationService.Notify(ctx, msg)
 }
+
+// suppressFlapping reports whether the notification of an alert firing or
+// resolving is suppressed because the alert is flapping.
+func suppressFlapping(payload event.AlertPayload) bool {
+	if !payload.Flapping {
+		return false
+	}
+
+	metrics.FlappingNotificationsSuppressedTotal.Inc()
+	log.Debug().Str("alert_id", payload.ID).Msg("Notification suppressed for flapping alert")
+	return true
+}
+
+// suppressDownstream reports whether the notification of an alert firing or
+// resolving is suppressed because the alert is a consequence of an active
+// critical alert upstream, which is notified instead.
+func suppressDownstream(payload event.AlertPayload) bool {
+	if !payload.Downstream {
+		return false
+	}
+
+	metrics.DownstreamNotificationsSuppressedTotal.Inc()
+	log.Debug().Str("alert_id", payload.ID).Msg("Notification suppressed for downstream alert")
+	return true
+}
+
+// suppressSilenced reports whether the notification of an alert firing or
+// resolving is suppressed because its source was under maintenance at the
+// time. Alerts are notified when the maintenance windows cannot be checked.
+func (h *NotificationHandler) suppressSilenced(ctx context.Context, payload event.AlertPayload, at time.Time) bool {
+	if !silenced(ctx, h.silencer, payload, at) {
+		return false
+	}
+
+	metrics.SilencedNotificationsSuppressedTotal.Inc()
+	log.Debug().Str("alert_id", payload.ID).Msg("Notification suppressed for source under maintenance")
+	return true
+}
+
+// silenced reports whether the source of an alert was under maintenance at
+// a time. Sources are not silenced when the maintenance windows cannot be
+// checked.
+func silenced(ctx context.Context, silencer Silencer, payload event.AlertPayload, at time.Time) bool {
+	if silencer == nil {
+		return false
+	}
+
+	var teamID *entity.ID
+	if payload.TeamID != "" {
+		if id, err := entity.ParseID(payload.TeamID); err == nil {
+			teamID = &id
+		}
+	}
+
+	silenced, err := silencer.IsSilenced(ctx, payload.Source, teamID, at)
+	if err != nil {
+		log.Warn().Err(err).Str("alert_id", payload.ID).Msg("Failed to check maintenance windows")
+		return false
+	}
+	return silenced
+}
+
+// withRunbook copies the runbook and remediation of an alert, from its
+// metadata, to the notification of an alert still needing attention.
+func withRunbook(msg *notification.Message, metadata map[string]interface{}) {
+	msg.RunbookURL, _ = metadata[entity.MetadataRunbookURL].(string)
+	msg.Remediation, _ = metadata[entity.MetadataRemediation].(string)
+}
+
+// labels converts alert metadata to the string labels exposed to notification templates.
+func labels(metadata map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		result[key] = fmt.Sprint(value)
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+)
+
+// StatusPageHandler updates the status page component of the source of an
+// alert when the alert fires or ends.
+type StatusPageHandler struct {
+	statusPageService *service.StatusPageService
+}
+
+// NewStatusPageHandler creates a new status page handler.
+func NewStatusPageHandler(statusPageService *service.StatusPageService) *StatusPageHandler {
+	return &StatusPageHandler{
+		statusPageService: statusPageService,
+	}
+}
+
+// HandleAlertCreated updates the component of the source of new alerts.
+func (h *StatusPageHandler) HandleAlertCreated(ctx context.Context, payload event.AlertPayload) error {
+	return h.statusPageService.SyncSource(ctx, payload.Source)
+}
+
+// HandleAlertAcknowledged leaves components as they are; acknowledged alerts
+// are not over.
+func (h *StatusPageHandler) HandleAlertAcknowledged(_ context.Context, _ event.AlertPayload) error {
+	return nil
+}
+
+// HandleAlertResolved updates the component of the source of resolved alerts.
+func (h *StatusPageHandler) HandleAlertResolved(ctx context.Context, payload event.AlertPayload) error {
+	return h.statusPageService.SyncSource(ctx, payload.Source)
+}
+
+// HandleAlertDeleted updates every component; the event does not carry the
+// source of the deleted alert.
+func (h *StatusPageHandler) HandleAlertDeleted(ctx context.Context, _ event.AlertDeletedPayload) error {
+	return h.statusPageService.SyncAll(ctx)
+}
+
+// HandleAlertExpired updates the component of the source of expired alerts.
+func (h *StatusPageHandler) HandleAlertExpired(ctx context.Context, payload event.AlertPayload) error {
+	return h.statusPageService.SyncSource(ctx, payload.Source)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+)
+
+// SubscriptionHandler queues the delivery of alert events to the outbound
+// webhook subscriptions receiving them.
+type SubscriptionHandler struct {
+	subscriptionService *service.SubscriptionService
+}
+
+// NewSubscriptionHandler creates a new subscription handler.
+func NewSubscriptionHandler(subscriptionService *service.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+	}
+}
+
+// HandleAlertCreated pushes new alerts.
+func (h *SubscriptionHandler) HandleAlertCreated(ctx context.Context, payload event.AlertPayload) error {
+	return h.enqueue(ctx, entity.SubscriptionEventAlertCreated, payload, payload.CreatedAt)
+}
+
+// HandleAlertAcknowledged pushes acknowledged alerts.
+func (h *SubscriptionHandler) HandleAlertAcknowledged(ctx context.Context, payload event.AlertPayload) error {
+	occurredAt := payload.CreatedAt
+	if payload.AcknowledgedAt != nil {
+		occurredAt = *payload.AcknowledgedAt
+	}
+	return h.enqueue(ctx, entity.SubscriptionEventAlertAcknowledged, payload, occurredAt)
+}
+
+// HandleAlertResolved pushes resolved alerts.
+func (h *SubscriptionHandler) HandleAlertResolved(ctx context.Context, payload event.AlertPayload) error {
+	occurredAt := payload.CreatedAt
+	if payload.ResolvedAt != nil {
+		occurredAt = *payload.ResolvedAt
+	}
+	return h.enqueue(ctx, entity.SubscriptionEventAlertResolved, payload, occurredAt)
+}
+
+// HandleAlertDeleted pushes deleted alerts to the subscriptions without a filter.
+func (h *SubscriptionHandler) HandleAlertDeleted(ctx context.Context, payload event.AlertDeletedPayload) error {
+	return h.subscriptionService.Enqueue(ctx, service.SubscriptionEvent{
+		Type:       entity.SubscriptionEventAlertDeleted,
+		Data:       payload,
+		OccurredAt: payload.DeletedAt,
+	})
+}
+
+// HandleAlertExpired pushes expired alerts. The payload does not tell when
+// the alert expired, so the event is dated when it is handled.
+func (h *SubscriptionHandler) HandleAlertExpired(ctx context.Context, payload event.AlertPayload) error {
+	return h.enqueue(ctx, entity.SubscriptionEventAlertExpired, payload, time.Now().UTC())
+}
+
+// enqueue queues the deliveries of an alert event.
+func (h *SubscriptionHandler) enqueue(ctx context.Context, eventType string, payload event.AlertPayload, occurredAt time.Time) error {
+	// The labels are read from the metadata like those of stored alerts.
+	alert := &entity.Alert{Metadata: payload.Metadata}
+
+	return h.subscriptionService.Enqueue(ctx, service.SubscriptionEvent{
+		Type:       eventType,
+		Severity:   entity.AlertSeverity(payload.Severity),
+		Source:     payload.Source,
+		Labels:     alert.Labels(),
+		Data:       payload,
+		OccurredAt: occurredAt,
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+)
+
+// TicketHandler files the tickets of the alerts of rules with CreateTicket
+// and resolves the tickets of alerts when they are resolved.
+type TicketHandler struct {
+	ticketService *service.TicketService
+}
+
+// NewTicketHandler creates a new ticket handler.
+func NewTicketHandler(ticketService *service.TicketService) *TicketHandler {
+	return &TicketHandler{
+		ticketService: ticketService,
+	}
+}
+
+// HandleAlertCreated files the ticket of new alerts whose rule asks for one.
+// Flapping and downstream alerts file none, like they send no notification.
+func (h *TicketHandler) HandleAlertCreated(ctx context.Context, payload event.AlertPayload) error {
+	if create, _ := payload.Metadata[entity.MetadataCreateTicket].(bool); !create {
+		return nil
+	}
+	if payload.Flapping || payload.Downstream {
+		return nil
+	}
+
+	id, err := entity.ParseID(payload.ID)
+	if err != nil {
+		return err
+	}
+
+	return h.ticketService.CreateForAlert(ctx, id)
+}
+
+// HandleAlertAcknowledged leaves the tickets of acknowledged alerts as is.
+func (h *TicketHandler) HandleAlertAcknowledged(_ context.Context, _ event.AlertPayload) error {
+	return nil
+}
+
+// HandleAlertResolved resolves the ticket of resolved alerts.
+func (h *TicketHandler) HandleAlertResolved(ctx context.Context, payload event.AlertPayload) error {
+	alert := &entity.Alert{Metadata: payload.Metadata}
+	key := alert.TicketKey()
+	if key == "" {
+		return nil
+	}
+
+	comment := "Alert has been resolved"
+	if payload.ResolvedBy != nil {
+		comment = "Alert has been resolved by " + *payload.ResolvedBy
+	}
+
+	return h.ticketService.Resolve(ctx, key, comment)
+}
+
+// HandleAlertDeleted leaves the tickets of deleted alerts open; the event
+// does not carry them.
+func (h *TicketHandler) HandleAlertDeleted(_ context.Context, _ event.AlertDeletedPayload) error {
+	return nil
+}
+
+// HandleAlertExpired leaves the tickets of expired alerts open, for the
+// problem they report may not be over.
+func (h *TicketHandler) HandleAlertExpired(_ context.Context, _ event.AlertPayload) error {
+	return nil
+}
@@ -4,10 +4,14 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
@@ -17,15 +21,58 @@ import (
 
 // ErrAlertNotFound Alert service errors.
 var (
-	ErrAlertNotFound = errors.New("alert not found")
+	ErrAlertNotFound      = errors.New("alert not found")
+	ErrBulkFilterRequired = errors.New("bulk operation requires at least one filter")
+	ErrAlertTeamNotFound  = errors.New("alert team not found")
+	ErrAlertDuplicateOpen = errors.New("an open alert of the team has the same dedup key")
 )
 
+// BatchItemError is the validation error of one input of a batch.
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+// BatchValidationError lists the invalid inputs of a batch. It matches the
+// validation errors of its items with errors.Is.
+type BatchValidationError struct {
+	Items []BatchItemError
+}
+
+// Error implements error.
+func (e *BatchValidationError) Error() string {
+	msgs := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		msgs[i] = fmt.Sprintf("alert %d: %v", item.Index, item.Err)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of the invalid items.
+func (e *BatchValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Items))
+	for i, item := range e.Items {
+		errs[i] = item.Err
+	}
+	return errs
+}
+
+// Cache key prefixes of the flapping detection state, per alert fingerprint.
+const (
+	flapChangesKeyPrefix = "flap_changes:"
+	flappingKeyPrefix    = "flapping:"
+)
+
+// bulkSampleSize is the number of alert IDs returned as a preview of a bulk operation.
+const bulkSampleSize = 10
+
 // AlertEventPublisher defines the interface for publishing alert events.
 type AlertEventPublisher interface {
 	PublishAlertCreated(alert *entity.Alert)
 	PublishAlertAcknowledged(alert *entity.Alert)
 	PublishAlertResolved(alert *entity.Alert)
 	PublishAlertDeleted(alertID string)
+	PublishAlertRestored(alert *entity.Alert)
 }
 
 // AlertEventProducer defines the interface for publishing alert events to the event bus.
@@ -37,12 +84,52 @@ type AlertEventProducer interface {
 	PublishAlertExpired(ctx context.Context, alert *entity.Alert)
 }
 
+// UserEventPublisher delivers events addressed to a single user.
+type UserEventPublisher interface {
+	PublishToUser(ctx context.Context, userID entity.ID, item dto.DigestItem)
+}
+
+// AlertCorrelator groups newly created alerts, e.g. into incidents.
+type AlertCorrelator interface {
+	Correlate(ctx context.Context, alert *entity.Alert)
+}
+
+// UpstreamAlertFinder finds the alert a new alert is a consequence of.
+type UpstreamAlertFinder interface {
+	// UpstreamAlert returns the active critical alert of a source the
+	// alert's source depends on, or nil when there is none.
+	UpstreamAlert(ctx context.Context, alert *entity.Alert) (*entity.Alert, error)
+}
+
+// SeverityClassifier re-classifies the severity of new alerts.
+type SeverityClassifier interface {
+	// Classify changes the severity of the alert if a rule applies to it.
+	Classify(ctx context.Context, alert *entity.Alert)
+}
+
+// AlertAssigner assigns new alerts to teams and users.
+type AlertAssigner interface {
+	// Assign sets the team and assignee of the alert if a rule applies to it.
+	Assign(ctx context.Context, alert *entity.Alert)
+}
+
 // AlertService handles alert business logic.
 type AlertService struct {
 	alertRepo     repository.AlertRepository
 	cacheRepo     repository.CacheRepository
 	wsPublisher   AlertEventPublisher
 	eventProducer AlertEventProducer
+	userNotifier  UserEventPublisher
+	quotas        *QuotaService
+	visibility    valueobject.VisibilityPolicy
+	flapping      valueobject.FlappingPolicy
+	enrichment    *EnrichmentPipeline
+	classifier    SeverityClassifier
+	assigner      AlertAssigner
+	correlator    AlertCorrelator
+	topology      UpstreamAlertFinder
+	region        string
+	cluster       string
 }
 
 // NewAlertService creates a new alert service.
@@ -63,6 +150,89 @@ func (s *AlertService) SetEventProducer(producer AlertEventProducer) {
 	s.eventProducer = producer
 }
 
+// SetDeployment sets the region and cluster stamped onto alerts created by this instance.
+func (s *AlertService) SetDeployment(region, cluster string) {
+	s.region = region
+	s.cluster = cluster
+}
+
+// SetUserNotifier sets the publisher for events addressed to individual users.
+func (s *AlertService) SetUserNotifier(notifier UserEventPublisher) {
+	s.userNotifier = notifier
+}
+
+// SetQuotaService sets the service enforcing the daily alert quotas of
+// teams and webhook sources.
+func (s *AlertService) SetQuotaService(quotas *QuotaService) {
+	s.quotas = quotas
+}
+
+// SetFlappingPolicy sets the policy detecting alerts that keep firing and
+// resolving. Flapping alerts are flagged when created or resolved.
+func (s *AlertService) SetFlappingPolicy(policy valueobject.FlappingPolicy) {
+	s.flapping = policy
+}
+
+// SetEnrichment sets the enrichers run on new alerts before they are stored.
+func (s *AlertService) SetEnrichment(pipeline *EnrichmentPipeline) {
+	s.enrichment = pipeline
+}
+
+// SetSeverityClassifier sets the classifier re-classifying the severity of
+// new alerts before they are enriched and stored.
+func (s *AlertService) SetSeverityClassifier(classifier SeverityClassifier) {
+	s.classifier = classifier
+}
+
+// SetAssigner sets the assigner giving new alerts a team and an assignee
+// before they are stored. Assignees are notified once the alert is created.
+func (s *AlertService) SetAssigner(assigner AlertAssigner) {
+	s.assigner = assigner
+}
+
+// SetCorrelator sets the correlator new alerts are handed to once stored.
+func (s *AlertService) SetCorrelator(correlator AlertCorrelator) {
+	s.correlator = correlator
+}
+
+// SetTopology sets the finder of the upstream alerts new alerts are
+// downstream of. Downstream alerts are flagged when created.
+func (s *AlertService) SetTopology(topology UpstreamAlertFinder) {
+	s.topology = topology
+}
+
+// SetVisibilityPolicy sets the policy restricting which roles can see which alerts.
+func (s *AlertService) SetVisibilityPolicy(policy valueobject.VisibilityPolicy) {
+	s.visibility = policy
+}
+
+// CanView reports whether a caller with the role and tenant scope may see the alert.
+func (s *AlertService) CanView(alert *entity.Alert, role entity.UserRole, tenant valueobject.TenantScope) bool {
+	return tenant.Allows(alert.TeamID) && s.visibility.CanView(alert, role)
+}
+
+// VisibleFilter returns a filter restricted to the alerts a caller with the
+// role and tenant scope may see.
+func (s *AlertService) VisibleFilter(role entity.UserRole, tenant valueobject.TenantScope) valueobject.AlertFilter {
+	return valueobject.NewAlertFilter().WithVisibility(s.visibility, role).WithTenant(tenant)
+}
+
+// NotifyUser sends a significant alert event (assignment, mention, escalation)
+// to a user. Users without an active session receive it in their digest.
+func (s *AlertService) NotifyUser(ctx context.Context, userID entity.ID, kind string, alert *entity.Alert) {
+	if s.userNotifier == nil {
+		return
+	}
+
+	s.userNotifier.PublishToUser(ctx, userID, dto.DigestItem{
+		Kind:       kind,
+		Title:      alert.Title,
+		Message:    alert.Message,
+		AlertID:    alert.ID.String(),
+		OccurredAt: time.Now().UTC(),
+	})
+}
+
 // CreateAlertInput represents input for creating an alert.
 type CreateAlertInput struct {
 	Title    string
@@ -70,6 +240,9 @@ type CreateAlertInput struct {
 	Severity entity.AlertSeverity
 	Source   string
 	Metadata map[string]interface{}
+	TeamID   *entity.ID
+	// SourceID is the webhook source the alert came from, if any.
+	SourceID *entity.ID
 }
 
 // Create creates a new alert.
@@ -83,27 +256,262 @@ func (s *AlertService) Create(ctx context.Context, input CreateAlertInput) (*ent
 		attribute.String("alert.source", input.Source),
 	)
 
-	alert, err := entity.NewAlert(input.Title, input.Message, input.Severity, input.Source)
+	alert, err := s.prepare(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.alertRepo.Create(ctx, alert); err != nil {
+		return nil, s.storeError(ctx, alert, err)
+	}
+
+	span.SetAttributes(
+		attribute.String("alert.id", alert.ID.String()),
+		attribute.String("alert.region", alert.Region),
+	)
+
+	s.publishCreated(ctx, alert)
+
+	return alert, nil
+}
+
+// prepare builds a new alert from an input, consuming the alert quotas and
+// running the classification, assignment and enrichment it goes through
+// before being stored.
+func (s *AlertService) prepare(ctx context.Context, input CreateAlertInput) (*entity.Alert, error) {
+	alert, err := s.newAlert(input)
 	if err != nil {
 		tracing.RecordError(ctx, err)
 		return nil, err
 	}
 
-	for key, value := range input.Metadata {
-		alert.AddMetadata(key, value)
+	if err := s.consumeAlertQuotas(ctx, input); err != nil {
+		return nil, err
 	}
 
-	if err := s.alertRepo.Create(ctx, alert); err != nil {
+	s.classify(ctx, alert)
+	s.assign(ctx, alert)
+	s.enrichment.Enrich(ctx, alert)
+	s.trackFlapping(ctx, alert)
+	s.markDownstream(ctx, alert)
+
+	return alert, nil
+}
+
+// storeError translates the repository error of storing a new alert.
+func (s *AlertService) storeError(ctx context.Context, alert *entity.Alert, err error) error {
+	tracing.RecordError(ctx, err)
+	switch {
+	case errors.Is(err, repository.ErrForeignKeyViolation) && alert.TeamID != nil:
+		return ErrAlertTeamNotFound
+	case errors.Is(err, repository.ErrDuplicateKey):
+		return ErrAlertDuplicateOpen
+	}
+	return err
+}
+
+// CreateBatch creates several alerts with a single repository call.
+// The batch is validated up front; if any input is invalid nothing is stored
+// and a *BatchValidationError lists every invalid input.
+func (s *AlertService) CreateBatch(ctx context.Context, inputs []CreateAlertInput) ([]*entity.Alert, error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.CreateBatch")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("batch.size", len(inputs)))
+
+	alerts, err := s.ValidateBatch(inputs)
+	if err != nil {
 		tracing.RecordError(ctx, err)
 		return nil, err
 	}
 
-	span.SetAttributes(attribute.String("alert.id", alert.ID.String()))
+	if err := s.consumeAlertQuotas(ctx, inputs...); err != nil {
+		return nil, err
+	}
+
+	for _, alert := range alerts {
+		s.classify(ctx, alert)
+		s.assign(ctx, alert)
+		s.enrichment.Enrich(ctx, alert)
+		s.trackFlapping(ctx, alert)
+		s.markDownstream(ctx, alert)
+	}
+
+	if err := s.alertRepo.CreateBatch(ctx, alerts); err != nil {
+		tracing.RecordError(ctx, err)
+		if errors.Is(err, repository.ErrForeignKeyViolation) {
+			return nil, ErrAlertTeamNotFound
+		}
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, ErrAlertDuplicateOpen
+		}
+		return nil, err
+	}
+
+	for _, alert := range alerts {
+		s.publishCreated(ctx, alert)
+	}
+
+	return alerts, nil
+}
+
+// ValidateBatch builds and validates a batch of alerts without storing them.
+// It backs the dry-run mode of batch imports; invalid inputs are reported by
+// a *BatchValidationError.
+func (s *AlertService) ValidateBatch(inputs []CreateAlertInput) ([]*entity.Alert, error) {
+	alerts := make([]*entity.Alert, 0, len(inputs))
+	var invalid []BatchItemError
+	for i, input := range inputs {
+		alert, err := s.newAlert(input)
+		if err != nil {
+			invalid = append(invalid, BatchItemError{Index: i, Err: err})
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if len(invalid) > 0 {
+		return nil, &BatchValidationError{Items: invalid}
+	}
+
+	return alerts, nil
+}
+
+// ValidateAlert checks that an input builds a valid alert, without storing it.
+func (s *AlertService) ValidateAlert(input CreateAlertInput) error {
+	_, err := s.newAlert(input)
+	return err
+}
+
+// newAlert builds and validates an alert entity from input.
+func (s *AlertService) newAlert(input CreateAlertInput) (*entity.Alert, error) {
+	alert, err := entity.NewAlert(input.Title, input.Message, input.Severity, input.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range input.Metadata {
+		alert.AddMetadata(key, value)
+	}
+
+	alert.SetOrigin(s.region, s.cluster)
+	alert.TeamID = input.TeamID
+
+	return alert, nil
+}
 
-	_ = s.cacheRepo.Delete(ctx, "stats:alerts")
+// consumeAlertQuotas counts alerts about to be created against the daily
+// quotas of their teams and webhook sources.
+func (s *AlertService) consumeAlertQuotas(ctx context.Context, inputs ...CreateAlertInput) error {
+	if s.quotas == nil || !s.quotas.IsEnabled() {
+		return nil
+	}
+
+	counts := make(map[valueobject.QuotaSubject]int)
+	for _, input := range inputs {
+		if input.TeamID != nil {
+			counts[valueobject.TeamQuotaSubject(*input.TeamID)]++
+		}
+		if input.SourceID != nil {
+			counts[valueobject.SourceQuotaSubject(*input.SourceID)]++
+		}
+	}
+
+	for subject, n := range counts {
+		if _, err := s.quotas.Consume(ctx, subject, valueobject.QuotaAlerts, n); err != nil {
+			tracing.RecordError(ctx, err)
+			return err
+		}
+	}
+
+	return nil
+}
 
+// trackFlapping counts a state change of the condition an alert reports and
+// flags the alert when that condition is flapping. Cache errors are only
+// logged: flapping detection must not get in the way of alerting.
+func (s *AlertService) trackFlapping(ctx context.Context, alert *entity.Alert) {
+	if !s.flapping.IsEnabled() || s.cacheRepo == nil {
+		return
+	}
+
+	fingerprint := shortHash(alert.Fingerprint())
+	changesKey := flapChangesKeyPrefix + fingerprint
+	changes, err := s.cacheRepo.Increment(ctx, changesKey)
+	if err != nil {
+		log.Warn().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to count alert state change")
+		return
+	}
+	if changes == 1 {
+		_ = s.cacheRepo.Expire(ctx, changesKey, s.flapping.Window)
+	}
+
+	flappingKey := flappingKeyPrefix + fingerprint
+	if s.flapping.IsFlapping(int(changes)) {
+		started, err := s.cacheRepo.SetNX(ctx, flappingKey, time.Now().UTC(), s.flapping.Dampening)
+		if err == nil && started {
+			metrics.AlertsFlappingTotal.Inc()
+			log.Warn().
+				Str("alert_id", alert.ID.String()).
+				Str("fingerprint", fingerprint).
+				Int64("changes", changes).
+				Msg("Alert started flapping")
+		}
+	} else if flapping, err := s.cacheRepo.Exists(ctx, flappingKey); err != nil || !flapping {
+		return
+	}
+
+	// Every change while flapping restarts the dampening period
+	_ = s.cacheRepo.Expire(ctx, flappingKey, s.flapping.Dampening)
+	alert.MarkFlapping()
+}
+
+// classify re-classifies the severity of the alert when a classifier is set.
+func (s *AlertService) classify(ctx context.Context, alert *entity.Alert) {
+	if s.classifier == nil {
+		return
+	}
+	s.classifier.Classify(ctx, alert)
+}
+
+// assign gives the alert a team and an assignee when an assigner is set.
+func (s *AlertService) assign(ctx context.Context, alert *entity.Alert) {
+	if s.assigner == nil {
+		return
+	}
+	s.assigner.Assign(ctx, alert)
+}
+
+// markDownstream flags the alert as downstream when a source it depends on
+// has an active critical alert. Errors are only logged, like those of
+// flapping detection.
+func (s *AlertService) markDownstream(ctx context.Context, alert *entity.Alert) {
+	if s.topology == nil || alert.Source == "" {
+		return
+	}
+
+	upstream, err := s.topology.UpstreamAlert(ctx, alert)
+	if err != nil {
+		log.Warn().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to find upstream alert")
+		return
+	}
+	if upstream == nil {
+		return
+	}
+
+	alert.MarkDownstream(upstream)
+	metrics.AlertsDownstreamTotal.Inc()
+	log.Info().
+		Str("alert_id", alert.ID.String()).
+		Str("upstream_alert_id", upstream.ID.String()).
+		Str("upstream_source", upstream.Source).
+		Msg("Alert is downstream of an active critical alert")
+}
+
+// publishCreated records metrics and publishes a newly stored alert.
+func (s *AlertService) publishCreated(ctx context.Context, alert *entity.Alert) {
 	// Record metrics
-	metrics.AlertsCreatedTotal.WithLabelValues(string(input.Severity), input.Source).Inc()
+	metrics.AlertsCreatedTotal.WithLabelValues(string(alert.Severity), alert.Source).Inc()
 	metrics.AlertsActiveGauge.Inc()
 
 	// Publish to WebSocket (real-time)
@@ -116,9 +524,15 @@ func (s *AlertService) Create(ctx context.Context, input CreateAlertInput) (*ent
 		s.eventProducer.PublishAlertCreated(ctx, alert)
 	}
 
-	tracing.AddEvent(ctx, "alert_created", attribute.String("alert.id", alert.ID.String()))
+	if s.correlator != nil {
+		s.correlator.Correlate(ctx, alert)
+	}
 
-	return alert, nil
+	if assigneeID := alert.AssigneeID(); assigneeID != nil {
+		s.NotifyUser(ctx, *assigneeID, dto.DigestKindAssignment, alert)
+	}
+
+	tracing.AddEvent(ctx, "alert_created", attribute.String("alert.id", alert.ID.String()))
 }
 
 // GetByID retrieves an alert by ID.
@@ -142,10 +556,122 @@ func (s *AlertService) GetByID(ctx context.Context, id entity.ID) (*entity.Alert
 	return alert, nil
 }
 
+// GetVisible retrieves an alert by ID on behalf of a role and tenant scope.
+// Alerts hidden from the role or owned by another team are reported as not found.
+func (s *AlertService) GetVisible(
+	ctx context.Context,
+	id entity.ID,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) (*entity.Alert, error) {
+	alert, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.CanView(alert, role, tenant) {
+		return nil, ErrAlertNotFound
+	}
+
+	return alert, nil
+}
+
+// Reasons an alert is related to another.
+const (
+	RelatedByFingerprint = "fingerprint"
+	RelatedBySource      = "source"
+	RelatedByRule        = "rule"
+)
+
+// RelatedAlert is an alert related to another, with the reasons it is.
+type RelatedAlert struct {
+	Alert   *entity.Alert
+	Reasons []string
+}
+
+// RelatedAlerts gives responders the context of an alert: the alerts
+// sharing its fingerprint, source or rule around the time it was raised,
+// and the previous occurrences of its condition with their resolutions.
+type RelatedAlerts struct {
+	Alert       *entity.Alert
+	Related     []RelatedAlert
+	Occurrences []*entity.Alert
+}
+
+// GetRelated retrieves an alert visible to the role and tenant scope along
+// with up to limit related alerts raised within window of it, and up to
+// limit of its previous occurrences.
+func (s *AlertService) GetRelated(
+	ctx context.Context,
+	id entity.ID,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+	window time.Duration,
+	limit int,
+) (*RelatedAlerts, error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.GetRelated")
+	defer span.End()
+
+	alert, err := s.GetVisible(ctx, id, role, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := valueobject.NewAlertFilter().WithVisibility(s.visibility, role).WithTenant(tenant)
+
+	related, err := s.alertRepo.ListRelated(ctx,
+		alert, visible.WithDateRange(alert.CreatedAt.Add(-window), alert.CreatedAt.Add(window)), limit)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+
+	occurrences, err := s.alertRepo.ListOccurrences(ctx, alert, visible, limit)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+
+	result := &RelatedAlerts{
+		Alert:       alert,
+		Related:     make([]RelatedAlert, 0, len(related)),
+		Occurrences: occurrences,
+	}
+	for _, other := range related {
+		result.Related = append(result.Related, RelatedAlert{Alert: other, Reasons: relatedReasons(alert, other)})
+	}
+
+	span.SetAttributes(
+		attribute.Int("result.related_count", len(result.Related)),
+		attribute.Int("result.occurrences_count", len(occurrences)),
+	)
+
+	return result, nil
+}
+
+// relatedReasons returns the reasons other is related to alert.
+func relatedReasons(alert, other *entity.Alert) []string {
+	var reasons []string
+	if other.Fingerprint() == alert.Fingerprint() {
+		reasons = append(reasons, RelatedByFingerprint)
+	}
+	if alert.Source != "" && other.Source == alert.Source {
+		reasons = append(reasons, RelatedBySource)
+	}
+	if alert.RuleID != nil && other.RuleID != nil && *other.RuleID == *alert.RuleID {
+		reasons = append(reasons, RelatedByRule)
+	}
+	return reasons
+}
+
 // ListInput represents input for listing alerts.
+// Role and Tenant restrict the result to alerts visible to the caller;
+// the zero Tenant only includes alerts not owned by any team.
 type ListInput struct {
 	Filter     valueobject.AlertFilter
 	Pagination valueobject.Pagination
+	Role       entity.UserRole
+	Tenant     valueobject.TenantScope
 }
 
 // List retrieves alerts with filters and pagination.
@@ -167,8 +693,13 @@ func (s *AlertService) List(ctx context.Context, input ListInput) (*valueobject.
 	if input.Filter.Source != nil {
 		span.SetAttributes(attribute.String("filter.source", *input.Filter.Source))
 	}
+	if input.Filter.Region != nil {
+		span.SetAttributes(attribute.String("filter.region", *input.Filter.Region))
+	}
+
+	filter := input.Filter.WithVisibility(s.visibility, input.Role).WithTenant(input.Tenant)
 
-	result, err := s.alertRepo.List(ctx, input.Filter, input.Pagination)
+	result, err := s.alertRepo.List(ctx, filter, input.Pagination)
 	if err != nil {
 		tracing.RecordError(ctx, err)
 		return nil, err
@@ -211,8 +742,6 @@ func (s *AlertService) Acknowledge(ctx context.Context, alertID, userID entity.I
 		return nil, err
 	}
 
-	_ = s.cacheRepo.Delete(ctx, "stats:alerts")
-
 	// Record metrics
 	metrics.AlertsAcknowledgedTotal.Inc()
 
@@ -254,14 +783,20 @@ func (s *AlertService) Resolve(ctx context.Context, alertID, userID entity.ID) (
 		tracing.RecordError(ctx, err)
 		return nil, err
 	}
+	s.trackFlapping(ctx, alert)
 
 	if err := s.alertRepo.Update(ctx, alert); err != nil {
 		tracing.RecordError(ctx, err)
 		return nil, err
 	}
 
-	_ = s.cacheRepo.Delete(ctx, "stats:alerts")
+	s.publishResolved(ctx, alert)
 
+	return alert, nil
+}
+
+// publishResolved records metrics and publishes a newly resolved alert.
+func (s *AlertService) publishResolved(ctx context.Context, alert *entity.Alert) {
 	// Record metrics
 	metrics.AlertsResolvedTotal.Inc()
 	metrics.AlertsActiveGauge.Dec()
@@ -277,11 +812,118 @@ func (s *AlertService) Resolve(ctx context.Context, alertID, userID entity.ID) (
 	}
 
 	tracing.AddEvent(ctx, "alert_resolved", attribute.String("alert.id", alert.ID.String()))
+}
+
+// ExpireOverdue marks expired the open alerts past their expiration time and
+// publishes their expiry, returning how many expired.
+func (s *AlertService) ExpireOverdue(ctx context.Context) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.ExpireOverdue")
+	defer span.End()
+
+	alerts, err := s.alertRepo.ListExpired(ctx)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		return 0, err
+	}
+
+	expired := 0
+	for _, alert := range alerts {
+		alert.Expire()
+		if err := s.alertRepo.Update(ctx, alert); err != nil {
+			tracing.RecordError(ctx, err)
+			return expired, err
+		}
+		expired++
+
+		metrics.AlertsExpiredTotal.Inc()
+		metrics.AlertsActiveGauge.Dec()
+		if s.eventProducer != nil {
+			s.eventProducer.PublishAlertExpired(ctx, alert)
+		}
+	}
+
+	return expired, nil
+}
+
+// CreateDeduplicated creates an alert unless an open alert with the same dedup key exists.
+// It returns the stored or existing alert and whether a new alert was created.
+func (s *AlertService) CreateDeduplicated(ctx context.Context, dedupKey string, input CreateAlertInput) (*entity.Alert, bool, error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.CreateDeduplicated")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("alert.dedup_key", dedupKey))
+
+	// Most repeats of an open alert stop here, before consuming quotas and
+	// counting towards flapping; the insert below settles concurrent ones.
+	existing, err := s.alertRepo.GetOpenByDedupKey(ctx, dedupKey)
+	if err == nil && (input.TeamID == nil || sameID(existing.TeamID, input.TeamID)) {
+		tracing.AddEvent(ctx, "alert_deduplicated", attribute.String("alert.id", existing.ID.String()))
+		return existing, false, nil
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		tracing.RecordError(ctx, err)
+		return nil, false, err
+	}
+
+	if input.Metadata == nil {
+		input.Metadata = make(map[string]interface{})
+	}
+	input.Metadata[entity.MetadataDedupKey] = dedupKey
+
+	alert, err := s.prepare(ctx, input)
+	if err != nil {
+		return nil, false, err
+	}
+
+	stored, created, err := s.alertRepo.CreateDeduplicated(ctx, alert)
+	if err != nil {
+		return nil, false, s.storeError(ctx, alert, err)
+	}
+	if !created {
+		tracing.AddEvent(ctx, "alert_deduplicated", attribute.String("alert.id", stored.ID.String()))
+		return stored, false, nil
+	}
+
+	span.SetAttributes(attribute.String("alert.id", alert.ID.String()))
+	s.publishCreated(ctx, alert)
+
+	return alert, true, nil
+}
+
+// ResolveByDedupKey resolves the open alert with the given dedup key on behalf of its source.
+// Returns ErrAlertNotFound if no open alert has that key.
+func (s *AlertService) ResolveByDedupKey(ctx context.Context, dedupKey string) (*entity.Alert, error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.ResolveByDedupKey")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("alert.dedup_key", dedupKey))
+
+	alert, err := s.alertRepo.GetOpenByDedupKey(ctx, dedupKey)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAlertNotFound
+		}
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+
+	if err := alert.ResolveBySource(); err != nil {
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+	s.trackFlapping(ctx, alert)
+
+	if err := s.alertRepo.Update(ctx, alert); err != nil {
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+
+	s.publishResolved(ctx, alert)
 
 	return alert, nil
 }
 
-// Delete removes an alert.
+// Delete moves an alert to the trash, from which it can be restored until purged.
 func (s *AlertService) Delete(ctx context.Context, id entity.ID, deletedBy entity.ID) error {
 	ctx, span := tracing.StartSpan(ctx, "AlertService.Delete")
 	defer span.End()
@@ -299,8 +941,16 @@ func (s *AlertService) Delete(ctx context.Context, id entity.ID, deletedBy entit
 		return err
 	}
 
-	_ = s.cacheRepo.Delete(ctx, "stats:alerts")
+	s.publishDeleted(ctx, id, deletedBy.String())
+
+	tracing.AddEvent(ctx, "alert_deleted", attribute.String("alert.id", id.String()))
+
+	return nil
+}
 
+// publishDeleted records metrics and publishes an alert moved to the trash.
+// deletedBy is empty for alerts deleted by the system.
+func (s *AlertService) publishDeleted(ctx context.Context, id entity.ID, deletedBy string) {
 	// Record metrics
 	metrics.AlertsDeletedTotal.Inc()
 
@@ -311,39 +961,176 @@ func (s *AlertService) Delete(ctx context.Context, id entity.ID, deletedBy entit
 
 	// Publish to Event Bus (async processing)
 	if s.eventProducer != nil {
-		s.eventProducer.PublishAlertDeleted(ctx, id.String(), deletedBy.String())
+		s.eventProducer.PublishAlertDeleted(ctx, id.String(), deletedBy)
 	}
+}
 
-	tracing.AddEvent(ctx, "alert_deleted", attribute.String("alert.id", id.String()))
+// Restore takes an alert out of the trash.
+func (s *AlertService) Restore(ctx context.Context, id entity.ID, restoredBy entity.ID) (*entity.Alert, error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.Restore")
+	defer span.End()
 
-	return nil
+	span.SetAttributes(
+		attribute.String("alert.id", id.String()),
+		attribute.String("restored_by", restoredBy.String()),
+	)
+
+	if err := s.alertRepo.Restore(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAlertNotFound
+		}
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, ErrAlertDuplicateOpen
+		}
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+
+	alert, err := s.alertRepo.GetByID(ctx, id)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+
+	// Publish to WebSocket (real-time)
+	if s.wsPublisher != nil {
+		s.wsPublisher.PublishAlertRestored(alert)
+	}
+
+	tracing.AddEvent(ctx, "alert_restored", attribute.String("alert.id", id.String()))
+
+	return alert, nil
 }
 
-// GetStatistics retrieves alert statistics.
-func (s *AlertService) GetStatistics(ctx context.Context) (*repository.AlertStatistics, error) {
-	ctx, span := tracing.StartSpan(ctx, "AlertService.GetStatistics")
+// ListDeleted returns the alerts in the trash matching the filter, most
+// recently deleted first.
+func (s *AlertService) ListDeleted(
+	ctx context.Context,
+	filter valueobject.AlertFilter,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Alert], error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.ListDeleted")
 	defer span.End()
 
-	var stats repository.AlertStatistics
-	err := s.cacheRepo.Get(ctx, "stats:alerts", &stats)
-	if err == nil {
-		span.SetAttributes(attribute.Bool("cache.hit", true))
-		return &stats, nil
+	result, err := s.alertRepo.List(ctx, filter.DeletedOnly(), pagination)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BulkResult describes the alerts affected by a bulk operation.
+type BulkResult struct {
+	DryRun    bool
+	Affected  int64
+	SampleIDs []string
+}
+
+// BulkDelete moves every alert matching the filter to the trash.
+// An empty filter is rejected to avoid wiping the table by accident.
+// With dryRun set nothing is deleted and the result describes what would be.
+// Every deleted alert is published, like alerts deleted one by one.
+func (s *AlertService) BulkDelete(
+	ctx context.Context,
+	filter valueobject.AlertFilter,
+	dryRun bool,
+	deletedBy entity.ID,
+) (*BulkResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.BulkDelete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("deleted_by", deletedBy.String()))
+
+	if filter.IsEmpty() {
+		return nil, ErrBulkFilterRequired
 	}
 
-	span.SetAttributes(attribute.Bool("cache.hit", false))
+	return s.deleteMatching(ctx, filter, dryRun, deletedBy.String())
+}
+
+// PurgeResolved moves resolved and expired alerts created before the cutoff to the trash.
+// With dryRun set nothing is deleted and the result describes what would be.
+func (s *AlertService) PurgeResolved(ctx context.Context, before time.Time, dryRun bool) (*BulkResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.PurgeResolved")
+	defer span.End()
 
-	dbStats, err := s.alertRepo.GetStatistics(ctx)
+	span.SetAttributes(attribute.String("purge.before", before.Format(time.RFC3339)))
+
+	filter := valueobject.NewAlertFilter().
+		WithStatuses(entity.AlertStatusResolved, entity.AlertStatusExpired).
+		WithCreatedBefore(before)
+
+	return s.deleteMatching(ctx, filter, dryRun, "")
+}
+
+// deleteMatching previews and, unless dryRun is set, deletes alerts matching
+// filter on behalf of deletedBy, empty for the system.
+func (s *AlertService) deleteMatching(
+	ctx context.Context,
+	filter valueobject.AlertFilter,
+	dryRun bool,
+	deletedBy string,
+) (*BulkResult, error) {
+	preview, err := s.alertRepo.List(ctx, filter, valueobject.NewPagination(1, bulkSampleSize))
 	if err != nil {
 		tracing.RecordError(ctx, err)
 		return nil, err
 	}
 
-	_ = s.cacheRepo.Set(ctx, "stats:alerts", dbStats, time.Minute)
+	result := &BulkResult{
+		DryRun:    dryRun,
+		Affected:  preview.TotalItems,
+		SampleIDs: make([]string, 0, len(preview.Items)),
+	}
+	for _, alert := range preview.Items {
+		result.SampleIDs = append(result.SampleIDs, alert.ID.String())
+	}
+
+	tracing.AddEvent(ctx, "bulk_delete_preview",
+		attribute.Int64("bulk.affected", result.Affected),
+		attribute.Bool("bulk.dry_run", dryRun),
+	)
+
+	if dryRun || result.Affected == 0 {
+		return result, nil
+	}
+
+	deleted, err := s.alertRepo.DeleteByFilter(ctx, filter)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+	result.Affected = int64(len(deleted))
+
+	for _, id := range deleted {
+		s.publishDeleted(ctx, id, deletedBy)
+	}
+
+	return result, nil
+}
+
+// GetStatistics retrieves statistics of the alerts visible to the role within the tenant scope.
+func (s *AlertService) GetStatistics(
+	ctx context.Context,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) (*repository.AlertStatistics, error) {
+	ctx, span := tracing.StartSpan(ctx, "AlertService.GetStatistics")
+	defer span.End()
+
+	filter := valueobject.NewAlertFilter().WithVisibility(s.visibility, role).WithTenant(tenant)
+
+	stats, err := s.alertRepo.GetStatistics(ctx, filter)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
 
-	span.SetAttributes(attribute.Int64("stats.total_alerts", dbStats.TotalAlerts))
+	span.SetAttributes(attribute.Int64("stats.total_alerts", stats.TotalAlerts))
 
-	return dbStats, nil
+	return stats, nil
 }
 
 // GetActiveAlerts retrieves all active alerts.
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Alert view errors.
+var (
+	ErrAlertViewNotFound  = errors.New("view not found")
+	ErrAlertViewForbidden = errors.New("only the owner of a view or an admin can modify it")
+)
+
+// Bounds of the in-memory copy of the views matched against broadcast alerts.
+const (
+	// alertViewsRefreshInterval bounds how long views saved on other
+	// instances take to stream their alerts here.
+	alertViewsRefreshInterval = 30 * time.Second
+	alertViewsLoadTimeout     = 2 * time.Second
+)
+
+// AlertViewInput holds the settings of a new view.
+type AlertViewInput struct {
+	Name     string
+	Criteria entity.AlertViewCriteria
+	TeamID   *entity.ID
+}
+
+// AlertViewUpdate holds the changes to a view; nil fields are left unchanged.
+// Unshare makes a shared view private again.
+type AlertViewUpdate struct {
+	Name     *string
+	Criteria *entity.AlertViewCriteria
+	TeamID   *entity.ID
+	Unshare  bool
+}
+
+// AlertViewService manages saved alert views, lists their alerts and tells
+// the WebSocket hub which views a broadcast alert belongs to.
+type AlertViewService struct {
+	viewRepo     repository.AlertViewRepository
+	alertService *AlertService
+
+	mu       sync.Mutex
+	views    []*entity.AlertView
+	loadedAt time.Time
+}
+
+// NewAlertViewService creates a new alert view service.
+func NewAlertViewService(viewRepo repository.AlertViewRepository, alertService *AlertService) *AlertViewService {
+	return &AlertViewService{
+		viewRepo:     viewRepo,
+		alertService: alertService,
+	}
+}
+
+// Create saves a view owned by a user, shared with the given team if any.
+func (s *AlertViewService) Create(ctx context.Context, input AlertViewInput, ownerID entity.ID) (*entity.AlertView, error) {
+	view, err := entity.NewAlertView(input.Name, input.Criteria, ownerID, input.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.viewRepo.Create(ctx, view); err != nil {
+		if errors.Is(err, repository.ErrForeignKeyViolation) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return view, nil
+}
+
+// Get retrieves a view visible to a user: one they own or one shared with a
+// team of their tenant scope. Other views are reported as not found.
+func (s *AlertViewService) Get(
+	ctx context.Context,
+	id entity.ID,
+	userID entity.ID,
+	tenant valueobject.TenantScope,
+) (*entity.AlertView, error) {
+	view, err := s.viewRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAlertViewNotFound
+		}
+		return nil, err
+	}
+
+	if !canSeeView(view, userID, tenant) {
+		return nil, ErrAlertViewNotFound
+	}
+
+	return view, nil
+}
+
+// List retrieves the views visible to a user.
+func (s *AlertViewService) List(
+	ctx context.Context,
+	userID entity.ID,
+	tenant valueobject.TenantScope,
+) ([]*entity.AlertView, error) {
+	return s.viewRepo.ListVisible(ctx, userID, tenant)
+}
+
+// Update changes a view. Only its owner and admins may change it.
+func (s *AlertViewService) Update(
+	ctx context.Context,
+	id entity.ID,
+	update AlertViewUpdate,
+	actor valueobject.Actor,
+	tenant valueobject.TenantScope,
+) (*entity.AlertView, error) {
+	view, err := s.Get(ctx, id, actor.UserID, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.CanModify(&view.OwnerID) {
+		return nil, ErrAlertViewForbidden
+	}
+
+	if update.Name != nil {
+		view.Name = *update.Name
+	}
+	if update.Criteria != nil {
+		view.Criteria = *update.Criteria
+	}
+	switch {
+	case update.Unshare:
+		view.TeamID = nil
+	case update.TeamID != nil:
+		view.TeamID = update.TeamID
+	}
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+	view.Touch()
+
+	if err := s.viewRepo.Update(ctx, view); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrAlertViewNotFound
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return view, nil
+}
+
+// Delete removes a view. Only its owner and admins may remove it.
+func (s *AlertViewService) Delete(
+	ctx context.Context,
+	id entity.ID,
+	actor valueobject.Actor,
+	tenant valueobject.TenantScope,
+) error {
+	view, err := s.Get(ctx, id, actor.UserID, tenant)
+	if err != nil {
+		return err
+	}
+
+	if !actor.CanModify(&view.OwnerID) {
+		return ErrAlertViewForbidden
+	}
+
+	if err := s.viewRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAlertViewNotFound
+		}
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// ListAlerts retrieves the alerts of a view visible to the caller.
+func (s *AlertViewService) ListAlerts(
+	ctx context.Context,
+	id entity.ID,
+	actor valueobject.Actor,
+	tenant valueobject.TenantScope,
+	pagination valueobject.Pagination,
+) (*entity.AlertView, *valueobject.PaginatedResult[*entity.Alert], error) {
+	view, err := s.Get(ctx, id, actor.UserID, tenant)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	alerts, err := s.alertService.List(ctx, ListInput{
+		Filter:     AlertViewFilter(view.Criteria),
+		Pagination: pagination,
+		Role:       actor.Role,
+		Tenant:     tenant,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return view, alerts, nil
+}
+
+// CanSubscribe reports whether a user may stream the alerts of a view.
+func (s *AlertViewService) CanSubscribe(
+	ctx context.Context,
+	viewID entity.ID,
+	userID entity.ID,
+	tenant valueobject.TenantScope,
+) bool {
+	_, err := s.Get(ctx, viewID, userID, tenant)
+	return err == nil
+}
+
+// MatchingViews returns the IDs of the views an alert belongs to. Views are
+// matched against an in-memory copy, reloaded every 30 seconds and whenever
+// this instance changes a view; if it cannot be reloaded, the previous copy
+// is used.
+func (s *AlertViewService) MatchingViews(alert *entity.Alert) []entity.ID {
+	var matching []entity.ID
+	for _, view := range s.cachedViews() {
+		if AlertViewFilter(view.Criteria).Matches(alert) {
+			matching = append(matching, view.ID)
+		}
+	}
+	return matching
+}
+
+// cachedViews returns the in-memory copy of every view, reloading it when stale.
+func (s *AlertViewService) cachedViews() []*entity.AlertView {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.loadedAt) < alertViewsRefreshInterval {
+		return s.views
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), alertViewsLoadTimeout)
+	defer cancel()
+
+	views, err := s.viewRepo.ListAll(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load alert views")
+		return s.views
+	}
+
+	s.views = views
+	s.loadedAt = time.Now()
+	return views
+}
+
+// invalidate makes the next match reload the views.
+func (s *AlertViewService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+// AlertViewFilter converts the criteria of a view to an alert filter.
+func AlertViewFilter(criteria entity.AlertViewCriteria) valueobject.AlertFilter {
+	filter := valueobject.NewAlertFilter().
+		WithLabels(criteria.Labels).
+		WithSearch(criteria.Search)
+	if len(criteria.Statuses) > 0 {
+		filter = filter.WithStatuses(criteria.Statuses...)
+	}
+	if len(criteria.Severities) > 0 {
+		filter = filter.WithSeverities(criteria.Severities...)
+	}
+	return filter
+}
+
+// canSeeView reports whether a user owns a view or it is shared with a team
+// of their tenant scope.
+func canSeeView(view *entity.AlertView, userID entity.ID, tenant valueobject.TenantScope) bool {
+	return view.OwnerID == userID || (view.TeamID != nil && tenant.Includes(*view.TeamID))
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Assignment rule errors.
+var (
+	ErrAssignmentRuleNotFound         = errors.New("assignment rule not found")
+	ErrAssignmentRuleExists           = errors.New("assignment rule with this name already exists")
+	ErrAssignmentRuleAssigneeNotFound = errors.New("assignment rule team or user not found")
+)
+
+// Bounds of the in-memory policy applied to new alerts.
+const (
+	// assignmentPolicyRefreshInterval bounds how long rules changed on other
+	// instances take to apply here.
+	assignmentPolicyRefreshInterval = 30 * time.Second
+	assignmentPolicyLoadTimeout     = 2 * time.Second
+)
+
+// AssignmentRuleInput holds the settings of a new assignment rule.
+type AssignmentRuleInput struct {
+	Name     string
+	Position int
+	Match    entity.AssignmentRuleMatcher
+	TeamID   *entity.ID
+	UserID   *entity.ID
+}
+
+// AssignmentRuleUpdate holds the changes to an assignment rule; nil fields
+// are left unchanged. ClearTeam and ClearUser stop assigning to a team or user.
+type AssignmentRuleUpdate struct {
+	Name      *string
+	Position  *int
+	Match     *entity.AssignmentRuleMatcher
+	TeamID    *entity.ID
+	ClearTeam bool
+	UserID    *entity.ID
+	ClearUser bool
+	IsEnabled *bool
+}
+
+// AssignmentRuleService manages the assignment rules and assigns new alerts
+// to teams and users with them.
+type AssignmentRuleService struct {
+	ruleRepo repository.AssignmentRuleRepository
+
+	mu       sync.Mutex
+	policy   valueobject.AssignmentPolicy
+	loadedAt time.Time
+}
+
+// NewAssignmentRuleService creates a new assignment rule service.
+func NewAssignmentRuleService(ruleRepo repository.AssignmentRuleRepository) *AssignmentRuleService {
+	return &AssignmentRuleService{ruleRepo: ruleRepo}
+}
+
+// Create saves a new, enabled assignment rule.
+func (s *AssignmentRuleService) Create(ctx context.Context, input AssignmentRuleInput, createdBy *entity.ID) (*entity.AssignmentRule, error) {
+	rule, err := entity.NewAssignmentRule(input.Name, input.Match, input.TeamID, input.UserID, input.Position, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrAssignmentRuleExists
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrAssignmentRuleAssigneeNotFound
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return rule, nil
+}
+
+// Get retrieves an assignment rule by ID.
+func (s *AssignmentRuleService) Get(ctx context.Context, id entity.ID) (*entity.AssignmentRule, error) {
+	rule, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAssignmentRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// List retrieves every assignment rule in evaluation order.
+func (s *AssignmentRuleService) List(ctx context.Context) ([]*entity.AssignmentRule, error) {
+	return s.ruleRepo.List(ctx)
+}
+
+// Update changes an assignment rule.
+func (s *AssignmentRuleService) Update(ctx context.Context, id entity.ID, update AssignmentRuleUpdate) (*entity.AssignmentRule, error) {
+	rule, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		rule.Name = *update.Name
+	}
+	if update.Position != nil {
+		rule.Position = *update.Position
+	}
+	if update.Match != nil {
+		rule.Match = *update.Match
+	}
+	switch {
+	case update.ClearTeam:
+		rule.TeamID = nil
+	case update.TeamID != nil:
+		rule.TeamID = update.TeamID
+	}
+	switch {
+	case update.ClearUser:
+		rule.UserID = nil
+	case update.UserID != nil:
+		rule.UserID = update.UserID
+	}
+	if update.IsEnabled != nil {
+		rule.IsEnabled = *update.IsEnabled
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	rule.Touch()
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrAssignmentRuleNotFound
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrAssignmentRuleExists
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrAssignmentRuleAssigneeNotFound
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return rule, nil
+}
+
+// Delete removes an assignment rule.
+func (s *AssignmentRuleService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAssignmentRuleNotFound
+		}
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// Assign gives a new alert the team and user of the first rule matching it.
+// Rules are matched against an in-memory policy, reloaded every 30 seconds
+// and whenever this instance changes a rule; if it cannot be reloaded, the
+// previous policy is used.
+func (s *AssignmentRuleService) Assign(_ context.Context, alert *entity.Alert) {
+	rule := s.cachedPolicy().Match(alert)
+	if rule == nil {
+		return
+	}
+
+	metrics.AlertsAutoAssignedTotal.Inc()
+	log.Debug().
+		Str("alert_id", alert.ID.String()).
+		Str("rule", rule.Name).
+		Msg("Alert assigned by rule")
+
+	alert.Assign(rule.TeamID, rule.UserID, rule.Name)
+}
+
+// cachedPolicy returns the in-memory policy, reloading it when stale.
+func (s *AssignmentRuleService) cachedPolicy() valueobject.AssignmentPolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.loadedAt) < assignmentPolicyRefreshInterval {
+		return s.policy
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), assignmentPolicyLoadTimeout)
+	defer cancel()
+
+	rules, err := s.ruleRepo.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load assignment rules")
+		return s.policy
+	}
+
+	s.policy = valueobject.NewAssignmentPolicy(rules)
+	s.loadedAt = time.Now()
+	return s.policy
+}
+
+// invalidate makes the next assignment reload the rules.
+func (s *AssignmentRuleService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
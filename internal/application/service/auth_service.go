@@ -2,17 +2,38 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog/log"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
 )
 
+// Cache key prefixes of the login lockout state.
+const (
+	loginFailuresKeyPrefix = "login_failures:"
+	lockoutKeyPrefix       = "lockout:"
+)
+
+// dummyPasswordHash is verified for unknown emails, so that logging in to
+// them takes as long as logging in to an existing account.
+var dummyPasswordHash = sync.OnceValue(func() valueobject.PasswordHash {
+	hash, _ := valueobject.NewPasswordHash("Unused-login-password-1")
+	return hash
+})
+
 // Auth service errors.
 var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
@@ -20,8 +41,26 @@ var (
 	ErrTokenExpired       = errors.New("token has expired")
 	ErrTokenInvalid       = errors.New("token is invalid")
 	ErrUserAlreadyExists  = errors.New("user with this email already exists")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrAccountLocked      = errors.New("account is temporarily locked")
+	ErrUserNotFound       = errors.New("user not found")
 )
 
+// AccountLockedError reports until when an account is locked out after
+// repeated failed logins.
+type AccountLockedError struct {
+	Until time.Time
+}
+
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("account is locked until %s", e.Until.Format(time.RFC3339))
+}
+
+// Unwrap makes the error match ErrAccountLocked.
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
 // TokenPair represents access and refresh tokens.
 type TokenPair struct {
 	AccessToken  string
@@ -34,53 +73,106 @@ type JWTClaims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	// SessionID links the token to its session; empty for tokens issued before sessions existed.
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// ClientInfo describes the client a session is opened or refreshed from.
+type ClientInfo struct {
+	Device string
+	IP     string
+}
+
 // AuthService handles authentication and authorization logic.
 type AuthService struct {
-	userRepo  repository.UserRepository
-	cacheRepo repository.CacheRepository
-	jwtConfig *config.JWTConfig
+	userRepo    repository.UserRepository
+	cacheRepo   repository.CacheRepository
+	sessionRepo repository.SessionRepository
+	jwtConfig   *config.JWTConfig
+	lockout     valueobject.LockoutPolicy
+	mailer      notification.Mailer
+	revocations *tokenRevocations
 }
 
 // NewAuthService creates a new authentication service.
 func NewAuthService(
 	userRepo repository.UserRepository,
 	cacheRepo repository.CacheRepository,
+	sessionRepo repository.SessionRepository,
 	jwtConfig *config.JWTConfig,
 ) *AuthService {
 	return &AuthService{
-		userRepo:  userRepo,
-		cacheRepo: cacheRepo,
-		jwtConfig: jwtConfig,
+		userRepo:    userRepo,
+		cacheRepo:   cacheRepo,
+		sessionRepo: sessionRepo,
+		jwtConfig:   jwtConfig,
 	}
 }
 
-// Login authenticates a user and returns tokens.
-func (s *AuthService) Login(ctx context.Context, email, password string) (*TokenPair, *entity.User, error) {
+// SetLockoutPolicy sets the policy locking accounts out after repeated
+// failed logins. Mailer, which may be nil, tells users their account was locked.
+func (s *AuthService) SetLockoutPolicy(policy valueobject.LockoutPolicy, mailer notification.Mailer) {
+	s.lockout = policy
+	s.mailer = mailer
+}
+
+// SetTokenRevocation tracks revoked tokens and ended sessions by their ID in
+// repo, checked through a filter rebuilt by RefreshRevocations, instead of
+// blacklisting each token in the cache and looking sessions up.
+func (s *AuthService) SetTokenRevocation(repo repository.TokenRevocationRepository) {
+	s.revocations = newTokenRevocations(repo)
+}
+
+// RefreshRevocations rebuilds the filter of revoked tokens from the tokens
+// revoked by every instance. It does nothing without token revocation.
+func (s *AuthService) RefreshRevocations(ctx context.Context) error {
+	if s.revocations == nil {
+		return nil
+	}
+	return s.revocations.refresh(ctx)
+}
+
+// Login authenticates a user, opens a session for the client and returns tokens.
+func (s *AuthService) Login(ctx context.Context, email, password string, client ClientInfo) (*TokenPair, *entity.User, error) {
+	// Refuse locked emails before looking the account up: the lockout is
+	// kept per email so that it applies to unknown emails too, and does not
+	// tell whether an account exists
+	subject := lockoutSubject(email)
+	if err := s.checkLockout(ctx, subject); err != nil {
+		metrics.AuthLoginAttempts.WithLabelValues("locked").Inc()
+		return nil, nil, err
+	}
+
 	// Find user by email
 	user, err := s.userRepo.GetByEmail(ctx, email)
-	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return nil, nil, ErrInvalidCredentials
-		}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
 		return nil, nil, err
 	}
 
-	// Check if user is active
+	// Verify password, against a dummy hash for unknown emails
+	passwordHash := dummyPasswordHash()
+	if user != nil {
+		passwordHash = valueobject.NewPasswordHashFromHash(user.PasswordHash)
+	}
+	if !passwordHash.Verify(password) || user == nil {
+		metrics.AuthLoginAttempts.WithLabelValues("failure").Inc()
+		if err := s.recordFailure(ctx, subject, user, client); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	// Check if user is active, once the caller proved to own the account
 	if !user.IsActive {
 		return nil, nil, ErrUserNotActive
 	}
 
-	// Verify password
-	passwordHash := valueobject.NewPasswordHashFromHash(user.PasswordHash)
-	if !passwordHash.Verify(password) {
-		return nil, nil, ErrInvalidCredentials
-	}
+	metrics.AuthLoginAttempts.WithLabelValues("success").Inc()
+	s.clearFailures(ctx, subject)
 
-	// Generate tokens
-	tokens, err := s.generateTokenPair(user)
+	// Open a session and generate its tokens
+	tokens, err := s.startSession(ctx, user, client)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -91,8 +183,8 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*Token
 	return tokens, user, nil
 }
 
-// Register creates a new user account.
-func (s *AuthService) Register(ctx context.Context, email, password, name string) (*TokenPair, *entity.User, error) {
+// Register creates a new user account and opens a session for the client.
+func (s *AuthService) Register(ctx context.Context, email, password, name string, client ClientInfo) (*TokenPair, *entity.User, error) {
 	// Check if email already exists
 	exists, err := s.userRepo.ExistsByEmail(ctx, email)
 	if err != nil {
@@ -119,8 +211,8 @@ func (s *AuthService) Register(ctx context.Context, email, password, name string
 		return nil, nil, err
 	}
 
-	// Generate tokens
-	tokens, err := s.generateTokenPair(user)
+	// Open a session and generate its tokens
+	tokens, err := s.startSession(ctx, user, client)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -128,18 +220,17 @@ func (s *AuthService) Register(ctx context.Context, email, password, name string
 	return tokens, user, nil
 }
 
-// RefreshToken generates new tokens using a refresh token.
-func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
+// RefreshToken generates new tokens using a refresh token and extends its session.
+// Tokens issued before sessions existed are moved to a new session.
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string, client ClientInfo) (*TokenPair, error) {
 	// Parse and validate refresh token
 	claims, err := s.validateToken(refreshToken)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if token is blacklisted
-	blacklistKey := "blacklist:" + refreshToken
-	exists, _ := s.cacheRepo.Exists(ctx, blacklistKey)
-	if exists {
+	// Check if token was revoked
+	if s.isTokenRevoked(ctx, refreshToken, claims) {
 		return nil, ErrTokenInvalid
 	}
 
@@ -158,24 +249,42 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*T
 		return nil, ErrUserNotActive
 	}
 
-	// Blacklist old refresh token
-	_ = s.cacheRepo.Set(ctx, blacklistKey, true, s.jwtConfig.RefreshExpiration)
+	if claims.SessionID == "" {
+		// Revoke old refresh token
+		s.revokeToken(ctx, refreshToken, claims, s.jwtConfig.RefreshExpiration)
+		return s.startSession(ctx, user, client)
+	}
+
+	session, err := s.activeSession(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// Revoke old refresh token
+	s.revokeToken(ctx, refreshToken, claims, s.jwtConfig.RefreshExpiration)
+
+	session.Refresh(client.IP, s.jwtConfig.RefreshExpiration)
+	if err := s.sessionRepo.Save(ctx, session); err != nil {
+		return nil, err
+	}
 
 	// Generate new tokens
-	return s.generateTokenPair(user)
+	return s.generateTokenPair(user, session.ID)
 }
 
-// Logout invalidates the user's tokens.
+// Logout invalidates the user's tokens and ends their session.
 func (s *AuthService) Logout(ctx context.Context, accessToken, refreshToken string) error {
-	// Blacklist both tokens
-	if accessToken != "" {
-		blacklistKey := "blacklist:" + accessToken
-		_ = s.cacheRepo.Set(ctx, blacklistKey, true, s.jwtConfig.Expiration)
+	// End the session of the access token and revoke it
+	if claims, err := s.validateToken(accessToken); err == nil {
+		if session, err := s.activeSession(ctx, claims); err == nil {
+			_ = s.endSession(ctx, session)
+		}
+		s.revokeToken(ctx, accessToken, claims, s.jwtConfig.Expiration)
 	}
 
-	if refreshToken != "" {
-		blacklistKey := "blacklist:" + refreshToken
-		_ = s.cacheRepo.Set(ctx, blacklistKey, true, s.jwtConfig.RefreshExpiration)
+	// Revoke the refresh token; invalid or expired tokens are refused anyway
+	if claims, err := s.validateToken(refreshToken); err == nil {
+		s.revokeToken(ctx, refreshToken, claims, s.jwtConfig.RefreshExpiration)
 	}
 
 	return nil
@@ -188,27 +297,396 @@ func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*J
 		return nil, err
 	}
 
-	// Check if token is blacklisted
-	blacklistKey := "blacklist:" + tokenString
-	exists, _ := s.cacheRepo.Exists(ctx, blacklistKey)
-	if exists {
+	// Check if token was revoked
+	if s.isTokenRevoked(ctx, tokenString, claims) {
 		return nil, ErrTokenInvalid
 	}
 
+	// Check if the session was revoked
+	if claims.SessionID != "" {
+		sessionID, err := entity.ParseID(claims.SessionID)
+		if err != nil {
+			return nil, ErrTokenInvalid
+		}
+		if s.isSessionEnded(ctx, sessionID) {
+			return nil, ErrTokenInvalid
+		}
+	}
+
+	// Refuse deactivated users and apply the current role, so that changes
+	// take effect before the token expires
+	userID, err := entity.ParseID(claims.UserID)
+	if err != nil {
+		return nil, ErrTokenInvalid
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return nil, ErrTokenInvalid
+	case err != nil:
+		// Fail open like the revocation check: the claims are still signed
+		log.Warn().Err(err).Str("user_id", claims.UserID).Msg("Failed to load user of token")
+	case !user.IsActive:
+		return nil, ErrUserNotActive
+	default:
+		claims.Email = user.Email
+		claims.Role = string(user.Role)
+	}
+
 	return claims, nil
 }
 
-// generateTokenPair creates access and refresh tokens.
-func (s *AuthService) generateTokenPair(user *entity.User) (*TokenPair, error) {
+// ParseToken verifies the signature and expiry of an access token and
+// returns its claims, without checking whether it was revoked. It is meant
+// for identifying clients cheaply, not for authenticating them.
+func (s *AuthService) ParseToken(tokenString string) (*JWTClaims, error) {
+	return s.validateToken(tokenString)
+}
+
+// ListSessions returns the active sessions of a user, newest first.
+func (s *AuthService) ListSessions(ctx context.Context, userID entity.ID) ([]*entity.Session, error) {
+	return s.sessionRepo.ListByUser(ctx, userID)
+}
+
+// RevokeSession ends a session, invalidating its tokens. Unless asAdmin is
+// set, only the owner may revoke it; other users get ErrSessionNotFound.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID entity.ID, asAdmin bool) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	if !asAdmin && !session.IsOwnedBy(userID) {
+		return ErrSessionNotFound
+	}
+
+	if err := s.endSession(ctx, session); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Unlock lifts the lockout of an account and forgets its failed logins.
+func (s *AuthService) Unlock(ctx context.Context, userID, unlockedBy entity.ID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	subject := lockoutSubject(user.Email)
+	if err := s.cacheRepo.Delete(ctx, lockoutKeyPrefix+subject); err != nil {
+		return err
+	}
+	if err := s.cacheRepo.Delete(ctx, loginFailuresKeyPrefix+subject); err != nil {
+		return err
+	}
+
+	log.Warn().
+		Str("user_id", userID.String()).
+		Str("unlocked_by", unlockedBy.String()).
+		Msg("Account unlocked")
+
+	return nil
+}
+
+// UpdateUser changes the name, role and/or active state of a user; nil
+// values are kept. Tokens of the user keep working, but take the new role
+// and stop working once the user is deactivated.
+func (s *AuthService) UpdateUser(
+	ctx context.Context,
+	userID entity.ID,
+	name, role *string,
+	isActive *bool,
+	updatedBy entity.ID,
+) (*entity.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if name != nil {
+		user.Name = *name
+		user.Touch()
+	}
+	if role != nil {
+		if err := user.ChangeRole(entity.UserRole(*role)); err != nil {
+			return nil, err
+		}
+	}
+	if isActive != nil {
+		if *isActive {
+			user.Activate()
+		} else {
+			user.Deactivate()
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	log.Warn().
+		Str("user_id", userID.String()).
+		Str("role", string(user.Role)).
+		Bool("active", user.IsActive).
+		Str("updated_by", updatedBy.String()).
+		Msg("User updated")
+
+	return user, nil
+}
+
+// lockoutSubject returns the key of the lockout state of logins to an email.
+// The email is normalized and hashed so that keys hold no addresses.
+func lockoutSubject(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkLockout returns an AccountLockedError while logins to an email are locked.
+func (s *AuthService) checkLockout(ctx context.Context, subject string) error {
+	if !s.lockout.IsEnabled() {
+		return nil
+	}
+
+	var until time.Time
+	if err := s.cacheRepo.Get(ctx, lockoutKeyPrefix+subject, &until); err != nil {
+		return nil
+	}
+	if time.Now().Before(until) {
+		return &AccountLockedError{Until: until}
+	}
+
+	return nil
+}
+
+// recordFailure counts a failed login to an email and, once the policy says
+// so, locks it, returning an AccountLockedError. Existing users are told when
+// their account gets locked for the first time in a streak of failures; user
+// is nil for unknown emails.
+func (s *AuthService) recordFailure(ctx context.Context, subject string, user *entity.User, client ClientInfo) error {
+	if !s.lockout.IsEnabled() {
+		return nil
+	}
+
+	logCtx := log.With().Str("login", subject)
+	if user != nil {
+		logCtx = logCtx.Str("user_id", user.ID.String())
+	}
+	logger := logCtx.Logger()
+
+	key := loginFailuresKeyPrefix + subject
+	failures, err := s.cacheRepo.Increment(ctx, key)
+	if err != nil {
+		// Fail open: the login rate limiter still applies
+		logger.Error().Err(err).Msg("Failed to count failed login")
+		return nil
+	}
+	_ = s.cacheRepo.Expire(ctx, key, s.lockout.ResetAfter)
+
+	logger.Info().
+		Int64("failures", failures).
+		Str("ip", client.IP).
+		Msg("Login failed")
+
+	delay := s.lockout.DelayAfter(int(failures))
+	if delay == 0 {
+		return nil
+	}
+
+	until := time.Now().Add(delay).UTC()
+	if err := s.cacheRepo.Set(ctx, lockoutKeyPrefix+subject, until, delay); err != nil {
+		logger.Error().Err(err).Msg("Failed to lock account")
+		return nil
+	}
+
+	metrics.AuthLockoutsTotal.Inc()
+	logger.Warn().
+		Int64("failures", failures).
+		Str("ip", client.IP).
+		Time("locked_until", until).
+		Msg("Account locked")
+
+	if user != nil && int(failures) == s.lockout.MaxAttempts {
+		s.notifyLockout(context.WithoutCancel(ctx), user, until, client)
+	}
+
+	return &AccountLockedError{Until: until}
+}
+
+// clearFailures forgets the failed logins to an email after a successful one.
+func (s *AuthService) clearFailures(ctx context.Context, subject string) {
+	if !s.lockout.IsEnabled() {
+		return
+	}
+	_ = s.cacheRepo.Delete(ctx, loginFailuresKeyPrefix+subject)
+}
+
+// notifyLockout emails a user that their account was locked, in the
+// background so that the login response is not delayed.
+func (s *AuthService) notifyLockout(ctx context.Context, user *entity.User, until time.Time, client ClientInfo) {
+	if s.mailer == nil || !s.mailer.IsEnabled() {
+		return
+	}
+
+	body := fmt.Sprintf("Hello %s,\n\n"+
+		"Your account was locked after %d failed login attempts, the last one from %s.\n"+
+		"You can log in again after %s.\n\n"+
+		"If this was not you, change your password once you are back in and tell an administrator.\n",
+		user.Name, s.lockout.MaxAttempts, client.IP, until.Format(time.RFC1123))
+
+	go func() {
+		if err := s.mailer.SendMail(ctx, user.Email, "Your account was locked", body); err != nil {
+			log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to send lockout notification")
+		}
+	}()
+}
+
+// revokeToken revokes a token until it expires. Without token revocation,
+// or for tokens issued without an ID, the token is blacklisted in the cache
+// for ttl instead.
+func (s *AuthService) revokeToken(ctx context.Context, tokenString string, claims *JWTClaims, ttl time.Duration) {
+	if s.revocations == nil || claims.ID == "" {
+		_ = s.cacheRepo.Set(ctx, blacklistKey(tokenString, claims), true, ttl)
+		return
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	if err := s.revocations.revoke(ctx, claims.ID, expiresAt); err != nil {
+		log.Error().Err(err).Str("user_id", claims.UserID).Msg("Failed to revoke token")
+	}
+}
+
+// isTokenRevoked checks whether a token was revoked. Like the cache it
+// fails open: a token whose check fails is accepted.
+func (s *AuthService) isTokenRevoked(ctx context.Context, tokenString string, claims *JWTClaims) bool {
+	if s.revocations == nil || claims.ID == "" {
+		exists, _ := s.cacheRepo.Exists(ctx, blacklistKey(tokenString, claims))
+		return exists
+	}
+
+	revoked, err := s.revocations.isRevoked(ctx, claims.ID)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to check token revocation")
+		return false
+	}
+	return revoked
+}
+
+// endSession deletes a session. With token revocation its ID is revoked too,
+// until the last access token it issued expires, so that validating tokens
+// does not need to look sessions up.
+func (s *AuthService) endSession(ctx context.Context, session *entity.Session) error {
+	if err := s.sessionRepo.Delete(ctx, session); err != nil {
+		return err
+	}
+
+	if s.revocations != nil {
+		expiresAt := time.Now().Add(s.jwtConfig.Expiration)
+		if err := s.revocations.revoke(ctx, sessionRevocationID(session.ID), expiresAt); err != nil {
+			log.Error().Err(err).Str("session_id", session.ID.String()).Msg("Failed to revoke session")
+		}
+	}
+
+	return nil
+}
+
+// isSessionEnded checks whether the session of a token ended. With token
+// revocation the filter of revoked IDs answers for most sessions, and only
+// those it may contain are checked in Redis; without it the session is
+// looked up. Like the token check it fails open.
+func (s *AuthService) isSessionEnded(ctx context.Context, sessionID entity.ID) bool {
+	if s.revocations == nil {
+		active, err := s.sessionRepo.Exists(ctx, sessionID)
+		return err == nil && !active
+	}
+
+	revoked, err := s.revocations.isRevoked(ctx, sessionRevocationID(sessionID))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to check session revocation")
+		return false
+	}
+	return revoked
+}
+
+// sessionRevocationID is the ID an ended session is revoked under, apart
+// from the token IDs.
+func sessionRevocationID(sessionID entity.ID) string {
+	return "session:" + sessionID.String()
+}
+
+// blacklistKey returns the cache key blacklisting a token, by its ID when
+// it has one.
+func blacklistKey(tokenString string, claims *JWTClaims) string {
+	if claims.ID != "" {
+		return "blacklist:" + claims.ID
+	}
+	return "blacklist:" + tokenString
+}
+
+// startSession opens a session for the client and issues its tokens.
+func (s *AuthService) startSession(ctx context.Context, user *entity.User, client ClientInfo) (*TokenPair, error) {
+	session := entity.NewSession(user.ID, client.Device, client.IP, s.jwtConfig.RefreshExpiration)
+	if err := s.sessionRepo.Save(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return s.generateTokenPair(user, session.ID)
+}
+
+// activeSession loads the session a token belongs to.
+func (s *AuthService) activeSession(ctx context.Context, claims *JWTClaims) (*entity.Session, error) {
+	sessionID, err := entity.ParseID(claims.SessionID)
+	if err != nil {
+		return nil, ErrTokenInvalid
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTokenInvalid
+		}
+		return nil, err
+	}
+
+	if claims.UserID != session.UserID.String() {
+		return nil, ErrTokenInvalid
+	}
+
+	return session, nil
+}
+
+// generateTokenPair creates access and refresh tokens for a session.
+func (s *AuthService) generateTokenPair(user *entity.User, sessionID entity.ID) (*TokenPair, error) {
 	now := time.Now()
 	expiresAt := now.Add(s.jwtConfig.Expiration)
 
 	// Access token claims
 	accessClaims := JWTClaims{
-		UserID: user.ID.String(),
-		Email:  user.Email,
-		Role:   string(user.Role),
+		UserID:    user.ID.String(),
+		Email:     user.Email,
+		Role:      string(user.Role),
+		SessionID: sessionID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        entity.NewID().String(),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			Issuer:    s.jwtConfig.Issuer,
@@ -223,10 +701,12 @@ func (s *AuthService) generateTokenPair(user *entity.User) (*TokenPair, error) {
 
 	// Refresh token claims
 	refreshClaims := JWTClaims{
-		UserID: user.ID.String(),
-		Email:  user.Email,
-		Role:   string(user.Role),
+		UserID:    user.ID.String(),
+		Email:     user.Email,
+		Role:      string(user.Role),
+		SessionID: sessionID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        entity.NewID().String(),
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.jwtConfig.RefreshExpiration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			Issuer:    s.jwtConfig.Issuer,
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Check errors.
+var (
+	ErrCheckNotFound = errors.New("check not found")
+	ErrCheckExists   = errors.New("check with this name already exists")
+)
+
+// checkRunKeyPrefix is the cache key prefix of the lock taken by the
+// instance probing a check, for one interval.
+const checkRunKeyPrefix = "check_run:"
+
+// CheckSource is the source of the alerts raised for failing checks.
+const CheckSource = "uptime"
+
+// CheckProber probes the target of an uptime check. Failures are reported
+// in the result rather than as errors.
+type CheckProber interface {
+	Probe(ctx context.Context, check *entity.Check) entity.CheckResult
+}
+
+// CheckInput holds the settings of a new check.
+type CheckInput struct {
+	Name             string
+	URL              string
+	Method           string
+	ExpectedStatus   int
+	IntervalSeconds  int
+	TimeoutSeconds   int
+	FailureThreshold int
+	Severity         entity.AlertSeverity
+	TeamID           *entity.ID
+}
+
+// CheckUpdate holds the changes to a check; nil fields are left unchanged.
+type CheckUpdate struct {
+	Name             *string
+	URL              *string
+	Method           *string
+	ExpectedStatus   *int
+	IntervalSeconds  *int
+	TimeoutSeconds   *int
+	FailureThreshold *int
+	Severity         *entity.AlertSeverity
+	IsEnabled        *bool
+}
+
+// CheckService manages synthetic uptime checks, runs their probes and raises
+// an alert when a check goes down, resolved once it succeeds again.
+type CheckService struct {
+	checkRepo        repository.CheckRepository
+	cacheRepo        repository.CacheRepository
+	alertService     *AlertService
+	prober           CheckProber
+	historyRetention time.Duration
+}
+
+// NewCheckService creates a new check service. Probe results older than
+// historyRetention are purged; zero keeps them forever.
+func NewCheckService(
+	checkRepo repository.CheckRepository,
+	cacheRepo repository.CacheRepository,
+	alertService *AlertService,
+	prober CheckProber,
+	historyRetention time.Duration,
+) *CheckService {
+	return &CheckService{
+		checkRepo:        checkRepo,
+		cacheRepo:        cacheRepo,
+		alertService:     alertService,
+		prober:           prober,
+		historyRetention: historyRetention,
+	}
+}
+
+// Create creates a check; it is first probed on the next schedule.
+func (s *CheckService) Create(ctx context.Context, input CheckInput, createdBy *entity.ID) (*entity.Check, error) {
+	check, err := entity.NewCheck(input.Name, input.URL, input.Method, input.ExpectedStatus,
+		input.IntervalSeconds, input.TimeoutSeconds, input.FailureThreshold, input.Severity, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	check.TeamID = input.TeamID
+
+	if err := s.checkRepo.Create(ctx, check); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrCheckExists
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	return check, nil
+}
+
+// GetByID retrieves a check the tenant scope may see. Checks of other teams
+// are reported as not found.
+func (s *CheckService) GetByID(ctx context.Context, id entity.ID, tenant valueobject.TenantScope) (*entity.Check, error) {
+	check, err := s.checkRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrCheckNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(check.TeamID) {
+		return nil, ErrCheckNotFound
+	}
+	return check, nil
+}
+
+// List retrieves the checks of the tenant scope.
+func (s *CheckService) List(
+	ctx context.Context,
+	tenant valueobject.TenantScope,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Check], error) {
+	return s.checkRepo.List(ctx, tenant, pagination)
+}
+
+// Update changes the settings of a check the tenant scope may see.
+func (s *CheckService) Update(
+	ctx context.Context,
+	id entity.ID,
+	tenant valueobject.TenantScope,
+	update CheckUpdate,
+) (*entity.Check, error) {
+	check, err := s.GetByID(ctx, id, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		check.Name = *update.Name
+	}
+	if update.URL != nil {
+		check.URL = *update.URL
+	}
+	if update.Method != nil {
+		check.Method = *update.Method
+	}
+	if update.ExpectedStatus != nil {
+		check.ExpectedStatus = *update.ExpectedStatus
+	}
+	if update.IntervalSeconds != nil {
+		check.IntervalSeconds = *update.IntervalSeconds
+	}
+	if update.TimeoutSeconds != nil {
+		check.TimeoutSeconds = *update.TimeoutSeconds
+	}
+	if update.FailureThreshold != nil {
+		check.FailureThreshold = *update.FailureThreshold
+	}
+	if update.Severity != nil {
+		check.Severity = *update.Severity
+	}
+	if err := check.Validate(); err != nil {
+		return nil, err
+	}
+
+	if update.IsEnabled != nil {
+		if *update.IsEnabled {
+			check.Enable()
+		} else {
+			check.Disable()
+		}
+	}
+	check.Touch()
+
+	if err := s.checkRepo.Update(ctx, check); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, ErrCheckExists
+		}
+		return nil, err
+	}
+
+	return check, nil
+}
+
+// Delete removes a check the tenant scope may see and its history. An open
+// alert of the check is left for users to resolve.
+func (s *CheckService) Delete(ctx context.Context, id entity.ID, tenant valueobject.TenantScope) error {
+	if _, err := s.GetByID(ctx, id, tenant); err != nil {
+		return err
+	}
+	if err := s.checkRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrCheckNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// History retrieves the probe results of a check the tenant scope may see,
+// newest first.
+func (s *CheckService) History(
+	ctx context.Context,
+	id entity.ID,
+	tenant valueobject.TenantScope,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.CheckResult], error) {
+	if _, err := s.GetByID(ctx, id, tenant); err != nil {
+		return nil, err
+	}
+	return s.checkRepo.ListResults(ctx, id, pagination)
+}
+
+// ClaimDue returns up to limit checks due for a probe, claimed by this
+// instance for one interval so that other instances skip them.
+func (s *CheckService) ClaimDue(ctx context.Context, limit int) ([]*entity.Check, error) {
+	due, err := s.checkRepo.ListDue(ctx, time.Now().UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]*entity.Check, 0, len(due))
+	for _, check := range due {
+		acquired, err := s.cacheRepo.SetNX(ctx, checkRunKeyPrefix+check.ID.String(), time.Now().UTC(), check.Interval())
+		if err != nil {
+			return claimed, err
+		}
+		if acquired {
+			claimed = append(claimed, check)
+		}
+	}
+
+	return claimed, nil
+}
+
+// Run probes a check, records the result and raises or resolves the alert
+// of the check when its status changes.
+func (s *CheckService) Run(ctx context.Context, check *entity.Check) error {
+	result := s.prober.Probe(ctx, check)
+
+	outcome := "success"
+	if !result.Success {
+		outcome = "failure"
+	}
+	metrics.CheckProbesTotal.WithLabelValues(outcome).Inc()
+	metrics.CheckProbeDuration.Observe(float64(result.LatencyMs) / 1000)
+
+	transition := check.Record(result)
+	if err := s.checkRepo.AddResult(ctx, &result); err != nil {
+		return err
+	}
+	if err := s.checkRepo.UpdateStatus(ctx, check); err != nil {
+		return err
+	}
+
+	switch transition {
+	case entity.CheckWentDown:
+		metrics.ChecksDownTotal.Inc()
+		log.Warn().
+			Str("check_id", check.ID.String()).
+			Str("check", check.Name).
+			Str("error", result.Error).
+			Msg("Uptime check is down")
+		_, _, err := s.alertService.CreateDeduplicated(ctx, check.DedupKey(), checkAlertInput(check, result))
+		return err
+	case entity.CheckRecovered:
+		log.Info().Str("check_id", check.ID.String()).Msg("Uptime check is back up")
+		if _, err := s.alertService.ResolveByDedupKey(ctx, check.DedupKey()); err != nil &&
+			!errors.Is(err, ErrAlertNotFound) && !errors.Is(err, entity.ErrAlertAlreadyResolved) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PurgeHistory removes the probe results past the retention period and
+// returns how many were removed.
+func (s *CheckService) PurgeHistory(ctx context.Context) (int64, error) {
+	if s.historyRetention <= 0 {
+		return 0, nil
+	}
+	return s.checkRepo.DeleteResultsBefore(ctx, time.Now().UTC().Add(-s.historyRetention))
+}
+
+// checkAlertInput describes the alert raised for a check that went down.
+func checkAlertInput(check *entity.Check, result entity.CheckResult) CreateAlertInput {
+	return CreateAlertInput{
+		Title: "Uptime check failing: " + check.Name,
+		Message: fmt.Sprintf("%s %s failed %d consecutive times: %s",
+			check.Method, check.URL, check.ConsecutiveFailures, result.Error),
+		Severity: check.Severity,
+		Source:   CheckSource,
+		Metadata: map[string]interface{}{
+			"check_id":    check.ID.String(),
+			"url":         check.URL,
+			"status_code": result.StatusCode,
+			"latency_ms":  result.LatencyMs,
+		},
+		TeamID: check.TeamID,
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+)
+
+// Dashboard summary bounds.
+const (
+	// dashboardCacheTTL is how long a summary is served before it is assembled again.
+	dashboardCacheTTL = 15 * time.Second
+	// dashboardNoisyWindow is the period over which noisy sources are ranked.
+	dashboardNoisyWindow = 24 * time.Hour
+	// dashboardNoisySources is the number of noisy sources reported.
+	dashboardNoisySources = 5
+	// dashboardRecentAlerts is the number of recent alerts reported.
+	dashboardRecentAlerts = 10
+	// dashboardMaxIncidents bounds the open incidents whose commanders are reported.
+	dashboardMaxIncidents = 50
+)
+
+// SourceCount is the number of alerts raised by a source.
+type SourceCount struct {
+	Source string `json:"source"`
+	Count  int64  `json:"count"`
+}
+
+// OnCallResponder is a user commanding open incidents.
+type OnCallResponder struct {
+	User        *entity.User `json:"user"`
+	IncidentIDs []entity.ID  `json:"incident_ids"`
+}
+
+// DashboardSummary gathers what the dashboard shows on load.
+type DashboardSummary struct {
+	// ActiveBySeverity counts the active alerts by severity.
+	ActiveBySeverity map[string]int64 `json:"active_by_severity"`
+	// ActiveAlerts is the total of active alerts.
+	ActiveAlerts int64 `json:"active_alerts"`
+	// NoisySources are the sources that raised the most alerts in the last
+	// 24 hours, noisiest first.
+	NoisySources []SourceCount `json:"noisy_sources"`
+	// RecentAlerts are the latest alerts, newest first.
+	RecentAlerts []*entity.Alert `json:"recent_alerts"`
+	// OpenIncidents is the number of open incidents.
+	OpenIncidents int64 `json:"open_incidents"`
+	// OnCall are the commanders of the open incidents.
+	OnCall []OnCallResponder `json:"on_call"`
+	// GeneratedAt is when the summary was assembled.
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// DashboardService assembles the dashboard summary in one call.
+type DashboardService struct {
+	alertRepo    repository.AlertRepository
+	incidentRepo repository.IncidentRepository
+	userRepo     repository.UserRepository
+	cacheRepo    repository.CacheRepository
+	alertService *AlertService
+}
+
+// NewDashboardService creates a new dashboard service.
+func NewDashboardService(
+	alertRepo repository.AlertRepository,
+	incidentRepo repository.IncidentRepository,
+	userRepo repository.UserRepository,
+	cacheRepo repository.CacheRepository,
+	alertService *AlertService,
+) *DashboardService {
+	return &DashboardService{
+		alertRepo:    alertRepo,
+		incidentRepo: incidentRepo,
+		userRepo:     userRepo,
+		cacheRepo:    cacheRepo,
+		alertService: alertService,
+	}
+}
+
+// Summary returns the dashboard summary of the alerts and incidents visible
+// to a caller. Its parts are queried concurrently and the result is cached for
+// 15 seconds, shared by callers seeing the same alerts.
+func (s *DashboardService) Summary(
+	ctx context.Context,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) (*DashboardSummary, error) {
+	ctx, span := tracing.StartSpan(ctx, "DashboardService.Summary")
+	defer span.End()
+
+	visible := s.alertService.VisibleFilter(role, tenant)
+	cacheKey := dashboardCacheKey(role, visible)
+
+	var cached DashboardSummary
+	if err := s.cacheRepo.Get(ctx, cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	summary := &DashboardSummary{GeneratedAt: time.Now()}
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		stats, err := s.alertRepo.GetStatistics(gctx, visible.WithStatuses(entity.AlertStatusActive))
+		if err != nil {
+			return err
+		}
+		summary.ActiveBySeverity = stats.BySeverity
+		summary.ActiveAlerts = stats.ActiveAlerts
+		return nil
+	})
+
+	g.Go(func() error {
+		stats, err := s.alertRepo.GetStatistics(gctx,
+			visible.WithDateRange(summary.GeneratedAt.Add(-dashboardNoisyWindow), summary.GeneratedAt))
+		if err != nil {
+			return err
+		}
+		summary.NoisySources = noisiestSources(stats.BySource, dashboardNoisySources)
+		return nil
+	})
+
+	g.Go(func() error {
+		recent, err := s.alertRepo.List(gctx, visible, valueobject.NewPagination(1, dashboardRecentAlerts))
+		if err != nil {
+			return err
+		}
+		summary.RecentAlerts = recent.Items
+		return nil
+	})
+
+	g.Go(func() error {
+		open := entity.IncidentStatusOpen
+		filter := repository.IncidentFilter{Status: &open}
+		if !tenant.IsAll() {
+			filter.Tenant = &tenant
+		}
+		incidents, err := s.incidentRepo.List(gctx, filter, valueobject.NewPagination(1, dashboardMaxIncidents))
+		if err != nil {
+			return err
+		}
+		summary.OpenIncidents = incidents.TotalItems
+		summary.OnCall, err = s.commanders(gctx, incidents.Items)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		tracing.RecordError(ctx, err)
+		return nil, err
+	}
+
+	_ = s.cacheRepo.Set(ctx, cacheKey, summary, dashboardCacheTTL)
+
+	return summary, nil
+}
+
+// commanders groups incidents by commander, in the order they were first
+// seen. Commanders that no longer exist are skipped.
+func (s *DashboardService) commanders(ctx context.Context, incidents []*entity.Incident) ([]OnCallResponder, error) {
+	responders := []OnCallResponder{}
+	index := make(map[entity.ID]int)
+
+	for _, incident := range incidents {
+		if incident.CommanderID == nil {
+			continue
+		}
+		if i, ok := index[*incident.CommanderID]; ok {
+			responders[i].IncidentIDs = append(responders[i].IncidentIDs, incident.ID)
+			continue
+		}
+
+		user, err := s.userRepo.GetByID(ctx, *incident.CommanderID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		index[user.ID] = len(responders)
+		responders = append(responders, OnCallResponder{User: user, IncidentIDs: []entity.ID{incident.ID}})
+	}
+
+	return responders, nil
+}
+
+// dashboardCacheKey returns the cache key of the summary of a visible filter,
+// shared like statistics by callers seeing the same alerts.
+func dashboardCacheKey(role entity.UserRole, filter valueobject.AlertFilter) string {
+	key := "dashboard"
+	if filter.Tenant != nil {
+		key += ":tenant:" + filter.Tenant.Key()
+	}
+	if len(filter.Hidden) == 0 {
+		return key
+	}
+	return key + ":" + string(role)
+}
+
+// noisiestSources returns the sources with the highest counts, noisiest first
+// and by name on ties.
+func noisiestSources(bySource map[string]int64, limit int) []SourceCount {
+	sources := make([]SourceCount, 0, len(bySource))
+	for source, count := range bySource {
+		sources = append(sources, SourceCount{Source: source, Count: count})
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Count != sources[j].Count {
+			return sources[i].Count > sources[j].Count
+		}
+		return sources[i].Source < sources[j].Source
+	})
+
+	if len(sources) > limit {
+		sources = sources[:limit]
+	}
+	return sources
+}
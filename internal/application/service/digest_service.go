@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// digestIndexKey is the set of the users with a pending digest.
+const digestIndexKey = "digest:index"
+
+// DigestService accumulates significant events for users without an active
+// WebSocket session and delivers them as a "while you were away" summary.
+type DigestService struct {
+	cacheRepo repository.CacheRepository
+	userRepo  repository.UserRepository
+	mailer    notification.Mailer
+	maxItems  int
+	retention time.Duration
+}
+
+// NewDigestService creates a new digest service. Mailer may be nil.
+func NewDigestService(
+	cacheRepo repository.CacheRepository,
+	userRepo repository.UserRepository,
+	cfg config.DigestConfig,
+	mailer notification.Mailer,
+) *DigestService {
+	maxItems := cfg.MaxItems
+	if maxItems <= 0 {
+		maxItems = 100
+	}
+	retention := cfg.Retention
+	if retention <= 0 {
+		retention = 7 * 24 * time.Hour
+	}
+
+	return &DigestService{
+		cacheRepo: cacheRepo,
+		userRepo:  userRepo,
+		mailer:    mailer,
+		maxItems:  maxItems,
+		retention: retention,
+	}
+}
+
+// Record adds an event to a user's digest, dropping the oldest items beyond the limit.
+func (s *DigestService) Record(ctx context.Context, userID entity.ID, item dto.DigestItem) error {
+	if item.OccurredAt.IsZero() {
+		item.OccurredAt = time.Now().UTC()
+	}
+
+	if err := s.cacheRepo.ListAppend(ctx, digestKey(userID), item, int64(s.maxItems), s.retention); err != nil {
+		return err
+	}
+
+	return s.cacheRepo.AddToSet(ctx, digestIndexKey, userID.String(), s.retention)
+}
+
+// Drain returns and clears a user's digest. Returns nil if there is nothing to report.
+func (s *DigestService) Drain(ctx context.Context, userID entity.ID) (*dto.DigestResponse, error) {
+	var items []dto.DigestItem
+	if err := s.cacheRepo.ListTake(ctx, digestKey(userID), &items); err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	if err := s.cacheRepo.Delete(ctx, digestEmailedKey(userID)); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to clear email digest marker")
+	}
+
+	resp := dto.NewDigestResponse(items)
+	return &resp, nil
+}
+
+// SendEmailDigests emails every user the digest items not yet sent by email.
+// Items stay in the digest so they are still shown on the user's next login.
+// Returns the number of emails sent.
+func (s *DigestService) SendEmailDigests(ctx context.Context) (int, error) {
+	if s.mailer == nil || !s.mailer.IsEnabled() {
+		return 0, nil
+	}
+
+	index, err := s.cacheRepo.SetMembers(ctx, digestIndexKey)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, rawID := range index {
+		userID, err := entity.ParseID(rawID)
+		if err != nil {
+			_ = s.cacheRepo.RemoveFromSet(ctx, digestIndexKey, rawID)
+			continue
+		}
+
+		var items []dto.DigestItem
+		if err := s.cacheRepo.ListRange(ctx, digestKey(userID), &items); err != nil {
+			return sent, err
+		}
+		if len(items) == 0 {
+			// Drained or expired; drop from the index.
+			if err := s.cacheRepo.RemoveFromSet(ctx, digestIndexKey, rawID); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		pending, err := s.pendingEmail(ctx, userID, items)
+		if err != nil {
+			return sent, err
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", rawID).Msg("Failed to load user for email digest")
+			continue
+		}
+
+		subject, body := buildDigestEmail(user, dto.NewDigestResponse(pending))
+		if err := s.mailer.SendMail(ctx, user.Email, subject, body); err != nil {
+			log.Error().Err(err).Str("user_id", rawID).Msg("Failed to send email digest")
+			continue
+		}
+
+		emailedAt := pending[len(pending)-1].OccurredAt
+		if err := s.cacheRepo.Set(ctx, digestEmailedKey(userID), emailedAt, s.retention); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// pendingEmail returns the items of a digest that occurred after the last
+// item sent by email.
+func (s *DigestService) pendingEmail(ctx context.Context, userID entity.ID, items []dto.DigestItem) ([]dto.DigestItem, error) {
+	var emailedAt time.Time
+	if err := s.cacheRepo.Get(ctx, digestEmailedKey(userID), &emailedAt); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	for i, item := range items {
+		if item.OccurredAt.After(emailedAt) {
+			return items[i:], nil
+		}
+	}
+	return nil, nil
+}
+
+// digestKey returns the cache key of a user's digest.
+func digestKey(userID entity.ID) string {
+	return "digest:items:" + userID.String()
+}
+
+// digestEmailedKey returns the cache key of when the last item of a user's
+// digest sent by email occurred.
+func digestEmailedKey(userID entity.ID) string {
+	return "digest:emailed:" + userID.String()
+}
+
+// buildDigestEmail renders the subject and plain-text body of a digest email.
+func buildDigestEmail(user *entity.User, digest dto.DigestResponse) (string, string) {
+	subject := fmt.Sprintf("While you were away: %d update(s)", digest.Total)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", user.Name)
+	fmt.Fprintf(&body, "Since %s you have %d update(s):\n\n", digest.Since.Format(time.RFC1123), digest.Total)
+	for _, item := range digest.Items {
+		fmt.Fprintf(&body, "- [%s] %s", item.Kind, item.Title)
+		if item.AlertID != "" {
+			fmt.Fprintf(&body, " (alert %s)", item.AlertID)
+		}
+		if item.IncidentID != "" {
+			fmt.Fprintf(&body, " (incident %s)", item.IncidentID)
+		}
+		body.WriteString("\n")
+	}
+
+	return subject, body.String()
+}
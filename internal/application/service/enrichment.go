@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// AlertEnricher adds context to a new alert before it is stored, usually as
+// metadata. An enricher that finds nothing to add leaves the alert as is.
+type AlertEnricher interface {
+	// Name identifies the enricher in configuration, logs and metrics.
+	Name() string
+	// Enrich adds context to the alert. It must return when ctx is done.
+	Enrich(ctx context.Context, alert *entity.Alert) error
+}
+
+// EnrichmentStage is an enricher of the pipeline with the time it is given.
+type EnrichmentStage struct {
+	Enricher AlertEnricher
+	// Timeout bounds the enricher; zero leaves it unbounded.
+	Timeout time.Duration
+}
+
+// EnrichmentPipeline runs enrichers on new alerts, one after the other.
+// Enrichment is best effort: a failing or slow enricher is logged and
+// skipped, and never prevents an alert from being created.
+type EnrichmentPipeline struct {
+	stages []EnrichmentStage
+}
+
+// NewEnrichmentPipeline creates a pipeline running the stages in order.
+func NewEnrichmentPipeline(stages ...EnrichmentStage) *EnrichmentPipeline {
+	return &EnrichmentPipeline{stages: stages}
+}
+
+// Len returns the number of enrichers of the pipeline.
+func (p *EnrichmentPipeline) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.stages)
+}
+
+// Enrich runs every enricher on the alert.
+func (p *EnrichmentPipeline) Enrich(ctx context.Context, alert *entity.Alert) {
+	if p == nil {
+		return
+	}
+
+	for _, stage := range p.stages {
+		p.run(ctx, stage, alert)
+	}
+}
+
+// run runs one enricher within its timeout.
+func (p *EnrichmentPipeline) run(ctx context.Context, stage EnrichmentStage, alert *entity.Alert) {
+	name := stage.Enricher.Name()
+	if stage.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, stage.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := stage.Enricher.Enrich(ctx, alert)
+	metrics.AlertEnrichmentDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		metrics.AlertEnrichmentFailuresTotal.WithLabelValues(name).Inc()
+		log.Warn().
+			Err(err).
+			Str("enricher", name).
+			Str("alert_id", alert.ID.String()).
+			Msg("Alert enrichment failed")
+	}
+}
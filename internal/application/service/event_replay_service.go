@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Event replay errors.
+var (
+	ErrReplayUnavailable   = errors.New("the event bus does not retain events for replay")
+	ErrReplayStreamInvalid = errors.New("events can only be replayed from the alerts, alerts-priority or notifications stream")
+	ErrReplayRangeInvalid  = errors.New("replay range must end after it starts")
+)
+
+// Replay limits.
+const (
+	defaultReplayLimit = 1000
+	maxReplayLimit     = 10000
+)
+
+// ReplayInput selects the events to replay.
+type ReplayInput struct {
+	Stream string
+	From   time.Time
+	// To defaults to now.
+	To    time.Time
+	Types []event.Type
+	// Limit caps the number of replayed events; zero applies the default.
+	Limit  int
+	DryRun bool
+}
+
+// ReplayResult describes the events a replay re-dispatched.
+type ReplayResult struct {
+	DryRun bool
+	// Replayed is the number of events published again (or that would be).
+	Replayed int
+	// Truncated is true when more events matched than the limit allowed.
+	Truncated bool
+	SampleIDs []string
+}
+
+// EventReplayService re-dispatches events retained by the event bus, so that
+// consumers can rebuild their state after an outage.
+type EventReplayService struct {
+	reader    event.Reader
+	publisher event.Publisher
+}
+
+// NewEventReplayService creates a new event replay service. A nil reader
+// disables replay, e.g. for buses that do not retain events.
+func NewEventReplayService(reader event.Reader, publisher event.Publisher) *EventReplayService {
+	return &EventReplayService{
+		reader:    reader,
+		publisher: publisher,
+	}
+}
+
+// Replay reads the matching events back from their stream and publishes them
+// again to it. Every consumer group of the stream receives them again, but
+// alert handlers skip events they already processed within their idempotency window.
+// With DryRun set nothing is published and the result describes what would be.
+func (s *EventReplayService) Replay(ctx context.Context, input ReplayInput) (*ReplayResult, error) {
+	if s.reader == nil {
+		return nil, ErrReplayUnavailable
+	}
+	if input.Stream != event.StreamAlerts && input.Stream != event.StreamAlertsPriority &&
+		input.Stream != event.StreamNotifications {
+		return nil, ErrReplayStreamInvalid
+	}
+
+	to := input.To
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if !to.After(input.From) {
+		return nil, ErrReplayRangeInvalid
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultReplayLimit
+	}
+	if limit > maxReplayLimit {
+		limit = maxReplayLimit
+	}
+
+	// Read one extra event to tell whether the limit truncated the replay
+	events, err := s.reader.Read(ctx, input.Stream, event.ReadFilter{
+		From:  input.From,
+		To:    to,
+		Types: input.Types,
+		Limit: limit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReplayResult{
+		DryRun:    input.DryRun,
+		Truncated: len(events) > limit,
+		SampleIDs: make([]string, 0, bulkSampleSize),
+	}
+	if result.Truncated {
+		events = events[:limit]
+	}
+
+	for _, evt := range events {
+		if len(result.SampleIDs) < bulkSampleSize {
+			result.SampleIDs = append(result.SampleIDs, evt.ID)
+		}
+		if input.DryRun {
+			result.Replayed++
+			continue
+		}
+
+		evt.Retries = 0
+		evt.LastError = ""
+		evt.FailedGroup = ""
+		if err := s.publisher.PublishToStream(ctx, input.Stream, evt); err != nil {
+			return nil, err
+		}
+		result.Replayed++
+		metrics.EventsReplayedTotal.WithLabelValues(string(evt.Type), input.Stream).Inc()
+	}
+
+	if !input.DryRun {
+		log.Warn().
+			Str("stream", input.Stream).
+			Time("from", input.From).
+			Time("to", to).
+			Int("replayed", result.Replayed).
+			Bool("truncated", result.Truncated).
+			Msg("Events replayed")
+	}
+
+	return result, nil
+}
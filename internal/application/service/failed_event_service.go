@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// ErrFailedEventNotFound is returned when no dead-lettered event has the given ID.
+var ErrFailedEventNotFound = errors.New("failed event not found")
+
+// Bulk operations on failed events work through matching events in batches
+// and stop after failedEventBulkMax events.
+const (
+	failedEventBulkBatch = 100
+	failedEventBulkMax   = 1000
+)
+
+// FailedEventService stores events moved to the dead letter queue and lets
+// operators retry or ignore them.
+type FailedEventService struct {
+	failedEventRepo repository.FailedEventRepository
+	publisher       event.Publisher
+}
+
+// NewFailedEventService creates a new failed event service. Retried events
+// are published again through publisher.
+func NewFailedEventService(failedEventRepo repository.FailedEventRepository, publisher event.Publisher) *FailedEventService {
+	return &FailedEventService{
+		failedEventRepo: failedEventRepo,
+		publisher:       publisher,
+	}
+}
+
+// Record stores a dead-lettered event as pending.
+func (s *FailedEventService) Record(ctx context.Context, evt *event.Event) error {
+	failed := entity.NewFailedEvent(evt.ID, string(evt.Type), evt.Payload, evt.Retries, evt.LastError)
+	return s.failedEventRepo.Save(ctx, failed)
+}
+
+// List returns the failed events matching the filter, most recent first.
+func (s *FailedEventService) List(
+	ctx context.Context,
+	filter valueobject.FailedEventFilter,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.FailedEvent], error) {
+	return s.failedEventRepo.List(ctx, filter, pagination)
+}
+
+// Retry publishes a failed event again with its retries reset.
+func (s *FailedEventService) Retry(ctx context.Context, eventID string) error {
+	failed, err := s.get(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	return s.retry(ctx, failed)
+}
+
+// Ignore dismisses a pending failed event.
+func (s *FailedEventService) Ignore(ctx context.Context, eventID string) error {
+	failed, err := s.get(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	return s.ignore(ctx, failed)
+}
+
+// BulkRetry retries every pending or ignored event matching the filter.
+// Without a status filter only pending events are retried.
+// With dryRun set nothing is retried and the result describes what would be.
+func (s *FailedEventService) BulkRetry(ctx context.Context, filter valueobject.FailedEventFilter, dryRun bool) (*BulkResult, error) {
+	statuses := make([]entity.FailedEventStatus, 0, len(filter.Statuses))
+	for _, status := range filter.Statuses {
+		if status != entity.FailedEventStatusRetried {
+			statuses = append(statuses, status)
+		}
+	}
+	if len(statuses) == 0 {
+		statuses = []entity.FailedEventStatus{entity.FailedEventStatusPending}
+	}
+
+	return s.processMatching(ctx, filter.WithStatuses(statuses...), dryRun, s.retry)
+}
+
+// BulkIgnore dismisses every pending event matching the filter.
+// With dryRun set nothing is changed and the result describes what would be.
+func (s *FailedEventService) BulkIgnore(ctx context.Context, filter valueobject.FailedEventFilter, dryRun bool) (*BulkResult, error) {
+	filter = filter.WithStatuses(entity.FailedEventStatusPending)
+	return s.processMatching(ctx, filter, dryRun, s.ignore)
+}
+
+// Purge removes every failed event matching the filter.
+// With dryRun set nothing is removed and the result describes what would be.
+func (s *FailedEventService) Purge(ctx context.Context, filter valueobject.FailedEventFilter, dryRun bool) (*BulkResult, error) {
+	result, err := s.preview(ctx, filter, dryRun)
+	if err != nil || dryRun || result.Affected == 0 {
+		return result, err
+	}
+
+	deleted, err := s.failedEventRepo.DeleteByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	result.Affected = deleted
+
+	log.Warn().Int64("count", deleted).Msg("Purged failed events")
+	return result, nil
+}
+
+// processMatching previews and, unless dryRun is set, applies process to the
+// events matching filter. process must take events out of the filter, e.g.
+// by changing their status, otherwise they would be visited again.
+func (s *FailedEventService) processMatching(
+	ctx context.Context,
+	filter valueobject.FailedEventFilter,
+	dryRun bool,
+	process func(context.Context, *entity.FailedEvent) error,
+) (*BulkResult, error) {
+	result, err := s.preview(ctx, filter, dryRun)
+	if err != nil || dryRun || result.Affected == 0 {
+		return result, err
+	}
+
+	var processed int64
+	for processed < failedEventBulkMax {
+		page, err := s.failedEventRepo.List(ctx, filter, valueobject.NewPagination(1, failedEventBulkBatch))
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+
+		for _, failed := range page.Items {
+			if err := process(ctx, failed); err != nil {
+				return nil, err
+			}
+			processed++
+		}
+	}
+	result.Affected = processed
+
+	return result, nil
+}
+
+// preview counts the events matching filter and samples their IDs.
+func (s *FailedEventService) preview(ctx context.Context, filter valueobject.FailedEventFilter, dryRun bool) (*BulkResult, error) {
+	preview, err := s.failedEventRepo.List(ctx, filter, valueobject.NewPagination(1, bulkSampleSize))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkResult{
+		DryRun:    dryRun,
+		Affected:  preview.TotalItems,
+		SampleIDs: make([]string, 0, len(preview.Items)),
+	}
+	for _, failed := range preview.Items {
+		result.SampleIDs = append(result.SampleIDs, failed.EventID)
+	}
+
+	return result, nil
+}
+
+// retry publishes a failed event again and marks it retried.
+func (s *FailedEventService) retry(ctx context.Context, failed *entity.FailedEvent) error {
+	if err := failed.MarkRetried(); err != nil {
+		return err
+	}
+
+	evt := &event.Event{
+		ID:        failed.EventID,
+		Type:      event.Type(failed.EventType),
+		Payload:   failed.Payload,
+		Timestamp: time.Now().UTC(),
+		Version:   1,
+		Retries:   0, // Reset retries
+	}
+
+	if err := s.publisher.Publish(ctx, evt); err != nil {
+		return err
+	}
+
+	return s.failedEventRepo.Update(ctx, failed)
+}
+
+// ignore marks a failed event ignored.
+func (s *FailedEventService) ignore(ctx context.Context, failed *entity.FailedEvent) error {
+	if err := failed.MarkIgnored(); err != nil {
+		return err
+	}
+	return s.failedEventRepo.Update(ctx, failed)
+}
+
+// get finds a failed event by its event ID.
+func (s *FailedEventService) get(ctx context.Context, eventID string) (*entity.FailedEvent, error) {
+	failed, err := s.failedEventRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrFailedEventNotFound
+		}
+		return nil, err
+	}
+	return failed, nil
+}
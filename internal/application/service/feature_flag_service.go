@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Feature flag errors.
+var (
+	ErrFeatureNotFound   = errors.New("feature flag not found")
+	ErrFeatureOrgInvalid = errors.New("organization ID must not contain ':', '*' or whitespace")
+)
+
+// FeatureFlagService evaluates feature flags. Configured defaults can be
+// overridden at runtime for the whole deployment or for a single
+// organization; overrides are stored in Redis and shared by every instance
+// of the deployment.
+type FeatureFlagService struct {
+	cacheRepo  repository.CacheRepository
+	defaults   valueobject.FeatureDefaults
+	deployment string
+}
+
+// NewFeatureFlagService creates a new feature flag service. Deployment scopes
+// runtime overrides so that deployments sharing a Redis do not affect each other.
+// Nil defaults register the built-in flags, disabled.
+func NewFeatureFlagService(
+	cacheRepo repository.CacheRepository,
+	defaults valueobject.FeatureDefaults,
+	deployment string,
+) *FeatureFlagService {
+	if defaults == nil {
+		defaults, _ = valueobject.NewFeatureDefaults(nil)
+	}
+	if deployment == "" {
+		deployment = "default"
+	}
+
+	s := &FeatureFlagService{
+		cacheRepo:  cacheRepo,
+		defaults:   defaults,
+		deployment: deployment,
+	}
+
+	for name, enabled := range defaults {
+		metrics.FeatureFlagEnabled.WithLabelValues(name).Set(boolGauge(enabled))
+	}
+
+	return s
+}
+
+// IsEnabled evaluates a flag for an organization (empty for none) and records its usage.
+// Unknown flags are disabled. When Redis is unavailable the configured default applies.
+func (s *FeatureFlagService) IsEnabled(ctx context.Context, name, orgID string) bool {
+	if !s.defaults.Has(name) {
+		return false
+	}
+
+	flag := s.evaluate(ctx, name, orgID)
+	metrics.FeatureFlagEvaluationsTotal.WithLabelValues(name, strconv.FormatBool(flag.Enabled)).Inc()
+	return flag.Enabled
+}
+
+// Get evaluates a single flag for an organization (empty for the deployment).
+func (s *FeatureFlagService) Get(ctx context.Context, name, orgID string) (valueobject.FeatureFlag, error) {
+	if err := s.check(name, orgID); err != nil {
+		return valueobject.FeatureFlag{}, err
+	}
+	return s.evaluate(ctx, name, orgID), nil
+}
+
+// List evaluates every known flag for an organization (empty for the deployment).
+func (s *FeatureFlagService) List(ctx context.Context, orgID string) ([]valueobject.FeatureFlag, error) {
+	if err := validateFeatureOrg(orgID); err != nil {
+		return nil, err
+	}
+
+	names := s.defaults.Names()
+	flags := make([]valueobject.FeatureFlag, 0, len(names))
+	for _, name := range names {
+		flags = append(flags, s.evaluate(ctx, name, orgID))
+	}
+	return flags, nil
+}
+
+// Snapshot returns the deployment-wide state of every flag.
+func (s *FeatureFlagService) Snapshot(ctx context.Context) map[string]bool {
+	snapshot := make(map[string]bool, len(s.defaults))
+	for name := range s.defaults {
+		snapshot[name] = s.evaluate(ctx, name, "").Enabled
+	}
+	return snapshot
+}
+
+// Set overrides a flag for the deployment, or for one organization when orgID is set.
+func (s *FeatureFlagService) Set(ctx context.Context, name, orgID string, enabled bool) (valueobject.FeatureFlag, error) {
+	if err := s.check(name, orgID); err != nil {
+		return valueobject.FeatureFlag{}, err
+	}
+
+	if err := s.cacheRepo.Set(ctx, s.overrideKey(name, orgID), enabled, 0); err != nil {
+		return valueobject.FeatureFlag{}, err
+	}
+
+	log.Info().
+		Str("feature", name).
+		Str("org_id", orgID).
+		Bool("enabled", enabled).
+		Msg("Feature flag overridden")
+
+	return s.refresh(ctx, name, orgID), nil
+}
+
+// Reset removes an override so the flag falls back to the next layer.
+func (s *FeatureFlagService) Reset(ctx context.Context, name, orgID string) (valueobject.FeatureFlag, error) {
+	if err := s.check(name, orgID); err != nil {
+		return valueobject.FeatureFlag{}, err
+	}
+
+	if err := s.cacheRepo.Delete(ctx, s.overrideKey(name, orgID)); err != nil {
+		return valueobject.FeatureFlag{}, err
+	}
+
+	log.Info().
+		Str("feature", name).
+		Str("org_id", orgID).
+		Msg("Feature flag override removed")
+
+	return s.refresh(ctx, name, orgID), nil
+}
+
+// refresh re-evaluates a flag after a change and updates the state gauge.
+func (s *FeatureFlagService) refresh(ctx context.Context, name, orgID string) valueobject.FeatureFlag {
+	flag := s.evaluate(ctx, name, "")
+	metrics.FeatureFlagEnabled.WithLabelValues(name).Set(boolGauge(flag.Enabled))
+
+	if orgID != "" {
+		return s.evaluate(ctx, name, orgID)
+	}
+	return flag
+}
+
+// evaluate resolves a flag through its organization, deployment and configured layers.
+func (s *FeatureFlagService) evaluate(ctx context.Context, name, orgID string) valueobject.FeatureFlag {
+	var org *bool
+	if orgID != "" {
+		org = s.override(ctx, s.overrideKey(name, orgID))
+	}
+
+	return valueobject.ResolveFeature(name, s.defaults[name], s.override(ctx, s.overrideKey(name, "")), org)
+}
+
+// override reads a stored override; nil when unset or unreadable.
+func (s *FeatureFlagService) override(ctx context.Context, key string) *bool {
+	var enabled bool
+	if err := s.cacheRepo.Get(ctx, key, &enabled); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			log.Warn().Err(err).Str("key", key).Msg("Failed to read feature flag override")
+		}
+		return nil
+	}
+	return &enabled
+}
+
+// overrideKey returns the cache key of a deployment or organization override.
+func (s *FeatureFlagService) overrideKey(name, orgID string) string {
+	key := "features:" + s.deployment + ":" + name
+	if orgID != "" {
+		key += ":org:" + orgID
+	}
+	return key
+}
+
+// check validates a flag name and organization ID.
+func (s *FeatureFlagService) check(name, orgID string) error {
+	if !s.defaults.Has(name) {
+		return ErrFeatureNotFound
+	}
+	return validateFeatureOrg(orgID)
+}
+
+// validateFeatureOrg rejects organization IDs that would break cache key patterns.
+func validateFeatureOrg(orgID string) error {
+	if strings.ContainsAny(orgID, ":* \t\r\n") {
+		return ErrFeatureOrgInvalid
+	}
+	return nil
+}
+
+// boolGauge converts a flag state to a gauge value.
+func boolGauge(enabled bool) float64 {
+	if enabled {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Actions forwarded to on-call platforms, as exported in metrics.
+const (
+	forwardOpen        = "open"
+	forwardAcknowledge = "acknowledge"
+	forwardClose       = "close"
+)
+
+// ForwardingService mirrors alerts as incidents of on-call platforms such as
+// Opsgenie and Splunk On-Call. Incidents are opened with the alert, and
+// acknowledged and closed with it; the ID of each incident is stored in the
+// alert metadata under entity.MetadataExternalIncidents, keyed by platform,
+// which also tells which platforms an alert was forwarded to.
+type ForwardingService struct {
+	alertRepo  repository.AlertRepository
+	forwarders []notification.IncidentForwarder
+}
+
+// NewForwardingService creates a new forwarding service with the enabled
+// forwarders.
+func NewForwardingService(alertRepo repository.AlertRepository, forwarders ...notification.IncidentForwarder) *ForwardingService {
+	active := make([]notification.IncidentForwarder, 0, len(forwarders))
+	for _, f := range forwarders {
+		if f.IsEnabled() {
+			active = append(active, f)
+			log.Info().Str("platform", f.Name()).Msg("Incident forwarding enabled")
+		}
+	}
+
+	return &ForwardingService{
+		alertRepo:  alertRepo,
+		forwarders: active,
+	}
+}
+
+// IsEnabled reports whether alerts are forwarded to any platform.
+func (s *ForwardingService) IsEnabled() bool {
+	return len(s.forwarders) > 0
+}
+
+// Open opens the incidents of an alert on the platforms it is not forwarded
+// to yet, given the incidents already open, and stores their IDs on the
+// alert. Platforms are all tried; the error reports those that failed.
+func (s *ForwardingService) Open(ctx context.Context, msg notification.Message, incidents map[string]string) error {
+	alertID, err := entity.ParseID(msg.AlertID)
+	if err != nil {
+		return err
+	}
+
+	opened := make(map[string]string, len(incidents)+len(s.forwarders))
+	for platform, incidentID := range incidents {
+		opened[platform] = incidentID
+	}
+
+	var errs []error
+	changed := false
+	for _, f := range s.forwarders {
+		if opened[f.Name()] != "" {
+			continue
+		}
+
+		incidentID, err := f.Open(ctx, msg)
+		if err != nil {
+			metrics.ExternalIncidentsTotal.WithLabelValues(f.Name(), forwardOpen, "failed").Inc()
+			errs = append(errs, fmt.Errorf("%s: %w", f.Name(), err))
+			continue
+		}
+		// The alert is not forwarded to the platform
+		if incidentID == "" {
+			continue
+		}
+
+		metrics.ExternalIncidentsTotal.WithLabelValues(f.Name(), forwardOpen, "success").Inc()
+		opened[f.Name()] = incidentID
+		changed = true
+	}
+
+	if changed {
+		if err := s.alertRepo.SetMetadata(ctx, alertID, entity.MetadataExternalIncidents, opened); err != nil {
+			// The alert was deleted meanwhile; its incidents are left open
+			if !errors.Is(err, repository.ErrNotFound) {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Acknowledge acknowledges the open incidents of an alert.
+func (s *ForwardingService) Acknowledge(ctx context.Context, msg notification.Message, incidents map[string]string) error {
+	return s.each(forwardAcknowledge, incidents, func(f notification.IncidentForwarder, incidentID string) error {
+		return f.Acknowledge(ctx, incidentID, msg)
+	})
+}
+
+// Close closes the open incidents of an alert.
+func (s *ForwardingService) Close(ctx context.Context, msg notification.Message, incidents map[string]string) error {
+	return s.each(forwardClose, incidents, func(f notification.IncidentForwarder, incidentID string) error {
+		return f.Close(ctx, incidentID, msg)
+	})
+}
+
+// each runs an action on the incidents of the platforms an alert was
+// forwarded to. Platforms are all tried; the error reports those that failed.
+func (s *ForwardingService) each(
+	action string,
+	incidents map[string]string,
+	run func(f notification.IncidentForwarder, incidentID string) error,
+) error {
+	var errs []error
+	for _, f := range s.forwarders {
+		incidentID := incidents[f.Name()]
+		if incidentID == "" {
+			continue
+		}
+
+		if err := run(f, incidentID); err != nil {
+			metrics.ExternalIncidentsTotal.WithLabelValues(f.Name(), action, "failed").Inc()
+			errs = append(errs, fmt.Errorf("%s: %w", f.Name(), err))
+			continue
+		}
+		metrics.ExternalIncidentsTotal.WithLabelValues(f.Name(), action, "success").Inc()
+	}
+
+	return errors.Join(errs...)
+}
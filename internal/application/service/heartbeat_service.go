@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Heartbeat errors.
+var (
+	ErrHeartbeatNotFound = errors.New("heartbeat not found")
+	ErrHeartbeatExists   = errors.New("heartbeat with this name already exists")
+	ErrHeartbeatDisabled = errors.New("heartbeat is disabled")
+)
+
+// heartbeatDownKeyPrefix is the cache key prefix of the lock taken by the
+// instance raising the alert of a missed ping.
+const heartbeatDownKeyPrefix = "heartbeat_down:"
+
+// HeartbeatSource is the source of the alerts raised for missed pings.
+const HeartbeatSource = "heartbeat"
+
+// HeartbeatInput holds the settings of a new heartbeat.
+type HeartbeatInput struct {
+	Name            string
+	IntervalSeconds int
+	GraceSeconds    int
+	Severity        entity.AlertSeverity
+	TeamID          *entity.ID
+}
+
+// HeartbeatUpdate holds the changes to a heartbeat; nil fields are left
+// unchanged.
+type HeartbeatUpdate struct {
+	Name            *string
+	IntervalSeconds *int
+	GraceSeconds    *int
+	Severity        *entity.AlertSeverity
+	IsEnabled       *bool
+}
+
+// HeartbeatService manages heartbeats, records their pings and raises an
+// alert when a ping is missed, resolved by the next ping.
+type HeartbeatService struct {
+	heartbeatRepo repository.HeartbeatRepository
+	cacheRepo     repository.CacheRepository
+	alertService  *AlertService
+}
+
+// NewHeartbeatService creates a new heartbeat service.
+func NewHeartbeatService(
+	heartbeatRepo repository.HeartbeatRepository,
+	cacheRepo repository.CacheRepository,
+	alertService *AlertService,
+) *HeartbeatService {
+	return &HeartbeatService{
+		heartbeatRepo: heartbeatRepo,
+		cacheRepo:     cacheRepo,
+		alertService:  alertService,
+	}
+}
+
+// Create creates a heartbeat and returns it together with its ping token.
+// The token is only available here and on rotation; only its hash is stored.
+func (s *HeartbeatService) Create(
+	ctx context.Context,
+	input HeartbeatInput,
+	createdBy *entity.ID,
+) (*entity.Heartbeat, string, error) {
+	token, tokenHash, err := newWebhookToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	heartbeat, err := entity.NewHeartbeat(input.Name, tokenHash, input.IntervalSeconds, input.GraceSeconds,
+		input.Severity, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+	heartbeat.TeamID = input.TeamID
+
+	if err := s.heartbeatRepo.Create(ctx, heartbeat); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, "", ErrHeartbeatExists
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, "", ErrTeamNotFound
+		}
+		return nil, "", err
+	}
+
+	return heartbeat, token, nil
+}
+
+// GetByID retrieves a heartbeat by ID.
+func (s *HeartbeatService) GetByID(ctx context.Context, id entity.ID) (*entity.Heartbeat, error) {
+	heartbeat, err := s.heartbeatRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrHeartbeatNotFound
+		}
+		return nil, err
+	}
+	return heartbeat, nil
+}
+
+// List retrieves heartbeats.
+func (s *HeartbeatService) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Heartbeat], error) {
+	return s.heartbeatRepo.List(ctx, pagination)
+}
+
+// Update changes the settings of a heartbeat.
+func (s *HeartbeatService) Update(ctx context.Context, id entity.ID, update HeartbeatUpdate) (*entity.Heartbeat, error) {
+	heartbeat, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		heartbeat.Name = *update.Name
+	}
+	if update.IntervalSeconds != nil {
+		heartbeat.IntervalSeconds = *update.IntervalSeconds
+	}
+	if update.GraceSeconds != nil {
+		heartbeat.GraceSeconds = *update.GraceSeconds
+	}
+	if update.Severity != nil {
+		heartbeat.Severity = *update.Severity
+	}
+	if err := heartbeat.Validate(); err != nil {
+		return nil, err
+	}
+
+	if update.IsEnabled != nil {
+		if *update.IsEnabled {
+			heartbeat.Enable()
+		} else {
+			heartbeat.Disable()
+		}
+	}
+	heartbeat.Touch()
+
+	if err := s.heartbeatRepo.Update(ctx, heartbeat); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, ErrHeartbeatExists
+		}
+		return nil, err
+	}
+
+	return heartbeat, nil
+}
+
+// RotateToken issues a new ping token; the previous URL stops working.
+func (s *HeartbeatService) RotateToken(ctx context.Context, id entity.ID) (*entity.Heartbeat, string, error) {
+	heartbeat, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, tokenHash, err := newWebhookToken()
+	if err != nil {
+		return nil, "", err
+	}
+	heartbeat.RotateToken(tokenHash)
+
+	if err := s.heartbeatRepo.Update(ctx, heartbeat); err != nil {
+		return nil, "", err
+	}
+
+	return heartbeat, token, nil
+}
+
+// Delete removes a heartbeat. An open alert for a missed ping is left for
+// users to resolve.
+func (s *HeartbeatService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.heartbeatRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrHeartbeatNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Ping records a ping of the heartbeat with the given token. A heartbeat
+// that was down is back up, and the alert of its missed ping is resolved.
+func (s *HeartbeatService) Ping(ctx context.Context, token string) (*entity.Heartbeat, error) {
+	heartbeat, err := s.heartbeatRepo.GetByTokenHash(ctx, hashWebhookToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrHeartbeatNotFound
+		}
+		return nil, err
+	}
+
+	if !heartbeat.IsEnabled {
+		return nil, ErrHeartbeatDisabled
+	}
+
+	recovered := heartbeat.Ping(time.Now().UTC())
+	if err := s.heartbeatRepo.Update(ctx, heartbeat); err != nil {
+		return nil, err
+	}
+	metrics.HeartbeatPingsTotal.Inc()
+
+	if recovered {
+		log.Info().Str("heartbeat_id", heartbeat.ID.String()).Msg("Heartbeat is back up")
+		if _, err := s.alertService.ResolveByDedupKey(ctx, heartbeat.DedupKey()); err != nil &&
+			!errors.Is(err, ErrAlertNotFound) && !errors.Is(err, entity.ErrAlertAlreadyResolved) {
+			return heartbeat, err
+		}
+	}
+
+	return heartbeat, nil
+}
+
+// CheckOverdue marks down the heartbeats that missed their ping and raises
+// an alert for each, returning how many went down. Instances may check
+// concurrently; the one taking the lock of a missed deadline raises its alert.
+func (s *HeartbeatService) CheckOverdue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	overdue, err := s.heartbeatRepo.ListOverdue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	down := 0
+	for _, heartbeat := range overdue {
+		deadline, _ := heartbeat.Deadline()
+		lockKey := fmt.Sprintf("%s%s:%d", heartbeatDownKeyPrefix, heartbeat.ID, deadline.Unix())
+		ttl := time.Duration(heartbeat.IntervalSeconds+heartbeat.GraceSeconds) * time.Second
+		acquired, err := s.cacheRepo.SetNX(ctx, lockKey, now, ttl)
+		if err != nil {
+			return down, err
+		}
+		if !acquired {
+			continue
+		}
+
+		if err := s.markDown(ctx, heartbeat.ID, now); err != nil {
+			log.Error().Err(err).Str("heartbeat_id", heartbeat.ID.String()).Msg("Failed to mark heartbeat down")
+			continue
+		}
+		down++
+	}
+
+	return down, nil
+}
+
+// markDown marks a heartbeat down, unless a ping arrived since it was found
+// overdue, and raises the alert of its missed ping.
+func (s *HeartbeatService) markDown(ctx context.Context, id entity.ID, now time.Time) error {
+	heartbeat, err := s.heartbeatRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !heartbeat.IsOverdue(now) {
+		return nil
+	}
+
+	heartbeat.MarkDown()
+	if err := s.heartbeatRepo.Update(ctx, heartbeat); err != nil {
+		return err
+	}
+	metrics.HeartbeatsMissedTotal.Inc()
+
+	log.Warn().
+		Str("heartbeat_id", heartbeat.ID.String()).
+		Str("heartbeat", heartbeat.Name).
+		Time("last_ping_at", *heartbeat.LastPingAt).
+		Msg("Heartbeat missed")
+
+	_, _, err = s.alertService.CreateDeduplicated(ctx, heartbeat.DedupKey(), heartbeatAlertInput(heartbeat))
+	return err
+}
+
+// heartbeatAlertInput describes the alert raised for a missed ping.
+func heartbeatAlertInput(heartbeat *entity.Heartbeat) CreateAlertInput {
+	interval := time.Duration(heartbeat.IntervalSeconds) * time.Second
+	return CreateAlertInput{
+		Title: "Heartbeat missed: " + heartbeat.Name,
+		Message: fmt.Sprintf("No ping from %s since %s; expected every %s, with %ds of grace",
+			heartbeat.Name, heartbeat.LastPingAt.Format(time.RFC3339), interval, heartbeat.GraceSeconds),
+		Severity: heartbeat.Severity,
+		Source:   HeartbeatSource,
+		Metadata: map[string]interface{}{
+			"heartbeat_id": heartbeat.ID.String(),
+			"last_ping_at": heartbeat.LastPingAt.Format(time.RFC3339),
+		},
+		TeamID: heartbeat.TeamID,
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// User identity errors.
+var (
+	ErrIdentityNotFound  = errors.New("user identity not found")
+	ErrIdentityTaken     = errors.New("provider account is already linked to another user")
+	ErrIdentityNotLinked = errors.New("provider account is not linked to an active user")
+)
+
+// IdentityService links users to their chat provider accounts and resolves
+// the user behind an action taken from a provider.
+type IdentityService struct {
+	identityRepo repository.UserIdentityRepository
+	userRepo     repository.UserRepository
+}
+
+// NewIdentityService creates a new identity service.
+func NewIdentityService(identityRepo repository.UserIdentityRepository, userRepo repository.UserRepository) *IdentityService {
+	return &IdentityService{
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// Link links a user to their account on a provider, replacing the account
+// previously linked on that provider.
+func (s *IdentityService) Link(
+	ctx context.Context,
+	userID entity.ID,
+	provider, externalID string,
+	createdBy *entity.ID,
+) (*entity.UserIdentity, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	existing, err := s.identityRepo.Get(ctx, userID, provider)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	if existing != nil {
+		if err := existing.Relink(externalID); err != nil {
+			return nil, err
+		}
+		if err := s.identityRepo.Update(ctx, existing); err != nil {
+			if errors.Is(err, repository.ErrDuplicateKey) {
+				return nil, ErrIdentityTaken
+			}
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	identity, err := entity.NewUserIdentity(userID, provider, externalID, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrIdentityTaken
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// Unlink removes the link of a user to a provider.
+func (s *IdentityService) Unlink(ctx context.Context, userID entity.ID, provider string) error {
+	if err := s.identityRepo.Delete(ctx, userID, provider); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrIdentityNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Get returns the account of a user on a provider.
+func (s *IdentityService) Get(ctx context.Context, userID entity.ID, provider string) (*entity.UserIdentity, error) {
+	identity, err := s.identityRepo.Get(ctx, userID, provider)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrIdentityNotFound
+		}
+		return nil, err
+	}
+	return identity, nil
+}
+
+// List returns the provider accounts linked to a user.
+func (s *IdentityService) List(ctx context.Context, userID entity.ID) ([]*entity.UserIdentity, error) {
+	return s.identityRepo.ListByUser(ctx, userID)
+}
+
+// Resolve returns the active user linked to a provider account.
+func (s *IdentityService) Resolve(ctx context.Context, provider, externalID string) (*entity.User, error) {
+	identity, err := s.identityRepo.GetByExternalID(ctx, provider, externalID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrIdentityNotLinked
+		}
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, identity.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrIdentityNotLinked
+		}
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, ErrIdentityNotLinked
+	}
+
+	return user, nil
+}
@@ -0,0 +1,647 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Incident errors.
+var (
+	ErrIncidentNotFound          = errors.New("incident not found")
+	ErrIncidentAlertNotFound     = errors.New("alert is not in the incident")
+	ErrAlertInIncident           = errors.New("alert already belongs to an incident")
+	ErrIncidentCommanderNotFound = errors.New("incident commander not found")
+)
+
+// Triggers of the incidents opened, as counted by metrics.
+const (
+	incidentTriggerManual      = "manual"
+	incidentTriggerCorrelation = "correlation"
+)
+
+// IncidentEventPublisher defines the interface for publishing incident events.
+type IncidentEventPublisher interface {
+	PublishIncidentCreated(incident *entity.Incident)
+	PublishIncidentUpdated(incident *entity.Incident)
+	PublishIncidentTimeline(incident *entity.Incident, event *entity.IncidentEvent)
+}
+
+// IncidentInput holds the data of a new incident. AlertIDs are grouped into
+// it from the start.
+type IncidentInput struct {
+	Title       string
+	Summary     string
+	Severity    entity.AlertSeverity
+	TeamID      *entity.ID
+	CommanderID *entity.ID
+	AlertIDs    []entity.ID
+}
+
+// IncidentUpdate holds the changes to an incident; nil fields are left unchanged.
+type IncidentUpdate struct {
+	Title    *string
+	Summary  *string
+	Severity *entity.AlertSeverity
+}
+
+// IncidentService manages incidents: groups of related alerts, added by
+// hand or correlated as they are created, with a lifecycle, a commander and
+// a timeline of what happened.
+type IncidentService struct {
+	incidentRepo repository.IncidentRepository
+	userRepo     repository.UserRepository
+	alertService *AlertService
+	publisher    IncidentEventPublisher
+	correlation  valueobject.IncidentCorrelationPolicy
+	userNotifier UserEventPublisher
+}
+
+// NewIncidentService creates a new incident service.
+func NewIncidentService(
+	incidentRepo repository.IncidentRepository,
+	userRepo repository.UserRepository,
+	alertService *AlertService,
+	publisher IncidentEventPublisher,
+) *IncidentService {
+	return &IncidentService{
+		incidentRepo: incidentRepo,
+		userRepo:     userRepo,
+		alertService: alertService,
+		publisher:    publisher,
+	}
+}
+
+// SetCorrelationPolicy sets the policy grouping new alerts into incidents.
+func (s *IncidentService) SetCorrelationPolicy(policy valueobject.IncidentCorrelationPolicy) {
+	s.correlation = policy
+}
+
+// SetUserNotifier sets the publisher letting users know they were mentioned
+// in a note, in their digest when offline.
+func (s *IncidentService) SetUserNotifier(notifier UserEventPublisher) {
+	s.userNotifier = notifier
+}
+
+// Create opens an incident with the given alerts, which must be visible to
+// the role within the tenant scope.
+func (s *IncidentService) Create(
+	ctx context.Context,
+	input IncidentInput,
+	createdBy *entity.ID,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) (*entity.Incident, error) {
+	incident, err := entity.NewIncident(input.Title, input.Summary, input.Severity, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	incident.TeamID = input.TeamID
+
+	if input.CommanderID != nil {
+		if err := s.checkCommander(ctx, *input.CommanderID); err != nil {
+			return nil, err
+		}
+		incident.CommanderID = input.CommanderID
+	}
+
+	alerts := make([]*entity.Alert, 0, len(input.AlertIDs))
+	for _, alertID := range input.AlertIDs {
+		alert, err := s.alertService.GetVisible(ctx, alertID, role, tenant)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.incidentRepo.GetByAlert(ctx, alertID); err == nil {
+			return nil, ErrAlertInIncident
+		} else if !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := s.incidentRepo.Create(ctx, incident); err != nil {
+		if errors.Is(err, repository.ErrForeignKeyViolation) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	metrics.IncidentsOpenedTotal.WithLabelValues(incidentTriggerManual).Inc()
+	s.record(ctx, incident, entity.IncidentEventCreated, "Incident opened", createdBy)
+	if incident.CommanderID != nil {
+		s.record(ctx, incident, entity.IncidentEventCommanderChanged,
+			"Commander assigned: "+incident.CommanderID.String(), createdBy)
+	}
+
+	raised := false
+	for _, alert := range alerts {
+		changed, err := s.addAlert(ctx, incident, alert, createdBy)
+		if err != nil {
+			return nil, err
+		}
+		raised = raised || changed
+	}
+	if raised {
+		if err := s.incidentRepo.Update(ctx, incident); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.publisher != nil {
+		s.publisher.PublishIncidentCreated(incident)
+	}
+
+	return incident, nil
+}
+
+// GetByID retrieves an incident by ID.
+func (s *IncidentService) GetByID(ctx context.Context, id entity.ID) (*entity.Incident, error) {
+	incident, err := s.incidentRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrIncidentNotFound
+		}
+		return nil, err
+	}
+	return incident, nil
+}
+
+// GetVisible retrieves an incident the tenant scope may see. Incidents of
+// other teams are reported as not found.
+func (s *IncidentService) GetVisible(
+	ctx context.Context,
+	id entity.ID,
+	tenant valueobject.TenantScope,
+) (*entity.Incident, error) {
+	incident, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.Allows(incident.TeamID) {
+		return nil, ErrIncidentNotFound
+	}
+	return incident, nil
+}
+
+// List retrieves incidents, newest first.
+func (s *IncidentService) List(
+	ctx context.Context,
+	filter repository.IncidentFilter,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Incident], error) {
+	return s.incidentRepo.List(ctx, filter, pagination)
+}
+
+// Update changes the title, summary or severity of an incident.
+func (s *IncidentService) Update(
+	ctx context.Context,
+	id entity.ID,
+	update IncidentUpdate,
+	actor *entity.ID,
+) (*entity.Incident, error) {
+	incident, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if incident.IsClosed() {
+		return nil, entity.ErrIncidentClosed
+	}
+
+	if update.Title != nil {
+		incident.Title = *update.Title
+	}
+	if update.Summary != nil {
+		incident.Summary = *update.Summary
+	}
+	if update.Severity != nil {
+		incident.Severity = *update.Severity
+	}
+	if err := incident.Validate(); err != nil {
+		return nil, err
+	}
+	incident.Touch()
+
+	if err := s.incidentRepo.Update(ctx, incident); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, incident, entity.IncidentEventUpdated, "Incident updated", actor)
+	s.publishUpdated(incident)
+
+	return incident, nil
+}
+
+// Mitigate marks an open incident as mitigated, with an optional note.
+func (s *IncidentService) Mitigate(ctx context.Context, id entity.ID, note string, actor *entity.ID) (*entity.Incident, error) {
+	return s.transition(ctx, id, (*entity.Incident).Mitigate, note, actor)
+}
+
+// Close closes an incident, with an optional note. Its alerts are left as
+// they are.
+func (s *IncidentService) Close(ctx context.Context, id entity.ID, note string, actor *entity.ID) (*entity.Incident, error) {
+	return s.transition(ctx, id, (*entity.Incident).Close, note, actor)
+}
+
+// Reopen opens a mitigated or closed incident again, with an optional note.
+// A closed incident cannot be reopened while another incident of its
+// correlation key is active.
+func (s *IncidentService) Reopen(ctx context.Context, id entity.ID, note string, actor *entity.ID) (*entity.Incident, error) {
+	return s.transition(ctx, id, (*entity.Incident).Reopen, note, actor)
+}
+
+// transition applies a lifecycle change to an incident and records it.
+func (s *IncidentService) transition(
+	ctx context.Context,
+	id entity.ID,
+	change func(*entity.Incident) error,
+	note string,
+	actor *entity.ID,
+) (*entity.Incident, error) {
+	if note != "" {
+		if err := entity.ValidateIncidentNote(note); err != nil {
+			return nil, err
+		}
+	}
+
+	incident, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(incident); err != nil {
+		return nil, err
+	}
+
+	if err := s.incidentRepo.Update(ctx, incident); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, fmt.Errorf("%w: another incident of its correlation key is active", entity.ErrIncidentNotReopenable)
+		}
+		return nil, err
+	}
+
+	message := "Status changed to " + string(incident.Status)
+	if note != "" {
+		message += ": " + note
+	}
+	s.record(ctx, incident, entity.IncidentEventStatusChanged, message, actor)
+	s.publishUpdated(incident)
+
+	return incident, nil
+}
+
+// AssignCommander sets the user leading the response to an incident; nil
+// unassigns it.
+func (s *IncidentService) AssignCommander(
+	ctx context.Context,
+	id entity.ID,
+	commanderID *entity.ID,
+	actor *entity.ID,
+) (*entity.Incident, error) {
+	incident, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if commanderID != nil {
+		if err := s.checkCommander(ctx, *commanderID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := incident.AssignCommander(commanderID); err != nil {
+		return nil, err
+	}
+
+	if err := s.incidentRepo.Update(ctx, incident); err != nil {
+		return nil, err
+	}
+
+	message := "Commander unassigned"
+	if commanderID != nil {
+		message = "Commander assigned: " + commanderID.String()
+	}
+	s.record(ctx, incident, entity.IncidentEventCommanderChanged, message, actor)
+	s.publishUpdated(incident)
+
+	return incident, nil
+}
+
+// AddAlerts groups alerts, which must be visible to the role within the
+// tenant scope, into an incident. Its severity is raised to that of its most
+// severe alert.
+func (s *IncidentService) AddAlerts(
+	ctx context.Context,
+	id entity.ID,
+	alertIDs []entity.ID,
+	actor *entity.ID,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) (*entity.Incident, error) {
+	incident, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	raised := false
+	for _, alertID := range alertIDs {
+		alert, err := s.alertService.GetVisible(ctx, alertID, role, tenant)
+		if err != nil {
+			return nil, err
+		}
+		changed, err := s.addAlert(ctx, incident, alert, actor)
+		if err != nil {
+			return nil, err
+		}
+		raised = raised || changed
+	}
+
+	if raised {
+		if err := s.incidentRepo.Update(ctx, incident); err != nil {
+			return nil, err
+		}
+	}
+	s.publishUpdated(incident)
+
+	return incident, nil
+}
+
+// RemoveAlert takes an alert out of an incident.
+func (s *IncidentService) RemoveAlert(ctx context.Context, id, alertID entity.ID, actor *entity.ID) error {
+	incident, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if incident.IsClosed() {
+		return entity.ErrIncidentClosed
+	}
+
+	if err := s.incidentRepo.RemoveAlert(ctx, id, alertID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrIncidentAlertNotFound
+		}
+		return err
+	}
+
+	s.record(ctx, incident, entity.IncidentEventAlertRemoved, "Alert removed: "+alertID.String(), actor)
+	s.publishUpdated(incident)
+
+	return nil
+}
+
+// Alerts returns the alerts grouped into an incident that are visible to the
+// role within the tenant scope, most recent first.
+func (s *IncidentService) Alerts(
+	ctx context.Context,
+	id entity.ID,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) ([]*entity.Alert, error) {
+	alerts, err := s.incidentRepo.ListAlerts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*entity.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		if s.alertService.CanView(alert, role, tenant) {
+			visible = append(visible, alert)
+		}
+	}
+	return visible, nil
+}
+
+// Timeline returns the timeline of an incident, oldest first.
+func (s *IncidentService) Timeline(
+	ctx context.Context,
+	id entity.ID,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.IncidentEvent], error) {
+	return s.incidentRepo.ListEvents(ctx, id, pagination)
+}
+
+// AddNote adds a note of a responder to the timeline of an incident. Users
+// mentioned in the note by email, as in "@jane@example.com", are notified.
+func (s *IncidentService) AddNote(ctx context.Context, id entity.ID, note string, actor *entity.ID) (*entity.IncidentEvent, error) {
+	if err := entity.ValidateIncidentNote(note); err != nil {
+		return nil, err
+	}
+
+	incident, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	event := entity.NewIncidentEvent(incident.ID, entity.IncidentEventNote, note, actor)
+	if err := s.incidentRepo.AddEvent(ctx, event); err != nil {
+		return nil, err
+	}
+	if s.publisher != nil {
+		s.publisher.PublishIncidentTimeline(incident, event)
+	}
+	s.notifyMentions(ctx, incident, event)
+
+	return event, nil
+}
+
+// notifyMentions lets the users mentioned in a note know, except its author.
+// Mentions of unknown users are ignored.
+func (s *IncidentService) notifyMentions(ctx context.Context, incident *entity.Incident, event *entity.IncidentEvent) {
+	if s.userNotifier == nil {
+		return
+	}
+
+	for _, email := range mentionedEmails(event.Message) {
+		user, err := s.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			if !errors.Is(err, repository.ErrNotFound) {
+				log.Warn().Err(err).Str("incident_id", incident.ID.String()).Msg("Failed to look up mentioned user")
+			}
+			continue
+		}
+		if event.ActorID != nil && *event.ActorID == user.ID {
+			continue
+		}
+
+		s.userNotifier.PublishToUser(ctx, user.ID, dto.DigestItem{
+			Kind:       dto.DigestKindMention,
+			Title:      incident.Title,
+			Message:    event.Message,
+			IncidentID: incident.ID.String(),
+			OccurredAt: event.CreatedAt,
+		})
+	}
+}
+
+// mentionPattern matches a mention of a user by email, e.g. "@jane@example.com".
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.@])@([\w.+-]+@[\w-]+(?:\.[\w-]+)+)`)
+
+// mentionedEmails returns the emails mentioned in a note, lowercased and once each.
+func mentionedEmails(note string) []string {
+	seen := make(map[string]bool)
+	var emails []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(note, -1) {
+		email := strings.ToLower(match[1])
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// Correlate groups a new alert into the active incident of its correlation
+// key, opening one when the alert is severe enough. A mitigated incident
+// is reopened by a new alert. Failures are only logged: correlation must
+// not get in the way of alerting.
+func (s *IncidentService) Correlate(ctx context.Context, alert *entity.Alert) {
+	key, ok := s.correlation.Key(alert)
+	if !ok {
+		return
+	}
+
+	incident, err := s.incidentRepo.GetActiveByCorrelationKey(ctx, key)
+	if errors.Is(err, repository.ErrNotFound) {
+		if !s.correlation.Opens(alert) {
+			return
+		}
+		incident, err = s.openCorrelated(ctx, key, alert)
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("alert_id", alert.ID.String()).Msg("Failed to correlate alert into an incident")
+		return
+	}
+
+	reopened := incident.Status == entity.IncidentStatusMitigated && incident.Reopen() == nil
+	if reopened {
+		s.record(ctx, incident, entity.IncidentEventStatusChanged, "Status changed to open: a new alert was correlated", nil)
+	}
+
+	raised, err := s.addAlert(ctx, incident, alert, nil)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("alert_id", alert.ID.String()).
+			Str("incident_id", incident.ID.String()).
+			Msg("Failed to add correlated alert to incident")
+		return
+	}
+	metrics.IncidentAlertsCorrelatedTotal.Inc()
+
+	if raised || reopened {
+		if err := s.incidentRepo.Update(ctx, incident); err != nil {
+			log.Warn().Err(err).Str("incident_id", incident.ID.String()).Msg("Failed to update correlated incident")
+		}
+	}
+	s.publishUpdated(incident)
+}
+
+// openCorrelated opens the incident of a correlation key for an alert. When
+// another instance opened it meanwhile, that incident is returned.
+func (s *IncidentService) openCorrelated(ctx context.Context, key string, alert *entity.Alert) (*entity.Incident, error) {
+	incident, err := entity.NewIncident(alert.Title, "", alert.Severity, nil)
+	if err != nil {
+		return nil, err
+	}
+	incident.TeamID = alert.TeamID
+	incident.CorrelationKey = key
+
+	if err := s.incidentRepo.Create(ctx, incident); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return s.incidentRepo.GetActiveByCorrelationKey(ctx, key)
+		}
+		return nil, err
+	}
+
+	metrics.IncidentsOpenedTotal.WithLabelValues(incidentTriggerCorrelation).Inc()
+	log.Info().
+		Str("incident_id", incident.ID.String()).
+		Str("correlation_key", key).
+		Msg("Incident opened by alert correlation")
+
+	s.record(ctx, incident, entity.IncidentEventCreated, "Incident opened by correlation of alerts with "+key, nil)
+	if s.publisher != nil {
+		s.publisher.PublishIncidentCreated(incident)
+	}
+
+	return incident, nil
+}
+
+// addAlert groups an alert into an incident and records it. It reports
+// whether the severity of the incident was raised, which the caller saves.
+func (s *IncidentService) addAlert(
+	ctx context.Context,
+	incident *entity.Incident,
+	alert *entity.Alert,
+	actor *entity.ID,
+) (bool, error) {
+	if incident.IsClosed() {
+		return false, entity.ErrIncidentClosed
+	}
+
+	if err := s.incidentRepo.AddAlert(ctx, incident.ID, alert.ID, actor); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return false, ErrAlertInIncident
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return false, ErrAlertNotFound
+		}
+		return false, err
+	}
+
+	s.record(ctx, incident, entity.IncidentEventAlertAdded,
+		fmt.Sprintf("Alert added: %s (%s)", alert.Title, alert.Severity), actor)
+
+	return incident.RaiseSeverity(alert.Severity), nil
+}
+
+// checkCommander checks that the commander of an incident exists.
+func (s *IncidentService) checkCommander(ctx context.Context, userID entity.ID) error {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrIncidentCommanderNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// record adds an entry to the timeline of an incident. Failures are only
+// logged: the change it describes is already saved.
+func (s *IncidentService) record(
+	ctx context.Context,
+	incident *entity.Incident,
+	kind entity.IncidentEventKind,
+	message string,
+	actor *entity.ID,
+) {
+	event := entity.NewIncidentEvent(incident.ID, kind, message, actor)
+	if err := s.incidentRepo.AddEvent(ctx, event); err != nil {
+		log.Warn().
+			Err(err).
+			Str("incident_id", incident.ID.String()).
+			Str("kind", string(kind)).
+			Msg("Failed to record incident event")
+		return
+	}
+
+	if s.publisher != nil {
+		s.publisher.PublishIncidentTimeline(incident, event)
+	}
+}
+
+// publishUpdated publishes a changed incident.
+func (s *IncidentService) publishUpdated(incident *entity.Incident) {
+	if s.publisher != nil {
+		s.publisher.PublishIncidentUpdated(incident)
+	}
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/document"
+)
+
+// Maintenance errors.
+var (
+	ErrMaintenanceWindowNotFound = errors.New("maintenance window not found")
+	ErrMaintenanceRangeInvalid   = errors.New("maintenance calendar range must end after it starts and span at most 366 days")
+)
+
+// Bounds of maintenance calendars.
+const (
+	maxMaintenanceRange   = 366 * 24 * time.Hour
+	maxMaintenanceWindows = 500
+)
+
+// MaintenanceInput holds the data of a new maintenance window.
+type MaintenanceInput struct {
+	Title       string
+	Description string
+	TeamID      *entity.ID
+	Source      string
+	StartsAt    time.Time
+	EndsAt      time.Time
+}
+
+// MaintenanceUpdate holds the changes to a maintenance window; nil fields
+// are left unchanged, and an empty source is cleared.
+type MaintenanceUpdate struct {
+	Title       *string
+	Description *string
+	Source      *string
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+}
+
+// MaintenanceService manages scheduled maintenance windows and publishes
+// them as a calendar.
+type MaintenanceService struct {
+	maintenanceRepo repository.MaintenanceRepository
+}
+
+// NewMaintenanceService creates a new maintenance service.
+func NewMaintenanceService(maintenanceRepo repository.MaintenanceRepository) *MaintenanceService {
+	return &MaintenanceService{
+		maintenanceRepo: maintenanceRepo,
+	}
+}
+
+// Create schedules a maintenance window.
+func (s *MaintenanceService) Create(
+	ctx context.Context,
+	input MaintenanceInput,
+	createdBy *entity.ID,
+) (*entity.MaintenanceWindow, error) {
+	window, err := entity.NewMaintenanceWindow(input.Title, input.Description, input.StartsAt, input.EndsAt, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	window.TeamID = input.TeamID
+	window.Source = input.Source
+
+	if err := s.maintenanceRepo.Create(ctx, window); err != nil {
+		if errors.Is(err, repository.ErrForeignKeyViolation) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	return window, nil
+}
+
+// GetVisible retrieves a maintenance window the tenant scope may see.
+// Windows of other teams are reported as not found.
+func (s *MaintenanceService) GetVisible(
+	ctx context.Context,
+	id entity.ID,
+	tenant valueobject.TenantScope,
+) (*entity.MaintenanceWindow, error) {
+	window, err := s.maintenanceRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMaintenanceWindowNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(window.TeamID) {
+		return nil, ErrMaintenanceWindowNotFound
+	}
+	return window, nil
+}
+
+// Update reschedules or describes a maintenance window.
+func (s *MaintenanceService) Update(
+	ctx context.Context,
+	window *entity.MaintenanceWindow,
+	update MaintenanceUpdate,
+) (*entity.MaintenanceWindow, error) {
+	if update.Title != nil {
+		window.Title = *update.Title
+	}
+	if update.Description != nil {
+		window.Description = *update.Description
+	}
+	if update.Source != nil {
+		window.Source = *update.Source
+	}
+	if update.StartsAt != nil {
+		window.StartsAt = update.StartsAt.UTC()
+	}
+	if update.EndsAt != nil {
+		window.EndsAt = update.EndsAt.UTC()
+	}
+	if err := window.Validate(); err != nil {
+		return nil, err
+	}
+
+	window.Touch()
+	if err := s.maintenanceRepo.Update(ctx, window); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMaintenanceWindowNotFound
+		}
+		return nil, err
+	}
+
+	return window, nil
+}
+
+// Delete removes a maintenance window.
+func (s *MaintenanceService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.maintenanceRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrMaintenanceWindowNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Silence opens a maintenance window for a source, from now for the given
+// duration; the notifications of its alerts are suppressed meanwhile.
+func (s *MaintenanceService) Silence(
+	ctx context.Context,
+	source string,
+	duration time.Duration,
+	createdBy *entity.User,
+) (*entity.MaintenanceWindow, error) {
+	now := time.Now()
+	var createdByID *entity.ID
+	description := ""
+	if createdBy != nil {
+		createdByID = &createdBy.ID
+		description = "Silenced by " + createdBy.Name
+	}
+
+	return s.Create(ctx, MaintenanceInput{
+		Title:       "Silence: " + source,
+		Description: description,
+		Source:      source,
+		StartsAt:    now,
+		EndsAt:      now.Add(duration),
+	}, createdByID)
+}
+
+// IsSilenced reports whether the notifications of an alert are suppressed at
+// a time: a maintenance window of its source, shared or owned by its team, is
+// active.
+func (s *MaintenanceService) IsSilenced(ctx context.Context, source string, teamID *entity.ID, at time.Time) (bool, error) {
+	if source == "" {
+		return false, nil
+	}
+
+	tenant := valueobject.TeamsScope()
+	if teamID != nil {
+		tenant = valueobject.TeamsScope(*teamID)
+	}
+
+	windows, err := s.maintenanceRepo.List(ctx, repository.MaintenanceFilter{
+		From:   at,
+		To:     at.Add(time.Microsecond),
+		Tenant: &tenant,
+		Source: source,
+		Limit:  1,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(windows) > 0, nil
+}
+
+// Calendar returns the maintenance windows within the tenant scope that
+// overlap the period [from, to), by start; at most 500 are returned.
+func (s *MaintenanceService) Calendar(
+	ctx context.Context,
+	from, to time.Time,
+	tenant valueobject.TenantScope,
+) ([]*entity.MaintenanceWindow, error) {
+	if !to.After(from) || to.Sub(from) > maxMaintenanceRange {
+		return nil, ErrMaintenanceRangeInvalid
+	}
+
+	filter := repository.MaintenanceFilter{
+		From:  from,
+		To:    to,
+		Limit: maxMaintenanceWindows,
+	}
+	if !tenant.IsAll() {
+		filter.Tenant = &tenant
+	}
+
+	return s.maintenanceRepo.List(ctx, filter)
+}
+
+// ICalendar renders maintenance windows as an iCalendar document that
+// calendar clients can subscribe to.
+func (s *MaintenanceService) ICalendar(windows []*entity.MaintenanceWindow, now time.Time) []byte {
+	events := make([]document.CalendarEvent, len(windows))
+	for i, window := range windows {
+		description := window.Description
+		if window.Source != "" {
+			description = strings.TrimSpace(fmt.Sprintf("Source: %s\n\n%s", window.Source, description))
+		}
+		events[i] = document.CalendarEvent{
+			UID:         window.ID.String() + "@maintenance",
+			Summary:     "Maintenance: " + window.Title,
+			Description: description,
+			Start:       window.StartsAt,
+			End:         window.EndsAt,
+			Modified:    window.UpdatedAt,
+		}
+	}
+	return document.ICalendar("Maintenance windows", events, now)
+}
@@ -2,15 +2,36 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
 )
 
+const (
+	// notificationQueueTTL bounds how long queued notifications wait for delivery.
+	notificationQueueTTL = 7 * 24 * time.Hour
+	// notificationQueueMaxItems caps a queue; the oldest entries are dropped first.
+	notificationQueueMaxItems = 500
+	// digestMaxLines caps the notifications listed in a digest.
+	digestMaxLines = 20
+)
+
+// ErrNotificationChannelNotFound is returned for channels without an enabled notifier.
+var ErrNotificationChannelNotFound = errors.New("notification channel not found")
+
 // NotificationService manages notifications across multiple channels.
 type NotificationService struct {
 	notifiers   []notification.Notifier
@@ -19,6 +40,11 @@ type NotificationService struct {
 	mu          sync.Mutex
 	sentCount   map[string]int
 	lastReset   time.Time
+	cacheRepo   repository.CacheRepository
+	quietHours  map[string]valueobject.QuietHours
+	batches     map[string]valueobject.NotificationBatch
+	templates   *NotificationTemplateService
+	routes      *RouteService
 }
 
 // NewNotificationService creates a new notification service.
@@ -40,8 +66,56 @@ func NewNotificationService(cfg config.NotificationConfig, notifiers ...notifica
 	}
 }
 
-// Notify sends a notification through all enabled channels.
+// SetQuietHours enables do-not-disturb windows. Notifications a channel
+// receives during its window are queued in the cache and delivered as a
+// digest by FlushQueues once the window ends.
+func (s *NotificationService) SetQuietHours(cacheRepo repository.CacheRepository, schedules ...valueobject.QuietHours) {
+	s.cacheRepo = cacheRepo
+	s.quietHours = make(map[string]valueobject.QuietHours, len(schedules))
+	for _, schedule := range schedules {
+		s.quietHours[schedule.Channel()] = schedule
+	}
+}
+
+// SetTemplates renders the content of each channel's notifications with its template.
+func (s *NotificationService) SetTemplates(templates *NotificationTemplateService) {
+	s.templates = templates
+}
+
+// SetRoutes delivers notifications matching a route only to the route's
+// channels. Notifications no route matches go to every channel.
+func (s *NotificationService) SetRoutes(routes *RouteService) {
+	s.routes = routes
+}
+
+// SetBatching puts channels in digest mode. Notifications they receive at or
+// below the policy's severity are queued in the cache and delivered as one
+// digest per interval by FlushQueues.
+func (s *NotificationService) SetBatching(cacheRepo repository.CacheRepository, batches ...valueobject.NotificationBatch) {
+	s.cacheRepo = cacheRepo
+	s.batches = make(map[string]valueobject.NotificationBatch, len(batches))
+	for _, batch := range batches {
+		s.batches[batch.Channel()] = batch
+	}
+}
+
+// HasQueues reports whether any channel has quiet hours or digest mode.
+func (s *NotificationService) HasQueues() bool {
+	return len(s.quietHours) > 0 || len(s.batches) > 0
+}
+
+// Notify sends a notification through all enabled channels, or through the
+// channels of the matching routes. Channels in their quiet hours or in digest
+// mode queue it instead.
 func (s *NotificationService) Notify(ctx context.Context, msg notification.Message) error {
+	ctx, span := tracing.StartSpan(ctx, "NotificationService.Notify")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("alert.id", msg.AlertID),
+		attribute.String("alert.severity", msg.Severity),
+	)
+
 	// Check severity threshold
 	if !notification.ShouldNotify(msg.Severity, s.minSeverity) {
 		log.Debug().
@@ -59,10 +133,34 @@ func (s *NotificationService) Notify(ctx context.Context, msg notification.Messa
 		return nil
 	}
 
+	// Restrict delivery to the routed channels
+	var routed map[string]bool
+	if s.routes != nil {
+		if channels, ok := s.routes.Resolve(ctx, msg); ok {
+			routed = make(map[string]bool, len(channels))
+			for _, channel := range channels {
+				routed[channel] = true
+			}
+		}
+	}
+
 	// Send to all notifiers
 	var lastErr error
 	for _, notifier := range s.notifiers {
-		if err := notifier.Send(ctx, msg); err != nil {
+		if routed != nil && !routed[notifier.Name()] {
+			continue
+		}
+
+		if s.suppress(ctx, notifier.Name(), msg) || s.batch(ctx, notifier.Name(), msg) {
+			continue
+		}
+
+		content := msg
+		if s.templates != nil {
+			content = s.templates.Render(ctx, notifier.Name(), msg)
+		}
+
+		if err := notifier.Send(ctx, content); err != nil {
 			log.Error().
 				Err(err).
 				Str("notifier", notifier.Name()).
@@ -75,6 +173,188 @@ func (s *NotificationService) Notify(ctx context.Context, msg notification.Messa
 	return lastErr
 }
 
+// FlushQueues delivers queued notifications, one digest per channel and
+// queue, and returns the number of notifications delivered. Quiet hours
+// queues are flushed once the window ends; digest mode queues once per
+// interval, outside quiet hours. Queues are kept when delivery fails.
+func (s *NotificationService) FlushQueues(ctx context.Context) (int, error) {
+	now := time.Now()
+	delivered := 0
+
+	var lastErr error
+	for _, notifier := range s.notifiers {
+		channel := notifier.Name()
+
+		if schedule, ok := s.quietHours[channel]; ok {
+			if schedule.IsQuiet(now) {
+				continue
+			}
+
+			n, err := s.flushQueue(ctx, notifier, quietQueueKey(channel), "%d notifications during quiet hours", "quiet-hours")
+			if err != nil {
+				lastErr = err
+			}
+			if n > 0 {
+				metrics.QuietHoursDigestsSentTotal.WithLabelValues(channel).Inc()
+				delivered += n
+			}
+		}
+
+		if batch, ok := s.batches[channel]; ok {
+			// One instance flushes each channel per interval
+			due, err := s.cacheRepo.SetNX(ctx, "batch:lock:"+channel, now.Unix(), batch.Interval())
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if !due {
+				continue
+			}
+
+			n, err := s.flushQueue(ctx, notifier, batchQueueKey(channel), "Digest: %d notifications", "digest")
+			if err != nil {
+				lastErr = err
+			}
+			if n > 0 {
+				metrics.NotificationBatchesSentTotal.WithLabelValues(channel).Inc()
+				delivered += n
+			}
+		}
+	}
+
+	return delivered, lastErr
+}
+
+// flushQueue sends a queue as a single digest and clears it, returning the
+// number of notifications delivered. Title is a format taking the count.
+// The queue is taken at once, so notifications queued meanwhile wait for the
+// next digest; when delivery fails the taken ones are put back in front.
+func (s *NotificationService) flushQueue(
+	ctx context.Context,
+	notifier notification.Notifier,
+	key, title, source string,
+) (int, error) {
+	var queued []notification.Message
+	if err := s.cacheRepo.ListTake(ctx, key, &queued); err != nil || len(queued) == 0 {
+		return 0, err
+	}
+
+	if err := notifier.Send(ctx, queueDigest(queued, fmt.Sprintf(title, len(queued)), source)); err != nil {
+		log.Error().
+			Err(err).
+			Str("notifier", notifier.Name()).
+			Str("queue", key).
+			Int("queued", len(queued)).
+			Msg("Failed to send notification digest")
+
+		requeued := make([]interface{}, len(queued))
+		for i, msg := range queued {
+			requeued[i] = msg
+		}
+		if err := s.cacheRepo.ListPrepend(ctx, key, requeued, notificationQueueMaxItems, notificationQueueTTL); err != nil {
+			log.Error().Err(err).Str("queue", key).Int("queued", len(queued)).Msg("Failed to requeue notifications")
+		}
+		return 0, err
+	}
+
+	return len(queued), nil
+}
+
+// suppress queues a notification when the channel is in its quiet hours.
+// It reports false, so the notification is sent right away, when the channel
+// is not quiet, the severity bypasses the window or queueing fails.
+func (s *NotificationService) suppress(ctx context.Context, channel string, msg notification.Message) bool {
+	schedule, ok := s.quietHours[channel]
+	if !ok || !schedule.Suppresses(time.Now(), msg.Severity) {
+		return false
+	}
+
+	if err := s.enqueue(ctx, quietQueueKey(channel), msg); err != nil {
+		log.Warn().
+			Err(err).
+			Str("notifier", channel).
+			Str("alert_id", msg.AlertID).
+			Msg("Failed to queue notification during quiet hours, sending now")
+		return false
+	}
+
+	metrics.NotificationsSuppressedTotal.WithLabelValues(channel).Inc()
+	log.Debug().
+		Str("notifier", channel).
+		Str("alert_id", msg.AlertID).
+		Msg("Notification queued during quiet hours")
+	return true
+}
+
+// batch queues a notification for the channel's next digest when the channel
+// is in digest mode for its severity. It reports false when the notification
+// must be sent right away.
+func (s *NotificationService) batch(ctx context.Context, channel string, msg notification.Message) bool {
+	policy, ok := s.batches[channel]
+	if !ok || !policy.Includes(msg.Severity) {
+		return false
+	}
+
+	if err := s.enqueue(ctx, batchQueueKey(channel), msg); err != nil {
+		log.Warn().
+			Err(err).
+			Str("notifier", channel).
+			Str("alert_id", msg.AlertID).
+			Msg("Failed to batch notification, sending now")
+		return false
+	}
+
+	metrics.NotificationsBatchedTotal.WithLabelValues(channel).Inc()
+	return true
+}
+
+// enqueue appends a notification to a queue, dropping the oldest entries beyond the cap.
+func (s *NotificationService) enqueue(ctx context.Context, key string, msg notification.Message) error {
+	return s.cacheRepo.ListAppend(ctx, key, msg, notificationQueueMaxItems, notificationQueueTTL)
+}
+
+// quietQueueKey returns the cache key of a channel's quiet hours queue, a
+// list. It is not named like the JSON arrays queues once were, so that those
+// do not get in the way of the list commands.
+func quietQueueKey(channel string) string {
+	return "quiet:messages:" + channel
+}
+
+// batchQueueKey returns the cache key of a channel's digest mode queue, a list.
+func batchQueueKey(channel string) string {
+	return "batch:messages:" + channel
+}
+
+// queueDigest summarizes queued notifications in a single message carrying
+// the highest severity among them.
+func queueDigest(queued []notification.Message, title, source string) notification.Message {
+	severity := queued[0].Severity
+	for _, msg := range queued[1:] {
+		if notification.SeverityPriority(msg.Severity) < notification.SeverityPriority(severity) {
+			severity = msg.Severity
+		}
+	}
+
+	var text strings.Builder
+	for i, msg := range queued {
+		if i == digestMaxLines {
+			fmt.Fprintf(&text, "...and %d more\n", len(queued)-i)
+			break
+		}
+		fmt.Fprintf(&text, "- [%s] %s\n", msg.Severity, msg.Title)
+	}
+
+	return notification.Message{
+		Title:    title,
+		Text:     text.String(),
+		Severity: severity,
+		Fields: map[string]string{
+			"Queued": strconv.Itoa(len(queued)),
+		},
+		Source: source,
+	}
+}
+
 // checkRateLimit checks if we can send a notification (rate limiting).
 func (s *NotificationService) checkRateLimit(alertID string) bool {
 	s.mu.Lock()
@@ -99,6 +379,31 @@ func (s *NotificationService) checkRateLimit(alertID string) bool {
 	return true
 }
 
+// SendTest sends a test notification through a channel, bypassing the
+// severity threshold, rate limit, routes, quiet hours and batching, so that
+// operators can check the channel's configuration.
+func (s *NotificationService) SendTest(ctx context.Context, channel string) error {
+	for _, notifier := range s.notifiers {
+		if notifier.Name() != channel {
+			continue
+		}
+
+		msg := notification.Message{
+			Title:    "Test notification",
+			Text:     "This is a test notification sent to check the channel's configuration.",
+			Severity: notification.SeverityInfo,
+			Source:   "test",
+		}
+		if s.templates != nil {
+			msg = s.templates.Render(ctx, channel, msg)
+		}
+
+		return notifier.Send(ctx, msg)
+	}
+
+	return ErrNotificationChannelNotFound
+}
+
 // GetActiveNotifiers returns the list of active notifier names.
 func (s *NotificationService) GetActiveNotifiers() []string {
 	names := make([]string, len(s.notifiers))
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// ErrNotificationTemplateNotFound is returned when a channel type has no custom template.
+var ErrNotificationTemplateNotFound = errors.New("notification template not found")
+
+// notificationTemplateCacheTTL bounds how long other instances keep rendering a changed template.
+const notificationTemplateCacheTTL = time.Minute
+
+// cachedTemplate is a template resolved for a channel type.
+type cachedTemplate struct {
+	template  *entity.NotificationTemplate
+	expiresAt time.Time
+}
+
+// NotificationTemplateService manages per channel type notification templates
+// and renders notification content with them. Channel types without a custom
+// template, or whose template fails to render, use the built-in defaults.
+type NotificationTemplateService struct {
+	templateRepo repository.NotificationTemplateRepository
+	alertURL     string
+	mu           sync.RWMutex
+	cache        map[entity.ChannelType]cachedTemplate
+}
+
+// NewNotificationTemplateService creates a new notification template service.
+// AlertURL is the link to an alert exposed to templates; {id} is replaced by the alert ID.
+func NewNotificationTemplateService(templateRepo repository.NotificationTemplateRepository, alertURL string) *NotificationTemplateService {
+	return &NotificationTemplateService{
+		templateRepo: templateRepo,
+		alertURL:     alertURL,
+		cache:        make(map[entity.ChannelType]cachedTemplate),
+	}
+}
+
+// Get returns the template of a channel type, or its built-in default.
+func (s *NotificationTemplateService) Get(ctx context.Context, channel string) (*entity.NotificationTemplate, error) {
+	channelType := entity.ChannelType(channel)
+	if !channelType.IsValid() {
+		return nil, entity.ErrChannelInvalidType
+	}
+
+	template, err := s.templateRepo.GetByChannel(ctx, channelType)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return entity.DefaultNotificationTemplate(channelType), nil
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// List returns the template of every channel type, built-in defaults included.
+func (s *NotificationTemplateService) List(ctx context.Context) ([]*entity.NotificationTemplate, error) {
+	custom, err := s.templateRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byChannel := make(map[entity.ChannelType]*entity.NotificationTemplate, len(custom))
+	for _, template := range custom {
+		byChannel[template.Channel] = template
+	}
+
+	channels := entity.ChannelTypes()
+	templates := make([]*entity.NotificationTemplate, 0, len(channels))
+	for _, channel := range channels {
+		if template, ok := byChannel[channel]; ok {
+			templates = append(templates, template)
+			continue
+		}
+		templates = append(templates, entity.DefaultNotificationTemplate(channel))
+	}
+
+	return templates, nil
+}
+
+// Set creates or replaces the template of a channel type after validating it.
+func (s *NotificationTemplateService) Set(
+	ctx context.Context,
+	channel, title, body string,
+	createdBy *entity.ID,
+) (*entity.NotificationTemplate, error) {
+	channelType := entity.ChannelType(channel)
+
+	existing, err := s.templateRepo.GetByChannel(ctx, channelType)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	if existing != nil {
+		if err := existing.Update(title, body); err != nil {
+			return nil, err
+		}
+		if err := s.templateRepo.Update(ctx, existing); err != nil {
+			return nil, err
+		}
+		s.invalidate(channelType)
+		return existing, nil
+	}
+
+	template, err := entity.NewNotificationTemplate(channelType, title, body, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	s.invalidate(channelType)
+	return template, nil
+}
+
+// Delete removes the template of a channel type, restoring the built-in default.
+func (s *NotificationTemplateService) Delete(ctx context.Context, channel string) error {
+	channelType := entity.ChannelType(channel)
+
+	if err := s.templateRepo.Delete(ctx, channelType); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrNotificationTemplateNotFound
+		}
+		return err
+	}
+
+	s.invalidate(channelType)
+	return nil
+}
+
+// Preview validates templates and renders them with sample data without saving them.
+func (s *NotificationTemplateService) Preview(channel, title, body string) (string, string, error) {
+	template := &entity.NotificationTemplate{
+		Channel: entity.ChannelType(channel),
+		Title:   title,
+		Body:    body,
+	}
+
+	if err := template.Validate(); err != nil {
+		return "", "", err
+	}
+
+	return template.Render(entity.SampleTemplateData())
+}
+
+// Render applies the template of a channel type to a message. The message is
+// returned unchanged when the channel uses the built-in default, or when its
+// template cannot be loaded or rendered.
+func (s *NotificationTemplateService) Render(ctx context.Context, channel string, msg notification.Message) notification.Message {
+	template := s.resolve(ctx, entity.ChannelType(channel))
+	if template == nil || template.IsDefault() {
+		return msg
+	}
+
+	title, body, err := template.Render(notification.NewTemplateData(msg, s.links(msg)))
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("channel", channel).
+			Str("alert_id", msg.AlertID).
+			Msg("Failed to render notification template, using default")
+		return msg
+	}
+
+	msg.Title = title
+	msg.Text = body
+	return msg
+}
+
+// resolve returns the cached template of a channel type, loading it when stale.
+// It returns nil when the template cannot be loaded.
+func (s *NotificationTemplateService) resolve(ctx context.Context, channel entity.ChannelType) *entity.NotificationTemplate {
+	s.mu.RLock()
+	cached, ok := s.cache[channel]
+	s.mu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.template
+	}
+
+	template, err := s.Get(ctx, string(channel))
+	if err != nil {
+		log.Warn().Err(err).Str("channel", string(channel)).Msg("Failed to load notification template")
+		return nil
+	}
+
+	s.mu.Lock()
+	s.cache[channel] = cachedTemplate{template: template, expiresAt: time.Now().Add(notificationTemplateCacheTTL)}
+	s.mu.Unlock()
+
+	return template
+}
+
+// invalidate drops the cached template of a channel type.
+func (s *NotificationTemplateService) invalidate(channel entity.ChannelType) {
+	s.mu.Lock()
+	delete(s.cache, channel)
+	s.mu.Unlock()
+}
+
+// links returns the links exposed to templates for a message.
+func (s *NotificationTemplateService) links(msg notification.Message) map[string]string {
+	links := make(map[string]string)
+	if s.alertURL != "" && msg.AlertID != "" {
+		links["alert"] = strings.ReplaceAll(s.alertURL, "{id}", msg.AlertID)
+	}
+	if msg.RunbookURL != "" {
+		links["runbook"] = msg.RunbookURL
+	}
+	return links
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/document"
+)
+
+// Postmortem errors.
+var (
+	ErrPostmortemNotFound      = errors.New("postmortem not found")
+	ErrPostmortemExists        = errors.New("incident already has a postmortem")
+	ErrPostmortemOwnerNotFound = errors.New("action item owner not found")
+	ErrExportFormatInvalid     = errors.New("export format must be markdown or pdf")
+)
+
+// Formats postmortems are exported to.
+const (
+	ExportFormatMarkdown = "markdown"
+	ExportFormatPDF      = "pdf"
+)
+
+// exportFilenameUnsafe matches the characters left out of export file names.
+var exportFilenameUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// PostmortemInput holds the data of a new postmortem. Without a title, a
+// postmortem of an incident is named after it.
+type PostmortemInput struct {
+	IncidentID  *entity.ID
+	TeamID      *entity.ID
+	Title       string
+	Summary     string
+	Impact      string
+	RootCause   string
+	ActionItems []entity.PostmortemActionItem
+	AlertIDs    []entity.ID
+}
+
+// PostmortemUpdate holds the changes to a postmortem; nil fields are left
+// unchanged, and non-nil lists replace the current ones.
+type PostmortemUpdate struct {
+	Title       *string
+	Summary     *string
+	Impact      *string
+	RootCause   *string
+	ActionItems []entity.PostmortemActionItem
+	AlertIDs    []entity.ID
+}
+
+// PostmortemDocument is an exported postmortem.
+type PostmortemDocument struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// PostmortemService manages postmortems of incidents and alerts, and exports
+// them for sharing.
+type PostmortemService struct {
+	postmortemRepo  repository.PostmortemRepository
+	userRepo        repository.UserRepository
+	incidentService *IncidentService
+	alertService    *AlertService
+}
+
+// NewPostmortemService creates a new postmortem service.
+func NewPostmortemService(
+	postmortemRepo repository.PostmortemRepository,
+	userRepo repository.UserRepository,
+	incidentService *IncidentService,
+	alertService *AlertService,
+) *PostmortemService {
+	return &PostmortemService{
+		postmortemRepo:  postmortemRepo,
+		userRepo:        userRepo,
+		incidentService: incidentService,
+		alertService:    alertService,
+	}
+}
+
+// Create writes a postmortem. Its incident and alerts must be visible to the
+// role within the tenant scope; a postmortem of an incident belongs to the
+// incident's team.
+func (s *PostmortemService) Create(
+	ctx context.Context,
+	input PostmortemInput,
+	createdBy *entity.ID,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) (*entity.Postmortem, error) {
+	teamID := input.TeamID
+	title := input.Title
+	if input.IncidentID != nil {
+		incident, err := s.incidentService.GetVisible(ctx, *input.IncidentID, tenant)
+		if err != nil {
+			return nil, err
+		}
+		teamID = incident.TeamID
+		if title == "" {
+			title = incident.Title
+		}
+	}
+
+	postmortem, err := entity.NewPostmortem(title, input.Summary, input.Impact, input.RootCause, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	postmortem.IncidentID = input.IncidentID
+	postmortem.TeamID = teamID
+	if input.ActionItems != nil {
+		postmortem.ActionItems = input.ActionItems
+	}
+	postmortem.AlertIDs = input.AlertIDs
+	if err := postmortem.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkReferences(ctx, postmortem, role, tenant); err != nil {
+		return nil, err
+	}
+
+	if err := s.postmortemRepo.Create(ctx, postmortem); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrPostmortemExists
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	return postmortem, nil
+}
+
+// GetByID retrieves a postmortem by ID.
+func (s *PostmortemService) GetByID(ctx context.Context, id entity.ID) (*entity.Postmortem, error) {
+	postmortem, err := s.postmortemRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPostmortemNotFound
+		}
+		return nil, err
+	}
+	return postmortem, nil
+}
+
+// GetVisible retrieves a postmortem the tenant scope may see. Postmortems of
+// other teams are reported as not found.
+func (s *PostmortemService) GetVisible(
+	ctx context.Context,
+	id entity.ID,
+	tenant valueobject.TenantScope,
+) (*entity.Postmortem, error) {
+	postmortem, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.Allows(postmortem.TeamID) {
+		return nil, ErrPostmortemNotFound
+	}
+	return postmortem, nil
+}
+
+// GetByIncident retrieves the postmortem of an incident.
+func (s *PostmortemService) GetByIncident(ctx context.Context, incidentID entity.ID) (*entity.Postmortem, error) {
+	postmortem, err := s.postmortemRepo.GetByIncident(ctx, incidentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPostmortemNotFound
+		}
+		return nil, err
+	}
+	return postmortem, nil
+}
+
+// List retrieves postmortems, newest first.
+func (s *PostmortemService) List(
+	ctx context.Context,
+	filter repository.PostmortemFilter,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Postmortem], error) {
+	return s.postmortemRepo.List(ctx, filter, pagination)
+}
+
+// Update changes a postmortem. Alerts it is linked to must be visible to the
+// role within the tenant scope.
+func (s *PostmortemService) Update(
+	ctx context.Context,
+	id entity.ID,
+	update PostmortemUpdate,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) (*entity.Postmortem, error) {
+	postmortem, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Title != nil {
+		postmortem.Title = *update.Title
+	}
+	if update.Summary != nil {
+		postmortem.Summary = *update.Summary
+	}
+	if update.Impact != nil {
+		postmortem.Impact = *update.Impact
+	}
+	if update.RootCause != nil {
+		postmortem.RootCause = *update.RootCause
+	}
+	if update.ActionItems != nil {
+		postmortem.ActionItems = update.ActionItems
+	}
+	if update.AlertIDs != nil {
+		postmortem.AlertIDs = update.AlertIDs
+	}
+	if err := postmortem.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkReferences(ctx, postmortem, role, tenant); err != nil {
+		return nil, err
+	}
+	postmortem.Touch()
+
+	if err := s.postmortemRepo.Update(ctx, postmortem); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrPostmortemNotFound
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrAlertNotFound
+		}
+		return nil, err
+	}
+
+	return postmortem, nil
+}
+
+// Delete removes a postmortem.
+func (s *PostmortemService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.postmortemRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPostmortemNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Export renders a postmortem as a markdown or PDF document with its
+// incident, action items and the alerts it covers that are visible to the
+// role within the tenant scope.
+func (s *PostmortemService) Export(
+	ctx context.Context,
+	postmortem *entity.Postmortem,
+	format string,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) (*PostmortemDocument, error) {
+	if format != ExportFormatMarkdown && format != ExportFormatPDF {
+		return nil, ErrExportFormatInvalid
+	}
+
+	markdown, err := s.markdown(ctx, postmortem, role, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.Trim(exportFilenameUnsafe.ReplaceAllString(strings.ToLower(postmortem.Title), "-"), "-")
+	if name == "" {
+		name = postmortem.ID.String()
+	}
+	name = "postmortem-" + name
+
+	if format == ExportFormatPDF {
+		return &PostmortemDocument{
+			Filename:    name + ".pdf",
+			ContentType: "application/pdf",
+			Content:     document.MarkdownToPDF(markdown),
+		}, nil
+	}
+
+	return &PostmortemDocument{
+		Filename:    name + ".md",
+		ContentType: "text/markdown; charset=utf-8",
+		Content:     []byte(markdown),
+	}, nil
+}
+
+// markdown composes the document of a postmortem.
+func (s *PostmortemService) markdown(
+	ctx context.Context,
+	postmortem *entity.Postmortem,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Postmortem: %s\n\n", postmortem.Title)
+	fmt.Fprintf(&b, "- Written: %s\n", formatExportTime(postmortem.CreatedAt))
+	fmt.Fprintf(&b, "- Last updated: %s\n", formatExportTime(postmortem.UpdatedAt))
+	if postmortem.CreatedBy != nil {
+		fmt.Fprintf(&b, "- Author: %s\n", s.userName(ctx, *postmortem.CreatedBy))
+	}
+
+	var alerts []*entity.Alert
+	if postmortem.IncidentID != nil {
+		incident, err := s.incidentService.GetByID(ctx, *postmortem.IncidentID)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(&b, "\n## Incident\n\n")
+		fmt.Fprintf(&b, "- Title: %s\n", incident.Title)
+		fmt.Fprintf(&b, "- Severity: %s\n", incident.Severity)
+		fmt.Fprintf(&b, "- Status: %s\n", incident.Status)
+		fmt.Fprintf(&b, "- Opened: %s\n", formatExportTime(incident.CreatedAt))
+		if incident.MitigatedAt != nil {
+			fmt.Fprintf(&b, "- Mitigated: %s\n", formatExportTime(*incident.MitigatedAt))
+		}
+		if incident.ClosedAt != nil {
+			fmt.Fprintf(&b, "- Closed: %s\n", formatExportTime(*incident.ClosedAt))
+		}
+		if incident.CommanderID != nil {
+			fmt.Fprintf(&b, "- Commander: %s\n", s.userName(ctx, *incident.CommanderID))
+		}
+
+		alerts, err = s.incidentService.Alerts(ctx, incident.ID, role, tenant)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	writeExportSection(&b, "Summary", postmortem.Summary)
+	writeExportSection(&b, "Impact", postmortem.Impact)
+	writeExportSection(&b, "Root cause", postmortem.RootCause)
+
+	if len(postmortem.ActionItems) > 0 {
+		fmt.Fprintf(&b, "\n## Action items\n\n")
+		for _, item := range postmortem.ActionItems {
+			check := " "
+			if item.Done {
+				check = "x"
+			}
+			fmt.Fprintf(&b, "- [%s] %s", check, item.Description)
+			var details []string
+			if item.OwnerID != nil {
+				details = append(details, "owner: "+s.userName(ctx, *item.OwnerID))
+			}
+			if item.DueDate != nil {
+				details = append(details, "due: "+item.DueDate.UTC().Format(time.DateOnly))
+			}
+			if len(details) > 0 {
+				fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	// Alerts linked directly come after those of the incident, once each
+	seen := make(map[entity.ID]bool, len(alerts))
+	for _, alert := range alerts {
+		seen[alert.ID] = true
+	}
+	for _, alertID := range postmortem.AlertIDs {
+		if seen[alertID] {
+			continue
+		}
+		alert, err := s.alertService.GetVisible(ctx, alertID, role, tenant)
+		if errors.Is(err, ErrAlertNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		seen[alertID] = true
+		alerts = append(alerts, alert)
+	}
+
+	if len(alerts) > 0 {
+		fmt.Fprintf(&b, "\n## Alerts\n\n")
+		for _, alert := range alerts {
+			fmt.Fprintf(&b, "- %s: %s (%s, %s, %s)\n", formatExportTime(alert.CreatedAt),
+				alert.Title, alert.Severity, alert.Source, alert.Status)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// checkReferences checks that the alerts of a postmortem are visible to the
+// role within the tenant scope and that the owners of its action items exist.
+func (s *PostmortemService) checkReferences(
+	ctx context.Context,
+	postmortem *entity.Postmortem,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) error {
+	for _, alertID := range postmortem.AlertIDs {
+		if _, err := s.alertService.GetVisible(ctx, alertID, role, tenant); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range postmortem.ActionItems {
+		if item.OwnerID == nil {
+			continue
+		}
+		if _, err := s.userRepo.GetByID(ctx, *item.OwnerID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPostmortemOwnerNotFound
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// userName returns the name of a user for a document, or the ID of a user
+// that no longer exists.
+func (s *PostmortemService) userName(ctx context.Context, userID entity.ID) string {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return userID.String()
+	}
+	return user.Name
+}
+
+// writeExportSection writes a markdown section of a postmortem, if it has content.
+func writeExportSection(b *strings.Builder, heading, content string) {
+	if strings.TrimSpace(content) == "" {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n%s\n", heading, strings.TrimSpace(content))
+}
+
+// formatExportTime formats a time for a document.
+func formatExportTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 MST")
+}
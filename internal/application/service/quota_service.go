@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// ErrQuotaExceeded is returned, wrapped in a QuotaExceededError, when a
+// daily quota is exhausted.
+var ErrQuotaExceeded = errors.New("daily quota exceeded")
+
+// QuotaStatus is the state of a daily quota.
+type QuotaStatus struct {
+	Subject valueobject.QuotaSubject
+	Metric  valueobject.QuotaMetric
+	// Limit is the daily quota; zero when unlimited.
+	Limit int
+	// Used is the usage of the day so far.
+	Used int
+	// ResetAt is when the usage resets, at the next UTC midnight.
+	ResetAt time.Time
+}
+
+// Remaining returns what is left of the quota; zero when unlimited.
+func (s QuotaStatus) Remaining() int {
+	return max(0, s.Limit-s.Used)
+}
+
+// QuotaExceededError reports the quota a request would exceed.
+type QuotaExceededError struct {
+	Status QuotaStatus
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("daily %s quota of %s exceeded", e.Status.Metric, e.Status.Subject)
+}
+
+// Unwrap makes the error match ErrQuotaExceeded.
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// QuotaService enforces the daily quotas of teams and webhook sources on
+// requests and alerts created. Usage is counted in Redis, shared by every
+// instance; when Redis is unavailable, quotas are not enforced.
+type QuotaService struct {
+	quotaRepo repository.QuotaRepository
+	policy    valueobject.QuotaPolicy
+}
+
+// NewQuotaService creates a new quota service. Without a repository or
+// with a policy limiting nothing, nothing is counted.
+func NewQuotaService(quotaRepo repository.QuotaRepository, policy valueobject.QuotaPolicy) *QuotaService {
+	return &QuotaService{
+		quotaRepo: quotaRepo,
+		policy:    policy,
+	}
+}
+
+// IsEnabled reports whether quotas are counted.
+func (s *QuotaService) IsEnabled() bool {
+	return s.quotaRepo != nil && s.policy.IsEnabled()
+}
+
+// Consume counts n against the quota of a subject for a metric. It returns
+// a QuotaExceededError, counting nothing, when n does not fit in what is
+// left of the quota.
+func (s *QuotaService) Consume(
+	ctx context.Context,
+	subject valueobject.QuotaSubject,
+	metric valueobject.QuotaMetric,
+	n int,
+) (QuotaStatus, error) {
+	now := time.Now()
+	status := QuotaStatus{
+		Subject: subject,
+		Metric:  metric,
+		Limit:   s.policy.LimitsFor(subject).For(metric),
+		ResetAt: valueobject.QuotaResetAt(now),
+	}
+	if !s.IsEnabled() {
+		return status, nil
+	}
+
+	used, ok, err := s.quotaRepo.Consume(ctx, valueobject.QuotaDay(now), subject, metric, n, status.Limit)
+	if err != nil {
+		// Fail open: an unavailable Redis must not take the API down
+		log.Warn().Err(err).Str("subject", subject.String()).Str("metric", string(metric)).
+			Msg("Failed to count quota usage")
+		return status, nil
+	}
+	status.Used = used
+
+	labels := []string{subject.String(), string(metric)}
+	if status.Limit > 0 {
+		metrics.QuotaUsageRatio.WithLabelValues(labels...).Set(float64(used) / float64(status.Limit))
+	}
+	if !ok {
+		metrics.QuotaExceededTotal.WithLabelValues(labels...).Inc()
+		return status, &QuotaExceededError{Status: status}
+	}
+	metrics.QuotaConsumedTotal.WithLabelValues(labels...).Add(float64(n))
+
+	return status, nil
+}
+
+// ListUsage returns the quotas used today, sorted by subject and metric.
+func (s *QuotaService) ListUsage(ctx context.Context) ([]QuotaStatus, error) {
+	if s.quotaRepo == nil {
+		return []QuotaStatus{}, nil
+	}
+
+	now := time.Now()
+	usage, err := s.quotaRepo.ListUsage(ctx, valueobject.QuotaDay(now))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]QuotaStatus, 0, len(usage))
+	for _, u := range usage {
+		statuses = append(statuses, QuotaStatus{
+			Subject: u.Subject,
+			Metric:  u.Metric,
+			Limit:   s.policy.LimitsFor(u.Subject).For(u.Metric),
+			Used:    u.Used,
+			ResetAt: valueobject.QuotaResetAt(now),
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Subject != statuses[j].Subject {
+			return statuses[i].Subject.String() < statuses[j].Subject.String()
+		}
+		return statuses[i].Metric < statuses[j].Metric
+	})
+
+	return statuses, nil
+}
+
+// Reset clears the usage of the quotas of a subject for today.
+func (s *QuotaService) Reset(ctx context.Context, subject valueobject.QuotaSubject) error {
+	if s.quotaRepo == nil {
+		return nil
+	}
+
+	if err := s.quotaRepo.Reset(ctx, valueobject.QuotaDay(time.Now()), subject); err != nil {
+		return err
+	}
+
+	for _, metric := range []valueobject.QuotaMetric{valueobject.QuotaRequests, valueobject.QuotaAlerts} {
+		metrics.QuotaUsageRatio.DeleteLabelValues(subject.String(), string(metric))
+	}
+
+	return nil
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Route service errors.
+var (
+	ErrRouteNotFound       = errors.New("route not found")
+	ErrRouteParentNotFound = errors.New("parent route not found")
+	ErrRouteCycle          = errors.New("route cannot be nested under one of its descendants")
+
+	ErrRouteExternalIDConflict = errors.New("a route with this external ID is being created concurrently")
+)
+
+// routingTreeCacheTTL bounds how long other instances keep evaluating changed routes.
+const routingTreeCacheTTL = time.Minute
+
+// RouteInput holds the fields of a notification route. The parent is given
+// by ParentID, or by ParentExternalID for routes managed by external tools.
+// ExternalID is only set when a route is created.
+type RouteInput struct {
+	Name             string
+	ParentID         *entity.ID
+	ParentExternalID string
+	Position         int
+	Match            entity.RouteMatcher
+	Channels         []string
+	Continue         bool
+	CreatedBy        *entity.ID
+	ExternalID       string
+}
+
+// RouteService manages the notification routing tree and resolves the
+// channels each notification is delivered to.
+type RouteService struct {
+	routeRepo repository.NotificationRouteRepository
+	mu        sync.RWMutex
+	tree      valueobject.RoutingTree
+	expiresAt time.Time
+}
+
+// NewRouteService creates a new route service.
+func NewRouteService(routeRepo repository.NotificationRouteRepository) *RouteService {
+	return &RouteService{routeRepo: routeRepo}
+}
+
+// Create adds a route to the tree.
+func (s *RouteService) Create(ctx context.Context, input RouteInput) (*entity.NotificationRoute, error) {
+	if err := s.resolveParent(ctx, &input); err != nil {
+		return nil, err
+	}
+
+	route, err := entity.NewNotificationRoute(
+		input.Name,
+		input.ParentID,
+		input.Match,
+		input.Channels,
+		input.Continue,
+		input.Position,
+		input.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	route.ExternalID = input.ExternalID
+	if err := route.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.routeRepo.Create(ctx, route); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrRouteParentNotFound
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrRouteExternalIDConflict
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return route, nil
+}
+
+// Get returns a route by its ID.
+func (s *RouteService) Get(ctx context.Context, id entity.ID) (*entity.NotificationRoute, error) {
+	route, err := s.routeRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRouteNotFound
+		}
+		return nil, err
+	}
+	return route, nil
+}
+
+// List returns every route, ordered by position.
+func (s *RouteService) List(ctx context.Context) ([]*entity.NotificationRoute, error) {
+	return s.routeRepo.List(ctx)
+}
+
+// Update replaces the fields of a route. Moving a route moves its children with it.
+func (s *RouteService) Update(ctx context.Context, id entity.ID, input RouteInput) (*entity.NotificationRoute, error) {
+	route, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.resolveParent(ctx, &input); err != nil {
+		return nil, err
+	}
+
+	route.Name = input.Name
+	route.ParentID = input.ParentID
+	route.Position = input.Position
+	route.Match = input.Match
+	route.Channels = input.Channels
+	route.Continue = input.Continue
+	if route.Channels == nil {
+		route.Channels = []string{}
+	}
+
+	if err := route.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAncestors(ctx, route); err != nil {
+		return nil, err
+	}
+
+	route.Touch()
+	if err := s.routeRepo.Update(ctx, route); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrRouteNotFound
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrRouteParentNotFound
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return route, nil
+}
+
+// GetByExternalID returns the route with the ID given by an external tool.
+func (s *RouteService) GetByExternalID(ctx context.Context, externalID string) (*entity.NotificationRoute, error) {
+	route, err := s.routeRepo.GetByExternalID(ctx, externalID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRouteNotFound
+		}
+		return nil, err
+	}
+	return route, nil
+}
+
+// UpsertByExternalID creates the route with the ID given by an external tool,
+// or replaces the fields of the existing one, and reports whether it was created.
+func (s *RouteService) UpsertByExternalID(
+	ctx context.Context,
+	externalID string,
+	input RouteInput,
+) (*entity.NotificationRoute, bool, error) {
+	current, err := s.GetByExternalID(ctx, externalID)
+	if err != nil {
+		if !errors.Is(err, ErrRouteNotFound) {
+			return nil, false, err
+		}
+		input.ExternalID = externalID
+		route, err := s.Create(ctx, input)
+		return route, err == nil, err
+	}
+
+	route, err := s.Update(ctx, current.ID, input)
+	return route, false, err
+}
+
+// DeleteByExternalID removes the route with the ID given by an external tool,
+// together with its children.
+func (s *RouteService) DeleteByExternalID(ctx context.Context, externalID string) error {
+	route, err := s.GetByExternalID(ctx, externalID)
+	if err != nil {
+		return err
+	}
+	return s.Delete(ctx, route.ID)
+}
+
+// Delete removes a route together with its children.
+func (s *RouteService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.routeRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrRouteNotFound
+		}
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// Resolve returns the channels a notification is routed to. It reports false
+// when no route matches, or when the routes cannot be loaded, in which case
+// the notification goes to every channel.
+func (s *RouteService) Resolve(ctx context.Context, msg notification.Message) ([]string, bool) {
+	return s.routingTree(ctx).Resolve(msg)
+}
+
+// routingTree returns the cached routing tree, reloading it when stale.
+func (s *RouteService) routingTree(ctx context.Context) valueobject.RoutingTree {
+	s.mu.RLock()
+	tree, expiresAt := s.tree, s.expiresAt
+	s.mu.RUnlock()
+	if time.Now().Before(expiresAt) {
+		return tree
+	}
+
+	routes, err := s.routeRepo.List(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load notification routes")
+		return tree
+	}
+
+	tree = valueobject.NewRoutingTree(routes)
+
+	s.mu.Lock()
+	s.tree = tree
+	s.expiresAt = time.Now().Add(routingTreeCacheTTL)
+	s.mu.Unlock()
+
+	return tree
+}
+
+// checkAncestors ensures the parent of a route exists and is not one of its descendants.
+func (s *RouteService) checkAncestors(ctx context.Context, route *entity.NotificationRoute) error {
+	parentID := route.ParentID
+	for parentID != nil {
+		if *parentID == route.ID {
+			return ErrRouteCycle
+		}
+
+		parent, err := s.routeRepo.GetByID(ctx, *parentID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrRouteParentNotFound
+			}
+			return err
+		}
+		parentID = parent.ParentID
+	}
+	return nil
+}
+
+// resolveParent sets the parent ID of the input from its parent external ID.
+func (s *RouteService) resolveParent(ctx context.Context, input *RouteInput) error {
+	if input.ParentExternalID == "" {
+		return nil
+	}
+
+	parent, err := s.GetByExternalID(ctx, input.ParentExternalID)
+	if err != nil {
+		if errors.Is(err, ErrRouteNotFound) {
+			return ErrRouteParentNotFound
+		}
+		return err
+	}
+	input.ParentID = &parent.ID
+	return nil
+}
+
+// invalidate forces the routing tree to be reloaded.
+func (s *RouteService) invalidate() {
+	s.mu.Lock()
+	s.expiresAt = time.Time{}
+	s.mu.Unlock()
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Cache key prefixes of the rule evaluation state: streaks and cooldowns
+// per rule and label set, last and recent samples per metric and label set.
+const (
+	ruleStreakKeyPrefix   = "rule_streak:"
+	ruleCooldownKeyPrefix = "rule_cooldown:"
+	ruleMetricKeyPrefix   = "rule_metric:"
+	ruleHistoryKeyPrefix  = "rule_samples:"
+)
+
+// maxMetricHistory bounds how many samples of a series are kept for the
+// baselines of anomaly conditions.
+const maxMetricHistory = 1000
+
+// ruleStateTTL bounds how long a streak of matching samples, or the last
+// sample of a metric, is kept without a new sample.
+const ruleStateTTL = 24 * time.Hour
+
+// Outcomes of evaluating a rule against a sample.
+const (
+	RuleOutcomeNotMet   = "not_met"
+	RuleOutcomePending  = "pending"
+	RuleOutcomeCooldown = "cooldown"
+	RuleOutcomeFired    = "fired"
+)
+
+// MetricSample is a value of a metric, for the series identified by its labels.
+type MetricSample struct {
+	Metric string
+	Value  float64
+	Labels map[string]string
+}
+
+// metricSnapshot is the last sample of a metric series, kept to compute rates
+// of change, evaluate conditions over several metrics and detect absences.
+type metricSnapshot struct {
+	Value  float64   `json:"value"`
+	SeenAt time.Time `json:"seen_at"`
+}
+
+// RuleEvaluation is the outcome of evaluating a rule against a sample.
+type RuleEvaluation struct {
+	Rule    *entity.AlertRule
+	Sample  MetricSample
+	Outcome string
+	// Alert is the alert created when the rule fired.
+	Alert *entity.Alert
+}
+
+// RuleEngine evaluates the enabled alert rules against metric samples and
+// creates an alert when a rule fires. A rule is evaluated once per series
+// (label set) it has samples for in a batch, against the last sample of each
+// of its metrics with those labels. It fires once its condition held for
+// Consecutive evaluations in a row of a series, then not again for the same
+// series until CooldownMinutes have passed. Last samples, streaks and
+// cooldowns are kept in Redis, so that every instance evaluating samples
+// shares them; the cooldown is taken with SETNX, so that only one instance
+// fires.
+type RuleEngine struct {
+	ruleRepo     repository.AlertRuleRepository
+	cacheRepo    repository.CacheRepository
+	alertService *AlertService
+}
+
+// NewRuleEngine creates a new rule engine.
+func NewRuleEngine(
+	ruleRepo repository.AlertRuleRepository,
+	cacheRepo repository.CacheRepository,
+	alertService *AlertService,
+) *RuleEngine {
+	return &RuleEngine{
+		ruleRepo:     ruleRepo,
+		cacheRepo:    cacheRepo,
+		alertService: alertService,
+	}
+}
+
+// Evaluate records the samples, then evaluates the enabled rules reading
+// their metrics. Absent conditions are thus checked when other metrics of
+// their rule are sampled. The recent samples of metrics with anomaly
+// conditions are kept, for the longest window of those conditions.
+func (e *RuleEngine) Evaluate(ctx context.Context, samples []MetricSample) ([]RuleEvaluation, error) {
+	rules, err := e.ruleRepo.ListEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byMetric := make(map[string][]*entity.AlertRule)
+	baselines := make(map[string]time.Duration)
+	for _, rule := range rules {
+		for _, metric := range rule.Condition.Metrics() {
+			byMetric[metric] = append(byMetric[metric], rule)
+		}
+		for metric, window := range rule.Condition.BaselineWindows() {
+			baselines[metric] = max(baselines[metric], window)
+		}
+	}
+
+	now := time.Now().UTC()
+	states := make(map[string]map[string]entity.MetricState)
+	evaluations := make([]RuleEvaluation, 0, len(samples))
+	evaluated := make(map[string]bool)
+	for _, sample := range samples {
+		labels := labelsFingerprint(sample.Labels)
+		state, err := e.recordSample(ctx, sample, labels, now)
+		if err != nil {
+			return nil, err
+		}
+		if window := baselines[sample.Metric]; window > 0 {
+			if state.History, err = e.recordHistory(ctx, sample, labels, now, window); err != nil {
+				return nil, err
+			}
+		}
+		if states[labels] == nil {
+			states[labels] = make(map[string]entity.MetricState)
+		}
+		states[labels][sample.Metric] = state
+
+		// Rules are evaluated once per series, with the batch's first sample
+		for _, rule := range byMetric[sample.Metric] {
+			series := rule.ID.String() + ":" + labels
+			if evaluated[series] {
+				continue
+			}
+			evaluated[series] = true
+			evaluations = append(evaluations, RuleEvaluation{Rule: rule, Sample: sample})
+		}
+	}
+
+	for i := range evaluations {
+		evaluation := &evaluations[i]
+		labels := labelsFingerprint(evaluation.Sample.Labels)
+		if err := e.loadStates(ctx, evaluation.Rule, labels, states[labels]); err != nil {
+			return evaluations[:i], err
+		}
+
+		if err := e.evaluate(ctx, evaluation, labels, states[labels], now); err != nil {
+			return evaluations[:i], err
+		}
+		metrics.RuleEvaluationsTotal.WithLabelValues(evaluation.Outcome).Inc()
+	}
+
+	return evaluations, nil
+}
+
+// recordSample stores a sample as the last of its series and returns its
+// state, with its rate of change since the previous sample.
+func (e *RuleEngine) recordSample(ctx context.Context, sample MetricSample, labels string, now time.Time) (entity.MetricState, error) {
+	key := ruleMetricKeyPrefix + shortHash(sample.Metric) + ":" + labels
+	state := entity.MetricState{Value: sample.Value, SeenAt: now}
+
+	var previous metricSnapshot
+	err := e.cacheRepo.Get(ctx, key, &previous)
+	switch {
+	case err == nil:
+		if elapsed := now.Sub(previous.SeenAt).Seconds(); elapsed > 0 {
+			state.Rate = (sample.Value - previous.Value) / elapsed
+			state.HasRate = true
+		}
+	case !errors.Is(err, repository.ErrNotFound):
+		return state, err
+	}
+
+	snapshot := metricSnapshot{Value: sample.Value, SeenAt: now}
+	if err := e.cacheRepo.Set(ctx, key, snapshot, ruleStateTTL); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+// recordHistory appends a sample to the recent samples of its series, kept
+// for a window, and returns the previous ones. The samples are a list, so
+// that samples of a series recorded concurrently are all kept.
+func (e *RuleEngine) recordHistory(
+	ctx context.Context,
+	sample MetricSample,
+	labels string,
+	now time.Time,
+	window time.Duration,
+) ([]entity.MetricPoint, error) {
+	key := ruleHistoryKeyPrefix + shortHash(sample.Metric) + ":" + labels
+
+	var history []entity.MetricPoint
+	if err := e.cacheRepo.ListRange(ctx, key, &history); err != nil {
+		return nil, err
+	}
+
+	point := entity.MetricPoint{Value: sample.Value, At: now}
+	if err := e.cacheRepo.ListAppend(ctx, key, point, maxMetricHistory, window); err != nil {
+		return nil, err
+	}
+
+	// Samples older than the window stay in the list until trimmed
+	since := now.Add(-window)
+	previous := history[:0]
+	for _, point := range history {
+		if !point.At.Before(since) {
+			previous = append(previous, point)
+		}
+	}
+
+	return previous, nil
+}
+
+// loadStates adds to states the last samples of the rule's metrics that are
+// not in the batch. Rates and baselines are only known for metrics in the
+// batch.
+func (e *RuleEngine) loadStates(ctx context.Context, rule *entity.AlertRule, labels string, states map[string]entity.MetricState) error {
+	for _, metric := range rule.Condition.Metrics() {
+		if _, ok := states[metric]; ok {
+			continue
+		}
+
+		var snapshot metricSnapshot
+		err := e.cacheRepo.Get(ctx, ruleMetricKeyPrefix+shortHash(metric)+":"+labels, &snapshot)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		states[metric] = entity.MetricState{Value: snapshot.Value, SeenAt: snapshot.SeenAt}
+	}
+	return nil
+}
+
+// evaluate evaluates a rule against the last samples of a series.
+func (e *RuleEngine) evaluate(
+	ctx context.Context,
+	evaluation *RuleEvaluation,
+	labels string,
+	states map[string]entity.MetricState,
+	now time.Time,
+) error {
+	rule, sample := evaluation.Rule, evaluation.Sample
+	evaluation.Outcome = RuleOutcomeNotMet
+	series := rule.ID.String() + ":" + labels
+	streakKey := ruleStreakKeyPrefix + series
+
+	if !rule.EvaluateMetrics(states, now) {
+		return e.cacheRepo.Delete(ctx, streakKey)
+	}
+
+	if rule.Condition.Consecutive > 1 {
+		streak, err := e.cacheRepo.Increment(ctx, streakKey)
+		if err != nil {
+			return err
+		}
+		_ = e.cacheRepo.Expire(ctx, streakKey, ruleStateTTL)
+		if streak < int64(rule.Condition.Consecutive) {
+			evaluation.Outcome = RuleOutcomePending
+			return nil
+		}
+	}
+
+	// The cooldown is a lock held until it expires, released early only
+	// when the alert could not be created
+	cooldownKey := ruleCooldownKeyPrefix + series
+	var cooldownToken string
+	if rule.CooldownMinutes > 0 {
+		cooldown := time.Duration(rule.CooldownMinutes) * time.Minute
+		token, err := e.cacheRepo.Lock(ctx, cooldownKey, cooldown)
+		if errors.Is(err, repository.ErrLockHeld) {
+			evaluation.Outcome = RuleOutcomeCooldown
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		cooldownToken = token
+	}
+
+	alert, err := e.alertService.Create(ctx, ruleAlertInput(rule, sample))
+	if err != nil {
+		// Let the next sample fire again
+		if cooldownToken != "" {
+			_ = e.cacheRepo.Unlock(ctx, cooldownKey, cooldownToken)
+		}
+		return err
+	}
+	_ = e.cacheRepo.Delete(ctx, streakKey)
+
+	log.Info().
+		Str("rule_id", rule.ID.String()).
+		Str("alert_id", alert.ID.String()).
+		Float64("value", sample.Value).
+		Msg("Alert rule fired")
+
+	evaluation.Outcome = RuleOutcomeFired
+	evaluation.Alert = alert
+	return nil
+}
+
+// ruleAlertInput describes the alert a rule fires for a sample.
+func ruleAlertInput(rule *entity.AlertRule, sample MetricSample) CreateAlertInput {
+	message := fmt.Sprintf("%s is %g (%s %g)",
+		sample.Metric, sample.Value, rule.Condition.Operator, rule.Condition.Threshold)
+	switch {
+	case rule.Condition.IsComposite():
+		message = rule.Condition.String()
+	case rule.Condition.Type != "" && rule.Condition.Type != entity.ConditionThreshold:
+		message = fmt.Sprintf("%s is %g (%s)", sample.Metric, sample.Value, rule.Condition.String())
+	}
+	if len(sample.Labels) > 0 {
+		message += " for " + formatLabels(sample.Labels)
+	}
+
+	labels := make(map[string]interface{}, len(sample.Labels))
+	for k, v := range sample.Labels {
+		labels[k] = v
+	}
+
+	metadata := map[string]interface{}{
+		"rule_id": rule.ID.String(),
+		"metric":  sample.Metric,
+		"value":   sample.Value,
+		"labels":  labels,
+	}
+	if rule.RunbookURL != "" {
+		metadata[entity.MetadataRunbookURL] = rule.RunbookURL
+	}
+	if rule.Remediation != "" {
+		metadata[entity.MetadataRemediation] = rule.Remediation
+	}
+	if rule.CreateTicket {
+		metadata[entity.MetadataCreateTicket] = true
+	}
+
+	return CreateAlertInput{
+		Title:    rule.Name,
+		Message:  message,
+		Severity: rule.Severity,
+		Source:   "rule",
+		Metadata: metadata,
+		TeamID:   rule.TeamID,
+	}
+}
+
+// labelsFingerprint identifies the series of a label set, whatever the
+// order of its labels.
+func labelsFingerprint(labels map[string]string) string {
+	return shortHash(formatLabels(labels))
+}
+
+// shortHash returns a short, fixed-length hash of a value, for cache keys.
+func shortHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// formatLabels renders a label set as {k1="v1", k2="v2"}, sorted by name.
+func formatLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, labels[name]))
+	}
+
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
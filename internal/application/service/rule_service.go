@@ -0,0 +1,625 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Rule service errors.
+var (
+	ErrRuleNotFound           = errors.New("rule not found")
+	ErrRuleExternalIDConflict = errors.New("a rule with this external ID is being created concurrently")
+	ErrRuleForbidden          = errors.New("only the creator of a rule or an admin can modify it")
+	ErrRuleOtherTeam          = errors.New("a rule with this external ID belongs to another team")
+	ErrBundleInvalid          = errors.New("invalid bundle")
+	ErrBundleAmbiguous        = errors.New("bundle name matches several existing resources")
+)
+
+// Kinds of the resources managed by a bundle.
+const (
+	BundleKindRule  = "rule"
+	BundleKindRoute = "route"
+)
+
+// Actions of the changes made by a bundle.
+const (
+	BundleActionCreate = "create"
+	BundleActionUpdate = "update"
+	BundleActionDelete = "delete"
+)
+
+// RuleSpec declares the desired state of an alert rule.
+type RuleSpec struct {
+	Name            string
+	Description     string
+	Condition       entity.RuleCondition
+	Severity        entity.AlertSeverity
+	Enabled         bool
+	CooldownMinutes int
+	TeamID          *entity.ID
+	RunbookURL      string
+	Remediation     string
+	CreateTicket    bool
+}
+
+// RouteSpec declares the desired state of a notification route. Parent is
+// the name of the parent route, declared in the same bundle.
+type RouteSpec struct {
+	Name     string
+	Parent   string
+	Position int
+	Match    entity.RouteMatcher
+	Channels []string
+	Continue bool
+}
+
+// Bundle declares the desired alert rules and notification routes. A nil
+// section is left untouched; otherwise it is authoritative and the resources
+// it does not declare are deleted.
+type Bundle struct {
+	Rules  *[]RuleSpec
+	Routes *[]RouteSpec
+}
+
+// BundleChange is a change made, or to be made, by a bundle.
+type BundleChange struct {
+	Kind   string
+	Name   string
+	Action string
+}
+
+// BundleReport lists the changes made by applying a bundle. When DryRun is
+// true nothing was changed.
+type BundleReport struct {
+	DryRun    bool
+	Changes   []BundleChange
+	Unchanged int
+}
+
+// Count returns the number of changes with the given action.
+func (r *BundleReport) Count(action string) int {
+	count := 0
+	for _, change := range r.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// ruleChange is a planned change of an alert rule.
+type ruleChange struct {
+	action string
+	rule   *entity.AlertRule
+}
+
+// routeChange is a planned change of a notification route.
+type routeChange struct {
+	action string
+	id     entity.ID
+	spec   RouteSpec
+}
+
+// RuleService manages alert rules declaratively: by bundles of rules and
+// notification routes, or one by one under the IDs given by external tools.
+type RuleService struct {
+	ruleRepo  repository.AlertRuleRepository
+	routes    *RouteService
+	txManager repository.TxManager
+}
+
+// NewRuleService creates a new rule service.
+func NewRuleService(ruleRepo repository.AlertRuleRepository, routes *RouteService) *RuleService {
+	return &RuleService{ruleRepo: ruleRepo, routes: routes}
+}
+
+// SetTxManager makes bundles apply atomically: if any change fails, none
+// is kept. Without it, changes made before a failure remain.
+func (s *RuleService) SetTxManager(txManager repository.TxManager) {
+	s.txManager = txManager
+}
+
+// Apply makes the rules and routes match the bundle, and reports the changes.
+// The whole bundle is validated before anything is changed; with dryRun
+// nothing is. Applying the same bundle again changes nothing.
+func (s *RuleService) Apply(ctx context.Context, bundle Bundle, dryRun bool, appliedBy *entity.ID) (*BundleReport, error) {
+	report := &BundleReport{DryRun: dryRun, Changes: []BundleChange{}}
+
+	var ruleChanges []ruleChange
+	if bundle.Rules != nil {
+		changes, unchanged, err := s.planRules(ctx, *bundle.Rules, appliedBy)
+		if err != nil {
+			return nil, err
+		}
+		ruleChanges = changes
+		report.Unchanged += unchanged
+		for _, change := range changes {
+			report.Changes = append(report.Changes, BundleChange{Kind: BundleKindRule, Name: change.rule.Name, Action: change.action})
+		}
+	}
+
+	var routeChanges []routeChange
+	if bundle.Routes != nil {
+		changes, unchanged, err := s.planRoutes(ctx, *bundle.Routes)
+		if err != nil {
+			return nil, err
+		}
+		routeChanges = changes
+		report.Unchanged += unchanged
+		for _, change := range changes {
+			report.Changes = append(report.Changes, BundleChange{Kind: BundleKindRoute, Name: change.spec.Name, Action: change.action})
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	apply := func(ctx context.Context) error {
+		if err := s.applyRules(ctx, ruleChanges); err != nil {
+			return err
+		}
+		return s.applyRoutes(ctx, routeChanges, appliedBy)
+	}
+
+	var err error
+	if s.txManager != nil {
+		err = s.txManager.WithinTransaction(ctx, apply)
+		// The routing tree may have been reloaded before the commit
+		s.routes.invalidate()
+	} else {
+		err = apply(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// GetByExternalID returns the rule with the ID given by an external tool, if
+// the tenant scope may see it. Rules of other teams are reported as not found.
+func (s *RuleService) GetByExternalID(
+	ctx context.Context,
+	externalID string,
+	tenant valueobject.TenantScope,
+) (*entity.AlertRule, error) {
+	rule, err := s.ruleRepo.GetByExternalID(ctx, externalID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrRuleNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(rule.TeamID) {
+		return nil, ErrRuleNotFound
+	}
+	return rule, nil
+}
+
+// UpsertByExternalID creates the rule with the ID given by an external tool,
+// or replaces the fields of the existing one, and reports whether it was
+// created. An unchanged rule is not written. Only admins and the creator of
+// an existing rule may replace it, and only when the tenant scope covers both
+// the team of the existing rule and the declared one.
+func (s *RuleService) UpsertByExternalID(
+	ctx context.Context,
+	externalID string,
+	spec RuleSpec,
+	tenant valueobject.TenantScope,
+	actor valueobject.Actor,
+) (*entity.AlertRule, bool, error) {
+	if !tenant.Allows(spec.TeamID) {
+		return nil, false, ErrRuleOtherTeam
+	}
+
+	current, err := s.ruleRepo.GetByExternalID(ctx, externalID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, false, err
+		}
+		current = nil
+	}
+
+	if current == nil {
+		rule := &entity.AlertRule{
+			ID:         entity.NewID(),
+			Name:       spec.Name,
+			CreatedBy:  &actor.UserID,
+			ExternalID: externalID,
+			Timestamps: entity.NewTimestamps(),
+		}
+		applyRuleSpec(rule, spec)
+		if err := rule.Validate(); err != nil {
+			return nil, false, err
+		}
+
+		if err := s.ruleRepo.Create(ctx, rule); err != nil {
+			switch {
+			case errors.Is(err, repository.ErrDuplicateKey):
+				return nil, false, ErrRuleExternalIDConflict
+			case errors.Is(err, repository.ErrForeignKeyViolation):
+				return nil, false, ErrTeamNotFound
+			}
+			return nil, false, err
+		}
+		return rule, true, nil
+	}
+
+	if !tenant.Allows(current.TeamID) {
+		return nil, false, ErrRuleOtherTeam
+	}
+	if !actor.CanModify(current.CreatedBy) {
+		return nil, false, ErrRuleForbidden
+	}
+
+	rule := *current
+	rule.Name = spec.Name
+	applyRuleSpec(&rule, spec)
+	if err := rule.Validate(); err != nil {
+		return nil, false, err
+	}
+	if rule.Name == current.Name && sameRule(&rule, current) {
+		return current, false, nil
+	}
+
+	rule.Touch()
+	if err := s.ruleRepo.Update(ctx, &rule); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, false, ErrRuleNotFound
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, false, ErrTeamNotFound
+		}
+		return nil, false, err
+	}
+	return &rule, false, nil
+}
+
+// DeleteByExternalID removes the rule with the ID given by an external tool.
+// Only admins and the creator of the rule may delete it, within the tenant
+// scope.
+func (s *RuleService) DeleteByExternalID(
+	ctx context.Context,
+	externalID string,
+	tenant valueobject.TenantScope,
+	actor valueobject.Actor,
+) error {
+	rule, err := s.GetByExternalID(ctx, externalID, tenant)
+	if err != nil {
+		return err
+	}
+
+	if !actor.CanModify(rule.CreatedBy) {
+		return ErrRuleForbidden
+	}
+
+	if err := s.ruleRepo.Delete(ctx, rule.ID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrRuleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// planRules diffs the declared rules against the existing ones.
+func (s *RuleService) planRules(ctx context.Context, specs []RuleSpec, appliedBy *entity.ID) ([]ruleChange, int, error) {
+	existing, err := s.ruleRepo.ListAll(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	current := make(map[string]*entity.AlertRule, len(existing))
+	for _, rule := range existing {
+		if _, ok := current[rule.Name]; ok {
+			return nil, 0, fmt.Errorf("%w: rule %q", ErrBundleAmbiguous, rule.Name)
+		}
+		current[rule.Name] = rule
+	}
+
+	changes := make([]ruleChange, 0)
+	unchanged := 0
+	declared := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		if declared[spec.Name] {
+			return nil, 0, fmt.Errorf("%w: rule %q is declared twice", ErrBundleInvalid, spec.Name)
+		}
+		declared[spec.Name] = true
+
+		rule, ok := current[spec.Name]
+		if !ok {
+			rule = &entity.AlertRule{
+				ID:         entity.NewID(),
+				Name:       spec.Name,
+				CreatedBy:  appliedBy,
+				Timestamps: entity.NewTimestamps(),
+			}
+			applyRuleSpec(rule, spec)
+			if err := rule.Validate(); err != nil {
+				return nil, 0, fmt.Errorf("%w: rule %q: %v", ErrBundleInvalid, spec.Name, err)
+			}
+			changes = append(changes, ruleChange{action: BundleActionCreate, rule: rule})
+			continue
+		}
+
+		desired := *rule
+		applyRuleSpec(&desired, spec)
+		if err := desired.Validate(); err != nil {
+			return nil, 0, fmt.Errorf("%w: rule %q: %v", ErrBundleInvalid, spec.Name, err)
+		}
+		if sameRule(rule, &desired) {
+			unchanged++
+			continue
+		}
+		desired.Touch()
+		changes = append(changes, ruleChange{action: BundleActionUpdate, rule: &desired})
+	}
+
+	for _, rule := range existing {
+		if !declared[rule.Name] {
+			changes = append(changes, ruleChange{action: BundleActionDelete, rule: rule})
+		}
+	}
+
+	return changes, unchanged, nil
+}
+
+// applyRules makes the planned rule changes.
+func (s *RuleService) applyRules(ctx context.Context, changes []ruleChange) error {
+	for _, change := range changes {
+		var err error
+		switch change.action {
+		case BundleActionCreate:
+			err = s.ruleRepo.Create(ctx, change.rule)
+		case BundleActionUpdate:
+			err = s.ruleRepo.Update(ctx, change.rule)
+		case BundleActionDelete:
+			err = s.ruleRepo.Delete(ctx, change.rule.ID)
+			if errors.Is(err, repository.ErrNotFound) {
+				err = nil
+			}
+		}
+		if err != nil {
+			if errors.Is(err, repository.ErrForeignKeyViolation) {
+				return fmt.Errorf("%w: rule %q: team not found", ErrBundleInvalid, change.rule.Name)
+			}
+			return fmt.Errorf("failed to %s rule %q: %w", change.action, change.rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// planRoutes diffs the declared routes against the existing ones. Creates and
+// updates are ordered parents first, so that each route's parent exists when
+// it is placed.
+func (s *RuleService) planRoutes(ctx context.Context, specs []RouteSpec) ([]routeChange, int, error) {
+	existing, err := s.routes.List(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	current := make(map[string]*entity.NotificationRoute, len(existing))
+	for _, route := range existing {
+		if _, ok := current[route.Name]; ok {
+			return nil, 0, fmt.Errorf("%w: route %q", ErrBundleAmbiguous, route.Name)
+		}
+		current[route.Name] = route
+	}
+
+	declared := make(map[string]RouteSpec, len(specs))
+	for _, spec := range specs {
+		if _, ok := declared[spec.Name]; ok {
+			return nil, 0, fmt.Errorf("%w: route %q is declared twice", ErrBundleInvalid, spec.Name)
+		}
+		declared[spec.Name] = spec
+	}
+
+	depths := make(map[string]int, len(specs))
+	for _, spec := range specs {
+		depth, err := routeDepth(spec.Name, declared)
+		if err != nil {
+			return nil, 0, err
+		}
+		depths[spec.Name] = depth
+	}
+
+	ordered := make([]RouteSpec, len(specs))
+	copy(ordered, specs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return depths[ordered[i].Name] < depths[ordered[j].Name]
+	})
+
+	changes := make([]routeChange, 0)
+	unchanged := 0
+	for _, spec := range ordered {
+		desired, err := entity.NewNotificationRoute(spec.Name, nil, spec.Match, spec.Channels, spec.Continue, spec.Position, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: route %q: %v", ErrBundleInvalid, spec.Name, err)
+		}
+
+		route, ok := current[spec.Name]
+		if !ok {
+			changes = append(changes, routeChange{action: BundleActionCreate, spec: spec})
+			continue
+		}
+
+		if spec.Parent != "" {
+			if parent, ok := current[spec.Parent]; ok {
+				desired.ParentID = &parent.ID
+			} else {
+				// The parent is created by this bundle, so the route moves.
+				newParent := entity.NewID()
+				desired.ParentID = &newParent
+			}
+		}
+
+		if sameRoute(route, desired) {
+			unchanged++
+			continue
+		}
+		changes = append(changes, routeChange{action: BundleActionUpdate, id: route.ID, spec: spec})
+	}
+
+	for _, route := range existing {
+		if _, ok := declared[route.Name]; !ok {
+			changes = append(changes, routeChange{action: BundleActionDelete, id: route.ID, spec: RouteSpec{Name: route.Name}})
+		}
+	}
+
+	return changes, unchanged, nil
+}
+
+// applyRoutes makes the planned route changes. Deletes come last, after the
+// routes kept have been moved out of the subtrees being deleted.
+func (s *RuleService) applyRoutes(ctx context.Context, changes []routeChange, appliedBy *entity.ID) error {
+	existing, err := s.routes.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[string]entity.ID, len(existing))
+	for _, route := range existing {
+		ids[route.Name] = route.ID
+	}
+
+	for _, change := range changes {
+		input := RouteInput{
+			Name:      change.spec.Name,
+			Position:  change.spec.Position,
+			Match:     change.spec.Match,
+			Channels:  change.spec.Channels,
+			Continue:  change.spec.Continue,
+			CreatedBy: appliedBy,
+		}
+		if change.spec.Parent != "" {
+			parentID := ids[change.spec.Parent]
+			input.ParentID = &parentID
+		}
+
+		switch change.action {
+		case BundleActionCreate:
+			route, err := s.routes.Create(ctx, input)
+			if err != nil {
+				return fmt.Errorf("failed to create route %q: %w", change.spec.Name, err)
+			}
+			ids[route.Name] = route.ID
+		case BundleActionUpdate:
+			if _, err := s.routes.Update(ctx, change.id, input); err != nil {
+				return fmt.Errorf("failed to update route %q: %w", change.spec.Name, err)
+			}
+		case BundleActionDelete:
+			// Deleting a route deletes its children, which may be deleted already.
+			if err := s.routes.Delete(ctx, change.id); err != nil && !errors.Is(err, ErrRouteNotFound) {
+				return fmt.Errorf("failed to delete route %q: %w", change.spec.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// routeDepth returns the number of ancestors of a declared route, checking
+// that its parents are declared and do not form a cycle.
+func routeDepth(name string, declared map[string]RouteSpec) (int, error) {
+	depth := 0
+	seen := map[string]bool{name: true}
+	for parent := declared[name].Parent; parent != ""; parent = declared[parent].Parent {
+		if _, ok := declared[parent]; !ok {
+			return 0, fmt.Errorf("%w: route %q: parent route %q is not declared", ErrBundleInvalid, name, parent)
+		}
+		if seen[parent] {
+			return 0, fmt.Errorf("%w: route %q: %v", ErrBundleInvalid, name, ErrRouteCycle)
+		}
+		seen[parent] = true
+		depth++
+	}
+	return depth, nil
+}
+
+// applyRuleSpec sets the declared fields of a rule.
+func applyRuleSpec(rule *entity.AlertRule, spec RuleSpec) {
+	rule.Description = spec.Description
+	rule.Condition = spec.Condition
+	rule.Severity = spec.Severity
+	rule.IsEnabled = spec.Enabled
+	rule.CooldownMinutes = spec.CooldownMinutes
+	rule.TeamID = spec.TeamID
+	rule.RunbookURL = spec.RunbookURL
+	rule.Remediation = spec.Remediation
+	rule.CreateTicket = spec.CreateTicket
+}
+
+// sameRule reports whether two rules have the same declared fields.
+func sameRule(a, b *entity.AlertRule) bool {
+	return a.Description == b.Description &&
+		a.Condition.Equal(b.Condition) &&
+		a.Severity == b.Severity &&
+		a.IsEnabled == b.IsEnabled &&
+		a.CooldownMinutes == b.CooldownMinutes &&
+		sameID(a.TeamID, b.TeamID) &&
+		a.RunbookURL == b.RunbookURL &&
+		a.Remediation == b.Remediation &&
+		a.CreateTicket == b.CreateTicket
+}
+
+// sameRoute reports whether two routes have the same declared fields.
+func sameRoute(a, b *entity.NotificationRoute) bool {
+	return sameID(a.ParentID, b.ParentID) &&
+		a.Position == b.Position &&
+		a.Continue == b.Continue &&
+		sameStrings(a.Channels, b.Channels) &&
+		sameStrings(a.Match.Sources, b.Match.Sources) &&
+		sameSeverities(a.Match.Severities, b.Match.Severities) &&
+		sameLabels(a.Match.Labels, b.Match.Labels) &&
+		sameID(a.Match.TeamID, b.Match.TeamID)
+}
+
+func sameID(a, b *entity.ID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameSeverities(a, b []entity.AlertSeverity) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameLabels(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
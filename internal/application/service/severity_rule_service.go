@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Severity rule errors.
+var (
+	ErrSeverityRuleNotFound = errors.New("severity rule not found")
+	ErrSeverityRuleExists   = errors.New("severity rule with this name already exists")
+)
+
+// Bounds of the in-memory policy applied to new alerts.
+const (
+	// severityPolicyRefreshInterval bounds how long rules changed on other
+	// instances take to apply here.
+	severityPolicyRefreshInterval = 30 * time.Second
+	severityPolicyLoadTimeout     = 2 * time.Second
+)
+
+// SeverityRuleInput holds the settings of a new severity rule.
+type SeverityRuleInput struct {
+	Name     string
+	Position int
+	Match    entity.SeverityRuleMatcher
+	Severity entity.AlertSeverity
+}
+
+// SeverityRuleUpdate holds the changes to a severity rule; nil fields are
+// left unchanged.
+type SeverityRuleUpdate struct {
+	Name      *string
+	Position  *int
+	Match     *entity.SeverityRuleMatcher
+	Severity  *entity.AlertSeverity
+	IsEnabled *bool
+}
+
+// SeverityRuleSample is an alert evaluated against the severity rules
+// without being created.
+type SeverityRuleSample struct {
+	Title    string
+	Source   string
+	Severity entity.AlertSeverity
+	Labels   map[string]string
+}
+
+// SeverityRuleEvaluation is the outcome of evaluating a sample alert: the
+// rule that applies to it, if any, and the severity it would be given.
+type SeverityRuleEvaluation struct {
+	Rule     *entity.SeverityRule
+	Severity entity.AlertSeverity
+}
+
+// SeverityRuleService manages the severity rules and re-classifies the
+// severity of new alerts with them.
+type SeverityRuleService struct {
+	ruleRepo repository.SeverityRuleRepository
+
+	mu       sync.Mutex
+	policy   valueobject.SeverityPolicy
+	loadedAt time.Time
+}
+
+// NewSeverityRuleService creates a new severity rule service.
+func NewSeverityRuleService(ruleRepo repository.SeverityRuleRepository) *SeverityRuleService {
+	return &SeverityRuleService{ruleRepo: ruleRepo}
+}
+
+// Create saves a new, enabled severity rule.
+func (s *SeverityRuleService) Create(ctx context.Context, input SeverityRuleInput, createdBy *entity.ID) (*entity.SeverityRule, error) {
+	rule, err := entity.NewSeverityRule(input.Name, input.Match, input.Severity, input.Position, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, ErrSeverityRuleExists
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return rule, nil
+}
+
+// Get retrieves a severity rule by ID.
+func (s *SeverityRuleService) Get(ctx context.Context, id entity.ID) (*entity.SeverityRule, error) {
+	rule, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSeverityRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// List retrieves every severity rule in evaluation order.
+func (s *SeverityRuleService) List(ctx context.Context) ([]*entity.SeverityRule, error) {
+	return s.ruleRepo.List(ctx)
+}
+
+// Update changes a severity rule.
+func (s *SeverityRuleService) Update(ctx context.Context, id entity.ID, update SeverityRuleUpdate) (*entity.SeverityRule, error) {
+	rule, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		rule.Name = *update.Name
+	}
+	if update.Position != nil {
+		rule.Position = *update.Position
+	}
+	if update.Match != nil {
+		rule.Match = *update.Match
+	}
+	if update.Severity != nil {
+		rule.Severity = *update.Severity
+	}
+	if update.IsEnabled != nil {
+		rule.IsEnabled = *update.IsEnabled
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	rule.Touch()
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrSeverityRuleNotFound
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrSeverityRuleExists
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return rule, nil
+}
+
+// Delete removes a severity rule.
+func (s *SeverityRuleService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSeverityRuleNotFound
+		}
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// DryRun evaluates a sample alert against the current rules, loaded afresh,
+// and reports the severity it would be given if it were ingested.
+func (s *SeverityRuleService) DryRun(ctx context.Context, sample SeverityRuleSample) (*SeverityRuleEvaluation, error) {
+	rules, err := s.ruleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alert := &entity.Alert{
+		Title:    sample.Title,
+		Source:   sample.Source,
+		Severity: sample.Severity,
+	}
+	if len(sample.Labels) > 0 {
+		alert.Metadata = map[string]interface{}{entity.MetadataLabels: sample.Labels}
+	}
+
+	evaluation := &SeverityRuleEvaluation{Severity: sample.Severity}
+	if rule := valueobject.NewSeverityPolicy(rules).Match(alert); rule != nil {
+		evaluation.Rule = rule
+		evaluation.Severity = rule.Severity
+	}
+
+	return evaluation, nil
+}
+
+// Classify gives a new alert the severity of the first rule matching it.
+// Rules are matched against an in-memory policy, reloaded every 30 seconds
+// and whenever this instance changes a rule; if it cannot be reloaded, the
+// previous policy is used.
+func (s *SeverityRuleService) Classify(_ context.Context, alert *entity.Alert) {
+	rule := s.cachedPolicy().Match(alert)
+	if rule == nil || rule.Severity == alert.Severity {
+		return
+	}
+
+	metrics.AlertsReclassifiedTotal.WithLabelValues(string(alert.Severity), string(rule.Severity)).Inc()
+	log.Debug().
+		Str("alert_id", alert.ID.String()).
+		Str("rule", rule.Name).
+		Str("from", string(alert.Severity)).
+		Str("to", string(rule.Severity)).
+		Msg("Alert severity re-classified")
+
+	alert.Reclassify(rule.Severity, rule.Name)
+}
+
+// cachedPolicy returns the in-memory policy, reloading it when stale.
+func (s *SeverityRuleService) cachedPolicy() valueobject.SeverityPolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.loadedAt) < severityPolicyRefreshInterval {
+		return s.policy
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), severityPolicyLoadTimeout)
+	defer cancel()
+
+	rules, err := s.ruleRepo.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load severity rules")
+		return s.policy
+	}
+
+	s.policy = valueobject.NewSeverityPolicy(rules)
+	s.loadedAt = time.Now()
+	return s.policy
+}
+
+// invalidate makes the next classification reload the rules.
+func (s *SeverityRuleService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// SLA errors.
+var (
+	ErrSLAPolicyNotFound     = errors.New("SLA policy not found")
+	ErrSLAPolicyExists       = errors.New("SLA policy with this name already exists")
+	ErrSLAReportRangeInvalid = errors.New("SLA report range must end after it starts and span at most 366 days")
+)
+
+// slaBreachBatchSize caps the breaches of a policy target raised per check;
+// the rest are raised by the next checks.
+const slaBreachBatchSize = 100
+
+// maxSLAReportRange bounds the period of SLA reports.
+const maxSLAReportRange = 366 * 24 * time.Hour
+
+// SLAPolicyInput holds the settings of a new SLA policy.
+type SLAPolicyInput struct {
+	Name                 string
+	Severity             entity.AlertSeverity
+	TeamID               *entity.ID
+	Source               string
+	AckWithinSeconds     int
+	ResolveWithinSeconds int
+	BreachSeverity       entity.AlertSeverity
+}
+
+// SLAPolicyUpdate holds the changes to an SLA policy; nil fields are left
+// unchanged, and empty severity or source filters are cleared.
+type SLAPolicyUpdate struct {
+	Name                 *string
+	Severity             *entity.AlertSeverity
+	Source               *string
+	AckWithinSeconds     *int
+	ResolveWithinSeconds *int
+	BreachSeverity       *entity.AlertSeverity
+	IsEnabled            *bool
+}
+
+// SLAReport is the compliance of the alerts of a period with a policy.
+type SLAReport struct {
+	Policy     *entity.SLAPolicy
+	Compliance *repository.SLACompliance
+}
+
+// SLAService manages SLA policies, raises an alert when an alert misses a
+// target of a policy, and reports how alerts met the targets.
+type SLAService struct {
+	slaRepo      repository.SLARepository
+	alertService *AlertService
+}
+
+// NewSLAService creates a new SLA service.
+func NewSLAService(slaRepo repository.SLARepository, alertService *AlertService) *SLAService {
+	return &SLAService{
+		slaRepo:      slaRepo,
+		alertService: alertService,
+	}
+}
+
+// Create creates an SLA policy. It applies to the alerts created from now on.
+func (s *SLAService) Create(ctx context.Context, input SLAPolicyInput, createdBy *entity.ID) (*entity.SLAPolicy, error) {
+	policy, err := entity.NewSLAPolicy(input.Name, input.AckWithinSeconds, input.ResolveWithinSeconds,
+		input.BreachSeverity, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	policy.Severity = input.Severity
+	policy.TeamID = input.TeamID
+	policy.Source = input.Source
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.slaRepo.Create(ctx, policy); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrSLAPolicyExists
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// GetByID retrieves an SLA policy by ID.
+func (s *SLAService) GetByID(ctx context.Context, id entity.ID) (*entity.SLAPolicy, error) {
+	policy, err := s.slaRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSLAPolicyNotFound
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// List retrieves SLA policies.
+func (s *SLAService) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.SLAPolicy], error) {
+	return s.slaRepo.List(ctx, pagination)
+}
+
+// Update changes the settings of an SLA policy. Breaches already raised are
+// kept.
+func (s *SLAService) Update(ctx context.Context, id entity.ID, update SLAPolicyUpdate) (*entity.SLAPolicy, error) {
+	policy, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		policy.Name = *update.Name
+	}
+	if update.Severity != nil {
+		policy.Severity = *update.Severity
+	}
+	if update.Source != nil {
+		policy.Source = *update.Source
+	}
+	if update.AckWithinSeconds != nil {
+		policy.AckWithinSeconds = *update.AckWithinSeconds
+	}
+	if update.ResolveWithinSeconds != nil {
+		policy.ResolveWithinSeconds = *update.ResolveWithinSeconds
+	}
+	if update.BreachSeverity != nil {
+		policy.BreachSeverity = *update.BreachSeverity
+	}
+	if update.IsEnabled != nil {
+		policy.IsEnabled = *update.IsEnabled
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	policy.Touch()
+
+	if err := s.slaRepo.Update(ctx, policy); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrSLAPolicyNotFound
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrSLAPolicyExists
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Delete removes an SLA policy and its breaches. Open alerts raised for its
+// breaches are left for users to resolve.
+func (s *SLAService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.slaRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSLAPolicyNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// CheckBreaches raises an alert for every alert that missed a target of an
+// enabled policy since the last check, returning how many were raised.
+// Instances may check concurrently; the one recording a breach raises its
+// alert.
+func (s *SLAService) CheckBreaches(ctx context.Context) (int, error) {
+	policies, err := s.slaRepo.ListEnabled(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+	breached := 0
+	for _, policy := range policies {
+		for _, target := range policy.Targets() {
+			alerts, err := s.slaRepo.ListBreaching(ctx, policy, target, now, slaBreachBatchSize)
+			if err != nil {
+				return breached, err
+			}
+
+			for _, alert := range alerts {
+				raised, err := s.breach(ctx, policy, alert, target, now)
+				if err != nil {
+					log.Error().Err(err).
+						Str("sla_policy_id", policy.ID.String()).
+						Str("alert_id", alert.ID.String()).
+						Msg("Failed to raise SLA breach")
+					continue
+				}
+				if raised {
+					breached++
+				}
+			}
+		}
+	}
+
+	return breached, nil
+}
+
+// breach records that an alert missed a target of a policy and raises the
+// alert of the breach, unless another instance recorded it first.
+func (s *SLAService) breach(
+	ctx context.Context,
+	policy *entity.SLAPolicy,
+	alert *entity.Alert,
+	target entity.SLATarget,
+	now time.Time,
+) (bool, error) {
+	breach := entity.NewSLABreach(policy, alert, target, now)
+	recorded, err := s.slaRepo.RecordBreach(ctx, breach)
+	if err != nil || !recorded {
+		return false, err
+	}
+	metrics.SLABreachesTotal.WithLabelValues(string(target)).Inc()
+
+	log.Warn().
+		Str("sla_policy_id", policy.ID.String()).
+		Str("sla_policy", policy.Name).
+		Str("alert_id", alert.ID.String()).
+		Str("target", string(target)).
+		Time("due_at", breach.DueAt).
+		Msg("SLA breached")
+
+	_, _, err = s.alertService.CreateDeduplicated(ctx, breach.DedupKey(), slaBreachAlertInput(policy, alert, breach))
+	return err == nil, err
+}
+
+// Report measures how the alerts created within [from, to] met the targets
+// of the enabled policies, or of the given policy, counting the alerts the
+// caller may see. Policies owned by a team outside the scope are left out.
+func (s *SLAService) Report(
+	ctx context.Context,
+	policyID *entity.ID,
+	from, to time.Time,
+	role entity.UserRole,
+	tenant valueobject.TenantScope,
+) ([]SLAReport, error) {
+	if !to.After(from) || to.Sub(from) > maxSLAReportRange {
+		return nil, ErrSLAReportRangeInvalid
+	}
+
+	var policies []*entity.SLAPolicy
+	if policyID != nil {
+		policy, err := s.GetByID(ctx, *policyID)
+		if err != nil {
+			return nil, err
+		}
+		if !tenant.Allows(policy.TeamID) {
+			return nil, ErrSLAPolicyNotFound
+		}
+		policies = []*entity.SLAPolicy{policy}
+	} else {
+		enabled, err := s.slaRepo.ListEnabled(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, policy := range enabled {
+			if tenant.Allows(policy.TeamID) {
+				policies = append(policies, policy)
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	filter := s.alertService.VisibleFilter(role, tenant).WithDateRange(from, to)
+	reports := make([]SLAReport, 0, len(policies))
+	for _, policy := range policies {
+		compliance, err := s.slaRepo.Compliance(ctx, policy, now, filter)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, SLAReport{Policy: policy, Compliance: compliance})
+	}
+
+	return reports, nil
+}
+
+// slaBreachAlertInput describes the alert raised when an alert misses a
+// target. It belongs to the team of the alert.
+func slaBreachAlertInput(policy *entity.SLAPolicy, alert *entity.Alert, breach *entity.SLABreach) CreateAlertInput {
+	within, _ := policy.Within(breach.Target)
+	action := "acknowledged"
+	if breach.Target == entity.SLATargetResolve {
+		action = "resolved"
+	}
+
+	return CreateAlertInput{
+		Title: "SLA breached: " + policy.Name,
+		Message: fmt.Sprintf("Alert %q was not %s within %s, as %s requires; it was due at %s",
+			alert.Title, action, within, policy.Name, breach.DueAt.Format(time.RFC3339)),
+		Severity: policy.BreachSeverity,
+		Source:   entity.SLABreachSource,
+		Metadata: map[string]interface{}{
+			"sla_policy_id": policy.ID.String(),
+			"alert_id":      alert.ID.String(),
+			"target":        string(breach.Target),
+			"due_at":        breach.DueAt.Format(time.RFC3339),
+		},
+		TeamID: alert.TeamID,
+	}
+}
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// Slack action errors.
+var (
+	ErrSlackNotConfigured = errors.New("slack app signing secret is not configured")
+	ErrSlackUnknownAction = errors.New("unknown slack action")
+	ErrSlackForbidden     = errors.New("insufficient permissions")
+)
+
+// Slack request signing.
+const (
+	// slackSignatureVersion is the version of the signing scheme, prefixed to
+	// the signed base string and to the signature.
+	slackSignatureVersion = "v0"
+	// slackSignatureTolerance is the maximum age of a signed request.
+	slackSignatureTolerance = 5 * time.Minute
+	// slackResponseURLPrefix is the prefix of the URLs Slack accepts
+	// interaction replies on.
+	slackResponseURLPrefix = "https://hooks.slack.com/"
+)
+
+// SlackResponder replies to a Slack interaction through its response URL.
+type SlackResponder interface {
+	Respond(ctx context.Context, responseURL, text string) error
+}
+
+// SlackAction is a click on a button of an alert message.
+type SlackAction struct {
+	// UserID is the Slack ID of the user who clicked.
+	UserID string
+	// ActionID identifies the button, e.g. notification.ActionAcknowledge.
+	ActionID string
+	// AlertID is the value of the button.
+	AlertID string
+	// ResponseURL is where the result is replied to the user.
+	ResponseURL string
+}
+
+// SlackCommand is an "/alerts" slash command typed by a Slack user.
+type SlackCommand struct {
+	// UserID is the Slack ID of the user who typed the command.
+	UserID string
+	// Text is what follows the command, e.g. "ack <id>".
+	Text string
+}
+
+// Slash command bounds.
+const (
+	// slackListLimit is the number of alerts listed by "/alerts list".
+	slackListLimit = 10
+)
+
+// slackCommandUsage is replied to unknown or malformed commands.
+const slackCommandUsage = "Usage:\n" +
+	"`/alerts list` lists the open alerts\n" +
+	"`/alerts ack <alert-id>` acknowledges an alert\n" +
+	"`/alerts resolve <alert-id>` resolves an alert\n" +
+	"`/alerts silence <source> <duration>` suppresses the notifications of a source, e.g. `2h` or `1d`"
+
+// SlackService performs the alert actions of Slack message buttons and
+// slash commands on behalf of the users linked to the Slack accounts.
+//
+// Slack signs each request with the signing secret of the app: the signature
+// is "v0=" followed by the hex HMAC-SHA256 of "v0:<timestamp>:<body>".
+type SlackService struct {
+	identityService *IdentityService
+	teamService     *TeamService
+	alertService    *AlertService
+	maintenance     *MaintenanceService
+	responder       SlackResponder
+	signingSecret   string
+}
+
+// NewSlackService creates a new Slack service. The responder may be nil, in
+// which case results are not replied to users.
+func NewSlackService(
+	cfg config.SlackConfig,
+	identityService *IdentityService,
+	teamService *TeamService,
+	alertService *AlertService,
+	maintenance *MaintenanceService,
+	responder SlackResponder,
+) *SlackService {
+	return &SlackService{
+		identityService: identityService,
+		teamService:     teamService,
+		alertService:    alertService,
+		maintenance:     maintenance,
+		responder:       responder,
+		signingSecret:   cfg.SigningSecret,
+	}
+}
+
+// Verify checks the signature of a request received from Slack.
+func (s *SlackService) Verify(timestamp, signature string, body []byte) error {
+	if s.signingSecret == "" {
+		return ErrSlackNotConfigured
+	}
+
+	if timestamp == "" || signature == "" {
+		return ErrWebhookSignatureMissing
+	}
+
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookTimestampStale
+	}
+
+	skew := time.Since(time.Unix(sentAt, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > slackSignatureTolerance {
+		return ErrWebhookTimestampStale
+	}
+
+	expected := SignSlackRequest(s.signingSecret, timestamp, body)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrWebhookSignatureInvalid
+	}
+
+	return nil
+}
+
+// HandleAction acknowledges or resolves an alert on behalf of the user linked
+// to the Slack account that clicked, and replies the result to that user.
+// Actions follow the rules of the HTTP endpoints: the user must be an
+// operator or admin, and may only change alerts they can see.
+func (s *SlackService) HandleAction(ctx context.Context, action SlackAction) (*entity.Alert, error) {
+	alert, err := s.performAction(ctx, action)
+	s.reply(ctx, action, slackActionReply(action.ActionID, alert, err))
+	return alert, err
+}
+
+// HandleCommand runs a slash command on behalf of the user linked to the
+// Slack account that typed it, and returns the reply. Listing alerts needs a
+// linked user; changing them or silencing a source needs an operator or
+// admin. The error, if any, is already described by the reply.
+func (s *SlackService) HandleCommand(ctx context.Context, cmd SlackCommand) (string, error) {
+	args := strings.Fields(cmd.Text)
+	if len(args) == 0 {
+		return slackCommandUsage, nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "list":
+		return s.listCommand(ctx, cmd.UserID)
+	case "ack", "acknowledge":
+		if len(args) != 2 {
+			return slackCommandUsage, nil
+		}
+		return s.transitionCommand(ctx, cmd.UserID, notification.ActionAcknowledge, args[1])
+	case "resolve":
+		if len(args) != 2 {
+			return slackCommandUsage, nil
+		}
+		return s.transitionCommand(ctx, cmd.UserID, notification.ActionResolve, args[1])
+	case "silence":
+		if len(args) < 3 {
+			return slackCommandUsage, nil
+		}
+		return s.silenceCommand(ctx, cmd.UserID, strings.Join(args[1:len(args)-1], " "), args[len(args)-1])
+	}
+
+	return slackCommandUsage, nil
+}
+
+// listCommand replies the most recent open alerts the user can see.
+func (s *SlackService) listCommand(ctx context.Context, slackUserID string) (string, error) {
+	user, err := s.identityService.Resolve(ctx, entity.IdentityProviderSlack, slackUserID)
+	if err != nil {
+		return slackErrorReply(err), err
+	}
+
+	tenant, err := s.teamService.Scope(ctx, user.ID, user.Role)
+	if err != nil {
+		return slackErrorReply(err), err
+	}
+
+	alerts, err := s.alertService.List(ctx, ListInput{
+		Filter:     valueobject.NewAlertFilter().WithStatuses(entity.AlertStatusActive, entity.AlertStatusAcknowledged),
+		Pagination: valueobject.NewPagination(1, slackListLimit),
+		Role:       user.Role,
+		Tenant:     tenant,
+	})
+	if err != nil {
+		return slackErrorReply(err), err
+	}
+
+	return slackAlertList(alerts.Items, alerts.TotalItems), nil
+}
+
+// transitionCommand acknowledges or resolves an alert for the user.
+func (s *SlackService) transitionCommand(ctx context.Context, slackUserID, actionID, alertID string) (string, error) {
+	user, err := s.operator(ctx, slackUserID)
+	if err != nil {
+		return slackErrorReply(err), err
+	}
+
+	alert, err := s.transition(ctx, user, actionID, alertID)
+	if err != nil {
+		return slackErrorReply(err), err
+	}
+
+	return slackTransitionReply(actionID, alert), nil
+}
+
+// silenceCommand suppresses the notifications of a source for a duration.
+func (s *SlackService) silenceCommand(ctx context.Context, slackUserID, source, rawDuration string) (string, error) {
+	duration, ok := parseSilenceDuration(rawDuration)
+	if !ok {
+		return slackCommandUsage, nil
+	}
+
+	user, err := s.operator(ctx, slackUserID)
+	if err != nil {
+		return slackErrorReply(err), err
+	}
+
+	window, err := s.maintenance.Silence(ctx, source, duration, user)
+	if err != nil {
+		if errors.Is(err, entity.ErrMaintenanceInvalidPeriod) {
+			return "Silences last at most 30 days", err
+		}
+		return slackErrorReply(err), err
+	}
+
+	return fmt.Sprintf(":no_bell: Notifications of *%s* are silenced until %s", source, slackDate(window.EndsAt)), nil
+}
+
+// performAction checks the linked user may run the action and runs it.
+func (s *SlackService) performAction(ctx context.Context, action SlackAction) (*entity.Alert, error) {
+	if action.ActionID != notification.ActionAcknowledge && action.ActionID != notification.ActionResolve {
+		return nil, ErrSlackUnknownAction
+	}
+
+	user, err := s.operator(ctx, action.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.transition(ctx, user, action.ActionID, action.AlertID)
+}
+
+// operator returns the active user linked to a Slack account, who must be an
+// operator or admin.
+func (s *SlackService) operator(ctx context.Context, slackUserID string) (*entity.User, error) {
+	user, err := s.identityService.Resolve(ctx, entity.IdentityProviderSlack, slackUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CanManageAlerts() {
+		return nil, ErrSlackForbidden
+	}
+	return user, nil
+}
+
+// transition acknowledges or resolves an alert on behalf of a user.
+func (s *SlackService) transition(ctx context.Context, user *entity.User, actionID, rawAlertID string) (*entity.Alert, error) {
+	alertID, err := entity.ParseID(rawAlertID)
+	if err != nil {
+		return nil, ErrAlertNotFound
+	}
+
+	tenant, err := s.teamService.Scope(ctx, user.ID, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Alerts hidden from the user's role or teams cannot be changed either
+	if _, err := s.alertService.GetVisible(ctx, alertID, user.Role, tenant); err != nil {
+		return nil, err
+	}
+
+	if actionID == notification.ActionResolve {
+		return s.alertService.Resolve(ctx, alertID, user.ID)
+	}
+	return s.alertService.Acknowledge(ctx, alertID, user.ID)
+}
+
+// reply sends the result of an action to the user who clicked. Replies are
+// only sent to Slack's own response URLs.
+func (s *SlackService) reply(ctx context.Context, action SlackAction, text string) {
+	if s.responder == nil || !strings.HasPrefix(action.ResponseURL, slackResponseURLPrefix) {
+		return
+	}
+
+	if err := s.responder.Respond(ctx, action.ResponseURL, text); err != nil {
+		log.Warn().Err(err).Str("action_id", action.ActionID).Msg("Failed to reply to Slack action")
+	}
+}
+
+// slackActionReply returns the text replied for the result of an action.
+func slackActionReply(actionID string, alert *entity.Alert, err error) string {
+	if err != nil {
+		return slackErrorReply(err)
+	}
+	return slackTransitionReply(actionID, alert)
+}
+
+// slackTransitionReply returns the text replied when an alert was changed.
+func slackTransitionReply(actionID string, alert *entity.Alert) string {
+	if actionID == notification.ActionResolve {
+		return ":white_check_mark: You resolved *" + alert.Title + "*"
+	}
+	return ":eyes: You acknowledged *" + alert.Title + "*"
+}
+
+// slackErrorReply returns the text replied when an action or command failed.
+func slackErrorReply(err error) string {
+	switch {
+	case errors.Is(err, ErrIdentityNotLinked):
+		return "Your Slack account is not linked to an active user; ask an admin to link it"
+	case errors.Is(err, ErrSlackForbidden):
+		return "Only operators and admins can change alerts"
+	case errors.Is(err, ErrSlackUnknownAction):
+		return "This action is not supported"
+	case errors.Is(err, ErrAlertNotFound):
+		return "Alert not found"
+	case errors.Is(err, entity.ErrAlertAlreadyAcknowledged):
+		return "Alert is already acknowledged"
+	case errors.Is(err, entity.ErrAlertAlreadyResolved):
+		return "Alert is already resolved"
+	default:
+		return "The command failed, please try again"
+	}
+}
+
+// slackAlertList formats open alerts, most recent first, with their ID to
+// act on them.
+func slackAlertList(alerts []*entity.Alert, total int64) string {
+	if len(alerts) == 0 {
+		return ":white_check_mark: No open alerts"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d open alerts*", total)
+	if total > int64(len(alerts)) {
+		fmt.Fprintf(&b, " (the %d most recent)", len(alerts))
+	}
+	for _, alert := range alerts {
+		fmt.Fprintf(&b, "\n%s *%s* | %s | %s since %s\n`%s`",
+			slackSeverityEmoji(alert.Severity), alert.Title, alert.Source, alert.Status,
+			slackDate(alert.CreatedAt), alert.ID)
+	}
+	return b.String()
+}
+
+// slackSeverityEmoji maps an alert severity to the emoji shown in lists.
+func slackSeverityEmoji(severity entity.AlertSeverity) string {
+	switch severity {
+	case entity.AlertSeverityCritical:
+		return ":red_circle:"
+	case entity.AlertSeverityHigh:
+		return ":large_orange_circle:"
+	case entity.AlertSeverityMedium:
+		return ":large_yellow_circle:"
+	case entity.AlertSeverityLow:
+		return ":large_blue_circle:"
+	default:
+		return ":white_circle:"
+	}
+}
+
+// slackDate formats a time that Slack shows in the reader's time zone.
+func slackDate(t time.Time) string {
+	return fmt.Sprintf("<!date^%d^{date_short_pretty} {time}|%s>", t.Unix(), t.UTC().Format(time.RFC3339))
+}
+
+// parseSilenceDuration parses the duration of a silence, a Go duration such
+// as "90m" or "2h", or a number of days such as "1d".
+func parseSilenceDuration(raw string) (time.Duration, bool) {
+	if days, found := strings.CutSuffix(raw, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, false
+		}
+		return time.Duration(n) * 24 * time.Hour, true
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil || duration <= 0 {
+		return 0, false
+	}
+	return duration, true
+}
+
+// SignSlackRequest returns the signature Slack sends with a request:
+// "v0=" followed by the hex HMAC-SHA256 of "v0:<timestamp>:<body>".
+func SignSlackRequest(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(slackSignatureVersion + ":" + timestamp + ":"))
+	mac.Write(body)
+	return slackSignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
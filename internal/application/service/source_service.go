@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Source errors.
+var (
+	ErrSourceNotFound = errors.New("source not found")
+	ErrSourceExists   = errors.New("source with this name already exists")
+)
+
+// SilentSourceAlertSource is the source of the alerts raised for silent sources.
+const SilentSourceAlertSource = "source-monitor"
+
+// SourceInput holds the settings of a new source.
+type SourceInput struct {
+	Name                     string
+	Type                     entity.SourceType
+	Description              string
+	ExpectedHeartbeatSeconds int
+	Severity                 entity.AlertSeverity
+	TeamID                   *entity.ID
+}
+
+// SourceUpdate holds the changes to a source; nil fields are left unchanged.
+type SourceUpdate struct {
+	Name                     *string
+	Type                     *entity.SourceType
+	Description              *string
+	ExpectedHeartbeatSeconds *int
+	Severity                 *entity.AlertSeverity
+}
+
+// SourceHealth is a source with the activity of its alerts.
+type SourceHealth struct {
+	Source   *entity.Source
+	Activity repository.SourceActivity
+}
+
+// SourceService manages the registry of sources, reports their activity and
+// raises an alert when a source expected to report goes silent, resolved
+// once it reports again.
+type SourceService struct {
+	sourceRepo   repository.SourceRepository
+	alertService *AlertService
+}
+
+// NewSourceService creates a new source service.
+func NewSourceService(sourceRepo repository.SourceRepository, alertService *AlertService) *SourceService {
+	return &SourceService{
+		sourceRepo:   sourceRepo,
+		alertService: alertService,
+	}
+}
+
+// Create registers a source.
+func (s *SourceService) Create(ctx context.Context, input SourceInput, createdBy *entity.ID) (*entity.Source, error) {
+	source, err := entity.NewSource(input.Name, input.Type, input.ExpectedHeartbeatSeconds, input.Severity, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	source.Description = input.Description
+	source.TeamID = input.TeamID
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.sourceRepo.Create(ctx, source); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrSourceExists
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// Get retrieves a source the tenant scope may see, with its activity.
+// Sources of other teams are reported as not found.
+func (s *SourceService) Get(ctx context.Context, id entity.ID, tenant valueobject.TenantScope) (*SourceHealth, error) {
+	source, err := s.getVisible(ctx, id, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.sourceRepo.Activity(ctx, []string{source.Name}, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return &SourceHealth{Source: source, Activity: activity[source.Name]}, nil
+}
+
+// List retrieves the sources of the tenant scope with their activity.
+func (s *SourceService) List(
+	ctx context.Context,
+	tenant valueobject.TenantScope,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[SourceHealth], error) {
+	sources, err := s.sourceRepo.List(ctx, tenant, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(sources.Items))
+	for i, source := range sources.Items {
+		names[i] = source.Name
+	}
+
+	activity, err := s.sourceRepo.Activity(ctx, names, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	health := make([]SourceHealth, len(sources.Items))
+	for i, source := range sources.Items {
+		health[i] = SourceHealth{Source: source, Activity: activity[source.Name]}
+	}
+
+	result := valueobject.NewPaginatedResult(health, sources.TotalItems, pagination)
+	return &result, nil
+}
+
+// Update changes the settings of a source. A silent source that is no longer
+// expected to report is reporting again, and its alert is resolved.
+func (s *SourceService) Update(ctx context.Context, id entity.ID, update SourceUpdate) (*entity.Source, error) {
+	source, err := s.getVisible(ctx, id, valueobject.AllTenants())
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		source.Name = *update.Name
+	}
+	if update.Type != nil {
+		source.Type = *update.Type
+	}
+	if update.Description != nil {
+		source.Description = *update.Description
+	}
+	if update.ExpectedHeartbeatSeconds != nil {
+		source.ExpectedHeartbeatSeconds = *update.ExpectedHeartbeatSeconds
+	}
+	if update.Severity != nil {
+		source.Severity = *update.Severity
+	}
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	recovered := source.Status == entity.SourceStatusSilent && !source.IsMonitored()
+	if recovered {
+		source.MarkReporting()
+	}
+	source.Touch()
+
+	if err := s.sourceRepo.Update(ctx, source); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrSourceNotFound
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrSourceExists
+		}
+		return nil, err
+	}
+
+	if recovered {
+		s.resolveSilence(ctx, source)
+	}
+
+	return source, nil
+}
+
+// Delete removes a source from the registry. An open alert for its silence
+// is left for users to resolve.
+func (s *SourceService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.sourceRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSourceNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// CheckSilent raises an alert for each source expected to report that sent
+// no alert within its expected heartbeat, and resolves the alert of those
+// that report again. Returns how many sources went silent.
+func (s *SourceService) CheckSilent(ctx context.Context) (int, error) {
+	sources, err := s.sourceRepo.ListMonitored(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(sources) == 0 {
+		return 0, nil
+	}
+
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+
+	now := time.Now().UTC()
+	activity, err := s.sourceRepo.Activity(ctx, names, now)
+	if err != nil {
+		return 0, err
+	}
+
+	silent := 0
+	for _, source := range sources {
+		lastAlertAt := activity[source.Name].LastAlertAt
+		isSilent := source.IsSilent(lastAlertAt, now)
+
+		switch {
+		case isSilent && source.Status == entity.SourceStatusReporting:
+			if err := s.markSilent(ctx, source, lastAlertAt, now); err != nil {
+				log.Error().Err(err).Str("source_id", source.ID.String()).Msg("Failed to mark source silent")
+				continue
+			}
+			silent++
+		case !isSilent && source.Status == entity.SourceStatusSilent:
+			source.MarkReporting()
+			if err := s.sourceRepo.Update(ctx, source); err != nil {
+				log.Error().Err(err).Str("source_id", source.ID.String()).Msg("Failed to mark source reporting")
+				continue
+			}
+			log.Info().Str("source", source.Name).Msg("Source reports again")
+			s.resolveSilence(ctx, source)
+		}
+	}
+
+	return silent, nil
+}
+
+// markSilent marks a source silent and raises the alert of its silence.
+func (s *SourceService) markSilent(ctx context.Context, source *entity.Source, lastAlertAt *time.Time, now time.Time) error {
+	source.MarkSilent(now)
+	if err := s.sourceRepo.Update(ctx, source); err != nil {
+		return err
+	}
+	metrics.SourcesSilentTotal.Inc()
+
+	log.Warn().
+		Str("source_id", source.ID.String()).
+		Str("source", source.Name).
+		Msg("Source went silent")
+
+	_, _, err := s.alertService.CreateDeduplicated(ctx, source.DedupKey(), silentSourceAlertInput(source, lastAlertAt))
+	return err
+}
+
+// resolveSilence resolves the alert raised when a source went silent.
+func (s *SourceService) resolveSilence(ctx context.Context, source *entity.Source) {
+	if _, err := s.alertService.ResolveByDedupKey(ctx, source.DedupKey()); err != nil &&
+		!errors.Is(err, ErrAlertNotFound) && !errors.Is(err, entity.ErrAlertAlreadyResolved) {
+		log.Error().Err(err).Str("source_id", source.ID.String()).Msg("Failed to resolve silent source alert")
+	}
+}
+
+// getVisible retrieves a source the tenant scope may see.
+func (s *SourceService) getVisible(ctx context.Context, id entity.ID, tenant valueobject.TenantScope) (*entity.Source, error) {
+	source, err := s.sourceRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSourceNotFound
+		}
+		return nil, err
+	}
+	if !tenant.Allows(source.TeamID) {
+		return nil, ErrSourceNotFound
+	}
+	return source, nil
+}
+
+// silentSourceAlertInput describes the alert raised for a silent source.
+func silentSourceAlertInput(source *entity.Source, lastAlertAt *time.Time) CreateAlertInput {
+	heartbeat := time.Duration(source.ExpectedHeartbeatSeconds) * time.Second
+	metadata := map[string]interface{}{
+		"source_id":   source.ID.String(),
+		"source_name": source.Name,
+	}
+
+	message := fmt.Sprintf("%s has sent no alert since it was registered; expected at least one every %s",
+		source.Name, heartbeat)
+	if lastAlertAt != nil {
+		message = fmt.Sprintf("%s has sent no alert since %s; expected at least one every %s",
+			source.Name, lastAlertAt.Format(time.RFC3339), heartbeat)
+		metadata["last_alert_at"] = lastAlertAt.Format(time.RFC3339)
+	}
+
+	return CreateAlertInput{
+		Title:    "Source silent: " + source.Name,
+		Message:  message,
+		Severity: source.Severity,
+		Source:   SilentSourceAlertSource,
+		Metadata: metadata,
+		TeamID:   source.TeamID,
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Status page errors.
+var (
+	ErrStatusPageNotConfigured     = errors.New("status page is not configured")
+	ErrStatusPageComponentNotFound = errors.New("status page component not found")
+	ErrStatusPageUpdateFailed      = errors.New("status page update failed")
+)
+
+// statusPageSeverities are the severities looked for among the unresolved
+// alerts of a component, most severe first.
+var statusPageSeverities = []entity.AlertSeverity{
+	entity.AlertSeverityCritical,
+	entity.AlertSeverityHigh,
+	entity.AlertSeverityMedium,
+}
+
+// StatusPageService keeps the components of a public status page in line
+// with the unresolved alerts of the sources mapped to them. Admins can
+// override the status of a component, for example during an incident
+// communicated by hand; overrides are stored in Redis and shared by every
+// instance, and hold until removed.
+type StatusPageService struct {
+	alertRepo  repository.AlertRepository
+	cacheRepo  repository.CacheRepository
+	page       notification.StatusPage
+	components valueobject.StatusPageComponents
+	pageID     string
+}
+
+// NewStatusPageService creates a new status page service. PageID scopes the
+// overrides so that deployments sharing a Redis do not affect each other.
+func NewStatusPageService(
+	alertRepo repository.AlertRepository,
+	cacheRepo repository.CacheRepository,
+	page notification.StatusPage,
+	components valueobject.StatusPageComponents,
+	pageID string,
+) *StatusPageService {
+	return &StatusPageService{
+		alertRepo:  alertRepo,
+		cacheRepo:  cacheRepo,
+		page:       page,
+		components: components,
+		pageID:     pageID,
+	}
+}
+
+// IsEnabled reports whether components are synced.
+func (s *StatusPageService) IsEnabled() bool {
+	return s.page != nil && s.page.IsEnabled() && !s.components.IsEmpty()
+}
+
+// List returns the state of every mapped component.
+func (s *StatusPageService) List(ctx context.Context) ([]valueobject.StatusPageComponent, error) {
+	if !s.IsEnabled() {
+		return nil, ErrStatusPageNotConfigured
+	}
+
+	ids := s.components.Components()
+	components := make([]valueobject.StatusPageComponent, 0, len(ids))
+	for _, id := range ids {
+		component, err := s.evaluate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+// SyncSource publishes the status of the component a source affects, if any.
+func (s *StatusPageService) SyncSource(ctx context.Context, source string) error {
+	id, ok := s.components.ComponentOf(source)
+	if !ok {
+		return nil
+	}
+
+	_, err := s.sync(ctx, id)
+	return err
+}
+
+// SyncAll publishes the status of every mapped component. Components are all
+// tried; the error reports those that failed.
+func (s *StatusPageService) SyncAll(ctx context.Context) error {
+	var errs []error
+	for _, id := range s.components.Components() {
+		if _, err := s.sync(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SetOverride overrides the status of a component and publishes it.
+func (s *StatusPageService) SetOverride(
+	ctx context.Context,
+	id string,
+	status string,
+) (valueobject.StatusPageComponent, error) {
+	if err := s.check(id); err != nil {
+		return valueobject.StatusPageComponent{}, err
+	}
+
+	override, err := valueobject.ParseComponentStatus(status)
+	if err != nil {
+		return valueobject.StatusPageComponent{}, err
+	}
+
+	if err := s.cacheRepo.Set(ctx, s.overrideKey(id), string(override), 0); err != nil {
+		return valueobject.StatusPageComponent{}, err
+	}
+
+	log.Info().
+		Str("component", id).
+		Str("status", string(override)).
+		Msg("Status page component overridden")
+
+	return s.sync(ctx, id)
+}
+
+// ClearOverride removes the override of a component, which follows its
+// alerts again, and publishes its status.
+func (s *StatusPageService) ClearOverride(ctx context.Context, id string) (valueobject.StatusPageComponent, error) {
+	if err := s.check(id); err != nil {
+		return valueobject.StatusPageComponent{}, err
+	}
+
+	if err := s.cacheRepo.Delete(ctx, s.overrideKey(id)); err != nil {
+		return valueobject.StatusPageComponent{}, err
+	}
+
+	log.Info().Str("component", id).Msg("Status page component override removed")
+
+	return s.sync(ctx, id)
+}
+
+// sync evaluates a component and publishes its status.
+func (s *StatusPageService) sync(ctx context.Context, id string) (valueobject.StatusPageComponent, error) {
+	component, err := s.evaluate(ctx, id)
+	if err != nil {
+		return component, err
+	}
+
+	if err := s.page.SetComponentStatus(ctx, id, string(component.Status)); err != nil {
+		return component, fmt.Errorf("%w: component %s: %v", ErrStatusPageUpdateFailed, id, err)
+	}
+	return component, nil
+}
+
+// evaluate derives the status of a component from the most severe
+// unresolved alert of its sources, and applies its override.
+func (s *StatusPageService) evaluate(ctx context.Context, id string) (valueobject.StatusPageComponent, error) {
+	sources := s.components.Sources(id)
+
+	severity, err := s.highestSeverity(ctx, sources)
+	if err != nil {
+		return valueobject.StatusPageComponent{}, err
+	}
+
+	return valueobject.ResolveComponent(id, sources, valueobject.ComponentStatusFor(severity), s.override(ctx, id)), nil
+}
+
+// highestSeverity returns the severity of the most severe active or
+// acknowledged alert of the sources that affects the status of a component,
+// or "" if there is none.
+func (s *StatusPageService) highestSeverity(ctx context.Context, sources []string) (entity.AlertSeverity, error) {
+	for _, severity := range statusPageSeverities {
+		for _, source := range sources {
+			filter := valueobject.NewAlertFilter().
+				WithStatuses(entity.AlertStatusActive, entity.AlertStatusAcknowledged).
+				WithSeverities(severity).
+				WithSource(source)
+
+			result, err := s.alertRepo.List(ctx, filter, valueobject.NewPagination(1, 1))
+			if err != nil {
+				return "", err
+			}
+			if result.TotalItems > 0 {
+				return severity, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// override reads the override of a component; "" when unset or unreadable.
+func (s *StatusPageService) override(ctx context.Context, id string) valueobject.ComponentStatus {
+	var status string
+	if err := s.cacheRepo.Get(ctx, s.overrideKey(id), &status); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			log.Warn().Err(err).Str("component", id).Msg("Failed to read status page override")
+		}
+		return ""
+	}
+
+	override, err := valueobject.ParseComponentStatus(status)
+	if err != nil {
+		return ""
+	}
+	return override
+}
+
+// overrideKey returns the cache key of the override of a component.
+func (s *StatusPageService) overrideKey(id string) string {
+	return "statuspage:" + s.pageID + ":override:" + id
+}
+
+// check verifies that status pages are configured and a component is mapped.
+func (s *StatusPageService) check(id string) error {
+	if !s.IsEnabled() {
+		return ErrStatusPageNotConfigured
+	}
+	if s.components.Sources(id) == nil {
+		return ErrStatusPageComponentNotFound
+	}
+	return nil
+}
@@ -0,0 +1,483 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Subscription errors.
+var (
+	ErrSubscriptionNotFound          = errors.New("subscription not found")
+	ErrSubscriptionExists            = errors.New("subscription with this name already exists")
+	ErrSubscriptionDeliveryNotFound  = errors.New("delivery not found")
+	ErrSubscriptionDeliveryNotFailed = errors.New("only failed deliveries can be redelivered")
+)
+
+// Headers of the requests pushing events to subscriptions. The signature
+// is computed like that of inbound webhooks, see SignWebhookPayload.
+const (
+	SubscriptionEventHeader     = "X-Webhook-Event"
+	SubscriptionDeliveryHeader  = "X-Webhook-Delivery"
+	SubscriptionSignatureHeader = "X-Webhook-Signature"
+	SubscriptionTimestampHeader = "X-Webhook-Timestamp"
+)
+
+const (
+	// subscriptionUserAgent identifies the deliveries to the endpoints.
+	subscriptionUserAgent = "realtime-alerting-webhooks/1.0"
+	// subscriptionsRefreshInterval bounds how long subscriptions changed on
+	// other instances take to receive the events published here.
+	subscriptionsRefreshInterval = 30 * time.Second
+	subscriptionsLoadTimeout     = 2 * time.Second
+	// subscriptionClaimLease is the shortest time a claimed delivery is
+	// hidden from other instances while it is attempted.
+	subscriptionClaimLease = 5 * time.Minute
+)
+
+// WebhookPoster POSTs a body to an endpoint and returns the status of the
+// response. Errors are those of requests that got no response.
+type WebhookPoster interface {
+	Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
+
+// SubscriptionInput holds the settings of a new subscription. An empty
+// secret is generated.
+type SubscriptionInput struct {
+	Name       string
+	URL        string
+	Secret     string
+	EventTypes []string
+	Filter     entity.SubscriptionFilter
+}
+
+// SubscriptionUpdate holds the changes to a subscription; nil fields are left
+// unchanged. RotateSecret replaces the secret with Secret, or with a
+// generated one when Secret is empty.
+type SubscriptionUpdate struct {
+	Name         *string
+	URL          *string
+	EventTypes   []string
+	Filter       *entity.SubscriptionFilter
+	IsEnabled    *bool
+	RotateSecret bool
+	Secret       string
+}
+
+// SubscriptionEvent is an alert event to push to the subscriptions receiving
+// it. Events without a severity, such as deletions, carry no alert details
+// to filter on and are pushed only to subscriptions without a filter.
+type SubscriptionEvent struct {
+	Type       string
+	Severity   entity.AlertSeverity
+	Source     string
+	Labels     map[string]string
+	Data       interface{}
+	OccurredAt time.Time
+}
+
+// matches reports whether the event passes the filter of a subscription.
+func (e SubscriptionEvent) matches(filter entity.SubscriptionFilter) bool {
+	if e.Severity == "" {
+		return filter.IsEmpty()
+	}
+	return filter.Matches(e.Severity, e.Source, e.Labels)
+}
+
+// subscriptionEnvelope is the body POSTed to subscriptions. Its ID is shared
+// by the deliveries of an event to every subscription.
+type subscriptionEnvelope struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// SubscriptionService manages outbound webhook subscriptions, queues a
+// delivery of each alert event to the subscriptions receiving it, and
+// delivers them with retries. Deliveries refused max attempts times are
+// failed, and kept as the dead letters of their subscription until
+// redelivered or purged.
+type SubscriptionService struct {
+	subscriptionRepo repository.SubscriptionRepository
+	poster           WebhookPoster
+	cfg              config.OutboundWebhooksConfig
+
+	mu            sync.Mutex
+	subscriptions []*entity.Subscription
+	loadedAt      time.Time
+}
+
+// NewSubscriptionService creates a new subscription service.
+func NewSubscriptionService(
+	subscriptionRepo repository.SubscriptionRepository,
+	poster WebhookPoster,
+	cfg config.OutboundWebhooksConfig,
+) *SubscriptionService {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		cfg.MaxBackoff = cfg.InitialBackoff
+	}
+
+	return &SubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		poster:           poster,
+		cfg:              cfg,
+	}
+}
+
+// Create registers a subscription.
+func (s *SubscriptionService) Create(ctx context.Context, input SubscriptionInput, createdBy *entity.ID) (*entity.Subscription, error) {
+	secret := input.Secret
+	if secret == "" {
+		generated, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription, err := entity.NewSubscription(input.Name, input.URL, secret, input.EventTypes, input.Filter, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, ErrSubscriptionExists
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return subscription, nil
+}
+
+// GetByID retrieves a subscription.
+func (s *SubscriptionService) GetByID(ctx context.Context, id entity.ID) (*entity.Subscription, error) {
+	subscription, err := s.subscriptionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// List retrieves the subscriptions, ordered by name.
+func (s *SubscriptionService) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Subscription], error) {
+	return s.subscriptionRepo.List(ctx, pagination)
+}
+
+// Update changes a subscription. Deliveries already queued are sent with
+// the new URL and secret.
+func (s *SubscriptionService) Update(ctx context.Context, id entity.ID, update SubscriptionUpdate) (*entity.Subscription, error) {
+	subscription, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		subscription.Name = *update.Name
+	}
+	if update.URL != nil {
+		subscription.URL = *update.URL
+	}
+	if update.EventTypes != nil {
+		subscription.EventTypes = update.EventTypes
+	}
+	if update.Filter != nil {
+		subscription.Filter = *update.Filter
+	}
+	if update.IsEnabled != nil {
+		subscription.IsEnabled = *update.IsEnabled
+	}
+	if update.RotateSecret {
+		secret := update.Secret
+		if secret == "" {
+			if secret, err = newWebhookSecret(); err != nil {
+				return nil, err
+			}
+		}
+		subscription.Secret = secret
+	}
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	subscription.Touch()
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrSubscriptionNotFound
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrSubscriptionExists
+		}
+		return nil, err
+	}
+
+	s.invalidate()
+	return subscription, nil
+}
+
+// Delete removes a subscription with its deliveries.
+func (s *SubscriptionService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.subscriptionRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// Stats summarizes the deliveries of a subscription.
+func (s *SubscriptionService) Stats(ctx context.Context, id entity.ID) (*entity.Subscription, repository.SubscriptionStats, error) {
+	subscription, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, repository.SubscriptionStats{}, err
+	}
+
+	stats, err := s.subscriptionRepo.Stats(ctx, id)
+	if err != nil {
+		return nil, repository.SubscriptionStats{}, err
+	}
+
+	return subscription, stats, nil
+}
+
+// ListDeliveries retrieves the deliveries of a subscription, newest first,
+// optionally restricted to a status; failed deliveries are its dead letters.
+func (s *SubscriptionService) ListDeliveries(
+	ctx context.Context,
+	id entity.ID,
+	status *entity.DeliveryStatus,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.SubscriptionDelivery], error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.subscriptionRepo.ListDeliveries(ctx, id, status, pagination)
+}
+
+// Redeliver queues a failed delivery again, due immediately with a fresh
+// set of attempts.
+func (s *SubscriptionService) Redeliver(ctx context.Context, id, deliveryID entity.ID) (*entity.SubscriptionDelivery, error) {
+	delivery, err := s.subscriptionRepo.GetDelivery(ctx, id, deliveryID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSubscriptionDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	if delivery.Status != entity.DeliveryStatusFailed {
+		return nil, ErrSubscriptionDeliveryNotFailed
+	}
+
+	delivery.Redeliver(time.Now().UTC())
+	if err := s.subscriptionRepo.UpdateDelivery(ctx, delivery); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSubscriptionDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Enqueue queues a delivery of an event to each enabled subscription
+// receiving its type and matching its filter. Subscriptions are read from an
+// in-memory copy, reloaded every 30 seconds and whenever this instance
+// changes a subscription.
+func (s *SubscriptionService) Enqueue(ctx context.Context, evt SubscriptionEvent) error {
+	body, err := json.Marshal(subscriptionEnvelope{
+		ID:         entity.NewID().String(),
+		Type:       evt.Type,
+		OccurredAt: evt.OccurredAt,
+		Data:       evt.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.enqueue(ctx, evt, body)
+	if errors.Is(err, repository.ErrForeignKeyViolation) {
+		// A subscription was removed by another instance: reload them.
+		s.invalidate()
+		err = s.enqueue(ctx, evt, body)
+	}
+	return err
+}
+
+// enqueue queues the deliveries of an event to the cached subscriptions.
+func (s *SubscriptionService) enqueue(ctx context.Context, evt SubscriptionEvent, body []byte) error {
+	var deliveries []*entity.SubscriptionDelivery
+	for _, subscription := range s.cachedSubscriptions() {
+		if subscription.Receives(evt.Type) && evt.matches(subscription.Filter) {
+			deliveries = append(deliveries, entity.NewSubscriptionDelivery(subscription.ID, evt.Type, body))
+		}
+	}
+
+	return s.subscriptionRepo.CreateDeliveries(ctx, deliveries)
+}
+
+// ClaimDue returns up to limit deliveries due for an attempt, hidden from
+// other instances while they are attempted.
+func (s *SubscriptionService) ClaimDue(ctx context.Context, limit int) ([]*entity.SubscriptionDelivery, error) {
+	lease := max(subscriptionClaimLease, 2*s.cfg.Timeout)
+	return s.subscriptionRepo.ClaimDue(ctx, time.Now().UTC(), lease, limit)
+}
+
+// Deliver POSTs a delivery to its subscription and records the outcome. A
+// refused delivery is retried after a backoff doubling from the initial
+// backoff, and fails once it reaches max attempts. Deliveries of disabled
+// subscriptions fail without being sent.
+func (s *SubscriptionService) Deliver(ctx context.Context, delivery *entity.SubscriptionDelivery) error {
+	subscription, err := s.subscriptionRepo.GetByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			// Removed meanwhile, along with its deliveries.
+			return nil
+		}
+		return err
+	}
+
+	if !subscription.IsEnabled {
+		delivery.Fail("subscription is disabled")
+		metrics.SubscriptionDeliveriesTotal.WithLabelValues("failed").Inc()
+		return s.subscriptionRepo.UpdateDelivery(ctx, delivery)
+	}
+
+	startedAt := time.Now()
+	statusCode, err := s.post(ctx, subscription, delivery)
+	metrics.SubscriptionDeliveryDuration.Observe(time.Since(startedAt).Seconds())
+
+	now := time.Now().UTC()
+	switch {
+	case err == nil && statusCode >= 200 && statusCode < 300:
+		delivery.RecordSuccess(statusCode, now)
+		metrics.SubscriptionDeliveriesTotal.WithLabelValues("delivered").Inc()
+	default:
+		reason := fmt.Sprintf("unexpected status %d", statusCode)
+		if err != nil {
+			reason = err.Error()
+			if errors.Is(err, context.DeadlineExceeded) {
+				reason = "timed out"
+			}
+		}
+
+		if delivery.RecordFailure(statusCode, reason, now, s.backoff(delivery.Attempts+1), s.cfg.MaxAttempts) {
+			metrics.SubscriptionDeliveriesTotal.WithLabelValues("failed").Inc()
+			log.Warn().
+				Str("subscription_id", subscription.ID.String()).
+				Str("delivery_id", delivery.ID.String()).
+				Int("attempts", delivery.Attempts).
+				Str("error", reason).
+				Msg("Webhook delivery failed, moved to dead letters")
+		} else {
+			metrics.SubscriptionDeliveriesTotal.WithLabelValues("retried").Inc()
+		}
+	}
+
+	return s.subscriptionRepo.UpdateDelivery(ctx, delivery)
+}
+
+// PurgeDeliveries removes the finished deliveries past the retention period
+// and returns how many were removed.
+func (s *SubscriptionService) PurgeDeliveries(ctx context.Context) (int64, error) {
+	if s.cfg.Retention <= 0 {
+		return 0, nil
+	}
+	return s.subscriptionRepo.DeleteDeliveriesBefore(ctx, time.Now().UTC().Add(-s.cfg.Retention))
+}
+
+// post signs a delivery with the secret of its subscription and sends it.
+func (s *SubscriptionService) post(
+	ctx context.Context,
+	subscription *entity.Subscription,
+	delivery *entity.SubscriptionDelivery,
+) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		"Content-Type":              "application/json",
+		"User-Agent":                subscriptionUserAgent,
+		SubscriptionEventHeader:     delivery.EventType,
+		SubscriptionDeliveryHeader:  delivery.ID.String(),
+		SubscriptionTimestampHeader: timestamp,
+		SubscriptionSignatureHeader: webhookSignaturePrefix + SignWebhookPayload(subscription.Secret, timestamp, delivery.Payload),
+	}
+
+	return s.poster.Post(ctx, subscription.URL, headers, delivery.Payload)
+}
+
+// backoff returns the delay before the attempt following the given number
+// of failed attempts.
+func (s *SubscriptionService) backoff(failures int) time.Duration {
+	delay := s.cfg.InitialBackoff
+	for i := 1; i < failures && delay < s.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.cfg.MaxBackoff)
+}
+
+// cachedSubscriptions returns the in-memory copy of the enabled
+// subscriptions, reloading it when stale. If it cannot be reloaded, the
+// previous copy is used.
+func (s *SubscriptionService) cachedSubscriptions() []*entity.Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.loadedAt) < subscriptionsRefreshInterval {
+		return s.subscriptions
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), subscriptionsLoadTimeout)
+	defer cancel()
+
+	subscriptions, err := s.subscriptionRepo.ListEnabled(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load webhook subscriptions")
+		return s.subscriptions
+	}
+
+	s.subscriptions = subscriptions
+	s.loadedAt = time.Now()
+	return subscriptions
+}
+
+// invalidate makes the next event reload the subscriptions.
+func (s *SubscriptionService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
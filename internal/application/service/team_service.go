@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Team service errors.
+var (
+	ErrTeamNotFound       = errors.New("team not found")
+	ErrTeamNameTaken      = errors.New("a team with this name already exists")
+	ErrTeamNotEmpty       = errors.New("team still owns alerts, rules or channels")
+	ErrTeamForbidden      = errors.New("only team owners can manage the team")
+	ErrTeamMemberNotFound = errors.New("team member not found")
+	ErrTeamLastOwner      = errors.New("a team must keep at least one owner")
+	ErrTeamUserNotFound   = errors.New("user not found")
+)
+
+// teamScopeTTL bounds how long a user's resolved team memberships are cached.
+const teamScopeTTL = time.Minute
+
+// TeamService manages teams, their members and the tenant scope of users.
+// Non-admin callers only see the teams they belong to; teams they are not
+// a member of are reported as not found.
+type TeamService struct {
+	teamRepo  repository.TeamRepository
+	cacheRepo repository.CacheRepository
+}
+
+// NewTeamService creates a new team service.
+func NewTeamService(teamRepo repository.TeamRepository, cacheRepo repository.CacheRepository) *TeamService {
+	return &TeamService{
+		teamRepo:  teamRepo,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// Scope resolves the tenant scope of a user. Admins span every team.
+func (s *TeamService) Scope(ctx context.Context, userID entity.ID, role entity.UserRole) (valueobject.TenantScope, error) {
+	if role == entity.UserRoleAdmin {
+		return valueobject.AllTenants(), nil
+	}
+
+	var teamIDs []entity.ID
+	if err := s.cacheRepo.Get(ctx, teamScopeKey(userID), &teamIDs); err == nil {
+		return valueobject.TeamsScope(teamIDs...), nil
+	}
+
+	teamIDs, err := s.teamRepo.ListTeamIDs(ctx, userID)
+	if err != nil {
+		return valueobject.TenantScope{}, err
+	}
+
+	_ = s.cacheRepo.Set(ctx, teamScopeKey(userID), teamIDs, teamScopeTTL)
+
+	return valueobject.TeamsScope(teamIDs...), nil
+}
+
+// Create creates a new team.
+func (s *TeamService) Create(ctx context.Context, name, description string) (*entity.Team, error) {
+	team, err := entity.NewTeam(name, description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.teamRepo.Create(ctx, team); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, ErrTeamNameTaken
+		}
+		return nil, err
+	}
+
+	log.Info().Str("team_id", team.ID.String()).Str("name", team.Name).Msg("Team created")
+
+	return team, nil
+}
+
+// Get returns a team the caller belongs to, or any team for admins.
+func (s *TeamService) Get(ctx context.Context, id, actorID entity.ID, asAdmin bool) (*entity.Team, error) {
+	if _, err := s.membership(ctx, id, actorID, asAdmin); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// List returns every team for admins and the caller's teams otherwise.
+func (s *TeamService) List(
+	ctx context.Context,
+	actorID entity.ID,
+	asAdmin bool,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Team], error) {
+	if asAdmin {
+		return s.teamRepo.List(ctx, pagination)
+	}
+	return s.teamRepo.ListByUser(ctx, actorID, pagination)
+}
+
+// Update changes the name and/or description of a team; nil values are kept.
+// Only owners and admins may update it.
+func (s *TeamService) Update(
+	ctx context.Context,
+	id entity.ID,
+	name, description *string,
+	actorID entity.ID,
+	asAdmin bool,
+) (*entity.Team, error) {
+	if err := s.requireOwner(ctx, id, actorID, asAdmin); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	newName, newDescription := team.Name, team.Description
+	if name != nil {
+		newName = *name
+	}
+	if description != nil {
+		newDescription = *description
+	}
+
+	if err := team.Update(newName, newDescription); err != nil {
+		return nil, err
+	}
+
+	if err := s.teamRepo.Update(ctx, team); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrTeamNotFound
+		case errors.Is(err, repository.ErrDuplicateKey):
+			return nil, ErrTeamNameTaken
+		}
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// Delete removes a team. Teams still owning data cannot be deleted.
+func (s *TeamService) Delete(ctx context.Context, id entity.ID) error {
+	members, err := s.teamRepo.ListMembers(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.teamRepo.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return ErrTeamNotFound
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return ErrTeamNotEmpty
+		}
+		return err
+	}
+
+	for _, member := range members {
+		s.invalidateScope(ctx, member.UserID)
+	}
+
+	log.Info().Str("team_id", id.String()).Msg("Team deleted")
+
+	return nil
+}
+
+// ListMembers returns the members of a team the caller belongs to, or of any team for admins.
+func (s *TeamService) ListMembers(ctx context.Context, teamID, actorID entity.ID, asAdmin bool) ([]*entity.TeamMember, error) {
+	if _, err := s.Get(ctx, teamID, actorID, asAdmin); err != nil {
+		return nil, err
+	}
+
+	return s.teamRepo.ListMembers(ctx, teamID)
+}
+
+// SetMember adds a user to a team or changes their role.
+// Only owners and admins may manage members, and the last owner cannot be demoted.
+func (s *TeamService) SetMember(
+	ctx context.Context,
+	teamID, userID entity.ID,
+	role entity.TeamRole,
+	actorID entity.ID,
+	asAdmin bool,
+) (*entity.TeamMember, error) {
+	if err := s.requireOwner(ctx, teamID, actorID, asAdmin); err != nil {
+		return nil, err
+	}
+
+	member, err := entity.NewTeamMember(teamID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.teamRepo.GetMember(ctx, teamID, userID)
+	switch {
+	case err == nil:
+		if existing.IsOwner() && !member.IsOwner() {
+			if err := s.requireOtherOwner(ctx, teamID); err != nil {
+				return nil, err
+			}
+		}
+		member.JoinedAt = existing.JoinedAt
+	case !errors.Is(err, repository.ErrNotFound):
+		return nil, err
+	}
+
+	if err := s.teamRepo.SaveMember(ctx, member); err != nil {
+		if errors.Is(err, repository.ErrForeignKeyViolation) {
+			if _, getErr := s.teamRepo.GetByID(ctx, teamID); errors.Is(getErr, repository.ErrNotFound) {
+				return nil, ErrTeamNotFound
+			}
+			return nil, ErrTeamUserNotFound
+		}
+		return nil, err
+	}
+
+	s.invalidateScope(ctx, userID)
+
+	log.Info().
+		Str("team_id", teamID.String()).
+		Str("user_id", userID.String()).
+		Str("role", string(role)).
+		Msg("Team member saved")
+
+	return member, nil
+}
+
+// RemoveMember removes a user from a team. Owners and admins may remove
+// anyone, and members may leave; the last owner cannot be removed.
+func (s *TeamService) RemoveMember(ctx context.Context, teamID, userID, actorID entity.ID, asAdmin bool) error {
+	if actorID != userID {
+		if err := s.requireOwner(ctx, teamID, actorID, asAdmin); err != nil {
+			return err
+		}
+	}
+
+	member, err := s.teamRepo.GetMember(ctx, teamID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			if actorID == userID && !asAdmin {
+				return ErrTeamNotFound
+			}
+			return ErrTeamMemberNotFound
+		}
+		return err
+	}
+
+	if member.IsOwner() {
+		if err := s.requireOtherOwner(ctx, teamID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.teamRepo.RemoveMember(ctx, teamID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrTeamMemberNotFound
+		}
+		return err
+	}
+
+	s.invalidateScope(ctx, userID)
+
+	log.Info().
+		Str("team_id", teamID.String()).
+		Str("user_id", userID.String()).
+		Msg("Team member removed")
+
+	return nil
+}
+
+// membership returns the caller's membership of a team; nil for admins.
+// Teams the caller does not belong to are reported as not found.
+func (s *TeamService) membership(ctx context.Context, teamID, actorID entity.ID, asAdmin bool) (*entity.TeamMember, error) {
+	if asAdmin {
+		return nil, nil
+	}
+
+	member, err := s.teamRepo.GetMember(ctx, teamID, actorID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// requireOwner checks that the caller may manage a team.
+func (s *TeamService) requireOwner(ctx context.Context, teamID, actorID entity.ID, asAdmin bool) error {
+	member, err := s.membership(ctx, teamID, actorID, asAdmin)
+	if err != nil {
+		return err
+	}
+
+	if member != nil && !member.IsOwner() {
+		return ErrTeamForbidden
+	}
+
+	return nil
+}
+
+// requireOtherOwner checks that a team keeps an owner after one is removed or demoted.
+func (s *TeamService) requireOtherOwner(ctx context.Context, teamID entity.ID) error {
+	owners, err := s.teamRepo.CountOwners(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return ErrTeamLastOwner
+	}
+
+	return nil
+}
+
+// invalidateScope drops the cached memberships of a user.
+func (s *TeamService) invalidateScope(ctx context.Context, userID entity.ID) {
+	_ = s.cacheRepo.Delete(ctx, teamScopeKey(userID))
+}
+
+// teamScopeKey returns the cache key of a user's team memberships.
+func teamScopeKey(userID entity.ID) string {
+	return "teams:user:" + userID.String()
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// Telegram action errors.
+var (
+	ErrTelegramNotConfigured = errors.New("telegram webhook secret is not configured")
+	ErrTelegramUnknownAction = errors.New("unknown telegram action")
+	ErrTelegramForbidden     = errors.New("insufficient permissions")
+)
+
+// TelegramResponder answers the clicks on the buttons of Telegram messages.
+type TelegramResponder interface {
+	AnswerCallback(ctx context.Context, callbackID, text string) error
+	RemoveButtons(ctx context.Context, chatID, messageID int64) error
+}
+
+// TelegramCallback is a click on a button of an alert message.
+type TelegramCallback struct {
+	// ID identifies the click, to answer it.
+	ID string
+	// UserID is the Telegram ID of the user who clicked.
+	UserID string
+	// ChatID and MessageID identify the message of the button (zero if unknown).
+	ChatID    int64
+	MessageID int64
+	// Data is the callback data of the button, "<action>:<alert-id>".
+	Data string
+}
+
+// TelegramService acknowledges alerts from the buttons of Telegram messages
+// on behalf of the users linked to the Telegram accounts.
+//
+// Telegram sends each update to the bot's webhook with the secret token the
+// webhook was registered with, in the X-Telegram-Bot-Api-Secret-Token header.
+type TelegramService struct {
+	identityService *IdentityService
+	teamService     *TeamService
+	alertService    *AlertService
+	responder       TelegramResponder
+	webhookSecret   string
+}
+
+// NewTelegramService creates a new Telegram service. The responder may be
+// nil, in which case clicks are not answered.
+func NewTelegramService(
+	cfg config.TelegramConfig,
+	identityService *IdentityService,
+	teamService *TeamService,
+	alertService *AlertService,
+	responder TelegramResponder,
+) *TelegramService {
+	return &TelegramService{
+		identityService: identityService,
+		teamService:     teamService,
+		alertService:    alertService,
+		responder:       responder,
+		webhookSecret:   cfg.WebhookSecret,
+	}
+}
+
+// Verify checks the secret token of an update received from Telegram.
+func (s *TelegramService) Verify(secretToken string) error {
+	if s.webhookSecret == "" {
+		return ErrTelegramNotConfigured
+	}
+
+	if secretToken == "" {
+		return ErrWebhookSignatureMissing
+	}
+
+	if subtle.ConstantTimeCompare([]byte(secretToken), []byte(s.webhookSecret)) != 1 {
+		return ErrWebhookSignatureInvalid
+	}
+
+	return nil
+}
+
+// HandleCallback acknowledges an alert on behalf of the user linked to the
+// Telegram account that clicked, and answers the click with the result. The
+// button is removed once the alert no longer needs acknowledging. Actions
+// follow the rules of the HTTP endpoints: the user must be an operator or
+// admin, and may only change alerts they can see.
+func (s *TelegramService) HandleCallback(ctx context.Context, callback TelegramCallback) (*entity.Alert, error) {
+	alert, err := s.performCallback(ctx, callback)
+	s.answer(ctx, callback, telegramReply(alert, err))
+
+	if err == nil || errors.Is(err, entity.ErrAlertAlreadyAcknowledged) || errors.Is(err, entity.ErrAlertAlreadyResolved) {
+		s.removeButtons(ctx, callback)
+	}
+
+	return alert, err
+}
+
+// performCallback checks the linked user may acknowledge the alert and
+// acknowledges it.
+func (s *TelegramService) performCallback(ctx context.Context, callback TelegramCallback) (*entity.Alert, error) {
+	action, rawAlertID, _ := strings.Cut(callback.Data, ":")
+	if action != notification.ActionAcknowledge {
+		return nil, ErrTelegramUnknownAction
+	}
+
+	user, err := s.identityService.Resolve(ctx, entity.IdentityProviderTelegram, callback.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CanManageAlerts() {
+		return nil, ErrTelegramForbidden
+	}
+
+	alertID, err := entity.ParseID(rawAlertID)
+	if err != nil {
+		return nil, ErrAlertNotFound
+	}
+
+	tenant, err := s.teamService.Scope(ctx, user.ID, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Alerts hidden from the user's role or teams cannot be changed either
+	if _, err := s.alertService.GetVisible(ctx, alertID, user.Role, tenant); err != nil {
+		return nil, err
+	}
+
+	return s.alertService.Acknowledge(ctx, alertID, user.ID)
+}
+
+// answer shows the result of a click to the user who clicked.
+func (s *TelegramService) answer(ctx context.Context, callback TelegramCallback, text string) {
+	if s.responder == nil {
+		return
+	}
+
+	if err := s.responder.AnswerCallback(ctx, callback.ID, text); err != nil {
+		log.Warn().Err(err).Str("callback_id", callback.ID).Msg("Failed to answer Telegram callback")
+	}
+}
+
+// removeButtons removes the buttons of the message that was clicked.
+func (s *TelegramService) removeButtons(ctx context.Context, callback TelegramCallback) {
+	if s.responder == nil || callback.ChatID == 0 || callback.MessageID == 0 {
+		return
+	}
+
+	if err := s.responder.RemoveButtons(ctx, callback.ChatID, callback.MessageID); err != nil {
+		log.Warn().Err(err).Str("callback_id", callback.ID).Msg("Failed to remove Telegram message buttons")
+	}
+}
+
+// telegramReply returns the text shown for the result of a click.
+func telegramReply(alert *entity.Alert, err error) string {
+	switch {
+	case err == nil:
+		return "You acknowledged " + alert.Title
+	case errors.Is(err, ErrIdentityNotLinked):
+		return "Your Telegram account is not linked to an active user; ask an admin to link it"
+	case errors.Is(err, ErrTelegramForbidden):
+		return "Only operators and admins can acknowledge alerts"
+	case errors.Is(err, ErrTelegramUnknownAction):
+		return "This action is not supported"
+	case errors.Is(err, ErrAlertNotFound):
+		return "Alert not found"
+	case errors.Is(err, entity.ErrAlertAlreadyAcknowledged):
+		return "Alert is already acknowledged"
+	case errors.Is(err, entity.ErrAlertAlreadyResolved):
+		return "Alert is already resolved"
+	default:
+		return "The action failed, please try again"
+	}
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// Ticket errors.
+var (
+	ErrTicketsNotConfigured = errors.New("issue tracker is not configured")
+	ErrTicketExists         = errors.New("a ticket was already filed for this alert")
+	ErrTicketTrackerFailed  = errors.New("issue tracker request failed")
+)
+
+// Ticket is an issue filed for an alert.
+type Ticket struct {
+	Key string
+	URL string
+}
+
+// TicketService files issue tracker tickets for alerts, on demand or for the
+// alerts of rules with CreateTicket, and resolves them with the alert. The
+// key of the issue is stored in the alert metadata under
+// entity.MetadataTicketKey.
+type TicketService struct {
+	alertRepo repository.AlertRepository
+	tracker   notification.IssueTracker
+	alertURL  string
+}
+
+// NewTicketService creates a new ticket service. AlertURL is the link to an
+// alert included in the issues; {id} is replaced by the alert ID.
+func NewTicketService(alertRepo repository.AlertRepository, tracker notification.IssueTracker, alertURL string) *TicketService {
+	return &TicketService{
+		alertRepo: alertRepo,
+		tracker:   tracker,
+		alertURL:  alertURL,
+	}
+}
+
+// IsEnabled reports whether tickets can be filed.
+func (s *TicketService) IsEnabled() bool {
+	return s.tracker != nil && s.tracker.IsEnabled()
+}
+
+// Create files the ticket of an alert and stores its key on the alert.
+func (s *TicketService) Create(ctx context.Context, alert *entity.Alert) (*Ticket, error) {
+	if !s.IsEnabled() {
+		return nil, ErrTicketsNotConfigured
+	}
+	if alert.TicketKey() != "" {
+		return nil, ErrTicketExists
+	}
+
+	key, err := s.tracker.CreateIssue(ctx, s.issue(alert))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTicketTrackerFailed, err)
+	}
+
+	if err := s.alertRepo.SetMetadata(ctx, alert.ID, entity.MetadataTicketKey, key); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAlertNotFound
+		}
+		return nil, err
+	}
+	alert.AddMetadata(entity.MetadataTicketKey, key)
+
+	log.Info().
+		Str("alert_id", alert.ID.String()).
+		Str("issue", key).
+		Msg("Ticket created for alert")
+
+	return &Ticket{Key: key, URL: s.tracker.IssueURL(key)}, nil
+}
+
+// CreateForAlert files the ticket of a stored alert, unless one was filed
+// already.
+func (s *TicketService) CreateForAlert(ctx context.Context, id entity.ID) error {
+	alert, err := s.alertRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if _, err := s.Create(ctx, alert); err != nil && !errors.Is(err, ErrTicketExists) {
+		return err
+	}
+	return nil
+}
+
+// Resolve resolves the ticket of an alert with a comment.
+func (s *TicketService) Resolve(ctx context.Context, key, comment string) error {
+	if !s.IsEnabled() {
+		return ErrTicketsNotConfigured
+	}
+
+	if err := s.tracker.ResolveIssue(ctx, key, comment); err != nil {
+		return fmt.Errorf("%w: failed to resolve issue %s: %v", ErrTicketTrackerFailed, key, err)
+	}
+	return nil
+}
+
+// issue builds the issue of an alert: its title as summary and its details
+// as description.
+func (s *TicketService) issue(alert *entity.Alert) notification.Issue {
+	var b strings.Builder
+
+	b.WriteString(alert.Message + "\n\n")
+	fmt.Fprintf(&b, "*Severity:* %s\n", alert.Severity)
+	if alert.Source != "" {
+		fmt.Fprintf(&b, "*Source:* %s\n", alert.Source)
+	}
+	fmt.Fprintf(&b, "*Alert ID:* %s\n", alert.ID)
+	fmt.Fprintf(&b, "*Created at:* %s\n", alert.CreatedAt.UTC().Format("2006-01-02 15:04:05 MST"))
+	if s.alertURL != "" {
+		fmt.Fprintf(&b, "*Alert:* %s\n", strings.ReplaceAll(s.alertURL, "{id}", alert.ID.String()))
+	}
+	if runbook := alert.RunbookURL(); runbook != "" {
+		fmt.Fprintf(&b, "*Runbook:* %s\n", runbook)
+	}
+	if remediation := alert.Remediation(); remediation != "" {
+		b.WriteString("\n*Remediation*\n" + remediation + "\n")
+	}
+
+	return notification.Issue{
+		Summary:     alert.Title,
+		Description: b.String(),
+		Severity:    string(alert.Severity),
+		Labels:      []string{"alerting", "severity-" + string(alert.Severity)},
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// revocationFalsePositiveRate is the share of valid tokens the revocation
+// filter sends to Redis to be checked.
+const revocationFalsePositiveRate = 0.01
+
+// tokenRevocations tracks the revoked tokens. A bloom filter of the revoked
+// token IDs, rebuilt periodically, answers for most tokens without a Redis
+// round-trip; only the tokens it may contain are checked in Redis.
+//
+// Tokens revoked by other instances are only known once the filter is
+// rebuilt, so the rebuild interval bounds how long they are still accepted.
+type tokenRevocations struct {
+	repo repository.TokenRevocationRepository
+
+	mu     sync.RWMutex
+	filter *valueobject.BloomFilter // nil until first built
+	// IDs revoked by this instance since the current rebuild started, which
+	// the rebuilt filter may not contain
+	pending []string
+}
+
+// newTokenRevocations creates revoked token tracking over a repository.
+func newTokenRevocations(repo repository.TokenRevocationRepository) *tokenRevocations {
+	return &tokenRevocations{repo: repo}
+}
+
+// revoke marks a token revoked until it expires.
+func (r *tokenRevocations) revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if err := r.repo.Revoke(ctx, tokenID, expiresAt); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	if r.filter != nil {
+		r.filter.Add(tokenID)
+	}
+	r.pending = append(r.pending, tokenID)
+	r.mu.Unlock()
+
+	return nil
+}
+
+// isRevoked checks whether a token was revoked, in Redis only if the filter
+// may contain it.
+func (r *tokenRevocations) isRevoked(ctx context.Context, tokenID string) (bool, error) {
+	r.mu.RLock()
+	filtered := r.filter != nil && !r.filter.MayContain(tokenID)
+	r.mu.RUnlock()
+	if filtered {
+		metrics.AuthRevocationChecksTotal.WithLabelValues("filter").Inc()
+		return false, nil
+	}
+
+	metrics.AuthRevocationChecksTotal.WithLabelValues("redis").Inc()
+	return r.repo.IsRevoked(ctx, tokenID)
+}
+
+// refresh rebuilds the filter from the tokens revoked by every instance.
+func (r *tokenRevocations) refresh(ctx context.Context) error {
+	r.mu.Lock()
+	r.pending = nil
+	r.mu.Unlock()
+
+	ids, err := r.repo.ListRevoked(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter := valueobject.NewBloomFilter(2*len(ids), revocationFalsePositiveRate)
+	for _, id := range ids {
+		filter.Add(id)
+	}
+
+	r.mu.Lock()
+	for _, id := range r.pending {
+		filter.Add(id)
+	}
+	r.filter = filter
+	r.mu.Unlock()
+
+	metrics.AuthRevokedTokens.Set(float64(len(ids)))
+	return nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Topology errors.
+var (
+	ErrSourceDependencyNotFound = errors.New("source dependency not found")
+	ErrSourceDependencyExists   = errors.New("source already depends on this source")
+	ErrSourceDependencyCycle    = errors.New("dependency would create a cycle")
+)
+
+// upstreamSeverity is the severity of the upstream alerts that make the
+// alerts of the sources depending on theirs downstream.
+const upstreamSeverity = entity.AlertSeverityCritical
+
+// TopologyService manages the dependency graph between alert sources and
+// finds the upstream alerts new alerts are a consequence of.
+type TopologyService struct {
+	dependencyRepo repository.SourceDependencyRepository
+}
+
+// NewTopologyService creates a new topology service.
+func NewTopologyService(dependencyRepo repository.SourceDependencyRepository) *TopologyService {
+	return &TopologyService{
+		dependencyRepo: dependencyRepo,
+	}
+}
+
+// Create declares that source depends on dependsOn. Dependencies closing a
+// cycle are rejected, since every source of the cycle would be downstream
+// of the others.
+func (s *TopologyService) Create(
+	ctx context.Context,
+	source, dependsOn string,
+	createdBy *entity.ID,
+) (*entity.SourceDependency, error) {
+	dependency, err := entity.NewSourceDependency(source, dependsOn, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	upstream, err := s.dependencyRepo.ListUpstream(ctx, dependsOn)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(upstream, source) {
+		return nil, ErrSourceDependencyCycle
+	}
+
+	if err := s.dependencyRepo.Create(ctx, dependency); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, ErrSourceDependencyExists
+		}
+		return nil, err
+	}
+
+	return dependency, nil
+}
+
+// List retrieves source dependencies, by source.
+func (s *TopologyService) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.SourceDependency], error) {
+	return s.dependencyRepo.List(ctx, pagination)
+}
+
+// Delete removes a source dependency.
+func (s *TopologyService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.dependencyRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSourceDependencyNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// UpstreamAlert returns the oldest active critical alert of a source the
+// alert's source depends on, or nil when there is none.
+func (s *TopologyService) UpstreamAlert(ctx context.Context, alert *entity.Alert) (*entity.Alert, error) {
+	upstream, err := s.dependencyRepo.GetUpstreamAlert(ctx, alert.Source, upstreamSeverity)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return upstream, nil
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // Twilio signs requests with HMAC-SHA1
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Voice escalation errors.
+var (
+	ErrVoiceNotConfigured = errors.New("voice escalation is not configured")
+	ErrVoiceForbidden     = errors.New("insufficient permissions")
+	ErrVoiceUnknownKey    = errors.New("unknown key")
+)
+
+// Voice escalation settings.
+const (
+	// voiceEscalationWindow bounds how old the alerts called about may be, so
+	// that enabling escalation, or a long outage of the checks, does not call
+	// about every stale alert at once.
+	voiceEscalationWindow = 24 * time.Hour
+	// voiceEscalationPageSize is how many alerts are read at a time.
+	voiceEscalationPageSize = 100
+	// voiceAcknowledgeKey is the key acknowledging the alert during a call.
+	voiceAcknowledgeKey = "1"
+	// voiceCallbackPath is where Twilio posts the keys pressed during calls.
+	voiceCallbackPath = "/api/v1/integrations/twilio/voice"
+	// voiceMaxTitleLength bounds the title read during calls.
+	voiceMaxTitleLength = 200
+)
+
+// VoiceKeypress is a key pressed during an escalation call.
+type VoiceKeypress struct {
+	// AlertID is the alert the call was placed for.
+	AlertID string
+	// To is the phone number called.
+	To string
+	// Digits are the keys pressed.
+	Digits string
+}
+
+// VoiceEscalationService calls about critical alerts left unacknowledged.
+// Alerts still active the acknowledgement timeout after they fired call
+// their assignee, or the on-call user when they have none, at the phone
+// number linked to the user; pressing 1 during the call acknowledges the
+// alert on behalf of the user called. Each alert is called about once; the
+// call is recorded in its metadata under entity.MetadataVoiceEscalation.
+//
+// Twilio posts the keys pressed to the callback URL of the call, signed with
+// the account's auth token in the X-Twilio-Signature header.
+type VoiceEscalationService struct {
+	alertRepo          repository.AlertRepository
+	userRepo           repository.UserRepository
+	identityService    *IdentityService
+	teamService        *TeamService
+	alertService       *AlertService
+	maintenanceService *MaintenanceService
+	caller             notification.VoiceCaller
+	authToken          string
+	publicURL          string
+	onCallEmail        string
+	ackTimeout         time.Duration
+}
+
+// NewVoiceEscalationService creates a new voice escalation service.
+func NewVoiceEscalationService(
+	cfg config.TwilioConfig,
+	alertRepo repository.AlertRepository,
+	userRepo repository.UserRepository,
+	identityService *IdentityService,
+	teamService *TeamService,
+	alertService *AlertService,
+	maintenanceService *MaintenanceService,
+	caller notification.VoiceCaller,
+) *VoiceEscalationService {
+	return &VoiceEscalationService{
+		alertRepo:          alertRepo,
+		userRepo:           userRepo,
+		identityService:    identityService,
+		teamService:        teamService,
+		alertService:       alertService,
+		maintenanceService: maintenanceService,
+		caller:             caller,
+		authToken:          cfg.AuthToken,
+		publicURL:          strings.TrimSuffix(cfg.PublicURL, "/"),
+		onCallEmail:        cfg.OnCallEmail,
+		ackTimeout:         cfg.AckTimeout,
+	}
+}
+
+// IsEnabled reports whether unacknowledged alerts are called about.
+func (s *VoiceEscalationService) IsEnabled() bool {
+	return s.caller != nil && s.caller.IsEnabled() && s.publicURL != "" && s.ackTimeout > 0
+}
+
+// EscalateOverdue calls about the critical alerts still active the
+// acknowledgement timeout after they fired, and returns how many calls were
+// placed. Flapping, downstream and silenced alerts are not called about.
+// Alerts are all tried; the error reports those that failed.
+func (s *VoiceEscalationService) EscalateOverdue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	cutoff := now.Add(-s.ackTimeout)
+	filter := valueobject.NewAlertFilter().
+		WithStatuses(entity.AlertStatusActive).
+		WithSeverities(entity.AlertSeverityCritical).
+		WithDateRange(cutoff.Add(-voiceEscalationWindow), cutoff)
+
+	var errs []error
+	placed := 0
+	for page := 1; ; page++ {
+		result, err := s.alertRepo.List(ctx, filter, valueobject.NewPagination(page, voiceEscalationPageSize))
+		if err != nil {
+			return placed, err
+		}
+
+		for _, alert := range result.Items {
+			if alert.IsVoiceEscalated() || alert.IsFlapping() || alert.IsDownstream() {
+				continue
+			}
+
+			silenced, err := s.maintenanceService.IsSilenced(ctx, alert.Source, alert.TeamID, now)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if silenced {
+				continue
+			}
+
+			called, err := s.escalate(ctx, alert, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("alert %s: %w", alert.ID, err))
+				continue
+			}
+			if called {
+				placed++
+			}
+		}
+
+		if page >= result.TotalPages {
+			break
+		}
+	}
+
+	return placed, errors.Join(errs...)
+}
+
+// escalate calls about an alert, records the call on the alert and lets the
+// callee know, in their digest when offline. Alerts whose callee has no
+// phone number linked are recorded without a call, and reported as not called.
+func (s *VoiceEscalationService) escalate(ctx context.Context, alert *entity.Alert, now time.Time) (bool, error) {
+	userID, number, err := s.callee(ctx, alert)
+	if err != nil {
+		return false, err
+	}
+
+	record := map[string]interface{}{"escalated_at": now}
+	if number == "" {
+		log.Warn().
+			Str("alert_id", alert.ID.String()).
+			Msg("No phone number to call about unacknowledged alert")
+		metrics.VoiceEscalationsTotal.WithLabelValues("no_number").Inc()
+	} else {
+		callID, err := s.caller.Call(ctx, notification.VoiceCall{
+			To:          number,
+			Message:     voiceMessage(alert, now),
+			Prompt:      "Press 1 to acknowledge the alert.",
+			CallbackURL: s.publicURL + voiceCallbackPath + "?alert_id=" + url.QueryEscape(alert.ID.String()),
+		})
+		if err != nil {
+			metrics.VoiceEscalationsTotal.WithLabelValues("failed").Inc()
+			return false, err
+		}
+		metrics.VoiceEscalationsTotal.WithLabelValues("placed").Inc()
+		record["to"] = number
+		record["call_id"] = callID
+
+		log.Info().
+			Str("alert_id", alert.ID.String()).
+			Str("call_id", callID).
+			Msg("Escalation call placed for unacknowledged alert")
+	}
+
+	if err := s.alertRepo.SetMetadata(ctx, alert.ID, entity.MetadataVoiceEscalation, record); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return false, err
+		}
+	}
+
+	if userID != nil {
+		s.alertService.NotifyUser(ctx, *userID, dto.DigestKindEscalation, alert)
+	}
+
+	return number != "", nil
+}
+
+// callee returns the user to call about an alert, its assignee or the
+// on-call user when it has none, and their phone number; "" if the user has
+// no phone number linked.
+func (s *VoiceEscalationService) callee(ctx context.Context, alert *entity.Alert) (*entity.ID, string, error) {
+	userID := alert.AssigneeID()
+	if userID == nil && s.onCallEmail != "" {
+		user, err := s.userRepo.GetByEmail(ctx, s.onCallEmail)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, "", nil
+			}
+			return nil, "", err
+		}
+		userID = &user.ID
+	}
+	if userID == nil {
+		return nil, "", nil
+	}
+
+	identity, err := s.identityService.Get(ctx, *userID, entity.IdentityProviderPhone)
+	if err != nil {
+		if errors.Is(err, ErrIdentityNotFound) {
+			return userID, "", nil
+		}
+		return nil, "", err
+	}
+	return userID, identity.ExternalID, nil
+}
+
+// Verify checks the signature of a request posted by Twilio: the base64
+// HMAC-SHA1, keyed with the auth token, of the URL of the request followed
+// by its form parameters sorted by name. RequestURI is the path and query
+// of the request, under the public URL.
+func (s *VoiceEscalationService) Verify(requestURI string, params map[string]string, signature string) error {
+	if s.authToken == "" || s.publicURL == "" {
+		return ErrVoiceNotConfigured
+	}
+
+	if signature == "" {
+		return ErrWebhookSignatureMissing
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var data strings.Builder
+	data.WriteString(s.publicURL + requestURI)
+	for _, key := range keys {
+		data.WriteString(key + params[key])
+	}
+
+	mac := hmac.New(sha1.New, []byte(s.authToken))
+	mac.Write([]byte(data.String()))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(expected)) != 1 {
+		return ErrWebhookSignatureInvalid
+	}
+
+	return nil
+}
+
+// HandleKeypress acknowledges an alert on behalf of the user linked to the
+// phone number called when they press 1, and returns the text read back to
+// them. Actions follow the rules of the HTTP endpoints: the user must be an
+// operator or admin, and may only change alerts they can see.
+func (s *VoiceEscalationService) HandleKeypress(ctx context.Context, keypress VoiceKeypress) (string, error) {
+	alert, err := s.performKeypress(ctx, keypress)
+	return voiceReply(alert, err), err
+}
+
+// performKeypress checks the linked user may acknowledge the alert and
+// acknowledges it.
+func (s *VoiceEscalationService) performKeypress(ctx context.Context, keypress VoiceKeypress) (*entity.Alert, error) {
+	if keypress.Digits != voiceAcknowledgeKey {
+		return nil, ErrVoiceUnknownKey
+	}
+
+	user, err := s.identityService.Resolve(ctx, entity.IdentityProviderPhone, keypress.To)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CanManageAlerts() {
+		return nil, ErrVoiceForbidden
+	}
+
+	alertID, err := entity.ParseID(keypress.AlertID)
+	if err != nil {
+		return nil, ErrAlertNotFound
+	}
+
+	tenant, err := s.teamService.Scope(ctx, user.ID, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Alerts hidden from the user's role or teams cannot be changed either
+	if _, err := s.alertService.GetVisible(ctx, alertID, user.Role, tenant); err != nil {
+		return nil, err
+	}
+
+	return s.alertService.Acknowledge(ctx, alertID, user.ID)
+}
+
+// voiceMessage returns the summary of an alert read during its call.
+func voiceMessage(alert *entity.Alert, now time.Time) string {
+	title := []rune(alert.Title)
+	if len(title) > voiceMaxTitleLength {
+		title = title[:voiceMaxTitleLength]
+	}
+
+	message := "Critical alert: " + string(title) + "."
+	if alert.Source != "" {
+		message += " Source: " + alert.Source + "."
+	}
+	minutes := int(now.Sub(alert.CreatedAt).Minutes())
+	return message + fmt.Sprintf(" It fired %d minutes ago and is not acknowledged.", minutes)
+}
+
+// voiceReply returns the text read back for the result of a key press.
+func voiceReply(alert *entity.Alert, err error) string {
+	switch {
+	case err == nil:
+		return "You acknowledged " + alert.Title + ". Goodbye."
+	case errors.Is(err, ErrVoiceUnknownKey):
+		return "No action was taken. Goodbye."
+	case errors.Is(err, ErrIdentityNotLinked):
+		return "This phone number is not linked to an active user. Ask an admin to link it. Goodbye."
+	case errors.Is(err, ErrVoiceForbidden):
+		return "Only operators and admins can acknowledge alerts. Goodbye."
+	case errors.Is(err, ErrAlertNotFound):
+		return "The alert was not found. Goodbye."
+	case errors.Is(err, entity.ErrAlertAlreadyAcknowledged):
+		return "The alert is already acknowledged. Goodbye."
+	case errors.Is(err, entity.ErrAlertAlreadyResolved):
+		return "The alert is already resolved. Goodbye."
+	default:
+		return "The action failed. Please acknowledge the alert from the dashboard. Goodbye."
+	}
+}
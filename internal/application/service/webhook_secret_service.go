@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// Webhook signature errors.
+var (
+	ErrWebhookSecretNotFound      = errors.New("webhook secret not found")
+	ErrWebhookSecretNotConfigured = errors.New("webhook integration has no signing secret")
+	ErrWebhookSignatureMissing    = errors.New("webhook signature or timestamp is missing")
+	ErrWebhookSignatureInvalid    = errors.New("webhook signature is invalid")
+	ErrWebhookTimestampStale      = errors.New("webhook timestamp is outside the allowed window")
+)
+
+// webhookSignaturePrefix is the optional scheme prefix of a signature header value.
+const webhookSignaturePrefix = "sha256="
+
+// WebhookSecretService manages per-integration signing secrets and verifies
+// signed webhook payloads.
+//
+// Senders sign "<timestamp>.<body>" with HMAC-SHA256 using the shared secret,
+// where timestamp is the Unix time in seconds sent alongside the signature.
+type WebhookSecretService struct {
+	secretRepo repository.WebhookSecretRepository
+	required   bool
+	tolerance  time.Duration
+}
+
+// NewWebhookSecretService creates a new webhook secret service.
+func NewWebhookSecretService(secretRepo repository.WebhookSecretRepository, cfg config.WebhookSignatureConfig) *WebhookSecretService {
+	tolerance := cfg.Tolerance
+	if tolerance <= 0 {
+		tolerance = 5 * time.Minute
+	}
+
+	return &WebhookSecretService{
+		secretRepo: secretRepo,
+		required:   cfg.Required,
+		tolerance:  tolerance,
+	}
+}
+
+// Set configures or rotates the secret of an integration and returns it.
+// When secret is empty a random one is generated.
+func (s *WebhookSecretService) Set(
+	ctx context.Context,
+	integration, secret string,
+	createdBy *entity.ID,
+) (*entity.WebhookSecret, string, error) {
+	if secret == "" {
+		generated, err := newWebhookSecret()
+		if err != nil {
+			return nil, "", err
+		}
+		secret = generated
+	}
+
+	existing, err := s.secretRepo.GetByIntegration(ctx, integration)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, "", err
+	}
+
+	if existing != nil {
+		if err := existing.Rotate(secret); err != nil {
+			return nil, "", err
+		}
+		if err := s.secretRepo.Update(ctx, existing); err != nil {
+			return nil, "", err
+		}
+		return existing, secret, nil
+	}
+
+	ws, err := entity.NewWebhookSecret(integration, secret, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.secretRepo.Create(ctx, ws); err != nil {
+		return nil, "", err
+	}
+
+	return ws, secret, nil
+}
+
+// List returns every configured secret.
+func (s *WebhookSecretService) List(ctx context.Context) ([]*entity.WebhookSecret, error) {
+	return s.secretRepo.List(ctx)
+}
+
+// Delete removes the secret of an integration. Unless signatures are
+// required, the integration then accepts unsigned payloads again.
+func (s *WebhookSecretService) Delete(ctx context.Context, integration string) error {
+	if err := s.secretRepo.Delete(ctx, integration); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWebhookSecretNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Verify checks a payload received from an integration against its secret.
+// Integrations without a secret pass unless signatures are required.
+func (s *WebhookSecretService) Verify(ctx context.Context, integration, timestamp, signature string, body []byte) error {
+	ws, err := s.secretRepo.GetByIntegration(ctx, integration)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			if s.required {
+				return ErrWebhookSecretNotConfigured
+			}
+			return nil
+		}
+		return err
+	}
+
+	if timestamp == "" || signature == "" {
+		return ErrWebhookSignatureMissing
+	}
+
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookTimestampStale
+	}
+
+	skew := time.Since(time.Unix(sentAt, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > s.tolerance {
+		return ErrWebhookTimestampStale
+	}
+
+	expected := SignWebhookPayload(ws.Secret, timestamp, body)
+	if !hmac.Equal([]byte(strings.TrimPrefix(signature, webhookSignaturePrefix)), []byte(expected)) {
+		return ErrWebhookSignatureInvalid
+	}
+
+	return nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookSecret generates a random signing secret.
+func newWebhookSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Webhook source errors.
+var (
+	ErrWebhookSourceNotFound  = errors.New("webhook source not found")
+	ErrWebhookSourceExists    = errors.New("webhook source with this name already exists")
+	ErrWebhookSourceDisabled  = errors.New("webhook source is disabled")
+	ErrWebhookPayloadInvalid  = errors.New("webhook payload is not valid JSON")
+	ErrWebhookPayloadUnmapped = errors.New("webhook payload did not produce a title")
+)
+
+// Inbound webhook actions reported back to the sender.
+const (
+	WebhookActionCreated      = "created"
+	WebhookActionDeduplicated = "deduplicated"
+	WebhookActionResolved     = "resolved"
+	WebhookActionNone         = "none"
+)
+
+// templatePlaceholder matches {{path}} placeholders in mapping templates.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// WebhookSourceService manages inbound webhook sources and converts their
+// payloads into alerts using each source's mapping.
+type WebhookSourceService struct {
+	sourceRepo   repository.WebhookSourceRepository
+	alertService *AlertService
+	quotas       *QuotaService
+}
+
+// NewWebhookSourceService creates a new webhook source service.
+func NewWebhookSourceService(sourceRepo repository.WebhookSourceRepository, alertService *AlertService) *WebhookSourceService {
+	return &WebhookSourceService{
+		sourceRepo:   sourceRepo,
+		alertService: alertService,
+	}
+}
+
+// SetQuotaService sets the service enforcing the daily request quotas of
+// sources. Their alert quotas are enforced by the alert service.
+func (s *WebhookSourceService) SetQuotaService(quotas *QuotaService) {
+	s.quotas = quotas
+}
+
+// Register creates a source and returns it together with its webhook token.
+// The token is only available here and on rotation; only its hash is stored.
+func (s *WebhookSourceService) Register(
+	ctx context.Context,
+	name string,
+	mapping entity.WebhookMapping,
+	createdBy *entity.ID,
+) (*entity.WebhookSource, string, error) {
+	token, tokenHash, err := newWebhookToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	source, err := entity.NewWebhookSource(name, tokenHash, mapping, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.sourceRepo.Create(ctx, source); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, "", ErrWebhookSourceExists
+		}
+		return nil, "", err
+	}
+
+	return source, token, nil
+}
+
+// GetByID retrieves a source by ID.
+func (s *WebhookSourceService) GetByID(ctx context.Context, id entity.ID) (*entity.WebhookSource, error) {
+	source, err := s.sourceRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWebhookSourceNotFound
+		}
+		return nil, err
+	}
+	return source, nil
+}
+
+// List retrieves registered sources.
+func (s *WebhookSourceService) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.WebhookSource], error) {
+	return s.sourceRepo.List(ctx, pagination)
+}
+
+// Update changes the mapping and enabled state of a source.
+// A nil mapping or enabled flag leaves the current value unchanged.
+func (s *WebhookSourceService) Update(
+	ctx context.Context,
+	id entity.ID,
+	mapping *entity.WebhookMapping,
+	enabled *bool,
+) (*entity.WebhookSource, error) {
+	source, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping != nil {
+		if err := source.UpdateMapping(*mapping); err != nil {
+			return nil, err
+		}
+	}
+
+	if enabled != nil {
+		if *enabled {
+			source.Enable()
+		} else {
+			source.Disable()
+		}
+	}
+
+	if err := s.sourceRepo.Update(ctx, source); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// RotateToken issues a new webhook token, invalidating the previous URL.
+func (s *WebhookSourceService) RotateToken(ctx context.Context, id entity.ID) (*entity.WebhookSource, string, error) {
+	source, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, tokenHash, err := newWebhookToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	source.RotateToken(tokenHash)
+	if err := s.sourceRepo.Update(ctx, source); err != nil {
+		return nil, "", err
+	}
+
+	return source, token, nil
+}
+
+// Delete removes a source, invalidating its webhook URL.
+func (s *WebhookSourceService) Delete(ctx context.Context, id entity.ID) error {
+	if err := s.sourceRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWebhookSourceNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Ingest maps a payload pushed with a source token into an alert.
+// With a dedup key, repeated payloads update nothing while the alert is open
+// and payloads with a resolved status resolve it. Returns the action taken.
+func (s *WebhookSourceService) Ingest(ctx context.Context, token string, body []byte) (string, *entity.Alert, error) {
+	source, err := s.sourceRepo.GetByTokenHash(ctx, hashWebhookToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", nil, ErrWebhookSourceNotFound
+		}
+		return "", nil, err
+	}
+
+	if !source.IsEnabled {
+		return "", nil, ErrWebhookSourceDisabled
+	}
+
+	if s.quotas != nil {
+		if _, err := s.quotas.Consume(ctx, valueobject.SourceQuotaSubject(source.ID), valueobject.QuotaRequests, 1); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", nil, ErrWebhookPayloadInvalid
+	}
+
+	mapping := source.Mapping
+	dedupKey := ""
+	if key := renderMapping(payload, mapping.DedupKey); key != "" {
+		dedupKey = "webhook:" + source.ID.String() + ":" + key
+	}
+
+	if mapping.IsResolvedStatus(renderMapping(payload, mapping.Status)) {
+		if dedupKey == "" {
+			return WebhookActionNone, nil, nil
+		}
+		alert, err := s.alertService.ResolveByDedupKey(ctx, dedupKey)
+		if err != nil {
+			if errors.Is(err, ErrAlertNotFound) {
+				return WebhookActionNone, nil, nil
+			}
+			return "", nil, err
+		}
+		return WebhookActionResolved, alert, nil
+	}
+
+	title := renderMapping(payload, mapping.Title)
+	if title == "" {
+		return "", nil, ErrWebhookPayloadUnmapped
+	}
+
+	message := renderMapping(payload, mapping.Message)
+	if message == "" {
+		message = "Alert received from " + source.Name
+	}
+
+	input := CreateAlertInput{
+		Title:    title,
+		Message:  message,
+		Severity: mapSeverity(mapping, renderMapping(payload, mapping.Severity)),
+		Source:   source.Name,
+		SourceID: &source.ID,
+		Metadata: map[string]interface{}{
+			"webhook_source_id": source.ID.String(),
+			"payload":           payload,
+		},
+	}
+
+	if dedupKey == "" {
+		alert, err := s.alertService.Create(ctx, input)
+		if err != nil {
+			return "", nil, err
+		}
+		return WebhookActionCreated, alert, nil
+	}
+
+	alert, created, err := s.alertService.CreateDeduplicated(ctx, dedupKey, input)
+	if err != nil {
+		return "", nil, err
+	}
+	if !created {
+		return WebhookActionDeduplicated, alert, nil
+	}
+	return WebhookActionCreated, alert, nil
+}
+
+// mapSeverity translates a raw severity through the mapping.
+func mapSeverity(mapping entity.WebhookMapping, raw string) entity.AlertSeverity {
+	if severity, ok := mapping.SeverityMap[raw]; ok {
+		return severity
+	}
+
+	if severity := entity.AlertSeverity(strings.ToLower(raw)); severity.IsValid() {
+		return severity
+	}
+
+	if mapping.DefaultSeverity != "" {
+		return mapping.DefaultSeverity
+	}
+
+	return entity.AlertSeverityMedium
+}
+
+// renderMapping evaluates a mapping field against a payload.
+// "$.path" selects a value, {{path}} placeholders are substituted and
+// anything else is returned as a literal.
+func renderMapping(payload interface{}, field string) string {
+	if field == "" {
+		return ""
+	}
+
+	if path, ok := strings.CutPrefix(field, "$."); ok {
+		return lookupPath(payload, path)
+	}
+
+	if !strings.Contains(field, "{{") {
+		return field
+	}
+
+	return templatePlaceholder.ReplaceAllStringFunc(field, func(placeholder string) string {
+		path := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+		return lookupPath(payload, strings.TrimPrefix(path, "$."))
+	})
+}
+
+// lookupPath resolves a dot-separated path (object keys and array indexes)
+// in a decoded JSON value and formats the result as a string.
+func lookupPath(value interface{}, path string) string {
+	for _, part := range strings.Split(path, ".") {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			value = node[part]
+		case []interface{}:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(node) {
+				return ""
+			}
+			value = node[index]
+		default:
+			return ""
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	}
+}
+
+// newWebhookToken generates a random webhook token and its hash.
+func newWebhookToken() (string, string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(raw)
+	return token, hashWebhookToken(token), nil
+}
+
+// hashWebhookToken returns the stored SHA-256 hash of a webhook token.
+func hashWebhookToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
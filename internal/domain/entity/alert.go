@@ -79,6 +79,69 @@ func (s AlertStatus) IsValid() bool {
 	}
 }
 
+// MetadataDedupKey is the metadata key holding an alert's deduplication key.
+// Integrations set it so repeated notifications for the same condition
+// update one open alert instead of creating new ones.
+const MetadataDedupKey = "dedup_key"
+
+// MetadataFlapping is the metadata key set on alerts that kept firing and
+// resolving when they were created or resolved.
+const MetadataFlapping = "flapping"
+
+// Metadata keys set by alert enrichment: the location of the source, the
+// runbook of the rule, and the owner of the source with its CMDB record.
+const (
+	MetadataGeo        = "geo"
+	MetadataRunbookURL = "runbook_url"
+	MetadataOwner      = "owner"
+	MetadataCMDB       = "cmdb"
+)
+
+// MetadataRemediation is the metadata key of the remediation steps copied
+// from the rule that fired an alert, next to its MetadataRunbookURL.
+const MetadataRemediation = "remediation"
+
+// Metadata keys set on alerts raised while a source they depend on had an
+// active critical alert: the downstream flag and the ID of that alert.
+const (
+	MetadataDownstream      = "downstream"
+	MetadataUpstreamAlertID = "upstream_alert_id"
+)
+
+// MetadataLabels is the metadata key of the labels of an alert, as populated
+// by the webhook integrations.
+const MetadataLabels = "labels"
+
+// Metadata keys set on alerts whose severity a severity rule changed when
+// they were ingested: the severity they were raised with and the rule.
+const (
+	MetadataOriginalSeverity = "original_severity"
+	MetadataSeverityRule     = "severity_rule"
+)
+
+// Metadata keys set on alerts an assignment rule assigned when they were
+// ingested: the user they were assigned to and the rule.
+const (
+	MetadataAssigneeID     = "assignee_id"
+	MetadataAssignmentRule = "assignment_rule"
+)
+
+// MetadataExternalIncidents is the metadata key of the incidents mirroring an
+// alert on on-call platforms, as the incident ID keyed by platform.
+const MetadataExternalIncidents = "external_incidents"
+
+// Metadata keys of the tickets filed for alerts: the flag set on the alerts
+// of rules that file tickets automatically, and the key of the filed issue.
+const (
+	MetadataCreateTicket = "create_ticket"
+	MetadataTicketKey    = "ticket_key"
+)
+
+// MetadataVoiceEscalation is the metadata key recording the escalation call
+// placed for an unacknowledged alert: the number called, the call ID and
+// when it was placed.
+const MetadataVoiceEscalation = "voice_escalation"
+
 // Alert represents an alert in the real-time alerting system.
 // It tracks the alert lifecycle from creation through resolution or expiration.
 type Alert struct {
@@ -86,6 +149,8 @@ type Alert struct {
 	ID ID `json:"id" db:"id"`
 	// RuleID references the alert rule that triggered this alert (nil if manually created).
 	RuleID *ID `json:"rule_id,omitempty" db:"rule_id"`
+	// TeamID is the team owning the alert (nil if shared with every team).
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
 	// Title is a brief description of the alert (max 255 characters).
 	Title string `json:"title" db:"title"`
 	// Message contains the detailed alert description.
@@ -96,6 +161,10 @@ type Alert struct {
 	Status AlertStatus `json:"status" db:"status"`
 	// Source identifies where the alert originated from.
 	Source string `json:"source,omitempty" db:"source"`
+	// Region identifies the deployment region that produced the alert.
+	Region string `json:"region,omitempty" db:"region"`
+	// Cluster identifies the deployment cluster that produced the alert.
+	Cluster string `json:"cluster,omitempty" db:"cluster"`
 	// Metadata stores additional key-value data associated with the alert.
 	Metadata map[string]interface{} `json:"metadata,omitempty" db:"metadata"`
 	// AcknowledgedBy is the ID of the user who acknowledged the alert.
@@ -108,6 +177,8 @@ type Alert struct {
 	ResolvedAt *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
 	// ExpiresAt is the optional expiration time for the alert.
 	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	// DeletedAt is the timestamp when the alert was moved to the trash (nil if not deleted).
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 	// CreatedAt is the timestamp when the alert was resolved.
 	// CreatedAt is the timestamp when the alert was created.
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
@@ -221,6 +292,23 @@ func (a *Alert) Resolve(userID ID) error {
 	return nil
 }
 
+// ResolveBySource marks the alert as resolved by its originating system
+// rather than by a user, e.g. when a monitoring tool reports recovery.
+// Returns ErrAlertAlreadyResolved if the alert is already resolved.
+func (a *Alert) ResolveBySource() error {
+	if a.Status == AlertStatusResolved {
+		return ErrAlertAlreadyResolved
+	}
+
+	now := time.Now().UTC()
+	a.Status = AlertStatusResolved
+	a.ResolvedBy = nil
+	a.ResolvedAt = &now
+	a.Touch()
+
+	return nil
+}
+
 // Expire marks the alert as expired.
 // Typically called by a background job when the alert passes its expiration time.
 func (a *Alert) Expire() {
@@ -244,6 +332,11 @@ func (a *Alert) IsExpired() bool {
 	return time.Now().UTC().After(*a.ExpiresAt)
 }
 
+// IsDeleted checks if the alert is in the trash.
+func (a *Alert) IsDeleted() bool {
+	return a.DeletedAt != nil
+}
+
 // AddMetadata adds a key-value pair to the alert's metadata.
 // Creates the metadata map if it doesn't exist.
 func (a *Alert) AddMetadata(key string, value interface{}) {
@@ -254,6 +347,155 @@ func (a *Alert) AddMetadata(key string, value interface{}) {
 	a.Touch()
 }
 
+// Fingerprint identifies the condition an alert reports across its
+// occurrences: its dedup key when it has one, otherwise its source, team
+// and title.
+func (a *Alert) Fingerprint() string {
+	if key, ok := a.Metadata[MetadataDedupKey].(string); ok && key != "" {
+		return key
+	}
+
+	team := ""
+	if a.TeamID != nil {
+		team = a.TeamID.String()
+	}
+	return a.Source + "|" + team + "|" + a.Title
+}
+
+// MarkFlapping flags the alert as flapping.
+func (a *Alert) MarkFlapping() {
+	a.AddMetadata(MetadataFlapping, true)
+}
+
+// IsFlapping reports whether the alert was flagged as flapping.
+func (a *Alert) IsFlapping() bool {
+	flapping, _ := a.Metadata[MetadataFlapping].(bool)
+	return flapping
+}
+
+// MarkDownstream flags the alert as a consequence of the upstream alert, an
+// active critical alert of a source it depends on.
+func (a *Alert) MarkDownstream(upstream *Alert) {
+	a.AddMetadata(MetadataDownstream, true)
+	a.AddMetadata(MetadataUpstreamAlertID, upstream.ID.String())
+}
+
+// Reclassify gives the alert the severity of a severity rule, recording the
+// severity it was raised with and the name of the rule. The first severity
+// is kept when the alert is re-classified again.
+func (a *Alert) Reclassify(severity AlertSeverity, rule string) {
+	if _, ok := a.Metadata[MetadataOriginalSeverity]; !ok {
+		a.AddMetadata(MetadataOriginalSeverity, string(a.Severity))
+	}
+	a.AddMetadata(MetadataSeverityRule, rule)
+	a.Severity = severity
+}
+
+// Assign gives the alert the team and user of an assignment rule, recording
+// the name of the rule. A team the alert already belongs to is kept, and a
+// nil user leaves the alert unassigned.
+func (a *Alert) Assign(teamID, userID *ID, rule string) {
+	if a.TeamID == nil && teamID != nil {
+		team := *teamID
+		a.TeamID = &team
+	}
+	if userID != nil {
+		a.AddMetadata(MetadataAssigneeID, userID.String())
+	}
+	a.AddMetadata(MetadataAssignmentRule, rule)
+}
+
+// AssigneeID returns the user the alert was assigned to, or nil when it is
+// unassigned.
+func (a *Alert) AssigneeID() *ID {
+	value, _ := a.Metadata[MetadataAssigneeID].(string)
+	if value == "" {
+		return nil
+	}
+	id, err := ParseID(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// IsDownstream reports whether the alert was flagged as downstream.
+func (a *Alert) IsDownstream() bool {
+	downstream, _ := a.Metadata[MetadataDownstream].(bool)
+	return downstream
+}
+
+// RunbookURL returns the runbook of the alert, copied from its rule or set
+// by enrichment, or "" when it has none.
+func (a *Alert) RunbookURL() string {
+	runbookURL, _ := a.Metadata[MetadataRunbookURL].(string)
+	return runbookURL
+}
+
+// Remediation returns the remediation steps of the alert, copied from its
+// rule, or "" when it has none.
+func (a *Alert) Remediation() string {
+	remediation, _ := a.Metadata[MetadataRemediation].(string)
+	return remediation
+}
+
+// Labels returns the labels of the alert as strings. Freshly created alerts
+// hold a map[string]string while alerts loaded from the database hold a
+// decoded JSON object.
+func (a *Alert) Labels() map[string]string {
+	return stringMap(a.Metadata[MetadataLabels])
+}
+
+// TicketKey returns the key of the issue filed for the alert, or "" when
+// none was filed.
+func (a *Alert) TicketKey() string {
+	key, _ := a.Metadata[MetadataTicketKey].(string)
+	return key
+}
+
+// IsVoiceEscalated reports whether an escalation call was placed for the alert.
+func (a *Alert) IsVoiceEscalated() bool {
+	_, ok := a.Metadata[MetadataVoiceEscalation]
+	return ok
+}
+
+// ExternalIncidents returns the IDs of the incidents mirroring the alert on
+// on-call platforms, keyed by platform, or nil if there are none.
+func (a *Alert) ExternalIncidents() map[string]string {
+	return stringMap(a.Metadata[MetadataExternalIncidents])
+}
+
+// stringMap reads a metadata map of strings, as set in memory or decoded
+// from JSON. Values that are not strings are skipped.
+func stringMap(value interface{}) map[string]string {
+	switch m := value.(type) {
+	case map[string]string:
+		return m
+	case map[string]interface{}:
+		result := make(map[string]string, len(m))
+		for key, value := range m {
+			if s, ok := value.(string); ok {
+				result[key] = s
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+// SetOrigin stamps the deployment region and cluster that produced the alert.
+// Values already present are kept so that alerts forwarded from another
+// deployment retain their original identity.
+func (a *Alert) SetOrigin(region, cluster string) {
+	if a.Region == "" {
+		a.Region = region
+	}
+	if a.Cluster == "" {
+		a.Cluster = cluster
+	}
+}
+
 // IsCritical checks if the alert has critical severity.
 // Returns true if the severity is AlertSeverityCritical.
 func (a *Alert) IsCritical() bool {
@@ -2,9 +2,14 @@ package entity
 
 import (
 	"errors"
+	"net/url"
+	"time"
 )
 
 // AlertRule define las condiciones para disparar alertas automáticamente.
+// RunbookURL y Remediation indican a quien responde qué hacer, y se copian
+// a las alertas que dispara la regla. Con CreateTicket, cada alerta que
+// dispara la regla abre un ticket en el gestor de incidencias.
 type AlertRule struct {
 	ID              ID            `json:"id" db:"id"`
 	Name            string        `json:"name" db:"name"`
@@ -14,18 +19,14 @@ type AlertRule struct {
 	IsEnabled       bool          `json:"is_enabled" db:"is_enabled"`
 	CooldownMinutes int           `json:"cooldown_minutes" db:"cooldown_minutes"`
 	CreatedBy       *ID           `json:"created_by,omitempty" db:"created_by"`
+	TeamID          *ID           `json:"team_id,omitempty" db:"team_id"`
+	ExternalID      string        `json:"external_id,omitempty" db:"external_id"`
+	RunbookURL      string        `json:"runbook_url,omitempty" db:"runbook_url"`
+	Remediation     string        `json:"remediation,omitempty" db:"remediation"`
+	CreateTicket    bool          `json:"create_ticket" db:"create_ticket"`
 	Timestamps
 }
 
-// RuleCondition define la condición que dispara la regla.
-// Se almacena como JSON en la base de datos.
-type RuleCondition struct {
-	Metric      string  `json:"metric"`
-	Operator    string  `json:"operator"`
-	Threshold   float64 `json:"threshold"`
-	Consecutive int     `json:"consecutive"`
-}
-
 // Errores de validación de reglas.
 var (
 	ErrRuleNameRequired      = errors.New("rule name is required")
@@ -35,17 +36,17 @@ var (
 	ErrRuleConditionRequired = errors.New("rule condition is required")
 	ErrRuleInvalidOperator   = errors.New("invalid operator, must be one of: >, <, ==, >=, <=, !=")
 	ErrRuleMetricRequired    = errors.New("condition metric is required")
-)
+	ErrRuleExternalIDTooLong = errors.New("rule external ID must be less than 256 characters")
 
-// Operadores válidos para las condiciones.
-var validOperators = map[string]bool{
-	">":  true,
-	"<":  true,
-	"==": true,
-	">=": true,
-	"<=": true,
-	"!=": true,
-}
+	ErrRuleInvalidRunbookURL  = errors.New("rule runbook URL must be an absolute http or https URL")
+	ErrRuleRemediationTooLong = errors.New("rule remediation must be less than 4001 characters")
+
+	ErrRuleInvalidConditionType = errors.New("invalid condition type, must be one of: threshold, rate, anomaly, absent, and, or, not")
+	ErrRuleConditionOperands    = errors.New("and/or conditions take at least two conditions, not exactly one, comparisons none")
+	ErrRuleConditionTooDeep     = errors.New("conditions must not be nested more than 5 levels deep")
+	ErrRuleAbsenceWindowInvalid = errors.New("absent conditions require for_seconds between 1 and 86400")
+	ErrRuleAnomalyInvalid       = errors.New("anomaly conditions require positive deviations and window_seconds between 60 and 86400")
+)
 
 // NewAlertRule crea una nueva regla de alerta.
 func NewAlertRule(name, description string, condition RuleCondition, severity AlertSeverity, createdBy *ID) (*AlertRule, error) {
@@ -86,22 +87,24 @@ func (r *AlertRule) Validate() error {
 		return ErrRuleInvalidCooldown
 	}
 
-	// Validar condición
-	if err := r.Condition.Validate(); err != nil {
-		return err
+	if len(r.ExternalID) > 255 {
+		return ErrRuleExternalIDTooLong
 	}
 
-	return nil
-}
+	if r.RunbookURL != "" {
+		runbook, err := url.Parse(r.RunbookURL)
+		if err != nil || (runbook.Scheme != "http" && runbook.Scheme != "https") || runbook.Host == "" {
+			return ErrRuleInvalidRunbookURL
+		}
+	}
 
-// Validate verifica que la condición sea válida.
-func (c *RuleCondition) Validate() error {
-	if c.Metric == "" {
-		return ErrRuleMetricRequired
+	if len(r.Remediation) > 4000 {
+		return ErrRuleRemediationTooLong
 	}
 
-	if !validOperators[c.Operator] {
-		return ErrRuleInvalidOperator
+	// Validar condición
+	if err := r.Condition.Validate(); err != nil {
+		return err
 	}
 
 	return nil
@@ -131,25 +134,20 @@ func (r *AlertRule) SetCooldown(minutes int) error {
 
 // Evaluate evalúa si un valor cumple la condición de la regla.
 // Retorna true si la condición se cumple (debería dispararse una alerta).
+// El valor se toma como la última muestra de la métrica de la condición;
+// las condiciones compuestas se evalúan con EvaluateMetrics.
 func (r *AlertRule) Evaluate(value float64) bool {
-	if !r.IsEnabled {
-		return false
-	}
+	now := time.Now()
+	return r.EvaluateMetrics(map[string]MetricState{
+		r.Condition.Metric: {Value: value, SeenAt: now},
+	}, now)
+}
 
-	switch r.Condition.Operator {
-	case ">":
-		return value > r.Condition.Threshold
-	case "<":
-		return value < r.Condition.Threshold
-	case "==":
-		return value == r.Condition.Threshold
-	case ">=":
-		return value >= r.Condition.Threshold
-	case "<=":
-		return value <= r.Condition.Threshold
-	case "!=":
-		return value != r.Condition.Threshold
-	default:
+// EvaluateMetrics evalúa la condición de la regla con el último estado de
+// cada métrica de una serie.
+func (r *AlertRule) EvaluateMetrics(states map[string]MetricState, now time.Time) bool {
+	if !r.IsEnabled {
 		return false
 	}
+	return r.Condition.Matches(states, now)
 }
@@ -0,0 +1,116 @@
+package entity
+
+import "errors"
+
+// Alert view bounds.
+const (
+	AlertViewNameMaxLength   = 100
+	AlertViewSearchMaxLength = 255
+	AlertViewMaxLabels       = 20
+)
+
+// AlertViewCriteria selects the alerts of a view. Every set criterion must
+// match; empty criteria match every alert.
+type AlertViewCriteria struct {
+	// Statuses matches any of the listed statuses.
+	Statuses []AlertStatus `json:"statuses,omitempty"`
+	// Severities matches any of the listed severities.
+	Severities []AlertSeverity `json:"severities,omitempty"`
+	// Labels matches alerts carrying every listed label value.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Search matches alerts whose title or message contains the text.
+	Search string `json:"search,omitempty"`
+}
+
+// AlertView is a named combination of alert filters saved by a user, private
+// to its owner unless shared with one of the owner's teams. Dashboards list
+// its alerts and stream the matching ones over WebSocket.
+type AlertView struct {
+	// ID is the unique identifier of the view.
+	ID ID `json:"id" db:"id"`
+	// Name is the human-readable name of the view.
+	Name string `json:"name" db:"name"`
+	// Criteria selects the alerts of the view.
+	Criteria AlertViewCriteria `json:"criteria" db:"criteria"`
+	// OwnerID is the user who saved the view.
+	OwnerID ID `json:"owner_id" db:"owner_id"`
+	// TeamID is the team the view is shared with (nil if private).
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Alert view validation errors.
+var (
+	// ErrAlertViewNameRequired is returned when the view name is empty.
+	ErrAlertViewNameRequired = errors.New("view name is required")
+	// ErrAlertViewNameTooLong is returned when the view name exceeds 100 characters.
+	ErrAlertViewNameTooLong = errors.New("view name must be less than 101 characters")
+	// ErrAlertViewInvalidStatus is returned when the criteria refer to an unknown status.
+	ErrAlertViewInvalidStatus = errors.New("view criteria contain an invalid status")
+	// ErrAlertViewInvalidSeverity is returned when the criteria refer to an unknown severity.
+	ErrAlertViewInvalidSeverity = errors.New("view criteria contain an invalid severity")
+	// ErrAlertViewInvalidLabels is returned for empty label names or more than 20 labels.
+	ErrAlertViewInvalidLabels = errors.New("view criteria must have at most 20 labels with non-empty names")
+	// ErrAlertViewSearchTooLong is returned when the search text exceeds 255 characters.
+	ErrAlertViewSearchTooLong = errors.New("view search must be less than 256 characters")
+)
+
+// NewAlertView creates a new alert view and validates it.
+func NewAlertView(name string, criteria AlertViewCriteria, ownerID ID, teamID *ID) (*AlertView, error) {
+	view := &AlertView{
+		ID:         NewID(),
+		Name:       name,
+		Criteria:   criteria,
+		OwnerID:    ownerID,
+		TeamID:     teamID,
+		Timestamps: NewTimestamps(),
+	}
+
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// Validate checks that the view has valid data.
+func (v *AlertView) Validate() error {
+	if v.Name == "" {
+		return ErrAlertViewNameRequired
+	}
+	if len(v.Name) > AlertViewNameMaxLength {
+		return ErrAlertViewNameTooLong
+	}
+
+	for _, status := range v.Criteria.Statuses {
+		if !status.IsValid() {
+			return ErrAlertViewInvalidStatus
+		}
+	}
+	for _, severity := range v.Criteria.Severities {
+		if !severity.IsValid() {
+			return ErrAlertViewInvalidSeverity
+		}
+	}
+
+	if len(v.Criteria.Labels) > AlertViewMaxLabels {
+		return ErrAlertViewInvalidLabels
+	}
+	for key := range v.Criteria.Labels {
+		if key == "" {
+			return ErrAlertViewInvalidLabels
+		}
+	}
+
+	if len(v.Criteria.Search) > AlertViewSearchMaxLength {
+		return ErrAlertViewSearchTooLong
+	}
+
+	return nil
+}
+
+// IsShared reports whether the view is shared with a team.
+func (v *AlertView) IsShared() bool {
+	return v.TeamID != nil
+}
@@ -0,0 +1,120 @@
+package entity
+
+import "errors"
+
+// Assignment rule bounds.
+const (
+	AssignmentRuleNameMaxLength = 100
+	AssignmentRuleMaxLabels     = 20
+)
+
+// AssignmentRuleMatcher selects the alerts an assignment rule applies to.
+// Every set criterion must match; at least one must be set.
+type AssignmentRuleMatcher struct {
+	// Sources matches any of the listed alert sources.
+	Sources []string `json:"sources,omitempty"`
+	// Labels matches alerts carrying every listed label value.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// IsEmpty reports whether the matcher sets no criterion.
+func (m AssignmentRuleMatcher) IsEmpty() bool {
+	return len(m.Sources) == 0 && len(m.Labels) == 0
+}
+
+// AssignmentRule assigns the alerts it matches to a team, a user or both
+// when they are ingested, e.g. alerts labelled service=payments to the
+// payments team. Rules are evaluated by position and the first enabled
+// matching rule applies.
+type AssignmentRule struct {
+	// ID is the unique identifier of the rule.
+	ID ID `json:"id" db:"id"`
+	// Name is the unique, human-readable name of the rule.
+	Name string `json:"name" db:"name"`
+	// Position orders the rules; lower positions are evaluated first.
+	Position int `json:"position" db:"position"`
+	// Match selects the alerts the rule applies to.
+	Match AssignmentRuleMatcher `json:"match" db:"match"`
+	// TeamID is the team matching alerts are assigned to, if any.
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
+	// UserID is the user matching alerts are assigned to, if any.
+	UserID *ID `json:"user_id,omitempty" db:"user_id"`
+	// IsEnabled indicates whether the rule applies to new alerts.
+	IsEnabled bool `json:"is_enabled" db:"is_enabled"`
+	// CreatedBy is the optional ID of the admin who created the rule.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Assignment rule validation errors.
+var (
+	// ErrAssignmentRuleNameRequired is returned when the rule name is empty.
+	ErrAssignmentRuleNameRequired = errors.New("assignment rule name is required")
+	// ErrAssignmentRuleNameTooLong is returned when the rule name exceeds 100 characters.
+	ErrAssignmentRuleNameTooLong = errors.New("assignment rule name must be less than 101 characters")
+	// ErrAssignmentRuleMatchRequired is returned when the matcher sets no criterion.
+	ErrAssignmentRuleMatchRequired = errors.New("assignment rule must match on at least one of sources or labels")
+	// ErrAssignmentRuleInvalidLabels is returned for empty label names or more than 20 labels.
+	ErrAssignmentRuleInvalidLabels = errors.New("assignment rule matcher must have at most 20 labels with non-empty names")
+	// ErrAssignmentRuleAssigneeRequired is returned when the rule assigns to neither a team nor a user.
+	ErrAssignmentRuleAssigneeRequired = errors.New("assignment rule must assign to a team, a user or both")
+)
+
+// NewAssignmentRule creates a new, enabled assignment rule and validates it.
+func NewAssignmentRule(
+	name string,
+	match AssignmentRuleMatcher,
+	teamID *ID,
+	userID *ID,
+	position int,
+	createdBy *ID,
+) (*AssignmentRule, error) {
+	rule := &AssignmentRule{
+		ID:         NewID(),
+		Name:       name,
+		Position:   position,
+		Match:      match,
+		TeamID:     teamID,
+		UserID:     userID,
+		IsEnabled:  true,
+		CreatedBy:  createdBy,
+		Timestamps: NewTimestamps(),
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// Validate checks that the rule has valid data.
+func (r *AssignmentRule) Validate() error {
+	if r.Name == "" {
+		return ErrAssignmentRuleNameRequired
+	}
+
+	if len(r.Name) > AssignmentRuleNameMaxLength {
+		return ErrAssignmentRuleNameTooLong
+	}
+
+	if r.Match.IsEmpty() {
+		return ErrAssignmentRuleMatchRequired
+	}
+
+	if len(r.Match.Labels) > AssignmentRuleMaxLabels {
+		return ErrAssignmentRuleInvalidLabels
+	}
+	for key := range r.Match.Labels {
+		if key == "" {
+			return ErrAssignmentRuleInvalidLabels
+		}
+	}
+
+	if r.TeamID == nil && r.UserID == nil {
+		return ErrAssignmentRuleAssigneeRequired
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package entity
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// CheckStatus represents the state of an uptime check.
+type CheckStatus string
+
+// Check statuses. A check is unknown until it first ran, up while its probes
+// succeed, and down once FailureThreshold consecutive probes failed.
+const (
+	CheckStatusUnknown CheckStatus = "unknown"
+	CheckStatusUp      CheckStatus = "up"
+	CheckStatusDown    CheckStatus = "down"
+)
+
+// Check bounds.
+const (
+	MinCheckInterval         = 30
+	MaxCheckInterval         = 86400
+	MaxCheckTimeout          = 60
+	MaxCheckFailureThreshold = 10
+)
+
+// checkMethods are the HTTP methods a check may probe with.
+var checkMethods = map[string]bool{
+	http.MethodGet:  true,
+	http.MethodHead: true,
+	http.MethodPost: true,
+}
+
+// Check is a synthetic HTTP uptime check: URL is requested with Method every
+// Interval, and the probe succeeds when the response has ExpectedStatus
+// within Timeout. An alert is raised once FailureThreshold consecutive probes
+// failed, and resolved by the next successful probe.
+type Check struct {
+	// ID is the unique identifier of the check.
+	ID ID `json:"id" db:"id"`
+	// Name is the human-readable name of the check.
+	Name string `json:"name" db:"name"`
+	// URL is the http or https URL probed.
+	URL string `json:"url" db:"url"`
+	// Method is the HTTP method of the probes: GET, HEAD or POST.
+	Method string `json:"method" db:"method"`
+	// ExpectedStatus is the response status of a successful probe.
+	ExpectedStatus int `json:"expected_status" db:"expected_status"`
+	// IntervalSeconds is how often the URL is probed.
+	IntervalSeconds int `json:"interval_seconds" db:"interval_seconds"`
+	// TimeoutSeconds is how long a probe may take before it fails.
+	TimeoutSeconds int `json:"timeout_seconds" db:"timeout_seconds"`
+	// FailureThreshold is how many consecutive probes must fail before the check is down.
+	FailureThreshold int `json:"failure_threshold" db:"failure_threshold"`
+	// Severity is the severity of the alert raised when the check is down.
+	Severity AlertSeverity `json:"severity" db:"severity"`
+	// Status is unknown, up or down.
+	Status CheckStatus `json:"status" db:"status"`
+	// ConsecutiveFailures counts the failed probes since the last success.
+	ConsecutiveFailures int `json:"consecutive_failures" db:"consecutive_failures"`
+	// LastCheckedAt is when the check was last probed.
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty" db:"last_checked_at"`
+	// IsEnabled indicates whether the check is probed.
+	IsEnabled bool `json:"is_enabled" db:"is_enabled"`
+	// TeamID is the team owning the alerts of the check.
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
+	// CreatedBy is the optional ID of the user who created the check.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// CheckResult is the outcome of one probe of a check.
+type CheckResult struct {
+	// ID is the unique identifier of the result.
+	ID ID `json:"id" db:"id"`
+	// CheckID is the check probed.
+	CheckID ID `json:"check_id" db:"check_id"`
+	// Success reports whether the response had the expected status in time.
+	Success bool `json:"success" db:"success"`
+	// StatusCode is the response status, zero when no response was received.
+	StatusCode int `json:"status_code,omitempty" db:"status_code"`
+	// LatencyMs is how long the probe took, in milliseconds.
+	LatencyMs int64 `json:"latency_ms" db:"latency_ms"`
+	// Error describes why the probe failed.
+	Error string `json:"error,omitempty" db:"error"`
+	// CheckedAt is when the probe started.
+	CheckedAt time.Time `json:"checked_at" db:"checked_at"`
+}
+
+// CheckTransition is the change of status caused by a probe result.
+type CheckTransition int
+
+// Check transitions.
+const (
+	// CheckUnchanged means the status did not change between up and down.
+	CheckUnchanged CheckTransition = iota
+	// CheckWentDown means the check reached its failure threshold.
+	CheckWentDown
+	// CheckRecovered means a down check succeeded again.
+	CheckRecovered
+)
+
+// Check validation errors.
+var (
+	// ErrCheckNameRequired is returned when the check name is empty.
+	ErrCheckNameRequired = errors.New("check name is required")
+	// ErrCheckNameTooLong is returned when the check name exceeds 100 characters.
+	ErrCheckNameTooLong = errors.New("check name must be less than 101 characters")
+	// ErrCheckInvalidURL is returned when the URL is not an absolute http or https URL.
+	ErrCheckInvalidURL = errors.New("check URL must be an absolute http or https URL")
+	// ErrCheckInvalidMethod is returned for methods other than GET, HEAD and POST.
+	ErrCheckInvalidMethod = errors.New("check method must be GET, HEAD or POST")
+	// ErrCheckInvalidStatus is returned when the expected status is not an HTTP status.
+	ErrCheckInvalidStatus = errors.New("check expected status must be between 100 and 599")
+	// ErrCheckInvalidInterval is returned when the interval is out of bounds.
+	ErrCheckInvalidInterval = errors.New("check interval must be between 30 seconds and 1 day")
+	// ErrCheckInvalidTimeout is returned when the timeout is out of bounds or not shorter than the interval.
+	ErrCheckInvalidTimeout = errors.New("check timeout must be between 1 and 60 seconds and shorter than the interval")
+	// ErrCheckInvalidThreshold is returned when the failure threshold is out of bounds.
+	ErrCheckInvalidThreshold = errors.New("check failure threshold must be between 1 and 10")
+	// ErrCheckInvalidSeverity is returned when the alert severity is unknown.
+	ErrCheckInvalidSeverity = errors.New("invalid check severity")
+)
+
+// NewCheck creates a new, enabled check that has not run yet and validates
+// it.
+func NewCheck(
+	name, rawURL, method string,
+	expectedStatus, intervalSeconds, timeoutSeconds, failureThreshold int,
+	severity AlertSeverity,
+	createdBy *ID,
+) (*Check, error) {
+	check := &Check{
+		ID:               NewID(),
+		Name:             name,
+		URL:              rawURL,
+		Method:           method,
+		ExpectedStatus:   expectedStatus,
+		IntervalSeconds:  intervalSeconds,
+		TimeoutSeconds:   timeoutSeconds,
+		FailureThreshold: failureThreshold,
+		Severity:         severity,
+		Status:           CheckStatusUnknown,
+		IsEnabled:        true,
+		CreatedBy:        createdBy,
+		Timestamps:       NewTimestamps(),
+	}
+
+	if err := check.Validate(); err != nil {
+		return nil, err
+	}
+
+	return check, nil
+}
+
+// Validate checks that the check has valid data.
+func (c *Check) Validate() error {
+	if c.Name == "" {
+		return ErrCheckNameRequired
+	}
+
+	if len(c.Name) > 100 {
+		return ErrCheckNameTooLong
+	}
+
+	target, err := url.Parse(c.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return ErrCheckInvalidURL
+	}
+
+	if !checkMethods[c.Method] {
+		return ErrCheckInvalidMethod
+	}
+
+	if c.ExpectedStatus < 100 || c.ExpectedStatus > 599 {
+		return ErrCheckInvalidStatus
+	}
+
+	if c.IntervalSeconds < MinCheckInterval || c.IntervalSeconds > MaxCheckInterval {
+		return ErrCheckInvalidInterval
+	}
+
+	if c.TimeoutSeconds < 1 || c.TimeoutSeconds > MaxCheckTimeout || c.TimeoutSeconds >= c.IntervalSeconds {
+		return ErrCheckInvalidTimeout
+	}
+
+	if c.FailureThreshold < 1 || c.FailureThreshold > MaxCheckFailureThreshold {
+		return ErrCheckInvalidThreshold
+	}
+
+	if !c.Severity.IsValid() {
+		return ErrCheckInvalidSeverity
+	}
+
+	return nil
+}
+
+// Interval returns how often the check is probed.
+func (c *Check) Interval() time.Duration {
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+// Timeout returns how long a probe may take.
+func (c *Check) Timeout() time.Duration {
+	return time.Duration(c.TimeoutSeconds) * time.Second
+}
+
+// IsDue reports whether the check should be probed at now.
+func (c *Check) IsDue(now time.Time) bool {
+	if !c.IsEnabled {
+		return false
+	}
+	return c.LastCheckedAt == nil || !now.Before(c.LastCheckedAt.Add(c.Interval()))
+}
+
+// Succeeds reports whether a response status is the expected one.
+func (c *Check) Succeeds(statusCode int) bool {
+	return statusCode == c.ExpectedStatus
+}
+
+// Record applies the result of a probe and returns the resulting change of
+// status.
+func (c *Check) Record(result CheckResult) CheckTransition {
+	checkedAt := result.CheckedAt
+	c.LastCheckedAt = &checkedAt
+	c.Touch()
+
+	if result.Success {
+		wasDown := c.Status == CheckStatusDown
+		c.ConsecutiveFailures = 0
+		c.Status = CheckStatusUp
+		if wasDown {
+			return CheckRecovered
+		}
+		return CheckUnchanged
+	}
+
+	c.ConsecutiveFailures++
+	if c.Status != CheckStatusDown && c.ConsecutiveFailures >= c.FailureThreshold {
+		c.Status = CheckStatusDown
+		return CheckWentDown
+	}
+	return CheckUnchanged
+}
+
+// Enable resumes probing.
+func (c *Check) Enable() {
+	c.IsEnabled = true
+	c.Touch()
+}
+
+// Disable stops probing, e.g. during planned downtime. The status is kept.
+func (c *Check) Disable() {
+	c.IsEnabled = false
+	c.Touch()
+}
+
+// DedupKey returns the dedup key of the alerts raised for the check.
+func (c *Check) DedupKey() string {
+	return "check:" + c.ID.String()
+}
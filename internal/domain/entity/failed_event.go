@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// FailedEventStatus defines what an operator did with a dead-lettered event.
+type FailedEventStatus string
+
+// Failed event status constants.
+const (
+	// FailedEventStatusPending indicates the event waits for an operator decision.
+	FailedEventStatusPending FailedEventStatus = "pending"
+	// FailedEventStatusRetried indicates the event was published again.
+	FailedEventStatusRetried FailedEventStatus = "retried"
+	// FailedEventStatusIgnored indicates the event was dismissed.
+	FailedEventStatusIgnored FailedEventStatus = "ignored"
+)
+
+// IsValid checks if the status is a valid FailedEventStatus value.
+func (s FailedEventStatus) IsValid() bool {
+	switch s {
+	case FailedEventStatusPending, FailedEventStatusRetried, FailedEventStatusIgnored:
+		return true
+	default:
+		return false
+	}
+}
+
+// Failed event state transition errors.
+var (
+	// ErrFailedEventAlreadyRetried is returned when retrying an event that was already retried.
+	ErrFailedEventAlreadyRetried = errors.New("failed event was already retried")
+	// ErrFailedEventNotPending is returned when ignoring an event that is no longer pending.
+	ErrFailedEventNotPending = errors.New("failed event is not pending")
+)
+
+// FailedEvent is an event that exhausted its delivery attempts and was moved
+// to the dead letter queue, kept for analysis and manual retry.
+type FailedEvent struct {
+	// ID is the unique identifier of the record.
+	ID ID `json:"id" db:"id"`
+	// EventID is the ID of the dead-lettered event (one record per event).
+	EventID string `json:"event_id" db:"event_id"`
+	// EventType is the type of the dead-lettered event.
+	EventType string `json:"event_type" db:"event_type"`
+	// Payload is the original event payload.
+	Payload json.RawMessage `json:"payload" db:"payload"`
+	// Retries is how many times the event was delivered before it was dead-lettered.
+	Retries int `json:"retries" db:"retries"`
+	// LastError is the error returned by the last failed delivery, if known.
+	LastError string `json:"last_error,omitempty" db:"last_error"`
+	// Status is what an operator did with the event.
+	Status FailedEventStatus `json:"status" db:"status"`
+	// FailedAt is when the event was dead-lettered.
+	FailedAt time.Time `json:"failed_at" db:"failed_at"`
+	// ProcessedAt is when the event was retried or ignored.
+	ProcessedAt *time.Time `json:"processed_at,omitempty" db:"processed_at"`
+}
+
+// NewFailedEvent creates a pending failed event record.
+func NewFailedEvent(eventID, eventType string, payload json.RawMessage, retries int, lastError string) *FailedEvent {
+	return &FailedEvent{
+		ID:        NewID(),
+		EventID:   eventID,
+		EventType: eventType,
+		Payload:   payload,
+		Retries:   retries,
+		LastError: lastError,
+		Status:    FailedEventStatusPending,
+		FailedAt:  time.Now().UTC(),
+	}
+}
+
+// MarkRetried records that the event was published again.
+// Ignored events may still be retried.
+func (e *FailedEvent) MarkRetried() error {
+	if e.Status == FailedEventStatusRetried {
+		return ErrFailedEventAlreadyRetried
+	}
+	e.process(FailedEventStatusRetried)
+	return nil
+}
+
+// MarkIgnored dismisses a pending event.
+func (e *FailedEvent) MarkIgnored() error {
+	if e.Status != FailedEventStatusPending {
+		return ErrFailedEventNotPending
+	}
+	e.process(FailedEventStatusIgnored)
+	return nil
+}
+
+// process moves the event to a final status.
+func (e *FailedEvent) process(status FailedEventStatus) {
+	now := time.Now().UTC()
+	e.Status = status
+	e.ProcessedAt = &now
+}
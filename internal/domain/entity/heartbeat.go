@@ -0,0 +1,180 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// HeartbeatStatus represents the state of a heartbeat.
+type HeartbeatStatus string
+
+// Heartbeat statuses. A heartbeat is new until its first ping, up while it
+// is pinged in time, and down once a ping is overdue.
+const (
+	HeartbeatStatusNew  HeartbeatStatus = "new"
+	HeartbeatStatusUp   HeartbeatStatus = "up"
+	HeartbeatStatusDown HeartbeatStatus = "down"
+)
+
+// Heartbeat bounds, in seconds.
+const (
+	MinHeartbeatInterval = 60
+	MaxHeartbeatInterval = 30 * 24 * 3600
+)
+
+// Heartbeat is a dead man's switch: a scheduled job pings it through a
+// tokenized URL at least every Interval, and an alert is raised when no ping
+// arrives within Interval plus Grace. The alert is resolved by the next ping.
+type Heartbeat struct {
+	// ID is the unique identifier of the heartbeat.
+	ID ID `json:"id" db:"id"`
+	// Name is the human-readable name of the monitored job.
+	Name string `json:"name" db:"name"`
+	// TokenHash is the SHA-256 hash of the ping token; the token itself is never stored.
+	TokenHash string `json:"-" db:"token_hash"`
+	// IntervalSeconds is how often the job is expected to ping.
+	IntervalSeconds int `json:"interval_seconds" db:"interval_seconds"`
+	// GraceSeconds is how late a ping may be before the heartbeat is down.
+	GraceSeconds int `json:"grace_seconds" db:"grace_seconds"`
+	// Severity is the severity of the alert raised when a ping is missed.
+	Severity AlertSeverity `json:"severity" db:"severity"`
+	// Status is new, up or down.
+	Status HeartbeatStatus `json:"status" db:"status"`
+	// LastPingAt is when the heartbeat was last pinged.
+	LastPingAt *time.Time `json:"last_ping_at,omitempty" db:"last_ping_at"`
+	// IsEnabled indicates whether pings are expected; disabled heartbeats never go down.
+	IsEnabled bool `json:"is_enabled" db:"is_enabled"`
+	// TeamID is the team owning the alerts of the heartbeat.
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
+	// CreatedBy is the optional ID of the user who created the heartbeat.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Heartbeat validation errors.
+var (
+	// ErrHeartbeatNameRequired is returned when the heartbeat name is empty.
+	ErrHeartbeatNameRequired = errors.New("heartbeat name is required")
+	// ErrHeartbeatNameTooLong is returned when the heartbeat name exceeds 100 characters.
+	ErrHeartbeatNameTooLong = errors.New("heartbeat name must be less than 101 characters")
+	// ErrHeartbeatInvalidInterval is returned when the interval is out of bounds.
+	ErrHeartbeatInvalidInterval = errors.New("heartbeat interval must be between 60 seconds and 30 days")
+	// ErrHeartbeatInvalidGrace is returned when the grace period is negative or longer than the maximum interval.
+	ErrHeartbeatInvalidGrace = errors.New("heartbeat grace must be between 0 seconds and 30 days")
+	// ErrHeartbeatInvalidSeverity is returned when the alert severity is unknown.
+	ErrHeartbeatInvalidSeverity = errors.New("invalid heartbeat severity")
+)
+
+// NewHeartbeat creates a new, enabled heartbeat awaiting its first ping and
+// validates it.
+func NewHeartbeat(
+	name, tokenHash string,
+	intervalSeconds, graceSeconds int,
+	severity AlertSeverity,
+	createdBy *ID,
+) (*Heartbeat, error) {
+	heartbeat := &Heartbeat{
+		ID:              NewID(),
+		Name:            name,
+		TokenHash:       tokenHash,
+		IntervalSeconds: intervalSeconds,
+		GraceSeconds:    graceSeconds,
+		Severity:        severity,
+		Status:          HeartbeatStatusNew,
+		IsEnabled:       true,
+		CreatedBy:       createdBy,
+		Timestamps:      NewTimestamps(),
+	}
+
+	if err := heartbeat.Validate(); err != nil {
+		return nil, err
+	}
+
+	return heartbeat, nil
+}
+
+// Validate checks that the heartbeat has valid data.
+func (h *Heartbeat) Validate() error {
+	if h.Name == "" {
+		return ErrHeartbeatNameRequired
+	}
+
+	if len(h.Name) > 100 {
+		return ErrHeartbeatNameTooLong
+	}
+
+	if h.IntervalSeconds < MinHeartbeatInterval || h.IntervalSeconds > MaxHeartbeatInterval {
+		return ErrHeartbeatInvalidInterval
+	}
+
+	if h.GraceSeconds < 0 || h.GraceSeconds > MaxHeartbeatInterval {
+		return ErrHeartbeatInvalidGrace
+	}
+
+	if !h.Severity.IsValid() {
+		return ErrHeartbeatInvalidSeverity
+	}
+
+	return nil
+}
+
+// Ping records a ping and reports whether it brings the heartbeat back up
+// from down.
+func (h *Heartbeat) Ping(at time.Time) bool {
+	recovered := h.Status == HeartbeatStatusDown
+	h.LastPingAt = &at
+	h.Status = HeartbeatStatusUp
+	h.Touch()
+	return recovered
+}
+
+// Deadline returns when the next ping is due at the latest, and false when
+// no ping is expected: before the first ping or while disabled.
+func (h *Heartbeat) Deadline() (time.Time, bool) {
+	if !h.IsEnabled || h.LastPingAt == nil || h.Status == HeartbeatStatusNew {
+		return time.Time{}, false
+	}
+	grace := time.Duration(h.IntervalSeconds+h.GraceSeconds) * time.Second
+	return h.LastPingAt.Add(grace), true
+}
+
+// IsOverdue reports whether the heartbeat is up but missed its deadline.
+func (h *Heartbeat) IsOverdue(now time.Time) bool {
+	deadline, ok := h.Deadline()
+	return ok && h.Status == HeartbeatStatusUp && now.After(deadline)
+}
+
+// MarkDown records that a ping was missed.
+func (h *Heartbeat) MarkDown() {
+	h.Status = HeartbeatStatusDown
+	h.Touch()
+}
+
+// RotateToken replaces the token hash, invalidating the previous URL.
+func (h *Heartbeat) RotateToken(tokenHash string) {
+	h.TokenHash = tokenHash
+	h.Touch()
+}
+
+// Enable starts expecting pings again. A heartbeat that was up waits for
+// its next ping before it can go down; one that was down stays down until
+// pinged.
+func (h *Heartbeat) Enable() {
+	if !h.IsEnabled && h.Status == HeartbeatStatusUp {
+		h.Status = HeartbeatStatusNew
+	}
+	h.IsEnabled = true
+	h.Touch()
+}
+
+// Disable stops expecting pings, e.g. while the job is paused.
+func (h *Heartbeat) Disable() {
+	h.IsEnabled = false
+	h.Touch()
+}
+
+// DedupKey returns the dedup key of the alerts raised for the heartbeat.
+func (h *Heartbeat) DedupKey() string {
+	return "heartbeat:" + h.ID.String()
+}
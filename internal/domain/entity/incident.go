@@ -0,0 +1,249 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// IncidentStatus represents the lifecycle state of an incident.
+type IncidentStatus string
+
+// Incident statuses. An incident is open while responders work on it,
+// mitigated once its impact stopped and closed when the work is done.
+const (
+	IncidentStatusOpen      IncidentStatus = "open"
+	IncidentStatusMitigated IncidentStatus = "mitigated"
+	IncidentStatusClosed    IncidentStatus = "closed"
+)
+
+// IsValid checks if the status is a valid IncidentStatus value.
+func (s IncidentStatus) IsValid() bool {
+	switch s {
+	case IncidentStatusOpen, IncidentStatusMitigated, IncidentStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IncidentEventKind is the kind of an entry of an incident's timeline.
+type IncidentEventKind string
+
+// Incident event kinds.
+const (
+	IncidentEventCreated          IncidentEventKind = "created"
+	IncidentEventUpdated          IncidentEventKind = "updated"
+	IncidentEventStatusChanged    IncidentEventKind = "status_changed"
+	IncidentEventCommanderChanged IncidentEventKind = "commander_changed"
+	IncidentEventAlertAdded       IncidentEventKind = "alert_added"
+	IncidentEventAlertRemoved     IncidentEventKind = "alert_removed"
+	IncidentEventNote             IncidentEventKind = "note"
+)
+
+// Incident bounds.
+const (
+	MaxIncidentTitleLength   = 255
+	MaxIncidentSummaryLength = 10000
+	MaxIncidentNoteLength    = 10000
+)
+
+// Incident groups related alerts, added by hand or correlated automatically,
+// under a single lifecycle: open, mitigated and closed. A commander leads
+// the response, and the timeline records what happened.
+type Incident struct {
+	// ID is the unique identifier of the incident.
+	ID ID `json:"id" db:"id"`
+	// Title is a short description of the incident.
+	Title string `json:"title" db:"title"`
+	// Summary optionally describes the impact and the current state of the response.
+	Summary string `json:"summary,omitempty" db:"summary"`
+	// Severity is the severity of the incident, raised with its most severe alert.
+	Severity AlertSeverity `json:"severity" db:"severity"`
+	// Status is open, mitigated or closed.
+	Status IncidentStatus `json:"status" db:"status"`
+	// CommanderID is the user leading the response, if any.
+	CommanderID *ID `json:"commander_id,omitempty" db:"commander_id"`
+	// TeamID is the team owning the incident.
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
+	// CorrelationKey identifies the alerts grouped automatically into the
+	// incident; it is empty for incidents opened by hand.
+	CorrelationKey string `json:"correlation_key,omitempty" db:"correlation_key"`
+	// CreatedBy is the user who opened the incident, nil when it was correlated.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// MitigatedAt is when the incident was last mitigated.
+	MitigatedAt *time.Time `json:"mitigated_at,omitempty" db:"mitigated_at"`
+	// ClosedAt is when the incident was closed.
+	ClosedAt *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// IncidentEvent is an entry of the timeline of an incident.
+type IncidentEvent struct {
+	// ID is the unique identifier of the event.
+	ID ID `json:"id" db:"id"`
+	// IncidentID is the incident the event belongs to.
+	IncidentID ID `json:"incident_id" db:"incident_id"`
+	// Kind tells what happened.
+	Kind IncidentEventKind `json:"kind" db:"kind"`
+	// Message describes the event.
+	Message string `json:"message" db:"message"`
+	// ActorID is the user who caused the event, nil for the system.
+	ActorID *ID `json:"actor_id,omitempty" db:"actor_id"`
+	// CreatedAt is when the event happened.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Incident validation and lifecycle errors.
+var (
+	// ErrIncidentTitleRequired is returned when the incident title is empty.
+	ErrIncidentTitleRequired = errors.New("incident title is required")
+	// ErrIncidentTitleTooLong is returned when the title exceeds 255 characters.
+	ErrIncidentTitleTooLong = errors.New("incident title must be less than 256 characters")
+	// ErrIncidentSummaryTooLong is returned when the summary exceeds 10000 characters.
+	ErrIncidentSummaryTooLong = errors.New("incident summary must be less than 10001 characters")
+	// ErrIncidentInvalidSeverity is returned when the severity is unknown.
+	ErrIncidentInvalidSeverity = errors.New("invalid incident severity")
+	// ErrIncidentNotOpen is returned when mitigating an incident that is not open.
+	ErrIncidentNotOpen = errors.New("only open incidents can be mitigated")
+	// ErrIncidentClosed is returned when changing a closed incident.
+	ErrIncidentClosed = errors.New("incident is closed")
+	// ErrIncidentNotReopenable is returned when reopening an open incident.
+	ErrIncidentNotReopenable = errors.New("only mitigated or closed incidents can be reopened")
+	// ErrIncidentNoteRequired is returned when a timeline note is empty.
+	ErrIncidentNoteRequired = errors.New("incident note is required")
+	// ErrIncidentNoteTooLong is returned when a note exceeds 10000 characters.
+	ErrIncidentNoteTooLong = errors.New("incident note must be less than 10001 characters")
+)
+
+// NewIncident creates a new, open incident and validates it.
+func NewIncident(title, summary string, severity AlertSeverity, createdBy *ID) (*Incident, error) {
+	incident := &Incident{
+		ID:         NewID(),
+		Title:      title,
+		Summary:    summary,
+		Severity:   severity,
+		Status:     IncidentStatusOpen,
+		CreatedBy:  createdBy,
+		Timestamps: NewTimestamps(),
+	}
+
+	if err := incident.Validate(); err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+// Validate checks that the incident has valid data.
+func (i *Incident) Validate() error {
+	if i.Title == "" {
+		return ErrIncidentTitleRequired
+	}
+
+	if len(i.Title) > MaxIncidentTitleLength {
+		return ErrIncidentTitleTooLong
+	}
+
+	if len(i.Summary) > MaxIncidentSummaryLength {
+		return ErrIncidentSummaryTooLong
+	}
+
+	if !i.Severity.IsValid() {
+		return ErrIncidentInvalidSeverity
+	}
+
+	return nil
+}
+
+// IsClosed reports whether the incident is closed.
+func (i *Incident) IsClosed() bool {
+	return i.Status == IncidentStatusClosed
+}
+
+// Mitigate marks an open incident as mitigated.
+func (i *Incident) Mitigate() error {
+	if i.Status != IncidentStatusOpen {
+		return ErrIncidentNotOpen
+	}
+
+	now := time.Now().UTC()
+	i.Status = IncidentStatusMitigated
+	i.MitigatedAt = &now
+	i.Touch()
+	return nil
+}
+
+// Close closes an open or mitigated incident.
+func (i *Incident) Close() error {
+	if i.IsClosed() {
+		return ErrIncidentClosed
+	}
+
+	now := time.Now().UTC()
+	i.Status = IncidentStatusClosed
+	i.ClosedAt = &now
+	i.Touch()
+	return nil
+}
+
+// Reopen opens a mitigated or closed incident again, e.g. when its impact
+// comes back.
+func (i *Incident) Reopen() error {
+	if i.Status == IncidentStatusOpen {
+		return ErrIncidentNotReopenable
+	}
+
+	i.Status = IncidentStatusOpen
+	i.ClosedAt = nil
+	i.Touch()
+	return nil
+}
+
+// AssignCommander sets the user leading the response; nil unassigns it.
+func (i *Incident) AssignCommander(userID *ID) error {
+	if i.IsClosed() {
+		return ErrIncidentClosed
+	}
+
+	i.CommanderID = userID
+	i.Touch()
+	return nil
+}
+
+// RaiseSeverity raises the severity of the incident to that of an alert it
+// groups, and reports whether it changed.
+func (i *Incident) RaiseSeverity(severity AlertSeverity) bool {
+	if !severity.IsValid() || severity.Priority() >= i.Severity.Priority() {
+		return false
+	}
+
+	i.Severity = severity
+	i.Touch()
+	return true
+}
+
+// NewIncidentEvent creates an entry of the timeline of an incident.
+func NewIncidentEvent(incidentID ID, kind IncidentEventKind, message string, actorID *ID) *IncidentEvent {
+	return &IncidentEvent{
+		ID:         NewID(),
+		IncidentID: incidentID,
+		Kind:       kind,
+		Message:    message,
+		ActorID:    actorID,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+// ValidateIncidentNote checks a note added to the timeline of an incident.
+func ValidateIncidentNote(note string) error {
+	if note == "" {
+		return ErrIncidentNoteRequired
+	}
+
+	if len(note) > MaxIncidentNoteLength {
+		return ErrIncidentNoteTooLong
+	}
+
+	return nil
+}
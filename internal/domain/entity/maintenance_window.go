@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// MaintenanceStatus represents where a maintenance window stands in time.
+type MaintenanceStatus string
+
+// Maintenance statuses. A window is scheduled until it starts, active until
+// it ends, and completed afterwards.
+const (
+	MaintenanceStatusScheduled MaintenanceStatus = "scheduled"
+	MaintenanceStatusActive    MaintenanceStatus = "active"
+	MaintenanceStatusCompleted MaintenanceStatus = "completed"
+)
+
+// Maintenance window bounds.
+const (
+	MaxMaintenanceTitleLength       = 255
+	MaxMaintenanceDescriptionLength = 10000
+	MaxMaintenanceDuration          = 30 * 24 * time.Hour
+)
+
+// MaintenanceWindow is a period of scheduled maintenance, such as a database
+// upgrade, during which the alerts of a team or source are expected.
+type MaintenanceWindow struct {
+	// ID is the unique identifier of the window.
+	ID ID `json:"id" db:"id"`
+	// Title is a short description of the maintenance.
+	Title string `json:"title" db:"title"`
+	// Description details the maintenance and its expected impact.
+	Description string `json:"description,omitempty" db:"description"`
+	// TeamID is the team owning the window; shared windows have none.
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
+	// Source is the alert source under maintenance, if any.
+	Source string `json:"source,omitempty" db:"source"`
+	// StartsAt is when the maintenance starts.
+	StartsAt time.Time `json:"starts_at" db:"starts_at"`
+	// EndsAt is when the maintenance ends.
+	EndsAt time.Time `json:"ends_at" db:"ends_at"`
+	// CreatedBy is the optional ID of the user who scheduled the window.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Maintenance window validation errors.
+var (
+	// ErrMaintenanceTitleRequired is returned when the title is empty.
+	ErrMaintenanceTitleRequired = errors.New("maintenance title is required")
+	// ErrMaintenanceTitleTooLong is returned when the title exceeds 255 characters.
+	ErrMaintenanceTitleTooLong = errors.New("maintenance title must be less than 256 characters")
+	// ErrMaintenanceDescriptionTooLong is returned when the description exceeds 10000 characters.
+	ErrMaintenanceDescriptionTooLong = errors.New("maintenance description must be less than 10001 characters")
+	// ErrMaintenanceInvalidPeriod is returned when the window does not end
+	// after it starts, or lasts longer than 30 days.
+	ErrMaintenanceInvalidPeriod = errors.New("maintenance must end after it starts and last at most 30 days")
+)
+
+// NewMaintenanceWindow creates a new maintenance window and validates it.
+func NewMaintenanceWindow(
+	title, description string,
+	startsAt, endsAt time.Time,
+	createdBy *ID,
+) (*MaintenanceWindow, error) {
+	window := &MaintenanceWindow{
+		ID:          NewID(),
+		Title:       title,
+		Description: description,
+		StartsAt:    startsAt.UTC(),
+		EndsAt:      endsAt.UTC(),
+		CreatedBy:   createdBy,
+		Timestamps:  NewTimestamps(),
+	}
+
+	if err := window.Validate(); err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+// Validate checks that the window has valid data.
+func (w *MaintenanceWindow) Validate() error {
+	if w.Title == "" {
+		return ErrMaintenanceTitleRequired
+	}
+
+	if len(w.Title) > MaxMaintenanceTitleLength {
+		return ErrMaintenanceTitleTooLong
+	}
+
+	if len(w.Description) > MaxMaintenanceDescriptionLength {
+		return ErrMaintenanceDescriptionTooLong
+	}
+
+	if !w.EndsAt.After(w.StartsAt) || w.EndsAt.Sub(w.StartsAt) > MaxMaintenanceDuration {
+		return ErrMaintenanceInvalidPeriod
+	}
+
+	return nil
+}
+
+// Status returns whether the window is scheduled, active or completed at now.
+func (w *MaintenanceWindow) Status(now time.Time) MaintenanceStatus {
+	switch {
+	case now.Before(w.StartsAt):
+		return MaintenanceStatusScheduled
+	case now.Before(w.EndsAt):
+		return MaintenanceStatusActive
+	default:
+		return MaintenanceStatusCompleted
+	}
+}
+
+// Overlaps reports whether the window overlaps the period [from, to).
+func (w *MaintenanceWindow) Overlaps(from, to time.Time) bool {
+	return w.StartsAt.Before(to) && w.EndsAt.After(from)
+}
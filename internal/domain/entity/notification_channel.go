@@ -19,13 +19,20 @@ const (
 	ChannelTypeSMS ChannelType = "sms"
 	// ChannelTypeWebhook represents a generic webhook notification channel.
 	ChannelTypeWebhook ChannelType = "webhook"
+	// ChannelTypeTelegram represents a Telegram bot notification channel.
+	ChannelTypeTelegram ChannelType = "telegram"
 )
 
+// ChannelTypes returns every supported channel type.
+func ChannelTypes() []ChannelType {
+	return []ChannelType{ChannelTypeSlack, ChannelTypeEmail, ChannelTypeSMS, ChannelTypeWebhook, ChannelTypeTelegram}
+}
+
 // IsValid checks whether the channel type is a valid supported type.
-// Returns true if the type is one of: slack, email, sms, or webhook.
+// Returns true if the type is one of: slack, email, sms, webhook, or telegram.
 func (t ChannelType) IsValid() bool {
 	switch t {
-	case ChannelTypeSlack, ChannelTypeEmail, ChannelTypeSMS, ChannelTypeWebhook:
+	case ChannelTypeSlack, ChannelTypeEmail, ChannelTypeSMS, ChannelTypeWebhook, ChannelTypeTelegram:
 		return true
 	default:
 		return false
@@ -40,7 +47,7 @@ type NotificationChannel struct {
 	ID ID `json:"id" db:"id"`
 	// Name is the human-readable name of the channel.
 	Name string `json:"name" db:"name"`
-	// Type specifies the delivery mechanism (slack, email, sms, webhook).
+	// Type specifies the delivery mechanism (slack, email, sms, webhook, telegram).
 	Type ChannelType `json:"type" db:"type"`
 	// Config holds channel-specific configuration as key-value pairs.
 	// Required keys depend on the channel type:
@@ -51,6 +58,8 @@ type NotificationChannel struct {
 	IsEnabled bool `json:"is_enabled" db:"is_enabled"`
 	// CreatedBy is the optional ID of the user who created this channel.
 	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// TeamID is the team owning the channel (nil if shared with every team).
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
 	// Timestamps embeds creation and update timestamps.
 	Timestamps
 }
@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"errors"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+)
+
+// RouteMatcher selects the notifications a route applies to.
+// Every set criterion must match; an empty matcher matches every notification.
+type RouteMatcher struct {
+	// Severities matches any of the listed severities.
+	Severities []AlertSeverity `json:"severities,omitempty"`
+	// Sources matches any of the listed alert sources.
+	Sources []string `json:"sources,omitempty"`
+	// Labels matches notifications carrying every listed label value.
+	Labels map[string]string `json:"labels,omitempty"`
+	// TeamID matches notifications of the team's alerts.
+	TeamID *ID `json:"team_id,omitempty"`
+}
+
+// NotificationRoute is a node of the notification routing tree. A matching
+// route delivers to its channels unless one of its child routes matches, in
+// which case the children decide. Sibling routes are evaluated by position;
+// evaluation stops at the first match unless the route has Continue set.
+type NotificationRoute struct {
+	// ID is the unique identifier of the route.
+	ID ID `json:"id" db:"id"`
+	// ParentID is the route this route is nested under (nil for top-level routes).
+	ParentID *ID `json:"parent_id,omitempty" db:"parent_id"`
+	// Name is the human-readable name of the route.
+	Name string `json:"name" db:"name"`
+	// Position orders sibling routes; lower positions are evaluated first.
+	Position int `json:"position" db:"position"`
+	// Match selects the notifications the route applies to.
+	Match RouteMatcher `json:"match" db:"match"`
+	// Channels lists the notifier names (e.g. "slack") notifications are delivered to.
+	// An empty list drops matching notifications.
+	Channels []string `json:"channels" db:"channels"`
+	// Continue keeps evaluating sibling routes after this one matches.
+	Continue bool `json:"continue" db:"continue"`
+	// CreatedBy is the optional ID of the admin who created the route.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// ExternalID identifies the route in an infrastructure-as-code tool (empty if unmanaged).
+	ExternalID string `json:"external_id,omitempty" db:"external_id"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Notification route validation errors.
+var (
+	// ErrRouteNameRequired is returned when the route name is empty.
+	ErrRouteNameRequired = errors.New("route name is required")
+	// ErrRouteNameTooLong is returned when the route name exceeds 100 characters.
+	ErrRouteNameTooLong = errors.New("route name must be less than 101 characters")
+	// ErrRouteChannelInvalid is returned when a channel name is empty or exceeds 50 characters.
+	ErrRouteChannelInvalid = errors.New("route channel names must be 1 to 50 characters")
+	// ErrRouteSeverityInvalid is returned when the matcher refers to an unknown severity.
+	ErrRouteSeverityInvalid = errors.New("route matcher contains an invalid severity")
+	// ErrRouteParentSelf is returned when a route is nested under itself.
+	ErrRouteParentSelf = errors.New("route cannot be its own parent")
+	// ErrRouteExternalIDTooLong is returned when the external ID exceeds 255 characters.
+	ErrRouteExternalIDTooLong = errors.New("route external ID must be less than 256 characters")
+)
+
+// NewNotificationRoute creates a new notification route and validates it.
+func NewNotificationRoute(
+	name string,
+	parentID *ID,
+	match RouteMatcher,
+	channels []string,
+	continueMatching bool,
+	position int,
+	createdBy *ID,
+) (*NotificationRoute, error) {
+	route := &NotificationRoute{
+		ID:         NewID(),
+		ParentID:   parentID,
+		Name:       name,
+		Position:   position,
+		Match:      match,
+		Channels:   channels,
+		Continue:   continueMatching,
+		CreatedBy:  createdBy,
+		Timestamps: NewTimestamps(),
+	}
+
+	if route.Channels == nil {
+		route.Channels = []string{}
+	}
+
+	if err := route.Validate(); err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// Validate checks that the route has valid data.
+func (r *NotificationRoute) Validate() error {
+	if r.Name == "" {
+		return ErrRouteNameRequired
+	}
+
+	if len(r.Name) > 100 {
+		return ErrRouteNameTooLong
+	}
+
+	if r.ParentID != nil && *r.ParentID == r.ID {
+		return ErrRouteParentSelf
+	}
+
+	if len(r.ExternalID) > 255 {
+		return ErrRouteExternalIDTooLong
+	}
+
+	for _, channel := range r.Channels {
+		if channel == "" || len(channel) > 50 {
+			return ErrRouteChannelInvalid
+		}
+	}
+
+	return r.Match.Validate()
+}
+
+// Validate checks that the matcher only refers to known severities.
+func (m RouteMatcher) Validate() error {
+	for _, severity := range m.Severities {
+		if !severity.IsValid() {
+			return ErrRouteSeverityInvalid
+		}
+	}
+	return nil
+}
+
+// Matches reports whether a notification meets every criterion of the matcher.
+func (m RouteMatcher) Matches(msg notification.Message) bool {
+	if len(m.Severities) > 0 && !containsSeverity(m.Severities, AlertSeverity(msg.Severity)) {
+		return false
+	}
+
+	if len(m.Sources) > 0 && !containsString(m.Sources, msg.Source) {
+		return false
+	}
+
+	for key, value := range m.Labels {
+		if actual, ok := msg.Labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+
+	if m.TeamID != nil && m.TeamID.String() != msg.TeamID {
+		return false
+	}
+
+	return true
+}
+
+// containsSeverity reports whether a severity is in the list.
+func containsSeverity(severities []AlertSeverity, severity AlertSeverity) bool {
+	for _, s := range severities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString reports whether a value is in the list.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
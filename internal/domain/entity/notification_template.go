@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"github.com/google/uuid"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+)
+
+// Built-in templates used by channel types without a custom template.
+const (
+	DefaultTitleTemplate = "{{.Title}}"
+	DefaultBodyTemplate  = "{{.Text}}"
+)
+
+// Template size limits.
+const (
+	NotificationTitleTemplateMaxLength = 500
+	NotificationBodyTemplateMaxLength  = 10000
+)
+
+// NotificationTemplate renders the content of the notifications sent through
+// a channel type using Go text/template syntax. Templates receive a
+// notification.TemplateData value.
+type NotificationTemplate struct {
+	// ID is the unique identifier of the template.
+	ID ID `json:"id" db:"id"`
+	// Channel is the channel type the template applies to (one per type).
+	Channel ChannelType `json:"channel" db:"channel"`
+	// Title is the template of the notification title.
+	Title string `json:"title" db:"title"`
+	// Body is the template of the notification text.
+	Body string `json:"body" db:"body"`
+	// CreatedBy is the optional ID of the admin who configured the template.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Notification template validation errors.
+var (
+	// ErrTemplateTitleRequired is returned when the title template is empty.
+	ErrTemplateTitleRequired = errors.New("template title is required")
+	// ErrTemplateBodyRequired is returned when the body template is empty.
+	ErrTemplateBodyRequired = errors.New("template body is required")
+	// ErrTemplateTooLong is returned when a template exceeds its size limit.
+	ErrTemplateTooLong = errors.New("template exceeds its maximum length")
+	// ErrTemplateInvalid is returned when a template does not parse or render.
+	ErrTemplateInvalid = errors.New("template is invalid")
+)
+
+// NewNotificationTemplate creates a new notification template and validates it.
+func NewNotificationTemplate(channel ChannelType, title, body string, createdBy *ID) (*NotificationTemplate, error) {
+	t := &NotificationTemplate{
+		ID:         NewID(),
+		Channel:    channel,
+		Title:      title,
+		Body:       body,
+		CreatedBy:  createdBy,
+		Timestamps: NewTimestamps(),
+	}
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// DefaultNotificationTemplate returns the built-in template of a channel type.
+func DefaultNotificationTemplate(channel ChannelType) *NotificationTemplate {
+	return &NotificationTemplate{
+		Channel: channel,
+		Title:   DefaultTitleTemplate,
+		Body:    DefaultBodyTemplate,
+	}
+}
+
+// IsDefault reports whether the template is a built-in one.
+func (t *NotificationTemplate) IsDefault() bool {
+	return t.ID == uuid.Nil
+}
+
+// Validate checks that the template has valid data and renders sample data.
+func (t *NotificationTemplate) Validate() error {
+	if !t.Channel.IsValid() {
+		return ErrChannelInvalidType
+	}
+
+	if t.Title == "" {
+		return ErrTemplateTitleRequired
+	}
+	if t.Body == "" {
+		return ErrTemplateBodyRequired
+	}
+	if len(t.Title) > NotificationTitleTemplateMaxLength || len(t.Body) > NotificationBodyTemplateMaxLength {
+		return ErrTemplateTooLong
+	}
+
+	_, _, err := t.Render(SampleTemplateData())
+	return err
+}
+
+// Update replaces the templates after validating them.
+// On error the template is left unchanged.
+func (t *NotificationTemplate) Update(title, body string) error {
+	previousTitle, previousBody := t.Title, t.Body
+	t.Title, t.Body = title, body
+
+	if err := t.Validate(); err != nil {
+		t.Title, t.Body = previousTitle, previousBody
+		return err
+	}
+
+	t.Touch()
+	return nil
+}
+
+// Render executes the title and body templates.
+func (t *NotificationTemplate) Render(data notification.TemplateData) (string, string, error) {
+	title, err := renderTemplate("title", t.Title, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	body, err := renderTemplate("body", t.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	return title, body, nil
+}
+
+// SampleTemplateData returns the data used to validate and preview templates.
+func SampleTemplateData() notification.TemplateData {
+	return notification.NewTemplateData(notification.Message{
+		Title:    "High CPU usage on api-01",
+		Text:     "CPU usage has been above 90% for 5 minutes",
+		Severity: string(AlertSeverityHigh),
+		Source:   "prometheus",
+		AlertID:  "00000000-0000-0000-0000-000000000000",
+		Fields:   map[string]string{"Status": string(AlertStatusActive)},
+		Labels:   map[string]string{"host": "api-01", "env": "production"},
+	}, map[string]string{"alert": "https://alerts.example.com/alerts/00000000-0000-0000-0000-000000000000"})
+}
+
+// renderTemplate parses and executes a single template.
+func renderTemplate(name, text string, data notification.TemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrTemplateInvalid, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrTemplateInvalid, err)
+	}
+
+	return buf.String(), nil
+}
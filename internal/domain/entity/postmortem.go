@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Postmortem bounds.
+const (
+	MaxPostmortemTitleLength      = 255
+	MaxPostmortemSectionLength    = 50000
+	MaxPostmortemActionItems      = 50
+	MaxPostmortemActionItemLength = 1000
+	MaxPostmortemAlerts           = 100
+)
+
+// Postmortem records what happened during an incident, once it is over:
+// a summary, its impact, the root cause and the action items that keep it
+// from happening again. Summary, Impact and RootCause are markdown.
+type Postmortem struct {
+	// ID is the unique identifier of the postmortem.
+	ID ID `json:"id" db:"id"`
+	// IncidentID is the incident the postmortem covers, if any; an incident
+	// has one postmortem at most.
+	IncidentID *ID `json:"incident_id,omitempty" db:"incident_id"`
+	// TeamID is the team owning the postmortem.
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
+	// Title is a short description of what happened.
+	Title string `json:"title" db:"title"`
+	// Summary describes what happened and how it was resolved.
+	Summary string `json:"summary,omitempty" db:"summary"`
+	// Impact describes who and what was affected, and for how long.
+	Impact string `json:"impact,omitempty" db:"impact"`
+	// RootCause describes why it happened.
+	RootCause string `json:"root_cause,omitempty" db:"root_cause"`
+	// ActionItems are the follow-up tasks.
+	ActionItems []PostmortemActionItem `json:"action_items" db:"action_items"`
+	// AlertIDs are the alerts the postmortem covers, besides those of its incident.
+	AlertIDs []ID `json:"alert_ids,omitempty" db:"-"`
+	// CreatedBy is the user who wrote the postmortem.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// PostmortemActionItem is a follow-up task of a postmortem.
+type PostmortemActionItem struct {
+	// Description tells what is to be done.
+	Description string `json:"description"`
+	// OwnerID is the user responsible for the task, if any.
+	OwnerID *ID `json:"owner_id,omitempty"`
+	// DueDate is when the task should be done, if set.
+	DueDate *time.Time `json:"due_date,omitempty"`
+	// Done reports whether the task is done.
+	Done bool `json:"done"`
+}
+
+// Postmortem validation errors.
+var (
+	// ErrPostmortemTitleRequired is returned when the postmortem title is empty.
+	ErrPostmortemTitleRequired = errors.New("postmortem title is required")
+	// ErrPostmortemTitleTooLong is returned when the title exceeds 255 characters.
+	ErrPostmortemTitleTooLong = errors.New("postmortem title must be less than 256 characters")
+	// ErrPostmortemSectionTooLong is returned when the summary, impact or root
+	// cause exceeds 50000 characters.
+	ErrPostmortemSectionTooLong = errors.New("postmortem sections must be less than 50001 characters")
+	// ErrPostmortemTooManyActionItems is returned when there are more than 50 action items.
+	ErrPostmortemTooManyActionItems = errors.New("postmortem must have at most 50 action items")
+	// ErrPostmortemActionItemInvalid is returned when an action item has no
+	// description or one longer than 1000 characters.
+	ErrPostmortemActionItemInvalid = errors.New("postmortem action items need a description of at most 1000 characters")
+	// ErrPostmortemTooManyAlerts is returned when more than 100 alerts are linked.
+	ErrPostmortemTooManyAlerts = errors.New("postmortem must cover at most 100 alerts")
+)
+
+// NewPostmortem creates a new postmortem and validates it.
+func NewPostmortem(title, summary, impact, rootCause string, createdBy *ID) (*Postmortem, error) {
+	postmortem := &Postmortem{
+		ID:          NewID(),
+		Title:       title,
+		Summary:     summary,
+		Impact:      impact,
+		RootCause:   rootCause,
+		ActionItems: []PostmortemActionItem{},
+		CreatedBy:   createdBy,
+		Timestamps:  NewTimestamps(),
+	}
+
+	if err := postmortem.Validate(); err != nil {
+		return nil, err
+	}
+
+	return postmortem, nil
+}
+
+// Validate checks that the postmortem has valid data.
+func (p *Postmortem) Validate() error {
+	if p.Title == "" {
+		return ErrPostmortemTitleRequired
+	}
+
+	if len(p.Title) > MaxPostmortemTitleLength {
+		return ErrPostmortemTitleTooLong
+	}
+
+	for _, section := range []string{p.Summary, p.Impact, p.RootCause} {
+		if len(section) > MaxPostmortemSectionLength {
+			return ErrPostmortemSectionTooLong
+		}
+	}
+
+	if len(p.ActionItems) > MaxPostmortemActionItems {
+		return ErrPostmortemTooManyActionItems
+	}
+
+	for _, item := range p.ActionItems {
+		description := strings.TrimSpace(item.Description)
+		if description == "" || len(item.Description) > MaxPostmortemActionItemLength {
+			return ErrPostmortemActionItemInvalid
+		}
+	}
+
+	if len(p.AlertIDs) > MaxPostmortemAlerts {
+		return ErrPostmortemTooManyAlerts
+	}
+
+	return nil
+}
+
+// OpenActionItems returns how many action items are not done yet.
+func (p *Postmortem) OpenActionItems() int {
+	open := 0
+	for _, item := range p.ActionItems {
+		if !item.Done {
+			open++
+		}
+	}
+	return open
+}
@@ -0,0 +1,373 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Condition types. Comparisons test the value of a metric (threshold), its
+// rate of change (rate), its deviation from its recent values (anomaly) or
+// whether it stopped being reported (absent); composite conditions combine
+// other conditions.
+const (
+	ConditionThreshold = "threshold"
+	ConditionRate      = "rate"
+	ConditionAnomaly   = "anomaly"
+	ConditionAbsent    = "absent"
+	ConditionAnd       = "and"
+	ConditionOr        = "or"
+	ConditionNot       = "not"
+)
+
+// MaxConditionDepth is how deep composite conditions may be nested.
+const MaxConditionDepth = 5
+
+// maxWindowSeconds bounds the window of absent and anomaly conditions,
+// which is how long the samples of a metric are remembered.
+const maxWindowSeconds = 86400
+
+// minAnomalyWindowSeconds is the shortest baseline window of anomaly
+// conditions.
+const minAnomalyWindowSeconds = 60
+
+// MinBaselineSamples is how many samples within its window a baseline needs
+// before anomaly conditions are evaluated against it.
+const MinBaselineSamples = 10
+
+// validOperators are the comparison operators of conditions.
+var validOperators = map[string]bool{
+	">":  true,
+	"<":  true,
+	"==": true,
+	">=": true,
+	"<=": true,
+	"!=": true,
+}
+
+// RuleCondition is the condition that fires a rule: a comparison, or an
+// expression tree of and, or and not over comparisons. It is stored as JSON.
+//
+// A comparison tests one metric of a series:
+//   - threshold (the default type): its last value, e.g. cpu > 90;
+//   - rate: its change per second between its last two samples;
+//   - anomaly: its last value is more than Deviations standard deviations
+//     away from the mean of its samples over the previous WindowSeconds,
+//     above it for the > operator, below for <, either way when unset;
+//   - absent: no sample for ForSeconds; Operator and Threshold are unused.
+//
+// All the metrics of a condition are read from the series with the same
+// labels. Consecutive only applies to the root condition: how many
+// evaluations in a row it must hold for the rule to fire.
+type RuleCondition struct {
+	Type          string          `json:"type,omitempty"`
+	Metric        string          `json:"metric"`
+	Operator      string          `json:"operator"`
+	Threshold     float64         `json:"threshold"`
+	Deviations    float64         `json:"deviations,omitempty"`
+	WindowSeconds int             `json:"window_seconds,omitempty"`
+	ForSeconds    int             `json:"for_seconds,omitempty"`
+	Conditions    []RuleCondition `json:"conditions,omitempty"`
+	Consecutive   int             `json:"consecutive"`
+}
+
+// MetricState is the last sample of a metric of a series, as conditions
+// see it.
+type MetricState struct {
+	Value float64
+	// Rate is the change of the value per second since the previous sample,
+	// when HasRate is true.
+	Rate    float64
+	HasRate bool
+	SeenAt  time.Time
+	// History holds the previous samples, oldest first, when a baseline of
+	// the metric is kept.
+	History []MetricPoint
+}
+
+// MetricPoint is a past sample of a metric.
+type MetricPoint struct {
+	Value float64   `json:"v"`
+	At    time.Time `json:"t"`
+}
+
+// Baseline summarizes the recent values of a metric.
+type Baseline struct {
+	Mean    float64
+	StdDev  float64
+	Samples int
+}
+
+// NewBaseline computes the mean and population standard deviation of the
+// points since a time.
+func NewBaseline(points []MetricPoint, since time.Time) Baseline {
+	var baseline Baseline
+	var sum, sumSquares float64
+	for _, point := range points {
+		if point.At.Before(since) {
+			continue
+		}
+		baseline.Samples++
+		sum += point.Value
+		sumSquares += point.Value * point.Value
+	}
+	if baseline.Samples == 0 {
+		return baseline
+	}
+
+	n := float64(baseline.Samples)
+	baseline.Mean = sum / n
+	baseline.StdDev = math.Sqrt(math.Max(sumSquares/n-baseline.Mean*baseline.Mean, 0))
+	return baseline
+}
+
+// Deviates reports whether a value is more than deviations standard
+// deviations above (>), below (<) or away from (no operator) the mean.
+func (b Baseline) Deviates(value, deviations float64, operator string) bool {
+	band := deviations * b.StdDev
+	switch operator {
+	case ">":
+		return value > b.Mean+band
+	case "<":
+		return value < b.Mean-band
+	default:
+		return math.Abs(value-b.Mean) > band
+	}
+}
+
+// Validate checks that the condition is well-formed.
+func (c *RuleCondition) Validate() error {
+	return c.validate(1)
+}
+
+// validate checks a condition nested at a depth.
+func (c *RuleCondition) validate(depth int) error {
+	if depth > MaxConditionDepth {
+		return ErrRuleConditionTooDeep
+	}
+
+	switch c.kind() {
+	case ConditionAnd, ConditionOr:
+		if len(c.Conditions) < 2 {
+			return ErrRuleConditionOperands
+		}
+	case ConditionNot:
+		if len(c.Conditions) != 1 {
+			return ErrRuleConditionOperands
+		}
+	case ConditionThreshold, ConditionRate, ConditionAnomaly, ConditionAbsent:
+		if len(c.Conditions) > 0 {
+			return ErrRuleConditionOperands
+		}
+		if c.Metric == "" {
+			return ErrRuleMetricRequired
+		}
+		switch c.kind() {
+		case ConditionAbsent:
+			if c.ForSeconds <= 0 || c.ForSeconds > maxWindowSeconds {
+				return ErrRuleAbsenceWindowInvalid
+			}
+			return nil
+		case ConditionAnomaly:
+			if c.Deviations <= 0 || c.WindowSeconds < minAnomalyWindowSeconds || c.WindowSeconds > maxWindowSeconds {
+				return ErrRuleAnomalyInvalid
+			}
+			if c.Operator != "" && c.Operator != ">" && c.Operator != "<" {
+				return ErrRuleInvalidOperator
+			}
+			return nil
+		}
+		if !validOperators[c.Operator] {
+			return ErrRuleInvalidOperator
+		}
+		return nil
+	default:
+		return ErrRuleInvalidConditionType
+	}
+
+	for i := range c.Conditions {
+		if err := c.Conditions[i].validate(depth + 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kind returns the type of the condition, threshold when unset.
+func (c *RuleCondition) kind() string {
+	if c.Type == "" {
+		return ConditionThreshold
+	}
+	return c.Type
+}
+
+// IsComposite reports whether the condition combines other conditions.
+func (c *RuleCondition) IsComposite() bool {
+	switch c.kind() {
+	case ConditionAnd, ConditionOr, ConditionNot:
+		return true
+	default:
+		return false
+	}
+}
+
+// Metrics returns the metrics the condition reads, once each, in order of
+// appearance.
+func (c *RuleCondition) Metrics() []string {
+	var metrics []string
+	seen := make(map[string]bool)
+	c.walk(func(leaf *RuleCondition) {
+		if !seen[leaf.Metric] {
+			seen[leaf.Metric] = true
+			metrics = append(metrics, leaf.Metric)
+		}
+	})
+	return metrics
+}
+
+// BaselineWindows returns, per metric, the longest window of the anomaly
+// conditions on it.
+func (c *RuleCondition) BaselineWindows() map[string]time.Duration {
+	windows := make(map[string]time.Duration)
+	c.walk(func(leaf *RuleCondition) {
+		window := time.Duration(leaf.WindowSeconds) * time.Second
+		if leaf.kind() == ConditionAnomaly && window > windows[leaf.Metric] {
+			windows[leaf.Metric] = window
+		}
+	})
+	return windows
+}
+
+// walk calls fn on every comparison of the condition.
+func (c *RuleCondition) walk(fn func(leaf *RuleCondition)) {
+	if !c.IsComposite() {
+		fn(c)
+		return
+	}
+	for i := range c.Conditions {
+		c.Conditions[i].walk(fn)
+	}
+}
+
+// Matches reports whether the condition holds for the last states of the
+// metrics of a series at a time. Metrics without a state have never been
+// seen, or not for long: comparisons on them do not hold, except absent.
+func (c *RuleCondition) Matches(states map[string]MetricState, now time.Time) bool {
+	switch c.kind() {
+	case ConditionAnd:
+		for i := range c.Conditions {
+			if !c.Conditions[i].Matches(states, now) {
+				return false
+			}
+		}
+		return len(c.Conditions) > 0
+	case ConditionOr:
+		for i := range c.Conditions {
+			if c.Conditions[i].Matches(states, now) {
+				return true
+			}
+		}
+		return false
+	case ConditionNot:
+		return len(c.Conditions) == 1 && !c.Conditions[0].Matches(states, now)
+	}
+
+	state, ok := states[c.Metric]
+	switch c.kind() {
+	case ConditionThreshold:
+		return ok && compare(c.Operator, state.Value, c.Threshold)
+	case ConditionRate:
+		return ok && state.HasRate && compare(c.Operator, state.Rate, c.Threshold)
+	case ConditionAnomaly:
+		if !ok {
+			return false
+		}
+		baseline := NewBaseline(state.History, state.SeenAt.Add(-time.Duration(c.WindowSeconds)*time.Second))
+		return baseline.Samples >= MinBaselineSamples && baseline.Deviates(state.Value, c.Deviations, c.Operator)
+	case ConditionAbsent:
+		return !ok || now.Sub(state.SeenAt) >= time.Duration(c.ForSeconds)*time.Second
+	default:
+		return false
+	}
+}
+
+// Equal reports whether two conditions are the same.
+func (c *RuleCondition) Equal(other RuleCondition) bool {
+	if c.kind() != other.kind() ||
+		c.Metric != other.Metric ||
+		c.Operator != other.Operator ||
+		c.Threshold != other.Threshold ||
+		c.Deviations != other.Deviations ||
+		c.WindowSeconds != other.WindowSeconds ||
+		c.ForSeconds != other.ForSeconds ||
+		c.Consecutive != other.Consecutive ||
+		len(c.Conditions) != len(other.Conditions) {
+		return false
+	}
+	for i := range c.Conditions {
+		if !c.Conditions[i].Equal(other.Conditions[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the condition as an expression, e.g.
+// (cpu > 90 AND rate(errors) > 5) OR absent(heartbeat, 300s).
+func (c *RuleCondition) String() string {
+	switch c.kind() {
+	case ConditionThreshold:
+		return fmt.Sprintf("%s %s %g", c.Metric, c.Operator, c.Threshold)
+	case ConditionRate:
+		return fmt.Sprintf("rate(%s) %s %g", c.Metric, c.Operator, c.Threshold)
+	case ConditionAnomaly:
+		side := "±"
+		if c.Operator != "" {
+			side = c.Operator
+		}
+		return fmt.Sprintf("anomaly(%s, %s%gσ, %ds)", c.Metric, side, c.Deviations, c.WindowSeconds)
+	case ConditionAbsent:
+		return fmt.Sprintf("absent(%s, %ds)", c.Metric, c.ForSeconds)
+	case ConditionNot:
+		if len(c.Conditions) == 1 {
+			return "NOT " + c.Conditions[0].operand()
+		}
+	case ConditionAnd, ConditionOr:
+		operands := make([]string, len(c.Conditions))
+		for i := range c.Conditions {
+			operands[i] = c.Conditions[i].operand()
+		}
+		return strings.Join(operands, " "+strings.ToUpper(c.Type)+" ")
+	}
+	return c.kind()
+}
+
+// operand renders the condition as an operand of a composite condition,
+// parenthesized unless it is a comparison.
+func (c *RuleCondition) operand() string {
+	if c.IsComposite() {
+		return "(" + c.String() + ")"
+	}
+	return c.String()
+}
+
+// compare applies a comparison operator.
+func compare(operator string, value, threshold float64) bool {
+	switch operator {
+	case ">":
+		return value > threshold
+	case "<":
+		return value < threshold
+	case "==":
+		return value == threshold
+	case ">=":
+		return value >= threshold
+	case "<=":
+		return value <= threshold
+	case "!=":
+		return value != threshold
+	default:
+		return false
+	}
+}
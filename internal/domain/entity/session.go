@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+)
+
+// SessionDeviceMaxLength bounds the stored device description (the client's User-Agent).
+const SessionDeviceMaxLength = 255
+
+// Session is a login of a user on a device. Tokens issued for the session
+// carry its ID; revoking the session invalidates them.
+type Session struct {
+	// ID is the unique identifier of the session.
+	ID ID `json:"id"`
+	// UserID is the ID of the user who owns the session.
+	UserID ID `json:"user_id"`
+	// Device describes the client, usually its User-Agent.
+	Device string `json:"device"`
+	// IP is the client address seen on the latest login or token refresh.
+	IP string `json:"ip"`
+	// IssuedAt is when the user logged in.
+	IssuedAt time.Time `json:"issued_at"`
+	// LastSeenAt is when the session's tokens were last issued or refreshed.
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// ExpiresAt is when the session ends unless it is refreshed.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewSession starts a session lasting ttl.
+func NewSession(userID ID, device, ip string, ttl time.Duration) *Session {
+	if len(device) > SessionDeviceMaxLength {
+		device = device[:SessionDeviceMaxLength]
+	}
+
+	now := time.Now().UTC()
+	return &Session{
+		ID:         NewID(),
+		UserID:     userID,
+		Device:     device,
+		IP:         ip,
+		IssuedAt:   now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+}
+
+// Refresh records a token refresh from ip and extends the session by ttl.
+func (s *Session) Refresh(ip string, ttl time.Duration) {
+	now := time.Now().UTC()
+	if ip != "" {
+		s.IP = ip
+	}
+	s.LastSeenAt = now
+	s.ExpiresAt = now.Add(ttl)
+}
+
+// IsOwnedBy reports whether the session belongs to the user.
+func (s *Session) IsOwnedBy(userID ID) bool {
+	return s.UserID == userID
+}
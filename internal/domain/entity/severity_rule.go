@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+)
+
+// Severity rule bounds.
+const (
+	SeverityRuleNameMaxLength    = 100
+	SeverityRulePatternMaxLength = 255
+	SeverityRuleMaxLabels        = 20
+)
+
+// SeverityRuleMatcher selects the alerts a severity rule applies to. Every
+// set criterion must match; at least one must be set.
+type SeverityRuleMatcher struct {
+	// Sources matches any of the listed alert sources.
+	Sources []string `json:"sources,omitempty"`
+	// Severities matches any of the listed severities the alert was raised with.
+	Severities []AlertSeverity `json:"severities,omitempty"`
+	// TitlePattern matches alerts whose title matches the regular expression,
+	// e.g. the alert name of Grafana and Prometheus alerts.
+	TitlePattern string `json:"title_pattern,omitempty"`
+	// Labels matches alerts carrying every listed label value.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// IsEmpty reports whether the matcher sets no criterion.
+func (m SeverityRuleMatcher) IsEmpty() bool {
+	return len(m.Sources) == 0 && len(m.Severities) == 0 && m.TitlePattern == "" && len(m.Labels) == 0
+}
+
+// SeverityRule changes the severity of the alerts it matches when they are
+// ingested, e.g. downgrading critical alerts of a staging source to medium.
+// Rules are evaluated by position and the first enabled matching rule applies.
+type SeverityRule struct {
+	// ID is the unique identifier of the rule.
+	ID ID `json:"id" db:"id"`
+	// Name is the unique, human-readable name of the rule.
+	Name string `json:"name" db:"name"`
+	// Position orders the rules; lower positions are evaluated first.
+	Position int `json:"position" db:"position"`
+	// Match selects the alerts the rule applies to.
+	Match SeverityRuleMatcher `json:"match" db:"match"`
+	// Severity is the severity given to matching alerts.
+	Severity AlertSeverity `json:"severity" db:"severity"`
+	// IsEnabled indicates whether the rule applies to new alerts.
+	IsEnabled bool `json:"is_enabled" db:"is_enabled"`
+	// CreatedBy is the optional ID of the admin who created the rule.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Severity rule validation errors.
+var (
+	// ErrSeverityRuleNameRequired is returned when the rule name is empty.
+	ErrSeverityRuleNameRequired = errors.New("severity rule name is required")
+	// ErrSeverityRuleNameTooLong is returned when the rule name exceeds 100 characters.
+	ErrSeverityRuleNameTooLong = errors.New("severity rule name must be less than 101 characters")
+	// ErrSeverityRuleMatchRequired is returned when the matcher sets no criterion.
+	ErrSeverityRuleMatchRequired = errors.New("severity rule must match on at least one of sources, severities, title pattern or labels")
+	// ErrSeverityRuleInvalidMatchSeverity is returned when the matcher refers to an unknown severity.
+	ErrSeverityRuleInvalidMatchSeverity = errors.New("severity rule matcher contains an invalid severity")
+	// ErrSeverityRuleInvalidPattern is returned when the title pattern is not a valid regular expression or exceeds 255 characters.
+	ErrSeverityRuleInvalidPattern = errors.New("severity rule title pattern must be a valid regular expression of at most 255 characters")
+	// ErrSeverityRuleInvalidLabels is returned for empty label names or more than 20 labels.
+	ErrSeverityRuleInvalidLabels = errors.New("severity rule matcher must have at most 20 labels with non-empty names")
+	// ErrSeverityRuleInvalidSeverity is returned when the target severity is unknown.
+	ErrSeverityRuleInvalidSeverity = errors.New("invalid severity rule severity")
+)
+
+// NewSeverityRule creates a new, enabled severity rule and validates it.
+func NewSeverityRule(
+	name string,
+	match SeverityRuleMatcher,
+	severity AlertSeverity,
+	position int,
+	createdBy *ID,
+) (*SeverityRule, error) {
+	rule := &SeverityRule{
+		ID:         NewID(),
+		Name:       name,
+		Position:   position,
+		Match:      match,
+		Severity:   severity,
+		IsEnabled:  true,
+		CreatedBy:  createdBy,
+		Timestamps: NewTimestamps(),
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// Validate checks that the rule has valid data.
+func (r *SeverityRule) Validate() error {
+	if r.Name == "" {
+		return ErrSeverityRuleNameRequired
+	}
+
+	if len(r.Name) > SeverityRuleNameMaxLength {
+		return ErrSeverityRuleNameTooLong
+	}
+
+	if r.Match.IsEmpty() {
+		return ErrSeverityRuleMatchRequired
+	}
+
+	for _, severity := range r.Match.Severities {
+		if !severity.IsValid() {
+			return ErrSeverityRuleInvalidMatchSeverity
+		}
+	}
+
+	if r.Match.TitlePattern != "" {
+		if len(r.Match.TitlePattern) > SeverityRulePatternMaxLength {
+			return ErrSeverityRuleInvalidPattern
+		}
+		if _, err := regexp.Compile(r.Match.TitlePattern); err != nil {
+			return ErrSeverityRuleInvalidPattern
+		}
+	}
+
+	if len(r.Match.Labels) > SeverityRuleMaxLabels {
+		return ErrSeverityRuleInvalidLabels
+	}
+	for key := range r.Match.Labels {
+		if key == "" {
+			return ErrSeverityRuleInvalidLabels
+		}
+	}
+
+	if !r.Severity.IsValid() {
+		return ErrSeverityRuleInvalidSeverity
+	}
+
+	return nil
+}
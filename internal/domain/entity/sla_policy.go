@@ -0,0 +1,220 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// SLATarget identifies a response target of an SLA policy.
+type SLATarget string
+
+// SLA targets. An alert meets its acknowledgement target once acknowledged
+// or resolved, and its resolution target once resolved.
+const (
+	SLATargetAck     SLATarget = "ack"
+	SLATargetResolve SLATarget = "resolve"
+)
+
+// SLABreachSource is the source of the alerts raised for SLA breaches. They
+// are never held to an SLA themselves.
+const SLABreachSource = "sla"
+
+// MaxSLATarget bounds SLA targets, in seconds.
+const MaxSLATarget = 30 * 24 * 3600
+
+// SLAPolicy sets how quickly alerts must be acknowledged and resolved. It
+// applies to the alerts matching its severity, team and source, any of them
+// when unset, created after the policy; an alert missing a target breaches
+// the policy, which raises an alert of BreachSeverity.
+type SLAPolicy struct {
+	// ID is the unique identifier of the policy.
+	ID ID `json:"id" db:"id"`
+	// Name is the human-readable name of the policy.
+	Name string `json:"name" db:"name"`
+	// Severity limits the policy to alerts of a severity, if set.
+	Severity AlertSeverity `json:"severity,omitempty" db:"severity"`
+	// TeamID limits the policy to the alerts of a team, if set.
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
+	// Source limits the policy to the alerts of a source, if set.
+	Source string `json:"source,omitempty" db:"source"`
+	// AckWithinSeconds is the acknowledgement target; zero sets none.
+	AckWithinSeconds int `json:"ack_within_seconds" db:"ack_within_seconds"`
+	// ResolveWithinSeconds is the resolution target; zero sets none.
+	ResolveWithinSeconds int `json:"resolve_within_seconds" db:"resolve_within_seconds"`
+	// BreachSeverity is the severity of the alerts raised for breaches.
+	BreachSeverity AlertSeverity `json:"breach_severity" db:"breach_severity"`
+	// IsEnabled indicates whether breaches are checked.
+	IsEnabled bool `json:"is_enabled" db:"is_enabled"`
+	// CreatedBy is the optional ID of the user who created the policy.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// SLA policy validation errors.
+var (
+	// ErrSLAPolicyNameRequired is returned when the policy name is empty.
+	ErrSLAPolicyNameRequired = errors.New("SLA policy name is required")
+	// ErrSLAPolicyNameTooLong is returned when the policy name exceeds 100 characters.
+	ErrSLAPolicyNameTooLong = errors.New("SLA policy name must be less than 101 characters")
+	// ErrSLAPolicyTargetRequired is returned when the policy sets no target.
+	ErrSLAPolicyTargetRequired = errors.New("SLA policy needs an acknowledgement or resolution target")
+	// ErrSLAPolicyInvalidTarget is returned when a target is negative or
+	// longer than 30 days, or when acknowledgement is due after resolution.
+	ErrSLAPolicyInvalidTarget = errors.New("SLA targets must be at most 30 days, acknowledgement no later than resolution")
+	// ErrSLAPolicyInvalidSeverity is returned when the severity or the breach severity is unknown.
+	ErrSLAPolicyInvalidSeverity = errors.New("invalid SLA policy severity")
+)
+
+// NewSLAPolicy creates a new, enabled SLA policy and validates it.
+func NewSLAPolicy(
+	name string,
+	ackWithinSeconds, resolveWithinSeconds int,
+	breachSeverity AlertSeverity,
+	createdBy *ID,
+) (*SLAPolicy, error) {
+	policy := &SLAPolicy{
+		ID:                   NewID(),
+		Name:                 name,
+		AckWithinSeconds:     ackWithinSeconds,
+		ResolveWithinSeconds: resolveWithinSeconds,
+		BreachSeverity:       breachSeverity,
+		IsEnabled:            true,
+		CreatedBy:            createdBy,
+		Timestamps:           NewTimestamps(),
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Validate checks that the policy has valid data.
+func (p *SLAPolicy) Validate() error {
+	if p.Name == "" {
+		return ErrSLAPolicyNameRequired
+	}
+
+	if len(p.Name) > 100 {
+		return ErrSLAPolicyNameTooLong
+	}
+
+	if p.AckWithinSeconds == 0 && p.ResolveWithinSeconds == 0 {
+		return ErrSLAPolicyTargetRequired
+	}
+
+	for _, target := range []int{p.AckWithinSeconds, p.ResolveWithinSeconds} {
+		if target < 0 || target > MaxSLATarget {
+			return ErrSLAPolicyInvalidTarget
+		}
+	}
+
+	if p.AckWithinSeconds > 0 && p.ResolveWithinSeconds > 0 && p.AckWithinSeconds > p.ResolveWithinSeconds {
+		return ErrSLAPolicyInvalidTarget
+	}
+
+	if p.Severity != "" && !p.Severity.IsValid() {
+		return ErrSLAPolicyInvalidSeverity
+	}
+
+	if !p.BreachSeverity.IsValid() {
+		return ErrSLAPolicyInvalidSeverity
+	}
+
+	return nil
+}
+
+// Targets returns the targets the policy sets.
+func (p *SLAPolicy) Targets() []SLATarget {
+	var targets []SLATarget
+	if p.AckWithinSeconds > 0 {
+		targets = append(targets, SLATargetAck)
+	}
+	if p.ResolveWithinSeconds > 0 {
+		targets = append(targets, SLATargetResolve)
+	}
+	return targets
+}
+
+// Within returns the time given to meet a target, and false when the policy
+// does not set it.
+func (p *SLAPolicy) Within(target SLATarget) (time.Duration, bool) {
+	seconds := 0
+	switch target {
+	case SLATargetAck:
+		seconds = p.AckWithinSeconds
+	case SLATargetResolve:
+		seconds = p.ResolveWithinSeconds
+	}
+	return time.Duration(seconds) * time.Second, seconds > 0
+}
+
+// Matches reports whether the policy applies to an alert.
+func (p *SLAPolicy) Matches(alert *Alert) bool {
+	if alert.Source == SLABreachSource || alert.CreatedAt.Before(p.CreatedAt) {
+		return false
+	}
+	if p.Severity != "" && alert.Severity != p.Severity {
+		return false
+	}
+	if p.TeamID != nil && (alert.TeamID == nil || *alert.TeamID != *p.TeamID) {
+		return false
+	}
+	return p.Source == "" || alert.Source == p.Source
+}
+
+// IsBreached reports whether an alert missed a target of the policy by now:
+// the target is due and the alert met it late or not at all.
+func (p *SLAPolicy) IsBreached(alert *Alert, target SLATarget, now time.Time) bool {
+	within, ok := p.Within(target)
+	if !ok || !p.Matches(alert) {
+		return false
+	}
+
+	due := alert.CreatedAt.Add(within)
+	if !now.After(due) {
+		return false
+	}
+
+	metAt := alert.ResolvedAt
+	if target == SLATargetAck && alert.AcknowledgedAt != nil {
+		metAt = alert.AcknowledgedAt
+	}
+	return metAt == nil || metAt.After(due)
+}
+
+// SLABreach records that an alert missed a target of an SLA policy.
+type SLABreach struct {
+	// ID is the unique identifier of the breach.
+	ID ID `json:"id" db:"id"`
+	// PolicyID is the policy breached.
+	PolicyID ID `json:"policy_id" db:"policy_id"`
+	// AlertID is the alert that missed the target.
+	AlertID ID `json:"alert_id" db:"alert_id"`
+	// Target is the target missed.
+	Target SLATarget `json:"target" db:"target"`
+	// DueAt is when the target was due.
+	DueAt time.Time `json:"due_at" db:"due_at"`
+	// BreachedAt is when the breach was detected.
+	BreachedAt time.Time `json:"breached_at" db:"breached_at"`
+}
+
+// NewSLABreach records the breach of a target of a policy by an alert.
+func NewSLABreach(policy *SLAPolicy, alert *Alert, target SLATarget, now time.Time) *SLABreach {
+	within, _ := policy.Within(target)
+	return &SLABreach{
+		ID:         NewID(),
+		PolicyID:   policy.ID,
+		AlertID:    alert.ID,
+		Target:     target,
+		DueAt:      alert.CreatedAt.Add(within),
+		BreachedAt: now,
+	}
+}
+
+// DedupKey returns the dedup key of the alert raised for the breach.
+func (b *SLABreach) DedupKey() string {
+	return "sla:" + b.PolicyID.String() + ":" + b.AlertID.String() + ":" + string(b.Target)
+}
@@ -0,0 +1,181 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// SourceType is the kind of integration a source reports through.
+type SourceType string
+
+// Source types.
+const (
+	SourceTypeAPI        SourceType = "api"
+	SourceTypeWebhook    SourceType = "webhook"
+	SourceTypeGrafana    SourceType = "grafana"
+	SourceTypeDatadog    SourceType = "datadog"
+	SourceTypeSentry     SourceType = "sentry"
+	SourceTypeCloudWatch SourceType = "cloudwatch"
+	SourceTypeOther      SourceType = "other"
+)
+
+// IsValid checks if the source type is a valid SourceType value.
+func (t SourceType) IsValid() bool {
+	switch t {
+	case SourceTypeAPI, SourceTypeWebhook, SourceTypeGrafana, SourceTypeDatadog,
+		SourceTypeSentry, SourceTypeCloudWatch, SourceTypeOther:
+		return true
+	}
+	return false
+}
+
+// SourceStatus represents whether a source reports as expected.
+type SourceStatus string
+
+// Source statuses. A source is reporting while its alerts arrive within its
+// expected heartbeat, and silent once they stop; sources without an expected
+// heartbeat are always reporting.
+const (
+	SourceStatusReporting SourceStatus = "reporting"
+	SourceStatusSilent    SourceStatus = "silent"
+)
+
+// Source is an integration registered as sending alerts, matched to its
+// alerts by name. A source with an expected heartbeat is expected to send
+// at least one alert per heartbeat; an alert is raised when it goes silent,
+// resolved once it reports again.
+type Source struct {
+	// ID is the unique identifier of the source.
+	ID ID `json:"id" db:"id"`
+	// Name is the source of the alerts sent by the integration.
+	Name string `json:"name" db:"name"`
+	// Type is the kind of integration.
+	Type SourceType `json:"type" db:"type"`
+	// Description optionally explains what the source monitors.
+	Description string `json:"description,omitempty" db:"description"`
+	// ExpectedHeartbeatSeconds is the longest expected gap between two
+	// alerts of the source (0 if the source may stay silent).
+	ExpectedHeartbeatSeconds int `json:"expected_heartbeat_seconds" db:"expected_heartbeat_seconds"`
+	// Severity is the severity of the alert raised when the source goes silent.
+	Severity AlertSeverity `json:"severity" db:"severity"`
+	// Status is reporting or silent.
+	Status SourceStatus `json:"status" db:"status"`
+	// SilentSince is when the source was found silent (nil while reporting).
+	SilentSince *time.Time `json:"silent_since,omitempty" db:"silent_since"`
+	// TeamID is the team owning the source and its silence alerts.
+	TeamID *ID `json:"team_id,omitempty" db:"team_id"`
+	// CreatedBy is the optional ID of the user who registered the source.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Source validation errors.
+var (
+	// ErrSourceNameRequired is returned when the source name is empty.
+	ErrSourceNameRequired = errors.New("source name is required")
+	// ErrSourceNameTooLong is returned when the source name exceeds 100 characters.
+	ErrSourceNameTooLong = errors.New("source name must be less than 101 characters")
+	// ErrSourceInvalidType is returned when the source type is unknown.
+	ErrSourceInvalidType = errors.New("invalid source type, must be one of: api, webhook, grafana, datadog, sentry, cloudwatch, other")
+	// ErrSourceDescriptionTooLong is returned when the description exceeds 500 characters.
+	ErrSourceDescriptionTooLong = errors.New("source description must be less than 501 characters")
+	// ErrSourceInvalidHeartbeat is returned when the expected heartbeat is out of bounds.
+	ErrSourceInvalidHeartbeat = errors.New("source expected heartbeat must be 0 or between 60 seconds and 30 days")
+	// ErrSourceInvalidSeverity is returned when the alert severity is unknown.
+	ErrSourceInvalidSeverity = errors.New("invalid source severity")
+)
+
+// NewSource registers a new, reporting source and validates it.
+func NewSource(
+	name string,
+	sourceType SourceType,
+	expectedHeartbeatSeconds int,
+	severity AlertSeverity,
+	createdBy *ID,
+) (*Source, error) {
+	source := &Source{
+		ID:                       NewID(),
+		Name:                     name,
+		Type:                     sourceType,
+		ExpectedHeartbeatSeconds: expectedHeartbeatSeconds,
+		Severity:                 severity,
+		Status:                   SourceStatusReporting,
+		CreatedBy:                createdBy,
+		Timestamps:               NewTimestamps(),
+	}
+
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// Validate checks that the source has valid data.
+func (s *Source) Validate() error {
+	if s.Name == "" {
+		return ErrSourceNameRequired
+	}
+
+	if len(s.Name) > 100 {
+		return ErrSourceNameTooLong
+	}
+
+	if !s.Type.IsValid() {
+		return ErrSourceInvalidType
+	}
+
+	if len(s.Description) > 500 {
+		return ErrSourceDescriptionTooLong
+	}
+
+	if s.ExpectedHeartbeatSeconds != 0 &&
+		(s.ExpectedHeartbeatSeconds < MinHeartbeatInterval || s.ExpectedHeartbeatSeconds > MaxHeartbeatInterval) {
+		return ErrSourceInvalidHeartbeat
+	}
+
+	if !s.Severity.IsValid() {
+		return ErrSourceInvalidSeverity
+	}
+
+	return nil
+}
+
+// IsMonitored reports whether the source is expected to report regularly.
+func (s *Source) IsMonitored() bool {
+	return s.ExpectedHeartbeatSeconds > 0
+}
+
+// IsSilent reports whether a monitored source sent no alert within its
+// expected heartbeat, counted from its last alert or, if it never sent one,
+// from its registration.
+func (s *Source) IsSilent(lastAlertAt *time.Time, now time.Time) bool {
+	if !s.IsMonitored() {
+		return false
+	}
+	since := s.CreatedAt
+	if lastAlertAt != nil && lastAlertAt.After(since) {
+		since = *lastAlertAt
+	}
+	return now.Sub(since) > time.Duration(s.ExpectedHeartbeatSeconds)*time.Second
+}
+
+// MarkSilent records that the source stopped reporting.
+func (s *Source) MarkSilent(at time.Time) {
+	s.Status = SourceStatusSilent
+	s.SilentSince = &at
+	s.Touch()
+}
+
+// MarkReporting records that the source reports again.
+func (s *Source) MarkReporting() {
+	s.Status = SourceStatusReporting
+	s.SilentSince = nil
+	s.Touch()
+}
+
+// DedupKey returns the dedup key of the alerts raised when the source goes silent.
+func (s *Source) DedupKey() string {
+	return "source:" + s.ID.String()
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// SourceDependency is an edge of the dependency graph between alert sources:
+// Source depends on DependsOn, e.g. a service on its database. While a source
+// has an active critical alert, the alerts of the sources depending on it,
+// directly or not, are downstream and their notifications are suppressed.
+type SourceDependency struct {
+	// ID is the unique identifier of the dependency.
+	ID ID `json:"id" db:"id"`
+	// Source is the dependent source.
+	Source string `json:"source" db:"source"`
+	// DependsOn is the source Source depends on.
+	DependsOn string `json:"depends_on" db:"depends_on"`
+	// CreatedBy is the optional ID of the user who declared the dependency.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// CreatedAt is when the dependency was declared.
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Source dependency validation errors.
+var (
+	// ErrSourceDependencySourceRequired is returned when either source is empty.
+	ErrSourceDependencySourceRequired = errors.New("source and depends_on are required")
+	// ErrSourceDependencySourceTooLong is returned when a source exceeds 255 characters.
+	ErrSourceDependencySourceTooLong = errors.New("source must be less than 256 characters")
+	// ErrSourceDependencySelf is returned when a source depends on itself.
+	ErrSourceDependencySelf = errors.New("a source cannot depend on itself")
+)
+
+// NewSourceDependency creates a dependency of source on dependsOn and
+// validates it.
+func NewSourceDependency(source, dependsOn string, createdBy *ID) (*SourceDependency, error) {
+	dependency := &SourceDependency{
+		ID:        NewID(),
+		Source:    source,
+		DependsOn: dependsOn,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := dependency.Validate(); err != nil {
+		return nil, err
+	}
+
+	return dependency, nil
+}
+
+// Validate checks that the dependency has valid data.
+func (d *SourceDependency) Validate() error {
+	if d.Source == "" || d.DependsOn == "" {
+		return ErrSourceDependencySourceRequired
+	}
+
+	if len(d.Source) > 255 || len(d.DependsOn) > 255 {
+		return ErrSourceDependencySourceTooLong
+	}
+
+	if d.Source == d.DependsOn {
+		return ErrSourceDependencySelf
+	}
+
+	return nil
+}
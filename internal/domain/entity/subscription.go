@@ -0,0 +1,303 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// Subscription event types, the types of the alert events pushed to
+// subscriptions.
+const (
+	SubscriptionEventAlertCreated      = "alert.created"
+	SubscriptionEventAlertAcknowledged = "alert.acknowledged"
+	SubscriptionEventAlertResolved     = "alert.resolved"
+	SubscriptionEventAlertDeleted      = "alert.deleted"
+	SubscriptionEventAlertExpired      = "alert.expired"
+)
+
+// Subscription bounds.
+const (
+	SubscriptionNameMaxLength = 100
+	SubscriptionURLMaxLength  = 2048
+	// SubscriptionSecretMinLength is the minimum length of a signing secret.
+	SubscriptionSecretMinLength = 32
+	SubscriptionMaxLabels       = 20
+)
+
+// SubscriptionEventTypes returns the event types a subscription may receive.
+func SubscriptionEventTypes() []string {
+	return []string{
+		SubscriptionEventAlertCreated,
+		SubscriptionEventAlertAcknowledged,
+		SubscriptionEventAlertResolved,
+		SubscriptionEventAlertDeleted,
+		SubscriptionEventAlertExpired,
+	}
+}
+
+// SubscriptionFilter selects the alerts whose events are pushed to a
+// subscription. Every set criterion must match; an empty filter matches
+// every alert.
+type SubscriptionFilter struct {
+	// Severities matches any of the listed severities.
+	Severities []AlertSeverity `json:"severities,omitempty"`
+	// Sources matches any of the listed alert sources.
+	Sources []string `json:"sources,omitempty"`
+	// Labels matches alerts carrying every listed label value.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// IsEmpty reports whether the filter sets no criterion.
+func (f SubscriptionFilter) IsEmpty() bool {
+	return len(f.Severities) == 0 && len(f.Sources) == 0 && len(f.Labels) == 0
+}
+
+// Matches reports whether an alert with the severity, source and labels
+// passes the filter.
+func (f SubscriptionFilter) Matches(severity AlertSeverity, source string, labels map[string]string) bool {
+	if len(f.Severities) > 0 && !slices.Contains(f.Severities, severity) {
+		return false
+	}
+	if len(f.Sources) > 0 && !slices.Contains(f.Sources, source) {
+		return false
+	}
+	for key, value := range f.Labels {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Subscription is an external endpoint alert events are pushed to. Each
+// event is POSTed as JSON, signed with the subscription secret.
+type Subscription struct {
+	// ID is the unique identifier of the subscription.
+	ID ID `json:"id" db:"id"`
+	// Name is the human-readable name of the subscription.
+	Name string `json:"name" db:"name"`
+	// URL is the http(s) endpoint the events are POSTed to.
+	URL string `json:"url" db:"url"`
+	// Secret is the HMAC key the events are signed with; it is never serialized.
+	Secret string `json:"-" db:"secret"`
+	// EventTypes are the alert event types pushed to the subscription.
+	EventTypes []string `json:"event_types" db:"event_types"`
+	// Filter selects the alerts whose events are pushed.
+	Filter SubscriptionFilter `json:"filter" db:"filter"`
+	// IsEnabled indicates whether new events are pushed to the subscription.
+	IsEnabled bool `json:"is_enabled" db:"is_enabled"`
+	// CreatedBy is the optional ID of the user who registered the subscription.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Subscription validation errors.
+var (
+	// ErrSubscriptionNameRequired is returned when the subscription name is empty.
+	ErrSubscriptionNameRequired = errors.New("subscription name is required")
+	// ErrSubscriptionNameTooLong is returned when the subscription name exceeds 100 characters.
+	ErrSubscriptionNameTooLong = errors.New("subscription name must be less than 101 characters")
+	// ErrSubscriptionInvalidURL is returned when the URL is not an absolute http(s) URL.
+	ErrSubscriptionInvalidURL = errors.New("subscription url must be an absolute http or https URL of at most 2048 characters")
+	// ErrSubscriptionSecretTooShort is returned when the secret is shorter than SubscriptionSecretMinLength.
+	ErrSubscriptionSecretTooShort = errors.New("subscription secret must be at least 32 characters")
+	// ErrSubscriptionEventTypesRequired is returned when no event type is selected.
+	ErrSubscriptionEventTypesRequired = errors.New("subscription must receive at least one event type")
+	// ErrSubscriptionInvalidEventType is returned when an event type is unknown.
+	ErrSubscriptionInvalidEventType = errors.New("invalid subscription event type, must be one of: alert.created, alert.acknowledged, alert.resolved, alert.deleted, alert.expired")
+	// ErrSubscriptionInvalidFilter is returned for unknown severities, empty label names or more than 20 labels.
+	ErrSubscriptionInvalidFilter = errors.New("subscription filter must have valid severities and at most 20 labels with non-empty names")
+)
+
+// NewSubscription creates a new, enabled subscription and validates it.
+func NewSubscription(
+	name, endpoint, secret string,
+	eventTypes []string,
+	filter SubscriptionFilter,
+	createdBy *ID,
+) (*Subscription, error) {
+	subscription := &Subscription{
+		ID:         NewID(),
+		Name:       name,
+		URL:        endpoint,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Filter:     filter,
+		IsEnabled:  true,
+		CreatedBy:  createdBy,
+		Timestamps: NewTimestamps(),
+	}
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Validate checks that the subscription has valid data.
+func (s *Subscription) Validate() error {
+	if s.Name == "" {
+		return ErrSubscriptionNameRequired
+	}
+
+	if len(s.Name) > SubscriptionNameMaxLength {
+		return ErrSubscriptionNameTooLong
+	}
+
+	target, err := url.Parse(s.URL)
+	if err != nil || len(s.URL) > SubscriptionURLMaxLength ||
+		(target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return ErrSubscriptionInvalidURL
+	}
+
+	if len(s.Secret) < SubscriptionSecretMinLength {
+		return ErrSubscriptionSecretTooShort
+	}
+
+	if len(s.EventTypes) == 0 {
+		return ErrSubscriptionEventTypesRequired
+	}
+	for _, eventType := range s.EventTypes {
+		if !slices.Contains(SubscriptionEventTypes(), eventType) {
+			return ErrSubscriptionInvalidEventType
+		}
+	}
+
+	for _, severity := range s.Filter.Severities {
+		if !severity.IsValid() {
+			return ErrSubscriptionInvalidFilter
+		}
+	}
+	if len(s.Filter.Labels) > SubscriptionMaxLabels {
+		return ErrSubscriptionInvalidFilter
+	}
+	for key := range s.Filter.Labels {
+		if key == "" {
+			return ErrSubscriptionInvalidFilter
+		}
+	}
+
+	return nil
+}
+
+// Receives reports whether events of the type are pushed to the subscription.
+func (s *Subscription) Receives(eventType string) bool {
+	return s.IsEnabled && slices.Contains(s.EventTypes, eventType)
+}
+
+// DeliveryStatus is the state of the delivery of an event to a subscription.
+type DeliveryStatus string
+
+// Delivery statuses. A delivery is pending until the endpoint accepts it,
+// and failed once every attempt was refused; failed deliveries form the
+// dead letter queue of the subscription.
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// IsValid checks if the status is a valid DeliveryStatus value.
+func (s DeliveryStatus) IsValid() bool {
+	switch s {
+	case DeliveryStatusPending, DeliveryStatusDelivered, DeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+// maxDeliveryErrorLength bounds the error recorded for a failed attempt.
+const maxDeliveryErrorLength = 500
+
+// SubscriptionDelivery is an event to push to a subscription, with the
+// outcome of its attempts.
+type SubscriptionDelivery struct {
+	// ID is the unique identifier of the delivery, sent to the endpoint.
+	ID ID `json:"id" db:"id"`
+	// SubscriptionID is the subscription the event is pushed to.
+	SubscriptionID ID `json:"subscription_id" db:"subscription_id"`
+	// EventType is the type of the event.
+	EventType string `json:"event_type" db:"event_type"`
+	// Payload is the JSON body POSTed to the endpoint.
+	Payload json.RawMessage `json:"payload" db:"payload"`
+	// Status is pending, delivered or failed.
+	Status DeliveryStatus `json:"status" db:"status"`
+	// Attempts is the number of requests sent.
+	Attempts int `json:"attempts" db:"attempts"`
+	// NextAttemptAt is when the delivery is next attempted while pending.
+	NextAttemptAt time.Time `json:"next_attempt_at" db:"next_attempt_at"`
+	// LastStatusCode is the response status of the last attempt (0 without response).
+	LastStatusCode int `json:"last_status_code,omitempty" db:"last_status_code"`
+	// LastError describes why the last attempt failed.
+	LastError string `json:"last_error,omitempty" db:"last_error"`
+	// DeliveredAt is when the endpoint accepted the event.
+	DeliveredAt *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// NewSubscriptionDelivery creates a pending delivery, due immediately.
+func NewSubscriptionDelivery(subscriptionID ID, eventType string, payload []byte) *SubscriptionDelivery {
+	timestamps := NewTimestamps()
+	return &SubscriptionDelivery{
+		ID:             NewID(),
+		SubscriptionID: subscriptionID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         DeliveryStatusPending,
+		NextAttemptAt:  timestamps.CreatedAt,
+		Timestamps:     timestamps,
+	}
+}
+
+// RecordSuccess records an attempt the endpoint accepted.
+func (d *SubscriptionDelivery) RecordSuccess(statusCode int, at time.Time) {
+	d.Attempts++
+	d.Status = DeliveryStatusDelivered
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = &at
+	d.Touch()
+}
+
+// RecordFailure records a failed attempt. The delivery is attempted again
+// after retryAfter, unless it reached maxAttempts; it then fails and
+// RecordFailure returns true.
+func (d *SubscriptionDelivery) RecordFailure(statusCode int, reason string, at time.Time, retryAfter time.Duration, maxAttempts int) bool {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	if len(reason) > maxDeliveryErrorLength {
+		reason = reason[:maxDeliveryErrorLength]
+	}
+	d.LastError = reason
+	d.Touch()
+
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryStatusFailed
+		return true
+	}
+
+	d.NextAttemptAt = at.Add(retryAfter)
+	return false
+}
+
+// Fail fails the delivery without attempting it.
+func (d *SubscriptionDelivery) Fail(reason string) {
+	d.Status = DeliveryStatusFailed
+	d.LastError = reason
+	d.Touch()
+}
+
+// Redeliver makes a failed delivery pending again, due immediately, with a
+// fresh set of attempts.
+func (d *SubscriptionDelivery) Redeliver(at time.Time) {
+	d.Status = DeliveryStatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = at
+	d.Touch()
+}
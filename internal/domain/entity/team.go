@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// TeamRole defines the role of a user within a team.
+type TeamRole string
+
+// Team role constants.
+const (
+	// TeamRoleOwner can manage the team and its members.
+	TeamRoleOwner TeamRole = "owner"
+	// TeamRoleMember can see and act on the team's data.
+	TeamRoleMember TeamRole = "member"
+)
+
+// IsValid checks if the team role is a valid TeamRole value.
+func (r TeamRole) IsValid() bool {
+	return r == TeamRoleOwner || r == TeamRoleMember
+}
+
+// Team is a tenant owning alerts, rules and notification channels.
+// Members only see their teams' data and data not owned by any team.
+type Team struct {
+	// ID is the unique identifier of the team.
+	ID ID `json:"id" db:"id"`
+	// Name is the unique, human-readable name of the team.
+	Name string `json:"name" db:"name"`
+	// Description optionally explains the team's scope.
+	Description string `json:"description,omitempty" db:"description"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// TeamMember is the membership of a user in a team.
+type TeamMember struct {
+	// TeamID is the team the user belongs to.
+	TeamID ID `json:"team_id" db:"team_id"`
+	// UserID is the member.
+	UserID ID `json:"user_id" db:"user_id"`
+	// Role is the member's role within the team.
+	Role TeamRole `json:"role" db:"role"`
+	// JoinedAt is when the user joined the team.
+	JoinedAt time.Time `json:"joined_at" db:"joined_at"`
+}
+
+// Team validation errors.
+var (
+	// ErrTeamNameRequired is returned when the team name is empty.
+	ErrTeamNameRequired = errors.New("team name is required")
+	// ErrTeamNameTooLong is returned when the team name exceeds 100 characters.
+	ErrTeamNameTooLong = errors.New("team name must be less than 101 characters")
+	// ErrTeamDescriptionTooLong is returned when the description exceeds 500 characters.
+	ErrTeamDescriptionTooLong = errors.New("team description must be less than 501 characters")
+	// ErrTeamRoleInvalid is returned when a membership has an unknown role.
+	ErrTeamRoleInvalid = errors.New("invalid team role, must be one of: owner, member")
+)
+
+// NewTeam creates a new team and validates it.
+func NewTeam(name, description string) (*Team, error) {
+	team := &Team{
+		ID:          NewID(),
+		Name:        name,
+		Description: description,
+		Timestamps:  NewTimestamps(),
+	}
+
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// Validate checks that the team has valid data.
+func (t *Team) Validate() error {
+	if t.Name == "" {
+		return ErrTeamNameRequired
+	}
+
+	if len(t.Name) > 100 {
+		return ErrTeamNameTooLong
+	}
+
+	if len(t.Description) > 500 {
+		return ErrTeamDescriptionTooLong
+	}
+
+	return nil
+}
+
+// Update changes the team name and description.
+func (t *Team) Update(name, description string) error {
+	previousName, previousDescription := t.Name, t.Description
+	t.Name = name
+	t.Description = description
+
+	if err := t.Validate(); err != nil {
+		t.Name, t.Description = previousName, previousDescription
+		return err
+	}
+
+	t.Touch()
+	return nil
+}
+
+// NewTeamMember creates a membership of a user in a team.
+func NewTeamMember(teamID, userID ID, role TeamRole) (*TeamMember, error) {
+	if !role.IsValid() {
+		return nil, ErrTeamRoleInvalid
+	}
+
+	return &TeamMember{
+		TeamID:   teamID,
+		UserID:   userID,
+		Role:     role,
+		JoinedAt: time.Now().UTC(),
+	}, nil
+}
+
+// IsOwner reports whether the member can manage the team.
+func (m *TeamMember) IsOwner() bool {
+	return m.Role == TeamRoleOwner
+}
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+)
+
+// Chat providers whose users can be linked to users of the system, and the
+// phone number users are called at, in E.164 format.
+const (
+	IdentityProviderSlack    = "slack"
+	IdentityProviderTelegram = "telegram"
+	IdentityProviderPhone    = "phone"
+)
+
+// phoneNumberPattern matches phone numbers in E.164 format, e.g. +14155550100.
+var phoneNumberPattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// UserIdentityExternalIDMaxLength is the maximum length of an external user ID.
+const UserIdentityExternalIDMaxLength = 100
+
+// UserIdentity links a user to their account on a chat provider, so that
+// actions taken from the provider are performed on behalf of the user.
+type UserIdentity struct {
+	// ID is the unique identifier of the identity.
+	ID ID `json:"id" db:"id"`
+	// UserID is the user the identity belongs to (one per provider).
+	UserID ID `json:"user_id" db:"user_id"`
+	// Provider is the chat provider, e.g. slack or telegram.
+	Provider string `json:"provider" db:"provider"`
+	// ExternalID is the ID of the user on the provider (unique per provider).
+	ExternalID string `json:"external_id" db:"external_id"`
+	// CreatedBy is the optional ID of the admin who linked the identity.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// User identity validation errors.
+var (
+	// ErrIdentityProviderInvalid is returned when the provider is unknown.
+	ErrIdentityProviderInvalid = errors.New("invalid identity provider, must be one of: slack, telegram, phone")
+	// ErrIdentityExternalIDRequired is returned when the external ID is empty.
+	ErrIdentityExternalIDRequired = errors.New("external user ID is required")
+	// ErrIdentityExternalIDTooLong is returned when the external ID exceeds 100 characters.
+	ErrIdentityExternalIDTooLong = errors.New("external user ID must be less than 101 characters")
+	// ErrIdentityPhoneInvalid is returned when a phone number is not in E.164 format.
+	ErrIdentityPhoneInvalid = errors.New("phone number must be in E.164 format, e.g. +14155550100")
+)
+
+// IdentityProviders returns the providers users can be linked to.
+func IdentityProviders() []string {
+	return []string{
+		IdentityProviderSlack,
+		IdentityProviderTelegram,
+		IdentityProviderPhone,
+	}
+}
+
+// IsIdentityProvider reports whether users can be linked to a provider.
+func IsIdentityProvider(provider string) bool {
+	for _, known := range IdentityProviders() {
+		if known == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// NewUserIdentity links a user to a provider account and validates the link.
+func NewUserIdentity(userID ID, provider, externalID string, createdBy *ID) (*UserIdentity, error) {
+	identity := &UserIdentity{
+		ID:         NewID(),
+		UserID:     userID,
+		Provider:   provider,
+		ExternalID: externalID,
+		CreatedBy:  createdBy,
+		Timestamps: NewTimestamps(),
+	}
+
+	if err := identity.Validate(); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// Validate checks that the identity has valid data.
+func (i *UserIdentity) Validate() error {
+	if !IsIdentityProvider(i.Provider) {
+		return ErrIdentityProviderInvalid
+	}
+
+	if i.ExternalID == "" {
+		return ErrIdentityExternalIDRequired
+	}
+
+	if len(i.ExternalID) > UserIdentityExternalIDMaxLength {
+		return ErrIdentityExternalIDTooLong
+	}
+
+	if i.Provider == IdentityProviderPhone && !phoneNumberPattern.MatchString(i.ExternalID) {
+		return ErrIdentityPhoneInvalid
+	}
+
+	return nil
+}
+
+// Relink points the identity to another provider account.
+func (i *UserIdentity) Relink(externalID string) error {
+	previous := i.ExternalID
+	i.ExternalID = externalID
+	if err := i.Validate(); err != nil {
+		i.ExternalID = previous
+		return err
+	}
+	i.Touch()
+	return nil
+}
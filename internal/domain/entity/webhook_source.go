@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"errors"
+)
+
+// WebhookMapping describes how to build an alert from an arbitrary JSON payload.
+//
+// Each field is one of:
+//   - a JSON path starting with "$." (e.g. "$.alert.name", "$.items.0.text"),
+//   - a template with {{path}} placeholders (e.g. "{{host}}: {{check.output}}"),
+//   - a literal value.
+type WebhookMapping struct {
+	// Title builds the alert title (required).
+	Title string `json:"title"`
+	// Message builds the alert message.
+	Message string `json:"message,omitempty"`
+	// Severity builds the raw severity, translated through SeverityMap.
+	Severity string `json:"severity,omitempty"`
+	// SeverityMap translates source-specific severities (e.g. "P1", "sev2")
+	// into alert severities. Unmapped values are used as-is if valid.
+	SeverityMap map[string]AlertSeverity `json:"severity_map,omitempty"`
+	// DefaultSeverity is used when no valid severity can be derived.
+	DefaultSeverity AlertSeverity `json:"default_severity,omitempty"`
+	// DedupKey builds the key used to deduplicate and resolve alerts.
+	DedupKey string `json:"dedup_key,omitempty"`
+	// Status builds the status reported by the source.
+	Status string `json:"status,omitempty"`
+	// ResolvedValues lists the Status values that resolve the alert.
+	ResolvedValues []string `json:"resolved_values,omitempty"`
+}
+
+// WebhookSource is an external system registered to push alerts through a
+// tokenized inbound webhook URL.
+type WebhookSource struct {
+	// ID is the unique identifier of the source.
+	ID ID `json:"id" db:"id"`
+	// Name is the human-readable name, also used as the alert source.
+	Name string `json:"name" db:"name"`
+	// TokenHash is the SHA-256 hash of the webhook token; the token itself is never stored.
+	TokenHash string `json:"-" db:"token_hash"`
+	// Mapping describes how payloads are converted into alerts.
+	Mapping WebhookMapping `json:"mapping" db:"mapping"`
+	// IsEnabled indicates whether the source accepts payloads.
+	IsEnabled bool `json:"is_enabled" db:"is_enabled"`
+	// CreatedBy is the optional ID of the admin who registered the source.
+	CreatedBy *ID `json:"created_by,omitempty" db:"created_by"`
+	// Timestamps embeds creation and update timestamps.
+	Timestamps
+}
+
+// Webhook source validation errors.
+var (
+	// ErrWebhookSourceNameRequired is returned when the source name is empty.
+	ErrWebhookSourceNameRequired = errors.New("webhook source name is required")
+	// ErrWebhookSourceNameTooLong is returned when the source name exceeds 100 characters.
+	ErrWebhookSourceNameTooLong = errors.New("webhook source name must be less than 101 characters")
+	// ErrWebhookMappingTitleRequired is returned when the mapping has no title.
+	ErrWebhookMappingTitleRequired = errors.New("webhook mapping requires a title")
+	// ErrWebhookMappingInvalidSeverity is returned when the mapping refers to an unknown severity.
+	ErrWebhookMappingInvalidSeverity = errors.New("webhook mapping contains an invalid severity")
+)
+
+// NewWebhookSource creates a new, enabled webhook source and validates it.
+func NewWebhookSource(name, tokenHash string, mapping WebhookMapping, createdBy *ID) (*WebhookSource, error) {
+	source := &WebhookSource{
+		ID:         NewID(),
+		Name:       name,
+		TokenHash:  tokenHash,
+		Mapping:    mapping,
+		IsEnabled:  true,
+		CreatedBy:  createdBy,
+		Timestamps: NewTimestamps(),
+	}
+
+	if err := source.Validate(); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// Validate checks that the webhook source has valid data.
+func (s *WebhookSource) Validate() error {
+	if s.Name == "" {
+		return ErrWebhookSourceNameRequired
+	}
+
+	if len(s.Name) > 100 {
+		return ErrWebhookSourceNameTooLong
+	}
+
+	return s.Mapping.Validate()
+}
+
+// Validate checks that the mapping can produce alerts.
+func (m WebhookMapping) Validate() error {
+	if m.Title == "" {
+		return ErrWebhookMappingTitleRequired
+	}
+
+	if m.DefaultSeverity != "" && !m.DefaultSeverity.IsValid() {
+		return ErrWebhookMappingInvalidSeverity
+	}
+
+	for _, severity := range m.SeverityMap {
+		if !severity.IsValid() {
+			return ErrWebhookMappingInvalidSeverity
+		}
+	}
+
+	return nil
+}
+
+// UpdateMapping replaces the mapping and validates it.
+func (s *WebhookSource) UpdateMapping(mapping WebhookMapping) error {
+	if err := mapping.Validate(); err != nil {
+		return err
+	}
+	s.Mapping = mapping
+	s.Touch()
+	return nil
+}
+
+// RotateToken replaces the token hash, invalidating the previous URL.
+func (s *WebhookSource) RotateToken(tokenHash string) {
+	s.TokenHash = tokenHash
+	s.Touch()
+}
+
+// Enable allows the source to push alerts.
+func (s *WebhookSource) Enable() {
+	s.IsEnabled = true
+	s.Touch()
+}
+
+// Disable stops accepting payloads from the source.
+func (s *WebhookSource) Disable() {
+	s.IsEnabled = false
+	s.Touch()
+}
+
+// IsResolvedStatus reports whether a status value resolves the alert.
+func (m WebhookMapping) IsResolvedStatus(status string) bool {
+	for _, value := range m.ResolvedValues {
+		if value == status {
+			return true
+		}
+	}
+	return false
+}
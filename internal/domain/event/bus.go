@@ -1,6 +1,9 @@
 package event
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Publisher defines the interface for publishing events.
 type Publisher interface {
@@ -14,9 +17,79 @@ type Subscriber interface {
 	Unsubscribe() error
 }
 
+// Scheduler defines the interface for publishing events at a later time,
+// e.g. for timers that would otherwise need a polling worker.
+type Scheduler interface {
+	// PublishAt publishes an event to its default stream once deliverAt is
+	// reached. Events already due are published right away.
+	PublishAt(ctx context.Context, event *Event, deliverAt time.Time) error
+}
+
+// Drainer defines the interface for stopping a subscriber gracefully.
+type Drainer interface {
+	// Drain stops taking new events and waits until the events being handled
+	// finish, or ctx is done.
+	Drain(ctx context.Context) error
+}
+
 // Handler defines the interface for handling events.
 type Handler func(ctx context.Context, event *Event) error
 
+// ReadFilter selects the events returned by a Reader.
+type ReadFilter struct {
+	// From and To bound the publish time, both inclusive.
+	From time.Time
+	To   time.Time
+	// Types restricts the events to these types; empty matches all.
+	Types []Type
+	// Limit caps the number of events returned.
+	Limit int
+}
+
+// Matches reports whether an event has one of the filter's types.
+func (f ReadFilter) Matches(evt *Event) bool {
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if evt.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Reader defines the interface for reading back events a bus retained,
+// e.g. to replay them.
+type Reader interface {
+	// Read returns the events published to a stream that match the filter, oldest first.
+	Read(ctx context.Context, stream string, filter ReadFilter) ([]*Event, error)
+}
+
+// GroupLag is the backlog of a consumer group on a stream.
+type GroupLag struct {
+	Stream    string
+	Group     string
+	Consumers int
+	// Length is the number of events retained in the stream.
+	Length int64
+	// Lag is the number of events not yet delivered to the group, or -1 when
+	// the bus cannot tell.
+	Lag int64
+	// Pending is the number of events delivered but not yet acknowledged.
+	Pending int64
+	// OldestPending is when the oldest pending event was published; zero when
+	// none is pending.
+	OldestPending time.Time
+}
+
+// LagInspector defines the interface for inspecting the backlog of the
+// consumer groups of a bus.
+type LagInspector interface {
+	// Lag returns the backlog of every consumer group, by stream and group.
+	Lag(ctx context.Context) ([]GroupLag, error)
+}
+
 // Bus combines Publisher and Subscriber interfaces.
 type Bus interface {
 	Publisher
@@ -25,9 +98,10 @@ type Bus interface {
 
 // Stream names.
 const (
-	StreamAlerts        = "alerts"
-	StreamNotifications = "notifications"
-	StreamDeadLetter    = "dead-letter"
+	StreamAlerts         = "alerts"
+	StreamAlertsPriority = "alerts-priority"
+	StreamNotifications  = "notifications"
+	StreamDeadLetter     = "dead-letter"
 )
 
 // Consumer group names.
@@ -35,4 +109,5 @@ const (
 	GroupAlertProcessors      = "alert-processors"
 	GroupNotificationSenders  = "notification-senders"
 	GroupDeadLetterProcessors = "dead-letter-processors"
+	GroupFederationForwarders = "federation-forwarders"
 )
@@ -23,6 +23,17 @@ const (
 	UserUpdated       Type = "user.updated"
 )
 
+// Priority ranks an event within its type. High priority events are published
+// to a stream of their own, so that they are not queued behind a backlog of
+// normal ones.
+type Priority string
+
+// Event priorities.
+const (
+	PriorityNormal Priority = ""
+	PriorityHigh   Priority = "high"
+)
+
 // Event represents a domain event.
 type Event struct {
 	ID        string          `json:"id"`
@@ -31,6 +42,18 @@ type Event struct {
 	Timestamp time.Time       `json:"timestamp"`
 	Version   int             `json:"version"`
 	Retries   int             `json:"retries"`
+	LastError string          `json:"last_error,omitempty"`
+	Region    string          `json:"region,omitempty"`
+	Cluster   string          `json:"cluster,omitempty"`
+	Priority  Priority        `json:"priority,omitempty"`
+	// FailedGroup is the consumer group whose handler failed the event.
+	// Retries are re-published to every group of the stream, which use it to
+	// tell their own retries.
+	FailedGroup string `json:"failed_group,omitempty"`
+	// TraceParent and TraceState carry the W3C trace context of the
+	// publisher, so that handling the event continues its trace.
+	TraceParent string `json:"traceparent,omitempty"`
+	TraceState  string `json:"tracestate,omitempty"`
 }
 
 // NewEvent creates a new event with the given type and payload.
@@ -58,12 +81,30 @@ func (e *Event) UnmarshalPayload(target interface{}) error {
 // ToMap converts the event to a map for Redis Streams.
 func (e *Event) ToMap() map[string]interface{} {
 	return map[string]interface{}{
-		"id":        e.ID,
-		"type":      string(e.Type),
-		"payload":   string(e.Payload),
-		"timestamp": e.Timestamp.Format(time.RFC3339Nano),
-		"version":   e.Version,
-		"retries":   e.Retries,
+		"id":          e.ID,
+		"type":        string(e.Type),
+		"payload":     string(e.Payload),
+		"timestamp":   e.Timestamp.Format(time.RFC3339Nano),
+		"version":     e.Version,
+		"retries":     e.Retries,
+		"error":       e.LastError,
+		"failedgroup": e.FailedGroup,
+		"region":      e.Region,
+		"cluster":     e.Cluster,
+		"priority":    string(e.Priority),
+		"traceparent": e.TraceParent,
+		"tracestate":  e.TraceState,
+	}
+}
+
+// SetOrigin stamps the region and cluster that produced the event.
+// Values already present are kept so forwarded events retain their origin.
+func (e *Event) SetOrigin(region, cluster string) {
+	if e.Region == "" {
+		e.Region = region
+	}
+	if e.Cluster == "" {
+		e.Cluster = cluster
 	}
 }
 
@@ -98,12 +139,27 @@ func FromMap(data map[string]interface{}) (*Event, error) {
 		}
 	}
 
+	lastError, _ := data["error"].(string)
+	failedGroup, _ := data["failedgroup"].(string)
+	region, _ := data["region"].(string)
+	cluster, _ := data["cluster"].(string)
+	priority, _ := data["priority"].(string)
+	traceParent, _ := data["traceparent"].(string)
+	traceState, _ := data["tracestate"].(string)
+
 	return &Event{
-		ID:        data["id"].(string),
-		Type:      Type(data["type"].(string)),
-		Payload:   json.RawMessage(data["payload"].(string)),
-		Timestamp: timestamp,
-		Version:   version,
-		Retries:   retries,
+		ID:          data["id"].(string),
+		Type:        Type(data["type"].(string)),
+		Payload:     json.RawMessage(data["payload"].(string)),
+		Timestamp:   timestamp,
+		Version:     version,
+		Retries:     retries,
+		LastError:   lastError,
+		FailedGroup: failedGroup,
+		Region:      region,
+		Cluster:     cluster,
+		Priority:    Priority(priority),
+		TraceParent: traceParent,
+		TraceState:  traceState,
 	}, nil
 }
@@ -10,7 +10,12 @@ type AlertPayload struct {
 	Severity       string                 `json:"severity"`
 	Status         string                 `json:"status"`
 	Source         string                 `json:"source"`
+	Region         string                 `json:"region,omitempty"`
+	Cluster        string                 `json:"cluster,omitempty"`
+	TeamID         string                 `json:"team_id,omitempty"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	Flapping       bool                   `json:"flapping,omitempty"`
+	Downstream     bool                   `json:"downstream,omitempty"`
 	AcknowledgedBy *string                `json:"acknowledged_by,omitempty"`
 	AcknowledgedAt *time.Time             `json:"acknowledged_at,omitempty"`
 	ResolvedBy     *string                `json:"resolved_by,omitempty"`
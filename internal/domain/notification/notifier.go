@@ -12,14 +12,65 @@ const (
 	SeverityInfo     = "info"
 )
 
-// Message represents a notification message.
+// Alert actions offered by interactive notifications, identified by the
+// action ID the channel sends back with the alert ID.
+const (
+	ActionAcknowledge = "alert_acknowledge"
+	ActionResolve     = "alert_resolve"
+)
+
+// Message represents a notification message. RunbookURL and Remediation
+// tell responders what to do about the alert; Status is the alert's status
+// after the event, telling which actions are still possible.
 type Message struct {
-	Title    string
-	Text     string
-	Severity string
-	Fields   map[string]string
-	AlertID  string
-	Source   string
+	Title       string
+	Text        string
+	Severity    string
+	Status      string
+	Fields      map[string]string
+	Labels      map[string]string
+	AlertID     string
+	Source      string
+	TeamID      string
+	RunbookURL  string
+	Remediation string
+}
+
+// TemplateData holds the variables available to notification templates.
+type TemplateData struct {
+	Title       string
+	Text        string
+	Severity    string
+	Source      string
+	AlertID     string
+	Fields      map[string]string
+	Labels      map[string]string
+	Links       map[string]string
+	Remediation string
+}
+
+// NewTemplateData exposes a message to templates. Links are keyed by name,
+// e.g. "alert" for the alert's page and "runbook" for its runbook.
+func NewTemplateData(msg Message, links map[string]string) TemplateData {
+	return TemplateData{
+		Title:       msg.Title,
+		Text:        msg.Text,
+		Severity:    msg.Severity,
+		Source:      msg.Source,
+		AlertID:     msg.AlertID,
+		Fields:      orEmpty(msg.Fields),
+		Labels:      orEmpty(msg.Labels),
+		Links:       orEmpty(links),
+		Remediation: msg.Remediation,
+	}
+}
+
+// orEmpty returns an empty map instead of nil so that templates can range over it.
+func orEmpty(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
 }
 
 // Notifier defines the interface for sending notifications.
@@ -29,6 +80,64 @@ type Notifier interface {
 	IsEnabled() bool
 }
 
+// IncidentForwarder mirrors alerts as incidents of an on-call platform,
+// opened, acknowledged and closed in lockstep with the alert. Open returns the
+// ID of the incident on the platform, or "" if the alert is not forwarded.
+type IncidentForwarder interface {
+	Open(ctx context.Context, msg Message) (string, error)
+	Acknowledge(ctx context.Context, incidentID string, msg Message) error
+	Close(ctx context.Context, incidentID string, msg Message) error
+	Name() string
+	IsEnabled() bool
+}
+
+// Issue represents a ticket filed for an alert in an issue tracker.
+type Issue struct {
+	Summary     string
+	Description string
+	Severity    string
+	Labels      []string
+}
+
+// IssueTracker files tickets for alerts and resolves them with the alert.
+type IssueTracker interface {
+	// CreateIssue files an issue and returns its key, e.g. OPS-123.
+	CreateIssue(ctx context.Context, issue Issue) (string, error)
+	// ResolveIssue comments on an issue and moves it to its resolved state.
+	ResolveIssue(ctx context.Context, key, comment string) error
+	// IssueURL returns the link to an issue.
+	IssueURL(key string) string
+	IsEnabled() bool
+}
+
+// StatusPage publishes the status of the components of a public status page.
+type StatusPage interface {
+	SetComponentStatus(ctx context.Context, componentID, status string) error
+	IsEnabled() bool
+}
+
+// VoiceCall is a phone call reading a message with text-to-speech, then
+// Prompt while waiting for a key, which is sent to CallbackURL.
+type VoiceCall struct {
+	To          string
+	Message     string
+	Prompt      string
+	CallbackURL string
+}
+
+// VoiceCaller places phone calls.
+type VoiceCaller interface {
+	// Call places a call and returns its ID.
+	Call(ctx context.Context, call VoiceCall) (string, error)
+	IsEnabled() bool
+}
+
+// Mailer defines the interface for sending email to a single recipient.
+type Mailer interface {
+	SendMail(ctx context.Context, to, subject, body string) error
+	IsEnabled() bool
+}
+
 // SeverityPriority returns the priority of a severity level (lower is higher priority).
 func SeverityPriority(severity string) int {
 	switch severity {
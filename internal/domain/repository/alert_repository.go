@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
@@ -10,20 +11,51 @@ import (
 // AlertRepository defines the persistence operations for alerts.
 type AlertRepository interface {
 	// Create saves a new alert.
+	// Returns ErrDuplicateKey if an open alert of its team has its dedup key.
 	Create(ctx context.Context, alert *entity.Alert) error
 
+	// CreateDeduplicated saves a new alert unless an open alert of its team
+	// has its dedup key, atomically. It returns the saved or the existing
+	// alert and whether the alert was saved.
+	CreateDeduplicated(ctx context.Context, alert *entity.Alert) (*entity.Alert, bool, error)
+
+	// CreateBatch saves several alerts in as few round-trips as possible.
+	// Either all alerts are stored or none are.
+	CreateBatch(ctx context.Context, alerts []*entity.Alert) error
+
 	// GetByID finds an alert by its ID.
 	// Returns ErrNotFound if it doesn't exist.
 	GetByID(ctx context.Context, id entity.ID) (*entity.Alert, error)
 
+	// GetOpenByDedupKey finds the most recent active or acknowledged alert with the given dedup key.
+	// Returns ErrNotFound if there is none.
+	GetOpenByDedupKey(ctx context.Context, dedupKey string) (*entity.Alert, error)
+
 	// Update updates an existing alert.
 	// Returns ErrNotFound if it doesn't exist.
 	Update(ctx context.Context, alert *entity.Alert) error
 
-	// Delete removes an alert by its ID.
+	// SetMetadata sets one metadata key of an alert, leaving the rest of the
+	// alert untouched.
 	// Returns ErrNotFound if it doesn't exist.
+	SetMetadata(ctx context.Context, id entity.ID, key string, value interface{}) error
+
+	// Delete moves an alert to the trash, hiding it from every other query.
+	// Returns ErrNotFound if it doesn't exist or is already deleted.
 	Delete(ctx context.Context, id entity.ID) error
 
+	// DeleteByFilter moves every alert matching the filter to the trash and returns the IDs of the deleted alerts.
+	DeleteByFilter(ctx context.Context, filter valueobject.AlertFilter) ([]entity.ID, error)
+
+	// Restore takes an alert out of the trash.
+	// Returns ErrNotFound if it isn't in the trash, and ErrDuplicateKey if it
+	// is open and another open alert of its team has its dedup key.
+	Restore(ctx context.Context, id entity.ID) error
+
+	// PurgeDeleted permanently removes up to limit alerts moved to the trash
+	// before the cutoff, and returns how many were removed.
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error)
+
 	// List returns paginated alerts with optional filters.
 	List(ctx context.Context, filter valueobject.AlertFilter, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.Alert], error)
 
@@ -36,6 +68,14 @@ type AlertRepository interface {
 	// ListActive returns all active alerts (unpaginated, for broadcasting).
 	ListActive(ctx context.Context) ([]*entity.Alert, error)
 
+	// ListRelated returns up to limit alerts matching the filter, other than
+	// the given one, that share its fingerprint, source or rule, most recent first.
+	ListRelated(ctx context.Context, alert *entity.Alert, filter valueobject.AlertFilter, limit int) ([]*entity.Alert, error)
+
+	// ListOccurrences returns up to limit alerts matching the filter raised
+	// before the given one with its fingerprint, most recent first.
+	ListOccurrences(ctx context.Context, alert *entity.Alert, filter valueobject.AlertFilter, limit int) ([]*entity.Alert, error)
+
 	// ListExpired returns alerts that have expired but are still active.
 	// Useful for a cleanup job to mark them as expired.
 	ListExpired(ctx context.Context) ([]*entity.Alert, error)
@@ -49,8 +89,8 @@ type AlertRepository interface {
 	// CountBySeverity returns the number of alerts by severity.
 	CountBySeverity(ctx context.Context, severity entity.AlertSeverity) (int64, error)
 
-	// GetStatistics returns aggregated alert statistics.
-	GetStatistics(ctx context.Context) (*AlertStatistics, error)
+	// GetStatistics returns aggregated statistics of the alerts matching the filter.
+	GetStatistics(ctx context.Context, filter valueobject.AlertFilter) (*AlertStatistics, error)
 }
 
 // AlertStatistics contains aggregated alert statistics.
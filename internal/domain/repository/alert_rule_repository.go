@@ -17,6 +17,10 @@ type AlertRuleRepository interface {
 	// Returns ErrNotFound if it doesn't exist.
 	GetByID(ctx context.Context, id entity.ID) (*entity.AlertRule, error)
 
+	// GetByExternalID finds a rule by the ID given by an external tool.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByExternalID(ctx context.Context, externalID string) (*entity.AlertRule, error)
+
 	// Update updates an existing rule.
 	// Returns ErrNotFound if it doesn't exist.
 	Update(ctx context.Context, rule *entity.AlertRule) error
@@ -28,6 +32,13 @@ type AlertRuleRepository interface {
 	// List returns paginated rules.
 	List(ctx context.Context, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.AlertRule], error)
 
+	// ListForTenant returns paginated rules accessible within the tenant scope:
+	// shared rules and those of the scope's teams.
+	ListForTenant(ctx context.Context, tenant valueobject.TenantScope, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.AlertRule], error)
+
+	// ListAll returns every rule, ordered by name.
+	ListAll(ctx context.Context) ([]*entity.AlertRule, error)
+
 	// ListEnabled returns only enabled rules.
 	// Useful for the rule evaluation engine.
 	ListEnabled(ctx context.Context) ([]*entity.AlertRule, error)
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// AlertViewRepository defines the persistence operations for saved alert views.
+type AlertViewRepository interface {
+	// Create saves a new view.
+	Create(ctx context.Context, view *entity.AlertView) error
+
+	// GetByID finds a view by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.AlertView, error)
+
+	// Update updates an existing view.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, view *entity.AlertView) error
+
+	// Delete removes a view by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// ListVisible returns the views of the owner and those shared with the
+	// teams of the tenant scope, by name.
+	ListVisible(ctx context.Context, ownerID entity.ID, tenant valueobject.TenantScope) ([]*entity.AlertView, error)
+
+	// ListAll returns every view.
+	ListAll(ctx context.Context) ([]*entity.AlertView, error)
+}
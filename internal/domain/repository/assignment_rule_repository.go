@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// AssignmentRuleRepository defines the persistence operations for assignment rules.
+type AssignmentRuleRepository interface {
+	// Create saves a new rule.
+	// Returns ErrDuplicateKey if a rule with the same name exists, and
+	// ErrForeignKeyViolation if its team or user doesn't exist.
+	Create(ctx context.Context, rule *entity.AssignmentRule) error
+
+	// GetByID finds a rule by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.AssignmentRule, error)
+
+	// Update updates an existing rule.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, rule *entity.AssignmentRule) error
+
+	// Delete removes a rule by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns every rule, by position then name.
+	List(ctx context.Context) ([]*entity.AssignmentRule, error)
+}
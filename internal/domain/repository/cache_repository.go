@@ -27,6 +27,45 @@ type CacheRepository interface {
 	// Returns true if stored, false if it already existed.
 	SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
 
+	// Lock takes a lock on a key for ttl, shared by every instance.
+	// Returns the token releasing it, or ErrLockHeld if another owner has it.
+	Lock(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	// Unlock releases a lock, only if the token still holds it.
+	// Returns ErrLockNotHeld if the lock expired or another owner took it.
+	Unlock(ctx context.Context, key, token string) error
+
+	// TryWithLock runs fn while holding the lock on a key, released after.
+	// Returns false without running fn if another owner holds the lock.
+	// The lock expires after ttl even if fn is still running.
+	TryWithLock(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) error) (bool, error)
+
+	// ListAppend adds a value at the tail of a list, keeping only its newest
+	// maxLen entries. If ttl is positive, the list expires after it.
+	ListAppend(ctx context.Context, key string, value interface{}, maxLen int64, ttl time.Duration) error
+
+	// ListPrepend puts values back at the head of a list in their order,
+	// keeping only its newest maxLen entries.
+	// If ttl is positive, the list expires after it.
+	ListPrepend(ctx context.Context, key string, values []interface{}, maxLen int64, ttl time.Duration) error
+
+	// ListRange decodes every entry of a list into dest, a pointer to a
+	// slice, oldest first. A missing list yields none.
+	ListRange(ctx context.Context, key string, dest interface{}) error
+
+	// ListTake removes every entry of a list at once and decodes them into
+	// dest, a pointer to a slice, oldest first. A missing list yields none.
+	ListTake(ctx context.Context, key string, dest interface{}) error
+
+	// AddToSet adds a member to a set. If ttl is positive, the set expires after it.
+	AddToSet(ctx context.Context, key, member string, ttl time.Duration) error
+
+	// SetMembers returns the members of a set; none if it doesn't exist.
+	SetMembers(ctx context.Context, key string) ([]string, error)
+
+	// RemoveFromSet removes members from a set.
+	RemoveFromSet(ctx context.Context, key string, members ...string) error
+
 	// Increment increments a counter.
 	// If the key doesn't exist, it creates it with value 1.
 	Increment(ctx context.Context, key string) (int64, error)
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// CheckRepository defines the persistence operations for uptime checks and
+// the history of their probes.
+type CheckRepository interface {
+	// Create saves a new check.
+	Create(ctx context.Context, check *entity.Check) error
+
+	// GetByID finds a check by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.Check, error)
+
+	// Update updates an existing check.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, check *entity.Check) error
+
+	// UpdateStatus saves the status, consecutive failures and last probe time
+	// of a check, leaving its settings untouched.
+	// Returns ErrNotFound if it doesn't exist.
+	UpdateStatus(ctx context.Context, check *entity.Check) error
+
+	// Delete removes a check and its history by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns paginated checks of the tenant scope: those of its teams
+	// and those owned by no team.
+	List(
+		ctx context.Context,
+		tenant valueobject.TenantScope,
+		pagination valueobject.Pagination,
+	) (*valueobject.PaginatedResult[*entity.Check], error)
+
+	// ListDue returns the enabled checks that were never probed or whose
+	// interval elapsed at now, least recently probed first, up to limit.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.Check, error)
+
+	// AddResult saves the result of a probe.
+	AddResult(ctx context.Context, result *entity.CheckResult) error
+
+	// ListResults returns the probe results of a check, newest first.
+	ListResults(
+		ctx context.Context,
+		checkID entity.ID,
+		pagination valueobject.Pagination,
+	) (*valueobject.PaginatedResult[*entity.CheckResult], error)
+
+	// DeleteResultsBefore removes the probe results older than before and
+	// returns how many were removed.
+	DeleteResultsBefore(ctx context.Context, before time.Time) (int64, error)
+}
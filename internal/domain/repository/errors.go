@@ -25,4 +25,11 @@ var (
 
 	// ErrTimeout indicates that the operation exceeded the time limit.
 	ErrTimeout = errors.New("operation timeout")
+
+	// ErrLockHeld indicates that another owner holds the requested lock.
+	ErrLockHeld = errors.New("lock held by another owner")
+
+	// ErrLockNotHeld indicates that a lock being released expired or was
+	// taken by another owner.
+	ErrLockNotHeld = errors.New("lock not held")
 )
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// FailedEventRepository defines the persistence operations for dead-lettered events.
+type FailedEventRepository interface {
+	// Save stores a failed event. An event dead-lettered again replaces its
+	// previous record, which becomes pending again.
+	Save(ctx context.Context, event *entity.FailedEvent) error
+
+	// GetByEventID finds the record of an event.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByEventID(ctx context.Context, eventID string) (*entity.FailedEvent, error)
+
+	// Update updates the status of an existing record.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, event *entity.FailedEvent) error
+
+	// List returns paginated records matching the filter, most recent first.
+	List(ctx context.Context, filter valueobject.FailedEventFilter, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.FailedEvent], error)
+
+	// DeleteByFilter removes every record matching the filter and returns how many were deleted.
+	DeleteByFilter(ctx context.Context, filter valueobject.FailedEventFilter) (int64, error)
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// HeartbeatRepository defines the persistence operations for heartbeats.
+type HeartbeatRepository interface {
+	// Create saves a new heartbeat.
+	Create(ctx context.Context, heartbeat *entity.Heartbeat) error
+
+	// GetByID finds a heartbeat by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.Heartbeat, error)
+
+	// GetByTokenHash finds a heartbeat by the hash of its ping token.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Heartbeat, error)
+
+	// Update updates an existing heartbeat.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, heartbeat *entity.Heartbeat) error
+
+	// Delete removes a heartbeat by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns paginated heartbeats.
+	List(ctx context.Context, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.Heartbeat], error)
+
+	// ListOverdue returns the enabled heartbeats that are up but whose
+	// deadline passed before now.
+	ListOverdue(ctx context.Context, now time.Time) ([]*entity.Heartbeat, error)
+}
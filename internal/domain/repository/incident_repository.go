@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// IncidentFilter selects the incidents to list.
+type IncidentFilter struct {
+	// Status restricts the list to incidents with that status.
+	Status *entity.IncidentStatus
+	// Tenant restricts the list to shared incidents and those of its teams.
+	Tenant *valueobject.TenantScope
+}
+
+// IncidentRepository defines the persistence operations for incidents, the
+// alerts they group and their timeline.
+type IncidentRepository interface {
+	// Create saves a new incident.
+	// Returns ErrDuplicateKey if an incident with its correlation key is not
+	// closed yet, and ErrForeignKeyViolation if its team or commander doesn't exist.
+	Create(ctx context.Context, incident *entity.Incident) error
+
+	// GetByID finds an incident by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.Incident, error)
+
+	// GetActiveByCorrelationKey finds the incident with the correlation key
+	// that is not closed.
+	// Returns ErrNotFound if there is none.
+	GetActiveByCorrelationKey(ctx context.Context, key string) (*entity.Incident, error)
+
+	// GetByAlert finds the incident grouping an alert.
+	// Returns ErrNotFound if the alert is in no incident.
+	GetByAlert(ctx context.Context, alertID entity.ID) (*entity.Incident, error)
+
+	// Update updates an existing incident.
+	// Returns ErrNotFound if it doesn't exist and ErrForeignKeyViolation if
+	// its commander doesn't exist.
+	Update(ctx context.Context, incident *entity.Incident) error
+
+	// List returns paginated incidents matching the filter, newest first.
+	List(
+		ctx context.Context,
+		filter IncidentFilter,
+		pagination valueobject.Pagination,
+	) (*valueobject.PaginatedResult[*entity.Incident], error)
+
+	// AddAlert adds an alert to an incident.
+	// Returns ErrDuplicateKey if the alert already is in an incident and
+	// ErrForeignKeyViolation if the alert doesn't exist.
+	AddAlert(ctx context.Context, incidentID, alertID entity.ID, addedBy *entity.ID) error
+
+	// RemoveAlert removes an alert from an incident.
+	// Returns ErrNotFound if the alert is not in the incident.
+	RemoveAlert(ctx context.Context, incidentID, alertID entity.ID) error
+
+	// ListAlerts returns the alerts of an incident, most recent first.
+	ListAlerts(ctx context.Context, incidentID entity.ID) ([]*entity.Alert, error)
+
+	// AddEvent saves an entry of the timeline of an incident.
+	AddEvent(ctx context.Context, event *entity.IncidentEvent) error
+
+	// ListEvents returns the timeline of an incident, oldest first.
+	ListEvents(
+		ctx context.Context,
+		incidentID entity.ID,
+		pagination valueobject.Pagination,
+	) (*valueobject.PaginatedResult[*entity.IncidentEvent], error)
+}
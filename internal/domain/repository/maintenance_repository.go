@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// MaintenanceFilter selects the maintenance windows overlapping the period
+// [From, To).
+type MaintenanceFilter struct {
+	From time.Time
+	To   time.Time
+	// Tenant restricts windows to those shared or owned by the scope's teams.
+	Tenant *valueobject.TenantScope
+	// Source restricts windows to those of the source, if set.
+	Source string
+	// Limit caps the number of windows returned.
+	Limit int
+}
+
+// MaintenanceRepository defines the persistence operations for maintenance
+// windows.
+type MaintenanceRepository interface {
+	// Create saves a new maintenance window.
+	Create(ctx context.Context, window *entity.MaintenanceWindow) error
+
+	// GetByID finds a maintenance window by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.MaintenanceWindow, error)
+
+	// Update updates an existing maintenance window.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, window *entity.MaintenanceWindow) error
+
+	// Delete removes a maintenance window by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns the maintenance windows matching the filter, by start.
+	List(ctx context.Context, filter MaintenanceFilter) ([]*entity.MaintenanceWindow, error)
+}
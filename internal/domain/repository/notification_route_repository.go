@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// NotificationRouteRepository defines the persistence operations for notification routes.
+type NotificationRouteRepository interface {
+	// Create saves a new route.
+	// Returns ErrForeignKeyViolation if the parent route doesn't exist.
+	Create(ctx context.Context, route *entity.NotificationRoute) error
+
+	// GetByID finds a route by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.NotificationRoute, error)
+
+	// GetByExternalID finds a route by the ID given by an external tool.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByExternalID(ctx context.Context, externalID string) (*entity.NotificationRoute, error)
+
+	// Update updates an existing route.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, route *entity.NotificationRoute) error
+
+	// Delete removes a route and its child routes.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns every route, ordered by position.
+	List(ctx context.Context) ([]*entity.NotificationRoute, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// NotificationTemplateRepository defines the persistence operations for notification templates.
+type NotificationTemplateRepository interface {
+	// Create saves a new template.
+	Create(ctx context.Context, template *entity.NotificationTemplate) error
+
+	// GetByChannel finds the template of a channel type.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByChannel(ctx context.Context, channel entity.ChannelType) (*entity.NotificationTemplate, error)
+
+	// Update updates an existing template.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, template *entity.NotificationTemplate) error
+
+	// Delete removes the template of a channel type.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, channel entity.ChannelType) error
+
+	// List returns every custom template.
+	List(ctx context.Context) ([]*entity.NotificationTemplate, error)
+}
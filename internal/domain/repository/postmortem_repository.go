@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// PostmortemFilter selects the postmortems to list.
+type PostmortemFilter struct {
+	// AlertID restricts the list to postmortems covering the alert, directly
+	// or through their incident.
+	AlertID *entity.ID
+	// Tenant restricts the list to shared postmortems and those of its teams.
+	Tenant *valueobject.TenantScope
+}
+
+// PostmortemRepository defines the persistence operations for postmortems.
+// Postmortems are loaded with the IDs of the alerts they cover.
+type PostmortemRepository interface {
+	// Create saves a new postmortem and the alerts it covers.
+	// Returns ErrDuplicateKey if its incident already has a postmortem, and
+	// ErrForeignKeyViolation if its incident, team or one of its alerts doesn't exist.
+	Create(ctx context.Context, postmortem *entity.Postmortem) error
+
+	// GetByID finds a postmortem by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.Postmortem, error)
+
+	// GetByIncident finds the postmortem of an incident.
+	// Returns ErrNotFound if the incident has none.
+	GetByIncident(ctx context.Context, incidentID entity.ID) (*entity.Postmortem, error)
+
+	// Update updates an existing postmortem and replaces the alerts it covers.
+	// Returns ErrNotFound if it doesn't exist and ErrForeignKeyViolation if
+	// one of its alerts doesn't exist.
+	Update(ctx context.Context, postmortem *entity.Postmortem) error
+
+	// Delete removes a postmortem.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns paginated postmortems matching the filter, newest first.
+	List(
+		ctx context.Context,
+		filter PostmortemFilter,
+		pagination valueobject.Pagination,
+	) (*valueobject.PaginatedResult[*entity.Postmortem], error)
+}
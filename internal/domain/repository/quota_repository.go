@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// QuotaRepository counts the daily usage of quotas, shared by every
+// instance. Days are keyed as YYYY-MM-DD.
+// Implemented by Redis in the infrastructure layer.
+type QuotaRepository interface {
+	// Consume adds n to the usage of a quota on a day, unless that would
+	// exceed limit (zero for no limit). It returns the usage after the
+	// call and whether n was added.
+	Consume(ctx context.Context, day string, subject valueobject.QuotaSubject, metric valueobject.QuotaMetric, n, limit int) (int, bool, error)
+
+	// ListUsage returns the usage of every quota used on a day.
+	ListUsage(ctx context.Context, day string) ([]valueobject.QuotaUsage, error)
+
+	// Reset clears the usage of the quotas of a subject on a day.
+	Reset(ctx context.Context, day string, subject valueobject.QuotaSubject) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitResult is the outcome of counting a request against a limit.
+type RateLimitResult struct {
+	// Allowed reports whether the request fits in the window.
+	Allowed bool
+	// Remaining is the number of requests still allowed in the window.
+	Remaining int
+	// RetryAfter is the time until the oldest request counted leaves the
+	// window, freeing room for another one.
+	RetryAfter time.Duration
+}
+
+// RateLimitRepository counts requests in sliding windows shared by every
+// instance. Implemented by Redis in the infrastructure layer.
+type RateLimitRepository interface {
+	// Allow counts a request under a key, unless limit requests were
+	// already counted in the last window. Denied requests are not counted.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// SessionRepository defines the persistence operations for user sessions.
+// Sessions expire on their own at ExpiresAt.
+type SessionRepository interface {
+	// Save creates or updates a session.
+	Save(ctx context.Context, session *entity.Session) error
+
+	// GetByID finds a session by its ID.
+	// Returns ErrNotFound if it doesn't exist or has expired.
+	GetByID(ctx context.Context, id entity.ID) (*entity.Session, error)
+
+	// Exists checks whether a session is still active.
+	Exists(ctx context.Context, id entity.ID) (bool, error)
+
+	// ListByUser returns the active sessions of a user, newest first.
+	ListByUser(ctx context.Context, userID entity.ID) ([]*entity.Session, error)
+
+	// Delete removes a session.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, session *entity.Session) error
+}
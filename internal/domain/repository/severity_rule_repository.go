@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// SeverityRuleRepository defines the persistence operations for severity rules.
+type SeverityRuleRepository interface {
+	// Create saves a new rule.
+	// Returns ErrDuplicateKey if a rule with the same name exists.
+	Create(ctx context.Context, rule *entity.SeverityRule) error
+
+	// GetByID finds a rule by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.SeverityRule, error)
+
+	// Update updates an existing rule.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, rule *entity.SeverityRule) error
+
+	// Delete removes a rule by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns every rule, by position then name.
+	List(ctx context.Context) ([]*entity.SeverityRule, error)
+}
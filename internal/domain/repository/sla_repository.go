@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// SLARepository defines the persistence operations for SLA policies and
+// their breaches.
+type SLARepository interface {
+	// Create saves a new SLA policy.
+	Create(ctx context.Context, policy *entity.SLAPolicy) error
+
+	// GetByID finds an SLA policy by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.SLAPolicy, error)
+
+	// Update updates an existing SLA policy.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, policy *entity.SLAPolicy) error
+
+	// Delete removes an SLA policy, with its breaches, by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns paginated SLA policies.
+	List(ctx context.Context, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.SLAPolicy], error)
+
+	// ListEnabled returns the SLA policies whose breaches are checked.
+	ListEnabled(ctx context.Context) ([]*entity.SLAPolicy, error)
+
+	// ListBreaching returns the alerts matching a policy that missed one of
+	// its targets by now, and whose breach is not recorded yet. At most
+	// limit alerts are returned, oldest first.
+	ListBreaching(
+		ctx context.Context,
+		policy *entity.SLAPolicy,
+		target entity.SLATarget,
+		now time.Time,
+		limit int,
+	) ([]*entity.Alert, error)
+
+	// RecordBreach saves a breach and reports whether it was new; a breach
+	// already recorded for the same policy, alert and target is left as is.
+	RecordBreach(ctx context.Context, breach *entity.SLABreach) (bool, error)
+
+	// Compliance measures how the alerts matching both a policy and the
+	// filter met the targets of the policy by now.
+	Compliance(
+		ctx context.Context,
+		policy *entity.SLAPolicy,
+		now time.Time,
+		filter valueobject.AlertFilter,
+	) (*SLACompliance, error)
+}
+
+// SLACompliance reports how alerts met the targets of an SLA policy. A
+// target the policy does not set is nil.
+type SLACompliance struct {
+	Ack     *SLATargetCompliance `json:"ack,omitempty"`
+	Resolve *SLATargetCompliance `json:"resolve,omitempty"`
+}
+
+// SLATargetCompliance reports how alerts met a target. Pending alerts are
+// not due yet and have not met the target; compliance is the share of the
+// other alerts that met it, and the mean is the time they took to meet it.
+type SLATargetCompliance struct {
+	TotalAlerts       int64   `json:"total_alerts" db:"total_alerts"`
+	MetAlerts         int64   `json:"met_alerts" db:"met_alerts"`
+	BreachedAlerts    int64   `json:"breached_alerts" db:"breached_alerts"`
+	PendingAlerts     int64   `json:"pending_alerts" db:"pending_alerts"`
+	CompliancePercent float64 `json:"compliance_percent"`
+	MeanSeconds       float64 `json:"mean_seconds" db:"mean_seconds"`
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// SourceDependencyRepository defines the persistence operations for the
+// dependency graph between alert sources.
+type SourceDependencyRepository interface {
+	// Create saves a new dependency.
+	// Returns ErrDuplicateKey if the source already depends on the other.
+	Create(ctx context.Context, dependency *entity.SourceDependency) error
+
+	// Delete removes a dependency by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns paginated dependencies, by source.
+	List(ctx context.Context, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.SourceDependency], error)
+
+	// ListUpstream returns the sources the source depends on, directly or
+	// through other sources, nearest first.
+	ListUpstream(ctx context.Context, source string) ([]string, error)
+
+	// GetUpstreamAlert returns the oldest open alert of the severity raised
+	// by a source the source depends on, directly or not.
+	// Returns ErrNotFound if there is none.
+	GetUpstreamAlert(ctx context.Context, source string, severity entity.AlertSeverity) (*entity.Alert, error)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// SourceActivity summarizes the alerts sent by a source.
+type SourceActivity struct {
+	// LastAlertAt is when the source last sent an alert (nil if never).
+	LastAlertAt *time.Time `db:"last_alert_at"`
+	// AlertsLastHour is the number of alerts sent in the last hour.
+	AlertsLastHour int64 `db:"alerts_last_hour"`
+	// AlertsLastDay is the number of alerts sent in the last 24 hours.
+	AlertsLastDay int64 `db:"alerts_last_day"`
+}
+
+// SourceRepository defines the persistence operations for registered sources.
+type SourceRepository interface {
+	// Create saves a new source.
+	// Returns ErrDuplicateKey if a source with its name exists and
+	// ErrForeignKeyViolation if its team doesn't exist.
+	Create(ctx context.Context, source *entity.Source) error
+
+	// GetByID finds a source by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.Source, error)
+
+	// Update updates an existing source.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, source *entity.Source) error
+
+	// Delete removes a source by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns paginated sources of the tenant scope, by name.
+	List(
+		ctx context.Context,
+		tenant valueobject.TenantScope,
+		pagination valueobject.Pagination,
+	) (*valueobject.PaginatedResult[*entity.Source], error)
+
+	// ListMonitored returns the sources with an expected heartbeat.
+	ListMonitored(ctx context.Context) ([]*entity.Source, error)
+
+	// Activity returns the activity of the named sources as of now, keyed by
+	// name. Sources that never sent an alert are missing.
+	Activity(ctx context.Context, names []string, now time.Time) (map[string]SourceActivity, error)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// SubscriptionStats summarizes the deliveries of a subscription.
+type SubscriptionStats struct {
+	// Pending is the number of deliveries waiting for an attempt.
+	Pending int64 `json:"pending"`
+	// Delivered is the number of deliveries the endpoint accepted.
+	Delivered int64 `json:"delivered"`
+	// Failed is the number of dead-lettered deliveries.
+	Failed int64 `json:"failed"`
+	// Attempts is the number of requests sent.
+	Attempts int64 `json:"attempts"`
+	// LastDeliveredAt is when the endpoint last accepted a delivery.
+	LastDeliveredAt *time.Time `json:"last_delivered_at,omitempty"`
+	// LastError is the error of the latest failed attempt.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// SubscriptionRepository defines the persistence operations for outbound
+// webhook subscriptions and their deliveries.
+type SubscriptionRepository interface {
+	// Create saves a new subscription.
+	Create(ctx context.Context, subscription *entity.Subscription) error
+
+	// GetByID finds a subscription by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.Subscription, error)
+
+	// Update updates an existing subscription.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, subscription *entity.Subscription) error
+
+	// Delete removes a subscription and its deliveries by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns paginated subscriptions, ordered by name.
+	List(ctx context.Context, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.Subscription], error)
+
+	// ListEnabled returns the enabled subscriptions.
+	ListEnabled(ctx context.Context) ([]*entity.Subscription, error)
+
+	// CreateDeliveries saves new deliveries.
+	CreateDeliveries(ctx context.Context, deliveries []*entity.SubscriptionDelivery) error
+
+	// GetDelivery finds a delivery of a subscription by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetDelivery(ctx context.Context, subscriptionID, id entity.ID) (*entity.SubscriptionDelivery, error)
+
+	// ClaimDue returns up to limit pending deliveries due at now, oldest
+	// first, and postpones them by lease so that other instances skip them
+	// while they are attempted.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.SubscriptionDelivery, error)
+
+	// UpdateDelivery saves the outcome of the attempts of a delivery.
+	// Returns ErrNotFound if it doesn't exist.
+	UpdateDelivery(ctx context.Context, delivery *entity.SubscriptionDelivery) error
+
+	// ListDeliveries returns the deliveries of a subscription, newest first,
+	// optionally restricted to a status.
+	ListDeliveries(
+		ctx context.Context,
+		subscriptionID entity.ID,
+		status *entity.DeliveryStatus,
+		pagination valueobject.Pagination,
+	) (*valueobject.PaginatedResult[*entity.SubscriptionDelivery], error)
+
+	// Stats summarizes the deliveries of a subscription.
+	Stats(ctx context.Context, subscriptionID entity.ID) (SubscriptionStats, error)
+
+	// DeleteDeliveriesBefore removes the delivered and failed deliveries last
+	// updated before before and returns how many were removed.
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// TeamRepository defines the persistence operations for teams and their members.
+type TeamRepository interface {
+	// Create saves a new team.
+	// Returns ErrDuplicateKey if the name is taken.
+	Create(ctx context.Context, team *entity.Team) error
+
+	// GetByID finds a team by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.Team, error)
+
+	// Update updates an existing team.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, team *entity.Team) error
+
+	// Delete removes a team and its memberships.
+	// Returns ErrNotFound if it doesn't exist and ErrForeignKeyViolation
+	// while alerts, rules or channels still belong to it.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns paginated teams.
+	List(ctx context.Context, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.Team], error)
+
+	// ListByUser returns paginated teams the user is a member of.
+	ListByUser(ctx context.Context, userID entity.ID, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.Team], error)
+
+	// ListTeamIDs returns the IDs of every team the user is a member of.
+	ListTeamIDs(ctx context.Context, userID entity.ID) ([]entity.ID, error)
+
+	// SaveMember adds a member to a team or changes their role.
+	// Returns ErrForeignKeyViolation if the team or user doesn't exist.
+	SaveMember(ctx context.Context, member *entity.TeamMember) error
+
+	// GetMember finds the membership of a user in a team.
+	// Returns ErrNotFound if the user is not a member.
+	GetMember(ctx context.Context, teamID, userID entity.ID) (*entity.TeamMember, error)
+
+	// RemoveMember removes a user from a team.
+	// Returns ErrNotFound if the user is not a member.
+	RemoveMember(ctx context.Context, teamID, userID entity.ID) error
+
+	// ListMembers returns the members of a team.
+	ListMembers(ctx context.Context, teamID entity.ID) ([]*entity.TeamMember, error)
+
+	// CountOwners returns the number of owners of a team.
+	CountOwners(ctx context.Context, teamID entity.ID) (int64, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationRepository defines the persistence operations for revoked
+// tokens, identified by their ID (jti claim). Revocations are forgotten
+// once the token expires.
+type TokenRevocationRepository interface {
+	// Revoke marks a token revoked until it expires.
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// IsRevoked checks whether a token was revoked.
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+
+	// ListRevoked returns the IDs of the revoked tokens that did not expire yet.
+	ListRevoked(ctx context.Context) ([]string, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+// TxManager runs units of work atomically.
+// Implemented by PostgreSQL in the infrastructure layer.
+type TxManager interface {
+	// WithinTransaction calls fn in a transaction, committed if fn returns nil
+	// and rolled back otherwise. Repository calls made with the context given
+	// to fn run in the transaction. Nested calls join the outer transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
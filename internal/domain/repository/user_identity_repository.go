@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// UserIdentityRepository defines the persistence operations for the links
+// between users and their chat provider accounts.
+type UserIdentityRepository interface {
+	// Create saves a new identity.
+	// Returns ErrDuplicateKey if the provider account is linked to another user.
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+
+	// Get finds the identity of a user on a provider.
+	// Returns ErrNotFound if it doesn't exist.
+	Get(ctx context.Context, userID entity.ID, provider string) (*entity.UserIdentity, error)
+
+	// GetByExternalID finds the identity of a provider account.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByExternalID(ctx context.Context, provider, externalID string) (*entity.UserIdentity, error)
+
+	// Update updates an existing identity.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, identity *entity.UserIdentity) error
+
+	// Delete removes the identity of a user on a provider.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, userID entity.ID, provider string) error
+
+	// ListByUser returns the identities of a user.
+	ListByUser(ctx context.Context, userID entity.ID) ([]*entity.UserIdentity, error)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// WebhookSecretRepository defines the persistence operations for webhook signing secrets.
+type WebhookSecretRepository interface {
+	// Create saves a new secret.
+	Create(ctx context.Context, secret *entity.WebhookSecret) error
+
+	// GetByIntegration finds the secret of an integration.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByIntegration(ctx context.Context, integration string) (*entity.WebhookSecret, error)
+
+	// Update updates an existing secret.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, secret *entity.WebhookSecret) error
+
+	// Delete removes the secret of an integration.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, integration string) error
+
+	// List returns every configured secret.
+	List(ctx context.Context) ([]*entity.WebhookSecret, error)
+}
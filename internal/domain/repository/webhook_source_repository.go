@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// WebhookSourceRepository defines the persistence operations for inbound webhook sources.
+type WebhookSourceRepository interface {
+	// Create saves a new source.
+	Create(ctx context.Context, source *entity.WebhookSource) error
+
+	// GetByID finds a source by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id entity.ID) (*entity.WebhookSource, error)
+
+	// GetByTokenHash finds a source by the hash of its webhook token.
+	// Returns ErrNotFound if it doesn't exist.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.WebhookSource, error)
+
+	// Update updates an existing source.
+	// Returns ErrNotFound if it doesn't exist.
+	Update(ctx context.Context, source *entity.WebhookSource) error
+
+	// Delete removes a source by its ID.
+	// Returns ErrNotFound if it doesn't exist.
+	Delete(ctx context.Context, id entity.ID) error
+
+	// List returns paginated sources.
+	List(ctx context.Context, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.WebhookSource], error)
+}
@@ -1,6 +1,8 @@
 package valueobject
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
@@ -23,14 +25,29 @@ type AlertFilter struct {
 	Severities []entity.AlertSeverity
 	// Source filters alerts by their originating source system.
 	Source *string
+	// Region filters alerts by the deployment region that produced them.
+	Region *string
 	// RuleID filters alerts by the rule that triggered them.
 	RuleID *entity.ID
+	// TeamID filters alerts by the team owning them.
+	TeamID *entity.ID
 	// FromDate filters alerts created on or after this timestamp.
 	FromDate *time.Time
 	// ToDate filters alerts created on or before this timestamp.
 	ToDate *time.Time
 	// Search performs a text search across alert title and message fields.
 	Search *string
+	// Labels filters alerts carrying every listed label value.
+	Labels map[string]string
+	// Hidden excludes alerts matching any of these visibility rules.
+	// It enforces access restrictions and is not counted as a user filter by IsEmpty.
+	Hidden []VisibilityRule
+	// Tenant restricts alerts to shared ones and those of the scope's teams.
+	// Nil applies no restriction. Like Hidden, it is not counted by IsEmpty.
+	Tenant *TenantScope
+	// Deleted selects the alerts in the trash instead of the others.
+	// It chooses where to look rather than what to match, so IsEmpty ignores it.
+	Deleted bool
 }
 
 // NewAlertFilter creates an empty AlertFilter with no criteria set.
@@ -59,12 +76,24 @@ func (f AlertFilter) WithSource(source string) AlertFilter {
 	return f
 }
 
+// WithRegion adds a region filter to include only alerts produced in the specified region.
+func (f AlertFilter) WithRegion(region string) AlertFilter {
+	f.Region = &region
+	return f
+}
+
 // WithRuleID adds a rule filter to include only alerts triggered by the specified rule.
 func (f AlertFilter) WithRuleID(ruleID entity.ID) AlertFilter {
 	f.RuleID = &ruleID
 	return f
 }
 
+// WithTeamID adds a team filter to include only alerts owned by the specified team.
+func (f AlertFilter) WithTeamID(teamID entity.ID) AlertFilter {
+	f.TeamID = &teamID
+	return f
+}
+
 // WithDateRange adds a date range filter to include only alerts within the specified time period.
 // Both from and to dates are inclusive.
 func (f AlertFilter) WithDateRange(from, to time.Time) AlertFilter {
@@ -73,6 +102,13 @@ func (f AlertFilter) WithDateRange(from, to time.Time) AlertFilter {
 	return f
 }
 
+// WithCreatedBefore adds an upper bound on creation time, leaving the lower bound open.
+// Useful for retention policies that target alerts older than a cutoff.
+func (f AlertFilter) WithCreatedBefore(before time.Time) AlertFilter {
+	f.ToDate = &before
+	return f
+}
+
 // WithSearch adds a text search filter to find alerts matching the search term.
 // The search is performed against alert title and message fields.
 // Empty search strings are ignored.
@@ -83,12 +119,43 @@ func (f AlertFilter) WithSearch(search string) AlertFilter {
 	return f
 }
 
+// WithLabels adds a label filter to include only alerts carrying every listed label value.
+func (f AlertFilter) WithLabels(labels map[string]string) AlertFilter {
+	if len(labels) > 0 {
+		f.Labels = labels
+	}
+	return f
+}
+
+// WithVisibility excludes alerts the role may not see under the policy.
+func (f AlertFilter) WithVisibility(policy VisibilityPolicy, role entity.UserRole) AlertFilter {
+	f.Hidden = policy.HiddenFor(role)
+	return f
+}
+
+// WithTenant restricts alerts to those accessible within the tenant scope.
+// Unrestricted scopes clear the restriction.
+func (f AlertFilter) WithTenant(scope TenantScope) AlertFilter {
+	if scope.IsAll() {
+		f.Tenant = nil
+		return f
+	}
+	f.Tenant = &scope
+	return f
+}
+
 // ActiveOnly is a convenience method that filters for alerts with active status only.
 // Equivalent to WithStatuses(entity.AlertStatusActive).
 func (f AlertFilter) ActiveOnly() AlertFilter {
 	return f.WithStatuses(entity.AlertStatusActive)
 }
 
+// DeletedOnly selects the alerts in the trash.
+func (f AlertFilter) DeletedOnly() AlertFilter {
+	f.Deleted = true
+	return f
+}
+
 // CriticalOnly is a convenience method that filters for critical severity alerts only.
 // Equivalent to WithSeverities(entity.AlertSeverityCritical).
 func (f AlertFilter) CriticalOnly() AlertFilter {
@@ -128,7 +195,65 @@ func (f AlertFilter) IsEmpty() bool {
 	return !f.HasStatusFilter() &&
 		!f.HasSeverityFilter() &&
 		f.Source == nil &&
+		f.Region == nil &&
 		f.RuleID == nil &&
+		f.TeamID == nil &&
 		!f.HasDateFilter() &&
-		!f.HasSearch()
+		!f.HasSearch() &&
+		len(f.Labels) == 0
+}
+
+// Matches reports whether an alert meets every criterion of the filter, as
+// the repositories would select it, for alerts that are not stored yet or
+// not read back, such as those being broadcast.
+func (f AlertFilter) Matches(alert *entity.Alert) bool {
+	if alert.IsDeleted() != f.Deleted {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, alert.Status) {
+		return false
+	}
+	if len(f.Severities) > 0 && !slices.Contains(f.Severities, alert.Severity) {
+		return false
+	}
+	if f.Source != nil && alert.Source != *f.Source {
+		return false
+	}
+	if f.Region != nil && alert.Region != *f.Region {
+		return false
+	}
+	if f.RuleID != nil && (alert.RuleID == nil || *alert.RuleID != *f.RuleID) {
+		return false
+	}
+	if f.TeamID != nil && (alert.TeamID == nil || *alert.TeamID != *f.TeamID) {
+		return false
+	}
+	if f.FromDate != nil && alert.CreatedAt.Before(*f.FromDate) {
+		return false
+	}
+	if f.ToDate != nil && alert.CreatedAt.After(*f.ToDate) {
+		return false
+	}
+	if f.HasSearch() {
+		search := strings.ToLower(*f.Search)
+		if !strings.Contains(strings.ToLower(alert.Title), search) &&
+			!strings.Contains(strings.ToLower(alert.Message), search) {
+			return false
+		}
+	}
+
+	labels := alert.Labels()
+	for key, value := range f.Labels {
+		if labels[key] != value {
+			return false
+		}
+	}
+
+	for _, rule := range f.Hidden {
+		if rule.Matches(alert) {
+			return false
+		}
+	}
+
+	return f.Tenant == nil || f.Tenant.Allows(alert.TeamID)
 }
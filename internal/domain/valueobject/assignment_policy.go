@@ -0,0 +1,74 @@
+package valueobject
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// AssignmentPolicy assigns new alerts with assignment rules: enabled rules
+// are tried by position, then by name, and the first one matching an alert
+// assigns it. The zero value has no rules.
+type AssignmentPolicy struct {
+	rules []*entity.AssignmentRule
+}
+
+// NewAssignmentPolicy builds a policy from assignment rules, leaving out
+// disabled rules.
+func NewAssignmentPolicy(rules []*entity.AssignmentRule) AssignmentPolicy {
+	var policy AssignmentPolicy
+	for _, rule := range rules {
+		if rule.IsEnabled {
+			policy.rules = append(policy.rules, rule)
+		}
+	}
+
+	sort.SliceStable(policy.rules, func(i, j int) bool {
+		a, b := policy.rules[i], policy.rules[j]
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.Name < b.Name
+	})
+
+	return policy
+}
+
+// IsEmpty reports whether the policy has no enabled rules.
+func (p AssignmentPolicy) IsEmpty() bool {
+	return len(p.rules) == 0
+}
+
+// Match returns the first rule matching the alert, or nil when none does.
+func (p AssignmentPolicy) Match(alert *entity.Alert) *entity.AssignmentRule {
+	for _, rule := range p.rules {
+		if assignmentRuleMatches(rule.Match, alert) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// assignmentRuleMatches reports whether every criterion of the matcher
+// matches the alert.
+func assignmentRuleMatches(match entity.AssignmentRuleMatcher, alert *entity.Alert) bool {
+	if match.IsEmpty() {
+		return false
+	}
+
+	if len(match.Sources) > 0 && !slices.Contains(match.Sources, alert.Source) {
+		return false
+	}
+
+	if len(match.Labels) > 0 {
+		labels := alert.Labels()
+		for key, value := range match.Labels {
+			if labels[key] != value {
+				return false
+			}
+		}
+	}
+
+	return true
+}
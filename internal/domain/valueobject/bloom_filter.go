@@ -0,0 +1,74 @@
+package valueobject
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// minBloomFilterItems is the smallest number of items a bloom filter is
+// sized for, so that a filter built from few items has room to grow.
+const minBloomFilterItems = 1024
+
+// BloomFilter is a set of strings that answers whether it may contain one.
+// It has no false negatives, and false positives at about the rate it was
+// sized for, as long as it holds no more items than it was sized for.
+//
+// A BloomFilter is not safe for concurrent use.
+type BloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// NewBloomFilter creates a bloom filter sized for the expected number of
+// items and false positive rate, which must be between 0 and 1.
+func NewBloomFilter(expectedItems int, falsePositiveRate float64) *BloomFilter {
+	n := float64(max(expectedItems, minBloomFilterItems))
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+
+	// Optimal number of bits and of hash functions for n items
+	size := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Max(1, math.Round(float64(size)/n*math.Ln2)))
+
+	return &BloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// Add adds an item to the filter.
+func (f *BloomFilter) Add(item string) {
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MayContain reports whether the filter may contain an item. False means
+// the item was never added.
+func (f *BloomFilter) MayContain(item string) bool {
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes returns the two hashes of an item from which the bits of each
+// hash function are derived (double hashing).
+func bloomHashes(item string) (uint64, uint64) {
+	a := fnv.New64a()
+	_, _ = a.Write([]byte(item))
+	b := fnv.New64()
+	_, _ = b.Write([]byte(item))
+
+	// An odd step visits distinct bits for each hash function
+	return a.Sum64(), b.Sum64() | 1
+}
@@ -0,0 +1,219 @@
+package valueobject
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron schedule errors.
+var (
+	ErrCronScheduleInvalid   = errors.New("cron schedule must have five fields: minute hour day-of-month month day-of-week")
+	ErrCronFieldInvalid      = errors.New("cron schedule field is invalid")
+	ErrCronIntervalInvalid   = errors.New("cron schedule interval must be at least one second")
+	ErrCronScheduleNoMatch   = errors.New("cron schedule never matches")
+	ErrCronDescriptorUnknown = errors.New("cron schedule descriptor is unknown")
+)
+
+// cronDescriptors maps the predefined schedules to their expressions.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSearchLimit bounds the search for the next matching minute, so that
+// schedules matching only impossible dates (30 February) end.
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// cronField is the range of values of a cron field.
+type cronField struct {
+	min, max int
+}
+
+// The five fields of a cron expression, in order.
+var cronFields = [5]cronField{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 6},  // day of week, Sunday is 0 (7 is accepted too)
+}
+
+// CronSchedule is a recurring schedule of jobs, either a standard five-field
+// cron expression evaluated in UTC, a predefined one (@daily, @hourly...), or
+// a fixed interval ("@every 30s"). Intervals are aligned on a fixed origin,
+// so that every instance computes the same run times.
+type CronSchedule struct {
+	expr          string
+	every         time.Duration
+	fields        [5]uint64
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// ParseCronSchedule parses a cron expression.
+func ParseCronSchedule(expr string) (CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if rest, ok := strings.CutPrefix(expr, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || interval < time.Second {
+			return CronSchedule{}, ErrCronIntervalInvalid
+		}
+		return EveryInterval(interval), nil
+	}
+
+	spec := expr
+	if strings.HasPrefix(expr, "@") {
+		descriptor, ok := cronDescriptors[strings.ToLower(expr)]
+		if !ok {
+			return CronSchedule{}, ErrCronDescriptorUnknown
+		}
+		spec = descriptor
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return CronSchedule{}, ErrCronScheduleInvalid
+	}
+
+	schedule := CronSchedule{expr: expr}
+	for i, part := range parts {
+		field := cronFields[i]
+		if i == 4 {
+			// Sunday is both 0 and 7
+			field.max = 7
+		}
+		bits, err := parseCronField(part, field)
+		if err != nil {
+			return CronSchedule{}, err
+		}
+		schedule.fields[i] = bits
+	}
+	if schedule.fields[4]&(1<<7) != 0 {
+		schedule.fields[4] |= 1
+	}
+	schedule.anyDayOfMonth = parts[2] == "*"
+	schedule.anyDayOfWeek = parts[4] == "*"
+
+	if schedule.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return CronSchedule{}, ErrCronScheduleNoMatch
+	}
+	return schedule, nil
+}
+
+// EveryInterval returns the schedule running every interval.
+func EveryInterval(interval time.Duration) CronSchedule {
+	return CronSchedule{expr: "@every " + interval.String(), every: interval}
+}
+
+// parseCronField parses a comma-separated list of values, ranges (a-b) and
+// steps (*/n, a-b/n) into a bit set of the matching values.
+func parseCronField(part string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(part, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, ErrCronFieldInvalid
+			}
+			step = n
+		}
+
+		low, high := field.min, field.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(from); err != nil {
+				return 0, ErrCronFieldInvalid
+			}
+			if high, err = strconv.Atoi(to); err != nil {
+				return 0, ErrCronFieldInvalid
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, ErrCronFieldInvalid
+			}
+			low = n
+			if !hasStep {
+				high = n
+			}
+		}
+
+		if low < field.min || high > field.max || low > high {
+			return 0, ErrCronFieldInvalid
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// Next returns the first run time of the schedule strictly after t, or the
+// zero time when there is none.
+func (s CronSchedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Truncate(s.every).Add(s.every)
+	}
+	if s.fields[0] == 0 {
+		// Zero value, or an interval that is not positive
+		return time.Time{}
+	}
+
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+	for t.Before(limit) {
+		switch {
+		case !s.has(3, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !s.has(1, t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !s.has(0, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of t matches the day-of-month and
+// day-of-week fields. As in cron, when both are restricted either matches.
+func (s CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.has(2, t.Day())
+	dow := s.has(4, int(t.Weekday()))
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dow
+	case s.anyDayOfWeek:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// has reports whether a field matches a value.
+func (s CronSchedule) has(field, value int) bool {
+	return s.fields[field]&(1<<uint(value)) != 0
+}
+
+// String returns the expression of the schedule.
+func (s CronSchedule) String() string {
+	return s.expr
+}
@@ -0,0 +1,68 @@
+package valueobject
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Deprecation errors.
+var (
+	ErrDeprecationPathRequired  = errors.New("deprecation path is required")
+	ErrDeprecationSinceRequired = errors.New("deprecation date is required")
+	ErrDeprecationSunsetInvalid = errors.New("sunset must be after the deprecation date")
+)
+
+// Deprecation marks an endpoint as deprecated. Matching responses advertise
+// it through the Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers.
+type Deprecation struct {
+	// Method restricts the notice to one HTTP method; empty matches all.
+	Method string
+	// Path is an exact path, or a prefix when it ends with "*".
+	Path string
+	// Since is when the endpoint was deprecated.
+	Since time.Time
+	// Sunset is when the endpoint stops working; zero when not scheduled.
+	Sunset time.Time
+	// Link points to migration documentation.
+	Link string
+}
+
+// NewDeprecation creates a validated deprecation notice.
+func NewDeprecation(method, path string, since, sunset time.Time, link string) (Deprecation, error) {
+	if path == "" {
+		return Deprecation{}, ErrDeprecationPathRequired
+	}
+	if since.IsZero() {
+		return Deprecation{}, ErrDeprecationSinceRequired
+	}
+	if !sunset.IsZero() && !sunset.After(since) {
+		return Deprecation{}, ErrDeprecationSunsetInvalid
+	}
+
+	return Deprecation{
+		Method: strings.ToUpper(method),
+		Path:   path,
+		Since:  since,
+		Sunset: sunset,
+		Link:   link,
+	}, nil
+}
+
+// Matches reports whether the notice applies to a request.
+func (d Deprecation) Matches(method, path string) bool {
+	if d.Method != "" && d.Method != method {
+		return false
+	}
+
+	if prefix, ok := strings.CutSuffix(d.Path, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+
+	return d.Path == path
+}
+
+// IsSunset reports whether the sunset date has passed.
+func (d Deprecation) IsSunset(now time.Time) bool {
+	return !d.Sunset.IsZero() && !now.Before(d.Sunset)
+}
@@ -0,0 +1,68 @@
+package valueobject
+
+import (
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// FailedEventFilter represents filtering criteria for querying dead-lettered events.
+// Like AlertFilter, its builder methods return a new filter for chaining.
+type FailedEventFilter struct {
+	// EventIDs restricts the result to the given event IDs.
+	EventIDs []string
+	// EventType filters events by type (e.g., alert.created).
+	EventType *string
+	// Statuses filters events by what was done with them.
+	Statuses []entity.FailedEventStatus
+	// FromDate filters events dead-lettered on or after this timestamp.
+	FromDate *time.Time
+	// ToDate filters events dead-lettered on or before this timestamp.
+	ToDate *time.Time
+}
+
+// NewFailedEventFilter creates an empty FailedEventFilter with no criteria set.
+func NewFailedEventFilter() FailedEventFilter {
+	return FailedEventFilter{}
+}
+
+// WithEventIDs restricts the filter to the given event IDs.
+func (f FailedEventFilter) WithEventIDs(ids ...string) FailedEventFilter {
+	f.EventIDs = ids
+	return f
+}
+
+// WithEventType adds an event type filter. Empty types are ignored.
+func (f FailedEventFilter) WithEventType(eventType string) FailedEventFilter {
+	if eventType != "" {
+		f.EventType = &eventType
+	}
+	return f
+}
+
+// WithStatuses adds a status filter; events matching any of them are included.
+func (f FailedEventFilter) WithStatuses(statuses ...entity.FailedEventStatus) FailedEventFilter {
+	f.Statuses = statuses
+	return f
+}
+
+// WithFailedAfter adds a lower bound on the dead-letter time.
+func (f FailedEventFilter) WithFailedAfter(from time.Time) FailedEventFilter {
+	f.FromDate = &from
+	return f
+}
+
+// WithFailedBefore adds an upper bound on the dead-letter time.
+func (f FailedEventFilter) WithFailedBefore(to time.Time) FailedEventFilter {
+	f.ToDate = &to
+	return f
+}
+
+// IsEmpty returns true if no filtering criteria are set.
+func (f FailedEventFilter) IsEmpty() bool {
+	return len(f.EventIDs) == 0 &&
+		f.EventType == nil &&
+		len(f.Statuses) == 0 &&
+		f.FromDate == nil &&
+		f.ToDate == nil
+}
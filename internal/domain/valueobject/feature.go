@@ -0,0 +1,102 @@
+package valueobject
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+)
+
+// Built-in feature flags gating heavy subsystems that are rolled out gradually.
+const (
+	FeatureIncidents = "incidents"
+	FeatureGraphQL   = "graphql"
+	FeatureSSE       = "sse"
+)
+
+// Feature flag sources, from lowest to highest precedence.
+const (
+	// FeatureSourceConfig means the flag uses the deployment configuration.
+	FeatureSourceConfig = "config"
+	// FeatureSourceDeployment means a runtime override applies to the whole deployment.
+	FeatureSourceDeployment = "deployment"
+	// FeatureSourceOrg means a runtime override applies to a single organization.
+	FeatureSourceOrg = "org"
+)
+
+// ErrFeatureNameInvalid is returned when a feature name is not a lowercase identifier.
+var ErrFeatureNameInvalid = errors.New("feature name must be lowercase letters, digits, '-' or '_'")
+
+// featureNamePattern restricts feature names so they are safe as cache keys and metric labels.
+var featureNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,63}$`)
+
+// BuiltinFeatures returns the feature flags known to this version.
+func BuiltinFeatures() []string {
+	return []string{FeatureIncidents, FeatureGraphQL, FeatureSSE}
+}
+
+// ValidateFeatureName checks that a feature name can be used as a flag.
+func ValidateFeatureName(name string) error {
+	if !featureNamePattern.MatchString(name) {
+		return ErrFeatureNameInvalid
+	}
+	return nil
+}
+
+// FeatureFlag is the evaluated state of a feature flag.
+type FeatureFlag struct {
+	Name    string
+	Enabled bool
+	// Source tells which layer decided the state.
+	Source string
+}
+
+// ResolveFeature evaluates a flag from its configured default and optional
+// runtime overrides. An organization override wins over a deployment
+// override, which wins over the configuration; nil means "not set".
+func ResolveFeature(name string, configured bool, deployment, org *bool) FeatureFlag {
+	switch {
+	case org != nil:
+		return FeatureFlag{Name: name, Enabled: *org, Source: FeatureSourceOrg}
+	case deployment != nil:
+		return FeatureFlag{Name: name, Enabled: *deployment, Source: FeatureSourceDeployment}
+	default:
+		return FeatureFlag{Name: name, Enabled: configured, Source: FeatureSourceConfig}
+	}
+}
+
+// FeatureDefaults holds the configured state of every known feature flag.
+type FeatureDefaults map[string]bool
+
+// NewFeatureDefaults builds the defaults from configuration. Built-in flags
+// are always present and disabled unless configured otherwise.
+func NewFeatureDefaults(configured map[string]bool) (FeatureDefaults, error) {
+	defaults := make(FeatureDefaults, len(configured)+len(BuiltinFeatures()))
+	for _, name := range BuiltinFeatures() {
+		defaults[name] = false
+	}
+
+	for name, enabled := range configured {
+		if err := ValidateFeatureName(name); err != nil {
+			return nil, err
+		}
+		defaults[name] = enabled
+	}
+
+	return defaults, nil
+}
+
+// Names returns the known feature names in alphabetical order.
+func (d FeatureDefaults) Names() []string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Has reports whether a feature is known.
+func (d FeatureDefaults) Has(name string) bool {
+	_, ok := d[name]
+	return ok
+}
@@ -0,0 +1,52 @@
+package valueobject
+
+import (
+	"errors"
+	"time"
+)
+
+// Flapping policy errors.
+var (
+	ErrFlappingThresholdInvalid = errors.New("flapping threshold must not be negative")
+	ErrFlappingWindowInvalid    = errors.New("flapping window and dampening must be positive")
+)
+
+// FlappingPolicy detects alerts that keep firing and resolving. An alert
+// fingerprint is flapping once it changed state (fired or resolved)
+// Threshold times within Window, and stays flapping until Dampening has
+// passed without a change; notifications are suppressed meanwhile.
+//
+// The zero policy never detects flapping.
+type FlappingPolicy struct {
+	Threshold int
+	Window    time.Duration
+	Dampening time.Duration
+}
+
+// NewFlappingPolicy creates a validated flapping policy. A zero threshold
+// disables detection.
+func NewFlappingPolicy(threshold int, window, dampening time.Duration) (FlappingPolicy, error) {
+	if threshold < 0 {
+		return FlappingPolicy{}, ErrFlappingThresholdInvalid
+	}
+	if threshold > 0 && (window <= 0 || dampening <= 0) {
+		return FlappingPolicy{}, ErrFlappingWindowInvalid
+	}
+
+	return FlappingPolicy{
+		Threshold: threshold,
+		Window:    window,
+		Dampening: dampening,
+	}, nil
+}
+
+// IsEnabled reports whether flapping is detected.
+func (p FlappingPolicy) IsEnabled() bool {
+	return p.Threshold > 0
+}
+
+// IsFlapping reports whether a number of state changes within the window
+// makes an alert flapping.
+func (p FlappingPolicy) IsFlapping(changes int) bool {
+	return p.IsEnabled() && changes >= p.Threshold
+}
@@ -0,0 +1,100 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// Attributes alerts can be correlated by.
+const (
+	CorrelateByRule   = "rule"
+	CorrelateBySource = "source"
+	CorrelateByTeam   = "team"
+)
+
+// Incident correlation policy errors.
+var (
+	ErrCorrelationAttributeInvalid = errors.New("correlation attribute must be one of: rule, source, team")
+	ErrCorrelationSeverityInvalid  = errors.New("invalid correlation minimum severity")
+)
+
+// IncidentCorrelationPolicy groups new alerts into incidents. Alerts with
+// the same values of the GroupBy attributes join the incident of that
+// correlation key that is not closed yet; without one, an incident is opened
+// for alerts at least as severe as MinSeverity.
+//
+// The zero policy correlates nothing.
+type IncidentCorrelationPolicy struct {
+	GroupBy     []string
+	MinSeverity entity.AlertSeverity
+}
+
+// NewIncidentCorrelationPolicy creates a validated correlation policy. No
+// attributes disable correlation.
+func NewIncidentCorrelationPolicy(groupBy []string, minSeverity entity.AlertSeverity) (IncidentCorrelationPolicy, error) {
+	attributes := make([]string, 0, len(groupBy))
+	for _, attribute := range groupBy {
+		attribute = strings.TrimSpace(attribute)
+		switch attribute {
+		case "":
+			continue
+		case CorrelateByRule, CorrelateBySource, CorrelateByTeam:
+			attributes = append(attributes, attribute)
+		default:
+			return IncidentCorrelationPolicy{}, fmt.Errorf("%w: %q", ErrCorrelationAttributeInvalid, attribute)
+		}
+	}
+	if len(attributes) > 0 && !minSeverity.IsValid() {
+		return IncidentCorrelationPolicy{}, ErrCorrelationSeverityInvalid
+	}
+
+	return IncidentCorrelationPolicy{
+		GroupBy:     attributes,
+		MinSeverity: minSeverity,
+	}, nil
+}
+
+// IsEnabled reports whether alerts are correlated.
+func (p IncidentCorrelationPolicy) IsEnabled() bool {
+	return len(p.GroupBy) > 0
+}
+
+// Key returns the correlation key of an alert, and false when the alert
+// lacks one of the attributes, e.g. a rule for an alert sent by a webhook.
+func (p IncidentCorrelationPolicy) Key(alert *entity.Alert) (string, bool) {
+	if !p.IsEnabled() {
+		return "", false
+	}
+
+	parts := make([]string, 0, len(p.GroupBy))
+	for _, attribute := range p.GroupBy {
+		var value string
+		switch attribute {
+		case CorrelateByRule:
+			value, _ = alert.Metadata["rule_id"].(string)
+			if value == "" && alert.RuleID != nil {
+				value = alert.RuleID.String()
+			}
+		case CorrelateBySource:
+			value = alert.Source
+		case CorrelateByTeam:
+			if alert.TeamID != nil {
+				value = alert.TeamID.String()
+			}
+		}
+		if value == "" {
+			return "", false
+		}
+		parts = append(parts, attribute+"="+value)
+	}
+
+	return strings.Join(parts, ","), true
+}
+
+// Opens reports whether an alert without an incident to join opens one.
+func (p IncidentCorrelationPolicy) Opens(alert *entity.Alert) bool {
+	return p.IsEnabled() && alert.Severity.Priority() <= p.MinSeverity.Priority()
+}
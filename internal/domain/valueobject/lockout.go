@@ -0,0 +1,64 @@
+package valueobject
+
+import (
+	"errors"
+	"time"
+)
+
+// Lockout policy errors.
+var (
+	ErrLockoutAttemptsInvalid = errors.New("lockout max attempts must not be negative")
+	ErrLockoutDelayInvalid    = errors.New("lockout delays must be positive, the maximum no shorter than the base")
+)
+
+// LockoutPolicy locks an account out of logging in after repeated failed
+// attempts. Once MaxAttempts consecutive attempts failed, every further
+// failure locks the account again, for twice as long as the previous time:
+// BaseDelay, then 2x, 4x... up to MaxDelay. Failures are forgotten after a
+// successful login or ResetAfter without failures.
+//
+// The zero policy never locks an account out.
+type LockoutPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	ResetAfter  time.Duration
+}
+
+// NewLockoutPolicy creates a validated lockout policy. Zero attempts
+// disables lockouts.
+func NewLockoutPolicy(maxAttempts int, baseDelay, maxDelay, resetAfter time.Duration) (LockoutPolicy, error) {
+	if maxAttempts < 0 {
+		return LockoutPolicy{}, ErrLockoutAttemptsInvalid
+	}
+	if maxAttempts > 0 && (baseDelay <= 0 || maxDelay < baseDelay || resetAfter <= 0) {
+		return LockoutPolicy{}, ErrLockoutDelayInvalid
+	}
+
+	return LockoutPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   baseDelay,
+		MaxDelay:    maxDelay,
+		ResetAfter:  resetAfter,
+	}, nil
+}
+
+// IsEnabled reports whether accounts may be locked out.
+func (p LockoutPolicy) IsEnabled() bool {
+	return p.MaxAttempts > 0
+}
+
+// DelayAfter returns how long an account is locked after a number of
+// consecutive failed attempts; zero when it is not locked.
+func (p LockoutPolicy) DelayAfter(failures int) time.Duration {
+	if !p.IsEnabled() || failures < p.MaxAttempts {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.MaxAttempts; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxDelay)
+}
@@ -0,0 +1,111 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// ErrNetworkInvalid is returned for an entry of a network ACL that is
+// neither a CIDR range nor an IP address.
+var ErrNetworkInvalid = errors.New("network must be a CIDR range or an IP address")
+
+// NetworkACL restricts a group of routes to client networks. A client is
+// refused when its address is in a Deny network or, when Allow is not
+// empty, in none of the Allow networks.
+//
+// The zero ACL allows every client.
+type NetworkACL struct {
+	Allow []netip.Prefix
+	Deny  []netip.Prefix
+}
+
+// NewNetworkACL creates a network ACL from CIDR ranges, such as
+// "10.8.0.0/16", or single IP addresses.
+func NewNetworkACL(allow, deny []string) (NetworkACL, error) {
+	allowed, err := parseNetworks(allow)
+	if err != nil {
+		return NetworkACL{}, err
+	}
+	denied, err := parseNetworks(deny)
+	if err != nil {
+		return NetworkACL{}, err
+	}
+
+	return NetworkACL{Allow: allowed, Deny: denied}, nil
+}
+
+// IsEnabled reports whether the ACL refuses any client.
+func (a NetworkACL) IsEnabled() bool {
+	return len(a.Allow) > 0 || len(a.Deny) > 0
+}
+
+// Allows reports whether a client address may use the routes. Addresses
+// that cannot be parsed are refused by an enabled ACL.
+func (a NetworkACL) Allows(ip string) bool {
+	if !a.IsEnabled() {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, network := range a.Deny {
+		if network.Contains(addr) {
+			return false
+		}
+	}
+	if len(a.Allow) == 0 {
+		return true
+	}
+	for _, network := range a.Allow {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NetworkACLPolicy holds the network ACLs of the route groups that can be
+// restricted.
+type NetworkACLPolicy struct {
+	// Webhooks restricts the inbound webhook routes.
+	Webhooks NetworkACL
+	// Admin restricts the admin routes.
+	Admin NetworkACL
+}
+
+// parseNetworks parses CIDR ranges and IP addresses, the latter as
+// single-address ranges.
+func parseNetworks(entries []string) ([]netip.Prefix, error) {
+	networks := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if strings.Contains(entry, "/") {
+			network, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrNetworkInvalid, entry)
+			}
+			networks = append(networks, network.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrNetworkInvalid, entry)
+		}
+		addr = addr.Unmap()
+		networks = append(networks, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return networks, nil
+}
@@ -0,0 +1,68 @@
+package valueobject
+
+import (
+	"errors"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// Notification batch errors.
+var (
+	ErrBatchChannelRequired = errors.New("batch channel is required")
+	ErrBatchSeverityInvalid = errors.New("batch max severity is invalid")
+	ErrBatchIntervalInvalid = errors.New("batch interval must be at least one minute")
+)
+
+// minBatchInterval is the shortest interval between batched messages.
+const minBatchInterval = time.Minute
+
+// NotificationBatch puts a notification channel in digest mode: notifications
+// at or below MaxSeverity are collected and sent as a single summary every
+// interval instead of one message each.
+type NotificationBatch struct {
+	channel     string
+	maxSeverity entity.AlertSeverity
+	interval    time.Duration
+}
+
+// NewNotificationBatch creates a validated digest mode policy.
+// An empty max severity batches low and info notifications.
+func NewNotificationBatch(channel, maxSeverity string, interval time.Duration) (NotificationBatch, error) {
+	if channel == "" {
+		return NotificationBatch{}, ErrBatchChannelRequired
+	}
+
+	severity := entity.AlertSeverity(maxSeverity)
+	if maxSeverity == "" {
+		severity = entity.AlertSeverityLow
+	}
+	if !severity.IsValid() {
+		return NotificationBatch{}, ErrBatchSeverityInvalid
+	}
+
+	if interval < minBatchInterval {
+		return NotificationBatch{}, ErrBatchIntervalInvalid
+	}
+
+	return NotificationBatch{
+		channel:     channel,
+		maxSeverity: severity,
+		interval:    interval,
+	}, nil
+}
+
+// Channel returns the notification channel the policy applies to.
+func (b NotificationBatch) Channel() string {
+	return b.channel
+}
+
+// Interval returns how often batched notifications are sent.
+func (b NotificationBatch) Interval() time.Duration {
+	return b.interval
+}
+
+// Includes reports whether notifications of the severity are batched.
+func (b NotificationBatch) Includes(severity string) bool {
+	return entity.AlertSeverity(severity).Priority() >= b.maxSeverity.Priority()
+}
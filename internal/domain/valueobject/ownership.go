@@ -0,0 +1,32 @@
+package valueobject
+
+import "github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+
+// Actor is the user changing a resource, as seen by the ownership policy.
+//
+// Admins may change every resource. Other users may only change the
+// resources they created; resources without a recorded creator were set up
+// by admins or the system and stay admin-only.
+type Actor struct {
+	UserID entity.ID
+	Role   entity.UserRole
+}
+
+// NewActor creates an actor for a user with the given role.
+func NewActor(userID entity.ID, role entity.UserRole) Actor {
+	return Actor{UserID: userID, Role: role}
+}
+
+// IsAdmin reports whether the actor is an administrator.
+func (a Actor) IsAdmin() bool {
+	return a.Role == entity.UserRoleAdmin
+}
+
+// CanModify reports whether the actor may update or delete a resource
+// created by createdBy.
+func (a Actor) CanModify(createdBy *entity.ID) bool {
+	if a.IsAdmin() {
+		return true
+	}
+	return createdBy != nil && *createdBy == a.UserID
+}
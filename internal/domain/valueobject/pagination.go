@@ -1,5 +1,10 @@
 package valueobject
 
+import (
+	"errors"
+	"fmt"
+)
+
 // Default values and limits for pagination.
 const (
 	// DefaultPage is the default page number when none is specified.
@@ -10,6 +15,14 @@ const (
 	MaxPageSize = 100
 	// MinPageSize is the minimum allowed items per page.
 	MinPageSize = 1
+	// PageSizeCeiling is the highest MaxPageSize a deployment may configure.
+	PageSizeCeiling = 1000
+)
+
+// Pagination policy errors.
+var (
+	ErrDefaultPageSizeInvalid = errors.New("default page size must be at least 1")
+	ErrMaxPageSizeInvalid     = fmt.Errorf("max page size must be between the default page size and %d", PageSizeCeiling)
 )
 
 // Pagination represents pagination parameters for database queries.
@@ -32,7 +45,7 @@ func NewPagination(page, pageSize int) Pagination {
 	}
 
 	// Normalize values to valid ranges
-	p.normalize()
+	p.normalize(DefaultPageSize, MaxPageSize)
 
 	return p
 }
@@ -48,22 +61,74 @@ func DefaultPagination() Pagination {
 
 // normalize adjusts pagination values to valid ranges.
 // This is an internal method called during construction.
-func (p *Pagination) normalize() {
+func (p *Pagination) normalize(defaultPageSize, maxPageSize int) {
 	// Minimum page is 1
 	if p.page < 1 {
 		p.page = DefaultPage
 	}
 
-	// PageSize must be between MinPageSize and MaxPageSize
+	// PageSize must be between MinPageSize and maxPageSize
 	if p.pageSize < MinPageSize {
-		p.pageSize = DefaultPageSize
+		p.pageSize = defaultPageSize
 	}
 
-	if p.pageSize > MaxPageSize {
-		p.pageSize = MaxPageSize
+	if p.pageSize > maxPageSize {
+		p.pageSize = maxPageSize
 	}
 }
 
+// PaginationPolicy holds the page size limits of a deployment.
+// The zero value applies DefaultPageSize and MaxPageSize.
+type PaginationPolicy struct {
+	defaultPageSize int
+	maxPageSize     int
+}
+
+// NewPaginationPolicy creates a policy with the given default and maximum page sizes.
+// Returns an error unless 1 <= defaultPageSize <= maxPageSize <= PageSizeCeiling.
+func NewPaginationPolicy(defaultPageSize, maxPageSize int) (PaginationPolicy, error) {
+	if defaultPageSize < MinPageSize {
+		return PaginationPolicy{}, ErrDefaultPageSizeInvalid
+	}
+
+	if maxPageSize < defaultPageSize || maxPageSize > PageSizeCeiling {
+		return PaginationPolicy{}, ErrMaxPageSizeInvalid
+	}
+
+	return PaginationPolicy{
+		defaultPageSize: defaultPageSize,
+		maxPageSize:     maxPageSize,
+	}, nil
+}
+
+// DefaultPageSize returns the page size used when none is requested.
+func (p PaginationPolicy) DefaultPageSize() int {
+	if p.defaultPageSize == 0 {
+		return DefaultPageSize
+	}
+	return p.defaultPageSize
+}
+
+// MaxPageSize returns the largest page size that may be requested.
+func (p PaginationPolicy) MaxPageSize() int {
+	if p.maxPageSize == 0 {
+		return MaxPageSize
+	}
+	return p.maxPageSize
+}
+
+// Paginate creates a Pagination normalized to the policy's limits.
+func (p PaginationPolicy) Paginate(page, pageSize int) Pagination {
+	pagination := Pagination{
+		page:     page,
+		pageSize: pageSize,
+	}
+
+	pagination.normalize(p.DefaultPageSize(), p.MaxPageSize())
+
+	return pagination
+}
+
 // Page returns the current page number (1-indexed).
 func (p Pagination) Page() int {
 	return p.page
@@ -0,0 +1,147 @@
+package valueobject
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// Quiet hours errors.
+var (
+	ErrQuietHoursChannelRequired = errors.New("quiet hours channel is required")
+	ErrQuietHoursTimeInvalid     = errors.New("quiet hours start and end must be HH:MM")
+	ErrQuietHoursEmpty           = errors.New("quiet hours start and end must differ")
+	ErrQuietHoursTimezoneInvalid = errors.New("quiet hours timezone is invalid")
+	ErrQuietHoursDayInvalid      = errors.New("quiet hours day is invalid")
+	ErrQuietHoursSeverityInvalid = errors.New("quiet hours bypass severity is invalid")
+)
+
+// weekdays maps lowercase day names and abbreviations to weekdays.
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// QuietHours is a recurring do-not-disturb window of a notification channel,
+// evaluated in the channel's timezone. Windows may span midnight (22:00-07:00);
+// such a window belongs to the day it starts on.
+type QuietHours struct {
+	channel  string
+	start    int
+	end      int
+	location *time.Location
+	days     map[time.Weekday]bool
+	bypass   entity.AlertSeverity
+}
+
+// NewQuietHours creates a validated quiet hours window.
+// Start and end use the HH:MM format; an empty timezone means UTC and no days
+// means every day. Notifications at or above the bypass severity (empty for
+// none) are delivered even during quiet hours.
+func NewQuietHours(channel, start, end, timezone string, days []string, bypass string) (QuietHours, error) {
+	if channel == "" {
+		return QuietHours{}, ErrQuietHoursChannelRequired
+	}
+
+	startMinute, err := parseClock(start)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	endMinute, err := parseClock(end)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	if startMinute == endMinute {
+		return QuietHours{}, ErrQuietHoursEmpty
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return QuietHours{}, ErrQuietHoursTimezoneInvalid
+	}
+
+	var dayset map[time.Weekday]bool
+	if len(days) > 0 {
+		dayset = make(map[time.Weekday]bool, len(days))
+		for _, day := range days {
+			weekday, ok := weekdays[strings.ToLower(strings.TrimSpace(day))]
+			if !ok {
+				return QuietHours{}, ErrQuietHoursDayInvalid
+			}
+			dayset[weekday] = true
+		}
+	}
+
+	severity := entity.AlertSeverity(bypass)
+	if bypass != "" && !severity.IsValid() {
+		return QuietHours{}, ErrQuietHoursSeverityInvalid
+	}
+
+	return QuietHours{
+		channel:  channel,
+		start:    startMinute,
+		end:      endMinute,
+		location: location,
+		days:     dayset,
+		bypass:   severity,
+	}, nil
+}
+
+// Channel returns the notification channel the window applies to.
+func (q QuietHours) Channel() string {
+	return q.channel
+}
+
+// IsQuiet reports whether the window is in effect at the given instant.
+func (q QuietHours) IsQuiet(now time.Time) bool {
+	local := now.In(q.location)
+	minute := local.Hour()*60 + local.Minute()
+
+	if q.start < q.end {
+		return minute >= q.start && minute < q.end && q.onDay(local.Weekday())
+	}
+
+	// The window spans midnight
+	switch {
+	case minute >= q.start:
+		return q.onDay(local.Weekday())
+	case minute < q.end:
+		return q.onDay(local.AddDate(0, 0, -1).Weekday())
+	default:
+		return false
+	}
+}
+
+// Bypasses reports whether a notification of the severity ignores the window.
+func (q QuietHours) Bypasses(severity string) bool {
+	if q.bypass == "" {
+		return false
+	}
+	return entity.AlertSeverity(severity).Priority() <= q.bypass.Priority()
+}
+
+// Suppresses reports whether a notification of the severity must be held back at the given instant.
+func (q QuietHours) Suppresses(now time.Time, severity string) bool {
+	return q.IsQuiet(now) && !q.Bypasses(severity)
+}
+
+// onDay reports whether windows starting on the weekday are active.
+func (q QuietHours) onDay(day time.Weekday) bool {
+	return len(q.days) == 0 || q.days[day]
+}
+
+// parseClock parses an HH:MM time of day into minutes since midnight.
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, ErrQuietHoursTimeInvalid
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,155 @@
+package valueobject
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// Quota errors.
+var (
+	ErrQuotaInvalid        = errors.New("quota must not be negative")
+	ErrQuotaSubjectInvalid = errors.New("quota subject must be team:<id> or source:<id>")
+)
+
+// QuotaSubjectKind is the kind of client a quota is tracked for.
+type QuotaSubjectKind string
+
+const (
+	// QuotaSubjectTeam tracks the usage of a team.
+	QuotaSubjectTeam QuotaSubjectKind = "team"
+	// QuotaSubjectSource tracks the usage of an inbound webhook source,
+	// the API key of machine clients.
+	QuotaSubjectSource QuotaSubjectKind = "source"
+)
+
+// QuotaSubject identifies the team or webhook source a quota is tracked for.
+type QuotaSubject struct {
+	Kind QuotaSubjectKind
+	ID   entity.ID
+}
+
+// TeamQuotaSubject returns the quota subject of a team.
+func TeamQuotaSubject(teamID entity.ID) QuotaSubject {
+	return QuotaSubject{Kind: QuotaSubjectTeam, ID: teamID}
+}
+
+// SourceQuotaSubject returns the quota subject of a webhook source.
+func SourceQuotaSubject(sourceID entity.ID) QuotaSubject {
+	return QuotaSubject{Kind: QuotaSubjectSource, ID: sourceID}
+}
+
+// ParseQuotaSubject parses a subject in its "kind:id" form.
+func ParseQuotaSubject(s string) (QuotaSubject, error) {
+	kind, rawID, ok := strings.Cut(s, ":")
+	if !ok {
+		return QuotaSubject{}, ErrQuotaSubjectInvalid
+	}
+
+	return NewQuotaSubject(QuotaSubjectKind(kind), rawID)
+}
+
+// NewQuotaSubject creates a quota subject from its kind and ID.
+func NewQuotaSubject(kind QuotaSubjectKind, rawID string) (QuotaSubject, error) {
+	if kind != QuotaSubjectTeam && kind != QuotaSubjectSource {
+		return QuotaSubject{}, ErrQuotaSubjectInvalid
+	}
+	id, err := entity.ParseID(rawID)
+	if err != nil {
+		return QuotaSubject{}, ErrQuotaSubjectInvalid
+	}
+
+	return QuotaSubject{Kind: kind, ID: id}, nil
+}
+
+// String returns the subject in its "kind:id" form.
+func (s QuotaSubject) String() string {
+	return string(s.Kind) + ":" + s.ID.String()
+}
+
+// QuotaMetric is what a quota counts.
+type QuotaMetric string
+
+const (
+	// QuotaRequests counts API requests.
+	QuotaRequests QuotaMetric = "requests"
+	// QuotaAlerts counts alerts created.
+	QuotaAlerts QuotaMetric = "alerts"
+)
+
+// QuotaLimits are the daily quotas of a subject. Zero means unlimited.
+type QuotaLimits struct {
+	RequestsPerDay int
+	AlertsPerDay   int
+}
+
+// NewQuotaLimits creates validated quota limits.
+func NewQuotaLimits(requestsPerDay, alertsPerDay int) (QuotaLimits, error) {
+	if requestsPerDay < 0 || alertsPerDay < 0 {
+		return QuotaLimits{}, ErrQuotaInvalid
+	}
+	return QuotaLimits{RequestsPerDay: requestsPerDay, AlertsPerDay: alertsPerDay}, nil
+}
+
+// For returns the daily limit of a metric.
+func (l QuotaLimits) For(metric QuotaMetric) int {
+	switch metric {
+	case QuotaRequests:
+		return l.RequestsPerDay
+	case QuotaAlerts:
+		return l.AlertsPerDay
+	default:
+		return 0
+	}
+}
+
+// QuotaPolicy holds the daily quotas of teams and webhook sources. Days are
+// UTC days. The zero policy limits nothing.
+type QuotaPolicy struct {
+	// Default applies to the subjects without an override.
+	Default QuotaLimits
+	// Overrides replace Default for some subjects.
+	Overrides map[QuotaSubject]QuotaLimits
+}
+
+// LimitsFor returns the quotas of a subject.
+func (p QuotaPolicy) LimitsFor(subject QuotaSubject) QuotaLimits {
+	if limits, ok := p.Overrides[subject]; ok {
+		return limits
+	}
+	return p.Default
+}
+
+// IsEnabled reports whether any subject has a quota.
+func (p QuotaPolicy) IsEnabled() bool {
+	if p.Default != (QuotaLimits{}) {
+		return true
+	}
+	for _, limits := range p.Overrides {
+		if limits != (QuotaLimits{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// QuotaDay returns the UTC day a time falls in, as YYYY-MM-DD.
+func QuotaDay(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// QuotaResetAt returns when the quotas of the day t falls in reset: the
+// next UTC midnight.
+func QuotaResetAt(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+}
+
+// QuotaUsage is the usage of a quota during a day.
+type QuotaUsage struct {
+	Subject QuotaSubject
+	Metric  QuotaMetric
+	Used    int
+}
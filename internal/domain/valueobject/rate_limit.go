@@ -0,0 +1,134 @@
+package valueobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// Rate limit errors.
+var (
+	ErrRateLimitInvalid       = errors.New("rate limit must not be negative")
+	ErrRateLimitWindowInvalid = errors.New("rate limit window must be positive")
+	ErrRateLimitPathRequired  = errors.New("rate limit path is required")
+)
+
+// RateLimit is the number of requests a client may make within a sliding
+// window. Authenticated users are limited per user, with the limit of their
+// role when one is set; anonymous clients are limited per IP address.
+// A zero limit disables limiting.
+type RateLimit struct {
+	// Limit is the number of requests allowed in any window.
+	Limit int
+	// Window is the duration of the sliding window.
+	Window time.Duration
+	// RoleLimits override Limit for the users with a role; zero exempts them.
+	RoleLimits map[entity.UserRole]int
+}
+
+// NewRateLimit creates a validated rate limit.
+func NewRateLimit(limit int, window time.Duration, roleLimits map[entity.UserRole]int) (RateLimit, error) {
+	if limit < 0 {
+		return RateLimit{}, ErrRateLimitInvalid
+	}
+	for role, roleLimit := range roleLimits {
+		if !role.IsValid() {
+			return RateLimit{}, fmt.Errorf("%w: %s", entity.ErrUserInvalidRole, role)
+		}
+		if roleLimit < 0 {
+			return RateLimit{}, fmt.Errorf("%w: %s", ErrRateLimitInvalid, role)
+		}
+	}
+	if window <= 0 && (limit > 0 || len(roleLimits) > 0) {
+		return RateLimit{}, ErrRateLimitWindowInvalid
+	}
+
+	return RateLimit{
+		Limit:      limit,
+		Window:     window,
+		RoleLimits: roleLimits,
+	}, nil
+}
+
+// For returns the limit of a user with the given role, or of an anonymous
+// client when the role is empty.
+func (r RateLimit) For(role entity.UserRole) int {
+	if limit, ok := r.RoleLimits[role]; ok {
+		return limit
+	}
+	return r.Limit
+}
+
+// IsEnabled reports whether any client is limited.
+func (r RateLimit) IsEnabled() bool {
+	if r.Window <= 0 {
+		return false
+	}
+	if r.Limit > 0 {
+		return true
+	}
+	for _, limit := range r.RoleLimits {
+		if limit > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RouteRateLimit limits the requests to some endpoints in a window of their
+// own, on top of the API-wide limit.
+type RouteRateLimit struct {
+	// Method restricts the limit to one HTTP method; empty matches all.
+	Method string
+	// Path is an exact path, or a prefix when it ends with "*".
+	Path string
+	RateLimit
+}
+
+// NewRouteRateLimit creates a validated route rate limit.
+func NewRouteRateLimit(method, path string, limit RateLimit) (RouteRateLimit, error) {
+	if path == "" {
+		return RouteRateLimit{}, ErrRateLimitPathRequired
+	}
+
+	return RouteRateLimit{
+		Method:    strings.ToUpper(method),
+		Path:      path,
+		RateLimit: limit,
+	}, nil
+}
+
+// Matches reports whether the limit applies to a request.
+func (r RouteRateLimit) Matches(method, path string) bool {
+	if r.Method != "" && r.Method != method {
+		return false
+	}
+
+	if prefix, ok := strings.CutSuffix(r.Path, "*"); ok {
+		return strings.HasPrefix(path, prefix)
+	}
+
+	return r.Path == path
+}
+
+// Name identifies the route limit, e.g. in the keys of its counters.
+func (r RouteRateLimit) Name() string {
+	if r.Method == "" {
+		return r.Path
+	}
+	return r.Method + " " + r.Path
+}
+
+// RateLimitPolicy holds the rate limits of the API. The zero policy limits
+// nothing.
+type RateLimitPolicy struct {
+	// API limits every request to the API.
+	API RateLimit
+	// Login limits the login attempts of each client.
+	Login RateLimit
+	// Routes limit some endpoints further; every matching limit applies.
+	Routes []RouteRateLimit
+}
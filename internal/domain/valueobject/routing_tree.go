@@ -0,0 +1,110 @@
+package valueobject
+
+import (
+	"sort"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+)
+
+// maxRouteDepth guards against cycles in stored routes.
+const maxRouteDepth = 32
+
+// RoutingTree evaluates notification routes, Alertmanager style: sibling
+// routes are tried by position, a matching route hands over to its first
+// matching children (or delivers to its own channels when none match), and
+// evaluation stops at the first matching sibling unless it has Continue set.
+// The zero value has no routes.
+type RoutingTree struct {
+	roots    []*entity.NotificationRoute
+	children map[entity.ID][]*entity.NotificationRoute
+}
+
+// NewRoutingTree builds a routing tree from a flat list of routes.
+// Routes whose parent is missing are ignored.
+func NewRoutingTree(routes []*entity.NotificationRoute) RoutingTree {
+	known := make(map[entity.ID]bool, len(routes))
+	for _, route := range routes {
+		known[route.ID] = true
+	}
+
+	tree := RoutingTree{children: make(map[entity.ID][]*entity.NotificationRoute)}
+	for _, route := range routes {
+		switch {
+		case route.ParentID == nil:
+			tree.roots = append(tree.roots, route)
+		case known[*route.ParentID]:
+			tree.children[*route.ParentID] = append(tree.children[*route.ParentID], route)
+		}
+	}
+
+	sortRoutes(tree.roots)
+	for _, siblings := range tree.children {
+		sortRoutes(siblings)
+	}
+
+	return tree
+}
+
+// IsEmpty reports whether the tree has no routes.
+func (t RoutingTree) IsEmpty() bool {
+	return len(t.roots) == 0
+}
+
+// Resolve returns the channels a notification is routed to. It reports false
+// when no route matches, in which case the notification is not routed.
+func (t RoutingTree) Resolve(msg notification.Message) ([]string, bool) {
+	channels, matched := t.walk(t.roots, msg, 0)
+	if !matched {
+		return nil, false
+	}
+
+	seen := make(map[string]bool, len(channels))
+	unique := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		if !seen[channel] {
+			seen[channel] = true
+			unique = append(unique, channel)
+		}
+	}
+
+	return unique, true
+}
+
+// walk evaluates a set of sibling routes.
+func (t RoutingTree) walk(routes []*entity.NotificationRoute, msg notification.Message, depth int) ([]string, bool) {
+	if depth > maxRouteDepth {
+		return nil, false
+	}
+
+	var channels []string
+	matched := false
+	for _, route := range routes {
+		if !route.Match.Matches(msg) {
+			continue
+		}
+		matched = true
+
+		if nested, ok := t.walk(t.children[route.ID], msg, depth+1); ok {
+			channels = append(channels, nested...)
+		} else {
+			channels = append(channels, route.Channels...)
+		}
+
+		if !route.Continue {
+			break
+		}
+	}
+
+	return channels, matched
+}
+
+// sortRoutes orders siblings by position, then by name.
+func sortRoutes(routes []*entity.NotificationRoute) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Position != routes[j].Position {
+			return routes[i].Position < routes[j].Position
+		}
+		return routes[i].Name < routes[j].Name
+	})
+}
@@ -0,0 +1,99 @@
+package valueobject
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// SeverityPolicy re-classifies the severity of new alerts with severity
+// rules: enabled rules are tried by position, then by name, and the first
+// one matching an alert gives it its severity. The zero value has no rules.
+type SeverityPolicy struct {
+	rules []compiledSeverityRule
+}
+
+// compiledSeverityRule is a severity rule with its title pattern compiled.
+type compiledSeverityRule struct {
+	rule  *entity.SeverityRule
+	title *regexp.Regexp
+}
+
+// NewSeverityPolicy builds a policy from severity rules. Disabled rules and
+// rules whose title pattern does not compile are left out.
+func NewSeverityPolicy(rules []*entity.SeverityRule) SeverityPolicy {
+	var policy SeverityPolicy
+	for _, rule := range rules {
+		if !rule.IsEnabled {
+			continue
+		}
+
+		compiled := compiledSeverityRule{rule: rule}
+		if rule.Match.TitlePattern != "" {
+			title, err := regexp.Compile(rule.Match.TitlePattern)
+			if err != nil {
+				continue
+			}
+			compiled.title = title
+		}
+		policy.rules = append(policy.rules, compiled)
+	}
+
+	sort.SliceStable(policy.rules, func(i, j int) bool {
+		a, b := policy.rules[i].rule, policy.rules[j].rule
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.Name < b.Name
+	})
+
+	return policy
+}
+
+// IsEmpty reports whether the policy has no enabled rules.
+func (p SeverityPolicy) IsEmpty() bool {
+	return len(p.rules) == 0
+}
+
+// Match returns the first rule matching the alert, or nil when none does.
+func (p SeverityPolicy) Match(alert *entity.Alert) *entity.SeverityRule {
+	for _, compiled := range p.rules {
+		if compiled.matches(alert) {
+			return compiled.rule
+		}
+	}
+	return nil
+}
+
+// matches reports whether every criterion of the rule matches the alert.
+func (c compiledSeverityRule) matches(alert *entity.Alert) bool {
+	match := c.rule.Match
+	if match.IsEmpty() {
+		return false
+	}
+
+	if len(match.Sources) > 0 && !slices.Contains(match.Sources, alert.Source) {
+		return false
+	}
+
+	if len(match.Severities) > 0 && !slices.Contains(match.Severities, alert.Severity) {
+		return false
+	}
+
+	if c.title != nil && !c.title.MatchString(alert.Title) {
+		return false
+	}
+
+	if len(match.Labels) > 0 {
+		labels := alert.Labels()
+		for key, value := range match.Labels {
+			if labels[key] != value {
+				return false
+			}
+		}
+	}
+
+	return true
+}
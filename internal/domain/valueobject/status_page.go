@@ -0,0 +1,150 @@
+package valueobject
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ComponentStatus is the status of a component of a status page.
+type ComponentStatus string
+
+// Component statuses, as defined by Statuspage.
+const (
+	ComponentOperational         ComponentStatus = "operational"
+	ComponentDegradedPerformance ComponentStatus = "degraded_performance"
+	ComponentPartialOutage       ComponentStatus = "partial_outage"
+	ComponentMajorOutage         ComponentStatus = "major_outage"
+	ComponentUnderMaintenance    ComponentStatus = "under_maintenance"
+)
+
+// Status page errors.
+var (
+	ErrComponentStatusInvalid = errors.New(
+		"component status must be operational, degraded_performance, partial_outage, major_outage or under_maintenance")
+	ErrComponentMappingInvalid   = errors.New("component mappings must be source=component_id pairs")
+	ErrComponentMappingDuplicate = errors.New("a source can only be mapped to one component")
+)
+
+// ParseComponentStatus parses a component status.
+func ParseComponentStatus(value string) (ComponentStatus, error) {
+	switch status := ComponentStatus(value); status {
+	case ComponentOperational, ComponentDegradedPerformance, ComponentPartialOutage,
+		ComponentMajorOutage, ComponentUnderMaintenance:
+		return status, nil
+	default:
+		return "", ErrComponentStatusInvalid
+	}
+}
+
+// ComponentStatusFor returns the status of a component whose most severe
+// unresolved alert has the given severity; "" means no unresolved alert.
+// Critical alerts are a major outage, high ones a partial outage and medium
+// ones degraded performance; low and info alerts leave it operational.
+func ComponentStatusFor(severity entity.AlertSeverity) ComponentStatus {
+	switch severity {
+	case entity.AlertSeverityCritical:
+		return ComponentMajorOutage
+	case entity.AlertSeverityHigh:
+		return ComponentPartialOutage
+	case entity.AlertSeverityMedium:
+		return ComponentDegradedPerformance
+	default:
+		return ComponentOperational
+	}
+}
+
+// StatusPageComponent is the state of a component of a status page.
+type StatusPageComponent struct {
+	ID string
+	// Sources are the alert sources affecting the component.
+	Sources []string
+	// AlertStatus is the status derived from the unresolved alerts of the
+	// sources, and Override the status set by hand, if any.
+	AlertStatus ComponentStatus
+	Override    ComponentStatus
+	// Status is the status published: the override if set, the alert status
+	// otherwise.
+	Status ComponentStatus
+}
+
+// ResolveComponent builds the state of a component from the status derived
+// from its alerts and its override ("" when not set).
+func ResolveComponent(id string, sources []string, alertStatus, override ComponentStatus) StatusPageComponent {
+	status := alertStatus
+	if override != "" {
+		status = override
+	}
+
+	return StatusPageComponent{
+		ID:          id,
+		Sources:     sources,
+		AlertStatus: alertStatus,
+		Override:    override,
+		Status:      status,
+	}
+}
+
+// StatusPageComponents maps alert sources to the status page components they
+// affect. A component may be affected by several sources.
+type StatusPageComponents struct {
+	bySource    map[string]string
+	byComponent map[string][]string
+}
+
+// NewStatusPageComponents builds the mapping from source=component_id pairs.
+func NewStatusPageComponents(pairs []string) (StatusPageComponents, error) {
+	c := StatusPageComponents{
+		bySource:    make(map[string]string, len(pairs)),
+		byComponent: make(map[string][]string),
+	}
+
+	for _, pair := range pairs {
+		source, component, ok := strings.Cut(pair, "=")
+		source, component = strings.TrimSpace(source), strings.TrimSpace(component)
+		if !ok || source == "" || component == "" {
+			return StatusPageComponents{}, ErrComponentMappingInvalid
+		}
+		if _, exists := c.bySource[source]; exists {
+			return StatusPageComponents{}, ErrComponentMappingDuplicate
+		}
+
+		c.bySource[source] = component
+		c.byComponent[component] = append(c.byComponent[component], source)
+	}
+
+	for _, sources := range c.byComponent {
+		sort.Strings(sources)
+	}
+
+	return c, nil
+}
+
+// IsEmpty reports whether no source is mapped.
+func (c StatusPageComponents) IsEmpty() bool {
+	return len(c.bySource) == 0
+}
+
+// ComponentOf returns the component a source affects.
+func (c StatusPageComponents) ComponentOf(source string) (string, bool) {
+	component, ok := c.bySource[source]
+	return component, ok
+}
+
+// Sources returns the sources affecting a component, in alphabetical order,
+// or nil if the component is not mapped.
+func (c StatusPageComponents) Sources(component string) []string {
+	return c.byComponent[component]
+}
+
+// Components returns the mapped components in alphabetical order.
+func (c StatusPageComponents) Components() []string {
+	components := make([]string, 0, len(c.byComponent))
+	for component := range c.byComponent {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	return components
+}
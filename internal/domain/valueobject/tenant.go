@@ -0,0 +1,86 @@
+package valueobject
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// TenantScope is the set of teams whose data a caller may access.
+// Data not owned by any team is shared and visible to every scope.
+//
+// The zero value grants access to shared data only, so callers whose
+// memberships could not be resolved never see another team's data.
+type TenantScope struct {
+	all   bool
+	teams []entity.ID
+}
+
+// AllTenants returns a scope spanning every team, for admins and the system.
+func AllTenants() TenantScope {
+	return TenantScope{all: true}
+}
+
+// TeamsScope returns a scope limited to the given teams and shared data.
+func TeamsScope(teamIDs ...entity.ID) TenantScope {
+	teams := make([]entity.ID, len(teamIDs))
+	copy(teams, teamIDs)
+	sort.Slice(teams, func(i, j int) bool { return teams[i].String() < teams[j].String() })
+	return TenantScope{teams: teams}
+}
+
+// IsAll returns true if the scope is unrestricted.
+func (s TenantScope) IsAll() bool {
+	return s.all
+}
+
+// Teams returns the teams of a restricted scope.
+func (s TenantScope) Teams() []entity.ID {
+	return s.teams
+}
+
+// Includes reports whether the scope covers a team.
+func (s TenantScope) Includes(teamID entity.ID) bool {
+	if s.all {
+		return true
+	}
+	for _, id := range s.teams {
+		if id == teamID {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether data owned by teamID is accessible.
+// A nil team marks shared data.
+func (s TenantScope) Allows(teamID *entity.ID) bool {
+	return teamID == nil || s.Includes(*teamID)
+}
+
+// Narrow restricts the scope to a single team it includes.
+// It returns false when the team is outside the scope.
+func (s TenantScope) Narrow(teamID entity.ID) (TenantScope, bool) {
+	if !s.Includes(teamID) {
+		return TenantScope{}, false
+	}
+	return TeamsScope(teamID), true
+}
+
+// Key returns a stable identifier of the scope, suitable for cache keys.
+func (s TenantScope) Key() string {
+	if s.all {
+		return "all"
+	}
+	if len(s.teams) == 0 {
+		return "shared"
+	}
+
+	hash := sha256.New()
+	for _, id := range s.teams {
+		hash.Write([]byte(id.String()))
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
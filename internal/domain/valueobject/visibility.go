@@ -0,0 +1,119 @@
+package valueobject
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// VisibilityRule restricts alerts matching a source and/or set of labels
+// to the given roles. Admins can always see every alert.
+//
+// A rule matches an alert when every criterion that is set matches:
+//   - Source matches the alert source exactly or as a prefix ("security"
+//     matches "security" and "security:ids-1").
+//   - Labels must all be present with the same value in the alert's
+//     "labels" metadata, as populated by the webhook integrations.
+type VisibilityRule struct {
+	// Name identifies the rule in logs and configuration.
+	Name string
+	// Source restricts alerts coming from this source.
+	Source string
+	// Labels restricts alerts carrying all of these labels.
+	Labels map[string]string
+	// Roles lists the roles allowed to see matching alerts.
+	Roles []entity.UserRole
+}
+
+// Matches reports whether the rule applies to the alert.
+// A rule without any criteria matches nothing.
+func (r VisibilityRule) Matches(alert *entity.Alert) bool {
+	if r.Source == "" && len(r.Labels) == 0 {
+		return false
+	}
+
+	if r.Source != "" && alert.Source != r.Source && !strings.HasPrefix(alert.Source, r.Source+":") {
+		return false
+	}
+
+	if len(r.Labels) > 0 {
+		labels := alert.Labels()
+		for key, value := range r.Labels {
+			if labels[key] != value {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Allows reports whether the role may see alerts matching the rule.
+func (r VisibilityRule) Allows(role entity.UserRole) bool {
+	if role == entity.UserRoleAdmin {
+		return true
+	}
+	for _, allowed := range r.Roles {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// VisibilityPolicy is the set of visibility rules of an installation.
+// An alert is visible to a role when every rule matching it allows the role.
+type VisibilityPolicy struct {
+	rules []VisibilityRule
+}
+
+// NewVisibilityPolicy creates a policy from the given rules.
+func NewVisibilityPolicy(rules ...VisibilityRule) VisibilityPolicy {
+	return VisibilityPolicy{rules: rules}
+}
+
+// Rules returns the rules of the policy.
+func (p VisibilityPolicy) Rules() []VisibilityRule {
+	return p.rules
+}
+
+// IsEmpty returns true if the policy restricts nothing.
+func (p VisibilityPolicy) IsEmpty() bool {
+	return len(p.rules) == 0
+}
+
+// CanView reports whether the role may see the alert.
+func (p VisibilityPolicy) CanView(alert *entity.Alert, role entity.UserRole) bool {
+	for _, rule := range p.rules {
+		if rule.Matches(alert) && !rule.Allows(role) {
+			return false
+		}
+	}
+	return true
+}
+
+// HiddenFor returns the rules whose alerts the role may not see.
+// Queries on behalf of the role must exclude alerts matching any of them.
+func (p VisibilityPolicy) HiddenFor(role entity.UserRole) []VisibilityRule {
+	var hidden []VisibilityRule
+	for _, rule := range p.rules {
+		if !rule.Allows(role) {
+			hidden = append(hidden, rule)
+		}
+	}
+	return hidden
+}
+
+// ParseLabelSelector parses "key=value" label selectors into a label map.
+func ParseLabelSelector(selectors []string) (map[string]string, error) {
+	labels := make(map[string]string, len(selectors))
+	for _, selector := range selectors {
+		key, value, ok := strings.Cut(selector, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label selector %q: expected key=value", selector)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
@@ -9,15 +9,30 @@ import (
 // Config holds all application configuration
 type Config struct {
 	App          AppConfig          `mapstructure:"app"`
+	Deployment   DeploymentConfig   `mapstructure:"deployment"`
 	Server       ServerConfig       `mapstructure:"server"`
 	Database     DatabaseConfig     `mapstructure:"database"`
 	Redis        RedisConfig        `mapstructure:"redis"`
+	Cache        CacheConfig        `mapstructure:"cache"`
 	JWT          JWTConfig          `mapstructure:"jwt"`
 	Logging      LoggingConfig      `mapstructure:"logging"`
 	WebSocket    WebSocketConfig    `mapstructure:"websocket"`
 	EventBus     EventBusConfig     `mapstructure:"event_bus"`
 	Notification NotificationConfig `mapstructure:"notification"`
 	Tracing      TracingConfig      `mapstructure:"tracing"`
+	Federation   FederationConfig   `mapstructure:"federation"`
+	Alerts       AlertsConfig       `mapstructure:"alerts"`
+	Webhooks     WebhooksConfig     `mapstructure:"webhooks"`
+	Visibility   VisibilityConfig   `mapstructure:"visibility"`
+	Pagination   PaginationConfig   `mapstructure:"pagination"`
+	RateLimit    RateLimitConfig    `mapstructure:"rate_limit"`
+	Quota        QuotaConfig        `mapstructure:"quota"`
+	Lockout      LockoutConfig      `mapstructure:"lockout"`
+	NetworkACL   NetworkACLConfig   `mapstructure:"network_acl"`
+	Enrichment   EnrichmentConfig   `mapstructure:"enrichment"`
+	Features     FeaturesConfig     `mapstructure:"features"`
+	Deprecation  DeprecationConfig  `mapstructure:"deprecation"`
+	Scheduler    SchedulerConfig    `mapstructure:"scheduler"`
 }
 
 // AppConfig manage environment the app
@@ -27,6 +42,14 @@ type AppConfig struct {
 	Version string `mapstructure:"version"`
 }
 
+// DeploymentConfig identifies where this instance runs.
+// It is stamped onto alerts, events and metrics so that multi-region
+// (active/active) installations can tell their data apart.
+type DeploymentConfig struct {
+	Region  string `mapstructure:"region"`
+	Cluster string `mapstructure:"cluster"`
+}
+
 // ServerConfig manage the timing API rest
 type ServerConfig struct {
 	Host         string        `mapstructure:"host"`
@@ -34,6 +57,77 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
 	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`
+	// MetricsPort serves /metrics on its own listener, outside the API.
+	// Zero serves it on the API port.
+	MetricsPort int `mapstructure:"metrics_port"`
+	// ShutdownTimeout bounds the graceful shutdown: draining HTTP requests,
+	// WebSocket clients and in-flight events.
+	ShutdownTimeout time.Duration  `mapstructure:"shutdown_timeout"`
+	CORS            CORSConfig     `mapstructure:"cors"`
+	Security        SecurityConfig `mapstructure:"security"`
+	// ProxyHeader, e.g. X-Forwarded-For, carries the client address when
+	// the request comes from one of TrustedProxies (IPs or CIDR ranges).
+	// Without it, the client address is the peer address.
+	ProxyHeader    string   `mapstructure:"proxy_header"`
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+	UI             UIConfig `mapstructure:"ui"`
+}
+
+// UIConfig controls the web dashboard embedded in the binary and served
+// under /ui.
+type UIConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// SecurityConfig holds the browser security headers set on every response
+// and the optional CSRF protection.
+type SecurityConfig struct {
+	Headers SecurityHeadersConfig `mapstructure:"headers"`
+	CSRF    CSRFConfig            `mapstructure:"csrf"`
+}
+
+// SecurityHeadersConfig controls the security headers. A zero HSTSMaxAge
+// omits Strict-Transport-Security, an empty FrameOptions X-Frame-Options and
+// an empty ContentSecurityPolicy Content-Security-Policy.
+type SecurityHeadersConfig struct {
+	Enabled               bool          `mapstructure:"enabled"`
+	HSTSMaxAge            time.Duration `mapstructure:"hsts_max_age"`
+	HSTSIncludeSubdomains bool          `mapstructure:"hsts_include_subdomains"`
+	FrameOptions          string        `mapstructure:"frame_options"`
+	ContentSecurityPolicy string        `mapstructure:"content_security_policy"`
+}
+
+// CSRFConfig enables double-submit CSRF protection, for deployments where
+// browsers authenticate with cookies. Requests that change state and carry
+// cookies must echo the token of the CookieName cookie in the X-CSRF-Token
+// header. ExemptPaths are exact or prefixes ending with "*".
+type CSRFConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`
+	CookieName   string   `mapstructure:"cookie_name"`
+	CookieSecure bool     `mapstructure:"cookie_secure"`
+	SameSite     string   `mapstructure:"same_site"`
+	ExemptPaths  []string `mapstructure:"exempt_paths"`
+}
+
+// CORSConfig controls which browser origins may call the API. With no
+// origins, development allows every origin and other environments allow
+// none. Credentials (cookies) require explicit origins.
+type CORSConfig struct {
+	AllowOrigins     []string      `mapstructure:"allow_origins"`
+	AllowMethods     []string      `mapstructure:"allow_methods"`
+	AllowHeaders     []string      `mapstructure:"allow_headers"`
+	AllowCredentials bool          `mapstructure:"allow_credentials"`
+	MaxAge           time.Duration `mapstructure:"max_age"`
+}
+
+// AllowsAllOrigins reports whether the wildcard origin is configured.
+func (c *CORSConfig) AllowsAllOrigins() bool {
+	for _, origin := range c.AllowOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
 }
 
 // DatabaseConfig manage the features of database
@@ -47,6 +141,9 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	// SlowQueryThreshold is the duration above which queries are logged;
+	// zero disables slow query logging.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
 }
 
 // RedisConfig manage the features of cache
@@ -58,18 +155,47 @@ type RedisConfig struct {
 	PoolSize int    `mapstructure:"pool_size"`
 }
 
+// CacheConfig holds the configuration of the caches in front of Redis.
+type CacheConfig struct {
+	Local LocalCacheConfig `mapstructure:"local"`
+}
+
+// LocalCacheConfig configures the in-process cache serving the Redis keys
+// read on every request, e.g. users and revoked tokens. Writes are relayed
+// between instances on a Redis pub/sub channel to invalidate their copies.
+type LocalCacheConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Size is the number of entries kept, least recently used evicted first.
+	Size int `mapstructure:"size"`
+	// TTL bounds how long an entry is served, and so how long a lost
+	// invalidation leaves it stale.
+	TTL      time.Duration `mapstructure:"ttl"`
+	Prefixes []string      `mapstructure:"prefixes"`
+	Channel  string        `mapstructure:"channel"`
+}
+
 // JWTConfig manage the auth
 type JWTConfig struct {
 	Secret            string        `mapstructure:"secret"`
 	Expiration        time.Duration `mapstructure:"expiration"`
 	RefreshExpiration time.Duration `mapstructure:"refresh_expiration"`
 	Issuer            string        `mapstructure:"issuer"`
+	// RevocationRefreshInterval is how often each instance rebuilds its
+	// filter of revoked tokens, and so how long a token revoked on another
+	// instance may still be accepted.
+	RevocationRefreshInterval time.Duration `mapstructure:"revocation_refresh_interval"`
 }
 
 // LoggingConfig manage level the logs
 type LoggingConfig struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+	// BodySampleRate is the fraction (0 to 1) of failed requests logged
+	// with their request and response bodies, truncated to BodyMaxBytes.
+	BodySampleRate float64 `mapstructure:"body_sample_rate"`
+	BodyMaxBytes   int     `mapstructure:"body_max_bytes"`
+	// SkipPaths are not logged, e.g. health probes.
+	SkipPaths []string `mapstructure:"skip_paths"`
 }
 
 // WebSocketConfig manage buffers the app
@@ -78,6 +204,20 @@ type WebSocketConfig struct {
 	WriteBufferSize int           `mapstructure:"write_buffer_size"`
 	PingInterval    time.Duration `mapstructure:"ping_interval"`
 	PongTimeout     time.Duration `mapstructure:"pong_timeout"`
+	SendBufferSize  int           `mapstructure:"send_buffer_size"`
+	// SlowClientPolicy is "disconnect" or "drop-oldest"
+	SlowClientPolicy string `mapstructure:"slow_client_policy"`
+	// AuthCheckInterval is how often the tokens of connected clients are
+	// checked for expiry, revocation and role changes
+	AuthCheckInterval time.Duration `mapstructure:"auth_check_interval"`
+	Relay             RelayConfig   `mapstructure:"relay"`
+}
+
+// RelayConfig configures the Redis pub/sub channel relaying WebSocket
+// broadcasts between API instances.
+type RelayConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Channel string `mapstructure:"channel"`
 }
 
 // DSN returns the PostgreSQL connection string
@@ -98,6 +238,15 @@ func (s *ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%d", s.Host, s.Port)
 }
 
+// MetricsAddress returns the address of the metrics listener, or an empty
+// string when metrics are served by the API.
+func (s *ServerConfig) MetricsAddress() string {
+	if s.MetricsPort == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", s.Host, s.MetricsPort)
+}
+
 // IsProduction returns true if running in production
 func (a *AppConfig) IsProduction() bool {
 	return a.Env == "production"
@@ -108,33 +257,471 @@ func (a *AppConfig) IsDevelopment() bool {
 	return a.Env == "development"
 }
 
+// Labels returns the deployment identity as a label set, skipping empty values.
+func (d *DeploymentConfig) Labels() map[string]string {
+	labels := make(map[string]string)
+	if d.Region != "" {
+		labels["region"] = d.Region
+	}
+	if d.Cluster != "" {
+		labels["cluster"] = d.Cluster
+	}
+	return labels
+}
+
 // EventBusConfig holds event bus configuration.
 type EventBusConfig struct {
-	ConsumerID     string        `mapstructure:"consumer_id"`
-	MaxRetries     int           `mapstructure:"max_retries"`
-	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
-	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
-	Multiplier     float64       `mapstructure:"multiplier"`
+	Driver            string        `mapstructure:"driver"`
+	ConsumerID        string        `mapstructure:"consumer_id"`
+	MaxRetries        int           `mapstructure:"max_retries"`
+	InitialBackoff    time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff        time.Duration `mapstructure:"max_backoff"`
+	Multiplier        float64       `mapstructure:"multiplier"`
+	DeadLetterAfter   int           `mapstructure:"dead_letter_after"`
+	ClaimMinIdle      time.Duration `mapstructure:"claim_min_idle"`
+	ClaimInterval     time.Duration `mapstructure:"claim_interval"`
+	Workers           int           `mapstructure:"workers"`
+	OrderedEventTypes []string      `mapstructure:"ordered_event_types"`
+	IdempotencyTTL    time.Duration `mapstructure:"idempotency_ttl"`
+	LagInterval       time.Duration `mapstructure:"lag_interval"`
 }
 
-// SlackConfig holds Slack notification configuration.
+// SlackConfig holds Slack notification configuration. SigningSecret is the
+// signing secret of the Slack app; when set, alert messages carry
+// Acknowledge and Resolve buttons whose clicks Slack sends back signed.
 type SlackConfig struct {
-	WebhookURL string `mapstructure:"webhook_url"`
-	Channel    string `mapstructure:"channel"`
-	Username   string `mapstructure:"username"`
-	Enabled    bool   `mapstructure:"enabled"`
+	WebhookURL    string `mapstructure:"webhook_url"`
+	Channel       string `mapstructure:"channel"`
+	Username      string `mapstructure:"username"`
+	Enabled       bool   `mapstructure:"enabled"`
+	SigningSecret string `mapstructure:"signing_secret"`
+}
+
+// TelegramConfig holds Telegram bot notification configuration. Alerts are
+// sent by the bot to every chat of ChatIDs. WebhookSecret is the secret token
+// the bot's webhook was registered with; when set, alert messages carry an
+// Acknowledge button whose clicks Telegram sends back with the token.
+type TelegramConfig struct {
+	Enabled       bool     `mapstructure:"enabled"`
+	BotToken      string   `mapstructure:"bot_token"`
+	ChatIDs       []string `mapstructure:"chat_ids"`
+	WebhookSecret string   `mapstructure:"webhook_secret"`
+	APIURL        string   `mapstructure:"api_url"`
+}
+
+// OpsgenieConfig holds the configuration of the Opsgenie integration. Alerts
+// of at least MinSeverity are mirrored as Opsgenie alerts.
+type OpsgenieConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	APIKey      string `mapstructure:"api_key"`
+	APIURL      string `mapstructure:"api_url"`
+	MinSeverity string `mapstructure:"min_severity"`
+}
+
+// SplunkOnCallConfig holds the configuration of the Splunk On-Call
+// (VictorOps) REST integration. Alerts of at least MinSeverity are mirrored
+// as incidents routed with RoutingKey.
+type SplunkOnCallConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	APIKey      string `mapstructure:"api_key"`
+	RoutingKey  string `mapstructure:"routing_key"`
+	APIURL      string `mapstructure:"api_url"`
+	MinSeverity string `mapstructure:"min_severity"`
+}
+
+// JiraConfig holds the configuration of the Jira issue tracker. Issues are
+// filed in ProjectKey with IssueType, authenticated with the API token of
+// Email, and moved through ResolveTransition when their alert resolves.
+type JiraConfig struct {
+	Enabled           bool   `mapstructure:"enabled"`
+	BaseURL           string `mapstructure:"base_url"`
+	Email             string `mapstructure:"email"`
+	APIToken          string `mapstructure:"api_token"`
+	ProjectKey        string `mapstructure:"project_key"`
+	IssueType         string `mapstructure:"issue_type"`
+	ResolveTransition string `mapstructure:"resolve_transition"`
+	// SetPriority maps the alert severity to the issue priority; disable it
+	// for projects whose create screen has no priority.
+	SetPriority bool `mapstructure:"set_priority"`
+}
+
+// StatuspageConfig holds the configuration of the Statuspage.io page whose
+// components reflect the alerts of their sources. Components maps sources to
+// the components they affect, as source=component_id pairs; the status of
+// every component is also pushed again every SyncInterval.
+type StatuspageConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	APIKey       string        `mapstructure:"api_key"`
+	PageID       string        `mapstructure:"page_id"`
+	APIURL       string        `mapstructure:"api_url"`
+	Components   []string      `mapstructure:"components"`
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+}
+
+// TwilioConfig holds the voice call escalation of critical alerts through
+// Twilio. Critical alerts still unacknowledged AckTimeout after they fire,
+// as checked every CheckInterval, call their assignee, or the user with
+// OnCallEmail when they have none, at the phone number linked to the user.
+// PublicURL is the address Twilio reaches the API at, for the keys pressed
+// during calls.
+type TwilioConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	AccountSID    string        `mapstructure:"account_sid"`
+	AuthToken     string        `mapstructure:"auth_token"`
+	FromNumber    string        `mapstructure:"from_number"`
+	APIURL        string        `mapstructure:"api_url"`
+	PublicURL     string        `mapstructure:"public_url"`
+	OnCallEmail   string        `mapstructure:"on_call_email"`
+	AckTimeout    time.Duration `mapstructure:"ack_timeout"`
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+}
+
+// EmailConfig holds SMTP email configuration.
+type EmailConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	SMTPHost string `mapstructure:"smtp_host"`
+	SMTPPort int    `mapstructure:"smtp_port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
+// DigestConfig holds configuration for the per-user digest of missed events.
+type DigestConfig struct {
+	MaxItems      int           `mapstructure:"max_items"`
+	Retention     time.Duration `mapstructure:"retention"`
+	EmailInterval time.Duration `mapstructure:"email_interval"`
+}
+
+// QuietHoursConfig holds the do-not-disturb window of a notification channel.
+type QuietHoursConfig struct {
+	Channel        string   `mapstructure:"channel"`
+	Start          string   `mapstructure:"start"`
+	End            string   `mapstructure:"end"`
+	Timezone       string   `mapstructure:"timezone"`
+	Days           []string `mapstructure:"days"`
+	BypassSeverity string   `mapstructure:"bypass_severity"`
+}
+
+// BatchingConfig puts a notification channel in digest mode.
+type BatchingConfig struct {
+	Channel     string        `mapstructure:"channel"`
+	MaxSeverity string        `mapstructure:"max_severity"`
+	Interval    time.Duration `mapstructure:"interval"`
 }
 
 // NotificationConfig holds notification configuration.
 type NotificationConfig struct {
-	Slack              SlackConfig   `mapstructure:"slack"`
-	MinSeverity        string        `mapstructure:"min_severity"`
-	RateLimitPerMinute int           `mapstructure:"rate_limit_per_minute"`
-	Timeout            time.Duration `mapstructure:"timeout"`
+	Slack              SlackConfig        `mapstructure:"slack"`
+	Telegram           TelegramConfig     `mapstructure:"telegram"`
+	Opsgenie           OpsgenieConfig     `mapstructure:"opsgenie"`
+	SplunkOnCall       SplunkOnCallConfig `mapstructure:"splunk_oncall"`
+	Jira               JiraConfig         `mapstructure:"jira"`
+	Statuspage         StatuspageConfig   `mapstructure:"statuspage"`
+	Twilio             TwilioConfig       `mapstructure:"twilio"`
+	Email              EmailConfig        `mapstructure:"email"`
+	Digest             DigestConfig       `mapstructure:"digest"`
+	QuietHours         []QuietHoursConfig `mapstructure:"quiet_hours"`
+	Batching           []BatchingConfig   `mapstructure:"batching"`
+	AlertURL           string             `mapstructure:"alert_url"`
+	MinSeverity        string             `mapstructure:"min_severity"`
+	RateLimitPerMinute int                `mapstructure:"rate_limit_per_minute"`
+	Timeout            time.Duration      `mapstructure:"timeout"`
 }
 
 // TracingConfig holds tracing configuration.
 type TracingConfig struct {
-	Enabled        bool   `mapstructure:"enabled"`
-	JaegerEndpoint string `mapstructure:"jaeger_endpoint"`
+	Enabled bool `mapstructure:"enabled"`
+	// Exporter is otlp-grpc or otlp-http.
+	Exporter string `mapstructure:"exporter"`
+	// Endpoint is the OpenTelemetry collector address, host:port or a URL.
+	Endpoint string `mapstructure:"endpoint"`
+	// JaegerEndpoint is the former name of Endpoint, used when it is empty.
+	JaegerEndpoint string            `mapstructure:"jaeger_endpoint"`
+	Insecure       bool              `mapstructure:"insecure"`
+	Headers        map[string]string `mapstructure:"headers"`
+	SampleRatio    float64           `mapstructure:"sample_ratio"`
+}
+
+// CollectorEndpoint returns the address traces are exported to.
+func (t *TracingConfig) CollectorEndpoint() string {
+	if t.Endpoint != "" {
+		return t.Endpoint
+	}
+	return t.JaegerEndpoint
+}
+
+// FederationConfig holds cross-region event federation configuration.
+// When enabled, locally originated alert events matching EventTypes and
+// MinSeverity are forwarded to the event bus of the Target deployment.
+type FederationConfig struct {
+	Enabled     bool        `mapstructure:"enabled"`
+	Target      RedisConfig `mapstructure:"target"`
+	EventTypes  []string    `mapstructure:"event_types"`
+	MinSeverity string      `mapstructure:"min_severity"`
+}
+
+// AlertsConfig holds alert presentation configuration.
+type AlertsConfig struct {
+	// MessageSummaryLength caps alert messages in list responses and
+	// WebSocket broadcasts; zero disables truncation.
+	MessageSummaryLength int `mapstructure:"message_summary_length"`
+	// StatisticsRebuildInterval is how often the incremental alert counters
+	// behind statistics are recounted from the alerts table.
+	StatisticsRebuildInterval time.Duration `mapstructure:"statistics_rebuild_interval"`
+	// TrashRetention is how long deleted alerts can be restored before they
+	// are purged, every TrashPurgeInterval.
+	TrashRetention     time.Duration  `mapstructure:"trash_retention"`
+	TrashPurgeInterval time.Duration  `mapstructure:"trash_purge_interval"`
+	Flapping           FlappingConfig `mapstructure:"flapping"`
+	// HeartbeatCheckInterval is how often heartbeats are checked for
+	// missed pings.
+	HeartbeatCheckInterval time.Duration `mapstructure:"heartbeat_check_interval"`
+	// SourceCheckInterval is how often registered sources are checked for
+	// having gone silent.
+	SourceCheckInterval time.Duration `mapstructure:"source_check_interval"`
+	// SLACheckInterval is how often alerts are checked for missed SLA
+	// targets.
+	SLACheckInterval time.Duration `mapstructure:"sla_check_interval"`
+	// ExpirationSweepInterval is how often alerts past their expiration
+	// time are marked expired.
+	ExpirationSweepInterval time.Duration   `mapstructure:"expiration_sweep_interval"`
+	Checks                  ChecksConfig    `mapstructure:"checks"`
+	Incidents               IncidentsConfig `mapstructure:"incidents"`
+}
+
+// SchedulerConfig holds the configuration of the periodic jobs scheduler.
+type SchedulerConfig struct {
+	// Schedules overrides the schedule of jobs by name with a cron
+	// expression, e.g. "trash-purge": "0 3 * * *". Jobs run every interval
+	// of their alerts setting otherwise.
+	Schedules map[string]string `mapstructure:"schedules"`
+}
+
+// IncidentsConfig holds incidents grouping related alerts.
+type IncidentsConfig struct {
+	Correlation IncidentCorrelationConfig `mapstructure:"correlation"`
+}
+
+// IncidentCorrelationConfig holds the grouping of new alerts into incidents.
+// Alerts with the same values of the GroupBy attributes (rule, source, team)
+// join the same incident, which alerts at least as severe as MinSeverity
+// open. No GroupBy attributes disable correlation.
+type IncidentCorrelationConfig struct {
+	GroupBy     []string `mapstructure:"group_by"`
+	MinSeverity string   `mapstructure:"min_severity"`
+}
+
+// ChecksConfig holds the prober running synthetic uptime checks. Every
+// ScheduleInterval the checks that are due are handed to Workers concurrent
+// probes; probe results older than HistoryRetention are purged.
+type ChecksConfig struct {
+	Workers          int           `mapstructure:"workers"`
+	ScheduleInterval time.Duration `mapstructure:"schedule_interval"`
+	HistoryRetention time.Duration `mapstructure:"history_retention"`
+}
+
+// FlappingConfig holds the detection of alerts that keep firing and
+// resolving. An alert is flapping once it changed state Threshold times
+// within Window, and until Dampening passes without a change. Zero
+// Threshold disables detection.
+type FlappingConfig struct {
+	Threshold int           `mapstructure:"threshold"`
+	Window    time.Duration `mapstructure:"window"`
+	Dampening time.Duration `mapstructure:"dampening"`
+}
+
+// WebhooksConfig holds inbound webhook configuration and the delivery of
+// outbound webhook subscriptions.
+type WebhooksConfig struct {
+	Sentry     SentryWebhookConfig     `mapstructure:"sentry"`
+	CloudWatch CloudWatchWebhookConfig `mapstructure:"cloudwatch"`
+	Signature  WebhookSignatureConfig  `mapstructure:"signature"`
+	Outbound   OutboundWebhooksConfig  `mapstructure:"outbound"`
+}
+
+// OutboundWebhooksConfig holds the dispatcher of outbound webhook
+// subscriptions. Every DispatchInterval the deliveries that are due are
+// handed to Workers concurrent requests, each bounded by Timeout. A failed
+// delivery is retried after InitialBackoff, doubled up to MaxBackoff, until
+// MaxAttempts; it is then dead-lettered. Finished deliveries older than
+// Retention are purged.
+type OutboundWebhooksConfig struct {
+	Workers          int           `mapstructure:"workers"`
+	DispatchInterval time.Duration `mapstructure:"dispatch_interval"`
+	Timeout          time.Duration `mapstructure:"timeout"`
+	MaxAttempts      int           `mapstructure:"max_attempts"`
+	InitialBackoff   time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff       time.Duration `mapstructure:"max_backoff"`
+	Retention        time.Duration `mapstructure:"retention"`
+}
+
+// WebhookSignatureConfig controls HMAC verification of webhook payloads.
+// Integrations with a stored secret always require a valid signature;
+// Required also rejects integrations that have no secret yet.
+// Tolerance is the maximum allowed clock skew of the signed timestamp.
+type WebhookSignatureConfig struct {
+	Required  bool          `mapstructure:"required"`
+	Tolerance time.Duration `mapstructure:"tolerance"`
+}
+
+// SentryWebhookConfig holds Sentry integration settings.
+// ClientSecret verifies the Sentry-Hook-Signature header; when empty,
+// Sentry webhooks are rejected.
+type SentryWebhookConfig struct {
+	ClientSecret string `mapstructure:"client_secret"`
+}
+
+// CloudWatchWebhookConfig holds CloudWatch integration settings.
+// TopicARNs lists the SNS topics whose messages are accepted, including
+// subscription confirmations; when empty, SNS messages are rejected.
+type CloudWatchWebhookConfig struct {
+	TopicARNs []string `mapstructure:"topic_arns"`
+}
+
+// VisibilityConfig holds alert visibility restrictions.
+type VisibilityConfig struct {
+	Rules []VisibilityRuleConfig `mapstructure:"rules"`
+}
+
+// VisibilityRuleConfig restricts alerts from a source and/or with the given
+// labels ("key=value") to the listed roles. Admins always see every alert.
+type VisibilityRuleConfig struct {
+	Name   string   `mapstructure:"name"`
+	Source string   `mapstructure:"source"`
+	Labels []string `mapstructure:"labels"`
+	Roles  []string `mapstructure:"roles"`
+}
+
+// PaginationConfig holds the page size limits of list endpoints.
+type PaginationConfig struct {
+	DefaultPageSize int `mapstructure:"default_page_size"`
+	MaxPageSize     int `mapstructure:"max_page_size"`
+}
+
+// RateLimitConfig holds the API rate limits, counted in sliding windows
+// shared by every instance. A zero limit disables limiting.
+type RateLimitConfig struct {
+	API    RateLimitRuleConfig    `mapstructure:"api"`
+	Login  RateLimitRuleConfig    `mapstructure:"login"`
+	Routes []RouteRateLimitConfig `mapstructure:"routes"`
+}
+
+// RateLimitRuleConfig allows Limit requests per Window to each user, or to
+// each IP address for anonymous clients. Roles override Limit for the users
+// with a role.
+type RateLimitRuleConfig struct {
+	Limit  int            `mapstructure:"limit"`
+	Window time.Duration  `mapstructure:"window"`
+	Roles  map[string]int `mapstructure:"roles"`
+}
+
+// RouteRateLimitConfig limits the requests to some endpoints further. Path
+// is exact or a prefix ending with "*"; an empty Method matches all.
+type RouteRateLimitConfig struct {
+	Method string         `mapstructure:"method"`
+	Path   string         `mapstructure:"path"`
+	Limit  int            `mapstructure:"limit"`
+	Window time.Duration  `mapstructure:"window"`
+	Roles  map[string]int `mapstructure:"roles"`
+}
+
+// QuotaConfig holds the daily (UTC) quotas of teams and inbound webhook
+// sources; zero is unlimited. Teams and Sources override the defaults, by
+// team or source ID.
+type QuotaConfig struct {
+	RequestsPerDay int                         `mapstructure:"requests_per_day"`
+	AlertsPerDay   int                         `mapstructure:"alerts_per_day"`
+	Teams          map[string]QuotaLimitConfig `mapstructure:"teams"`
+	Sources        map[string]QuotaLimitConfig `mapstructure:"sources"`
+}
+
+// QuotaLimitConfig holds the daily quotas of a team or webhook source.
+type QuotaLimitConfig struct {
+	RequestsPerDay int `mapstructure:"requests_per_day"`
+	AlertsPerDay   int `mapstructure:"alerts_per_day"`
+}
+
+// LockoutConfig holds the lockout of accounts after repeated failed logins.
+// After MaxAttempts consecutive failures the account is locked for BaseDelay,
+// doubled on every further failure up to MaxDelay. Failures are forgotten
+// after ResetAfter. Zero attempts disables lockouts.
+type LockoutConfig struct {
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	BaseDelay   time.Duration `mapstructure:"base_delay"`
+	MaxDelay    time.Duration `mapstructure:"max_delay"`
+	ResetAfter  time.Duration `mapstructure:"reset_after"`
+}
+
+// NetworkACLConfig holds the client networks allowed to use the restricted
+// route groups.
+type NetworkACLConfig struct {
+	Webhooks NetworkACLRuleConfig `mapstructure:"webhooks"`
+	Admin    NetworkACLRuleConfig `mapstructure:"admin"`
+}
+
+// NetworkACLRuleConfig lists CIDR ranges or IP addresses. Deny wins over
+// Allow; an empty Allow allows every network not denied.
+type NetworkACLRuleConfig struct {
+	Allow []string `mapstructure:"allow"`
+	Deny  []string `mapstructure:"deny"`
+}
+
+// EnrichmentConfig holds the enrichers adding context to alerts before they
+// are stored. Enabled enrichers run in Order; those left out of it run
+// after, in the order below.
+type EnrichmentConfig struct {
+	Order   []string                `mapstructure:"order"`
+	GeoIP   GeoIPEnrichmentConfig   `mapstructure:"geoip"`
+	Runbook RunbookEnrichmentConfig `mapstructure:"runbook"`
+	CMDB    CMDBEnrichmentConfig    `mapstructure:"cmdb"`
+}
+
+// GeoIPEnrichmentConfig locates alerts whose source is an IP address, from
+// a CSV database of network,country,region,city rows.
+type GeoIPEnrichmentConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Database string        `mapstructure:"database"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// RunbookEnrichmentConfig links the alerts of rules to runbooks. URLs maps
+// rule IDs or names to runbook URLs.
+type RunbookEnrichmentConfig struct {
+	Enabled bool              `mapstructure:"enabled"`
+	URLs    map[string]string `mapstructure:"urls"`
+	Timeout time.Duration     `mapstructure:"timeout"`
+}
+
+// CMDBEnrichmentConfig looks up the owner of alert sources in a CMDB over
+// HTTP. URL contains a {source} placeholder; lookups are cached for
+// CacheTTL.
+type CMDBEnrichmentConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	URL        string        `mapstructure:"url"`
+	Token      string        `mapstructure:"token"`
+	OwnerField string        `mapstructure:"owner_field"`
+	Timeout    time.Duration `mapstructure:"timeout"`
+	CacheTTL   time.Duration `mapstructure:"cache_ttl"`
+}
+
+// FeaturesConfig holds the feature flag defaults of this deployment.
+// Runtime overrides set through the admin API take precedence.
+type FeaturesConfig struct {
+	Flags map[string]bool `mapstructure:"flags"`
+}
+
+// DeprecationConfig lists deprecated API endpoints.
+type DeprecationConfig struct {
+	Endpoints []DeprecatedEndpointConfig `mapstructure:"endpoints"`
+}
+
+// DeprecatedEndpointConfig marks an endpoint as deprecated. Path is exact or a
+// prefix ending with "*"; Since and Sunset are dates (YYYY-MM-DD) or RFC 3339 times.
+type DeprecatedEndpointConfig struct {
+	Method string `mapstructure:"method"`
+	Path   string `mapstructure:"path"`
+	Since  string `mapstructure:"since"`
+	Sunset string `mapstructure:"sunset"`
+	Link   string `mapstructure:"link"`
 }
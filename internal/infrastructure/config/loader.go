@@ -57,9 +57,28 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("app.env", "APP_ENV")
 	_ = v.BindEnv("app.version", "APP_VERSION")
 
+	// Deployment
+	_ = v.BindEnv("deployment.region", "DEPLOYMENT_REGION")
+	_ = v.BindEnv("deployment.cluster", "DEPLOYMENT_CLUSTER")
+
 	// Server
 	_ = v.BindEnv("server.host", "SERVER_HOST")
 	_ = v.BindEnv("server.port", "SERVER_PORT")
+	_ = v.BindEnv("server.metrics_port", "SERVER_METRICS_PORT")
+	_ = v.BindEnv("server.shutdown_timeout", "SERVER_SHUTDOWN_TIMEOUT")
+	_ = v.BindEnv("server.cors.allow_origins", "SERVER_CORS_ALLOW_ORIGINS")
+	_ = v.BindEnv("server.cors.allow_methods", "SERVER_CORS_ALLOW_METHODS")
+	_ = v.BindEnv("server.cors.allow_headers", "SERVER_CORS_ALLOW_HEADERS")
+	_ = v.BindEnv("server.cors.allow_credentials", "SERVER_CORS_ALLOW_CREDENTIALS")
+	_ = v.BindEnv("server.cors.max_age", "SERVER_CORS_MAX_AGE")
+	_ = v.BindEnv("server.security.headers.enabled", "SERVER_SECURITY_HEADERS_ENABLED")
+	_ = v.BindEnv("server.security.headers.hsts_max_age", "SERVER_SECURITY_HEADERS_HSTS_MAX_AGE")
+	_ = v.BindEnv("server.security.headers.content_security_policy", "SERVER_SECURITY_HEADERS_CONTENT_SECURITY_POLICY")
+	_ = v.BindEnv("server.security.csrf.enabled", "SERVER_SECURITY_CSRF_ENABLED")
+	_ = v.BindEnv("server.security.csrf.cookie_secure", "SERVER_SECURITY_CSRF_COOKIE_SECURE")
+	_ = v.BindEnv("server.proxy_header", "SERVER_PROXY_HEADER")
+	_ = v.BindEnv("server.trusted_proxies", "SERVER_TRUSTED_PROXIES")
+	_ = v.BindEnv("server.ui.enabled", "SERVER_UI_ENABLED")
 
 	// Database
 	_ = v.BindEnv("database.host", "DATABASE_HOST")
@@ -68,20 +87,192 @@ func bindEnvVars(v *viper.Viper) {
 	_ = v.BindEnv("database.password", "DATABASE_PASSWORD")
 	_ = v.BindEnv("database.name", "DATABASE_NAME")
 	_ = v.BindEnv("database.ssl_mode", "DATABASE_SSL_MODE")
+	_ = v.BindEnv("database.slow_query_threshold", "DATABASE_SLOW_QUERY_THRESHOLD")
 
 	// Redis
 	_ = v.BindEnv("redis.host", "REDIS_HOST")
 	_ = v.BindEnv("redis.port", "REDIS_PORT")
 	_ = v.BindEnv("redis.password", "REDIS_PASSWORD")
 	_ = v.BindEnv("redis.db", "REDIS_DB")
+	_ = v.BindEnv("cache.local.enabled", "CACHE_LOCAL_ENABLED")
+	_ = v.BindEnv("cache.local.size", "CACHE_LOCAL_SIZE")
+	_ = v.BindEnv("cache.local.ttl", "CACHE_LOCAL_TTL")
 
 	// JWT
 	_ = v.BindEnv("jwt.secret", "JWT_SECRET")
 	_ = v.BindEnv("jwt.expiration", "JWT_EXPIRATION")
+	_ = v.BindEnv("jwt.revocation_refresh_interval", "JWT_REVOCATION_REFRESH_INTERVAL")
+
+	// Tracing
+	_ = v.BindEnv("tracing.enabled", "TRACING_ENABLED")
+	_ = v.BindEnv("tracing.exporter", "TRACING_EXPORTER")
+	_ = v.BindEnv("tracing.endpoint", "TRACING_ENDPOINT")
+	_ = v.BindEnv("tracing.insecure", "TRACING_INSECURE")
+	_ = v.BindEnv("tracing.sample_ratio", "TRACING_SAMPLE_RATIO")
 
 	// Logging
 	_ = v.BindEnv("logging.level", "LOG_LEVEL")
 	_ = v.BindEnv("logging.format", "LOG_FORMAT")
+	_ = v.BindEnv("logging.body_sample_rate", "LOG_BODY_SAMPLE_RATE")
+	_ = v.BindEnv("logging.body_max_bytes", "LOG_BODY_MAX_BYTES")
+
+	// Event bus
+	_ = v.BindEnv("websocket.send_buffer_size", "WEBSOCKET_SEND_BUFFER_SIZE")
+	_ = v.BindEnv("websocket.slow_client_policy", "WEBSOCKET_SLOW_CLIENT_POLICY")
+	_ = v.BindEnv("websocket.auth_check_interval", "WEBSOCKET_AUTH_CHECK_INTERVAL")
+	_ = v.BindEnv("websocket.relay.enabled", "WEBSOCKET_RELAY_ENABLED")
+	_ = v.BindEnv("websocket.relay.channel", "WEBSOCKET_RELAY_CHANNEL")
+
+	_ = v.BindEnv("event_bus.driver", "EVENT_BUS_DRIVER")
+	_ = v.BindEnv("event_bus.max_retries", "EVENT_BUS_MAX_RETRIES")
+	_ = v.BindEnv("event_bus.initial_backoff", "EVENT_BUS_INITIAL_BACKOFF")
+	_ = v.BindEnv("event_bus.max_backoff", "EVENT_BUS_MAX_BACKOFF")
+	_ = v.BindEnv("event_bus.dead_letter_after", "EVENT_BUS_DEAD_LETTER_AFTER")
+	_ = v.BindEnv("event_bus.claim_min_idle", "EVENT_BUS_CLAIM_MIN_IDLE")
+	_ = v.BindEnv("event_bus.claim_interval", "EVENT_BUS_CLAIM_INTERVAL")
+	_ = v.BindEnv("event_bus.workers", "EVENT_BUS_WORKERS")
+	_ = v.BindEnv("event_bus.idempotency_ttl", "EVENT_BUS_IDEMPOTENCY_TTL")
+	_ = v.BindEnv("event_bus.lag_interval", "EVENT_BUS_LAG_INTERVAL")
+
+	// Alerts
+	_ = v.BindEnv("alerts.message_summary_length", "ALERTS_MESSAGE_SUMMARY_LENGTH")
+	_ = v.BindEnv("alerts.statistics_rebuild_interval", "ALERTS_STATISTICS_REBUILD_INTERVAL")
+	_ = v.BindEnv("alerts.trash_retention", "ALERTS_TRASH_RETENTION")
+	_ = v.BindEnv("alerts.trash_purge_interval", "ALERTS_TRASH_PURGE_INTERVAL")
+	_ = v.BindEnv("alerts.heartbeat_check_interval", "ALERTS_HEARTBEAT_CHECK_INTERVAL")
+	_ = v.BindEnv("alerts.source_check_interval", "ALERTS_SOURCE_CHECK_INTERVAL")
+	_ = v.BindEnv("alerts.sla_check_interval", "ALERTS_SLA_CHECK_INTERVAL")
+	_ = v.BindEnv("alerts.expiration_sweep_interval", "ALERTS_EXPIRATION_SWEEP_INTERVAL")
+	_ = v.BindEnv("alerts.checks.workers", "ALERTS_CHECKS_WORKERS")
+	_ = v.BindEnv("alerts.checks.schedule_interval", "ALERTS_CHECKS_SCHEDULE_INTERVAL")
+	_ = v.BindEnv("alerts.checks.history_retention", "ALERTS_CHECKS_HISTORY_RETENTION")
+	_ = v.BindEnv("alerts.flapping.threshold", "ALERTS_FLAPPING_THRESHOLD")
+	_ = v.BindEnv("alerts.flapping.window", "ALERTS_FLAPPING_WINDOW")
+	_ = v.BindEnv("alerts.flapping.dampening", "ALERTS_FLAPPING_DAMPENING")
+	_ = v.BindEnv("alerts.incidents.correlation.group_by", "ALERTS_INCIDENTS_CORRELATION_GROUP_BY")
+	_ = v.BindEnv("alerts.incidents.correlation.min_severity", "ALERTS_INCIDENTS_CORRELATION_MIN_SEVERITY")
+
+	// Pagination
+	_ = v.BindEnv("pagination.default_page_size", "PAGINATION_DEFAULT_PAGE_SIZE")
+	_ = v.BindEnv("pagination.max_page_size", "PAGINATION_MAX_PAGE_SIZE")
+
+	// Rate limits
+	_ = v.BindEnv("rate_limit.api.limit", "RATE_LIMIT_API_LIMIT")
+	_ = v.BindEnv("rate_limit.api.window", "RATE_LIMIT_API_WINDOW")
+	_ = v.BindEnv("rate_limit.login.limit", "RATE_LIMIT_LOGIN_LIMIT")
+	_ = v.BindEnv("rate_limit.login.window", "RATE_LIMIT_LOGIN_WINDOW")
+
+	// Quotas
+	_ = v.BindEnv("quota.requests_per_day", "QUOTA_REQUESTS_PER_DAY")
+	_ = v.BindEnv("quota.alerts_per_day", "QUOTA_ALERTS_PER_DAY")
+
+	// Account lockout
+	_ = v.BindEnv("lockout.max_attempts", "LOCKOUT_MAX_ATTEMPTS")
+	_ = v.BindEnv("lockout.base_delay", "LOCKOUT_BASE_DELAY")
+	_ = v.BindEnv("lockout.max_delay", "LOCKOUT_MAX_DELAY")
+	_ = v.BindEnv("lockout.reset_after", "LOCKOUT_RESET_AFTER")
+
+	// Network ACLs
+	_ = v.BindEnv("network_acl.webhooks.allow", "NETWORK_ACL_WEBHOOKS_ALLOW")
+	_ = v.BindEnv("network_acl.webhooks.deny", "NETWORK_ACL_WEBHOOKS_DENY")
+	_ = v.BindEnv("network_acl.admin.allow", "NETWORK_ACL_ADMIN_ALLOW")
+	_ = v.BindEnv("network_acl.admin.deny", "NETWORK_ACL_ADMIN_DENY")
+
+	// Alert enrichment
+	_ = v.BindEnv("enrichment.order", "ENRICHMENT_ORDER")
+	_ = v.BindEnv("enrichment.geoip.enabled", "ENRICHMENT_GEOIP_ENABLED")
+	_ = v.BindEnv("enrichment.geoip.database", "ENRICHMENT_GEOIP_DATABASE")
+	_ = v.BindEnv("enrichment.geoip.timeout", "ENRICHMENT_GEOIP_TIMEOUT")
+	_ = v.BindEnv("enrichment.runbook.enabled", "ENRICHMENT_RUNBOOK_ENABLED")
+	_ = v.BindEnv("enrichment.runbook.timeout", "ENRICHMENT_RUNBOOK_TIMEOUT")
+	_ = v.BindEnv("enrichment.cmdb.enabled", "ENRICHMENT_CMDB_ENABLED")
+	_ = v.BindEnv("enrichment.cmdb.url", "ENRICHMENT_CMDB_URL")
+	_ = v.BindEnv("enrichment.cmdb.token", "ENRICHMENT_CMDB_TOKEN")
+	_ = v.BindEnv("enrichment.cmdb.owner_field", "ENRICHMENT_CMDB_OWNER_FIELD")
+	_ = v.BindEnv("enrichment.cmdb.timeout", "ENRICHMENT_CMDB_TIMEOUT")
+	_ = v.BindEnv("enrichment.cmdb.cache_ttl", "ENRICHMENT_CMDB_CACHE_TTL")
+
+	// Webhooks
+	_ = v.BindEnv("webhooks.sentry.client_secret", "WEBHOOKS_SENTRY_CLIENT_SECRET")
+	_ = v.BindEnv("webhooks.cloudwatch.topic_arns", "WEBHOOKS_CLOUDWATCH_TOPIC_ARNS")
+	_ = v.BindEnv("webhooks.signature.required", "WEBHOOKS_SIGNATURE_REQUIRED")
+	_ = v.BindEnv("webhooks.signature.tolerance", "WEBHOOKS_SIGNATURE_TOLERANCE")
+	_ = v.BindEnv("webhooks.outbound.workers", "WEBHOOKS_OUTBOUND_WORKERS")
+	_ = v.BindEnv("webhooks.outbound.dispatch_interval", "WEBHOOKS_OUTBOUND_DISPATCH_INTERVAL")
+	_ = v.BindEnv("webhooks.outbound.timeout", "WEBHOOKS_OUTBOUND_TIMEOUT")
+	_ = v.BindEnv("webhooks.outbound.max_attempts", "WEBHOOKS_OUTBOUND_MAX_ATTEMPTS")
+	_ = v.BindEnv("webhooks.outbound.initial_backoff", "WEBHOOKS_OUTBOUND_INITIAL_BACKOFF")
+	_ = v.BindEnv("webhooks.outbound.max_backoff", "WEBHOOKS_OUTBOUND_MAX_BACKOFF")
+	_ = v.BindEnv("webhooks.outbound.retention", "WEBHOOKS_OUTBOUND_RETENTION")
+
+	// Feature flags
+	_ = v.BindEnv("features.flags.incidents", "FEATURES_INCIDENTS")
+	_ = v.BindEnv("features.flags.graphql", "FEATURES_GRAPHQL")
+	_ = v.BindEnv("features.flags.sse", "FEATURES_SSE")
+
+	// Telegram
+	_ = v.BindEnv("notification.telegram.enabled", "NOTIFICATION_TELEGRAM_ENABLED")
+	_ = v.BindEnv("notification.telegram.bot_token", "NOTIFICATION_TELEGRAM_BOT_TOKEN")
+	_ = v.BindEnv("notification.telegram.chat_ids", "NOTIFICATION_TELEGRAM_CHAT_IDS")
+	_ = v.BindEnv("notification.telegram.webhook_secret", "NOTIFICATION_TELEGRAM_WEBHOOK_SECRET")
+	_ = v.BindEnv("notification.telegram.api_url", "NOTIFICATION_TELEGRAM_API_URL")
+
+	// On-call platforms
+	_ = v.BindEnv("notification.opsgenie.enabled", "NOTIFICATION_OPSGENIE_ENABLED")
+	_ = v.BindEnv("notification.opsgenie.api_key", "NOTIFICATION_OPSGENIE_API_KEY")
+	_ = v.BindEnv("notification.opsgenie.api_url", "NOTIFICATION_OPSGENIE_API_URL")
+	_ = v.BindEnv("notification.opsgenie.min_severity", "NOTIFICATION_OPSGENIE_MIN_SEVERITY")
+	_ = v.BindEnv("notification.splunk_oncall.enabled", "NOTIFICATION_SPLUNK_ONCALL_ENABLED")
+	_ = v.BindEnv("notification.splunk_oncall.api_key", "NOTIFICATION_SPLUNK_ONCALL_API_KEY")
+	_ = v.BindEnv("notification.splunk_oncall.routing_key", "NOTIFICATION_SPLUNK_ONCALL_ROUTING_KEY")
+	_ = v.BindEnv("notification.splunk_oncall.api_url", "NOTIFICATION_SPLUNK_ONCALL_API_URL")
+	_ = v.BindEnv("notification.splunk_oncall.min_severity", "NOTIFICATION_SPLUNK_ONCALL_MIN_SEVERITY")
+
+	// Jira
+	_ = v.BindEnv("notification.jira.enabled", "NOTIFICATION_JIRA_ENABLED")
+	_ = v.BindEnv("notification.jira.base_url", "NOTIFICATION_JIRA_BASE_URL")
+	_ = v.BindEnv("notification.jira.email", "NOTIFICATION_JIRA_EMAIL")
+	_ = v.BindEnv("notification.jira.api_token", "NOTIFICATION_JIRA_API_TOKEN")
+	_ = v.BindEnv("notification.jira.project_key", "NOTIFICATION_JIRA_PROJECT_KEY")
+	_ = v.BindEnv("notification.jira.issue_type", "NOTIFICATION_JIRA_ISSUE_TYPE")
+	_ = v.BindEnv("notification.jira.resolve_transition", "NOTIFICATION_JIRA_RESOLVE_TRANSITION")
+	_ = v.BindEnv("notification.jira.set_priority", "NOTIFICATION_JIRA_SET_PRIORITY")
+
+	// Statuspage
+	_ = v.BindEnv("notification.statuspage.enabled", "NOTIFICATION_STATUSPAGE_ENABLED")
+	_ = v.BindEnv("notification.statuspage.api_key", "NOTIFICATION_STATUSPAGE_API_KEY")
+	_ = v.BindEnv("notification.statuspage.page_id", "NOTIFICATION_STATUSPAGE_PAGE_ID")
+	_ = v.BindEnv("notification.statuspage.api_url", "NOTIFICATION_STATUSPAGE_API_URL")
+	_ = v.BindEnv("notification.statuspage.components", "NOTIFICATION_STATUSPAGE_COMPONENTS")
+	_ = v.BindEnv("notification.statuspage.sync_interval", "NOTIFICATION_STATUSPAGE_SYNC_INTERVAL")
+
+	// Twilio
+	_ = v.BindEnv("notification.twilio.enabled", "NOTIFICATION_TWILIO_ENABLED")
+	_ = v.BindEnv("notification.twilio.account_sid", "NOTIFICATION_TWILIO_ACCOUNT_SID")
+	_ = v.BindEnv("notification.twilio.auth_token", "NOTIFICATION_TWILIO_AUTH_TOKEN")
+	_ = v.BindEnv("notification.twilio.from_number", "NOTIFICATION_TWILIO_FROM_NUMBER")
+	_ = v.BindEnv("notification.twilio.api_url", "NOTIFICATION_TWILIO_API_URL")
+	_ = v.BindEnv("notification.twilio.public_url", "NOTIFICATION_TWILIO_PUBLIC_URL")
+	_ = v.BindEnv("notification.twilio.on_call_email", "NOTIFICATION_TWILIO_ON_CALL_EMAIL")
+	_ = v.BindEnv("notification.twilio.ack_timeout", "NOTIFICATION_TWILIO_ACK_TIMEOUT")
+	_ = v.BindEnv("notification.twilio.check_interval", "NOTIFICATION_TWILIO_CHECK_INTERVAL")
+
+	// Email
+	_ = v.BindEnv("notification.email.enabled", "NOTIFICATION_EMAIL_ENABLED")
+	_ = v.BindEnv("notification.email.smtp_host", "NOTIFICATION_EMAIL_SMTP_HOST")
+	_ = v.BindEnv("notification.email.smtp_port", "NOTIFICATION_EMAIL_SMTP_PORT")
+	_ = v.BindEnv("notification.email.username", "NOTIFICATION_EMAIL_USERNAME")
+	_ = v.BindEnv("notification.email.password", "NOTIFICATION_EMAIL_PASSWORD")
+	_ = v.BindEnv("notification.email.from", "NOTIFICATION_EMAIL_FROM")
+	_ = v.BindEnv("notification.alert_url", "NOTIFICATION_ALERT_URL")
+
+	// Federation
+	_ = v.BindEnv("federation.enabled", "FEDERATION_ENABLED")
+	_ = v.BindEnv("federation.target.host", "FEDERATION_TARGET_HOST")
+	_ = v.BindEnv("federation.target.port", "FEDERATION_TARGET_PORT")
+	_ = v.BindEnv("federation.target.password", "FEDERATION_TARGET_PASSWORD")
+	_ = v.BindEnv("federation.target.db", "FEDERATION_TARGET_DB")
+	_ = v.BindEnv("federation.min_severity", "FEDERATION_MIN_SEVERITY")
 }
 
 func setDefaults(v *viper.Viper) {
@@ -90,12 +281,36 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("app.env", "development")
 	v.SetDefault("app.version", "1.0.0")
 
+	// Deployment defaults
+	v.SetDefault("deployment.region", "local")
+	v.SetDefault("deployment.cluster", "default")
+
 	// Server defaults
 	v.SetDefault("server.host", "0.0.0.0")
 	v.SetDefault("server.port", 8080)
 	v.SetDefault("server.read_timeout", "10s")
 	v.SetDefault("server.write_timeout", "10s")
 	v.SetDefault("server.idle_timeout", "120s")
+	v.SetDefault("server.metrics_port", 0)
+	v.SetDefault("server.shutdown_timeout", "30s")
+	v.SetDefault("server.cors.allow_origins", []string{})
+	v.SetDefault("server.cors.allow_methods", []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"})
+	v.SetDefault("server.cors.allow_headers", []string{"Origin", "Content-Type", "Accept", "Authorization"})
+	v.SetDefault("server.cors.allow_credentials", false)
+	v.SetDefault("server.cors.max_age", "0s")
+	v.SetDefault("server.security.headers.enabled", true)
+	v.SetDefault("server.security.headers.hsts_max_age", "8760h")
+	v.SetDefault("server.security.headers.hsts_include_subdomains", true)
+	v.SetDefault("server.security.headers.frame_options", "DENY")
+	v.SetDefault("server.security.headers.content_security_policy", "default-src 'none'; frame-ancestors 'none'")
+	v.SetDefault("server.security.csrf.enabled", false)
+	v.SetDefault("server.security.csrf.cookie_name", "csrf_token")
+	v.SetDefault("server.security.csrf.cookie_secure", true)
+	v.SetDefault("server.security.csrf.same_site", "Strict")
+	v.SetDefault("server.security.csrf.exempt_paths", []string{"/api/v1/webhooks/*"})
+	v.SetDefault("server.proxy_header", "")
+	v.SetDefault("server.trusted_proxies", []string{})
+	v.SetDefault("server.ui.enabled", true)
 
 	// Database defaults
 	v.SetDefault("database.host", "localhost")
@@ -107,6 +322,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.max_open_conns", 25)
 	v.SetDefault("database.max_idle_conns", 5)
 	v.SetDefault("database.conn_max_lifetime", "5m")
+	v.SetDefault("database.slow_query_threshold", "200ms")
 
 	// Redis defaults
 	v.SetDefault("redis.host", "localhost")
@@ -115,39 +331,191 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("redis.db", 0)
 	v.SetDefault("redis.pool_size", 10)
 
+	// Local cache defaults
+	v.SetDefault("cache.local.enabled", false)
+	v.SetDefault("cache.local.size", 10000)
+	v.SetDefault("cache.local.ttl", "5s")
+	v.SetDefault("cache.local.prefixes", []string{"user:", "blacklist:"})
+	v.SetDefault("cache.local.channel", "cache:invalidate")
+
 	// JWT defaults
 	v.SetDefault("jwt.secret", "change-me-in-production")
 	v.SetDefault("jwt.expiration", "15m")
 	v.SetDefault("jwt.refresh_expiration", "168h")
 	v.SetDefault("jwt.issuer", "realtime-alerting-system")
+	v.SetDefault("jwt.revocation_refresh_interval", "5s")
 
 	// Logging defaults
 	v.SetDefault("logging.level", "debug")
 	v.SetDefault("logging.format", "console")
+	v.SetDefault("logging.body_sample_rate", 0.1)
+	v.SetDefault("logging.body_max_bytes", 2048)
+	v.SetDefault("logging.skip_paths", []string{"/health", "/ready", "/live", "/metrics"})
 
 	// WebSocket defaults
 	v.SetDefault("websocket.read_buffer_size", 1024)
 	v.SetDefault("websocket.write_buffer_size", 1024)
 	v.SetDefault("websocket.ping_interval", "30s")
 	v.SetDefault("websocket.pong_timeout", "60s")
+	v.SetDefault("websocket.send_buffer_size", 256)
+	v.SetDefault("websocket.slow_client_policy", "disconnect")
+	v.SetDefault("websocket.auth_check_interval", "30s")
+	v.SetDefault("websocket.relay.enabled", false)
+	v.SetDefault("websocket.relay.channel", "ws:broadcast")
 
 	// Event Bus defaults
-	viper.SetDefault("event_bus.consumer_id", "api-server-1")
-	viper.SetDefault("event_bus.max_retries", 3)
-	viper.SetDefault("event_bus.initial_backoff", "100ms")
-	viper.SetDefault("event_bus.max_backoff", "30s")
-	viper.SetDefault("event_bus.multiplier", 2.0)
+	v.SetDefault("event_bus.driver", "redis-stream")
+	v.SetDefault("event_bus.consumer_id", "api-server-1")
+	v.SetDefault("event_bus.max_retries", 3)
+	v.SetDefault("event_bus.initial_backoff", "100ms")
+	v.SetDefault("event_bus.max_backoff", "30s")
+	v.SetDefault("event_bus.multiplier", 2.0)
+	v.SetDefault("event_bus.dead_letter_after", 0)
+	v.SetDefault("event_bus.claim_min_idle", "1m")
+	v.SetDefault("event_bus.claim_interval", "30s")
+	v.SetDefault("event_bus.workers", 1)
+	v.SetDefault("event_bus.idempotency_ttl", "24h")
+	v.SetDefault("event_bus.lag_interval", "15s")
 
 	// Notification defaults
-	viper.SetDefault("notification.slack.enabled", false)
-	viper.SetDefault("notification.slack.webhook_url", "")
-	viper.SetDefault("notification.slack.channel", "#alerts")
-	viper.SetDefault("notification.slack.username", "Alert Bot")
-	viper.SetDefault("notification.min_severity", "high")
-	viper.SetDefault("notification.rate_limit_per_minute", 10)
-	viper.SetDefault("notification.timeout", "10s")
+	v.SetDefault("notification.slack.enabled", false)
+	v.SetDefault("notification.slack.webhook_url", "")
+	v.SetDefault("notification.slack.channel", "#alerts")
+	v.SetDefault("notification.slack.username", "Alert Bot")
+	v.SetDefault("notification.slack.signing_secret", "")
+	v.SetDefault("notification.min_severity", "high")
+	v.SetDefault("notification.rate_limit_per_minute", 10)
+	v.SetDefault("notification.timeout", "10s")
 
 	// Tracing defaults
-	viper.SetDefault("tracing.enabled", true)
-	viper.SetDefault("tracing.jaeger_endpoint", "jaeger:4317")
+	v.SetDefault("tracing.enabled", true)
+	v.SetDefault("tracing.exporter", "otlp-grpc")
+	v.SetDefault("tracing.endpoint", "")
+	v.SetDefault("tracing.jaeger_endpoint", "jaeger:4317")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.sample_ratio", 1.0)
+
+	// Alerts defaults
+	v.SetDefault("alerts.message_summary_length", 500)
+	v.SetDefault("alerts.statistics_rebuild_interval", "6h")
+	v.SetDefault("alerts.trash_retention", "720h")
+	v.SetDefault("alerts.trash_purge_interval", "1h")
+	v.SetDefault("alerts.heartbeat_check_interval", "30s")
+	v.SetDefault("alerts.source_check_interval", "1m")
+	v.SetDefault("alerts.sla_check_interval", "30s")
+	v.SetDefault("alerts.expiration_sweep_interval", "1m")
+	v.SetDefault("alerts.checks.workers", 4)
+	v.SetDefault("alerts.checks.schedule_interval", "5s")
+	v.SetDefault("alerts.checks.history_retention", "168h")
+	v.SetDefault("alerts.flapping.threshold", 6)
+	v.SetDefault("alerts.flapping.window", "30m")
+	v.SetDefault("alerts.flapping.dampening", "30m")
+	v.SetDefault("alerts.incidents.correlation.group_by", []string{})
+	v.SetDefault("alerts.incidents.correlation.min_severity", "high")
+
+	// Pagination defaults
+	v.SetDefault("pagination.default_page_size", 20)
+	v.SetDefault("pagination.max_page_size", 100)
+
+	// Rate limit defaults
+	v.SetDefault("rate_limit.api.limit", 100)
+	v.SetDefault("rate_limit.api.window", "1m")
+	v.SetDefault("rate_limit.login.limit", 5)
+	v.SetDefault("rate_limit.login.window", "15m")
+
+	// Quota defaults
+	v.SetDefault("quota.requests_per_day", 0)
+	v.SetDefault("quota.alerts_per_day", 0)
+
+	// Account lockout defaults
+	v.SetDefault("lockout.max_attempts", 5)
+	v.SetDefault("lockout.base_delay", "1m")
+	v.SetDefault("lockout.max_delay", "1h")
+	v.SetDefault("lockout.reset_after", "24h")
+
+	// Network ACL defaults (allow every network)
+	v.SetDefault("network_acl.webhooks.allow", []string{})
+	v.SetDefault("network_acl.webhooks.deny", []string{})
+	v.SetDefault("network_acl.admin.allow", []string{})
+	v.SetDefault("network_acl.admin.deny", []string{})
+
+	// Alert enrichment defaults
+	v.SetDefault("enrichment.order", []string{"geoip", "runbook", "cmdb"})
+	v.SetDefault("enrichment.geoip.enabled", false)
+	v.SetDefault("enrichment.geoip.database", "")
+	v.SetDefault("enrichment.geoip.timeout", "50ms")
+	v.SetDefault("enrichment.runbook.enabled", false)
+	v.SetDefault("enrichment.runbook.urls", map[string]string{})
+	v.SetDefault("enrichment.runbook.timeout", "50ms")
+	v.SetDefault("enrichment.cmdb.enabled", false)
+	v.SetDefault("enrichment.cmdb.url", "")
+	v.SetDefault("enrichment.cmdb.token", "")
+	v.SetDefault("enrichment.cmdb.owner_field", "owner")
+	v.SetDefault("enrichment.cmdb.timeout", "2s")
+	v.SetDefault("enrichment.cmdb.cache_ttl", "10m")
+
+	// Webhook signature defaults
+	v.SetDefault("webhooks.signature.required", false)
+	v.SetDefault("webhooks.signature.tolerance", "5m")
+
+	// Outbound webhook subscription defaults
+	v.SetDefault("webhooks.outbound.workers", 4)
+	v.SetDefault("webhooks.outbound.dispatch_interval", "2s")
+	v.SetDefault("webhooks.outbound.timeout", "10s")
+	v.SetDefault("webhooks.outbound.max_attempts", 8)
+	v.SetDefault("webhooks.outbound.initial_backoff", "30s")
+	v.SetDefault("webhooks.outbound.max_backoff", "1h")
+	v.SetDefault("webhooks.outbound.retention", "168h")
+
+	// Feature flag defaults
+	v.SetDefault("features.flags.incidents", false)
+	v.SetDefault("features.flags.graphql", false)
+	v.SetDefault("features.flags.sse", false)
+
+	// Telegram defaults
+	v.SetDefault("notification.telegram.enabled", false)
+	v.SetDefault("notification.telegram.chat_ids", []string{})
+	v.SetDefault("notification.telegram.api_url", "https://api.telegram.org")
+
+	// On-call platform defaults
+	v.SetDefault("notification.opsgenie.enabled", false)
+	v.SetDefault("notification.opsgenie.api_url", "https://api.opsgenie.com")
+	v.SetDefault("notification.opsgenie.min_severity", "high")
+	v.SetDefault("notification.splunk_oncall.enabled", false)
+	v.SetDefault("notification.splunk_oncall.api_url", "https://alert.victorops.com/integrations/generic/20131114/alert")
+	v.SetDefault("notification.splunk_oncall.min_severity", "high")
+
+	// Jira defaults
+	v.SetDefault("notification.jira.enabled", false)
+	v.SetDefault("notification.jira.issue_type", "Task")
+	v.SetDefault("notification.jira.resolve_transition", "Done")
+	v.SetDefault("notification.jira.set_priority", true)
+
+	// Statuspage defaults
+	v.SetDefault("notification.statuspage.enabled", false)
+	v.SetDefault("notification.statuspage.api_url", "https://api.statuspage.io/v1")
+	v.SetDefault("notification.statuspage.sync_interval", "5m")
+
+	// Twilio defaults
+	v.SetDefault("notification.twilio.enabled", false)
+	v.SetDefault("notification.twilio.api_url", "https://api.twilio.com")
+	v.SetDefault("notification.twilio.ack_timeout", "10m")
+	v.SetDefault("notification.twilio.check_interval", "1m")
+
+	// Email and digest defaults
+	v.SetDefault("notification.email.enabled", false)
+	v.SetDefault("notification.email.smtp_port", 587)
+	v.SetDefault("notification.email.from", "alerts@alerting.local")
+	v.SetDefault("notification.digest.max_items", 100)
+	v.SetDefault("notification.digest.retention", "168h")
+	v.SetDefault("notification.digest.email_interval", "1h")
+
+	// Federation defaults
+	v.SetDefault("federation.enabled", false)
+	v.SetDefault("federation.target.host", "localhost")
+	v.SetDefault("federation.target.port", 6379)
+	v.SetDefault("federation.target.db", 0)
+	v.SetDefault("federation.target.pool_size", 5)
+	v.SetDefault("federation.event_types", []string{"alert.created", "alert.acknowledged", "alert.resolved"})
+	v.SetDefault("federation.min_severity", "high")
 }
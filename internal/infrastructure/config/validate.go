@@ -0,0 +1,233 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// minProductionSecretLength is the shortest JWT secret accepted in production.
+const minProductionSecretLength = 32
+
+// placeholderSecrets are the JWT secrets shipped in the defaults, the sample
+// configuration and the development compose file.
+var placeholderSecrets = map[string]bool{
+	"change-me-in-production":                    true,
+	"your-super-secret-key-change-in-production": true,
+	"dev-secret-change-in-production":            true,
+}
+
+// redactedKeys are the configuration keys whose values are never dumped.
+var redactedKeys = map[string]bool{
+	"password":      true,
+	"secret":        true,
+	"client_secret": true,
+	"webhook_url":   true,
+	"headers":       true,
+}
+
+// Validate checks that the fields required by the enabled features are set
+// and, in production, that no insecure default is left. It reports every
+// problem found at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.App.Env == "development" || c.App.Env == "staging" || c.App.Env == "production",
+		"app.env must be development, staging or production, got %q", c.App.Env)
+
+	// Server
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "server.port must be between 1 and 65535")
+	check(c.Server.MetricsPort >= 0 && c.Server.MetricsPort <= 65535, "server.metrics_port must be between 0 and 65535")
+	check(c.Server.MetricsPort != c.Server.Port, "server.metrics_port must differ from server.port")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	for _, origin := range c.Server.CORS.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		check(err == nil && u.Scheme != "" && u.Host != "" && (u.Path == "" || u.Path == "/"),
+			"server.cors.allow_origins entry %q must be a scheme and host, e.g. https://example.com", origin)
+	}
+	if c.Server.CORS.AllowCredentials {
+		check(len(c.Server.CORS.AllowOrigins) > 0 && !c.Server.CORS.AllowsAllOrigins(),
+			"server.cors.allow_credentials requires explicit server.cors.allow_origins")
+	}
+	if c.Server.ProxyHeader != "" {
+		check(len(c.Server.TrustedProxies) > 0, "server.trusted_proxies is required when server.proxy_header is set")
+	}
+	if c.Server.Security.Headers.Enabled {
+		check(c.Server.Security.Headers.HSTSMaxAge >= 0, "server.security.headers.hsts_max_age must not be negative")
+		fo := c.Server.Security.Headers.FrameOptions
+		check(fo == "" || fo == "DENY" || fo == "SAMEORIGIN",
+			"server.security.headers.frame_options must be DENY or SAMEORIGIN, got %q", fo)
+	}
+	if c.Server.Security.CSRF.Enabled {
+		check(c.Server.Security.CSRF.CookieName != "", "server.security.csrf.cookie_name is required when CSRF protection is enabled")
+		ss := c.Server.Security.CSRF.SameSite
+		check(ss == "Strict" || ss == "Lax" || ss == "None",
+			"server.security.csrf.same_site must be Strict, Lax or None, got %q", ss)
+	}
+
+	// Connections
+	check(c.Database.Host != "", "database.host is required")
+	check(c.Database.Name != "", "database.name is required")
+	check(c.Database.User != "", "database.user is required")
+	check(c.Redis.Host != "", "redis.host is required")
+	if c.Cache.Local.Enabled {
+		check(c.Cache.Local.Size > 0, "cache.local.size must be positive when the local cache is enabled")
+		check(c.Cache.Local.TTL > 0, "cache.local.ttl must be positive when the local cache is enabled")
+		check(c.Cache.Local.Channel != "", "cache.local.channel is required when the local cache is enabled")
+	}
+
+	// Auth
+	check(c.JWT.Secret != "", "jwt.secret is required")
+	check(c.JWT.Expiration > 0, "jwt.expiration must be positive")
+	check(c.JWT.RefreshExpiration > 0, "jwt.refresh_expiration must be positive")
+	check(c.JWT.RevocationRefreshInterval > 0, "jwt.revocation_refresh_interval must be positive")
+
+	// Logging and tracing
+	check(c.Logging.Format == "json" || c.Logging.Format == "console",
+		"logging.format must be json or console, got %q", c.Logging.Format)
+	check(c.Logging.BodySampleRate >= 0 && c.Logging.BodySampleRate <= 1, "logging.body_sample_rate must be between 0 and 1")
+	if c.Tracing.Enabled {
+		check(c.Tracing.Exporter == "otlp-grpc" || c.Tracing.Exporter == "otlp-http",
+			"tracing.exporter must be otlp-grpc or otlp-http, got %q", c.Tracing.Exporter)
+		check(c.Tracing.CollectorEndpoint() != "", "tracing.endpoint is required when tracing is enabled")
+		check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+	}
+
+	// Event bus and WebSocket
+	if c.EventBus.Driver == "redis-stream" {
+		check(c.EventBus.ConsumerID != "", "event_bus.consumer_id is required by the redis-stream driver")
+	}
+	check(c.WebSocket.SlowClientPolicy == "" || c.WebSocket.SlowClientPolicy == "disconnect" ||
+		c.WebSocket.SlowClientPolicy == "drop-oldest",
+		"websocket.slow_client_policy must be disconnect or drop-oldest, got %q", c.WebSocket.SlowClientPolicy)
+	check(c.WebSocket.AuthCheckInterval > 0, "websocket.auth_check_interval must be positive")
+	if c.WebSocket.Relay.Enabled {
+		check(c.WebSocket.Relay.Channel != "", "websocket.relay.channel is required when the relay is enabled")
+	}
+
+	// Notifications
+	if c.Notification.Slack.Enabled {
+		check(c.Notification.Slack.WebhookURL != "", "notification.slack.webhook_url is required when Slack is enabled")
+	}
+	if c.Notification.Telegram.Enabled {
+		check(c.Notification.Telegram.BotToken != "", "notification.telegram.bot_token is required when Telegram is enabled")
+		check(len(c.Notification.Telegram.ChatIDs) > 0, "notification.telegram.chat_ids is required when Telegram is enabled")
+	}
+	if c.Notification.Opsgenie.Enabled {
+		check(c.Notification.Opsgenie.APIKey != "", "notification.opsgenie.api_key is required when Opsgenie is enabled")
+	}
+	if c.Notification.SplunkOnCall.Enabled {
+		check(c.Notification.SplunkOnCall.APIKey != "", "notification.splunk_oncall.api_key is required when Splunk On-Call is enabled")
+		check(c.Notification.SplunkOnCall.RoutingKey != "", "notification.splunk_oncall.routing_key is required when Splunk On-Call is enabled")
+	}
+	if c.Notification.Jira.Enabled {
+		check(c.Notification.Jira.BaseURL != "", "notification.jira.base_url is required when Jira is enabled")
+		check(c.Notification.Jira.Email != "", "notification.jira.email is required when Jira is enabled")
+		check(c.Notification.Jira.APIToken != "", "notification.jira.api_token is required when Jira is enabled")
+		check(c.Notification.Jira.ProjectKey != "", "notification.jira.project_key is required when Jira is enabled")
+	}
+	if c.Notification.Statuspage.Enabled {
+		check(c.Notification.Statuspage.APIKey != "", "notification.statuspage.api_key is required when Statuspage is enabled")
+		check(c.Notification.Statuspage.PageID != "", "notification.statuspage.page_id is required when Statuspage is enabled")
+		check(len(c.Notification.Statuspage.Components) > 0,
+			"notification.statuspage.components is required when Statuspage is enabled")
+		check(c.Notification.Statuspage.SyncInterval > 0, "notification.statuspage.sync_interval must be positive")
+	}
+	if c.Notification.Twilio.Enabled {
+		check(c.Notification.Twilio.AccountSID != "", "notification.twilio.account_sid is required when Twilio is enabled")
+		check(c.Notification.Twilio.AuthToken != "", "notification.twilio.auth_token is required when Twilio is enabled")
+		check(c.Notification.Twilio.FromNumber != "", "notification.twilio.from_number is required when Twilio is enabled")
+		check(c.Notification.Twilio.PublicURL != "", "notification.twilio.public_url is required when Twilio is enabled")
+		check(c.Notification.Twilio.AckTimeout > 0, "notification.twilio.ack_timeout must be positive")
+		check(c.Notification.Twilio.CheckInterval > 0, "notification.twilio.check_interval must be positive")
+	}
+	if c.Notification.Email.Enabled {
+		check(c.Notification.Email.SMTPHost != "", "notification.email.smtp_host is required when email is enabled")
+		check(c.Notification.Email.SMTPPort > 0, "notification.email.smtp_port is required when email is enabled")
+		check(c.Notification.Email.From != "", "notification.email.from is required when email is enabled")
+	}
+
+	// Federation
+	if c.Federation.Enabled {
+		check(c.Federation.Target.Host != "", "federation.target.host is required when federation is enabled")
+	}
+
+	if c.App.IsProduction() {
+		check(!placeholderSecrets[c.JWT.Secret], "jwt.secret must be changed from its default in production")
+		check(len(c.JWT.Secret) >= minProductionSecretLength,
+			"jwt.secret must be at least %d characters in production", minProductionSecretLength)
+		check(c.Database.SSLMode != "" && c.Database.SSLMode != "disable",
+			"database.ssl_mode must not be disabled in production")
+		check(!c.Server.CORS.AllowsAllOrigins(), "server.cors.allow_origins must not allow every origin in production")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Redacted returns the configuration as a map keyed like the configuration
+// file, with secrets masked, so that the effective configuration can be
+// logged. Durations are rendered as strings.
+func (c *Config) Redacted() map[string]any {
+	dump, _ := dumpValue(reflect.ValueOf(*c)).(map[string]any)
+	return dump
+}
+
+// dumpValue converts a configuration value to maps, slices and scalars,
+// masking the values of the redacted keys.
+func dumpValue(v reflect.Value) any {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			key, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+			if key == "" {
+				continue
+			}
+			fields[key] = redact(key, v.Field(i))
+		}
+		return fields
+	case reflect.Map:
+		entries := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			entries[key] = redact(key, iter.Value())
+		}
+		return entries
+	case reflect.Slice:
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = dumpValue(v.Index(i))
+		}
+		return items
+	default:
+		return v.Interface()
+	}
+}
+
+// redact masks the value of a redacted key, unless it is unset.
+func redact(key string, v reflect.Value) any {
+	if redactedKeys[key] && !v.IsZero() {
+		return "[REDACTED]"
+	}
+	return dumpValue(v)
+}
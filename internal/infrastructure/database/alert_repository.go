@@ -4,10 +4,10 @@ package database
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
-
-	"github.com/jmoiron/sqlx"
+	"time"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
@@ -16,54 +16,184 @@ import (
 
 // PostgresAlertRepository implements AlertRepository using PostgreSQL.
 type PostgresAlertRepository struct {
-	db *sqlx.DB
+	db *InstrumentedDB
 }
 
 // NewPostgresAlertRepository creates a new PostgreSQL alert repository.
 func NewPostgresAlertRepository(db *PostgresDB) *PostgresAlertRepository {
 	return &PostgresAlertRepository{
-		db: db.DB,
+		db: db.Instrumented(),
 	}
 }
 
+// alertInsertQuery inserts one alert; Create and CreateDeduplicated append
+// their conflict handling.
+const alertInsertQuery = `
+		INSERT INTO alerts (id, rule_id, team_id, title, message, severity, status, source, region, cluster, metadata, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+
 // Create inserts a new alert into the database.
 func (r *PostgresAlertRepository) Create(ctx context.Context, alert *entity.Alert) error {
+	args, err := alertInsertArgs(alert)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, alertInsertQuery, args...)
+	return TranslateError(err)
+}
+
+// createDeduplicatedAttempts bounds how often CreateDeduplicated retries when
+// the conflicting open alert is closed before it could be read.
+const createDeduplicatedAttempts = 3
+
+// CreateDeduplicated inserts an alert unless an open alert of the same team
+// has its dedup key. The conflict is detected by the unique index on open
+// dedup keys, so concurrent calls never store the same alert twice.
+func (r *PostgresAlertRepository) CreateDeduplicated(ctx context.Context, alert *entity.Alert) (*entity.Alert, bool, error) {
+	dedupKey, _ := alert.Metadata[entity.MetadataDedupKey].(string)
+	if dedupKey == "" {
+		if err := r.Create(ctx, alert); err != nil {
+			return nil, false, err
+		}
+		return alert, true, nil
+	}
+
+	args, err := alertInsertArgs(alert)
+	if err != nil {
+		return nil, false, err
+	}
+
+	query := alertInsertQuery + `ON CONFLICT (team_id, (metadata->>'dedup_key'))
+		WHERE metadata->>'dedup_key' IS NOT NULL AND status IN ('active', 'acknowledged') AND deleted_at IS NULL
+		DO NOTHING
+	`
+
+	for attempt := 0; attempt < createDeduplicatedAttempts; attempt++ {
+		result, err := r.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return nil, false, TranslateError(err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, false, err
+		}
+		if rows == 1 {
+			return alert, true, nil
+		}
+
+		existing, err := r.getOpenByTeamDedupKey(ctx, alert.TeamID, dedupKey)
+		if err == nil {
+			return existing, false, nil
+		}
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, false, err
+		}
+	}
+
+	return nil, false, fmt.Errorf("alert with dedup key %q kept conflicting with closed alerts", dedupKey)
+}
+
+// getOpenByTeamDedupKey retrieves the open alert of a team with the given dedup key.
+func (r *PostgresAlertRepository) getOpenByTeamDedupKey(ctx context.Context, teamID *entity.ID, dedupKey string) (*entity.Alert, error) {
 	query := `
-		INSERT INTO alerts (id, rule_id, title, message, severity, status, source, metadata, expires_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		SELECT * FROM alerts
+		WHERE team_id IS NOT DISTINCT FROM $1 AND metadata->>'dedup_key' = $2
+		  AND status IN ('active', 'acknowledged') AND deleted_at IS NULL
 	`
 
-	metadata, err := json.Marshal(alert.Metadata)
+	var model AlertModel
+	err := r.db.GetContext(ctx, &model, query, optionalID(teamID), dedupKey)
 	if err != nil {
-		return err
+		return nil, TranslateError(err)
 	}
 
-	var ruleID *string
-	if alert.RuleID != nil {
-		id := alert.RuleID.String()
-		ruleID = &id
+	return model.ToEntity()
+}
+
+// alertInsertArgs returns the values of alertInsertQuery for an alert, in
+// column order.
+func alertInsertArgs(alert *entity.Alert) ([]interface{}, error) {
+	metadata, err := json.Marshal(alert.Metadata)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = r.db.ExecContext(ctx, query,
+	return []interface{}{
 		alert.ID.String(),
-		ruleID,
+		optionalID(alert.RuleID),
+		optionalID(alert.TeamID),
 		alert.Title,
 		alert.Message,
 		string(alert.Severity),
 		string(alert.Status),
 		alert.Source,
+		alert.Region,
+		alert.Cluster,
 		metadata,
 		alert.ExpiresAt,
 		alert.CreatedAt,
 		alert.UpdatedAt,
-	)
+	}, nil
+}
+
+// alertInsertColumns is the number of columns written per alert by CreateBatch.
+const alertInsertColumns = 14
+
+// alertBatchChunkSize bounds the rows per INSERT to stay well below
+// PostgreSQL's limit of 65535 bind parameters per statement.
+const alertBatchChunkSize = 500
+
+// CreateBatch inserts several alerts using multi-row INSERT statements.
+// All chunks run inside a single transaction.
+func (r *PostgresAlertRepository) CreateBatch(ctx context.Context, alerts []*entity.Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		for start := 0; start < len(alerts); start += alertBatchChunkSize {
+			end := min(start+alertBatchChunkSize, len(alerts))
+			if err := r.insertChunk(ctx, alerts[start:end]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
+// insertChunk inserts a chunk of alerts with a single statement.
+func (r *PostgresAlertRepository) insertChunk(ctx context.Context, alerts []*entity.Alert) error {
+	rows := make([]string, 0, len(alerts))
+	args := make([]interface{}, 0, len(alerts)*alertInsertColumns)
+
+	for i, alert := range alerts {
+		values, err := alertInsertArgs(alert)
+		if err != nil {
+			return err
+		}
+
+		placeholders := make([]string, alertInsertColumns)
+		for j := range placeholders {
+			placeholders[j] = fmt.Sprintf("$%d", i*alertInsertColumns+j+1)
+		}
+		rows = append(rows, "("+strings.Join(placeholders, ", ")+")")
+		args = append(args, values...)
+	}
+
+	query := `
+		INSERT INTO alerts (id, rule_id, team_id, title, message, severity, status, source, region, cluster, metadata, expires_at, created_at, updated_at)
+		VALUES ` + strings.Join(rows, ", ")
+
+	_, err := r.db.ExecContext(ctx, query, args...)
 	return TranslateError(err)
 }
 
 // GetByID retrieves an alert by its ID.
 func (r *PostgresAlertRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Alert, error) {
-	query := `SELECT * FROM alerts WHERE id = $1`
+	query := `SELECT * FROM alerts WHERE id = $1 AND deleted_at IS NULL`
 
 	var model AlertModel
 	err := r.db.GetContext(ctx, &model, query, id.String())
@@ -74,6 +204,24 @@ func (r *PostgresAlertRepository) GetByID(ctx context.Context, id entity.ID) (*e
 	return model.ToEntity()
 }
 
+// GetOpenByDedupKey retrieves the latest open alert with the given dedup key.
+func (r *PostgresAlertRepository) GetOpenByDedupKey(ctx context.Context, dedupKey string) (*entity.Alert, error) {
+	query := `
+		SELECT * FROM alerts
+		WHERE metadata->>'dedup_key' = $1 AND status IN ('active', 'acknowledged') AND deleted_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var model AlertModel
+	err := r.db.GetContext(ctx, &model, query, dedupKey)
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return model.ToEntity()
+}
+
 // Update updates an existing alert.
 func (r *PostgresAlertRepository) Update(ctx context.Context, alert *entity.Alert) error {
 	query := `
@@ -81,7 +229,7 @@ func (r *PostgresAlertRepository) Update(ctx context.Context, alert *entity.Aler
 		SET title = $1, message = $2, severity = $3, status = $4, source = $5, metadata = $6,
 		    acknowledged_by = $7, acknowledged_at = $8, resolved_by = $9, resolved_at = $10,
 		    expires_at = $11, updated_at = $12
-		WHERE id = $13
+		WHERE id = $13 AND deleted_at IS NULL
 	`
 
 	metadata, err := json.Marshal(alert.Metadata)
@@ -130,9 +278,38 @@ func (r *PostgresAlertRepository) Update(ctx context.Context, alert *entity.Aler
 	return nil
 }
 
-// Delete removes an alert from the database.
+// SetMetadata sets one metadata key of an alert.
+func (r *PostgresAlertRepository) SetMetadata(ctx context.Context, id entity.ID, key string, value interface{}) error {
+	query := `
+		UPDATE alerts
+		SET metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object($1::text, $2::jsonb)
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query, key, string(encoded), id.String())
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete moves an alert to the trash.
 func (r *PostgresAlertRepository) Delete(ctx context.Context, id entity.ID) error {
-	query := `DELETE FROM alerts WHERE id = $1`
+	query := `UPDATE alerts SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.ExecContext(ctx, query, id.String())
 	if err != nil {
@@ -150,6 +327,70 @@ func (r *PostgresAlertRepository) Delete(ctx context.Context, id entity.ID) erro
 	return nil
 }
 
+// DeleteByFilter moves all alerts matching the filter to the trash and
+// returns their IDs.
+func (r *PostgresAlertRepository) DeleteByFilter(ctx context.Context, filter valueobject.AlertFilter) ([]entity.ID, error) {
+	filter.Deleted = false
+	where, args := r.buildWhereClause(filter)
+
+	var ids []string
+	if err := r.db.SelectContext(ctx, &ids, "UPDATE alerts SET deleted_at = NOW()"+where+" RETURNING id", args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	deleted := make([]entity.ID, 0, len(ids))
+	for _, raw := range ids {
+		id, err := entity.ParseID(raw)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, id)
+	}
+
+	return deleted, nil
+}
+
+// Restore takes an alert out of the trash.
+func (r *PostgresAlertRepository) Restore(ctx context.Context, id entity.ID) error {
+	query := `UPDATE alerts SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id.String())
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeleted permanently removes up to limit alerts moved to the trash
+// before the cutoff, and returns how many were removed.
+func (r *PostgresAlertRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error) {
+	query := `
+		DELETE FROM alerts
+		WHERE id IN (
+			SELECT id FROM alerts
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, TranslateError(err)
+	}
+
+	return result.RowsAffected()
+}
+
 // List retrieves alerts with filtering and pagination.
 func (r *PostgresAlertRepository) List(
 	ctx context.Context,
@@ -164,11 +405,17 @@ func (r *PostgresAlertRepository) List(
 		return nil, TranslateError(err)
 	}
 
+	// The trash lists the most recently deleted alerts first
+	orderBy := "created_at DESC"
+	if filter.Deleted {
+		orderBy = "deleted_at DESC"
+	}
+
 	query := fmt.Sprintf(`
 		SELECT * FROM alerts %s
-		ORDER BY created_at DESC
+		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, where, len(args)+1, len(args)+2)
+	`, where, orderBy, len(args)+1, len(args)+2)
 
 	args = append(args, pagination.PageSize(), pagination.Offset())
 
@@ -192,7 +439,7 @@ func (r *PostgresAlertRepository) ListByStatus(
 	status entity.AlertStatus,
 	pagination valueobject.Pagination,
 ) (*valueobject.PaginatedResult[*entity.Alert], error) {
-	countQuery := `SELECT COUNT(*) FROM alerts WHERE status = $1`
+	countQuery := `SELECT COUNT(*) FROM alerts WHERE status = $1 AND deleted_at IS NULL`
 	var total int64
 	if err := r.db.GetContext(ctx, &total, countQuery, string(status)); err != nil {
 		return nil, TranslateError(err)
@@ -200,7 +447,7 @@ func (r *PostgresAlertRepository) ListByStatus(
 
 	query := `
 		SELECT * FROM alerts
-		WHERE status = $1
+		WHERE status = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
@@ -225,7 +472,7 @@ func (r *PostgresAlertRepository) ListByRuleID(
 	ruleID entity.ID,
 	pagination valueobject.Pagination,
 ) (*valueobject.PaginatedResult[*entity.Alert], error) {
-	countQuery := `SELECT COUNT(*) FROM alerts WHERE rule_id = $1`
+	countQuery := `SELECT COUNT(*) FROM alerts WHERE rule_id = $1 AND deleted_at IS NULL`
 	var total int64
 	if err := r.db.GetContext(ctx, &total, countQuery, ruleID.String()); err != nil {
 		return nil, TranslateError(err)
@@ -233,7 +480,7 @@ func (r *PostgresAlertRepository) ListByRuleID(
 
 	query := `
 		SELECT * FROM alerts
-		WHERE rule_id = $1
+		WHERE rule_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
@@ -254,7 +501,7 @@ func (r *PostgresAlertRepository) ListByRuleID(
 
 // ListActive retrieves all active alerts (for WebSocket broadcast).
 func (r *PostgresAlertRepository) ListActive(ctx context.Context) ([]*entity.Alert, error) {
-	query := `SELECT * FROM alerts WHERE status = 'active' ORDER BY severity, created_at DESC`
+	query := `SELECT * FROM alerts WHERE status = 'active' AND deleted_at IS NULL ORDER BY severity, created_at DESC`
 
 	var models []AlertModel
 	if err := r.db.SelectContext(ctx, &models, query); err != nil {
@@ -264,6 +511,93 @@ func (r *PostgresAlertRepository) ListActive(ctx context.Context) ([]*entity.Ale
 	return r.modelsToEntities(models)
 }
 
+// ListRelated retrieves the alerts sharing the fingerprint, source or rule
+// of an alert.
+func (r *PostgresAlertRepository) ListRelated(
+	ctx context.Context,
+	alert *entity.Alert,
+	filter valueobject.AlertFilter,
+	limit int,
+) ([]*entity.Alert, error) {
+	conditions, args := alertFilterConditions(filter)
+
+	fingerprint, fingerprintArgs := fingerprintCondition(alert, len(args)+1)
+	args = append(args, fingerprintArgs...)
+	related := []string{fingerprint}
+
+	if alert.Source != "" {
+		args = append(args, alert.Source)
+		related = append(related, fmt.Sprintf("source = $%d", len(args)))
+	}
+	if alert.RuleID != nil {
+		args = append(args, alert.RuleID.String())
+		related = append(related, fmt.Sprintf("rule_id = $%d", len(args)))
+	}
+
+	args = append(args, alert.ID.String())
+	conditions = append(conditions,
+		"("+strings.Join(related, " OR ")+")",
+		fmt.Sprintf("id <> $%d", len(args)),
+		"deleted_at IS NULL",
+	)
+
+	return r.listLatest(ctx, conditions, args, limit)
+}
+
+// ListOccurrences retrieves the alerts raised before an alert with its fingerprint.
+func (r *PostgresAlertRepository) ListOccurrences(
+	ctx context.Context,
+	alert *entity.Alert,
+	filter valueobject.AlertFilter,
+	limit int,
+) ([]*entity.Alert, error) {
+	conditions, args := alertFilterConditions(filter)
+
+	fingerprint, fingerprintArgs := fingerprintCondition(alert, len(args)+1)
+	args = append(args, fingerprintArgs...)
+	args = append(args, alert.ID.String(), alert.CreatedAt)
+	conditions = append(conditions,
+		fingerprint,
+		fmt.Sprintf("id <> $%d", len(args)-1),
+		fmt.Sprintf("created_at < $%d", len(args)),
+		"deleted_at IS NULL",
+	)
+
+	return r.listLatest(ctx, conditions, args, limit)
+}
+
+// fingerprintCondition builds the condition matching the alerts with the
+// fingerprint of an alert, numbering its arguments from argIndex.
+func fingerprintCondition(alert *entity.Alert, argIndex int) (string, []interface{}) {
+	if key, ok := alert.Metadata[entity.MetadataDedupKey].(string); ok && key != "" {
+		return fmt.Sprintf("metadata->>'dedup_key' = $%d", argIndex), []interface{}{key}
+	}
+
+	condition := fmt.Sprintf(
+		"(COALESCE(metadata->>'dedup_key', '') = '' AND source = $%d AND title = $%d AND team_id IS NOT DISTINCT FROM $%d::uuid)",
+		argIndex, argIndex+1, argIndex+2)
+	return condition, []interface{}{alert.Source, alert.Title, optionalID(alert.TeamID)}
+}
+
+// listLatest retrieves up to limit alerts matching the conditions, most recent first.
+func (r *PostgresAlertRepository) listLatest(
+	ctx context.Context,
+	conditions []string,
+	args []interface{},
+	limit int,
+) ([]*entity.Alert, error) {
+	query := fmt.Sprintf(`SELECT * FROM alerts %s ORDER BY created_at DESC LIMIT $%d`,
+		whereClause(conditions), len(args)+1)
+	args = append(args, limit)
+
+	var models []AlertModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return r.modelsToEntities(models)
+}
+
 // ListExpired retrieves alerts that have expired but not marked as such.
 func (r *PostgresAlertRepository) ListExpired(ctx context.Context) ([]*entity.Alert, error) {
 	query := `
@@ -271,6 +605,7 @@ func (r *PostgresAlertRepository) ListExpired(ctx context.Context) ([]*entity.Al
 		WHERE status NOT IN ('resolved', 'expired')
 		AND expires_at IS NOT NULL
 		AND expires_at < NOW()
+		AND deleted_at IS NULL
 	`
 
 	var models []AlertModel
@@ -283,7 +618,7 @@ func (r *PostgresAlertRepository) ListExpired(ctx context.Context) ([]*entity.Al
 
 // Count returns the total number of alerts.
 func (r *PostgresAlertRepository) Count(ctx context.Context) (int64, error) {
-	query := `SELECT COUNT(*) FROM alerts`
+	query := `SELECT COUNT(*) FROM alerts WHERE deleted_at IS NULL`
 	var count int64
 	if err := r.db.GetContext(ctx, &count, query); err != nil {
 		return 0, TranslateError(err)
@@ -293,7 +628,7 @@ func (r *PostgresAlertRepository) Count(ctx context.Context) (int64, error) {
 
 // CountByStatus returns the number of alerts by status.
 func (r *PostgresAlertRepository) CountByStatus(ctx context.Context, status entity.AlertStatus) (int64, error) {
-	query := `SELECT COUNT(*) FROM alerts WHERE status = $1`
+	query := `SELECT COUNT(*) FROM alerts WHERE status = $1 AND deleted_at IS NULL`
 	var count int64
 	if err := r.db.GetContext(ctx, &count, query, string(status)); err != nil {
 		return 0, TranslateError(err)
@@ -303,7 +638,7 @@ func (r *PostgresAlertRepository) CountByStatus(ctx context.Context, status enti
 
 // CountBySeverity returns the number of alerts by severity.
 func (r *PostgresAlertRepository) CountBySeverity(ctx context.Context, severity entity.AlertSeverity) (int64, error) {
-	query := `SELECT COUNT(*) FROM alerts WHERE severity = $1`
+	query := `SELECT COUNT(*) FROM alerts WHERE severity = $1 AND deleted_at IS NULL`
 	var count int64
 	if err := r.db.GetContext(ctx, &count, query, string(severity)); err != nil {
 		return 0, TranslateError(err)
@@ -311,25 +646,38 @@ func (r *PostgresAlertRepository) CountBySeverity(ctx context.Context, severity
 	return count, nil
 }
 
-// GetStatistics retrieves alert statistics.
-func (r *PostgresAlertRepository) GetStatistics(ctx context.Context) (*repository.AlertStatistics, error) {
+// GetStatistics retrieves statistics of the alerts matching the filter.
+// Filters on the counted columns alone are answered from alert_counters, which
+// triggers keep up to date, so that statistics do not scan the alerts table;
+// any other filter falls back to counting the alerts.
+func (r *PostgresAlertRepository) GetStatistics(ctx context.Context, filter valueobject.AlertFilter) (*repository.AlertStatistics, error) {
+	where, args := r.buildWhereClause(filter)
+
+	// alert_counters only counts alerts not in the trash
+	table, count := "alerts", "COUNT(*)"
+	if countersCover(filter) {
+		table, count = "alert_counters", "COALESCE(SUM(count), 0)::BIGINT"
+		conditions, counterArgs := alertFilterConditions(filter)
+		where, args = whereClause(conditions), counterArgs
+	}
+
 	query := `
 		SELECT
-			COUNT(*) as total_alerts,
-			COUNT(*) FILTER (WHERE status = 'active') as active_alerts,
-			COUNT(*) FILTER (WHERE status = 'acknowledged') as acknowledged_alerts,
-			COUNT(*) FILTER (WHERE status = 'resolved') as resolved_alerts
-		FROM alerts
-	`
+			` + count + ` as total_alerts,
+			` + count + ` FILTER (WHERE status = 'active') as active_alerts,
+			` + count + ` FILTER (WHERE status = 'acknowledged') as acknowledged_alerts,
+			` + count + ` FILTER (WHERE status = 'resolved') as resolved_alerts
+		FROM ` + table + where
 
 	var stats repository.AlertStatistics
-	if err := r.db.GetContext(ctx, &stats, query); err != nil {
+	if err := r.db.GetContext(ctx, &stats, query, args...); err != nil {
 		return nil, TranslateError(err)
 	}
 
-	// Get by severity
-	severityQuery := `SELECT severity, COUNT(*) as count FROM alerts GROUP BY severity`
-	rows, err := r.db.QueryContext(ctx, severityQuery)
+	// Get by severity; counters may have dropped to zero
+	severityQuery := `SELECT severity, ` + count + ` as count FROM ` + table + where +
+		` GROUP BY severity HAVING ` + count + ` > 0`
+	rows, err := r.db.QueryContext(ctx, severityQuery, args...)
 	if err != nil {
 		return nil, TranslateError(err)
 	}
@@ -349,8 +697,13 @@ func (r *PostgresAlertRepository) GetStatistics(ctx context.Context) (*repositor
 	}
 
 	// Get by source
-	sourceQuery := `SELECT source, COUNT(*) as count FROM alerts WHERE source != '' GROUP BY source`
-	rows, err = r.db.QueryContext(ctx, sourceQuery)
+	sourceWhere := " WHERE source != ''"
+	if where != "" {
+		sourceWhere = where + " AND source != ''"
+	}
+	sourceQuery := `SELECT source, ` + count + ` as count FROM ` + table + sourceWhere +
+		` GROUP BY source HAVING ` + count + ` > 0`
+	rows, err = r.db.QueryContext(ctx, sourceQuery, args...)
 	if err != nil {
 		return nil, TranslateError(err)
 	}
@@ -372,8 +725,63 @@ func (r *PostgresAlertRepository) GetStatistics(ctx context.Context) (*repositor
 	return &stats, nil
 }
 
+// countersCover reports whether a filter only restricts the columns kept in
+// alert_counters: status, severity, source and team.
+func countersCover(filter valueobject.AlertFilter) bool {
+	if filter.Deleted || filter.Region != nil || filter.RuleID != nil || filter.FromDate != nil || filter.ToDate != nil ||
+		len(filter.Labels) > 0 {
+		return false
+	}
+	if filter.Search != nil && *filter.Search != "" {
+		return false
+	}
+	for _, rule := range filter.Hidden {
+		if len(rule.Labels) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// RebuildStatistics recounts alert_counters from the alerts table, repairing
+// any drift. Writes to the counters wait until the rebuild commits.
+func (r *PostgresAlertRepository) RebuildStatistics(ctx context.Context) error {
+	statements := []string{
+		`LOCK TABLE alert_counters IN EXCLUSIVE MODE`,
+		`DELETE FROM alert_counters`,
+		`INSERT INTO alert_counters (team_id, status, severity, source, count)
+		SELECT team_id, status, severity, COALESCE(source, ''), COUNT(*)
+		FROM alerts
+		WHERE deleted_at IS NULL
+		GROUP BY team_id, status, severity, COALESCE(source, '')`,
+	}
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, statement := range statements {
+			if _, err := r.db.ExecContext(ctx, statement); err != nil {
+				return TranslateError(err)
+			}
+		}
+		return nil
+	})
+}
+
 // buildWhereClause builds the WHERE clause for filtering alerts.
 func (r *PostgresAlertRepository) buildWhereClause(filter valueobject.AlertFilter) (string, []interface{}) {
+	conditions, args := alertFilterConditions(filter)
+
+	// Alerts in the trash are only listed when asked for
+	if filter.Deleted {
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	} else {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	return whereClause(conditions), args
+}
+
+// alertFilterConditions builds the conditions of the filter on the columns
+// shared by alerts and alert_counters, along with their arguments.
+func alertFilterConditions(filter valueobject.AlertFilter) ([]string, []interface{}) {
 	var conditions []string
 	var args []interface{}
 	argIndex := 1
@@ -404,6 +812,18 @@ func (r *PostgresAlertRepository) buildWhereClause(filter valueobject.AlertFilte
 		argIndex++
 	}
 
+	if filter.Region != nil {
+		conditions = append(conditions, fmt.Sprintf("region = $%d", argIndex))
+		args = append(args, *filter.Region)
+		argIndex++
+	}
+
+	if filter.TeamID != nil {
+		conditions = append(conditions, fmt.Sprintf("team_id = $%d", argIndex))
+		args = append(args, filter.TeamID.String())
+		argIndex++
+	}
+
 	if filter.Search != nil && *filter.Search != "" {
 		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR message ILIKE $%d)", argIndex, argIndex+1))
 		searchTerm := "%" + *filter.Search + "%"
@@ -411,16 +831,95 @@ func (r *PostgresAlertRepository) buildWhereClause(filter valueobject.AlertFilte
 		argIndex += 2
 	}
 
-	if filter.FromDate != nil && filter.ToDate != nil {
+	for key, value := range filter.Labels {
+		conditions = append(conditions, fmt.Sprintf("metadata->'labels'->>$%d = $%d", argIndex, argIndex+1))
+		args = append(args, key, value)
+		argIndex += 2
+	}
+
+	switch {
+	case filter.FromDate != nil && filter.ToDate != nil:
 		conditions = append(conditions, fmt.Sprintf("created_at BETWEEN $%d AND $%d", argIndex, argIndex+1))
 		args = append(args, filter.FromDate, filter.ToDate)
+		argIndex += 2
+	case filter.FromDate != nil:
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, filter.FromDate)
+		argIndex++
+	case filter.ToDate != nil:
+		conditions = append(conditions, fmt.Sprintf("created_at <= $%d", argIndex))
+		args = append(args, filter.ToDate)
+		argIndex++
+	}
+
+	// Visibility restrictions exclude every alert matching a hidden rule
+	for _, rule := range filter.Hidden {
+		var criteria []string
+
+		if rule.Source != "" {
+			criteria = append(criteria, fmt.Sprintf("(source = $%d OR source LIKE $%d)", argIndex, argIndex+1))
+			args = append(args, rule.Source, escapeLike(rule.Source)+":%")
+			argIndex += 2
+		}
+
+		for key, value := range rule.Labels {
+			criteria = append(criteria, fmt.Sprintf("COALESCE(metadata->'labels'->>$%d, '') = $%d", argIndex, argIndex+1))
+			args = append(args, key, value)
+			argIndex += 2
+		}
+
+		if len(criteria) > 0 {
+			conditions = append(conditions, "NOT ("+strings.Join(criteria, " AND ")+")")
+		}
 	}
 
+	// Tenant restrictions keep shared alerts and those of the scope's teams
+	if filter.Tenant != nil {
+		teams := filter.Tenant.Teams()
+		if len(teams) == 0 {
+			conditions = append(conditions, "team_id IS NULL")
+		} else {
+			placeholders := make([]string, len(teams))
+			for i, teamID := range teams {
+				placeholders[i] = fmt.Sprintf("$%d", argIndex)
+				args = append(args, teamID.String())
+				argIndex++
+			}
+			conditions = append(conditions, fmt.Sprintf("(team_id IS NULL OR team_id IN (%s))", strings.Join(placeholders, ",")))
+		}
+	}
+
+	return conditions, args
+}
+
+// whereClause joins conditions into a WHERE clause, empty without conditions.
+func whereClause(conditions []string) string {
 	if len(conditions) == 0 {
-		return "", args
+		return ""
 	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// optionalID converts an optional ID to a nullable column value.
+func optionalID(id *entity.ID) *string {
+	if id == nil {
+		return nil
+	}
+	value := id.String()
+	return &value
+}
+
+// optionalString converts an optional string to a nullable column value.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
 
-	return " WHERE " + strings.Join(conditions, " AND "), args
+// escapeLike escapes LIKE wildcards so the value matches literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
 }
 
 // modelsToEntities converts a slice of AlertModel to a slice of entity.Alert.
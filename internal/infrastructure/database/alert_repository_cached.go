@@ -0,0 +1,415 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Cache TTL constants. Alerts change often, so entries are kept briefly even
+// though writes invalidate them.
+const (
+	alertCacheTTL           = 30 * time.Second
+	activeAlertsCacheTTL    = 10 * time.Second
+	alertStatisticsCacheTTL = 15 * time.Second
+)
+
+// Stale TTL constants: how long the active alerts and statistics are served
+// past their TTL while a single caller reloads them, instead of every caller
+// of a dashboard hitting the database at once.
+const (
+	activeAlertsStaleTTL    = 10 * time.Second
+	alertStatisticsStaleTTL = 30 * time.Second
+)
+
+// Statistics version scopes. Statistics are cached under the versions of the
+// alerts they count, bumped by every write to those alerts instead of
+// deleting the cached statistics: the version of each team, or of the shared
+// alerts, for tenant-restricted statistics; the all version, bumped on every
+// write, for unrestricted ones; and the epoch, bumped when the team of the
+// written alerts is not known, for every statistics.
+const (
+	statisticsVersionAll    = "all"
+	statisticsVersionShared = "shared"
+	statisticsVersionEpoch  = "epoch"
+)
+
+// Ensure CachedAlertRepository implements repository.AlertRepository
+var _ repository.AlertRepository = (*CachedAlertRepository)(nil)
+
+// CachedAlertRepository wraps PostgresAlertRepository with Redis caching of
+// single alerts, the active alerts and statistics, the reads behind dashboards.
+type CachedAlertRepository struct {
+	postgres *PostgresAlertRepository
+	cache    repository.CacheRepository
+	loader   *cacheLoader
+	keys     *CacheKey
+}
+
+// NewCachedAlertRepository creates a new cached alert repository.
+func NewCachedAlertRepository(postgres *PostgresAlertRepository, cache repository.CacheRepository) *CachedAlertRepository {
+	return &CachedAlertRepository{
+		postgres: postgres,
+		cache:    cache,
+		loader:   newCacheLoader(cache),
+		keys:     NewCacheKey(),
+	}
+}
+
+// Create saves a new alert and invalidates the cached lists.
+func (r *CachedAlertRepository) Create(ctx context.Context, alert *entity.Alert) error {
+	if err := r.postgres.Create(ctx, alert); err != nil {
+		return err
+	}
+
+	r.invalidateLists(ctx)
+	r.bumpStatistics(ctx, statisticsVersionScope(alert.TeamID))
+	return nil
+}
+
+// CreateDeduplicated saves a new alert unless an open alert has its dedup
+// key, invalidating the cached lists when it was saved.
+func (r *CachedAlertRepository) CreateDeduplicated(ctx context.Context, alert *entity.Alert) (*entity.Alert, bool, error) {
+	stored, created, err := r.postgres.CreateDeduplicated(ctx, alert)
+	if err != nil || !created {
+		return stored, created, err
+	}
+
+	r.invalidateLists(ctx)
+	r.bumpStatistics(ctx, statisticsVersionScope(alert.TeamID))
+	return stored, true, nil
+}
+
+// CreateBatch saves several alerts and invalidates the cached lists.
+func (r *CachedAlertRepository) CreateBatch(ctx context.Context, alerts []*entity.Alert) error {
+	if err := r.postgres.CreateBatch(ctx, alerts); err != nil {
+		return err
+	}
+
+	scopes := make([]string, len(alerts))
+	for i, alert := range alerts {
+		scopes[i] = statisticsVersionScope(alert.TeamID)
+	}
+
+	r.invalidateLists(ctx)
+	r.bumpStatistics(ctx, scopes...)
+	return nil
+}
+
+// GetByID finds an alert by ID, using cache when available.
+func (r *CachedAlertRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Alert, error) {
+	cacheKey := r.keys.Alert(id)
+
+	// Try cache first
+	var alert entity.Alert
+	if err := r.cache.Get(ctx, cacheKey, &alert); err == nil {
+		return &alert, nil
+	}
+
+	// Cache miss - get from database
+	dbAlert, err := r.postgres.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store in cache (ignore errors - cache is optional)
+	if cacheErr := r.cache.Set(ctx, cacheKey, dbAlert, alertCacheTTL); cacheErr != nil {
+		log.Warn().Err(cacheErr).Str("key", cacheKey).Msg("Failed to cache alert")
+	}
+
+	return dbAlert, nil
+}
+
+// GetOpenByDedupKey finds the open alert with a dedup key (not cached - it
+// decides whether a new alert is created).
+func (r *CachedAlertRepository) GetOpenByDedupKey(ctx context.Context, dedupKey string) (*entity.Alert, error) {
+	return r.postgres.GetOpenByDedupKey(ctx, dedupKey)
+}
+
+// Update updates an alert and invalidates cache.
+func (r *CachedAlertRepository) Update(ctx context.Context, alert *entity.Alert) error {
+	if err := r.postgres.Update(ctx, alert); err != nil {
+		return err
+	}
+
+	r.Invalidate(ctx, alert.ID, alert.TeamID)
+	return nil
+}
+
+// SetMetadata sets one metadata key of an alert and invalidates cache. The
+// statistics do not count metadata, so they are kept.
+func (r *CachedAlertRepository) SetMetadata(ctx context.Context, id entity.ID, key string, value interface{}) error {
+	if err := r.postgres.SetMetadata(ctx, id, key, value); err != nil {
+		return err
+	}
+
+	r.invalidateAlert(ctx, id)
+	r.invalidateLists(ctx)
+	return nil
+}
+
+// Delete removes an alert and invalidates cache.
+func (r *CachedAlertRepository) Delete(ctx context.Context, id entity.ID) error {
+	if err := r.postgres.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	r.InvalidateRemoved(ctx, id)
+	return nil
+}
+
+// DeleteByFilter removes the matching alerts and invalidates every cached alert.
+func (r *CachedAlertRepository) DeleteByFilter(ctx context.Context, filter valueobject.AlertFilter) ([]entity.ID, error) {
+	deleted, err := r.postgres.DeleteByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deleted) > 0 {
+		if err := r.cache.DeleteByPattern(ctx, r.keys.Pattern("alert", "*")); err != nil {
+			log.Warn().Err(err).Msg("Failed to invalidate alert cache")
+		}
+		r.invalidateLists(ctx)
+		r.bumpStatistics(ctx, statisticsVersionEpoch)
+	}
+
+	return deleted, nil
+}
+
+// Restore takes an alert out of the trash and invalidates the cache.
+func (r *CachedAlertRepository) Restore(ctx context.Context, id entity.ID) error {
+	if err := r.postgres.Restore(ctx, id); err != nil {
+		return err
+	}
+
+	r.InvalidateRemoved(ctx, id)
+	return nil
+}
+
+// PurgeDeleted permanently removes alerts from the trash (not cached - deleted
+// alerts never are).
+func (r *CachedAlertRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.postgres.PurgeDeleted(ctx, before, limit)
+}
+
+// RebuildStatistics recounts the alert counters and invalidates the cached
+// statistics.
+func (r *CachedAlertRepository) RebuildStatistics(ctx context.Context) error {
+	if err := r.postgres.RebuildStatistics(ctx); err != nil {
+		return err
+	}
+
+	r.bumpStatistics(ctx, statisticsVersionEpoch)
+	return nil
+}
+
+// Invalidate drops an alert, the lists it may appear in and the statistics
+// of its team from the cache. It is called on writes, and on alert events so
+// that changes made elsewhere are not served stale until the entries expire.
+func (r *CachedAlertRepository) Invalidate(ctx context.Context, id entity.ID, teamID *entity.ID) {
+	r.invalidateAlert(ctx, id)
+	r.invalidateLists(ctx)
+	r.bumpStatistics(ctx, statisticsVersionScope(teamID))
+}
+
+// InvalidateRemoved drops an alert whose team is not known, e.g. a deleted
+// one, and the lists and statistics it may appear in from the cache.
+func (r *CachedAlertRepository) InvalidateRemoved(ctx context.Context, id entity.ID) {
+	r.invalidateAlert(ctx, id)
+	r.invalidateLists(ctx)
+	r.bumpStatistics(ctx, statisticsVersionEpoch)
+}
+
+// invalidateAlert drops an alert from the cache.
+func (r *CachedAlertRepository) invalidateAlert(ctx context.Context, id entity.ID) {
+	if err := r.cache.Delete(ctx, r.keys.Alert(id)); err != nil {
+		log.Warn().Err(err).Msg("Failed to invalidate alert cache by ID")
+	}
+}
+
+// invalidateLists drops the cached active alerts.
+func (r *CachedAlertRepository) invalidateLists(ctx context.Context) {
+	if err := r.cache.Delete(ctx, r.keys.AlertsActive()); err != nil {
+		log.Warn().Err(err).Msg("Failed to invalidate active alerts cache")
+	}
+}
+
+// bumpStatistics increments the statistics versions of the written scopes,
+// and the all version, so that the statistics cached under the previous
+// versions are no longer read. They expire on their own.
+func (r *CachedAlertRepository) bumpStatistics(ctx context.Context, scopes ...string) {
+	bumped := map[string]bool{statisticsVersionAll: true}
+	for _, scope := range scopes {
+		bumped[scope] = true
+	}
+
+	for scope := range bumped {
+		if _, err := r.cache.Increment(ctx, r.keys.AlertStatisticsVersion(scope)); err != nil {
+			log.Warn().Err(err).Str("scope", scope).Msg("Failed to invalidate alert statistics cache")
+		}
+	}
+}
+
+// statisticsVersion returns the versions of the statistics of the alerts
+// matching a filter, joined for use in cache keys.
+func (r *CachedAlertRepository) statisticsVersion(ctx context.Context, filter valueobject.AlertFilter) (string, error) {
+	scopes := []string{statisticsVersionEpoch}
+	if filter.Tenant == nil || filter.Tenant.IsAll() {
+		scopes = append(scopes, statisticsVersionAll)
+	} else {
+		scopes = append(scopes, statisticsVersionShared)
+		for _, teamID := range filter.Tenant.Teams() {
+			scopes = append(scopes, teamID.String())
+		}
+	}
+
+	versions := make([]string, len(scopes))
+	for i, scope := range scopes {
+		var version int64
+		err := r.cache.Get(ctx, r.keys.AlertStatisticsVersion(scope), &version)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return "", err
+		}
+		versions[i] = strconv.FormatInt(version, 10)
+	}
+
+	return strings.Join(versions, "."), nil
+}
+
+// statisticsVersionScope returns the statistics version scope of the alerts
+// of a team, or of the shared alerts when teamID is nil.
+func statisticsVersionScope(teamID *entity.ID) string {
+	if teamID == nil {
+		return statisticsVersionShared
+	}
+	return teamID.String()
+}
+
+// List returns paginated alerts (not cached - filters and pages vary too much).
+func (r *CachedAlertRepository) List(
+	ctx context.Context,
+	filter valueobject.AlertFilter,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Alert], error) {
+	return r.postgres.List(ctx, filter, pagination)
+}
+
+// ListByStatus returns alerts by status (not cached).
+func (r *CachedAlertRepository) ListByStatus(
+	ctx context.Context,
+	status entity.AlertStatus,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Alert], error) {
+	return r.postgres.ListByStatus(ctx, status, pagination)
+}
+
+// ListByRuleID returns alerts of a rule (not cached).
+func (r *CachedAlertRepository) ListByRuleID(
+	ctx context.Context,
+	ruleID entity.ID,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Alert], error) {
+	return r.postgres.ListByRuleID(ctx, ruleID, pagination)
+}
+
+// ListActive returns all active alerts, using cache when available.
+func (r *CachedAlertRepository) ListActive(ctx context.Context) ([]*entity.Alert, error) {
+	return loadThrough(ctx, r.loader, r.keys.AlertsActive(), activeAlertsCacheTTL, activeAlertsStaleTTL,
+		r.postgres.ListActive)
+}
+
+// ListRelated returns the alerts related to an alert (not cached).
+func (r *CachedAlertRepository) ListRelated(
+	ctx context.Context,
+	alert *entity.Alert,
+	filter valueobject.AlertFilter,
+	limit int,
+) ([]*entity.Alert, error) {
+	return r.postgres.ListRelated(ctx, alert, filter, limit)
+}
+
+// ListOccurrences returns the previous occurrences of an alert (not cached).
+func (r *CachedAlertRepository) ListOccurrences(
+	ctx context.Context,
+	alert *entity.Alert,
+	filter valueobject.AlertFilter,
+	limit int,
+) ([]*entity.Alert, error) {
+	return r.postgres.ListOccurrences(ctx, alert, filter, limit)
+}
+
+// ListExpired returns expired alerts (not cached - read by the cleanup job).
+func (r *CachedAlertRepository) ListExpired(ctx context.Context) ([]*entity.Alert, error) {
+	return r.postgres.ListExpired(ctx)
+}
+
+// Count returns total alerts (not cached).
+func (r *CachedAlertRepository) Count(ctx context.Context) (int64, error) {
+	return r.postgres.Count(ctx)
+}
+
+// CountByStatus returns alerts count by status (not cached).
+func (r *CachedAlertRepository) CountByStatus(ctx context.Context, status entity.AlertStatus) (int64, error) {
+	return r.postgres.CountByStatus(ctx, status)
+}
+
+// CountBySeverity returns alerts count by severity (not cached).
+func (r *CachedAlertRepository) CountBySeverity(ctx context.Context, severity entity.AlertSeverity) (int64, error) {
+	return r.postgres.CountBySeverity(ctx, severity)
+}
+
+// GetStatistics returns the statistics of the alerts matching a filter,
+// using cache when available. A burst of requests after the statistics
+// expire runs a single query, the others getting the stale statistics.
+// Statistics are cached under the versions of the teams they count, so that
+// writes to the alerts of other teams keep them.
+func (r *CachedAlertRepository) GetStatistics(
+	ctx context.Context,
+	filter valueobject.AlertFilter,
+) (*repository.AlertStatistics, error) {
+	filterHash, err := alertFilterHash(filter)
+	if err != nil {
+		return r.postgres.GetStatistics(ctx, filter)
+	}
+	version, err := r.statisticsVersion(ctx, filter)
+	if err != nil {
+		return r.postgres.GetStatistics(ctx, filter)
+	}
+	cacheKey := r.keys.AlertStatisticsByFilter(filterHash + ":" + version)
+
+	return loadThrough(ctx, r.loader, cacheKey, alertStatisticsCacheTTL, alertStatisticsStaleTTL,
+		func(ctx context.Context) (*repository.AlertStatistics, error) {
+			return r.postgres.GetStatistics(ctx, filter)
+		})
+}
+
+// alertFilterHash identifies a filter in cache keys. The tenant scope has no
+// exported fields, so it is hashed through its key.
+func alertFilterHash(filter valueobject.AlertFilter) (string, error) {
+	tenant := ""
+	if filter.Tenant != nil {
+		tenant = filter.Tenant.Key()
+	}
+
+	data, err := json.Marshal(struct {
+		Filter valueobject.AlertFilter
+		Tenant string
+	}{filter, tenant})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:16]), nil
+}
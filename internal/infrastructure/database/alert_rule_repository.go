@@ -0,0 +1,277 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresAlertRuleRepository implements repository.AlertRuleRepository
+var _ repository.AlertRuleRepository = (*PostgresAlertRuleRepository)(nil)
+
+// PostgresAlertRuleRepository implements AlertRuleRepository using PostgreSQL.
+type PostgresAlertRuleRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresAlertRuleRepository creates a new PostgreSQL alert rule repository.
+func NewPostgresAlertRuleRepository(db *PostgresDB) *PostgresAlertRuleRepository {
+	return &PostgresAlertRuleRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new rule to the database.
+func (r *PostgresAlertRuleRepository) Create(ctx context.Context, rule *entity.AlertRule) error {
+	condition, err := json.Marshal(rule.Condition)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO alert_rules (
+			id, name, description, condition, severity, is_enabled, cooldown_minutes, created_by, team_id,
+			external_id, runbook_url, remediation, create_ticket, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Description,
+		condition,
+		string(rule.Severity),
+		rule.IsEnabled,
+		rule.CooldownMinutes,
+		optionalID(rule.CreatedBy),
+		optionalID(rule.TeamID),
+		optionalString(rule.ExternalID),
+		optionalString(rule.RunbookURL),
+		optionalString(rule.Remediation),
+		rule.CreateTicket,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a rule by its ID.
+func (r *PostgresAlertRuleRepository) GetByID(ctx context.Context, id entity.ID) (*entity.AlertRule, error) {
+	var model AlertRuleModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM alert_rules WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// GetByExternalID finds a rule by the ID given by an external tool.
+func (r *PostgresAlertRuleRepository) GetByExternalID(ctx context.Context, externalID string) (*entity.AlertRule, error) {
+	var model AlertRuleModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM alert_rules WHERE external_id = $1`, externalID); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing rule.
+func (r *PostgresAlertRuleRepository) Update(ctx context.Context, rule *entity.AlertRule) error {
+	condition, err := json.Marshal(rule.Condition)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE alert_rules
+		SET name = $2, description = $3, condition = $4, severity = $5,
+			is_enabled = $6, cooldown_minutes = $7, team_id = $8, external_id = $9,
+			runbook_url = $10, remediation = $11, create_ticket = $12, updated_at = $13
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Description,
+		condition,
+		string(rule.Severity),
+		rule.IsEnabled,
+		rule.CooldownMinutes,
+		optionalID(rule.TeamID),
+		optionalString(rule.ExternalID),
+		optionalString(rule.RunbookURL),
+		optionalString(rule.Remediation),
+		rule.CreateTicket,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a rule by its ID.
+func (r *PostgresAlertRuleRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated rules ordered by name.
+func (r *PostgresAlertRuleRepository) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.AlertRule], error) {
+	return r.list(ctx, "", nil, pagination)
+}
+
+// ListForTenant returns paginated rules accessible within the tenant scope, ordered by name.
+func (r *PostgresAlertRuleRepository) ListForTenant(
+	ctx context.Context,
+	tenant valueobject.TenantScope,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.AlertRule], error) {
+	if tenant.IsAll() {
+		return r.list(ctx, "", nil, pagination)
+	}
+
+	teams := tenant.Teams()
+	if len(teams) == 0 {
+		return r.list(ctx, " WHERE team_id IS NULL", nil, pagination)
+	}
+
+	placeholders := make([]string, len(teams))
+	args := make([]interface{}, len(teams))
+	for i, teamID := range teams {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = teamID.String()
+	}
+	where := fmt.Sprintf(" WHERE (team_id IS NULL OR team_id IN (%s))", strings.Join(placeholders, ","))
+
+	return r.list(ctx, where, args, pagination)
+}
+
+// ListAll returns every rule, ordered by name.
+func (r *PostgresAlertRuleRepository) ListAll(ctx context.Context) ([]*entity.AlertRule, error) {
+	return r.selectAll(ctx, `SELECT * FROM alert_rules ORDER BY name`)
+}
+
+// ListEnabled returns every enabled rule.
+func (r *PostgresAlertRuleRepository) ListEnabled(ctx context.Context) ([]*entity.AlertRule, error) {
+	return r.selectAll(ctx, `SELECT * FROM alert_rules WHERE is_enabled = true ORDER BY name`)
+}
+
+// selectAll returns the rules selected by an unpaginated query.
+func (r *PostgresAlertRuleRepository) selectAll(ctx context.Context, query string) ([]*entity.AlertRule, error) {
+	var models []AlertRuleModel
+	if err := r.db.SelectContext(ctx, &models, query); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	rules := make([]*entity.AlertRule, 0, len(models))
+	for i := range models {
+		rule, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// ListByCreator returns paginated rules created by a user, ordered by name.
+func (r *PostgresAlertRuleRepository) ListByCreator(
+	ctx context.Context,
+	userID entity.ID,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.AlertRule], error) {
+	return r.list(ctx, " WHERE created_by = $1", []interface{}{userID}, pagination)
+}
+
+// ExistsByName checks if a rule with that name exists.
+func (r *PostgresAlertRuleRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM alert_rules WHERE name = $1)`, name)
+	return exists, TranslateError(err)
+}
+
+// Count returns the total number of rules.
+func (r *PostgresAlertRuleRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM alert_rules`)
+	return count, TranslateError(err)
+}
+
+// CountEnabled returns the number of enabled rules.
+func (r *PostgresAlertRuleRepository) CountEnabled(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM alert_rules WHERE is_enabled = true`)
+	return count, TranslateError(err)
+}
+
+// list returns paginated rules matching the WHERE clause, ordered by name.
+func (r *PostgresAlertRuleRepository) list(
+	ctx context.Context,
+	where string,
+	args []interface{},
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.AlertRule], error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM alert_rules"+where, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM alert_rules %s
+		ORDER BY name
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+
+	args = append(args, pagination.Limit(), pagination.Offset())
+
+	var models []AlertRuleModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	rules := make([]*entity.AlertRule, 0, len(models))
+	for i := range models {
+		rule, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	result := valueobject.NewPaginatedResult(rules, total, pagination)
+	return &result, nil
+}
@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresAlertViewRepository implements repository.AlertViewRepository
+var _ repository.AlertViewRepository = (*PostgresAlertViewRepository)(nil)
+
+// PostgresAlertViewRepository implements AlertViewRepository using PostgreSQL.
+type PostgresAlertViewRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresAlertViewRepository creates a new PostgreSQL alert view repository.
+func NewPostgresAlertViewRepository(db *PostgresDB) *PostgresAlertViewRepository {
+	return &PostgresAlertViewRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new view to the database.
+func (r *PostgresAlertViewRepository) Create(ctx context.Context, view *entity.AlertView) error {
+	query := `
+		INSERT INTO alert_views (id, name, criteria, owner_id, team_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	criteria, err := json.Marshal(view.Criteria)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		view.ID,
+		view.Name,
+		criteria,
+		view.OwnerID,
+		optionalID(view.TeamID),
+		view.CreatedAt,
+		view.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a view by its ID.
+func (r *PostgresAlertViewRepository) GetByID(ctx context.Context, id entity.ID) (*entity.AlertView, error) {
+	var model AlertViewModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM alert_views WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing view.
+func (r *PostgresAlertViewRepository) Update(ctx context.Context, view *entity.AlertView) error {
+	query := `
+		UPDATE alert_views
+		SET name = $2, criteria = $3, team_id = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	criteria, err := json.Marshal(view.Criteria)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		view.ID,
+		view.Name,
+		criteria,
+		optionalID(view.TeamID),
+		view.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a view by its ID.
+func (r *PostgresAlertViewRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM alert_views WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListVisible returns the views of the owner and those shared with the teams
+// of the tenant scope.
+func (r *PostgresAlertViewRepository) ListVisible(
+	ctx context.Context,
+	ownerID entity.ID,
+	tenant valueobject.TenantScope,
+) ([]*entity.AlertView, error) {
+	args := []interface{}{ownerID}
+	shared := "team_id IS NOT NULL"
+	if !tenant.IsAll() {
+		teams := tenant.Teams()
+		if len(teams) == 0 {
+			shared = "FALSE"
+		} else {
+			placeholders := make([]string, len(teams))
+			for i, teamID := range teams {
+				args = append(args, teamID.String())
+				placeholders[i] = fmt.Sprintf("$%d", len(args))
+			}
+			shared = fmt.Sprintf("team_id IN (%s)", strings.Join(placeholders, ","))
+		}
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM alert_views WHERE owner_id = $1 OR %s ORDER BY name`, shared)
+
+	var models []AlertViewModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return alertViewsFromModels(models)
+}
+
+// ListAll returns every view.
+func (r *PostgresAlertViewRepository) ListAll(ctx context.Context) ([]*entity.AlertView, error) {
+	var models []AlertViewModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM alert_views`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return alertViewsFromModels(models)
+}
+
+// alertViewsFromModels converts database models to domain entities.
+func alertViewsFromModels(models []AlertViewModel) ([]*entity.AlertView, error) {
+	views := make([]*entity.AlertView, 0, len(models))
+	for i := range models {
+		view, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
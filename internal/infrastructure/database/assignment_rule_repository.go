@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// Ensure PostgresAssignmentRuleRepository implements repository.AssignmentRuleRepository
+var _ repository.AssignmentRuleRepository = (*PostgresAssignmentRuleRepository)(nil)
+
+// PostgresAssignmentRuleRepository implements AssignmentRuleRepository using PostgreSQL.
+type PostgresAssignmentRuleRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresAssignmentRuleRepository creates a new PostgreSQL assignment rule repository.
+func NewPostgresAssignmentRuleRepository(db *PostgresDB) *PostgresAssignmentRuleRepository {
+	return &PostgresAssignmentRuleRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new rule to the database.
+func (r *PostgresAssignmentRuleRepository) Create(ctx context.Context, rule *entity.AssignmentRule) error {
+	query := `
+		INSERT INTO assignment_rules (id, name, position, match, team_id, user_id, is_enabled, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	match, err := json.Marshal(rule.Match)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Position,
+		match,
+		optionalID(rule.TeamID),
+		optionalID(rule.UserID),
+		rule.IsEnabled,
+		optionalID(rule.CreatedBy),
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a rule by its ID.
+func (r *PostgresAssignmentRuleRepository) GetByID(ctx context.Context, id entity.ID) (*entity.AssignmentRule, error) {
+	var model AssignmentRuleModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM assignment_rules WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing rule.
+func (r *PostgresAssignmentRuleRepository) Update(ctx context.Context, rule *entity.AssignmentRule) error {
+	query := `
+		UPDATE assignment_rules
+		SET name = $2, position = $3, match = $4, team_id = $5, user_id = $6, is_enabled = $7, updated_at = $8
+		WHERE id = $1
+	`
+
+	match, err := json.Marshal(rule.Match)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Position,
+		match,
+		optionalID(rule.TeamID),
+		optionalID(rule.UserID),
+		rule.IsEnabled,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a rule by its ID.
+func (r *PostgresAssignmentRuleRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM assignment_rules WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns every rule, by position then name.
+func (r *PostgresAssignmentRuleRepository) List(ctx context.Context) ([]*entity.AssignmentRule, error) {
+	var models []AssignmentRuleModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM assignment_rules ORDER BY position, name`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	rules := make([]*entity.AssignmentRule, 0, len(models))
+	for i := range models {
+		rule, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -30,6 +30,23 @@ func (c *CacheKey) Alert(id entity.ID) string {
 	return fmt.Sprintf("alert:%s", id.String())
 }
 
+// AlertsActive returns the cache key for the list of active alerts.
+func (c *CacheKey) AlertsActive() string {
+	return "alerts:active"
+}
+
+// AlertStatisticsByFilter returns the cache key for the statistics of the
+// alerts matching a filter, identified by its hash.
+func (c *CacheKey) AlertStatisticsByFilter(filterHash string) string {
+	return fmt.Sprintf("stats:alerts:filter:%s", filterHash)
+}
+
+// AlertStatisticsVersion returns the cache key of the version of the alert
+// statistics of a scope, a team ID or one of the statistics version scopes.
+func (c *CacheKey) AlertStatisticsVersion(scope string) string {
+	return fmt.Sprintf("stats:alerts:version:%s", scope)
+}
+
 // AlertRule returns the cache key for an alert rule by ID.
 func (c *CacheKey) AlertRule(id entity.ID) string {
 	return fmt.Sprintf("rule:%s", id.String())
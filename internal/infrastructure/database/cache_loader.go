@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// cacheLoadTimeout bounds a load shared by several callers, which is not
+// cancelled with the request of the caller that started it.
+const cacheLoadTimeout = 30 * time.Second
+
+// cachedEntry is a cached value with the time it stops being fresh. It is
+// kept past that time so that it can be served while it is refreshed.
+type cachedEntry[T any] struct {
+	Value      T         `json:"value"`
+	FreshUntil time.Time `json:"fresh_until"`
+}
+
+// cacheLoader reads values through the cache with stampede protection: the
+// callers missing the same key at the same time share a single load, and a
+// stale value is served while one caller refreshes it in the background.
+type cacheLoader struct {
+	cache repository.CacheRepository
+	group singleflight.Group
+}
+
+// newCacheLoader creates a new cache loader.
+func newCacheLoader(cache repository.CacheRepository) *cacheLoader {
+	return &cacheLoader{cache: cache}
+}
+
+// loadThrough returns the value of a key, fresh for the fresh TTL and then
+// served stale for up to the stale TTL while it is reloaded. Missing values
+// are loaded and cached before returning.
+func loadThrough[T any](
+	ctx context.Context,
+	l *cacheLoader,
+	key string,
+	fresh, stale time.Duration,
+	load func(ctx context.Context) (T, error),
+) (T, error) {
+	refresh := func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheLoadTimeout)
+		defer cancel()
+
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := cachedEntry[T]{Value: value, FreshUntil: time.Now().Add(fresh)}
+		if cacheErr := l.cache.Set(loadCtx, key, entry, fresh+stale); cacheErr != nil {
+			log.Warn().Err(cacheErr).Str("key", key).Msg("Failed to cache value")
+		}
+		return value, nil
+	}
+
+	var entry cachedEntry[T]
+	if err := l.cache.Get(ctx, key, &entry); err == nil {
+		if time.Now().Before(entry.FreshUntil) {
+			metrics.CacheHitsTotal.Inc()
+			return entry.Value, nil
+		}
+
+		// Stale - serve it and refresh it once in the background. The
+		// result channel is buffered, so it need not be read.
+		metrics.CacheStaleHitsTotal.Inc()
+		l.group.DoChan(key, func() (interface{}, error) {
+			value, err := refresh()
+			if err != nil {
+				log.Warn().Err(err).Str("key", key).Msg("Failed to refresh stale cached value")
+			}
+			return value, err
+		})
+		return entry.Value, nil
+	}
+
+	metrics.CacheMissesTotal.Inc()
+	value, err, _ := l.group.Do(key, refresh)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
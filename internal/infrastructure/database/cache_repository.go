@@ -2,9 +2,12 @@ package database
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -12,6 +15,18 @@ import (
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
 )
 
+// unlockScript deletes a lock only if it still holds the token of its
+// owner, so that a lock that expired and was taken again is not released.
+//
+// KEYS[1]: the lock; ARGV[1]: the token of the owner.
+var unlockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+
+return 0
+`)
+
 // Ensure RedisCacheRepository implements repository.CacheRepository
 var _ repository.CacheRepository = (*RedisCacheRepository)(nil)
 
@@ -92,6 +107,188 @@ func (r *RedisCacheRepository) SetNX(ctx context.Context, key string, value inte
 	return result, nil
 }
 
+// Lock takes a lock on a key for ttl (SET NX PX), storing a random token
+// that only its owner knows.
+func (r *RedisCacheRepository) Lock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	acquired, err := r.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return "", translateRedisError(err)
+	}
+	if !acquired {
+		return "", repository.ErrLockHeld
+	}
+
+	return token, nil
+}
+
+// Unlock releases a lock, only if it still holds the token.
+func (r *RedisCacheRepository) Unlock(ctx context.Context, key, token string) error {
+	deleted, err := unlockScript.Run(ctx, r.client, []string{key}, token).Int()
+	if err != nil {
+		return translateRedisError(err)
+	}
+	if deleted == 0 {
+		return repository.ErrLockNotHeld
+	}
+
+	return nil
+}
+
+// TryWithLock runs fn while holding the lock on a key.
+// The lock is released even if the context of fn was cancelled.
+func (r *RedisCacheRepository) TryWithLock(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) error) (bool, error) {
+	token, err := r.Lock(ctx, key, ttl)
+	if errors.Is(err, repository.ErrLockHeld) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	runErr := fn(ctx)
+
+	if err := r.Unlock(context.WithoutCancel(ctx), key, token); err != nil {
+		if runErr != nil {
+			return true, runErr
+		}
+		return true, fmt.Errorf("failed to release lock: %w", err)
+	}
+
+	return true, runErr
+}
+
+// ListAppend adds a value at the tail of a list (RPUSH), trims it to the
+// newest maxLen entries and renews its TTL, in a single transaction.
+func (r *RedisCacheRepository) ListAppend(ctx context.Context, key string, value interface{}, maxLen int64, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, key, data)
+		pipe.LTrim(ctx, key, -maxLen, -1)
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return translateRedisError(err)
+	}
+
+	return nil
+}
+
+// ListPrepend puts values back at the head of a list (LPUSH in reverse
+// order), trims it to the newest maxLen entries and renews its TTL, in a
+// single transaction.
+func (r *RedisCacheRepository) ListPrepend(ctx context.Context, key string, values []interface{}, maxLen int64, ttl time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	reversed := make([]interface{}, len(values))
+	for i, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal value: %w", err)
+		}
+		reversed[len(values)-1-i] = data
+	}
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, reversed...)
+		pipe.LTrim(ctx, key, -maxLen, -1)
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return translateRedisError(err)
+	}
+
+	return nil
+}
+
+// ListRange reads every entry of a list (LRANGE).
+func (r *RedisCacheRepository) ListRange(ctx context.Context, key string, dest interface{}) error {
+	entries, err := r.client.LRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return translateRedisError(err)
+	}
+
+	return decodeList(entries, dest)
+}
+
+// ListTake reads and deletes a list (LRANGE and DEL) in a single
+// transaction, so that entries appended meanwhile are not lost.
+func (r *RedisCacheRepository) ListTake(ctx context.Context, key string, dest interface{}) error {
+	var entries *redis.StringSliceCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		entries = pipe.LRange(ctx, key, 0, -1)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return translateRedisError(err)
+	}
+
+	return decodeList(entries.Val(), dest)
+}
+
+// AddToSet adds a member to a set (SADD) and renews its TTL, in a single
+// transaction.
+func (r *RedisCacheRepository) AddToSet(ctx context.Context, key, member string, ttl time.Duration) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, key, member)
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return translateRedisError(err)
+	}
+
+	return nil
+}
+
+// SetMembers returns the members of a set (SMEMBERS).
+func (r *RedisCacheRepository) SetMembers(ctx context.Context, key string) ([]string, error) {
+	members, err := r.client.SMembers(ctx, key).Result()
+	if err != nil {
+		return nil, translateRedisError(err)
+	}
+
+	return members, nil
+}
+
+// RemoveFromSet removes members from a set (SREM).
+func (r *RedisCacheRepository) RemoveFromSet(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, len(members))
+	for i, member := range members {
+		values[i] = member
+	}
+
+	if err := r.client.SRem(ctx, key, values...).Err(); err != nil {
+		return translateRedisError(err)
+	}
+
+	return nil
+}
+
 // Increment increments a counter.
 // If the key doesn't exist, it creates it with value 1.
 func (r *RedisCacheRepository) Increment(ctx context.Context, key string) (int64, error) {
@@ -190,6 +387,16 @@ func (r *RedisCacheRepository) Close() error {
 	return r.client.Close()
 }
 
+// decodeList decodes the JSON entries of a list into dest, a pointer to a slice.
+func decodeList(entries []string, dest interface{}) error {
+	data := "[" + strings.Join(entries, ",") + "]"
+	if err := json.Unmarshal([]byte(data), dest); err != nil {
+		return fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+
+	return nil
+}
+
 // translateRedisError converts Redis errors to domain errors.
 func translateRedisError(err error) error {
 	if err == nil {
@@ -0,0 +1,313 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresCheckRepository implements repository.CheckRepository
+var _ repository.CheckRepository = (*PostgresCheckRepository)(nil)
+
+// PostgresCheckRepository implements CheckRepository using PostgreSQL.
+type PostgresCheckRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresCheckRepository creates a new PostgreSQL check repository.
+func NewPostgresCheckRepository(db *PostgresDB) *PostgresCheckRepository {
+	return &PostgresCheckRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new check to the database.
+func (r *PostgresCheckRepository) Create(ctx context.Context, check *entity.Check) error {
+	query := `
+		INSERT INTO checks (
+			id, name, url, method, expected_status, interval_seconds, timeout_seconds, failure_threshold,
+			severity, status, consecutive_failures, last_checked_at, is_enabled, team_id, created_by,
+			created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		check.ID,
+		check.Name,
+		check.URL,
+		check.Method,
+		check.ExpectedStatus,
+		check.IntervalSeconds,
+		check.TimeoutSeconds,
+		check.FailureThreshold,
+		string(check.Severity),
+		string(check.Status),
+		check.ConsecutiveFailures,
+		check.LastCheckedAt,
+		check.IsEnabled,
+		optionalID(check.TeamID),
+		optionalID(check.CreatedBy),
+		check.CreatedAt,
+		check.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a check by its ID.
+func (r *PostgresCheckRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Check, error) {
+	var model CheckModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM checks WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing check.
+func (r *PostgresCheckRepository) Update(ctx context.Context, check *entity.Check) error {
+	query := `
+		UPDATE checks
+		SET name = $2, url = $3, method = $4, expected_status = $5, interval_seconds = $6,
+			timeout_seconds = $7, failure_threshold = $8, severity = $9, status = $10,
+			consecutive_failures = $11, last_checked_at = $12, is_enabled = $13, team_id = $14,
+			updated_at = $15
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		check.ID,
+		check.Name,
+		check.URL,
+		check.Method,
+		check.ExpectedStatus,
+		check.IntervalSeconds,
+		check.TimeoutSeconds,
+		check.FailureThreshold,
+		string(check.Severity),
+		string(check.Status),
+		check.ConsecutiveFailures,
+		check.LastCheckedAt,
+		check.IsEnabled,
+		optionalID(check.TeamID),
+		check.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateStatus saves the probe state of a check without overwriting
+// settings changed meanwhile.
+func (r *PostgresCheckRepository) UpdateStatus(ctx context.Context, check *entity.Check) error {
+	query := `
+		UPDATE checks
+		SET status = $2, consecutive_failures = $3, last_checked_at = $4, updated_at = $5
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		check.ID,
+		string(check.Status),
+		check.ConsecutiveFailures,
+		check.LastCheckedAt,
+		check.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a check by its ID; its results are removed by cascade.
+func (r *PostgresCheckRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM checks WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated checks of the tenant scope: those of its teams and
+// those owned by no team.
+func (r *PostgresCheckRepository) List(
+	ctx context.Context,
+	tenant valueobject.TenantScope,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Check], error) {
+	var conditions []string
+	var args []interface{}
+	if !tenant.IsAll() {
+		teams := tenant.Teams()
+		if len(teams) == 0 {
+			conditions = append(conditions, "team_id IS NULL")
+		} else {
+			placeholders := make([]string, len(teams))
+			for i, teamID := range teams {
+				args = append(args, teamID.String())
+				placeholders[i] = fmt.Sprintf("$%d", len(args))
+			}
+			conditions = append(conditions, fmt.Sprintf("(team_id IS NULL OR team_id IN (%s))", strings.Join(placeholders, ",")))
+		}
+	}
+	where := whereClause(conditions)
+
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM checks"+where, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM checks%s ORDER BY name LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+	args = append(args, pagination.Limit(), pagination.Offset())
+
+	var models []CheckModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	checks, err := checksFromModels(models)
+	if err != nil {
+		return nil, err
+	}
+
+	result := valueobject.NewPaginatedResult(checks, total, pagination)
+	return &result, nil
+}
+
+// ListDue returns the enabled checks due for a probe at now.
+func (r *PostgresCheckRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.Check, error) {
+	query := `
+		SELECT * FROM checks
+		WHERE is_enabled
+			AND (last_checked_at IS NULL OR last_checked_at + make_interval(secs => interval_seconds) <= $1)
+		ORDER BY last_checked_at NULLS FIRST
+		LIMIT $2
+	`
+
+	var models []CheckModel
+	if err := r.db.SelectContext(ctx, &models, query, now, limit); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return checksFromModels(models)
+}
+
+// AddResult saves the result of a probe.
+func (r *PostgresCheckRepository) AddResult(ctx context.Context, result *entity.CheckResult) error {
+	query := `
+		INSERT INTO check_results (id, check_id, success, status_code, latency_ms, error, checked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	var statusCode *int
+	if result.StatusCode != 0 {
+		statusCode = &result.StatusCode
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		result.ID,
+		result.CheckID,
+		result.Success,
+		statusCode,
+		result.LatencyMs,
+		optionalString(result.Error),
+		result.CheckedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// ListResults returns the probe results of a check, newest first.
+func (r *PostgresCheckRepository) ListResults(
+	ctx context.Context,
+	checkID entity.ID,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.CheckResult], error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM check_results WHERE check_id = $1`, checkID); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT * FROM check_results
+		WHERE check_id = $1
+		ORDER BY checked_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var models []CheckResultModel
+	if err := r.db.SelectContext(ctx, &models, query, checkID, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	results := make([]*entity.CheckResult, 0, len(models))
+	for i := range models {
+		result, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	paginated := valueobject.NewPaginatedResult(results, total, pagination)
+	return &paginated, nil
+}
+
+// DeleteResultsBefore removes the probe results older than before.
+func (r *PostgresCheckRepository) DeleteResultsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM check_results WHERE checked_at < $1`, before)
+	if err != nil {
+		return 0, TranslateError(err)
+	}
+
+	return result.RowsAffected()
+}
+
+// checksFromModels converts database models to domain entities.
+func checksFromModels(models []CheckModel) ([]*entity.Check, error) {
+	checks := make([]*entity.Check, 0, len(models))
+	for i := range models {
+		check, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
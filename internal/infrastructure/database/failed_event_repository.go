@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresFailedEventRepository implements repository.FailedEventRepository
+var _ repository.FailedEventRepository = (*PostgresFailedEventRepository)(nil)
+
+// PostgresFailedEventRepository implements FailedEventRepository using PostgreSQL.
+type PostgresFailedEventRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresFailedEventRepository creates a new PostgreSQL failed event repository.
+func NewPostgresFailedEventRepository(db *PostgresDB) *PostgresFailedEventRepository {
+	return &PostgresFailedEventRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Save stores a failed event, replacing the previous record of the same event.
+func (r *PostgresFailedEventRepository) Save(ctx context.Context, event *entity.FailedEvent) error {
+	query := `
+		INSERT INTO failed_events (id, event_id, event_type, payload, retries, last_error, status, failed_at, processed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (event_id) DO UPDATE
+		SET event_type = EXCLUDED.event_type,
+			payload = EXCLUDED.payload,
+			retries = EXCLUDED.retries,
+			last_error = EXCLUDED.last_error,
+			status = EXCLUDED.status,
+			failed_at = EXCLUDED.failed_at,
+			processed_at = EXCLUDED.processed_at
+		RETURNING id
+	`
+
+	payload := []byte(event.Payload)
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+
+	err := r.db.GetContext(ctx, &event.ID, query,
+		event.ID,
+		event.EventID,
+		event.EventType,
+		payload,
+		event.Retries,
+		event.LastError,
+		string(event.Status),
+		event.FailedAt,
+		event.ProcessedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByEventID finds the record of an event.
+func (r *PostgresFailedEventRepository) GetByEventID(ctx context.Context, eventID string) (*entity.FailedEvent, error) {
+	var model FailedEventModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM failed_events WHERE event_id = $1`, eventID); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates the status of an existing record.
+func (r *PostgresFailedEventRepository) Update(ctx context.Context, event *entity.FailedEvent) error {
+	query := `
+		UPDATE failed_events
+		SET status = $2, processed_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, event.ID, string(event.Status), event.ProcessedAt)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated records matching the filter, most recent first.
+func (r *PostgresFailedEventRepository) List(
+	ctx context.Context,
+	filter valueobject.FailedEventFilter,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.FailedEvent], error) {
+	where, args := r.buildWhereClause(filter)
+
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM failed_events"+where, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM failed_events %s
+		ORDER BY failed_at DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+
+	args = append(args, pagination.PageSize(), pagination.Offset())
+
+	var models []FailedEventModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	events := make([]*entity.FailedEvent, 0, len(models))
+	for i := range models {
+		event, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	result := valueobject.NewPaginatedResult(events, total, pagination)
+	return &result, nil
+}
+
+// DeleteByFilter removes every record matching the filter.
+func (r *PostgresFailedEventRepository) DeleteByFilter(ctx context.Context, filter valueobject.FailedEventFilter) (int64, error) {
+	where, args := r.buildWhereClause(filter)
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM failed_events"+where, args...)
+	if err != nil {
+		return 0, TranslateError(err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, TranslateError(err)
+	}
+
+	return deleted, nil
+}
+
+// buildWhereClause constructs the WHERE clause of a filter.
+func (r *PostgresFailedEventRepository) buildWhereClause(filter valueobject.FailedEventFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if len(filter.EventIDs) > 0 {
+		placeholders := make([]string, len(filter.EventIDs))
+		for i, id := range filter.EventIDs {
+			placeholders[i] = fmt.Sprintf("$%d", argIndex)
+			args = append(args, id)
+			argIndex++
+		}
+		conditions = append(conditions, fmt.Sprintf("event_id IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	if filter.EventType != nil {
+		conditions = append(conditions, fmt.Sprintf("event_type = $%d", argIndex))
+		args = append(args, *filter.EventType)
+		argIndex++
+	}
+
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			placeholders[i] = fmt.Sprintf("$%d", argIndex)
+			args = append(args, string(status))
+			argIndex++
+		}
+		conditions = append(conditions, fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	if filter.FromDate != nil {
+		conditions = append(conditions, fmt.Sprintf("failed_at >= $%d", argIndex))
+		args = append(args, filter.FromDate)
+		argIndex++
+	}
+
+	if filter.ToDate != nil {
+		conditions = append(conditions, fmt.Sprintf("failed_at <= $%d", argIndex))
+		args = append(args, filter.ToDate)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
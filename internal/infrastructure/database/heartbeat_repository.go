@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresHeartbeatRepository implements repository.HeartbeatRepository
+var _ repository.HeartbeatRepository = (*PostgresHeartbeatRepository)(nil)
+
+// PostgresHeartbeatRepository implements HeartbeatRepository using PostgreSQL.
+type PostgresHeartbeatRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresHeartbeatRepository creates a new PostgreSQL heartbeat repository.
+func NewPostgresHeartbeatRepository(db *PostgresDB) *PostgresHeartbeatRepository {
+	return &PostgresHeartbeatRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new heartbeat to the database.
+func (r *PostgresHeartbeatRepository) Create(ctx context.Context, heartbeat *entity.Heartbeat) error {
+	query := `
+		INSERT INTO heartbeats (
+			id, name, token_hash, interval_seconds, grace_seconds, severity, status, last_ping_at,
+			is_enabled, team_id, created_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		heartbeat.ID,
+		heartbeat.Name,
+		heartbeat.TokenHash,
+		heartbeat.IntervalSeconds,
+		heartbeat.GraceSeconds,
+		string(heartbeat.Severity),
+		string(heartbeat.Status),
+		heartbeat.LastPingAt,
+		heartbeat.IsEnabled,
+		optionalID(heartbeat.TeamID),
+		optionalID(heartbeat.CreatedBy),
+		heartbeat.CreatedAt,
+		heartbeat.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a heartbeat by its ID.
+func (r *PostgresHeartbeatRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Heartbeat, error) {
+	return r.getOne(ctx, `SELECT * FROM heartbeats WHERE id = $1`, id)
+}
+
+// GetByTokenHash finds a heartbeat by the hash of its ping token.
+func (r *PostgresHeartbeatRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Heartbeat, error) {
+	return r.getOne(ctx, `SELECT * FROM heartbeats WHERE token_hash = $1`, tokenHash)
+}
+
+// Update updates an existing heartbeat.
+func (r *PostgresHeartbeatRepository) Update(ctx context.Context, heartbeat *entity.Heartbeat) error {
+	query := `
+		UPDATE heartbeats
+		SET name = $2, token_hash = $3, interval_seconds = $4, grace_seconds = $5, severity = $6,
+			status = $7, last_ping_at = $8, is_enabled = $9, team_id = $10, updated_at = $11
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		heartbeat.ID,
+		heartbeat.Name,
+		heartbeat.TokenHash,
+		heartbeat.IntervalSeconds,
+		heartbeat.GraceSeconds,
+		string(heartbeat.Severity),
+		string(heartbeat.Status),
+		heartbeat.LastPingAt,
+		heartbeat.IsEnabled,
+		optionalID(heartbeat.TeamID),
+		heartbeat.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a heartbeat by its ID.
+func (r *PostgresHeartbeatRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM heartbeats WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated heartbeats.
+func (r *PostgresHeartbeatRepository) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Heartbeat], error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM heartbeats`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT * FROM heartbeats
+		ORDER BY name
+		LIMIT $1 OFFSET $2
+	`
+
+	var models []HeartbeatModel
+	if err := r.db.SelectContext(ctx, &models, query, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	heartbeats, err := heartbeatsFromModels(models)
+	if err != nil {
+		return nil, err
+	}
+
+	result := valueobject.NewPaginatedResult(heartbeats, total, pagination)
+	return &result, nil
+}
+
+// ListOverdue returns the running heartbeats whose deadline passed.
+func (r *PostgresHeartbeatRepository) ListOverdue(ctx context.Context, now time.Time) ([]*entity.Heartbeat, error) {
+	query := `
+		SELECT * FROM heartbeats
+		WHERE status = 'up' AND is_enabled
+			AND last_ping_at + make_interval(secs => interval_seconds + grace_seconds) < $1
+		ORDER BY last_ping_at
+	`
+
+	var models []HeartbeatModel
+	if err := r.db.SelectContext(ctx, &models, query, now); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return heartbeatsFromModels(models)
+}
+
+// getOne runs a query returning a single heartbeat.
+func (r *PostgresHeartbeatRepository) getOne(ctx context.Context, query string, arg interface{}) (*entity.Heartbeat, error) {
+	var model HeartbeatModel
+	if err := r.db.GetContext(ctx, &model, query, arg); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// heartbeatsFromModels converts database models to domain entities.
+func heartbeatsFromModels(models []HeartbeatModel) ([]*entity.Heartbeat, error) {
+	heartbeats := make([]*entity.Heartbeat, 0, len(models))
+	for i := range models {
+		heartbeat, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		heartbeats = append(heartbeats, heartbeat)
+	}
+	return heartbeats, nil
+}
@@ -0,0 +1,300 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresIncidentRepository implements repository.IncidentRepository
+var _ repository.IncidentRepository = (*PostgresIncidentRepository)(nil)
+
+// PostgresIncidentRepository implements IncidentRepository using PostgreSQL.
+type PostgresIncidentRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresIncidentRepository creates a new PostgreSQL incident repository.
+func NewPostgresIncidentRepository(db *PostgresDB) *PostgresIncidentRepository {
+	return &PostgresIncidentRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new incident to the database.
+func (r *PostgresIncidentRepository) Create(ctx context.Context, incident *entity.Incident) error {
+	query := `
+		INSERT INTO incidents (
+			id, title, summary, severity, status, commander_id, team_id, correlation_key, created_by,
+			mitigated_at, closed_at, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		incident.ID,
+		incident.Title,
+		optionalString(incident.Summary),
+		string(incident.Severity),
+		string(incident.Status),
+		optionalID(incident.CommanderID),
+		optionalID(incident.TeamID),
+		optionalString(incident.CorrelationKey),
+		optionalID(incident.CreatedBy),
+		incident.MitigatedAt,
+		incident.ClosedAt,
+		incident.CreatedAt,
+		incident.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds an incident by its ID.
+func (r *PostgresIncidentRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Incident, error) {
+	var model IncidentModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM incidents WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// GetActiveByCorrelationKey finds the incident of a correlation key that is not closed.
+func (r *PostgresIncidentRepository) GetActiveByCorrelationKey(ctx context.Context, key string) (*entity.Incident, error) {
+	query := `SELECT * FROM incidents WHERE correlation_key = $1 AND status <> 'closed'`
+
+	var model IncidentModel
+	if err := r.db.GetContext(ctx, &model, query, key); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// GetByAlert finds the incident grouping an alert.
+func (r *PostgresIncidentRepository) GetByAlert(ctx context.Context, alertID entity.ID) (*entity.Incident, error) {
+	query := `
+		SELECT i.* FROM incidents i
+		JOIN incident_alerts ia ON ia.incident_id = i.id
+		WHERE ia.alert_id = $1
+	`
+
+	var model IncidentModel
+	if err := r.db.GetContext(ctx, &model, query, alertID); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing incident.
+func (r *PostgresIncidentRepository) Update(ctx context.Context, incident *entity.Incident) error {
+	query := `
+		UPDATE incidents
+		SET title = $2, summary = $3, severity = $4, status = $5, commander_id = $6,
+			mitigated_at = $7, closed_at = $8, updated_at = $9
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		incident.ID,
+		incident.Title,
+		optionalString(incident.Summary),
+		string(incident.Severity),
+		string(incident.Status),
+		optionalID(incident.CommanderID),
+		incident.MitigatedAt,
+		incident.ClosedAt,
+		incident.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated incidents matching the filter, newest first.
+func (r *PostgresIncidentRepository) List(
+	ctx context.Context,
+	filter repository.IncidentFilter,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Incident], error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Status != nil {
+		args = append(args, string(*filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	// Tenant restrictions keep shared incidents and those of the scope's teams
+	if filter.Tenant != nil {
+		teams := filter.Tenant.Teams()
+		if len(teams) == 0 {
+			conditions = append(conditions, "team_id IS NULL")
+		} else {
+			placeholders := make([]string, len(teams))
+			for i, teamID := range teams {
+				args = append(args, teamID.String())
+				placeholders[i] = fmt.Sprintf("$%d", len(args))
+			}
+			conditions = append(conditions, fmt.Sprintf("(team_id IS NULL OR team_id IN (%s))", strings.Join(placeholders, ",")))
+		}
+	}
+
+	where := whereClause(conditions)
+
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM incidents"+where, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM incidents%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+
+	var models []IncidentModel
+	if err := r.db.SelectContext(ctx, &models, query, append(args, pagination.Limit(), pagination.Offset())...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	incidents := make([]*entity.Incident, 0, len(models))
+	for i := range models {
+		incident, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		incidents = append(incidents, incident)
+	}
+
+	result := valueobject.NewPaginatedResult(incidents, total, pagination)
+	return &result, nil
+}
+
+// AddAlert adds an alert to an incident.
+func (r *PostgresIncidentRepository) AddAlert(ctx context.Context, incidentID, alertID entity.ID, addedBy *entity.ID) error {
+	query := `
+		INSERT INTO incident_alerts (incident_id, alert_id, added_by, added_at)
+		VALUES ($1, $2, $3, NOW())
+	`
+
+	_, err := r.db.ExecContext(ctx, query, incidentID, alertID, optionalID(addedBy))
+	return TranslateError(err)
+}
+
+// RemoveAlert removes an alert from an incident.
+func (r *PostgresIncidentRepository) RemoveAlert(ctx context.Context, incidentID, alertID entity.ID) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM incident_alerts WHERE incident_id = $1 AND alert_id = $2`, incidentID, alertID)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListAlerts returns the alerts of an incident that are not in the trash,
+// most recent first.
+func (r *PostgresIncidentRepository) ListAlerts(ctx context.Context, incidentID entity.ID) ([]*entity.Alert, error) {
+	query := `
+		SELECT a.* FROM alerts a
+		JOIN incident_alerts ia ON ia.alert_id = a.id
+		WHERE ia.incident_id = $1 AND a.deleted_at IS NULL
+		ORDER BY a.created_at DESC
+	`
+
+	var models []AlertModel
+	if err := r.db.SelectContext(ctx, &models, query, incidentID); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	alerts := make([]*entity.Alert, 0, len(models))
+	for i := range models {
+		alert, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// AddEvent saves an entry of the timeline of an incident.
+func (r *PostgresIncidentRepository) AddEvent(ctx context.Context, event *entity.IncidentEvent) error {
+	query := `
+		INSERT INTO incident_events (id, incident_id, kind, message, actor_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		event.IncidentID,
+		string(event.Kind),
+		event.Message,
+		optionalID(event.ActorID),
+		event.CreatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// ListEvents returns the timeline of an incident, oldest first.
+func (r *PostgresIncidentRepository) ListEvents(
+	ctx context.Context,
+	incidentID entity.ID,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.IncidentEvent], error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total,
+		`SELECT COUNT(*) FROM incident_events WHERE incident_id = $1`, incidentID); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT * FROM incident_events
+		WHERE incident_id = $1
+		ORDER BY created_at, id
+		LIMIT $2 OFFSET $3
+	`
+
+	var models []IncidentEventModel
+	if err := r.db.SelectContext(ctx, &models, query, incidentID, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	events := make([]*entity.IncidentEvent, 0, len(models))
+	for i := range models {
+		event, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	result := valueobject.NewPaginatedResult(events, total, pagination)
+	return &result, nil
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+)
+
+// queryTablePattern finds the table a statement reads or writes first.
+var queryTablePattern = regexp.MustCompile(`(?i)\b(?:from|into|update|table)\s+([a-z_][a-z0-9_.]*)`)
+
+// InstrumentedDB wraps sqlx.DB so that every repository query is timed in
+// the db_query_duration_seconds metric, traced in a span and logged when
+// slower than the threshold. Queries run in the transaction of their context,
+// if any; see WithinTransaction.
+type InstrumentedDB struct {
+	*sqlx.DB
+	observer queryObserver
+}
+
+// NewInstrumentedDB wraps a database connection. A zero slowQueryThreshold
+// disables slow query logging.
+func NewInstrumentedDB(db *sqlx.DB, slowQueryThreshold time.Duration) *InstrumentedDB {
+	return &InstrumentedDB{
+		DB:       db,
+		observer: queryObserver{slowQueryThreshold: slowQueryThreshold},
+	}
+}
+
+// ExecContext executes a query without returning any rows.
+func (db *InstrumentedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, done := db.observer.start(ctx, query)
+	result, err := db.executor(ctx).ExecContext(ctx, query, args...)
+	done(err)
+	return result, err
+}
+
+// GetContext scans a single row into dest.
+func (db *InstrumentedDB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, done := db.observer.start(ctx, query)
+	err := db.executor(ctx).GetContext(ctx, dest, query, args...)
+	done(err)
+	return err
+}
+
+// SelectContext scans all rows into dest.
+func (db *InstrumentedDB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	ctx, done := db.observer.start(ctx, query)
+	err := db.executor(ctx).SelectContext(ctx, dest, query, args...)
+	done(err)
+	return err
+}
+
+// QueryContext executes a query returning rows. Only the time until the
+// first rows are available is measured, not their iteration.
+func (db *InstrumentedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, done := db.observer.start(ctx, query)
+	rows, err := db.executor(ctx).QueryContext(ctx, query, args...)
+	done(err)
+	return rows, err
+}
+
+// queryObserver records the duration, span and slow query log of queries.
+type queryObserver struct {
+	slowQueryThreshold time.Duration
+}
+
+// start begins observing a query. The returned function ends the
+// observation with the query's error.
+func (o queryObserver) start(ctx context.Context, query string) (context.Context, func(error)) {
+	operation, table := queryLabels(query)
+	started := time.Now()
+
+	ctx, span := tracing.StartSpan(ctx, "db."+operation+" "+table,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", operation),
+			attribute.String("db.sql.table", table),
+			attribute.String("db.statement", compactQuery(query)),
+		),
+	)
+
+	return ctx, func(err error) {
+		duration := time.Since(started)
+		metrics.DBQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
+
+		// A missing row is a result, not a failure
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		if o.slowQueryThreshold > 0 && duration >= o.slowQueryThreshold {
+			log.Warn().
+				Str("operation", operation).
+				Str("table", table).
+				Dur("duration", duration).
+				Str("query", compactQuery(query)).
+				Msg("Slow database query")
+		}
+	}
+}
+
+// queryLabels returns the operation of a statement, e.g. "select", and the
+// first table it names, or "unknown" when none is found.
+func queryLabels(query string) (operation, table string) {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "unknown", "unknown"
+	}
+	operation = strings.ToLower(fields[0])
+
+	table = "unknown"
+	if match := queryTablePattern.FindStringSubmatch(query); match != nil {
+		table = strings.ToLower(match[1])
+	}
+	return operation, table
+}
+
+// compactQuery collapses the whitespace of a query so that it logs on one line.
+func compactQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
@@ -0,0 +1,348 @@
+package database
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// localInvalidationTimeout bounds the time a write waits to tell the other
+// instances about the keys it changed.
+const localInvalidationTimeout = 2 * time.Second
+
+// CacheRelay carries cache invalidations between the instances of the API.
+// A relay must not deliver an instance's own payloads back to it.
+type CacheRelay interface {
+	// Publish sends a payload to the other instances.
+	Publish(ctx context.Context, payload []byte) error
+
+	// Subscribe calls handler with the payloads published by other instances.
+	Subscribe(ctx context.Context, handler func(payload []byte)) error
+}
+
+// localInvalidation is the payload relayed when keys change. All drops
+// every entry, for writes by pattern.
+type localInvalidation struct {
+	Keys []string `json:"keys,omitempty"`
+	All  bool     `json:"all,omitempty"`
+}
+
+// localEntry is a cached value, or the fact that a key does not exist.
+type localEntry struct {
+	key       string
+	data      []byte
+	exists    bool
+	expiresAt time.Time
+}
+
+// LocalCache is an in-process LRU cache whose entries expire after a short
+// TTL. It saves the Redis round-trip of keys read on every request; entries
+// changed by another instance are dropped when it relays the invalidation,
+// and the TTL bounds how long a lost invalidation leaves them stale.
+type LocalCache struct {
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	order   *list.List // most recently used first
+	relay   CacheRelay
+	mu      sync.Mutex
+}
+
+// NewLocalCache creates a local cache holding up to size entries for ttl.
+func NewLocalCache(size int, ttl time.Duration) *LocalCache {
+	return &LocalCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// SetRelay relays the invalidations of this cache to the other instances
+// and applies theirs.
+func (c *LocalCache) SetRelay(ctx context.Context, relay CacheRelay) error {
+	if err := relay.Subscribe(ctx, c.applyInvalidation); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.relay = relay
+	c.mu.Unlock()
+	return nil
+}
+
+// get returns the entry of a key, unless it expired.
+func (c *LocalCache) get(key string) (*localEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		metrics.LocalCacheRequestsTotal.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+
+	entry := elem.Value.(*localEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(elem)
+		metrics.LocalCacheRequestsTotal.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	metrics.LocalCacheRequestsTotal.WithLabelValues("hit").Inc()
+	return entry, true
+}
+
+// put stores the entry of a key, evicting the least recently used entry
+// when the cache is full.
+func (c *LocalCache) put(key string, data []byte, exists bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &localEntry{key: key, data: data, exists: exists, expiresAt: time.Now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+		metrics.LocalCacheEvictionsTotal.Inc()
+	}
+}
+
+// Invalidate drops keys from this cache and from the caches of the other
+// instances.
+func (c *LocalCache) Invalidate(ctx context.Context, keys ...string) {
+	c.drop(keys)
+	c.publish(ctx, localInvalidation{Keys: keys})
+}
+
+// Purge drops every entry from this cache and from the caches of the other
+// instances.
+func (c *LocalCache) Purge(ctx context.Context) {
+	c.dropAll()
+	c.publish(ctx, localInvalidation{All: true})
+}
+
+// publish relays an invalidation, if a relay is set. Failures are logged:
+// the other instances then serve the keys until they expire.
+func (c *LocalCache) publish(ctx context.Context, invalidation localInvalidation) {
+	c.mu.Lock()
+	relay := c.relay
+	c.mu.Unlock()
+	if relay == nil {
+		return
+	}
+
+	payload, err := json.Marshal(invalidation)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal cache invalidation")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), localInvalidationTimeout)
+	defer cancel()
+	if err := relay.Publish(ctx, payload); err != nil {
+		log.Warn().Err(err).Int("keys", len(invalidation.Keys)).Msg("Failed to relay cache invalidation")
+	}
+}
+
+// applyInvalidation drops the keys relayed by another instance.
+func (c *LocalCache) applyInvalidation(payload []byte) {
+	var invalidation localInvalidation
+	if err := json.Unmarshal(payload, &invalidation); err != nil {
+		log.Warn().Err(err).Msg("Failed to parse relayed cache invalidation")
+		return
+	}
+
+	if invalidation.All {
+		c.dropAll()
+		return
+	}
+	c.drop(invalidation.Keys)
+}
+
+// drop removes keys from this cache only.
+func (c *LocalCache) drop(keys []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.removeElement(elem)
+		}
+	}
+}
+
+// dropAll removes every entry from this cache only.
+func (c *LocalCache) dropAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element, c.size)
+	c.order.Init()
+}
+
+// removeElement removes an entry. The caller must hold the lock.
+func (c *LocalCache) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*localEntry).key)
+}
+
+// Ensure TieredCacheRepository implements repository.CacheRepository
+var _ repository.CacheRepository = (*TieredCacheRepository)(nil)
+
+// TieredCacheRepository serves the keys with one of its prefixes from a
+// local cache in front of another cache repository, usually Redis. Writes
+// to those keys go to the other repository and invalidate them everywhere.
+// Other keys and operations go to the other repository unchanged.
+type TieredCacheRepository struct {
+	repository.CacheRepository
+	local    *LocalCache
+	prefixes []string
+}
+
+// NewTieredCacheRepository creates a cache repository serving the keys with
+// one of prefixes from local before remote.
+func NewTieredCacheRepository(remote repository.CacheRepository, local *LocalCache, prefixes []string) *TieredCacheRepository {
+	return &TieredCacheRepository{
+		CacheRepository: remote,
+		local:           local,
+		prefixes:        prefixes,
+	}
+}
+
+// isLocal reports whether a key is served from the local cache.
+func (r *TieredCacheRepository) isLocal(key string) bool {
+	for _, prefix := range r.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get retrieves a value by its key, from the local cache when it holds it.
+func (r *TieredCacheRepository) Get(ctx context.Context, key string, dest interface{}) error {
+	if !r.isLocal(key) {
+		return r.CacheRepository.Get(ctx, key, dest)
+	}
+
+	if entry, ok := r.local.get(key); ok && (entry.data != nil || !entry.exists) {
+		if !entry.exists {
+			return repository.ErrNotFound
+		}
+		if err := json.Unmarshal(entry.data, dest); err != nil {
+			return fmt.Errorf("failed to unmarshal value: %w", err)
+		}
+		return nil
+	}
+
+	err := r.CacheRepository.Get(ctx, key, dest)
+	if errors.Is(err, repository.ErrNotFound) {
+		r.local.put(key, nil, false)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if data, err := json.Marshal(dest); err == nil {
+		r.local.put(key, data, true)
+	}
+	return nil
+}
+
+// Exists checks if a key exists, from the local cache when it knows.
+func (r *TieredCacheRepository) Exists(ctx context.Context, key string) (bool, error) {
+	if !r.isLocal(key) {
+		return r.CacheRepository.Exists(ctx, key)
+	}
+
+	if entry, ok := r.local.get(key); ok {
+		return entry.exists, nil
+	}
+
+	exists, err := r.CacheRepository.Exists(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	r.local.put(key, nil, exists)
+	return exists, nil
+}
+
+// Set stores a value with optional TTL and invalidates the key.
+func (r *TieredCacheRepository) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	err := r.CacheRepository.Set(ctx, key, value, ttl)
+	r.invalidate(ctx, key)
+	return err
+}
+
+// Delete removes a key and invalidates it.
+func (r *TieredCacheRepository) Delete(ctx context.Context, key string) error {
+	err := r.CacheRepository.Delete(ctx, key)
+	r.invalidate(ctx, key)
+	return err
+}
+
+// SetNX stores only if the key doesn't exist and invalidates it when stored.
+func (r *TieredCacheRepository) SetNX(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error) {
+	stored, err := r.CacheRepository.SetNX(ctx, key, value, ttl)
+	if stored {
+		r.invalidate(ctx, key)
+	}
+	return stored, err
+}
+
+// Increment increments a counter and invalidates it.
+func (r *TieredCacheRepository) Increment(ctx context.Context, key string) (int64, error) {
+	value, err := r.CacheRepository.Increment(ctx, key)
+	r.invalidate(ctx, key)
+	return value, err
+}
+
+// Decrement decrements a counter and invalidates it.
+func (r *TieredCacheRepository) Decrement(ctx context.Context, key string) (int64, error) {
+	value, err := r.CacheRepository.Decrement(ctx, key)
+	r.invalidate(ctx, key)
+	return value, err
+}
+
+// Expire sets TTL on an existing key and invalidates it, as a zero TTL
+// deletes it.
+func (r *TieredCacheRepository) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	err := r.CacheRepository.Expire(ctx, key, ttl)
+	r.invalidate(ctx, key)
+	return err
+}
+
+// DeleteByPattern deletes all keys matching a pattern. The local cache is
+// purged, as it does not match patterns.
+func (r *TieredCacheRepository) DeleteByPattern(ctx context.Context, pattern string) error {
+	err := r.CacheRepository.DeleteByPattern(ctx, pattern)
+	if len(r.prefixes) > 0 {
+		r.local.Purge(ctx)
+	}
+	return err
+}
+
+// invalidate drops a key from the local caches if it is served from them.
+func (r *TieredCacheRepository) invalidate(ctx context.Context, key string) {
+	if r.isLocal(key) {
+		r.local.Invalidate(ctx, key)
+	}
+}
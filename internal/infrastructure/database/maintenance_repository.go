@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// Ensure PostgresMaintenanceRepository implements repository.MaintenanceRepository
+var _ repository.MaintenanceRepository = (*PostgresMaintenanceRepository)(nil)
+
+// PostgresMaintenanceRepository implements MaintenanceRepository using PostgreSQL.
+type PostgresMaintenanceRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresMaintenanceRepository creates a new PostgreSQL maintenance repository.
+func NewPostgresMaintenanceRepository(db *PostgresDB) *PostgresMaintenanceRepository {
+	return &PostgresMaintenanceRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new maintenance window to the database.
+func (r *PostgresMaintenanceRepository) Create(ctx context.Context, window *entity.MaintenanceWindow) error {
+	query := `
+		INSERT INTO maintenance_windows (
+			id, title, description, team_id, source, starts_at, ends_at, created_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		window.ID,
+		window.Title,
+		window.Description,
+		optionalID(window.TeamID),
+		optionalString(window.Source),
+		window.StartsAt,
+		window.EndsAt,
+		optionalID(window.CreatedBy),
+		window.CreatedAt,
+		window.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a maintenance window by its ID.
+func (r *PostgresMaintenanceRepository) GetByID(ctx context.Context, id entity.ID) (*entity.MaintenanceWindow, error) {
+	var model MaintenanceWindowModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM maintenance_windows WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing maintenance window.
+func (r *PostgresMaintenanceRepository) Update(ctx context.Context, window *entity.MaintenanceWindow) error {
+	query := `
+		UPDATE maintenance_windows
+		SET title = $2, description = $3, team_id = $4, source = $5, starts_at = $6, ends_at = $7,
+			updated_at = $8
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		window.ID,
+		window.Title,
+		window.Description,
+		optionalID(window.TeamID),
+		optionalString(window.Source),
+		window.StartsAt,
+		window.EndsAt,
+		window.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a maintenance window by its ID.
+func (r *PostgresMaintenanceRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM maintenance_windows WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns the maintenance windows overlapping the filter period, by start.
+func (r *PostgresMaintenanceRepository) List(
+	ctx context.Context,
+	filter repository.MaintenanceFilter,
+) ([]*entity.MaintenanceWindow, error) {
+	args := []interface{}{filter.From, filter.To}
+	conditions := []string{"ends_at > $1", "starts_at < $2"}
+
+	// Tenant restrictions keep shared windows and those of the scope's teams
+	if filter.Tenant != nil {
+		teams := filter.Tenant.Teams()
+		if len(teams) == 0 {
+			conditions = append(conditions, "team_id IS NULL")
+		} else {
+			placeholders := make([]string, len(teams))
+			for i, teamID := range teams {
+				args = append(args, teamID.String())
+				placeholders[i] = fmt.Sprintf("$%d", len(args))
+			}
+			conditions = append(conditions, fmt.Sprintf("(team_id IS NULL OR team_id IN (%s))", strings.Join(placeholders, ",")))
+		}
+	}
+
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM maintenance_windows%s
+		ORDER BY starts_at, id
+		LIMIT $%d
+	`, whereClause(conditions), len(args)+1)
+
+	var models []MaintenanceWindowModel
+	if err := r.db.SelectContext(ctx, &models, query, append(args, filter.Limit)...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	windows := make([]*entity.MaintenanceWindow, 0, len(models))
+	for i := range models {
+		window, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
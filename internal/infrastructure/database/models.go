@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
@@ -10,17 +11,21 @@ import (
 type AlertModel struct {
 	ID             string     `db:"id"`
 	RuleID         *string    `db:"rule_id"`
+	TeamID         *string    `db:"team_id"`
 	Title          string     `db:"title"`
 	Message        string     `db:"message"`
 	Severity       string     `db:"severity"`
 	Status         string     `db:"status"`
 	Source         string     `db:"source"`
+	Region         string     `db:"region"`
+	Cluster        string     `db:"cluster"`
 	Metadata       JSONMap    `db:"metadata"`
 	AcknowledgedBy *string    `db:"acknowledged_by"`
 	AcknowledgedAt *time.Time `db:"acknowledged_at"`
 	ResolvedBy     *string    `db:"resolved_by"`
 	ResolvedAt     *time.Time `db:"resolved_at"`
 	ExpiresAt      *time.Time `db:"expires_at"`
+	DeletedAt      *time.Time `db:"deleted_at"`
 	CreatedAt      time.Time  `db:"created_at"`
 	UpdatedAt      time.Time  `db:"updated_at"`
 }
@@ -39,10 +44,13 @@ func (m *AlertModel) ToEntity() (*entity.Alert, error) {
 		Severity:       entity.AlertSeverity(m.Severity),
 		Status:         entity.AlertStatus(m.Status),
 		Source:         m.Source,
+		Region:         m.Region,
+		Cluster:        m.Cluster,
 		Metadata:       m.Metadata,
 		AcknowledgedAt: m.AcknowledgedAt,
 		ResolvedAt:     m.ResolvedAt,
 		ExpiresAt:      m.ExpiresAt,
+		DeletedAt:      m.DeletedAt,
 		CreatedAt:      m.CreatedAt,
 		UpdatedAt:      m.UpdatedAt,
 	}
@@ -55,6 +63,14 @@ func (m *AlertModel) ToEntity() (*entity.Alert, error) {
 		alert.RuleID = &ruleID
 	}
 
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		alert.TeamID = &teamID
+	}
+
 	if m.AcknowledgedBy != nil {
 		ackBy, err := entity.ParseID(*m.AcknowledgedBy)
 		if err != nil {
@@ -73,3 +89,1228 @@ func (m *AlertModel) ToEntity() (*entity.Alert, error) {
 
 	return alert, nil
 }
+
+// WebhookSourceModel represents the database model for inbound webhook sources.
+type WebhookSourceModel struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	TokenHash string    `db:"token_hash"`
+	Mapping   []byte    `db:"mapping"`
+	IsEnabled bool      `db:"is_enabled"`
+	CreatedBy *string   `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *WebhookSourceModel) ToEntity() (*entity.WebhookSource, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &entity.WebhookSource{
+		ID:        id,
+		Name:      m.Name,
+		TokenHash: m.TokenHash,
+		IsEnabled: m.IsEnabled,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if err := json.Unmarshal(m.Mapping, &source.Mapping); err != nil {
+		return nil, err
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		source.CreatedBy = &createdBy
+	}
+
+	return source, nil
+}
+
+// WebhookSecretModel represents the database model for webhook signing secrets.
+type WebhookSecretModel struct {
+	ID          string    `db:"id"`
+	Integration string    `db:"integration"`
+	Secret      string    `db:"secret"`
+	CreatedBy   *string   `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *WebhookSecretModel) ToEntity() (*entity.WebhookSecret, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &entity.WebhookSecret{
+		ID:          id,
+		Integration: m.Integration,
+		Secret:      m.Secret,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		secret.CreatedBy = &createdBy
+	}
+
+	return secret, nil
+}
+
+// NotificationTemplateModel represents the database model for notification templates.
+type NotificationTemplateModel struct {
+	ID        string    `db:"id"`
+	Channel   string    `db:"channel"`
+	Title     string    `db:"title"`
+	Body      string    `db:"body"`
+	CreatedBy *string   `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *NotificationTemplateModel) ToEntity() (*entity.NotificationTemplate, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &entity.NotificationTemplate{
+		ID:      id,
+		Channel: entity.ChannelType(m.Channel),
+		Title:   m.Title,
+		Body:    m.Body,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		template.CreatedBy = &createdBy
+	}
+
+	return template, nil
+}
+
+// NotificationRouteModel represents the database model for notification routes.
+type NotificationRouteModel struct {
+	ID         string    `db:"id"`
+	ParentID   *string   `db:"parent_id"`
+	Name       string    `db:"name"`
+	Position   int       `db:"position"`
+	Match      []byte    `db:"match"`
+	Channels   []byte    `db:"channels"`
+	Continue   bool      `db:"continue"`
+	CreatedBy  *string   `db:"created_by"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	ExternalID *string   `db:"external_id"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *NotificationRouteModel) ToEntity() (*entity.NotificationRoute, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	route := &entity.NotificationRoute{
+		ID:       id,
+		Name:     m.Name,
+		Position: m.Position,
+		Continue: m.Continue,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if err := json.Unmarshal(m.Match, &route.Match); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(m.Channels, &route.Channels); err != nil {
+		return nil, err
+	}
+
+	if m.ParentID != nil {
+		parentID, err := entity.ParseID(*m.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		route.ParentID = &parentID
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		route.CreatedBy = &createdBy
+	}
+
+	if m.ExternalID != nil {
+		route.ExternalID = *m.ExternalID
+	}
+
+	return route, nil
+}
+
+// TeamModel represents the database model for teams.
+type TeamModel struct {
+	ID          string    `db:"id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *TeamModel) ToEntity() (*entity.Team, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Team{
+		ID:          id,
+		Name:        m.Name,
+		Description: m.Description,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}, nil
+}
+
+// TeamMemberModel represents the database model for team memberships.
+type TeamMemberModel struct {
+	TeamID   string    `db:"team_id"`
+	UserID   string    `db:"user_id"`
+	Role     string    `db:"role"`
+	JoinedAt time.Time `db:"joined_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *TeamMemberModel) ToEntity() (*entity.TeamMember, error) {
+	teamID, err := entity.ParseID(m.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entity.ParseID(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TeamMember{
+		TeamID:   teamID,
+		UserID:   userID,
+		Role:     entity.TeamRole(m.Role),
+		JoinedAt: m.JoinedAt,
+	}, nil
+}
+
+// FailedEventModel represents the database model for dead-lettered events.
+type FailedEventModel struct {
+	ID          string     `db:"id"`
+	EventID     string     `db:"event_id"`
+	EventType   string     `db:"event_type"`
+	Payload     []byte     `db:"payload"`
+	Retries     int        `db:"retries"`
+	LastError   string     `db:"last_error"`
+	Status      string     `db:"status"`
+	FailedAt    time.Time  `db:"failed_at"`
+	ProcessedAt *time.Time `db:"processed_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *FailedEventModel) ToEntity() (*entity.FailedEvent, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.FailedEvent{
+		ID:          id,
+		EventID:     m.EventID,
+		EventType:   m.EventType,
+		Payload:     json.RawMessage(m.Payload),
+		Retries:     m.Retries,
+		LastError:   m.LastError,
+		Status:      entity.FailedEventStatus(m.Status),
+		FailedAt:    m.FailedAt,
+		ProcessedAt: m.ProcessedAt,
+	}, nil
+}
+
+// AlertRuleModel represents the database model for alert rules.
+type AlertRuleModel struct {
+	ID              string    `db:"id"`
+	Name            string    `db:"name"`
+	Description     *string   `db:"description"`
+	Condition       []byte    `db:"condition"`
+	Severity        string    `db:"severity"`
+	IsEnabled       bool      `db:"is_enabled"`
+	CooldownMinutes int       `db:"cooldown_minutes"`
+	CreatedBy       *string   `db:"created_by"`
+	TeamID          *string   `db:"team_id"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+	ExternalID      *string   `db:"external_id"`
+	RunbookURL      *string   `db:"runbook_url"`
+	Remediation     *string   `db:"remediation"`
+	CreateTicket    bool      `db:"create_ticket"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *AlertRuleModel) ToEntity() (*entity.AlertRule, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &entity.AlertRule{
+		ID:              id,
+		Name:            m.Name,
+		Severity:        entity.AlertSeverity(m.Severity),
+		IsEnabled:       m.IsEnabled,
+		CooldownMinutes: m.CooldownMinutes,
+		CreateTicket:    m.CreateTicket,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.Description != nil {
+		rule.Description = *m.Description
+	}
+
+	if m.ExternalID != nil {
+		rule.ExternalID = *m.ExternalID
+	}
+
+	if m.RunbookURL != nil {
+		rule.RunbookURL = *m.RunbookURL
+	}
+
+	if m.Remediation != nil {
+		rule.Remediation = *m.Remediation
+	}
+
+	if err := json.Unmarshal(m.Condition, &rule.Condition); err != nil {
+		return nil, err
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		rule.CreatedBy = &createdBy
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		rule.TeamID = &teamID
+	}
+
+	return rule, nil
+}
+
+// HeartbeatModel represents the database model for heartbeats.
+type HeartbeatModel struct {
+	ID              string     `db:"id"`
+	Name            string     `db:"name"`
+	TokenHash       string     `db:"token_hash"`
+	IntervalSeconds int        `db:"interval_seconds"`
+	GraceSeconds    int        `db:"grace_seconds"`
+	Severity        string     `db:"severity"`
+	Status          string     `db:"status"`
+	LastPingAt      *time.Time `db:"last_ping_at"`
+	IsEnabled       bool       `db:"is_enabled"`
+	TeamID          *string    `db:"team_id"`
+	CreatedBy       *string    `db:"created_by"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *HeartbeatModel) ToEntity() (*entity.Heartbeat, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	heartbeat := &entity.Heartbeat{
+		ID:              id,
+		Name:            m.Name,
+		TokenHash:       m.TokenHash,
+		IntervalSeconds: m.IntervalSeconds,
+		GraceSeconds:    m.GraceSeconds,
+		Severity:        entity.AlertSeverity(m.Severity),
+		Status:          entity.HeartbeatStatus(m.Status),
+		LastPingAt:      m.LastPingAt,
+		IsEnabled:       m.IsEnabled,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		heartbeat.TeamID = &teamID
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		heartbeat.CreatedBy = &createdBy
+	}
+
+	return heartbeat, nil
+}
+
+// SourceModel represents the database model for registered sources.
+type SourceModel struct {
+	ID                       string     `db:"id"`
+	Name                     string     `db:"name"`
+	Type                     string     `db:"type"`
+	Description              string     `db:"description"`
+	ExpectedHeartbeatSeconds int        `db:"expected_heartbeat_seconds"`
+	Severity                 string     `db:"severity"`
+	Status                   string     `db:"status"`
+	SilentSince              *time.Time `db:"silent_since"`
+	TeamID                   *string    `db:"team_id"`
+	CreatedBy                *string    `db:"created_by"`
+	CreatedAt                time.Time  `db:"created_at"`
+	UpdatedAt                time.Time  `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *SourceModel) ToEntity() (*entity.Source, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &entity.Source{
+		ID:                       id,
+		Name:                     m.Name,
+		Type:                     entity.SourceType(m.Type),
+		Description:              m.Description,
+		ExpectedHeartbeatSeconds: m.ExpectedHeartbeatSeconds,
+		Severity:                 entity.AlertSeverity(m.Severity),
+		Status:                   entity.SourceStatus(m.Status),
+		SilentSince:              m.SilentSince,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		source.TeamID = &teamID
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		source.CreatedBy = &createdBy
+	}
+
+	return source, nil
+}
+
+// CheckModel represents the database model for uptime checks.
+type CheckModel struct {
+	ID                  string     `db:"id"`
+	Name                string     `db:"name"`
+	URL                 string     `db:"url"`
+	Method              string     `db:"method"`
+	ExpectedStatus      int        `db:"expected_status"`
+	IntervalSeconds     int        `db:"interval_seconds"`
+	TimeoutSeconds      int        `db:"timeout_seconds"`
+	FailureThreshold    int        `db:"failure_threshold"`
+	Severity            string     `db:"severity"`
+	Status              string     `db:"status"`
+	ConsecutiveFailures int        `db:"consecutive_failures"`
+	LastCheckedAt       *time.Time `db:"last_checked_at"`
+	IsEnabled           bool       `db:"is_enabled"`
+	TeamID              *string    `db:"team_id"`
+	CreatedBy           *string    `db:"created_by"`
+	CreatedAt           time.Time  `db:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *CheckModel) ToEntity() (*entity.Check, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &entity.Check{
+		ID:                  id,
+		Name:                m.Name,
+		URL:                 m.URL,
+		Method:              m.Method,
+		ExpectedStatus:      m.ExpectedStatus,
+		IntervalSeconds:     m.IntervalSeconds,
+		TimeoutSeconds:      m.TimeoutSeconds,
+		FailureThreshold:    m.FailureThreshold,
+		Severity:            entity.AlertSeverity(m.Severity),
+		Status:              entity.CheckStatus(m.Status),
+		ConsecutiveFailures: m.ConsecutiveFailures,
+		LastCheckedAt:       m.LastCheckedAt,
+		IsEnabled:           m.IsEnabled,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		check.TeamID = &teamID
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		check.CreatedBy = &createdBy
+	}
+
+	return check, nil
+}
+
+// CheckResultModel represents the database model for check probe results.
+type CheckResultModel struct {
+	ID         string    `db:"id"`
+	CheckID    string    `db:"check_id"`
+	Success    bool      `db:"success"`
+	StatusCode *int      `db:"status_code"`
+	LatencyMs  int64     `db:"latency_ms"`
+	Error      *string   `db:"error"`
+	CheckedAt  time.Time `db:"checked_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *CheckResultModel) ToEntity() (*entity.CheckResult, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkID, err := entity.ParseID(m.CheckID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.CheckResult{
+		ID:        id,
+		CheckID:   checkID,
+		Success:   m.Success,
+		LatencyMs: m.LatencyMs,
+		CheckedAt: m.CheckedAt,
+	}
+	if m.StatusCode != nil {
+		result.StatusCode = *m.StatusCode
+	}
+	if m.Error != nil {
+		result.Error = *m.Error
+	}
+
+	return result, nil
+}
+
+// IncidentModel represents the database model for incidents.
+type IncidentModel struct {
+	ID             string     `db:"id"`
+	Title          string     `db:"title"`
+	Summary        *string    `db:"summary"`
+	Severity       string     `db:"severity"`
+	Status         string     `db:"status"`
+	CommanderID    *string    `db:"commander_id"`
+	TeamID         *string    `db:"team_id"`
+	CorrelationKey *string    `db:"correlation_key"`
+	CreatedBy      *string    `db:"created_by"`
+	MitigatedAt    *time.Time `db:"mitigated_at"`
+	ClosedAt       *time.Time `db:"closed_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *IncidentModel) ToEntity() (*entity.Incident, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	incident := &entity.Incident{
+		ID:          id,
+		Title:       m.Title,
+		Severity:    entity.AlertSeverity(m.Severity),
+		Status:      entity.IncidentStatus(m.Status),
+		MitigatedAt: m.MitigatedAt,
+		ClosedAt:    m.ClosedAt,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.Summary != nil {
+		incident.Summary = *m.Summary
+	}
+
+	if m.CorrelationKey != nil {
+		incident.CorrelationKey = *m.CorrelationKey
+	}
+
+	if m.CommanderID != nil {
+		commanderID, err := entity.ParseID(*m.CommanderID)
+		if err != nil {
+			return nil, err
+		}
+		incident.CommanderID = &commanderID
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		incident.TeamID = &teamID
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		incident.CreatedBy = &createdBy
+	}
+
+	return incident, nil
+}
+
+// IncidentEventModel represents the database model for incident timeline entries.
+type IncidentEventModel struct {
+	ID         string    `db:"id"`
+	IncidentID string    `db:"incident_id"`
+	Kind       string    `db:"kind"`
+	Message    string    `db:"message"`
+	ActorID    *string   `db:"actor_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *IncidentEventModel) ToEntity() (*entity.IncidentEvent, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	incidentID, err := entity.ParseID(m.IncidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &entity.IncidentEvent{
+		ID:         id,
+		IncidentID: incidentID,
+		Kind:       entity.IncidentEventKind(m.Kind),
+		Message:    m.Message,
+		CreatedAt:  m.CreatedAt,
+	}
+
+	if m.ActorID != nil {
+		actorID, err := entity.ParseID(*m.ActorID)
+		if err != nil {
+			return nil, err
+		}
+		event.ActorID = &actorID
+	}
+
+	return event, nil
+}
+
+// PostmortemModel represents the database model for postmortems. AlertIDs
+// is the JSON array of the alerts it covers.
+type PostmortemModel struct {
+	ID          string    `db:"id"`
+	IncidentID  *string   `db:"incident_id"`
+	TeamID      *string   `db:"team_id"`
+	Title       string    `db:"title"`
+	Summary     *string   `db:"summary"`
+	Impact      *string   `db:"impact"`
+	RootCause   *string   `db:"root_cause"`
+	ActionItems []byte    `db:"action_items"`
+	AlertIDs    []byte    `db:"alert_ids"`
+	CreatedBy   *string   `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *PostmortemModel) ToEntity() (*entity.Postmortem, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	postmortem := &entity.Postmortem{
+		ID:    id,
+		Title: m.Title,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.Summary != nil {
+		postmortem.Summary = *m.Summary
+	}
+	if m.Impact != nil {
+		postmortem.Impact = *m.Impact
+	}
+	if m.RootCause != nil {
+		postmortem.RootCause = *m.RootCause
+	}
+
+	if err := json.Unmarshal(m.ActionItems, &postmortem.ActionItems); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(m.AlertIDs, &postmortem.AlertIDs); err != nil {
+		return nil, err
+	}
+
+	if m.IncidentID != nil {
+		incidentID, err := entity.ParseID(*m.IncidentID)
+		if err != nil {
+			return nil, err
+		}
+		postmortem.IncidentID = &incidentID
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		postmortem.TeamID = &teamID
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		postmortem.CreatedBy = &createdBy
+	}
+
+	return postmortem, nil
+}
+
+// SLAPolicyModel represents the database model for SLA policies.
+type SLAPolicyModel struct {
+	ID                   string    `db:"id"`
+	Name                 string    `db:"name"`
+	Severity             *string   `db:"severity"`
+	TeamID               *string   `db:"team_id"`
+	Source               *string   `db:"source"`
+	AckWithinSeconds     int       `db:"ack_within_seconds"`
+	ResolveWithinSeconds int       `db:"resolve_within_seconds"`
+	BreachSeverity       string    `db:"breach_severity"`
+	IsEnabled            bool      `db:"is_enabled"`
+	CreatedBy            *string   `db:"created_by"`
+	CreatedAt            time.Time `db:"created_at"`
+	UpdatedAt            time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *SLAPolicyModel) ToEntity() (*entity.SLAPolicy, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &entity.SLAPolicy{
+		ID:                   id,
+		Name:                 m.Name,
+		AckWithinSeconds:     m.AckWithinSeconds,
+		ResolveWithinSeconds: m.ResolveWithinSeconds,
+		BreachSeverity:       entity.AlertSeverity(m.BreachSeverity),
+		IsEnabled:            m.IsEnabled,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.Severity != nil {
+		policy.Severity = entity.AlertSeverity(*m.Severity)
+	}
+
+	if m.Source != nil {
+		policy.Source = *m.Source
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		policy.TeamID = &teamID
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		policy.CreatedBy = &createdBy
+	}
+
+	return policy, nil
+}
+
+// MaintenanceWindowModel represents the database model for maintenance windows.
+type MaintenanceWindowModel struct {
+	ID          string    `db:"id"`
+	Title       string    `db:"title"`
+	Description string    `db:"description"`
+	TeamID      *string   `db:"team_id"`
+	Source      *string   `db:"source"`
+	StartsAt    time.Time `db:"starts_at"`
+	EndsAt      time.Time `db:"ends_at"`
+	CreatedBy   *string   `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *MaintenanceWindowModel) ToEntity() (*entity.MaintenanceWindow, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	window := &entity.MaintenanceWindow{
+		ID:          id,
+		Title:       m.Title,
+		Description: m.Description,
+		StartsAt:    m.StartsAt,
+		EndsAt:      m.EndsAt,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.Source != nil {
+		window.Source = *m.Source
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		window.TeamID = &teamID
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		window.CreatedBy = &createdBy
+	}
+
+	return window, nil
+}
+
+// SourceDependencyModel represents the database model for source dependencies.
+type SourceDependencyModel struct {
+	ID        string    `db:"id"`
+	Source    string    `db:"source"`
+	DependsOn string    `db:"depends_on"`
+	CreatedBy *string   `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *SourceDependencyModel) ToEntity() (*entity.SourceDependency, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dependency := &entity.SourceDependency{
+		ID:        id,
+		Source:    m.Source,
+		DependsOn: m.DependsOn,
+		CreatedAt: m.CreatedAt,
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		dependency.CreatedBy = &createdBy
+	}
+
+	return dependency, nil
+}
+
+// AlertViewModel represents the database model for saved alert views.
+type AlertViewModel struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	Criteria  []byte    `db:"criteria"`
+	OwnerID   string    `db:"owner_id"`
+	TeamID    *string   `db:"team_id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *AlertViewModel) ToEntity() (*entity.AlertView, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID, err := entity.ParseID(m.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &entity.AlertView{
+		ID:      id,
+		Name:    m.Name,
+		OwnerID: ownerID,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if err := json.Unmarshal(m.Criteria, &view.Criteria); err != nil {
+		return nil, err
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		view.TeamID = &teamID
+	}
+
+	return view, nil
+}
+
+// SeverityRuleModel represents the database model for severity rules.
+type SeverityRuleModel struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	Position  int       `db:"position"`
+	Match     []byte    `db:"match"`
+	Severity  string    `db:"severity"`
+	IsEnabled bool      `db:"is_enabled"`
+	CreatedBy *string   `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *SeverityRuleModel) ToEntity() (*entity.SeverityRule, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &entity.SeverityRule{
+		ID:        id,
+		Name:      m.Name,
+		Position:  m.Position,
+		Severity:  entity.AlertSeverity(m.Severity),
+		IsEnabled: m.IsEnabled,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if err := json.Unmarshal(m.Match, &rule.Match); err != nil {
+		return nil, err
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		rule.CreatedBy = &createdBy
+	}
+
+	return rule, nil
+}
+
+// AssignmentRuleModel represents the database model for assignment rules.
+type AssignmentRuleModel struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	Position  int       `db:"position"`
+	Match     []byte    `db:"match"`
+	TeamID    *string   `db:"team_id"`
+	UserID    *string   `db:"user_id"`
+	IsEnabled bool      `db:"is_enabled"`
+	CreatedBy *string   `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *AssignmentRuleModel) ToEntity() (*entity.AssignmentRule, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &entity.AssignmentRule{
+		ID:        id,
+		Name:      m.Name,
+		Position:  m.Position,
+		IsEnabled: m.IsEnabled,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if err := json.Unmarshal(m.Match, &rule.Match); err != nil {
+		return nil, err
+	}
+
+	if m.TeamID != nil {
+		teamID, err := entity.ParseID(*m.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		rule.TeamID = &teamID
+	}
+
+	if m.UserID != nil {
+		userID, err := entity.ParseID(*m.UserID)
+		if err != nil {
+			return nil, err
+		}
+		rule.UserID = &userID
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		rule.CreatedBy = &createdBy
+	}
+
+	return rule, nil
+}
+
+// SubscriptionModel represents the database model for outbound webhook subscriptions.
+type SubscriptionModel struct {
+	ID         string    `db:"id"`
+	Name       string    `db:"name"`
+	URL        string    `db:"url"`
+	Secret     string    `db:"secret"`
+	EventTypes []byte    `db:"event_types"`
+	Filter     []byte    `db:"filter"`
+	IsEnabled  bool      `db:"is_enabled"`
+	CreatedBy  *string   `db:"created_by"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *SubscriptionModel) ToEntity() (*entity.Subscription, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &entity.Subscription{
+		ID:        id,
+		Name:      m.Name,
+		URL:       m.URL,
+		Secret:    m.Secret,
+		IsEnabled: m.IsEnabled,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if err := json.Unmarshal(m.EventTypes, &subscription.EventTypes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(m.Filter, &subscription.Filter); err != nil {
+		return nil, err
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		subscription.CreatedBy = &createdBy
+	}
+
+	return subscription, nil
+}
+
+// SubscriptionDeliveryModel represents the database model for the deliveries
+// of events to subscriptions.
+type SubscriptionDeliveryModel struct {
+	ID             string     `db:"id"`
+	SubscriptionID string     `db:"subscription_id"`
+	EventType      string     `db:"event_type"`
+	Payload        []byte     `db:"payload"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	LastStatusCode *int       `db:"last_status_code"`
+	LastError      *string    `db:"last_error"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *SubscriptionDeliveryModel) ToEntity() (*entity.SubscriptionDelivery, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionID, err := entity.ParseID(m.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &entity.SubscriptionDelivery{
+		ID:             id,
+		SubscriptionID: subscriptionID,
+		EventType:      m.EventType,
+		Payload:        m.Payload,
+		Status:         entity.DeliveryStatus(m.Status),
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		DeliveredAt:    m.DeliveredAt,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+	if m.LastStatusCode != nil {
+		delivery.LastStatusCode = *m.LastStatusCode
+	}
+	if m.LastError != nil {
+		delivery.LastError = *m.LastError
+	}
+
+	return delivery, nil
+}
+
+// UserIdentityModel represents the database model for user identities.
+type UserIdentityModel struct {
+	ID         string    `db:"id"`
+	UserID     string    `db:"user_id"`
+	Provider   string    `db:"provider"`
+	ExternalID string    `db:"external_id"`
+	CreatedBy  *string   `db:"created_by"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// ToEntity converts the database model to a domain entity.
+func (m *UserIdentityModel) ToEntity() (*entity.UserIdentity, error) {
+	id, err := entity.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := entity.ParseID(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &entity.UserIdentity{
+		ID:         id,
+		UserID:     userID,
+		Provider:   m.Provider,
+		ExternalID: m.ExternalID,
+		Timestamps: entity.Timestamps{
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		},
+	}
+
+	if m.CreatedBy != nil {
+		createdBy, err := entity.ParseID(*m.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		identity.CreatedBy = &createdBy
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// Ensure PostgresNotificationRouteRepository implements repository.NotificationRouteRepository
+var _ repository.NotificationRouteRepository = (*PostgresNotificationRouteRepository)(nil)
+
+// PostgresNotificationRouteRepository implements NotificationRouteRepository using PostgreSQL.
+type PostgresNotificationRouteRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresNotificationRouteRepository creates a new PostgreSQL notification route repository.
+func NewPostgresNotificationRouteRepository(db *PostgresDB) *PostgresNotificationRouteRepository {
+	return &PostgresNotificationRouteRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new notification route to the database.
+func (r *PostgresNotificationRouteRepository) Create(ctx context.Context, route *entity.NotificationRoute) error {
+	match, channels, err := marshalRoute(route)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO notification_routes (
+			id, parent_id, name, position, match, channels, continue, created_by, external_id, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		route.ID,
+		route.ParentID,
+		route.Name,
+		route.Position,
+		match,
+		channels,
+		route.Continue,
+		route.CreatedBy,
+		optionalString(route.ExternalID),
+		route.CreatedAt,
+		route.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a notification route by its ID.
+func (r *PostgresNotificationRouteRepository) GetByID(ctx context.Context, id entity.ID) (*entity.NotificationRoute, error) {
+	var model NotificationRouteModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM notification_routes WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// GetByExternalID finds a notification route by the ID given by an external tool.
+func (r *PostgresNotificationRouteRepository) GetByExternalID(ctx context.Context, externalID string) (*entity.NotificationRoute, error) {
+	var model NotificationRouteModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM notification_routes WHERE external_id = $1`, externalID); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing notification route.
+func (r *PostgresNotificationRouteRepository) Update(ctx context.Context, route *entity.NotificationRoute) error {
+	match, channels, err := marshalRoute(route)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE notification_routes
+		SET parent_id = $2, name = $3, position = $4, match = $5, channels = $6, continue = $7,
+			external_id = $8, updated_at = $9
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		route.ID,
+		route.ParentID,
+		route.Name,
+		route.Position,
+		match,
+		channels,
+		route.Continue,
+		optionalString(route.ExternalID),
+		route.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a notification route; child routes are removed by cascade.
+func (r *PostgresNotificationRouteRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM notification_routes WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns every notification route, ordered by position.
+func (r *PostgresNotificationRouteRepository) List(ctx context.Context) ([]*entity.NotificationRoute, error) {
+	var models []NotificationRouteModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM notification_routes ORDER BY position, name`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	routes := make([]*entity.NotificationRoute, 0, len(models))
+	for i := range models {
+		route, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// marshalRoute encodes the JSONB columns of a route.
+func marshalRoute(route *entity.NotificationRoute) ([]byte, []byte, error) {
+	match, err := json.Marshal(route.Match)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	channels, err := json.Marshal(route.Channels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return match, channels, nil
+}
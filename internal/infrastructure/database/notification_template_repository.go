@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// Ensure PostgresNotificationTemplateRepository implements repository.NotificationTemplateRepository
+var _ repository.NotificationTemplateRepository = (*PostgresNotificationTemplateRepository)(nil)
+
+// PostgresNotificationTemplateRepository implements NotificationTemplateRepository using PostgreSQL.
+type PostgresNotificationTemplateRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresNotificationTemplateRepository creates a new PostgreSQL notification template repository.
+func NewPostgresNotificationTemplateRepository(db *PostgresDB) *PostgresNotificationTemplateRepository {
+	return &PostgresNotificationTemplateRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new notification template to the database.
+func (r *PostgresNotificationTemplateRepository) Create(ctx context.Context, template *entity.NotificationTemplate) error {
+	query := `
+		INSERT INTO notification_templates (id, channel, title, body, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		template.ID,
+		template.Channel,
+		template.Title,
+		template.Body,
+		template.CreatedBy,
+		template.CreatedAt,
+		template.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByChannel finds the template of a channel type.
+func (r *PostgresNotificationTemplateRepository) GetByChannel(
+	ctx context.Context,
+	channel entity.ChannelType,
+) (*entity.NotificationTemplate, error) {
+	var model NotificationTemplateModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM notification_templates WHERE channel = $1`, channel); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing notification template.
+func (r *PostgresNotificationTemplateRepository) Update(ctx context.Context, template *entity.NotificationTemplate) error {
+	query := `
+		UPDATE notification_templates
+		SET title = $2, body = $3, updated_at = $4
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, template.ID, template.Title, template.Body, template.UpdatedAt)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the template of a channel type.
+func (r *PostgresNotificationTemplateRepository) Delete(ctx context.Context, channel entity.ChannelType) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM notification_templates WHERE channel = $1`, channel)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns every custom notification template.
+func (r *PostgresNotificationTemplateRepository) List(ctx context.Context) ([]*entity.NotificationTemplate, error) {
+	var models []NotificationTemplateModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM notification_templates ORDER BY channel`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	templates := make([]*entity.NotificationTemplate, 0, len(models))
+	for i := range models {
+		template, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
@@ -15,7 +15,8 @@ import (
 // PostgresDB wraps the sqlx.DB connection with additional functionality.
 type PostgresDB struct {
 	*sqlx.DB
-	config *config.DatabaseConfig
+	config       *config.DatabaseConfig
+	instrumented *InstrumentedDB
 }
 
 // NewPostgresDB creates a new PostgreSQL connection.
@@ -52,11 +53,18 @@ func NewPostgresDB(cfg *config.DatabaseConfig) (*PostgresDB, error) {
 	}
 
 	return &PostgresDB{
-		DB:     db,
-		config: cfg,
+		DB:           db,
+		config:       cfg,
+		instrumented: NewInstrumentedDB(db, cfg.SlowQueryThreshold),
 	}, nil
 }
 
+// Instrumented returns the connection with query instrumentation, for use by
+// the repositories.
+func (p *PostgresDB) Instrumented() *InstrumentedDB {
+	return p.instrumented
+}
+
 // Health checks if the database connection is healthy.
 func (p *PostgresDB) Health(ctx context.Context) error {
 	return p.PingContext(ctx)
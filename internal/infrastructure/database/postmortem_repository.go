@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresPostmortemRepository implements repository.PostmortemRepository
+var _ repository.PostmortemRepository = (*PostgresPostmortemRepository)(nil)
+
+// postmortemSelect selects postmortems with the JSON array of their alerts.
+const postmortemSelect = `
+	SELECT p.*, COALESCE(
+		(SELECT json_agg(pa.alert_id) FROM postmortem_alerts pa WHERE pa.postmortem_id = p.id), '[]'
+	) AS alert_ids
+	FROM postmortems p`
+
+// PostgresPostmortemRepository implements PostmortemRepository using PostgreSQL.
+type PostgresPostmortemRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresPostmortemRepository creates a new PostgreSQL postmortem repository.
+func NewPostgresPostmortemRepository(db *PostgresDB) *PostgresPostmortemRepository {
+	return &PostgresPostmortemRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new postmortem and the alerts it covers in a transaction.
+func (r *PostgresPostmortemRepository) Create(ctx context.Context, postmortem *entity.Postmortem) error {
+	actionItems, err := json.Marshal(postmortem.ActionItems)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO postmortems (
+			id, incident_id, team_id, title, summary, impact, root_cause, action_items,
+			created_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := r.db.ExecContext(ctx, query,
+			postmortem.ID,
+			optionalID(postmortem.IncidentID),
+			optionalID(postmortem.TeamID),
+			postmortem.Title,
+			optionalString(postmortem.Summary),
+			optionalString(postmortem.Impact),
+			optionalString(postmortem.RootCause),
+			actionItems,
+			optionalID(postmortem.CreatedBy),
+			postmortem.CreatedAt,
+			postmortem.UpdatedAt,
+		); err != nil {
+			return TranslateError(err)
+		}
+
+		return r.insertAlerts(ctx, postmortem)
+	})
+}
+
+// GetByID finds a postmortem by its ID.
+func (r *PostgresPostmortemRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Postmortem, error) {
+	var model PostmortemModel
+	if err := r.db.GetContext(ctx, &model, postmortemSelect+` WHERE p.id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// GetByIncident finds the postmortem of an incident.
+func (r *PostgresPostmortemRepository) GetByIncident(ctx context.Context, incidentID entity.ID) (*entity.Postmortem, error) {
+	var model PostmortemModel
+	if err := r.db.GetContext(ctx, &model, postmortemSelect+` WHERE p.incident_id = $1`, incidentID); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing postmortem and replaces the alerts it covers
+// in a transaction.
+func (r *PostgresPostmortemRepository) Update(ctx context.Context, postmortem *entity.Postmortem) error {
+	actionItems, err := json.Marshal(postmortem.ActionItems)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE postmortems
+		SET title = $2, summary = $3, impact = $4, root_cause = $5, action_items = $6, updated_at = $7
+		WHERE id = $1
+	`
+
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		result, err := r.db.ExecContext(ctx, query,
+			postmortem.ID,
+			postmortem.Title,
+			optionalString(postmortem.Summary),
+			optionalString(postmortem.Impact),
+			optionalString(postmortem.RootCause),
+			actionItems,
+			postmortem.UpdatedAt,
+		)
+		if err != nil {
+			return TranslateError(err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return TranslateError(err)
+		}
+
+		if rowsAffected == 0 {
+			return repository.ErrNotFound
+		}
+
+		if _, err := r.db.ExecContext(ctx,
+			`DELETE FROM postmortem_alerts WHERE postmortem_id = $1`, postmortem.ID); err != nil {
+			return TranslateError(err)
+		}
+
+		return r.insertAlerts(ctx, postmortem)
+	})
+}
+
+// Delete removes a postmortem; the links to its alerts go with it.
+func (r *PostgresPostmortemRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM postmortems WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated postmortems matching the filter, newest first.
+func (r *PostgresPostmortemRepository) List(
+	ctx context.Context,
+	filter repository.PostmortemFilter,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Postmortem], error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.AlertID != nil {
+		args = append(args, *filter.AlertID)
+		conditions = append(conditions, fmt.Sprintf(
+			"(p.id IN (SELECT postmortem_id FROM postmortem_alerts WHERE alert_id = $%[1]d)"+
+				" OR p.incident_id IN (SELECT incident_id FROM incident_alerts WHERE alert_id = $%[1]d))",
+			len(args)))
+	}
+
+	// Tenant restrictions keep shared postmortems and those of the scope's teams
+	if filter.Tenant != nil {
+		teams := filter.Tenant.Teams()
+		if len(teams) == 0 {
+			conditions = append(conditions, "p.team_id IS NULL")
+		} else {
+			placeholders := make([]string, len(teams))
+			for i, teamID := range teams {
+				args = append(args, teamID.String())
+				placeholders[i] = fmt.Sprintf("$%d", len(args))
+			}
+			conditions = append(conditions, fmt.Sprintf("(p.team_id IS NULL OR p.team_id IN (%s))", strings.Join(placeholders, ",")))
+		}
+	}
+
+	where := whereClause(conditions)
+
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM postmortems p"+where, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := fmt.Sprintf(`%s%s
+		ORDER BY p.created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, postmortemSelect, where, len(args)+1, len(args)+2)
+
+	var models []PostmortemModel
+	if err := r.db.SelectContext(ctx, &models, query, append(args, pagination.Limit(), pagination.Offset())...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	postmortems := make([]*entity.Postmortem, 0, len(models))
+	for i := range models {
+		postmortem, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		postmortems = append(postmortems, postmortem)
+	}
+
+	result := valueobject.NewPaginatedResult(postmortems, total, pagination)
+	return &result, nil
+}
+
+// insertAlerts links a postmortem to the alerts it covers.
+func (r *PostgresPostmortemRepository) insertAlerts(ctx context.Context, postmortem *entity.Postmortem) error {
+	if len(postmortem.AlertIDs) == 0 {
+		return nil
+	}
+
+	rows := make([]string, len(postmortem.AlertIDs))
+	args := make([]interface{}, 0, len(postmortem.AlertIDs)+1)
+	args = append(args, postmortem.ID)
+	for i, alertID := range postmortem.AlertIDs {
+		args = append(args, alertID)
+		rows[i] = fmt.Sprintf("($1, $%d)", len(args))
+	}
+
+	query := `INSERT INTO postmortem_alerts (postmortem_id, alert_id) VALUES ` +
+		strings.Join(rows, ", ") + ` ON CONFLICT DO NOTHING`
+
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return TranslateError(err)
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// quotaKeyPrefix prefixes the hash holding the quota usage of each day.
+const quotaKeyPrefix = "quota:"
+
+// quotaRetention is how long the usage of a day is kept, so that the
+// usage of the previous day can still be looked at.
+const quotaRetention = 48 * time.Hour
+
+// consumeQuotaScript adds to the usage of a quota unless that would exceed
+// its limit, atomically. It returns whether the usage was added and the
+// usage after the call.
+//
+// KEYS[1]: the hash of the day; ARGV[1]: the field of the quota;
+// ARGV[2]: the amount to add; ARGV[3]: the limit, zero for none;
+// ARGV[4]: the retention of the hash in seconds.
+var consumeQuotaScript = redis.NewScript(`
+local used = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0')
+local n = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+if limit > 0 and used + n > limit then
+	return {0, used}
+end
+
+used = redis.call('HINCRBY', KEYS[1], ARGV[1], n)
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+
+return {1, used}
+`)
+
+// Ensure RedisQuotaRepository implements repository.QuotaRepository
+var _ repository.QuotaRepository = (*RedisQuotaRepository)(nil)
+
+// RedisQuotaRepository implements QuotaRepository using Redis.
+// The usage of a day is a hash with a field per subject and metric.
+type RedisQuotaRepository struct {
+	client *redis.Client
+}
+
+// NewRedisQuotaRepository creates a new Redis quota repository.
+func NewRedisQuotaRepository(redisClient *RedisClient) *RedisQuotaRepository {
+	return &RedisQuotaRepository{
+		client: redisClient.Client(),
+	}
+}
+
+// Consume adds n to the usage of a quota on a day, unless that would exceed limit.
+func (r *RedisQuotaRepository) Consume(
+	ctx context.Context,
+	day string,
+	subject valueobject.QuotaSubject,
+	metric valueobject.QuotaMetric,
+	n, limit int,
+) (int, bool, error) {
+	values, err := consumeQuotaScript.Run(ctx, r.client, []string{quotaKeyPrefix + day},
+		quotaField(subject, metric), n, limit, int(quotaRetention.Seconds())).Int64Slice()
+	if err != nil {
+		return 0, false, translateRedisError(err)
+	}
+	if len(values) != 2 {
+		return 0, false, fmt.Errorf("unexpected quota script result: %v", values)
+	}
+
+	return int(values[1]), values[0] == 1, nil
+}
+
+// ListUsage returns the usage of every quota used on a day.
+func (r *RedisQuotaRepository) ListUsage(ctx context.Context, day string) ([]valueobject.QuotaUsage, error) {
+	fields, err := r.client.HGetAll(ctx, quotaKeyPrefix+day).Result()
+	if err != nil {
+		return nil, translateRedisError(err)
+	}
+
+	usage := make([]valueobject.QuotaUsage, 0, len(fields))
+	for field, value := range fields {
+		rawSubject, metric, ok := strings.Cut(field, "|")
+		if !ok {
+			continue
+		}
+		subject, err := valueobject.ParseQuotaSubject(rawSubject)
+		if err != nil {
+			continue
+		}
+		used, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+
+		usage = append(usage, valueobject.QuotaUsage{
+			Subject: subject,
+			Metric:  valueobject.QuotaMetric(metric),
+			Used:    used,
+		})
+	}
+
+	return usage, nil
+}
+
+// Reset clears the usage of the quotas of a subject on a day.
+func (r *RedisQuotaRepository) Reset(ctx context.Context, day string, subject valueobject.QuotaSubject) error {
+	err := r.client.HDel(ctx, quotaKeyPrefix+day,
+		quotaField(subject, valueobject.QuotaRequests),
+		quotaField(subject, valueobject.QuotaAlerts),
+	).Err()
+	return translateRedisError(err)
+}
+
+// quotaField returns the hash field of the quota of a subject.
+func quotaField(subject valueobject.QuotaSubject, metric valueobject.QuotaMetric) string {
+	return subject.String() + "|" + string(metric)
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// slidingWindowScript counts a request in a sorted set of the request times
+// (in milliseconds, from the Redis clock shared by every instance) within
+// the window, atomically. It returns whether the request was allowed, the
+// number of requests in the window and the milliseconds until the oldest
+// of them leaves it.
+//
+// KEYS[1]: the counter; ARGV[1]: limit; ARGV[2]: window in milliseconds;
+// ARGV[3]: a member unique to the request.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+
+local retry = 0
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	retry = tonumber(oldest[2]) + window - now
+	redis.call('PEXPIRE', key, window)
+end
+
+return {allowed, count, retry}
+`)
+
+// Ensure RedisRateLimitRepository implements repository.RateLimitRepository
+var _ repository.RateLimitRepository = (*RedisRateLimitRepository)(nil)
+
+// RedisRateLimitRepository implements RateLimitRepository using Redis.
+// Each key is a sorted set of the times of the requests in its window, so
+// that, unlike fixed windows, no burst of twice the limit fits across a
+// window boundary.
+type RedisRateLimitRepository struct {
+	client *redis.Client
+}
+
+// NewRedisRateLimitRepository creates a new Redis rate limit repository.
+func NewRedisRateLimitRepository(redisClient *RedisClient) *RedisRateLimitRepository {
+	return &RedisRateLimitRepository{
+		client: redisClient.Client(),
+	}
+}
+
+// Allow counts a request under a key, unless limit requests were already
+// counted in the last window.
+func (r *RedisRateLimitRepository) Allow(ctx context.Context, key string, limit int, window time.Duration) (repository.RateLimitResult, error) {
+	values, err := slidingWindowScript.Run(ctx, r.client, []string{key},
+		limit, window.Milliseconds(), uuid.NewString()).Int64Slice()
+	if err != nil {
+		return repository.RateLimitResult{}, translateRedisError(err)
+	}
+	if len(values) != 3 {
+		return repository.RateLimitResult{}, fmt.Errorf("unexpected rate limit script result: %v", values)
+	}
+
+	return repository.RateLimitResult{
+		Allowed:    values[0] == 1,
+		Remaining:  max(0, limit-int(values[1])),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// sessionKeyPrefix prefixes the cache key of each session.
+const sessionKeyPrefix = "session:"
+
+// Ensure RedisSessionRepository implements repository.SessionRepository
+var _ repository.SessionRepository = (*RedisSessionRepository)(nil)
+
+// RedisSessionRepository implements SessionRepository using Redis.
+// Each session is a key expiring with the session; a set per user indexes them.
+type RedisSessionRepository struct {
+	client *redis.Client
+}
+
+// NewRedisSessionRepository creates a new Redis session repository.
+func NewRedisSessionRepository(redisClient *RedisClient) *RedisSessionRepository {
+	return &RedisSessionRepository{
+		client: redisClient.Client(),
+	}
+}
+
+// Save creates or updates a session, expiring it at its ExpiresAt.
+func (r *RedisSessionRepository) Save(ctx context.Context, session *entity.Session) error {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	userKey := userSessionsKey(session.UserID)
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, sessionKey(session.ID), data, ttl)
+	pipe.SAdd(ctx, userKey, session.ID.String())
+	// Every save extends the session to the full lifetime, the longest of the user's sessions
+	pipe.Expire(ctx, userKey, ttl)
+
+	_, err = pipe.Exec(ctx)
+	return translateRedisError(err)
+}
+
+// GetByID finds a session by its ID.
+func (r *RedisSessionRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Session, error) {
+	data, err := r.client.Get(ctx, sessionKey(id)).Bytes()
+	if err != nil {
+		return nil, translateRedisError(err)
+	}
+
+	var session entity.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// Exists checks whether a session is still active.
+func (r *RedisSessionRepository) Exists(ctx context.Context, id entity.ID) (bool, error) {
+	n, err := r.client.Exists(ctx, sessionKey(id)).Result()
+	if err != nil {
+		return false, translateRedisError(err)
+	}
+	return n > 0, nil
+}
+
+// ListByUser returns the active sessions of a user, newest first.
+// Expired sessions are pruned from the user's index.
+func (r *RedisSessionRepository) ListByUser(ctx context.Context, userID entity.ID) ([]*entity.Session, error) {
+	userKey := userSessionsKey(userID)
+
+	ids, err := r.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return nil, translateRedisError(err)
+	}
+	if len(ids) == 0 {
+		return []*entity.Session{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionKeyPrefix + id
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, translateRedisError(err)
+	}
+
+	sessions := make([]*entity.Session, 0, len(values))
+	var expired []interface{}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+
+		var session entity.Session
+		if err := json.Unmarshal([]byte(data), &session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	if len(expired) > 0 {
+		_ = r.client.SRem(ctx, userKey, expired...).Err()
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].IssuedAt.After(sessions[j].IssuedAt)
+	})
+
+	return sessions, nil
+}
+
+// Delete removes a session.
+func (r *RedisSessionRepository) Delete(ctx context.Context, session *entity.Session) error {
+	pipe := r.client.TxPipeline()
+	deleted := pipe.Del(ctx, sessionKey(session.ID))
+	pipe.SRem(ctx, userSessionsKey(session.UserID), session.ID.String())
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return translateRedisError(err)
+	}
+
+	if deleted.Val() == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// sessionKey returns the cache key of a session.
+func sessionKey(id entity.ID) string {
+	return sessionKeyPrefix + id.String()
+}
+
+// userSessionsKey returns the cache key of a user's session index.
+func userSessionsKey(userID entity.ID) string {
+	return "sessions:user:" + userID.String()
+}
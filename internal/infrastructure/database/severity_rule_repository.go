@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// Ensure PostgresSeverityRuleRepository implements repository.SeverityRuleRepository
+var _ repository.SeverityRuleRepository = (*PostgresSeverityRuleRepository)(nil)
+
+// PostgresSeverityRuleRepository implements SeverityRuleRepository using PostgreSQL.
+type PostgresSeverityRuleRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresSeverityRuleRepository creates a new PostgreSQL severity rule repository.
+func NewPostgresSeverityRuleRepository(db *PostgresDB) *PostgresSeverityRuleRepository {
+	return &PostgresSeverityRuleRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new rule to the database.
+func (r *PostgresSeverityRuleRepository) Create(ctx context.Context, rule *entity.SeverityRule) error {
+	query := `
+		INSERT INTO severity_rules (id, name, position, match, severity, is_enabled, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	match, err := json.Marshal(rule.Match)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Position,
+		match,
+		rule.Severity,
+		rule.IsEnabled,
+		optionalID(rule.CreatedBy),
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a rule by its ID.
+func (r *PostgresSeverityRuleRepository) GetByID(ctx context.Context, id entity.ID) (*entity.SeverityRule, error) {
+	var model SeverityRuleModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM severity_rules WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing rule.
+func (r *PostgresSeverityRuleRepository) Update(ctx context.Context, rule *entity.SeverityRule) error {
+	query := `
+		UPDATE severity_rules
+		SET name = $2, position = $3, match = $4, severity = $5, is_enabled = $6, updated_at = $7
+		WHERE id = $1
+	`
+
+	match, err := json.Marshal(rule.Match)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Position,
+		match,
+		rule.Severity,
+		rule.IsEnabled,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a rule by its ID.
+func (r *PostgresSeverityRuleRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM severity_rules WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns every rule, by position then name.
+func (r *PostgresSeverityRuleRepository) List(ctx context.Context) ([]*entity.SeverityRule, error) {
+	var models []SeverityRuleModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM severity_rules ORDER BY position, name`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	rules := make([]*entity.SeverityRule, 0, len(models))
+	for i := range models {
+		rule, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
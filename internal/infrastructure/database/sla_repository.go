@@ -0,0 +1,355 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresSLARepository implements repository.SLARepository
+var _ repository.SLARepository = (*PostgresSLARepository)(nil)
+
+// PostgresSLARepository implements SLARepository using PostgreSQL.
+type PostgresSLARepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresSLARepository creates a new PostgreSQL SLA repository.
+func NewPostgresSLARepository(db *PostgresDB) *PostgresSLARepository {
+	return &PostgresSLARepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new SLA policy to the database.
+func (r *PostgresSLARepository) Create(ctx context.Context, policy *entity.SLAPolicy) error {
+	query := `
+		INSERT INTO sla_policies (
+			id, name, severity, team_id, source, ack_within_seconds, resolve_within_seconds,
+			breach_severity, is_enabled, created_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		policy.ID,
+		policy.Name,
+		optionalString(string(policy.Severity)),
+		optionalID(policy.TeamID),
+		optionalString(policy.Source),
+		policy.AckWithinSeconds,
+		policy.ResolveWithinSeconds,
+		string(policy.BreachSeverity),
+		policy.IsEnabled,
+		optionalID(policy.CreatedBy),
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds an SLA policy by its ID.
+func (r *PostgresSLARepository) GetByID(ctx context.Context, id entity.ID) (*entity.SLAPolicy, error) {
+	var model SLAPolicyModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM sla_policies WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing SLA policy.
+func (r *PostgresSLARepository) Update(ctx context.Context, policy *entity.SLAPolicy) error {
+	query := `
+		UPDATE sla_policies
+		SET name = $2, severity = $3, team_id = $4, source = $5, ack_within_seconds = $6,
+			resolve_within_seconds = $7, breach_severity = $8, is_enabled = $9, updated_at = $10
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		policy.ID,
+		policy.Name,
+		optionalString(string(policy.Severity)),
+		optionalID(policy.TeamID),
+		optionalString(policy.Source),
+		policy.AckWithinSeconds,
+		policy.ResolveWithinSeconds,
+		string(policy.BreachSeverity),
+		policy.IsEnabled,
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an SLA policy by its ID; its breaches are removed with it.
+func (r *PostgresSLARepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM sla_policies WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated SLA policies.
+func (r *PostgresSLARepository) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.SLAPolicy], error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM sla_policies`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT * FROM sla_policies
+		ORDER BY name
+		LIMIT $1 OFFSET $2
+	`
+
+	var models []SLAPolicyModel
+	if err := r.db.SelectContext(ctx, &models, query, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	policies, err := slaPoliciesFromModels(models)
+	if err != nil {
+		return nil, err
+	}
+
+	result := valueobject.NewPaginatedResult(policies, total, pagination)
+	return &result, nil
+}
+
+// ListEnabled returns the enabled SLA policies.
+func (r *PostgresSLARepository) ListEnabled(ctx context.Context) ([]*entity.SLAPolicy, error) {
+	var models []SLAPolicyModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM sla_policies WHERE is_enabled ORDER BY name`); err != nil {
+		return nil, TranslateError(err)
+	}
+	return slaPoliciesFromModels(models)
+}
+
+// ListBreaching returns the alerts matching a policy that missed a target
+// and have no breach of it recorded.
+func (r *PostgresSLARepository) ListBreaching(
+	ctx context.Context,
+	policy *entity.SLAPolicy,
+	target entity.SLATarget,
+	now time.Time,
+	limit int,
+) ([]*entity.Alert, error) {
+	within, ok := policy.Within(target)
+	if !ok {
+		return nil, nil
+	}
+
+	conditions, args := slaPolicyConditions(policy, []string{"deleted_at IS NULL"}, nil)
+	args = append(args, within.Seconds(), now, policy.ID, string(target), limit)
+	due := fmt.Sprintf("created_at + make_interval(secs => $%d)", len(args)-4)
+	conditions = append(conditions,
+		fmt.Sprintf("%s < $%d", due, len(args)-3),
+		fmt.Sprintf("COALESCE(%s, $%d) > %s", slaMetAt(target), len(args)-3, due),
+		fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM sla_breaches b
+			WHERE b.policy_id = $%d AND b.alert_id = alerts.id AND b.target = $%d
+		)`, len(args)-2, len(args)-1),
+	)
+
+	query := fmt.Sprintf(`
+		SELECT * FROM alerts%s
+		ORDER BY created_at
+		LIMIT $%d
+	`, whereClause(conditions), len(args))
+
+	var models []AlertModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	alerts := make([]*entity.Alert, 0, len(models))
+	for i := range models {
+		alert, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+// RecordBreach saves a breach unless it was already recorded.
+func (r *PostgresSLARepository) RecordBreach(ctx context.Context, breach *entity.SLABreach) (bool, error) {
+	query := `
+		INSERT INTO sla_breaches (id, policy_id, alert_id, target, due_at, breached_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (policy_id, alert_id, target) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		breach.ID,
+		breach.PolicyID,
+		breach.AlertID,
+		string(breach.Target),
+		breach.DueAt,
+		breach.BreachedAt,
+	)
+	if err != nil {
+		return false, TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, TranslateError(err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Compliance measures how the alerts matching a policy met each of its
+// targets.
+func (r *PostgresSLARepository) Compliance(
+	ctx context.Context,
+	policy *entity.SLAPolicy,
+	now time.Time,
+	filter valueobject.AlertFilter,
+) (*repository.SLACompliance, error) {
+	compliance := &repository.SLACompliance{}
+
+	for _, target := range policy.Targets() {
+		result, err := r.targetCompliance(ctx, policy, target, now, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		switch target {
+		case entity.SLATargetAck:
+			compliance.Ack = result
+		case entity.SLATargetResolve:
+			compliance.Resolve = result
+		}
+	}
+
+	return compliance, nil
+}
+
+// targetCompliance measures how the alerts matching a policy and the filter
+// met a target.
+func (r *PostgresSLARepository) targetCompliance(
+	ctx context.Context,
+	policy *entity.SLAPolicy,
+	target entity.SLATarget,
+	now time.Time,
+	filter valueobject.AlertFilter,
+) (*repository.SLATargetCompliance, error) {
+	within, _ := policy.Within(target)
+
+	filterConditions, filterArgs := alertFilterConditions(filter)
+	conditions, args := slaPolicyConditions(policy, filterConditions, filterArgs)
+	conditions = append(conditions, "deleted_at IS NULL")
+	args = append(args, within.Seconds(), now)
+
+	query := fmt.Sprintf(`
+		WITH matched AS (
+			SELECT created_at, %s AS met_at, created_at + make_interval(secs => $%d) AS due_at
+			FROM alerts%s
+		)
+		SELECT
+			COUNT(*) AS total_alerts,
+			COUNT(*) FILTER (WHERE met_at <= due_at) AS met_alerts,
+			COUNT(*) FILTER (WHERE due_at < $%d AND (met_at IS NULL OR met_at > due_at)) AS breached_alerts,
+			COUNT(*) FILTER (WHERE due_at >= $%d AND met_at IS NULL) AS pending_alerts,
+			COALESCE(AVG(EXTRACT(EPOCH FROM met_at - created_at)) FILTER (WHERE met_at IS NOT NULL), 0) AS mean_seconds
+		FROM matched
+	`, slaMetAt(target), len(args)-1, whereClause(conditions), len(args), len(args))
+
+	var result repository.SLATargetCompliance
+	if err := r.db.GetContext(ctx, &result, query, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	if settled := result.MetAlerts + result.BreachedAlerts; settled > 0 {
+		result.CompliancePercent = float64(result.MetAlerts) * 100 / float64(settled)
+	}
+
+	return &result, nil
+}
+
+// slaPolicyConditions adds the conditions selecting the alerts a policy
+// applies to, and their arguments, to those given.
+func slaPolicyConditions(
+	policy *entity.SLAPolicy,
+	conditions []string,
+	args []interface{},
+) ([]string, []interface{}) {
+	args = append(args, entity.SLABreachSource, policy.CreatedAt)
+	conditions = append(conditions,
+		fmt.Sprintf("source <> $%d", len(args)-1),
+		fmt.Sprintf("created_at >= $%d", len(args)),
+	)
+
+	if policy.Severity != "" {
+		args = append(args, string(policy.Severity))
+		conditions = append(conditions, fmt.Sprintf("severity = $%d", len(args)))
+	}
+	if policy.TeamID != nil {
+		args = append(args, policy.TeamID.String())
+		conditions = append(conditions, fmt.Sprintf("team_id = $%d", len(args)))
+	}
+	if policy.Source != "" {
+		args = append(args, policy.Source)
+		conditions = append(conditions, fmt.Sprintf("source = $%d", len(args)))
+	}
+
+	return conditions, args
+}
+
+// slaMetAt returns the expression of when an alert met a target: resolving
+// an alert also meets its acknowledgement target.
+func slaMetAt(target entity.SLATarget) string {
+	if target == entity.SLATargetAck {
+		return "COALESCE(acknowledged_at, resolved_at)"
+	}
+	return "resolved_at"
+}
+
+// slaPoliciesFromModels converts database models to domain entities.
+func slaPoliciesFromModels(models []SLAPolicyModel) ([]*entity.SLAPolicy, error) {
+	policies := make([]*entity.SLAPolicy, 0, len(models))
+	for i := range models {
+		policy, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
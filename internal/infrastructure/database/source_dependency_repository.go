@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// maxDependencyDepth bounds how far the dependency graph is walked upstream,
+// which also stops the walk should the graph hold a cycle.
+const maxDependencyDepth = 10
+
+// upstreamSources selects, as upstream(source, depth), the sources $1
+// depends on, directly or not.
+const upstreamSources = `
+	WITH RECURSIVE upstream(source, depth) AS (
+		SELECT depends_on, 1 FROM source_dependencies WHERE source = $1
+		UNION
+		SELECT d.depends_on, u.depth + 1
+		FROM source_dependencies d
+		JOIN upstream u ON d.source = u.source
+		WHERE u.depth < $2
+	)
+`
+
+// Ensure PostgresSourceDependencyRepository implements repository.SourceDependencyRepository
+var _ repository.SourceDependencyRepository = (*PostgresSourceDependencyRepository)(nil)
+
+// PostgresSourceDependencyRepository implements SourceDependencyRepository using PostgreSQL.
+type PostgresSourceDependencyRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresSourceDependencyRepository creates a new PostgreSQL source dependency repository.
+func NewPostgresSourceDependencyRepository(db *PostgresDB) *PostgresSourceDependencyRepository {
+	return &PostgresSourceDependencyRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new source dependency to the database.
+func (r *PostgresSourceDependencyRepository) Create(ctx context.Context, dependency *entity.SourceDependency) error {
+	query := `
+		INSERT INTO source_dependencies (id, source, depends_on, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		dependency.ID,
+		dependency.Source,
+		dependency.DependsOn,
+		optionalID(dependency.CreatedBy),
+		dependency.CreatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// Delete removes a source dependency by its ID.
+func (r *PostgresSourceDependencyRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM source_dependencies WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated source dependencies.
+func (r *PostgresSourceDependencyRepository) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.SourceDependency], error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM source_dependencies`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT * FROM source_dependencies
+		ORDER BY source, depends_on
+		LIMIT $1 OFFSET $2
+	`
+
+	var models []SourceDependencyModel
+	if err := r.db.SelectContext(ctx, &models, query, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	dependencies := make([]*entity.SourceDependency, 0, len(models))
+	for i := range models {
+		dependency, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	result := valueobject.NewPaginatedResult(dependencies, total, pagination)
+	return &result, nil
+}
+
+// ListUpstream returns the sources the source depends on, nearest first.
+func (r *PostgresSourceDependencyRepository) ListUpstream(ctx context.Context, source string) ([]string, error) {
+	query := upstreamSources + `
+		SELECT source FROM upstream
+		WHERE source <> $1
+		GROUP BY source
+		ORDER BY MIN(depth), source
+	`
+
+	var sources []string
+	if err := r.db.SelectContext(ctx, &sources, query, source, maxDependencyDepth); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return sources, nil
+}
+
+// GetUpstreamAlert returns the oldest open alert of the severity raised by
+// a source upstream of the source.
+func (r *PostgresSourceDependencyRepository) GetUpstreamAlert(
+	ctx context.Context,
+	source string,
+	severity entity.AlertSeverity,
+) (*entity.Alert, error) {
+	query := upstreamSources + `
+		SELECT * FROM alerts
+		WHERE source IN (SELECT source FROM upstream WHERE source <> $1)
+			AND severity = $3 AND status IN ('active', 'acknowledged') AND deleted_at IS NULL
+		ORDER BY created_at
+		LIMIT 1
+	`
+
+	var model AlertModel
+	if err := r.db.GetContext(ctx, &model, query, source, maxDependencyDepth, string(severity)); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return model.ToEntity()
+}
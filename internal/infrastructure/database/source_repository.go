@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresSourceRepository implements repository.SourceRepository
+var _ repository.SourceRepository = (*PostgresSourceRepository)(nil)
+
+// PostgresSourceRepository implements SourceRepository using PostgreSQL.
+type PostgresSourceRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresSourceRepository creates a new PostgreSQL source repository.
+func NewPostgresSourceRepository(db *PostgresDB) *PostgresSourceRepository {
+	return &PostgresSourceRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new source to the database.
+func (r *PostgresSourceRepository) Create(ctx context.Context, source *entity.Source) error {
+	query := `
+		INSERT INTO sources (
+			id, name, type, description, expected_heartbeat_seconds, severity, status, silent_since,
+			team_id, created_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		source.ID,
+		source.Name,
+		string(source.Type),
+		source.Description,
+		source.ExpectedHeartbeatSeconds,
+		string(source.Severity),
+		string(source.Status),
+		source.SilentSince,
+		optionalID(source.TeamID),
+		optionalID(source.CreatedBy),
+		source.CreatedAt,
+		source.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a source by its ID.
+func (r *PostgresSourceRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Source, error) {
+	var model SourceModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM sources WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing source.
+func (r *PostgresSourceRepository) Update(ctx context.Context, source *entity.Source) error {
+	query := `
+		UPDATE sources
+		SET name = $2, type = $3, description = $4, expected_heartbeat_seconds = $5, severity = $6,
+			status = $7, silent_since = $8, team_id = $9, updated_at = $10
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		source.ID,
+		source.Name,
+		string(source.Type),
+		source.Description,
+		source.ExpectedHeartbeatSeconds,
+		string(source.Severity),
+		string(source.Status),
+		source.SilentSince,
+		optionalID(source.TeamID),
+		source.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a source by its ID.
+func (r *PostgresSourceRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM sources WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated sources of the tenant scope: those of its teams and
+// those owned by no team.
+func (r *PostgresSourceRepository) List(
+	ctx context.Context,
+	tenant valueobject.TenantScope,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Source], error) {
+	var conditions []string
+	var args []interface{}
+	if !tenant.IsAll() {
+		teams := tenant.Teams()
+		if len(teams) == 0 {
+			conditions = append(conditions, "team_id IS NULL")
+		} else {
+			placeholders := make([]string, len(teams))
+			for i, teamID := range teams {
+				args = append(args, teamID.String())
+				placeholders[i] = fmt.Sprintf("$%d", len(args))
+			}
+			conditions = append(conditions, fmt.Sprintf("(team_id IS NULL OR team_id IN (%s))", strings.Join(placeholders, ",")))
+		}
+	}
+	where := whereClause(conditions)
+
+	var total int64
+	if err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM sources"+where, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := fmt.Sprintf(`SELECT * FROM sources%s ORDER BY name LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+	args = append(args, pagination.Limit(), pagination.Offset())
+
+	var models []SourceModel
+	if err := r.db.SelectContext(ctx, &models, query, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	sources, err := sourcesFromModels(models)
+	if err != nil {
+		return nil, err
+	}
+
+	result := valueobject.NewPaginatedResult(sources, total, pagination)
+	return &result, nil
+}
+
+// ListMonitored returns the sources with an expected heartbeat.
+func (r *PostgresSourceRepository) ListMonitored(ctx context.Context) ([]*entity.Source, error) {
+	var models []SourceModel
+	query := `SELECT * FROM sources WHERE expected_heartbeat_seconds > 0 ORDER BY name`
+	if err := r.db.SelectContext(ctx, &models, query); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return sourcesFromModels(models)
+}
+
+// Activity returns the last alert time and the alert rates of the named
+// sources, counting every alert they sent, including those in the trash.
+func (r *PostgresSourceRepository) Activity(
+	ctx context.Context,
+	names []string,
+	now time.Time,
+) (map[string]repository.SourceActivity, error) {
+	activity := make(map[string]repository.SourceActivity, len(names))
+	if len(names) == 0 {
+		return activity, nil
+	}
+
+	args := []interface{}{now.Add(-time.Hour), now.Add(-24 * time.Hour)}
+	placeholders := make([]string, len(names))
+	for i, name := range names {
+		args = append(args, name)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			source,
+			MAX(created_at) AS last_alert_at,
+			COUNT(*) FILTER (WHERE created_at >= $1) AS alerts_last_hour,
+			COUNT(*) FILTER (WHERE created_at >= $2) AS alerts_last_day
+		FROM alerts
+		WHERE source IN (%s)
+		GROUP BY source
+	`, strings.Join(placeholders, ","))
+
+	var rows []struct {
+		Source string `db:"source"`
+		repository.SourceActivity
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	for _, row := range rows {
+		activity[row.Source] = row.SourceActivity
+	}
+
+	return activity, nil
+}
+
+// sourcesFromModels converts database models to domain entities.
+func sourcesFromModels(models []SourceModel) ([]*entity.Source, error) {
+	sources := make([]*entity.Source, 0, len(models))
+	for i := range models {
+		source, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
@@ -0,0 +1,418 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresSubscriptionRepository implements repository.SubscriptionRepository
+var _ repository.SubscriptionRepository = (*PostgresSubscriptionRepository)(nil)
+
+// PostgresSubscriptionRepository implements SubscriptionRepository using PostgreSQL.
+type PostgresSubscriptionRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresSubscriptionRepository creates a new PostgreSQL subscription repository.
+func NewPostgresSubscriptionRepository(db *PostgresDB) *PostgresSubscriptionRepository {
+	return &PostgresSubscriptionRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new subscription to the database.
+func (r *PostgresSubscriptionRepository) Create(ctx context.Context, subscription *entity.Subscription) error {
+	query := `
+		INSERT INTO subscriptions (
+			id, name, url, secret, event_types, filter, is_enabled, created_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	eventTypes, filter, err := marshalSubscription(subscription)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		subscription.ID,
+		subscription.Name,
+		subscription.URL,
+		subscription.Secret,
+		eventTypes,
+		filter,
+		subscription.IsEnabled,
+		optionalID(subscription.CreatedBy),
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a subscription by its ID.
+func (r *PostgresSubscriptionRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Subscription, error) {
+	var model SubscriptionModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM subscriptions WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing subscription.
+func (r *PostgresSubscriptionRepository) Update(ctx context.Context, subscription *entity.Subscription) error {
+	query := `
+		UPDATE subscriptions
+		SET name = $2, url = $3, secret = $4, event_types = $5, filter = $6, is_enabled = $7, updated_at = $8
+		WHERE id = $1
+	`
+
+	eventTypes, filter, err := marshalSubscription(subscription)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		subscription.ID,
+		subscription.Name,
+		subscription.URL,
+		subscription.Secret,
+		eventTypes,
+		filter,
+		subscription.IsEnabled,
+		subscription.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a subscription by its ID; its deliveries are removed by cascade.
+func (r *PostgresSubscriptionRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated subscriptions.
+func (r *PostgresSubscriptionRepository) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Subscription], error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM subscriptions`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT * FROM subscriptions
+		ORDER BY name
+		LIMIT $1 OFFSET $2
+	`
+
+	var models []SubscriptionModel
+	if err := r.db.SelectContext(ctx, &models, query, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	subscriptions, err := subscriptionsFromModels(models)
+	if err != nil {
+		return nil, err
+	}
+
+	result := valueobject.NewPaginatedResult(subscriptions, total, pagination)
+	return &result, nil
+}
+
+// ListEnabled returns the enabled subscriptions.
+func (r *PostgresSubscriptionRepository) ListEnabled(ctx context.Context) ([]*entity.Subscription, error) {
+	var models []SubscriptionModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM subscriptions WHERE is_enabled ORDER BY name`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return subscriptionsFromModels(models)
+}
+
+// CreateDeliveries saves new deliveries in a single transaction.
+func (r *PostgresSubscriptionRepository) CreateDeliveries(ctx context.Context, deliveries []*entity.SubscriptionDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO subscription_deliveries (
+			id, subscription_id, event_type, payload, status, attempts, next_attempt_at,
+			created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	return r.db.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, delivery := range deliveries {
+			_, err := r.db.ExecContext(ctx, query,
+				delivery.ID,
+				delivery.SubscriptionID,
+				delivery.EventType,
+				[]byte(delivery.Payload),
+				string(delivery.Status),
+				delivery.Attempts,
+				delivery.NextAttemptAt,
+				delivery.CreatedAt,
+				delivery.UpdatedAt,
+			)
+			if err != nil {
+				return TranslateError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetDelivery finds a delivery of a subscription by its ID.
+func (r *PostgresSubscriptionRepository) GetDelivery(
+	ctx context.Context,
+	subscriptionID, id entity.ID,
+) (*entity.SubscriptionDelivery, error) {
+	query := `SELECT * FROM subscription_deliveries WHERE id = $1 AND subscription_id = $2`
+
+	var model SubscriptionDeliveryModel
+	if err := r.db.GetContext(ctx, &model, query, id, subscriptionID); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// ClaimDue postpones the pending deliveries due at now by lease and returns
+// them. Rows locked by another instance claiming concurrently are skipped.
+func (r *PostgresSubscriptionRepository) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]*entity.SubscriptionDelivery, error) {
+	query := `
+		UPDATE subscription_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM subscription_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *
+	`
+
+	var models []SubscriptionDeliveryModel
+	if err := r.db.SelectContext(ctx, &models, query, now, now.Add(lease), limit); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return deliveriesFromModels(models)
+}
+
+// UpdateDelivery saves the outcome of the attempts of a delivery.
+func (r *PostgresSubscriptionRepository) UpdateDelivery(ctx context.Context, delivery *entity.SubscriptionDelivery) error {
+	query := `
+		UPDATE subscription_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6,
+			delivered_at = $7, updated_at = $8
+		WHERE id = $1
+	`
+
+	var statusCode *int
+	if delivery.LastStatusCode != 0 {
+		statusCode = &delivery.LastStatusCode
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		delivery.ID,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		statusCode,
+		optionalString(delivery.LastError),
+		delivery.DeliveredAt,
+		delivery.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the deliveries of a subscription, newest first.
+func (r *PostgresSubscriptionRepository) ListDeliveries(
+	ctx context.Context,
+	subscriptionID entity.ID,
+	status *entity.DeliveryStatus,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.SubscriptionDelivery], error) {
+	var statusFilter *string
+	if status != nil {
+		value := string(*status)
+		statusFilter = &value
+	}
+
+	var total int64
+	countQuery := `
+		SELECT COUNT(*) FROM subscription_deliveries
+		WHERE subscription_id = $1 AND ($2::text IS NULL OR status = $2)
+	`
+	if err := r.db.GetContext(ctx, &total, countQuery, subscriptionID, statusFilter); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT * FROM subscription_deliveries
+		WHERE subscription_id = $1 AND ($2::text IS NULL OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	var models []SubscriptionDeliveryModel
+	if err := r.db.SelectContext(ctx, &models, query,
+		subscriptionID, statusFilter, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	deliveries, err := deliveriesFromModels(models)
+	if err != nil {
+		return nil, err
+	}
+
+	result := valueobject.NewPaginatedResult(deliveries, total, pagination)
+	return &result, nil
+}
+
+// Stats summarizes the deliveries of a subscription.
+func (r *PostgresSubscriptionRepository) Stats(ctx context.Context, subscriptionID entity.ID) (repository.SubscriptionStats, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+			COUNT(*) FILTER (WHERE status = 'delivered') AS delivered,
+			COUNT(*) FILTER (WHERE status = 'failed') AS failed,
+			COALESCE(SUM(attempts), 0) AS attempts,
+			MAX(delivered_at) AS last_delivered_at,
+			COALESCE((
+				SELECT last_error FROM subscription_deliveries
+				WHERE subscription_id = $1 AND last_error IS NOT NULL
+				ORDER BY updated_at DESC
+				LIMIT 1
+			), '') AS last_error
+		FROM subscription_deliveries
+		WHERE subscription_id = $1
+	`
+
+	var row struct {
+		Pending         int64      `db:"pending"`
+		Delivered       int64      `db:"delivered"`
+		Failed          int64      `db:"failed"`
+		Attempts        int64      `db:"attempts"`
+		LastDeliveredAt *time.Time `db:"last_delivered_at"`
+		LastError       string     `db:"last_error"`
+	}
+	if err := r.db.GetContext(ctx, &row, query, subscriptionID); err != nil {
+		return repository.SubscriptionStats{}, TranslateError(err)
+	}
+
+	return repository.SubscriptionStats{
+		Pending:         row.Pending,
+		Delivered:       row.Delivered,
+		Failed:          row.Failed,
+		Attempts:        row.Attempts,
+		LastDeliveredAt: row.LastDeliveredAt,
+		LastError:       row.LastError,
+	}, nil
+}
+
+// DeleteDeliveriesBefore removes the finished deliveries last updated before before.
+func (r *PostgresSubscriptionRepository) DeleteDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM subscription_deliveries WHERE status <> 'pending' AND updated_at < $1`, before)
+	if err != nil {
+		return 0, TranslateError(err)
+	}
+
+	return result.RowsAffected()
+}
+
+// marshalSubscription encodes the JSONB columns of a subscription.
+func marshalSubscription(subscription *entity.Subscription) ([]byte, []byte, error) {
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter, err := json.Marshal(subscription.Filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return eventTypes, filter, nil
+}
+
+// subscriptionsFromModels converts database models to domain entities.
+func subscriptionsFromModels(models []SubscriptionModel) ([]*entity.Subscription, error) {
+	subscriptions := make([]*entity.Subscription, 0, len(models))
+	for i := range models {
+		subscription, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
+
+// deliveriesFromModels converts database models to domain entities.
+func deliveriesFromModels(models []SubscriptionDeliveryModel) ([]*entity.SubscriptionDelivery, error) {
+	deliveries := make([]*entity.SubscriptionDelivery, 0, len(models))
+	for i := range models {
+		delivery, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresTeamRepository implements repository.TeamRepository
+var _ repository.TeamRepository = (*PostgresTeamRepository)(nil)
+
+// PostgresTeamRepository implements TeamRepository using PostgreSQL.
+type PostgresTeamRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresTeamRepository creates a new PostgreSQL team repository.
+func NewPostgresTeamRepository(db *PostgresDB) *PostgresTeamRepository {
+	return &PostgresTeamRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new team to the database.
+func (r *PostgresTeamRepository) Create(ctx context.Context, team *entity.Team) error {
+	query := `
+		INSERT INTO teams (id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		team.ID,
+		team.Name,
+		team.Description,
+		team.CreatedAt,
+		team.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a team by its ID.
+func (r *PostgresTeamRepository) GetByID(ctx context.Context, id entity.ID) (*entity.Team, error) {
+	var model TeamModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM teams WHERE id = $1`, id); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing team.
+func (r *PostgresTeamRepository) Update(ctx context.Context, team *entity.Team) error {
+	query := `
+		UPDATE teams
+		SET name = $2, description = $3, updated_at = $4
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		team.ID,
+		team.Name,
+		team.Description,
+		team.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a team by its ID. Memberships are removed by cascade.
+func (r *PostgresTeamRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM teams WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated teams ordered by name.
+func (r *PostgresTeamRepository) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Team], error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM teams`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT * FROM teams
+		ORDER BY name
+		LIMIT $1 OFFSET $2
+	`
+
+	var models []TeamModel
+	if err := r.db.SelectContext(ctx, &models, query, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return teamPage(models, total, pagination)
+}
+
+// ListByUser returns paginated teams the user is a member of, ordered by name.
+func (r *PostgresTeamRepository) ListByUser(
+	ctx context.Context,
+	userID entity.ID,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Team], error) {
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM team_members WHERE user_id = $1`
+	if err := r.db.GetContext(ctx, &total, countQuery, userID); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT t.* FROM teams t
+		JOIN team_members m ON m.team_id = t.id
+		WHERE m.user_id = $1
+		ORDER BY t.name
+		LIMIT $2 OFFSET $3
+	`
+
+	var models []TeamModel
+	if err := r.db.SelectContext(ctx, &models, query, userID, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return teamPage(models, total, pagination)
+}
+
+// ListTeamIDs returns the IDs of every team the user is a member of.
+func (r *PostgresTeamRepository) ListTeamIDs(ctx context.Context, userID entity.ID) ([]entity.ID, error) {
+	var ids []string
+	if err := r.db.SelectContext(ctx, &ids, `SELECT team_id FROM team_members WHERE user_id = $1`, userID); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	teamIDs := make([]entity.ID, 0, len(ids))
+	for _, raw := range ids {
+		id, err := entity.ParseID(raw)
+		if err != nil {
+			return nil, err
+		}
+		teamIDs = append(teamIDs, id)
+	}
+
+	return teamIDs, nil
+}
+
+// SaveMember adds a member to a team or updates their role.
+func (r *PostgresTeamRepository) SaveMember(ctx context.Context, member *entity.TeamMember) error {
+	query := `
+		INSERT INTO team_members (team_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (team_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		member.TeamID,
+		member.UserID,
+		string(member.Role),
+		member.JoinedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetMember finds the membership of a user in a team.
+func (r *PostgresTeamRepository) GetMember(ctx context.Context, teamID, userID entity.ID) (*entity.TeamMember, error) {
+	query := `SELECT * FROM team_members WHERE team_id = $1 AND user_id = $2`
+
+	var model TeamMemberModel
+	if err := r.db.GetContext(ctx, &model, query, teamID, userID); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// RemoveMember removes a user from a team.
+func (r *PostgresTeamRepository) RemoveMember(ctx context.Context, teamID, userID entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM team_members WHERE team_id = $1 AND user_id = $2`, teamID, userID)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListMembers returns the members of a team, owners first.
+func (r *PostgresTeamRepository) ListMembers(ctx context.Context, teamID entity.ID) ([]*entity.TeamMember, error) {
+	query := `
+		SELECT * FROM team_members
+		WHERE team_id = $1
+		ORDER BY role = 'owner' DESC, joined_at
+	`
+
+	var models []TeamMemberModel
+	if err := r.db.SelectContext(ctx, &models, query, teamID); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	members := make([]*entity.TeamMember, 0, len(models))
+	for i := range models {
+		member, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, nil
+}
+
+// CountOwners returns the number of owners of a team.
+func (r *PostgresTeamRepository) CountOwners(ctx context.Context, teamID entity.ID) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(*) FROM team_members WHERE team_id = $1 AND role = 'owner'`
+	if err := r.db.GetContext(ctx, &count, query, teamID); err != nil {
+		return 0, TranslateError(err)
+	}
+	return count, nil
+}
+
+// teamPage converts a page of team models into a paginated result.
+func teamPage(models []TeamModel, total int64, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.Team], error) {
+	teams := make([]*entity.Team, 0, len(models))
+	for i := range models {
+		team, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+
+	result := valueobject.NewPaginatedResult(teams, total, pagination)
+	return &result, nil
+}
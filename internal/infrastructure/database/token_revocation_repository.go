@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// revokedTokensKey is the sorted set of revoked token IDs, scored by the
+// time their token expires.
+const revokedTokensKey = "revoked_tokens"
+
+// Ensure RedisTokenRevocationRepository implements repository.TokenRevocationRepository
+var _ repository.TokenRevocationRepository = (*RedisTokenRevocationRepository)(nil)
+
+// RedisTokenRevocationRepository implements TokenRevocationRepository using
+// a Redis sorted set, trimmed of expired tokens on every revocation.
+type RedisTokenRevocationRepository struct {
+	client *redis.Client
+}
+
+// NewRedisTokenRevocationRepository creates a new Redis token revocation repository.
+func NewRedisTokenRevocationRepository(redisClient *RedisClient) *RedisTokenRevocationRepository {
+	return &RedisTokenRevocationRepository{
+		client: redisClient.Client(),
+	}
+}
+
+// Revoke marks a token revoked until it expires.
+func (r *RedisTokenRevocationRepository) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if !expiresAt.After(time.Now()) {
+		return nil
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.ZAdd(ctx, revokedTokensKey, redis.Z{Score: float64(expiresAt.Unix()), Member: tokenID})
+	pipe.ZRemRangeByScore(ctx, revokedTokensKey, "-inf", "("+nowScore())
+
+	_, err := pipe.Exec(ctx)
+	return translateRedisError(err)
+}
+
+// IsRevoked checks whether a token was revoked.
+func (r *RedisTokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	score, err := r.client.ZScore(ctx, revokedTokensKey, tokenID).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, translateRedisError(err)
+	}
+
+	return int64(score) >= time.Now().Unix(), nil
+}
+
+// ListRevoked returns the IDs of the revoked tokens that did not expire yet.
+func (r *RedisTokenRevocationRepository) ListRevoked(ctx context.Context) ([]string, error) {
+	ids, err := r.client.ZRangeByScore(ctx, revokedTokensKey, &redis.ZRangeBy{
+		Min: nowScore(),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, translateRedisError(err)
+	}
+
+	return ids, nil
+}
+
+// nowScore returns the score of tokens expiring now.
+func nowScore() string {
+	return strconv.FormatInt(time.Now().Unix(), 10)
+}
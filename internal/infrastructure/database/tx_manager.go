@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// Ensure PostgresTxManager implements repository.TxManager
+var _ repository.TxManager = (*PostgresTxManager)(nil)
+
+// txKey is the context key of the current transaction.
+type txKey struct{}
+
+// executor is the part of sqlx shared by connections and transactions.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// PostgresTxManager implements TxManager using PostgreSQL transactions.
+type PostgresTxManager struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresTxManager creates a new PostgreSQL transaction manager.
+func NewPostgresTxManager(db *PostgresDB) *PostgresTxManager {
+	return &PostgresTxManager{db: db.Instrumented()}
+}
+
+// WithinTransaction calls fn in a transaction shared by the repositories.
+func (m *PostgresTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.db.WithinTransaction(ctx, fn)
+}
+
+// WithinTransaction calls fn in a transaction, committed if fn returns nil
+// and rolled back otherwise or if fn panics. Queries made with the context
+// given to fn run in the transaction; if ctx already carries one, fn joins it.
+func (db *InstrumentedDB) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return TranslateError(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return TranslateError(tx.Commit())
+}
+
+// executor returns the transaction of the context, or the connection pool.
+func (db *InstrumentedDB) executor(ctx context.Context) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db.DB
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// Ensure PostgresUserIdentityRepository implements repository.UserIdentityRepository
+var _ repository.UserIdentityRepository = (*PostgresUserIdentityRepository)(nil)
+
+// PostgresUserIdentityRepository implements UserIdentityRepository using PostgreSQL.
+type PostgresUserIdentityRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresUserIdentityRepository creates a new PostgreSQL user identity repository.
+func NewPostgresUserIdentityRepository(db *PostgresDB) *PostgresUserIdentityRepository {
+	return &PostgresUserIdentityRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new user identity to the database.
+func (r *PostgresUserIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, external_id, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.ExternalID,
+		identity.CreatedBy,
+		identity.CreatedAt,
+		identity.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// Get finds the identity of a user on a provider.
+func (r *PostgresUserIdentityRepository) Get(ctx context.Context, userID entity.ID, provider string) (*entity.UserIdentity, error) {
+	var model UserIdentityModel
+	query := `SELECT * FROM user_identities WHERE user_id = $1 AND provider = $2`
+	if err := r.db.GetContext(ctx, &model, query, userID, provider); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// GetByExternalID finds the identity of a provider account.
+func (r *PostgresUserIdentityRepository) GetByExternalID(
+	ctx context.Context,
+	provider, externalID string,
+) (*entity.UserIdentity, error) {
+	var model UserIdentityModel
+	query := `SELECT * FROM user_identities WHERE provider = $1 AND external_id = $2`
+	if err := r.db.GetContext(ctx, &model, query, provider, externalID); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing user identity.
+func (r *PostgresUserIdentityRepository) Update(ctx context.Context, identity *entity.UserIdentity) error {
+	query := `
+		UPDATE user_identities
+		SET external_id = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, identity.ID, identity.ExternalID, identity.UpdatedAt)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the identity of a user on a provider.
+func (r *PostgresUserIdentityRepository) Delete(ctx context.Context, userID entity.ID, provider string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM user_identities WHERE user_id = $1 AND provider = $2`, userID, provider)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListByUser returns the identities of a user.
+func (r *PostgresUserIdentityRepository) ListByUser(ctx context.Context, userID entity.ID) ([]*entity.UserIdentity, error) {
+	var models []UserIdentityModel
+	query := `SELECT * FROM user_identities WHERE user_id = $1 ORDER BY provider`
+	if err := r.db.SelectContext(ctx, &models, query, userID); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	identities := make([]*entity.UserIdentity, 0, len(models))
+	for i := range models {
+		identity, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, nil
+}
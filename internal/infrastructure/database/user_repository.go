@@ -5,8 +5,6 @@ package database
 import (
 	"context"
 
-	"github.com/jmoiron/sqlx"
-
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
@@ -17,13 +15,13 @@ var _ repository.UserRepository = (*PostgresUserRepository)(nil)
 
 // PostgresUserRepository implements UserRepository using PostgreSQL.
 type PostgresUserRepository struct {
-	db *sqlx.DB
+	db *InstrumentedDB
 }
 
 // NewPostgresUserRepository creates a new PostgreSQL user repository.
 func NewPostgresUserRepository(db *PostgresDB) *PostgresUserRepository {
 	return &PostgresUserRepository{
-		db: db.DB,
+		db: db.Instrumented(),
 	}
 }
 
@@ -83,11 +81,12 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 	return &user, nil
 }
 
-// Update updates an existing user.
+// Update updates an existing user. An empty password hash keeps the
+// current one, as users read from the cache have none.
 func (r *PostgresUserRepository) Update(ctx context.Context, user *entity.User) error {
 	query := `
 		UPDATE users
-		SET email = $2, password_hash = $3, name = $4, role = $5, is_active = $6, last_login_at = $7, updated_at = $8
+		SET email = $2, password_hash = COALESCE(NULLIF($3, ''), password_hash), name = $4, role = $5, is_active = $6, last_login_at = $7, updated_at = $8
 		WHERE id = $1
 	`
 
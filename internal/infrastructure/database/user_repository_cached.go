@@ -20,6 +20,7 @@ const (
 var _ repository.UserRepository = (*CachedUserRepository)(nil)
 
 // CachedUserRepository wraps PostgresUserRepository with Redis caching.
+// Given a TieredCacheRepository, users are also kept in process.
 type CachedUserRepository struct {
 	postgres *PostgresUserRepository
 	cache    repository.CacheRepository
@@ -65,29 +66,10 @@ func (r *CachedUserRepository) GetByID(ctx context.Context, id entity.ID) (*enti
 	return dbUser, nil
 }
 
-// GetByEmail finds a user by email, using cache when available.
+// GetByEmail finds a user by email. It is not cached: logins look users up
+// by email and need their password hash, which cached users leave out.
 func (r *CachedUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
-	cacheKey := r.keys.UserByEmail(email)
-
-	// Try cache first
-	var user entity.User
-	err := r.cache.Get(ctx, cacheKey, &user)
-	if err == nil {
-		return &user, nil
-	}
-
-	// Cache miss - get from database
-	dbUser, err := r.postgres.GetByEmail(ctx, email)
-	if err != nil {
-		return nil, err
-	}
-
-	// Store in cache
-	if cacheErr := r.cache.Set(ctx, cacheKey, dbUser, userCacheTTL); cacheErr != nil {
-		log.Warn().Err(cacheErr).Str("key", cacheKey).Msg("Failed to cache user")
-	}
-
-	return dbUser, nil
+	return r.postgres.GetByEmail(ctx, email)
 }
 
 // Update updates a user and invalidates cache.
@@ -129,10 +111,6 @@ func (r *CachedUserRepository) invalidateUserCache(ctx context.Context, user *en
 		log.Warn().Err(err).Msg("Failed to invalidate user cache by ID")
 	}
 
-	// Delete by email
-	if err := r.cache.Delete(ctx, r.keys.UserByEmail(user.Email)); err != nil {
-		log.Warn().Err(err).Msg("Failed to invalidate user cache by email")
-	}
 }
 
 // List returns paginated users (not cached - lists change frequently).
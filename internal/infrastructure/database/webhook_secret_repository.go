@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// Ensure PostgresWebhookSecretRepository implements repository.WebhookSecretRepository
+var _ repository.WebhookSecretRepository = (*PostgresWebhookSecretRepository)(nil)
+
+// PostgresWebhookSecretRepository implements WebhookSecretRepository using PostgreSQL.
+type PostgresWebhookSecretRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresWebhookSecretRepository creates a new PostgreSQL webhook secret repository.
+func NewPostgresWebhookSecretRepository(db *PostgresDB) *PostgresWebhookSecretRepository {
+	return &PostgresWebhookSecretRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new webhook secret to the database.
+func (r *PostgresWebhookSecretRepository) Create(ctx context.Context, secret *entity.WebhookSecret) error {
+	query := `
+		INSERT INTO webhook_secrets (id, integration, secret, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		secret.ID,
+		secret.Integration,
+		secret.Secret,
+		secret.CreatedBy,
+		secret.CreatedAt,
+		secret.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByIntegration finds the secret of an integration.
+func (r *PostgresWebhookSecretRepository) GetByIntegration(ctx context.Context, integration string) (*entity.WebhookSecret, error) {
+	var model WebhookSecretModel
+	if err := r.db.GetContext(ctx, &model, `SELECT * FROM webhook_secrets WHERE integration = $1`, integration); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
+
+// Update updates an existing webhook secret.
+func (r *PostgresWebhookSecretRepository) Update(ctx context.Context, secret *entity.WebhookSecret) error {
+	query := `
+		UPDATE webhook_secrets
+		SET secret = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, secret.ID, secret.Secret, secret.UpdatedAt)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the secret of an integration.
+func (r *PostgresWebhookSecretRepository) Delete(ctx context.Context, integration string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_secrets WHERE integration = $1`, integration)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns every configured webhook secret.
+func (r *PostgresWebhookSecretRepository) List(ctx context.Context) ([]*entity.WebhookSecret, error) {
+	var models []WebhookSecretModel
+	if err := r.db.SelectContext(ctx, &models, `SELECT * FROM webhook_secrets ORDER BY integration`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	secrets := make([]*entity.WebhookSecret, 0, len(models))
+	for i := range models {
+		secret, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Ensure PostgresWebhookSourceRepository implements repository.WebhookSourceRepository
+var _ repository.WebhookSourceRepository = (*PostgresWebhookSourceRepository)(nil)
+
+// PostgresWebhookSourceRepository implements WebhookSourceRepository using PostgreSQL.
+type PostgresWebhookSourceRepository struct {
+	db *InstrumentedDB
+}
+
+// NewPostgresWebhookSourceRepository creates a new PostgreSQL webhook source repository.
+func NewPostgresWebhookSourceRepository(db *PostgresDB) *PostgresWebhookSourceRepository {
+	return &PostgresWebhookSourceRepository{
+		db: db.Instrumented(),
+	}
+}
+
+// Create saves a new webhook source to the database.
+func (r *PostgresWebhookSourceRepository) Create(ctx context.Context, source *entity.WebhookSource) error {
+	mapping, err := json.Marshal(source.Mapping)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhook_sources (id, name, token_hash, mapping, is_enabled, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		source.ID,
+		source.Name,
+		source.TokenHash,
+		mapping,
+		source.IsEnabled,
+		source.CreatedBy,
+		source.CreatedAt,
+		source.UpdatedAt,
+	)
+
+	return TranslateError(err)
+}
+
+// GetByID finds a webhook source by its ID.
+func (r *PostgresWebhookSourceRepository) GetByID(ctx context.Context, id entity.ID) (*entity.WebhookSource, error) {
+	return r.getOne(ctx, `SELECT * FROM webhook_sources WHERE id = $1`, id)
+}
+
+// GetByTokenHash finds a webhook source by the hash of its token.
+func (r *PostgresWebhookSourceRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.WebhookSource, error) {
+	return r.getOne(ctx, `SELECT * FROM webhook_sources WHERE token_hash = $1`, tokenHash)
+}
+
+// Update updates an existing webhook source.
+func (r *PostgresWebhookSourceRepository) Update(ctx context.Context, source *entity.WebhookSource) error {
+	mapping, err := json.Marshal(source.Mapping)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE webhook_sources
+		SET name = $2, token_hash = $3, mapping = $4, is_enabled = $5, updated_at = $6
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		source.ID,
+		source.Name,
+		source.TokenHash,
+		mapping,
+		source.IsEnabled,
+		source.UpdatedAt,
+	)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a webhook source by its ID.
+func (r *PostgresWebhookSourceRepository) Delete(ctx context.Context, id entity.ID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_sources WHERE id = $1`, id)
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return TranslateError(err)
+	}
+
+	if rowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// List returns paginated webhook sources.
+func (r *PostgresWebhookSourceRepository) List(
+	ctx context.Context,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.WebhookSource], error) {
+	var total int64
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM webhook_sources`); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	query := `
+		SELECT * FROM webhook_sources
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	var models []WebhookSourceModel
+	if err := r.db.SelectContext(ctx, &models, query, pagination.Limit(), pagination.Offset()); err != nil {
+		return nil, TranslateError(err)
+	}
+
+	sources := make([]*entity.WebhookSource, 0, len(models))
+	for i := range models {
+		source, err := models[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	result := valueobject.NewPaginatedResult(sources, total, pagination)
+	return &result, nil
+}
+
+// getOne runs a query returning a single webhook source.
+func (r *PostgresWebhookSourceRepository) getOne(ctx context.Context, query string, arg interface{}) (*entity.WebhookSource, error) {
+	var model WebhookSourceModel
+	if err := r.db.GetContext(ctx, &model, query, arg); err != nil {
+		return nil, TranslateError(err)
+	}
+	return model.ToEntity()
+}
@@ -0,0 +1,91 @@
+package document
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icalProductID identifies the system as the producer of calendars.
+const icalProductID = "-//realtime-alerting-system//maintenance//EN"
+
+// icalLineLength is the maximum length of a calendar line in octets,
+// excluding the line break (RFC 5545, section 3.1).
+const icalLineLength = 75
+
+// icalTimeFormat formats times as UTC calendar date-times.
+const icalTimeFormat = "20060102T150405Z"
+
+// icalEscaper escapes the characters with a meaning in calendar text values.
+var icalEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// CalendarEvent is an event of a calendar. The UID identifies it across
+// updates, so calendar clients replace their copy instead of adding another.
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	Modified    time.Time
+}
+
+// ICalendar renders events as an iCalendar (RFC 5545) document named name.
+// Times are written in UTC, so clients show them in their own time zone.
+func ICalendar(name string, events []CalendarEvent, now time.Time) []byte {
+	var buf bytes.Buffer
+
+	writeICalLine(&buf, "BEGIN:VCALENDAR")
+	writeICalLine(&buf, "VERSION:2.0")
+	writeICalLine(&buf, "PRODID:"+icalProductID)
+	writeICalLine(&buf, "CALSCALE:GREGORIAN")
+	writeICalLine(&buf, "METHOD:PUBLISH")
+	writeICalLine(&buf, "X-WR-CALNAME:"+icalEscaper.Replace(name))
+
+	stamp := now.UTC().Format(icalTimeFormat)
+	for _, event := range events {
+		writeICalLine(&buf, "BEGIN:VEVENT")
+		writeICalLine(&buf, "UID:"+event.UID)
+		writeICalLine(&buf, "DTSTAMP:"+stamp)
+		writeICalLine(&buf, "DTSTART:"+event.Start.UTC().Format(icalTimeFormat))
+		writeICalLine(&buf, "DTEND:"+event.End.UTC().Format(icalTimeFormat))
+		writeICalLine(&buf, "SUMMARY:"+icalEscaper.Replace(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&buf, "DESCRIPTION:"+icalEscaper.Replace(event.Description))
+		}
+		if !event.Modified.IsZero() {
+			writeICalLine(&buf, "LAST-MODIFIED:"+event.Modified.UTC().Format(icalTimeFormat))
+		}
+		writeICalLine(&buf, "END:VEVENT")
+	}
+
+	writeICalLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// writeICalLine writes a content line ended by CRLF, folding it into lines
+// of at most 75 octets continued with a space, without splitting characters.
+func writeICalLine(buf *bytes.Buffer, line string) {
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts to their length
+		limit = icalLineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
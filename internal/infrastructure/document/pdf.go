@@ -0,0 +1,268 @@
+// Package document renders documents shared outside the system, such as
+// postmortems, to portable formats.
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Page layout of rendered PDFs: A4 in points, with margins of 2 cm.
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	pageMargin = 56.0
+)
+
+// avgGlyphWidth approximates the width of a Helvetica glyph, as a fraction
+// of the font size, to wrap lines without font metrics.
+const avgGlyphWidth = 0.55
+
+// textStyle is how a kind of markdown line is set.
+type textStyle struct {
+	font    string
+	size    float64
+	indent  float64
+	spacing float64
+}
+
+// Styles of the markdown lines rendered.
+var (
+	styleHeading1  = textStyle{font: "F2", size: 18, spacing: 12}
+	styleHeading2  = textStyle{font: "F2", size: 14, spacing: 10}
+	styleHeading3  = textStyle{font: "F2", size: 12, spacing: 6}
+	styleParagraph = textStyle{font: "F1", size: 11, spacing: 4}
+	styleListItem  = textStyle{font: "F1", size: 11, indent: 14}
+	styleCode      = textStyle{font: "F3", size: 10, indent: 14}
+)
+
+// Inline markdown reduced to plain text.
+var (
+	markdownLink     = regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\)`)
+	markdownEmphasis = regexp.MustCompile("(\\*\\*|__|\\*|`)")
+)
+
+// line is a line of text set on a page.
+type line struct {
+	text  string
+	style textStyle
+}
+
+// MarkdownToPDF renders markdown as a PDF document of A4 pages. Headings,
+// list items, code blocks and paragraphs are laid out with the standard
+// Helvetica and Courier fonts; other markup is reduced to plain text, and
+// characters outside Latin-1 are replaced.
+func MarkdownToPDF(markdown string) []byte {
+	pages := paginate(layout(markdown))
+
+	var buf bytes.Buffer
+	offsets := make([]int, 0, 5+2*len(pages))
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// Objects 1 to 5: catalog, page tree and fonts; then a page and its
+	// content stream per page
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		content := pageContent(page)
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 7+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// layout breaks markdown into styled lines wrapped to the page width. Empty
+// lines separate paragraphs.
+func layout(markdown string) []line {
+	var lines []line
+	inCode := false
+
+	for _, raw := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(raw)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			lines = append(lines, wrap(strings.TrimRight(raw, " \t"), styleCode)...)
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			lines = append(lines, line{style: styleParagraph})
+		case strings.HasPrefix(trimmed, "### "):
+			lines = append(lines, wrap(inline(trimmed[4:]), styleHeading3)...)
+		case strings.HasPrefix(trimmed, "## "):
+			lines = append(lines, wrap(inline(trimmed[3:]), styleHeading2)...)
+		case strings.HasPrefix(trimmed, "# "):
+			lines = append(lines, wrap(inline(trimmed[2:]), styleHeading1)...)
+		case strings.HasPrefix(trimmed, "- [ ] "), strings.HasPrefix(trimmed, "* [ ] "):
+			lines = append(lines, wrap("[ ] "+inline(trimmed[6:]), styleListItem)...)
+		case strings.HasPrefix(trimmed, "- [x] "), strings.HasPrefix(trimmed, "* [x] "):
+			lines = append(lines, wrap("[x] "+inline(trimmed[6:]), styleListItem)...)
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			lines = append(lines, wrap("- "+inline(trimmed[2:]), styleListItem)...)
+		default:
+			lines = append(lines, wrap(inline(trimmed), styleParagraph)...)
+		}
+	}
+
+	return lines
+}
+
+// inline reduces inline markdown to plain text: links keep their text and
+// URL, emphasis and code marks are dropped.
+func inline(text string) string {
+	text = markdownLink.ReplaceAllString(text, "$1 ($2)")
+	return markdownEmphasis.ReplaceAllString(text, "")
+}
+
+// wrap splits text into lines that fit the page width in the style. Code
+// is split at the width; other text between words.
+func wrap(text string, style textStyle) []line {
+	glyphWidth := avgGlyphWidth
+	if style.font == styleCode.font {
+		// Courier glyphs are 0.6 em wide
+		glyphWidth = 0.6
+	}
+	maxChars := int((pageWidth - 2*pageMargin - style.indent) / (style.size * glyphWidth))
+
+	if style.font == styleCode.font {
+		runes := []rune(text)
+		lines := []line{{text: string(runes[:min(len(runes), maxChars)]), style: style}}
+		for start := maxChars; start < len(runes); start += maxChars {
+			lines = append(lines, line{text: string(runes[start:min(len(runes), start+maxChars)]), style: style})
+		}
+		return lines
+	}
+
+	var lines []line
+	current := ""
+	for _, word := range strings.Fields(text) {
+		for len([]rune(word)) > maxChars {
+			runes := []rune(word)
+			if current != "" {
+				lines = append(lines, line{text: current, style: style})
+				current = ""
+			}
+			lines = append(lines, line{text: string(runes[:maxChars]), style: style})
+			word = string(runes[maxChars:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case len([]rune(current))+1+len([]rune(word)) > maxChars:
+			lines = append(lines, line{text: current, style: style})
+			current = word
+		default:
+			current += " " + word
+		}
+	}
+	lines = append(lines, line{text: current, style: style})
+
+	// Continuation lines of a list item align with its text
+	if style == styleListItem {
+		for i := 1; i < len(lines); i++ {
+			lines[i].text = "  " + lines[i].text
+		}
+	}
+
+	// The spacing of the style follows the whole block
+	for i := 0; i < len(lines)-1; i++ {
+		lines[i].style.spacing = 0
+	}
+
+	return lines
+}
+
+// paginate distributes lines over pages. Every document has a page.
+func paginate(lines []line) [][]line {
+	pages := [][]line{nil}
+	y := pageHeight - pageMargin
+
+	for _, l := range lines {
+		height := l.style.size*1.4 + l.style.spacing
+		if y-height < pageMargin && len(pages[len(pages)-1]) > 0 {
+			pages = append(pages, nil)
+			y = pageHeight - pageMargin
+		}
+		pages[len(pages)-1] = append(pages[len(pages)-1], l)
+		y -= height
+	}
+
+	return pages
+}
+
+// pageContent returns the content stream setting the lines of a page.
+func pageContent(lines []line) string {
+	var b strings.Builder
+	y := pageHeight - pageMargin
+
+	for _, l := range lines {
+		y -= l.style.size * 1.4
+		if l.text != "" {
+			fmt.Fprintf(&b, "BT /%s %.0f Tf %.1f %.1f Td (%s) Tj ET\n",
+				l.style.font, l.style.size, pageMargin+l.style.indent, y, escape(l.text))
+		}
+		y -= l.style.spacing
+	}
+
+	return b.String()
+}
+
+// escape encodes text as a PDF string literal in WinAnsiEncoding.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteString("    ")
+		case r < 0x20:
+			continue
+		case r < 0x80:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case r == '–' || r == '—':
+			b.WriteByte('-')
+		case r == '‘' || r == '’':
+			b.WriteByte('\'')
+		case r == '“' || r == '”':
+			b.WriteByte('"')
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
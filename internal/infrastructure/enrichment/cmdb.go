@@ -0,0 +1,161 @@
+package enrichment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// cmdbKeyPrefix is the cache key prefix of CMDB lookups.
+const cmdbKeyPrefix = "enrichment:cmdb:"
+
+// cmdbSourcePlaceholder is replaced by the alert source in the lookup URL.
+const cmdbSourcePlaceholder = "{source}"
+
+// maxCMDBResponse bounds the size of a CMDB record.
+const maxCMDBResponse = 1 << 20
+
+// ErrCMDBURLInvalid is returned for a CMDB URL without the source placeholder.
+var ErrCMDBURLInvalid = errors.New("CMDB URL must be an http or https URL containing {source}")
+
+// CMDBEnricher looks up the source of alerts in a CMDB over HTTP, and sets
+// the record as "cmdb" metadata and its owner field as "owner". Sources
+// the CMDB does not know (404) are left alone. Lookups, including unknown
+// sources, are cached so that bursts of alerts from one source cost one
+// request.
+type CMDBEnricher struct {
+	url        string
+	token      string
+	ownerField string
+	cacheRepo  repository.CacheRepository
+	cacheTTL   time.Duration
+	client     *http.Client
+}
+
+// NewCMDBEnricher creates a CMDB enricher. The URL contains a {source}
+// placeholder; the token, if any, is sent as a bearer token. A nil cache
+// or zero TTL disables caching.
+func NewCMDBEnricher(
+	rawURL, token, ownerField string,
+	cacheRepo repository.CacheRepository,
+	cacheTTL time.Duration,
+) (*CMDBEnricher, error) {
+	target, err := url.Parse(strings.Replace(rawURL, cmdbSourcePlaceholder, "source", 1))
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" ||
+		!strings.Contains(rawURL, cmdbSourcePlaceholder) {
+		return nil, ErrCMDBURLInvalid
+	}
+
+	return &CMDBEnricher{
+		url:        rawURL,
+		token:      token,
+		ownerField: ownerField,
+		cacheRepo:  cacheRepo,
+		cacheTTL:   cacheTTL,
+		client:     &http.Client{},
+	}, nil
+}
+
+// Name implements service.AlertEnricher.
+func (e *CMDBEnricher) Name() string {
+	return "cmdb"
+}
+
+// Enrich implements service.AlertEnricher.
+func (e *CMDBEnricher) Enrich(ctx context.Context, alert *entity.Alert) error {
+	if alert.Source == "" {
+		return nil
+	}
+
+	record, err := e.lookup(ctx, alert.Source)
+	if err != nil {
+		return err
+	}
+	if len(record) == 0 {
+		return nil
+	}
+
+	alert.AddMetadata(entity.MetadataCMDB, record)
+	if owner, ok := record[e.ownerField]; ok && e.ownerField != "" {
+		if _, exists := alert.Metadata[entity.MetadataOwner]; !exists {
+			alert.AddMetadata(entity.MetadataOwner, owner)
+		}
+	}
+
+	return nil
+}
+
+// lookup returns the CMDB record of a source, empty when it is unknown.
+func (e *CMDBEnricher) lookup(ctx context.Context, source string) (map[string]interface{}, error) {
+	key := cmdbKeyPrefix + cmdbHash(source)
+	if e.cached() {
+		var record map[string]interface{}
+		if err := e.cacheRepo.Get(ctx, key, &record); err == nil {
+			return record, nil
+		}
+	}
+
+	record, err := e.fetch(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.cached() {
+		_ = e.cacheRepo.Set(ctx, key, record, e.cacheTTL)
+	}
+	return record, nil
+}
+
+// fetch requests the CMDB record of a source.
+func (e *CMDBEnricher) fetch(ctx context.Context, source string) (map[string]interface{}, error) {
+	target := strings.ReplaceAll(e.url, cmdbSourcePlaceholder, url.PathEscape(source))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if e.token != "" {
+		req.Header.Set("Authorization", "Bearer "+e.token)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return map[string]interface{}{}, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("CMDB returned status %d", resp.StatusCode)
+	}
+
+	record := map[string]interface{}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCMDBResponse)).Decode(&record); err != nil {
+		return nil, fmt.Errorf("decode CMDB record: %w", err)
+	}
+	return record, nil
+}
+
+// cached reports whether lookups are cached.
+func (e *CMDBEnricher) cached() bool {
+	return e.cacheRepo != nil && e.cacheTTL > 0
+}
+
+// cmdbHash returns a fixed-length key for a source.
+func cmdbHash(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:8])
+}
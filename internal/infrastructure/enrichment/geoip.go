@@ -0,0 +1,182 @@
+// Package enrichment provides the enrichers adding context to new alerts.
+package enrichment
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// ErrGeoIPDatabaseInvalid is returned for a GeoIP database that cannot be parsed.
+var ErrGeoIPDatabaseInvalid = errors.New("invalid GeoIP database")
+
+// location is the place a network is in.
+type location struct {
+	Country string
+	Region  string
+	City    string
+}
+
+// GeoIPEnricher locates alerts whose source is an IP address, optionally
+// with a port, and sets their "geo" metadata. Networks are matched on the
+// longest prefix.
+type GeoIPEnricher struct {
+	// networks holds the locations by prefix length, longest first in lengths.
+	networks map[int]map[netip.Prefix]location
+	lengths  []int
+}
+
+// NewGeoIPEnricher loads a GeoIP database from a CSV file of
+// network,country,region,city rows. Networks are CIDR ranges or IP
+// addresses; blank lines and lines starting with "#" are skipped.
+func NewGeoIPEnricher(path string) (*GeoIPEnricher, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	return ParseGeoIPDatabase(file)
+}
+
+// ParseGeoIPDatabase reads a GeoIP database in the format of
+// NewGeoIPEnricher.
+func ParseGeoIPDatabase(r io.Reader) (*GeoIPEnricher, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	enricher := &GeoIPEnricher{networks: make(map[int]map[netip.Prefix]location)}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrGeoIPDatabaseInvalid, err)
+		}
+		if len(record) < 2 {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("%w: line %d has no country", ErrGeoIPDatabaseInvalid, line)
+		}
+
+		network, err := parseNetwork(record[0])
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("%w: line %d: %q is not a network", ErrGeoIPDatabaseInvalid, line, record[0])
+		}
+
+		loc := location{Country: record[1]}
+		if len(record) > 2 {
+			loc.Region = record[2]
+		}
+		if len(record) > 3 {
+			loc.City = record[3]
+		}
+		enricher.add(network, loc)
+	}
+
+	return enricher, nil
+}
+
+// Name implements service.AlertEnricher.
+func (e *GeoIPEnricher) Name() string {
+	return "geoip"
+}
+
+// Enrich implements service.AlertEnricher.
+func (e *GeoIPEnricher) Enrich(_ context.Context, alert *entity.Alert) error {
+	if _, ok := alert.Metadata[entity.MetadataGeo]; ok {
+		return nil
+	}
+
+	addr, ok := sourceAddr(alert.Source)
+	if !ok {
+		return nil
+	}
+
+	loc, ok := e.lookup(addr)
+	if !ok {
+		return nil
+	}
+
+	geo := map[string]interface{}{"country": loc.Country}
+	if loc.Region != "" {
+		geo["region"] = loc.Region
+	}
+	if loc.City != "" {
+		geo["city"] = loc.City
+	}
+	alert.AddMetadata(entity.MetadataGeo, geo)
+
+	return nil
+}
+
+// lookup returns the location of the most specific network holding addr.
+func (e *GeoIPEnricher) lookup(addr netip.Addr) (location, bool) {
+	addr = addr.Unmap()
+	for _, bits := range e.lengths {
+		if bits > addr.BitLen() {
+			continue
+		}
+		prefix, err := addr.Prefix(bits)
+		if err != nil {
+			continue
+		}
+		if loc, ok := e.networks[bits][prefix]; ok {
+			return loc, true
+		}
+	}
+	return location{}, false
+}
+
+// add records the location of a network.
+func (e *GeoIPEnricher) add(network netip.Prefix, loc location) {
+	bits := network.Bits()
+	if e.networks[bits] == nil {
+		e.networks[bits] = make(map[netip.Prefix]location)
+		e.lengths = append(e.lengths, bits)
+		sort.Sort(sort.Reverse(sort.IntSlice(e.lengths)))
+	}
+	e.networks[bits][network] = loc
+}
+
+// parseNetwork parses a CIDR range, or an IP address as a single-address range.
+func parseNetwork(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		network, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return network.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// sourceAddr returns the IP address of an alert source such as "10.0.0.7"
+// or "10.0.0.7:9100".
+func sourceAddr(source string) (netip.Addr, bool) {
+	if addr, err := netip.ParseAddr(source); err == nil {
+		return addr, true
+	}
+	if addrPort, err := netip.ParseAddrPort(source); err == nil {
+		return addrPort.Addr(), true
+	}
+	return netip.Addr{}, false
+}
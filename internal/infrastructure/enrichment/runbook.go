@@ -0,0 +1,51 @@
+package enrichment
+
+import (
+	"context"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// RunbookEnricher sets the "runbook_url" metadata of the alerts raised by
+// rules, looked up by rule ID, then by rule name. Alerts that already have
+// a runbook URL keep it.
+type RunbookEnricher struct {
+	urls map[string]string
+}
+
+// NewRunbookEnricher creates a runbook enricher from rule IDs or names
+// mapped to runbook URLs.
+func NewRunbookEnricher(urls map[string]string) *RunbookEnricher {
+	return &RunbookEnricher{urls: urls}
+}
+
+// Name implements service.AlertEnricher.
+func (e *RunbookEnricher) Name() string {
+	return "runbook"
+}
+
+// Enrich implements service.AlertEnricher.
+func (e *RunbookEnricher) Enrich(_ context.Context, alert *entity.Alert) error {
+	if url, _ := alert.Metadata[entity.MetadataRunbookURL].(string); url != "" {
+		return nil
+	}
+
+	ruleID, _ := alert.Metadata["rule_id"].(string)
+	if ruleID == "" && alert.RuleID != nil {
+		ruleID = alert.RuleID.String()
+	}
+	if ruleID == "" {
+		return nil
+	}
+
+	url, ok := e.urls[ruleID]
+	if !ok {
+		// Alerts of rules are titled after the rule
+		url, ok = e.urls[alert.Title]
+	}
+	if ok && url != "" {
+		alert.AddMetadata(entity.MetadataRunbookURL, url)
+	}
+
+	return nil
+}
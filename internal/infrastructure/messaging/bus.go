@@ -0,0 +1,111 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// Event bus drivers.
+const (
+	DriverRedisStream = "redis-stream"
+	DriverMemory      = "in-memory"
+	DriverKafka       = "kafka"
+	DriverNATS        = "nats"
+)
+
+// Event bus factory errors.
+var (
+	ErrUnknownDriver       = errors.New("unknown event bus driver")
+	ErrDriverUnavailable   = errors.New("event bus driver is not available in this build")
+	ErrRedisClientRequired = errors.New("redis-stream event bus requires a redis client")
+)
+
+// defaultDeadLetterAfter is how many times a failing event is handled before it
+// is moved to the dead letter stream when no retry policy is set.
+const defaultDeadLetterAfter = 3
+
+// BusOptions holds what the drivers need besides their configuration.
+type BusOptions struct {
+	// RedisClient is required by the redis-stream driver.
+	RedisClient *redis.Client
+	// Region and Cluster are stamped onto every published event.
+	Region  string
+	Cluster string
+}
+
+// NewBus creates the event bus selected by the configured driver.
+// An empty driver selects redis-stream.
+func NewBus(cfg config.EventBusConfig, opts BusOptions) (event.Bus, error) {
+	switch cfg.Driver {
+	case "", DriverRedisStream:
+		if opts.RedisClient == nil {
+			return nil, ErrRedisClientRequired
+		}
+		bus := NewRedisStreamBus(opts.RedisClient, cfg.ConsumerID)
+		bus.SetOrigin(opts.Region, opts.Cluster)
+		bus.SetClaim(cfg.ClaimMinIdle, cfg.ClaimInterval)
+		bus.SetRetryPolicy(NewRetryConfig(cfg), cfg.DeadLetterAfter)
+		bus.SetWorkers(cfg.Workers, cfg.OrderedEventTypes)
+		return bus, nil
+	case DriverMemory:
+		bus := NewMemoryBus()
+		bus.SetOrigin(opts.Region, opts.Cluster)
+		bus.SetRetryPolicy(NewRetryConfig(cfg), cfg.DeadLetterAfter)
+		bus.SetWorkers(cfg.Workers, cfg.OrderedEventTypes)
+		return bus, nil
+	case DriverKafka, DriverNATS:
+		return nil, fmt.Errorf("%w: %s", ErrDriverUnavailable, cfg.Driver)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, cfg.Driver)
+	}
+}
+
+// NewRetryConfig returns the retry configuration of an event bus configuration.
+func NewRetryConfig(cfg config.EventBusConfig) RetryConfig {
+	return RetryConfig{
+		MaxRetries:     cfg.MaxRetries,
+		InitialBackoff: cfg.InitialBackoff,
+		MaxBackoff:     cfg.MaxBackoff,
+		Multiplier:     cfg.Multiplier,
+		Jitter:         true,
+	}
+}
+
+// streamForEvent returns the stream name for an event, from its type and
+// priority.
+func streamForEvent(evt *event.Event) string {
+	switch evt.Type {
+	case event.AlertCreated, event.AlertAcknowledged, event.AlertResolved, event.AlertDeleted, event.AlertExpired:
+		if evt.Priority == event.PriorityHigh {
+			return event.StreamAlertsPriority
+		}
+		return event.StreamAlerts
+	case event.UserCreated, event.UserUpdated:
+		return event.StreamNotifications
+	default:
+		return event.StreamAlerts
+	}
+}
+
+// waitGroup waits for a wait group, or until ctx is done.
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
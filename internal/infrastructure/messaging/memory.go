@@ -0,0 +1,338 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// ErrBusClosed is returned when publishing to a bus that was unsubscribed.
+var ErrBusClosed = errors.New("event bus is closed")
+
+// memoryBufferSize is the number of undelivered events kept per consumer group,
+// and per stream until its first group subscribes.
+const memoryBufferSize = 1024
+
+// MemoryBus implements event.Bus in process, for tests and single-node
+// deployments. Like Redis Streams, each consumer group receives every event
+// of a stream once and subscribers of the same group share its events.
+// Events are not persisted and are lost on restart.
+type MemoryBus struct {
+	mu      sync.RWMutex
+	groups  map[string]map[string]chan *event.Event
+	members map[string]map[string]int
+	backlog map[string][]*event.Event
+	stopCh  chan struct{}
+	stop    sync.Once
+	wg      sync.WaitGroup
+	region  string
+	cluster string
+	policy  deliveryPolicy
+	workers int
+	ordered map[event.Type]bool
+}
+
+// NewMemoryBus creates a new in-memory event bus.
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		groups:  make(map[string]map[string]chan *event.Event),
+		members: make(map[string]map[string]int),
+		backlog: make(map[string][]*event.Event),
+		stopCh:  make(chan struct{}),
+		policy:  newDeliveryPolicy(DefaultRetryConfig(), defaultDeadLetterAfter),
+	}
+}
+
+// SetOrigin sets the region and cluster stamped onto every event published by this bus.
+func (b *MemoryBus) SetOrigin(region, cluster string) {
+	b.region = region
+	b.cluster = cluster
+}
+
+// SetRetryPolicy sets how failed events are retried. Each retry is delayed by
+// the backoff of config; after deadLetterAfter failed deliveries (zero for
+// config.MaxRetries + 1) the event is moved to the dead letter stream.
+func (b *MemoryBus) SetRetryPolicy(config RetryConfig, deadLetterAfter int) {
+	b.policy = newDeliveryPolicy(config, deadLetterAfter)
+}
+
+// SetWorkers sets how many events each consumer group handles concurrently.
+// Events of the ordered types are still handled one at a time, in publish
+// order. One or less handles every event sequentially.
+func (b *MemoryBus) SetWorkers(workers int, ordered []string) {
+	b.workers = workers
+	b.ordered = orderedTypes(ordered)
+}
+
+// Publish publishes an event to the default stream based on event type and priority.
+func (b *MemoryBus) Publish(ctx context.Context, evt *event.Event) error {
+	return b.PublishToStream(ctx, streamForEvent(evt), evt)
+}
+
+// PublishToStream publishes an event to a specific stream. Until a group
+// subscribes to the stream its events are kept in a bounded backlog.
+func (b *MemoryBus) PublishToStream(ctx context.Context, stream string, evt *event.Event) error {
+	select {
+	case <-b.stopCh:
+		return ErrBusClosed
+	default:
+	}
+
+	evt.SetOrigin(b.region, b.cluster)
+	_, span := startPublishSpan(ctx, DriverMemory, stream, evt)
+	defer span.End()
+
+	b.mu.Lock()
+	groups := b.groups[stream]
+	if len(groups) == 0 {
+		backlog := append(b.backlog[stream], evt)
+		if len(backlog) > memoryBufferSize {
+			backlog = backlog[len(backlog)-memoryBufferSize:]
+		}
+		b.backlog[stream] = backlog
+		b.mu.Unlock()
+		return nil
+	}
+
+	queues := make([]chan *event.Event, 0, len(groups))
+	for _, queue := range groups {
+		queues = append(queues, queue)
+	}
+	b.mu.Unlock()
+
+	for _, queue := range queues {
+		delivery := *evt
+		select {
+		case queue <- &delivery:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.stopCh:
+			return ErrBusClosed
+		}
+	}
+
+	log.Debug().Str("stream", stream).Str("event_id", evt.ID).Str("event_type", string(evt.Type)).Msg("Event published")
+	return nil
+}
+
+// Subscribe subscribes to a stream with a consumer group.
+func (b *MemoryBus) Subscribe(ctx context.Context, stream string, group string, handler event.Handler) error {
+	b.mu.Lock()
+	if b.groups[stream] == nil {
+		b.groups[stream] = make(map[string]chan *event.Event)
+		b.members[stream] = make(map[string]int)
+	}
+	b.members[stream][group]++
+
+	queue, ok := b.groups[stream][group]
+	if !ok {
+		queue = make(chan *event.Event, memoryBufferSize)
+		b.groups[stream][group] = queue
+
+		// The first group of a stream receives the events published before it subscribed
+		for _, evt := range b.backlog[stream] {
+			queue <- evt
+		}
+		delete(b.backlog, stream)
+	}
+	b.mu.Unlock()
+
+	b.wg.Add(1)
+	go b.consume(ctx, stream, group, queue, handler)
+
+	log.Info().Str("stream", stream).Str("group", group).Msg("Subscribed to in-memory stream")
+	return nil
+}
+
+// consume handles the events of a consumer group until stopped.
+func (b *MemoryBus) consume(ctx context.Context, stream, group string, queue chan *event.Event, handler event.Handler) {
+	defer b.wg.Done()
+
+	var pool *workerPool
+	if b.workers > 1 {
+		pool = newWorkerPool(b.workers, b.ordered)
+		defer pool.close()
+	}
+
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case evt := <-queue:
+			if pool == nil {
+				b.handle(ctx, stream, group, evt, handler)
+				continue
+			}
+			pool.submit(evt.Type, func() {
+				if b.stopping() {
+					return
+				}
+				b.handle(ctx, stream, group, evt, handler)
+			})
+		}
+	}
+}
+
+// handle runs the handler of an event and retries it on failure.
+func (b *MemoryBus) handle(ctx context.Context, stream, group string, evt *event.Event, handler event.Handler) {
+	spanCtx, span := startProcessSpan(ctx, DriverMemory, stream, group, evt)
+	err := handler(spanCtx, evt)
+	endSpan(span, err)
+
+	if err != nil {
+		log.Error().Err(err).Str("event_id", evt.ID).Str("event_type", string(evt.Type)).Msg("Failed to handle event")
+		b.handleFailedEvent(ctx, stream, group, evt, err)
+	}
+}
+
+// handleFailedEvent re-queues a failed event after its retry delay, or moves
+// it to the dead letter stream once the retry policy is exhausted.
+func (b *MemoryBus) handleFailedEvent(ctx context.Context, stream, group string, evt *event.Event, handlerErr error) {
+	evt.Retries++
+	evt.LastError = handlerErr.Error()
+	evt.FailedGroup = group
+
+	target := stream
+	deadLetter, delay := b.policy.next(evt.Retries)
+	if deadLetter {
+		target = event.StreamDeadLetter
+		metrics.EventsRetriedTotal.WithLabelValues(string(evt.Type), "dead_letter").Inc()
+		log.Warn().Str("event_id", evt.ID).Int("retries", evt.Retries).Msg("Event moved to dead letter queue")
+	} else {
+		metrics.EventsRetriedTotal.WithLabelValues(string(evt.Type), "retry").Inc()
+	}
+
+	// Publishing blocks while queues are full; do it outside the consumer loop
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-b.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := b.PublishToStream(ctx, target, evt); err != nil && !errors.Is(err, ErrBusClosed) {
+			log.Error().Err(err).Str("event_id", evt.ID).Msg("Failed to re-publish event")
+		}
+	}()
+}
+
+// PublishAt publishes an event to its default stream at deliverAt. Like
+// its other events, the scheduled events of the bus are lost on restart.
+func (b *MemoryBus) PublishAt(ctx context.Context, evt *event.Event, deliverAt time.Time) error {
+	delay := time.Until(deliverAt)
+	if delay <= 0 {
+		return b.Publish(ctx, evt)
+	}
+
+	select {
+	case <-b.stopCh:
+		return ErrBusClosed
+	default:
+	}
+
+	metrics.EventsScheduledTotal.WithLabelValues(string(evt.Type)).Inc()
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-b.stopCh:
+			return
+		}
+
+		if err := b.Publish(context.WithoutCancel(ctx), evt); err != nil && !errors.Is(err, ErrBusClosed) {
+			log.Error().Err(err).Str("event_id", evt.ID).Msg("Failed to publish scheduled event")
+		}
+	}()
+	return nil
+}
+
+// Lag returns the backlog of every consumer group. Events are only retained
+// until each group has taken them, so the lag of a group is its queue, the
+// length of a stream its longest queue, and events being handled are not
+// reported as pending.
+func (b *MemoryBus) Lag(ctx context.Context) ([]event.GroupLag, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	streams := make([]string, 0, len(b.groups))
+	for stream := range b.groups {
+		streams = append(streams, stream)
+	}
+	sort.Strings(streams)
+
+	lags := make([]event.GroupLag, 0)
+	for _, stream := range streams {
+		groups := make([]string, 0, len(b.groups[stream]))
+		var length int64
+		for group, queue := range b.groups[stream] {
+			groups = append(groups, group)
+			length = max(length, int64(len(queue)))
+		}
+		sort.Strings(groups)
+
+		for _, group := range groups {
+			lags = append(lags, event.GroupLag{
+				Stream:    stream,
+				Group:     group,
+				Consumers: b.members[stream][group],
+				Length:    length,
+				Lag:       int64(len(b.groups[stream][group])),
+			})
+		}
+	}
+	return lags, nil
+}
+
+// Drain stops taking events from the queues and waits until the events being
+// handled finish, or ctx is done. Events still queued are dropped.
+func (b *MemoryBus) Drain(ctx context.Context) error {
+	b.stop.Do(func() { close(b.stopCh) })
+	return waitGroup(ctx, &b.wg)
+}
+
+// stopping reports whether the bus is stopping.
+func (b *MemoryBus) stopping() bool {
+	select {
+	case <-b.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// Unsubscribe stops all consumers. Events still queued are dropped.
+func (b *MemoryBus) Unsubscribe() error {
+	return b.Drain(context.Background())
+}
+
+// Compile-time interface verification.
+var (
+	_ event.Bus          = (*MemoryBus)(nil)
+	_ event.LagInspector = (*MemoryBus)(nil)
+	_ event.Drainer      = (*MemoryBus)(nil)
+	_ event.Scheduler    = (*MemoryBus)(nil)
+)
@@ -0,0 +1,92 @@
+package messaging
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+)
+
+// workerQueueSize is the number of tasks buffered per worker before
+// submitting blocks.
+const workerQueueSize = 16
+
+// workerPool runs the events of a subscription on a fixed number of
+// goroutines. Events of an ordered type always run on the same worker, in
+// submission order; other events run on whichever worker is free.
+type workerPool struct {
+	shared  chan func()
+	keyed   []chan func()
+	ordered map[event.Type]bool
+	wg      sync.WaitGroup
+}
+
+// newWorkerPool starts a pool of size workers.
+func newWorkerPool(size int, ordered map[event.Type]bool) *workerPool {
+	p := &workerPool{
+		shared:  make(chan func(), size*workerQueueSize),
+		keyed:   make([]chan func(), size),
+		ordered: ordered,
+	}
+
+	for i := range p.keyed {
+		p.keyed[i] = make(chan func(), workerQueueSize)
+		p.wg.Add(1)
+		go p.work(p.keyed[i])
+	}
+
+	return p
+}
+
+// submit queues a task for an event type, blocking while the pool is saturated.
+func (p *workerPool) submit(eventType event.Type, task func()) {
+	if !p.ordered[eventType] {
+		p.shared <- task
+		return
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(eventType))
+	p.keyed[h.Sum32()%uint32(len(p.keyed))] <- task
+}
+
+// close stops accepting tasks and waits for the queued ones to finish.
+func (p *workerPool) close() {
+	close(p.shared)
+	for _, queue := range p.keyed {
+		close(queue)
+	}
+	p.wg.Wait()
+}
+
+// work runs the tasks of its own queue and of the shared queue until both are closed.
+func (p *workerPool) work(own chan func()) {
+	defer p.wg.Done()
+
+	shared := p.shared
+	for own != nil || shared != nil {
+		select {
+		case task, ok := <-own:
+			if !ok {
+				own = nil
+				continue
+			}
+			task()
+		case task, ok := <-shared:
+			if !ok {
+				shared = nil
+				continue
+			}
+			task()
+		}
+	}
+}
+
+// orderedTypes converts configured event type names to a lookup set.
+func orderedTypes(names []string) map[event.Type]bool {
+	ordered := make(map[event.Type]bool, len(names))
+	for _, name := range names {
+		ordered[event.Type(name)] = true
+	}
+	return ordered
+}
@@ -0,0 +1,99 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// RedisPubSubRelay relays payloads between the instances of the API over a
+// Redis pub/sub channel. Every payload is tagged with the node that published
+// it, so that a node never receives its own payloads back.
+type RedisPubSubRelay struct {
+	client  *redis.Client
+	channel string
+	nodeID  string
+	pubsub  *redis.PubSub
+	mu      sync.Mutex
+}
+
+// relayEnvelope is a relayed payload and the node it comes from.
+type relayEnvelope struct {
+	Node    string `json:"node"`
+	Payload []byte `json:"payload"`
+}
+
+// NewRedisPubSubRelay creates a new relay publishing to channel. nodeID must
+// be unique to the instance.
+func NewRedisPubSubRelay(client *redis.Client, channel, nodeID string) *RedisPubSubRelay {
+	return &RedisPubSubRelay{
+		client:  client,
+		channel: channel,
+		nodeID:  nodeID,
+	}
+}
+
+// Publish sends a payload to the other instances.
+func (r *RedisPubSubRelay) Publish(ctx context.Context, payload []byte) error {
+	data, err := json.Marshal(relayEnvelope{Node: r.nodeID, Payload: payload})
+	if err != nil {
+		return fmt.Errorf("failed to marshal relayed payload: %w", err)
+	}
+
+	if err := r.client.Publish(ctx, r.channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish relayed payload: %w", err)
+	}
+
+	metrics.RelayMessagesTotal.WithLabelValues("sent").Inc()
+	return nil
+}
+
+// Subscribe calls handler with the payloads published by other instances,
+// until the relay is closed.
+func (r *RedisPubSubRelay) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	pubsub := r.client.Subscribe(ctx, r.channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return fmt.Errorf("failed to subscribe to relay channel: %w", err)
+	}
+
+	r.mu.Lock()
+	r.pubsub = pubsub
+	r.mu.Unlock()
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			var envelope relayEnvelope
+			if err := json.Unmarshal([]byte(msg.Payload), &envelope); err != nil {
+				log.Warn().Err(err).Str("channel", r.channel).Msg("Failed to parse relayed payload")
+				continue
+			}
+			if envelope.Node == r.nodeID {
+				continue
+			}
+
+			metrics.RelayMessagesTotal.WithLabelValues("received").Inc()
+			handler(envelope.Payload)
+		}
+	}()
+
+	log.Info().Str("channel", r.channel).Str("node", r.nodeID).Msg("Subscribed to relay channel")
+	return nil
+}
+
+// Close stops the subscription.
+func (r *RedisPubSubRelay) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pubsub == nil {
+		return nil
+	}
+	return r.pubsub.Close()
+}
@@ -3,8 +3,12 @@ package messaging
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +16,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
 )
 
 // RedisStreamBus implements event.Bus using Redis Streams.
@@ -20,8 +25,41 @@ type RedisStreamBus struct {
 	handlers   map[string]event.Handler
 	mu         sync.RWMutex
 	stopCh     chan struct{}
+	stop       sync.Once
 	wg         sync.WaitGroup
 	consumerID string
+	region     string
+	cluster    string
+
+	claimMinIdle  time.Duration
+	claimInterval time.Duration
+
+	policy       deliveryPolicy
+	redeliverRun sync.Once
+
+	workers int
+	ordered map[event.Type]bool
+}
+
+// delayedEventsKey is the sorted set holding scheduled events and failed
+// events waiting for their retry, scored by the Unix time in milliseconds
+// they are due at.
+const delayedEventsKey = "stream:delayed"
+
+// redeliverInterval is how often due events are moved to their stream.
+const redeliverInterval = time.Second
+
+// delayedPageSize is the number of due events moved to their stream per
+// read of the delayed set.
+const delayedPageSize = 100
+
+// readPageSize is the number of stream entries fetched per XRANGE when reading back events.
+const readPageSize = 500
+
+// delayedEvent is an event waiting in the delayed set.
+type delayedEvent struct {
+	Stream string       `json:"stream"`
+	Event  *event.Event `json:"event"`
 }
 
 // NewRedisStreamBus creates a new Redis Streams event bus.
@@ -31,23 +69,58 @@ func NewRedisStreamBus(client *redis.Client, consumerID string) *RedisStreamBus
 		handlers:   make(map[string]event.Handler),
 		stopCh:     make(chan struct{}),
 		consumerID: consumerID,
+		policy:     newDeliveryPolicy(DefaultRetryConfig(), defaultDeadLetterAfter),
 	}
 }
 
-// Publish publishes an event to the default stream based on event type.
+// SetOrigin sets the region and cluster stamped onto every event published by this bus.
+func (b *RedisStreamBus) SetOrigin(region, cluster string) {
+	b.region = region
+	b.cluster = cluster
+}
+
+// SetClaim enables reclaiming messages that stayed pending longer than minIdle
+// in any consumer of a group, e.g. because the consumer crashed before
+// acknowledging them. Each subscription sweeps its group every interval.
+// A zero interval disables reclaiming.
+func (b *RedisStreamBus) SetClaim(minIdle, interval time.Duration) {
+	b.claimMinIdle = minIdle
+	b.claimInterval = interval
+}
+
+// SetRetryPolicy sets how failed events are retried. Each retry is delayed by
+// the backoff of config; after deadLetterAfter failed deliveries (zero for
+// config.MaxRetries + 1) the event is moved to the dead letter stream.
+func (b *RedisStreamBus) SetRetryPolicy(config RetryConfig, deadLetterAfter int) {
+	b.policy = newDeliveryPolicy(config, deadLetterAfter)
+}
+
+// SetWorkers sets how many events each subscription handles concurrently.
+// Events of the ordered types are still handled one at a time, in stream
+// order. One or less handles every event sequentially.
+func (b *RedisStreamBus) SetWorkers(workers int, ordered []string) {
+	b.workers = workers
+	b.ordered = orderedTypes(ordered)
+}
+
+// Publish publishes an event to the default stream based on event type and priority.
 func (b *RedisStreamBus) Publish(ctx context.Context, evt *event.Event) error {
-	stream := b.getStreamForEventType(evt.Type)
+	stream := streamForEvent(evt)
 	return b.PublishToStream(ctx, stream, evt)
 }
 
 // PublishToStream publishes an event to a specific stream.
 func (b *RedisStreamBus) PublishToStream(ctx context.Context, stream string, evt *event.Event) error {
+	evt.SetOrigin(b.region, b.cluster)
+	ctx, span := startPublishSpan(ctx, DriverRedisStream, stream, evt)
+
 	args := &redis.XAddArgs{
 		Stream: stream,
 		Values: evt.ToMap(),
 	}
 
 	_, err := b.client.XAdd(ctx, args).Result()
+	endSpan(span, err)
 	if err != nil {
 		log.Error().Err(err).Str("stream", stream).Str("event_type", string(evt.Type)).Msg("Failed to publish event")
 		return fmt.Errorf("failed to publish event: %w", err)
@@ -73,6 +146,16 @@ func (b *RedisStreamBus) Subscribe(ctx context.Context, stream string, group str
 	b.wg.Add(1)
 	go b.consume(ctx, stream, group, handler)
 
+	if b.claimInterval > 0 {
+		b.wg.Add(1)
+		go b.reclaim(ctx, stream, group, handler)
+	}
+
+	b.redeliverRun.Do(func() {
+		b.wg.Add(1)
+		go b.redeliver(ctx)
+	})
+
 	log.Info().Str("stream", stream).Str("group", group).Str("consumer", b.consumerID).Msg("Subscribed to stream")
 	return nil
 }
@@ -81,6 +164,24 @@ func (b *RedisStreamBus) Subscribe(ctx context.Context, stream string, group str
 func (b *RedisStreamBus) consume(ctx context.Context, stream string, group string, handler event.Handler) {
 	defer b.wg.Done()
 
+	var pool *workerPool
+	if b.workers > 1 {
+		pool = newWorkerPool(b.workers, b.ordered)
+		defer pool.close()
+	}
+
+	// A blocked read is cut short when the bus stops, while the handlers keep
+	// ctx so that the messages already read can finish.
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-b.stopCh:
+			cancel()
+		case <-readCtx.Done():
+		}
+	}()
+
 	for {
 		select {
 		case <-b.stopCh:
@@ -88,14 +189,16 @@ func (b *RedisStreamBus) consume(ctx context.Context, stream string, group strin
 		case <-ctx.Done():
 			return
 		default:
-			b.readMessages(ctx, stream, group, handler)
+			b.readMessages(ctx, readCtx, stream, group, handler, pool)
 		}
 	}
 }
 
-// readMessages reads and processes messages from the stream.
-func (b *RedisStreamBus) readMessages(ctx context.Context, stream string, group string, handler event.Handler) {
-	streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+// readMessages reads messages from the stream and processes them, on the
+// worker pool when there is one. Messages not yet started when the bus
+// stops are left pending, for another consumer to reclaim.
+func (b *RedisStreamBus) readMessages(ctx, readCtx context.Context, stream string, group string, handler event.Handler, pool *workerPool) {
+	streams, err := b.client.XReadGroup(readCtx, &redis.XReadGroupArgs{
 		Group:    group,
 		Consumer: b.consumerID,
 		Streams:  []string{stream, ">"},
@@ -104,7 +207,7 @@ func (b *RedisStreamBus) readMessages(ctx context.Context, stream string, group
 	}).Result()
 
 	if err != nil {
-		if !errors.Is(err, redis.Nil) {
+		if !errors.Is(err, redis.Nil) && readCtx.Err() == nil {
 			log.Error().Err(err).Str("stream", stream).Msg("Error reading from stream")
 		}
 		return
@@ -112,8 +215,84 @@ func (b *RedisStreamBus) readMessages(ctx context.Context, stream string, group
 
 	for _, s := range streams {
 		for _, msg := range s.Messages {
+			if pool == nil {
+				if b.stopping() {
+					return
+				}
+				b.processMessage(ctx, stream, group, msg, handler)
+				continue
+			}
+
+			eventType, _ := msg.Values["type"].(string)
+			pool.submit(event.Type(eventType), func() {
+				if b.stopping() {
+					return
+				}
+				b.processMessage(ctx, stream, group, msg, handler)
+			})
+		}
+	}
+}
+
+// reclaim periodically takes over the group's idle pending messages until stopped.
+func (b *RedisStreamBus) reclaim(ctx context.Context, stream string, group string, handler event.Handler) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.claimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.claimPending(ctx, stream, group, handler)
+		}
+	}
+}
+
+// claimPending claims and processes every message pending longer than the
+// minimum idle time, walking the pending entries list with XAUTOCLAIM.
+func (b *RedisStreamBus) claimPending(ctx context.Context, stream string, group string, handler event.Handler) {
+	start := "0-0"
+	for {
+		messages, next, err := b.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   stream,
+			Group:    group,
+			MinIdle:  b.claimMinIdle,
+			Start:    start,
+			Count:    100,
+			Consumer: b.consumerID,
+		}).Result()
+		if err != nil {
+			if !errors.Is(err, redis.Nil) && ctx.Err() == nil {
+				log.Error().Err(err).Str("stream", stream).Str("group", group).Msg("Failed to claim pending messages")
+			}
+			return
+		}
+
+		if len(messages) > 0 {
+			log.Warn().
+				Str("stream", stream).
+				Str("group", group).
+				Int("count", len(messages)).
+				Msg("Reclaimed pending messages from idle consumers")
+			metrics.EventsReclaimedTotal.WithLabelValues(stream, group).Add(float64(len(messages)))
+		}
+
+		for _, msg := range messages {
+			if b.stopping() {
+				return
+			}
 			b.processMessage(ctx, stream, group, msg, handler)
 		}
+
+		if next == "0-0" || next == "" {
+			return
+		}
+		start = next
 	}
 }
 
@@ -126,9 +305,13 @@ func (b *RedisStreamBus) processMessage(ctx context.Context, stream string, grou
 		return
 	}
 
-	if err := handler(ctx, evt); err != nil {
+	spanCtx, span := startProcessSpan(ctx, DriverRedisStream, stream, group, evt)
+	err = handler(spanCtx, evt)
+	endSpan(span, err)
+
+	if err != nil {
 		log.Error().Err(err).Str("event_id", evt.ID).Str("event_type", string(evt.Type)).Msg("Failed to handle event")
-		b.handleFailedEvent(ctx, evt, err)
+		b.handleFailedEvent(ctx, group, evt, err)
 	}
 
 	b.acknowledgeMessage(ctx, stream, group, msg.ID)
@@ -141,12 +324,16 @@ func (b *RedisStreamBus) acknowledgeMessage(ctx context.Context, stream string,
 	}
 }
 
-// handleFailedEvent moves failed events to the dead letter queue.
-func (b *RedisStreamBus) handleFailedEvent(ctx context.Context, evt *event.Event, _ error) {
+// handleFailedEvent schedules a failed event for a delayed retry, or moves it
+// to the dead letter queue once the retry policy is exhausted.
+func (b *RedisStreamBus) handleFailedEvent(ctx context.Context, group string, evt *event.Event, handlerErr error) {
 	evt.Retries++
+	evt.LastError = handlerErr.Error()
+	evt.FailedGroup = group
 
-	if evt.Retries >= 3 {
-		// Move to dead letter queue
+	deadLetter, delay := b.policy.next(evt.Retries)
+	if deadLetter {
+		metrics.EventsRetriedTotal.WithLabelValues(string(evt.Type), "dead_letter").Inc()
 		if err := b.PublishToStream(ctx, event.StreamDeadLetter, evt); err != nil {
 			log.Error().Err(err).Str("event_id", evt.ID).Msg("Failed to move event to dead letter queue")
 		}
@@ -154,32 +341,257 @@ func (b *RedisStreamBus) handleFailedEvent(ctx context.Context, evt *event.Event
 		return
 	}
 
-	// Re-publish for retry
-	stream := b.getStreamForEventType(evt.Type)
-	if err := b.PublishToStream(ctx, stream, evt); err != nil {
-		log.Error().Err(err).Str("event_id", evt.ID).Msg("Failed to re-publish event for retry")
+	metrics.EventsRetriedTotal.WithLabelValues(string(evt.Type), "retry").Inc()
+	stream := streamForEvent(evt)
+	if delay <= 0 {
+		if err := b.PublishToStream(ctx, stream, evt); err != nil {
+			log.Error().Err(err).Str("event_id", evt.ID).Msg("Failed to re-publish event for retry")
+		}
+		log.Debug().Str("event_id", evt.ID).Int("retries", evt.Retries).Msg("Event re-published for retry")
+		return
+	}
+
+	if err := b.delay(ctx, stream, evt, time.Now().Add(delay)); err != nil {
+		log.Error().Err(err).Str("event_id", evt.ID).Msg("Failed to schedule event for retry")
+		return
 	}
-	log.Debug().Str("event_id", evt.ID).Int("retries", evt.Retries).Msg("Event re-published for retry")
+	log.Debug().Str("event_id", evt.ID).Int("retries", evt.Retries).Dur("delay", delay).Msg("Event scheduled for retry")
 }
 
-// Unsubscribe stops all consumers.
-func (b *RedisStreamBus) Unsubscribe() error {
-	close(b.stopCh)
-	b.wg.Wait()
+// PublishAt schedules an event for delivery to its default stream at
+// deliverAt. The event waits in the delayed set, from which the instances
+// subscribed to the bus move it to the stream once due.
+func (b *RedisStreamBus) PublishAt(ctx context.Context, evt *event.Event, deliverAt time.Time) error {
+	if !deliverAt.After(time.Now()) {
+		return b.Publish(ctx, evt)
+	}
+
+	evt.SetOrigin(b.region, b.cluster)
+	stream := streamForEvent(evt)
+	if err := b.delay(ctx, stream, evt, deliverAt); err != nil {
+		log.Error().Err(err).Str("stream", stream).Str("event_type", string(evt.Type)).Msg("Failed to schedule event")
+		return fmt.Errorf("failed to schedule event: %w", err)
+	}
+
+	metrics.EventsScheduledTotal.WithLabelValues(string(evt.Type)).Inc()
+	log.Debug().Str("stream", stream).Str("event_id", evt.ID).Time("deliver_at", deliverAt).Msg("Event scheduled")
 	return nil
 }
 
-// getStreamForEventType returns the stream name for an event type.
-func (b *RedisStreamBus) getStreamForEventType(eventType event.Type) string {
-	switch eventType {
-	case event.AlertCreated, event.AlertAcknowledged, event.AlertResolved, event.AlertDeleted, event.AlertExpired:
-		return event.StreamAlerts
-	case event.UserCreated, event.UserUpdated:
-		return event.StreamNotifications
+// delay adds an event to the delayed set, due at the given time.
+func (b *RedisStreamBus) delay(ctx context.Context, stream string, evt *event.Event, due time.Time) error {
+	data, err := json.Marshal(delayedEvent{Stream: stream, Event: evt})
+	if err != nil {
+		return err
+	}
+	return b.client.ZAdd(ctx, delayedEventsKey, redis.Z{Score: float64(due.UnixMilli()), Member: data}).Err()
+}
+
+// redeliver periodically moves due events to their stream until stopped.
+func (b *RedisStreamBus) redeliver(ctx context.Context) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(redeliverInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.redeliverDue(ctx)
+		}
+	}
+}
+
+// redeliverDue publishes every delayed event that is due, in pages. Removing
+// the entry before publishing ensures only one instance publishes it.
+func (b *RedisStreamBus) redeliverDue(ctx context.Context) {
+	for !b.stopping() {
+		members, err := b.client.ZRangeByScore(ctx, delayedEventsKey, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   strconv.FormatInt(time.Now().UnixMilli(), 10),
+			Count: delayedPageSize,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Error().Err(err).Msg("Failed to read due events")
+			}
+			return
+		}
+
+		b.publishDelayed(ctx, members)
+		if len(members) < delayedPageSize {
+			return
+		}
+	}
+}
+
+// publishDelayed publishes the delayed events of a page of the delayed set.
+func (b *RedisStreamBus) publishDelayed(ctx context.Context, members []string) {
+	for _, member := range members {
+		removed, err := b.client.ZRem(ctx, delayedEventsKey, member).Result()
+		if err != nil || removed == 0 {
+			continue
+		}
+
+		var delayed delayedEvent
+		if err := json.Unmarshal([]byte(member), &delayed); err != nil || delayed.Event == nil {
+			log.Error().Err(err).Msg("Failed to decode delayed event, dropped")
+			continue
+		}
+
+		if err := b.PublishToStream(ctx, delayed.Stream, delayed.Event); err != nil {
+			log.Error().Err(err).Str("event_id", delayed.Event.ID).Msg("Failed to publish delayed event")
+			continue
+		}
+		log.Debug().Str("event_id", delayed.Event.ID).Int("retries", delayed.Event.Retries).Msg("Delayed event published")
+	}
+}
+
+// Read returns the events retained in a stream that match the filter, oldest
+// first. Stream entry IDs start with their publish time in milliseconds, so
+// the time range maps onto an XRANGE walked in pages.
+func (b *RedisStreamBus) Read(ctx context.Context, stream string, filter event.ReadFilter) ([]*event.Event, error) {
+	start := strconv.FormatInt(filter.From.UnixMilli(), 10)
+	end := strconv.FormatInt(filter.To.UnixMilli(), 10)
+
+	events := make([]*event.Event, 0)
+	for filter.Limit <= 0 || len(events) < filter.Limit {
+		messages, err := b.client.XRangeN(ctx, stream, start, end, readPageSize).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stream: %w", err)
+		}
+
+		for _, msg := range messages {
+			evt, err := event.FromMap(msg.Values)
+			if err != nil {
+				log.Warn().Err(err).Str("stream", stream).Str("message_id", msg.ID).Msg("Skipping unreadable event")
+				continue
+			}
+			if !filter.Matches(evt) {
+				continue
+			}
+			events = append(events, evt)
+			if filter.Limit > 0 && len(events) == filter.Limit {
+				return events, nil
+			}
+		}
+
+		if len(messages) < readPageSize {
+			break
+		}
+		start = "(" + messages[len(messages)-1].ID
+	}
+
+	return events, nil
+}
+
+// Lag returns the backlog of the consumer groups of the event streams and of
+// any other stream subscribed to, from XINFO GROUPS. The publish time of the
+// oldest pending event comes from the ID of the first entry XPENDING reports.
+// Streams that do not exist yet are skipped.
+func (b *RedisStreamBus) Lag(ctx context.Context) ([]event.GroupLag, error) {
+	lags := make([]event.GroupLag, 0)
+	for _, stream := range b.inspectedStreams() {
+		info, err := b.client.XInfoStream(ctx, stream).Result()
+		if err != nil {
+			if strings.Contains(err.Error(), "no such key") {
+				continue
+			}
+			return nil, fmt.Errorf("failed to inspect stream: %w", err)
+		}
+
+		groups, err := b.client.XInfoGroups(ctx, stream).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect consumer groups: %w", err)
+		}
+
+		for _, group := range groups {
+			lag := event.GroupLag{
+				Stream:    stream,
+				Group:     group.Name,
+				Consumers: int(group.Consumers),
+				Length:    info.Length,
+				Lag:       group.Lag,
+				Pending:   group.Pending,
+			}
+			if group.Pending > 0 {
+				pending, err := b.client.XPending(ctx, stream, group.Name).Result()
+				if err != nil {
+					return nil, fmt.Errorf("failed to inspect pending events: %w", err)
+				}
+				lag.OldestPending = streamIDTime(pending.Lower)
+			}
+			lags = append(lags, lag)
+		}
+	}
+	return lags, nil
+}
+
+// inspectedStreams returns the event streams followed by those subscribed
+// to, without duplicates.
+func (b *RedisStreamBus) inspectedStreams() []string {
+	streams := []string{event.StreamAlerts, event.StreamAlertsPriority, event.StreamNotifications, event.StreamDeadLetter}
+	seen := map[string]bool{}
+	for _, stream := range streams {
+		seen[stream] = true
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	subscribed := make([]string, 0, len(b.handlers))
+	for key := range b.handlers {
+		stream := key[:strings.LastIndex(key, ":")]
+		if !seen[stream] {
+			seen[stream] = true
+			subscribed = append(subscribed, stream)
+		}
+	}
+	sort.Strings(subscribed)
+	return append(streams, subscribed...)
+}
+
+// streamIDTime returns the time a stream entry was added, which its ID
+// starts with in milliseconds.
+func streamIDTime(id string) time.Time {
+	ms, err := strconv.ParseInt(strings.SplitN(id, "-", 2)[0], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// Drain stops reading from the streams and waits until the messages being
+// handled are acknowledged, or ctx is done. Messages read but not yet handled
+// stay pending in their group, where another consumer reclaims them.
+func (b *RedisStreamBus) Drain(ctx context.Context) error {
+	b.stop.Do(func() { close(b.stopCh) })
+	return waitGroup(ctx, &b.wg)
+}
+
+// stopping reports whether the bus is stopping.
+func (b *RedisStreamBus) stopping() bool {
+	select {
+	case <-b.stopCh:
+		return true
 	default:
-		return event.StreamAlerts
+		return false
 	}
 }
 
+// Unsubscribe stops all consumers, waiting for the messages being handled.
+func (b *RedisStreamBus) Unsubscribe() error {
+	return b.Drain(context.Background())
+}
+
 // Compile-time interface verification.
-var _ event.Bus = (*RedisStreamBus)(nil)
+var (
+	_ event.Bus          = (*RedisStreamBus)(nil)
+	_ event.Reader       = (*RedisStreamBus)(nil)
+	_ event.LagInspector = (*RedisStreamBus)(nil)
+	_ event.Drainer      = (*RedisStreamBus)(nil)
+	_ event.Scheduler    = (*RedisStreamBus)(nil)
+)
@@ -123,6 +123,35 @@ func (r *Retries) calculateBackoff(attempt int) time.Duration {
 	return time.Duration(backoff)
 }
 
+// deliveryPolicy decides what happens to an event whose handler failed.
+type deliveryPolicy struct {
+	retries         *Retries
+	deadLetterAfter int
+}
+
+// newDeliveryPolicy creates a delivery policy. Failed events are re-delivered
+// with the backoff of config until they failed deadLetterAfter times, then
+// they are moved to the dead letter stream. A deadLetterAfter of zero or less
+// allows config.MaxRetries re-deliveries.
+func newDeliveryPolicy(config RetryConfig, deadLetterAfter int) deliveryPolicy {
+	if deadLetterAfter <= 0 {
+		deadLetterAfter = config.MaxRetries + 1
+	}
+	return deliveryPolicy{
+		retries:         NewRetries(config),
+		deadLetterAfter: deadLetterAfter,
+	}
+}
+
+// next reports whether an event that failed the given number of times goes
+// to the dead letter stream and, if not, how long to wait before re-delivering it.
+func (p deliveryPolicy) next(failures int) (deadLetter bool, delay time.Duration) {
+	if failures >= p.deadLetterAfter {
+		return true, 0
+	}
+	return false, p.retries.calculateBackoff(failures)
+}
+
 // IsRetryable determines if an error is retryable.
 func IsRetryable(err error) bool {
 	if err == nil {
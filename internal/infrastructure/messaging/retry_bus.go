@@ -2,10 +2,16 @@ package messaging
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
 )
 
+// ErrSchedulingUnsupported is returned when scheduling an event on a bus that
+// cannot deliver events later.
+var ErrSchedulingUnsupported = errors.New("event bus does not support scheduled delivery")
+
 // RetryableBus wraps an event bus with retry logic.
 type RetryableBus struct {
 	bus     event.Bus
@@ -34,6 +40,17 @@ func (b *RetryableBus) PublishToStream(ctx context.Context, stream string, evt *
 	})
 }
 
+// PublishAt schedules an event on the wrapped bus with retry logic.
+func (b *RetryableBus) PublishAt(ctx context.Context, evt *event.Event, deliverAt time.Time) error {
+	scheduler, ok := b.bus.(event.Scheduler)
+	if !ok {
+		return ErrSchedulingUnsupported
+	}
+	return b.retries.Do(ctx, "publish_event_at", func(ctx context.Context) error {
+		return scheduler.PublishAt(ctx, evt, deliverAt)
+	})
+}
+
 // Subscribe subscribes to a stream (no retry needed as it maintains connection).
 func (b *RetryableBus) Subscribe(ctx context.Context, stream string, group string, handler event.Handler) error {
 	return b.bus.Subscribe(ctx, stream, group, handler)
@@ -44,5 +61,17 @@ func (b *RetryableBus) Unsubscribe() error {
 	return b.bus.Unsubscribe()
 }
 
+// Drain drains the wrapped bus, or unsubscribes it when it cannot drain.
+func (b *RetryableBus) Drain(ctx context.Context) error {
+	if drainer, ok := b.bus.(event.Drainer); ok {
+		return drainer.Drain(ctx)
+	}
+	return b.bus.Unsubscribe()
+}
+
 // Compile-time interface verification.
-var _ event.Bus = (*RetryableBus)(nil)
+var (
+	_ event.Bus       = (*RetryableBus)(nil)
+	_ event.Drainer   = (*RetryableBus)(nil)
+	_ event.Scheduler = (*RetryableBus)(nil)
+)
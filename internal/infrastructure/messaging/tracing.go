@@ -0,0 +1,100 @@
+package messaging
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+)
+
+// Trace context fields of an event.
+const (
+	traceParentField = "traceparent"
+	traceStateField  = "tracestate"
+)
+
+// eventCarrier adapts the trace context fields of an event to
+// propagation.TextMapCarrier.
+type eventCarrier struct {
+	evt *event.Event
+}
+
+func (c eventCarrier) Get(key string) string {
+	switch key {
+	case traceParentField:
+		return c.evt.TraceParent
+	case traceStateField:
+		return c.evt.TraceState
+	default:
+		return ""
+	}
+}
+
+func (c eventCarrier) Set(key, value string) {
+	switch key {
+	case traceParentField:
+		c.evt.TraceParent = value
+	case traceStateField:
+		c.evt.TraceState = value
+	}
+}
+
+func (c eventCarrier) Keys() []string {
+	return []string{traceParentField, traceStateField}
+}
+
+// startPublishSpan starts the producer span of an event and stamps its trace
+// context onto the event. An event published outside of any trace, e.g. a
+// delayed retry, continues the trace it already carries.
+func startPublishSpan(ctx context.Context, system, stream string, evt *event.Event) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = tracing.Extract(ctx, eventCarrier{evt})
+	}
+
+	ctx, span := tracing.StartSpan(ctx, stream+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", system),
+			attribute.String("messaging.operation", "publish"),
+			attribute.String("messaging.destination.name", stream),
+			attribute.String("messaging.message.id", evt.ID),
+			attribute.String("event.type", string(evt.Type)),
+			attribute.Int("event.retries", evt.Retries),
+		),
+	)
+
+	tracing.Inject(ctx, eventCarrier{evt})
+	return ctx, span
+}
+
+// startProcessSpan starts the consumer span of handling an event, as a
+// child of the span that published it.
+func startProcessSpan(ctx context.Context, system, stream, group string, evt *event.Event) (context.Context, trace.Span) {
+	ctx = tracing.Extract(ctx, eventCarrier{evt})
+
+	return tracing.StartSpan(ctx, stream+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", system),
+			attribute.String("messaging.operation", "process"),
+			attribute.String("messaging.destination.name", stream),
+			attribute.String("messaging.consumer.group.name", group),
+			attribute.String("messaging.message.id", evt.ID),
+			attribute.String("event.type", string(evt.Type)),
+			attribute.Int("event.retries", evt.Retries),
+		),
+	)
+}
+
+// endSpan ends a span, recording the error it ended with, if any.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
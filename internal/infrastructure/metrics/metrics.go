@@ -2,8 +2,11 @@
 package metrics
 
 import (
+	"sort"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	dto "github.com/prometheus/client_model/go"
 )
 
 // HTTP metrics.
@@ -64,6 +67,13 @@ var (
 		},
 	)
 
+	AlertsExpiredTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "alerts_expired_total",
+			Help: "Total number of alerts that passed their expiration time unresolved",
+		},
+	)
+
 	AlertsActiveGauge = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "alerts_active",
@@ -106,6 +116,103 @@ var (
 		},
 		[]string{"event_type"},
 	)
+
+	EventsFederatedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_federated_total",
+			Help: "Total number of events forwarded to a remote deployment",
+		},
+		[]string{"event_type", "status"},
+	)
+
+	EventsReclaimedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_reclaimed_total",
+			Help: "Total number of pending events reclaimed from idle consumers",
+		},
+		[]string{"stream", "group"},
+	)
+
+	EventsRetriedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_retried_total",
+			Help: "Total number of failed events scheduled for retry or moved to the dead letter stream",
+		},
+		[]string{"event_type", "outcome"},
+	)
+
+	EventsScheduledTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_scheduled_total",
+			Help: "Total number of events scheduled for delayed delivery",
+		},
+		[]string{"event_type"},
+	)
+
+	EventsReplayedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_replayed_total",
+			Help: "Total number of events re-dispatched by an admin replay",
+		},
+		[]string{"event_type", "stream"},
+	)
+
+	EventsDeduplicatedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_deduplicated_total",
+			Help: "Total number of event deliveries skipped by a handler that already processed the event",
+		},
+		[]string{"event_type"},
+	)
+
+	EventHandlerInvocationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "event_handler_invocations_total",
+			Help: "Total number of events run through a handler, by outcome (success, error or skipped)",
+		},
+		[]string{"handler", "event_type", "status"},
+	)
+
+	EventHandlerDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "event_handler_duration_seconds",
+			Help:    "Time a handler took to process an event, in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"handler", "event_type"},
+	)
+
+	EventStreamLength = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "event_stream_length",
+			Help: "Number of events retained in a stream",
+		},
+		[]string{"stream"},
+	)
+
+	EventConsumerLag = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "event_consumer_lag",
+			Help: "Number of events in a stream not yet delivered to a consumer group",
+		},
+		[]string{"stream", "group"},
+	)
+
+	EventConsumerPending = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "event_consumer_pending",
+			Help: "Number of events delivered to a consumer group but not yet acknowledged",
+		},
+		[]string{"stream", "group"},
+	)
+
+	EventConsumerOldestPendingAge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "event_consumer_oldest_pending_age_seconds",
+			Help: "Age of the oldest event pending in a consumer group",
+		},
+		[]string{"stream", "group"},
+	)
 )
 
 // WebSocket metrics.
@@ -124,12 +231,49 @@ var (
 		},
 	)
 
+	WebSocketUsersOnline = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "websocket_users_online",
+			Help: "Current number of users with an active WebSocket connection",
+		},
+	)
+
 	WebSocketMessagesSent = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "websocket_messages_sent_total",
 			Help: "Total number of WebSocket messages sent",
 		},
 	)
+
+	WebSocketMessagesDropped = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "websocket_messages_dropped_total",
+			Help: "Total number of WebSocket messages dropped for slow clients",
+		},
+	)
+
+	WebSocketSlowClientsDisconnected = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "websocket_slow_clients_disconnected_total",
+			Help: "Total number of WebSocket clients disconnected for falling behind",
+		},
+	)
+
+	WebSocketAuthDisconnectsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "websocket_auth_disconnects_total",
+			Help: "Total number of WebSocket clients disconnected because their token expired or was revoked",
+		},
+		[]string{"reason"},
+	)
+
+	RelayMessagesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "websocket_relay_messages_total",
+			Help: "Total number of WebSocket broadcasts relayed between instances",
+		},
+		[]string{"direction"},
+	)
 )
 
 // Database metrics.
@@ -166,6 +310,28 @@ var (
 			Help: "Total number of cache misses",
 		},
 	)
+
+	CacheStaleHitsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cache_stale_hits_total",
+			Help: "Total number of stale cache hits served while the value is refreshed",
+		},
+	)
+
+	LocalCacheRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "local_cache_requests_total",
+			Help: "Total number of lookups in the in-process cache in front of Redis",
+		},
+		[]string{"result"},
+	)
+
+	LocalCacheEvictionsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "local_cache_evictions_total",
+			Help: "Total number of entries evicted from the full in-process cache",
+		},
+	)
 )
 
 // Circuit breaker metrics.
@@ -203,4 +369,401 @@ var (
 			Help: "Total number of tokens issued",
 		},
 	)
+
+	AuthLockoutsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "auth_lockouts_total",
+			Help: "Total number of accounts locked after repeated failed logins",
+		},
+	)
+
+	AuthRevocationChecksTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_revocation_checks_total",
+			Help: "Total number of token revocation checks, by where they were answered (filter or redis)",
+		},
+		[]string{"source"},
+	)
+
+	AuthRevokedTokens = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "auth_revoked_tokens",
+			Help: "Number of revoked tokens not yet expired, as of the last revocation filter rebuild",
+		},
+	)
+
+	NetworkACLDeniedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "network_acl_denied_total",
+			Help: "Total number of requests refused by a network ACL",
+		},
+		[]string{"group"},
+	)
+)
+
+// Feature flag and deprecation metrics.
+var (
+	FeatureFlagEvaluationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "feature_flag_evaluations_total",
+			Help: "Total number of feature flag evaluations",
+		},
+		[]string{"feature", "enabled"},
+	)
+
+	FeatureFlagEnabled = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "feature_flag_enabled",
+			Help: "Deployment-wide state of each feature flag (1=enabled, 0=disabled)",
+		},
+		[]string{"feature"},
+	)
+
+	DeprecatedRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_deprecated_requests_total",
+			Help: "Total number of requests to deprecated endpoints",
+		},
+		[]string{"method", "path"},
+	)
+)
+
+// Notification metrics.
+var (
+	NotificationsSuppressedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "notifications_suppressed_total",
+			Help: "Total number of notifications queued during quiet hours",
+		},
+		[]string{"channel"},
+	)
+
+	QuietHoursDigestsSentTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "quiet_hours_digests_sent_total",
+			Help: "Total number of quiet hours digests delivered",
+		},
+		[]string{"channel"},
+	)
+
+	NotificationsBatchedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "notifications_batched_total",
+			Help: "Total number of notifications queued for a digest mode summary",
+		},
+		[]string{"channel"},
+	)
+
+	NotificationBatchesSentTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "notification_batches_sent_total",
+			Help: "Total number of digest mode summaries delivered",
+		},
+		[]string{"channel"},
+	)
+)
+
+// Quota metrics.
+var (
+	QuotaConsumedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "quota_consumed_total",
+			Help: "Total usage counted against daily quotas",
+		},
+		[]string{"subject", "metric"},
+	)
+
+	QuotaExceededTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "quota_exceeded_total",
+			Help: "Total number of requests rejected by an exhausted daily quota",
+		},
+		[]string{"subject", "metric"},
+	)
+
+	QuotaUsageRatio = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "quota_usage_ratio",
+			Help: "Fraction of the daily quota used, as last seen by this instance",
+		},
+		[]string{"subject", "metric"},
+	)
+)
+
+// Flapping metrics.
+var (
+	AlertsFlappingTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "alerts_flapping_total",
+			Help: "Total number of alert conditions detected flapping",
+		},
+	)
+
+	FlappingNotificationsSuppressedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "flapping_notifications_suppressed_total",
+			Help: "Total number of notifications suppressed because their alert was flapping",
+		},
+	)
+)
+
+// Topology metrics.
+var (
+	AlertsDownstreamTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "alerts_downstream_total",
+			Help: "Total number of alerts raised while a source they depend on had an active critical alert",
+		},
+	)
+
+	DownstreamNotificationsSuppressedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "downstream_notifications_suppressed_total",
+			Help: "Total number of notifications suppressed because their alert was downstream",
+		},
+	)
+)
+
+// Maintenance metrics.
+var (
+	SilencedNotificationsSuppressedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "silenced_notifications_suppressed_total",
+			Help: "Total number of notifications suppressed because their source was under maintenance",
+		},
+	)
+)
+
+// Rule engine metrics.
+var (
+	RuleEvaluationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rule_evaluations_total",
+			Help: "Total number of alert rule evaluations by outcome (not_met, pending, cooldown, fired)",
+		},
+		[]string{"outcome"},
+	)
+)
+
+// Alert enrichment metrics.
+var (
+	AlertEnrichmentDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "alert_enrichment_duration_seconds",
+			Help:    "Time spent by each enricher on a new alert, in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"enricher"},
+	)
+
+	AlertEnrichmentFailuresTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "alert_enrichment_failures_total",
+			Help: "Total number of enrichers that failed or timed out on a new alert",
+		},
+		[]string{"enricher"},
+	)
+)
+
+// Heartbeat metrics.
+var (
+	HeartbeatPingsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "heartbeat_pings_total",
+			Help: "Total number of heartbeat pings received",
+		},
+	)
+
+	HeartbeatsMissedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "heartbeats_missed_total",
+			Help: "Total number of heartbeats that went down for a missed ping",
+		},
+	)
+)
+
+// Source registry metrics.
+var (
+	SourcesSilentTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "sources_silent_total",
+			Help: "Total number of registered sources that went silent",
+		},
+	)
+)
+
+// Severity rule metrics.
+var (
+	AlertsReclassifiedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "alerts_reclassified_total",
+			Help: "Total number of alerts whose severity was changed by a severity rule",
+		},
+		[]string{"from", "to"},
+	)
+)
+
+// Assignment rule metrics.
+var (
+	AlertsAutoAssignedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "alerts_auto_assigned_total",
+			Help: "Total number of alerts assigned by an assignment rule",
+		},
+	)
 )
+
+// Uptime check metrics.
+var (
+	CheckProbesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "check_probes_total",
+			Help: "Total number of uptime check probes by result (success, failure)",
+		},
+		[]string{"result"},
+	)
+
+	CheckProbeDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "check_probe_duration_seconds",
+			Help:    "Uptime check probe duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+
+	ChecksDownTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "checks_down_total",
+			Help: "Total number of uptime checks that went down",
+		},
+	)
+)
+
+// Outbound webhook subscription metrics.
+var (
+	SubscriptionDeliveriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subscription_deliveries_total",
+			Help: "Total number of webhook subscription delivery attempts by result (delivered, retried, failed)",
+		},
+		[]string{"result"},
+	)
+
+	SubscriptionDeliveryDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "subscription_delivery_duration_seconds",
+			Help:    "Webhook subscription delivery request duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+)
+
+// On-call platform metrics.
+var (
+	ExternalIncidentsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_incidents_total",
+			Help: "Total number of incident actions forwarded to on-call platforms by platform, action (open, acknowledge, close) and result (success, failed)",
+		},
+		[]string{"platform", "action", "result"},
+	)
+
+	VoiceEscalationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "voice_escalations_total",
+			Help: "Total number of escalation calls for unacknowledged critical alerts by result (placed, failed, no_number)",
+		},
+		[]string{"result"},
+	)
+)
+
+// Incident metrics.
+var (
+	IncidentsOpenedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "incidents_opened_total",
+			Help: "Total number of incidents opened by trigger (manual, correlation)",
+		},
+		[]string{"trigger"},
+	)
+
+	IncidentAlertsCorrelatedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "incident_alerts_correlated_total",
+			Help: "Total number of alerts grouped into incidents by correlation",
+		},
+	)
+)
+
+// SLA metrics.
+var (
+	SLABreachesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sla_breaches_total",
+			Help: "Total number of alerts that missed an SLA target by target (ack, resolve)",
+		},
+		[]string{"target"},
+	)
+)
+
+// Scheduler metrics.
+var (
+	SchedulerJobRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scheduler_job_runs_total",
+			Help: "Total number of scheduled job runs by outcome (success, failed, or skipped when another instance ran it)",
+		},
+		[]string{"job", "status"},
+	)
+
+	SchedulerJobDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "scheduler_job_duration_seconds",
+			Help:    "Time a scheduled job took to run, in seconds",
+			Buckets: []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+		},
+		[]string{"job"},
+	)
+)
+
+// WithConstLabels wraps a gatherer so that every exported sample carries the given labels.
+// Labels already present on a sample are left untouched.
+func WithConstLabels(g prometheus.Gatherer, labels map[string]string) prometheus.Gatherer {
+	if len(labels) == 0 {
+		return g
+	}
+
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		families, err := g.Gather()
+		for _, family := range families {
+			for _, m := range family.GetMetric() {
+				m.Label = appendMissingLabels(m.GetLabel(), labels)
+			}
+		}
+		return families, err
+	})
+}
+
+// appendMissingLabels adds the labels that are not yet present on a sample.
+func appendMissingLabels(pairs []*dto.LabelPair, labels map[string]string) []*dto.LabelPair {
+	existing := make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		existing[pair.GetName()] = true
+	}
+
+	for name, value := range labels {
+		if existing[name] {
+			continue
+		}
+		pairs = append(pairs, &dto.LabelPair{
+			Name:  &name,
+			Value: &value,
+		})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].GetName() < pairs[j].GetName()
+	})
+
+	return pairs
+}
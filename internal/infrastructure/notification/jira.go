@@ -0,0 +1,280 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+)
+
+// Jira REST API limits.
+const (
+	// jiraMaxSummaryLength is the maximum length of the summary of an issue.
+	jiraMaxSummaryLength = 255
+	// jiraMaxDescriptionLength is the maximum length of its description.
+	jiraMaxDescriptionLength = 32000
+)
+
+// JiraClient files issues for alerts in a Jira project through the REST API,
+// authenticated with the API token of a user, and resolves them by moving
+// them through a workflow transition.
+type JiraClient struct {
+	baseURL           string
+	email             string
+	apiToken          string
+	projectKey        string
+	issueType         string
+	resolveTransition string
+	setPriority       bool
+	enabled           bool
+	client            *http.Client
+}
+
+// jiraIssue represents a create issue request.
+type jiraIssue struct {
+	Fields jiraIssueFields `json:"fields"`
+}
+
+// jiraIssueFields represents the fields of a created issue.
+type jiraIssueFields struct {
+	Project     jiraKey   `json:"project"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description,omitempty"`
+	IssueType   jiraName  `json:"issuetype"`
+	Labels      []string  `json:"labels,omitempty"`
+	Priority    *jiraName `json:"priority,omitempty"`
+}
+
+// jiraKey references a Jira object by key.
+type jiraKey struct {
+	Key string `json:"key"`
+}
+
+// jiraName references a Jira object by name.
+type jiraName struct {
+	Name string `json:"name"`
+}
+
+// jiraComment represents an add comment request.
+type jiraComment struct {
+	Body string `json:"body"`
+}
+
+// jiraTransitions represents the transitions available on an issue.
+type jiraTransitions struct {
+	Transitions []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"transitions"`
+}
+
+// jiraTransition represents a transition issue request.
+type jiraTransition struct {
+	Transition struct {
+		ID string `json:"id"`
+	} `json:"transition"`
+}
+
+// jiraErrors represents the errors returned by the REST API.
+type jiraErrors struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// NewJiraClient creates a new Jira client.
+func NewJiraClient(cfg config.JiraConfig, timeout time.Duration) *JiraClient {
+	return &JiraClient{
+		baseURL:           strings.TrimSuffix(cfg.BaseURL, "/"),
+		email:             cfg.Email,
+		apiToken:          cfg.APIToken,
+		projectKey:        cfg.ProjectKey,
+		issueType:         cfg.IssueType,
+		resolveTransition: cfg.ResolveTransition,
+		setPriority:       cfg.SetPriority,
+		enabled:           cfg.Enabled && cfg.BaseURL != "" && cfg.APIToken != "" && cfg.ProjectKey != "",
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// CreateIssue files an issue in the project and returns its key.
+func (c *JiraClient) CreateIssue(ctx context.Context, issue notification.Issue) (string, error) {
+	ctx, span := tracing.StartSpan(ctx, "jira.create_issue", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	fields := jiraIssueFields{
+		Project:     jiraKey{Key: c.projectKey},
+		Summary:     truncate(issue.Summary, jiraMaxSummaryLength),
+		Description: truncate(issue.Description, jiraMaxDescriptionLength),
+		IssueType:   jiraName{Name: c.issueType},
+		Labels:      issue.Labels,
+	}
+	if c.setPriority {
+		fields.Priority = &jiraName{Name: jiraPriority(issue.Severity)}
+	}
+
+	var created jiraKey
+	if err := c.call(ctx, http.MethodPost, "/rest/api/2/issue", jiraIssue{Fields: fields}, &created); err != nil {
+		return "", err
+	}
+	span.SetAttributes(attribute.String("jira.issue", created.Key))
+
+	log.Debug().Str("issue", created.Key).Msg("Jira issue created")
+
+	return created.Key, nil
+}
+
+// ResolveIssue comments on an issue and moves it through the resolve
+// transition. Issues the transition is not available on, such as those
+// already resolved by hand, are only commented on.
+func (c *JiraClient) ResolveIssue(ctx context.Context, key, comment string) error {
+	ctx, span := tracing.StartSpan(ctx, "jira.resolve_issue", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	span.SetAttributes(attribute.String("jira.issue", key))
+
+	path := "/rest/api/2/issue/" + url.PathEscape(key)
+
+	if comment != "" {
+		if err := c.call(ctx, http.MethodPost, path+"/comment", jiraComment{Body: comment}, nil); err != nil {
+			return err
+		}
+	}
+
+	var available jiraTransitions
+	if err := c.call(ctx, http.MethodGet, path+"/transitions", nil, &available); err != nil {
+		return err
+	}
+
+	var transition jiraTransition
+	for _, t := range available.Transitions {
+		if strings.EqualFold(t.Name, c.resolveTransition) {
+			transition.Transition.ID = t.ID
+			break
+		}
+	}
+	if transition.Transition.ID == "" {
+		log.Warn().
+			Str("issue", key).
+			Str("transition", c.resolveTransition).
+			Msg("Jira resolve transition not available, issue left as is")
+		return nil
+	}
+
+	if err := c.call(ctx, http.MethodPost, path+"/transitions", transition, nil); err != nil {
+		return err
+	}
+
+	log.Debug().Str("issue", key).Msg("Jira issue resolved")
+
+	return nil
+}
+
+// IssueURL returns the link to an issue.
+func (c *JiraClient) IssueURL(key string) string {
+	return c.baseURL + "/browse/" + url.PathEscape(key)
+}
+
+// IsEnabled returns whether the client is enabled.
+func (c *JiraClient) IsEnabled() bool {
+	return c.enabled
+}
+
+// call sends a request to the Jira REST API and decodes the response into
+// result, if not nil.
+func (c *JiraClient) call(ctx context.Context, method, path string, body, result interface{}) error {
+	span := trace.SpanFromContext(ctx)
+
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal jira request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.email, c.apiToken)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	tracing.InjectHTTP(ctx, req.Header)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to call jira: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		span.SetStatus(codes.Error, resp.Status)
+		if detail := jiraErrorDetail(resp.Body); detail != "" {
+			return fmt.Errorf("jira returned non-2xx status %d: %s", resp.StatusCode, detail)
+		}
+		return fmt.Errorf("jira returned non-2xx status: %d", resp.StatusCode)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("failed to decode jira response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// jiraErrorDetail returns the errors described by an error response, such
+// as the fields rejected on create.
+func jiraErrorDetail(body io.Reader) string {
+	var errs jiraErrors
+	if err := json.NewDecoder(body).Decode(&errs); err != nil {
+		return ""
+	}
+
+	details := append([]string(nil), errs.ErrorMessages...)
+	for field, message := range errs.Errors {
+		details = append(details, field+": "+message)
+	}
+	return strings.Join(details, "; ")
+}
+
+// jiraPriority maps severity to the default Jira priorities.
+func jiraPriority(severity string) string {
+	switch severity {
+	case notification.SeverityCritical:
+		return "Highest"
+	case notification.SeverityHigh:
+		return "High"
+	case notification.SeverityMedium:
+		return "Medium"
+	case notification.SeverityLow:
+		return "Low"
+	default:
+		return "Lowest"
+	}
+}
+
+// Compile-time interface verification.
+var _ notification.IssueTracker = (*JiraClient)(nil)
@@ -0,0 +1,282 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+)
+
+// Opsgenie Alert API limits and timings.
+const (
+	// opsgenieMaxMessageLength is the maximum length of the message of an alert.
+	opsgenieMaxMessageLength = 130
+	// opsgenieMaxDescriptionLength is the maximum length of its description.
+	opsgenieMaxDescriptionLength = 15000
+	// opsgenieRequestAttempts is how many times the status of a create
+	// request is read, waiting opsgenieRequestDelay in between, before giving
+	// up on learning the ID of the created alert.
+	opsgenieRequestAttempts = 3
+	opsgenieRequestDelay    = 500 * time.Millisecond
+	// opsgenieSource is the source recorded on Opsgenie alerts and actions.
+	opsgenieSource = "realtime-alerting-system"
+)
+
+// errOpsgenieRequestPending is returned while Opsgenie has not processed a
+// create request yet.
+var errOpsgenieRequestPending = errors.New("opsgenie request is not processed yet")
+
+// OpsgenieNotifier mirrors alerts as Opsgenie alerts. Each alert is created
+// with the alert ID as alias, so that creating it again is deduplicated by
+// Opsgenie, and is then acknowledged and closed by its Opsgenie ID.
+type OpsgenieNotifier struct {
+	apiURL      string
+	apiKey      string
+	minSeverity string
+	enabled     bool
+	client      *http.Client
+}
+
+// opsgenieAlert represents a create alert request.
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
+	Source      string            `json:"source"`
+	Priority    string            `json:"priority"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+// opsgenieAction represents an acknowledge or close alert request.
+type opsgenieAction struct {
+	Source string `json:"source"`
+	Note   string `json:"note,omitempty"`
+}
+
+// opsgenieAccepted represents the response to an asynchronous request.
+type opsgenieAccepted struct {
+	RequestID string `json:"requestId"`
+}
+
+// opsgenieRequestStatus represents the status of a processed request.
+type opsgenieRequestStatus struct {
+	Data struct {
+		Success bool   `json:"success"`
+		Status  string `json:"status"`
+		AlertID string `json:"alertId"`
+	} `json:"data"`
+}
+
+// NewOpsgenieNotifier creates a new Opsgenie notifier.
+func NewOpsgenieNotifier(cfg config.OpsgenieConfig, timeout time.Duration) *OpsgenieNotifier {
+	return &OpsgenieNotifier{
+		apiURL:      strings.TrimSuffix(cfg.APIURL, "/"),
+		apiKey:      cfg.APIKey,
+		minSeverity: cfg.MinSeverity,
+		enabled:     cfg.Enabled && cfg.APIKey != "",
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Open creates the Opsgenie alert of an alert of at least the minimum
+// severity and returns its Opsgenie ID.
+func (n *OpsgenieNotifier) Open(ctx context.Context, msg notification.Message) (string, error) {
+	if !n.enabled || !notification.ShouldNotify(msg.Severity, n.minSeverity) {
+		return "", nil
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "opsgenie.open", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	span.SetAttributes(attribute.String("alert.id", msg.AlertID))
+
+	details := make(map[string]string, len(msg.Labels)+len(msg.Fields)+1)
+	for key, value := range msg.Labels {
+		details[key] = value
+	}
+	for key, value := range msg.Fields {
+		details[key] = value
+	}
+	if msg.RunbookURL != "" {
+		details["runbook"] = msg.RunbookURL
+	}
+
+	var accepted opsgenieAccepted
+	err := n.call(ctx, http.MethodPost, "/v2/alerts", opsgenieAlert{
+		Message:     truncate(msg.Title, opsgenieMaxMessageLength),
+		Alias:       msg.AlertID,
+		Description: truncate(msg.Text, opsgenieMaxDescriptionLength),
+		Entity:      msg.Source,
+		Source:      opsgenieSource,
+		Priority:    opsgeniePriority(msg.Severity),
+		Tags:        []string{msg.Severity},
+		Details:     details,
+	}, &accepted)
+	if err != nil {
+		return "", err
+	}
+
+	// Alerts are created asynchronously; their ID is known once the request
+	// is processed
+	incidentID, err := n.createdAlertID(ctx, accepted.RequestID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+
+	log.Debug().
+		Str("alert_id", msg.AlertID).
+		Str("opsgenie_alert_id", incidentID).
+		Msg("Opsgenie alert created")
+
+	return incidentID, nil
+}
+
+// Acknowledge acknowledges an Opsgenie alert.
+func (n *OpsgenieNotifier) Acknowledge(ctx context.Context, incidentID string, msg notification.Message) error {
+	ctx, span := tracing.StartSpan(ctx, "opsgenie.acknowledge", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	return n.call(ctx, http.MethodPost, "/v2/alerts/"+url.PathEscape(incidentID)+"/acknowledge?identifierType=id",
+		opsgenieAction{Source: opsgenieSource, Note: msg.Text}, nil)
+}
+
+// Close closes an Opsgenie alert.
+func (n *OpsgenieNotifier) Close(ctx context.Context, incidentID string, msg notification.Message) error {
+	ctx, span := tracing.StartSpan(ctx, "opsgenie.close", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	return n.call(ctx, http.MethodPost, "/v2/alerts/"+url.PathEscape(incidentID)+"/close?identifierType=id",
+		opsgenieAction{Source: opsgenieSource, Note: msg.Text}, nil)
+}
+
+// Name returns the notifier name.
+func (n *OpsgenieNotifier) Name() string {
+	return "opsgenie"
+}
+
+// IsEnabled returns whether the notifier is enabled.
+func (n *OpsgenieNotifier) IsEnabled() bool {
+	return n.enabled
+}
+
+// createdAlertID waits for a create request to be processed and returns the
+// ID of the created alert.
+func (n *OpsgenieNotifier) createdAlertID(ctx context.Context, requestID string) (string, error) {
+	for attempt := 1; ; attempt++ {
+		var status opsgenieRequestStatus
+		err := n.call(ctx, http.MethodGet, "/v2/alerts/requests/"+url.PathEscape(requestID), nil, &status)
+		switch {
+		case err == nil && status.Data.Success && status.Data.AlertID != "":
+			return status.Data.AlertID, nil
+		case err == nil && !status.Data.Success && status.Data.Status != "":
+			return "", fmt.Errorf("opsgenie rejected the alert: %s", status.Data.Status)
+		case err == nil:
+			err = errOpsgenieRequestPending
+		}
+
+		// Unprocessed requests are not found yet
+		if attempt == opsgenieRequestAttempts {
+			return "", err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(opsgenieRequestDelay):
+		}
+	}
+}
+
+// call sends a request to the Opsgenie API and decodes the response into
+// result, if not nil.
+func (n *OpsgenieNotifier) call(ctx context.Context, method, path string, body, result interface{}) error {
+	span := trace.SpanFromContext(ctx)
+
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal opsgenie request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, n.apiURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "GenieKey "+n.apiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	tracing.InjectHTTP(ctx, req.Header)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to call opsgenie: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		span.SetStatus(codes.Error, resp.Status)
+		return fmt.Errorf("opsgenie returned non-2xx status: %d", resp.StatusCode)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return fmt.Errorf("failed to decode opsgenie response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// opsgeniePriority maps severity to Opsgenie priority, P1 being the highest.
+func opsgeniePriority(severity string) string {
+	switch severity {
+	case notification.SeverityCritical:
+		return "P1"
+	case notification.SeverityHigh:
+		return "P2"
+	case notification.SeverityMedium:
+		return "P3"
+	case notification.SeverityLow:
+		return "P4"
+	default:
+		return "P5"
+	}
+}
+
+// truncate shortens text to at most limit characters.
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// Compile-time interface verification.
+var _ notification.IncidentForwarder = (*OpsgenieNotifier)(nil)
@@ -7,55 +7,92 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
 )
 
-// SlackNotifier sends notifications to Slack.
+// SlackNotifier sends notifications to Slack as Block Kit messages. When the
+// Slack app's signing secret is configured, alert messages carry Acknowledge
+// and Resolve buttons.
 type SlackNotifier struct {
-	webhookURL string
-	channel    string
-	username   string
-	enabled    bool
-	client     *http.Client
+	webhookURL  string
+	channel     string
+	username    string
+	enabled     bool
+	interactive bool
+	client      *http.Client
 }
 
-// slackMessage represents a Slack message payload.
+// slackMessage represents a Slack message payload. Text is the fallback
+// shown in notifications.
 type slackMessage struct {
 	Channel     string            `json:"channel,omitempty"`
 	Username    string            `json:"username,omitempty"`
 	IconEmoji   string            `json:"icon_emoji,omitempty"`
+	Text        string            `json:"text,omitempty"`
 	Attachments []slackAttachment `json:"attachments"`
 }
 
-// slackAttachment represents a Slack message attachment.
+// slackAttachment represents a Slack message attachment, coloring the side
+// bar of its blocks.
 type slackAttachment struct {
-	Color     string       `json:"color"`
-	Title     string       `json:"title"`
-	Text      string       `json:"text"`
-	Fields    []slackField `json:"fields,omitempty"`
-	Footer    string       `json:"footer,omitempty"`
-	Timestamp int64        `json:"ts,omitempty"`
+	Color  string       `json:"color"`
+	Blocks []slackBlock `json:"blocks"`
 }
 
-// slackField represents a field in a Slack attachment.
-type slackField struct {
-	Title string `json:"title"`
-	Value string `json:"value"`
-	Short bool   `json:"short"`
+// slackBlock represents a Block Kit layout block. Elements are the buttons
+// of actions blocks and the text of context blocks.
+type slackBlock struct {
+	Type     string        `json:"type"`
+	BlockID  string        `json:"block_id,omitempty"`
+	Text     *slackText    `json:"text,omitempty"`
+	Fields   []slackText   `json:"fields,omitempty"`
+	Elements []interface{} `json:"elements,omitempty"`
 }
 
+// slackText represents a Block Kit text object.
+type slackText struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+// slackElement represents a Block Kit button.
+type slackElement struct {
+	Type     string     `json:"type"`
+	Text     *slackText `json:"text,omitempty"`
+	ActionID string     `json:"action_id,omitempty"`
+	Value    string     `json:"value,omitempty"`
+	Style    string     `json:"style,omitempty"`
+}
+
+// slackResponse represents a reply posted to the response URL of an interaction.
+type slackResponse struct {
+	ResponseType    string `json:"response_type"`
+	ReplaceOriginal bool   `json:"replace_original"`
+	Text            string `json:"text"`
+}
+
+// slackMaxSectionFields is the maximum number of fields of a section block.
+const slackMaxSectionFields = 10
+
 // NewSlackNotifier creates a new Slack notifier.
 func NewSlackNotifier(cfg config.SlackConfig, timeout time.Duration) *SlackNotifier {
 	return &SlackNotifier{
-		webhookURL: cfg.WebhookURL,
-		channel:    cfg.Channel,
-		username:   cfg.Username,
-		enabled:    cfg.Enabled && cfg.WebhookURL != "",
+		webhookURL:  cfg.WebhookURL,
+		channel:     cfg.Channel,
+		username:    cfg.Username,
+		enabled:     cfg.Enabled && cfg.WebhookURL != "",
+		interactive: cfg.SigningSecret != "",
 		client: &http.Client{
 			Timeout: timeout,
 		},
@@ -69,100 +106,191 @@ func (n *SlackNotifier) Send(ctx context.Context, msg notification.Message) erro
 		return nil
 	}
 
+	ctx, span := tracing.StartSpan(ctx, "slack.send", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	span.SetAttributes(attribute.String("alert.id", msg.AlertID))
+
 	slackMsg := n.buildMessage(msg)
 
-	payload, err := json.Marshal(slackMsg)
+	if err := n.post(ctx, n.webhookURL, slackMsg); err != nil {
+		return err
+	}
+
+	log.Debug().
+		Str("alert_id", msg.AlertID).
+		Str("severity", msg.Severity).
+		Msg("Slack notification sent")
+
+	return nil
+}
+
+// Name returns the notifier name.
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// IsEnabled returns whether the notifier is enabled.
+func (n *SlackNotifier) IsEnabled() bool {
+	return n.enabled
+}
+
+// Respond posts a reply, visible only to the user who clicked, to the
+// response URL of a Slack interaction.
+func (n *SlackNotifier) Respond(ctx context.Context, responseURL, text string) error {
+	ctx, span := tracing.StartSpan(ctx, "slack.respond", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	return n.post(ctx, responseURL, slackResponse{
+		ResponseType: "ephemeral",
+		Text:         text,
+	})
+}
+
+// post sends a JSON payload to a Slack URL.
+func (n *SlackNotifier) post(ctx context.Context, url string, body interface{}) error {
+	span := trace.SpanFromContext(ctx)
+
+	payload, err := json.Marshal(body)
 	if err != nil {
 		return fmt.Errorf("failed to marshal slack message: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(payload))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	tracing.InjectHTTP(ctx, req.Header)
 
 	resp, err := n.client.Do(req)
 	if err != nil {
+		tracing.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
 		return fmt.Errorf("failed to send slack message: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
 	if resp.StatusCode != http.StatusOK {
+		span.SetStatus(codes.Error, resp.Status)
 		return fmt.Errorf("slack returned non-200 status: %d", resp.StatusCode)
 	}
 
-	log.Debug().
-		Str("alert_id", msg.AlertID).
-		Str("severity", msg.Severity).
-		Msg("Slack notification sent")
-
 	return nil
 }
 
-// Name returns the notifier name.
-func (n *SlackNotifier) Name() string {
-	return "slack"
-}
-
-// IsEnabled returns whether the notifier is enabled.
-func (n *SlackNotifier) IsEnabled() bool {
-	return n.enabled
-}
-
-// buildMessage builds a Slack message from a notification message.
+// buildMessage builds a Slack message from a notification message: the
+// title and text, a section of fields, the action buttons and a footer.
 func (n *SlackNotifier) buildMessage(msg notification.Message) slackMessage {
 	color := n.severityToColor(msg.Severity)
 	emoji := n.severityToEmoji(msg.Severity)
 
-	fields := make([]slackField, 0)
-	fields = append(fields, slackField{
-		Title: "Severity",
-		Value: fmt.Sprintf("%s %s", emoji, msg.Severity),
-		Short: true,
-	})
+	fields := make([]slackText, 0)
+	fields = append(fields, slackMarkdownField("Severity", fmt.Sprintf("%s %s", emoji, msg.Severity)))
 
 	if msg.Source != "" {
-		fields = append(fields, slackField{
-			Title: "Source",
-			Value: msg.Source,
-			Short: true,
-		})
+		fields = append(fields, slackMarkdownField("Source", msg.Source))
 	}
 
 	if msg.AlertID != "" {
-		fields = append(fields, slackField{
-			Title: "Alert ID",
-			Value: msg.AlertID,
-			Short: true,
-		})
+		fields = append(fields, slackMarkdownField("Alert ID", msg.AlertID))
+	}
+
+	keys := make([]string, 0, len(msg.Fields))
+	for key := range msg.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fields = append(fields, slackMarkdownField(key, msg.Fields[key]))
+	}
+
+	if msg.RunbookURL != "" {
+		fields = append(fields, slackMarkdownField("Runbook", "<"+msg.RunbookURL+"|Open runbook>"))
+	}
+
+	text := "*" + msg.Title + "*"
+	if msg.Text != "" {
+		text += "\n" + msg.Text
+	}
+	blocks := []slackBlock{
+		{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}},
+	}
+
+	for start := 0; start < len(fields); start += slackMaxSectionFields {
+		end := min(start+slackMaxSectionFields, len(fields))
+		blocks = append(blocks, slackBlock{Type: "section", Fields: fields[start:end]})
 	}
 
-	for key, value := range msg.Fields {
-		fields = append(fields, slackField{
-			Title: key,
-			Value: value,
-			Short: true,
+	if msg.Remediation != "" {
+		blocks = append(blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: "*Remediation*\n" + msg.Remediation},
 		})
 	}
 
+	if buttons := n.actionButtons(msg); len(buttons) > 0 {
+		blocks = append(blocks, slackBlock{Type: "actions", BlockID: "alert_actions", Elements: buttons})
+	}
+
+	now := time.Now()
+	blocks = append(blocks, slackBlock{
+		Type: "context",
+		Elements: []interface{}{slackText{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("Real-Time Alerting System | <!date^%d^{date_short_pretty} {time}|%s>",
+				now.Unix(), now.UTC().Format(time.RFC3339)),
+		}},
+	})
+
 	return slackMessage{
 		Channel:   n.channel,
 		Username:  n.username,
 		IconEmoji: ":rotating_light:",
+		Text:      msg.Title,
 		Attachments: []slackAttachment{
 			{
-				Color:     color,
-				Title:     msg.Title,
-				Text:      msg.Text,
-				Fields:    fields,
-				Footer:    "Real-Time Alerting System",
-				Timestamp: time.Now().Unix(),
+				Color:  color,
+				Blocks: blocks,
 			},
 		},
 	}
 }
 
+// actionButtons returns the buttons of the actions still possible on the
+// alert of a message. Buttons are only offered when Slack can send their
+// clicks back signed.
+func (n *SlackNotifier) actionButtons(msg notification.Message) []interface{} {
+	if !n.interactive || msg.AlertID == "" {
+		return nil
+	}
+
+	var buttons []interface{}
+	if msg.Status == "active" {
+		buttons = append(buttons, slackButton("Acknowledge", notification.ActionAcknowledge, msg.AlertID, "primary"))
+	}
+	if msg.Status == "active" || msg.Status == "acknowledged" {
+		buttons = append(buttons, slackButton("Resolve", notification.ActionResolve, msg.AlertID, "danger"))
+	}
+	return buttons
+}
+
+// slackMarkdownField builds a section field with a bold title.
+func slackMarkdownField(title, value string) slackText {
+	return slackText{Type: "mrkdwn", Text: "*" + title + "*\n" + value}
+}
+
+// slackButton builds a button sending its action ID and value when clicked.
+func slackButton(label, actionID, value, style string) slackElement {
+	return slackElement{
+		Type:     "button",
+		Text:     &slackText{Type: "plain_text", Text: label, Emoji: true},
+		ActionID: actionID,
+		Value:    value,
+		Style:    style,
+	}
+}
+
 // severityToColor maps severity to Slack attachment color.
 func (n *SlackNotifier) severityToColor(severity string) string {
 	switch severity {
@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// SMTPMailer sends plain-text email through an SMTP server.
+type SMTPMailer struct {
+	addr    string
+	auth    smtp.Auth
+	from    string
+	enabled bool
+}
+
+// NewSMTPMailer creates a new SMTP mailer.
+func NewSMTPMailer(cfg config.EmailConfig) *SMTPMailer {
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.SMTPHost)
+	}
+
+	return &SMTPMailer{
+		addr:    fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort),
+		auth:    auth,
+		from:    cfg.From,
+		enabled: cfg.Enabled && cfg.SMTPHost != "",
+	}
+}
+
+// SendMail sends an email to a single recipient.
+func (m *SMTPMailer) SendMail(ctx context.Context, to, subject, body string) error {
+	if !m.enabled {
+		log.Debug().Msg("Email notifications disabled, skipping")
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(body)
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Debug().Str("to", to).Str("subject", subject).Msg("Email sent")
+	return nil
+}
+
+// IsEnabled returns whether the mailer is enabled.
+func (m *SMTPMailer) IsEnabled() bool {
+	return m.enabled
+}
+
+// Compile-time interface verification.
+var _ notification.Mailer = (*SMTPMailer)(nil)
@@ -0,0 +1,223 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+)
+
+// Splunk On-Call message types. CRITICAL opens an incident, WARNING and INFO
+// only record the event on the timeline unless routed otherwise.
+const (
+	splunkOnCallCritical        = "CRITICAL"
+	splunkOnCallWarning         = "WARNING"
+	splunkOnCallInfo            = "INFO"
+	splunkOnCallAcknowledgement = "ACKNOWLEDGEMENT"
+	splunkOnCallRecovery        = "RECOVERY"
+)
+
+// splunkOnCallTool is the monitoring tool recorded on Splunk On-Call events.
+const splunkOnCallTool = "realtime-alerting-system"
+
+// SplunkOnCallNotifier mirrors alerts as Splunk On-Call (VictorOps) incidents
+// through the REST endpoint integration. Events of an alert share its ID as
+// entity ID, which is how Splunk On-Call ties them to one incident.
+type SplunkOnCallNotifier struct {
+	endpoint    string
+	minSeverity string
+	enabled     bool
+	client      *http.Client
+}
+
+// splunkOnCallEvent represents an event sent to the REST endpoint.
+type splunkOnCallEvent struct {
+	MessageType       string            `json:"message_type"`
+	EntityID          string            `json:"entity_id"`
+	EntityDisplayName string            `json:"entity_display_name,omitempty"`
+	StateMessage      string            `json:"state_message,omitempty"`
+	StateStartTime    int64             `json:"state_start_time"`
+	MonitoringTool    string            `json:"monitoring_tool"`
+	HostName          string            `json:"host_name,omitempty"`
+	Details           map[string]string `json:"details,omitempty"`
+}
+
+// splunkOnCallResult represents the response of the REST endpoint.
+type splunkOnCallResult struct {
+	Result   string `json:"result"`
+	EntityID string `json:"entity_id"`
+	Message  string `json:"message"`
+}
+
+// NewSplunkOnCallNotifier creates a new Splunk On-Call notifier.
+func NewSplunkOnCallNotifier(cfg config.SplunkOnCallConfig, timeout time.Duration) *SplunkOnCallNotifier {
+	return &SplunkOnCallNotifier{
+		endpoint: strings.TrimSuffix(cfg.APIURL, "/") + "/" +
+			url.PathEscape(cfg.APIKey) + "/" + url.PathEscape(cfg.RoutingKey),
+		minSeverity: cfg.MinSeverity,
+		enabled:     cfg.Enabled && cfg.APIKey != "" && cfg.RoutingKey != "",
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Open opens the incident of an alert of at least the minimum severity and
+// returns its entity ID.
+func (n *SplunkOnCallNotifier) Open(ctx context.Context, msg notification.Message) (string, error) {
+	if !n.enabled || !notification.ShouldNotify(msg.Severity, n.minSeverity) {
+		return "", nil
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "splunk_oncall.open", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	span.SetAttributes(attribute.String("alert.id", msg.AlertID))
+
+	details := make(map[string]string, len(msg.Labels)+len(msg.Fields)+1)
+	for key, value := range msg.Labels {
+		details[key] = value
+	}
+	for key, value := range msg.Fields {
+		details[key] = value
+	}
+	if msg.RunbookURL != "" {
+		details["runbook"] = msg.RunbookURL
+	}
+
+	result, err := n.send(ctx, splunkOnCallEvent{
+		MessageType:       splunkOnCallMessageType(msg.Severity),
+		EntityID:          msg.AlertID,
+		EntityDisplayName: msg.Title,
+		StateMessage:      msg.Text,
+		HostName:          msg.Source,
+		Details:           details,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	incidentID := result.EntityID
+	if incidentID == "" {
+		incidentID = msg.AlertID
+	}
+
+	log.Debug().
+		Str("alert_id", msg.AlertID).
+		Str("splunk_oncall_entity_id", incidentID).
+		Msg("Splunk On-Call incident opened")
+
+	return incidentID, nil
+}
+
+// Acknowledge acknowledges the incident of an entity.
+func (n *SplunkOnCallNotifier) Acknowledge(ctx context.Context, incidentID string, msg notification.Message) error {
+	ctx, span := tracing.StartSpan(ctx, "splunk_oncall.acknowledge", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	_, err := n.send(ctx, splunkOnCallEvent{
+		MessageType:  splunkOnCallAcknowledgement,
+		EntityID:     incidentID,
+		StateMessage: msg.Text,
+	})
+	return err
+}
+
+// Close resolves the incident of an entity.
+func (n *SplunkOnCallNotifier) Close(ctx context.Context, incidentID string, msg notification.Message) error {
+	ctx, span := tracing.StartSpan(ctx, "splunk_oncall.close", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	_, err := n.send(ctx, splunkOnCallEvent{
+		MessageType:  splunkOnCallRecovery,
+		EntityID:     incidentID,
+		StateMessage: msg.Text,
+	})
+	return err
+}
+
+// Name returns the notifier name.
+func (n *SplunkOnCallNotifier) Name() string {
+	return "splunk_oncall"
+}
+
+// IsEnabled returns whether the notifier is enabled.
+func (n *SplunkOnCallNotifier) IsEnabled() bool {
+	return n.enabled
+}
+
+// send posts an event to the REST endpoint.
+func (n *SplunkOnCallNotifier) send(ctx context.Context, evt splunkOnCallEvent) (*splunkOnCallResult, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("splunk_oncall.message_type", evt.MessageType))
+
+	evt.MonitoringTool = splunkOnCallTool
+	evt.StateStartTime = time.Now().Unix()
+
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal splunk on-call event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	tracing.InjectHTTP(ctx, req.Header)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// The request URL carries the API key, keep it out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		tracing.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to send splunk on-call event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+
+	var result splunkOnCallResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || result.Result != "success" {
+		span.SetStatus(codes.Error, resp.Status)
+		if result.Message != "" {
+			return nil, fmt.Errorf("splunk on-call rejected the event with status %d: %s", resp.StatusCode, result.Message)
+		}
+		return nil, fmt.Errorf("splunk on-call rejected the event with status %d", resp.StatusCode)
+	}
+
+	return &result, nil
+}
+
+// splunkOnCallMessageType maps severity to the message type opening an
+// incident: critical and high alerts page, medium and low ones warn.
+func splunkOnCallMessageType(severity string) string {
+	switch severity {
+	case notification.SeverityCritical, notification.SeverityHigh:
+		return splunkOnCallCritical
+	case notification.SeverityMedium, notification.SeverityLow:
+		return splunkOnCallWarning
+	default:
+		return splunkOnCallInfo
+	}
+}
+
+// Compile-time interface verification.
+var _ notification.IncidentForwarder = (*SplunkOnCallNotifier)(nil)
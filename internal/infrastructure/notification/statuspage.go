@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+)
+
+// StatuspageClient updates the components of a Statuspage.io page through
+// the Statuspage REST API.
+type StatuspageClient struct {
+	apiURL  string
+	apiKey  string
+	pageID  string
+	enabled bool
+	client  *http.Client
+}
+
+// statuspageComponentUpdate represents an update component request.
+type statuspageComponentUpdate struct {
+	Component struct {
+		Status string `json:"status"`
+	} `json:"component"`
+}
+
+// NewStatuspageClient creates a new Statuspage client.
+func NewStatuspageClient(cfg config.StatuspageConfig, timeout time.Duration) *StatuspageClient {
+	return &StatuspageClient{
+		apiURL:  strings.TrimSuffix(cfg.APIURL, "/"),
+		apiKey:  cfg.APIKey,
+		pageID:  cfg.PageID,
+		enabled: cfg.Enabled && cfg.APIKey != "" && cfg.PageID != "",
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// SetComponentStatus sets the status of a component of the page.
+func (c *StatuspageClient) SetComponentStatus(ctx context.Context, componentID, status string) error {
+	ctx, span := tracing.StartSpan(ctx, "statuspage.set_component_status", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("statuspage.component", componentID),
+		attribute.String("statuspage.status", status),
+	)
+
+	var update statuspageComponentUpdate
+	update.Component.Status = status
+
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal statuspage request: %w", err)
+	}
+
+	endpoint := c.apiURL + "/pages/" + url.PathEscape(c.pageID) + "/components/" + url.PathEscape(componentID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "OAuth "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	tracing.InjectHTTP(ctx, req.Header)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to call statuspage: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		span.SetStatus(codes.Error, resp.Status)
+		return fmt.Errorf("statuspage returned non-2xx status: %d", resp.StatusCode)
+	}
+
+	log.Debug().
+		Str("component", componentID).
+		Str("status", status).
+		Msg("Statuspage component updated")
+
+	return nil
+}
+
+// IsEnabled returns whether the client is enabled.
+func (c *StatuspageClient) IsEnabled() bool {
+	return c.enabled
+}
+
+// Compile-time interface verification.
+var _ notification.StatusPage = (*StatuspageClient)(nil)
@@ -0,0 +1,302 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+)
+
+// Telegram Bot API limits.
+const (
+	// telegramMaxTextLength is the maximum length, in characters, of the
+	// alert text included in a message.
+	telegramMaxTextLength = 1000
+	// telegramMaxCallbackText is the maximum length, in characters, of the
+	// notification shown when a button is clicked.
+	telegramMaxCallbackText = 200
+)
+
+// TelegramNotifier sends notifications through a Telegram bot to a set of
+// chats. When the bot's webhook secret is configured, messages of active
+// alerts carry an Acknowledge button.
+type TelegramNotifier struct {
+	apiURL      string
+	botToken    string
+	chatIDs     []string
+	enabled     bool
+	interactive bool
+	client      *http.Client
+}
+
+// telegramMessage represents a sendMessage request.
+type telegramMessage struct {
+	ChatID                string                `json:"chat_id"`
+	Text                  string                `json:"text"`
+	ParseMode             string                `json:"parse_mode"`
+	DisableWebPagePreview bool                  `json:"disable_web_page_preview"`
+	ReplyMarkup           *telegramInlineMarkup `json:"reply_markup,omitempty"`
+}
+
+// telegramInlineMarkup represents the inline keyboard of a message, as rows
+// of buttons.
+type telegramInlineMarkup struct {
+	InlineKeyboard [][]telegramButton `json:"inline_keyboard"`
+}
+
+// telegramButton represents an inline keyboard button sending its callback
+// data back when clicked.
+type telegramButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+// telegramCallbackAnswer represents an answerCallbackQuery request.
+type telegramCallbackAnswer struct {
+	CallbackQueryID string `json:"callback_query_id"`
+	Text            string `json:"text,omitempty"`
+}
+
+// telegramMarkupEdit represents an editMessageReplyMarkup request.
+type telegramMarkupEdit struct {
+	ChatID      int64                `json:"chat_id"`
+	MessageID   int64                `json:"message_id"`
+	ReplyMarkup telegramInlineMarkup `json:"reply_markup"`
+}
+
+// telegramResult represents the response of a Bot API method.
+type telegramResult struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// NewTelegramNotifier creates a new Telegram notifier.
+func NewTelegramNotifier(cfg config.TelegramConfig, timeout time.Duration) *TelegramNotifier {
+	return &TelegramNotifier{
+		apiURL:      strings.TrimSuffix(cfg.APIURL, "/"),
+		botToken:    cfg.BotToken,
+		chatIDs:     cfg.ChatIDs,
+		enabled:     cfg.Enabled && cfg.BotToken != "" && len(cfg.ChatIDs) > 0,
+		interactive: cfg.WebhookSecret != "",
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Send sends a notification to every configured chat. Chats are all tried;
+// the error reports those that failed.
+func (n *TelegramNotifier) Send(ctx context.Context, msg notification.Message) error {
+	if !n.enabled {
+		log.Debug().Msg("Telegram notifications disabled, skipping")
+		return nil
+	}
+
+	ctx, span := tracing.StartSpan(ctx, "telegram.send", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("alert.id", msg.AlertID),
+		attribute.Int("telegram.chats", len(n.chatIDs)),
+	)
+
+	text := n.buildText(msg)
+	markup := n.buttons(msg)
+
+	var errs []error
+	for _, chatID := range n.chatIDs {
+		err := n.call(ctx, "sendMessage", telegramMessage{
+			ChatID:                chatID,
+			Text:                  text,
+			ParseMode:             "HTML",
+			DisableWebPagePreview: true,
+			ReplyMarkup:           markup,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("chat %s: %w", chatID, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	log.Debug().
+		Str("alert_id", msg.AlertID).
+		Str("severity", msg.Severity).
+		Int("chats", len(n.chatIDs)).
+		Msg("Telegram notification sent")
+
+	return nil
+}
+
+// Name returns the notifier name.
+func (n *TelegramNotifier) Name() string {
+	return "telegram"
+}
+
+// IsEnabled returns whether the notifier is enabled.
+func (n *TelegramNotifier) IsEnabled() bool {
+	return n.enabled
+}
+
+// AnswerCallback shows a notification to the user who clicked a button.
+func (n *TelegramNotifier) AnswerCallback(ctx context.Context, callbackID, text string) error {
+	ctx, span := tracing.StartSpan(ctx, "telegram.answer_callback", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	return n.call(ctx, "answerCallbackQuery", telegramCallbackAnswer{
+		CallbackQueryID: callbackID,
+		Text:            truncate(text, telegramMaxCallbackText),
+	})
+}
+
+// RemoveButtons removes the inline keyboard of a message.
+func (n *TelegramNotifier) RemoveButtons(ctx context.Context, chatID, messageID int64) error {
+	ctx, span := tracing.StartSpan(ctx, "telegram.remove_buttons", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	return n.call(ctx, "editMessageReplyMarkup", telegramMarkupEdit{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		ReplyMarkup: telegramInlineMarkup{InlineKeyboard: [][]telegramButton{}},
+	})
+}
+
+// call invokes a Bot API method with a JSON payload.
+func (n *TelegramNotifier) call(ctx context.Context, method string, body interface{}) error {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("telegram.method", method))
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal telegram request: %w", err)
+	}
+
+	endpoint := n.apiURL + "/bot" + n.botToken + "/" + method
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	tracing.InjectHTTP(ctx, req.Header)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// The request URL carries the bot token, keep it out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		tracing.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to call telegram %s: %w", method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+
+	var result telegramResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || !result.OK {
+		span.SetStatus(codes.Error, resp.Status)
+		if result.Description != "" {
+			return fmt.Errorf("telegram %s failed with status %d: %s", method, resp.StatusCode, result.Description)
+		}
+		return fmt.Errorf("telegram %s failed with status %d", method, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// buildText builds the HTML text of a message: the title and text, the
+// fields, the runbook and the remediation.
+func (n *TelegramNotifier) buildText(msg notification.Message) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s <b>%s</b>", telegramSeverityEmoji(msg.Severity), html.EscapeString(msg.Title))
+	if msg.Text != "" {
+		b.WriteString("\n" + html.EscapeString(truncate(msg.Text, telegramMaxTextLength)))
+	}
+	b.WriteString("\n")
+
+	telegramField(&b, "Severity", html.EscapeString(msg.Severity))
+	if msg.Source != "" {
+		telegramField(&b, "Source", html.EscapeString(msg.Source))
+	}
+	if msg.AlertID != "" {
+		telegramField(&b, "Alert ID", "<code>"+html.EscapeString(msg.AlertID)+"</code>")
+	}
+
+	keys := make([]string, 0, len(msg.Fields))
+	for key := range msg.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		telegramField(&b, html.EscapeString(key), html.EscapeString(msg.Fields[key]))
+	}
+
+	if msg.RunbookURL != "" {
+		telegramField(&b, "Runbook", `<a href="`+html.EscapeString(msg.RunbookURL)+`">Open runbook</a>`)
+	}
+
+	if msg.Remediation != "" {
+		b.WriteString("\n<b>Remediation</b>\n" + html.EscapeString(msg.Remediation))
+	}
+
+	return b.String()
+}
+
+// buttons returns the inline keyboard of a message: an Acknowledge button
+// while the alert is active. Buttons are only offered when Telegram can send
+// their clicks back to the webhook.
+func (n *TelegramNotifier) buttons(msg notification.Message) *telegramInlineMarkup {
+	if !n.interactive || msg.AlertID == "" || msg.Status != "active" {
+		return nil
+	}
+
+	return &telegramInlineMarkup{
+		InlineKeyboard: [][]telegramButton{{
+			{Text: "Acknowledge", CallbackData: notification.ActionAcknowledge + ":" + msg.AlertID},
+		}},
+	}
+}
+
+// telegramField writes a line with a bold title.
+func telegramField(b *strings.Builder, title, value string) {
+	b.WriteString("\n<b>" + title + ":</b> " + value)
+}
+
+// telegramSeverityEmoji maps severity to emoji.
+func telegramSeverityEmoji(severity string) string {
+	switch severity {
+	case notification.SeverityCritical:
+		return "🔴"
+	case notification.SeverityHigh:
+		return "🟠"
+	case notification.SeverityMedium:
+		return "🟡"
+	case notification.SeverityLow:
+		return "🔵"
+	default:
+		return "⚪"
+	}
+}
+
+// Compile-time interface verification.
+var _ notification.Notifier = (*TelegramNotifier)(nil)
@@ -0,0 +1,158 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/tracing"
+)
+
+// twilioGatherTimeout is how long, in seconds, a call waits for a key once
+// the prompt has been read.
+const twilioGatherTimeout = 10
+
+// TwilioCaller places voice calls through the Twilio Programmable Voice API.
+// Calls read their message with text-to-speech and collect a single key,
+// which Twilio posts to the callback URL of the call.
+type TwilioCaller struct {
+	apiURL     string
+	accountSID string
+	authToken  string
+	from       string
+	enabled    bool
+	client     *http.Client
+}
+
+// twiml represents the TwiML instructions of a call, as verbs run in order.
+type twiml struct {
+	XMLName xml.Name `xml:"Response"`
+	Verbs   []interface{}
+}
+
+// twimlSay reads a text with text-to-speech.
+type twimlSay struct {
+	XMLName xml.Name `xml:"Say"`
+	Text    string   `xml:",chardata"`
+}
+
+// twimlGather collects a key while reading a prompt.
+type twimlGather struct {
+	XMLName   xml.Name `xml:"Gather"`
+	NumDigits int      `xml:"numDigits,attr"`
+	Timeout   int      `xml:"timeout,attr"`
+	Action    string   `xml:"action,attr"`
+	Method    string   `xml:"method,attr"`
+	Say       twimlSay
+}
+
+// twilioCall represents a created call.
+type twilioCall struct {
+	SID string `json:"sid"`
+}
+
+// twilioError represents an error returned by the Twilio API.
+type twilioError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewTwilioCaller creates a new Twilio caller.
+func NewTwilioCaller(cfg config.TwilioConfig, timeout time.Duration) *TwilioCaller {
+	return &TwilioCaller{
+		apiURL:     strings.TrimSuffix(cfg.APIURL, "/"),
+		accountSID: cfg.AccountSID,
+		authToken:  cfg.AuthToken,
+		from:       cfg.FromNumber,
+		enabled:    cfg.Enabled && cfg.AccountSID != "" && cfg.AuthToken != "" && cfg.FromNumber != "",
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Call places a call and returns its SID.
+func (c *TwilioCaller) Call(ctx context.Context, call notification.VoiceCall) (string, error) {
+	ctx, span := tracing.StartSpan(ctx, "twilio.call", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	// The call ends with the last verb when no key is pressed
+	instructions, err := xml.Marshal(twiml{Verbs: []interface{}{
+		twimlSay{Text: call.Message},
+		twimlGather{
+			NumDigits: 1,
+			Timeout:   twilioGatherTimeout,
+			Action:    call.CallbackURL,
+			Method:    http.MethodPost,
+			Say:       twimlSay{Text: call.Prompt},
+		},
+		twimlSay{Text: "No key was pressed. Goodbye."},
+	}})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal twiml: %w", err)
+	}
+
+	form := url.Values{
+		"To":    {call.To},
+		"From":  {c.from},
+		"Twiml": {string(instructions)},
+	}
+
+	endpoint := c.apiURL + "/2010-04-01/Accounts/" + url.PathEscape(c.accountSID) + "/Calls.json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(c.accountSID, c.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	tracing.InjectHTTP(ctx, req.Header)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		tracing.RecordError(ctx, err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("failed to call twilio: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		span.SetStatus(codes.Error, resp.Status)
+		var apiErr twilioError
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Message != "" {
+			return "", fmt.Errorf("twilio rejected the call with status %d: %s (%d)",
+				resp.StatusCode, apiErr.Message, apiErr.Code)
+		}
+		return "", fmt.Errorf("twilio returned non-2xx status: %d", resp.StatusCode)
+	}
+
+	var created twilioCall
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("failed to decode twilio response: %w", err)
+	}
+	span.SetAttributes(attribute.String("twilio.call_sid", created.SID))
+
+	log.Debug().Str("call_sid", created.SID).Msg("Twilio call placed")
+
+	return created.SID, nil
+}
+
+// IsEnabled returns whether the caller is enabled.
+func (c *TwilioCaller) IsEnabled() bool {
+	return c.enabled
+}
+
+// Compile-time interface verification.
+var _ notification.VoiceCaller = (*TwilioCaller)(nil)
@@ -0,0 +1,171 @@
+// Package prober provides the probes of synthetic uptime checks.
+package prober
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// userAgent identifies the probes to the checked services.
+const userAgent = "realtime-alerting-uptime/1.0"
+
+// maxBodyRead bounds how much of a response body is read, so that the
+// connection can be reused without downloading large pages.
+const maxBodyRead = 64 << 10
+
+// maxErrorLength bounds the error recorded for a failed probe.
+const maxErrorLength = 500
+
+// ErrAddressNotAllowed is returned when a check resolves to an address of a
+// private or reserved network, such as loopback or the cloud metadata service.
+var ErrAddressNotAllowed = errors.New("address is not allowed")
+
+// reservedPrefixes are the special-purpose networks not covered by the
+// netip.Addr predicates.
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// HTTPProber probes checks with HTTP requests. Redirects are not followed,
+// so that a check may expect a redirect status.
+//
+// Checks are created by users, so the prober only connects to public
+// addresses: the address is checked when connecting, after name resolution,
+// so that names resolving to internal addresses are refused too.
+type HTTPProber struct {
+	client *http.Client
+}
+
+// NewHTTPProber creates a new HTTP prober. Timeouts are those of the checks.
+func NewHTTPProber() *HTTPProber {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !IsPublicAddr(addrPort.Addr()) {
+				return ErrAddressNotAllowed
+			}
+			return nil
+		},
+	}
+
+	return &HTTPProber{
+		client: &http.Client{
+			Transport: &http.Transport{
+				// No proxy: the address dialed must be the one checked
+				Proxy:               nil,
+				DialContext:         dialer.DialContext,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// IsPublicAddr reports whether an address may be probed: it must not belong
+// to a loopback, private, link-local, multicast or otherwise reserved network.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// Probe requests the URL of a check and reports whether the response had
+// the expected status within the check timeout. The latency covers the
+// whole exchange, up to the part of the body that is read.
+func (p *HTTPProber) Probe(ctx context.Context, check *entity.Check) entity.CheckResult {
+	startedAt := time.Now()
+	result := entity.CheckResult{
+		ID:        entity.NewID(),
+		CheckID:   check.ID,
+		CheckedAt: startedAt.UTC(),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout())
+	defer cancel()
+
+	statusCode, err := p.do(ctx, check)
+	result.LatencyMs = time.Since(startedAt).Milliseconds()
+	result.StatusCode = statusCode
+
+	switch {
+	case err != nil:
+		result.Error = describe(err)
+	case !check.Succeeds(statusCode):
+		result.Error = fmt.Sprintf("unexpected status %d, expected %d", statusCode, check.ExpectedStatus)
+	default:
+		result.Success = true
+	}
+
+	return result
+}
+
+// do sends the request of a check and returns the response status.
+func (p *HTTPProber) do(ctx context.Context, check *entity.Check) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, check.Method, check.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if _, err := io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyRead)); err != nil {
+		return resp.StatusCode, err
+	}
+
+	return resp.StatusCode, nil
+}
+
+// describe turns a request error into the error recorded for the probe.
+func describe(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timed out"
+	}
+	if errors.Is(err, ErrAddressNotAllowed) {
+		return ErrAddressNotAllowed.Error()
+	}
+
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	return message
+}
@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Inject writes the trace context of ctx into a carrier, e.g. the headers
+// of an outgoing request, with the propagator set up by InitTracer.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// Extract returns ctx with the trace context read from a carrier, so that
+// spans started from it continue the remote trace.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// InjectHTTP writes the trace context of ctx into the headers of an
+// outgoing HTTP request as W3C traceparent and tracestate headers.
+func InjectHTTP(ctx context.Context, header http.Header) {
+	Inject(ctx, propagation.HeaderCarrier(header))
+}
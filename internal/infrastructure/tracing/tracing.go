@@ -3,26 +3,51 @@ package tracing
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
+// Trace exporters.
+const (
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+)
+
+// ErrUnknownExporter is returned for an exporter other than otlp-grpc and otlp-http.
+var ErrUnknownExporter = errors.New("unknown trace exporter")
+
 // Config holds tracing configuration.
 type Config struct {
 	ServiceName    string
 	ServiceVersion string
 	Environment    string
-	JaegerEndpoint string
+	Region         string
+	Cluster        string
 	Enabled        bool
+	// Exporter is otlp-grpc (the default) or otlp-http.
+	Exporter string
+	// Endpoint is the collector address, host:port or a URL. The
+	// OTEL_EXPORTER_OTLP_* environment variables apply when it is empty.
+	Endpoint string
+	// Insecure disables TLS towards the collector.
+	Insecure bool
+	// Headers are sent with every export, e.g. an API key.
+	Headers map[string]string
+	// SampleRatio is the fraction of new traces sampled. Traces started
+	// upstream follow the decision of their parent.
+	SampleRatio float64
 }
 
 // Tracer is the global tracer instance.
@@ -43,15 +68,7 @@ func InitTracer(cfg Config) (func(context.Context) error, error) {
 	ctx := context.Background()
 
 	// Create OTLP exporter
-	conn, err := grpc.NewClient(cfg.JaegerEndpoint,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
-	if err != nil {
-		Tracer = noop.NewTracerProvider().Tracer(cfg.ServiceName)
-		return func(context.Context) error { return nil }, err
-	}
-
-	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithGRPCConn(conn))
+	exporter, err := newExporter(ctx, cfg)
 	if err != nil {
 		Tracer = noop.NewTracerProvider().Tracer(cfg.ServiceName)
 		return func(context.Context) error { return nil }, err
@@ -63,13 +80,15 @@ func InitTracer(cfg Config) (func(context.Context) error, error) {
 		attribute.String("service.name", cfg.ServiceName),
 		attribute.String("service.version", cfg.ServiceVersion),
 		attribute.String("deployment.environment", cfg.Environment),
+		attribute.String("cloud.region", cfg.Region),
+		attribute.String("k8s.cluster.name", cfg.Cluster),
 	)
 
 	// Create trace provider
 	tp := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
 	)
 
 	// Set global trace provider
@@ -87,6 +106,46 @@ func InitTracer(cfg Config) (func(context.Context) error, error) {
 	return tp.Shutdown, nil
 }
 
+// newExporter creates the OTLP exporter selected by the configuration.
+func newExporter(ctx context.Context, cfg Config) (*otlptrace.Exporter, error) {
+	isURL := strings.Contains(cfg.Endpoint, "://")
+
+	switch cfg.Exporter {
+	case "", ExporterOTLPGRPC:
+		opts := []otlptracegrpc.Option{}
+		switch {
+		case isURL:
+			opts = append(opts, otlptracegrpc.WithEndpointURL(cfg.Endpoint))
+		case cfg.Endpoint != "":
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{}
+		switch {
+		case isURL:
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		case cfg.Endpoint != "":
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExporter, cfg.Exporter)
+	}
+}
+
 // StartSpan starts a new span with the given name.
 func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
 	return Tracer.Start(ctx, name, opts...)
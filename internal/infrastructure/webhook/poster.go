@@ -0,0 +1,53 @@
+// Package webhook provides the delivery of outbound webhook subscriptions.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+)
+
+// maxBodyRead bounds how much of a response body is read, so that the
+// connection can be reused without downloading large responses.
+const maxBodyRead = 64 << 10
+
+// HTTPPoster POSTs webhook deliveries. Redirects are not followed, so that
+// a moved endpoint is reported rather than sent the payload elsewhere.
+type HTTPPoster struct {
+	client *http.Client
+}
+
+// NewHTTPPoster creates a new HTTP poster. Timeouts are those of the
+// request contexts.
+func NewHTTPPoster() *HTTPPoster {
+	return &HTTPPoster{
+		client: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Post sends body to url with the given headers and returns the response
+// status.
+func (p *HTTPPoster) Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyRead))
+
+	return resp.StatusCode, nil
+}
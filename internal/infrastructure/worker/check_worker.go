@@ -0,0 +1,158 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+const (
+	// checkPurgeInterval is how often the probe history is purged.
+	checkPurgeInterval = time.Hour
+	// checkClaimBatch is the most checks claimed on a tick.
+	checkClaimBatch = 100
+)
+
+// CheckRunner claims the uptime checks that are due and runs them.
+type CheckRunner interface {
+	ClaimDue(ctx context.Context, limit int) ([]*entity.Check, error)
+	Run(ctx context.Context, check *entity.Check) error
+	PurgeHistory(ctx context.Context) (int64, error)
+}
+
+// CheckWorker schedules uptime checks and probes them with a pool of
+// workers.
+type CheckWorker struct {
+	runner   CheckRunner
+	interval time.Duration
+	workers  int
+	jobs     chan *entity.Check
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewCheckWorker creates a new check worker scheduling due checks every
+// interval for the given number of concurrent probes. The runner is set
+// with SetRunner before the worker is started.
+func NewCheckWorker(interval time.Duration, workers int) *CheckWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	if workers <= 0 {
+		workers = 4
+	}
+
+	return &CheckWorker{
+		interval: interval,
+		workers:  workers,
+		jobs:     make(chan *entity.Check),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// SetRunner sets the service running checks. Without one the worker does
+// nothing.
+func (w *CheckWorker) SetRunner(runner CheckRunner) {
+	w.runner = runner
+}
+
+// Start starts the scheduler and the probe workers.
+func (w *CheckWorker) Start() error {
+	log.Info().
+		Dur("interval", w.interval).
+		Int("workers", w.workers).
+		Msg("Starting check worker...")
+
+	if w.runner == nil {
+		log.Warn().Msg("Check worker has no runner, uptime checks are not probed")
+		return nil
+	}
+
+	for i := 0; i < w.workers; i++ {
+		w.wg.Add(1)
+		go w.probe()
+	}
+	w.wg.Add(1)
+	go w.schedule()
+
+	log.Info().Msg("Check worker started successfully")
+	return nil
+}
+
+// Stop stops the check worker, cancelling the probes in flight.
+func (w *CheckWorker) Stop() error {
+	log.Info().Msg("Stopping check worker...")
+	w.cancel()
+	w.wg.Wait()
+	log.Info().Msg("Check worker stopped")
+	return nil
+}
+
+// schedule hands the due checks to the probe workers on every tick, and
+// purges the probe history periodically, until stopped.
+func (w *CheckWorker) schedule() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	purge := time.NewTicker(checkPurgeInterval)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.dispatch()
+		case <-purge.C:
+			removed, err := w.runner.PurgeHistory(w.ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to purge check history")
+			} else if removed > 0 {
+				log.Info().Int64("removed", removed).Msg("Purged check history")
+			}
+		}
+	}
+}
+
+// dispatch claims the due checks and hands each to a probe worker, waiting
+// while they are all busy. Checks due beyond the batch are claimed on a
+// later tick, by this instance or another.
+func (w *CheckWorker) dispatch() {
+	checks, err := w.runner.ClaimDue(w.ctx, checkClaimBatch)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to claim due checks")
+	}
+
+	for _, check := range checks {
+		select {
+		case <-w.ctx.Done():
+			return
+		case w.jobs <- check:
+		}
+	}
+}
+
+// probe runs the checks handed over by the scheduler until stopped.
+func (w *CheckWorker) probe() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case check := <-w.jobs:
+			if err := w.runner.Run(w.ctx, check); err != nil {
+				log.Error().Err(err).Str("check_id", check.ID.String()).Msg("Failed to run check")
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+)
+
+// DigestWorker periodically emails users the events they missed while offline.
+type DigestWorker struct {
+	digestService *service.DigestService
+	interval      time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+	done          chan struct{}
+}
+
+// NewDigestWorker creates a new digest worker.
+func NewDigestWorker(digestService *service.DigestService, interval time.Duration) *DigestWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	return &DigestWorker{
+		digestService: digestService,
+		interval:      interval,
+		ctx:           ctx,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+}
+
+// Start starts the digest worker.
+func (w *DigestWorker) Start() error {
+	log.Info().Dur("interval", w.interval).Msg("Starting digest worker...")
+
+	go w.run()
+
+	log.Info().Msg("Digest worker started successfully")
+	return nil
+}
+
+// Stop stops the digest worker.
+func (w *DigestWorker) Stop() error {
+	log.Info().Msg("Stopping digest worker...")
+	w.cancel()
+	<-w.done
+	log.Info().Msg("Digest worker stopped")
+	return nil
+}
+
+// run sends email digests on every tick until stopped.
+func (w *DigestWorker) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			sent, err := w.digestService.SendEmailDigests(w.ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to send email digests")
+			}
+			if sent > 0 {
+				log.Info().Int("sent", sent).Msg("Email digests sent")
+			}
+		}
+	}
+}
@@ -3,61 +3,74 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
 	appevent "github.com/daniel-caso-github/realtime-alerting-system/internal/application/event"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/event/handlers"
-	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
 )
 
 // EventWorker manages event consumers and handlers.
 type EventWorker struct {
-	bus                 event.Bus
-	alertConsumer       *appevent.AlertConsumer
-	metricsHandler      *handlers.MetricsHandler
-	notificationService *service.NotificationService
-	ctx                 context.Context
-	cancel              context.CancelFunc
+	bus            event.Bus
+	alertConsumer  *appevent.AlertConsumer
+	metricsHandler *handlers.MetricsHandler
+	cacheRepo      repository.CacheRepository
+	idempotencyTTL time.Duration
+	ctx            context.Context
+	cancel         context.CancelFunc
 }
 
-// NewEventWorker creates a new event worker.
-func NewEventWorker(bus event.Bus, notificationService *service.NotificationService) *EventWorker {
+// NewEventWorker creates a new event worker. Its alert consumer starts with
+// the logging and metrics handlers; the others are added with
+// RegisterHandler.
+func NewEventWorker(bus event.Bus) *EventWorker {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	metricsHandler := handlers.NewMetricsHandler()
+	alertConsumer := appevent.NewAlertConsumer()
+	alertConsumer.RegisterHandler("logging", handlers.NewLoggingHandler())
+	alertConsumer.RegisterHandler("metrics", metricsHandler)
+
 	return &EventWorker{
-		bus:                 bus,
-		notificationService: notificationService,
-		ctx:                 ctx,
-		cancel:              cancel,
+		bus:            bus,
+		alertConsumer:  alertConsumer,
+		metricsHandler: metricsHandler,
+		ctx:            ctx,
+		cancel:         cancel,
 	}
 }
 
+// RegisterHandler adds a handler of alert events, run after those already
+// registered. The name labels the handler in logs and metrics. It must be
+// called before Start.
+func (w *EventWorker) RegisterHandler(name string, handler appevent.AlertEventHandler) {
+	w.alertConsumer.RegisterHandler(name, handler)
+}
+
+// SetIdempotency makes the alert consumer's handlers skip events they already
+// processed within ttl. It must be called before Start.
+func (w *EventWorker) SetIdempotency(cacheRepo repository.CacheRepository, ttl time.Duration) {
+	w.cacheRepo = cacheRepo
+	w.idempotencyTTL = ttl
+}
+
 // Start starts the event worker and all consumers.
 func (w *EventWorker) Start() error {
 	log.Info().Msg("Starting event worker...")
 
-	// Create consumers
-	w.alertConsumer = appevent.NewAlertConsumer()
-
-	// Create and register handlers
-	loggingHandler := handlers.NewLoggingHandler()
-	w.metricsHandler = handlers.NewMetricsHandler()
+	w.alertConsumer.SetIdempotency(w.cacheRepo, w.idempotencyTTL)
+	log.Info().Strs("handlers", w.alertConsumer.Handlers()).Msg("Alert event handlers registered")
 
-	w.alertConsumer.RegisterHandler(loggingHandler)
-	w.alertConsumer.RegisterHandler(w.metricsHandler)
-
-	// Add notification handler if service is available
-	if w.notificationService != nil {
-		notificationHandler := handlers.NewNotificationHandler(w.notificationService)
-		w.alertConsumer.RegisterHandler(notificationHandler)
-		log.Info().Msg("Notification handler registered")
-	}
-
-	// Subscribe to streams
-	if err := w.bus.Subscribe(w.ctx, event.StreamAlerts, event.GroupAlertProcessors, w.alertConsumer.Handle); err != nil {
-		return err
+	// Subscribe to streams. The priority stream has its own consumer, so its
+	// events are not queued behind the backlog of the alerts stream.
+	for _, stream := range []string{event.StreamAlertsPriority, event.StreamAlerts} {
+		if err := w.bus.Subscribe(w.ctx, stream, event.GroupAlertProcessors, w.alertConsumer.Handle); err != nil {
+			return err
+		}
 	}
 
 	log.Info().Msg("Event worker started successfully")
@@ -78,10 +91,29 @@ func (w *EventWorker) Stop() error {
 	return nil
 }
 
+// Shutdown stops the event worker gracefully: the bus stops taking new events
+// and the events being handled finish, until ctx is done. Handlers still
+// running then are cancelled.
+func (w *EventWorker) Shutdown(ctx context.Context) error {
+	drainer, ok := w.bus.(event.Drainer)
+	if !ok {
+		return w.Stop()
+	}
+
+	log.Info().Msg("Draining event worker...")
+	err := drainer.Drain(ctx)
+	w.cancel()
+
+	if err != nil {
+		log.Warn().Err(err).Msg("Event worker stopped before its handlers finished")
+		return err
+	}
+
+	log.Info().Msg("Event worker stopped")
+	return nil
+}
+
 // GetMetrics returns the current event metrics.
 func (w *EventWorker) GetMetrics() map[string]int64 {
-	if w.metricsHandler == nil {
-		return make(map[string]int64)
-	}
 	return w.metricsHandler.GetMetrics()
 }
@@ -0,0 +1,143 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// ErrForwardFailed is returned for events that could not be published to the
+// remote deployment, which the local stream then retries.
+var ErrForwardFailed = errors.New("federation: failed to forward event")
+
+// FederationBridge forwards selected local alert events to a remote deployment.
+//
+// Only events that originated in this deployment are forwarded, so events
+// received from other regions are never sent back (loop prevention).
+type FederationBridge struct {
+	source      event.Bus
+	target      event.Publisher
+	region      string
+	cluster     string
+	eventTypes  map[event.Type]struct{}
+	minSeverity entity.AlertSeverity
+	ctx         context.Context
+	cancel      context.CancelFunc
+}
+
+// NewFederationBridge creates a new federation bridge.
+// Region and cluster identify the local deployment.
+func NewFederationBridge(source event.Bus, target event.Publisher, cfg config.FederationConfig, region, cluster string) *FederationBridge {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	eventTypes := make(map[event.Type]struct{}, len(cfg.EventTypes))
+	for _, t := range cfg.EventTypes {
+		eventTypes[event.Type(t)] = struct{}{}
+	}
+
+	return &FederationBridge{
+		source:      source,
+		target:      target,
+		region:      region,
+		cluster:     cluster,
+		eventTypes:  eventTypes,
+		minSeverity: entity.AlertSeverity(cfg.MinSeverity),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start starts forwarding events.
+func (f *FederationBridge) Start() error {
+	log.Info().Msg("Starting federation bridge...")
+
+	for _, stream := range []string{event.StreamAlertsPriority, event.StreamAlerts} {
+		if err := f.source.Subscribe(f.ctx, stream, event.GroupFederationForwarders, f.forward); err != nil {
+			return err
+		}
+	}
+
+	log.Info().
+		Str("region", f.region).
+		Str("cluster", f.cluster).
+		Int("event_types", len(f.eventTypes)).
+		Str("min_severity", string(f.minSeverity)).
+		Msg("Federation bridge started successfully")
+	return nil
+}
+
+// Stop stops the federation bridge.
+func (f *FederationBridge) Stop() error {
+	log.Info().Msg("Stopping federation bridge...")
+	f.cancel()
+
+	if err := f.source.Unsubscribe(); err != nil {
+		log.Error().Err(err).Msg("Error unsubscribing federation bridge")
+		return err
+	}
+
+	log.Info().Msg("Federation bridge stopped")
+	return nil
+}
+
+// forward publishes an event to the remote deployment if it passes the filters.
+// Publish failures are returned so that the retry, backoff and dead letter
+// policy of the local stream applies to them.
+func (f *FederationBridge) forward(ctx context.Context, evt *event.Event) error {
+	if !f.shouldForward(evt) {
+		return nil
+	}
+
+	// The remote deployment receives the event as first published
+	forwarded := *evt
+	forwarded.Retries = 0
+	forwarded.LastError = ""
+	forwarded.FailedGroup = ""
+
+	if err := f.target.Publish(ctx, &forwarded); err != nil {
+		metrics.EventsFederatedTotal.WithLabelValues(string(evt.Type), "failed").Inc()
+		log.Error().Err(err).Str("event_id", evt.ID).Str("event_type", string(evt.Type)).Msg("Failed to forward event")
+		return fmt.Errorf("%w: %v", ErrForwardFailed, err)
+	}
+
+	metrics.EventsFederatedTotal.WithLabelValues(string(evt.Type), "forwarded").Inc()
+	log.Debug().Str("event_id", evt.ID).Str("event_type", string(evt.Type)).Msg("Event forwarded")
+	return nil
+}
+
+// shouldForward reports whether an event is eligible for federation.
+func (f *FederationBridge) shouldForward(evt *event.Event) bool {
+	// Events received from another deployment are never forwarded again.
+	if evt.Region != f.region || evt.Cluster != f.cluster {
+		return false
+	}
+
+	// Retries are re-published copies of an event, forwarded again only when
+	// the bridge itself failed to forward it.
+	if evt.Retries > 0 && evt.FailedGroup != event.GroupFederationForwarders {
+		return false
+	}
+
+	if _, ok := f.eventTypes[evt.Type]; !ok {
+		return false
+	}
+
+	if f.minSeverity == "" {
+		return true
+	}
+
+	// Payloads without a severity (e.g. deletions) are not filtered.
+	var payload event.AlertPayload
+	if err := evt.UnmarshalPayload(&payload); err != nil || payload.Severity == "" {
+		return true
+	}
+
+	return entity.AlertSeverity(payload.Severity).Priority() <= f.minSeverity.Priority()
+}
@@ -0,0 +1,170 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Scheduled job names.
+const (
+	JobAlertExpiration   = "alert-expiration"
+	JobStatisticsRebuild = "statistics-rebuild"
+	JobTrashPurge        = "trash-purge"
+	JobHeartbeatCheck    = "heartbeat-check"
+	JobSourceCheck       = "source-check"
+	JobSLACheck          = "sla-check"
+	JobStatusPageSync    = "statuspage-sync"
+	JobVoiceEscalation   = "voice-escalation"
+)
+
+// trashPurgeBatchSize bounds the alerts removed per statement, so that
+// purging a large trash does not hold long locks.
+const trashPurgeBatchSize = 1000
+
+// AlertExpirer marks expired the alerts past their expiration time.
+type AlertExpirer interface {
+	ExpireOverdue(ctx context.Context) (int, error)
+}
+
+// StatisticsRebuilder recounts the incremental alert counters.
+type StatisticsRebuilder interface {
+	RebuildStatistics(ctx context.Context) error
+}
+
+// TrashPurger permanently removes alerts from the trash.
+type TrashPurger interface {
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// HeartbeatChecker marks down the heartbeats that missed their ping.
+type HeartbeatChecker interface {
+	CheckOverdue(ctx context.Context) (int, error)
+}
+
+// SourceChecker raises an alert for each registered source that went silent.
+type SourceChecker interface {
+	CheckSilent(ctx context.Context) (int, error)
+}
+
+// SLAChecker raises an alert for each alert that missed an SLA target.
+type SLAChecker interface {
+	CheckBreaches(ctx context.Context) (int, error)
+}
+
+// StatusPageSyncer publishes the status of every status page component.
+type StatusPageSyncer interface {
+	SyncAll(ctx context.Context) error
+}
+
+// VoiceEscalator calls about the critical alerts left unacknowledged.
+type VoiceEscalator interface {
+	EscalateOverdue(ctx context.Context) (int, error)
+}
+
+// AlertExpirationJob returns the job expiring the alerts past their
+// expiration time.
+func AlertExpirationJob(expirer AlertExpirer) Job {
+	return func(ctx context.Context) error {
+		expired, err := expirer.ExpireOverdue(ctx)
+		if expired > 0 {
+			log.Info().Int("expired", expired).Msg("Alerts expired")
+		}
+		return err
+	}
+}
+
+// StatisticsRebuildJob returns the job rebuilding the alert counters behind
+// the statistics, so that any drift from the alerts table does not persist.
+func StatisticsRebuildJob(rebuilder StatisticsRebuilder) Job {
+	return func(ctx context.Context) error {
+		start := time.Now()
+		if err := rebuilder.RebuildStatistics(ctx); err != nil {
+			return err
+		}
+		log.Info().Dur("duration", time.Since(start)).Msg("Alert statistics rebuilt")
+		return nil
+	}
+}
+
+// TrashPurgeJob returns the job purging the alerts kept in the trash for
+// longer than the retention period, batch by batch.
+func TrashPurgeJob(purger TrashPurger, retention time.Duration) Job {
+	if retention <= 0 {
+		retention = 30 * 24 * time.Hour
+	}
+
+	return func(ctx context.Context) error {
+		before := time.Now().UTC().Add(-retention)
+
+		var total int64
+		defer func() {
+			if total > 0 {
+				log.Info().Int64("purged", total).Msg("Deleted alerts purged")
+			}
+		}()
+
+		for {
+			purged, err := purger.PurgeDeleted(ctx, before, trashPurgeBatchSize)
+			total += purged
+			if err != nil || purged < trashPurgeBatchSize {
+				return err
+			}
+		}
+	}
+}
+
+// HeartbeatCheckJob returns the job checking heartbeats for missed pings.
+func HeartbeatCheckJob(checker HeartbeatChecker) Job {
+	return func(ctx context.Context) error {
+		down, err := checker.CheckOverdue(ctx)
+		if down > 0 {
+			log.Info().Int("down", down).Msg("Heartbeats missed their ping")
+		}
+		return err
+	}
+}
+
+// SourceCheckJob returns the job checking registered sources for silence.
+func SourceCheckJob(checker SourceChecker) Job {
+	return func(ctx context.Context) error {
+		silent, err := checker.CheckSilent(ctx)
+		if silent > 0 {
+			log.Info().Int("silent", silent).Msg("Sources went silent")
+		}
+		return err
+	}
+}
+
+// SLACheckJob returns the job checking alerts for missed SLA targets.
+func SLACheckJob(checker SLAChecker) Job {
+	return func(ctx context.Context) error {
+		breached, err := checker.CheckBreaches(ctx)
+		if breached > 0 {
+			log.Info().Int("breached", breached).Msg("Alerts breached their SLA")
+		}
+		return err
+	}
+}
+
+// StatusPageSyncJob returns the job publishing the status of every status
+// page component, so that updates lost on the way or made on the page by
+// hand do not persist.
+func StatusPageSyncJob(syncer StatusPageSyncer) Job {
+	return func(ctx context.Context) error {
+		return syncer.SyncAll(ctx)
+	}
+}
+
+// VoiceEscalationJob returns the job calling about the critical alerts left
+// unacknowledged.
+func VoiceEscalationJob(escalator VoiceEscalator) Job {
+	return func(ctx context.Context) error {
+		placed, err := escalator.EscalateOverdue(ctx)
+		if placed > 0 {
+			log.Info().Int("calls", placed).Msg("Escalation calls placed")
+		}
+		return err
+	}
+}
@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// LagWorker periodically exports the backlog of the event bus consumer
+// groups as gauges, so that a group falling behind can be alerted on.
+type LagWorker struct {
+	inspector event.LagInspector
+	interval  time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewLagWorker creates a new consumer lag worker.
+func NewLagWorker(inspector event.LagInspector, interval time.Duration) *LagWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+
+	return &LagWorker{
+		inspector: inspector,
+		interval:  interval,
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+}
+
+// Start starts the consumer lag worker.
+func (w *LagWorker) Start() error {
+	log.Info().Dur("interval", w.interval).Msg("Starting consumer lag worker...")
+
+	go w.run()
+
+	log.Info().Msg("Consumer lag worker started successfully")
+	return nil
+}
+
+// Stop stops the consumer lag worker.
+func (w *LagWorker) Stop() error {
+	log.Info().Msg("Stopping consumer lag worker...")
+	w.cancel()
+	<-w.done
+	log.Info().Msg("Consumer lag worker stopped")
+	return nil
+}
+
+// run collects the consumer lag right away and then on every tick until stopped.
+func (w *LagWorker) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.collect()
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// collect replaces the lag gauges with the current backlog, so that deleted
+// streams and groups stop being exported.
+func (w *LagWorker) collect() {
+	lags, err := w.inspector.Lag(w.ctx)
+	if err != nil {
+		if w.ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to collect consumer lag")
+		}
+		return
+	}
+
+	metrics.EventStreamLength.Reset()
+	metrics.EventConsumerLag.Reset()
+	metrics.EventConsumerPending.Reset()
+	metrics.EventConsumerOldestPendingAge.Reset()
+
+	now := time.Now()
+	for _, lag := range lags {
+		metrics.EventStreamLength.WithLabelValues(lag.Stream).Set(float64(lag.Length))
+		if lag.Lag >= 0 {
+			metrics.EventConsumerLag.WithLabelValues(lag.Stream, lag.Group).Set(float64(lag.Lag))
+		}
+		metrics.EventConsumerPending.WithLabelValues(lag.Stream, lag.Group).Set(float64(lag.Pending))
+
+		age := 0.0
+		if !lag.OldestPending.IsZero() {
+			age = now.Sub(lag.OldestPending).Seconds()
+		}
+		metrics.EventConsumerOldestPendingAge.WithLabelValues(lag.Stream, lag.Group).Set(age)
+	}
+}
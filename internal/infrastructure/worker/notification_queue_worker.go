@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+)
+
+// NotificationQueueWorker periodically delivers the notifications queued by
+// channels in their quiet hours or in digest mode.
+type NotificationQueueWorker struct {
+	notificationService *service.NotificationService
+	interval            time.Duration
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	done                chan struct{}
+}
+
+// NewNotificationQueueWorker creates a new notification queue worker.
+func NewNotificationQueueWorker(notificationService *service.NotificationService, interval time.Duration) *NotificationQueueWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	return &NotificationQueueWorker{
+		notificationService: notificationService,
+		interval:            interval,
+		ctx:                 ctx,
+		cancel:              cancel,
+		done:                make(chan struct{}),
+	}
+}
+
+// Start starts the notification queue worker.
+func (w *NotificationQueueWorker) Start() error {
+	log.Info().Dur("interval", w.interval).Msg("Starting notification queue worker...")
+
+	go w.run()
+
+	log.Info().Msg("Notification queue worker started successfully")
+	return nil
+}
+
+// Stop stops the notification queue worker.
+func (w *NotificationQueueWorker) Stop() error {
+	log.Info().Msg("Stopping notification queue worker...")
+	w.cancel()
+	<-w.done
+	log.Info().Msg("Notification queue worker stopped")
+	return nil
+}
+
+// run flushes the notification queues that are due on every tick until stopped.
+func (w *NotificationQueueWorker) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			delivered, err := w.notificationService.FlushQueues(w.ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to deliver notification digests")
+			}
+			if delivered > 0 {
+				log.Info().Int("notifications", delivered).Msg("Notification digests delivered")
+			}
+		}
+	}
+}
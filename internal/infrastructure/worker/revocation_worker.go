@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RevocationRefresher rebuilds the filter of revoked tokens.
+type RevocationRefresher interface {
+	RefreshRevocations(ctx context.Context) error
+}
+
+// RevocationWorker periodically rebuilds the filter of revoked tokens of
+// this instance, so that it learns about the tokens revoked by the others.
+// Unlike scheduled jobs it runs on every instance.
+type RevocationWorker struct {
+	refresher RevocationRefresher
+	interval  time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewRevocationWorker creates a new revocation worker. The refresher is set
+// with SetRefresher before the worker is started.
+func NewRevocationWorker(interval time.Duration) *RevocationWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	return &RevocationWorker{
+		interval: interval,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// SetRefresher sets the service rebuilding the filter. Without one the
+// worker does nothing.
+func (w *RevocationWorker) SetRefresher(refresher RevocationRefresher) {
+	w.refresher = refresher
+}
+
+// Start starts the revocation worker.
+func (w *RevocationWorker) Start() error {
+	log.Info().Dur("interval", w.interval).Msg("Starting revocation worker...")
+
+	go w.run()
+
+	log.Info().Msg("Revocation worker started successfully")
+	return nil
+}
+
+// Stop stops the revocation worker.
+func (w *RevocationWorker) Stop() error {
+	log.Info().Msg("Stopping revocation worker...")
+	w.cancel()
+	<-w.done
+	log.Info().Msg("Revocation worker stopped")
+	return nil
+}
+
+// run rebuilds the filter right away and then on every tick until stopped.
+func (w *RevocationWorker) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.refresh()
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh rebuilds the filter. On failure the previous filter is kept.
+func (w *RevocationWorker) refresh() {
+	if w.refresher == nil {
+		return
+	}
+
+	if err := w.refresher.RefreshRevocations(w.ctx); err != nil && w.ctx.Err() == nil {
+		log.Error().Err(err).Msg("Failed to refresh revoked tokens")
+	}
+}
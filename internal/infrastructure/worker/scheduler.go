@@ -0,0 +1,282 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// Scheduler keys.
+const (
+	schedulerLockKeyPrefix    = "scheduler:lock:"
+	schedulerLastRunKeyPrefix = "scheduler:last-run:"
+)
+
+// schedulerLastRunTTL is how long the last run of a job is kept once the job
+// stops running, e.g. after it was removed.
+const schedulerLastRunTTL = 30 * 24 * time.Hour
+
+// Job run outcomes.
+const (
+	JobStatusSuccess = "success"
+	JobStatusFailed  = "failed"
+	JobStatusSkipped = "skipped"
+)
+
+// Job is a periodic task run by the scheduler.
+type Job func(ctx context.Context) error
+
+// JobRun describes a run of a job, by whichever instance ran it.
+type JobRun struct {
+	Instance  string        `json:"instance"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Status    string        `json:"status"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// JobStatus describes a registered job.
+type JobStatus struct {
+	Name     string
+	Schedule string
+	// NextRun is when this instance next competes to run the job.
+	NextRun time.Time
+	// Running reports whether this instance is running the job.
+	Running bool
+	// LastRun is the last run of the job by any instance, nil if it never ran.
+	LastRun *JobRun
+}
+
+// scheduledJob is a job with its schedule and the state of its runs on this
+// instance.
+type scheduledJob struct {
+	name     string
+	schedule valueobject.CronSchedule
+	run      Job
+
+	mu      sync.Mutex
+	next    time.Time
+	running bool
+	lastRun *JobRun
+}
+
+// Scheduler runs periodic jobs on their cron schedule. Every instance keeps
+// the schedule, and for each run time the instance that takes its lock in
+// the cache runs the job, so that a job runs once per run time across the
+// deployment. Without a cache every run time runs locally.
+type Scheduler struct {
+	cacheRepo repository.CacheRepository
+	instance  string
+	overrides map[string]valueobject.CronSchedule
+	jobs      []*scheduledJob
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewScheduler creates a new scheduler. The instance names this process in
+// the last runs of jobs. Overrides replace the schedule of jobs by name with
+// a cron expression; an invalid expression is an error.
+func NewScheduler(cacheRepo repository.CacheRepository, instance string, overrides map[string]string) (*Scheduler, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	parsed := make(map[string]valueobject.CronSchedule, len(overrides))
+	for name, expr := range overrides {
+		schedule, err := valueobject.ParseCronSchedule(expr)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("schedule of job %s: %w", name, err)
+		}
+		parsed[name] = schedule
+	}
+
+	return &Scheduler{
+		cacheRepo: cacheRepo,
+		instance:  instance,
+		overrides: parsed,
+		ctx:       ctx,
+		cancel:    cancel,
+	}, nil
+}
+
+// Register adds a job under a unique name, run on the schedule unless the
+// configuration overrides it. It must be called before Start.
+func (s *Scheduler) Register(name string, schedule valueobject.CronSchedule, job Job) {
+	if override, ok := s.overrides[name]; ok {
+		schedule = override
+	}
+	s.jobs = append(s.jobs, &scheduledJob{name: name, schedule: schedule, run: job})
+}
+
+// Start starts running the registered jobs.
+func (s *Scheduler) Start() error {
+	log.Info().Int("jobs", len(s.jobs)).Msg("Starting scheduler...")
+
+	registered := make(map[string]bool, len(s.jobs))
+	for _, job := range s.jobs {
+		registered[job.name] = true
+		log.Info().Str("job", job.name).Str("schedule", job.schedule.String()).Msg("Job scheduled")
+
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+	for name := range s.overrides {
+		if !registered[name] {
+			log.Warn().Str("job", name).Msg("Schedule configured for an unknown job")
+		}
+	}
+
+	log.Info().Msg("Scheduler started successfully")
+	return nil
+}
+
+// Stop stops the scheduler, waiting for the jobs being run, which are
+// cancelled.
+func (s *Scheduler) Stop() error {
+	log.Info().Msg("Stopping scheduler...")
+	s.cancel()
+	s.wg.Wait()
+	log.Info().Msg("Scheduler stopped")
+	return nil
+}
+
+// Jobs returns the status of the registered jobs, by name. Last runs come
+// from the cache, so that they include the runs of other instances.
+func (s *Scheduler) Jobs(ctx context.Context) []JobStatus {
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		job.mu.Lock()
+		status := JobStatus{
+			Name:     job.name,
+			Schedule: job.schedule.String(),
+			NextRun:  job.next,
+			Running:  job.running,
+			LastRun:  job.lastRun,
+		}
+		job.mu.Unlock()
+
+		if s.cacheRepo != nil {
+			var lastRun JobRun
+			if err := s.cacheRepo.Get(ctx, schedulerLastRunKeyPrefix+job.name, &lastRun); err == nil {
+				status.LastRun = &lastRun
+			} else if !errors.Is(err, repository.ErrNotFound) {
+				log.Warn().Err(err).Str("job", job.name).Msg("Failed to read last job run")
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// loop waits for every run time of a job and runs it, until stopped. Run
+// times missed while the job was running are skipped.
+func (s *Scheduler) loop(job *scheduledJob) {
+	defer s.wg.Done()
+
+	for {
+		next := job.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Warn().Str("job", job.name).Msg("Job has no further run time")
+			return
+		}
+		job.mu.Lock()
+		job.next = next
+		job.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.runAt(job, next)
+	}
+}
+
+// runAt runs a job for a run time, unless another instance took it.
+func (s *Scheduler) runAt(job *scheduledJob, at time.Time) {
+	acquired, err := s.acquire(job, at)
+	if err != nil {
+		metrics.SchedulerJobRunsTotal.WithLabelValues(job.name, JobStatusFailed).Inc()
+		log.Error().Err(err).Str("job", job.name).Msg("Failed to lock job run")
+		return
+	}
+	if !acquired {
+		metrics.SchedulerJobRunsTotal.WithLabelValues(job.name, JobStatusSkipped).Inc()
+		return
+	}
+
+	job.mu.Lock()
+	job.running = true
+	job.mu.Unlock()
+
+	start := time.Now()
+	runErr := job.run(s.ctx)
+	duration := time.Since(start)
+
+	run := &JobRun{
+		Instance:  s.instance,
+		StartedAt: start.UTC(),
+		Duration:  duration,
+		Status:    JobStatusSuccess,
+	}
+	if runErr != nil {
+		run.Status = JobStatusFailed
+		run.Error = runErr.Error()
+		log.Error().Err(runErr).Str("job", job.name).Dur("duration", duration).Msg("Job failed")
+	} else {
+		log.Debug().Str("job", job.name).Dur("duration", duration).Msg("Job completed")
+	}
+
+	job.mu.Lock()
+	job.running = false
+	job.lastRun = run
+	job.mu.Unlock()
+
+	metrics.SchedulerJobRunsTotal.WithLabelValues(job.name, run.Status).Inc()
+	metrics.SchedulerJobDuration.WithLabelValues(job.name).Observe(duration.Seconds())
+
+	if s.cacheRepo != nil {
+		if err := s.cacheRepo.Set(s.ctx, schedulerLastRunKeyPrefix+job.name, run, schedulerLastRunTTL); err != nil {
+			log.Warn().Err(err).Str("job", job.name).Msg("Failed to record job run")
+		}
+	}
+}
+
+// acquire takes the lock of a run time of a job. The lock is not released:
+// it lasts until the following run time, so instances whose clocks drift
+// apart still agree.
+func (s *Scheduler) acquire(job *scheduledJob, at time.Time) (bool, error) {
+	if s.cacheRepo == nil {
+		return true, nil
+	}
+
+	ttl := time.Minute
+	if following := job.schedule.Next(at); !following.IsZero() && following.Sub(at) > ttl {
+		ttl = following.Sub(at)
+	}
+
+	key := fmt.Sprintf("%s%s:%d", schedulerLockKeyPrefix, job.name, at.Unix())
+	if _, err := s.cacheRepo.Lock(s.ctx, key, ttl); err != nil {
+		if errors.Is(err, repository.ErrLockHeld) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+const (
+	// subscriptionPurgeInterval is how often finished deliveries are purged.
+	subscriptionPurgeInterval = time.Hour
+	// subscriptionClaimBatch is the most deliveries claimed on a tick.
+	subscriptionClaimBatch = 100
+)
+
+// SubscriptionDispatcher claims the webhook deliveries that are due and
+// sends them.
+type SubscriptionDispatcher interface {
+	ClaimDue(ctx context.Context, limit int) ([]*entity.SubscriptionDelivery, error)
+	Deliver(ctx context.Context, delivery *entity.SubscriptionDelivery) error
+	PurgeDeliveries(ctx context.Context) (int64, error)
+}
+
+// SubscriptionWorker schedules the deliveries of outbound webhook
+// subscriptions and sends them with a pool of workers.
+type SubscriptionWorker struct {
+	dispatcher SubscriptionDispatcher
+	interval   time.Duration
+	workers    int
+	jobs       chan *entity.SubscriptionDelivery
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// NewSubscriptionWorker creates a new subscription worker claiming due
+// deliveries every interval for the given number of concurrent requests.
+func NewSubscriptionWorker(dispatcher SubscriptionDispatcher, interval time.Duration, workers int) *SubscriptionWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	if workers <= 0 {
+		workers = 4
+	}
+
+	return &SubscriptionWorker{
+		dispatcher: dispatcher,
+		interval:   interval,
+		workers:    workers,
+		jobs:       make(chan *entity.SubscriptionDelivery),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start starts the scheduler and the delivery workers.
+func (w *SubscriptionWorker) Start() error {
+	log.Info().
+		Dur("interval", w.interval).
+		Int("workers", w.workers).
+		Msg("Starting subscription worker...")
+
+	for i := 0; i < w.workers; i++ {
+		w.wg.Add(1)
+		go w.deliver()
+	}
+	w.wg.Add(1)
+	go w.schedule()
+
+	log.Info().Msg("Subscription worker started successfully")
+	return nil
+}
+
+// Stop stops the subscription worker, cancelling the deliveries in flight.
+// They are claimed again once their lease expires.
+func (w *SubscriptionWorker) Stop() error {
+	log.Info().Msg("Stopping subscription worker...")
+	w.cancel()
+	w.wg.Wait()
+	log.Info().Msg("Subscription worker stopped")
+	return nil
+}
+
+// schedule hands the due deliveries to the delivery workers on every tick,
+// and purges finished deliveries periodically, until stopped.
+func (w *SubscriptionWorker) schedule() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	purge := time.NewTicker(subscriptionPurgeInterval)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.dispatch()
+		case <-purge.C:
+			removed, err := w.dispatcher.PurgeDeliveries(w.ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to purge webhook deliveries")
+			} else if removed > 0 {
+				log.Info().Int64("removed", removed).Msg("Purged webhook deliveries")
+			}
+		}
+	}
+}
+
+// dispatch claims the due deliveries and hands each to a delivery worker,
+// waiting while they are all busy.
+func (w *SubscriptionWorker) dispatch() {
+	deliveries, err := w.dispatcher.ClaimDue(w.ctx, subscriptionClaimBatch)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to claim due webhook deliveries")
+	}
+
+	for _, delivery := range deliveries {
+		select {
+		case <-w.ctx.Done():
+			return
+		case w.jobs <- delivery:
+		}
+	}
+}
+
+// deliver sends the deliveries handed over by the scheduler until stopped.
+func (w *SubscriptionWorker) deliver() {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case delivery := <-w.jobs:
+			if err := w.dispatcher.Deliver(w.ctx, delivery); err != nil {
+				log.Error().Err(err).Str("delivery_id", delivery.ID.String()).Msg("Failed to deliver webhook")
+			}
+		}
+	}
+}
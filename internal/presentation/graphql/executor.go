@@ -0,0 +1,491 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Query complexity limits. Every selected field costs one, and fields computed
+// by a resolver, which query the stores, cost resolverCost more.
+const (
+	// MaxComplexity is the maximum complexity of an operation.
+	MaxComplexity = 1000
+	resolverCost  = 50
+)
+
+// ErrTooComplex is returned for operations selecting too many fields.
+var ErrTooComplex = errors.New("operation is too complex")
+
+// Request is a GraphQL request as sent by clients.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the result of a GraphQL operation.
+type Response struct {
+	Data   interface{} `json:"data"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is an error of a GraphQL request. Field errors carry the path of the
+// field they occurred at.
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Operation is a validated operation ready to run.
+type Operation struct {
+	kind       string
+	root       *Object
+	selections []*selection
+	variables  map[string]interface{}
+}
+
+// Prepare parses a request and validates the selected operation against the schema.
+func Prepare(schema *Schema, req Request) (*Operation, error) {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := selectOperation(doc, req.OperationName)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *Object
+	switch op.kind {
+	case "query":
+		root = schema.Query
+	case "subscription":
+		root = schema.Subscription
+	}
+	if root == nil {
+		return nil, fmt.Errorf("%s operations are not supported", op.kind)
+	}
+
+	variables, err := coerceVariables(op, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validate(root, op.selections); err != nil {
+		return nil, err
+	}
+
+	if cost := complexity(root, op.selections); cost > MaxComplexity {
+		return nil, fmt.Errorf("%w: complexity %d, at most %d allowed", ErrTooComplex, cost, MaxComplexity)
+	}
+
+	if op.kind == "subscription" && (len(op.selections) != 1 || op.selections[0].name == "__typename") {
+		return nil, fmt.Errorf("subscription %q must select exactly one top level field", op.name)
+	}
+
+	return &Operation{
+		kind:       op.kind,
+		root:       root,
+		selections: op.selections,
+		variables:  variables,
+	}, nil
+}
+
+// selectOperation picks the operation to run from a document.
+func selectOperation(doc *document, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, fmt.Errorf("operation name is required when the document contains several operations")
+		}
+		return doc.operations[0], nil
+	}
+
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation named %q", name)
+}
+
+// coerceVariables applies the defaults of an operation's variables and
+// checks that the required ones are provided.
+func coerceVariables(op *operation, provided map[string]interface{}) (map[string]interface{}, error) {
+	variables := make(map[string]interface{}, len(op.variables))
+	for _, def := range op.variables {
+		value, ok := provided[def.name]
+		switch {
+		case ok && value != nil:
+			variables[def.name] = value
+		case def.hasDefault:
+			variables[def.name] = def.defaultValue
+		case def.required:
+			return nil, fmt.Errorf("variable \"$%s\" of required type was not provided", def.name)
+		default:
+			variables[def.name] = nil
+		}
+	}
+	return variables, nil
+}
+
+// validate checks the selections against the fields of an object type.
+func validate(obj *Object, selections []*selection) error {
+	for _, sel := range selections {
+		for _, d := range sel.directives {
+			if d.name != "include" && d.name != "skip" {
+				return fmt.Errorf("unknown directive \"@%s\"", d.name)
+			}
+		}
+
+		if sel.name == "__typename" {
+			if sel.selections != nil {
+				return fmt.Errorf("field \"__typename\" must not have a selection since type is scalar")
+			}
+			continue
+		}
+
+		field, ok := obj.Fields[sel.name]
+		if !ok {
+			return fmt.Errorf("cannot query field %q on type %q", sel.name, obj.Name)
+		}
+
+		for name := range sel.arguments {
+			if !contains(field.Args, name) {
+				return fmt.Errorf("unknown argument %q on field \"%s.%s\"", name, obj.Name, sel.name)
+			}
+		}
+
+		switch {
+		case field.Type == nil && sel.selections != nil:
+			return fmt.Errorf("field %q must not have a selection since type is scalar", sel.name)
+		case field.Type != nil && sel.selections == nil:
+			return fmt.Errorf("field %q of type %q must have a selection of subfields", sel.name, field.Type.Name)
+		case field.Type != nil:
+			if err := validate(field.Type, sel.selections); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// complexity returns the cost of validated selections of an object type.
+func complexity(obj *Object, selections []*selection) int {
+	cost := 0
+	for _, sel := range selections {
+		cost++
+		if sel.name == "__typename" {
+			continue
+		}
+
+		field := obj.Fields[sel.name]
+		if field.Resolve != nil || field.Subscribe != nil {
+			cost += resolverCost
+		}
+		if field.Type != nil {
+			cost += complexity(field.Type, sel.selections)
+		}
+	}
+	return cost
+}
+
+// IsSubscription reports whether the operation is a subscription.
+func (o *Operation) IsSubscription() bool {
+	return o.kind == "subscription"
+}
+
+// Execute runs a query operation. Field errors are reported in the response
+// and leave the field null.
+func (o *Operation) Execute(ctx context.Context) *Response {
+	e := &executor{variables: o.variables}
+	data := newOrderedMap()
+
+	for _, sel := range o.selections {
+		included, err := e.included(sel)
+		if err != nil {
+			e.fail(err, []interface{}{sel.responseKey()})
+			continue
+		}
+		if !included {
+			continue
+		}
+
+		key := sel.responseKey()
+		if sel.name == "__typename" {
+			data.set(key, o.root.Name)
+			continue
+		}
+
+		data.set(key, e.resolve(ctx, o.root.Fields[sel.name], sel, nil, []interface{}{key}))
+	}
+
+	return &Response{Data: data, Errors: e.errors}
+}
+
+// Subscribe runs a subscription operation, returning a response for each of
+// its events until ctx is done.
+func (o *Operation) Subscribe(ctx context.Context) (<-chan *Response, error) {
+	if !o.IsSubscription() {
+		return nil, fmt.Errorf("operation is not a subscription")
+	}
+
+	sel := o.selections[0]
+	field := o.root.Fields[sel.name]
+	key := sel.responseKey()
+
+	e := &executor{variables: o.variables}
+	args, err := e.arguments(sel.arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := field.Subscribe(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(chan *Response)
+	go func() {
+		defer close(responses)
+
+		for event := range events {
+			e := &executor{variables: o.variables}
+			data := newOrderedMap()
+			data.set(key, e.complete(ctx, field.Type, sel.selections, toJSON(event), []interface{}{key}))
+
+			select {
+			case responses <- &Response{Data: data, Errors: e.errors}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return responses, nil
+}
+
+// executor selects the fields of an operation's results.
+type executor struct {
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+// fail records a field error.
+func (e *executor) fail(err error, path []interface{}) {
+	e.errors = append(e.errors, &Error{Message: err.Error(), Path: path})
+}
+
+// included applies the @include and @skip directives of a selection.
+func (e *executor) included(sel *selection) (bool, error) {
+	for _, d := range sel.directives {
+		args, err := e.arguments(d.arguments)
+		if err != nil {
+			return false, err
+		}
+		condition, ok := args["if"].(bool)
+		if !ok {
+			return false, fmt.Errorf("directive \"@%s\" requires a boolean \"if\" argument", d.name)
+		}
+		if (d.name == "include") != condition {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// arguments substitutes the variables in argument values.
+func (e *executor) arguments(arguments map[string]interface{}) (Args, error) {
+	args := make(Args, len(arguments))
+	for name, value := range arguments {
+		resolved, err := e.value(value)
+		if err != nil {
+			return nil, err
+		}
+		args[name] = resolved
+	}
+	return args, nil
+}
+
+// value substitutes the variables in a value.
+func (e *executor) value(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case variableRef:
+		resolved, ok := e.variables[string(v)]
+		if !ok {
+			return nil, fmt.Errorf("variable \"$%s\" is not defined", v)
+		}
+		return resolved, nil
+
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved, err := e.value(item)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = resolved
+		}
+		return list, nil
+
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for name, item := range v {
+			resolved, err := e.value(item)
+			if err != nil {
+				return nil, err
+			}
+			object[name] = resolved
+		}
+		return object, nil
+
+	default:
+		return v, nil
+	}
+}
+
+// resolve computes a field of a parent value and selects its subfields.
+func (e *executor) resolve(ctx context.Context, field *Field, sel *selection, parent map[string]interface{}, path []interface{}) interface{} {
+	if field.Resolve == nil {
+		return e.complete(ctx, field.Type, sel.selections, parent[field.Key], path)
+	}
+
+	args, err := e.arguments(sel.arguments)
+	if err != nil {
+		e.fail(err, path)
+		return nil
+	}
+
+	result, err := field.Resolve(ctx, args)
+	if err != nil {
+		e.fail(err, path)
+		return nil
+	}
+
+	return e.complete(ctx, field.Type, sel.selections, toJSON(result), path)
+}
+
+// complete selects the subfields of a JSON value of the given type.
+func (e *executor) complete(ctx context.Context, typ *Object, selections []*selection, value interface{}, path []interface{}) interface{} {
+	if typ == nil || value == nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = e.complete(ctx, typ, selections, item, appendPath(path, i))
+		}
+		return list
+
+	case map[string]interface{}:
+		object := newOrderedMap()
+		for _, sel := range selections {
+			key := sel.responseKey()
+			included, err := e.included(sel)
+			if err != nil {
+				e.fail(err, appendPath(path, key))
+				continue
+			}
+			if !included {
+				continue
+			}
+
+			if sel.name == "__typename" {
+				object.set(key, typ.Name)
+				continue
+			}
+			object.set(key, e.resolve(ctx, typ.Fields[sel.name], sel, v, appendPath(path, key)))
+		}
+		return object
+
+	default:
+		e.fail(fmt.Errorf("expected an object of type %q", typ.Name), path)
+		return nil
+	}
+}
+
+// appendPath returns a copy of path with an element added.
+func appendPath(path []interface{}, element interface{}) []interface{} {
+	extended := make([]interface{}, len(path), len(path)+1)
+	copy(extended, path)
+	return append(extended, element)
+}
+
+// toJSON converts a resolved value to its JSON representation, from which
+// fields are selected by key.
+func toJSON(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var converted interface{}
+	if err := decoder.Decode(&converted); err != nil {
+		return nil
+	}
+	return converted
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// orderedMap is a JSON object keeping the order of the selected fields.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]interface{})}
+}
+
+// set sets a key, keeping the position of a key set before.
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
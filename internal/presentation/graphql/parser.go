@@ -0,0 +1,661 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Document limits, bounding the work and stack a request may take.
+const (
+	// MaxDocumentSize is the maximum size of a document, in bytes.
+	MaxDocumentSize = 64 << 10
+	// MaxDepth is how deeply selection sets, list and object values, and list
+	// types may be nested.
+	MaxDepth = 32
+)
+
+// Document errors.
+var (
+	ErrDocumentTooLarge = errors.New("document is too large")
+	ErrDocumentTooDeep  = errors.New("document is nested too deeply")
+)
+
+// errFragments is returned for documents using fragments, which are not supported.
+var errFragments = errors.New("fragments are not supported")
+
+// document is a parsed GraphQL document.
+type document struct {
+	operations []*operation
+}
+
+// operation is a query, mutation or subscription of a document.
+type operation struct {
+	kind       string
+	name       string
+	variables  []variableDefinition
+	selections []*selection
+}
+
+// variableDefinition declares a variable of an operation.
+type variableDefinition struct {
+	name         string
+	required     bool
+	defaultValue interface{}
+	hasDefault   bool
+}
+
+// selection is a field selected in a selection set.
+type selection struct {
+	alias      string
+	name       string
+	arguments  map[string]interface{}
+	directives []directive
+	selections []*selection
+}
+
+// responseKey returns the key of the field in the response.
+func (s *selection) responseKey() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+// directive is a directive applied to a field, such as @include(if: $x).
+type directive struct {
+	name      string
+	arguments map[string]interface{}
+}
+
+// variableRef is a reference to a variable in an argument value.
+type variableRef string
+
+// Token kinds.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// token is a lexical token of a document.
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// describe returns the token as shown in syntax errors.
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "<EOF>"
+	case tokenString:
+		return "string"
+	default:
+		return strconv.Quote(t.value)
+	}
+}
+
+// lexer splits a document into tokens.
+type lexer struct {
+	src string
+	pos int
+}
+
+// skipIgnored skips whitespace, commas and comments.
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ', c == '\t', c == '\n', c == '\r', c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// next returns the next token.
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokenPunct, value: "...", pos: start}, nil
+
+	case strings.ContainsRune("!$():=@[]{}|&", rune(c)):
+		l.pos++
+		return token{kind: tokenPunct, value: string(c), pos: start}, nil
+
+	case isNameStart(c):
+		for l.pos < len(l.src) && isNameContinue(l.src[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], pos: start}, nil
+
+	case c == '-' || isDigit(c):
+		return l.number()
+
+	case c == '"':
+		return l.string()
+
+	default:
+		return token{}, fmt.Errorf("syntax error at %d: unexpected character %q", start, c)
+	}
+}
+
+// number scans an int or float token.
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	kind := tokenInt
+
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	if !l.digits() {
+		return token{}, fmt.Errorf("syntax error at %d: invalid number", start)
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.pos++
+		if !l.digits() {
+			return token{}, fmt.Errorf("syntax error at %d: invalid number", start)
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if !l.digits() {
+			return token{}, fmt.Errorf("syntax error at %d: invalid number", start)
+		}
+	}
+
+	return token{kind: kind, value: l.src[start:l.pos], pos: start}, nil
+}
+
+// digits scans a sequence of digits and reports whether there was any.
+func (l *lexer) digits() bool {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+	return l.pos > start
+}
+
+// string scans a string or block string token. String escapes are those of JSON.
+func (l *lexer) string() (token, error) {
+	start := l.pos
+
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		end := strings.Index(l.src[l.pos+3:], `"""`)
+		if end < 0 {
+			return token{}, fmt.Errorf("syntax error at %d: unterminated string", start)
+		}
+		value := l.src[l.pos+3 : l.pos+3+end]
+		l.pos += end + 6
+		return token{kind: tokenString, value: strings.TrimSpace(value), pos: start}, nil
+	}
+
+	l.pos++
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\\':
+			l.pos += 2
+		case '"':
+			l.pos++
+			var value string
+			if err := json.Unmarshal([]byte(l.src[start:l.pos]), &value); err != nil {
+				return token{}, fmt.Errorf("syntax error at %d: invalid string", start)
+			}
+			return token{kind: tokenString, value: value, pos: start}, nil
+		case '\n':
+			return token{}, fmt.Errorf("syntax error at %d: unterminated string", start)
+		default:
+			l.pos++
+		}
+	}
+
+	return token{}, fmt.Errorf("syntax error at %d: unterminated string", start)
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parser builds a document from the tokens of a lexer.
+type parser struct {
+	lex   *lexer
+	tok   token
+	depth int
+}
+
+// parse parses a GraphQL document of at most MaxDocumentSize bytes, nested
+// at most MaxDepth levels deep.
+func parse(src string) (*document, error) {
+	if len(src) > MaxDocumentSize {
+		return nil, fmt.Errorf("%w: %d bytes, at most %d allowed", ErrDocumentTooLarge, len(src), MaxDocumentSize)
+	}
+
+	p := &parser{lex: &lexer{src: src}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{}
+	for p.tok.kind != tokenEOF {
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		doc.operations = append(doc.operations, op)
+	}
+
+	if len(doc.operations) == 0 {
+		return nil, errors.New("document does not contain any operation")
+	}
+
+	return doc, nil
+}
+
+// advance moves to the next token.
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// peek reports whether the current token is the given punctuator.
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokenPunct && p.tok.value == punct
+}
+
+// unexpected returns a syntax error for the current token.
+func (p *parser) unexpected(expected string) error {
+	return fmt.Errorf("syntax error at %d: expected %s, found %s", p.tok.pos, expected, p.tok.describe())
+}
+
+// enter descends into a nested selection set, value or type. The parser
+// recurses on nesting, so its depth is bounded to keep the stack in check.
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > MaxDepth {
+		return fmt.Errorf("%w at %d: at most %d levels allowed", ErrDocumentTooDeep, p.tok.pos, MaxDepth)
+	}
+	return nil
+}
+
+// leave returns from a nested selection set, value or type.
+func (p *parser) leave() {
+	p.depth--
+}
+
+// expect consumes the given punctuator.
+func (p *parser) expect(punct string) error {
+	if !p.peek(punct) {
+		return p.unexpected(strconv.Quote(punct))
+	}
+	return p.advance()
+}
+
+// parseName consumes a name.
+func (p *parser) parseName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected("name")
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+// parseOperation parses an operation definition, or the shorthand query form.
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{kind: "query"}
+
+	if !p.peek("{") {
+		if p.tok.kind != tokenName {
+			return nil, p.unexpected("operation")
+		}
+		switch p.tok.value {
+		case "query", "mutation", "subscription":
+			op.kind = p.tok.value
+		case "fragment":
+			return nil, errFragments
+		default:
+			return nil, p.unexpected("operation")
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind == tokenName {
+			op.name = p.tok.value
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+
+		if p.peek("(") {
+			variables, err := p.parseVariableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = variables
+		}
+
+		// Operation directives have no effect
+		if _, err := p.parseDirectives(); err != nil {
+			return nil, err
+		}
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = selections
+
+	return op, nil
+}
+
+// parseVariableDefinitions parses the variables of an operation.
+func (p *parser) parseVariableDefinitions() ([]variableDefinition, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var variables []variableDefinition
+	for !p.peek(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		required, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		variable := variableDefinition{name: name, required: required}
+		if p.peek("=") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if variable.defaultValue, err = p.parseValue(); err != nil {
+				return nil, err
+			}
+			variable.hasDefault = true
+		}
+
+		variables = append(variables, variable)
+	}
+
+	return variables, p.advance()
+}
+
+// parseType parses a variable type and reports whether it is non-null.
+func (p *parser) parseType() (bool, error) {
+	if p.peek("[") {
+		if err := p.enter(); err != nil {
+			return false, err
+		}
+		defer p.leave()
+
+		if err := p.advance(); err != nil {
+			return false, err
+		}
+		if _, err := p.parseType(); err != nil {
+			return false, err
+		}
+		if err := p.expect("]"); err != nil {
+			return false, err
+		}
+	} else if _, err := p.parseName(); err != nil {
+		return false, err
+	}
+
+	if p.peek("!") {
+		return true, p.advance()
+	}
+	return false, nil
+}
+
+// parseSelectionSet parses the fields selected between braces.
+func (p *parser) parseSelectionSet() ([]*selection, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var selections []*selection
+	for !p.peek("}") {
+		if p.peek("...") {
+			return nil, errFragments
+		}
+		sel, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+
+	if len(selections) == 0 {
+		return nil, p.unexpected("field")
+	}
+
+	return selections, p.advance()
+}
+
+// parseField parses a field with its alias, arguments, directives and selections.
+func (p *parser) parseField() (*selection, error) {
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+
+	sel := &selection{name: name}
+	if p.peek(":") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		sel.alias = name
+		if sel.name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek("(") {
+		if sel.arguments, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+	}
+
+	if sel.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	if p.peek("{") {
+		if sel.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sel, nil
+}
+
+// parseArguments parses the arguments between parentheses.
+func (p *parser) parseArguments() (map[string]interface{}, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	arguments := make(map[string]interface{})
+	for !p.peek(")") {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if arguments[name], err = p.parseValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	return arguments, p.advance()
+}
+
+// parseDirectives parses the directives applied to a field or operation.
+func (p *parser) parseDirectives() ([]directive, error) {
+	var directives []directive
+	for p.peek("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+
+		d := directive{name: name}
+		if p.peek("(") {
+			if d.arguments, err = p.parseArguments(); err != nil {
+				return nil, err
+			}
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// parseValue parses an argument value. Enum values are returned as strings
+// and variables as variableRef.
+func (p *parser) parseValue() (interface{}, error) {
+	tok := p.tok
+
+	switch {
+	case p.peek("$"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		return variableRef(name), err
+
+	case p.peek("["):
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		list := []interface{}{}
+		for !p.peek("]") {
+			item, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, p.advance()
+
+	case p.peek("{"):
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		object := make(map[string]interface{})
+		for !p.peek("}") {
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if object[name], err = p.parseValue(); err != nil {
+				return nil, err
+			}
+		}
+		return object, p.advance()
+
+	case tok.kind == tokenInt:
+		n, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("syntax error at %d: invalid int %s", tok.pos, tok.value)
+		}
+		return n, p.advance()
+
+	case tok.kind == tokenFloat:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("syntax error at %d: invalid float %s", tok.pos, tok.value)
+		}
+		return f, p.advance()
+
+	case tok.kind == tokenString:
+		return tok.value, p.advance()
+
+	case tok.kind == tokenName:
+		var value interface{}
+		switch tok.value {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			value = tok.value
+		}
+		return value, p.advance()
+
+	default:
+		return nil, p.unexpected("value")
+	}
+}
@@ -0,0 +1,107 @@
+// Package graphql implements the subset of GraphQL served by the API: queries
+// and subscriptions with field selection, aliases, arguments, variables and
+// the @include and @skip directives. Fragments, mutations and introspection
+// are not supported. Documents are limited in size, nesting depth and
+// complexity.
+package graphql
+
+import (
+	"context"
+	"strings"
+)
+
+// Schema holds the root types of a GraphQL API.
+type Schema struct {
+	Query        *Object
+	Subscription *Object
+}
+
+// Object is a GraphQL object type.
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+// Field is a field of an object type. Fields of the root types are computed by
+// Resolve, or by Subscribe for subscriptions. Other fields are read from the
+// JSON representation of their parent, under Key, unless they have a resolver.
+type Field struct {
+	// Key is the JSON key the field is read from.
+	Key string
+	// Type is the type of object and list of object fields; nil for scalars.
+	Type *Object
+	// Args lists the arguments the field accepts.
+	Args []string
+	// Resolve computes the field; the result is converted to JSON before selection.
+	Resolve func(ctx context.Context, args Args) (interface{}, error)
+	// Subscribe streams the events of a subscription field until ctx is done.
+	Subscribe func(ctx context.Context, args Args) (<-chan interface{}, error)
+}
+
+// NewObject creates an object type whose scalar fields are read from the
+// given JSON keys. Snake case keys are exposed as camel case fields, e.g.
+// created_at as createdAt.
+func NewObject(name string, keys ...string) *Object {
+	obj := &Object{Name: name, Fields: make(map[string]*Field, len(keys))}
+	for _, key := range keys {
+		obj.Fields[camelCase(key)] = &Field{Key: key}
+	}
+	return obj
+}
+
+// With adds a field to the object and returns the object.
+func (o *Object) With(name string, field *Field) *Object {
+	o.Fields[name] = field
+	return o
+}
+
+// camelCase converts a snake case key to camel case.
+func camelCase(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// Args holds the arguments of a field, with variables substituted.
+type Args map[string]interface{}
+
+// String returns a string argument, or "" if it is missing.
+func (a Args) String(name string) string {
+	s, _ := a[name].(string)
+	return s
+}
+
+// Int returns an integer argument, or 0 if it is missing.
+func (a Args) Int(name string) int {
+	switch n := a[name].(type) {
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	default:
+		return 0
+	}
+}
+
+// Strings returns a list of strings argument. A single string is accepted as
+// a list of one, as GraphQL input coercion does.
+func (a Args) Strings(name string) []string {
+	switch v := a[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
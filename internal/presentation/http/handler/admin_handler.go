@@ -1,29 +1,84 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/circuitbreaker"
 	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
 
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/worker"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
 )
 
+// SlowClientReporter reports the WebSocket clients falling behind.
+type SlowClientReporter interface {
+	SlowClients() []dto.WebSocketClientStats
+}
+
+// PresenceSource reports the users connected to the WebSocket hub.
+type PresenceSource interface {
+	Presence() []dto.PresenceResponse
+}
+
 // AdminHandler handles admin endpoints.
 type AdminHandler struct {
-	deadLetterProcessor *worker.DeadLetterProcessor
-	eventWorker         *worker.EventWorker
-	cbRegistry          *circuitbreaker.Registry
+	failedEvents *service.FailedEventService
+	eventWorker  *worker.EventWorker
+	cbRegistry   *circuitbreaker.Registry
+	alertService *service.AlertService
+	pagination   valueobject.PaginationPolicy
+	slowClients  SlowClientReporter
+	presence     PresenceSource
+	lag          event.LagInspector
+	scheduler    *worker.Scheduler
 }
 
-// NewAdminHandler creates a new admin handler.
-func NewAdminHandler(dlp *worker.DeadLetterProcessor, ew *worker.EventWorker, cbRegistry *circuitbreaker.Registry) *AdminHandler {
+// NewAdminHandler creates a new admin handler. Failed events are listed
+// within the limits of the pagination policy.
+func NewAdminHandler(
+	failedEvents *service.FailedEventService,
+	ew *worker.EventWorker,
+	cbRegistry *circuitbreaker.Registry,
+	alertService *service.AlertService,
+	pagination valueobject.PaginationPolicy,
+) *AdminHandler {
 	return &AdminHandler{
-		deadLetterProcessor: dlp,
-		eventWorker:         ew,
-		cbRegistry:          cbRegistry,
+		failedEvents: failedEvents,
+		eventWorker:  ew,
+		cbRegistry:   cbRegistry,
+		alertService: alertService,
+		pagination:   pagination,
 	}
 }
 
+// SetSlowClientReporter sets the source of the slow WebSocket client report.
+func (h *AdminHandler) SetSlowClientReporter(reporter SlowClientReporter) {
+	h.slowClients = reporter
+}
+
+// SetPresenceSource sets the source of the connected users list.
+func (h *AdminHandler) SetPresenceSource(source PresenceSource) {
+	h.presence = source
+}
+
+// SetLagInspector sets the source of the consumer lag report.
+func (h *AdminHandler) SetLagInspector(inspector event.LagInspector) {
+	h.lag = inspector
+}
+
+// SetScheduler sets the scheduler whose jobs are reported.
+func (h *AdminHandler) SetScheduler(scheduler *worker.Scheduler) {
+	h.scheduler = scheduler
+}
+
 // Add this method:
 
 // GetCircuitBreakerStats handles GET /api/v1/admin/circuit-breakers
@@ -48,26 +103,57 @@ func (h *AdminHandler) GetCircuitBreakerStats(c *fiber.Ctx) error {
 // GetFailedEvents handles GET /api/v1/admin/failed-events
 //
 //	@Summary		Get failed events
-//	@Description	Retrieve all events in the dead letter queue
+//	@Description	Retrieve a paginated list of events in the dead letter queue, most recent first
 //	@Tags			admin
 //	@Produce		json
-//	@Success		200	{array}		map[string]interface{}
-//	@Failure		401	{object}	dto.ErrorResponse
-//	@Failure		403	{object}	dto.ErrorResponse
-//	@Failure		500	{object}	dto.ErrorResponse
+//	@Param			page		query		int			false	"Page number"		default(1)
+//	@Param			page_size	query		int			false	"Items per page, capped at the configured maximum"	default(20)
+//	@Param			event_type	query		string		false	"Filter by event type"
+//	@Param			status		query		[]string	false	"Filter by status"	Enums(pending, retried, ignored)
+//	@Param			from_date	query		string		false	"Failed on or after (RFC3339)"
+//	@Param			to_date		query		string		false	"Failed on or before (RFC3339)"
+//	@Success		200			{object}	dto.PaginatedFailedEventResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ValidationErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/admin/failed-events [get]
 func (h *AdminHandler) GetFailedEvents(c *fiber.Ctx) error {
-	if h.deadLetterProcessor == nil {
-		return helper.Success(c, []worker.FailedEvent{})
+	if h.failedEvents == nil {
+		return helper.Success(c, dto.PaginatedResponse[dto.FailedEventResponse]{Items: []dto.FailedEventResponse{}})
+	}
+
+	var req dto.ListFailedEventsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid query parameters")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	filter, err := failedEventFilterFromRequest(req)
+	if err != nil {
+		return helper.BadRequest(c, "Dates must be RFC3339")
 	}
 
-	events, err := h.deadLetterProcessor.GetFailedEvents(c.Context())
+	result, err := h.failedEvents.List(c.Context(), filter, h.pagination.Paginate(req.Page, req.PageSize))
 	if err != nil {
+		log.Error().Err(err).Msg("Failed to list failed events")
 		return helper.InternalError(c, "Failed to retrieve failed events")
 	}
 
-	return helper.Success(c, events)
+	return helper.Success(c, dto.PaginatedResponse[dto.FailedEventResponse]{
+		Items:       dto.FailedEventsFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
 }
 
 // RetryFailedEvent handles POST /api/v1/admin/failed-events/:id/retry
@@ -80,17 +166,17 @@ func (h *AdminHandler) GetFailedEvents(c *fiber.Ctx) error {
 //	@Failure		401	{object}	dto.ErrorResponse
 //	@Failure		403	{object}	dto.ErrorResponse
 //	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
 //	@Failure		500	{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/admin/failed-events/{id}/retry [post]
 func (h *AdminHandler) RetryFailedEvent(c *fiber.Ctx) error {
-	if h.deadLetterProcessor == nil {
+	if h.failedEvents == nil {
 		return helper.NotFound(c, "Dead letter processor not available")
 	}
 
-	eventID := c.Params("id")
-	if err := h.deadLetterProcessor.RetryEvent(c.Context(), eventID); err != nil {
-		return helper.InternalError(c, "Failed to retry event")
+	if err := h.failedEvents.Retry(c.Context(), c.Params("id")); err != nil {
+		return h.handleFailedEventError(c, err, "Failed to retry event")
 	}
 
 	return helper.NoContent(c)
@@ -99,29 +185,110 @@ func (h *AdminHandler) RetryFailedEvent(c *fiber.Ctx) error {
 // IgnoreFailedEvent handles POST /api/v1/admin/failed-events/:id/ignore
 //
 //	@Summary		Ignore failed event
-//	@Description	Mark a failed event as ignored
+//	@Description	Mark a pending failed event as ignored
 //	@Tags			admin
 //	@Param			id	path	string	true	"Event ID"
 //	@Success		204
 //	@Failure		401	{object}	dto.ErrorResponse
 //	@Failure		403	{object}	dto.ErrorResponse
 //	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
 //	@Failure		500	{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/admin/failed-events/{id}/ignore [post]
 func (h *AdminHandler) IgnoreFailedEvent(c *fiber.Ctx) error {
-	if h.deadLetterProcessor == nil {
+	if h.failedEvents == nil {
 		return helper.NotFound(c, "Dead letter processor not available")
 	}
 
-	eventID := c.Params("id")
-	if err := h.deadLetterProcessor.IgnoreEvent(c.Context(), eventID); err != nil {
-		return helper.InternalError(c, "Failed to ignore event")
+	if err := h.failedEvents.Ignore(c.Context(), c.Params("id")); err != nil {
+		return h.handleFailedEventError(c, err, "Failed to ignore event")
 	}
 
 	return helper.NoContent(c)
 }
 
+// BulkRetryFailedEvents handles POST /api/v1/admin/failed-events/retry
+//
+//	@Summary		Retry failed events
+//	@Description	Retry every failed event matching the criteria; without a status only pending events are retried
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.BulkFailedEventsRequest	false	"Selection criteria"
+//	@Success		200		{object}	dto.BulkOperationResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Failure		500		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/failed-events/retry [post]
+func (h *AdminHandler) BulkRetryFailedEvents(c *fiber.Ctx) error {
+	return h.bulkFailedEvents(c, h.failedEvents.BulkRetry, "Failed to retry events")
+}
+
+// BulkIgnoreFailedEvents handles POST /api/v1/admin/failed-events/ignore
+//
+//	@Summary		Ignore failed events
+//	@Description	Mark every pending failed event matching the criteria as ignored
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.BulkFailedEventsRequest	false	"Selection criteria"
+//	@Success		200		{object}	dto.BulkOperationResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Failure		500		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/failed-events/ignore [post]
+func (h *AdminHandler) BulkIgnoreFailedEvents(c *fiber.Ctx) error {
+	return h.bulkFailedEvents(c, h.failedEvents.BulkIgnore, "Failed to ignore events")
+}
+
+// bulkFailedEvents parses a bulk selection and applies operation to it.
+func (h *AdminHandler) bulkFailedEvents(
+	c *fiber.Ctx,
+	operation func(context.Context, valueobject.FailedEventFilter, bool) (*service.BulkResult, error),
+	message string,
+) error {
+	if h.failedEvents == nil {
+		return helper.NotFound(c, "Dead letter processor not available")
+	}
+
+	var req dto.BulkFailedEventsRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return helper.BadRequest(c, "Invalid request body")
+		}
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	filter := valueobject.NewFailedEventFilter().WithEventType(req.EventType)
+	if len(req.EventIDs) > 0 {
+		filter = filter.WithEventIDs(req.EventIDs...)
+	}
+	filter = filter.WithStatuses(failedEventStatuses(req.Status)...)
+	if req.FromDate != nil {
+		filter = filter.WithFailedAfter(*req.FromDate)
+	}
+	if req.ToDate != nil {
+		filter = filter.WithFailedBefore(*req.ToDate)
+	}
+
+	result, err := operation(c.Context(), filter, req.DryRun)
+	if err != nil {
+		return h.handleFailedEventError(c, err, message)
+	}
+
+	return helper.Success(c, bulkResponse(result))
+}
+
 // GetEventMetrics handles GET /api/v1/admin/metrics/events
 //
 //	@Summary		Get event metrics
@@ -140,3 +307,368 @@ func (h *AdminHandler) GetEventMetrics(c *fiber.Ctx) error {
 
 	return helper.Success(c, h.eventWorker.GetMetrics())
 }
+
+// GetConsumerLag handles GET /api/v1/admin/event-bus/lag
+//
+//	@Summary		Get event bus consumer lag
+//	@Description	Report the backlog of every consumer group by stream: the events not yet delivered, those delivered but not acknowledged and the age of the oldest of them
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		dto.ConsumerLagResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/event-bus/lag [get]
+func (h *AdminHandler) GetConsumerLag(c *fiber.Ctx) error {
+	if h.lag == nil {
+		return helper.Success(c, []dto.ConsumerLagResponse{})
+	}
+
+	lags, err := h.lag.Lag(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to inspect consumer lag")
+		return helper.InternalError(c, "Failed to inspect consumer lag")
+	}
+
+	now := time.Now()
+	response := make([]dto.ConsumerLagResponse, 0, len(lags))
+	for _, lag := range lags {
+		item := dto.ConsumerLagResponse{
+			Stream:    lag.Stream,
+			Group:     lag.Group,
+			Consumers: lag.Consumers,
+			Length:    lag.Length,
+			Lag:       lag.Lag,
+			Pending:   lag.Pending,
+		}
+		if !lag.OldestPending.IsZero() {
+			oldest := lag.OldestPending
+			item.OldestPendingAt = &oldest
+			item.OldestPendingAgeSec = now.Sub(oldest).Seconds()
+		}
+		response = append(response, item)
+	}
+	return helper.Success(c, response)
+}
+
+// GetJobs handles GET /api/v1/admin/jobs
+//
+//	@Summary		List scheduled jobs
+//	@Description	Report the periodic jobs with their schedule, the next run of this instance and the last run by any instance
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		dto.JobResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/jobs [get]
+func (h *AdminHandler) GetJobs(c *fiber.Ctx) error {
+	if h.scheduler == nil {
+		return helper.Success(c, []dto.JobResponse{})
+	}
+
+	jobs := h.scheduler.Jobs(c.Context())
+	response := make([]dto.JobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		item := dto.JobResponse{
+			Name:     job.Name,
+			Schedule: job.Schedule,
+			Running:  job.Running,
+		}
+		if !job.NextRun.IsZero() {
+			next := job.NextRun.UTC()
+			item.NextRun = &next
+		}
+		if job.LastRun != nil {
+			item.LastRun = &dto.JobRunStats{
+				Instance:   job.LastRun.Instance,
+				StartedAt:  job.LastRun.StartedAt,
+				DurationMs: float64(job.LastRun.Duration) / float64(time.Millisecond),
+				Status:     job.LastRun.Status,
+				Error:      job.LastRun.Error,
+			}
+		}
+		response = append(response, item)
+	}
+
+	return helper.Success(c, response)
+}
+
+// GetSlowWebSocketClients handles GET /api/v1/admin/websocket/slow-clients
+//
+//	@Summary		Get slow WebSocket clients
+//	@Description	List the connected clients of this instance that dropped messages or whose send buffer is at least half full
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		dto.WebSocketClientStats
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/websocket/slow-clients [get]
+func (h *AdminHandler) GetSlowWebSocketClients(c *fiber.Ctx) error {
+	if h.slowClients == nil {
+		return helper.Success(c, []dto.WebSocketClientStats{})
+	}
+
+	slow := h.slowClients.SlowClients()
+	if slow == nil {
+		slow = []dto.WebSocketClientStats{}
+	}
+	return helper.Success(c, slow)
+}
+
+// GetPresence handles GET /api/v1/admin/presence
+//
+//	@Summary		Get connected users
+//	@Description	List the users connected to this instance over WebSocket or Server-Sent Events, those connected the longest first
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		dto.PresenceResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/presence [get]
+func (h *AdminHandler) GetPresence(c *fiber.Ctx) error {
+	if h.presence == nil {
+		return helper.Success(c, []dto.PresenceResponse{})
+	}
+
+	return helper.Success(c, h.presence.Presence())
+}
+
+// PurgeFailedEvents handles DELETE /api/v1/admin/failed-events
+//
+//	@Summary		Purge failed events
+//	@Description	Remove failed events from the dead letter queue, optionally only those matching the criteria
+//	@Tags			admin
+//	@Produce		json
+//	@Param			event_type	query		string		false	"Only purge events of this type"
+//	@Param			status		query		[]string	false	"Only purge events with this status"	Enums(pending, retried, ignored)
+//	@Param			from_date	query		string		false	"Failed on or after (RFC3339)"
+//	@Param			to_date		query		string		false	"Failed on or before (RFC3339)"
+//	@Param			dry_run		query		bool		false	"Report what would be purged without purging"
+//	@Success		200			{object}	dto.BulkOperationResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ValidationErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/failed-events [delete]
+func (h *AdminHandler) PurgeFailedEvents(c *fiber.Ctx) error {
+	if h.failedEvents == nil {
+		return helper.NotFound(c, "Dead letter processor not available")
+	}
+
+	var req dto.ListFailedEventsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid query parameters")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	filter, err := failedEventFilterFromRequest(req)
+	if err != nil {
+		return helper.BadRequest(c, "Dates must be RFC3339")
+	}
+
+	result, err := h.failedEvents.Purge(c.Context(), filter, c.QueryBool("dry_run"))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to purge failed events")
+		return helper.InternalError(c, "Failed to purge failed events")
+	}
+
+	return helper.Success(c, bulkResponse(result))
+}
+
+// handleFailedEventError maps failed event service errors to HTTP responses.
+func (h *AdminHandler) handleFailedEventError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrFailedEventNotFound):
+		return helper.NotFound(c, "Failed event not found")
+	case errors.Is(err, entity.ErrFailedEventAlreadyRetried),
+		errors.Is(err, entity.ErrFailedEventNotPending):
+		return helper.Conflict(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
+
+// BulkDeleteAlerts handles DELETE /api/v1/admin/alerts
+//
+//	@Summary		Bulk delete alerts
+//	@Description	Move every alert matching the filters to the trash. At least one filter is required.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			status		query		[]string	false	"Filter by status"
+//	@Param			severity	query		[]string	false	"Filter by severity"
+//	@Param			source		query		string		false	"Filter by source"
+//	@Param			region		query		string		false	"Filter by deployment region"
+//	@Param			search		query		string		false	"Search in title and message"
+//	@Param			from_date	query		string		false	"Created on or after (RFC3339)"
+//	@Param			to_date		query		string		false	"Created on or before (RFC3339)"
+//	@Param			dry_run		query		bool		false	"Report what would be deleted without deleting"
+//	@Success		200			{object}	dto.BulkOperationResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/alerts [delete]
+func (h *AdminHandler) BulkDeleteAlerts(c *fiber.Ctx) error {
+	var req dto.ListAlertsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid query parameters")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	result, err := h.alertService.BulkDelete(c.Context(), alertFilterFromRequest(req), c.QueryBool("dry_run"), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrBulkFilterRequired) {
+			return helper.BadRequest(c, "At least one filter is required")
+		}
+		log.Error().Err(err).Msg("Failed to bulk delete alerts")
+		return helper.InternalError(c, "Failed to delete alerts")
+	}
+
+	return helper.Success(c, bulkResponse(result))
+}
+
+// PurgeAlerts handles POST /api/v1/admin/alerts/purge
+//
+//	@Summary		Purge old alerts
+//	@Description	Move resolved and expired alerts older than the retention period to the trash
+//	@Tags			admin
+//	@Produce		json
+//	@Param			older_than_days	query		int		true	"Retention period in days"
+//	@Param			dry_run			query		bool	false	"Report what would be purged without purging"
+//	@Success		200				{object}	dto.BulkOperationResponse
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		422				{object}	dto.ValidationErrorResponse
+//	@Failure		500				{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/alerts/purge [post]
+func (h *AdminHandler) PurgeAlerts(c *fiber.Ctx) error {
+	var req dto.PurgeAlertsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid query parameters")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	before := time.Now().UTC().AddDate(0, 0, -req.OlderThanDays)
+	result, err := h.alertService.PurgeResolved(c.Context(), before, req.DryRun)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to purge alerts")
+		return helper.InternalError(c, "Failed to purge alerts")
+	}
+
+	return helper.Success(c, bulkResponse(result))
+}
+
+// ListTrash handles GET /api/v1/admin/alerts/trash
+//
+//	@Summary		List deleted alerts
+//	@Description	Retrieve the alerts in the trash, most recently deleted first, with optional filters. They can be restored until purged.
+//	@Tags			admin
+//	@Produce		json
+//	@Param			page		query		int			false	"Page number"	default(1)
+//	@Param			page_size	query		int			false	"Items per page, capped at the configured maximum"	default(20)
+//	@Param			status		query		[]string	false	"Filter by status"
+//	@Param			severity	query		[]string	false	"Filter by severity"
+//	@Param			source		query		string		false	"Filter by source"
+//	@Param			search		query		string		false	"Search in title and message"
+//	@Success		200			{object}	dto.PaginatedAlertResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		500			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/alerts/trash [get]
+func (h *AdminHandler) ListTrash(c *fiber.Ctx) error {
+	var req dto.ListAlertsRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid query parameters")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	pagination := h.pagination.Paginate(req.Page, req.PageSize)
+	result, err := h.alertService.ListDeleted(c.Context(), alertFilterFromRequest(req), pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list deleted alerts")
+		return helper.InternalError(c, "Failed to list deleted alerts")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.AlertResponse]{
+		Items:       dto.AlertsFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// bulkResponse converts a service bulk result into its API representation.
+func bulkResponse(result *service.BulkResult) dto.BulkOperationResponse {
+	return dto.BulkOperationResponse{
+		DryRun:    result.DryRun,
+		Affected:  result.Affected,
+		SampleIDs: result.SampleIDs,
+	}
+}
+
+// failedEventFilterFromRequest converts list query parameters into a failed event filter.
+func failedEventFilterFromRequest(req dto.ListFailedEventsRequest) (valueobject.FailedEventFilter, error) {
+	filter := valueobject.NewFailedEventFilter().
+		WithEventType(req.EventType).
+		WithStatuses(failedEventStatuses(req.Status)...)
+
+	if req.FromDate != "" {
+		from, err := time.Parse(time.RFC3339, req.FromDate)
+		if err != nil {
+			return filter, err
+		}
+		filter = filter.WithFailedAfter(from)
+	}
+
+	if req.ToDate != "" {
+		to, err := time.Parse(time.RFC3339, req.ToDate)
+		if err != nil {
+			return filter, err
+		}
+		filter = filter.WithFailedBefore(to)
+	}
+
+	return filter, nil
+}
+
+// failedEventStatuses converts status names to failed event statuses.
+func failedEventStatuses(names []string) []entity.FailedEventStatus {
+	statuses := make([]entity.FailedEventStatus, len(names))
+	for i, name := range names {
+		statuses[i] = entity.FailedEventStatus(name)
+	}
+	return statuses
+}
@@ -3,6 +3,7 @@ package handler
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -12,26 +13,34 @@ import (
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
-
-	"github.com/rs/zerolog/log"
 )
 
 // AlertHandler handles alert-related HTTP requests.
 type AlertHandler struct {
-	alertService *service.AlertService
+	alertService     *service.AlertService
+	messageMaxLength int
+	pagination       valueobject.PaginationPolicy
 }
 
 // NewAlertHandler creates a new alert handler.
-func NewAlertHandler(alertService *service.AlertService) *AlertHandler {
+// List responses truncate alert messages to messageMaxLength characters (0 disables)
+// and are paginated within the limits of the pagination policy.
+func NewAlertHandler(
+	alertService *service.AlertService,
+	messageMaxLength int,
+	pagination valueobject.PaginationPolicy,
+) *AlertHandler {
 	return &AlertHandler{
-		alertService: alertService,
+		alertService:     alertService,
+		messageMaxLength: messageMaxLength,
+		pagination:       pagination,
 	}
 }
 
 // Create handles POST /api/v1/alerts
 //
 //	@Summary		Create alert
-//	@Description	Create a new alert
+//	@Description	Create a new alert, optionally owned by one of the caller's teams
 //	@Tags			alerts
 //	@Accept			json
 //	@Produce		json
@@ -41,6 +50,7 @@ func NewAlertHandler(alertService *service.AlertService) *AlertHandler {
 //	@Failure		401		{object}	dto.ErrorResponse
 //	@Failure		403		{object}	dto.ErrorResponse
 //	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Failure		429		{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/alerts [post]
 func (h *AlertHandler) Create(c *fiber.Ctx) error {
@@ -54,6 +64,11 @@ func (h *AlertHandler) Create(c *fiber.Ctx) error {
 		return helper.ValidationErrors(c, errors)
 	}
 
+	teamID, ok := ownerTeam(c, req.TeamID)
+	if !ok {
+		return helper.Forbidden(c, "Not a member of the team")
+	}
+
 	// Create alert
 	input := service.CreateAlertInput{
 		Title:    req.Title,
@@ -61,27 +76,119 @@ func (h *AlertHandler) Create(c *fiber.Ctx) error {
 		Severity: entity.AlertSeverity(req.Severity),
 		Source:   req.Source,
 		Metadata: req.Metadata,
+		TeamID:   teamID,
 	}
 
 	alert, err := h.alertService.Create(c.Context(), input)
 	if err != nil {
-		return helper.InternalError(c, "Failed to create alert")
+		return RespondError(c, err, "Failed to create alert")
 	}
 
 	return helper.Created(c, dto.AlertFromEntity(alert))
 }
 
+// CreateBatch handles POST /api/v1/alerts/batch
+//
+//	@Summary		Create alerts in batch
+//	@Description	Create up to 500 alerts with a single request. With dry_run=true the batch is only validated.
+//	@Tags			alerts
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateAlertBatchRequest	true	"Alerts data"
+//	@Param			dry_run	query		bool						false	"Validate without storing"
+//	@Success		200		{object}	dto.AlertBatchResponse
+//	@Success		201		{object}	dto.AlertBatchResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Failure		429		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/alerts/batch [post]
+func (h *AlertHandler) CreateBatch(c *fiber.Ctx) error {
+	var req dto.CreateAlertBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	// Validate request
+	if errors := helper.ValidateStruct(req); len(errors) > 0 {
+		return helper.ValidationErrors(c, errors)
+	}
+
+	inputs := make([]service.CreateAlertInput, len(req.Alerts))
+	for i, a := range req.Alerts {
+		teamID, ok := ownerTeam(c, a.TeamID)
+		if !ok {
+			return helper.Forbidden(c, "Not a member of the team")
+		}
+
+		inputs[i] = service.CreateAlertInput{
+			Title:    a.Title,
+			Message:  a.Message,
+			Severity: entity.AlertSeverity(a.Severity),
+			Source:   a.Source,
+			Metadata: a.Metadata,
+			TeamID:   teamID,
+		}
+	}
+
+	if c.QueryBool("dry_run") {
+		alerts, err := h.alertService.ValidateBatch(inputs)
+		if err != nil {
+			return respondBatchError(c, err, "Failed to validate alerts")
+		}
+
+		return helper.Success(c, dto.AlertBatchResponse{
+			DryRun:  true,
+			Created: len(alerts),
+			Items:   dto.AlertsFromEntities(alerts),
+		})
+	}
+
+	alerts, err := h.alertService.CreateBatch(c.Context(), inputs)
+	if err != nil {
+		return respondBatchError(c, err, "Failed to create alerts")
+	}
+
+	return helper.Created(c, dto.AlertBatchResponse{
+		Created: len(alerts),
+		Items:   dto.AlertsFromEntities(alerts),
+	})
+}
+
+// respondBatchError reports each invalid alert of a batch as a validation
+// error of its index, and other errors through RespondError.
+func respondBatchError(c *fiber.Ctx, err error, fallback string) error {
+	var batchErr *service.BatchValidationError
+	if !errors.As(err, &batchErr) {
+		return RespondError(c, err, fallback)
+	}
+
+	fields := make([]helper.ValidationError, len(batchErr.Items))
+	for i, item := range batchErr.Items {
+		fields[i] = helper.ValidationError{
+			Field:   "alerts[" + strconv.Itoa(item.Index) + "]",
+			Rule:    "valid",
+			Message: item.Err.Error(),
+		}
+	}
+	return helper.ValidationErrors(c, fields)
+}
+
 // GetByID handles GET /api/v1/alerts/:id
 //
 //	@Summary		Get alert by ID
 //	@Description	Retrieve a specific alert
 //	@Tags			alerts
 //	@Produce		json
-//	@Param			id	path		string	true	"Alert ID"
-//	@Success		200	{object}	dto.AlertResponse
-//	@Failure		400	{object}	dto.ErrorResponse
-//	@Failure		401	{object}	dto.ErrorResponse
-//	@Failure		404	{object}	dto.ErrorResponse
+//	@Param			id				path		string	true	"Alert ID"
+//	@Param			If-None-Match	header		string	false	"ETag of the cached alert"
+//	@Success		200				{object}	dto.AlertResponse
+//	@Success		304				"Alert unchanged"
+//	@Failure		400				{object}	dto.ErrorResponse
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		404				{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/alerts/{id} [get]
 func (h *AlertHandler) GetByID(c *fiber.Ctx) error {
@@ -90,17 +197,75 @@ func (h *AlertHandler) GetByID(c *fiber.Ctx) error {
 		return helper.BadRequest(c, "Invalid alert ID")
 	}
 
-	alert, err := h.alertService.GetByID(c.Context(), id)
+	alert, err := h.alertService.GetVisible(c.Context(), id, userRole(c), tenantScope(c))
 	if err != nil {
-		if errors.Is(err, service.ErrAlertNotFound) {
-			return helper.NotFound(c, "Alert not found")
-		}
-		return helper.InternalError(c, "Failed to get alert")
+		return RespondError(c, err, "Failed to get alert")
+	}
+
+	if helper.NotModified(c, alertsETag([]*entity.Alert{alert})) {
+		return c.SendStatus(fiber.StatusNotModified)
 	}
 
 	return helper.Success(c, dto.AlertFromEntity(alert))
 }
 
+// Bounds of the related alerts view: the window around the alert, in
+// hours, and the number of related alerts and previous occurrences.
+const (
+	defaultRelatedWindowHours = 24
+	maxRelatedWindowHours     = 7 * 24
+	relatedAlertsLimit        = 20
+)
+
+// GetRelated handles GET /api/v1/alerts/:id/related
+//
+//	@Summary		Get related alerts
+//	@Description	Get an alert with the alerts sharing its fingerprint, source or rule raised around it, and the previous occurrences of its condition with their resolutions
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			id				path		string	true	"Alert ID"
+//	@Param			window_hours	query		int		false	"Hours before and after the alert to look for related alerts (max 168)"	default(24)
+//	@Success		200				{object}	dto.RelatedAlertsResponse
+//	@Failure		400				{object}	dto.ErrorResponse
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		404				{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/alerts/{id}/related [get]
+func (h *AlertHandler) GetRelated(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid alert ID")
+	}
+
+	windowHours := c.QueryInt("window_hours", defaultRelatedWindowHours)
+	if windowHours < 1 || windowHours > maxRelatedWindowHours {
+		return helper.BadRequest(c, "window_hours must be between 1 and 168")
+	}
+
+	result, err := h.alertService.GetRelated(c.Context(), id, userRole(c), tenantScope(c),
+		time.Duration(windowHours)*time.Hour, relatedAlertsLimit)
+	if err != nil {
+		return RespondError(c, err, "Failed to get related alerts")
+	}
+
+	response := dto.RelatedAlertsResponse{
+		Alert:               dto.AlertFromEntity(result.Alert),
+		Related:             make([]dto.RelatedAlertResponse, len(result.Related)),
+		PreviousOccurrences: make([]dto.AlertOccurrenceResponse, len(result.Occurrences)),
+	}
+	for i, related := range result.Related {
+		response.Related[i] = dto.RelatedAlertResponse{
+			AlertResponse: dto.AlertSummaryFromEntity(related.Alert, h.messageMaxLength),
+			RelatedBy:     related.Reasons,
+		}
+	}
+	for i, occurrence := range result.Occurrences {
+		response.PreviousOccurrences[i] = dto.AlertOccurrenceFromEntity(occurrence)
+	}
+
+	return helper.Success(c, response)
+}
+
 // List handles GET /api/v1/alerts
 //
 //	@Summary		List alerts
@@ -108,12 +273,16 @@ func (h *AlertHandler) GetByID(c *fiber.Ctx) error {
 //	@Tags			alerts
 //	@Produce		json
 //	@Param			page		query		int		false	"Page number"		default(1)
-//	@Param			page_size	query		int		false	"Items per page"	default(20)
+//	@Param			page_size	query		int		false	"Items per page, capped at the configured maximum"	default(20)
 //	@Param			status		query		[]string	false	"Filter by status"
 //	@Param			severity	query		[]string	false	"Filter by severity"
 //	@Param			source		query		string	false	"Filter by source"
+//	@Param			region		query		string	false	"Filter by deployment region"
+//	@Param			team_id		query		string	false	"Filter by owning team"
 //	@Param			search		query		string	false	"Search in title/message"
+//	@Param			If-None-Match	header	string	false	"ETag of the cached page"
 //	@Success		200			{object}	dto.PaginatedAlertResponse
+//	@Success		304			"Page unchanged"
 //	@Failure		401			{object}	dto.ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/alerts [get]
@@ -123,59 +292,32 @@ func (h *AlertHandler) List(c *fiber.Ctx) error {
 		return helper.BadRequest(c, "Invalid query parameters")
 	}
 
-	// Build filter
-	filter := valueobject.NewAlertFilter()
-
-	if len(req.Status) > 0 {
-		statuses := make([]entity.AlertStatus, len(req.Status))
-		for i, s := range req.Status {
-			statuses[i] = entity.AlertStatus(s)
-		}
-		filter = filter.WithStatuses(statuses...)
-	}
-
-	if len(req.Severity) > 0 {
-		severities := make([]entity.AlertSeverity, len(req.Severity))
-		for i, s := range req.Severity {
-			severities[i] = entity.AlertSeverity(s)
-		}
-		filter = filter.WithSeverities(severities...)
-	}
+	filter := alertFilterFromRequest(req)
 
-	if req.Source != "" {
-		filter = filter.WithSource(req.Source)
-	}
-
-	if req.Search != "" {
-		filter = filter.WithSearch(req.Search)
-	}
-
-	filter = applyDateFilter(filter, req.FromDate, req.ToDate)
-
-	// Build pagination
-	page := req.Page
-	if page < 1 {
-		page = 1
-	}
-	pageSize := req.PageSize
-	if pageSize < 1 {
-		pageSize = 20
-	}
-	pagination := valueobject.NewPagination(page, pageSize)
+	// Build pagination within the deployment's page size limits
+	pagination := h.pagination.Paginate(req.Page, req.PageSize)
 
 	// Get alerts
 	result, err := h.alertService.List(c.Context(), service.ListInput{
 		Filter:     filter,
 		Pagination: pagination,
+		Role:       userRole(c),
+		Tenant:     tenantScope(c),
 	})
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to create alert")
-		return helper.InternalError(c, "Failed to create alert")
+		return RespondError(c, err, "Failed to list alerts")
+	}
+
+	// The page changes with its alerts, and with the total when alerts are
+	// added or removed elsewhere
+	etag := alertsETag(result.Items, strconv.FormatInt(result.TotalItems, 10), strconv.Itoa(h.messageMaxLength))
+	if helper.NotModified(c, etag) {
+		return c.SendStatus(fiber.StatusNotModified)
 	}
 
 	// Build response
 	response := dto.PaginatedResponse[dto.AlertResponse]{
-		Items:       dto.AlertsFromEntities(result.Items),
+		Items:       dto.AlertSummariesFromEntities(result.Items, h.messageMaxLength),
 		TotalItems:  result.TotalItems,
 		TotalPages:  result.TotalPages,
 		CurrentPage: result.CurrentPage,
@@ -214,18 +356,14 @@ func (h *AlertHandler) Acknowledge(c *fiber.Ctx) error {
 		return helper.Unauthorized(c, "User not authenticated")
 	}
 
+	// Alerts hidden from the user's role or teams cannot be changed either
+	if _, err := h.alertService.GetVisible(c.Context(), alertID, userRole(c), tenantScope(c)); err != nil {
+		return RespondError(c, err, "Failed to acknowledge alert")
+	}
+
 	alert, err := h.alertService.Acknowledge(c.Context(), alertID, userID)
 	if err != nil {
-		if errors.Is(err, service.ErrAlertNotFound) {
-			return helper.NotFound(c, "Alert not found")
-		}
-		if errors.Is(err, entity.ErrAlertAlreadyAcknowledged) {
-			return helper.Conflict(c, "Alert is already acknowledged")
-		}
-		if errors.Is(err, entity.ErrAlertAlreadyResolved) {
-			return helper.Conflict(c, "Alert is already resolved")
-		}
-		return helper.InternalError(c, "Failed to acknowledge alert")
+		return RespondError(c, err, "Failed to acknowledge alert")
 	}
 
 	return helper.Success(c, dto.AlertFromEntity(alert))
@@ -258,15 +396,14 @@ func (h *AlertHandler) Resolve(c *fiber.Ctx) error {
 		return helper.Unauthorized(c, "User not authenticated")
 	}
 
+	// Alerts hidden from the user's role or teams cannot be changed either
+	if _, err := h.alertService.GetVisible(c.Context(), alertID, userRole(c), tenantScope(c)); err != nil {
+		return RespondError(c, err, "Failed to resolve alert")
+	}
+
 	alert, err := h.alertService.Resolve(c.Context(), alertID, userID)
 	if err != nil {
-		if errors.Is(err, service.ErrAlertNotFound) {
-			return helper.NotFound(c, "Alert not found")
-		}
-		if errors.Is(err, entity.ErrAlertAlreadyResolved) {
-			return helper.Conflict(c, "Alert is already resolved")
-		}
-		return helper.InternalError(c, "Failed to resolve alert")
+		return RespondError(c, err, "Failed to resolve alert")
 	}
 
 	return helper.Success(c, dto.AlertFromEntity(alert))
@@ -275,7 +412,7 @@ func (h *AlertHandler) Resolve(c *fiber.Ctx) error {
 // Delete handles DELETE /api/v1/alerts/:id
 //
 //	@Summary		Delete alert
-//	@Description	Remove an alert (admin only)
+//	@Description	Move an alert to the trash, from which it can be restored until purged (admin only)
 //	@Tags			alerts
 //	@Param			id	path	string	true	"Alert ID"
 //	@Success		204
@@ -298,20 +435,53 @@ func (h *AlertHandler) Delete(c *fiber.Ctx) error {
 	}
 
 	if err := h.alertService.Delete(c.Context(), id, userID); err != nil {
+		return RespondError(c, err, "Failed to delete alert")
+	}
+
+	return helper.NoContent(c)
+}
+
+// Restore handles POST /api/v1/alerts/:id/restore
+//
+//	@Summary		Restore alert
+//	@Description	Take a deleted alert out of the trash (admin only)
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			id	path		string	true	"Alert ID"
+//	@Success		200	{object}	dto.AlertResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/alerts/{id}/restore [post]
+func (h *AlertHandler) Restore(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid alert ID")
+	}
+
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User ID not found in context")
+	}
+
+	alert, err := h.alertService.Restore(c.Context(), id, userID)
+	if err != nil {
 		if errors.Is(err, service.ErrAlertNotFound) {
-			return helper.NotFound(c, "Alert not found")
+			return helper.Error(c, fiber.StatusNotFound, "Alert not found in trash", "ALERT_NOT_FOUND")
 		}
-		log.Error().Err(err).Msg("Failed to delete alert")
-		return helper.InternalError(c, "Failed to delete alert")
+		return RespondError(c, err, "Failed to restore alert")
 	}
 
-	return helper.NoContent(c)
+	return helper.Success(c, dto.AlertFromEntity(alert))
 }
 
 // GetStatistics handles GET /api/v1/alerts/statistics
 //
 //	@Summary		Get alert statistics
-//	@Description	Retrieve aggregated alert statistics
+//	@Description	Retrieve aggregated statistics of the alerts visible to the caller
 //	@Tags			alerts
 //	@Produce		json
 //	@Success		200	{object}	dto.AlertStatisticsResponse
@@ -319,10 +489,9 @@ func (h *AlertHandler) Delete(c *fiber.Ctx) error {
 //	@Security		BearerAuth
 //	@Router			/alerts/statistics [get]
 func (h *AlertHandler) GetStatistics(c *fiber.Ctx) error {
-	stats, err := h.alertService.GetStatistics(c.Context())
+	stats, err := h.alertService.GetStatistics(c.Context(), userRole(c), tenantScope(c))
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get statistics")
-		return helper.InternalError(c, "Failed to get statistics")
+		return RespondError(c, err, "Failed to get statistics")
 	}
 
 	response := dto.AlertStatisticsResponse{
@@ -337,6 +506,55 @@ func (h *AlertHandler) GetStatistics(c *fiber.Ctx) error {
 	return helper.Success(c, response)
 }
 
+// alertsETag returns the entity tag of a response holding alerts, from their
+// IDs and update times and any other input of the response.
+func alertsETag(alerts []*entity.Alert, extra ...string) string {
+	versions := make([]string, 0, len(alerts)+len(extra))
+	for _, alert := range alerts {
+		versions = append(versions, alert.ID.String()+"@"+strconv.FormatInt(alert.UpdatedAt.UnixNano(), 10))
+	}
+	return helper.WeakETag(append(versions, extra...)...)
+}
+
+// alertFilterFromRequest builds an alert filter from list query parameters.
+func alertFilterFromRequest(req dto.ListAlertsRequest) valueobject.AlertFilter {
+	filter := valueobject.NewAlertFilter()
+
+	if len(req.Status) > 0 {
+		statuses := make([]entity.AlertStatus, len(req.Status))
+		for i, s := range req.Status {
+			statuses[i] = entity.AlertStatus(s)
+		}
+		filter = filter.WithStatuses(statuses...)
+	}
+
+	if len(req.Severity) > 0 {
+		severities := make([]entity.AlertSeverity, len(req.Severity))
+		for i, s := range req.Severity {
+			severities[i] = entity.AlertSeverity(s)
+		}
+		filter = filter.WithSeverities(severities...)
+	}
+
+	if req.Source != "" {
+		filter = filter.WithSource(req.Source)
+	}
+
+	if req.Region != "" {
+		filter = filter.WithRegion(req.Region)
+	}
+
+	if teamID, err := entity.ParseID(req.TeamID); err == nil {
+		filter = filter.WithTeamID(teamID)
+	}
+
+	if req.Search != "" {
+		filter = filter.WithSearch(req.Search)
+	}
+
+	return applyDateFilter(filter, req.FromDate, req.ToDate)
+}
+
 // applyDateFilter applies date range filter if valid dates are provided.
 func applyDateFilter(filter valueobject.AlertFilter, fromDate, toDate string) valueobject.AlertFilter {
 	if fromDate == "" {
@@ -359,3 +577,31 @@ func applyDateFilter(filter valueobject.AlertFilter, fromDate, toDate string) va
 
 	return filter.WithDateRange(from, to)
 }
+
+// userRole returns the role of the authenticated user (set by auth middleware).
+func userRole(c *fiber.Ctx) entity.UserRole {
+	role, _ := c.Locals("userRole").(string)
+	return entity.UserRole(role)
+}
+
+// tenantScope returns the teams the authenticated user may access (set by tenant middleware).
+// Requests without a resolved scope only access data not owned by any team.
+func tenantScope(c *fiber.Ctx) valueobject.TenantScope {
+	scope, _ := c.Locals("tenant").(valueobject.TenantScope)
+	return scope
+}
+
+// ownerTeam parses the team a new alert is created for and reports whether
+// the caller may create alerts for it. An empty value creates a shared alert.
+func ownerTeam(c *fiber.Ctx, raw string) (*entity.ID, bool) {
+	if raw == "" {
+		return nil, true
+	}
+
+	teamID, err := entity.ParseID(raw)
+	if err != nil || !tenantScope(c).Includes(teamID) {
+		return nil, false
+	}
+
+	return &teamID, true
+}
@@ -0,0 +1,296 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// AlertViewHandler handles saved alert views.
+type AlertViewHandler struct {
+	viewService      *service.AlertViewService
+	messageMaxLength int
+	pagination       valueobject.PaginationPolicy
+}
+
+// NewAlertViewHandler creates a new alert view handler. Alert messages are
+// truncated to messageMaxLength characters like in alert lists.
+func NewAlertViewHandler(
+	viewService *service.AlertViewService,
+	messageMaxLength int,
+	pagination valueobject.PaginationPolicy,
+) *AlertViewHandler {
+	return &AlertViewHandler{
+		viewService:      viewService,
+		messageMaxLength: messageMaxLength,
+		pagination:       pagination,
+	}
+}
+
+// Create handles POST /api/v1/views
+//
+//	@Summary		Save alert view
+//	@Description	Save a named combination of alert filters, private or shared with one of the caller's teams. Subscribe to the WebSocket channel view:{id} to stream its alerts.
+//	@Tags			views
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateAlertViewRequest	true	"View data"
+//	@Success		201		{object}	dto.AlertViewResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/views [post]
+func (h *AlertViewHandler) Create(c *fiber.Ctx) error {
+	actor, ok := ruleActor(c)
+	if !ok {
+		return helper.Unauthorized(c, "User ID not found in context")
+	}
+
+	var req dto.CreateAlertViewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	teamID, ok := ownerTeam(c, req.TeamID)
+	if !ok {
+		return helper.Forbidden(c, "Not a member of the team")
+	}
+
+	view, err := h.viewService.Create(c.Context(), service.AlertViewInput{
+		Name:     req.Name,
+		Criteria: req.Criteria.ToEntity(),
+		TeamID:   teamID,
+	}, actor.UserID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to save view")
+	}
+
+	return helper.Created(c, dto.AlertViewFromEntity(view))
+}
+
+// List handles GET /api/v1/views
+//
+//	@Summary		List alert views
+//	@Description	Retrieve the caller's views and those shared with their teams
+//	@Tags			views
+//	@Produce		json
+//	@Success		200	{array}		dto.AlertViewResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/views [get]
+func (h *AlertViewHandler) List(c *fiber.Ctx) error {
+	actor, ok := ruleActor(c)
+	if !ok {
+		return helper.Unauthorized(c, "User ID not found in context")
+	}
+
+	views, err := h.viewService.List(c.Context(), actor.UserID, tenantScope(c))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list views")
+		return helper.InternalError(c, "Failed to list views")
+	}
+
+	return helper.Success(c, dto.AlertViewsFromEntities(views))
+}
+
+// GetByID handles GET /api/v1/views/:id
+//
+//	@Summary		Get alert view
+//	@Description	Retrieve a view owned by the caller or shared with their teams
+//	@Tags			views
+//	@Produce		json
+//	@Param			id	path		string	true	"View ID"
+//	@Success		200	{object}	dto.AlertViewResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/views/{id} [get]
+func (h *AlertViewHandler) GetByID(c *fiber.Ctx) error {
+	actor, ok := ruleActor(c)
+	if !ok {
+		return helper.Unauthorized(c, "User ID not found in context")
+	}
+
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid view ID")
+	}
+
+	view, err := h.viewService.Get(c.Context(), id, actor.UserID, tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to get view")
+	}
+
+	return helper.Success(c, dto.AlertViewFromEntity(view))
+}
+
+// Alerts handles GET /api/v1/views/:id/alerts
+//
+//	@Summary		List the alerts of a view
+//	@Description	Retrieve the alerts matching a view, newest first
+//	@Tags			views
+//	@Produce		json
+//	@Param			id			path		string	true	"View ID"
+//	@Param			page		query		int		false	"Page number"		default(1)
+//	@Param			page_size	query		int		false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.AlertViewAlertsResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/views/{id}/alerts [get]
+func (h *AlertViewHandler) Alerts(c *fiber.Ctx) error {
+	actor, ok := ruleActor(c)
+	if !ok {
+		return helper.Unauthorized(c, "User ID not found in context")
+	}
+
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid view ID")
+	}
+
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	view, result, err := h.viewService.ListAlerts(c.Context(), id, actor, tenantScope(c), pagination)
+	if err != nil {
+		return h.handleError(c, err, "Failed to list view alerts")
+	}
+
+	return helper.Success(c, dto.AlertViewAlertsResponse{
+		View: dto.AlertViewFromEntity(view),
+		Alerts: dto.PaginatedResponse[dto.AlertResponse]{
+			Items:       dto.AlertSummariesFromEntities(result.Items, h.messageMaxLength),
+			TotalItems:  result.TotalItems,
+			TotalPages:  result.TotalPages,
+			CurrentPage: result.CurrentPage,
+			PageSize:    result.PageSize,
+			HasNext:     result.HasNext,
+			HasPrevious: result.HasPrevious,
+		},
+	})
+}
+
+// Update handles PATCH /api/v1/views/:id
+//
+//	@Summary		Update alert view
+//	@Description	Rename a view, change its filters, or share it with one of the caller's teams; an empty team_id makes it private (owner or admin)
+//	@Tags			views
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"View ID"
+//	@Param			request	body		dto.UpdateAlertViewRequest	true	"Changes"
+//	@Success		200		{object}	dto.AlertViewResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/views/{id} [patch]
+func (h *AlertViewHandler) Update(c *fiber.Ctx) error {
+	actor, ok := ruleActor(c)
+	if !ok {
+		return helper.Unauthorized(c, "User ID not found in context")
+	}
+
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid view ID")
+	}
+
+	var req dto.UpdateAlertViewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	update := service.AlertViewUpdate{Name: req.Name}
+	if req.Criteria != nil {
+		criteria := req.Criteria.ToEntity()
+		update.Criteria = &criteria
+	}
+	if req.TeamID != nil {
+		if *req.TeamID == "" {
+			update.Unshare = true
+		} else {
+			teamID, ok := ownerTeam(c, *req.TeamID)
+			if !ok {
+				return helper.Forbidden(c, "Not a member of the team")
+			}
+			update.TeamID = teamID
+		}
+	}
+
+	view, err := h.viewService.Update(c.Context(), id, update, actor, tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to update view")
+	}
+
+	return helper.Success(c, dto.AlertViewFromEntity(view))
+}
+
+// Delete handles DELETE /api/v1/views/:id
+//
+//	@Summary		Delete alert view
+//	@Description	Remove a view (owner or admin)
+//	@Tags			views
+//	@Param			id	path	string	true	"View ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/views/{id} [delete]
+func (h *AlertViewHandler) Delete(c *fiber.Ctx) error {
+	actor, ok := ruleActor(c)
+	if !ok {
+		return helper.Unauthorized(c, "User ID not found in context")
+	}
+
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid view ID")
+	}
+
+	if err := h.viewService.Delete(c.Context(), id, actor, tenantScope(c)); err != nil {
+		return h.handleError(c, err, "Failed to delete view")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps alert view service errors to HTTP responses.
+func (h *AlertViewHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrAlertViewNotFound):
+		return helper.NotFound(c, "View not found")
+	case errors.Is(err, service.ErrAlertViewForbidden):
+		return helper.Forbidden(c, err.Error())
+	case errors.Is(err, service.ErrTeamNotFound),
+		errors.Is(err, entity.ErrAlertViewNameRequired),
+		errors.Is(err, entity.ErrAlertViewNameTooLong),
+		errors.Is(err, entity.ErrAlertViewInvalidStatus),
+		errors.Is(err, entity.ErrAlertViewInvalidSeverity),
+		errors.Is(err, entity.ErrAlertViewInvalidLabels),
+		errors.Is(err, entity.ErrAlertViewSearchTooLong):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// AssignmentRuleHandler handles the rules assigning new alerts to teams and users.
+type AssignmentRuleHandler struct {
+	ruleService *service.AssignmentRuleService
+}
+
+// NewAssignmentRuleHandler creates a new assignment rule handler.
+func NewAssignmentRuleHandler(ruleService *service.AssignmentRuleService) *AssignmentRuleHandler {
+	return &AssignmentRuleHandler{ruleService: ruleService}
+}
+
+// Create handles POST /api/v1/assignment-rules
+//
+//	@Summary		Create assignment rule
+//	@Description	Create a rule assigning the alerts it matches by source and labels to a team, a user or both when they are ingested. Rules are evaluated by position and the first matching enabled rule applies.
+//	@Tags			assignment-rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateAssignmentRuleRequest	true	"Rule data"
+//	@Success		201		{object}	dto.AssignmentRuleResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/assignment-rules [post]
+func (h *AssignmentRuleHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateAssignmentRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	input := service.AssignmentRuleInput{
+		Name:     req.Name,
+		Position: req.Position,
+		Match:    req.Match.ToEntity(),
+	}
+	if req.TeamID != "" {
+		teamID, err := entity.ParseID(req.TeamID)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid team ID")
+		}
+		input.TeamID = &teamID
+	}
+	if req.UserID != "" {
+		userID, err := entity.ParseID(req.UserID)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid user ID")
+		}
+		input.UserID = &userID
+	}
+
+	var createdBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &userID
+	}
+
+	rule, err := h.ruleService.Create(c.Context(), input, createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create assignment rule")
+	}
+
+	return helper.Created(c, dto.AssignmentRuleFromEntity(rule))
+}
+
+// List handles GET /api/v1/assignment-rules
+//
+//	@Summary		List assignment rules
+//	@Description	Retrieve every assignment rule in evaluation order
+//	@Tags			assignment-rules
+//	@Produce		json
+//	@Success		200	{array}		dto.AssignmentRuleResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/assignment-rules [get]
+func (h *AssignmentRuleHandler) List(c *fiber.Ctx) error {
+	rules, err := h.ruleService.List(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list assignment rules")
+		return helper.InternalError(c, "Failed to list assignment rules")
+	}
+
+	return helper.Success(c, dto.AssignmentRulesFromEntities(rules))
+}
+
+// GetByID handles GET /api/v1/assignment-rules/:id
+//
+//	@Summary		Get assignment rule
+//	@Description	Retrieve an assignment rule
+//	@Tags			assignment-rules
+//	@Produce		json
+//	@Param			id	path		string	true	"Assignment rule ID"
+//	@Success		200	{object}	dto.AssignmentRuleResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/assignment-rules/{id} [get]
+func (h *AssignmentRuleHandler) GetByID(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid assignment rule ID")
+	}
+
+	rule, err := h.ruleService.Get(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get assignment rule")
+	}
+
+	return helper.Success(c, dto.AssignmentRuleFromEntity(rule))
+}
+
+// Update handles PATCH /api/v1/assignment-rules/:id
+//
+//	@Summary		Update assignment rule
+//	@Description	Change, reorder, enable or disable an assignment rule; an empty team_id or user_id stops assigning to a team or user
+//	@Tags			assignment-rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Assignment rule ID"
+//	@Param			request	body		dto.UpdateAssignmentRuleRequest	true	"Changes"
+//	@Success		200		{object}	dto.AssignmentRuleResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/assignment-rules/{id} [patch]
+func (h *AssignmentRuleHandler) Update(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid assignment rule ID")
+	}
+
+	var req dto.UpdateAssignmentRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	update := service.AssignmentRuleUpdate{
+		Name:      req.Name,
+		Position:  req.Position,
+		IsEnabled: req.IsEnabled,
+	}
+	if req.Match != nil {
+		match := req.Match.ToEntity()
+		update.Match = &match
+	}
+	if req.TeamID != nil {
+		if *req.TeamID == "" {
+			update.ClearTeam = true
+		} else {
+			teamID, err := entity.ParseID(*req.TeamID)
+			if err != nil {
+				return helper.BadRequest(c, "Invalid team ID")
+			}
+			update.TeamID = &teamID
+		}
+	}
+	if req.UserID != nil {
+		if *req.UserID == "" {
+			update.ClearUser = true
+		} else {
+			userID, err := entity.ParseID(*req.UserID)
+			if err != nil {
+				return helper.BadRequest(c, "Invalid user ID")
+			}
+			update.UserID = &userID
+		}
+	}
+
+	rule, err := h.ruleService.Update(c.Context(), id, update)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update assignment rule")
+	}
+
+	return helper.Success(c, dto.AssignmentRuleFromEntity(rule))
+}
+
+// Delete handles DELETE /api/v1/assignment-rules/:id
+//
+//	@Summary		Delete assignment rule
+//	@Description	Remove an assignment rule
+//	@Tags			assignment-rules
+//	@Param			id	path	string	true	"Assignment rule ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/assignment-rules/{id} [delete]
+func (h *AssignmentRuleHandler) Delete(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid assignment rule ID")
+	}
+
+	if err := h.ruleService.Delete(c.Context(), id); err != nil {
+		return h.handleError(c, err, "Failed to delete assignment rule")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps assignment rule service errors to HTTP responses.
+func (h *AssignmentRuleHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrAssignmentRuleNotFound):
+		return helper.NotFound(c, "Assignment rule not found")
+	case errors.Is(err, service.ErrAssignmentRuleExists):
+		return helper.Conflict(c, "Assignment rule with this name already exists")
+	case errors.Is(err, service.ErrAssignmentRuleAssigneeNotFound),
+		errors.Is(err, entity.ErrAssignmentRuleNameRequired),
+		errors.Is(err, entity.ErrAssignmentRuleNameTooLong),
+		errors.Is(err, entity.ErrAssignmentRuleMatchRequired),
+		errors.Is(err, entity.ErrAssignmentRuleInvalidLabels),
+		errors.Is(err, entity.ErrAssignmentRuleAssigneeRequired):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -2,23 +2,29 @@ package handler
 
 import (
 	"errors"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
 )
 
 // AuthHandler handles authentication-related HTTP requests.
 type AuthHandler struct {
-	authService *service.AuthService
+	authService   *service.AuthService
+	digestService *service.DigestService
 }
 
 // NewAuthHandler creates a new auth handler.
-func NewAuthHandler(authService *service.AuthService) *AuthHandler {
+func NewAuthHandler(authService *service.AuthService, digestService *service.DigestService) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:   authService,
+		digestService: digestService,
 	}
 }
 
@@ -34,6 +40,7 @@ func NewAuthHandler(authService *service.AuthService) *AuthHandler {
 //	@Failure		400		{object}	dto.ErrorResponse
 //	@Failure		401		{object}	dto.ErrorResponse
 //	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Failure		423		{object}	dto.ErrorResponse	"Account locked after repeated failed logins"
 //	@Router			/auth/login [post]
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var req dto.LoginRequest
@@ -47,7 +54,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	}
 
 	// Authenticate
-	tokens, user, err := h.authService.Login(c.Context(), req.Email, req.Password)
+	tokens, user, err := h.authService.Login(c.Context(), req.Email, req.Password, clientInfo(c))
 	if err != nil {
 		if errors.Is(err, service.ErrInvalidCredentials) {
 			return helper.Unauthorized(c, "Invalid email or password")
@@ -55,6 +62,11 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		if errors.Is(err, service.ErrUserNotActive) {
 			return helper.Forbidden(c, "Account is deactivated")
 		}
+		var lockedErr *service.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(int64(time.Until(lockedErr.Until).Seconds())+1, 10))
+			return helper.Error(c, fiber.StatusLocked, "Account is locked after too many failed logins, please try again later", "ACCOUNT_LOCKED")
+		}
 		return helper.InternalError(c, "Authentication failed")
 	}
 
@@ -65,6 +77,15 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		User:         dto.UserFromEntity(user),
 	}
 
+	// Deliver events missed while the user was away
+	if h.digestService != nil {
+		digest, err := h.digestService.Drain(c.Context(), user.ID)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to load missed events digest")
+		}
+		response.Digest = digest
+	}
+
 	return helper.Success(c, response)
 }
 
@@ -93,7 +114,7 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	}
 
 	// Register user
-	tokens, user, err := h.authService.Register(c.Context(), req.Email, req.Password, req.Name)
+	tokens, user, err := h.authService.Register(c.Context(), req.Email, req.Password, req.Name, clientInfo(c))
 	if err != nil {
 		if errors.Is(err, service.ErrUserAlreadyExists) {
 			return helper.Conflict(c, "Email already registered")
@@ -134,7 +155,7 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	}
 
 	// Refresh tokens
-	tokens, err := h.authService.RefreshToken(c.Context(), req.RefreshToken)
+	tokens, err := h.authService.RefreshToken(c.Context(), req.RefreshToken, clientInfo(c))
 	if err != nil {
 		if errors.Is(err, service.ErrTokenExpired) {
 			return helper.Unauthorized(c, "Refresh token has expired")
@@ -202,3 +223,172 @@ func (h *AuthHandler) Me(c *fiber.Ctx) error {
 
 	return helper.Success(c, user)
 }
+
+// Sessions handles GET /api/v1/auth/sessions
+//
+//	@Summary		List sessions
+//	@Description	List the active sessions of the current user. Admins may pass user_id to review another user's sessions.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			user_id	query		string	false	"User ID (admin only)"
+//	@Success		200		{array}		dto.SessionResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/auth/sessions [get]
+func (h *AuthHandler) Sessions(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	if raw := c.Query("user_id"); raw != "" {
+		if userRole(c) != entity.UserRoleAdmin {
+			return helper.Forbidden(c, "Insufficient permissions")
+		}
+		id, err := entity.ParseID(raw)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid user ID")
+		}
+		userID = id
+	}
+
+	sessions, err := h.authService.ListSessions(c.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list sessions")
+		return helper.InternalError(c, "Failed to list sessions")
+	}
+
+	currentID, _ := c.Locals("sessionID").(string)
+	return helper.Success(c, dto.SessionsFromEntities(sessions, currentID))
+}
+
+// RevokeSession handles DELETE /api/v1/auth/sessions/:id
+//
+//	@Summary		Revoke session
+//	@Description	End a session and invalidate its tokens. Users may revoke their own sessions; admins may revoke any.
+//	@Tags			auth
+//	@Param			id	path	string	true	"Session ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	sessionID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid session ID")
+	}
+
+	asAdmin := userRole(c) == entity.UserRoleAdmin
+	if err := h.authService.RevokeSession(c.Context(), userID, sessionID, asAdmin); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			return helper.NotFound(c, "Session not found")
+		}
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to revoke session")
+		return helper.InternalError(c, "Failed to revoke session")
+	}
+
+	return helper.NoContent(c)
+}
+
+// UnlockUser handles POST /api/v1/admin/users/:id/unlock
+//
+//	@Summary		Unlock user
+//	@Description	Lift the lockout of an account locked after repeated failed logins
+//	@Tags			admin
+//	@Param			id	path	string	true	"User ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/users/{id}/unlock [post]
+func (h *AuthHandler) UnlockUser(c *fiber.Ctx) error {
+	adminID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	userID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid user ID")
+	}
+
+	if err := h.authService.Unlock(c.Context(), userID, adminID); err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			return helper.NotFound(c, "User not found")
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to unlock user")
+		return helper.InternalError(c, "Failed to unlock user")
+	}
+
+	return helper.NoContent(c)
+}
+
+// UpdateUser handles PATCH /api/v1/admin/users/:id
+//
+//	@Summary		Update user
+//	@Description	Change the name, role or active state of a user. Deactivated users are refused with their existing tokens within seconds.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"User ID"
+//	@Param			request	body		dto.UpdateUserRequest	true	"Fields to change"
+//	@Success		200		{object}	dto.UserResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/users/{id} [patch]
+func (h *AuthHandler) UpdateUser(c *fiber.Ctx) error {
+	adminID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	userID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid user ID")
+	}
+
+	var req dto.UpdateUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	user, err := h.authService.UpdateUser(c.Context(), userID, req.Name, req.Role, req.IsActive, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			return helper.NotFound(c, "User not found")
+		case errors.Is(err, entity.ErrUserInvalidRole):
+			return helper.BadRequest(c, err.Error())
+		}
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to update user")
+		return helper.InternalError(c, "Failed to update user")
+	}
+
+	return helper.Success(c, dto.UserFromEntity(user))
+}
+
+// clientInfo describes the client of a request for session tracking.
+func clientInfo(c *fiber.Ctx) service.ClientInfo {
+	return service.ClientInfo{
+		Device: c.Get(fiber.HeaderUserAgent),
+		IP:     c.IP(),
+	}
+}
@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// Check defaults applied when the request leaves them out.
+const (
+	defaultCheckExpectedStatus   = http.StatusOK
+	defaultCheckTimeoutSeconds   = 10
+	defaultCheckFailureThreshold = 1
+)
+
+// CheckHandler handles synthetic uptime checks.
+type CheckHandler struct {
+	checkService *service.CheckService
+	pagination   valueobject.PaginationPolicy
+}
+
+// NewCheckHandler creates a new check handler.
+func NewCheckHandler(checkService *service.CheckService, pagination valueobject.PaginationPolicy) *CheckHandler {
+	return &CheckHandler{
+		checkService: checkService,
+		pagination:   pagination,
+	}
+}
+
+// Create handles POST /api/v1/checks
+//
+//	@Summary		Create uptime check
+//	@Description	Create a synthetic HTTP check (operator or admin). The URL, which must resolve to a public address, is probed every interval; an alert is raised once failure_threshold consecutive probes fail, and resolved by the next success. Redirects are not followed.
+//	@Tags			checks
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateCheckRequest	true	"Check data"
+//	@Success		201		{object}	dto.CheckResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/checks [post]
+func (h *CheckHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateCheckRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	input := service.CheckInput{
+		Name:             req.Name,
+		URL:              req.URL,
+		Method:           fiber.MethodGet,
+		ExpectedStatus:   defaultCheckExpectedStatus,
+		IntervalSeconds:  req.IntervalSeconds,
+		TimeoutSeconds:   defaultCheckTimeoutSeconds,
+		FailureThreshold: defaultCheckFailureThreshold,
+		Severity:         entity.AlertSeverityHigh,
+	}
+	if req.Method != "" {
+		input.Method = req.Method
+	}
+	if req.ExpectedStatus != 0 {
+		input.ExpectedStatus = req.ExpectedStatus
+	}
+	if req.TimeoutSeconds != 0 {
+		input.TimeoutSeconds = req.TimeoutSeconds
+	}
+	if req.FailureThreshold != 0 {
+		input.FailureThreshold = req.FailureThreshold
+	}
+	if req.Severity != "" {
+		input.Severity = entity.AlertSeverity(req.Severity)
+	}
+	if req.TeamID != "" {
+		teamID, err := entity.ParseID(req.TeamID)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid team ID")
+		}
+		if !tenantScope(c).Includes(teamID) {
+			return helper.Forbidden(c, "Not a member of the team")
+		}
+		input.TeamID = &teamID
+	}
+
+	var createdBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &userID
+	}
+
+	check, err := h.checkService.Create(c.Context(), input, createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create check")
+	}
+
+	return helper.Created(c, dto.CheckFromEntity(check))
+}
+
+// List handles GET /api/v1/checks
+//
+//	@Summary		List uptime checks
+//	@Description	Retrieve the uptime checks of the caller's teams and shared checks, with their current status
+//	@Tags			checks
+//	@Produce		json
+//	@Param			page		query		int	false	"Page number"		default(1)
+//	@Param			page_size	query		int	false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.CheckResponse]
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/checks [get]
+func (h *CheckHandler) List(c *fiber.Ctx) error {
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.checkService.List(c.Context(), tenantScope(c), pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list checks")
+		return helper.InternalError(c, "Failed to list checks")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.CheckResponse]{
+		Items:       dto.ChecksFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// GetByID handles GET /api/v1/checks/:id
+//
+//	@Summary		Get uptime check
+//	@Description	Retrieve an uptime check
+//	@Tags			checks
+//	@Produce		json
+//	@Param			id	path		string	true	"Check ID"
+//	@Success		200	{object}	dto.CheckResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/checks/{id} [get]
+func (h *CheckHandler) GetByID(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid check ID")
+	}
+
+	check, err := h.checkService.GetByID(c.Context(), id, tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to get check")
+	}
+
+	return helper.Success(c, dto.CheckFromEntity(check))
+}
+
+// History handles GET /api/v1/checks/:id/history
+//
+//	@Summary		Get uptime check history
+//	@Description	Retrieve the probe results of a check with their latency, newest first
+//	@Tags			checks
+//	@Produce		json
+//	@Param			id			path		string	true	"Check ID"
+//	@Param			page		query		int		false	"Page number"		default(1)
+//	@Param			page_size	query		int		false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.CheckResultResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/checks/{id}/history [get]
+func (h *CheckHandler) History(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid check ID")
+	}
+
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.checkService.History(c.Context(), id, tenantScope(c), pagination)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get check history")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.CheckResultResponse]{
+		Items:       dto.CheckResultsFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// Update handles PATCH /api/v1/checks/:id
+//
+//	@Summary		Update uptime check
+//	@Description	Change the settings of a check, or pause it by disabling it (operator or admin)
+//	@Tags			checks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Check ID"
+//	@Param			request	body		dto.UpdateCheckRequest	true	"Changes"
+//	@Success		200		{object}	dto.CheckResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/checks/{id} [patch]
+func (h *CheckHandler) Update(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid check ID")
+	}
+
+	var req dto.UpdateCheckRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	update := service.CheckUpdate{
+		Name:             req.Name,
+		URL:              req.URL,
+		Method:           req.Method,
+		ExpectedStatus:   req.ExpectedStatus,
+		IntervalSeconds:  req.IntervalSeconds,
+		TimeoutSeconds:   req.TimeoutSeconds,
+		FailureThreshold: req.FailureThreshold,
+		IsEnabled:        req.IsEnabled,
+	}
+	if req.Severity != nil {
+		severity := entity.AlertSeverity(*req.Severity)
+		update.Severity = &severity
+	}
+
+	check, err := h.checkService.Update(c.Context(), id, tenantScope(c), update)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update check")
+	}
+
+	return helper.Success(c, dto.CheckFromEntity(check))
+}
+
+// Delete handles DELETE /api/v1/checks/:id
+//
+//	@Summary		Delete uptime check
+//	@Description	Remove a check and its history (operator or admin)
+//	@Tags			checks
+//	@Param			id	path	string	true	"Check ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/checks/{id} [delete]
+func (h *CheckHandler) Delete(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid check ID")
+	}
+
+	if err := h.checkService.Delete(c.Context(), id, tenantScope(c)); err != nil {
+		return h.handleError(c, err, "Failed to delete check")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps check service errors to HTTP responses.
+func (h *CheckHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrCheckNotFound):
+		return helper.NotFound(c, "Check not found")
+	case errors.Is(err, service.ErrCheckExists):
+		return helper.Conflict(c, "Check with this name already exists")
+	case errors.Is(err, service.ErrTeamNotFound),
+		errors.Is(err, entity.ErrCheckNameRequired),
+		errors.Is(err, entity.ErrCheckNameTooLong),
+		errors.Is(err, entity.ErrCheckInvalidURL),
+		errors.Is(err, entity.ErrCheckInvalidMethod),
+		errors.Is(err, entity.ErrCheckInvalidStatus),
+		errors.Is(err, entity.ErrCheckInvalidInterval),
+		errors.Is(err, entity.ErrCheckInvalidTimeout),
+		errors.Is(err, entity.ErrCheckInvalidThreshold),
+		errors.Is(err, entity.ErrCheckInvalidSeverity):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// DashboardHandler handles the dashboard summary.
+type DashboardHandler struct {
+	dashboardService *service.DashboardService
+	messageMaxLength int
+}
+
+// NewDashboardHandler creates a new dashboard handler. Alert messages are
+// truncated to messageMaxLength characters like in alert lists.
+func NewDashboardHandler(dashboardService *service.DashboardService, messageMaxLength int) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+		messageMaxLength: messageMaxLength,
+	}
+}
+
+// Get handles GET /api/v1/dashboard
+//
+//	@Summary		Get dashboard summary
+//	@Description	Retrieve in one call the active alerts by severity, the 5 sources raising the most alerts in the last 24 hours, the latest alerts, the number of open incidents and their commanders. The summary is cached for 15 seconds.
+//	@Tags			dashboard
+//	@Produce		json
+//	@Success		200	{object}	dto.DashboardResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/dashboard [get]
+func (h *DashboardHandler) Get(c *fiber.Ctx) error {
+	summary, err := h.dashboardService.Summary(c.Context(), userRole(c), tenantScope(c))
+	if err != nil {
+		return RespondError(c, err, "Failed to get dashboard")
+	}
+
+	response := dto.DashboardResponse{
+		ActiveAlerts:     summary.ActiveAlerts,
+		ActiveBySeverity: summary.ActiveBySeverity,
+		NoisySources:     make([]dto.SourceCountResponse, len(summary.NoisySources)),
+		RecentAlerts:     dto.AlertSummariesFromEntities(summary.RecentAlerts, h.messageMaxLength),
+		OpenIncidents:    summary.OpenIncidents,
+		OnCall:           make([]dto.OnCallResponse, len(summary.OnCall)),
+		GeneratedAt:      summary.GeneratedAt,
+	}
+
+	for i, source := range summary.NoisySources {
+		response.NoisySources[i] = dto.SourceCountResponse{Source: source.Source, Count: source.Count}
+	}
+
+	for i, responder := range summary.OnCall {
+		incidentIDs := make([]string, len(responder.IncidentIDs))
+		for j, id := range responder.IncidentIDs {
+			incidentIDs[j] = id.String()
+		}
+		response.OnCall[i] = dto.OnCallResponse{
+			UserID:      responder.User.ID.String(),
+			Name:        responder.User.Name,
+			Email:       responder.User.Email,
+			IncidentIDs: incidentIDs,
+		}
+	}
+
+	return helper.Success(c, response)
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// DiagnosticsHandler handles the runtime diagnostics of the instance.
+// Profiles and goroutine dumps are served by the pprof middleware.
+type DiagnosticsHandler struct {
+	startedAt time.Time
+}
+
+// NewDiagnosticsHandler creates a new diagnostics handler. The uptime it
+// reports starts now.
+func NewDiagnosticsHandler() *DiagnosticsHandler {
+	return &DiagnosticsHandler{startedAt: time.Now().UTC()}
+}
+
+// GetRuntimeStats handles GET /api/v1/admin/runtime
+//
+//	@Summary		Get runtime statistics
+//	@Description	Report the goroutines, memory and garbage collector statistics of the instance serving the request
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	dto.RuntimeStatsResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/runtime [get]
+func (h *DiagnosticsHandler) GetRuntimeStats(c *fiber.Ctx) error {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	gc := dto.GCStats{
+		NumGC:        mem.NumGC,
+		PauseTotalMs: float64(mem.PauseTotalNs) / float64(time.Millisecond),
+		NextGCBytes:  mem.NextGC,
+		CPUFraction:  mem.GCCPUFraction,
+	}
+	if mem.NumGC > 0 {
+		lastGC := time.Unix(0, int64(mem.LastGC)).UTC()
+		gc.LastGC = &lastGC
+		gc.LastPauseMs = float64(mem.PauseNs[(mem.NumGC+255)%256]) / float64(time.Millisecond)
+	}
+
+	return helper.Success(c, dto.RuntimeStatsResponse{
+		GoVersion:     runtime.Version(),
+		StartedAt:     h.startedAt,
+		UptimeSeconds: time.Since(h.startedAt).Seconds(),
+		Goroutines:    runtime.NumGoroutine(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		NumCPU:        runtime.NumCPU(),
+		Memory: dto.MemoryStats{
+			HeapAlloc:    mem.HeapAlloc,
+			HeapInuse:    mem.HeapInuse,
+			HeapIdle:     mem.HeapIdle,
+			HeapReleased: mem.HeapReleased,
+			HeapObjects:  mem.HeapObjects,
+			StackInuse:   mem.StackInuse,
+			Sys:          mem.Sys,
+			TotalAlloc:   mem.TotalAlloc,
+		},
+		GC: gc,
+	})
+}
+
+// GetLogLevel handles GET /api/v1/admin/log-level
+//
+//	@Summary		Get log level
+//	@Description	Get the log level of the instance serving the request
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	dto.LogLevelResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/log-level [get]
+func (h *DiagnosticsHandler) GetLogLevel(c *fiber.Ctx) error {
+	return helper.Success(c, dto.LogLevelResponse{Level: zerolog.GlobalLevel().String()})
+}
+
+// SetLogLevel handles PUT /api/v1/admin/log-level
+//
+//	@Summary		Set log level
+//	@Description	Change the log level of the instance serving the request until it restarts. Other instances keep their level.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.LogLevelRequest	true	"Log level"
+//	@Success		200		{object}	dto.LogLevelResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/log-level [put]
+func (h *DiagnosticsHandler) SetLogLevel(c *fiber.Ctx) error {
+	var req dto.LogLevelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	level, err := zerolog.ParseLevel(req.Level)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid log level")
+	}
+
+	previous := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(level)
+
+	// Logged at warn or above so the change is recorded at most levels
+	userID, _ := c.Locals("userID").(entity.ID)
+	log.WithLevel(max(level, zerolog.WarnLevel)).
+		Str("previous_level", previous.String()).
+		Str("new_level", level.String()).
+		Str("changed_by", userID.String()).
+		Msg("Log level changed")
+
+	return helper.Success(c, dto.LogLevelResponse{Level: level.String()})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// errorMapping is the problem a domain or service error is reported as.
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+	detail string
+}
+
+// errorMappings are the errors handlers report through RespondError, matched
+// in order with errors.Is.
+var errorMappings = []errorMapping{
+	{service.ErrAlertNotFound, fiber.StatusNotFound, "ALERT_NOT_FOUND", "Alert not found"},
+	{service.ErrAlertTeamNotFound, fiber.StatusBadRequest, "TEAM_NOT_FOUND", "Team not found"},
+	{service.ErrAlertDuplicateOpen, fiber.StatusConflict, "ALERT_DUPLICATE_OPEN", "An open alert of the team has the same dedup key"},
+	{service.ErrQuotaExceeded, fiber.StatusTooManyRequests, "QUOTA_EXCEEDED", ""},
+	{service.ErrBulkFilterRequired, fiber.StatusBadRequest, "BULK_FILTER_REQUIRED", "At least one filter is required"},
+	{entity.ErrAlertAlreadyAcknowledged, fiber.StatusConflict, "ALERT_ALREADY_ACKNOWLEDGED", "Alert is already acknowledged"},
+	{entity.ErrAlertAlreadyResolved, fiber.StatusConflict, "ALERT_ALREADY_RESOLVED", "Alert is already resolved"},
+	{entity.ErrAlertNotActive, fiber.StatusConflict, "ALERT_NOT_ACTIVE", "Alert is not active"},
+	{entity.ErrAlertTitleRequired, fiber.StatusUnprocessableEntity, "ALERT_TITLE_REQUIRED", ""},
+	{entity.ErrAlertTitleTooLong, fiber.StatusUnprocessableEntity, "ALERT_TITLE_TOO_LONG", ""},
+	{entity.ErrAlertMessageRequired, fiber.StatusUnprocessableEntity, "ALERT_MESSAGE_REQUIRED", ""},
+	{entity.ErrAlertInvalidSeverity, fiber.StatusUnprocessableEntity, "ALERT_INVALID_SEVERITY", ""},
+	{entity.ErrAlertInvalidStatus, fiber.StatusUnprocessableEntity, "ALERT_INVALID_STATUS", ""},
+	{service.ErrTicketsNotConfigured, fiber.StatusNotFound, "TICKETS_NOT_CONFIGURED", "Issue tracker is not configured"},
+	{service.ErrTicketExists, fiber.StatusConflict, "TICKET_EXISTS", "A ticket was already filed for this alert"},
+	{service.ErrTicketTrackerFailed, fiber.StatusBadGateway, "TICKET_TRACKER_FAILED", ""},
+	{service.ErrStatusPageNotConfigured, fiber.StatusNotFound, "STATUS_PAGE_NOT_CONFIGURED", "Status page is not configured"},
+	{service.ErrStatusPageComponentNotFound, fiber.StatusNotFound, "COMPONENT_NOT_FOUND", "Status page component not found"},
+	{service.ErrStatusPageUpdateFailed, fiber.StatusBadGateway, "STATUS_PAGE_UPDATE_FAILED", ""},
+	{valueobject.ErrComponentStatusInvalid, fiber.StatusUnprocessableEntity, "COMPONENT_STATUS_INVALID", ""},
+}
+
+// RespondError sends the problem an error maps to; mappings without a detail
+// report the error's own message. Unmapped errors are logged
+// and reported as internal errors with the fallback detail, so that their
+// messages do not leak.
+func RespondError(c *fiber.Ctx, err error, fallback string) error {
+	var quotaErr *service.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		status := quotaErr.Status
+		helper.SetQuotaHeaders(c, status.Limit, status.Remaining(), status.ResetAt)
+		c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(int64(time.Until(status.ResetAt).Seconds())+1, 10))
+	}
+
+	for _, m := range errorMappings {
+		if errors.Is(err, m.err) {
+			detail := m.detail
+			if detail == "" {
+				detail = err.Error()
+			}
+			code := m.code
+			if helper.APIVersion(c) < 2 {
+				// v1 clients only know the generic codes
+				code = helper.StatusCode(m.status)
+			}
+			return helper.Error(c, m.status, detail, code)
+		}
+	}
+
+	log.Error().Err(err).Str("path", c.Path()).Msg(fallback)
+	return helper.InternalError(c, fallback)
+}
+
+// ErrorHandler reports the errors returned through the middleware chain, such
+// as unknown routes, as problems.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		return helper.Error(c, fe.Code, fe.Message, helper.StatusCode(fe.Code))
+	}
+	return RespondError(c, err, "Internal server error")
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// EventReplayHandler handles event replay administration.
+type EventReplayHandler struct {
+	replayService *service.EventReplayService
+}
+
+// NewEventReplayHandler creates a new event replay handler.
+func NewEventReplayHandler(replayService *service.EventReplayService) *EventReplayHandler {
+	return &EventReplayHandler{replayService: replayService}
+}
+
+// Replay handles POST /api/v1/admin/events/replay
+//
+//	@Summary		Replay events
+//	@Description	Re-dispatch the events published to a stream within a time range to every consumer of the stream, e.g. to recover downstream systems after an outage
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.ReplayEventsRequest	true	"Replay selection"
+//	@Success		200		{object}	dto.ReplayEventsResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Failure		500		{object}	dto.ErrorResponse
+//	@Failure		501		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/events/replay [post]
+func (h *EventReplayHandler) Replay(c *fiber.Ctx) error {
+	var req dto.ReplayEventsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	input := service.ReplayInput{
+		Stream: req.Stream,
+		From:   req.From,
+		Limit:  req.Limit,
+		DryRun: req.DryRun,
+	}
+	if req.To != nil {
+		input.To = *req.To
+	}
+	for _, t := range req.EventTypes {
+		input.Types = append(input.Types, event.Type(t))
+	}
+
+	result, err := h.replayService.Replay(c.Context(), input)
+	if err != nil {
+		return h.handleError(c, err, "Failed to replay events")
+	}
+
+	return helper.Success(c, dto.ReplayEventsResponse{
+		DryRun:    result.DryRun,
+		Replayed:  result.Replayed,
+		Truncated: result.Truncated,
+		SampleIDs: result.SampleIDs,
+	})
+}
+
+// handleError maps event replay service errors to HTTP responses.
+func (h *EventReplayHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrReplayUnavailable):
+		return helper.Error(c, fiber.StatusNotImplemented, err.Error(), "NOT_IMPLEMENTED")
+	case errors.Is(err, service.ErrReplayStreamInvalid),
+		errors.Is(err, service.ErrReplayRangeInvalid):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// FeatureHandler handles feature flag administration.
+type FeatureHandler struct {
+	flags *service.FeatureFlagService
+}
+
+// NewFeatureHandler creates a new feature flag handler.
+func NewFeatureHandler(flags *service.FeatureFlagService) *FeatureHandler {
+	return &FeatureHandler{flags: flags}
+}
+
+// List handles GET /api/v1/admin/features
+//
+//	@Summary		List feature flags
+//	@Description	Evaluate every feature flag for the deployment, or for an organization
+//	@Tags			admin
+//	@Produce		json
+//	@Param			org_id	query		string	false	"Organization ID"
+//	@Success		200		{array}		dto.FeatureFlagResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/features [get]
+func (h *FeatureHandler) List(c *fiber.Ctx) error {
+	orgID := c.Query("org_id")
+
+	flags, err := h.flags.List(c.Context(), orgID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to list feature flags")
+	}
+
+	return helper.Success(c, dto.FeatureFlagsFromValues(flags, orgID))
+}
+
+// Get handles GET /api/v1/admin/features/:name
+//
+//	@Summary		Get feature flag
+//	@Description	Evaluate a feature flag for the deployment, or for an organization
+//	@Tags			admin
+//	@Produce		json
+//	@Param			name	path		string	true	"Feature name"
+//	@Param			org_id	query		string	false	"Organization ID"
+//	@Success		200		{object}	dto.FeatureFlagResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/features/{name} [get]
+func (h *FeatureHandler) Get(c *fiber.Ctx) error {
+	orgID := c.Query("org_id")
+
+	flag, err := h.flags.Get(c.Context(), c.Params("name"), orgID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get feature flag")
+	}
+
+	return helper.Success(c, dto.FeatureFlagFromValue(flag, orgID))
+}
+
+// Set handles PUT /api/v1/admin/features/:name
+//
+//	@Summary		Override feature flag
+//	@Description	Enable or disable a feature at runtime for the deployment, or for one organization
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string						true	"Feature name"
+//	@Param			request	body		dto.SetFeatureFlagRequest	true	"Flag state"
+//	@Success		200		{object}	dto.FeatureFlagResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/features/{name} [put]
+func (h *FeatureHandler) Set(c *fiber.Ctx) error {
+	var req dto.SetFeatureFlagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	flag, err := h.flags.Set(c.Context(), c.Params("name"), req.OrgID, *req.Enabled)
+	if err != nil {
+		return h.handleError(c, err, "Failed to set feature flag")
+	}
+
+	return helper.Success(c, dto.FeatureFlagFromValue(flag, req.OrgID))
+}
+
+// Reset handles DELETE /api/v1/admin/features/:name
+//
+//	@Summary		Remove feature flag override
+//	@Description	Remove a runtime override so the flag falls back to the deployment or configured state
+//	@Tags			admin
+//	@Produce		json
+//	@Param			name	path		string	true	"Feature name"
+//	@Param			org_id	query		string	false	"Organization ID"
+//	@Success		200		{object}	dto.FeatureFlagResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/features/{name} [delete]
+func (h *FeatureHandler) Reset(c *fiber.Ctx) error {
+	orgID := c.Query("org_id")
+
+	flag, err := h.flags.Reset(c.Context(), c.Params("name"), orgID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to reset feature flag")
+	}
+
+	return helper.Success(c, dto.FeatureFlagFromValue(flag, orgID))
+}
+
+// handleError maps feature flag service errors to HTTP responses.
+func (h *FeatureHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrFeatureNotFound):
+		return helper.NotFound(c, "Feature flag not found")
+	case errors.Is(err, service.ErrFeatureOrgInvalid):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -0,0 +1,549 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/graphql"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// alertEventsChannel is the hub channel carrying every alert event.
+const alertEventsChannel = "alerts"
+
+// Subscription stream timings.
+const (
+	subscriptionKeepAlive = 30 * time.Second
+	subscriptionWriteWait = 10 * time.Second
+)
+
+// RuleLister lists the alert rules accessible within a tenant scope.
+type RuleLister interface {
+	ListForTenant(ctx context.Context, tenant valueobject.TenantScope, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.AlertRule], error)
+}
+
+// UserLister lists the users of the system.
+type UserLister interface {
+	List(ctx context.Context, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.User], error)
+	ListByRole(ctx context.Context, role entity.UserRole, pagination valueobject.Pagination) (*valueobject.PaginatedResult[*entity.User], error)
+}
+
+// EventListener follows the real-time messages a user may receive.
+type EventListener interface {
+	Listen(ctx context.Context, userID *entity.ID, role string, tenant valueobject.TenantScope, channels []string) (<-chan []byte, error)
+}
+
+// GraphQLHandler serves the GraphQL API used by dashboards to fetch exactly
+// the alert, rule, statistics and user fields they need.
+type GraphQLHandler struct {
+	alertService     *service.AlertService
+	rules            RuleLister
+	users            UserLister
+	events           EventListener
+	messageMaxLength int
+	pagination       valueobject.PaginationPolicy
+	schema           *graphql.Schema
+}
+
+// NewGraphQLHandler creates a new GraphQL handler. Alert lists truncate
+// messages to messageMaxLength characters (0 disables) and lists are
+// paginated within the limits of the pagination policy.
+func NewGraphQLHandler(
+	alertService *service.AlertService,
+	rules RuleLister,
+	users UserLister,
+	messageMaxLength int,
+	pagination valueobject.PaginationPolicy,
+) *GraphQLHandler {
+	h := &GraphQLHandler{
+		alertService:     alertService,
+		rules:            rules,
+		users:            users,
+		messageMaxLength: messageMaxLength,
+		pagination:       pagination,
+	}
+	h.schema = h.buildSchema()
+	return h
+}
+
+// SetEventListener enables subscriptions, which follow the events of listener.
+func (h *GraphQLHandler) SetEventListener(listener EventListener) {
+	h.events = listener
+}
+
+// graphqlViewer is the caller of a GraphQL request.
+type graphqlViewer struct {
+	userID *entity.ID
+	role   entity.UserRole
+	tenant valueobject.TenantScope
+	user   *dto.UserResponse
+}
+
+// graphqlViewerKey is the context key of the caller of a GraphQL request.
+type graphqlViewerKey struct{}
+
+// viewer returns the caller of a GraphQL request.
+func viewer(ctx context.Context) graphqlViewer {
+	v, _ := ctx.Value(graphqlViewerKey{}).(graphqlViewer)
+	return v
+}
+
+// buildSchema defines the types and fields of the GraphQL API. Fields mirror
+// the JSON responses of the REST API, in camel case.
+func (h *GraphQLHandler) buildSchema() *graphql.Schema {
+	alertType := graphql.NewObject("Alert",
+		"id", "rule_id", "team_id", "title", "message", "message_truncated", "severity", "status",
+		"source", "region", "cluster", "metadata", "flapping", "downstream", "runbook_url", "remediation",
+		"ticket_key", "acknowledged_by", "acknowledged_at", "resolved_by", "resolved_at", "expires_at", "created_at", "updated_at")
+
+	conditionType := graphql.NewObject("RuleCondition",
+		"type", "metric", "operator", "threshold", "deviations", "window_seconds", "for_seconds", "consecutive")
+	conditionType.With("conditions", &graphql.Field{Key: "conditions", Type: conditionType})
+
+	ruleType := graphql.NewObject("Rule",
+		"id", "name", "description", "severity", "is_enabled", "cooldown_minutes",
+		"runbook_url", "remediation", "create_ticket", "created_by", "team_id", "created_at", "updated_at").
+		With("condition", &graphql.Field{
+			Key:  "condition",
+			Type: conditionType,
+		})
+
+	statisticsType := graphql.NewObject("Statistics",
+		"total_alerts", "active_alerts", "acknowledged_alerts", "resolved_alerts", "by_severity", "by_source")
+
+	userType := graphql.NewObject("User",
+		"id", "email", "name", "role", "is_active", "last_login_at", "created_at", "updated_at")
+
+	alertEventType := graphql.NewObject("AlertEvent", "type", "alert_id", "timestamp").
+		With("alert", &graphql.Field{Key: "alert", Type: alertType})
+
+	pagination := []string{"page", "pageSize"}
+
+	return &graphql.Schema{
+		Query: &graphql.Object{
+			Name: "Query",
+			Fields: map[string]*graphql.Field{
+				"alerts": {
+					Type: pageType("AlertPage", alertType),
+					Args: append([]string{
+						"status", "severity", "source", "region", "teamId", "search", "fromDate", "toDate",
+					}, pagination...),
+					Resolve: h.resolveAlerts,
+				},
+				"alert": {
+					Type:    alertType,
+					Args:    []string{"id"},
+					Resolve: h.resolveAlert,
+				},
+				"rules": {
+					Type:    pageType("RulePage", ruleType),
+					Args:    pagination,
+					Resolve: h.resolveRules,
+				},
+				"statistics": {
+					Type:    statisticsType,
+					Resolve: h.resolveStatistics,
+				},
+				"users": {
+					Type:    pageType("UserPage", userType),
+					Args:    append([]string{"role"}, pagination...),
+					Resolve: h.resolveUsers,
+				},
+				"me": {
+					Type:    userType,
+					Resolve: h.resolveMe,
+				},
+			},
+		},
+		Subscription: &graphql.Object{
+			Name: "Subscription",
+			Fields: map[string]*graphql.Field{
+				"alertEvents": {
+					Type:      alertEventType,
+					Args:      []string{"severity", "source", "teamId"},
+					Subscribe: h.subscribeAlertEvents,
+				},
+			},
+		},
+	}
+}
+
+// pageType defines a page of items, matching dto.PaginatedResponse.
+func pageType(name string, item *graphql.Object) *graphql.Object {
+	return graphql.NewObject(name,
+		"total_items", "total_pages", "current_page", "page_size", "has_next", "has_previous").
+		With("items", &graphql.Field{Key: "items", Type: item})
+}
+
+// Serve handles GET and POST /api/v1/graphql
+//
+//	@Summary		GraphQL API
+//	@Description	Run a GraphQL query (alerts, alert, rules, statistics, users, me) with field selection. Subscriptions (alertEvents) are streamed as Server-Sent Events. Fragments, mutations and introspection are not supported.
+//	@Tags			graphql
+//	@Accept			json
+//	@Produce		json
+//	@Param			request			body		graphql.Request	false	"GraphQL request (POST)"
+//	@Param			query			query		string			false	"GraphQL document (GET)"
+//	@Param			operationName	query		string			false	"Operation to run (GET)"
+//	@Param			variables		query		string			false	"JSON encoded variables (GET)"
+//	@Success		200				{object}	graphql.Response
+//	@Failure		400				{object}	graphql.Response
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/graphql [post]
+func (h *GraphQLHandler) Serve(c *fiber.Ctx) error {
+	req, err := graphqlRequest(c)
+	if err != nil {
+		return graphqlError(c, err)
+	}
+
+	op, err := graphql.Prepare(h.schema, req)
+	if err != nil {
+		return graphqlError(c, err)
+	}
+
+	v := graphqlViewer{role: userRole(c), tenant: tenantScope(c)}
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		v.userID = &userID
+	}
+	v.user, _ = c.Locals("user").(*dto.UserResponse)
+
+	if op.IsSubscription() {
+		return h.stream(c, op, v)
+	}
+
+	ctx := context.WithValue(c.Context(), graphqlViewerKey{}, v)
+	return c.JSON(op.Execute(ctx))
+}
+
+// graphqlRequest reads a GraphQL request from the query string (GET) or the JSON body.
+func graphqlRequest(c *fiber.Ctx) (graphql.Request, error) {
+	var req graphql.Request
+
+	if c.Method() == fiber.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return req, errors.New("variables must be a JSON object")
+			}
+		}
+	} else if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return req, errors.New("request body must be a JSON object with a query")
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return req, errors.New("query is required")
+	}
+	return req, nil
+}
+
+// graphqlError responds with a request error.
+func graphqlError(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(graphql.Response{
+		Errors: []*graphql.Error{{Message: err.Error()}},
+	})
+}
+
+// stream serves a subscription as Server-Sent Events, one response per event.
+func (h *GraphQLHandler) stream(c *fiber.Ctx, op *graphql.Operation, v graphqlViewer) error {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), graphqlViewerKey{}, v))
+
+	responses, err := op.Subscribe(ctx)
+	if err != nil {
+		cancel()
+		return graphqlError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	conn := c.Context().Conn()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ticker := time.NewTicker(subscriptionKeepAlive)
+		defer func() {
+			ticker.Stop()
+			cancel()
+		}()
+
+		// Send the headers right away
+		_, _ = w.WriteString(": subscribed\n\n")
+
+		for {
+			_ = conn.SetWriteDeadline(time.Now().Add(subscriptionWriteWait))
+			if err := w.Flush(); err != nil {
+				return
+			}
+
+			select {
+			case response, ok := <-responses:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(response)
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to marshal GraphQL subscription response")
+					continue
+				}
+				_, _ = fmt.Fprintf(w, "event: next\ndata: %s\n\n", data)
+
+			case <-ticker.C:
+				_, _ = w.WriteString(": ping\n\n")
+			}
+		}
+	})
+
+	return nil
+}
+
+// resolveAlerts lists the alerts visible to the caller, with the filters of GET /alerts.
+func (h *GraphQLHandler) resolveAlerts(ctx context.Context, args graphql.Args) (interface{}, error) {
+	req := dto.ListAlertsRequest{
+		Page:     args.Int("page"),
+		PageSize: args.Int("pageSize"),
+		Status:   args.Strings("status"),
+		Severity: args.Strings("severity"),
+		Source:   args.String("source"),
+		Region:   args.String("region"),
+		TeamID:   args.String("teamId"),
+		Search:   args.String("search"),
+		FromDate: args.String("fromDate"),
+		ToDate:   args.String("toDate"),
+	}
+	if errs := helper.ValidateStruct(req); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid argument %s: %s", errs[0].Field, errs[0].Message)
+	}
+
+	v := viewer(ctx)
+	result, err := h.alertService.List(ctx, service.ListInput{
+		Filter:     alertFilterFromRequest(req),
+		Pagination: h.pagination.Paginate(req.Page, req.PageSize),
+		Role:       v.role,
+		Tenant:     v.tenant,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list alerts")
+		return nil, errors.New("failed to list alerts")
+	}
+
+	return paginatedResponse(result, dto.AlertSummariesFromEntities(result.Items, h.messageMaxLength)), nil
+}
+
+// resolveAlert returns an alert visible to the caller.
+func (h *GraphQLHandler) resolveAlert(ctx context.Context, args graphql.Args) (interface{}, error) {
+	alertID, err := entity.ParseID(args.String("id"))
+	if err != nil {
+		return nil, errors.New("invalid alert ID")
+	}
+
+	v := viewer(ctx)
+	alert, err := h.alertService.GetVisible(ctx, alertID, v.role, v.tenant)
+	if err != nil {
+		if errors.Is(err, service.ErrAlertNotFound) {
+			return nil, errors.New("alert not found")
+		}
+		log.Error().Err(err).Msg("Failed to get alert")
+		return nil, errors.New("failed to get alert")
+	}
+
+	return dto.AlertFromEntity(alert), nil
+}
+
+// resolveRules lists the alert rules accessible to the caller.
+func (h *GraphQLHandler) resolveRules(ctx context.Context, args graphql.Args) (interface{}, error) {
+	if h.rules == nil {
+		return nil, errors.New("rules are not available")
+	}
+
+	pagination := h.pagination.Paginate(args.Int("page"), args.Int("pageSize"))
+	result, err := h.rules.ListForTenant(ctx, viewer(ctx).tenant, pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list rules")
+		return nil, errors.New("failed to list rules")
+	}
+
+	return paginatedResponse(result, result.Items), nil
+}
+
+// resolveStatistics returns the statistics of the alerts visible to the caller.
+func (h *GraphQLHandler) resolveStatistics(ctx context.Context, _ graphql.Args) (interface{}, error) {
+	v := viewer(ctx)
+	stats, err := h.alertService.GetStatistics(ctx, v.role, v.tenant)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get statistics")
+		return nil, errors.New("failed to get statistics")
+	}
+
+	return dto.AlertStatisticsResponse{
+		TotalAlerts:        stats.TotalAlerts,
+		ActiveAlerts:       stats.ActiveAlerts,
+		AcknowledgedAlerts: stats.AcknowledgedAlerts,
+		ResolvedAlerts:     stats.ResolvedAlerts,
+		BySeverity:         stats.BySeverity,
+		BySource:           stats.BySource,
+	}, nil
+}
+
+// resolveUsers lists users, optionally of a role. Admin only.
+func (h *GraphQLHandler) resolveUsers(ctx context.Context, args graphql.Args) (interface{}, error) {
+	if viewer(ctx).role != entity.UserRoleAdmin {
+		return nil, errors.New("admin access required")
+	}
+	if h.users == nil {
+		return nil, errors.New("users are not available")
+	}
+
+	pagination := h.pagination.Paginate(args.Int("page"), args.Int("pageSize"))
+
+	var result *valueobject.PaginatedResult[*entity.User]
+	var err error
+	if role := entity.UserRole(args.String("role")); role != "" {
+		if !role.IsValid() {
+			return nil, errors.New("invalid role")
+		}
+		result, err = h.users.ListByRole(ctx, role, pagination)
+	} else {
+		result, err = h.users.List(ctx, pagination)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list users")
+		return nil, errors.New("failed to list users")
+	}
+
+	return paginatedResponse(result, dto.UsersFromEntities(result.Items)), nil
+}
+
+// resolveMe returns the caller.
+func (h *GraphQLHandler) resolveMe(ctx context.Context, _ graphql.Args) (interface{}, error) {
+	user := viewer(ctx).user
+	if user == nil {
+		return nil, errors.New("user not authenticated")
+	}
+	return user, nil
+}
+
+// alertEvent is an alert event as delivered to subscriptions.
+type alertEvent struct {
+	Type      string             `json:"type"`
+	AlertID   string             `json:"alert_id"`
+	Alert     *dto.AlertResponse `json:"alert,omitempty"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// subscribeAlertEvents streams the alert events visible to the caller that
+// match the severity, source and team arguments. Deletions carry no alert
+// and are always delivered.
+func (h *GraphQLHandler) subscribeAlertEvents(ctx context.Context, args graphql.Args) (<-chan interface{}, error) {
+	if h.events == nil {
+		return nil, errors.New("subscriptions are not available")
+	}
+
+	severities := args.Strings("severity")
+	source := args.String("source")
+	teamID := args.String("teamId")
+
+	v := viewer(ctx)
+	messages, err := h.events.Listen(ctx, v.userID, string(v.role), v.tenant, []string{alertEventsChannel})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan interface{})
+	go func() {
+		defer close(events)
+
+		for data := range messages {
+			event, ok := parseAlertEvent(data)
+			if !ok {
+				continue
+			}
+			if a := event.Alert; a != nil {
+				if len(severities) > 0 && !containsString(severities, a.Severity) {
+					continue
+				}
+				if source != "" && a.Source != source {
+					continue
+				}
+				if teamID != "" && (a.TeamID == nil || *a.TeamID != teamID) {
+					continue
+				}
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// parseAlertEvent decodes an alert event from a hub message.
+func parseAlertEvent(data []byte) (alertEvent, bool) {
+	var msg struct {
+		Type      string          `json:"type"`
+		Payload   json.RawMessage `json:"payload"`
+		Timestamp time.Time       `json:"timestamp"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil || !strings.HasPrefix(msg.Type, "alert.") {
+		return alertEvent{}, false
+	}
+
+	event := alertEvent{Type: msg.Type, Timestamp: msg.Timestamp}
+	if msg.Type == "alert.deleted" {
+		var deleted struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(msg.Payload, &deleted); err != nil {
+			return alertEvent{}, false
+		}
+		event.AlertID = deleted.ID
+		return event, true
+	}
+
+	var alert dto.AlertResponse
+	if err := json.Unmarshal(msg.Payload, &alert); err != nil {
+		return alertEvent{}, false
+	}
+	event.AlertID = alert.ID
+	event.Alert = &alert
+	return event, true
+}
+
+// paginatedResponse builds the response of a page of results.
+func paginatedResponse[E, T any](result *valueobject.PaginatedResult[E], items []T) dto.PaginatedResponse[T] {
+	return dto.PaginatedResponse[T]{
+		Items:       items,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -38,12 +38,18 @@ type WebSocketStats interface {
 	ClientCount() int
 }
 
+// FeatureStates defines the interface for reporting feature flag state.
+type FeatureStates interface {
+	Snapshot(ctx context.Context) map[string]bool
+}
+
 // HealthHandler handles health check endpoints.
 type HealthHandler struct {
-	config  *config.Config
-	db      HealthChecker
-	cache   CacheHealthChecker
-	wsStats WebSocketStats
+	config   *config.Config
+	db       HealthChecker
+	cache    CacheHealthChecker
+	wsStats  WebSocketStats
+	features FeatureStates
 }
 
 // NewHealthHandler creates a new health handler.
@@ -56,6 +62,11 @@ func NewHealthHandler(cfg *config.Config, db HealthChecker, cache CacheHealthChe
 	}
 }
 
+// SetFeatureStates sets the source of the feature flag state reported by /health.
+func (h *HealthHandler) SetFeatureStates(features FeatureStates) {
+	h.features = features
+}
+
 // Check handles GET /health
 func (h *HealthHandler) Check(c *fiber.Ctx) error {
 	ctx, cancel := context.WithTimeout(c.Context(), 5*time.Second)
@@ -101,6 +112,10 @@ func (h *HealthHandler) Check(c *fiber.Ctx) error {
 		Services:  services,
 	}
 
+	if h.features != nil {
+		response.Features = h.features.Snapshot(ctx)
+	}
+
 	if status == statusHealthy {
 		return helper.Success(c, response)
 	}
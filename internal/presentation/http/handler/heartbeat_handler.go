@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// heartbeatPingPath is the path heartbeats are pinged on, followed by their token.
+const heartbeatPingPath = "/api/v1/heartbeats/"
+
+// HeartbeatHandler handles heartbeat management and pings.
+type HeartbeatHandler struct {
+	heartbeatService *service.HeartbeatService
+	pagination       valueobject.PaginationPolicy
+}
+
+// NewHeartbeatHandler creates a new heartbeat handler.
+func NewHeartbeatHandler(
+	heartbeatService *service.HeartbeatService,
+	pagination valueobject.PaginationPolicy,
+) *HeartbeatHandler {
+	return &HeartbeatHandler{
+		heartbeatService: heartbeatService,
+		pagination:       pagination,
+	}
+}
+
+// Create handles POST /api/v1/admin/heartbeats
+//
+//	@Summary		Create heartbeat
+//	@Description	Create a dead man's switch and get its ping URL. An alert is raised when no ping arrives within the interval plus grace, once the first ping was received. The token is only returned once.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateHeartbeatRequest	true	"Heartbeat data"
+//	@Success		201		{object}	dto.HeartbeatResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/heartbeats [post]
+func (h *HeartbeatHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateHeartbeatRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	input := service.HeartbeatInput{
+		Name:            req.Name,
+		IntervalSeconds: req.IntervalSeconds,
+		GraceSeconds:    req.GraceSeconds,
+		Severity:        entity.AlertSeverityHigh,
+	}
+	if req.Severity != "" {
+		input.Severity = entity.AlertSeverity(req.Severity)
+	}
+	if req.TeamID != "" {
+		teamID, err := entity.ParseID(req.TeamID)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid team ID")
+		}
+		input.TeamID = &teamID
+	}
+
+	var createdBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &userID
+	}
+
+	heartbeat, token, err := h.heartbeatService.Create(c.Context(), input, createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create heartbeat")
+	}
+
+	return helper.Created(c, h.withToken(c, heartbeat, token))
+}
+
+// List handles GET /api/v1/admin/heartbeats
+//
+//	@Summary		List heartbeats
+//	@Description	Retrieve heartbeats with their status and next ping deadline
+//	@Tags			admin
+//	@Produce		json
+//	@Param			page		query		int	false	"Page number"		default(1)
+//	@Param			page_size	query		int	false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.HeartbeatResponse]
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/heartbeats [get]
+func (h *HeartbeatHandler) List(c *fiber.Ctx) error {
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.heartbeatService.List(c.Context(), pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list heartbeats")
+		return helper.InternalError(c, "Failed to list heartbeats")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.HeartbeatResponse]{
+		Items:       dto.HeartbeatsFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// GetByID handles GET /api/v1/admin/heartbeats/:id
+//
+//	@Summary		Get heartbeat
+//	@Description	Retrieve a heartbeat
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"Heartbeat ID"
+//	@Success		200	{object}	dto.HeartbeatResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/heartbeats/{id} [get]
+func (h *HeartbeatHandler) GetByID(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid heartbeat ID")
+	}
+
+	heartbeat, err := h.heartbeatService.GetByID(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get heartbeat")
+	}
+
+	return helper.Success(c, dto.HeartbeatFromEntity(heartbeat))
+}
+
+// Update handles PATCH /api/v1/admin/heartbeats/:id
+//
+//	@Summary		Update heartbeat
+//	@Description	Change the settings of a heartbeat, or pause it by disabling it
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Heartbeat ID"
+//	@Param			request	body		dto.UpdateHeartbeatRequest	true	"Changes"
+//	@Success		200		{object}	dto.HeartbeatResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/heartbeats/{id} [patch]
+func (h *HeartbeatHandler) Update(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid heartbeat ID")
+	}
+
+	var req dto.UpdateHeartbeatRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	update := service.HeartbeatUpdate{
+		Name:            req.Name,
+		IntervalSeconds: req.IntervalSeconds,
+		GraceSeconds:    req.GraceSeconds,
+		IsEnabled:       req.IsEnabled,
+	}
+	if req.Severity != nil {
+		severity := entity.AlertSeverity(*req.Severity)
+		update.Severity = &severity
+	}
+
+	heartbeat, err := h.heartbeatService.Update(c.Context(), id, update)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update heartbeat")
+	}
+
+	return helper.Success(c, dto.HeartbeatFromEntity(heartbeat))
+}
+
+// RotateToken handles POST /api/v1/admin/heartbeats/:id/rotate-token
+//
+//	@Summary		Rotate heartbeat token
+//	@Description	Issue a new ping token; the previous URL stops working immediately
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"Heartbeat ID"
+//	@Success		200	{object}	dto.HeartbeatResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/heartbeats/{id}/rotate-token [post]
+func (h *HeartbeatHandler) RotateToken(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid heartbeat ID")
+	}
+
+	heartbeat, token, err := h.heartbeatService.RotateToken(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to rotate heartbeat token")
+	}
+
+	return helper.Success(c, h.withToken(c, heartbeat, token))
+}
+
+// Delete handles DELETE /api/v1/admin/heartbeats/:id
+//
+//	@Summary		Delete heartbeat
+//	@Description	Remove a heartbeat and invalidate its ping URL
+//	@Tags			admin
+//	@Param			id	path	string	true	"Heartbeat ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/heartbeats/{id} [delete]
+func (h *HeartbeatHandler) Delete(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid heartbeat ID")
+	}
+
+	if err := h.heartbeatService.Delete(c.Context(), id); err != nil {
+		return h.handleError(c, err, "Failed to delete heartbeat")
+	}
+
+	return helper.NoContent(c)
+}
+
+// Ping handles POST /api/v1/heartbeats/:token
+//
+//	@Summary		Ping heartbeat
+//	@Description	Record that a scheduled job ran. A heartbeat that was down is back up and its alert is resolved.
+//	@Tags			heartbeats
+//	@Produce		json
+//	@Param			token	path		string	true	"Heartbeat token"
+//	@Success		200		{object}	dto.HeartbeatPingResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		500		{object}	dto.ErrorResponse
+//	@Router			/heartbeats/{token} [post]
+func (h *HeartbeatHandler) Ping(c *fiber.Ctx) error {
+	heartbeat, err := h.heartbeatService.Ping(c.Context(), c.Params("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrHeartbeatNotFound):
+			return helper.NotFound(c, "Unknown heartbeat")
+		case errors.Is(err, service.ErrHeartbeatDisabled):
+			return helper.Forbidden(c, "Heartbeat is disabled")
+		}
+		log.Error().Err(err).Msg("Failed to record heartbeat ping")
+		return helper.InternalError(c, "Failed to record ping")
+	}
+
+	response := dto.HeartbeatPingResponse{Status: "received"}
+	if deadline, ok := heartbeat.Deadline(); ok {
+		response.NextPingDueAt = &deadline
+	}
+
+	return helper.Success(c, response)
+}
+
+// withToken builds a response carrying a freshly issued token and its ping URL.
+func (h *HeartbeatHandler) withToken(c *fiber.Ctx, heartbeat *entity.Heartbeat, token string) dto.HeartbeatResponse {
+	response := dto.HeartbeatFromEntity(heartbeat)
+	response.Token = token
+	response.URL = c.BaseURL() + heartbeatPingPath + token
+	return response
+}
+
+// handleError maps heartbeat service errors to HTTP responses.
+func (h *HeartbeatHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrHeartbeatNotFound):
+		return helper.NotFound(c, "Heartbeat not found")
+	case errors.Is(err, service.ErrHeartbeatExists):
+		return helper.Conflict(c, "Heartbeat with this name already exists")
+	case errors.Is(err, service.ErrTeamNotFound),
+		errors.Is(err, entity.ErrHeartbeatNameRequired),
+		errors.Is(err, entity.ErrHeartbeatNameTooLong),
+		errors.Is(err, entity.ErrHeartbeatInvalidInterval),
+		errors.Is(err, entity.ErrHeartbeatInvalidGrace),
+		errors.Is(err, entity.ErrHeartbeatInvalidSeverity):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// IdentityHandler handles the links between users and their chat provider accounts.
+type IdentityHandler struct {
+	identityService *service.IdentityService
+}
+
+// NewIdentityHandler creates a new identity handler.
+func NewIdentityHandler(identityService *service.IdentityService) *IdentityHandler {
+	return &IdentityHandler{identityService: identityService}
+}
+
+// List handles GET /api/v1/admin/users/:id/identities
+//
+//	@Summary		List user identities
+//	@Description	List the chat provider accounts linked to a user
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{array}		dto.UserIdentityResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/users/{id}/identities [get]
+func (h *IdentityHandler) List(c *fiber.Ctx) error {
+	userID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid user ID")
+	}
+
+	identities, err := h.identityService.List(c.Context(), userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to list user identities")
+	}
+
+	return helper.Success(c, dto.UserIdentitiesFromEntities(identities))
+}
+
+// Link handles PUT /api/v1/admin/users/:id/identities/:provider
+//
+//	@Summary		Link user identity
+//	@Description	Link a user to their account on a chat provider (slack, telegram) or to their phone number (phone, in E.164 format), replacing the account previously linked. Actions taken from the provider, such as the Acknowledge and Resolve buttons of Slack messages or the keys pressed during escalation calls, are performed on behalf of the user.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"User ID"
+//	@Param			provider	path		string					true	"Provider"
+//	@Param			request		body		dto.LinkIdentityRequest	true	"Provider account"
+//	@Success		200			{object}	dto.UserIdentityResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/users/{id}/identities/{provider} [put]
+func (h *IdentityHandler) Link(c *fiber.Ctx) error {
+	userID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid user ID")
+	}
+
+	var req dto.LinkIdentityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	var createdBy *entity.ID
+	if adminID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &adminID
+	}
+
+	identity, err := h.identityService.Link(c.Context(), userID, c.Params("provider"), req.ExternalID, createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to link user identity")
+	}
+
+	return helper.Success(c, dto.UserIdentityFromEntity(identity))
+}
+
+// Unlink handles DELETE /api/v1/admin/users/:id/identities/:provider
+//
+//	@Summary		Unlink user identity
+//	@Description	Remove the link of a user to a chat provider; actions taken from their account are refused again
+//	@Tags			admin
+//	@Param			id			path	string	true	"User ID"
+//	@Param			provider	path	string	true	"Provider"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/users/{id}/identities/{provider} [delete]
+func (h *IdentityHandler) Unlink(c *fiber.Ctx) error {
+	userID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid user ID")
+	}
+
+	if err := h.identityService.Unlink(c.Context(), userID, c.Params("provider")); err != nil {
+		return h.handleError(c, err, "Failed to unlink user identity")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps identity service errors to HTTP responses.
+func (h *IdentityHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return helper.NotFound(c, "User not found")
+	case errors.Is(err, service.ErrIdentityNotFound):
+		return helper.NotFound(c, "User identity not found")
+	case errors.Is(err, service.ErrIdentityTaken):
+		return helper.Conflict(c, "Provider account is already linked to another user")
+	case errors.Is(err, entity.ErrIdentityProviderInvalid),
+		errors.Is(err, entity.ErrIdentityExternalIDRequired),
+		errors.Is(err, entity.ErrIdentityExternalIDTooLong),
+		errors.Is(err, entity.ErrIdentityPhoneInvalid):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -0,0 +1,599 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// errInvalidIncidentID reports a malformed incident ID in the path.
+var errInvalidIncidentID = errors.New("invalid incident ID")
+
+// IncidentHandler handles incidents grouping related alerts.
+// Incidents owned by a team are only accessible within its tenant scope.
+type IncidentHandler struct {
+	incidentService *service.IncidentService
+	pagination      valueobject.PaginationPolicy
+}
+
+// NewIncidentHandler creates a new incident handler.
+func NewIncidentHandler(incidentService *service.IncidentService, pagination valueobject.PaginationPolicy) *IncidentHandler {
+	return &IncidentHandler{
+		incidentService: incidentService,
+		pagination:      pagination,
+	}
+}
+
+// Create handles POST /api/v1/incidents
+//
+//	@Summary		Open incident
+//	@Description	Open an incident grouping the given alerts (operator or admin). Severity defaults to that of the most severe alert, or high without alerts; it is raised as more severe alerts are added.
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateIncidentRequest	true	"Incident data"
+//	@Success		201		{object}	dto.IncidentResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents [post]
+func (h *IncidentHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	teamID, ok := ownerTeam(c, req.TeamID)
+	if !ok {
+		return helper.Forbidden(c, "Not a member of the team")
+	}
+
+	alertIDs, err := parseIDs(req.AlertIDs)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid alert ID")
+	}
+
+	input := service.IncidentInput{
+		Title:    req.Title,
+		Summary:  req.Summary,
+		Severity: entity.AlertSeverityHigh,
+		TeamID:   teamID,
+		AlertIDs: alertIDs,
+	}
+	switch {
+	case req.Severity != "":
+		input.Severity = entity.AlertSeverity(req.Severity)
+	case len(alertIDs) > 0:
+		// Raised to the severity of the most severe alert as they are added
+		input.Severity = entity.AlertSeverityInfo
+	}
+	if req.CommanderID != "" {
+		commanderID, err := entity.ParseID(req.CommanderID)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid commander ID")
+		}
+		input.CommanderID = &commanderID
+	}
+
+	incident, err := h.incidentService.Create(c.Context(), input, actor(c), userRole(c), tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to create incident")
+	}
+
+	return helper.Created(c, dto.IncidentFromEntity(incident))
+}
+
+// List handles GET /api/v1/incidents
+//
+//	@Summary		List incidents
+//	@Description	Retrieve the incidents within the tenant scope, newest first
+//	@Tags			incidents
+//	@Produce		json
+//	@Param			status		query		string	false	"Filter by status"	Enums(open, mitigated, closed)
+//	@Param			page		query		int		false	"Page number"		default(1)
+//	@Param			page_size	query		int		false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.IncidentResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents [get]
+func (h *IncidentHandler) List(c *fiber.Ctx) error {
+	var filter repository.IncidentFilter
+	if raw := c.Query("status"); raw != "" {
+		status := entity.IncidentStatus(raw)
+		if !status.IsValid() {
+			return helper.BadRequest(c, "Invalid status")
+		}
+		filter.Status = &status
+	}
+	if tenant := tenantScope(c); !tenant.IsAll() {
+		filter.Tenant = &tenant
+	}
+
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.incidentService.List(c.Context(), filter, pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list incidents")
+		return helper.InternalError(c, "Failed to list incidents")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.IncidentResponse]{
+		Items:       dto.IncidentsFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// GetByID handles GET /api/v1/incidents/:id
+//
+//	@Summary		Get incident
+//	@Description	Retrieve an incident
+//	@Tags			incidents
+//	@Produce		json
+//	@Param			id	path		string	true	"Incident ID"
+//	@Success		200	{object}	dto.IncidentResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id} [get]
+func (h *IncidentHandler) GetByID(c *fiber.Ctx) error {
+	incident, err := h.visibleIncident(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get incident")
+	}
+
+	return helper.Success(c, dto.IncidentFromEntity(incident))
+}
+
+// Update handles PATCH /api/v1/incidents/:id
+//
+//	@Summary		Update incident
+//	@Description	Change the title, summary or severity of an incident that is not closed (operator or admin)
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Incident ID"
+//	@Param			request	body		dto.UpdateIncidentRequest	true	"Changes"
+//	@Success		200		{object}	dto.IncidentResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id} [patch]
+func (h *IncidentHandler) Update(c *fiber.Ctx) error {
+	var req dto.UpdateIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	current, err := h.visibleIncident(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update incident")
+	}
+
+	update := service.IncidentUpdate{
+		Title:   req.Title,
+		Summary: req.Summary,
+	}
+	if req.Severity != nil {
+		severity := entity.AlertSeverity(*req.Severity)
+		update.Severity = &severity
+	}
+
+	incident, err := h.incidentService.Update(c.Context(), current.ID, update, actor(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to update incident")
+	}
+
+	return helper.Success(c, dto.IncidentFromEntity(incident))
+}
+
+// Mitigate handles POST /api/v1/incidents/:id/mitigate
+//
+//	@Summary		Mitigate incident
+//	@Description	Mark an open incident as mitigated, with an optional note for the timeline (operator or admin)
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Incident ID"
+//	@Param			request	body		dto.IncidentTransitionRequest	false	"Note"
+//	@Success		200		{object}	dto.IncidentResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/mitigate [post]
+func (h *IncidentHandler) Mitigate(c *fiber.Ctx) error {
+	return h.transition(c, h.incidentService.Mitigate, "Failed to mitigate incident")
+}
+
+// Close handles POST /api/v1/incidents/:id/close
+//
+//	@Summary		Close incident
+//	@Description	Close an incident, with an optional note for the timeline (operator or admin). Its alerts are left as they are.
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Incident ID"
+//	@Param			request	body		dto.IncidentTransitionRequest	false	"Note"
+//	@Success		200		{object}	dto.IncidentResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/close [post]
+func (h *IncidentHandler) Close(c *fiber.Ctx) error {
+	return h.transition(c, h.incidentService.Close, "Failed to close incident")
+}
+
+// Reopen handles POST /api/v1/incidents/:id/reopen
+//
+//	@Summary		Reopen incident
+//	@Description	Open a mitigated or closed incident again, with an optional note for the timeline (operator or admin)
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Incident ID"
+//	@Param			request	body		dto.IncidentTransitionRequest	false	"Note"
+//	@Success		200		{object}	dto.IncidentResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/reopen [post]
+func (h *IncidentHandler) Reopen(c *fiber.Ctx) error {
+	return h.transition(c, h.incidentService.Reopen, "Failed to reopen incident")
+}
+
+// AssignCommander handles PUT /api/v1/incidents/:id/commander
+//
+//	@Summary		Assign incident commander
+//	@Description	Set the user leading the response to an incident; an empty user_id unassigns it (operator or admin)
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Incident ID"
+//	@Param			request	body		dto.AssignCommanderRequest	true	"Commander"
+//	@Success		200		{object}	dto.IncidentResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/commander [put]
+func (h *IncidentHandler) AssignCommander(c *fiber.Ctx) error {
+	var req dto.AssignCommanderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	var commanderID *entity.ID
+	if req.UserID != "" {
+		id, err := entity.ParseID(req.UserID)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid user ID")
+		}
+		commanderID = &id
+	}
+
+	current, err := h.visibleIncident(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to assign incident commander")
+	}
+
+	incident, err := h.incidentService.AssignCommander(c.Context(), current.ID, commanderID, actor(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to assign incident commander")
+	}
+
+	return helper.Success(c, dto.IncidentFromEntity(incident))
+}
+
+// Alerts handles GET /api/v1/incidents/:id/alerts
+//
+//	@Summary		List incident alerts
+//	@Description	Retrieve the alerts grouped into an incident, most recent first
+//	@Tags			incidents
+//	@Produce		json
+//	@Param			id	path		string	true	"Incident ID"
+//	@Success		200	{array}		dto.AlertResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/alerts [get]
+func (h *IncidentHandler) Alerts(c *fiber.Ctx) error {
+	incident, err := h.visibleIncident(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to list incident alerts")
+	}
+
+	alerts, err := h.incidentService.Alerts(c.Context(), incident.ID, userRole(c), tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to list incident alerts")
+	}
+
+	return helper.Success(c, dto.AlertsFromEntities(alerts))
+}
+
+// AddAlerts handles POST /api/v1/incidents/:id/alerts
+//
+//	@Summary		Add alerts to incident
+//	@Description	Group alerts into an incident that is not closed (operator or admin). An alert belongs to one incident at most.
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Incident ID"
+//	@Param			request	body		dto.AddIncidentAlertsRequest	true	"Alerts"
+//	@Success		200		{object}	dto.IncidentResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/alerts [post]
+func (h *IncidentHandler) AddAlerts(c *fiber.Ctx) error {
+	var req dto.AddIncidentAlertsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	alertIDs, err := parseIDs(req.AlertIDs)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid alert ID")
+	}
+
+	current, err := h.visibleIncident(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to add alerts to incident")
+	}
+
+	incident, err := h.incidentService.AddAlerts(c.Context(), current.ID, alertIDs, actor(c), userRole(c), tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to add alerts to incident")
+	}
+
+	return helper.Success(c, dto.IncidentFromEntity(incident))
+}
+
+// RemoveAlert handles DELETE /api/v1/incidents/:id/alerts/:alertId
+//
+//	@Summary		Remove alert from incident
+//	@Description	Take an alert out of an incident that is not closed (operator or admin)
+//	@Tags			incidents
+//	@Param			id		path	string	true	"Incident ID"
+//	@Param			alertId	path	string	true	"Alert ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/alerts/{alertId} [delete]
+func (h *IncidentHandler) RemoveAlert(c *fiber.Ctx) error {
+	alertID, err := entity.ParseID(c.Params("alertId"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid alert ID")
+	}
+
+	incident, err := h.visibleIncident(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to remove alert from incident")
+	}
+
+	if err := h.incidentService.RemoveAlert(c.Context(), incident.ID, alertID, actor(c)); err != nil {
+		return h.handleError(c, err, "Failed to remove alert from incident")
+	}
+
+	return helper.NoContent(c)
+}
+
+// Timeline handles GET /api/v1/incidents/:id/timeline
+//
+//	@Summary		Get incident timeline
+//	@Description	Retrieve what happened during an incident, oldest first: changes of status and commander, alerts added and removed, and notes of responders
+//	@Tags			incidents
+//	@Produce		json
+//	@Param			id			path		string	true	"Incident ID"
+//	@Param			page		query		int		false	"Page number"		default(1)
+//	@Param			page_size	query		int		false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.IncidentEventResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/timeline [get]
+func (h *IncidentHandler) Timeline(c *fiber.Ctx) error {
+	incident, err := h.visibleIncident(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get incident timeline")
+	}
+
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.incidentService.Timeline(c.Context(), incident.ID, pagination)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get incident timeline")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.IncidentEventResponse]{
+		Items:       dto.IncidentEventsFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// AddNote handles POST /api/v1/incidents/:id/timeline
+//
+//	@Summary		Add incident note
+//	@Description	Add a note to the timeline of an incident (operator or admin)
+//	@Tags			incidents
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Incident ID"
+//	@Param			request	body		dto.IncidentNoteRequest	true	"Note"
+//	@Success		201		{object}	dto.IncidentEventResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/timeline [post]
+func (h *IncidentHandler) AddNote(c *fiber.Ctx) error {
+	var req dto.IncidentNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	incident, err := h.visibleIncident(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to add incident note")
+	}
+
+	event, err := h.incidentService.AddNote(c.Context(), incident.ID, req.Message, actor(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to add incident note")
+	}
+
+	return helper.Created(c, dto.IncidentEventFromEntity(event))
+}
+
+// transition applies a change of status with the optional note of the body.
+func (h *IncidentHandler) transition(
+	c *fiber.Ctx,
+	change func(ctx context.Context, id entity.ID, note string, actor *entity.ID) (*entity.Incident, error),
+	message string,
+) error {
+	var req dto.IncidentTransitionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return helper.BadRequest(c, "Invalid request body")
+		}
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	current, err := h.visibleIncident(c)
+	if err != nil {
+		return h.handleError(c, err, message)
+	}
+
+	incident, err := change(c.Context(), current.ID, req.Note, actor(c))
+	if err != nil {
+		return h.handleError(c, err, message)
+	}
+
+	return helper.Success(c, dto.IncidentFromEntity(incident))
+}
+
+// visibleIncident retrieves the incident of the path within the tenant scope.
+// Incidents hidden from the user's teams cannot be changed either.
+func (h *IncidentHandler) visibleIncident(c *fiber.Ctx) (*entity.Incident, error) {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return nil, errInvalidIncidentID
+	}
+
+	return h.incidentService.GetVisible(c.Context(), id, tenantScope(c))
+}
+
+// handleError maps incident service errors to HTTP responses.
+func (h *IncidentHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, errInvalidIncidentID):
+		return helper.BadRequest(c, "Invalid incident ID")
+	case errors.Is(err, service.ErrIncidentNotFound):
+		return helper.NotFound(c, "Incident not found")
+	case errors.Is(err, service.ErrAlertNotFound):
+		return helper.NotFound(c, "Alert not found")
+	case errors.Is(err, service.ErrIncidentAlertNotFound):
+		return helper.NotFound(c, "Alert is not in the incident")
+	case errors.Is(err, service.ErrAlertInIncident),
+		errors.Is(err, entity.ErrIncidentNotOpen),
+		errors.Is(err, entity.ErrIncidentClosed),
+		errors.Is(err, entity.ErrIncidentNotReopenable):
+		return helper.Conflict(c, err.Error())
+	case errors.Is(err, service.ErrTeamNotFound),
+		errors.Is(err, service.ErrIncidentCommanderNotFound),
+		errors.Is(err, entity.ErrIncidentTitleRequired),
+		errors.Is(err, entity.ErrIncidentTitleTooLong),
+		errors.Is(err, entity.ErrIncidentSummaryTooLong),
+		errors.Is(err, entity.ErrIncidentInvalidSeverity),
+		errors.Is(err, entity.ErrIncidentNoteRequired),
+		errors.Is(err, entity.ErrIncidentNoteTooLong):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
+
+// actor returns the authenticated user, if any, as the author of a change.
+func actor(c *fiber.Ctx) *entity.ID {
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		return &userID
+	}
+	return nil
+}
+
+// parseIDs parses a list of IDs.
+func parseIDs(raw []string) ([]entity.ID, error) {
+	ids := make([]entity.ID, 0, len(raw))
+	for _, value := range raw {
+		id, err := entity.ParseID(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// defaultMaintenanceRange is the period of maintenance calendars without an
+// end.
+const defaultMaintenanceRange = 90 * 24 * time.Hour
+
+var errInvalidMaintenanceWindowID = errors.New("invalid maintenance window ID")
+
+// MaintenanceHandler handles maintenance windows and their calendar.
+type MaintenanceHandler struct {
+	maintenanceService *service.MaintenanceService
+}
+
+// NewMaintenanceHandler creates a new maintenance handler.
+func NewMaintenanceHandler(maintenanceService *service.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// Create handles POST /api/v1/maintenance
+//
+//	@Summary		Schedule maintenance window
+//	@Description	Schedule a maintenance window (operator or admin) for a team, or shared when no team is given
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateMaintenanceWindowRequest	true	"Maintenance window data"
+//	@Success		201		{object}	dto.MaintenanceWindowResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/maintenance [post]
+func (h *MaintenanceHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateMaintenanceWindowRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	teamID, ok := ownerTeam(c, req.TeamID)
+	if !ok {
+		return helper.Forbidden(c, "Not a member of the team")
+	}
+
+	input := service.MaintenanceInput{
+		Title:       req.Title,
+		Description: req.Description,
+		TeamID:      teamID,
+		Source:      req.Source,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+	}
+
+	window, err := h.maintenanceService.Create(c.Context(), input, actor(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to schedule maintenance window")
+	}
+
+	return helper.Created(c, dto.MaintenanceWindowFromEntity(window, time.Now().UTC()))
+}
+
+// List handles GET /api/v1/maintenance
+//
+//	@Summary		Maintenance calendar
+//	@Description	List the maintenance windows within the tenant scope that are current or upcoming in a period, by start; at most 500 windows are returned
+//	@Tags			maintenance
+//	@Produce		json
+//	@Param			from	query		string	false	"Start of the period (RFC3339), now by default"
+//	@Param			to		query		string	false	"End of the period (RFC3339), 90 days after its start by default"
+//	@Success		200		{object}	dto.MaintenanceCalendarResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/maintenance [get]
+func (h *MaintenanceHandler) List(c *fiber.Ctx) error {
+	from, to, invalid := maintenancePeriod(c)
+	if invalid != "" {
+		return helper.BadRequest(c, "Invalid "+invalid+" date, expected RFC3339")
+	}
+
+	windows, err := h.maintenanceService.Calendar(c.Context(), from, to, tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to list maintenance windows")
+	}
+
+	return helper.Success(c, dto.MaintenanceCalendarFromEntities(from, to, windows, time.Now().UTC()))
+}
+
+// ICalendar handles GET /api/v1/maintenance.ics
+//
+//	@Summary		Maintenance calendar feed
+//	@Description	Download the maintenance windows within the tenant scope as an iCalendar feed, to sync them into calendar applications. The period defaults to the coming 90 days.
+//	@Tags			maintenance
+//	@Produce		text/calendar
+//	@Param			from	query		string	false	"Start of the period (RFC3339), now by default"
+//	@Param			to		query		string	false	"End of the period (RFC3339), 90 days after its start by default"
+//	@Success		200		{file}		binary
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/maintenance.ics [get]
+func (h *MaintenanceHandler) ICalendar(c *fiber.Ctx) error {
+	from, to, invalid := maintenancePeriod(c)
+	if invalid != "" {
+		return helper.BadRequest(c, "Invalid "+invalid+" date, expected RFC3339")
+	}
+
+	windows, err := h.maintenanceService.Calendar(c.Context(), from, to, tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to export maintenance calendar")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="maintenance.ics"`)
+	return c.Send(h.maintenanceService.ICalendar(windows, time.Now().UTC()))
+}
+
+// GetByID handles GET /api/v1/maintenance/:id
+//
+//	@Summary		Get maintenance window
+//	@Description	Retrieve a maintenance window
+//	@Tags			maintenance
+//	@Produce		json
+//	@Param			id	path		string	true	"Maintenance window ID"
+//	@Success		200	{object}	dto.MaintenanceWindowResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/maintenance/{id} [get]
+func (h *MaintenanceHandler) GetByID(c *fiber.Ctx) error {
+	window, err := h.visibleWindow(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get maintenance window")
+	}
+
+	return helper.Success(c, dto.MaintenanceWindowFromEntity(window, time.Now().UTC()))
+}
+
+// Update handles PATCH /api/v1/maintenance/:id
+//
+//	@Summary		Update maintenance window
+//	@Description	Reschedule or describe a maintenance window (operator or admin)
+//	@Tags			maintenance
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Maintenance window ID"
+//	@Param			request	body		dto.UpdateMaintenanceWindowRequest	true	"Changes"
+//	@Success		200		{object}	dto.MaintenanceWindowResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/maintenance/{id} [patch]
+func (h *MaintenanceHandler) Update(c *fiber.Ctx) error {
+	var req dto.UpdateMaintenanceWindowRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	current, err := h.visibleWindow(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update maintenance window")
+	}
+
+	update := service.MaintenanceUpdate{
+		Title:       req.Title,
+		Description: req.Description,
+		Source:      req.Source,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+	}
+
+	window, err := h.maintenanceService.Update(c.Context(), current, update)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update maintenance window")
+	}
+
+	return helper.Success(c, dto.MaintenanceWindowFromEntity(window, time.Now().UTC()))
+}
+
+// Delete handles DELETE /api/v1/maintenance/:id
+//
+//	@Summary		Delete maintenance window
+//	@Description	Cancel a maintenance window (operator or admin)
+//	@Tags			maintenance
+//	@Param			id	path	string	true	"Maintenance window ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/maintenance/{id} [delete]
+func (h *MaintenanceHandler) Delete(c *fiber.Ctx) error {
+	window, err := h.visibleWindow(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to delete maintenance window")
+	}
+
+	if err := h.maintenanceService.Delete(c.Context(), window.ID); err != nil {
+		return h.handleError(c, err, "Failed to delete maintenance window")
+	}
+
+	return helper.NoContent(c)
+}
+
+// visibleWindow retrieves the maintenance window of the path within the
+// tenant scope.
+func (h *MaintenanceHandler) visibleWindow(c *fiber.Ctx) (*entity.MaintenanceWindow, error) {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return nil, errInvalidMaintenanceWindowID
+	}
+
+	return h.maintenanceService.GetVisible(c.Context(), id, tenantScope(c))
+}
+
+// maintenancePeriod parses the period of a maintenance calendar, from now
+// to 90 days later by default. It returns the name of the first invalid
+// query parameter, if any.
+func maintenancePeriod(c *fiber.Ctx) (from, to time.Time, invalid string) {
+	from = time.Now().UTC()
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return from, to, "from"
+		}
+		from = parsed
+	}
+
+	to = from.Add(defaultMaintenanceRange)
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return from, to, "to"
+		}
+		to = parsed
+	}
+
+	return from, to, ""
+}
+
+// handleError maps maintenance service errors to HTTP responses.
+func (h *MaintenanceHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, errInvalidMaintenanceWindowID):
+		return helper.BadRequest(c, "Invalid maintenance window ID")
+	case errors.Is(err, service.ErrMaintenanceWindowNotFound):
+		return helper.NotFound(c, "Maintenance window not found")
+	case errors.Is(err, service.ErrTeamNotFound),
+		errors.Is(err, service.ErrMaintenanceRangeInvalid),
+		errors.Is(err, entity.ErrMaintenanceTitleRequired),
+		errors.Is(err, entity.ErrMaintenanceTitleTooLong),
+		errors.Is(err, entity.ErrMaintenanceDescriptionTooLong),
+		errors.Is(err, entity.ErrMaintenanceInvalidPeriod):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
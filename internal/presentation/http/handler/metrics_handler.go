@@ -1,12 +1,28 @@
 package handler
 
 import (
+	"net/http"
+
 	"github.com/gofiber/adaptor/v2"
 	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
 )
 
 // MetricsHandler returns Prometheus metrics.
-func MetricsHandler() fiber.Handler {
-	return adaptor.HTTPHandler(promhttp.Handler())
+// The given labels (e.g. region and cluster) are added to every exported sample.
+func MetricsHandler(labels map[string]string) fiber.Handler {
+	return adaptor.HTTPHandler(MetricsHTTPHandler(labels))
+}
+
+// MetricsHTTPHandler is MetricsHandler for a net/http server, used when
+// metrics are served on their own port.
+func MetricsHTTPHandler(labels map[string]string) http.Handler {
+	gatherer := metrics.WithConstLabels(prometheus.DefaultGatherer, labels)
+	return promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}),
+	)
 }
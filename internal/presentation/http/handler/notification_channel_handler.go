@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// NotificationChannelHandler handles the notification channels of the deployment.
+type NotificationChannelHandler struct {
+	notificationService *service.NotificationService
+}
+
+// NewNotificationChannelHandler creates a new notification channel handler.
+func NewNotificationChannelHandler(notificationService *service.NotificationService) *NotificationChannelHandler {
+	return &NotificationChannelHandler{notificationService: notificationService}
+}
+
+// List handles GET /api/v1/admin/notification-channels
+//
+//	@Summary		List notification channels
+//	@Description	List the names of the enabled notification channels
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		string
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/notification-channels [get]
+func (h *NotificationChannelHandler) List(c *fiber.Ctx) error {
+	if h.notificationService == nil {
+		return helper.Success(c, []string{})
+	}
+	return helper.Success(c, h.notificationService.GetActiveNotifiers())
+}
+
+// Test handles POST /api/v1/admin/notification-channels/:channel/test
+//
+//	@Summary		Test notification channel
+//	@Description	Send a test notification through a channel, bypassing routes, quiet hours and rate limits
+//	@Tags			admin
+//	@Param			channel	path	string	true	"Channel name"
+//	@Success		204
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		502	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/notification-channels/{channel}/test [post]
+func (h *NotificationChannelHandler) Test(c *fiber.Ctx) error {
+	if h.notificationService == nil {
+		return helper.NotFound(c, "Notification channel not found")
+	}
+
+	if err := h.notificationService.SendTest(c.Context(), c.Params("channel")); err != nil {
+		if errors.Is(err, service.ErrNotificationChannelNotFound) {
+			return helper.NotFound(c, "Notification channel not found")
+		}
+		log.Error().Err(err).Str("channel", c.Params("channel")).Msg("Failed to send test notification")
+		return helper.BadGateway(c, "Failed to send test notification: "+err.Error())
+	}
+
+	return helper.NoContent(c)
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// NotificationTemplateHandler handles notification template management.
+type NotificationTemplateHandler struct {
+	templateService *service.NotificationTemplateService
+}
+
+// NewNotificationTemplateHandler creates a new notification template handler.
+func NewNotificationTemplateHandler(templateService *service.NotificationTemplateService) *NotificationTemplateHandler {
+	return &NotificationTemplateHandler{templateService: templateService}
+}
+
+// List handles GET /api/v1/admin/notification-templates
+//
+//	@Summary		List notification templates
+//	@Description	List the template of every channel type, built-in defaults included
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		dto.NotificationTemplateResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/notification-templates [get]
+func (h *NotificationTemplateHandler) List(c *fiber.Ctx) error {
+	templates, err := h.templateService.List(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list notification templates")
+		return helper.InternalError(c, "Failed to list notification templates")
+	}
+
+	return helper.Success(c, dto.NotificationTemplatesFromEntities(templates))
+}
+
+// Get handles GET /api/v1/admin/notification-templates/:channel
+//
+//	@Summary		Get notification template
+//	@Description	Get the template of a channel type (slack, email, sms, webhook, telegram)
+//	@Tags			admin
+//	@Produce		json
+//	@Param			channel	path		string	true	"Channel type"
+//	@Success		200		{object}	dto.NotificationTemplateResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/notification-templates/{channel} [get]
+func (h *NotificationTemplateHandler) Get(c *fiber.Ctx) error {
+	template, err := h.templateService.Get(c.Context(), c.Params("channel"))
+	if err != nil {
+		return h.handleError(c, err, "Failed to get notification template")
+	}
+
+	return helper.Success(c, dto.NotificationTemplateFromEntity(template))
+}
+
+// Set handles PUT /api/v1/admin/notification-templates/:channel
+//
+//	@Summary		Set notification template
+//	@Description	Configure the Go text/template title and body of a channel type's notifications. Templates receive .Title, .Text, .Severity, .Source, .AlertID, .Fields, .Labels and .Links.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			channel	path		string								true	"Channel type"
+//	@Param			request	body		dto.SetNotificationTemplateRequest	true	"Templates"
+//	@Success		200		{object}	dto.NotificationTemplateResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/notification-templates/{channel} [put]
+func (h *NotificationTemplateHandler) Set(c *fiber.Ctx) error {
+	var req dto.SetNotificationTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	var createdBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &userID
+	}
+
+	template, err := h.templateService.Set(c.Context(), c.Params("channel"), req.Title, req.Body, createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to set notification template")
+	}
+
+	return helper.Success(c, dto.NotificationTemplateFromEntity(template))
+}
+
+// Preview handles POST /api/v1/admin/notification-templates/:channel/preview
+//
+//	@Summary		Preview notification template
+//	@Description	Validate templates and render them with sample data without saving them
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			channel	path		string								true	"Channel type"
+//	@Param			request	body		dto.SetNotificationTemplateRequest	true	"Templates"
+//	@Success		200		{object}	dto.NotificationTemplatePreviewResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/notification-templates/{channel}/preview [post]
+func (h *NotificationTemplateHandler) Preview(c *fiber.Ctx) error {
+	var req dto.SetNotificationTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	title, body, err := h.templateService.Preview(c.Params("channel"), req.Title, req.Body)
+	if err != nil {
+		return h.handleError(c, err, "Failed to preview notification template")
+	}
+
+	return helper.Success(c, dto.NotificationTemplatePreviewResponse{Title: title, Body: body})
+}
+
+// Delete handles DELETE /api/v1/admin/notification-templates/:channel
+//
+//	@Summary		Delete notification template
+//	@Description	Remove a channel type's template so its notifications use the built-in default
+//	@Tags			admin
+//	@Param			channel	path	string	true	"Channel type"
+//	@Success		204
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/notification-templates/{channel} [delete]
+func (h *NotificationTemplateHandler) Delete(c *fiber.Ctx) error {
+	if err := h.templateService.Delete(c.Context(), c.Params("channel")); err != nil {
+		return h.handleError(c, err, "Failed to delete notification template")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps notification template service errors to HTTP responses.
+func (h *NotificationTemplateHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrNotificationTemplateNotFound):
+		return helper.NotFound(c, "Notification template not found")
+	case errors.Is(err, entity.ErrChannelInvalidType),
+		errors.Is(err, entity.ErrTemplateTitleRequired),
+		errors.Is(err, entity.ErrTemplateBodyRequired),
+		errors.Is(err, entity.ErrTemplateTooLong),
+		errors.Is(err, entity.ErrTemplateInvalid):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
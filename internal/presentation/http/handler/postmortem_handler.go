@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// errInvalidPostmortemID reports a malformed postmortem ID in the path.
+var errInvalidPostmortemID = errors.New("invalid postmortem ID")
+
+// PostmortemHandler handles postmortems of incidents and alerts.
+// Postmortems owned by a team are only accessible within its tenant scope.
+type PostmortemHandler struct {
+	postmortemService *service.PostmortemService
+	pagination        valueobject.PaginationPolicy
+}
+
+// NewPostmortemHandler creates a new postmortem handler.
+func NewPostmortemHandler(postmortemService *service.PostmortemService, pagination valueobject.PaginationPolicy) *PostmortemHandler {
+	return &PostmortemHandler{
+		postmortemService: postmortemService,
+		pagination:        pagination,
+	}
+}
+
+// Create handles POST /api/v1/postmortems
+//
+//	@Summary		Write postmortem
+//	@Description	Write the postmortem of an incident, or of alerts (operator or admin). Summary, impact and root cause are markdown. An incident has one postmortem at most, which belongs to its team and is named after it unless a title is given.
+//	@Tags			postmortems
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreatePostmortemRequest	true	"Postmortem data"
+//	@Success		201		{object}	dto.PostmortemResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/postmortems [post]
+func (h *PostmortemHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreatePostmortemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	teamID, ok := ownerTeam(c, req.TeamID)
+	if !ok {
+		return helper.Forbidden(c, "Not a member of the team")
+	}
+
+	input := service.PostmortemInput{
+		TeamID:    teamID,
+		Title:     req.Title,
+		Summary:   req.Summary,
+		Impact:    req.Impact,
+		RootCause: req.RootCause,
+	}
+	if req.IncidentID != "" {
+		incidentID, err := entity.ParseID(req.IncidentID)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid incident ID")
+		}
+		input.IncidentID = &incidentID
+	}
+
+	var err error
+	if input.ActionItems, err = actionItems(req.ActionItems); err != nil {
+		return helper.BadRequest(c, "Invalid action item owner ID")
+	}
+	if input.AlertIDs, err = parseIDs(req.AlertIDs); err != nil {
+		return helper.BadRequest(c, "Invalid alert ID")
+	}
+
+	postmortem, err := h.postmortemService.Create(c.Context(), input, actor(c), userRole(c), tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to create postmortem")
+	}
+
+	return helper.Created(c, dto.PostmortemFromEntity(postmortem))
+}
+
+// List handles GET /api/v1/postmortems
+//
+//	@Summary		List postmortems
+//	@Description	Retrieve the postmortems within the tenant scope, newest first
+//	@Tags			postmortems
+//	@Produce		json
+//	@Param			alert_id	query		string	false	"Only postmortems covering the alert, directly or through their incident"
+//	@Param			page		query		int		false	"Page number"		default(1)
+//	@Param			page_size	query		int		false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.PostmortemResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/postmortems [get]
+func (h *PostmortemHandler) List(c *fiber.Ctx) error {
+	var filter repository.PostmortemFilter
+	if raw := c.Query("alert_id"); raw != "" {
+		alertID, err := entity.ParseID(raw)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid alert ID")
+		}
+		filter.AlertID = &alertID
+	}
+	if tenant := tenantScope(c); !tenant.IsAll() {
+		filter.Tenant = &tenant
+	}
+
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.postmortemService.List(c.Context(), filter, pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list postmortems")
+		return helper.InternalError(c, "Failed to list postmortems")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.PostmortemResponse]{
+		Items:       dto.PostmortemsFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// GetByID handles GET /api/v1/postmortems/:id
+//
+//	@Summary		Get postmortem
+//	@Description	Retrieve a postmortem
+//	@Tags			postmortems
+//	@Produce		json
+//	@Param			id	path		string	true	"Postmortem ID"
+//	@Success		200	{object}	dto.PostmortemResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/postmortems/{id} [get]
+func (h *PostmortemHandler) GetByID(c *fiber.Ctx) error {
+	postmortem, err := h.visiblePostmortem(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get postmortem")
+	}
+
+	return helper.Success(c, dto.PostmortemFromEntity(postmortem))
+}
+
+// GetByIncident handles GET /api/v1/incidents/:id/postmortem
+//
+//	@Summary		Get incident postmortem
+//	@Description	Retrieve the postmortem of an incident
+//	@Tags			incidents
+//	@Produce		json
+//	@Param			id	path		string	true	"Incident ID"
+//	@Success		200	{object}	dto.PostmortemResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/incidents/{id}/postmortem [get]
+func (h *PostmortemHandler) GetByIncident(c *fiber.Ctx) error {
+	incidentID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid incident ID")
+	}
+
+	postmortem, err := h.postmortemService.GetByIncident(c.Context(), incidentID)
+	if err == nil && !tenantScope(c).Allows(postmortem.TeamID) {
+		err = service.ErrPostmortemNotFound
+	}
+	if err != nil {
+		return h.handleError(c, err, "Failed to get incident postmortem")
+	}
+
+	return helper.Success(c, dto.PostmortemFromEntity(postmortem))
+}
+
+// Update handles PATCH /api/v1/postmortems/:id
+//
+//	@Summary		Update postmortem
+//	@Description	Change a postmortem (operator or admin); action items and alerts, when given, replace the current ones
+//	@Tags			postmortems
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Postmortem ID"
+//	@Param			request	body		dto.UpdatePostmortemRequest	true	"Changes"
+//	@Success		200		{object}	dto.PostmortemResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/postmortems/{id} [patch]
+func (h *PostmortemHandler) Update(c *fiber.Ctx) error {
+	var req dto.UpdatePostmortemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	update := service.PostmortemUpdate{
+		Title:     req.Title,
+		Summary:   req.Summary,
+		Impact:    req.Impact,
+		RootCause: req.RootCause,
+	}
+
+	var err error
+	if req.ActionItems != nil {
+		if update.ActionItems, err = actionItems(req.ActionItems); err != nil {
+			return helper.BadRequest(c, "Invalid action item owner ID")
+		}
+	}
+	if req.AlertIDs != nil {
+		if update.AlertIDs, err = parseIDs(req.AlertIDs); err != nil {
+			return helper.BadRequest(c, "Invalid alert ID")
+		}
+	}
+
+	current, err := h.visiblePostmortem(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update postmortem")
+	}
+
+	postmortem, err := h.postmortemService.Update(c.Context(), current.ID, update, userRole(c), tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to update postmortem")
+	}
+
+	return helper.Success(c, dto.PostmortemFromEntity(postmortem))
+}
+
+// Delete handles DELETE /api/v1/postmortems/:id
+//
+//	@Summary		Delete postmortem
+//	@Description	Remove a postmortem (operator or admin)
+//	@Tags			postmortems
+//	@Param			id	path	string	true	"Postmortem ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/postmortems/{id} [delete]
+func (h *PostmortemHandler) Delete(c *fiber.Ctx) error {
+	postmortem, err := h.visiblePostmortem(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to delete postmortem")
+	}
+
+	if err := h.postmortemService.Delete(c.Context(), postmortem.ID); err != nil {
+		return h.handleError(c, err, "Failed to delete postmortem")
+	}
+
+	return helper.NoContent(c)
+}
+
+// Export handles GET /api/v1/postmortems/:id/export
+//
+//	@Summary		Export postmortem
+//	@Description	Download a postmortem with its incident, action items and alerts as a markdown or PDF document for sharing
+//	@Tags			postmortems
+//	@Produce		text/markdown
+//	@Produce		application/pdf
+//	@Param			id		path		string	true	"Postmortem ID"
+//	@Param			format	query		string	false	"Document format"	Enums(markdown, pdf)	default(markdown)
+//	@Success		200		{file}		binary
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/postmortems/{id}/export [get]
+func (h *PostmortemHandler) Export(c *fiber.Ctx) error {
+	postmortem, err := h.visiblePostmortem(c)
+	if err != nil {
+		return h.handleError(c, err, "Failed to export postmortem")
+	}
+
+	format := c.Query("format", service.ExportFormatMarkdown)
+
+	document, err := h.postmortemService.Export(c.Context(), postmortem, format, userRole(c), tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to export postmortem")
+	}
+
+	c.Set(fiber.HeaderContentType, document.ContentType)
+	c.Set(fiber.HeaderContentDisposition,
+		mime.FormatMediaType("attachment", map[string]string{"filename": document.Filename}))
+	return c.Send(document.Content)
+}
+
+// visiblePostmortem retrieves the postmortem of the path within the tenant
+// scope. Postmortems hidden from the user's teams cannot be changed either.
+func (h *PostmortemHandler) visiblePostmortem(c *fiber.Ctx) (*entity.Postmortem, error) {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return nil, errInvalidPostmortemID
+	}
+
+	return h.postmortemService.GetVisible(c.Context(), id, tenantScope(c))
+}
+
+// handleError maps postmortem service errors to HTTP responses.
+func (h *PostmortemHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, errInvalidPostmortemID):
+		return helper.BadRequest(c, "Invalid postmortem ID")
+	case errors.Is(err, service.ErrPostmortemNotFound):
+		return helper.NotFound(c, "Postmortem not found")
+	case errors.Is(err, service.ErrIncidentNotFound):
+		return helper.NotFound(c, "Incident not found")
+	case errors.Is(err, service.ErrAlertNotFound):
+		return helper.NotFound(c, "Alert not found")
+	case errors.Is(err, service.ErrPostmortemExists):
+		return helper.Conflict(c, err.Error())
+	case errors.Is(err, service.ErrTeamNotFound),
+		errors.Is(err, service.ErrPostmortemOwnerNotFound),
+		errors.Is(err, service.ErrExportFormatInvalid),
+		errors.Is(err, entity.ErrPostmortemTitleRequired),
+		errors.Is(err, entity.ErrPostmortemTitleTooLong),
+		errors.Is(err, entity.ErrPostmortemSectionTooLong),
+		errors.Is(err, entity.ErrPostmortemTooManyActionItems),
+		errors.Is(err, entity.ErrPostmortemActionItemInvalid),
+		errors.Is(err, entity.ErrPostmortemTooManyAlerts):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
+
+// actionItems converts the action items of a request.
+func actionItems(items []dto.PostmortemActionItemRequest) ([]entity.PostmortemActionItem, error) {
+	result := make([]entity.PostmortemActionItem, len(items))
+	for i, item := range items {
+		result[i] = entity.PostmortemActionItem{
+			Description: item.Description,
+			DueDate:     item.DueDate,
+			Done:        item.Done,
+		}
+		if item.OwnerID != "" {
+			ownerID, err := entity.ParseID(item.OwnerID)
+			if err != nil {
+				return nil, err
+			}
+			result[i].OwnerID = &ownerID
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// QuotaHandler handles the daily quotas of teams and webhook sources.
+type QuotaHandler struct {
+	quotaService *service.QuotaService
+}
+
+// NewQuotaHandler creates a new quota handler.
+func NewQuotaHandler(quotaService *service.QuotaService) *QuotaHandler {
+	return &QuotaHandler{quotaService: quotaService}
+}
+
+// List handles GET /api/v1/admin/quotas
+//
+//	@Summary		List quota usage
+//	@Description	List the quotas used today (UTC) by teams and webhook sources, with their limits
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		dto.QuotaUsageResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/quotas [get]
+func (h *QuotaHandler) List(c *fiber.Ctx) error {
+	statuses, err := h.quotaService.ListUsage(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list quota usage")
+		return helper.InternalError(c, "Failed to list quota usage")
+	}
+
+	usage := make([]dto.QuotaUsageResponse, len(statuses))
+	for i, status := range statuses {
+		usage[i] = dto.QuotaUsageResponse{
+			Subject:   status.Subject.String(),
+			Metric:    string(status.Metric),
+			Used:      status.Used,
+			Limit:     status.Limit,
+			Remaining: status.Remaining(),
+			ResetAt:   status.ResetAt,
+		}
+	}
+
+	return helper.Success(c, usage)
+}
+
+// Reset handles DELETE /api/v1/admin/quotas/:subject
+//
+//	@Summary		Reset quota usage
+//	@Description	Clear today's usage of the quotas of a team (team:<id>) or webhook source (source:<id>)
+//	@Tags			admin
+//	@Param			subject	path	string	true	"Subject, e.g. team:6f1c..."
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/quotas/{subject} [delete]
+func (h *QuotaHandler) Reset(c *fiber.Ctx) error {
+	subject, err := valueobject.ParseQuotaSubject(c.Params("subject"))
+	if err != nil {
+		return helper.BadRequest(c, err.Error())
+	}
+
+	if err := h.quotaService.Reset(c.Context(), subject); err != nil {
+		log.Error().Err(err).Str("subject", subject.String()).Msg("Failed to reset quota usage")
+		return helper.InternalError(c, "Failed to reset quota usage")
+	}
+
+	userID, _ := c.Locals("userID").(entity.ID)
+	log.Info().Str("subject", subject.String()).Str("reset_by", userID.String()).Msg("Quota usage reset")
+
+	return helper.NoContent(c)
+}
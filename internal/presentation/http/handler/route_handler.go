@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// RouteHandler handles notification routing tree management.
+type RouteHandler struct {
+	routeService *service.RouteService
+}
+
+// NewRouteHandler creates a new route handler.
+func NewRouteHandler(routeService *service.RouteService) *RouteHandler {
+	return &RouteHandler{routeService: routeService}
+}
+
+// List handles GET /api/v1/routes
+//
+//	@Summary		Get routing tree
+//	@Description	Get the notification routing tree; sibling routes are listed in evaluation order
+//	@Tags			routes
+//	@Produce		json
+//	@Success		200	{array}		dto.RouteResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/routes [get]
+func (h *RouteHandler) List(c *fiber.Ctx) error {
+	routes, err := h.routeService.List(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list routes")
+		return helper.InternalError(c, "Failed to list routes")
+	}
+
+	return helper.Success(c, dto.RouteTreeFromEntities(routes))
+}
+
+// Create handles POST /api/v1/routes
+//
+//	@Summary		Create route
+//	@Description	Add a notification route, at the top level or under a parent route
+//	@Tags			routes
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.RouteRequest	true	"Route"
+//	@Success		201		{object}	dto.RouteResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/routes [post]
+func (h *RouteHandler) Create(c *fiber.Ctx) error {
+	var req dto.RouteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	input, err := routeInput(req)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid route ID")
+	}
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		input.CreatedBy = &userID
+	}
+
+	route, err := h.routeService.Create(c.Context(), input)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create route")
+	}
+
+	return helper.Created(c, dto.RouteFromEntity(route))
+}
+
+// GetByID handles GET /api/v1/routes/:id
+//
+//	@Summary		Get route
+//	@Description	Retrieve a single notification route
+//	@Tags			routes
+//	@Produce		json
+//	@Param			id	path		string	true	"Route ID"
+//	@Success		200	{object}	dto.RouteResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/routes/{id} [get]
+func (h *RouteHandler) GetByID(c *fiber.Ctx) error {
+	routeID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid route ID")
+	}
+
+	route, err := h.routeService.Get(c.Context(), routeID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get route")
+	}
+
+	return helper.Success(c, dto.RouteFromEntity(route))
+}
+
+// Update handles PUT /api/v1/routes/:id
+//
+//	@Summary		Replace route
+//	@Description	Replace a notification route; changing its parent moves its child routes with it
+//	@Tags			routes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Route ID"
+//	@Param			request	body		dto.RouteRequest	true	"Route"
+//	@Success		200		{object}	dto.RouteResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/routes/{id} [put]
+func (h *RouteHandler) Update(c *fiber.Ctx) error {
+	routeID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid route ID")
+	}
+
+	var req dto.RouteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	input, err := routeInput(req)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid route ID")
+	}
+
+	route, err := h.routeService.Update(c.Context(), routeID, input)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update route")
+	}
+
+	return helper.Success(c, dto.RouteFromEntity(route))
+}
+
+// Delete handles DELETE /api/v1/routes/:id
+//
+//	@Summary		Delete route
+//	@Description	Delete a notification route together with its child routes
+//	@Tags			routes
+//	@Param			id	path	string	true	"Route ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/routes/{id} [delete]
+func (h *RouteHandler) Delete(c *fiber.Ctx) error {
+	routeID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid route ID")
+	}
+
+	if err := h.routeService.Delete(c.Context(), routeID); err != nil {
+		return h.handleError(c, err, "Failed to delete route")
+	}
+
+	return helper.NoContent(c)
+}
+
+// GetByExternalID handles GET /api/v1/routes/external/:externalId
+//
+//	@Summary		Get route by external ID
+//	@Description	Retrieve the notification route managed under an external ID, e.g. by Terraform
+//	@Tags			routes
+//	@Produce		json
+//	@Param			externalId	path		string	true	"External ID"
+//	@Success		200			{object}	dto.RouteResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/routes/external/{externalId} [get]
+func (h *RouteHandler) GetByExternalID(c *fiber.Ctx) error {
+	externalID, err := externalIDParam(c)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid external ID")
+	}
+
+	route, err := h.routeService.GetByExternalID(c.Context(), externalID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get route")
+	}
+
+	return helper.Success(c, dto.RouteFromEntity(route))
+}
+
+// UpsertByExternalID handles PUT /api/v1/routes/external/:externalId
+//
+//	@Summary		Create or replace route by external ID
+//	@Description	Create the notification route managed under an external ID, or replace it if it exists, so infrastructure-as-code tools need not track route IDs. The parent may be given by parent_external_id.
+//	@Tags			routes
+//	@Accept			json
+//	@Produce		json
+//	@Param			externalId	path		string				true	"External ID"
+//	@Param			request		body		dto.RouteRequest	true	"Route"
+//	@Success		200			{object}	dto.RouteResponse
+//	@Success		201			{object}	dto.RouteResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/routes/external/{externalId} [put]
+func (h *RouteHandler) UpsertByExternalID(c *fiber.Ctx) error {
+	externalID, err := externalIDParam(c)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid external ID")
+	}
+
+	var req dto.RouteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	input, err := routeInput(req)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid route ID")
+	}
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		input.CreatedBy = &userID
+	}
+
+	route, created, err := h.routeService.UpsertByExternalID(c.Context(), externalID, input)
+	if err != nil {
+		return h.handleError(c, err, "Failed to save route")
+	}
+
+	if created {
+		return helper.Created(c, dto.RouteFromEntity(route))
+	}
+	return helper.Success(c, dto.RouteFromEntity(route))
+}
+
+// DeleteByExternalID handles DELETE /api/v1/routes/external/:externalId
+//
+//	@Summary		Delete route by external ID
+//	@Description	Delete the notification route managed under an external ID together with its child routes
+//	@Tags			routes
+//	@Param			externalId	path	string	true	"External ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/routes/external/{externalId} [delete]
+func (h *RouteHandler) DeleteByExternalID(c *fiber.Ctx) error {
+	externalID, err := externalIDParam(c)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid external ID")
+	}
+
+	if err := h.routeService.DeleteByExternalID(c.Context(), externalID); err != nil {
+		return h.handleError(c, err, "Failed to delete route")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps route service errors to HTTP responses.
+func (h *RouteHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrRouteNotFound):
+		return helper.NotFound(c, "Route not found")
+	case errors.Is(err, service.ErrRouteParentNotFound),
+		errors.Is(err, service.ErrRouteCycle),
+		errors.Is(err, entity.ErrRouteNameRequired),
+		errors.Is(err, entity.ErrRouteNameTooLong),
+		errors.Is(err, entity.ErrRouteChannelInvalid),
+		errors.Is(err, entity.ErrRouteSeverityInvalid),
+		errors.Is(err, entity.ErrRouteParentSelf),
+		errors.Is(err, entity.ErrRouteExternalIDTooLong):
+		return helper.BadRequest(c, err.Error())
+	case errors.Is(err, service.ErrRouteExternalIDConflict):
+		return helper.Conflict(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
+
+// routeInput converts a route request into service input.
+func routeInput(req dto.RouteRequest) (service.RouteInput, error) {
+	input := service.RouteInput{
+		Name:             req.Name,
+		ParentExternalID: req.ParentExternalID,
+		Position:         req.Position,
+		Channels:         req.Channels,
+		Continue:         req.Continue,
+		Match: entity.RouteMatcher{
+			Sources: req.Match.Sources,
+			Labels:  req.Match.Labels,
+		},
+	}
+
+	for _, severity := range req.Match.Severities {
+		input.Match.Severities = append(input.Match.Severities, entity.AlertSeverity(severity))
+	}
+
+	if req.ParentID != "" {
+		parentID, err := entity.ParseID(req.ParentID)
+		if err != nil {
+			return service.RouteInput{}, err
+		}
+		input.ParentID = &parentID
+	}
+
+	if req.Match.TeamID != "" {
+		teamID, err := entity.ParseID(req.Match.TeamID)
+		if err != nil {
+			return service.RouteInput{}, err
+		}
+		input.Match.TeamID = &teamID
+	}
+
+	return input, nil
+}
@@ -0,0 +1,430 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// RuleHandler handles declarative alert rule management and evaluation.
+type RuleHandler struct {
+	ruleService *service.RuleService
+	ruleEngine  *service.RuleEngine
+}
+
+// NewRuleHandler creates a new rule handler.
+func NewRuleHandler(ruleService *service.RuleService, ruleEngine *service.RuleEngine) *RuleHandler {
+	return &RuleHandler{
+		ruleService: ruleService,
+		ruleEngine:  ruleEngine,
+	}
+}
+
+// Apply handles POST /api/v1/rules/apply
+//
+//	@Summary		Apply rule bundle
+//	@Description	Make the alert rules and notification routes match a YAML or JSON bundle. Each section present is authoritative: rules or routes it does not declare are deleted; an omitted section is left untouched. Resources are matched by name, and applying the same bundle again changes nothing. With dry_run=true the changes are only reported.
+//	@Tags			rules
+//	@Accept			json
+//	@Accept			application/yaml
+//	@Produce		json
+//	@Param			request	body		dto.RuleBundleRequest	true	"Bundle"
+//	@Param			dry_run	query		bool					false	"Report the changes without applying them"
+//	@Success		200		{object}	dto.RuleBundleResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/rules/apply [post]
+func (h *RuleHandler) Apply(c *fiber.Ctx) error {
+	req, err := parseBundle(c)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid bundle: "+err.Error())
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	bundle, err := bundleFromRequest(req)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid team ID")
+	}
+
+	var appliedBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		appliedBy = &userID
+	}
+
+	report, err := h.ruleService.Apply(c.Context(), bundle, c.QueryBool("dry_run"), appliedBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBundleInvalid):
+			return helper.BadRequest(c, err.Error())
+		case errors.Is(err, service.ErrBundleAmbiguous):
+			return helper.Conflict(c, err.Error())
+		}
+		log.Error().Err(err).Msg("Failed to apply rule bundle")
+		return helper.InternalError(c, "Failed to apply rule bundle")
+	}
+
+	return helper.Success(c, bundleResponse(report))
+}
+
+// Evaluate handles POST /api/v1/rules/evaluate
+//
+//	@Summary		Evaluate rules
+//	@Description	Evaluate the enabled alert rules against metric samples. Each rule is evaluated once per series sampled, against the last sample of each of its metrics with the same labels. A rule fires, creating an alert, once its condition held for `consecutive` evaluations in a row of a series (labels), then not again for that series until its cooldown has passed, across every API instance.
+//	@Tags			rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.EvaluateRulesRequest	true	"Samples"
+//	@Success		200		{object}	dto.EvaluateRulesResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/rules/evaluate [post]
+func (h *RuleHandler) Evaluate(c *fiber.Ctx) error {
+	var req dto.EvaluateRulesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	samples := make([]service.MetricSample, 0, len(req.Samples))
+	for _, s := range req.Samples {
+		samples = append(samples, service.MetricSample{Metric: s.Metric, Value: *s.Value, Labels: s.Labels})
+	}
+
+	evaluations, err := h.ruleEngine.Evaluate(c.Context(), samples)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to evaluate rules")
+		return helper.InternalError(c, "Failed to evaluate rules")
+	}
+
+	response := dto.EvaluateRulesResponse{Evaluations: make([]dto.RuleEvaluationResponse, 0, len(evaluations))}
+	for _, e := range evaluations {
+		item := dto.RuleEvaluationResponse{
+			RuleID:  e.Rule.ID.String(),
+			Rule:    e.Rule.Name,
+			Metric:  e.Sample.Metric,
+			Value:   e.Sample.Value,
+			Labels:  e.Sample.Labels,
+			Outcome: e.Outcome,
+		}
+		if e.Alert != nil {
+			item.AlertID = e.Alert.ID.String()
+			response.Fired++
+		}
+		response.Evaluations = append(response.Evaluations, item)
+	}
+
+	return helper.Success(c, response)
+}
+
+// GetByExternalID handles GET /api/v1/rules/external/:externalId
+//
+//	@Summary		Get rule by external ID
+//	@Description	Retrieve the alert rule managed under an external ID, e.g. by Terraform
+//	@Tags			rules
+//	@Produce		json
+//	@Param			externalId	path		string	true	"External ID"
+//	@Success		200			{object}	dto.RuleResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/rules/external/{externalId} [get]
+func (h *RuleHandler) GetByExternalID(c *fiber.Ctx) error {
+	externalID, err := externalIDParam(c)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid external ID")
+	}
+
+	rule, err := h.ruleService.GetByExternalID(c.Context(), externalID, tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to get rule")
+	}
+
+	return helper.Success(c, dto.RuleFromEntity(rule))
+}
+
+// UpsertByExternalID handles PUT /api/v1/rules/external/:externalId
+//
+//	@Summary		Create or replace rule by external ID
+//	@Description	Create the alert rule managed under an external ID, or replace it if it exists, so infrastructure-as-code tools need not track rule IDs. Enabled defaults to true and cooldown_minutes to 5. Operators may only replace the rules they created, and only for their own teams.
+//	@Tags			rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			externalId	path		string				true	"External ID"
+//	@Param			request		body		dto.RuleSpecRequest	true	"Rule"
+//	@Success		200			{object}	dto.RuleResponse
+//	@Success		201			{object}	dto.RuleResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/rules/external/{externalId} [put]
+func (h *RuleHandler) UpsertByExternalID(c *fiber.Ctx) error {
+	externalID, err := externalIDParam(c)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid external ID")
+	}
+
+	var req dto.RuleSpecRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	spec, err := ruleSpec(req)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid team ID")
+	}
+	if !tenantScope(c).Allows(spec.TeamID) {
+		return helper.Forbidden(c, "Not a member of the team")
+	}
+
+	actor, ok := ruleActor(c)
+	if !ok {
+		return helper.Unauthorized(c, "User ID not found in context")
+	}
+
+	rule, created, err := h.ruleService.UpsertByExternalID(c.Context(), externalID, spec, tenantScope(c), actor)
+	if err != nil {
+		return h.handleError(c, err, "Failed to save rule")
+	}
+
+	if created {
+		return helper.Created(c, dto.RuleFromEntity(rule))
+	}
+	return helper.Success(c, dto.RuleFromEntity(rule))
+}
+
+// DeleteByExternalID handles DELETE /api/v1/rules/external/:externalId
+//
+//	@Summary		Delete rule by external ID
+//	@Description	Delete the alert rule managed under an external ID. Operators may only delete the rules they created. Rules of other teams are reported as not found.
+//	@Tags			rules
+//	@Param			externalId	path	string	true	"External ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/rules/external/{externalId} [delete]
+func (h *RuleHandler) DeleteByExternalID(c *fiber.Ctx) error {
+	externalID, err := externalIDParam(c)
+	if err != nil {
+		return helper.BadRequest(c, "Invalid external ID")
+	}
+
+	actor, ok := ruleActor(c)
+	if !ok {
+		return helper.Unauthorized(c, "User ID not found in context")
+	}
+
+	if err := h.ruleService.DeleteByExternalID(c.Context(), externalID, tenantScope(c), actor); err != nil {
+		return h.handleError(c, err, "Failed to delete rule")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps rule service errors to HTTP responses.
+func (h *RuleHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrRuleNotFound):
+		return helper.NotFound(c, "Rule not found")
+	case errors.Is(err, service.ErrRuleForbidden),
+		errors.Is(err, service.ErrRuleOtherTeam):
+		return helper.Forbidden(c, err.Error())
+	case errors.Is(err, service.ErrRuleExternalIDConflict):
+		return helper.Conflict(c, err.Error())
+	case errors.Is(err, service.ErrTeamNotFound),
+		errors.Is(err, entity.ErrRuleNameRequired),
+		errors.Is(err, entity.ErrRuleNameTooLong),
+		errors.Is(err, entity.ErrRuleInvalidSeverity),
+		errors.Is(err, entity.ErrRuleInvalidCooldown),
+		errors.Is(err, entity.ErrRuleInvalidOperator),
+		errors.Is(err, entity.ErrRuleMetricRequired),
+		errors.Is(err, entity.ErrRuleInvalidConditionType),
+		errors.Is(err, entity.ErrRuleConditionOperands),
+		errors.Is(err, entity.ErrRuleConditionTooDeep),
+		errors.Is(err, entity.ErrRuleAbsenceWindowInvalid),
+		errors.Is(err, entity.ErrRuleAnomalyInvalid),
+		errors.Is(err, entity.ErrRuleExternalIDTooLong),
+		errors.Is(err, entity.ErrRuleInvalidRunbookURL),
+		errors.Is(err, entity.ErrRuleRemediationTooLong):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
+
+// ruleActor returns the authenticated user as seen by the ownership policy.
+func ruleActor(c *fiber.Ctx) (valueobject.Actor, bool) {
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return valueobject.Actor{}, false
+	}
+	return valueobject.NewActor(userID, userRole(c)), true
+}
+
+// externalIDParam returns the unescaped externalId path parameter, so that
+// external IDs may contain slashes encoded as %2F.
+func externalIDParam(c *fiber.Ctx) (string, error) {
+	externalID, err := url.PathUnescape(c.Params("externalId"))
+	if err != nil {
+		return "", err
+	}
+	if externalID == "" {
+		return "", errors.New("external ID is required")
+	}
+	return externalID, nil
+}
+
+// parseBundle decodes the bundle from YAML, or from JSON unless the content
+// type names YAML. Unknown fields are rejected so that typos are not ignored.
+func parseBundle(c *fiber.Ctx) (dto.RuleBundleRequest, error) {
+	var req dto.RuleBundleRequest
+	if strings.Contains(c.Get(fiber.HeaderContentType), "yaml") {
+		decoder := yaml.NewDecoder(bytes.NewReader(c.Body()))
+		decoder.KnownFields(true)
+		err := decoder.Decode(&req)
+		return req, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(c.Body()))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&req)
+	return req, err
+}
+
+// bundleFromRequest converts a bundle request into service input.
+func bundleFromRequest(req dto.RuleBundleRequest) (service.Bundle, error) {
+	var bundle service.Bundle
+
+	if req.Rules != nil {
+		rules := make([]service.RuleSpec, len(*req.Rules))
+		for i, spec := range *req.Rules {
+			rule, err := ruleSpec(spec)
+			if err != nil {
+				return service.Bundle{}, err
+			}
+			rules[i] = rule
+		}
+		bundle.Rules = &rules
+	}
+
+	if req.Routes != nil {
+		routes := make([]service.RouteSpec, len(*req.Routes))
+		for i, spec := range *req.Routes {
+			input, err := routeInput(dto.RouteRequest{Name: spec.Name, Match: spec.Match})
+			if err != nil {
+				return service.Bundle{}, err
+			}
+			routes[i] = service.RouteSpec{
+				Name:     spec.Name,
+				Parent:   spec.Parent,
+				Position: i,
+				Match:    input.Match,
+				Channels: spec.Channels,
+				Continue: spec.Continue,
+			}
+			if spec.Position != nil {
+				routes[i].Position = *spec.Position
+			}
+		}
+		bundle.Routes = &routes
+	}
+
+	return bundle, nil
+}
+
+// ruleSpec converts a rule request into service input, applying the defaults.
+func ruleSpec(req dto.RuleSpecRequest) (service.RuleSpec, error) {
+	spec := service.RuleSpec{
+		Name:            req.Name,
+		Description:     req.Description,
+		Condition:       ruleCondition(req.Condition),
+		Severity:        entity.AlertSeverity(req.Severity),
+		Enabled:         true,
+		CooldownMinutes: 5,
+		RunbookURL:      req.RunbookURL,
+		Remediation:     req.Remediation,
+		CreateTicket:    req.CreateTicket,
+	}
+	if req.Enabled != nil {
+		spec.Enabled = *req.Enabled
+	}
+	if req.CooldownMinutes != nil {
+		spec.CooldownMinutes = *req.CooldownMinutes
+	}
+	if req.TeamID != "" {
+		teamID, err := entity.ParseID(req.TeamID)
+		if err != nil {
+			return service.RuleSpec{}, err
+		}
+		spec.TeamID = &teamID
+	}
+	return spec, nil
+}
+
+// ruleCondition converts a condition request, and its operands, into a
+// domain condition.
+func ruleCondition(req dto.RuleConditionRequest) entity.RuleCondition {
+	condition := entity.RuleCondition{
+		Type:          req.Type,
+		Metric:        req.Metric,
+		Operator:      req.Operator,
+		Threshold:     req.Threshold,
+		Deviations:    req.Deviations,
+		WindowSeconds: req.WindowSeconds,
+		ForSeconds:    req.ForSeconds,
+		Consecutive:   req.Consecutive,
+	}
+	for _, operand := range req.Conditions {
+		condition.Conditions = append(condition.Conditions, ruleCondition(operand))
+	}
+	return condition
+}
+
+// bundleResponse converts a bundle report into a response DTO.
+func bundleResponse(report *service.BundleReport) dto.RuleBundleResponse {
+	response := dto.RuleBundleResponse{
+		DryRun:    report.DryRun,
+		Created:   report.Count(service.BundleActionCreate),
+		Updated:   report.Count(service.BundleActionUpdate),
+		Deleted:   report.Count(service.BundleActionDelete),
+		Unchanged: report.Unchanged,
+		Changes:   make([]dto.BundleChangeResponse, len(report.Changes)),
+	}
+	for i, change := range report.Changes {
+		response.Changes[i] = dto.BundleChangeResponse{Kind: change.Kind, Name: change.Name, Action: change.Action}
+	}
+	return response
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// SeverityRuleHandler handles the rules re-classifying the severity of new alerts.
+type SeverityRuleHandler struct {
+	ruleService *service.SeverityRuleService
+}
+
+// NewSeverityRuleHandler creates a new severity rule handler.
+func NewSeverityRuleHandler(ruleService *service.SeverityRuleService) *SeverityRuleHandler {
+	return &SeverityRuleHandler{ruleService: ruleService}
+}
+
+// Create handles POST /api/v1/severity-rules
+//
+//	@Summary		Create severity rule
+//	@Description	Create a rule changing the severity of the alerts it matches when they are ingested. Rules are evaluated by position and the first matching enabled rule applies.
+//	@Tags			severity-rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateSeverityRuleRequest	true	"Rule data"
+//	@Success		201		{object}	dto.SeverityRuleResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/severity-rules [post]
+func (h *SeverityRuleHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateSeverityRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	var createdBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &userID
+	}
+
+	rule, err := h.ruleService.Create(c.Context(), service.SeverityRuleInput{
+		Name:     req.Name,
+		Position: req.Position,
+		Match:    req.Match.ToEntity(),
+		Severity: entity.AlertSeverity(req.Severity),
+	}, createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create severity rule")
+	}
+
+	return helper.Created(c, dto.SeverityRuleFromEntity(rule))
+}
+
+// List handles GET /api/v1/severity-rules
+//
+//	@Summary		List severity rules
+//	@Description	Retrieve every severity rule in evaluation order
+//	@Tags			severity-rules
+//	@Produce		json
+//	@Success		200	{array}		dto.SeverityRuleResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/severity-rules [get]
+func (h *SeverityRuleHandler) List(c *fiber.Ctx) error {
+	rules, err := h.ruleService.List(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list severity rules")
+		return helper.InternalError(c, "Failed to list severity rules")
+	}
+
+	return helper.Success(c, dto.SeverityRulesFromEntities(rules))
+}
+
+// GetByID handles GET /api/v1/severity-rules/:id
+//
+//	@Summary		Get severity rule
+//	@Description	Retrieve a severity rule
+//	@Tags			severity-rules
+//	@Produce		json
+//	@Param			id	path		string	true	"Severity rule ID"
+//	@Success		200	{object}	dto.SeverityRuleResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/severity-rules/{id} [get]
+func (h *SeverityRuleHandler) GetByID(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid severity rule ID")
+	}
+
+	rule, err := h.ruleService.Get(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get severity rule")
+	}
+
+	return helper.Success(c, dto.SeverityRuleFromEntity(rule))
+}
+
+// Update handles PATCH /api/v1/severity-rules/:id
+//
+//	@Summary		Update severity rule
+//	@Description	Change, reorder, enable or disable a severity rule
+//	@Tags			severity-rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Severity rule ID"
+//	@Param			request	body		dto.UpdateSeverityRuleRequest	true	"Changes"
+//	@Success		200		{object}	dto.SeverityRuleResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/severity-rules/{id} [patch]
+func (h *SeverityRuleHandler) Update(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid severity rule ID")
+	}
+
+	var req dto.UpdateSeverityRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	update := service.SeverityRuleUpdate{
+		Name:      req.Name,
+		Position:  req.Position,
+		IsEnabled: req.IsEnabled,
+	}
+	if req.Match != nil {
+		match := req.Match.ToEntity()
+		update.Match = &match
+	}
+	if req.Severity != nil {
+		severity := entity.AlertSeverity(*req.Severity)
+		update.Severity = &severity
+	}
+
+	rule, err := h.ruleService.Update(c.Context(), id, update)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update severity rule")
+	}
+
+	return helper.Success(c, dto.SeverityRuleFromEntity(rule))
+}
+
+// Delete handles DELETE /api/v1/severity-rules/:id
+//
+//	@Summary		Delete severity rule
+//	@Description	Remove a severity rule
+//	@Tags			severity-rules
+//	@Param			id	path	string	true	"Severity rule ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/severity-rules/{id} [delete]
+func (h *SeverityRuleHandler) Delete(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid severity rule ID")
+	}
+
+	if err := h.ruleService.Delete(c.Context(), id); err != nil {
+		return h.handleError(c, err, "Failed to delete severity rule")
+	}
+
+	return helper.NoContent(c)
+}
+
+// DryRun handles POST /api/v1/severity-rules/dry-run
+//
+//	@Summary		Evaluate severity rules
+//	@Description	Evaluate a sample alert against the current severity rules without creating it, returning the rule that would apply and the resulting severity
+//	@Tags			severity-rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.SeverityRuleDryRunRequest	true	"Sample alert"
+//	@Success		200		{object}	dto.SeverityRuleDryRunResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/severity-rules/dry-run [post]
+func (h *SeverityRuleHandler) DryRun(c *fiber.Ctx) error {
+	var req dto.SeverityRuleDryRunRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	evaluation, err := h.ruleService.DryRun(c.Context(), service.SeverityRuleSample{
+		Title:    req.Title,
+		Source:   req.Source,
+		Severity: entity.AlertSeverity(req.Severity),
+		Labels:   req.Labels,
+	})
+	if err != nil {
+		return h.handleError(c, err, "Failed to evaluate severity rules")
+	}
+
+	response := dto.SeverityRuleDryRunResponse{
+		Matched:          evaluation.Rule != nil,
+		OriginalSeverity: req.Severity,
+		Severity:         string(evaluation.Severity),
+	}
+	if evaluation.Rule != nil {
+		rule := dto.SeverityRuleFromEntity(evaluation.Rule)
+		response.Rule = &rule
+	}
+
+	return helper.Success(c, response)
+}
+
+// handleError maps severity rule service errors to HTTP responses.
+func (h *SeverityRuleHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrSeverityRuleNotFound):
+		return helper.NotFound(c, "Severity rule not found")
+	case errors.Is(err, service.ErrSeverityRuleExists):
+		return helper.Conflict(c, "Severity rule with this name already exists")
+	case errors.Is(err, entity.ErrSeverityRuleNameRequired),
+		errors.Is(err, entity.ErrSeverityRuleNameTooLong),
+		errors.Is(err, entity.ErrSeverityRuleMatchRequired),
+		errors.Is(err, entity.ErrSeverityRuleInvalidMatchSeverity),
+		errors.Is(err, entity.ErrSeverityRuleInvalidPattern),
+		errors.Is(err, entity.ErrSeverityRuleInvalidLabels),
+		errors.Is(err, entity.ErrSeverityRuleInvalidSeverity):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// defaultSLAReportRange is the period of SLA reports without a start.
+const defaultSLAReportRange = 30 * 24 * time.Hour
+
+// SLAHandler handles SLA policy management and compliance reports.
+type SLAHandler struct {
+	slaService *service.SLAService
+	pagination valueobject.PaginationPolicy
+}
+
+// NewSLAHandler creates a new SLA handler.
+func NewSLAHandler(slaService *service.SLAService, pagination valueobject.PaginationPolicy) *SLAHandler {
+	return &SLAHandler{
+		slaService: slaService,
+		pagination: pagination,
+	}
+}
+
+// Create handles POST /api/v1/admin/sla-policies
+//
+//	@Summary		Create SLA policy
+//	@Description	Set how quickly the alerts of a severity, team and/or source must be acknowledged and resolved. Alerts created from now on that miss a target raise an "SLA breached" alert.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateSLAPolicyRequest	true	"SLA policy data"
+//	@Success		201		{object}	dto.SLAPolicyResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/sla-policies [post]
+func (h *SLAHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateSLAPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	input := service.SLAPolicyInput{
+		Name:                 req.Name,
+		Severity:             entity.AlertSeverity(req.Severity),
+		Source:               req.Source,
+		AckWithinSeconds:     req.AckWithinSeconds,
+		ResolveWithinSeconds: req.ResolveWithinSeconds,
+		BreachSeverity:       entity.AlertSeverityHigh,
+	}
+	if req.BreachSeverity != "" {
+		input.BreachSeverity = entity.AlertSeverity(req.BreachSeverity)
+	}
+	if req.TeamID != "" {
+		teamID, err := entity.ParseID(req.TeamID)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid team ID")
+		}
+		input.TeamID = &teamID
+	}
+
+	policy, err := h.slaService.Create(c.Context(), input, actor(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to create SLA policy")
+	}
+
+	return helper.Created(c, dto.SLAPolicyFromEntity(policy))
+}
+
+// List handles GET /api/v1/admin/sla-policies
+//
+//	@Summary		List SLA policies
+//	@Description	Retrieve SLA policies
+//	@Tags			admin
+//	@Produce		json
+//	@Param			page		query		int	false	"Page number"		default(1)
+//	@Param			page_size	query		int	false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.SLAPolicyResponse]
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/sla-policies [get]
+func (h *SLAHandler) List(c *fiber.Ctx) error {
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.slaService.List(c.Context(), pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list SLA policies")
+		return helper.InternalError(c, "Failed to list SLA policies")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.SLAPolicyResponse]{
+		Items:       dto.SLAPoliciesFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// GetByID handles GET /api/v1/admin/sla-policies/:id
+//
+//	@Summary		Get SLA policy
+//	@Description	Retrieve an SLA policy
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"SLA policy ID"
+//	@Success		200	{object}	dto.SLAPolicyResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/sla-policies/{id} [get]
+func (h *SLAHandler) GetByID(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid SLA policy ID")
+	}
+
+	policy, err := h.slaService.GetByID(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get SLA policy")
+	}
+
+	return helper.Success(c, dto.SLAPolicyFromEntity(policy))
+}
+
+// Update handles PATCH /api/v1/admin/sla-policies/:id
+//
+//	@Summary		Update SLA policy
+//	@Description	Change the targets or filters of an SLA policy, or pause it by disabling it. Breaches already raised are kept.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"SLA policy ID"
+//	@Param			request	body		dto.UpdateSLAPolicyRequest	true	"Changes"
+//	@Success		200		{object}	dto.SLAPolicyResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/sla-policies/{id} [patch]
+func (h *SLAHandler) Update(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid SLA policy ID")
+	}
+
+	var req dto.UpdateSLAPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	update := service.SLAPolicyUpdate{
+		Name:                 req.Name,
+		Source:               req.Source,
+		AckWithinSeconds:     req.AckWithinSeconds,
+		ResolveWithinSeconds: req.ResolveWithinSeconds,
+		IsEnabled:            req.IsEnabled,
+	}
+	if req.Severity != nil {
+		severity := entity.AlertSeverity(*req.Severity)
+		update.Severity = &severity
+	}
+	if req.BreachSeverity != nil {
+		severity := entity.AlertSeverity(*req.BreachSeverity)
+		update.BreachSeverity = &severity
+	}
+
+	policy, err := h.slaService.Update(c.Context(), id, update)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update SLA policy")
+	}
+
+	return helper.Success(c, dto.SLAPolicyFromEntity(policy))
+}
+
+// Delete handles DELETE /api/v1/admin/sla-policies/:id
+//
+//	@Summary		Delete SLA policy
+//	@Description	Remove an SLA policy and the record of its breaches
+//	@Tags			admin
+//	@Param			id	path	string	true	"SLA policy ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/sla-policies/{id} [delete]
+func (h *SLAHandler) Delete(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid SLA policy ID")
+	}
+
+	if err := h.slaService.Delete(c.Context(), id); err != nil {
+		return h.handleError(c, err, "Failed to delete SLA policy")
+	}
+
+	return helper.NoContent(c)
+}
+
+// Report handles GET /api/v1/reports/sla
+//
+//	@Summary		SLA compliance report
+//	@Description	Report how the alerts created within a period met the targets of the enabled SLA policies, or of one policy: how many met, breached or are still within each target, the compliance percentage and the mean time to acknowledge and resolve. Only the alerts visible to the caller are counted.
+//	@Tags			reports
+//	@Produce		json
+//	@Param			from		query		string	false	"Start of the period (RFC3339), 30 days before its end by default"
+//	@Param			to			query		string	false	"End of the period (RFC3339), now by default"
+//	@Param			policy_id	query		string	false	"Report on this policy only"
+//	@Success		200			{object}	dto.SLAReportResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/reports/sla [get]
+func (h *SLAHandler) Report(c *fiber.Ctx) error {
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid to date, expected RFC3339")
+		}
+		to = parsed
+	}
+
+	from := to.Add(-defaultSLAReportRange)
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid from date, expected RFC3339")
+		}
+		from = parsed
+	}
+
+	var policyID *entity.ID
+	if raw := c.Query("policy_id"); raw != "" {
+		id, err := entity.ParseID(raw)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid SLA policy ID")
+		}
+		policyID = &id
+	}
+
+	reports, err := h.slaService.Report(c.Context(), policyID, from, to, userRole(c), tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to build SLA report")
+	}
+
+	response := dto.SLAReportResponse{
+		From:     from,
+		To:       to,
+		Policies: make([]dto.SLAPolicyReportResponse, len(reports)),
+	}
+	for i, report := range reports {
+		response.Policies[i] = dto.SLAPolicyReportFromCompliance(report.Policy, report.Compliance)
+	}
+
+	return helper.Success(c, response)
+}
+
+// handleError maps SLA service errors to HTTP responses.
+func (h *SLAHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrSLAPolicyNotFound):
+		return helper.NotFound(c, "SLA policy not found")
+	case errors.Is(err, service.ErrSLAPolicyExists):
+		return helper.Conflict(c, "SLA policy with this name already exists")
+	case errors.Is(err, service.ErrTeamNotFound),
+		errors.Is(err, service.ErrSLAReportRangeInvalid),
+		errors.Is(err, entity.ErrSLAPolicyNameRequired),
+		errors.Is(err, entity.ErrSLAPolicyNameTooLong),
+		errors.Is(err, entity.ErrSLAPolicyTargetRequired),
+		errors.Is(err, entity.ErrSLAPolicyInvalidTarget),
+		errors.Is(err, entity.ErrSLAPolicyInvalidSeverity):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// slackEphemeral is the response type of replies shown only to their user.
+const slackEphemeral = "ephemeral"
+
+// Slack request headers.
+const (
+	slackSignatureHeader = "X-Slack-Signature"
+	slackTimestampHeader = "X-Slack-Request-Timestamp"
+)
+
+// slackInteractionBlockActions is the type of the interactions sent for
+// button clicks.
+const slackInteractionBlockActions = "block_actions"
+
+// SlackHandler handles requests sent by the Slack app.
+type SlackHandler struct {
+	slackService *service.SlackService
+}
+
+// NewSlackHandler creates a new Slack handler.
+func NewSlackHandler(slackService *service.SlackService) *SlackHandler {
+	return &SlackHandler{slackService: slackService}
+}
+
+// Actions handles POST /api/v1/integrations/slack/actions
+//
+//	@Summary		Slack interactive actions
+//	@Description	Receive the clicks on the Acknowledge and Resolve buttons of Slack alert messages, signed with the signing secret of the Slack app. The alert is changed on behalf of the user linked to the Slack account, who must be an operator or admin, and the result is replied to them in Slack.
+//	@Tags			integrations
+//	@Accept			x-www-form-urlencoded
+//	@Param			payload						formData	string	true	"Interaction payload (JSON)"
+//	@Param			X-Slack-Signature			header		string	true	"v0= hex HMAC-SHA256 of v0:<timestamp>:<body>"
+//	@Param			X-Slack-Request-Timestamp	header		string	true	"Unix timestamp of the request"
+//	@Success		200
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/integrations/slack/actions [post]
+func (h *SlackHandler) Actions(c *fiber.Ctx) error {
+	if err := h.slackService.Verify(c.Get(slackTimestampHeader), c.Get(slackSignatureHeader), c.Body()); err != nil {
+		return h.verifyError(c, err)
+	}
+
+	var payload dto.SlackInteractionPayload
+	if err := json.Unmarshal([]byte(c.FormValue("payload")), &payload); err != nil {
+		return helper.BadRequest(c, "Invalid interaction payload")
+	}
+
+	// Other interactions and clicks are acknowledged without effect
+	if payload.Type != slackInteractionBlockActions {
+		return c.SendStatus(fiber.StatusOK)
+	}
+
+	for _, action := range payload.Actions {
+		// Failures are replied to the user in Slack
+		alert, err := h.slackService.HandleAction(c.Context(), service.SlackAction{
+			UserID:      payload.User.ID,
+			ActionID:    action.ActionID,
+			AlertID:     action.Value,
+			ResponseURL: payload.ResponseURL,
+		})
+		if err != nil {
+			log.Warn().Err(err).
+				Str("slack_user", payload.User.ID).
+				Str("action_id", action.ActionID).
+				Msg("Slack action failed")
+			continue
+		}
+
+		log.Info().
+			Str("alert_id", alert.ID.String()).
+			Str("slack_user", payload.User.ID).
+			Str("action_id", action.ActionID).
+			Msg("Slack action performed")
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// Commands handles POST /api/v1/integrations/slack/commands
+//
+//	@Summary		Slack slash commands
+//	@Description	Run an "/alerts" slash command of the Slack app, signed with its signing secret: "list" lists the open alerts, "ack <id>" and "resolve <id>" change an alert, and "silence <source> <duration>" suppresses the notifications of a source (e.g. 2h or 1d). Commands run on behalf of the user linked to the Slack account; changes need an operator or admin. The reply is shown only to that user.
+//	@Tags			integrations
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			user_id						formData	string	true	"Slack user ID"
+//	@Param			text						formData	string	false	"Command arguments"
+//	@Param			X-Slack-Signature			header		string	true	"v0= hex HMAC-SHA256 of v0:<timestamp>:<body>"
+//	@Param			X-Slack-Request-Timestamp	header		string	true	"Unix timestamp of the request"
+//	@Success		200	{object}	dto.SlackCommandResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/integrations/slack/commands [post]
+func (h *SlackHandler) Commands(c *fiber.Ctx) error {
+	if err := h.slackService.Verify(c.Get(slackTimestampHeader), c.Get(slackSignatureHeader), c.Body()); err != nil {
+		return h.verifyError(c, err)
+	}
+
+	cmd := service.SlackCommand{
+		UserID: c.FormValue("user_id"),
+		Text:   c.FormValue("text"),
+	}
+
+	// Failures are replied to the user in Slack
+	reply, err := h.slackService.HandleCommand(c.Context(), cmd)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("slack_user", cmd.UserID).
+			Str("command", cmd.Text).
+			Msg("Slack command failed")
+	}
+
+	return helper.Success(c, dto.SlackCommandResponse{ResponseType: slackEphemeral, Text: reply})
+}
+
+// verifyError maps the failed verification of a Slack request to an HTTP response.
+func (h *SlackHandler) verifyError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, service.ErrSlackNotConfigured) {
+		return helper.NotFound(c, "Slack integration is not configured")
+	}
+	return helper.Unauthorized(c, err.Error())
+}
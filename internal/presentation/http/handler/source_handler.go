@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// SourceHandler handles the registry of alert sources.
+type SourceHandler struct {
+	sourceService *service.SourceService
+	pagination    valueobject.PaginationPolicy
+}
+
+// NewSourceHandler creates a new source handler.
+func NewSourceHandler(sourceService *service.SourceService, pagination valueobject.PaginationPolicy) *SourceHandler {
+	return &SourceHandler{
+		sourceService: sourceService,
+		pagination:    pagination,
+	}
+}
+
+// Create handles POST /api/v1/sources
+//
+//	@Summary		Register source
+//	@Description	Register an integration sending alerts, matched to its alerts by name. With an expected heartbeat, an alert is raised when the source sends no alert for that long.
+//	@Tags			sources
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateSourceRequest	true	"Source data"
+//	@Success		201		{object}	dto.SourceResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/sources [post]
+func (h *SourceHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateSourceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	input := service.SourceInput{
+		Name:                     req.Name,
+		Type:                     entity.SourceType(req.Type),
+		Description:              req.Description,
+		ExpectedHeartbeatSeconds: req.ExpectedHeartbeatSeconds,
+		Severity:                 entity.AlertSeverityHigh,
+	}
+	if req.Severity != "" {
+		input.Severity = entity.AlertSeverity(req.Severity)
+	}
+	if req.TeamID != "" {
+		teamID, err := entity.ParseID(req.TeamID)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid team ID")
+		}
+		input.TeamID = &teamID
+	}
+
+	var createdBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &userID
+	}
+
+	source, err := h.sourceService.Create(c.Context(), input, createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to register source")
+	}
+
+	return helper.Created(c, dto.SourceFromEntity(source))
+}
+
+// List handles GET /api/v1/sources
+//
+//	@Summary		List sources
+//	@Description	Retrieve the registered sources with their status, last alert time and alerts sent in the last hour and day
+//	@Tags			sources
+//	@Produce		json
+//	@Param			page		query		int	false	"Page number"		default(1)
+//	@Param			page_size	query		int	false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.SourceResponse]
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/sources [get]
+func (h *SourceHandler) List(c *fiber.Ctx) error {
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.sourceService.List(c.Context(), tenantScope(c), pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list sources")
+		return helper.InternalError(c, "Failed to list sources")
+	}
+
+	items := make([]dto.SourceResponse, len(result.Items))
+	for i, health := range result.Items {
+		items[i] = sourceHealthResponse(health)
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.SourceResponse]{
+		Items:       items,
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// GetByID handles GET /api/v1/sources/:id
+//
+//	@Summary		Get source
+//	@Description	Retrieve a registered source with its status, last alert time and alert rates
+//	@Tags			sources
+//	@Produce		json
+//	@Param			id	path		string	true	"Source ID"
+//	@Success		200	{object}	dto.SourceResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/sources/{id} [get]
+func (h *SourceHandler) GetByID(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid source ID")
+	}
+
+	health, err := h.sourceService.Get(c.Context(), id, tenantScope(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to get source")
+	}
+
+	return helper.Success(c, sourceHealthResponse(*health))
+}
+
+// Update handles PATCH /api/v1/sources/:id
+//
+//	@Summary		Update source
+//	@Description	Change the settings of a source; an expected heartbeat of 0 stops watching it for silence
+//	@Tags			sources
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Source ID"
+//	@Param			request	body		dto.UpdateSourceRequest	true	"Changes"
+//	@Success		200		{object}	dto.SourceResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/sources/{id} [patch]
+func (h *SourceHandler) Update(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid source ID")
+	}
+
+	var req dto.UpdateSourceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	update := service.SourceUpdate{
+		Name:                     req.Name,
+		Description:              req.Description,
+		ExpectedHeartbeatSeconds: req.ExpectedHeartbeatSeconds,
+	}
+	if req.Type != nil {
+		sourceType := entity.SourceType(*req.Type)
+		update.Type = &sourceType
+	}
+	if req.Severity != nil {
+		severity := entity.AlertSeverity(*req.Severity)
+		update.Severity = &severity
+	}
+
+	source, err := h.sourceService.Update(c.Context(), id, update)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update source")
+	}
+
+	return helper.Success(c, dto.SourceFromEntity(source))
+}
+
+// Delete handles DELETE /api/v1/sources/:id
+//
+//	@Summary		Delete source
+//	@Description	Remove a source from the registry
+//	@Tags			sources
+//	@Param			id	path	string	true	"Source ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/sources/{id} [delete]
+func (h *SourceHandler) Delete(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid source ID")
+	}
+
+	if err := h.sourceService.Delete(c.Context(), id); err != nil {
+		return h.handleError(c, err, "Failed to delete source")
+	}
+
+	return helper.NoContent(c)
+}
+
+// sourceHealthResponse builds the response of a source with its activity.
+func sourceHealthResponse(health service.SourceHealth) dto.SourceResponse {
+	response := dto.SourceFromEntity(health.Source)
+	response.LastAlertAt = health.Activity.LastAlertAt
+	response.AlertsLastHour = health.Activity.AlertsLastHour
+	response.AlertsLastDay = health.Activity.AlertsLastDay
+	return response
+}
+
+// handleError maps source service errors to HTTP responses.
+func (h *SourceHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrSourceNotFound):
+		return helper.NotFound(c, "Source not found")
+	case errors.Is(err, service.ErrSourceExists):
+		return helper.Conflict(c, "Source with this name already exists")
+	case errors.Is(err, service.ErrTeamNotFound),
+		errors.Is(err, entity.ErrSourceNameRequired),
+		errors.Is(err, entity.ErrSourceNameTooLong),
+		errors.Is(err, entity.ErrSourceInvalidType),
+		errors.Is(err, entity.ErrSourceDescriptionTooLong),
+		errors.Is(err, entity.ErrSourceInvalidHeartbeat),
+		errors.Is(err, entity.ErrSourceInvalidSeverity):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
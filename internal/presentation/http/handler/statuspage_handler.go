@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// StatusPageHandler handles the administration of status page components.
+type StatusPageHandler struct {
+	statusPageService *service.StatusPageService
+}
+
+// NewStatusPageHandler creates a new status page handler.
+func NewStatusPageHandler(statusPageService *service.StatusPageService) *StatusPageHandler {
+	return &StatusPageHandler{statusPageService: statusPageService}
+}
+
+// List handles GET /api/v1/admin/statuspage/components
+//
+//	@Summary		List status page components
+//	@Description	List the status page components mapped to alert sources, with the status derived from the unresolved alerts of their sources, their override and the status published
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		dto.StatusPageComponentResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/statuspage/components [get]
+func (h *StatusPageHandler) List(c *fiber.Ctx) error {
+	components, err := h.statusPageService.List(c.Context())
+	if err != nil {
+		return RespondError(c, err, "Failed to list status page components")
+	}
+
+	return helper.Success(c, dto.StatusPageComponentsFromValues(components))
+}
+
+// SetOverride handles PUT /api/v1/admin/statuspage/components/:id/override
+//
+//	@Summary		Override status page component
+//	@Description	Publish a status for a component regardless of its alerts, until the override is removed
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Component ID"
+//	@Param			request	body		dto.SetComponentOverrideRequest	true	"Component status"
+//	@Success		200		{object}	dto.StatusPageComponentResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Failure		502		{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/statuspage/components/{id}/override [put]
+func (h *StatusPageHandler) SetOverride(c *fiber.Ctx) error {
+	var req dto.SetComponentOverrideRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	component, err := h.statusPageService.SetOverride(c.Context(), c.Params("id"), req.Status)
+	if err != nil {
+		return RespondError(c, err, "Failed to override status page component")
+	}
+
+	return helper.Success(c, dto.StatusPageComponentFromValue(component))
+}
+
+// ClearOverride handles DELETE /api/v1/admin/statuspage/components/:id/override
+//
+//	@Summary		Remove status page component override
+//	@Description	Remove the override of a component, which follows the alerts of its sources again
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"Component ID"
+//	@Success		200	{object}	dto.StatusPageComponentResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		502	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/statuspage/components/{id}/override [delete]
+func (h *StatusPageHandler) ClearOverride(c *fiber.Ctx) error {
+	component, err := h.statusPageService.ClearOverride(c.Context(), c.Params("id"))
+	if err != nil {
+		return RespondError(c, err, "Failed to remove status page component override")
+	}
+
+	return helper.Success(c, dto.StatusPageComponentFromValue(component))
+}
@@ -0,0 +1,353 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// SubscriptionHandler handles outbound webhook subscriptions.
+type SubscriptionHandler struct {
+	subscriptionService *service.SubscriptionService
+	pagination          valueobject.PaginationPolicy
+}
+
+// NewSubscriptionHandler creates a new subscription handler.
+func NewSubscriptionHandler(
+	subscriptionService *service.SubscriptionService,
+	pagination valueobject.PaginationPolicy,
+) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+		pagination:          pagination,
+	}
+}
+
+// Create handles POST /api/v1/subscriptions
+//
+//	@Summary		Create webhook subscription
+//	@Description	Register an endpoint receiving alert events (admin only). Each event is POSTed as JSON with X-Webhook-Event, X-Webhook-Delivery, X-Webhook-Timestamp and X-Webhook-Signature (sha256= hex HMAC-SHA256 of "<timestamp>.<body>") headers, and retried with backoff until a 2xx response. The secret is only returned here.
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateSubscriptionRequest	true	"Subscription data"
+//	@Success		201		{object}	dto.SubscriptionResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/subscriptions [post]
+func (h *SubscriptionHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	var createdBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &userID
+	}
+
+	subscription, err := h.subscriptionService.Create(c.Context(), service.SubscriptionInput{
+		Name:       req.Name,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		Filter:     req.Filter.ToEntity(),
+	}, createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create subscription")
+	}
+
+	response := dto.SubscriptionFromEntity(subscription)
+	response.Secret = subscription.Secret
+	return helper.Created(c, response)
+}
+
+// List handles GET /api/v1/subscriptions
+//
+//	@Summary		List webhook subscriptions
+//	@Description	Retrieve the webhook subscriptions, ordered by name (admin only)
+//	@Tags			subscriptions
+//	@Produce		json
+//	@Param			page		query		int	false	"Page number"		default(1)
+//	@Param			page_size	query		int	false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.SubscriptionResponse]
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/subscriptions [get]
+func (h *SubscriptionHandler) List(c *fiber.Ctx) error {
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.subscriptionService.List(c.Context(), pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list subscriptions")
+		return helper.InternalError(c, "Failed to list subscriptions")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.SubscriptionResponse]{
+		Items:       dto.SubscriptionsFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// GetByID handles GET /api/v1/subscriptions/:id
+//
+//	@Summary		Get webhook subscription
+//	@Description	Retrieve a webhook subscription (admin only)
+//	@Tags			subscriptions
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID"
+//	@Success		200	{object}	dto.SubscriptionResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/subscriptions/{id} [get]
+func (h *SubscriptionHandler) GetByID(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid subscription ID")
+	}
+
+	subscription, err := h.subscriptionService.GetByID(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get subscription")
+	}
+
+	return helper.Success(c, dto.SubscriptionFromEntity(subscription))
+}
+
+// Update handles PATCH /api/v1/subscriptions/:id
+//
+//	@Summary		Update webhook subscription
+//	@Description	Change a subscription, pause it by disabling it, or rotate its secret (admin only). An empty secret is replaced by a generated one, returned in the response.
+//	@Tags			subscriptions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Subscription ID"
+//	@Param			request	body		dto.UpdateSubscriptionRequest	true	"Changes"
+//	@Success		200		{object}	dto.SubscriptionResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/subscriptions/{id} [patch]
+func (h *SubscriptionHandler) Update(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid subscription ID")
+	}
+
+	var req dto.UpdateSubscriptionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	update := service.SubscriptionUpdate{
+		Name:       req.Name,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		IsEnabled:  req.IsEnabled,
+	}
+	if req.Filter != nil {
+		filter := req.Filter.ToEntity()
+		update.Filter = &filter
+	}
+	if req.Secret != nil {
+		update.RotateSecret = true
+		update.Secret = *req.Secret
+	}
+
+	subscription, err := h.subscriptionService.Update(c.Context(), id, update)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update subscription")
+	}
+
+	response := dto.SubscriptionFromEntity(subscription)
+	if update.RotateSecret {
+		response.Secret = subscription.Secret
+	}
+	return helper.Success(c, response)
+}
+
+// Delete handles DELETE /api/v1/subscriptions/:id
+//
+//	@Summary		Delete webhook subscription
+//	@Description	Remove a subscription and its deliveries (admin only)
+//	@Tags			subscriptions
+//	@Param			id	path	string	true	"Subscription ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/subscriptions/{id} [delete]
+func (h *SubscriptionHandler) Delete(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid subscription ID")
+	}
+
+	if err := h.subscriptionService.Delete(c.Context(), id); err != nil {
+		return h.handleError(c, err, "Failed to delete subscription")
+	}
+
+	return helper.NoContent(c)
+}
+
+// Stats handles GET /api/v1/subscriptions/:id/stats
+//
+//	@Summary		Get webhook subscription statistics
+//	@Description	Count the pending, delivered and dead-lettered deliveries of a subscription, with its last delivery and error (admin only)
+//	@Tags			subscriptions
+//	@Produce		json
+//	@Param			id	path		string	true	"Subscription ID"
+//	@Success		200	{object}	dto.SubscriptionStatsResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/subscriptions/{id}/stats [get]
+func (h *SubscriptionHandler) Stats(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid subscription ID")
+	}
+
+	subscription, stats, err := h.subscriptionService.Stats(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get subscription statistics")
+	}
+
+	return helper.Success(c, dto.SubscriptionStatsFromEntity(subscription, stats))
+}
+
+// Deliveries handles GET /api/v1/subscriptions/:id/deliveries
+//
+//	@Summary		List webhook subscription deliveries
+//	@Description	Retrieve the deliveries of a subscription, newest first (admin only). Failed deliveries are its dead letters.
+//	@Tags			subscriptions
+//	@Produce		json
+//	@Param			id			path		string	true	"Subscription ID"
+//	@Param			status		query		string	false	"Filter by status"	Enums(pending, delivered, failed)
+//	@Param			page		query		int		false	"Page number"		default(1)
+//	@Param			page_size	query		int		false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.SubscriptionDeliveryResponse]
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/subscriptions/{id}/deliveries [get]
+func (h *SubscriptionHandler) Deliveries(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid subscription ID")
+	}
+
+	var status *entity.DeliveryStatus
+	if raw := c.Query("status"); raw != "" {
+		value := entity.DeliveryStatus(raw)
+		if !value.IsValid() {
+			return helper.BadRequest(c, "Invalid status")
+		}
+		status = &value
+	}
+
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.subscriptionService.ListDeliveries(c.Context(), id, status, pagination)
+	if err != nil {
+		return h.handleError(c, err, "Failed to list subscription deliveries")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.SubscriptionDeliveryResponse]{
+		Items:       dto.SubscriptionDeliveriesFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// Redeliver handles POST /api/v1/subscriptions/:id/deliveries/:deliveryId/redeliver
+//
+//	@Summary		Redeliver a dead-lettered webhook delivery
+//	@Description	Queue a failed delivery again, due immediately with a fresh set of attempts (admin only)
+//	@Tags			subscriptions
+//	@Produce		json
+//	@Param			id			path		string	true	"Subscription ID"
+//	@Param			deliveryId	path		string	true	"Delivery ID"
+//	@Success		200			{object}	dto.SubscriptionDeliveryResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		404			{object}	dto.ErrorResponse
+//	@Failure		409			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/subscriptions/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *SubscriptionHandler) Redeliver(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid subscription ID")
+	}
+
+	deliveryID, err := entity.ParseID(c.Params("deliveryId"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid delivery ID")
+	}
+
+	delivery, err := h.subscriptionService.Redeliver(c.Context(), id, deliveryID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to redeliver webhook")
+	}
+
+	return helper.Success(c, dto.SubscriptionDeliveryFromEntity(delivery))
+}
+
+// handleError maps subscription service errors to HTTP responses.
+func (h *SubscriptionHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrSubscriptionNotFound):
+		return helper.NotFound(c, "Subscription not found")
+	case errors.Is(err, service.ErrSubscriptionDeliveryNotFound):
+		return helper.NotFound(c, "Delivery not found")
+	case errors.Is(err, service.ErrSubscriptionExists):
+		return helper.Conflict(c, "Subscription with this name already exists")
+	case errors.Is(err, service.ErrSubscriptionDeliveryNotFailed):
+		return helper.Conflict(c, err.Error())
+	case errors.Is(err, entity.ErrSubscriptionNameRequired),
+		errors.Is(err, entity.ErrSubscriptionNameTooLong),
+		errors.Is(err, entity.ErrSubscriptionInvalidURL),
+		errors.Is(err, entity.ErrSubscriptionSecretTooShort),
+		errors.Is(err, entity.ErrSubscriptionEventTypesRequired),
+		errors.Is(err, entity.ErrSubscriptionInvalidEventType),
+		errors.Is(err, entity.ErrSubscriptionInvalidFilter):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
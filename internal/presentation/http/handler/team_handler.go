@@ -0,0 +1,346 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// TeamHandler handles team and membership management.
+type TeamHandler struct {
+	teamService *service.TeamService
+	pagination  valueobject.PaginationPolicy
+}
+
+// NewTeamHandler creates a new team handler.
+func NewTeamHandler(teamService *service.TeamService, pagination valueobject.PaginationPolicy) *TeamHandler {
+	return &TeamHandler{
+		teamService: teamService,
+		pagination:  pagination,
+	}
+}
+
+// Create handles POST /api/v1/teams
+//
+//	@Summary		Create team
+//	@Description	Create a team (admin only); add owners through the members endpoint
+//	@Tags			teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateTeamRequest	true	"Team data"
+//	@Success		201		{object}	dto.TeamResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/teams [post]
+func (h *TeamHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateTeamRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	team, err := h.teamService.Create(c.Context(), req.Name, req.Description)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create team")
+	}
+
+	return helper.Created(c, dto.TeamFromEntity(team))
+}
+
+// List handles GET /api/v1/teams
+//
+//	@Summary		List teams
+//	@Description	List the caller's teams, or every team for admins
+//	@Tags			teams
+//	@Produce		json
+//	@Param			page		query		int	false	"Page number"		default(1)
+//	@Param			page_size	query		int	false	"Items per page"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.TeamResponse]
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/teams [get]
+func (h *TeamHandler) List(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.teamService.List(c.Context(), userID, userRole(c) == entity.UserRoleAdmin, pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list teams")
+		return helper.InternalError(c, "Failed to list teams")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.TeamResponse]{
+		Items:       dto.TeamsFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// GetByID handles GET /api/v1/teams/:id
+//
+//	@Summary		Get team
+//	@Description	Retrieve a team the caller belongs to
+//	@Tags			teams
+//	@Produce		json
+//	@Param			id	path		string	true	"Team ID"
+//	@Success		200	{object}	dto.TeamResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/teams/{id} [get]
+func (h *TeamHandler) GetByID(c *fiber.Ctx) error {
+	teamID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid team ID")
+	}
+
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	team, err := h.teamService.Get(c.Context(), teamID, userID, userRole(c) == entity.UserRoleAdmin)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get team")
+	}
+
+	return helper.Success(c, dto.TeamFromEntity(team))
+}
+
+// Update handles PATCH /api/v1/teams/:id
+//
+//	@Summary		Update team
+//	@Description	Rename a team or change its description (team owners and admins)
+//	@Tags			teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Team ID"
+//	@Param			request	body		dto.UpdateTeamRequest	true	"Team changes"
+//	@Success		200		{object}	dto.TeamResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/teams/{id} [patch]
+func (h *TeamHandler) Update(c *fiber.Ctx) error {
+	teamID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid team ID")
+	}
+
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	var req dto.UpdateTeamRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	team, err := h.teamService.Update(c.Context(), teamID, req.Name, req.Description, userID, userRole(c) == entity.UserRoleAdmin)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update team")
+	}
+
+	return helper.Success(c, dto.TeamFromEntity(team))
+}
+
+// Delete handles DELETE /api/v1/teams/:id
+//
+//	@Summary		Delete team
+//	@Description	Remove a team (admin only). Teams still owning alerts, rules or channels cannot be deleted.
+//	@Tags			teams
+//	@Param			id	path	string	true	"Team ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/teams/{id} [delete]
+func (h *TeamHandler) Delete(c *fiber.Ctx) error {
+	teamID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid team ID")
+	}
+
+	if err := h.teamService.Delete(c.Context(), teamID); err != nil {
+		return h.handleError(c, err, "Failed to delete team")
+	}
+
+	return helper.NoContent(c)
+}
+
+// ListMembers handles GET /api/v1/teams/:id/members
+//
+//	@Summary		List team members
+//	@Description	List the members of a team the caller belongs to
+//	@Tags			teams
+//	@Produce		json
+//	@Param			id	path		string	true	"Team ID"
+//	@Success		200	{array}		dto.TeamMemberResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/teams/{id}/members [get]
+func (h *TeamHandler) ListMembers(c *fiber.Ctx) error {
+	teamID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid team ID")
+	}
+
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	members, err := h.teamService.ListMembers(c.Context(), teamID, userID, userRole(c) == entity.UserRoleAdmin)
+	if err != nil {
+		return h.handleError(c, err, "Failed to list team members")
+	}
+
+	return helper.Success(c, dto.TeamMembersFromEntities(members))
+}
+
+// SetMember handles PUT /api/v1/teams/:id/members/:userId
+//
+//	@Summary		Add or update team member
+//	@Description	Add a user to a team or change their role (team owners and admins)
+//	@Tags			teams
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Team ID"
+//	@Param			userId	path		string						true	"User ID"
+//	@Param			request	body		dto.SetTeamMemberRequest	true	"Membership"
+//	@Success		200		{object}	dto.TeamMemberResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/teams/{id}/members/{userId} [put]
+func (h *TeamHandler) SetMember(c *fiber.Ctx) error {
+	teamID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid team ID")
+	}
+
+	actorID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	memberID, err := entity.ParseID(c.Params("userId"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid user ID")
+	}
+
+	var req dto.SetTeamMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	member, err := h.teamService.SetMember(c.Context(), teamID, memberID, entity.TeamRole(req.Role),
+		actorID, userRole(c) == entity.UserRoleAdmin)
+	if err != nil {
+		return h.handleError(c, err, "Failed to save team member")
+	}
+
+	return helper.Success(c, dto.TeamMemberFromEntity(member))
+}
+
+// RemoveMember handles DELETE /api/v1/teams/:id/members/:userId
+//
+//	@Summary		Remove team member
+//	@Description	Remove a user from a team (team owners and admins), or leave a team
+//	@Tags			teams
+//	@Param			id		path	string	true	"Team ID"
+//	@Param			userId	path	string	true	"User ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/teams/{id}/members/{userId} [delete]
+func (h *TeamHandler) RemoveMember(c *fiber.Ctx) error {
+	teamID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid team ID")
+	}
+
+	actorID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return helper.Unauthorized(c, "User not authenticated")
+	}
+
+	memberID, err := entity.ParseID(c.Params("userId"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid user ID")
+	}
+
+	if err := h.teamService.RemoveMember(c.Context(), teamID, memberID, actorID, userRole(c) == entity.UserRoleAdmin); err != nil {
+		return h.handleError(c, err, "Failed to remove team member")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps team service errors to HTTP responses.
+func (h *TeamHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrTeamNotFound):
+		return helper.NotFound(c, "Team not found")
+	case errors.Is(err, service.ErrTeamMemberNotFound):
+		return helper.NotFound(c, "Team member not found")
+	case errors.Is(err, service.ErrTeamUserNotFound):
+		return helper.NotFound(c, "User not found")
+	case errors.Is(err, service.ErrTeamForbidden):
+		return helper.Forbidden(c, err.Error())
+	case errors.Is(err, service.ErrTeamNameTaken),
+		errors.Is(err, service.ErrTeamNotEmpty),
+		errors.Is(err, service.ErrTeamLastOwner):
+		return helper.Conflict(c, err.Error())
+	case errors.Is(err, entity.ErrTeamNameRequired),
+		errors.Is(err, entity.ErrTeamNameTooLong),
+		errors.Is(err, entity.ErrTeamDescriptionTooLong),
+		errors.Is(err, entity.ErrTeamRoleInvalid):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
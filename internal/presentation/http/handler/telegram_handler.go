@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// telegramSecretHeader carries the secret token the bot's webhook was
+// registered with.
+const telegramSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// TelegramHandler handles updates sent to the Telegram bot's webhook.
+type TelegramHandler struct {
+	telegramService *service.TelegramService
+}
+
+// NewTelegramHandler creates a new Telegram handler.
+func NewTelegramHandler(telegramService *service.TelegramService) *TelegramHandler {
+	return &TelegramHandler{telegramService: telegramService}
+}
+
+// Webhook handles POST /api/v1/integrations/telegram/webhook
+//
+//	@Summary		Telegram bot webhook
+//	@Description	Receive the updates of the Telegram bot, sent with the secret token its webhook was registered with. Clicks on the Acknowledge button of alert messages acknowledge the alert on behalf of the user linked to the Telegram account, who must be an operator or admin; the result is shown to them in Telegram. Other updates are ignored.
+//	@Tags			integrations
+//	@Accept			json
+//	@Param			request							body	dto.TelegramUpdate	true	"Bot update"
+//	@Param			X-Telegram-Bot-Api-Secret-Token	header	string				true	"Secret token of the webhook"
+//	@Success		200
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/integrations/telegram/webhook [post]
+func (h *TelegramHandler) Webhook(c *fiber.Ctx) error {
+	if err := h.telegramService.Verify(c.Get(telegramSecretHeader)); err != nil {
+		if errors.Is(err, service.ErrTelegramNotConfigured) {
+			return helper.NotFound(c, "Telegram integration is not configured")
+		}
+		return helper.Unauthorized(c, err.Error())
+	}
+
+	var update dto.TelegramUpdate
+	if err := json.Unmarshal(c.Body(), &update); err != nil {
+		return helper.BadRequest(c, "Invalid update")
+	}
+
+	// Other updates are acknowledged without effect
+	query := update.CallbackQuery
+	if query == nil {
+		return c.SendStatus(fiber.StatusOK)
+	}
+
+	callback := service.TelegramCallback{
+		ID:     query.ID,
+		UserID: strconv.FormatInt(query.From.ID, 10),
+		Data:   query.Data,
+	}
+	if query.Message != nil {
+		callback.ChatID = query.Message.Chat.ID
+		callback.MessageID = query.Message.MessageID
+	}
+
+	// Failures are shown to the user in Telegram
+	alert, err := h.telegramService.HandleCallback(c.Context(), callback)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("telegram_user", callback.UserID).
+			Str("callback_data", callback.Data).
+			Msg("Telegram callback failed")
+		return c.SendStatus(fiber.StatusOK)
+	}
+
+	log.Info().
+		Str("alert_id", alert.ID.String()).
+		Str("telegram_user", callback.UserID).
+		Msg("Telegram callback performed")
+
+	return c.SendStatus(fiber.StatusOK)
+}
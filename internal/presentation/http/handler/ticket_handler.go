@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// TicketHandler handles the issue tracker tickets of alerts.
+type TicketHandler struct {
+	alertService  *service.AlertService
+	ticketService *service.TicketService
+}
+
+// NewTicketHandler creates a new ticket handler.
+func NewTicketHandler(alertService *service.AlertService, ticketService *service.TicketService) *TicketHandler {
+	return &TicketHandler{
+		alertService:  alertService,
+		ticketService: ticketService,
+	}
+}
+
+// Create handles POST /api/v1/alerts/:id/ticket
+//
+//	@Summary		File alert ticket
+//	@Description	File an issue with the alert details in the configured issue tracker (Jira) and store its key on the alert. The issue is resolved when the alert is resolved. An alert has at most one ticket.
+//	@Tags			alerts
+//	@Produce		json
+//	@Param			id	path		string	true	"Alert ID"
+//	@Success		201	{object}	dto.TicketResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		502	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/alerts/{id}/ticket [post]
+func (h *TicketHandler) Create(c *fiber.Ctx) error {
+	alertID, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid alert ID")
+	}
+
+	// Alerts hidden from the user's role or teams cannot be ticketed either
+	alert, err := h.alertService.GetVisible(c.Context(), alertID, userRole(c), tenantScope(c))
+	if err != nil {
+		return RespondError(c, err, "Failed to create ticket")
+	}
+
+	ticket, err := h.ticketService.Create(c.Context(), alert)
+	if err != nil {
+		return RespondError(c, err, "Failed to create ticket")
+	}
+
+	return helper.Created(c, dto.TicketResponse{Key: ticket.Key, URL: ticket.URL})
+}
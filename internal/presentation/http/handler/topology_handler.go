@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// TopologyHandler handles the dependency graph between alert sources.
+type TopologyHandler struct {
+	topologyService *service.TopologyService
+	pagination      valueobject.PaginationPolicy
+}
+
+// NewTopologyHandler creates a new topology handler.
+func NewTopologyHandler(topologyService *service.TopologyService, pagination valueobject.PaginationPolicy) *TopologyHandler {
+	return &TopologyHandler{
+		topologyService: topologyService,
+		pagination:      pagination,
+	}
+}
+
+// Create handles POST /api/v1/admin/source-dependencies
+//
+//	@Summary		Create source dependency
+//	@Description	Declare that a source depends on another. While a source has an active critical alert, new alerts of the sources depending on it, directly or not, are flagged as downstream and not notified.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateSourceDependencyRequest	true	"Dependency"
+//	@Success		201		{object}	dto.SourceDependencyResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/source-dependencies [post]
+func (h *TopologyHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateSourceDependencyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	dependency, err := h.topologyService.Create(c.Context(), req.Source, req.DependsOn, actor(c))
+	if err != nil {
+		return h.handleError(c, err, "Failed to create source dependency")
+	}
+
+	return helper.Created(c, dto.SourceDependencyFromEntity(dependency))
+}
+
+// List handles GET /api/v1/admin/source-dependencies
+//
+//	@Summary		List source dependencies
+//	@Description	Retrieve the dependency graph between alert sources, by source
+//	@Tags			admin
+//	@Produce		json
+//	@Param			page		query		int	false	"Page number"		default(1)
+//	@Param			page_size	query		int	false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.SourceDependencyResponse]
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/source-dependencies [get]
+func (h *TopologyHandler) List(c *fiber.Ctx) error {
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.topologyService.List(c.Context(), pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list source dependencies")
+		return helper.InternalError(c, "Failed to list source dependencies")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.SourceDependencyResponse]{
+		Items:       dto.SourceDependenciesFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// Delete handles DELETE /api/v1/admin/source-dependencies/:id
+//
+//	@Summary		Delete source dependency
+//	@Description	Remove a source dependency. Alerts already flagged as downstream keep the flag.
+//	@Tags			admin
+//	@Param			id	path	string	true	"Source dependency ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/source-dependencies/{id} [delete]
+func (h *TopologyHandler) Delete(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid source dependency ID")
+	}
+
+	if err := h.topologyService.Delete(c.Context(), id); err != nil {
+		return h.handleError(c, err, "Failed to delete source dependency")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps topology service errors to HTTP responses.
+func (h *TopologyHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrSourceDependencyNotFound):
+		return helper.NotFound(c, "Source dependency not found")
+	case errors.Is(err, service.ErrSourceDependencyExists):
+		return helper.Conflict(c, "Source already depends on this source")
+	case errors.Is(err, service.ErrSourceDependencyCycle),
+		errors.Is(err, entity.ErrSourceDependencySourceRequired),
+		errors.Is(err, entity.ErrSourceDependencySourceTooLong),
+		errors.Is(err, entity.ErrSourceDependencySelf):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
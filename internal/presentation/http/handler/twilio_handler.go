@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// twilioSignatureHeader carries the signature of the requests Twilio posts.
+const twilioSignatureHeader = "X-Twilio-Signature"
+
+// TwilioHandler handles the keys pressed during escalation calls.
+type TwilioHandler struct {
+	voiceService *service.VoiceEscalationService
+}
+
+// NewTwilioHandler creates a new Twilio handler.
+func NewTwilioHandler(voiceService *service.VoiceEscalationService) *TwilioHandler {
+	return &TwilioHandler{voiceService: voiceService}
+}
+
+// Voice handles POST /api/v1/integrations/twilio/voice
+//
+//	@Summary		Twilio voice callback
+//	@Description	Receive the key pressed during the escalation call of an alert, signed with the Twilio auth token. Pressing 1 acknowledges the alert on behalf of the user linked to the phone number called, who must be an operator or admin; the result is read back to them.
+//	@Tags			integrations
+//	@Accept			x-www-form-urlencoded
+//	@Produce		xml
+//	@Param			alert_id			query		string	true	"Alert the call was placed for"
+//	@Param			Digits				formData	string	false	"Keys pressed"
+//	@Param			To					formData	string	true	"Phone number called"
+//	@Param			X-Twilio-Signature	header		string	true	"Base64 HMAC-SHA1 of the URL and sorted parameters"
+//	@Success		200	{object}	dto.TwilioVoiceResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/integrations/twilio/voice [post]
+func (h *TwilioHandler) Voice(c *fiber.Ctx) error {
+	params := make(map[string]string)
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		params[string(key)] = string(value)
+	})
+
+	if err := h.voiceService.Verify(c.OriginalURL(), params, c.Get(twilioSignatureHeader)); err != nil {
+		if errors.Is(err, service.ErrVoiceNotConfigured) {
+			return helper.NotFound(c, "Twilio integration is not configured")
+		}
+		return helper.Unauthorized(c, err.Error())
+	}
+
+	keypress := service.VoiceKeypress{
+		AlertID: c.Query("alert_id"),
+		To:      params["To"],
+		Digits:  params["Digits"],
+	}
+
+	// Failures are read back to the callee
+	reply, err := h.voiceService.HandleKeypress(c.Context(), keypress)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("alert_id", keypress.AlertID).
+			Str("digits", keypress.Digits).
+			Msg("Twilio keypress failed")
+	}
+
+	return c.XML(dto.TwilioVoiceResponse{Say: reply})
+}
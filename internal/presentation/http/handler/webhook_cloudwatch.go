@@ -0,0 +1,430 @@
+package handler
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	_ "crypto/sha1" //nolint:gosec // SignatureVersion 1 of SNS is SHA1withRSA
+	_ "crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// SNS message types.
+const (
+	snsTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	snsTypeNotification             = "Notification"
+	snsTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// maxSNSCertSize bounds the signing certificate download.
+const maxSNSCertSize = 64 << 10
+
+// snsHostPattern matches the SNS endpoints signing certificates and
+// subscribe URLs are served from.
+var snsHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// snsHTTPClient confirms SNS subscriptions and fetches signing certificates.
+var snsHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// snsCerts caches signing certificates by URL.
+var snsCerts sync.Map
+
+// SNSMessage represents an Amazon SNS HTTP(S) delivery.
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	SubscribeURL     string `json:"SubscribeURL"`
+	UnsubscribeURL   string `json:"UnsubscribeURL"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// CloudWatchAlarm represents a CloudWatch alarm state change notification,
+// delivered as the Message of an SNS notification.
+type CloudWatchAlarm struct {
+	AlarmName        string            `json:"AlarmName"`
+	AlarmDescription string            `json:"AlarmDescription"`
+	AWSAccountID     string            `json:"AWSAccountId"`
+	NewStateValue    string            `json:"NewStateValue"`
+	NewStateReason   string            `json:"NewStateReason"`
+	StateChangeTime  string            `json:"StateChangeTime"`
+	Region           string            `json:"Region"`
+	AlarmArn         string            `json:"AlarmArn"`
+	OldStateValue    string            `json:"OldStateValue"`
+	Trigger          CloudWatchTrigger `json:"Trigger"`
+}
+
+// CloudWatchTrigger describes the metric an alarm watches.
+type CloudWatchTrigger struct {
+	MetricName         string                `json:"MetricName"`
+	Namespace          string                `json:"Namespace"`
+	Statistic          string                `json:"Statistic"`
+	Period             int                   `json:"Period"`
+	EvaluationPeriods  int                   `json:"EvaluationPeriods"`
+	ComparisonOperator string                `json:"ComparisonOperator"`
+	Threshold          float64               `json:"Threshold"`
+	Dimensions         []CloudWatchDimension `json:"Dimensions"`
+}
+
+// CloudWatchDimension is a metric dimension of an alarm.
+type CloudWatchDimension struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CloudWatchWebhookHandler handles POST /api/v1/webhooks/cloudwatch
+//
+//	@Summary		Receive CloudWatch alarms via SNS
+//	@Description	Confirms SNS subscriptions and creates or resolves alerts from CloudWatch alarm state changes. Only messages of the configured topics are accepted.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Accept			plain
+//	@Produce		json
+//	@Param			payload	body	SNSMessage	true	"SNS message"
+//	@Success		200
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/webhooks/cloudwatch [post]
+func (h *WebhookHandler) CloudWatchWebhookHandler(c *fiber.Ctx) error {
+	// SNS posts JSON with a text/plain content type, so the body is decoded directly.
+	var msg SNSMessage
+	if err := json.Unmarshal(c.Body(), &msg); err != nil {
+		log.Error().Err(err).Msg("Failed to parse SNS message")
+		return helper.BadRequest(c, "Invalid webhook payload")
+	}
+
+	// Messages of other topics are refused before their signing certificate
+	// is fetched, so that anyone's SNS topic cannot subscribe this endpoint.
+	if _, ok := h.snsTopics[msg.TopicArn]; !ok {
+		log.Warn().
+			Str("topic_arn", msg.TopicArn).
+			Str("ip", c.IP()).
+			Msg("Rejected SNS message from an unknown topic")
+		return helper.Forbidden(c, "SNS topic is not allowed")
+	}
+
+	// Nothing in the message is acted on, including its subscribe URL,
+	// before SNS is known to have sent it.
+	if err := verifySNSSignature(c.Context(), msg); err != nil {
+		log.Warn().
+			Err(err).
+			Str("topic_arn", msg.TopicArn).
+			Str("ip", c.IP()).
+			Msg("Rejected SNS message")
+		return helper.Unauthorized(c, "Invalid SNS message signature")
+	}
+
+	log.Info().
+		Str("type", msg.Type).
+		Str("topic_arn", msg.TopicArn).
+		Str("message_id", msg.MessageID).
+		Msg("Received SNS message")
+
+	switch msg.Type {
+	case snsTypeSubscriptionConfirmation:
+		if err := confirmSNSSubscription(c.Context(), msg.SubscribeURL); err != nil {
+			log.Error().Err(err).Str("topic_arn", msg.TopicArn).Msg("Failed to confirm SNS subscription")
+			return helper.BadRequest(c, "Failed to confirm subscription")
+		}
+		log.Info().Str("topic_arn", msg.TopicArn).Msg("Confirmed SNS subscription")
+		return helper.Success(c, fiber.Map{"status": "received", "action": "subscription_confirmed"})
+	case snsTypeUnsubscribeConfirmation:
+		return helper.Success(c, fiber.Map{"status": "received", "action": "none"})
+	case snsTypeNotification:
+	default:
+		return helper.BadRequest(c, "Unsupported SNS message type")
+	}
+
+	var alarm CloudWatchAlarm
+	if err := json.Unmarshal([]byte(msg.Message), &alarm); err != nil || alarm.AlarmName == "" {
+		// Not a CloudWatch alarm; nothing to do.
+		return helper.Success(c, fiber.Map{"status": "received", "action": "none"})
+	}
+
+	dedupKey := cloudWatchDedupKey(alarm)
+
+	switch alarm.NewStateValue {
+	case "ALARM":
+	case "OK":
+		if _, err := h.alertService.ResolveByDedupKey(c.Context(), dedupKey); err != nil {
+			if errors.Is(err, service.ErrAlertNotFound) {
+				return helper.Success(c, fiber.Map{"status": "received", "action": "none"})
+			}
+			log.Error().Err(err).Str("dedup_key", dedupKey).Msg("Failed to resolve alert from CloudWatch")
+			return helper.InternalError(c, "Failed to process webhook")
+		}
+		return helper.Success(c, fiber.Map{"status": "received", "action": "resolved"})
+	default:
+		// INSUFFICIENT_DATA neither fires nor recovers an alarm.
+		return helper.Success(c, fiber.Map{"status": "received", "action": "none"})
+	}
+
+	message := alarm.NewStateReason
+	if alarm.AlarmDescription != "" {
+		message = alarm.AlarmDescription + "\n" + message
+	}
+	if message == "" {
+		message = "Alarm triggered in CloudWatch"
+	}
+
+	dimensions := make(map[string]string, len(alarm.Trigger.Dimensions))
+	for _, d := range alarm.Trigger.Dimensions {
+		dimensions[d.Name] = d.Value
+	}
+
+	input := service.CreateAlertInput{
+		Title:    alarm.AlarmName,
+		Message:  message,
+		Severity: entity.AlertSeverityHigh,
+		Source:   cloudWatchSource(alarm.Trigger.Namespace),
+		Metadata: map[string]interface{}{
+			"alarm_arn":         alarm.AlarmArn,
+			"account_id":        alarm.AWSAccountID,
+			"aws_region":        cloudWatchRegion(alarm),
+			"namespace":         alarm.Trigger.Namespace,
+			"metric":            alarm.Trigger.MetricName,
+			"statistic":         alarm.Trigger.Statistic,
+			"threshold":         alarm.Trigger.Threshold,
+			"comparison":        alarm.Trigger.ComparisonOperator,
+			"dimensions":        dimensions,
+			"old_state":         alarm.OldStateValue,
+			"state_change_time": alarm.StateChangeTime,
+			"topic_arn":         msg.TopicArn,
+		},
+	}
+
+	_, created, err := h.alertService.CreateDeduplicated(c.Context(), dedupKey, input)
+	if err != nil {
+		log.Error().Err(err).Str("dedup_key", dedupKey).Msg("Failed to create alert from CloudWatch")
+		return helper.InternalError(c, "Failed to process webhook")
+	}
+
+	action := "deduplicated"
+	if created {
+		action = "created"
+	}
+
+	return helper.Success(c, fiber.Map{"status": "received", "action": action})
+}
+
+// confirmSNSSubscription visits the subscribe URL of a confirmation message.
+// Only HTTPS URLs on an SNS endpoint are followed.
+func confirmSNSSubscription(ctx context.Context, subscribeURL string) error {
+	u, err := snsURL(subscribeURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := snsHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("subscribe URL returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// verifySNSSignature checks the signature of an SNS message against the
+// certificate it names, which must be served over HTTPS by an SNS endpoint.
+func verifySNSSignature(ctx context.Context, msg SNSMessage) error {
+	var hash crypto.Hash
+	switch msg.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+	case "2":
+		hash = crypto.SHA256
+	default:
+		return fmt.Errorf("unsupported signature version %q", msg.SignatureVersion)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if err != nil || len(signature) == 0 {
+		return errors.New("missing or malformed signature")
+	}
+
+	canonical, err := snsStringToSign(msg)
+	if err != nil {
+		return err
+	}
+
+	cert, err := snsSigningCert(ctx, msg.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("signing certificate does not hold an RSA key")
+	}
+
+	digest := hash.New()
+	digest.Write([]byte(canonical))
+
+	return rsa.VerifyPKCS1v15(key, hash, digest.Sum(nil), signature)
+}
+
+// snsStringToSign builds the canonical string SNS signs for a message type.
+func snsStringToSign(msg SNSMessage) (string, error) {
+	var fields [][2]string
+	switch msg.Type {
+	case snsTypeNotification:
+		fields = [][2]string{{"Message", msg.Message}, {"MessageId", msg.MessageID}}
+		if msg.Subject != "" {
+			fields = append(fields, [2]string{"Subject", msg.Subject})
+		}
+		fields = append(fields,
+			[2]string{"Timestamp", msg.Timestamp},
+			[2]string{"TopicArn", msg.TopicArn},
+			[2]string{"Type", msg.Type})
+	case snsTypeSubscriptionConfirmation, snsTypeUnsubscribeConfirmation:
+		fields = [][2]string{
+			{"Message", msg.Message},
+			{"MessageId", msg.MessageID},
+			{"SubscribeURL", msg.SubscribeURL},
+			{"Timestamp", msg.Timestamp},
+			{"Token", msg.Token},
+			{"TopicArn", msg.TopicArn},
+			{"Type", msg.Type},
+		}
+	default:
+		return "", fmt.Errorf("unsupported message type %q", msg.Type)
+	}
+
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteString(f[0])
+		b.WriteByte('\n')
+		b.WriteString(f[1])
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// snsSigningCert returns the certificate at certURL, fetching it on first use.
+func snsSigningCert(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	if cached, ok := snsCerts.Load(certURL); ok {
+		cert := cached.(*x509.Certificate)
+		if time.Now().Before(cert.NotAfter) {
+			return cert, nil
+		}
+		snsCerts.Delete(certURL)
+	}
+
+	u, err := snsURL(certURL)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(u.Path, ".pem") {
+		return nil, fmt.Errorf("untrusted signing certificate URL: %s", u.Path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := snsHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("signing certificate URL returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSNSCertSize))
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("signing certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, errors.New("signing certificate is not valid at this time")
+	}
+
+	snsCerts.Store(certURL, cert)
+	return cert, nil
+}
+
+// snsURL parses a URL SNS sent, accepting only HTTPS on an SNS endpoint.
+func snsURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" || u.Port() != "" || !snsHostPattern.MatchString(u.Hostname()) {
+		return nil, fmt.Errorf("untrusted SNS URL host: %s", u.Host)
+	}
+	return u, nil
+}
+
+// cloudWatchRegion returns the AWS region code of an alarm, taken from its
+// ARN (arn:aws:cloudwatch:<region>:...), falling back to the display name.
+func cloudWatchRegion(alarm CloudWatchAlarm) string {
+	if parts := strings.Split(alarm.AlarmArn, ":"); len(parts) > 3 && parts[3] != "" {
+		return parts[3]
+	}
+	return alarm.Region
+}
+
+// cloudWatchSource builds the alert source for a CloudWatch metric namespace.
+func cloudWatchSource(namespace string) string {
+	if namespace == "" {
+		return "cloudwatch"
+	}
+	return "cloudwatch:" + namespace
+}
+
+// cloudWatchDedupKey builds the deduplication key for a CloudWatch alarm.
+func cloudWatchDedupKey(alarm CloudWatchAlarm) string {
+	if alarm.AlarmArn != "" {
+		return "cloudwatch:" + alarm.AlarmArn
+	}
+	return "cloudwatch:" + alarm.AWSAccountID + ":" + alarm.AlarmName
+}
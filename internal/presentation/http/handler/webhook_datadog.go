@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// DatadogWebhook represents a Datadog monitor webhook payload.
+// Datadog payloads are user-templated; the fields below follow the
+// recommended template using the standard $VARIABLES.
+type DatadogWebhook struct {
+	ID              string      `json:"id"`
+	Title           string      `json:"title"`
+	Body            string      `json:"body"`
+	AlertID         string      `json:"alert_id"`
+	AlertTitle      string      `json:"alert_title"`
+	AlertType       string      `json:"alert_type"`
+	AlertTransition string      `json:"alert_transition"`
+	AlertStatus     string      `json:"alert_status"`
+	AlertPriority   string      `json:"alert_priority"`
+	AlertScope      string      `json:"alert_scope"`
+	AlertQuery      string      `json:"alert_query"`
+	AlertMetric     string      `json:"alert_metric"`
+	Priority        string      `json:"priority"`
+	Hostname        string      `json:"hostname"`
+	Link            string      `json:"link"`
+	Date            string      `json:"date"`
+	Tags            DatadogTags `json:"tags"`
+}
+
+// DatadogTags holds monitor tags, sent either as a comma-separated string
+// ($TAGS) or as a JSON array of "key:value" strings.
+type DatadogTags []string
+
+// UnmarshalJSON accepts both the string and the array form of tags.
+func (t *DatadogTags) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*t = list
+		return nil
+	}
+
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(raw, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	*t = tags
+	return nil
+}
+
+// Labels converts "key:value" tags into a label map.
+// Tags without a value map to an empty string.
+func (t DatadogTags) Labels() map[string]string {
+	labels := make(map[string]string, len(t))
+	for _, tag := range t {
+		key, value, _ := strings.Cut(tag, ":")
+		labels[key] = value
+	}
+	return labels
+}
+
+// DatadogWebhookHandler handles POST /api/v1/webhooks/datadog
+//
+//	@Summary		Receive Datadog webhook
+//	@Description	Receives monitor notifications from Datadog, resolving alerts on recovery
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body	DatadogWebhook	true	"Datadog webhook payload"
+//	@Success		200
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/webhooks/datadog [post]
+func (h *WebhookHandler) DatadogWebhookHandler(c *fiber.Ctx) error {
+	var payload DatadogWebhook
+	if err := c.BodyParser(&payload); err != nil {
+		log.Error().Err(err).Msg("Failed to parse Datadog webhook")
+		return helper.BadRequest(c, "Invalid webhook payload")
+	}
+
+	if payload.AlertID == "" {
+		return helper.BadRequest(c, "Missing alert_id")
+	}
+
+	log.Info().
+		Str("alert_id", payload.AlertID).
+		Str("transition", payload.AlertTransition).
+		Str("alert_type", payload.AlertType).
+		Msg("Received Datadog webhook")
+
+	dedupKey := datadogDedupKey(payload.AlertID, payload.AlertScope)
+
+	if isDatadogRecovery(payload) {
+		if _, err := h.alertService.ResolveByDedupKey(c.Context(), dedupKey); err != nil {
+			if errors.Is(err, service.ErrAlertNotFound) {
+				return helper.Success(c, fiber.Map{"status": "received", "action": "none"})
+			}
+			log.Error().Err(err).Str("dedup_key", dedupKey).Msg("Failed to resolve alert from Datadog")
+			return helper.InternalError(c, "Failed to process webhook")
+		}
+		return helper.Success(c, fiber.Map{"status": "received", "action": "resolved"})
+	}
+
+	title := payload.AlertTitle
+	if title == "" {
+		title = payload.Title
+	}
+	if title == "" {
+		title = "Datadog Monitor Alert"
+	}
+
+	message := payload.Body
+	if message == "" {
+		message = "Alert triggered from Datadog"
+	}
+
+	source := "datadog"
+	if payload.Hostname != "" {
+		source = "datadog:" + payload.Hostname
+	}
+
+	input := service.CreateAlertInput{
+		Title:    title,
+		Message:  message,
+		Severity: datadogSeverity(payload.AlertPriority, payload.Priority, payload.AlertType),
+		Source:   source,
+		Metadata: map[string]interface{}{
+			"monitor_id":  payload.AlertID,
+			"event_id":    payload.ID,
+			"scope":       payload.AlertScope,
+			"query":       payload.AlertQuery,
+			"metric":      payload.AlertMetric,
+			"transition":  payload.AlertTransition,
+			"status":      payload.AlertStatus,
+			"link":        payload.Link,
+			"labels":      payload.Tags.Labels(),
+			"received_at": payload.Date,
+		},
+	}
+
+	_, created, err := h.alertService.CreateDeduplicated(c.Context(), dedupKey, input)
+	if err != nil {
+		log.Error().Err(err).Str("dedup_key", dedupKey).Msg("Failed to create alert from Datadog")
+		return helper.InternalError(c, "Failed to process webhook")
+	}
+
+	action := "deduplicated"
+	if created {
+		action = "created"
+	}
+
+	return helper.Success(c, fiber.Map{"status": "received", "action": action})
+}
+
+// isDatadogRecovery reports whether a Datadog payload signals recovery.
+func isDatadogRecovery(payload DatadogWebhook) bool {
+	return strings.EqualFold(payload.AlertTransition, "Recovered") ||
+		strings.EqualFold(payload.AlertType, "success")
+}
+
+// datadogSeverity maps Datadog monitor priority (P1-P5), notification
+// priority and alert type to an alert severity.
+func datadogSeverity(alertPriority, priority, alertType string) entity.AlertSeverity {
+	switch strings.ToUpper(alertPriority) {
+	case "P1":
+		return entity.AlertSeverityCritical
+	case "P2":
+		return entity.AlertSeverityHigh
+	case "P3":
+		return entity.AlertSeverityMedium
+	case "P4":
+		return entity.AlertSeverityLow
+	case "P5":
+		return entity.AlertSeverityInfo
+	}
+
+	var severity entity.AlertSeverity
+	switch strings.ToLower(alertType) {
+	case "error":
+		severity = entity.AlertSeverityHigh
+	case "warning":
+		severity = entity.AlertSeverityMedium
+	case "info":
+		severity = entity.AlertSeverityInfo
+	default:
+		severity = entity.AlertSeverityLow
+	}
+
+	// Low-priority notifications never page as high or critical.
+	if strings.EqualFold(priority, "low") && severity.Priority() < entity.AlertSeverityMedium.Priority() {
+		severity = entity.AlertSeverityMedium
+	}
+
+	return severity
+}
+
+// datadogDedupKey builds the deduplication key for a Datadog monitor alert.
+// Multi-alert monitors are keyed per scope (e.g. host:web-1).
+func datadogDedupKey(monitorID, scope string) string {
+	if scope == "" {
+		return "datadog:" + monitorID
+	}
+	return "datadog:" + monitorID + ":" + scope
+}
@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// GrafanaWebhook represents the webhook payload from Grafana.
+// It covers both unified alerting (Alerts) and legacy dashboard alerts,
+// which send RuleID, RuleName, State and EvalMatches at the top level.
+type GrafanaWebhook struct {
+	Receiver          string            `json:"receiver"`
+	Status            string            `json:"status"`
+	OrgID             int64             `json:"orgId"`
+	Alerts            []GrafanaAlert    `json:"alerts"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Title             string            `json:"title"`
+	Message           string            `json:"message"`
+
+	// Legacy alerting fields.
+	RuleID      int64              `json:"ruleId"`
+	RuleName    string             `json:"ruleName"`
+	RuleURL     string             `json:"ruleUrl"`
+	State       string             `json:"state"`
+	ImageURL    string             `json:"imageUrl"`
+	EvalMatches []GrafanaEvalMatch `json:"evalMatches"`
+	Tags        map[string]string  `json:"tags"`
+}
+
+// GrafanaAlert represents a single alert from Grafana unified alerting.
+type GrafanaAlert struct {
+	Status       string                 `json:"status"`
+	Labels       map[string]string      `json:"labels"`
+	Annotations  map[string]string      `json:"annotations"`
+	StartsAt     time.Time              `json:"startsAt"`
+	EndsAt       time.Time              `json:"endsAt"`
+	GeneratorURL string                 `json:"generatorURL"`
+	Fingerprint  string                 `json:"fingerprint"`
+	SilenceURL   string                 `json:"silenceURL"`
+	DashboardURL string                 `json:"dashboardURL"`
+	PanelURL     string                 `json:"panelURL"`
+	Values       map[string]interface{} `json:"values"`
+	ValueString  string                 `json:"valueString"`
+}
+
+// GrafanaEvalMatch represents a metric that triggered a legacy Grafana alert.
+type GrafanaEvalMatch struct {
+	Value  float64           `json:"value"`
+	Metric string            `json:"metric"`
+	Tags   map[string]string `json:"tags"`
+}
+
+// grafanaAlert is a Grafana alert normalized for processing.
+type grafanaAlert struct {
+	firing   bool
+	dedupKey string
+	input    service.CreateAlertInput
+}
+
+// GrafanaWebhookHandler handles POST /api/v1/webhooks/grafana
+//
+//	@Summary		Receive Grafana webhook
+//	@Description	Receives unified and legacy alerts from Grafana, deduplicating by rule and fingerprint
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			payload	body	GrafanaWebhook	true	"Grafana webhook payload"
+//	@Success		200
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/webhooks/grafana [post]
+func (h *WebhookHandler) GrafanaWebhookHandler(c *fiber.Ctx) error {
+	var payload GrafanaWebhook
+	if err := c.BodyParser(&payload); err != nil {
+		log.Error().Err(err).Msg("Failed to parse Grafana webhook")
+		return helper.BadRequest(c, "Invalid webhook payload")
+	}
+
+	alerts := h.normalizeGrafana(payload)
+
+	log.Info().
+		Str("status", payload.Status).
+		Str("state", payload.State).
+		Int("alert_count", len(alerts)).
+		Msg("Received Grafana webhook")
+
+	var created, deduplicated, resolved int
+	for _, alert := range alerts {
+		if alert.firing {
+			_, isNew, err := h.alertService.CreateDeduplicated(c.Context(), alert.dedupKey, alert.input)
+			if err != nil {
+				log.Error().Err(err).Str("dedup_key", alert.dedupKey).Msg("Failed to create alert from Grafana")
+				return helper.InternalError(c, "Failed to process webhook")
+			}
+			if isNew {
+				created++
+			} else {
+				deduplicated++
+			}
+			continue
+		}
+
+		if _, err := h.alertService.ResolveByDedupKey(c.Context(), alert.dedupKey); err != nil {
+			if errors.Is(err, service.ErrAlertNotFound) {
+				continue
+			}
+			log.Error().Err(err).Str("dedup_key", alert.dedupKey).Msg("Failed to resolve alert from Grafana")
+			return helper.InternalError(c, "Failed to process webhook")
+		}
+		resolved++
+	}
+
+	return helper.Success(c, fiber.Map{
+		"status":       "received",
+		"created":      created,
+		"deduplicated": deduplicated,
+		"resolved":     resolved,
+	})
+}
+
+// normalizeGrafana converts a Grafana payload into alerts to create or resolve.
+func (h *WebhookHandler) normalizeGrafana(payload GrafanaWebhook) []grafanaAlert {
+	if len(payload.Alerts) == 0 {
+		if alert, ok := h.normalizeLegacyGrafana(payload); ok {
+			return []grafanaAlert{alert}
+		}
+		return nil
+	}
+
+	alerts := make([]grafanaAlert, 0, len(payload.Alerts))
+	for _, a := range payload.Alerts {
+		ruleID := a.Labels["__alert_rule_uid__"]
+		if ruleID == "" {
+			ruleID = a.Labels["alertname"]
+		}
+
+		title := a.Labels["alertname"]
+		if title == "" {
+			title = "Grafana Alert"
+		}
+
+		message := a.Annotations["description"]
+		if message == "" {
+			message = a.Annotations["summary"]
+		}
+		if message == "" {
+			message = a.ValueString
+		}
+		if message == "" {
+			message = "Alert triggered from Grafana"
+		}
+
+		alerts = append(alerts, grafanaAlert{
+			firing:   a.Status == "firing",
+			dedupKey: grafanaDedupKey(ruleID, a.Fingerprint, a.Labels),
+			input: service.CreateAlertInput{
+				Title:    title,
+				Message:  message,
+				Severity: h.mapSeverity(a.Labels["severity"]),
+				Source:   "grafana",
+				Metadata: map[string]interface{}{
+					"fingerprint":   a.Fingerprint,
+					"rule_uid":      ruleID,
+					"generator_url": a.GeneratorURL,
+					"dashboard_url": a.DashboardURL,
+					"panel_url":     a.PanelURL,
+					"labels":        a.Labels,
+					"annotations":   a.Annotations,
+					"values":        a.Values,
+					"starts_at":     a.StartsAt,
+				},
+			},
+		})
+	}
+
+	return alerts
+}
+
+// normalizeLegacyGrafana converts a legacy Grafana alert notification.
+// Pending and paused states are ignored.
+func (h *WebhookHandler) normalizeLegacyGrafana(payload GrafanaWebhook) (grafanaAlert, bool) {
+	var firing bool
+	switch payload.State {
+	case "alerting", "no_data":
+		firing = true
+	case "ok":
+		firing = false
+	default:
+		return grafanaAlert{}, false
+	}
+
+	title := payload.RuleName
+	if title == "" {
+		title = payload.Title
+	}
+	if title == "" {
+		title = "Grafana Alert"
+	}
+
+	message := payload.Message
+	if len(payload.EvalMatches) > 0 {
+		matches := make([]string, len(payload.EvalMatches))
+		for i, m := range payload.EvalMatches {
+			matches[i] = fmt.Sprintf("%s=%g", m.Metric, m.Value)
+		}
+		if message != "" {
+			message += "\n"
+		}
+		message += strings.Join(matches, ", ")
+	}
+	if message == "" {
+		message = "Alert triggered from Grafana"
+	}
+
+	// Without a rule ID the alert is told apart by its name and tags.
+	var dedupKey string
+	if payload.RuleID != 0 {
+		dedupKey = grafanaDedupKey(strconv.FormatInt(payload.RuleID, 10), "", nil)
+	} else {
+		labels := maps.Clone(payload.Tags)
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels["alertname"] = title
+		dedupKey = grafanaDedupKey("", "", labels)
+	}
+
+	return grafanaAlert{
+		firing:   firing,
+		dedupKey: dedupKey,
+		input: service.CreateAlertInput{
+			Title:    title,
+			Message:  message,
+			Severity: h.mapSeverity(payload.Tags["severity"]),
+			Source:   "grafana",
+			Metadata: map[string]interface{}{
+				"rule_id":      payload.RuleID,
+				"rule_url":     payload.RuleURL,
+				"image_url":    payload.ImageURL,
+				"state":        payload.State,
+				"eval_matches": payload.EvalMatches,
+				"tags":         payload.Tags,
+			},
+		},
+	}, true
+}
+
+// grafanaDedupKey builds the deduplication key for a Grafana alert. When
+// Grafana sends no fingerprint, the alert is told apart by a hash of its
+// labels, so that unrelated alerts are not deduplicated into one.
+func grafanaDedupKey(ruleID, fingerprint string, labels map[string]string) string {
+	if fingerprint == "" && len(labels) > 0 {
+		fingerprint = grafanaLabelsFingerprint(labels)
+	}
+
+	switch {
+	case fingerprint == "":
+		return "grafana:" + ruleID
+	case ruleID == "":
+		return "grafana:" + fingerprint
+	}
+	return "grafana:" + ruleID + ":" + fingerprint
+}
+
+// grafanaLabelsFingerprint hashes a label set, whatever the order of its labels.
+func grafanaLabelsFingerprint(labels map[string]string) string {
+	hash := sha256.New()
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		fmt.Fprintf(hash, "%s=%q,", name, labels[name])
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
@@ -38,7 +38,10 @@ type AlertManagerAlert struct {
 
 // WebhookHandler handles webhook endpoints.
 type WebhookHandler struct {
-	alertService *service.AlertService
+	alertService       *service.AlertService
+	sentryClientSecret string
+	sentryTolerance    time.Duration
+	snsTopics          map[string]struct{}
 }
 
 // NewWebhookHandler creates a new webhook handler.
@@ -48,16 +51,37 @@ func NewWebhookHandler(alertService *service.AlertService) *WebhookHandler {
 	}
 }
 
+// SetSentryClientSecret sets the secret used to verify Sentry webhook
+// signatures, and the maximum clock skew of the signed timestamp. Sentry
+// webhooks are rejected until a secret is set.
+func (h *WebhookHandler) SetSentryClientSecret(secret string, tolerance time.Duration) {
+	if tolerance <= 0 {
+		tolerance = 5 * time.Minute
+	}
+	h.sentryClientSecret = secret
+	h.sentryTolerance = tolerance
+}
+
+// SetCloudWatchTopics sets the SNS topics whose messages are accepted.
+// SNS messages are rejected until a topic is set.
+func (h *WebhookHandler) SetCloudWatchTopics(topicARNs []string) {
+	h.snsTopics = make(map[string]struct{}, len(topicARNs))
+	for _, arn := range topicARNs {
+		h.snsTopics[arn] = struct{}{}
+	}
+}
+
 // AlertManagerWebhookHandler handles POST /api/v1/webhooks/alertmanager
 //
 //	@Summary		Receive AlertManager webhook
-//	@Description	Receives alerts from Prometheus AlertManager
+//	@Description	Receives alerts from Prometheus AlertManager. Invalid alerts are skipped and listed under rejected.
 //	@Tags			webhooks
 //	@Accept			json
 //	@Produce		json
 //	@Param			payload	body	AlertManagerWebhook	true	"AlertManager webhook payload"
 //	@Success		200
 //	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
 //	@Router			/webhooks/alertmanager [post]
 func (h *WebhookHandler) AlertManagerWebhookHandler(c *fiber.Ctx) error {
 	var payload AlertManagerWebhook
@@ -72,17 +96,41 @@ func (h *WebhookHandler) AlertManagerWebhookHandler(c *fiber.Ctx) error {
 		Int("alert_count", len(payload.Alerts)).
 		Msg("Received AlertManager webhook")
 
-	for _, alert := range payload.Alerts {
-		if err := h.processAlert(c, alert); err != nil {
-			log.Error().Err(err).Str("fingerprint", alert.Fingerprint).Msg("Failed to process alert")
+	// Invalid alerts are skipped one by one: failing the whole group would
+	// make Alertmanager retry it, and hold back its valid alerts, forever.
+	inputs := make([]service.CreateAlertInput, 0, len(payload.Alerts))
+	rejected := []fiber.Map{}
+	for i, alert := range payload.Alerts {
+		input, ok := h.processAlert(alert)
+		if !ok {
+			continue
+		}
+		if err := h.alertService.ValidateAlert(input); err != nil {
+			log.Warn().
+				Err(err).
+				Str("alertname", alert.Labels["alertname"]).
+				Str("fingerprint", alert.Fingerprint).
+				Msg("Skipped invalid AlertManager alert")
+			rejected = append(rejected, fiber.Map{"index": i, "fingerprint": alert.Fingerprint, "error": err.Error()})
+			continue
+		}
+		inputs = append(inputs, input)
+	}
+
+	if len(inputs) > 0 {
+		if _, err := h.alertService.CreateBatch(c.Context(), inputs); err != nil {
+			return RespondError(c, err, "Failed to process webhook")
 		}
+
+		log.Info().Int("alert_count", len(inputs)).Msg("Created alerts from AlertManager")
 	}
 
-	return helper.Success(c, fiber.Map{"status": "received"})
+	return helper.Success(c, fiber.Map{"status": "received", "created": len(inputs), "rejected": rejected})
 }
 
-// processAlert processes a single AlertManager alert.
-func (h *WebhookHandler) processAlert(c *fiber.Ctx, alert AlertManagerAlert) error {
+// processAlert converts a single AlertManager alert into a create input.
+// It returns false for alerts that should not create a new alert (e.g. resolved).
+func (h *WebhookHandler) processAlert(alert AlertManagerAlert) (service.CreateAlertInput, bool) {
 	severity := h.mapSeverity(alert.Labels["severity"])
 
 	title := alert.Labels["alertname"]
@@ -104,40 +152,28 @@ func (h *WebhookHandler) processAlert(c *fiber.Ctx, alert AlertManagerAlert) err
 	}
 
 	// Only create alerts for firing status
-	if alert.Status == "firing" {
-		input := service.CreateAlertInput{
-			Title:    title,
-			Message:  message,
-			Severity: severity,
-			Source:   source,
-			Metadata: map[string]interface{}{
-				"fingerprint":   alert.Fingerprint,
-				"generator_url": alert.GeneratorURL,
-				"labels":        alert.Labels,
-				"annotations":   alert.Annotations,
-				"starts_at":     alert.StartsAt,
-			},
-		}
-
-		_, err := h.alertService.Create(c.Context(), input)
-		if err != nil {
-			return err
-		}
-
-		log.Info().
-			Str("alertname", title).
-			Str("severity", string(severity)).
-			Str("fingerprint", alert.Fingerprint).
-			Msg("Created alert from AlertManager")
-	} else {
+	if alert.Status != "firing" {
 		log.Info().
 			Str("alertname", title).
 			Str("status", alert.Status).
 			Str("fingerprint", alert.Fingerprint).
 			Msg("Alert resolved in AlertManager")
+		return service.CreateAlertInput{}, false
 	}
 
-	return nil
+	return service.CreateAlertInput{
+		Title:    title,
+		Message:  message,
+		Severity: severity,
+		Source:   source,
+		Metadata: map[string]interface{}{
+			"fingerprint":   alert.Fingerprint,
+			"generator_url": alert.GeneratorURL,
+			"labels":        alert.Labels,
+			"annotations":   alert.Annotations,
+			"starts_at":     alert.StartsAt,
+		},
+	}, true
 }
 
 // mapSeverity maps AlertManager severity to entity severity.
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// WebhookSecretHandler handles webhook signing secret management.
+type WebhookSecretHandler struct {
+	secretService *service.WebhookSecretService
+}
+
+// NewWebhookSecretHandler creates a new webhook secret handler.
+func NewWebhookSecretHandler(secretService *service.WebhookSecretService) *WebhookSecretHandler {
+	return &WebhookSecretHandler{secretService: secretService}
+}
+
+// List handles GET /api/v1/admin/webhook-secrets
+//
+//	@Summary		List webhook signing secrets
+//	@Description	List the integrations that require signed payloads (secrets are not returned)
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		dto.WebhookSecretResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/webhook-secrets [get]
+func (h *WebhookSecretHandler) List(c *fiber.Ctx) error {
+	secrets, err := h.secretService.List(c.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list webhook secrets")
+		return helper.InternalError(c, "Failed to list webhook secrets")
+	}
+
+	return helper.Success(c, dto.WebhookSecretsFromEntities(secrets))
+}
+
+// Set handles PUT /api/v1/admin/webhook-secrets/:integration
+//
+//	@Summary		Set webhook signing secret
+//	@Description	Configure or rotate the HMAC secret of an integration (alertmanager, grafana, datadog). The secret is only returned here.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			integration	path		string						true	"Integration"
+//	@Param			request		body		dto.SetWebhookSecretRequest	false	"Secret (generated when empty)"
+//	@Success		200			{object}	dto.WebhookSecretResponse
+//	@Failure		400			{object}	dto.ErrorResponse
+//	@Failure		422			{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/webhook-secrets/{integration} [put]
+func (h *WebhookSecretHandler) Set(c *fiber.Ctx) error {
+	var req dto.SetWebhookSecretRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return helper.BadRequest(c, "Invalid request body")
+		}
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	var createdBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &userID
+	}
+
+	ws, secret, err := h.secretService.Set(c.Context(), c.Params("integration"), req.Secret, createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to set webhook secret")
+	}
+
+	response := dto.WebhookSecretFromEntity(ws)
+	response.Secret = secret
+	return helper.Success(c, response)
+}
+
+// Delete handles DELETE /api/v1/admin/webhook-secrets/:integration
+//
+//	@Summary		Delete webhook signing secret
+//	@Description	Remove an integration's secret; it accepts unsigned payloads again unless signatures are required
+//	@Tags			admin
+//	@Param			integration	path	string	true	"Integration"
+//	@Success		204
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/webhook-secrets/{integration} [delete]
+func (h *WebhookSecretHandler) Delete(c *fiber.Ctx) error {
+	if err := h.secretService.Delete(c.Context(), c.Params("integration")); err != nil {
+		return h.handleError(c, err, "Failed to delete webhook secret")
+	}
+
+	return helper.NoContent(c)
+}
+
+// handleError maps webhook secret service errors to HTTP responses.
+func (h *WebhookSecretHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrWebhookSecretNotFound):
+		return helper.NotFound(c, "Webhook secret not found")
+	case errors.Is(err, entity.ErrWebhookIntegrationInvalid),
+		errors.Is(err, entity.ErrWebhookSecretTooShort):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
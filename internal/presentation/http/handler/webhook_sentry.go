@@ -0,0 +1,355 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// Sentry integration platform headers.
+const (
+	sentryResourceHeader  = "Sentry-Hook-Resource"
+	sentrySignatureHeader = "Sentry-Hook-Signature"
+	sentryTimestampHeader = "Sentry-Hook-Timestamp"
+)
+
+// SentryWebhook represents a Sentry integration webhook payload.
+// Issue webhooks carry Data.Issue; issue alert rules carry Data.Event.
+type SentryWebhook struct {
+	Action       string             `json:"action"`
+	Installation SentryInstallation `json:"installation"`
+	Data         SentryWebhookData  `json:"data"`
+}
+
+// SentryInstallation identifies the Sentry integration installation.
+type SentryInstallation struct {
+	UUID string `json:"uuid"`
+}
+
+// SentryWebhookData holds the resource a Sentry webhook refers to.
+type SentryWebhookData struct {
+	Issue         *SentryIssue `json:"issue"`
+	Event         *SentryEvent `json:"event"`
+	TriggeredRule string       `json:"triggered_rule"`
+}
+
+// SentryIssue represents a Sentry issue.
+type SentryIssue struct {
+	ID        json.Number       `json:"id"`
+	ShortID   string            `json:"shortId"`
+	Title     string            `json:"title"`
+	Culprit   string            `json:"culprit"`
+	Level     string            `json:"level"`
+	Status    string            `json:"status"`
+	Platform  string            `json:"platform"`
+	Permalink string            `json:"permalink"`
+	WebURL    string            `json:"web_url"`
+	FirstSeen string            `json:"firstSeen"`
+	LastSeen  string            `json:"lastSeen"`
+	Count     string            `json:"count"`
+	Project   SentryProject     `json:"project"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+// SentryProject represents the project an issue belongs to.
+type SentryProject struct {
+	ID   json.Number `json:"id"`
+	Name string      `json:"name"`
+	Slug string      `json:"slug"`
+}
+
+// SentryEvent represents the event that triggered a Sentry issue alert rule.
+type SentryEvent struct {
+	EventID     string      `json:"event_id"`
+	IssueID     json.Number `json:"issue_id"`
+	Title       string      `json:"title"`
+	Message     string      `json:"message"`
+	Culprit     string      `json:"culprit"`
+	Level       string      `json:"level"`
+	Environment string      `json:"environment"`
+	Release     string      `json:"release"`
+	Platform    string      `json:"platform"`
+	Project     json.Number `json:"project"`
+	WebURL      string      `json:"web_url"`
+	IssueURL    string      `json:"issue_url"`
+	Tags        [][]string  `json:"tags"`
+}
+
+// SentryWebhookHandler handles POST /api/v1/webhooks/sentry
+//
+//	@Summary		Receive Sentry webhook
+//	@Description	Receives issue and issue alert webhooks from Sentry, resolving alerts when the issue is resolved
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			Sentry-Hook-Resource	header	string			false	"Webhook resource (issue, event_alert)"
+//	@Param			Sentry-Hook-Signature	header	string			true	"HMAC-SHA256 of the body using the client secret"
+//	@Param			Sentry-Hook-Timestamp	header	string			true	"Unix time the webhook was sent at"
+//	@Param			payload					body	SentryWebhook	true	"Sentry webhook payload"
+//	@Success		200
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/webhooks/sentry [post]
+func (h *WebhookHandler) SentryWebhookHandler(c *fiber.Ctx) error {
+	if err := h.verifySentry(c); err != nil {
+		log.Warn().Err(err).Str("ip", c.IP()).Msg("Rejected Sentry webhook")
+		return helper.Unauthorized(c, err.Error())
+	}
+
+	var payload SentryWebhook
+	if err := c.BodyParser(&payload); err != nil {
+		log.Error().Err(err).Msg("Failed to parse Sentry webhook")
+		return helper.BadRequest(c, "Invalid webhook payload")
+	}
+
+	resource := c.Get(sentryResourceHeader)
+
+	log.Info().
+		Str("resource", resource).
+		Str("action", payload.Action).
+		Msg("Received Sentry webhook")
+
+	switch {
+	case payload.Data.Issue != nil:
+		return h.handleSentryIssue(c, payload.Action, payload.Data.Issue)
+	case payload.Data.Event != nil:
+		return h.handleSentryEvent(c, payload.Data.TriggeredRule, payload.Data.Event)
+	default:
+		return helper.Success(c, fiber.Map{"status": "received", "action": "none"})
+	}
+}
+
+// handleSentryIssue creates or resolves an alert for an issue state change.
+// Assignment and ignore actions are acknowledged without changes.
+func (h *WebhookHandler) handleSentryIssue(c *fiber.Ctx, action string, issue *SentryIssue) error {
+	if issue.ID == "" {
+		return helper.BadRequest(c, "Missing issue id")
+	}
+
+	dedupKey := sentryDedupKey(issue.ID.String())
+
+	switch action {
+	case "resolved":
+		return h.resolveSentry(c, dedupKey)
+	case "created", "unresolved":
+	default:
+		return helper.Success(c, fiber.Map{"status": "received", "action": "none"})
+	}
+
+	title := issue.Title
+	if title == "" {
+		title = "Sentry Issue"
+	}
+
+	message := issue.Metadata["value"]
+	if message == "" {
+		message = issue.Culprit
+	}
+	if message == "" {
+		message = "Issue reported by Sentry"
+	}
+
+	link := issue.WebURL
+	if link == "" {
+		link = issue.Permalink
+	}
+
+	input := service.CreateAlertInput{
+		Title:    title,
+		Message:  message,
+		Severity: sentrySeverity(issue.Level),
+		Source:   sentrySource(issue.Project.Slug),
+		Metadata: map[string]interface{}{
+			"issue_id":     issue.ID.String(),
+			"short_id":     issue.ShortID,
+			"project_id":   issue.Project.ID.String(),
+			"project":      issue.Project.Slug,
+			"project_name": issue.Project.Name,
+			"culprit":      issue.Culprit,
+			"platform":     issue.Platform,
+			"level":        issue.Level,
+			"link":         link,
+			"first_seen":   issue.FirstSeen,
+		},
+	}
+
+	return h.createSentry(c, dedupKey, input)
+}
+
+// handleSentryEvent creates an alert for an event that triggered an issue alert rule.
+func (h *WebhookHandler) handleSentryEvent(c *fiber.Ctx, rule string, event *SentryEvent) error {
+	if event.IssueID == "" {
+		return helper.BadRequest(c, "Missing issue id")
+	}
+
+	tags := make(map[string]string, len(event.Tags))
+	for _, tag := range event.Tags {
+		if len(tag) == 2 {
+			tags[tag[0]] = tag[1]
+		}
+	}
+
+	environment := event.Environment
+	if environment == "" {
+		environment = tags["environment"]
+	}
+
+	title := event.Title
+	if title == "" {
+		title = "Sentry Issue"
+	}
+
+	message := event.Message
+	if message == "" {
+		message = event.Culprit
+	}
+	if message == "" {
+		message = "Issue alert triggered in Sentry"
+	}
+
+	link := event.IssueURL
+	if link == "" {
+		link = event.WebURL
+	}
+
+	input := service.CreateAlertInput{
+		Title:    title,
+		Message:  message,
+		Severity: sentrySeverity(event.Level),
+		Source:   sentrySource(""),
+		Metadata: map[string]interface{}{
+			"issue_id":       event.IssueID.String(),
+			"event_id":       event.EventID,
+			"project_id":     event.Project.String(),
+			"environment":    environment,
+			"release":        event.Release,
+			"culprit":        event.Culprit,
+			"platform":       event.Platform,
+			"level":          event.Level,
+			"triggered_rule": rule,
+			"link":           link,
+			"labels":         tags,
+		},
+	}
+
+	return h.createSentry(c, sentryDedupKey(event.IssueID.String()), input)
+}
+
+// createSentry creates an alert for a Sentry issue unless one is already open.
+func (h *WebhookHandler) createSentry(c *fiber.Ctx, dedupKey string, input service.CreateAlertInput) error {
+	_, created, err := h.alertService.CreateDeduplicated(c.Context(), dedupKey, input)
+	if err != nil {
+		log.Error().Err(err).Str("dedup_key", dedupKey).Msg("Failed to create alert from Sentry")
+		return helper.InternalError(c, "Failed to process webhook")
+	}
+
+	action := "deduplicated"
+	if created {
+		action = "created"
+	}
+
+	return helper.Success(c, fiber.Map{"status": "received", "action": action})
+}
+
+// resolveSentry resolves the open alert of a Sentry issue, if any.
+func (h *WebhookHandler) resolveSentry(c *fiber.Ctx, dedupKey string) error {
+	if _, err := h.alertService.ResolveByDedupKey(c.Context(), dedupKey); err != nil {
+		if errors.Is(err, service.ErrAlertNotFound) {
+			return helper.Success(c, fiber.Map{"status": "received", "action": "none"})
+		}
+		log.Error().Err(err).Str("dedup_key", dedupKey).Msg("Failed to resolve alert from Sentry")
+		return helper.InternalError(c, "Failed to process webhook")
+	}
+
+	return helper.Success(c, fiber.Map{"status": "received", "action": "resolved"})
+}
+
+// verifySentry checks the signature and the timestamp of a Sentry webhook.
+// Webhooks are rejected while no client secret is configured.
+func (h *WebhookHandler) verifySentry(c *fiber.Ctx) error {
+	if h.sentryClientSecret == "" {
+		return service.ErrWebhookSecretNotConfigured
+	}
+
+	timestamp := c.Get(sentryTimestampHeader)
+	signature := c.Get(sentrySignatureHeader)
+	if timestamp == "" || signature == "" {
+		return service.ErrWebhookSignatureMissing
+	}
+
+	sentAt, err := strconv.ParseFloat(timestamp, 64)
+	if err != nil {
+		return service.ErrWebhookTimestampStale
+	}
+
+	skew := time.Since(time.Unix(int64(sentAt), 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > h.sentryTolerance {
+		return service.ErrWebhookTimestampStale
+	}
+
+	if !verifySentrySignature(h.sentryClientSecret, c.Body(), signature) {
+		return service.ErrWebhookSignatureInvalid
+	}
+	return nil
+}
+
+// verifySentrySignature checks the hex HMAC-SHA256 signature Sentry computes
+// over the raw request body with the integration client secret.
+func verifySentrySignature(secret string, body []byte, signature string) bool {
+	if signature == "" {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// sentrySeverity maps a Sentry event level to an alert severity.
+func sentrySeverity(level string) entity.AlertSeverity {
+	switch level {
+	case "fatal":
+		return entity.AlertSeverityCritical
+	case "error":
+		return entity.AlertSeverityHigh
+	case "warning":
+		return entity.AlertSeverityMedium
+	case "info":
+		return entity.AlertSeverityInfo
+	default:
+		return entity.AlertSeverityLow
+	}
+}
+
+// sentrySource builds the alert source for a Sentry project.
+func sentrySource(project string) string {
+	if project == "" {
+		return "sentry"
+	}
+	return "sentry:" + project
+}
+
+// sentryDedupKey builds the deduplication key for a Sentry issue.
+func sentryDedupKey(issueID string) string {
+	return "sentry:" + issueID
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// inboundWebhookPath is the path of the generic inbound webhook, followed by the source token.
+const inboundWebhookPath = "/api/v1/webhooks/inbound/"
+
+// WebhookSourceHandler handles inbound webhook source management and ingestion.
+type WebhookSourceHandler struct {
+	sourceService *service.WebhookSourceService
+	pagination    valueobject.PaginationPolicy
+}
+
+// NewWebhookSourceHandler creates a new webhook source handler.
+func NewWebhookSourceHandler(
+	sourceService *service.WebhookSourceService,
+	pagination valueobject.PaginationPolicy,
+) *WebhookSourceHandler {
+	return &WebhookSourceHandler{
+		sourceService: sourceService,
+		pagination:    pagination,
+	}
+}
+
+// Create handles POST /api/v1/admin/webhook-sources
+//
+//	@Summary		Register inbound webhook source
+//	@Description	Register an external system and get its tokenized webhook URL. The token is only returned once.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.CreateWebhookSourceRequest	true	"Source data"
+//	@Success		201		{object}	dto.WebhookSourceResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		401		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		409		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/webhook-sources [post]
+func (h *WebhookSourceHandler) Create(c *fiber.Ctx) error {
+	var req dto.CreateWebhookSourceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	var createdBy *entity.ID
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		createdBy = &userID
+	}
+
+	source, token, err := h.sourceService.Register(c.Context(), req.Name, req.Mapping.ToEntity(), createdBy)
+	if err != nil {
+		return h.handleError(c, err, "Failed to register webhook source")
+	}
+
+	return helper.Created(c, h.withToken(c, source, token))
+}
+
+// List handles GET /api/v1/admin/webhook-sources
+//
+//	@Summary		List inbound webhook sources
+//	@Description	Retrieve registered inbound webhook sources
+//	@Tags			admin
+//	@Produce		json
+//	@Param			page		query		int	false	"Page number"		default(1)
+//	@Param			page_size	query		int	false	"Items per page, capped at the configured maximum"	default(20)
+//	@Success		200			{object}	dto.PaginatedResponse[dto.WebhookSourceResponse]
+//	@Failure		401			{object}	dto.ErrorResponse
+//	@Failure		403			{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/webhook-sources [get]
+func (h *WebhookSourceHandler) List(c *fiber.Ctx) error {
+	pagination := h.pagination.Paginate(c.QueryInt("page"), c.QueryInt("page_size"))
+
+	result, err := h.sourceService.List(c.Context(), pagination)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list webhook sources")
+		return helper.InternalError(c, "Failed to list webhook sources")
+	}
+
+	return helper.Success(c, dto.PaginatedResponse[dto.WebhookSourceResponse]{
+		Items:       dto.WebhookSourcesFromEntities(result.Items),
+		TotalItems:  result.TotalItems,
+		TotalPages:  result.TotalPages,
+		CurrentPage: result.CurrentPage,
+		PageSize:    result.PageSize,
+		HasNext:     result.HasNext,
+		HasPrevious: result.HasPrevious,
+	})
+}
+
+// GetByID handles GET /api/v1/admin/webhook-sources/:id
+//
+//	@Summary		Get inbound webhook source
+//	@Description	Retrieve an inbound webhook source
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"Source ID"
+//	@Success		200	{object}	dto.WebhookSourceResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/webhook-sources/{id} [get]
+func (h *WebhookSourceHandler) GetByID(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid webhook source ID")
+	}
+
+	source, err := h.sourceService.GetByID(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to get webhook source")
+	}
+
+	return helper.Success(c, dto.WebhookSourceFromEntity(source))
+}
+
+// Update handles PATCH /api/v1/admin/webhook-sources/:id
+//
+//	@Summary		Update inbound webhook source
+//	@Description	Change the mapping or enable/disable an inbound webhook source
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Source ID"
+//	@Param			request	body		dto.UpdateWebhookSourceRequest	true	"Changes"
+//	@Success		200		{object}	dto.WebhookSourceResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ValidationErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/webhook-sources/{id} [patch]
+func (h *WebhookSourceHandler) Update(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid webhook source ID")
+	}
+
+	var req dto.UpdateWebhookSourceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Invalid request body")
+	}
+
+	if validationErrors := helper.ValidateStruct(req); len(validationErrors) > 0 {
+		return helper.ValidationErrors(c, validationErrors)
+	}
+
+	var mapping *entity.WebhookMapping
+	if req.Mapping != nil {
+		m := req.Mapping.ToEntity()
+		mapping = &m
+	}
+
+	source, err := h.sourceService.Update(c.Context(), id, mapping, req.IsEnabled)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update webhook source")
+	}
+
+	return helper.Success(c, dto.WebhookSourceFromEntity(source))
+}
+
+// RotateToken handles POST /api/v1/admin/webhook-sources/:id/rotate-token
+//
+//	@Summary		Rotate inbound webhook token
+//	@Description	Issue a new webhook token; the previous URL stops working immediately
+//	@Tags			admin
+//	@Produce		json
+//	@Param			id	path		string	true	"Source ID"
+//	@Success		200	{object}	dto.WebhookSourceResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/webhook-sources/{id}/rotate-token [post]
+func (h *WebhookSourceHandler) RotateToken(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid webhook source ID")
+	}
+
+	source, token, err := h.sourceService.RotateToken(c.Context(), id)
+	if err != nil {
+		return h.handleError(c, err, "Failed to rotate webhook token")
+	}
+
+	return helper.Success(c, h.withToken(c, source, token))
+}
+
+// Delete handles DELETE /api/v1/admin/webhook-sources/:id
+//
+//	@Summary		Delete inbound webhook source
+//	@Description	Remove an inbound webhook source and invalidate its URL
+//	@Tags			admin
+//	@Param			id	path	string	true	"Source ID"
+//	@Success		204
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/admin/webhook-sources/{id} [delete]
+func (h *WebhookSourceHandler) Delete(c *fiber.Ctx) error {
+	id, err := entity.ParseID(c.Params("id"))
+	if err != nil {
+		return helper.BadRequest(c, "Invalid webhook source ID")
+	}
+
+	if err := h.sourceService.Delete(c.Context(), id); err != nil {
+		return h.handleError(c, err, "Failed to delete webhook source")
+	}
+
+	return helper.NoContent(c)
+}
+
+// Ingest handles POST /api/v1/webhooks/inbound/:token
+//
+//	@Summary		Receive generic inbound webhook
+//	@Description	Create or resolve an alert from an arbitrary JSON payload using the source's mapping
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	path		string	true	"Source webhook token"
+//	@Success		200		{object}	dto.InboundWebhookResponse
+//	@Failure		400		{object}	dto.ErrorResponse
+//	@Failure		403		{object}	dto.ErrorResponse
+//	@Failure		404		{object}	dto.ErrorResponse
+//	@Failure		422		{object}	dto.ErrorResponse
+//	@Failure		429		{object}	dto.ErrorResponse
+//	@Failure		500		{object}	dto.ErrorResponse
+//	@Router			/webhooks/inbound/{token} [post]
+func (h *WebhookSourceHandler) Ingest(c *fiber.Ctx) error {
+	action, alert, err := h.sourceService.Ingest(c.Context(), c.Params("token"), c.Body())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrWebhookSourceNotFound):
+			return helper.NotFound(c, "Unknown webhook")
+		case errors.Is(err, service.ErrWebhookSourceDisabled):
+			return helper.Forbidden(c, "Webhook source is disabled")
+		case errors.Is(err, service.ErrWebhookPayloadInvalid):
+			return helper.BadRequest(c, "Invalid webhook payload")
+		case errors.Is(err, service.ErrWebhookPayloadUnmapped):
+			return helper.UnprocessableEntity(c, "Payload does not match the source mapping")
+		case errors.Is(err, service.ErrQuotaExceeded):
+			return RespondError(c, err, "Failed to process webhook")
+		}
+		log.Error().Err(err).Msg("Failed to process inbound webhook")
+		return helper.InternalError(c, "Failed to process webhook")
+	}
+
+	response := dto.InboundWebhookResponse{Status: "received", Action: action}
+	if alert != nil {
+		response.AlertID = alert.ID.String()
+	}
+
+	return helper.Success(c, response)
+}
+
+// withToken builds a response carrying a freshly issued token and its URL.
+func (h *WebhookSourceHandler) withToken(c *fiber.Ctx, source *entity.WebhookSource, token string) dto.WebhookSourceResponse {
+	response := dto.WebhookSourceFromEntity(source)
+	response.Token = token
+	response.URL = c.BaseURL() + inboundWebhookPath + token
+	return response
+}
+
+// handleError maps webhook source service errors to HTTP responses.
+func (h *WebhookSourceHandler) handleError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrWebhookSourceNotFound):
+		return helper.NotFound(c, "Webhook source not found")
+	case errors.Is(err, service.ErrWebhookSourceExists):
+		return helper.Conflict(c, "Webhook source with this name already exists")
+	case errors.Is(err, entity.ErrWebhookSourceNameRequired),
+		errors.Is(err, entity.ErrWebhookSourceNameTooLong),
+		errors.Is(err, entity.ErrWebhookMappingTitleRequired),
+		errors.Is(err, entity.ErrWebhookMappingInvalidSeverity):
+		return helper.BadRequest(c, err.Error())
+	}
+
+	log.Error().Err(err).Msg(message)
+	return helper.InternalError(c, message)
+}
@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WeakETag returns a weak entity tag hashing the versions a response is built
+// from, such as the IDs and update times of the resources it holds.
+func WeakETag(versions ...string) string {
+	h := sha256.New()
+	for _, version := range versions {
+		_, _ = h.Write([]byte(version))
+		_, _ = h.Write([]byte{0})
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// NotModified sets the entity tag of a response and reports whether the
+// request's If-None-Match already matches it. Clients must revalidate the
+// tag before reusing a cached response.
+func NotModified(c *fiber.Ctx, etag string) bool {
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	return c.Fresh()
+}
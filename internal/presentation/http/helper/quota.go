@@ -0,0 +1,19 @@
+package helper
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetQuotaHeaders advertises the state of a daily quota. Unlimited quotas
+// (zero limit) are not advertised.
+func SetQuotaHeaders(c *fiber.Ctx, limit, remaining int, resetAt time.Time) {
+	if limit <= 0 {
+		return
+	}
+	c.Set("X-Quota-Limit", strconv.Itoa(limit))
+	c.Set("X-Quota-Remaining", strconv.Itoa(remaining))
+	c.Set("X-Quota-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+}
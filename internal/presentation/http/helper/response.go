@@ -2,13 +2,25 @@
 package helper
 
 import (
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	dtov2 "github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto/v2"
 )
 
+// ProblemContentType is the media type of error responses from v2 of the API.
+const ProblemContentType = "application/problem+json"
+
+// statusCodes are the error codes that differ from the status text.
+var statusCodes = map[int]string{
+	fiber.StatusInternalServerError: "INTERNAL_ERROR",
+}
+
 // JSON sends a JSON response with the given status code.
 func JSON(c *fiber.Ctx, status int, data interface{}) error {
 	return c.Status(status).JSON(data)
@@ -29,15 +41,53 @@ func NoContent(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
-// Error sends an error response.
+// Error sends an error response in the format of the request's API version:
+// the error object of v1, or an RFC 7807 problem from v2 on.
 func Error(c *fiber.Ctx, status int, message string, code string) error {
+	if APIVersion(c) < 2 {
+		requestID, _ := c.Locals("requestid").(string)
+		return JSON(c, status, dto.ErrorResponse{
+			Error:     message,
+			Code:      code,
+			Timestamp: time.Now().UTC(),
+			RequestID: requestID,
+		})
+	}
+	return Problem(c, problem(c, status, message, code))
+}
+
+// Problem sends a problem details response.
+func Problem(c *fiber.Ctx, p dtov2.ErrorResponse) error {
+	c.Status(p.Status)
+	return c.JSON(p, ProblemContentType)
+}
+
+// problem builds the problem details of an error response to the request.
+func problem(c *fiber.Ctx, status int, detail string, code string) dtov2.ErrorResponse {
 	requestID, _ := c.Locals("requestid").(string)
-	return JSON(c, status, dto.ErrorResponse{
-		Error:     message,
+	p := dtov2.ErrorResponse{
+		Type:      "about:blank",
+		Title:     utils.StatusMessage(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Path(),
 		Code:      code,
-		Timestamp: time.Now().UTC(),
 		RequestID: requestID,
-	})
+		Timestamp: time.Now().UTC(),
+	}
+	if traceID := trace.SpanContextFromContext(c.UserContext()).TraceID(); traceID.IsValid() {
+		p.TraceID = traceID.String()
+	}
+	return p
+}
+
+// StatusCode returns the generic error code of an HTTP status, e.g.
+// NOT_FOUND for 404.
+func StatusCode(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	return strings.ToUpper(strings.ReplaceAll(utils.StatusMessage(status), " ", "_"))
 }
 
 // BadRequest sends a 400 Bad Request response.
@@ -75,17 +125,31 @@ func InternalError(c *fiber.Ctx, message string) error {
 	return Error(c, fiber.StatusInternalServerError, message, "INTERNAL_ERROR")
 }
 
+// BadGateway sends a 502 Bad Gateway response, for failures of an upstream service.
+func BadGateway(c *fiber.Ctx, message string) error {
+	return Error(c, fiber.StatusBadGateway, message, "BAD_GATEWAY")
+}
+
 // ValidationErrors sends a 422 response with field-level errors.
 func ValidationErrors(c *fiber.Ctx, errors []ValidationError) error {
-	fields := make(map[string]string)
-	for _, e := range errors {
-		fields[e.Field] = e.Message
+	if APIVersion(c) < 2 {
+		fields := make(map[string]string)
+		for _, e := range errors {
+			fields[e.Field] = e.Message
+		}
+
+		return JSON(c, fiber.StatusUnprocessableEntity, dto.ValidationErrorResponse{
+			Error:     "Validation failed",
+			Code:      "VALIDATION_ERROR",
+			Fields:    fields,
+			Timestamp: time.Now().UTC(),
+		})
 	}
 
-	return JSON(c, fiber.StatusUnprocessableEntity, dto.ValidationErrorResponse{
-		Error:     "Validation failed",
-		Code:      "VALIDATION_ERROR",
-		Fields:    fields,
-		Timestamp: time.Now().UTC(),
-	})
+	p := problem(c, fiber.StatusUnprocessableEntity, "Validation failed", "VALIDATION_ERROR")
+	p.Errors = make([]dtov2.FieldError, len(errors))
+	for i, e := range errors {
+		p.Errors[i] = dtov2.FieldError{Field: e.Field, Rule: e.Rule, Message: e.Message}
+	}
+	return Problem(c, p)
 }
@@ -13,6 +13,7 @@ var Validator = validator.New()
 // ValidationError represents a field validation error.
 type ValidationError struct {
 	Field   string `json:"field"`
+	Rule    string `json:"rule"`
 	Message string `json:"message"`
 }
 
@@ -27,6 +28,7 @@ func ValidateStruct(s interface{}) []ValidationError {
 			for _, fe := range ve {
 				validationErrors = append(validationErrors, ValidationError{
 					Field:   toSnakeCase(fe.Field()),
+					Rule:    fe.Tag(),
 					Message: getErrorMessage(fe),
 				})
 			}
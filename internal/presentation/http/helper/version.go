@@ -0,0 +1,20 @@
+package helper
+
+import "github.com/gofiber/fiber/v2"
+
+// apiVersionKey is the context key of the API version a request was routed to.
+const apiVersionKey = "apiVersion"
+
+// SetAPIVersion records the API version a request was routed to.
+func SetAPIVersion(c *fiber.Ctx, version int) {
+	c.Locals(apiVersionKey, version)
+}
+
+// APIVersion returns the API version a request was routed to. Requests
+// outside of a versioned group, such as health checks, are served as v1.
+func APIVersion(c *fiber.Ctx) int {
+	if version, ok := c.Locals(apiVersionKey).(int); ok {
+		return version
+	}
+	return 1
+}
@@ -12,6 +12,10 @@ import (
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
 )
 
+// WebSocketTokenProtocol prefixes the access token offered as a WebSocket
+// subprotocol, e.g. "bearer.<token>".
+const WebSocketTokenProtocol = "bearer."
+
 // AuthMiddleware handles JWT authentication.
 type AuthMiddleware struct {
 	authService *service.AuthService
@@ -59,6 +63,11 @@ func (m *AuthMiddleware) Authenticate(c *fiber.Ctx) error {
 	c.Locals("userID", userID)
 	c.Locals("userEmail", claims.Email)
 	c.Locals("userRole", claims.Role)
+	c.Locals("sessionID", claims.SessionID)
+	c.Locals("accessToken", token)
+	if claims.ExpiresAt != nil {
+		c.Locals("tokenExpiresAt", claims.ExpiresAt.Time)
+	}
 	c.Locals("user", &dto.UserResponse{
 		ID:    claims.UserID,
 		Email: claims.Email,
@@ -69,8 +78,13 @@ func (m *AuthMiddleware) Authenticate(c *fiber.Ctx) error {
 }
 
 // OptionalAuth validates JWT if present, but allows unauthenticated requests.
+// WebSocket clients that cannot set headers, such as browsers, may offer the
+// token as a subprotocol instead.
 func (m *AuthMiddleware) OptionalAuth(c *fiber.Ctx) error {
 	authHeader := c.Get("Authorization")
+	if authHeader == "" {
+		authHeader = protocolAuthorization(c)
+	}
 	if authHeader == "" {
 		return c.Next()
 	}
@@ -97,6 +111,11 @@ func (m *AuthMiddleware) OptionalAuth(c *fiber.Ctx) error {
 	c.Locals("userID", userID)
 	c.Locals("userEmail", claims.Email)
 	c.Locals("userRole", claims.Role)
+	c.Locals("sessionID", claims.SessionID)
+	c.Locals("accessToken", token)
+	if claims.ExpiresAt != nil {
+		c.Locals("tokenExpiresAt", claims.ExpiresAt.Time)
+	}
 	c.Locals("user", &dto.UserResponse{
 		ID:    claims.UserID,
 		Email: claims.Email,
@@ -105,3 +124,15 @@ func (m *AuthMiddleware) OptionalAuth(c *fiber.Ctx) error {
 
 	return c.Next()
 }
+
+// protocolAuthorization returns the bearer authorization of the access token
+// offered as a WebSocket subprotocol, if any.
+func protocolAuthorization(c *fiber.Ctx) string {
+	for _, protocol := range strings.Split(c.Get(fiber.HeaderSecWebSocketProtocol), ",") {
+		token, ok := strings.CutPrefix(strings.TrimSpace(protocol), WebSocketTokenProtocol)
+		if ok && token != "" {
+			return "Bearer " + token
+		}
+	}
+	return ""
+}
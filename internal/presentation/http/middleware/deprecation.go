@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// Deprecations returns a middleware that announces deprecated endpoints in a
+// machine-readable way: Deprecation (RFC 9745), Sunset (RFC 8594) and a Link
+// with rel="deprecation". Once the sunset date has passed, the endpoint
+// responds with 410 Gone. The first matching notice wins.
+func Deprecations(notices []valueobject.Deprecation) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, notice := range notices {
+			if !notice.Matches(c.Method(), c.Path()) {
+				continue
+			}
+
+			metrics.DeprecatedRequestsTotal.WithLabelValues(c.Method(), notice.Path).Inc()
+
+			c.Set("Deprecation", fmt.Sprintf("@%d", notice.Since.Unix()))
+			if !notice.Sunset.IsZero() {
+				c.Set("Sunset", notice.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if notice.Link != "" {
+				c.Append("Link", fmt.Sprintf("<%s>; rel=\"deprecation\"; type=\"text/html\"", notice.Link))
+			}
+
+			if notice.IsSunset(time.Now()) {
+				return helper.Error(c, fiber.StatusGone, "This endpoint has been retired", "GONE")
+			}
+			break
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// RequireFeature returns a middleware that hides routes behind a feature flag.
+// The flag is evaluated for the caller's organization ("orgID" local) when set,
+// so it must run after the tenant is resolved.
+// Disabled features respond with 404 so clients cannot probe unreleased routes.
+func RequireFeature(flags *service.FeatureFlagService, name string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		orgID, _ := c.Locals("orgID").(string)
+		if !flags.IsEnabled(c.Context(), name, orgID) {
+			return helper.NotFound(c, "Feature not available")
+		}
+		return c.Next()
+	}
+}
@@ -1,37 +1,56 @@
 package middleware
 
 import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
 	applogger "github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/logger"
 )
 
-// RequestLogger returns a middleware that logs HTTP requests.
-func RequestLogger() fiber.Handler {
+// sensitiveFieldPattern matches the JSON string fields whose value is never
+// logged with a sampled body.
+var sensitiveFieldPattern = regexp.MustCompile(
+	`(?i)("(?:[a-z_]*password|[a-z_]*token|[a-z_]*secret|authorization|api_key)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// RequestLogger returns a middleware that logs every HTTP request with its
+// request ID, user, trace, status and latency, except those to the skipped
+// paths. The bodies of a sample of the failed (4xx and 5xx) requests and
+// their responses are logged too, truncated and with secrets redacted.
+func RequestLogger(cfg config.LoggingConfig) fiber.Handler {
+	skip := make(map[string]bool, len(cfg.SkipPaths))
+	for _, path := range cfg.SkipPaths {
+		skip[path] = true
+	}
+
 	return func(c *fiber.Ctx) error {
+		if skip[c.Path()] {
+			return c.Next()
+		}
+
 		start := time.Now()
 
-		// Get or generate request ID
-		requestID := c.Get("X-Request-ID")
-		if requestID == "" {
-			requestID = c.Locals("requestid").(string)
+		requestID, _ := c.Locals("requestid").(string)
+		ctx := applogger.WithRequestID(c.UserContext(), requestID)
+		traceID := trace.SpanContextFromContext(ctx).TraceID()
+		if traceID.IsValid() {
+			ctx = applogger.WithTraceID(ctx, traceID.String())
 		}
-
-		// Add request ID to context
-		ctx := applogger.WithRequestID(c.Context(), requestID)
 		c.SetUserContext(ctx)
 
 		// Process request
 		err := c.Next()
 
-		// Calculate duration
 		duration := time.Since(start)
-
-		// Get response status
-		status := c.Response().StatusCode()
+		status := responseStatus(c, err)
 
 		// Build log event
 		event := log.Info()
@@ -41,27 +60,77 @@ func RequestLogger() fiber.Handler {
 			event = log.Warn()
 		}
 
-		// Log request details
 		event.
 			Str("request_id", requestID).
 			Str("method", c.Method()).
 			Str("path", c.Path()).
+			Str("route", c.Route().Path).
 			Int("status", status).
 			Dur("duration", duration).
 			Str("ip", c.IP()).
-			Str("user_agent", c.Get("User-Agent")).
-			Msg("HTTP request")
+			Str("user_agent", c.Get("User-Agent"))
+		if traceID.IsValid() {
+			event.Str("trace_id", traceID.String())
+		}
+		if userID := c.Locals("userID"); userID != nil {
+			event.Str("user_id", fmt.Sprint(userID))
+		}
+		if err != nil {
+			event.Err(err)
+		}
+		if status >= 400 && cfg.BodySampleRate > 0 && rand.Float64() < cfg.BodySampleRate {
+			logBodies(event, c, err, cfg.BodyMaxBytes)
+		}
+		event.Msg("HTTP request")
 
 		return err
 	}
 }
 
+// logBodies adds the request body and, unless the error handler has yet to
+// write it, the response body to a log event.
+func logBodies(event *zerolog.Event, c *fiber.Ctx, err error, maxBytes int) {
+	if body := c.Body(); len(body) > 0 {
+		event.Str("request_body", sampleBody(body, maxBytes))
+	}
+	if err == nil {
+		if body := c.Response().Body(); len(body) > 0 {
+			event.Str("response_body", sampleBody(body, maxBytes))
+		}
+	}
+}
+
+// sampleBody returns a body truncated to maxBytes (zero for no limit) with
+// the values of its sensitive fields redacted. Redaction comes first, so
+// that a value cut short is not missed.
+func sampleBody(body []byte, maxBytes int) string {
+	sampled := sensitiveFieldPattern.ReplaceAllString(string(body), `$1"[REDACTED]"`)
+	if maxBytes > 0 && len(sampled) > maxBytes {
+		sampled = sampled[:maxBytes] + "...(truncated)"
+	}
+	return sampled
+}
+
+// responseStatus returns the status of a response. When the handler chain
+// returned an error, the error handler has not written the response yet,
+// so the status is the one the error maps to.
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		return fe.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
 // AddUserToContext adds user ID to the logging context after authentication.
 func AddUserToContext() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Try to get user ID from locals (set by auth middleware)
 		if userID := c.Locals("userID"); userID != nil {
-			ctx := applogger.WithUserID(c.UserContext(), userID.(string))
+			ctx := applogger.WithUserID(c.UserContext(), fmt.Sprint(userID))
 			c.SetUserContext(ctx)
 		}
 		return c.Next()
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,7 +13,17 @@ import (
 
 // PrometheusMiddleware collects HTTP metrics.
 func PrometheusMiddleware() fiber.Handler {
+	// Routes are all registered before the first request
+	var (
+		endpointsOnce sync.Once
+		endpoints     map[string]bool
+	)
+
 	return func(c *fiber.Ctx) error {
+		endpointsOnce.Do(func() {
+			endpoints = endpointRoutes(c.App())
+		})
+
 		start := time.Now()
 
 		metrics.HTTPRequestsInFlight.Inc()
@@ -22,13 +34,38 @@ func PrometheusMiddleware() fiber.Handler {
 
 		// Record metrics
 		duration := time.Since(start).Seconds()
-		status := strconv.Itoa(c.Response().StatusCode())
+		status := responseStatus(c, err)
 		method := c.Method()
-		path := c.Route().Path // Use route path to avoid high cardinality
+		path := routeTemplate(c.Route(), endpoints, status)
 
-		metrics.HTTPRequestsTotal.WithLabelValues(method, path, status).Inc()
+		metrics.HTTPRequestsTotal.WithLabelValues(method, path, strconv.Itoa(status)).Inc()
 		metrics.HTTPRequestDuration.WithLabelValues(method, path).Observe(duration)
 
 		return err
 	}
 }
+
+// routeTemplate returns the path label of a request: the template of the
+// route that handled it, e.g. "/api/v1/alerts/:id", to avoid high
+// cardinality. Requests answered by a middleware, e.g. rejected by auth,
+// are labelled with its group, e.g. "/api/v1/alerts/*", and requests
+// that matched no route at all with "unmatched".
+func routeTemplate(route *fiber.Route, endpoints map[string]bool, status int) string {
+	if endpoints[route.Method+" "+route.Path] {
+		return route.Path
+	}
+	if status == fiber.StatusNotFound {
+		return "unmatched"
+	}
+	return strings.TrimSuffix(route.Path, "/") + "/*"
+}
+
+// endpointRoutes returns the method and path of every route of the app
+// that is not a middleware.
+func endpointRoutes(app *fiber.App) map[string]bool {
+	endpoints := make(map[string]bool)
+	for _, route := range app.GetRoutes(true) {
+		endpoints[route.Method+" "+route.Path] = true
+	}
+	return endpoints
+}
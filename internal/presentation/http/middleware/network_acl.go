@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// NetworkACL returns a middleware refusing the clients whose address the
+// ACL of a route group does not allow. Name identifies the group in logs
+// and metrics. Client addresses are taken from the proxy header only when
+// the request comes from a trusted proxy.
+func NetworkACL(name string, acl valueobject.NetworkACL) fiber.Handler {
+	if !acl.IsEnabled() {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		if !acl.Allows(c.IP()) {
+			metrics.NetworkACLDeniedTotal.WithLabelValues(name).Inc()
+			log.Warn().
+				Str("group", name).
+				Str("ip", c.IP()).
+				Str("path", c.Path()).
+				Msg("Request refused by network ACL")
+			return helper.Error(c, fiber.StatusForbidden, "Access from this network is not allowed", "NETWORK_NOT_ALLOWED")
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// RequestQuota returns a middleware that counts requests against the daily
+// request quota of the caller's team and advertises it in the X-Quota-*
+// headers. It must run after the tenant middleware. A request is charged
+// to the team it is narrowed to with X-Team-ID or, without the header, to
+// the caller's only team; other requests are not counted.
+func RequestQuota(quotas *service.QuotaService) fiber.Handler {
+	if quotas == nil || !quotas.IsEnabled() {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		scope, _ := c.Locals("tenant").(valueobject.TenantScope)
+		teams := scope.Teams()
+		if scope.IsAll() || len(teams) != 1 {
+			return c.Next()
+		}
+
+		status, err := quotas.Consume(c.Context(), valueobject.TeamQuotaSubject(teams[0]), valueobject.QuotaRequests, 1)
+		helper.SetQuotaHeaders(c, status.Limit, status.Remaining(), status.ResetAt)
+		if errors.Is(err, service.ErrQuotaExceeded) {
+			c.Set(fiber.HeaderRetryAfter, strconv.FormatInt(int64(time.Until(status.ResetAt).Seconds())+1, 10))
+			return helper.Error(c, fiber.StatusTooManyRequests, err.Error(), "QUOTA_EXCEEDED")
+		}
+
+		return c.Next()
+	}
+}
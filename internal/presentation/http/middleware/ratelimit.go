@@ -3,144 +3,141 @@ package middleware
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
 )
 
-// RateLimitConfig holds rate limiter configuration.
-type RateLimitConfig struct {
-	// Max requests allowed in the window
-	Max int
-	// Time window duration
-	Window time.Duration
-	// Key prefix for Redis
-	KeyPrefix string
-	// Message to show when rate limited
-	Message string
-}
+// rateLimitKeyPrefix prefixes the Redis keys of the rate limit counters.
+const rateLimitKeyPrefix = "ratelimit"
 
-// DefaultRateLimitConfig returns default rate limit configuration.
-func DefaultRateLimitConfig() RateLimitConfig {
-	return RateLimitConfig{
-		Max:       100,
-		Window:    time.Minute,
-		KeyPrefix: "ratelimit",
-		Message:   "Too many requests, please try again later",
-	}
+// TokenParser identifies the user of an access token.
+// Implemented by service.AuthService.
+type TokenParser interface {
+	ParseToken(token string) (*service.JWTClaims, error)
 }
 
-// RateLimiter handles request rate limiting using Redis.
+// RateLimiter limits requests in sliding windows counted in Redis, so that
+// every instance shares the same counters. Requests are counted per user
+// when they carry a valid access token, which allows limiting per role
+// before authentication runs, and per IP address otherwise.
 type RateLimiter struct {
-	cache  repository.CacheRepository
-	config RateLimitConfig
+	store  repository.RateLimitRepository
+	tokens TokenParser
 }
 
-// NewRateLimiter creates a new rate limiter.
-func NewRateLimiter(cache repository.CacheRepository, config RateLimitConfig) *RateLimiter {
+// NewRateLimiter creates a new rate limiter. Without a store, no request is
+// limited.
+func NewRateLimiter(store repository.RateLimitRepository, tokens TokenParser) *RateLimiter {
 	return &RateLimiter{
-		cache:  cache,
-		config: config,
+		store:  store,
+		tokens: tokens,
 	}
 }
 
-// Limit returns a middleware that limits requests based on IP.
-func (r *RateLimiter) Limit() fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		// Use IP as identifier
-		key := fmt.Sprintf("%s:ip:%s", r.config.KeyPrefix, c.IP())
-		return r.checkLimit(c, key)
+// Limit returns a middleware that limits the requests of each client to a
+// rate limit, counted under name.
+func (r *RateLimiter) Limit(name string, limit valueobject.RateLimit, message string) fiber.Handler {
+	if r.store == nil || !limit.IsEnabled() {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
 	}
-}
 
-// LimitByUser returns a middleware that limits requests based on user ID.
-func (r *RateLimiter) LimitByUser() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Get user ID from context
-		userID, ok := c.Locals("userID").(entity.ID)
-		if !ok {
-			// Fall back to IP if not authenticated
-			key := fmt.Sprintf("%s:ip:%s", r.config.KeyPrefix, c.IP())
-			return r.checkLimit(c, key)
+		client, role := r.identify(c)
+		allowed, err := r.check(c, name+":"+client, limit.For(role), limit.Window)
+		if err != nil || allowed {
+			return c.Next()
 		}
-
-		key := fmt.Sprintf("%s:user:%s", r.config.KeyPrefix, userID.String())
-		return r.checkLimit(c, key)
+		return helper.Error(c, fiber.StatusTooManyRequests, message, "RATE_LIMITED")
 	}
 }
 
-// LimitByEndpoint returns a middleware that limits requests per endpoint.
-func (r *RateLimiter) LimitByEndpoint() fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		// Combine IP and endpoint
-		key := fmt.Sprintf("%s:endpoint:%s:%s:%s", r.config.KeyPrefix, c.IP(), c.Method(), c.Path())
-		return r.checkLimit(c, key)
+// LimitRoutes returns a middleware that applies the route limits matching
+// each request, every one in its own window.
+func (r *RateLimiter) LimitRoutes(routes []valueobject.RouteRateLimit) fiber.Handler {
+	enabled := make([]valueobject.RouteRateLimit, 0, len(routes))
+	for _, route := range routes {
+		if route.IsEnabled() {
+			enabled = append(enabled, route)
+		}
+	}
+	if r.store == nil || len(enabled) == 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
 	}
-}
 
-// checkLimit checks if the request should be rate limited.
-func (r *RateLimiter) checkLimit(c *fiber.Ctx, key string) error {
-	ctx := c.Context()
+	return func(c *fiber.Ctx) error {
+		var client string
+		var role entity.UserRole
+		for _, route := range enabled {
+			if !route.Matches(c.Method(), c.Path()) {
+				continue
+			}
+			if client == "" {
+				client, role = r.identify(c)
+			}
+
+			allowed, err := r.check(c, "route:"+route.Name()+":"+client, route.For(role), route.Window)
+			if err == nil && !allowed {
+				return helper.Error(c, fiber.StatusTooManyRequests, "Too many requests to this endpoint, please try again later", "RATE_LIMITED")
+			}
+		}
 
-	// Increment counter
-	count, err := r.cache.Increment(ctx, key)
-	if err != nil {
-		// If Redis fails, allow the request (fail open)
 		return c.Next()
 	}
+}
 
-	// Set expiry on first request
-	if count == 1 {
-		_ = r.cache.Expire(ctx, key, r.config.Window)
+// identify returns the key of the client of a request and, when it is
+// authenticated, its role. A token is trusted once its signature checks
+// out: revoked tokens are rejected by the authentication that follows.
+func (r *RateLimiter) identify(c *fiber.Ctx) (string, entity.UserRole) {
+	if userID, ok := c.Locals("userID").(entity.ID); ok {
+		role, _ := c.Locals("userRole").(string)
+		return "user:" + userID.String(), entity.UserRole(role)
 	}
 
-	// Get remaining TTL
-	ttl, _ := r.cache.TTL(ctx, key)
+	if r.tokens != nil {
+		if token, ok := strings.CutPrefix(c.Get("Authorization"), "Bearer "); ok && token != "" {
+			if claims, err := r.tokens.ParseToken(token); err == nil {
+				return "user:" + claims.UserID, entity.UserRole(claims.Role)
+			}
+		}
+	}
 
-	// Set rate limit headers
-	c.Set("X-RateLimit-Limit", strconv.Itoa(r.config.Max))
-	c.Set("X-RateLimit-Remaining", strconv.Itoa(max(0, r.config.Max-int(count))))
-	c.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(ttl).Unix(), 10))
+	return "ip:" + c.IP(), ""
+}
 
-	// Check if over limit
-	if int(count) > r.config.Max {
-		c.Set("Retry-After", strconv.FormatInt(int64(ttl.Seconds()), 10))
-		return helper.Error(c, fiber.StatusTooManyRequests, r.config.Message, "RATE_LIMITED")
+// check counts a request against a limit and sets the rate limit headers.
+// A zero limit is no limit. If Redis fails, the request is allowed (fail
+// open) and the error returned.
+func (r *RateLimiter) check(c *fiber.Ctx, key string, limit int, window time.Duration) (bool, error) {
+	if limit <= 0 {
+		return true, nil
 	}
 
-	return c.Next()
-}
+	result, err := r.store.Allow(c.Context(), fmt.Sprintf("%s:%s", rateLimitKeyPrefix, key), limit, window)
+	if err != nil {
+		return true, err
+	}
 
-// LoginRateLimiter creates a rate limiter specifically for login attempts.
-func LoginRateLimiter(cache repository.CacheRepository) *RateLimiter {
-	return NewRateLimiter(cache, RateLimitConfig{
-		Max:       5,
-		Window:    15 * time.Minute,
-		KeyPrefix: "ratelimit:login",
-		Message:   "Too many login attempts, please try again in 15 minutes",
-	})
-}
+	c.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	c.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(result.RetryAfter).Unix(), 10))
 
-// APIRateLimiter creates a rate limiter for general API requests.
-func APIRateLimiter(cache repository.CacheRepository) *RateLimiter {
-	return NewRateLimiter(cache, RateLimitConfig{
-		Max:       100,
-		Window:    time.Minute,
-		KeyPrefix: "ratelimit:api",
-		Message:   "Too many requests, please slow down",
-	})
-}
+	if !result.Allowed {
+		// Rounded up, so that a retry right on time fits in the window
+		c.Set("Retry-After", strconv.FormatInt(int64((result.RetryAfter+time.Second-1)/time.Second), 10))
+	}
 
-// AlertCreationRateLimiter creates a rate limiter for creating alerts.
-func AlertCreationRateLimiter(cache repository.CacheRepository) *RateLimiter {
-	return NewRateLimiter(cache, RateLimitConfig{
-		Max:       30,
-		Window:    time.Minute,
-		KeyPrefix: "ratelimit:alerts",
-		Message:   "Too many alerts created, please try again later",
-	})
+	return result.Allowed, nil
 }
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// TeamHeader narrows a request to one of the caller's teams.
+const TeamHeader = "X-Team-ID"
+
+// TenantMiddleware resolves the teams whose data a request may access.
+type TenantMiddleware struct {
+	teamService *service.TeamService
+}
+
+// NewTenantMiddleware creates a new tenant middleware.
+func NewTenantMiddleware(teamService *service.TeamService) *TenantMiddleware {
+	return &TenantMiddleware{
+		teamService: teamService,
+	}
+}
+
+// Resolve sets the caller's tenant scope in the "tenant" local. It must run
+// after authentication; anonymous requests are left with the zero scope,
+// which only covers data not owned by any team.
+//
+// The X-Team-ID header narrows the scope to one team the caller belongs to
+// and makes it the organization feature flags are evaluated for.
+func (m *TenantMiddleware) Resolve(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(entity.ID)
+	if !ok {
+		return c.Next()
+	}
+	role, _ := c.Locals("userRole").(string)
+
+	scope, err := m.teamService.Scope(c.Context(), userID, entity.UserRole(role))
+	if err != nil {
+		// Fail closed: without memberships only shared data is accessible
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to resolve team memberships")
+		scope = valueobject.TenantScope{}
+	}
+
+	if header := c.Get(TeamHeader); header != "" {
+		teamID, err := entity.ParseID(header)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid team ID")
+		}
+
+		narrowed, ok := scope.Narrow(teamID)
+		if !ok {
+			return helper.Forbidden(c, "Not a member of the team")
+		}
+
+		scope = narrowed
+		c.Locals("orgID", teamID.String())
+	}
+
+	c.Locals("tenant", scope)
+
+	return c.Next()
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// APIVersionHeader tells clients which API version served a response.
+const APIVersionHeader = "API-Version"
+
+// APIVersion returns a middleware that marks the requests of a versioned
+// route group, so that shared handlers respond in that version's format.
+func APIVersion(version int) fiber.Handler {
+	header := strconv.Itoa(version)
+	return func(c *fiber.Ctx) error {
+		helper.SetAPIVersion(c, version)
+		c.Set(APIVersionHeader, header)
+		return c.Next()
+	}
+}
+
+// SuccessorVersion returns a middleware that links the responses of the
+// routes under prefix from to their counterpart under prefix to, when it
+// exists, with rel="successor-version" (RFC 5829).
+func SuccessorVersion(from, to string) fiber.Handler {
+	var once sync.Once
+	var successors map[string]bool
+
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		// Routes are only complete once the app serves requests
+		once.Do(func() {
+			successors = make(map[string]bool)
+			for _, route := range c.App().GetRoutes(true) {
+				if strings.HasPrefix(route.Path, to+"/") {
+					successors[route.Method+" "+from+strings.TrimPrefix(route.Path, to)] = true
+				}
+			}
+		})
+
+		// After the chain, the route is the endpoint that handled the request
+		route := c.Route()
+		if successors[route.Method+" "+route.Path] {
+			c.Append(fiber.HeaderLink, fmt.Sprintf("<%s%s>; rel=\"successor-version\"",
+				to, strings.TrimPrefix(c.Path(), from)))
+		}
+		return err
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// Webhook signature headers.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+)
+
+// WebhookSignature returns a middleware that verifies the HMAC signature of
+// webhook payloads sent by an integration, rejecting unsigned, forged and
+// stale requests with 401.
+func WebhookSignature(secrets *service.WebhookSecretService, integration string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := secrets.Verify(
+			c.Context(),
+			integration,
+			c.Get(WebhookTimestampHeader),
+			c.Get(WebhookSignatureHeader),
+			c.Body(),
+		)
+		if err == nil {
+			return c.Next()
+		}
+
+		switch {
+		case errors.Is(err, service.ErrWebhookSignatureMissing),
+			errors.Is(err, service.ErrWebhookSignatureInvalid),
+			errors.Is(err, service.ErrWebhookTimestampStale),
+			errors.Is(err, service.ErrWebhookSecretNotConfigured):
+			log.Warn().
+				Err(err).
+				Str("integration", integration).
+				Str("ip", c.IP()).
+				Msg("Rejected webhook payload")
+			return helper.Unauthorized(c, err.Error())
+		}
+
+		log.Error().Err(err).Str("integration", integration).Msg("Failed to verify webhook signature")
+		return helper.InternalError(c, "Failed to verify webhook signature")
+	}
+}
@@ -2,11 +2,12 @@
 package router
 
 import (
-	"errors"
+	"slices"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	fiberws "github.com/gofiber/websocket/v2"
@@ -16,13 +17,17 @@ import (
 
 	appevent "github.com/daniel-caso-github/realtime-alerting-system/internal/application/event"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/circuitbreaker"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/worker"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/handler"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/middleware"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/ui"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/websocket"
 )
 
@@ -31,12 +36,60 @@ type Dependencies struct {
 	Config              *config.Config
 	UserRepo            repository.UserRepository
 	AlertRepo           repository.AlertRepository
+	WebhookSourceRepo   repository.WebhookSourceRepository
+	WebhookSecretRepo   repository.WebhookSecretRepository
+	HeartbeatRepo       repository.HeartbeatRepository
+	SourceRepo          repository.SourceRepository
+	CheckRepo           repository.CheckRepository
+	IncidentRepo        repository.IncidentRepository
+	PostmortemRepo      repository.PostmortemRepository
+	SLARepo             repository.SLARepository
+	MaintenanceRepo     repository.MaintenanceRepository
+	TopologyRepo        repository.SourceDependencyRepository
 	CacheRepo           repository.CacheRepository
+	SessionRepo         repository.SessionRepository
+	TokenRevocationRepo repository.TokenRevocationRepository
+	RateLimitRepo       repository.RateLimitRepository
+	TeamRepo            repository.TeamRepository
+	AlertRuleRepo       repository.AlertRuleRepository
+	AlertViewRepo       repository.AlertViewRepository
+	SeverityRuleRepo    repository.SeverityRuleRepository
+	AssignmentRuleRepo  repository.AssignmentRuleRepository
+	UserIdentityRepo    repository.UserIdentityRepository
+	TxManager           repository.TxManager
 	DBHealthCheck       handler.HealthChecker
 	WSHub               *websocket.Hub
 	EventBus            event.Publisher
+	EventReader         event.Reader
+	LagInspector        event.LagInspector
 	EventWorker         *worker.EventWorker
-	DeadLetterProcessor *worker.DeadLetterProcessor
+	Scheduler           *worker.Scheduler
+	CheckWorker         *worker.CheckWorker
+	RevocationWorker    *worker.RevocationWorker
+	CheckProber         service.CheckProber
+	FailedEventService  *service.FailedEventService
+	DigestService       *service.DigestService
+	TemplateService     *service.NotificationTemplateService
+	NotificationService *service.NotificationService
+	RouteService        *service.RouteService
+	SubscriptionService *service.SubscriptionService
+	TicketService       *service.TicketService
+	StatusPageService   *service.StatusPageService
+	VoiceCaller         notification.VoiceCaller
+	SlackResponder      service.SlackResponder
+	TelegramResponder   service.TelegramResponder
+	QuotaService        *service.QuotaService
+	Mailer              notification.Mailer
+	Visibility          valueobject.VisibilityPolicy
+	Pagination          valueobject.PaginationPolicy
+	Features            valueobject.FeatureDefaults
+	Deprecations        []valueobject.Deprecation
+	RateLimits          valueobject.RateLimitPolicy
+	Lockout             valueobject.LockoutPolicy
+	NetworkACLs         valueobject.NetworkACLPolicy
+	Flapping            valueobject.FlappingPolicy
+	IncidentCorrelation valueobject.IncidentCorrelationPolicy
+	Enrichment          *service.EnrichmentPipeline
 }
 
 // Setup configures and returns a Fiber app with all routes.
@@ -46,16 +99,25 @@ func Setup(deps Dependencies) *fiber.App {
 		ReadTimeout:  deps.Config.Server.ReadTimeout,
 		WriteTimeout: deps.Config.Server.WriteTimeout,
 		IdleTimeout:  deps.Config.Server.IdleTimeout,
-		ErrorHandler: customErrorHandler,
+		ErrorHandler: handler.ErrorHandler,
+		// Client addresses, used by rate limits and network ACLs, are only
+		// read from the proxy header of trusted proxies
+		ProxyHeader:             deps.Config.Server.ProxyHeader,
+		EnableTrustedProxyCheck: deps.Config.Server.ProxyHeader != "",
+		TrustedProxies:          deps.Config.Server.TrustedProxies,
 	})
 
 	setupMiddleware(app, deps.Config)
+	if len(deps.Deprecations) > 0 {
+		app.Use(middleware.Deprecations(deps.Deprecations))
+	}
 
 	// Create circuit breaker registry
 	cbRegistry := circuitbreaker.NewRegistry()
 
 	// Create publisher for WebSocket events
-	alertPublisher := websocket.NewAlertPublisher(deps.WSHub)
+	alertPublisher := websocket.NewAlertPublisher(deps.WSHub, deps.Config.Alerts.MessageSummaryLength)
+	alertPublisher.SetVisibilityPolicy(deps.Visibility)
 
 	// Create event producer for async processing
 	var alertProducer *appevent.AlertProducer
@@ -64,81 +126,474 @@ func Setup(deps Dependencies) *fiber.App {
 	}
 
 	// Create services
-	authService := service.NewAuthService(deps.UserRepo, deps.CacheRepo, &deps.Config.JWT)
+	authService := service.NewAuthService(deps.UserRepo, deps.CacheRepo, deps.SessionRepo, &deps.Config.JWT)
+	authService.SetLockoutPolicy(deps.Lockout, deps.Mailer)
+	if deps.TokenRevocationRepo != nil {
+		authService.SetTokenRevocation(deps.TokenRevocationRepo)
+		if deps.RevocationWorker != nil {
+			deps.RevocationWorker.SetRefresher(authService)
+		}
+	}
+	deps.WSHub.SetAuthenticator(authService, deps.Config.WebSocket.AuthCheckInterval)
 	alertService := service.NewAlertService(deps.AlertRepo, deps.CacheRepo, alertPublisher)
+	alertService.SetDeployment(deps.Config.Deployment.Region, deps.Config.Deployment.Cluster)
+	alertService.SetVisibilityPolicy(deps.Visibility)
+	alertService.SetFlappingPolicy(deps.Flapping)
+	alertService.SetEnrichment(deps.Enrichment)
 
 	// Set event producer if available
 	if alertProducer != nil {
 		alertService.SetEventProducer(alertProducer)
 	}
 
+	// Events addressed to offline users are kept for their digest
+	digestService := deps.DigestService
+	if digestService == nil {
+		digestService = service.NewDigestService(deps.CacheRepo, deps.UserRepo, deps.Config.Notification.Digest, nil)
+	}
+	userPublisher := websocket.NewUserPublisher(deps.WSHub, digestService)
+	alertService.SetUserNotifier(userPublisher)
+	quotaService := deps.QuotaService
+	if quotaService == nil {
+		quotaService = service.NewQuotaService(nil, valueobject.QuotaPolicy{})
+	}
+	alertService.SetQuotaService(quotaService)
+	severityRuleService := service.NewSeverityRuleService(deps.SeverityRuleRepo)
+	if deps.SeverityRuleRepo != nil {
+		alertService.SetSeverityClassifier(severityRuleService)
+	}
+	assignmentRuleService := service.NewAssignmentRuleService(deps.AssignmentRuleRepo)
+	if deps.AssignmentRuleRepo != nil {
+		alertService.SetAssigner(assignmentRuleService)
+	}
+	webhookSourceService := service.NewWebhookSourceService(deps.WebhookSourceRepo, alertService)
+	webhookSourceService.SetQuotaService(quotaService)
+	heartbeatService := service.NewHeartbeatService(deps.HeartbeatRepo, deps.CacheRepo, alertService)
+	sourceService := service.NewSourceService(deps.SourceRepo, alertService)
+	checkService := service.NewCheckService(deps.CheckRepo, deps.CacheRepo, alertService, deps.CheckProber,
+		deps.Config.Alerts.Checks.HistoryRetention)
+	if deps.CheckWorker != nil {
+		deps.CheckWorker.SetRunner(checkService)
+	}
+	incidentService := service.NewIncidentService(deps.IncidentRepo, deps.UserRepo, alertService,
+		websocket.NewIncidentPublisher(deps.WSHub))
+	incidentService.SetCorrelationPolicy(deps.IncidentCorrelation)
+	incidentService.SetUserNotifier(userPublisher)
+	if deps.IncidentCorrelation.IsEnabled() {
+		alertService.SetCorrelator(incidentService)
+	}
+	postmortemService := service.NewPostmortemService(deps.PostmortemRepo, deps.UserRepo, incidentService, alertService)
+	slaService := service.NewSLAService(deps.SLARepo, alertService)
+	if deps.Scheduler != nil {
+		alerts := deps.Config.Alerts
+		deps.Scheduler.Register(worker.JobAlertExpiration,
+			valueobject.EveryInterval(alerts.ExpirationSweepInterval), worker.AlertExpirationJob(alertService))
+		deps.Scheduler.Register(worker.JobHeartbeatCheck,
+			valueobject.EveryInterval(alerts.HeartbeatCheckInterval), worker.HeartbeatCheckJob(heartbeatService))
+		if deps.SourceRepo != nil {
+			deps.Scheduler.Register(worker.JobSourceCheck,
+				valueobject.EveryInterval(alerts.SourceCheckInterval), worker.SourceCheckJob(sourceService))
+		}
+		deps.Scheduler.Register(worker.JobSLACheck,
+			valueobject.EveryInterval(alerts.SLACheckInterval), worker.SLACheckJob(slaService))
+	}
+	maintenanceService := service.NewMaintenanceService(deps.MaintenanceRepo)
+	topologyService := service.NewTopologyService(deps.TopologyRepo)
+	alertService.SetTopology(topologyService)
+	webhookSecretService := service.NewWebhookSecretService(deps.WebhookSecretRepo, deps.Config.Webhooks.Signature)
+	featureFlagService := service.NewFeatureFlagService(deps.CacheRepo, deps.Features, deploymentScope(deps.Config.Deployment))
+	teamService := service.NewTeamService(deps.TeamRepo, deps.CacheRepo)
+	identityService := service.NewIdentityService(deps.UserIdentityRepo, deps.UserRepo)
+	slackService := service.NewSlackService(deps.Config.Notification.Slack, identityService, teamService, alertService,
+		maintenanceService, deps.SlackResponder)
+	telegramService := service.NewTelegramService(deps.Config.Notification.Telegram, identityService, teamService,
+		alertService, deps.TelegramResponder)
+	voiceService := service.NewVoiceEscalationService(deps.Config.Notification.Twilio, deps.AlertRepo, deps.UserRepo,
+		identityService, teamService, alertService, maintenanceService, deps.VoiceCaller)
+	if deps.Scheduler != nil && voiceService.IsEnabled() {
+		deps.Scheduler.Register(worker.JobVoiceEscalation,
+			valueobject.EveryInterval(deps.Config.Notification.Twilio.CheckInterval), worker.VoiceEscalationJob(voiceService))
+	}
+	replayService := service.NewEventReplayService(deps.EventReader, deps.EventBus)
+	ruleService := service.NewRuleService(deps.AlertRuleRepo, deps.RouteService)
+	if deps.TxManager != nil {
+		ruleService.SetTxManager(deps.TxManager)
+	}
+	ruleEngine := service.NewRuleEngine(deps.AlertRuleRepo, deps.CacheRepo, alertService)
+	viewService := service.NewAlertViewService(deps.AlertViewRepo, alertService)
+	dashboardService := service.NewDashboardService(deps.AlertRepo, deps.IncidentRepo, deps.UserRepo, deps.CacheRepo, alertService)
+	if deps.AlertViewRepo != nil {
+		alertPublisher.SetViewMatcher(viewService)
+		deps.WSHub.SetViewAuthorizer(viewService)
+	}
+
 	// Create handlers
 	healthHandler := handler.NewHealthHandler(deps.Config, deps.DBHealthCheck, deps.CacheRepo, deps.WSHub)
-	authHandler := handler.NewAuthHandler(authService)
-	alertHandler := handler.NewAlertHandler(alertService)
-	adminHandler := handler.NewAdminHandler(deps.DeadLetterProcessor, deps.EventWorker, cbRegistry)
+	healthHandler.SetFeatureStates(featureFlagService)
+	authHandler := handler.NewAuthHandler(authService, digestService)
+	alertHandler := handler.NewAlertHandler(alertService, deps.Config.Alerts.MessageSummaryLength, deps.Pagination)
+	adminHandler := handler.NewAdminHandler(deps.FailedEventService, deps.EventWorker, cbRegistry, alertService, deps.Pagination)
+	adminHandler.SetSlowClientReporter(deps.WSHub)
+	adminHandler.SetPresenceSource(deps.WSHub)
+	adminHandler.SetLagInspector(deps.LagInspector)
+	adminHandler.SetScheduler(deps.Scheduler)
 	webhookHandler := handler.NewWebhookHandler(alertService)
+	webhookHandler.SetSentryClientSecret(deps.Config.Webhooks.Sentry.ClientSecret, deps.Config.Webhooks.Signature.Tolerance)
+	webhookHandler.SetCloudWatchTopics(deps.Config.Webhooks.CloudWatch.TopicARNs)
+	webhookSourceHandler := handler.NewWebhookSourceHandler(webhookSourceService, deps.Pagination)
+	heartbeatHandler := handler.NewHeartbeatHandler(heartbeatService, deps.Pagination)
+	sourceHandler := handler.NewSourceHandler(sourceService, deps.Pagination)
+	severityRuleHandler := handler.NewSeverityRuleHandler(severityRuleService)
+	assignmentRuleHandler := handler.NewAssignmentRuleHandler(assignmentRuleService)
+	subscriptionHandler := handler.NewSubscriptionHandler(deps.SubscriptionService, deps.Pagination)
+	checkHandler := handler.NewCheckHandler(checkService, deps.Pagination)
+	incidentHandler := handler.NewIncidentHandler(incidentService, deps.Pagination)
+	postmortemHandler := handler.NewPostmortemHandler(postmortemService, deps.Pagination)
+	slaHandler := handler.NewSLAHandler(slaService, deps.Pagination)
+	maintenanceHandler := handler.NewMaintenanceHandler(maintenanceService)
+	topologyHandler := handler.NewTopologyHandler(topologyService, deps.Pagination)
+	webhookSecretHandler := handler.NewWebhookSecretHandler(webhookSecretService)
+	templateHandler := handler.NewNotificationTemplateHandler(deps.TemplateService)
+	channelHandler := handler.NewNotificationChannelHandler(deps.NotificationService)
+	routeHandler := handler.NewRouteHandler(deps.RouteService)
+	ruleHandler := handler.NewRuleHandler(ruleService, ruleEngine)
+	featureHandler := handler.NewFeatureHandler(featureFlagService)
+	teamHandler := handler.NewTeamHandler(teamService, deps.Pagination)
+	identityHandler := handler.NewIdentityHandler(identityService)
+	slackHandler := handler.NewSlackHandler(slackService)
+	telegramHandler := handler.NewTelegramHandler(telegramService)
+	twilioHandler := handler.NewTwilioHandler(voiceService)
+	ticketHandler := handler.NewTicketHandler(alertService, deps.TicketService)
+	statusPageHandler := handler.NewStatusPageHandler(deps.StatusPageService)
+	viewHandler := handler.NewAlertViewHandler(viewService, deps.Config.Alerts.MessageSummaryLength, deps.Pagination)
+	dashboardHandler := handler.NewDashboardHandler(dashboardService, deps.Config.Alerts.MessageSummaryLength)
+	replayHandler := handler.NewEventReplayHandler(replayService)
+	diagnosticsHandler := handler.NewDiagnosticsHandler()
+	quotaHandler := handler.NewQuotaHandler(quotaService)
+	graphqlHandler := handler.NewGraphQLHandler(alertService, deps.AlertRuleRepo, deps.UserRepo,
+		deps.Config.Alerts.MessageSummaryLength, deps.Pagination)
+	graphqlHandler.SetEventListener(deps.WSHub)
 
 	// Create middleware
 	authMiddleware := middleware.NewAuthMiddleware(authService)
-	apiRateLimiter := middleware.APIRateLimiter(deps.CacheRepo)
-	loginRateLimiter := middleware.LoginRateLimiter(deps.CacheRepo)
+	tenantMiddleware := middleware.NewTenantMiddleware(teamService)
+	rateLimiter := middleware.NewRateLimiter(deps.RateLimitRepo, authService)
+	apiRateLimit := rateLimiter.Limit("api", deps.RateLimits.API, "Too many requests, please slow down")
+	routeRateLimit := rateLimiter.LimitRoutes(deps.RateLimits.Routes)
+	requestQuota := middleware.RequestQuota(quotaService)
 
 	// WebSocket handler
 	wsHandler := websocket.NewHandler(deps.WSHub)
+	wsHandler.SetDigestSource(digestService)
+	wsHandler.SetAlertCommands(alertService)
 
 	// Health routes (no auth required)
 	app.Get("/health", healthHandler.Check)
 	app.Get("/ready", healthHandler.Ready)
 	app.Get("/live", healthHandler.Live)
 
-	// Metrics endpoint (no auth required)
-	app.Get("/metrics", handler.MetricsHandler())
+	// Metrics endpoint (no auth required), unless served on its own port
+	if deps.Config.Server.MetricsAddress() == "" {
+		app.Get("/metrics", handler.MetricsHandler(deps.Config.Deployment.Labels()))
+	}
 
 	// Swagger documentation
 	app.Get("/swagger/*", swagger.WrapHandler)
 
-	// API v1 routes
-	v1 := app.Group("/api/v1")
-	v1.Use(apiRateLimiter.Limit())
+	// Embedded web dashboard (pages are public; they sign in against the API)
+	if deps.Config.Server.UI.Enabled {
+		ui.Register(app)
+	}
+
+	// API v1 routes, linked to their v2 counterpart where there is one
+	v1 := app.Group("/api/v1", middleware.APIVersion(1), middleware.SuccessorVersion("/api/v1", "/api/v2"))
+	v1.Use(apiRateLimit, routeRateLimit)
 
 	// Auth routes (public)
 	auth := v1.Group("/auth")
-	auth.Post("/login", loginRateLimiter.LimitByEndpoint(), authHandler.Login)
+	auth.Post("/login",
+		rateLimiter.Limit("login", deps.RateLimits.Login, "Too many login attempts, please try again later"),
+		authHandler.Login)
 	auth.Post("/register", authHandler.Register)
 	auth.Post("/refresh", authHandler.RefreshToken)
 	auth.Post("/logout", authHandler.Logout)
 	auth.Get("/me", authMiddleware.Authenticate, authHandler.Me)
+	auth.Get("/sessions", authMiddleware.Authenticate, authHandler.Sessions)
+	auth.Delete("/sessions/:id", authMiddleware.Authenticate, authHandler.RevokeSession)
 
 	// Alert routes (protected)
-	alerts := v1.Group("/alerts", authMiddleware.Authenticate)
-	alerts.Get("/", alertHandler.List)
-	alerts.Get("/statistics", alertHandler.GetStatistics)
-	alerts.Post("/", middleware.RequireOperator(), alertHandler.Create)
-	alerts.Get("/:id", alertHandler.GetByID)
-	alerts.Post("/:id/acknowledge", middleware.RequireOperator(), alertHandler.Acknowledge)
-	alerts.Post("/:id/resolve", middleware.RequireOperator(), alertHandler.Resolve)
-	alerts.Delete("/:id", middleware.RequireAdmin(), alertHandler.Delete)
+	alerts := v1.Group("/alerts", authMiddleware.Authenticate, tenantMiddleware.Resolve, requestQuota)
+	registerAlertRoutes(alerts, alertHandler)
+	alerts.Post("/:id/ticket", middleware.RequireOperator(), ticketHandler.Create)
+
+	// Dashboard summary (protected)
+	v1.Get("/dashboard", authMiddleware.Authenticate, tenantMiddleware.Resolve, requestQuota, dashboardHandler.Get)
+
+	// GraphQL routes (protected; subscriptions are streamed as Server-Sent Events)
+	graphqlRoutes := v1.Group("/graphql", authMiddleware.Authenticate, tenantMiddleware.Resolve,
+		middleware.RequireFeature(featureFlagService, valueobject.FeatureGraphQL), requestQuota)
+	graphqlRoutes.Get("/", graphqlHandler.Serve)
+	graphqlRoutes.Post("/", graphqlHandler.Serve)
+
+	// Team routes (protected; membership checks are done by the team service)
+	teams := v1.Group("/teams", authMiddleware.Authenticate)
+	teams.Get("/", teamHandler.List)
+	teams.Post("/", middleware.RequireAdmin(), teamHandler.Create)
+	teams.Get("/:id", teamHandler.GetByID)
+	teams.Patch("/:id", teamHandler.Update)
+	teams.Delete("/:id", middleware.RequireAdmin(), teamHandler.Delete)
+	teams.Get("/:id/members", teamHandler.ListMembers)
+	teams.Put("/:id/members/:userId", teamHandler.SetMember)
+	teams.Delete("/:id/members/:userId", teamHandler.RemoveMember)
+
+	// Source registry routes (protected; changes need an admin)
+	sources := v1.Group("/sources", authMiddleware.Authenticate, tenantMiddleware.Resolve)
+	sources.Get("/", sourceHandler.List)
+	sources.Post("/", middleware.RequireAdmin(), sourceHandler.Create)
+	sources.Get("/:id", sourceHandler.GetByID)
+	sources.Patch("/:id", middleware.RequireAdmin(), sourceHandler.Update)
+	sources.Delete("/:id", middleware.RequireAdmin(), sourceHandler.Delete)
+
+	// Severity rule routes (admin only)
+	severityRules := v1.Group("/severity-rules", authMiddleware.Authenticate, middleware.RequireAdmin())
+	severityRules.Get("/", severityRuleHandler.List)
+	severityRules.Post("/", severityRuleHandler.Create)
+	severityRules.Post("/dry-run", severityRuleHandler.DryRun)
+	severityRules.Get("/:id", severityRuleHandler.GetByID)
+	severityRules.Patch("/:id", severityRuleHandler.Update)
+	severityRules.Delete("/:id", severityRuleHandler.Delete)
+
+	// Assignment rule routes (admin only)
+	assignmentRules := v1.Group("/assignment-rules", authMiddleware.Authenticate, middleware.RequireAdmin())
+	assignmentRules.Get("/", assignmentRuleHandler.List)
+	assignmentRules.Post("/", assignmentRuleHandler.Create)
+	assignmentRules.Get("/:id", assignmentRuleHandler.GetByID)
+	assignmentRules.Patch("/:id", assignmentRuleHandler.Update)
+	assignmentRules.Delete("/:id", assignmentRuleHandler.Delete)
+
+	// Outbound webhook subscription routes (admin only)
+	subscriptions := v1.Group("/subscriptions", authMiddleware.Authenticate, middleware.RequireAdmin())
+	subscriptions.Get("/", subscriptionHandler.List)
+	subscriptions.Post("/", subscriptionHandler.Create)
+	subscriptions.Get("/:id", subscriptionHandler.GetByID)
+	subscriptions.Patch("/:id", subscriptionHandler.Update)
+	subscriptions.Delete("/:id", subscriptionHandler.Delete)
+	subscriptions.Get("/:id/stats", subscriptionHandler.Stats)
+	subscriptions.Get("/:id/deliveries", subscriptionHandler.Deliveries)
+	subscriptions.Post("/:id/deliveries/:deliveryId/redeliver", subscriptionHandler.Redeliver)
+
+	// Uptime check routes (protected; changes need an operator or admin)
+	checks := v1.Group("/checks", authMiddleware.Authenticate, tenantMiddleware.Resolve)
+	checks.Get("/", checkHandler.List)
+	checks.Post("/", middleware.RequireOperator(), checkHandler.Create)
+	checks.Get("/:id", checkHandler.GetByID)
+	checks.Get("/:id/history", checkHandler.History)
+	checks.Patch("/:id", middleware.RequireOperator(), checkHandler.Update)
+	checks.Delete("/:id", middleware.RequireOperator(), checkHandler.Delete)
+
+	// Incident routes (protected; changes need an operator or admin)
+	incidents := v1.Group("/incidents", authMiddleware.Authenticate, tenantMiddleware.Resolve,
+		middleware.RequireFeature(featureFlagService, valueobject.FeatureIncidents))
+	incidents.Get("/", incidentHandler.List)
+	incidents.Post("/", middleware.RequireOperator(), incidentHandler.Create)
+	incidents.Get("/:id", incidentHandler.GetByID)
+	incidents.Patch("/:id", middleware.RequireOperator(), incidentHandler.Update)
+	incidents.Post("/:id/mitigate", middleware.RequireOperator(), incidentHandler.Mitigate)
+	incidents.Post("/:id/close", middleware.RequireOperator(), incidentHandler.Close)
+	incidents.Post("/:id/reopen", middleware.RequireOperator(), incidentHandler.Reopen)
+	incidents.Put("/:id/commander", middleware.RequireOperator(), incidentHandler.AssignCommander)
+	incidents.Get("/:id/alerts", incidentHandler.Alerts)
+	incidents.Post("/:id/alerts", middleware.RequireOperator(), incidentHandler.AddAlerts)
+	incidents.Delete("/:id/alerts/:alertId", middleware.RequireOperator(), incidentHandler.RemoveAlert)
+	incidents.Get("/:id/timeline", incidentHandler.Timeline)
+	incidents.Post("/:id/timeline", middleware.RequireOperator(), incidentHandler.AddNote)
+	incidents.Get("/:id/postmortem", postmortemHandler.GetByIncident)
+
+	// Postmortem routes (protected; changes need an operator or admin)
+	postmortems := v1.Group("/postmortems", authMiddleware.Authenticate, tenantMiddleware.Resolve,
+		middleware.RequireFeature(featureFlagService, valueobject.FeatureIncidents))
+	postmortems.Get("/", postmortemHandler.List)
+	postmortems.Post("/", middleware.RequireOperator(), postmortemHandler.Create)
+	postmortems.Get("/:id", postmortemHandler.GetByID)
+	postmortems.Get("/:id/export", postmortemHandler.Export)
+	postmortems.Patch("/:id", middleware.RequireOperator(), postmortemHandler.Update)
+	postmortems.Delete("/:id", middleware.RequireOperator(), postmortemHandler.Delete)
+
+	// Maintenance calendar routes (protected; changes need an operator or
+	// admin). The iCalendar feed is registered first and on its own: group
+	// middleware matches by prefix, so a /maintenance group would also run
+	// for /maintenance.ics.
+	v1.Get("/maintenance.ics", authMiddleware.Authenticate, tenantMiddleware.Resolve, maintenanceHandler.ICalendar)
+	maintenance := v1.Group("/maintenance", authMiddleware.Authenticate, tenantMiddleware.Resolve)
+	maintenance.Get("/", maintenanceHandler.List)
+	maintenance.Post("/", middleware.RequireOperator(), maintenanceHandler.Create)
+	maintenance.Get("/:id", maintenanceHandler.GetByID)
+	maintenance.Patch("/:id", middleware.RequireOperator(), maintenanceHandler.Update)
+	maintenance.Delete("/:id", middleware.RequireOperator(), maintenanceHandler.Delete)
+
+	// Saved alert views (protected; private to their owner or shared with a team)
+	views := v1.Group("/views", authMiddleware.Authenticate, tenantMiddleware.Resolve)
+	views.Get("/", viewHandler.List)
+	views.Post("/", viewHandler.Create)
+	views.Get("/:id", viewHandler.GetByID)
+	views.Get("/:id/alerts", viewHandler.Alerts)
+	views.Patch("/:id", viewHandler.Update)
+	views.Delete("/:id", viewHandler.Delete)
+
+	// Report routes (protected; counting the alerts visible to the caller)
+	reports := v1.Group("/reports", authMiddleware.Authenticate, tenantMiddleware.Resolve)
+	reports.Get("/sla", slaHandler.Report)
+
+	// Notification routing tree (admin only)
+	routes := v1.Group("/routes", authMiddleware.Authenticate, middleware.RequireAdmin())
+	routes.Get("/", routeHandler.List)
+	routes.Post("/", routeHandler.Create)
+	routes.Get("/:id", routeHandler.GetByID)
+	routes.Put("/:id", routeHandler.Update)
+	routes.Delete("/:id", routeHandler.Delete)
+	routes.Get("/external/:externalId", routeHandler.GetByExternalID)
+	routes.Put("/external/:externalId", routeHandler.UpsertByExternalID)
+	routes.Delete("/external/:externalId", routeHandler.DeleteByExternalID)
+
+	// Declarative rule management (bundles are admin only; operators manage
+	// the rules they created for their own teams, as checked by the rule service)
+	rules := v1.Group("/rules", authMiddleware.Authenticate, tenantMiddleware.Resolve, middleware.RequireOperator())
+	rules.Post("/apply", middleware.RequireAdmin(), ruleHandler.Apply)
+	rules.Post("/evaluate", ruleHandler.Evaluate)
+	rules.Get("/external/:externalId", ruleHandler.GetByExternalID)
+	rules.Put("/external/:externalId", ruleHandler.UpsertByExternalID)
+	rules.Delete("/external/:externalId", ruleHandler.DeleteByExternalID)
 
 	// Admin routes (admin only)
-	admin := v1.Group("/admin", authMiddleware.Authenticate, middleware.RequireAdmin())
+	admin := v1.Group("/admin", middleware.NetworkACL("admin", deps.NetworkACLs.Admin),
+		authMiddleware.Authenticate, middleware.RequireAdmin())
 	admin.Get("/failed-events", adminHandler.GetFailedEvents)
+	admin.Delete("/failed-events", adminHandler.PurgeFailedEvents)
+	admin.Post("/failed-events/retry", adminHandler.BulkRetryFailedEvents)
+	admin.Post("/failed-events/ignore", adminHandler.BulkIgnoreFailedEvents)
 	admin.Post("/failed-events/:id/retry", adminHandler.RetryFailedEvent)
 	admin.Post("/failed-events/:id/ignore", adminHandler.IgnoreFailedEvent)
+	admin.Post("/events/replay", replayHandler.Replay)
 	admin.Get("/metrics/events", adminHandler.GetEventMetrics)
+	admin.Get("/event-bus/lag", adminHandler.GetConsumerLag)
+	admin.Get("/jobs", adminHandler.GetJobs)
+	admin.Get("/runtime", diagnosticsHandler.GetRuntimeStats)
+	admin.Get("/log-level", diagnosticsHandler.GetLogLevel)
+	admin.Put("/log-level", diagnosticsHandler.SetLogLevel)
+	// Profiles under /api/v1/admin/debug/pprof/, e.g. goroutine?debug=2 for a goroutine dump
+	admin.Use(pprof.New(pprof.Config{Prefix: "/api/v1/admin"}))
 	admin.Get("/circuit-breakers", adminHandler.GetCircuitBreakerStats)
+	admin.Get("/websocket/slow-clients", adminHandler.GetSlowWebSocketClients)
+	admin.Get("/presence", adminHandler.GetPresence)
+	admin.Patch("/users/:id", authHandler.UpdateUser)
+	admin.Post("/users/:id/unlock", authHandler.UnlockUser)
+	admin.Get("/users/:id/identities", identityHandler.List)
+	admin.Put("/users/:id/identities/:provider", identityHandler.Link)
+	admin.Delete("/users/:id/identities/:provider", identityHandler.Unlink)
+	admin.Delete("/alerts", adminHandler.BulkDeleteAlerts)
+	admin.Post("/alerts/purge", adminHandler.PurgeAlerts)
+	admin.Get("/alerts/trash", adminHandler.ListTrash)
+	admin.Get("/webhook-sources", webhookSourceHandler.List)
+	admin.Post("/webhook-sources", webhookSourceHandler.Create)
+	admin.Get("/webhook-sources/:id", webhookSourceHandler.GetByID)
+	admin.Patch("/webhook-sources/:id", webhookSourceHandler.Update)
+	admin.Delete("/webhook-sources/:id", webhookSourceHandler.Delete)
+	admin.Post("/webhook-sources/:id/rotate-token", webhookSourceHandler.RotateToken)
+	admin.Get("/heartbeats", heartbeatHandler.List)
+	admin.Post("/heartbeats", heartbeatHandler.Create)
+	admin.Get("/heartbeats/:id", heartbeatHandler.GetByID)
+	admin.Patch("/heartbeats/:id", heartbeatHandler.Update)
+	admin.Delete("/heartbeats/:id", heartbeatHandler.Delete)
+	admin.Post("/heartbeats/:id/rotate-token", heartbeatHandler.RotateToken)
+	admin.Get("/sla-policies", slaHandler.List)
+	admin.Post("/sla-policies", slaHandler.Create)
+	admin.Get("/sla-policies/:id", slaHandler.GetByID)
+	admin.Patch("/sla-policies/:id", slaHandler.Update)
+	admin.Delete("/sla-policies/:id", slaHandler.Delete)
+	admin.Get("/source-dependencies", topologyHandler.List)
+	admin.Post("/source-dependencies", topologyHandler.Create)
+	admin.Delete("/source-dependencies/:id", topologyHandler.Delete)
+	admin.Get("/webhook-secrets", webhookSecretHandler.List)
+	admin.Put("/webhook-secrets/:integration", webhookSecretHandler.Set)
+	admin.Delete("/webhook-secrets/:integration", webhookSecretHandler.Delete)
+	admin.Get("/notification-channels", channelHandler.List)
+	admin.Post("/notification-channels/:channel/test", channelHandler.Test)
+	admin.Get("/notification-templates", templateHandler.List)
+	admin.Get("/notification-templates/:channel", templateHandler.Get)
+	admin.Put("/notification-templates/:channel", templateHandler.Set)
+	admin.Post("/notification-templates/:channel/preview", templateHandler.Preview)
+	admin.Delete("/notification-templates/:channel", templateHandler.Delete)
+	admin.Get("/features", featureHandler.List)
+	admin.Get("/features/:name", featureHandler.Get)
+	admin.Put("/features/:name", featureHandler.Set)
+	admin.Delete("/features/:name", featureHandler.Reset)
+	admin.Get("/statuspage/components", statusPageHandler.List)
+	admin.Put("/statuspage/components/:id/override", statusPageHandler.SetOverride)
+	admin.Delete("/statuspage/components/:id/override", statusPageHandler.ClearOverride)
+	admin.Get("/quotas", quotaHandler.List)
+	admin.Delete("/quotas/:subject", quotaHandler.Reset)
 
 	// WebSocket route
 	app.Use("/ws", wsHandler.Upgrade)
-	app.Get("/ws", authMiddleware.OptionalAuth, fiberws.New(wsHandler.Handle))
-
-	// Webhook routes (no auth - secured by network/secret)
-	webhooks := v1.Group("/webhooks")
-	webhooks.Post("/alertmanager", webhookHandler.AlertManagerWebhookHandler)
+	app.Get("/ws", authMiddleware.OptionalAuth, tenantMiddleware.Resolve,
+		fiberws.New(wsHandler.Handle, fiberws.Config{Subprotocols: []string{websocket.Subprotocol}}))
+
+	// Server-Sent Events fallback for clients that cannot use WebSockets
+	v1.Get("/stream", authMiddleware.OptionalAuth, tenantMiddleware.Resolve,
+		middleware.RequireFeature(featureFlagService, valueobject.FeatureSSE), wsHandler.Stream)
+
+	// Webhook routes (no user auth - secured by HMAC signatures, provider secrets or tokens)
+	webhooks := v1.Group("/webhooks", middleware.NetworkACL("webhooks", deps.NetworkACLs.Webhooks))
+	webhooks.Post("/alertmanager",
+		middleware.WebhookSignature(webhookSecretService, entity.WebhookIntegrationAlertManager),
+		webhookHandler.AlertManagerWebhookHandler)
+	webhooks.Post("/grafana",
+		middleware.WebhookSignature(webhookSecretService, entity.WebhookIntegrationGrafana),
+		webhookHandler.GrafanaWebhookHandler)
+	webhooks.Post("/datadog",
+		middleware.WebhookSignature(webhookSecretService, entity.WebhookIntegrationDatadog),
+		webhookHandler.DatadogWebhookHandler)
+	webhooks.Post("/sentry", webhookHandler.SentryWebhookHandler)
+	webhooks.Post("/cloudwatch", webhookHandler.CloudWatchWebhookHandler)
+	webhooks.Post("/inbound/:token", webhookSourceHandler.Ingest)
+
+	// Chat integration routes (no user auth - secured by the provider's request
+	// signature, acting on behalf of the linked user)
+	integrations := v1.Group("/integrations")
+	integrations.Post("/slack/actions", slackHandler.Actions)
+	integrations.Post("/slack/commands", slackHandler.Commands)
+	integrations.Post("/telegram/webhook", telegramHandler.Webhook)
+	integrations.Post("/twilio/voice", twilioHandler.Voice)
+
+	// Heartbeat pings (no user auth - secured by the heartbeat token)
+	v1.Post("/heartbeats/:token", heartbeatHandler.Ping)
+
+	// API v2 routes. Resources whose shape did not change share the handlers
+	// and services of v1, which respond in the v2 format; the others are
+	// still served by v1 only.
+	v2 := app.Group("/api/v2", middleware.APIVersion(2))
+	v2.Use(apiRateLimit, routeRateLimit)
+
+	alertsV2 := v2.Group("/alerts", authMiddleware.Authenticate, tenantMiddleware.Resolve, requestQuota)
+	registerAlertRoutes(alertsV2, alertHandler)
 
 	return app
 }
 
+// registerAlertRoutes registers the alert routes of an API version.
+func registerAlertRoutes(alerts fiber.Router, alertHandler *handler.AlertHandler) {
+	alerts.Get("/", alertHandler.List)
+	alerts.Get("/statistics", alertHandler.GetStatistics)
+	alerts.Post("/", middleware.RequireOperator(), alertHandler.Create)
+	alerts.Post("/batch", middleware.RequireOperator(), alertHandler.CreateBatch)
+	alerts.Get("/:id", alertHandler.GetByID)
+	alerts.Get("/:id/related", alertHandler.GetRelated)
+	alerts.Post("/:id/acknowledge", middleware.RequireOperator(), alertHandler.Acknowledge)
+	alerts.Post("/:id/resolve", middleware.RequireOperator(), alertHandler.Resolve)
+	alerts.Delete("/:id", middleware.RequireAdmin(), alertHandler.Delete)
+	alerts.Post("/:id/restore", middleware.RequireAdmin(), alertHandler.Restore)
+}
+
 func setupMiddleware(app *fiber.App, cfg *config.Config) {
 	app.Use(recover.New(recover.Config{
 		EnableStackTrace: cfg.App.IsDevelopment(),
@@ -146,6 +601,9 @@ func setupMiddleware(app *fiber.App, cfg *config.Config) {
 
 	app.Use(requestid.New())
 
+	// Browser security headers on every response, errors included
+	app.Use(middleware.SecurityHeaders(cfg.Server.Security.Headers))
+
 	// Add tracing middleware
 	if cfg.Tracing.Enabled {
 		app.Use(middleware.TracingMiddleware())
@@ -154,28 +612,43 @@ func setupMiddleware(app *fiber.App, cfg *config.Config) {
 	// Add metrics middleware
 	app.Use(middleware.PrometheusMiddleware())
 
-	if cfg.App.IsDevelopment() {
-		app.Use(logger.New(logger.Config{
-			Format: "${time} | ${status} | ${latency} | ${method} ${path}\n",
-		}))
-	}
+	// Add request logging, inside tracing so that requests log their trace ID
+	app.Use(middleware.RequestLogger(cfg.Logging))
 
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,PATCH,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
-	}))
-}
+	app.Use(cors.New(corsConfig(cfg.Server.CORS, cfg.App.IsDevelopment())))
 
-func customErrorHandler(c *fiber.Ctx, err error) error {
-	code := fiber.StatusInternalServerError
+	// CSRF protection for cookie-authenticated clients, after CORS so that
+	// preflight requests are answered first
+	app.Use(middleware.CSRF(cfg.Server.Security.CSRF))
+}
 
-	var e *fiber.Error
-	if errors.As(err, &e) {
-		code = e.Code
+// corsConfig builds the CORS middleware configuration. The headers the API
+// itself defines are always allowed. Without configured origins, development
+// allows every origin and other environments allow none.
+func corsConfig(cfg config.CORSConfig, development bool) cors.Config {
+	headers := append(slices.Clone(cfg.AllowHeaders), websocket.LastEventIDHeader, middleware.TeamHeader, middleware.CSRFHeader)
+
+	corsCfg := cors.Config{
+		AllowOrigins:     strings.Join(cfg.AllowOrigins, ","),
+		AllowMethods:     strings.Join(cfg.AllowMethods, ","),
+		AllowHeaders:     strings.Join(headers, ","),
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           int(cfg.MaxAge.Seconds()),
+	}
+	if len(cfg.AllowOrigins) == 0 && !development {
+		corsCfg.AllowOriginsFunc = func(string) bool { return false }
 	}
+	return corsCfg
+}
 
-	return c.Status(code).JSON(fiber.Map{
-		"error": err.Error(),
-	})
+// deploymentScope identifies the deployment in feature flag override keys.
+func deploymentScope(d config.DeploymentConfig) string {
+	switch {
+	case d.Region != "" && d.Cluster != "":
+		return d.Region + "/" + d.Cluster
+	case d.Region != "":
+		return d.Region
+	default:
+		return d.Cluster
+	}
 }
@@ -0,0 +1,600 @@
+// Alerting dashboard: lists alerts, kept live through the WebSocket hub, lets
+// operators acknowledge and resolve them, and admins manage the dead letter
+// queue. It talks to the v1 API with the access token of the signed-in user,
+// kept in the browser session and refreshed before it expires.
+(function () {
+  "use strict";
+
+  const API = "/api/v1";
+  const SESSION_KEY = "alerting.session";
+  const SEVERITIES = ["critical", "high", "medium", "low", "info"];
+  const OPEN_STATUSES = ["active", "acknowledged"];
+  const PAGE_SIZE = 100;
+  // Tokens are refreshed this long before they expire.
+  const REFRESH_MARGIN_MS = 60 * 1000;
+  // WebSocket reconnections back off up to this delay.
+  const MAX_RECONNECT_MS = 30 * 1000;
+
+  const $ = (selector) => document.querySelector(selector);
+
+  let session = loadSession();
+  let alerts = new Map();
+  let socket = null;
+  let reconnectDelay = 1000;
+  let reconnectTimer = null;
+  let refreshTimer = null;
+
+  // ---------------------------------------------------------------------
+  // Session
+
+  function loadSession() {
+    try {
+      return JSON.parse(sessionStorage.getItem(SESSION_KEY));
+    } catch (e) {
+      return null;
+    }
+  }
+
+  function saveSession(tokens, user) {
+    session = {
+      accessToken: tokens.access_token,
+      refreshToken: tokens.refresh_token,
+      expiresAt: tokens.expires_at,
+      user: user || session.user,
+    };
+    sessionStorage.setItem(SESSION_KEY, JSON.stringify(session));
+    scheduleRefresh();
+  }
+
+  function clearSession() {
+    session = null;
+    sessionStorage.removeItem(SESSION_KEY);
+    clearTimeout(refreshTimer);
+    disconnect();
+  }
+
+  function scheduleRefresh() {
+    clearTimeout(refreshTimer);
+    const delay = new Date(session.expiresAt).getTime() - Date.now() - REFRESH_MARGIN_MS;
+    refreshTimer = setTimeout(refresh, Math.max(delay, 0));
+  }
+
+  async function refresh() {
+    try {
+      const tokens = await request("POST", "/auth/refresh", { refresh_token: session.refreshToken }, false);
+      saveSession(tokens);
+      // Live connections keep running with the refreshed token
+      send({ type: "auth", token: session.accessToken });
+    } catch (e) {
+      signOut();
+    }
+  }
+
+  function isAdmin() {
+    return session.user.role === "admin";
+  }
+
+  function canManageAlerts() {
+    return session.user.role === "admin" || session.user.role === "operator";
+  }
+
+  // ---------------------------------------------------------------------
+  // API
+
+  // request calls the API and returns the decoded response. Errors carry
+  // the message of the API error response.
+  async function request(method, path, body, authenticated = true) {
+    const headers = { Accept: "application/json" };
+    if (body !== undefined) {
+      headers["Content-Type"] = "application/json";
+    }
+    if (authenticated) {
+      headers.Authorization = "Bearer " + session.accessToken;
+    }
+    if (method !== "GET") {
+      withCSRF(headers);
+    }
+
+    const response = await fetch(API + path, {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+      credentials: "same-origin",
+    });
+
+    if (response.status === 401 && authenticated) {
+      signOut();
+      throw new Error("Session expired");
+    }
+    if (response.status === 204) {
+      return null;
+    }
+
+    const data = await response.json().catch(() => null);
+    if (!response.ok) {
+      throw new Error((data && (data.error || data.detail)) || response.statusText);
+    }
+    return data;
+  }
+
+  // withCSRF echoes the CSRF cookie, when the API issues one, as required on
+  // requests that change state.
+  function withCSRF(headers) {
+    const csrf = cookie("csrf_token");
+    if (csrf) {
+      headers["X-CSRF-Token"] = csrf;
+    }
+    return headers;
+  }
+
+  function cookie(name) {
+    const entry = document.cookie.split("; ").find((c) => c.startsWith(name + "="));
+    return entry ? decodeURIComponent(entry.slice(name.length + 1)) : "";
+  }
+
+  // ---------------------------------------------------------------------
+  // Live updates
+
+  function connect() {
+    if (!session || socket) {
+      return;
+    }
+
+    const scheme = location.protocol === "https:" ? "wss:" : "ws:";
+    // Browsers cannot set the Authorization header of WebSocket requests;
+    // the token is offered as a subprotocol instead
+    socket = new WebSocket(scheme + "//" + location.host + "/ws", ["alerting.v1", "bearer." + session.accessToken]);
+
+    socket.onopen = () => {
+      reconnectDelay = 1000;
+      setConnection(true);
+      send({ type: "subscribe", channel: "alerts" });
+      // Catch up on the changes missed while disconnected
+      loadAlerts();
+    };
+
+    socket.onmessage = (e) => {
+      // Queued messages are sent together, one per line
+      for (const line of String(e.data).split("\n")) {
+        if (line) {
+          handleMessage(JSON.parse(line));
+        }
+      }
+    };
+
+    socket.onclose = () => {
+      socket = null;
+      setConnection(false);
+      if (session) {
+        reconnectTimer = setTimeout(connect, reconnectDelay);
+        reconnectDelay = Math.min(reconnectDelay * 2, MAX_RECONNECT_MS);
+      }
+    };
+  }
+
+  function disconnect() {
+    clearTimeout(reconnectTimer);
+    if (socket) {
+      socket.onclose = null;
+      socket.close();
+      socket = null;
+    }
+    setConnection(false);
+  }
+
+  function send(message) {
+    if (socket && socket.readyState === WebSocket.OPEN) {
+      socket.send(JSON.stringify(message));
+    }
+  }
+
+  function setConnection(online) {
+    const el = $("#connection");
+    el.textContent = online ? "live" : "offline";
+    el.className = "connection " + (online ? "online" : "offline");
+  }
+
+  function handleMessage(msg) {
+    switch (msg.type) {
+      case "alert.created":
+      case "alert.updated":
+      case "alert.acknowledged":
+      case "alert.resolved":
+      case "alert.restored":
+        upsertAlert(msg.payload, msg.type === "alert.created");
+        break;
+      case "alert.deleted":
+        alerts.delete(msg.payload.id);
+        renderAlerts();
+        break;
+      case "error":
+        showNotice(msg.payload && msg.payload.error);
+        break;
+    }
+  }
+
+  // ---------------------------------------------------------------------
+  // Alerts
+
+  function alertFilters() {
+    const form = $("#alert-filters");
+    const status = form.elements.status.value;
+    return {
+      statuses: status === "open" ? OPEN_STATUSES : [status],
+      severities: [...form.querySelectorAll("input[name=severity]:checked")].map((el) => el.value),
+      search: form.elements.search.value.trim(),
+    };
+  }
+
+  function matches(alert, filters) {
+    if (!filters.statuses.includes(alert.status) || !filters.severities.includes(alert.severity)) {
+      return false;
+    }
+    if (!filters.search) {
+      return true;
+    }
+    const search = filters.search.toLowerCase();
+    return (alert.title + " " + alert.message).toLowerCase().includes(search);
+  }
+
+  async function loadAlerts() {
+    const filters = alertFilters();
+    if (filters.severities.length === 0) {
+      alerts = new Map();
+      renderAlerts();
+      return;
+    }
+
+    const params = new URLSearchParams({ page_size: PAGE_SIZE, sort_by: "created_at", sort_order: "desc" });
+    filters.statuses.forEach((s) => params.append("status", s));
+    if (filters.severities.length < SEVERITIES.length) {
+      filters.severities.forEach((s) => params.append("severity", s));
+    }
+    if (filters.search) {
+      params.set("search", filters.search);
+    }
+
+    try {
+      const page = await request("GET", "/alerts?" + params);
+      alerts = new Map(page.items.map((a) => [a.id, a]));
+      renderAlerts();
+    } catch (e) {
+      showNotice(e.message);
+    }
+  }
+
+  function upsertAlert(alert, fresh) {
+    if (matches(alert, alertFilters())) {
+      alert.fresh = fresh;
+      alerts.set(alert.id, alert);
+    } else {
+      alerts.delete(alert.id);
+    }
+    renderAlerts();
+  }
+
+  function renderAlerts() {
+    const body = $("#alerts");
+    const rows = [...alerts.values()].sort((a, b) => new Date(b.created_at) - new Date(a.created_at));
+
+    body.replaceChildren(...rows.map(alertRow));
+    $("#alerts-empty").hidden = rows.length > 0;
+    rows.forEach((a) => delete a.fresh);
+  }
+
+  function alertRow(alert) {
+    const tr = document.createElement("tr");
+    if (alert.fresh) {
+      tr.className = "fresh";
+    }
+
+    const title = cell(alert.title);
+    if (alert.message) {
+      title.append(detail(alert.message));
+    }
+
+    const actions = document.createElement("td");
+    actions.className = "actions";
+    if (canManageAlerts()) {
+      if (alert.status === "active") {
+        actions.append(button("Acknowledge", () => changeAlert(alert.id, "acknowledge")));
+      }
+      if (alert.status === "active" || alert.status === "acknowledged") {
+        actions.append(button("Resolve", () => changeAlert(alert.id, "resolve")));
+      }
+    }
+
+    tr.append(
+      badgeCell(alert.severity),
+      title,
+      cell(alert.source || "—"),
+      statusCell(alert.status, alert.acknowledged_by || alert.resolved_by),
+      cell(formatTime(alert.created_at)),
+      actions,
+    );
+    return tr;
+  }
+
+  async function changeAlert(id, action) {
+    try {
+      const alert = await request("POST", "/alerts/" + encodeURIComponent(id) + "/" + action, {});
+      upsertAlert(alert, false);
+    } catch (e) {
+      showNotice(e.message);
+    }
+  }
+
+  // ---------------------------------------------------------------------
+  // Dead letter queue
+
+  function dlqFilters() {
+    const form = $("#dlq-filters");
+    return {
+      status: form.elements.status.value,
+      eventType: form.elements.event_type.value.trim(),
+    };
+  }
+
+  async function loadDeadLetters() {
+    const filters = dlqFilters();
+    const params = new URLSearchParams({ page_size: PAGE_SIZE });
+    if (filters.status) {
+      params.set("status", filters.status);
+    }
+    if (filters.eventType) {
+      params.set("event_type", filters.eventType);
+    }
+
+    try {
+      const page = await request("GET", "/admin/failed-events?" + params);
+      $("#dlq").replaceChildren(...page.items.map(deadLetterRow));
+      $("#dlq-empty").hidden = page.items.length > 0;
+    } catch (e) {
+      showNotice(e.message);
+    }
+  }
+
+  function deadLetterRow(failed) {
+    const tr = document.createElement("tr");
+
+    const id = document.createElement("td");
+    const code = document.createElement("code");
+    code.textContent = failed.event_id;
+    id.append(code);
+
+    const error = cell(failed.last_error || "—");
+    error.className = "error-text";
+
+    const actions = document.createElement("td");
+    actions.className = "actions";
+    if (failed.status === "pending") {
+      actions.append(
+        button("Retry", () => changeDeadLetter(failed.event_id, "retry")),
+        button("Ignore", () => changeDeadLetter(failed.event_id, "ignore")),
+      );
+    }
+
+    tr.append(
+      id,
+      cell(failed.event_type),
+      cell(String(failed.retries)),
+      error,
+      cell(formatTime(failed.failed_at)),
+      statusCell(failed.status),
+      actions,
+    );
+    return tr;
+  }
+
+  async function changeDeadLetter(eventID, action) {
+    try {
+      await request("POST", "/admin/failed-events/" + encodeURIComponent(eventID) + "/" + action, {});
+      loadDeadLetters();
+    } catch (e) {
+      showNotice(e.message);
+    }
+  }
+
+  // bulkDeadLetters retries, ignores or purges the events matching the
+  // filters, after confirming how many there are with a dry run.
+  async function bulkDeadLetters(action) {
+    const filters = dlqFilters();
+    const selection = { event_type: filters.eventType || undefined, status: filters.status ? [filters.status] : undefined };
+    const params = new URLSearchParams();
+    if (filters.status) {
+      params.set("status", filters.status);
+    }
+    if (filters.eventType) {
+      params.set("event_type", filters.eventType);
+    }
+
+    const run = (dryRun) => {
+      if (action === "purge") {
+        params.set("dry_run", dryRun);
+        return request("DELETE", "/admin/failed-events?" + params);
+      }
+      return request("POST", "/admin/failed-events/" + action, { ...selection, dry_run: dryRun });
+    };
+
+    try {
+      const preview = await run(true);
+      if (preview.affected === 0) {
+        showNotice("No events match the filters.", true);
+        return;
+      }
+      if (!confirm(action[0].toUpperCase() + action.slice(1) + " " + preview.affected + " event(s)?")) {
+        return;
+      }
+      const result = await run(false);
+      showNotice(result.affected + " event(s) processed.", true);
+      loadDeadLetters();
+    } catch (e) {
+      showNotice(e.message);
+    }
+  }
+
+  // ---------------------------------------------------------------------
+  // Rendering helpers
+
+  function cell(text) {
+    const td = document.createElement("td");
+    td.textContent = text;
+    return td;
+  }
+
+  function detail(text) {
+    const div = document.createElement("div");
+    div.className = "detail";
+    div.textContent = text;
+    return div;
+  }
+
+  function badgeCell(severity) {
+    const td = document.createElement("td");
+    const badge = document.createElement("span");
+    badge.className = "badge " + severity;
+    badge.textContent = severity;
+    td.append(badge);
+    return td;
+  }
+
+  function statusCell(status, by) {
+    const td = cell(status);
+    td.className = "status";
+    if (by) {
+      td.append(detail("by " + by));
+    }
+    return td;
+  }
+
+  function button(label, onClick) {
+    const el = document.createElement("button");
+    el.type = "button";
+    el.textContent = label;
+    el.addEventListener("click", async () => {
+      el.disabled = true;
+      await onClick();
+      el.disabled = false;
+    });
+    return el;
+  }
+
+  function formatTime(value) {
+    return value ? new Date(value).toLocaleString() : "—";
+  }
+
+  let noticeTimer = null;
+
+  function showNotice(text, ok = false) {
+    if (!text) {
+      return;
+    }
+    const el = $("#notice");
+    el.textContent = text;
+    el.className = "notice" + (ok ? " ok" : "");
+    el.hidden = false;
+    clearTimeout(noticeTimer);
+    noticeTimer = setTimeout(() => { el.hidden = true; }, 6000);
+  }
+
+  // ---------------------------------------------------------------------
+  // Views
+
+  function showLogin() {
+    $("#app").hidden = true;
+    $("#login").hidden = false;
+  }
+
+  function showApp() {
+    $("#login").hidden = true;
+    $("#app").hidden = false;
+    $("#user").textContent = session.user.email + " (" + session.user.role + ")";
+    document.querySelectorAll("[data-role=admin]").forEach((el) => { el.hidden = !isAdmin(); });
+    selectTab("alerts");
+    scheduleRefresh();
+    loadAlerts();
+    connect();
+  }
+
+  function selectTab(name) {
+    document.querySelectorAll(".tab").forEach((el) => el.classList.toggle("active", el.dataset.tab === name));
+    $("#tab-alerts").hidden = name !== "alerts";
+    $("#tab-dlq").hidden = name !== "dlq";
+    if (name === "dlq") {
+      loadDeadLetters();
+    }
+  }
+
+  async function signIn(e) {
+    e.preventDefault();
+    const form = e.target;
+    const error = $("#login-error");
+    error.hidden = true;
+
+    try {
+      const login = await request("POST", "/auth/login", {
+        email: form.elements.email.value,
+        password: form.elements.password.value,
+      }, false);
+      saveSession(login, login.user);
+      form.reset();
+      showApp();
+    } catch (err) {
+      error.textContent = err.message;
+      error.hidden = false;
+    }
+  }
+
+  function signOut() {
+    if (session) {
+      const current = session;
+      clearSession();
+      fetch(API + "/auth/logout", {
+        method: "POST",
+        headers: withCSRF({ Authorization: "Bearer " + current.accessToken, "Content-Type": "application/json" }),
+        body: JSON.stringify({ refresh_token: current.refreshToken }),
+      }).catch(() => {});
+    }
+    showLogin();
+  }
+
+  // ---------------------------------------------------------------------
+  // Wiring
+
+  let searchTimer = null;
+
+  $("#login-form").addEventListener("submit", signIn);
+  $("#logout").addEventListener("click", signOut);
+  document.querySelectorAll(".tab").forEach((el) => el.addEventListener("click", () => selectTab(el.dataset.tab)));
+
+  $("#alert-filters").addEventListener("submit", (e) => e.preventDefault());
+  $("#alert-filters").addEventListener("change", loadAlerts);
+  $("#alert-filters").elements.search.addEventListener("input", () => {
+    clearTimeout(searchTimer);
+    searchTimer = setTimeout(loadAlerts, 300);
+  });
+
+  $("#dlq-filters").addEventListener("submit", (e) => e.preventDefault());
+  $("#dlq-filters").addEventListener("change", loadDeadLetters);
+  $("#dlq-retry-all").addEventListener("click", () => bulkDeadLetters("retry"));
+  $("#dlq-ignore-all").addEventListener("click", () => bulkDeadLetters("ignore"));
+  $("#dlq-purge").addEventListener("click", () => bulkDeadLetters("purge"));
+
+  start();
+
+  // start resumes the session of the browser tab, refreshing its token first
+  // if it expired meanwhile, or asks to sign in.
+  async function start() {
+    if (!session) {
+      showLogin();
+      return;
+    }
+    if (new Date(session.expiresAt).getTime() - Date.now() < REFRESH_MARGIN_MS) {
+      await refresh();
+      if (!session) {
+        return;
+      }
+    }
+    showApp();
+  }
+})();
@@ -0,0 +1,39 @@
+// Package ui serves the web dashboard embedded in the binary, for
+// deployments without a separate frontend.
+package ui
+
+import (
+	"embed"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/filesystem"
+)
+
+// Prefix is where the dashboard is served.
+const Prefix = "/ui"
+
+// contentSecurityPolicy replaces the policy of API responses, which allows
+// nothing, on the dashboard pages: they load their scripts and styles from
+// the dashboard and only talk to the API of the same origin.
+const contentSecurityPolicy = "default-src 'none'; script-src 'self'; style-src 'self'; img-src 'self' data:; " +
+	"connect-src 'self'; base-uri 'none'; form-action 'none'; frame-ancestors 'none'"
+
+//go:embed static
+var static embed.FS
+
+// Register serves the dashboard under Prefix. It is a single page talking to
+// the v1 API, with the access token kept in the browser session, and to the
+// WebSocket hub for live alerts.
+func Register(app *fiber.App) {
+	app.Use(Prefix,
+		func(c *fiber.Ctx) error {
+			c.Set(fiber.HeaderContentSecurityPolicy, contentSecurityPolicy)
+			c.Set(fiber.HeaderCacheControl, "no-cache")
+			return c.Next()
+		},
+		filesystem.New(filesystem.Config{
+			Root:       http.FS(static),
+			PathPrefix: "static",
+		}))
+}
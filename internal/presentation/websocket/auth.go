@@ -0,0 +1,217 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
+)
+
+// authCheckTimeout bounds the time a token check may take.
+const authCheckTimeout = 5 * time.Second
+
+// TokenAuthenticator validates the access tokens of connected clients.
+type TokenAuthenticator interface {
+	ValidateToken(ctx context.Context, tokenString string) (*service.JWTClaims, error)
+}
+
+// AuthenticatedPayload is sent to a client whose token was refreshed.
+type AuthenticatedPayload struct {
+	Role      string    `json:"role"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SetAuthenticator checks the tokens of authenticated clients every
+// interval, disconnecting those whose token expired or was revoked and
+// applying role changes, and lets clients refresh their token over the
+// socket with an auth message. The checks stop when the hub shuts down or
+// another authenticator is set.
+func (h *Hub) SetAuthenticator(auth TokenAuthenticator, interval time.Duration) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(h.ctx)
+
+	h.mu.Lock()
+	if h.stopAuthChecks != nil {
+		h.stopAuthChecks()
+	}
+	h.authenticator = auth
+	h.stopAuthChecks = cancel
+	h.mu.Unlock()
+
+	go h.checkTokens(ctx, auth, interval)
+}
+
+// tokenAuthenticator returns the authenticator, if any.
+func (h *Hub) tokenAuthenticator() TokenAuthenticator {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.authenticator
+}
+
+// checkTokens checks the tokens of the authenticated clients on every tick,
+// until ctx is done.
+func (h *Hub) checkTokens(ctx context.Context, auth TokenAuthenticator, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		h.mu.RLock()
+		clients := make([]*Client, 0, len(h.clients))
+		for client := range h.clients {
+			if client.userID != nil {
+				clients = append(clients, client)
+			}
+		}
+		h.mu.RUnlock()
+
+		for _, client := range clients {
+			h.checkToken(ctx, auth, client)
+		}
+	}
+}
+
+// checkToken disconnects a client whose token expired or was revoked, and
+// applies the current role of its user otherwise.
+func (h *Hub) checkToken(ctx context.Context, auth TokenAuthenticator, client *Client) {
+	token, expiresAt := client.credentials()
+	if token == "" {
+		return
+	}
+
+	if !expiresAt.IsZero() && time.Now().After(expiresAt) {
+		client.closeUnauthorized("token expired")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, authCheckTimeout)
+	defer cancel()
+
+	claims, err := auth.ValidateToken(ctx, token)
+	if err != nil {
+		client.closeUnauthorized("token revoked")
+		return
+	}
+	if claims.Role != client.role() {
+		h.changeRole(client, claims.Role)
+	}
+}
+
+// changeRole moves a client to the index of its new role.
+func (h *Hub) changeRole(client *Client, role string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[client]; ok && client.userRole != "" {
+		removeFromIndex(h.roleClients, client.userRole, client)
+	}
+
+	client.mu.Lock()
+	client.userRole = role
+	client.mu.Unlock()
+
+	if _, ok := h.clients[client]; ok && role != "" {
+		addToIndex(h.roleClients, role, client)
+	}
+}
+
+// setCredentials sets the token the client authenticated with and when it
+// expires; a zero time never expires.
+func (c *Client) setCredentials(token string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = token
+	c.tokenExpiresAt = expiresAt
+}
+
+// credentials returns the token the client authenticated with and when it expires.
+func (c *Client) credentials() (string, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.token, c.tokenExpiresAt
+}
+
+// role returns the role of the client.
+func (c *Client) role() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.userRole
+}
+
+// closeUnauthorized closes the client once the frames already queued are
+// written, with a policy violation close frame giving the reason.
+func (c *Client) closeUnauthorized(reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	log.Info().Str("reason", reason).Msg("Disconnecting unauthorized WebSocket client")
+	metrics.WebSocketAuthDisconnectsTotal.WithLabelValues(reason).Inc()
+
+	c.closed = true
+	c.closeMessage = websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason)
+	close(c.send)
+}
+
+// handleAuth replaces the token of the client with a refreshed one, which
+// must belong to the same user.
+func (c *Client) handleAuth(msg Message) {
+	auth := c.hub.tokenAuthenticator()
+	if auth == nil {
+		c.sendError("authentication is not available")
+		return
+	}
+	if c.userID == nil {
+		c.sendError("connect with a token to authenticate")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), authCheckTimeout)
+	defer cancel()
+
+	claims, err := auth.ValidateToken(ctx, msg.Token)
+	if err != nil {
+		c.sendError("invalid or expired token")
+		return
+	}
+	if claims.UserID != c.userID.String() {
+		c.sendError("token belongs to another user")
+		return
+	}
+
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	c.setCredentials(msg.Token, expiresAt)
+	if claims.Role != c.role() {
+		c.hub.changeRole(c, claims.Role)
+	}
+
+	response := Message{
+		Type:      MessageTypeAuthenticated,
+		Payload:   AuthenticatedPayload{Role: claims.Role, ExpiresAt: expiresAt},
+		Timestamp: time.Now().UTC(),
+	}
+	data, _ := json.Marshal(response)
+	c.Send(data)
+}
@@ -0,0 +1,79 @@
+package websocket
+
+import (
+	"sort"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+)
+
+// SlowClientPolicy decides what happens to a client whose send buffer is full.
+type SlowClientPolicy string
+
+// Slow client policies.
+const (
+	// SlowClientDisconnect closes the client, which reconnects and catches up.
+	SlowClientDisconnect SlowClientPolicy = "disconnect"
+	// SlowClientDropOldest discards the oldest queued message to make room.
+	SlowClientDropOldest SlowClientPolicy = "drop-oldest"
+)
+
+// Send buffer defaults.
+const (
+	defaultSendBufferSize = 256
+	defaultSlowClient     = SlowClientDisconnect
+)
+
+// IsValid checks if the policy is a valid SlowClientPolicy value.
+func (p SlowClientPolicy) IsValid() bool {
+	switch p {
+	case SlowClientDisconnect, SlowClientDropOldest:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetSendPolicy sets the send buffer size and slow client policy of clients
+// connecting from now on. A bufferSize of zero or less keeps the default.
+func (h *Hub) SetSendPolicy(bufferSize int, policy SlowClientPolicy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if bufferSize > 0 {
+		h.sendBufferSize = bufferSize
+	}
+	if policy.IsValid() {
+		h.slowClient = policy
+	}
+}
+
+// sendPolicy returns the send buffer size and slow client policy for a new client.
+func (h *Hub) sendPolicy() (int, SlowClientPolicy) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.sendBufferSize, h.slowClient
+}
+
+// SlowClients reports the connected clients that dropped messages or whose
+// send buffer is at least half full, those that dropped the most first.
+func (h *Hub) SlowClients() []dto.WebSocketClientStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var slow []dto.WebSocketClientStats
+	for client := range h.clients {
+		stats := client.Stats()
+		if stats.Dropped > 0 || stats.Queued*2 >= stats.BufferSize {
+			slow = append(slow, stats)
+		}
+	}
+
+	sort.Slice(slow, func(i, j int) bool {
+		if slow[i].Dropped != slow[j].Dropped {
+			return slow[i].Dropped > slow[j].Dropped
+		}
+		return slow[i].Queued > slow[j].Queued
+	})
+
+	return slow
+}
@@ -0,0 +1,152 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// Channels clients can subscribe to. Alert messages are published to the
+// alerts channel and to the channels of the alert's severity, source and team:
+//
+//	alerts                every alert
+//	alerts:<severity>     alerts of a severity, e.g. alerts:critical
+//	alerts:source:<name>  alerts raised by a source
+//	team:<id>             alerts and incidents owned by a team
+//	view:<id>             alerts matching a saved view the client may see
+//	incidents             every incident
+//	presence              users connecting and disconnecting
+//
+// A client without subscriptions receives every message it is allowed to see.
+const (
+	ChannelAlerts    = "alerts"
+	ChannelIncidents = "incidents"
+	ChannelPresence  = "presence"
+
+	channelAlertsPrefix = "alerts:"
+	channelSourcePrefix = "alerts:source:"
+	channelTeamPrefix   = "team:"
+	channelViewPrefix   = "view:"
+)
+
+// maxSubscriptions limits the channels a single client can subscribe to.
+const maxSubscriptions = 50
+
+// Channel subscription errors.
+var (
+	ErrChannelInvalid       = errors.New("unknown channel")
+	ErrChannelForbidden     = errors.New("channel not allowed")
+	ErrTooManySubscriptions = errors.New("too many subscriptions")
+)
+
+// AlertChannels returns the channels an alert message is published to.
+func AlertChannels(alert *entity.Alert) []string {
+	channels := []string{
+		ChannelAlerts,
+		channelAlertsPrefix + string(alert.Severity),
+		channelSourcePrefix + alert.Source,
+	}
+	if alert.TeamID != nil {
+		channels = append(channels, channelTeamPrefix+alert.TeamID.String())
+	}
+	return channels
+}
+
+// ViewChannel returns the channel of a saved alert view.
+func ViewChannel(viewID entity.ID) string {
+	return channelViewPrefix + viewID.String()
+}
+
+// IncidentChannels returns the channels an incident message is published to.
+func IncidentChannels(incident *entity.Incident) []string {
+	channels := []string{ChannelIncidents}
+	if incident.TeamID != nil {
+		channels = append(channels, channelTeamPrefix+incident.TeamID.String())
+	}
+	return channels
+}
+
+// validateChannel checks that a channel exists and that a client with the
+// given tenant scope may subscribe to it.
+func validateChannel(channel string, tenant valueobject.TenantScope) error {
+	switch {
+	case channel == ChannelAlerts, channel == ChannelIncidents, channel == ChannelPresence:
+		return nil
+
+	case strings.HasPrefix(channel, channelSourcePrefix):
+		if strings.TrimPrefix(channel, channelSourcePrefix) == "" {
+			return ErrChannelInvalid
+		}
+		return nil
+
+	case strings.HasPrefix(channel, channelAlertsPrefix):
+		if !entity.AlertSeverity(strings.TrimPrefix(channel, channelAlertsPrefix)).IsValid() {
+			return ErrChannelInvalid
+		}
+		return nil
+
+	case strings.HasPrefix(channel, channelTeamPrefix):
+		teamID, err := entity.ParseID(strings.TrimPrefix(channel, channelTeamPrefix))
+		if err != nil {
+			return ErrChannelInvalid
+		}
+		if !tenant.Includes(teamID) {
+			return ErrChannelForbidden
+		}
+		return nil
+
+	case strings.HasPrefix(channel, channelViewPrefix):
+		// Access to the view is checked by the hub
+		if _, err := entity.ParseID(strings.TrimPrefix(channel, channelViewPrefix)); err != nil {
+			return ErrChannelInvalid
+		}
+		return nil
+
+	default:
+		return ErrChannelInvalid
+	}
+}
+
+// ViewAuthorizer checks whether a user may stream the alerts of a saved view.
+type ViewAuthorizer interface {
+	CanSubscribe(ctx context.Context, viewID entity.ID, userID entity.ID, tenant valueobject.TenantScope) bool
+}
+
+// SetViewAuthorizer lets clients subscribe to the channels of the saved views
+// the authorizer allows them to see.
+func (h *Hub) SetViewAuthorizer(authorizer ViewAuthorizer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.viewAuthorizer = authorizer
+}
+
+// checkChannel checks that a channel exists and that a client may subscribe to it.
+func (h *Hub) checkChannel(client *Client, channel string) error {
+	if err := validateChannel(channel, client.tenant); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(channel, channelViewPrefix) {
+		return nil
+	}
+
+	h.mu.RLock()
+	authorizer := h.viewAuthorizer
+	h.mu.RUnlock()
+	if authorizer == nil || client.userID == nil {
+		return ErrChannelForbidden
+	}
+
+	viewID, _ := entity.ParseID(strings.TrimPrefix(channel, channelViewPrefix))
+
+	ctx, cancel := context.WithTimeout(context.Background(), authCheckTimeout)
+	defer cancel()
+
+	if !authorizer.CanSubscribe(ctx, viewID, *client.userID, client.tenant) {
+		return ErrChannelForbidden
+	}
+	return nil
+}
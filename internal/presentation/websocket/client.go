@@ -9,7 +9,10 @@ import (
 	"github.com/fasthttp/websocket"
 	"github.com/rs/zerolog/log"
 
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
 )
 
 const (
@@ -19,28 +22,72 @@ const (
 	maxMessageSize = 512
 )
 
-// Client represents a WebSocket client connection.
+// frame is a message queued for a client. Broadcast messages carry the
+// hub's sequence number, which stream clients use as their event ID.
+type frame struct {
+	id   uint64
+	data []byte
+}
+
+// Client represents a WebSocket client connection, or a Server-Sent Events
+// stream when it has no connection.
 type Client struct {
 	hub      *Hub
 	conn     *websocket.Conn
-	send     chan []byte
+	send     chan frame
 	userID   *entity.ID
 	userRole string
+	tenant   valueobject.TenantScope
+	commands AlertCommands
 	mu       sync.Mutex
 	closed   bool
+
+	// closeMessage is the payload of the close frame sent once the send
+	// queue is closed; empty unless the server is shutting down.
+	closeMessage []byte
+
+	// resumeAfter is the last broadcast the client received before reconnecting.
+	resumeAfter uint64
+
+	// Token the client authenticated with and when it expires, guarded by
+	// mu; the role is guarded by mu too, and changed under the hub's lock
+	token          string
+	tokenExpiresAt time.Time
+
+	// Send statistics, guarded by mu
+	policy      SlowClientPolicy
+	connectedAt time.Time
+	sent        uint64
+	dropped     uint64
+	lastDropAt  *time.Time
 }
 
 // NewClient creates a new WebSocket client.
 func NewClient(hub *Hub, conn *websocket.Conn, userID *entity.ID, userRole string) *Client {
+	bufferSize, policy := hub.sendPolicy()
 	return &Client{
-		hub:      hub,
-		conn:     conn,
-		send:     make(chan []byte, 256),
-		userID:   userID,
-		userRole: userRole,
+		hub:         hub,
+		conn:        conn,
+		send:        make(chan frame, bufferSize),
+		userID:      userID,
+		userRole:    userRole,
+		policy:      policy,
+		connectedAt: time.Now().UTC(),
 	}
 }
 
+// NewStreamClient creates a client without a WebSocket connection, whose
+// messages are written by a Server-Sent Events stream.
+func NewStreamClient(hub *Hub, userID *entity.ID, userRole string) *Client {
+	return NewClient(hub, nil, userID, userRole)
+}
+
+// SetTenant sets the teams whose alerts the client receives.
+// Clients without a tenant scope only receive alerts not owned by any team.
+func (c *Client) SetTenant(scope valueobject.TenantScope) {
+	c.tenant = scope
+}
+
 // ReadPump pumps messages from the WebSocket connection to the hub.
 func (c *Client) ReadPump() {
 	defer func() {
@@ -77,10 +124,10 @@ func (c *Client) WritePump() {
 
 	for {
 		select {
-		case message, ok := <-c.send:
+		case f, ok := <-c.send:
 			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
-				_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				_ = c.conn.WriteMessage(websocket.CloseMessage, c.closeMessage)
 				return
 			}
 
@@ -88,12 +135,12 @@ func (c *Client) WritePump() {
 			if err != nil {
 				return
 			}
-			_, _ = w.Write(message)
+			_, _ = w.Write(f.data)
 
 			n := len(c.send)
 			for i := 0; i < n; i++ {
 				_, _ = w.Write([]byte{'\n'})
-				_, _ = w.Write(<-c.send)
+				_, _ = w.Write((<-c.send).data)
 			}
 
 			if err := w.Close(); err != nil {
@@ -111,6 +158,12 @@ func (c *Client) WritePump() {
 
 // Send sends a message to the client.
 func (c *Client) Send(message []byte) {
+	c.sendFrame(frame{data: message})
+}
+
+// sendFrame queues a frame. When the queue is full the client's slow client
+// policy either drops the oldest queued frame or closes the client.
+func (c *Client) sendFrame(f frame) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -119,11 +172,63 @@ func (c *Client) Send(message []byte) {
 	}
 
 	select {
-	case c.send <- message:
+	case c.send <- f:
+		c.sent++
+		return
 	default:
-		c.closed = true
-		close(c.send)
 	}
+
+	if c.policy == SlowClientDropOldest {
+		// The write pump may drain the queue meanwhile, so neither step blocks
+		select {
+		case <-c.send:
+		default:
+		}
+		now := time.Now().UTC()
+		c.dropped++
+		c.lastDropAt = &now
+		metrics.WebSocketMessagesDropped.Inc()
+
+		select {
+		case c.send <- f:
+			c.sent++
+		default:
+		}
+		return
+	}
+
+	log.Warn().
+		Int("buffer_size", cap(c.send)).
+		Str("role", c.userRole).
+		Msg("Disconnecting slow WebSocket client")
+	metrics.WebSocketSlowClientsDisconnected.Inc()
+
+	c.closed = true
+	close(c.send)
+}
+
+// Stats returns the client's send statistics.
+func (c *Client) Stats() dto.WebSocketClientStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := dto.WebSocketClientStats{
+		Role:        c.userRole,
+		Transport:   "websocket",
+		ConnectedAt: c.connectedAt,
+		Queued:      len(c.send),
+		BufferSize:  cap(c.send),
+		Sent:        c.sent,
+		Dropped:     c.dropped,
+		LastDropAt:  c.lastDropAt,
+	}
+	if c.userID != nil {
+		stats.UserID = c.userID.String()
+	}
+	if c.conn == nil {
+		stats.Transport = "sse"
+	}
+	return stats
 }
 
 // Close closes the client connection.
@@ -137,7 +242,33 @@ func (c *Client) Close() {
 
 	c.closed = true
 	close(c.send)
-	_ = c.conn.Close()
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+}
+
+// drain closes the client once the frames already queued are written. A
+// WebSocket client is then sent a going away close frame, to which it
+// replies by closing the connection.
+func (c *Client) drain() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	c.closed = true
+	c.closeMessage = websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+	close(c.send)
+}
+
+// closeConn closes the client connection, if any, without waiting for the
+// frames still queued.
+func (c *Client) closeConn() {
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
 }
 
 func (c *Client) handleMessage(message []byte) {
@@ -154,6 +285,10 @@ func (c *Client) handleMessage(message []byte) {
 		c.handleSubscribe(msg)
 	case MessageTypeUnsubscribe:
 		c.handleUnsubscribe(msg)
+	case MessageTypeAcknowledge, MessageTypeResolve:
+		c.handleCommand(msg)
+	case MessageTypeAuth:
+		c.handleAuth(msg)
 	default:
 		log.Debug().Str("type", string(msg.Type)).Msg("Unknown message type")
 	}
@@ -169,6 +304,11 @@ func (c *Client) sendPong() {
 }
 
 func (c *Client) handleSubscribe(msg Message) {
+	if err := c.hub.Subscribe(c, msg.Channel); err != nil {
+		c.sendError(err.Error() + ": " + msg.Channel)
+		return
+	}
+
 	response := Message{
 		Type:      MessageTypeSubscribed,
 		Channel:   msg.Channel,
@@ -179,6 +319,8 @@ func (c *Client) handleSubscribe(msg Message) {
 }
 
 func (c *Client) handleUnsubscribe(msg Message) {
+	c.hub.Unsubscribe(c, msg.Channel)
+
 	response := Message{
 		Type:      MessageTypeUnsubscribed,
 		Channel:   msg.Channel,
@@ -187,3 +329,8 @@ func (c *Client) handleUnsubscribe(msg Message) {
 	data, _ := json.Marshal(response)
 	c.Send(data)
 }
+
+func (c *Client) sendError(err string) {
+	data, _ := json.Marshal(NewErrorMessage(err))
+	c.Send(data)
+}
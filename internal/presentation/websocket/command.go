@@ -0,0 +1,113 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// commandTimeout bounds the time a client command may take.
+const commandTimeout = 10 * time.Second
+
+// AlertCommands changes alerts on behalf of WebSocket clients.
+type AlertCommands interface {
+	GetVisible(ctx context.Context, id entity.ID, role entity.UserRole, tenant valueobject.TenantScope) (*entity.Alert, error)
+	Acknowledge(ctx context.Context, alertID, userID entity.ID) (*entity.Alert, error)
+	Resolve(ctx context.Context, alertID, userID entity.ID) (*entity.Alert, error)
+}
+
+// handleCommand acknowledges or resolves an alert and replies with a result
+// message. Commands follow the rules of the HTTP endpoints: the client must
+// be authenticated as an operator or admin, and may only change alerts it
+// can see.
+func (c *Client) handleCommand(msg Message) {
+	result := CommandResult{
+		RequestID: msg.RequestID,
+		Command:   msg.Type,
+	}
+
+	alert, err := c.runCommand(msg)
+	if err != nil {
+		result.Error, result.Code = commandError(msg.Type, err)
+	} else {
+		response := dto.AlertFromEntity(alert)
+		result.Success = true
+		result.Alert = &response
+	}
+
+	data, _ := json.Marshal(NewResultMessage(result))
+	c.Send(data)
+}
+
+// runCommand checks the client may run the command and runs it.
+func (c *Client) runCommand(msg Message) (*entity.Alert, error) {
+	if c.commands == nil {
+		return nil, errCommandUnavailable
+	}
+	if c.userID == nil {
+		return nil, errCommandUnauthenticated
+	}
+
+	role := entity.UserRole(c.role())
+	if role != entity.UserRoleAdmin && role != entity.UserRoleOperator {
+		return nil, errCommandForbidden
+	}
+
+	alertID, err := entity.ParseID(msg.AlertID)
+	if err != nil {
+		return nil, errCommandInvalidAlert
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	// Alerts hidden from the user's role or teams cannot be changed either
+	if _, err := c.commands.GetVisible(ctx, alertID, role, c.tenant); err != nil {
+		return nil, err
+	}
+
+	if msg.Type == MessageTypeResolve {
+		return c.commands.Resolve(ctx, alertID, *c.userID)
+	}
+	return c.commands.Acknowledge(ctx, alertID, *c.userID)
+}
+
+// Command errors.
+var (
+	errCommandUnavailable     = errors.New("commands are not available")
+	errCommandUnauthenticated = errors.New("user not authenticated")
+	errCommandForbidden       = errors.New("insufficient permissions")
+	errCommandInvalidAlert    = errors.New("invalid alert ID")
+)
+
+// commandError returns the message and code reported for a failed command,
+// matching those of the HTTP endpoints.
+func commandError(command MessageType, err error) (string, string) {
+	switch {
+	case errors.Is(err, errCommandUnavailable):
+		return "Commands are not available", "NOT_IMPLEMENTED"
+	case errors.Is(err, errCommandUnauthenticated):
+		return "User not authenticated", "UNAUTHORIZED"
+	case errors.Is(err, errCommandForbidden):
+		return "Insufficient permissions", "FORBIDDEN"
+	case errors.Is(err, errCommandInvalidAlert):
+		return "Invalid alert ID", "BAD_REQUEST"
+	case errors.Is(err, service.ErrAlertNotFound):
+		return "Alert not found", "NOT_FOUND"
+	case errors.Is(err, entity.ErrAlertAlreadyAcknowledged):
+		return "Alert is already acknowledged", "CONFLICT"
+	case errors.Is(err, entity.ErrAlertAlreadyResolved):
+		return "Alert is already resolved", "CONFLICT"
+	default:
+		log.Error().Err(err).Str("command", string(command)).Msg("WebSocket command failed")
+		return "Failed to " + string(command) + " alert", "INTERNAL_ERROR"
+	}
+}
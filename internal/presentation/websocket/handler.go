@@ -1,16 +1,34 @@
 package websocket
 
 import (
+	"context"
+	"encoding/json"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	fiberws "github.com/gofiber/websocket/v2"
 	"github.com/rs/zerolog/log"
 
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 )
 
+// Subprotocol is the WebSocket subprotocol of the API. Clients offering
+// their access token as a subprotocol also offer this one, which the server
+// selects: browsers drop connections where none of theirs is selected.
+const Subprotocol = "alerting.v1"
+
+// DigestSource provides the events a user missed while offline.
+type DigestSource interface {
+	Drain(ctx context.Context, userID entity.ID) (*dto.DigestResponse, error)
+}
+
 // Handler handles WebSocket connections.
 type Handler struct {
-	hub *Hub
+	hub      *Hub
+	digest   DigestSource
+	commands AlertCommands
 }
 
 // NewHandler creates a new WebSocket handler.
@@ -20,6 +38,16 @@ func NewHandler(hub *Hub) *Handler {
 	}
 }
 
+// SetDigestSource sets the source of missed-event digests delivered on connect.
+func (h *Handler) SetDigestSource(source DigestSource) {
+	h.digest = source
+}
+
+// SetAlertCommands lets authenticated clients acknowledge and resolve alerts over the socket.
+func (h *Handler) SetAlertCommands(commands AlertCommands) {
+	h.commands = commands
+}
+
 // Upgrade is middleware that checks if the request is a WebSocket upgrade request.
 func (h *Handler) Upgrade(c *fiber.Ctx) error {
 	if fiberws.IsWebSocketUpgrade(c) {
@@ -42,6 +70,14 @@ func (h *Handler) Handle(c *fiberws.Conn) {
 	}
 
 	client := NewClient(h.hub, c.Conn, userID, userRole)
+	client.commands = h.commands
+	if tenant, ok := c.Locals("tenant").(valueobject.TenantScope); ok {
+		client.SetTenant(tenant)
+	}
+	if token, ok := c.Locals("accessToken").(string); ok {
+		expiresAt, _ := c.Locals("tokenExpiresAt").(time.Time)
+		client.setCredentials(token, expiresAt)
+	}
 	h.hub.Register(client)
 
 	log.Debug().
@@ -49,10 +85,37 @@ func (h *Handler) Handle(c *fiberws.Conn) {
 		Str("role", userRole).
 		Msg("New WebSocket connection")
 
+	if userID != nil {
+		h.sendDigest(client, *userID)
+	}
+
 	go client.WritePump()
 	client.ReadPump()
 }
 
+// sendDigest delivers the events the user missed while offline, if any.
+func (h *Handler) sendDigest(client *Client, userID entity.ID) {
+	if h.digest == nil {
+		return
+	}
+
+	digest, err := h.digest.Drain(context.Background(), userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to load missed events digest")
+		return
+	}
+	if digest == nil {
+		return
+	}
+
+	data, err := json.Marshal(NewDigestMessage(*digest))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal digest message")
+		return
+	}
+	client.Send(data)
+}
+
 // GetHub returns the hub instance.
 func (h *Handler) GetHub() *Hub {
 	return h.hub
@@ -1,15 +1,40 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/metrics"
 )
 
+// historySize is the number of recent broadcasts kept for clients resuming a stream.
+const historySize = 256
+
+// shutdownPollInterval is how often Shutdown checks whether the clients are gone.
+const shutdownPollInterval = 50 * time.Millisecond
+
+// broadcastMessage is a marshaled message and the channels it is published to.
+type broadcastMessage struct {
+	data     []byte
+	channels []string
+}
+
+// historyEntry is a recent broadcast, kept with the check of BroadcastWhere so
+// that it is replayed only to clients allowed to see it.
+type historyEntry struct {
+	id       uint64
+	data     []byte
+	channels []string
+	allow    func(role string, tenant valueobject.TenantScope) bool
+}
+
 // Hub maintains the set of active clients and broadcasts messages.
 type Hub struct {
 	// Registered clients
@@ -18,8 +43,17 @@ type Hub struct {
 	// Clients indexed by user ID for targeted messages
 	userClients map[entity.ID]map[*Client]bool
 
+	// Clients indexed by role, and by the teams of their tenant scope; clients
+	// with an unrestricted scope are kept apart as they see every team
+	roleClients     map[string]map[*Client]bool
+	teamClients     map[entity.ID]map[*Client]bool
+	allTeamsClients map[*Client]bool
+
+	// Channels each client subscribed to
+	subscriptions map[*Client]map[string]bool
+
 	// Inbound messages from clients to broadcast
-	broadcast chan []byte
+	broadcast chan broadcastMessage
 
 	// Register requests from clients
 	register chan *Client
@@ -27,18 +61,51 @@ type Hub struct {
 	// Unregister requests from clients
 	unregister chan *Client
 
+	// Relay to the hubs of other instances, if any
+	relay Relay
+
+	// Validates the tokens of authenticated clients, if set, and stops the
+	// periodic check of their tokens
+	authenticator  TokenAuthenticator
+	stopAuthChecks context.CancelFunc
+
+	// Checks access to view channels; without it they cannot be subscribed to
+	viewAuthorizer ViewAuthorizer
+
+	// Send buffer size and slow client policy of new clients
+	sendBufferSize int
+	slowClient     SlowClientPolicy
+
 	// Mutex for thread-safe operations
 	mu sync.RWMutex
+
+	// Recent broadcasts, numbered in order; guarded by historyMu
+	history   []historyEntry
+	sequence  uint64
+	historyMu sync.Mutex
+
+	// Done once the hub shuts down, stopping its background checks
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewHub creates a new Hub instance.
 func NewHub() *Hub {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Hub{
-		clients:     make(map[*Client]bool),
-		userClients: make(map[entity.ID]map[*Client]bool),
-		broadcast:   make(chan []byte, 256),
-		register:    make(chan *Client),
-		unregister:  make(chan *Client),
+		clients:         make(map[*Client]bool),
+		userClients:     make(map[entity.ID]map[*Client]bool),
+		roleClients:     make(map[string]map[*Client]bool),
+		teamClients:     make(map[entity.ID]map[*Client]bool),
+		allTeamsClients: make(map[*Client]bool),
+		subscriptions:   make(map[*Client]map[string]bool),
+		broadcast:       make(chan broadcastMessage, 256),
+		register:        make(chan *Client),
+		unregister:      make(chan *Client),
+		sendBufferSize:  defaultSendBufferSize,
+		slowClient:      defaultSlowClient,
+		ctx:             ctx,
+		cancel:          cancel,
 	}
 }
 
@@ -47,30 +114,48 @@ func (h *Hub) Run() {
 	for {
 		select {
 		case client := <-h.register:
-			h.registerClient(client)
+			if h.registerClient(client) {
+				h.publishPresence(client, PresenceOnline)
+			}
 
 		case client := <-h.unregister:
-			h.unregisterClient(client)
+			if h.unregisterClient(client) {
+				h.publishPresence(client, PresenceOffline)
+			}
 
 		case message := <-h.broadcast:
-			h.broadcastMessage(message)
+			h.broadcastToAll(message)
 		}
 	}
 }
 
 // registerClient adds a client to the hub.
-func (h *Hub) registerClient(client *Client) {
+// It returns true when the client is the first connection of its user.
+func (h *Hub) registerClient(client *Client) bool {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
 	h.clients[client] = true
 
 	// Add to user-specific map if authenticated
+	firstConnection := false
 	if client.userID != nil {
-		if h.userClients[*client.userID] == nil {
-			h.userClients[*client.userID] = make(map[*Client]bool)
-		}
-		h.userClients[*client.userID][client] = true
+		addToIndex(h.userClients, *client.userID, client)
+		firstConnection = len(h.userClients[*client.userID]) == 1
+		metrics.WebSocketUsersOnline.Set(float64(len(h.userClients)))
+	}
+	if client.userRole != "" {
+		addToIndex(h.roleClients, client.userRole, client)
+	}
+	if client.tenant.IsAll() {
+		h.allTeamsClients[client] = true
+	}
+	for _, teamID := range client.tenant.Teams() {
+		addToIndex(h.teamClients, teamID, client)
+	}
+
+	if client.resumeAfter > 0 {
+		h.replay(client)
 	}
 
 	// Update Prometheus metrics
@@ -80,27 +165,36 @@ func (h *Hub) registerClient(client *Client) {
 	log.Info().
 		Int("total_clients", len(h.clients)).
 		Msg("WebSocket client connected")
+
+	return firstConnection
 }
 
 // unregisterClient removes a client from the hub.
-func (h *Hub) unregisterClient(client *Client) {
+// It returns true when the client was the last connection of its user.
+func (h *Hub) unregisterClient(client *Client) bool {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
 	if _, ok := h.clients[client]; !ok {
-		return
+		return false
 	}
 
 	delete(h.clients, client)
+	delete(h.subscriptions, client)
 
 	// Remove from user-specific map
+	lastConnection := false
 	if client.userID != nil {
-		if clients, ok := h.userClients[*client.userID]; ok {
-			delete(clients, client)
-			if len(clients) == 0 {
-				delete(h.userClients, *client.userID)
-			}
-		}
+		removeFromIndex(h.userClients, *client.userID, client)
+		lastConnection = len(h.userClients[*client.userID]) == 0
+		metrics.WebSocketUsersOnline.Set(float64(len(h.userClients)))
+	}
+	if client.userRole != "" {
+		removeFromIndex(h.roleClients, client.userRole, client)
+	}
+	delete(h.allTeamsClients, client)
+	for _, teamID := range client.tenant.Teams() {
+		removeFromIndex(h.teamClients, teamID, client)
 	}
 
 	// Update Prometheus metrics
@@ -109,22 +203,101 @@ func (h *Hub) unregisterClient(client *Client) {
 	log.Info().
 		Int("total_clients", len(h.clients)).
 		Msg("WebSocket client disconnected")
+
+	return lastConnection
+}
+
+// addToIndex adds a client to the set of an index key.
+func addToIndex[K comparable](index map[K]map[*Client]bool, key K, client *Client) {
+	if index[key] == nil {
+		index[key] = make(map[*Client]bool)
+	}
+	index[key][client] = true
+}
+
+// removeFromIndex removes a client from the set of an index key, and the key once its set is empty.
+func removeFromIndex[K comparable](index map[K]map[*Client]bool, key K, client *Client) {
+	if clients, ok := index[key]; ok {
+		delete(clients, client)
+		if len(clients) == 0 {
+			delete(index, key)
+		}
+	}
 }
 
-// broadcastMessage sends a message to all connected clients.
-func (h *Hub) broadcastMessage(message []byte) {
+// broadcastToAll sends a message to all connected clients subscribed to its channels.
+func (h *Hub) broadcastToAll(message broadcastMessage) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
+	id := h.record(message.data, message.channels, nil)
+
+	count := 0
 	for client := range h.clients {
-		client.Send(message)
+		if h.isSubscribed(client, message.channels) {
+			client.sendFrame(frame{id: id, data: message.data})
+			count++
+		}
 	}
 
 	// Update messages sent metric
-	metrics.WebSocketMessagesSent.Add(float64(len(h.clients)))
+	metrics.WebSocketMessagesSent.Add(float64(count))
+}
+
+// isSubscribed reports whether a client receives a message published to channels.
+// Messages without channels, and clients without subscriptions, are not filtered.
+// The caller must hold h.mu.
+func (h *Hub) isSubscribed(client *Client, channels []string) bool {
+	subscribed := h.subscriptions[client]
+	if len(channels) == 0 || len(subscribed) == 0 {
+		return true
+	}
+	for _, channel := range channels {
+		if subscribed[channel] {
+			return true
+		}
+	}
+	return false
+}
+
+// record numbers a broadcast and keeps it in the history.
+func (h *Hub) record(data []byte, channels []string, allow func(string, valueobject.TenantScope) bool) uint64 {
+	h.historyMu.Lock()
+	defer h.historyMu.Unlock()
+
+	h.sequence++
+	h.history = append(h.history, historyEntry{
+		id:       h.sequence,
+		data:     data,
+		channels: channels,
+		allow:    allow,
+	})
+	if len(h.history) > historySize {
+		h.history = h.history[len(h.history)-historySize:]
+	}
+
+	return h.sequence
 }
 
-// Broadcast sends a message to all connected clients.
+// replay sends a resuming client the broadcasts it missed that are still in
+// the history. The caller must hold h.mu, so that no broadcast is missed or
+// delivered twice.
+func (h *Hub) replay(client *Client) {
+	h.historyMu.Lock()
+	defer h.historyMu.Unlock()
+
+	for _, entry := range h.history {
+		if entry.id <= client.resumeAfter || !h.isSubscribed(client, entry.channels) {
+			continue
+		}
+		if entry.allow != nil && !entry.allow(client.userRole, client.tenant) {
+			continue
+		}
+		client.sendFrame(frame{id: entry.id, data: entry.data})
+	}
+}
+
+// Broadcast sends a message to all connected clients subscribed to its channels.
 func (h *Hub) Broadcast(msg Message) {
 	data, err := json.Marshal(msg)
 	if err != nil {
@@ -132,11 +305,24 @@ func (h *Hub) Broadcast(msg Message) {
 		return
 	}
 
-	h.broadcast <- data
+	h.broadcast <- broadcastMessage{data: data, channels: msg.channels}
+	h.forward(relayedMessage{Data: data, Channels: msg.channels})
 }
 
 // BroadcastToUser sends a message to all connections of a specific user.
 func (h *Hub) BroadcastToUser(userID entity.ID, msg Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal user message")
+		return
+	}
+
+	h.sendToUser(userID, data)
+	h.forward(relayedMessage{Data: data, UserID: &userID})
+}
+
+// sendToUser sends a message to the local connections of a user.
+func (h *Hub) sendToUser(userID entity.ID, data []byte) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -145,12 +331,32 @@ func (h *Hub) BroadcastToUser(userID entity.ID, msg Message) {
 		return
 	}
 
+	for client := range clients {
+		client.Send(data)
+	}
+
+	// Update messages sent metric
+	metrics.WebSocketMessagesSent.Add(float64(len(clients)))
+}
+
+// BroadcastToRole sends a message to all users with a specific role.
+func (h *Hub) BroadcastToRole(role string, msg Message) {
 	data, err := json.Marshal(msg)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to marshal user message")
+		log.Error().Err(err).Msg("Failed to marshal role message")
 		return
 	}
 
+	h.sendToRole(role, data)
+	h.forward(relayedMessage{Data: data, Role: role})
+}
+
+// sendToRole sends a message to the local clients with a role.
+func (h *Hub) sendToRole(role string, data []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	clients := h.roleClients[role]
 	for client := range clients {
 		client.Send(data)
 	}
@@ -159,21 +365,81 @@ func (h *Hub) BroadcastToUser(userID entity.ID, msg Message) {
 	metrics.WebSocketMessagesSent.Add(float64(len(clients)))
 }
 
-// BroadcastToRole sends a message to all users with a specific role.
-func (h *Hub) BroadcastToRole(role string, msg Message) {
+// BroadcastTo sends a message to the clients subscribed to its channels that
+// are in the audience.
+func (h *Hub) BroadcastTo(msg Message, audience Audience) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal filtered message")
+		return
+	}
+
+	h.sendTo(data, msg.channels, audience)
+	h.forward(relayedMessage{Data: data, Channels: msg.channels, Audience: &audience})
+}
+
+// sendTo sends a message to the local clients subscribed to channels that are
+// in the audience, looking them up by team, or else by role.
+func (h *Hub) sendTo(data []byte, channels []string, audience Audience) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
+	id := h.record(data, channels, audience.Allows)
+
+	// The candidate sets are disjoint, so no client is visited twice
+	var candidates []map[*Client]bool
+	switch {
+	case audience.TeamID != nil:
+		candidates = append(candidates, h.teamClients[*audience.TeamID], h.allTeamsClients)
+	case audience.Roles != nil:
+		for _, role := range audience.Roles {
+			candidates = append(candidates, h.roleClients[role])
+		}
+	default:
+		candidates = append(candidates, h.clients)
+	}
+
+	count := 0
+	for _, clients := range candidates {
+		for client := range clients {
+			if h.isSubscribed(client, channels) && audience.Allows(client.userRole, client.tenant) {
+				client.sendFrame(frame{id: id, data: data})
+				count++
+			}
+		}
+	}
+
+	// Update messages sent metric
+	metrics.WebSocketMessagesSent.Add(float64(count))
+}
+
+// BroadcastWhere sends a message to the clients subscribed to its channels
+// whose role and tenant scope pass the allow check. Anonymous clients are
+// checked with an empty role and the zero scope. As the check cannot be
+// relayed, the message only reaches clients of this instance; use BroadcastTo
+// to reach every instance.
+func (h *Hub) BroadcastWhere(msg Message, allow func(role string, tenant valueobject.TenantScope) bool) {
 	data, err := json.Marshal(msg)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to marshal role message")
+		log.Error().Err(err).Msg("Failed to marshal filtered message")
 		return
 	}
 
+	h.sendWhere(data, msg.channels, allow)
+}
+
+// sendWhere sends a message to the local clients subscribed to channels that
+// pass the allow check.
+func (h *Hub) sendWhere(data []byte, channels []string, allow func(role string, tenant valueobject.TenantScope) bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	id := h.record(data, channels, allow)
+
 	count := 0
 	for client := range h.clients {
-		if client.userRole == role {
-			client.Send(data)
+		if h.isSubscribed(client, channels) && allow(client.userRole, client.tenant) {
+			client.sendFrame(frame{id: id, data: data})
 			count++
 		}
 	}
@@ -182,6 +448,74 @@ func (h *Hub) BroadcastToRole(role string, msg Message) {
 	metrics.WebSocketMessagesSent.Add(float64(count))
 }
 
+// Subscribe subscribes a client to a channel its tenant scope allows.
+func (h *Hub) Subscribe(client *Client, channel string) error {
+	if err := h.checkChannel(client, channel); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscribed := h.subscriptions[client]
+	if subscribed == nil {
+		subscribed = make(map[string]bool)
+		h.subscriptions[client] = subscribed
+	}
+	if !subscribed[channel] && len(subscribed) >= maxSubscriptions {
+		return ErrTooManySubscriptions
+	}
+	subscribed[channel] = true
+
+	return nil
+}
+
+// subscribeAll subscribes a client to channels, or to none if any is not allowed.
+func (h *Hub) subscribeAll(client *Client, channels []string) error {
+	if len(channels) > maxSubscriptions {
+		return ErrTooManySubscriptions
+	}
+	for _, channel := range channels {
+		if err := h.checkChannel(client, channel); err != nil {
+			return fmt.Errorf("%w: %s", err, channel)
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscribed := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		subscribed[channel] = true
+	}
+	if len(subscribed) > 0 {
+		h.subscriptions[client] = subscribed
+	}
+
+	return nil
+}
+
+// Unsubscribe removes a client's subscription to a channel.
+// A client left without subscriptions receives every message again.
+func (h *Hub) Unsubscribe(client *Client, channel string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if subscribed, ok := h.subscriptions[client]; ok {
+		delete(subscribed, channel)
+		if len(subscribed) == 0 {
+			delete(h.subscriptions, client)
+		}
+	}
+}
+
+// IsUserOnline reports whether the user has at least one active connection.
+func (h *Hub) IsUserOnline(userID entity.ID) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.userClients[userID]) > 0
+}
+
 // ClientCount returns the number of connected clients.
 func (h *Hub) ClientCount() int {
 	h.mu.RLock()
@@ -189,6 +523,46 @@ func (h *Hub) ClientCount() int {
 	return len(h.clients)
 }
 
+// Shutdown disconnects every client gracefully: each is sent the messages
+// already queued for it and, over WebSocket, a going away close frame. It
+// waits until the clients are gone or ctx is done, and then closes the
+// connections left.
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.cancel()
+
+	h.mu.RLock()
+	clients := make([]*Client, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.RUnlock()
+
+	log.Info().Int("clients", len(clients)).Msg("Disconnecting WebSocket clients...")
+	for _, client := range clients {
+		client.drain()
+	}
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	for h.ClientCount() > 0 {
+		select {
+		case <-ctx.Done():
+			h.mu.RLock()
+			log.Warn().Int("clients", len(h.clients)).Msg("Closing WebSocket clients that did not disconnect in time")
+			for client := range h.clients {
+				client.closeConn()
+			}
+			h.mu.RUnlock()
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	log.Info().Msg("WebSocket clients disconnected")
+	return nil
+}
+
 // Register adds a client to the hub.
 func (h *Hub) Register(client *Client) {
 	h.register <- client
@@ -0,0 +1,42 @@
+package websocket
+
+import (
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// IncidentPublisher publishes incident events to WebSocket clients.
+// Incidents are only sent to authenticated clients, and those owned by a
+// team only to clients whose tenant scope includes it.
+type IncidentPublisher struct {
+	hub *Hub
+}
+
+// NewIncidentPublisher creates a new incident publisher.
+func NewIncidentPublisher(hub *Hub) *IncidentPublisher {
+	return &IncidentPublisher{hub: hub}
+}
+
+// PublishIncidentCreated broadcasts a new incident to all clients.
+func (p *IncidentPublisher) PublishIncidentCreated(incident *entity.Incident) {
+	p.broadcast(incident, NewIncidentCreatedMessage(dto.IncidentFromEntity(incident)))
+}
+
+// PublishIncidentUpdated broadcasts a changed incident to all clients.
+func (p *IncidentPublisher) PublishIncidentUpdated(incident *entity.Incident) {
+	p.broadcast(incident, NewIncidentUpdatedMessage(dto.IncidentFromEntity(incident)))
+}
+
+// PublishIncidentTimeline broadcasts a new entry of the timeline of an
+// incident to all clients.
+func (p *IncidentPublisher) PublishIncidentTimeline(incident *entity.Incident, event *entity.IncidentEvent) {
+	p.broadcast(incident, NewIncidentTimelineMessage(dto.IncidentEventFromEntity(event)))
+}
+
+// broadcast sends an incident message to every client allowed to see the
+// incident and subscribed to one of its channels.
+func (p *IncidentPublisher) broadcast(incident *entity.Incident, msg Message) {
+	msg = msg.OnChannels(IncidentChannels(incident)...)
+
+	p.hub.BroadcastTo(msg, Audience{TeamID: incident.TeamID, Roles: roleNames(alertRoles)})
+}
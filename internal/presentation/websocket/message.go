@@ -15,12 +15,17 @@ const (
 	MessageTypePing        MessageType = "ping"
 	MessageTypeSubscribe   MessageType = "subscribe"
 	MessageTypeUnsubscribe MessageType = "unsubscribe"
+	MessageTypeAcknowledge MessageType = "acknowledge"
+	MessageTypeResolve     MessageType = "resolve"
+	MessageTypeAuth        MessageType = "auth"
 
 	// Server -> Client
-	MessageTypePong         MessageType = "pong"
-	MessageTypeSubscribed   MessageType = "subscribed"
-	MessageTypeUnsubscribed MessageType = "unsubscribed"
-	MessageTypeError        MessageType = "error"
+	MessageTypePong          MessageType = "pong"
+	MessageTypeSubscribed    MessageType = "subscribed"
+	MessageTypeUnsubscribed  MessageType = "unsubscribed"
+	MessageTypeError         MessageType = "error"
+	MessageTypeResult        MessageType = "result"
+	MessageTypeAuthenticated MessageType = "authenticated"
 
 	// Alert events
 	MessageTypeAlertCreated      MessageType = "alert.created"
@@ -28,9 +33,22 @@ const (
 	MessageTypeAlertAcknowledged MessageType = "alert.acknowledged"
 	MessageTypeAlertResolved     MessageType = "alert.resolved"
 	MessageTypeAlertDeleted      MessageType = "alert.deleted"
+	MessageTypeAlertRestored     MessageType = "alert.restored"
+
+	// Incident events
+	MessageTypeIncidentCreated  MessageType = "incident.created"
+	MessageTypeIncidentUpdated  MessageType = "incident.updated"
+	MessageTypeIncidentTimeline MessageType = "incident.timeline"
+
+	// Presence
+	MessageTypePresenceChanged MessageType = "presence.changed"
 
 	// Statistics
 	MessageTypeStatsUpdate MessageType = "stats.update"
+
+	// User-addressed events
+	MessageTypeUserEvent MessageType = "user.event"
+	MessageTypeDigest    MessageType = "digest"
 )
 
 // Message represents a WebSocket message.
@@ -39,6 +57,22 @@ type Message struct {
 	Channel   string      `json:"channel,omitempty"`
 	Payload   interface{} `json:"payload,omitempty"`
 	Timestamp time.Time   `json:"timestamp"`
+
+	// Commands: the alert to change, and an ID the client correlates the result with
+	AlertID   string `json:"alert_id,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+
+	// Auth: the refreshed access token
+	Token string `json:"token,omitempty"`
+
+	// channels routes a broadcast to the clients subscribed to any of them.
+	channels []string
+}
+
+// OnChannels returns a copy of the message published to the given channels.
+func (m Message) OnChannels(channels ...string) Message {
+	m.channels = channels
+	return m
 }
 
 // NewAlertCreatedMessage creates a new alert created message.
@@ -88,6 +122,52 @@ func NewAlertDeletedMessage(alertID string) Message {
 	}
 }
 
+// NewAlertRestoredMessage creates a new alert restored message.
+func NewAlertRestoredMessage(alert dto.AlertResponse) Message {
+	return Message{
+		Type:      MessageTypeAlertRestored,
+		Payload:   alert,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// NewIncidentCreatedMessage creates a new incident created message.
+func NewIncidentCreatedMessage(incident dto.IncidentResponse) Message {
+	return Message{
+		Type:      MessageTypeIncidentCreated,
+		Payload:   incident,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// NewIncidentUpdatedMessage creates a new incident updated message.
+func NewIncidentUpdatedMessage(incident dto.IncidentResponse) Message {
+	return Message{
+		Type:      MessageTypeIncidentUpdated,
+		Payload:   incident,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// NewIncidentTimelineMessage creates a message for a new entry of the
+// timeline of an incident.
+func NewIncidentTimelineMessage(event dto.IncidentEventResponse) Message {
+	return Message{
+		Type:      MessageTypeIncidentTimeline,
+		Payload:   event,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// NewPresenceChangedMessage creates a message for a user connecting or disconnecting.
+func NewPresenceChangedMessage(presence dto.PresenceEvent) Message {
+	return Message{
+		Type:      MessageTypePresenceChanged,
+		Payload:   presence,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
 // NewStatsUpdateMessage creates a new statistics update message.
 func NewStatsUpdateMessage(stats dto.AlertStatisticsResponse) Message {
 	return Message{
@@ -97,6 +177,43 @@ func NewStatsUpdateMessage(stats dto.AlertStatisticsResponse) Message {
 	}
 }
 
+// NewUserEventMessage creates a message for an event addressed to a single user.
+func NewUserEventMessage(item dto.DigestItem) Message {
+	return Message{
+		Type:      MessageTypeUserEvent,
+		Payload:   item,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// NewDigestMessage creates a "while you were away" digest message.
+func NewDigestMessage(digest dto.DigestResponse) Message {
+	return Message{
+		Type:      MessageTypeDigest,
+		Payload:   digest,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// CommandResult is the outcome of a command sent by a client.
+type CommandResult struct {
+	RequestID string             `json:"request_id,omitempty"`
+	Command   MessageType        `json:"command"`
+	Success   bool               `json:"success"`
+	Alert     *dto.AlertResponse `json:"alert,omitempty"`
+	Error     string             `json:"error,omitempty"`
+	Code      string             `json:"code,omitempty"`
+}
+
+// NewResultMessage creates a command result message.
+func NewResultMessage(result CommandResult) Message {
+	return Message{
+		Type:      MessageTypeResult,
+		Payload:   result,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
 // NewErrorMessage creates a new error message.
 func NewErrorMessage(err string) Message {
 	return Message{
@@ -0,0 +1,57 @@
+package websocket
+
+import (
+	"sort"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// Presence statuses.
+const (
+	PresenceOnline  = "online"
+	PresenceOffline = "offline"
+)
+
+// presenceRoles are the roles presence changes are broadcast to.
+var presenceRoles = []string{string(entity.UserRoleAdmin), string(entity.UserRoleOperator)}
+
+// Presence returns the users connected to this instance, those connected the
+// longest first.
+func (h *Hub) Presence() []dto.PresenceResponse {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	presence := make([]dto.PresenceResponse, 0, len(h.userClients))
+	for userID, clients := range h.userClients {
+		entry := dto.PresenceResponse{
+			UserID:      userID.String(),
+			Connections: len(clients),
+		}
+		for client := range clients {
+			entry.Role = client.userRole
+			if entry.Since.IsZero() || client.connectedAt.Before(entry.Since) {
+				entry.Since = client.connectedAt
+			}
+		}
+		presence = append(presence, entry)
+	}
+
+	sort.Slice(presence, func(i, j int) bool {
+		return presence[i].Since.Before(presence[j].Since)
+	})
+
+	return presence
+}
+
+// publishPresence tells operators and admins that a user came online or went
+// offline. It must not be called with h.mu held.
+func (h *Hub) publishPresence(client *Client, status string) {
+	msg := NewPresenceChangedMessage(dto.PresenceEvent{
+		UserID: client.userID.String(),
+		Role:   client.role(),
+		Status: status,
+	}).OnChannels(ChannelPresence)
+
+	h.BroadcastTo(msg, Audience{Roles: presenceRoles})
+}
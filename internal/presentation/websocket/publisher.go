@@ -3,40 +3,111 @@ package websocket
 import (
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 )
 
 // AlertPublisher publishes alert events to WebSocket clients.
+// Alert messages are truncated to keep broadcasts small; clients fetch
+// the full text from the alert detail endpoint. Alerts are only sent to
+// authenticated clients: those restricted by the visibility policy only to
+// clients whose role may see them, and those owned by a team only to clients
+// whose tenant scope includes it.
 type AlertPublisher struct {
-	hub *Hub
+	hub              *Hub
+	messageMaxLength int
+	visibility       valueobject.VisibilityPolicy
+	views            ViewMatcher
+}
+
+// ViewMatcher finds the saved views an alert belongs to, whose channels
+// the alert is published to as well.
+type ViewMatcher interface {
+	MatchingViews(alert *entity.Alert) []entity.ID
 }
 
 // NewAlertPublisher creates a new alert publisher.
-func NewAlertPublisher(hub *Hub) *AlertPublisher {
+// A messageMaxLength of zero or less disables truncation.
+func NewAlertPublisher(hub *Hub, messageMaxLength int) *AlertPublisher {
 	return &AlertPublisher{
-		hub: hub,
+		hub:              hub,
+		messageMaxLength: messageMaxLength,
 	}
 }
 
+// SetVisibilityPolicy sets the policy restricting which roles receive which alerts.
+func (p *AlertPublisher) SetVisibilityPolicy(policy valueobject.VisibilityPolicy) {
+	p.visibility = policy
+}
+
+// SetViewMatcher publishes alerts to the channels of the views they belong to.
+func (p *AlertPublisher) SetViewMatcher(views ViewMatcher) {
+	p.views = views
+}
+
 // PublishAlertCreated broadcasts a new alert to all clients.
 func (p *AlertPublisher) PublishAlertCreated(alert *entity.Alert) {
-	msg := NewAlertCreatedMessage(dto.AlertFromEntity(alert))
-	p.hub.Broadcast(msg)
+	msg := NewAlertCreatedMessage(dto.AlertSummaryFromEntity(alert, p.messageMaxLength))
+	p.broadcast(alert, msg)
 }
 
 // PublishAlertAcknowledged broadcasts an acknowledged alert to all clients.
 func (p *AlertPublisher) PublishAlertAcknowledged(alert *entity.Alert) {
-	msg := NewAlertAcknowledgedMessage(dto.AlertFromEntity(alert))
-	p.hub.Broadcast(msg)
+	msg := NewAlertAcknowledgedMessage(dto.AlertSummaryFromEntity(alert, p.messageMaxLength))
+	p.broadcast(alert, msg)
 }
 
 // PublishAlertResolved broadcasts a resolved alert to all clients.
 func (p *AlertPublisher) PublishAlertResolved(alert *entity.Alert) {
-	msg := NewAlertResolvedMessage(dto.AlertFromEntity(alert))
-	p.hub.Broadcast(msg)
+	msg := NewAlertResolvedMessage(dto.AlertSummaryFromEntity(alert, p.messageMaxLength))
+	p.broadcast(alert, msg)
 }
 
-// PublishAlertDeleted broadcasts a deleted alert to all clients.
+// PublishAlertDeleted broadcasts a deleted alert to all authenticated clients.
+// Only the alerts channel carries it, as the deleted alert is no longer known.
 func (p *AlertPublisher) PublishAlertDeleted(alertID string) {
-	msg := NewAlertDeletedMessage(alertID)
-	p.hub.Broadcast(msg)
+	msg := NewAlertDeletedMessage(alertID).OnChannels(ChannelAlerts)
+	p.hub.BroadcastTo(msg, Audience{Roles: roleNames(alertRoles)})
+}
+
+// PublishAlertRestored broadcasts an alert taken out of the trash to all clients.
+func (p *AlertPublisher) PublishAlertRestored(alert *entity.Alert) {
+	msg := NewAlertRestoredMessage(dto.AlertSummaryFromEntity(alert, p.messageMaxLength))
+	p.broadcast(alert, msg)
+}
+
+// broadcast sends an alert message to every client allowed to see the alert
+// and subscribed to one of its channels.
+func (p *AlertPublisher) broadcast(alert *entity.Alert, msg Message) {
+	channels := AlertChannels(alert)
+	if p.views != nil {
+		for _, viewID := range p.views.MatchingViews(alert) {
+			channels = append(channels, ViewChannel(viewID))
+		}
+	}
+	msg = msg.OnChannels(channels...)
+
+	p.hub.BroadcastTo(msg, p.audience(alert))
+}
+
+// audience returns the clients allowed to see an alert.
+func (p *AlertPublisher) audience(alert *entity.Alert) Audience {
+	roles := make([]entity.UserRole, 0, len(alertRoles))
+	for _, role := range alertRoles {
+		if p.visibility.CanView(alert, role) {
+			roles = append(roles, role)
+		}
+	}
+	return Audience{TeamID: alert.TeamID, Roles: roleNames(roles)}
+}
+
+// alertRoles are the roles alerts can be broadcast to.
+var alertRoles = []entity.UserRole{entity.UserRoleAdmin, entity.UserRoleOperator, entity.UserRoleViewer}
+
+// roleNames converts roles to the names clients are indexed by.
+func roleNames(roles []entity.UserRole) []string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+	return names
 }
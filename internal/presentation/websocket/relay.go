@@ -0,0 +1,119 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// relayTimeout bounds the time a broadcast waits for the relay.
+const relayTimeout = 2 * time.Second
+
+// Relay carries hub broadcasts between the instances of the API, so that
+// clients receive them whichever instance they are connected to. A relay must
+// not deliver an instance's own payloads back to it.
+type Relay interface {
+	// Publish sends a payload to the other instances.
+	Publish(ctx context.Context, payload []byte) error
+
+	// Subscribe calls handler with the payloads published by other instances.
+	Subscribe(ctx context.Context, handler func(payload []byte)) error
+}
+
+// Audience restricts a broadcast by role and tenant scope. Unlike the check
+// of BroadcastWhere it is relayed along with the message.
+type Audience struct {
+	// TeamID restricts the broadcast to clients whose tenant scope includes the team.
+	TeamID *entity.ID `json:"team_id,omitempty"`
+	// Roles restricts the broadcast to clients with one of these roles; nil allows every client.
+	Roles []string `json:"roles"`
+}
+
+// Allows reports whether a client with the given role and tenant scope is in the audience.
+func (a Audience) Allows(role string, tenant valueobject.TenantScope) bool {
+	if !tenant.Allows(a.TeamID) {
+		return false
+	}
+	if a.Roles == nil {
+		return true
+	}
+	for _, allowed := range a.Roles {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// relayedMessage is a broadcast as relayed to other instances. Exactly one
+// of the broadcast, audience, user or role targets is used.
+type relayedMessage struct {
+	Data     json.RawMessage `json:"data"`
+	Channels []string        `json:"channels,omitempty"`
+	Audience *Audience       `json:"audience,omitempty"`
+	UserID   *entity.ID      `json:"user_id,omitempty"`
+	Role     string          `json:"role,omitempty"`
+}
+
+// SetRelay relays the hub's broadcasts to, and receives them from, the other
+// instances through relay.
+func (h *Hub) SetRelay(ctx context.Context, relay Relay) error {
+	if err := relay.Subscribe(ctx, h.receive); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	h.relay = relay
+	h.mu.Unlock()
+
+	return nil
+}
+
+// forward relays a broadcast made on this instance.
+func (h *Hub) forward(msg relayedMessage) {
+	h.mu.RLock()
+	relay := h.relay
+	h.mu.RUnlock()
+
+	if relay == nil {
+		return
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal relayed message")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), relayTimeout)
+	defer cancel()
+
+	if err := relay.Publish(ctx, payload); err != nil {
+		log.Error().Err(err).Msg("Failed to relay broadcast")
+	}
+}
+
+// receive delivers a broadcast relayed from another instance to the local clients.
+func (h *Hub) receive(payload []byte) {
+	var msg relayedMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		log.Warn().Err(err).Msg("Failed to parse relayed message")
+		return
+	}
+
+	switch {
+	case msg.UserID != nil:
+		h.sendToUser(*msg.UserID, msg.Data)
+	case msg.Role != "":
+		h.sendToRole(msg.Role, msg.Data)
+	case msg.Audience != nil:
+		h.sendTo(msg.Data, msg.Channels, *msg.Audience)
+	default:
+		h.broadcast <- broadcastMessage{data: msg.Data, channels: msg.Channels}
+	}
+}
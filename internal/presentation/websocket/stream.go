@@ -0,0 +1,177 @@
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/helper"
+)
+
+// LastEventIDHeader is the header an EventSource sends when it reconnects.
+const LastEventIDHeader = "Last-Event-ID"
+
+// streamRetry is the reconnection delay advised to stream clients.
+const streamRetry = 3 * time.Second
+
+// Stream serves the hub's messages as Server-Sent Events, for clients that
+// cannot open a WebSocket. Clients subscribe with a comma-separated channels
+// query parameter, and a reconnecting client receives the broadcasts it missed
+// after its Last-Event-ID (header, or last_event_id query parameter) while
+// they are still in the hub's history.
+func (h *Handler) Stream(c *fiber.Ctx) error {
+	var userID *entity.ID
+	var userRole string
+
+	if id, ok := c.Locals("userID").(entity.ID); ok {
+		userID = &id
+	}
+	if role, ok := c.Locals("userRole").(string); ok {
+		userRole = role
+	}
+
+	client := NewStreamClient(h.hub, userID, userRole)
+	if tenant, ok := c.Locals("tenant").(valueobject.TenantScope); ok {
+		client.SetTenant(tenant)
+	}
+	// Streams cannot refresh their token; they reconnect with a new one
+	if token, ok := c.Locals("accessToken").(string); ok {
+		expiresAt, _ := c.Locals("tokenExpiresAt").(time.Time)
+		client.setCredentials(token, expiresAt)
+	}
+
+	lastEventID := c.Get(LastEventIDHeader, c.Query("last_event_id"))
+	if lastEventID != "" {
+		resumeAfter, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return helper.BadRequest(c, "Invalid last event ID")
+		}
+		client.resumeAfter = resumeAfter
+	}
+
+	if err := h.hub.subscribeAll(client, streamChannels(c.Query("channels"))); err != nil {
+		return helper.BadRequest(c, err.Error())
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	h.hub.Register(client)
+
+	log.Debug().
+		Bool("authenticated", userID != nil).
+		Str("role", userRole).
+		Msg("New event stream connection")
+
+	if userID != nil {
+		h.sendDigest(client, *userID)
+	}
+
+	conn := c.Context().Conn()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ticker := time.NewTicker(pingPeriod)
+		defer func() {
+			ticker.Stop()
+			h.hub.Unregister(client)
+			client.Close()
+		}()
+
+		// Send the headers right away and tell the client how soon to reconnect
+		_, _ = fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+		_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case f, ok := <-client.send:
+				if !ok {
+					return
+				}
+				if f.id > 0 {
+					_, _ = fmt.Fprintf(w, "id: %d\n", f.id)
+				}
+				_, _ = fmt.Fprintf(w, "data: %s\n\n", f.data)
+
+			case <-ticker.C:
+				_, _ = w.WriteString(": ping\n\n")
+			}
+
+			// The server write timeout would otherwise end long-lived streams
+			_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// Listen receives the hub's messages like a client with the given identity
+// subscribed to channels, until ctx is done. It is used by other transports,
+// such as GraphQL subscriptions, to follow the hub's broadcasts.
+func (h *Hub) Listen(
+	ctx context.Context,
+	userID *entity.ID,
+	role string,
+	tenant valueobject.TenantScope,
+	channels []string,
+) (<-chan []byte, error) {
+	client := NewStreamClient(h, userID, role)
+	client.SetTenant(tenant)
+
+	if err := h.subscribeAll(client, channels); err != nil {
+		return nil, err
+	}
+	h.Register(client)
+
+	messages := make(chan []byte)
+	go func() {
+		defer func() {
+			h.Unregister(client)
+			client.Close()
+			close(messages)
+		}()
+
+		for {
+			select {
+			case f, ok := <-client.send:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- f.data:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+// streamChannels parses the channels query parameter of a stream.
+func streamChannels(query string) []string {
+	var channels []string
+	for _, channel := range strings.Split(query, ",") {
+		if channel = strings.TrimSpace(channel); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
@@ -0,0 +1,46 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+// DigestRecorder stores events for users who are offline.
+type DigestRecorder interface {
+	Record(ctx context.Context, userID entity.ID, item dto.DigestItem) error
+}
+
+// UserPublisher delivers events addressed to a single user.
+// When the user has no active connection the event is kept for their digest.
+type UserPublisher struct {
+	hub      *Hub
+	recorder DigestRecorder
+}
+
+// NewUserPublisher creates a new user publisher.
+func NewUserPublisher(hub *Hub, recorder DigestRecorder) *UserPublisher {
+	return &UserPublisher{
+		hub:      hub,
+		recorder: recorder,
+	}
+}
+
+// PublishToUser sends an event to the user, or records it if they are offline.
+func (p *UserPublisher) PublishToUser(ctx context.Context, userID entity.ID, item dto.DigestItem) {
+	if p.hub.IsUserOnline(userID) {
+		p.hub.BroadcastToUser(userID, NewUserEventMessage(item))
+		return
+	}
+
+	if p.recorder == nil {
+		return
+	}
+
+	if err := p.recorder.Record(ctx, userID, item); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to record missed event")
+	}
+}
@@ -0,0 +1,7 @@
+-- Rollback: Remove deployment origin from alerts
+
+DROP INDEX IF EXISTS idx_alerts_region;
+
+ALTER TABLE alerts
+    DROP COLUMN IF EXISTS cluster,
+    DROP COLUMN IF EXISTS region;
@@ -0,0 +1,9 @@
+-- Migration: Add deployment origin to alerts
+-- Description: Region and cluster of the instance that produced each alert
+
+ALTER TABLE alerts
+    ADD COLUMN region VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN cluster VARCHAR(64) NOT NULL DEFAULT '';
+
+-- Create indexes for region-scoped queries
+CREATE INDEX idx_alerts_region ON alerts(region);
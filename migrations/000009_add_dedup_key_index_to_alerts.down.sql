@@ -0,0 +1,3 @@
+-- Rollback: Remove alert deduplication key index
+
+DROP INDEX IF EXISTS idx_alerts_dedup_key;
@@ -0,0 +1,5 @@
+-- Migration: Index alert deduplication keys
+-- Description: Fast lookup of open alerts by metadata dedup_key for webhook integrations
+
+CREATE INDEX idx_alerts_dedup_key ON alerts((metadata->>'dedup_key'))
+    WHERE status IN ('active', 'acknowledged');
@@ -0,0 +1,4 @@
+-- Rollback: Drop webhook_sources table
+
+DROP TRIGGER IF EXISTS update_webhook_sources_updated_at ON webhook_sources;
+DROP TABLE IF EXISTS webhook_sources;
@@ -0,0 +1,19 @@
+-- Migration: Create webhook_sources table
+-- Description: External systems pushing alerts through tokenized inbound webhooks
+
+CREATE TABLE IF NOT EXISTS webhook_sources (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    mapping JSONB NOT NULL,
+    is_enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_webhook_sources_updated_at
+    BEFORE UPDATE ON webhook_sources
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback: Drop webhook_secrets table
+
+DROP TRIGGER IF EXISTS update_webhook_secrets_updated_at ON webhook_secrets;
+DROP TABLE IF EXISTS webhook_secrets;
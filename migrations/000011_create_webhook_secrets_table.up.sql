@@ -0,0 +1,17 @@
+-- Migration: Create webhook_secrets table
+-- Description: Per-integration shared secrets used to verify signed webhook payloads
+
+CREATE TABLE IF NOT EXISTS webhook_secrets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    integration VARCHAR(50) NOT NULL UNIQUE,
+    secret VARCHAR(256) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_webhook_secrets_updated_at
+    BEFORE UPDATE ON webhook_secrets
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
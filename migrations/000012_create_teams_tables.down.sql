@@ -0,0 +1,13 @@
+-- Rollback: Drop teams and team ownership
+
+DROP INDEX IF EXISTS idx_notification_channels_team_id;
+DROP INDEX IF EXISTS idx_alert_rules_team_id;
+DROP INDEX IF EXISTS idx_alerts_team_id;
+
+ALTER TABLE notification_channels DROP COLUMN IF EXISTS team_id;
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS team_id;
+ALTER TABLE alerts DROP COLUMN IF EXISTS team_id;
+
+DROP TRIGGER IF EXISTS update_teams_updated_at ON teams;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
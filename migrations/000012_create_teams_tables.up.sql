@@ -0,0 +1,43 @@
+-- Migration: Create teams and team ownership
+-- Description: Teams are tenants owning alerts, rules and notification channels.
+-- Rows without a team are shared with every team.
+
+CREATE TABLE IF NOT EXISTS teams (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'member')),
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+-- Create indexes for membership lookups by user
+CREATE INDEX idx_team_members_user_id ON team_members(user_id);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_teams_updated_at
+    BEFORE UPDATE ON teams
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Teams owning data cannot be deleted until it is reassigned or removed
+ALTER TABLE alerts
+    ADD COLUMN team_id UUID REFERENCES teams(id) ON DELETE RESTRICT;
+
+ALTER TABLE alert_rules
+    ADD COLUMN team_id UUID REFERENCES teams(id) ON DELETE RESTRICT;
+
+ALTER TABLE notification_channels
+    ADD COLUMN team_id UUID REFERENCES teams(id) ON DELETE RESTRICT;
+
+-- Create indexes for tenant-scoped queries
+CREATE INDEX idx_alerts_team_id ON alerts(team_id);
+CREATE INDEX idx_alert_rules_team_id ON alert_rules(team_id);
+CREATE INDEX idx_notification_channels_team_id ON notification_channels(team_id);
@@ -0,0 +1,4 @@
+-- Rollback: Drop notification_templates table
+
+DROP TRIGGER IF EXISTS update_notification_templates_updated_at ON notification_templates;
+DROP TABLE IF EXISTS notification_templates;
@@ -0,0 +1,18 @@
+-- Migration: Create notification_templates table
+-- Description: Per channel type text/template overrides of notification content
+
+CREATE TABLE IF NOT EXISTS notification_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    channel VARCHAR(50) NOT NULL UNIQUE CHECK (channel IN ('slack', 'email', 'sms', 'webhook')),
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_notification_templates_updated_at
+    BEFORE UPDATE ON notification_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
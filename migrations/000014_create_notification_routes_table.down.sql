@@ -0,0 +1,4 @@
+-- Rollback: Drop notification_routes table
+
+DROP TRIGGER IF EXISTS update_notification_routes_updated_at ON notification_routes;
+DROP TABLE IF EXISTS notification_routes;
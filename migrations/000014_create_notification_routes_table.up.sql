@@ -0,0 +1,23 @@
+-- Migration: Create notification_routes table
+-- Description: Routing tree deciding which channels receive each notification
+
+CREATE TABLE IF NOT EXISTS notification_routes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    parent_id UUID REFERENCES notification_routes(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    match JSONB NOT NULL DEFAULT '{}',
+    channels JSONB NOT NULL DEFAULT '[]',
+    continue BOOLEAN NOT NULL DEFAULT false,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_routes_parent_id ON notification_routes(parent_id);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_notification_routes_updated_at
+    BEFORE UPDATE ON notification_routes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
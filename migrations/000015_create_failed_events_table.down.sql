@@ -0,0 +1,3 @@
+-- Rollback: Drop failed_events table
+
+DROP TABLE IF EXISTS failed_events;
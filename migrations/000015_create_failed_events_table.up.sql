@@ -0,0 +1,19 @@
+-- Migration: Create failed_events table
+-- Description: Events moved to the dead letter queue, kept for analysis and manual retry
+
+CREATE TABLE IF NOT EXISTS failed_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id VARCHAR(64) NOT NULL UNIQUE,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    retries INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'retried', 'ignored')),
+    failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_failed_events_status_failed_at ON failed_events(status, failed_at DESC);
+CREATE INDEX idx_failed_events_event_type ON failed_events(event_type);
+CREATE INDEX idx_failed_events_failed_at ON failed_events(failed_at DESC);
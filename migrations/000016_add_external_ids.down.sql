@@ -0,0 +1,7 @@
+-- Rollback: Drop external IDs
+
+DROP INDEX IF EXISTS idx_notification_routes_external_id;
+DROP INDEX IF EXISTS idx_alert_rules_external_id;
+
+ALTER TABLE notification_routes DROP COLUMN IF EXISTS external_id;
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS external_id;
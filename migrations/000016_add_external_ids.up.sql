@@ -0,0 +1,9 @@
+-- Migration: Add external IDs
+-- Description: Identifiers assigned by infrastructure-as-code tools, used to upsert rules and routes
+
+ALTER TABLE alert_rules ADD COLUMN external_id VARCHAR(255);
+ALTER TABLE notification_routes ADD COLUMN external_id VARCHAR(255);
+
+-- Unique when set; rows without an external ID are not constrained
+CREATE UNIQUE INDEX idx_alert_rules_external_id ON alert_rules(external_id);
+CREATE UNIQUE INDEX idx_notification_routes_external_id ON notification_routes(external_id);
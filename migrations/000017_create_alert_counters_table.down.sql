@@ -0,0 +1,10 @@
+-- Rollback: Drop alert counters table
+
+DROP TRIGGER IF EXISTS reset_alert_counters_on_truncate ON alerts;
+DROP TRIGGER IF EXISTS update_alert_counters_on_update ON alerts;
+DROP TRIGGER IF EXISTS update_alert_counters_on_insert_delete ON alerts;
+
+DROP FUNCTION IF EXISTS reset_alert_counters();
+DROP FUNCTION IF EXISTS update_alert_counters();
+
+DROP TABLE IF EXISTS alert_counters;
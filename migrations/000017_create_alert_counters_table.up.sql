@@ -0,0 +1,70 @@
+-- Migration: Create alert counters table
+-- Description: Alert counts per team, status, severity and source, kept up to date by triggers
+-- so that statistics do not scan the alerts table
+
+CREATE TABLE IF NOT EXISTS alert_counters (
+    team_id UUID,
+    status alert_status NOT NULL,
+    severity alert_severity NOT NULL,
+    source VARCHAR(255) NOT NULL DEFAULT '',
+    count BIGINT NOT NULL DEFAULT 0,
+    CONSTRAINT alert_counters_key UNIQUE NULLS NOT DISTINCT (team_id, status, severity, source)
+);
+
+-- Move an alert between counters as it is inserted, updated or deleted
+CREATE OR REPLACE FUNCTION update_alert_counters()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE alert_counters
+        SET count = count - 1
+        WHERE team_id IS NOT DISTINCT FROM OLD.team_id
+          AND status = OLD.status
+          AND severity = OLD.severity
+          AND source = COALESCE(OLD.source, '');
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        INSERT INTO alert_counters (team_id, status, severity, source, count)
+        VALUES (NEW.team_id, NEW.status, NEW.severity, COALESCE(NEW.source, ''), 1)
+        ON CONFLICT ON CONSTRAINT alert_counters_key
+        DO UPDATE SET count = alert_counters.count + 1;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE FUNCTION reset_alert_counters()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM alert_counters;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER update_alert_counters_on_insert_delete
+    AFTER INSERT OR DELETE ON alerts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_alert_counters();
+
+-- Most updates (timestamps, metadata) do not move an alert between counters
+CREATE TRIGGER update_alert_counters_on_update
+    AFTER UPDATE ON alerts
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status
+       OR OLD.severity IS DISTINCT FROM NEW.severity
+       OR OLD.source IS DISTINCT FROM NEW.source
+       OR OLD.team_id IS DISTINCT FROM NEW.team_id)
+    EXECUTE FUNCTION update_alert_counters();
+
+CREATE TRIGGER reset_alert_counters_on_truncate
+    AFTER TRUNCATE ON alerts
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION reset_alert_counters();
+
+-- Count the existing alerts
+INSERT INTO alert_counters (team_id, status, severity, source, count)
+SELECT team_id, status, severity, COALESCE(source, ''), COUNT(*)
+FROM alerts
+GROUP BY team_id, status, severity, COALESCE(source, '');
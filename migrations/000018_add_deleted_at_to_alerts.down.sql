@@ -0,0 +1,42 @@
+-- Rollback: Drop soft delete from alerts
+-- Alerts in the trash are deleted for good
+
+DELETE FROM alerts WHERE deleted_at IS NOT NULL;
+
+DROP TRIGGER IF EXISTS update_alert_counters_on_update ON alerts;
+
+CREATE TRIGGER update_alert_counters_on_update
+    AFTER UPDATE ON alerts
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status
+       OR OLD.severity IS DISTINCT FROM NEW.severity
+       OR OLD.source IS DISTINCT FROM NEW.source
+       OR OLD.team_id IS DISTINCT FROM NEW.team_id)
+    EXECUTE FUNCTION update_alert_counters();
+
+CREATE OR REPLACE FUNCTION update_alert_counters()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE alert_counters
+        SET count = count - 1
+        WHERE team_id IS NOT DISTINCT FROM OLD.team_id
+          AND status = OLD.status
+          AND severity = OLD.severity
+          AND source = COALESCE(OLD.source, '');
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        INSERT INTO alert_counters (team_id, status, severity, source, count)
+        VALUES (NEW.team_id, NEW.status, NEW.severity, COALESCE(NEW.source, ''), 1)
+        ON CONFLICT ON CONSTRAINT alert_counters_key
+        DO UPDATE SET count = alert_counters.count + 1;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+DROP INDEX IF EXISTS idx_alerts_deleted_at;
+
+ALTER TABLE alerts DROP COLUMN IF EXISTS deleted_at;
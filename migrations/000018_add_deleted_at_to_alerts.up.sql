@@ -0,0 +1,43 @@
+-- Migration: Add soft delete to alerts
+-- Description: Deleted alerts are kept in a trash, from which they can be restored, until purged
+
+ALTER TABLE alerts ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Trash listing and purge
+CREATE INDEX idx_alerts_deleted_at ON alerts(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Alert counters only count alerts not in the trash
+CREATE OR REPLACE FUNCTION update_alert_counters()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.deleted_at IS NULL THEN
+        UPDATE alert_counters
+        SET count = count - 1
+        WHERE team_id IS NOT DISTINCT FROM OLD.team_id
+          AND status = OLD.status
+          AND severity = OLD.severity
+          AND source = COALESCE(OLD.source, '');
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.deleted_at IS NULL THEN
+        INSERT INTO alert_counters (team_id, status, severity, source, count)
+        VALUES (NEW.team_id, NEW.status, NEW.severity, COALESCE(NEW.source, ''), 1)
+        ON CONFLICT ON CONSTRAINT alert_counters_key
+        DO UPDATE SET count = alert_counters.count + 1;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS update_alert_counters_on_update ON alerts;
+
+CREATE TRIGGER update_alert_counters_on_update
+    AFTER UPDATE ON alerts
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status
+       OR OLD.severity IS DISTINCT FROM NEW.severity
+       OR OLD.source IS DISTINCT FROM NEW.source
+       OR OLD.team_id IS DISTINCT FROM NEW.team_id
+       OR OLD.deleted_at IS DISTINCT FROM NEW.deleted_at)
+    EXECUTE FUNCTION update_alert_counters();
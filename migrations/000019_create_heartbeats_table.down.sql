@@ -0,0 +1,5 @@
+-- Rollback: Drop heartbeats table
+
+DROP TRIGGER IF EXISTS update_heartbeats_updated_at ON heartbeats;
+DROP INDEX IF EXISTS idx_heartbeats_running;
+DROP TABLE IF EXISTS heartbeats;
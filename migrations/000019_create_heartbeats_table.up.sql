@@ -0,0 +1,27 @@
+-- Migration: Create heartbeats table
+-- Description: Dead man's switches pinged by scheduled jobs; a missed ping raises an alert
+
+CREATE TABLE IF NOT EXISTS heartbeats (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    interval_seconds INTEGER NOT NULL CHECK (interval_seconds > 0),
+    grace_seconds INTEGER NOT NULL DEFAULT 0 CHECK (grace_seconds >= 0),
+    severity VARCHAR(20) NOT NULL DEFAULT 'high',
+    status VARCHAR(20) NOT NULL DEFAULT 'new' CHECK (status IN ('new', 'up', 'down')),
+    last_ping_at TIMESTAMP WITH TIME ZONE,
+    is_enabled BOOLEAN NOT NULL DEFAULT true,
+    team_id UUID REFERENCES teams(id) ON DELETE RESTRICT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for the overdue check, which only looks at running heartbeats
+CREATE INDEX idx_heartbeats_running ON heartbeats(last_ping_at) WHERE status = 'up' AND is_enabled;
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_heartbeats_updated_at
+    BEFORE UPDATE ON heartbeats
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- Rollback: Drop checks tables
+
+DROP INDEX IF EXISTS idx_check_results_checked_at;
+DROP INDEX IF EXISTS idx_check_results_check_checked_at;
+DROP TABLE IF EXISTS check_results;
+
+DROP TRIGGER IF EXISTS update_checks_updated_at ON checks;
+DROP INDEX IF EXISTS idx_checks_enabled;
+DROP TABLE IF EXISTS checks;
@@ -0,0 +1,45 @@
+-- Migration: Create checks tables
+-- Description: Synthetic HTTP uptime checks and the history of their probes
+
+CREATE TABLE IF NOT EXISTS checks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    url TEXT NOT NULL,
+    method VARCHAR(10) NOT NULL DEFAULT 'GET',
+    expected_status INTEGER NOT NULL DEFAULT 200 CHECK (expected_status BETWEEN 100 AND 599),
+    interval_seconds INTEGER NOT NULL CHECK (interval_seconds > 0),
+    timeout_seconds INTEGER NOT NULL CHECK (timeout_seconds > 0),
+    failure_threshold INTEGER NOT NULL DEFAULT 1 CHECK (failure_threshold > 0),
+    severity VARCHAR(20) NOT NULL DEFAULT 'high',
+    status VARCHAR(20) NOT NULL DEFAULT 'unknown' CHECK (status IN ('unknown', 'up', 'down')),
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    last_checked_at TIMESTAMP WITH TIME ZONE,
+    is_enabled BOOLEAN NOT NULL DEFAULT true,
+    team_id UUID REFERENCES teams(id) ON DELETE RESTRICT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for the scheduler, which only looks at enabled checks
+CREATE INDEX idx_checks_enabled ON checks(last_checked_at NULLS FIRST) WHERE is_enabled;
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_checks_updated_at
+    BEFORE UPDATE ON checks
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS check_results (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    check_id UUID NOT NULL REFERENCES checks(id) ON DELETE CASCADE,
+    success BOOLEAN NOT NULL,
+    status_code INTEGER,
+    latency_ms BIGINT NOT NULL,
+    error TEXT,
+    checked_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create indexes for the history of a check and its retention
+CREATE INDEX idx_check_results_check_checked_at ON check_results(check_id, checked_at DESC);
+CREATE INDEX idx_check_results_checked_at ON check_results(checked_at);
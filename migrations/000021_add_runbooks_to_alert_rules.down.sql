@@ -0,0 +1,4 @@
+-- Rollback: Drop runbooks from alert rules
+
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS remediation;
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS runbook_url;
@@ -0,0 +1,5 @@
+-- Migration: Add runbooks to alert rules
+-- Description: The runbook link and remediation steps copied onto the alerts a rule fires
+
+ALTER TABLE alert_rules ADD COLUMN runbook_url TEXT;
+ALTER TABLE alert_rules ADD COLUMN remediation TEXT;
@@ -0,0 +1,10 @@
+-- Rollback: Drop incidents tables
+
+DROP INDEX IF EXISTS idx_incident_events_incident_created_at;
+DROP TABLE IF EXISTS incident_events;
+DROP TABLE IF EXISTS incident_alerts;
+
+DROP TRIGGER IF EXISTS update_incidents_updated_at ON incidents;
+DROP INDEX IF EXISTS idx_incidents_correlation_key;
+DROP INDEX IF EXISTS idx_incidents_status_created_at;
+DROP TABLE IF EXISTS incidents;
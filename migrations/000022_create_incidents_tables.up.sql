@@ -0,0 +1,53 @@
+-- Migration: Create incidents tables
+-- Description: Incidents grouping related alerts, the alerts they group and their timeline
+
+CREATE TABLE IF NOT EXISTS incidents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    title VARCHAR(255) NOT NULL,
+    summary TEXT,
+    severity VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'mitigated', 'closed')),
+    commander_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    team_id UUID REFERENCES teams(id) ON DELETE RESTRICT,
+    correlation_key TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    mitigated_at TIMESTAMP WITH TIME ZONE,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for listing incidents by status, newest first
+CREATE INDEX idx_incidents_status_created_at ON incidents(status, created_at DESC);
+
+-- At most one incident that is not closed per correlation key, so that
+-- instances correlating the same alerts concurrently open a single incident
+CREATE UNIQUE INDEX idx_incidents_correlation_key ON incidents(correlation_key)
+    WHERE status <> 'closed' AND correlation_key IS NOT NULL;
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_incidents_updated_at
+    BEFORE UPDATE ON incidents
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- An alert belongs to at most one incident
+CREATE TABLE IF NOT EXISTS incident_alerts (
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    alert_id UUID NOT NULL UNIQUE REFERENCES alerts(id) ON DELETE CASCADE,
+    added_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (incident_id, alert_id)
+);
+
+CREATE TABLE IF NOT EXISTS incident_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    incident_id UUID NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL,
+    message TEXT NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for the timeline of an incident
+CREATE INDEX idx_incident_events_incident_created_at ON incident_events(incident_id, created_at);
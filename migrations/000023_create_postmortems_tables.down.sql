@@ -0,0 +1,8 @@
+-- Rollback: Drop postmortems tables
+
+DROP INDEX IF EXISTS idx_postmortem_alerts_alert_id;
+DROP TABLE IF EXISTS postmortem_alerts;
+
+DROP TRIGGER IF EXISTS update_postmortems_updated_at ON postmortems;
+DROP INDEX IF EXISTS idx_postmortems_created_at;
+DROP TABLE IF EXISTS postmortems;
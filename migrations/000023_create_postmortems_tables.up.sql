@@ -0,0 +1,34 @@
+-- Migration: Create postmortems tables
+-- Description: Postmortem records of incidents and the alerts they cover
+
+CREATE TABLE IF NOT EXISTS postmortems (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    incident_id UUID UNIQUE REFERENCES incidents(id) ON DELETE SET NULL,
+    team_id UUID REFERENCES teams(id) ON DELETE RESTRICT,
+    title VARCHAR(255) NOT NULL,
+    summary TEXT,
+    impact TEXT,
+    root_cause TEXT,
+    action_items JSONB NOT NULL DEFAULT '[]',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for listing postmortems, newest first
+CREATE INDEX idx_postmortems_created_at ON postmortems(created_at DESC);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_postmortems_updated_at
+    BEFORE UPDATE ON postmortems
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS postmortem_alerts (
+    postmortem_id UUID NOT NULL REFERENCES postmortems(id) ON DELETE CASCADE,
+    alert_id UUID NOT NULL REFERENCES alerts(id) ON DELETE CASCADE,
+    PRIMARY KEY (postmortem_id, alert_id)
+);
+
+-- Create index for finding the postmortems of an alert
+CREATE INDEX idx_postmortem_alerts_alert_id ON postmortem_alerts(alert_id);
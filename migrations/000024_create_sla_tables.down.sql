@@ -0,0 +1,7 @@
+-- Rollback: Drop SLA tables
+
+DROP INDEX IF EXISTS idx_sla_breaches_alert_id;
+DROP TABLE IF EXISTS sla_breaches;
+
+DROP TRIGGER IF EXISTS update_sla_policies_updated_at ON sla_policies;
+DROP TABLE IF EXISTS sla_policies;
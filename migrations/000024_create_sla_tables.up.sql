@@ -0,0 +1,38 @@
+-- Migration: Create SLA tables
+-- Description: Response targets for alerts, and the breaches of those targets
+
+CREATE TABLE IF NOT EXISTS sla_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    severity VARCHAR(20),
+    team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+    source VARCHAR(255),
+    ack_within_seconds INTEGER NOT NULL DEFAULT 0 CHECK (ack_within_seconds >= 0),
+    resolve_within_seconds INTEGER NOT NULL DEFAULT 0 CHECK (resolve_within_seconds >= 0),
+    breach_severity VARCHAR(20) NOT NULL DEFAULT 'high',
+    is_enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (ack_within_seconds > 0 OR resolve_within_seconds > 0)
+);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_sla_policies_updated_at
+    BEFORE UPDATE ON sla_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- A target of an alert is breached once; the unique key keeps instances
+-- checking concurrently from raising a breach twice
+CREATE TABLE IF NOT EXISTS sla_breaches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    policy_id UUID NOT NULL REFERENCES sla_policies(id) ON DELETE CASCADE,
+    alert_id UUID NOT NULL REFERENCES alerts(id) ON DELETE CASCADE,
+    target VARCHAR(20) NOT NULL CHECK (target IN ('ack', 'resolve')),
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    breached_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (policy_id, alert_id, target)
+);
+
+CREATE INDEX idx_sla_breaches_alert_id ON sla_breaches(alert_id);
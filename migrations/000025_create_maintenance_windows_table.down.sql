@@ -0,0 +1,5 @@
+-- Rollback: Drop maintenance windows table
+
+DROP TRIGGER IF EXISTS update_maintenance_windows_updated_at ON maintenance_windows;
+DROP INDEX IF EXISTS idx_maintenance_windows_period;
+DROP TABLE IF EXISTS maintenance_windows;
@@ -0,0 +1,25 @@
+-- Migration: Create maintenance windows table
+-- Description: Scheduled maintenance, listed in the maintenance calendar
+
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+    source VARCHAR(255),
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+-- Create index for the calendar, which lists the windows overlapping a period
+CREATE INDEX idx_maintenance_windows_period ON maintenance_windows(ends_at, starts_at);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_maintenance_windows_updated_at
+    BEFORE UPDATE ON maintenance_windows
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback: Drop source dependencies table
+
+DROP INDEX IF EXISTS idx_source_dependencies_depends_on;
+DROP TABLE IF EXISTS source_dependencies;
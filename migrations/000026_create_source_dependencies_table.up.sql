@@ -0,0 +1,15 @@
+-- Migration: Create source dependencies table
+-- Description: Dependency graph between alert sources, to suppress downstream alerts
+
+CREATE TABLE IF NOT EXISTS source_dependencies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source VARCHAR(255) NOT NULL,
+    depends_on VARCHAR(255) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (source, depends_on),
+    CHECK (source <> depends_on)
+);
+
+-- Create index for walking the graph upstream from a source
+CREATE INDEX idx_source_dependencies_depends_on ON source_dependencies(depends_on);
@@ -0,0 +1,4 @@
+-- Rollback: Drop alert fingerprint indexes
+
+DROP INDEX IF EXISTS idx_alerts_source_title;
+DROP INDEX IF EXISTS idx_alerts_dedup_key_created_at;
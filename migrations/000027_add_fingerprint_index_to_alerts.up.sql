@@ -0,0 +1,6 @@
+-- Migration: Index alert fingerprints
+-- Description: Lookup of the previous occurrences and related alerts of an alert
+
+CREATE INDEX idx_alerts_dedup_key_created_at ON alerts((metadata->>'dedup_key'), created_at DESC)
+    WHERE metadata->>'dedup_key' IS NOT NULL;
+CREATE INDEX idx_alerts_source_title ON alerts(source, title);
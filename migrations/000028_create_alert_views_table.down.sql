@@ -0,0 +1,6 @@
+-- Rollback: Drop alert views table
+
+DROP TRIGGER IF EXISTS update_alert_views_updated_at ON alert_views;
+DROP INDEX IF EXISTS idx_alert_views_team_id;
+DROP INDEX IF EXISTS idx_alert_views_owner_id;
+DROP TABLE IF EXISTS alert_views;
@@ -0,0 +1,22 @@
+-- Migration: Create alert views table
+-- Description: Saved alert filters, private to their owner or shared with a team
+
+CREATE TABLE IF NOT EXISTS alert_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    criteria JSONB NOT NULL DEFAULT '{}',
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for listing the views of a user and of their teams
+CREATE INDEX idx_alert_views_owner_id ON alert_views(owner_id);
+CREATE INDEX idx_alert_views_team_id ON alert_views(team_id) WHERE team_id IS NOT NULL;
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_alert_views_updated_at
+    BEFORE UPDATE ON alert_views
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- Rollback: Drop sources table
+
+DROP TRIGGER IF EXISTS update_sources_updated_at ON sources;
+DROP INDEX IF EXISTS idx_alerts_source_created_at;
+DROP TABLE IF EXISTS sources;
@@ -0,0 +1,27 @@
+-- Migration: Create sources table
+-- Description: Registry of the integrations sending alerts; a source with an
+-- expected heartbeat that stops sending alerts raises an alert
+
+CREATE TABLE IF NOT EXISTS sources (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    type VARCHAR(20) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    expected_heartbeat_seconds INTEGER NOT NULL DEFAULT 0 CHECK (expected_heartbeat_seconds >= 0),
+    severity VARCHAR(20) NOT NULL DEFAULT 'high',
+    status VARCHAR(20) NOT NULL DEFAULT 'reporting' CHECK (status IN ('reporting', 'silent')),
+    silent_since TIMESTAMP WITH TIME ZONE,
+    team_id UUID REFERENCES teams(id) ON DELETE RESTRICT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for the last alert time and alert rates of each source
+CREATE INDEX idx_alerts_source_created_at ON alerts(source, created_at DESC);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_sources_updated_at
+    BEFORE UPDATE ON sources
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
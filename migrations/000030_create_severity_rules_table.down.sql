@@ -0,0 +1,4 @@
+-- Rollback: Drop severity rules table
+
+DROP TRIGGER IF EXISTS update_severity_rules_updated_at ON severity_rules;
+DROP TABLE IF EXISTS severity_rules;
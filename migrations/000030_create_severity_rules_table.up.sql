@@ -0,0 +1,23 @@
+-- Migration: Create severity rules table
+-- Description: Admin rules changing the severity of matching alerts at ingestion
+
+CREATE TABLE IF NOT EXISTS severity_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    position INTEGER NOT NULL DEFAULT 0,
+    match JSONB NOT NULL DEFAULT '{}',
+    severity alert_severity NOT NULL,
+    is_enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for evaluating the rules in order
+CREATE INDEX idx_severity_rules_position ON severity_rules(position, name);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_severity_rules_updated_at
+    BEFORE UPDATE ON severity_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback: Drop assignment rules table
+
+DROP TRIGGER IF EXISTS update_assignment_rules_updated_at ON assignment_rules;
+DROP TABLE IF EXISTS assignment_rules;
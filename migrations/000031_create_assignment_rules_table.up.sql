@@ -0,0 +1,25 @@
+-- Migration: Create assignment rules table
+-- Description: Admin rules assigning matching alerts to a team or user at ingestion
+
+CREATE TABLE IF NOT EXISTS assignment_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    position INTEGER NOT NULL DEFAULT 0,
+    match JSONB NOT NULL DEFAULT '{}',
+    team_id UUID REFERENCES teams(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    is_enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT assignment_rules_assignee CHECK (team_id IS NOT NULL OR user_id IS NOT NULL)
+);
+
+-- Create index for evaluating the rules in order
+CREATE INDEX idx_assignment_rules_position ON assignment_rules(position, name);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_assignment_rules_updated_at
+    BEFORE UPDATE ON assignment_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- Rollback: Drop subscriptions tables
+
+DROP TRIGGER IF EXISTS update_subscription_deliveries_updated_at ON subscription_deliveries;
+DROP TABLE IF EXISTS subscription_deliveries;
+DROP TRIGGER IF EXISTS update_subscriptions_updated_at ON subscriptions;
+DROP TABLE IF EXISTS subscriptions;
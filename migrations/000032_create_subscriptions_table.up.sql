@@ -0,0 +1,54 @@
+-- Migration: Create subscriptions tables
+-- Description: Outbound webhook subscriptions and the deliveries of alert events to them
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types JSONB NOT NULL DEFAULT '[]',
+    filter JSONB NOT NULL DEFAULT '{}',
+    is_enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_subscriptions_updated_at
+    BEFORE UPDATE ON subscriptions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS subscription_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_status_code INTEGER,
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT subscription_deliveries_status CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+-- Create index for claiming the pending deliveries that are due
+CREATE INDEX idx_subscription_deliveries_due ON subscription_deliveries(next_attempt_at)
+    WHERE status = 'pending';
+
+-- Create index for listing the deliveries of a subscription
+CREATE INDEX idx_subscription_deliveries_subscription ON subscription_deliveries(subscription_id, created_at DESC);
+
+-- Create index for purging finished deliveries
+CREATE INDEX idx_subscription_deliveries_updated_at ON subscription_deliveries(updated_at)
+    WHERE status <> 'pending';
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_subscription_deliveries_updated_at
+    BEFORE UPDATE ON subscription_deliveries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+-- Rollback: Drop user_identities table
+
+DROP TRIGGER IF EXISTS update_user_identities_updated_at ON user_identities;
+DROP TABLE IF EXISTS user_identities;
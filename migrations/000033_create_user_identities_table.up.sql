@@ -0,0 +1,20 @@
+-- Migration: Create user_identities table
+-- Description: Links between users and their chat provider accounts, used to act on their behalf
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    external_id VARCHAR(100) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_identities_user_provider UNIQUE (user_id, provider),
+    CONSTRAINT user_identities_provider_external_id UNIQUE (provider, external_id)
+);
+
+-- Apply updated_at trigger
+CREATE TRIGGER update_user_identities_updated_at
+    BEFORE UPDATE ON user_identities
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Rollback: Remove telegram channel type
+-- Note: PostgreSQL cannot drop an enum value, so 'telegram' stays in channel_type
+
+DELETE FROM notification_templates WHERE channel = 'telegram';
+
+ALTER TABLE notification_templates DROP CONSTRAINT IF EXISTS notification_templates_channel_check;
+ALTER TABLE notification_templates ADD CONSTRAINT notification_templates_channel_check
+    CHECK (channel IN ('slack', 'email', 'sms', 'webhook'));
@@ -0,0 +1,8 @@
+-- Migration: Add telegram channel type
+-- Description: Telegram bot notifications, with their own channel type and notification template
+
+ALTER TYPE channel_type ADD VALUE IF NOT EXISTS 'telegram';
+
+ALTER TABLE notification_templates DROP CONSTRAINT IF EXISTS notification_templates_channel_check;
+ALTER TABLE notification_templates ADD CONSTRAINT notification_templates_channel_check
+    CHECK (channel IN ('slack', 'email', 'sms', 'webhook', 'telegram'));
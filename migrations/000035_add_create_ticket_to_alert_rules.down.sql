@@ -0,0 +1,3 @@
+-- Rollback: Drop create_ticket from alert rules
+
+ALTER TABLE alert_rules DROP COLUMN IF EXISTS create_ticket;
@@ -0,0 +1,4 @@
+-- Migration: Add create_ticket to alert rules
+-- Description: Rules whose alerts automatically file an issue in the issue tracker
+
+ALTER TABLE alert_rules ADD COLUMN create_ticket BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+-- Rollback: Drop the unique open alert dedup key index
+
+DROP INDEX IF EXISTS idx_alerts_open_dedup_key;
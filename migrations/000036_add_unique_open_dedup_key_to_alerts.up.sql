@@ -0,0 +1,18 @@
+-- Migration: Make open alert dedup keys unique per team
+-- Description: Lets deduplicated creation insert atomically with ON CONFLICT instead of looking up first
+
+UPDATE alerts SET status = 'resolved', resolved_at = NOW(), updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (
+            PARTITION BY team_id, metadata->>'dedup_key' ORDER BY created_at DESC
+        ) AS position
+        FROM alerts
+        WHERE metadata->>'dedup_key' IS NOT NULL
+          AND status IN ('active', 'acknowledged') AND deleted_at IS NULL
+    ) duplicates
+    WHERE position > 1
+);
+
+CREATE UNIQUE INDEX idx_alerts_open_dedup_key ON alerts(team_id, (metadata->>'dedup_key')) NULLS NOT DISTINCT
+    WHERE metadata->>'dedup_key' IS NOT NULL AND status IN ('active', 'acknowledged') AND deleted_at IS NULL;
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+)
+
+// Login authenticates with email and password. The client uses the returned
+// access token for subsequent requests.
+func (c *Client) Login(ctx context.Context, email, password string) (*dto.LoginResponse, error) {
+	var resp dto.LoginResponse
+	req := dto.LoginRequest{Email: email, Password: password}
+	if err := c.do(ctx, http.MethodPost, "/auth/login", nil, req, &resp); err != nil {
+		return nil, err
+	}
+
+	c.SetToken(resp.AccessToken)
+	return &resp, nil
+}
+
+// ListAlertsParams filters and paginates a list of alerts. Zero values are ignored.
+type ListAlertsParams struct {
+	Status   []string
+	Severity []string
+	Source   string
+	Region   string
+	TeamID   string
+	Search   string
+	Page     int
+	PageSize int
+}
+
+// query encodes the parameters as the query string of GET /alerts.
+func (p ListAlertsParams) query() url.Values {
+	query := url.Values{}
+	for _, status := range p.Status {
+		query.Add("status", status)
+	}
+	for _, severity := range p.Severity {
+		query.Add("severity", severity)
+	}
+	setQuery(query, "source", p.Source)
+	setQuery(query, "region", p.Region)
+	setQuery(query, "team_id", p.TeamID)
+	setQuery(query, "search", p.Search)
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(p.PageSize))
+	}
+	return query
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// ListAlerts lists the alerts visible to the caller.
+func (c *Client) ListAlerts(ctx context.Context, params ListAlertsParams) (*dto.PaginatedResponse[dto.AlertResponse], error) {
+	var resp dto.PaginatedResponse[dto.AlertResponse]
+	if err := c.do(ctx, http.MethodGet, "/alerts", params.query(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAlert retrieves an alert by ID.
+func (c *Client) GetAlert(ctx context.Context, id string) (*dto.AlertResponse, error) {
+	var resp dto.AlertResponse
+	if err := c.do(ctx, http.MethodGet, "/alerts/"+url.PathEscape(id), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AcknowledgeAlert marks an alert as acknowledged by the caller.
+func (c *Client) AcknowledgeAlert(ctx context.Context, id string) (*dto.AlertResponse, error) {
+	var resp dto.AlertResponse
+	if err := c.do(ctx, http.MethodPost, "/alerts/"+url.PathEscape(id)+"/acknowledge", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ResolveAlert marks an alert as resolved by the caller.
+func (c *Client) ResolveAlert(ctx context.Context, id string) (*dto.AlertResponse, error) {
+	var resp dto.AlertResponse
+	if err := c.do(ctx, http.MethodPost, "/alerts/"+url.PathEscape(id)+"/resolve", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListNotificationChannels lists the enabled notification channels. Admin only.
+func (c *Client) ListNotificationChannels(ctx context.Context) ([]string, error) {
+	var resp []string
+	if err := c.do(ctx, http.MethodGet, "/admin/notification-channels", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TestNotificationChannel sends a test notification through a channel. Admin only.
+func (c *Client) TestNotificationChannel(ctx context.Context, channel string) error {
+	return c.do(ctx, http.MethodPost, "/admin/notification-channels/"+url.PathEscape(channel)+"/test", nil, nil, nil)
+}
+
+// ListFailedEvents lists the events in the dead letter queue. Admin only.
+func (c *Client) ListFailedEvents(ctx context.Context, page, pageSize int) (*dto.PaginatedResponse[dto.FailedEventResponse], error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+
+	var resp dto.PaginatedResponse[dto.FailedEventResponse]
+	if err := c.do(ctx, http.MethodGet, "/admin/failed-events", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RetryFailedEvent retries an event of the dead letter queue. Admin only.
+func (c *Client) RetryFailedEvent(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPost, "/admin/failed-events/"+url.PathEscape(id)+"/retry", nil, nil, nil)
+}
+
+// RetryFailedEvents retries the events of the dead letter queue matching the
+// request, or reports them with DryRun. Admin only.
+func (c *Client) RetryFailedEvents(ctx context.Context, req dto.BulkFailedEventsRequest) (*dto.BulkOperationResponse, error) {
+	var resp dto.BulkOperationResponse
+	if err := c.do(ctx, http.MethodPost, "/admin/failed-events/retry", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ApplyRules applies a bundle of alert rules and notification routes, in YAML
+// or JSON as given by contentType, and returns the change report. With dryRun
+// the changes are only reported. Admin only.
+func (c *Client) ApplyRules(ctx context.Context, bundle []byte, contentType string, dryRun bool) (*dto.RuleBundleResponse, error) {
+	query := url.Values{}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+
+	var resp dto.RuleBundleResponse
+	body := rawBody{data: bundle, contentType: contentType}
+	if err := c.do(ctx, http.MethodPost, "/rules/apply", query, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -232,3 +233,127 @@ func TestGetStatistics_Success(t *testing.T) {
 
 	assert.GreaterOrEqual(t, stats.TotalAlerts, int64(0))
 }
+
+func TestCreateAlertBatch_Success(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	token := app.Login(t, "admin@alerting.local", "Admin123!")
+
+	resp := app.MakeRequest("POST", "/api/v1/alerts/batch", dto.CreateAlertBatchRequest{
+		Alerts: []dto.CreateAlertRequest{
+			{Title: "Test Batch Alert 1", Message: "First", Severity: "high", Source: "integration-test"},
+			{Title: "Test Batch Alert 2", Message: "Second", Severity: "low", Source: "integration-test"},
+		},
+	}, token)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	var batchResp dto.AlertBatchResponse
+	err := json.Unmarshal(resp.Body.Bytes(), &batchResp)
+	require.NoError(t, err)
+
+	assert.False(t, batchResp.DryRun)
+	assert.Equal(t, 2, batchResp.Created)
+	assert.Len(t, batchResp.Items, 2)
+}
+
+func TestCreateAlertBatch_DryRun(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	token := app.Login(t, "admin@alerting.local", "Admin123!")
+
+	resp := app.MakeRequest("POST", "/api/v1/alerts/batch?dry_run=true", dto.CreateAlertBatchRequest{
+		Alerts: []dto.CreateAlertRequest{
+			{Title: "Test Dry Run Alert", Message: "Not stored", Severity: "medium", Source: "integration-test"},
+		},
+	}, token)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var batchResp dto.AlertBatchResponse
+	err := json.Unmarshal(resp.Body.Bytes(), &batchResp)
+	require.NoError(t, err)
+	require.Len(t, batchResp.Items, 1)
+
+	assert.True(t, batchResp.DryRun)
+
+	// Verify nothing was stored
+	resp = app.MakeRequest("GET", "/api/v1/alerts/"+batchResp.Items[0].ID, nil, token)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestAlertManagerWebhook_SkipsInvalidAlerts(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	resp := app.MakeRequest("POST", "/api/v1/webhooks/alertmanager", map[string]interface{}{
+		"status": "firing",
+		"alerts": []map[string]interface{}{
+			{"status": "firing", "labels": map[string]string{"alertname": strings.Repeat("x", 300)}, "fingerprint": "too-long"},
+			{"status": "firing", "labels": map[string]string{"alertname": "Test Webhook Alert"}, "fingerprint": "valid"},
+		},
+	}, "")
+
+	// The invalid alert is reported without holding back the valid one
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var result struct {
+		Created  int `json:"created"`
+		Rejected []struct {
+			Index       int    `json:"index"`
+			Fingerprint string `json:"fingerprint"`
+		} `json:"rejected"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+
+	assert.Equal(t, 1, result.Created)
+	require.Len(t, result.Rejected, 1)
+	assert.Equal(t, 0, result.Rejected[0].Index)
+	assert.Equal(t, "too-long", result.Rejected[0].Fingerprint)
+}
+
+func TestBulkDeleteAlerts_DryRun(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	token := app.Login(t, "admin@alerting.local", "Admin123!")
+
+	createResp := app.MakeRequest("POST", "/api/v1/alerts", dto.CreateAlertRequest{
+		Title:    "Test Bulk Delete Alert",
+		Message:  "Test message",
+		Severity: "low",
+		Source:   "bulk-delete-test",
+	}, token)
+
+	var created dto.AlertResponse
+	_ = json.Unmarshal(createResp.Body.Bytes(), &created)
+
+	resp := app.MakeRequest("DELETE", "/api/v1/admin/alerts?source=bulk-delete-test&dry_run=true", nil, token)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var bulkResp dto.BulkOperationResponse
+	err := json.Unmarshal(resp.Body.Bytes(), &bulkResp)
+	require.NoError(t, err)
+
+	assert.True(t, bulkResp.DryRun)
+	assert.GreaterOrEqual(t, bulkResp.Affected, int64(1))
+	assert.Contains(t, bulkResp.SampleIDs, created.ID)
+
+	// Verify the alert still exists
+	resp = app.MakeRequest("GET", "/api/v1/alerts/"+created.ID, nil, token)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestBulkDeleteAlerts_RequiresFilter(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	token := app.Login(t, "admin@alerting.local", "Admin123!")
+
+	resp := app.MakeRequest("DELETE", "/api/v1/admin/alerts", nil, token)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
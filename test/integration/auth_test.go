@@ -131,3 +131,71 @@ func TestMe_Unauthorized(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, resp.Code)
 }
+
+func TestSessions_ListAndRevoke(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	resp := app.MakeRequest("POST", "/api/v1/auth/register", dto.RegisterRequest{
+		Email:    "test.sessions@example.com",
+		Password: "TestPassword123!",
+		Name:     "Test Sessions",
+	}, "")
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	first := app.Login(t, "test.sessions@example.com", "TestPassword123!")
+	second := app.Login(t, "test.sessions@example.com", "TestPassword123!")
+
+	resp = app.MakeRequest("GET", "/api/v1/auth/sessions", nil, second)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var sessions []dto.SessionResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &sessions))
+	require.Len(t, sessions, 3)
+	assert.True(t, sessions[0].Current)
+	assert.False(t, sessions[1].Current)
+
+	// Revoking the first login's session invalidates its token
+	resp = app.MakeRequest("DELETE", "/api/v1/auth/sessions/"+sessions[1].ID, nil, second)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp = app.MakeRequest("GET", "/api/v1/auth/me", nil, first)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+
+	resp = app.MakeRequest("GET", "/api/v1/auth/me", nil, second)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestSessions_OtherUsers(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	resp := app.MakeRequest("POST", "/api/v1/auth/register", dto.RegisterRequest{
+		Email:    "test.viewer.sessions@example.com",
+		Password: "TestPassword123!",
+		Name:     "Test Viewer",
+	}, "")
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var registered dto.LoginResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &registered))
+
+	viewer := registered.AccessToken
+	admin := app.Login(t, "admin@alerting.local", "Admin123!")
+
+	resp = app.MakeRequest("GET", "/api/v1/auth/sessions?user_id="+registered.User.ID, nil, viewer)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp = app.MakeRequest("GET", "/api/v1/auth/sessions?user_id="+registered.User.ID, nil, admin)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var sessions []dto.SessionResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &sessions))
+	require.Len(t, sessions, 1)
+
+	resp = app.MakeRequest("DELETE", "/api/v1/auth/sessions/"+sessions[0].ID, nil, admin)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp = app.MakeRequest("GET", "/api/v1/auth/me", nil, viewer)
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
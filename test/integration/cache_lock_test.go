@@ -0,0 +1,65 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+func TestCacheLock_UnlockRequiresToken(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	ctx := context.Background()
+	key := "test:lock:" + entity.NewID().String()
+
+	token, err := app.CacheRepo.Lock(ctx, key, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	_, err = app.CacheRepo.Lock(ctx, key, time.Minute)
+	assert.ErrorIs(t, err, repository.ErrLockHeld)
+
+	// Another owner's token neither releases the lock nor breaks it
+	assert.ErrorIs(t, app.CacheRepo.Unlock(ctx, key, "wrong-token"), repository.ErrLockNotHeld)
+	_, err = app.CacheRepo.Lock(ctx, key, time.Minute)
+	assert.ErrorIs(t, err, repository.ErrLockHeld)
+
+	require.NoError(t, app.CacheRepo.Unlock(ctx, key, token))
+	assert.ErrorIs(t, app.CacheRepo.Unlock(ctx, key, token), repository.ErrLockNotHeld)
+
+	token, err = app.CacheRepo.Lock(ctx, key, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, app.CacheRepo.Unlock(ctx, key, token))
+}
+
+func TestCacheLock_TryWithLock(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	ctx := context.Background()
+	key := "test:lock:" + entity.NewID().String()
+	failure := errors.New("job failed")
+
+	ran, err := app.CacheRepo.TryWithLock(ctx, key, time.Minute, func(ctx context.Context) error {
+		// Held while fn runs
+		nested, err := app.CacheRepo.TryWithLock(ctx, key, time.Minute, func(context.Context) error { return nil })
+		assert.NoError(t, err)
+		assert.False(t, nested)
+		return failure
+	})
+	assert.True(t, ran)
+	assert.ErrorIs(t, err, failure)
+
+	// Released after fn, even when it failed
+	ran, err = app.CacheRepo.TryWithLock(ctx, key, time.Minute, func(context.Context) error { return nil })
+	assert.True(t, ran)
+	assert.NoError(t, err)
+}
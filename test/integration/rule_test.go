@@ -0,0 +1,89 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+)
+
+func TestRules_TeamIsolation(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	admin := app.Login(t, "admin@alerting.local", "Admin123!")
+
+	teamA := createTeam(t, app, admin, "test-rules-team-a")
+	teamB := createTeam(t, app, admin, "test-rules-team-b")
+
+	resp := app.MakeRequest("POST", "/api/v1/auth/register", dto.RegisterRequest{
+		Email:    "test.rules.operator@example.com",
+		Password: "TestPassword123!",
+		Name:     "Test Operator",
+	}, "")
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var registered dto.LoginResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &registered))
+
+	role := "operator"
+	resp = app.MakeRequest("PATCH", "/api/v1/admin/users/"+registered.User.ID,
+		dto.UpdateUserRequest{Role: &role}, admin)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	resp = app.MakeRequest("PUT", "/api/v1/teams/"+teamA.ID+"/members/"+registered.User.ID,
+		dto.SetTeamMemberRequest{Role: "member"}, admin)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	operator := app.Login(t, "test.rules.operator@example.com", "TestPassword123!")
+
+	resp = app.MakeRequest("PUT", "/api/v1/rules/external/test-rule-b",
+		ruleSpecRequest("Test Rule B", teamB.ID), admin)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	// Rules of other teams are hidden and cannot be replaced or deleted
+	resp = app.MakeRequest("GET", "/api/v1/rules/external/test-rule-b", nil, operator)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = app.MakeRequest("PUT", "/api/v1/rules/external/test-rule-b",
+		ruleSpecRequest("Test Rule B", teamA.ID), operator)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp = app.MakeRequest("DELETE", "/api/v1/rules/external/test-rule-b", nil, operator)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	// Rules cannot be created for, or moved to, other teams
+	resp = app.MakeRequest("PUT", "/api/v1/rules/external/test-rule-a",
+		ruleSpecRequest("Test Rule A", teamB.ID), operator)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp = app.MakeRequest("PUT", "/api/v1/rules/external/test-rule-a",
+		ruleSpecRequest("Test Rule A", teamA.ID), operator)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	resp = app.MakeRequest("PUT", "/api/v1/rules/external/test-rule-a",
+		ruleSpecRequest("Test Rule A", teamB.ID), operator)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp = app.MakeRequest("DELETE", "/api/v1/rules/external/test-rule-a", nil, operator)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+// ruleSpecRequest declares a threshold rule owned by a team.
+func ruleSpecRequest(name, teamID string) dto.RuleSpecRequest {
+	return dto.RuleSpecRequest{
+		Name:     name,
+		Severity: "high",
+		TeamID:   teamID,
+		Condition: dto.RuleConditionRequest{
+			Type:      "threshold",
+			Metric:    "cpu_usage",
+			Operator:  ">",
+			Threshold: 90,
+		},
+	}
+}
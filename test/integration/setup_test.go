@@ -11,6 +11,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/database"
@@ -96,12 +97,21 @@ func SetupTestApp(t *testing.T) *TestApp {
 
 	// Setup router
 	app := router.Setup(router.Dependencies{
-		Config:        cfg,
-		UserRepo:      userRepo,
-		AlertRepo:     alertRepo,
-		CacheRepo:     cacheRepo,
-		DBHealthCheck: db,
-		WSHub:         wsHub,
+		Config:            cfg,
+		UserRepo:          userRepo,
+		AlertRepo:         alertRepo,
+		CacheRepo:         cacheRepo,
+		SessionRepo:       database.NewRedisSessionRepository(redis),
+		TeamRepo:          database.NewPostgresTeamRepository(db),
+		AlertRuleRepo:     database.NewPostgresAlertRuleRepository(db),
+		DBHealthCheck:     db,
+		WebhookSourceRepo: database.NewPostgresWebhookSourceRepository(db),
+		WebhookSecretRepo: database.NewPostgresWebhookSecretRepository(db),
+		WSHub:             wsHub,
+		TemplateService: service.NewNotificationTemplateService(
+			database.NewPostgresNotificationTemplateRepository(db), cfg.Notification.AlertURL,
+		),
+		RouteService: service.NewRouteService(database.NewPostgresNotificationRouteRepository(db)),
 	})
 
 	return &TestApp{
@@ -129,6 +139,10 @@ func (ta *TestApp) Cleanup(t *testing.T) {
 	// Clear test data
 	ctx := context.Background()
 	_, _ = ta.DB.ExecContext(ctx, "DELETE FROM alerts WHERE title LIKE 'Test%'")
+	_, _ = ta.DB.ExecContext(ctx, "DELETE FROM webhook_sources WHERE name LIKE 'test%'")
+	_, _ = ta.DB.ExecContext(ctx, "DELETE FROM webhook_secrets")
+	_, _ = ta.DB.ExecContext(ctx, "DELETE FROM alert_rules WHERE name LIKE 'Test%'")
+	_, _ = ta.DB.ExecContext(ctx, "DELETE FROM teams WHERE name LIKE 'test%'")
 	_, _ = ta.DB.ExecContext(ctx, "DELETE FROM users WHERE email LIKE 'test%'")
 
 	// Clear rate limiting
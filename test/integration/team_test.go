@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+)
+
+func TestTeams_AlertIsolation(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	admin := app.Login(t, "admin@alerting.local", "Admin123!")
+
+	teamA := createTeam(t, app, admin, "test-team-a")
+	teamB := createTeam(t, app, admin, "test-team-b")
+
+	resp := app.MakeRequest("POST", "/api/v1/auth/register", dto.RegisterRequest{
+		Email:    "test.team.member@example.com",
+		Password: "TestPassword123!",
+		Name:     "Test Member",
+	}, "")
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var registered dto.LoginResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &registered))
+	member := registered.AccessToken
+
+	resp = app.MakeRequest("PUT", "/api/v1/teams/"+teamA.ID+"/members/"+registered.User.ID,
+		dto.SetTeamMemberRequest{Role: "member"}, admin)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	alertA := createTeamAlert(t, app, admin, "Test Team A Alert", teamA.ID)
+	alertB := createTeamAlert(t, app, admin, "Test Team B Alert", teamB.ID)
+	shared := createTeamAlert(t, app, admin, "Test Shared Alert", "")
+
+	resp = app.MakeRequest("GET", "/api/v1/alerts/"+alertA.ID, nil, member)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = app.MakeRequest("GET", "/api/v1/alerts/"+shared.ID, nil, member)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = app.MakeRequest("GET", "/api/v1/alerts/"+alertB.ID, nil, member)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = app.MakeRequest("GET", "/api/v1/alerts?page_size=100&search=Test%20Team", nil, member)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var page dto.PaginatedResponse[dto.AlertResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &page))
+	for _, alert := range page.Items {
+		assert.NotEqual(t, alertB.ID, alert.ID)
+	}
+
+	// Teams the user does not belong to are hidden
+	resp = app.MakeRequest("GET", "/api/v1/teams/"+teamB.ID, nil, member)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = app.MakeRequest("GET", "/api/v1/teams", nil, member)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var teams dto.PaginatedResponse[dto.TeamResponse]
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &teams))
+	require.Len(t, teams.Items, 1)
+	assert.Equal(t, teamA.ID, teams.Items[0].ID)
+
+	// Members cannot manage the team
+	resp = app.MakeRequest("PUT", "/api/v1/teams/"+teamA.ID+"/members/"+registered.User.ID,
+		dto.SetTeamMemberRequest{Role: "owner"}, member)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	// Teams owning alerts cannot be deleted
+	resp = app.MakeRequest("DELETE", "/api/v1/teams/"+teamA.ID, nil, admin)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	// Leaving the team hides its alerts
+	resp = app.MakeRequest("DELETE", "/api/v1/teams/"+teamA.ID+"/members/"+registered.User.ID, nil, member)
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp = app.MakeRequest("GET", "/api/v1/alerts/"+alertA.ID, nil, member)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestTeams_LastOwner(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	admin := app.Login(t, "admin@alerting.local", "Admin123!")
+	team := createTeam(t, app, admin, "test-team-owners")
+
+	resp := app.MakeRequest("GET", "/api/v1/auth/me", nil, admin)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var me dto.UserResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &me))
+
+	resp = app.MakeRequest("PUT", "/api/v1/teams/"+team.ID+"/members/"+me.ID,
+		dto.SetTeamMemberRequest{Role: "owner"}, admin)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	resp = app.MakeRequest("PUT", "/api/v1/teams/"+team.ID+"/members/"+me.ID,
+		dto.SetTeamMemberRequest{Role: "member"}, admin)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp = app.MakeRequest("DELETE", "/api/v1/teams/"+team.ID+"/members/"+me.ID, nil, admin)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+
+	resp = app.MakeRequest("DELETE", "/api/v1/teams/"+team.ID, nil, admin)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+// createTeam creates a team as admin.
+func createTeam(t *testing.T, app *TestApp, token, name string) dto.TeamResponse {
+	t.Helper()
+
+	resp := app.MakeRequest("POST", "/api/v1/teams", dto.CreateTeamRequest{Name: name}, token)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var team dto.TeamResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &team))
+	return team
+}
+
+// createTeamAlert creates an alert owned by a team, or a shared one when teamID is empty.
+func createTeamAlert(t *testing.T, app *TestApp, token, title, teamID string) dto.AlertResponse {
+	t.Helper()
+
+	resp := app.MakeRequest("POST", "/api/v1/alerts", dto.CreateAlertRequest{
+		Title:    title,
+		Message:  "Team isolation test",
+		Severity: "low",
+		TeamID:   teamID,
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var alert dto.AlertResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &alert))
+	return alert
+}
@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+)
+
+// postSigned sends a webhook payload with the given timestamp and signature headers.
+func postSigned(t *testing.T, app *TestApp, path string, body []byte, timestamp, signature string) int {
+	t.Helper()
+
+	req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if timestamp != "" {
+		req.Header.Set("X-Webhook-Timestamp", timestamp)
+	}
+	if signature != "" {
+		req.Header.Set("X-Webhook-Signature", signature)
+	}
+
+	resp, err := app.App.Test(req, -1)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	return resp.StatusCode
+}
+
+func TestWebhookSignature_AlertManager(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	token := app.Login(t, "admin@alerting.local", "Admin123!")
+	body := []byte(`{"status":"firing","alerts":[]}`)
+	path := "/api/v1/webhooks/alertmanager"
+
+	// Without a secret the integration accepts unsigned payloads
+	assert.Equal(t, http.StatusOK, postSigned(t, app, path, body, "", ""))
+
+	resp := app.MakeRequest("PUT", "/api/v1/admin/webhook-secrets/alertmanager", nil, token)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var secret dto.WebhookSecretResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &secret))
+	require.NotEmpty(t, secret.Secret)
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	assert.Equal(t, http.StatusUnauthorized, postSigned(t, app, path, body, "", ""))
+	assert.Equal(t, http.StatusUnauthorized, postSigned(t, app, path, body, now, "sha256=deadbeef"))
+	assert.Equal(t, http.StatusUnauthorized,
+		postSigned(t, app, path, body, stale, "sha256="+service.SignWebhookPayload(secret.Secret, stale, body)))
+	assert.Equal(t, http.StatusOK,
+		postSigned(t, app, path, body, now, "sha256="+service.SignWebhookPayload(secret.Secret, now, body)))
+
+	resp = app.MakeRequest("DELETE", "/api/v1/admin/webhook-secrets/alertmanager", nil, token)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, http.StatusOK, postSigned(t, app, path, body, "", ""))
+}
+
+func TestWebhookSecret_UnsupportedIntegration(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	token := app.Login(t, "admin@alerting.local", "Admin123!")
+
+	resp := app.MakeRequest("PUT", "/api/v1/admin/webhook-secrets/pagerduty", nil, token)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+)
+
+func TestInboundWebhook_CreateAndResolve(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	token := app.Login(t, "admin@alerting.local", "Admin123!")
+
+	resp := app.MakeRequest("POST", "/api/v1/admin/webhook-sources", dto.CreateWebhookSourceRequest{
+		Name: "test-inbound",
+		Mapping: dto.WebhookMappingRequest{
+			Title:          "Test {{check.name}}",
+			Message:        "$.check.output",
+			Severity:       "$.level",
+			SeverityMap:    map[string]string{"P1": "critical"},
+			DedupKey:       "$.check.id",
+			Status:         "$.state",
+			ResolvedValues: []string{"ok"},
+		},
+	}, token)
+	require.Equal(t, http.StatusCreated, resp.Code)
+
+	var source dto.WebhookSourceResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &source))
+	require.NotEmpty(t, source.Token)
+
+	firing := map[string]interface{}{
+		"state": "firing",
+		"level": "P1",
+		"check": map[string]interface{}{"id": 42, "name": "disk", "output": "disk full"},
+	}
+
+	resp = app.MakeRequest("POST", "/api/v1/webhooks/inbound/"+source.Token, firing, "")
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var result dto.InboundWebhookResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, "created", result.Action)
+
+	resp = app.MakeRequest("GET", "/api/v1/alerts/"+result.AlertID, nil, token)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var alert dto.AlertResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &alert))
+	assert.Equal(t, "Test disk", alert.Title)
+	assert.Equal(t, "disk full", alert.Message)
+	assert.Equal(t, "critical", alert.Severity)
+
+	resp = app.MakeRequest("POST", "/api/v1/webhooks/inbound/"+source.Token, firing, "")
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, "deduplicated", result.Action)
+
+	firing["state"] = "ok"
+	resp = app.MakeRequest("POST", "/api/v1/webhooks/inbound/"+source.Token, firing, "")
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, "resolved", result.Action)
+}
+
+func TestInboundWebhook_UnknownToken(t *testing.T) {
+	app := SetupTestApp(t)
+	defer app.Cleanup(t)
+
+	resp := app.MakeRequest("POST", "/api/v1/webhooks/inbound/unknown", map[string]string{"title": "x"}, "")
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
@@ -0,0 +1,71 @@
+package event_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appevent "github.com/daniel-caso-github/realtime-alerting-system/internal/application/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// claimCache keeps the keys claimed with SetNX.
+type claimCache struct {
+	repository.CacheRepository
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+func (c *claimCache) SetNX(_ context.Context, key string, _ interface{}, _ time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.keys[key] {
+		return false, nil
+	}
+	c.keys[key] = true
+	return true, nil
+}
+
+func (c *claimCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.keys, key)
+	return nil
+}
+
+// countingHandler counts the created alerts it handles.
+type countingHandler struct {
+	appevent.AlertEventHandler
+	created int
+}
+
+func (h *countingHandler) HandleAlertCreated(_ context.Context, _ event.AlertPayload) error {
+	h.created++
+	return nil
+}
+
+func TestAlertConsumer_IdempotencyPerRegisteredName(t *testing.T) {
+	first, second := &countingHandler{}, &countingHandler{}
+
+	consumer := appevent.NewAlertConsumer()
+	consumer.RegisterHandler("slack", first)
+	consumer.RegisterHandler("email", second)
+	consumer.SetIdempotency(&claimCache{keys: make(map[string]bool)}, time.Hour)
+
+	evt, err := event.NewEvent(event.AlertCreated, event.AlertPayload{ID: "alert-1"})
+	require.NoError(t, err)
+
+	// Both handlers share a type, but each processes the event once
+	require.NoError(t, consumer.Handle(context.Background(), evt))
+	require.NoError(t, consumer.Handle(context.Background(), evt))
+
+	assert.Equal(t, 1, first.created)
+	assert.Equal(t, 1, second.created)
+}
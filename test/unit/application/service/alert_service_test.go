@@ -0,0 +1,224 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+// memoryAlerts stores alerts, enforcing unique open dedup keys per team like
+// the database index does.
+type memoryAlerts struct {
+	repository.AlertRepository
+	mu     sync.Mutex
+	alerts []*entity.Alert
+}
+
+func (m *memoryAlerts) openByDedupKey(teamID *entity.ID, dedupKey string, anyTeam bool) *entity.Alert {
+	for i := len(m.alerts) - 1; i >= 0; i-- {
+		alert := m.alerts[i]
+		if alert.Fingerprint() != dedupKey || alert.Status == entity.AlertStatusResolved {
+			continue
+		}
+		if anyTeam || sameTeam(alert.TeamID, teamID) {
+			return alert
+		}
+	}
+	return nil
+}
+
+func (m *memoryAlerts) GetOpenByDedupKey(_ context.Context, dedupKey string) (*entity.Alert, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if alert := m.openByDedupKey(nil, dedupKey, true); alert != nil {
+		return alert, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *memoryAlerts) CreateDeduplicated(_ context.Context, alert *entity.Alert) (*entity.Alert, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing := m.openByDedupKey(alert.TeamID, alert.Fingerprint(), false); existing != nil {
+		return existing, false, nil
+	}
+	m.alerts = append(m.alerts, alert)
+	return alert, true, nil
+}
+
+func sameTeam(a, b *entity.ID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func TestAlertService_CreateDeduplicatedConcurrently(t *testing.T) {
+	repo := &memoryAlerts{}
+	alerts := service.NewAlertService(repo, nil, nil)
+
+	const callers = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+		ids     = make(map[entity.ID]bool)
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			alert, isNew, err := alerts.CreateDeduplicated(context.Background(), "grafana:rule-1", service.CreateAlertInput{
+				Title:    "High CPU",
+				Message:  "CPU above 90%",
+				Severity: entity.AlertSeverityCritical,
+				Source:   "grafana",
+			})
+			assert.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if isNew {
+				created++
+			}
+			ids[alert.ID] = true
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, created)
+	assert.Len(t, ids, 1)
+	assert.Len(t, repo.alerts, 1)
+}
+
+func TestAlertService_CreateDeduplicatedPerTeam(t *testing.T) {
+	teamA, teamB := entity.NewID(), entity.NewID()
+	input := func(teamID *entity.ID) service.CreateAlertInput {
+		return service.CreateAlertInput{
+			Title:    "Disk full",
+			Message:  "Disk above 95%",
+			Severity: entity.AlertSeverityMedium,
+			Source:   "datadog",
+			TeamID:   teamID,
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		first       *entity.ID
+		second      *entity.ID
+		wantCreated bool
+	}{
+		{name: "same team", first: &teamA, second: &teamA, wantCreated: false},
+		{name: "other team", first: &teamA, second: &teamB, wantCreated: true},
+		{name: "no team repeats any team", first: &teamA, second: nil, wantCreated: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alerts := service.NewAlertService(&memoryAlerts{}, nil, nil)
+
+			first, created, err := alerts.CreateDeduplicated(context.Background(), "datadog:42", input(tc.first))
+			require.NoError(t, err)
+			require.True(t, created)
+
+			second, created, err := alerts.CreateDeduplicated(context.Background(), "datadog:42", input(tc.second))
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCreated, created)
+			assert.Equal(t, !tc.wantCreated, first.ID == second.ID)
+		})
+	}
+}
+
+// trashAlerts deletes the alerts it holds, whatever the filter.
+type trashAlerts struct {
+	repository.AlertRepository
+	ids []entity.ID
+}
+
+func (t *trashAlerts) List(
+	_ context.Context,
+	_ valueobject.AlertFilter,
+	pagination valueobject.Pagination,
+) (*valueobject.PaginatedResult[*entity.Alert], error) {
+	alerts := make([]*entity.Alert, 0, len(t.ids))
+	for _, id := range t.ids {
+		alerts = append(alerts, &entity.Alert{ID: id})
+	}
+	result := valueobject.NewPaginatedResult(alerts, int64(len(alerts)), pagination)
+	return &result, nil
+}
+
+func (t *trashAlerts) DeleteByFilter(context.Context, valueobject.AlertFilter) ([]entity.ID, error) {
+	deleted := t.ids
+	t.ids = nil
+	return deleted, nil
+}
+
+// deletedBroadcasts records the alert deletions published to clients.
+type deletedBroadcasts struct {
+	service.AlertEventPublisher
+	ids []string
+}
+
+func (d *deletedBroadcasts) PublishAlertDeleted(alertID string) {
+	d.ids = append(d.ids, alertID)
+}
+
+// deletedEvents records the alert deletions published to the event bus.
+type deletedEvents struct {
+	service.AlertEventProducer
+	ids       []string
+	deletedBy []string
+}
+
+func (d *deletedEvents) PublishAlertDeleted(_ context.Context, alertID, deletedBy string) {
+	d.ids = append(d.ids, alertID)
+	d.deletedBy = append(d.deletedBy, deletedBy)
+}
+
+func TestAlertService_BulkDeletePublishesEachAlert(t *testing.T) {
+	admin := entity.NewID()
+	ids := []entity.ID{entity.NewID(), entity.NewID(), entity.NewID()}
+	filter := valueobject.NewAlertFilter().WithSource("datadog")
+
+	testCases := []struct {
+		name          string
+		dryRun        bool
+		wantDeleted   []string
+		wantDeletedBy []string
+	}{
+		{
+			name:          "deleted alerts",
+			wantDeleted:   []string{ids[0].String(), ids[1].String(), ids[2].String()},
+			wantDeletedBy: []string{admin.String(), admin.String(), admin.String()},
+		},
+		{name: "dry run", dryRun: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			broadcasts, events := &deletedBroadcasts{}, &deletedEvents{}
+			alerts := service.NewAlertService(&trashAlerts{ids: ids}, nil, broadcasts)
+			alerts.SetEventProducer(events)
+
+			result, err := alerts.BulkDelete(context.Background(), filter, tc.dryRun, admin)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(ids)), result.Affected)
+
+			assert.Equal(t, tc.wantDeleted, broadcasts.ids)
+			assert.Equal(t, tc.wantDeleted, events.ids)
+			assert.Equal(t, tc.wantDeletedBy, events.deletedBy)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// memorySessions stores sessions and counts the lookups of token validation.
+type memorySessions struct {
+	repository.SessionRepository
+	mu       sync.Mutex
+	sessions map[entity.ID]*entity.Session
+	lookups  int
+}
+
+func (m *memorySessions) Save(_ context.Context, session *entity.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *memorySessions) GetByID(_ context.Context, id entity.ID) (*entity.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if session, ok := m.sessions[id]; ok {
+		return session, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *memorySessions) Exists(_ context.Context, id entity.ID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lookups++
+	_, ok := m.sessions[id]
+	return ok, nil
+}
+
+func (m *memorySessions) Delete(_ context.Context, session *entity.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[session.ID]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.sessions, session.ID)
+	return nil
+}
+
+// memoryRevocations stores revoked IDs and counts the checks reaching it.
+type memoryRevocations struct {
+	mu      sync.Mutex
+	revoked map[string]time.Time
+	checks  int
+}
+
+func (m *memoryRevocations) Revoke(_ context.Context, tokenID string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.revoked[tokenID] = expiresAt
+	return nil
+}
+
+func (m *memoryRevocations) IsRevoked(_ context.Context, tokenID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checks++
+	_, ok := m.revoked[tokenID]
+	return ok, nil
+}
+
+func (m *memoryRevocations) ListRevoked(_ context.Context) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.revoked))
+	for id := range m.revoked {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// authFixture is an auth service with token revocation and a logged in user.
+type authFixture struct {
+	auth        *service.AuthService
+	sessions    *memorySessions
+	revocations *memoryRevocations
+	user        *entity.User
+	tokens      *service.TokenPair
+}
+
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+
+	hash, err := valueobject.NewPasswordHash("Passw0rd!")
+	require.NoError(t, err)
+	user, err := entity.NewUser("operator@example.com", hash.String(), "Operator", entity.UserRoleOperator)
+	require.NoError(t, err)
+
+	sessions := &memorySessions{sessions: make(map[entity.ID]*entity.Session)}
+	revocations := &memoryRevocations{revoked: make(map[string]time.Time)}
+
+	auth := service.NewAuthService(&memoryUsers{users: []*entity.User{user}}, newMemoryCache(), sessions, &config.JWTConfig{
+		Secret:            "test-secret",
+		Expiration:        15 * time.Minute,
+		RefreshExpiration: 24 * time.Hour,
+		Issuer:            "test",
+	})
+	auth.SetTokenRevocation(revocations)
+	require.NoError(t, auth.RefreshRevocations(context.Background()))
+
+	tokens, _, err := auth.Login(context.Background(), user.Email, "Passw0rd!", service.ClientInfo{Device: "test"})
+	require.NoError(t, err)
+
+	return &authFixture{auth: auth, sessions: sessions, revocations: revocations, user: user, tokens: tokens}
+}
+
+func TestAuthService_ValidateTokenSkipsSessionLookup(t *testing.T) {
+	f := newAuthFixture(t)
+
+	for i := 0; i < 3; i++ {
+		claims, err := f.auth.ValidateToken(context.Background(), f.tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, f.user.ID.String(), claims.UserID)
+	}
+
+	assert.Zero(t, f.sessions.lookups)
+	assert.Zero(t, f.revocations.checks)
+}
+
+func TestAuthService_ValidateTokenRefusesEndedSessions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		end     func(t *testing.T, f *authFixture, sessionID entity.ID)
+		rebuild bool
+	}{
+		{
+			name: "revoked session",
+			end: func(t *testing.T, f *authFixture, sessionID entity.ID) {
+				require.NoError(t, f.auth.RevokeSession(context.Background(), f.user.ID, sessionID, false))
+			},
+		},
+		{
+			name: "session revoked on another instance",
+			end: func(t *testing.T, f *authFixture, sessionID entity.ID) {
+				other := newAuthFixture(t)
+				other.sessions.sessions = f.sessions.sessions
+				other.auth.SetTokenRevocation(f.revocations)
+				require.NoError(t, other.auth.RevokeSession(context.Background(), f.user.ID, sessionID, true))
+			},
+			rebuild: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newAuthFixture(t)
+
+			claims, err := f.auth.ValidateToken(context.Background(), f.tokens.AccessToken)
+			require.NoError(t, err)
+			sessionID, err := entity.ParseID(claims.SessionID)
+			require.NoError(t, err)
+
+			tc.end(t, f, sessionID)
+			if tc.rebuild {
+				require.NoError(t, f.auth.RefreshRevocations(context.Background()))
+			}
+
+			_, err = f.auth.ValidateToken(context.Background(), f.tokens.AccessToken)
+			assert.ErrorIs(t, err, service.ErrTokenInvalid)
+			assert.Zero(t, f.sessions.lookups)
+			assert.Positive(t, f.revocations.checks)
+		})
+	}
+}
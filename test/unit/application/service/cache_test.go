@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// memoryCache is an in-memory cache whose operations are atomic, like the
+// Redis commands they stand for.
+type memoryCache struct {
+	repository.CacheRepository
+	mu     sync.Mutex
+	values map[string][]byte
+	lists  map[string][][]byte
+	sets   map[string]map[string]bool
+	tokens int
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		values: map[string][]byte{},
+		lists:  map[string][][]byte{},
+		sets:   map[string]map[string]bool{},
+	}
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = data
+	return nil
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	data, ok := m.values[key]
+	m.mu.Unlock()
+
+	if !ok {
+		return repository.ErrNotFound
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (m *memoryCache) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+	delete(m.lists, key)
+	delete(m.sets, key)
+	return nil
+}
+
+func (m *memoryCache) SetNX(_ context.Context, key string, value interface{}, _ time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.values[key]; ok {
+		return false, nil
+	}
+	m.values[key] = data
+	return true, nil
+}
+
+func (m *memoryCache) Lock(_ context.Context, key string, _ time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.values[key]; ok {
+		return "", repository.ErrLockHeld
+	}
+	m.tokens++
+	token := strconv.Itoa(m.tokens)
+	m.values[key] = []byte(token)
+	return token, nil
+}
+
+func (m *memoryCache) Unlock(_ context.Context, key, token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if data, ok := m.values[key]; !ok || string(data) != token {
+		return repository.ErrLockNotHeld
+	}
+	delete(m.values, key)
+	return nil
+}
+
+func (m *memoryCache) Increment(_ context.Context, key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var counter int64
+	if data, ok := m.values[key]; ok {
+		if err := json.Unmarshal(data, &counter); err != nil {
+			return 0, err
+		}
+	}
+	counter++
+	m.values[key] = []byte(strconv.FormatInt(counter, 10))
+	return counter, nil
+}
+
+func (m *memoryCache) Expire(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (m *memoryCache) ListAppend(_ context.Context, key string, value interface{}, maxLen int64, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lists[key] = trimList(append(m.lists[key], data), maxLen)
+	return nil
+}
+
+func (m *memoryCache) ListPrepend(_ context.Context, key string, values []interface{}, maxLen int64, _ time.Duration) error {
+	entries := make([][]byte, 0, len(values))
+	for _, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, data)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lists[key] = trimList(append(entries, m.lists[key]...), maxLen)
+	return nil
+}
+
+func (m *memoryCache) ListRange(_ context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	entries := append([][]byte(nil), m.lists[key]...)
+	m.mu.Unlock()
+
+	return decodeEntries(entries, dest)
+}
+
+func (m *memoryCache) ListTake(_ context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	entries := m.lists[key]
+	delete(m.lists, key)
+	m.mu.Unlock()
+
+	return decodeEntries(entries, dest)
+}
+
+func (m *memoryCache) AddToSet(_ context.Context, key, member string, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sets[key] == nil {
+		m.sets[key] = map[string]bool{}
+	}
+	m.sets[key][member] = true
+	return nil
+}
+
+func (m *memoryCache) SetMembers(_ context.Context, key string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	members := make([]string, 0, len(m.sets[key]))
+	for member := range m.sets[key] {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (m *memoryCache) RemoveFromSet(_ context.Context, key string, members ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, member := range members {
+		delete(m.sets[key], member)
+	}
+	return nil
+}
+
+// trimList keeps the newest maxLen entries of a list.
+func trimList(entries [][]byte, maxLen int64) [][]byte {
+	if int64(len(entries)) > maxLen {
+		return entries[int64(len(entries))-maxLen:]
+	}
+	return entries
+}
+
+// decodeEntries decodes the JSON entries of a list into dest, a pointer to a slice.
+func decodeEntries(entries [][]byte, dest interface{}) error {
+	raw := make([]json.RawMessage, len(entries))
+	for i, entry := range entries {
+		raw[i] = entry
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// memoryUsers finds users by ID or email.
+type memoryUsers struct {
+	repository.UserRepository
+	users []*entity.User
+}
+
+func (m *memoryUsers) GetByID(_ context.Context, id entity.ID) (*entity.User, error) {
+	for _, user := range m.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *memoryUsers) GetByEmail(_ context.Context, email string) (*entity.User, error) {
+	for _, user := range m.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// sentMail is an email sent by the recording mailer.
+type sentMail struct {
+	to, subject, body string
+}
+
+// recordingMailer keeps the emails it sends.
+type recordingMailer struct {
+	sent []sentMail
+}
+
+func (m *recordingMailer) SendMail(_ context.Context, to, subject, body string) error {
+	m.sent = append(m.sent, sentMail{to: to, subject: subject, body: body})
+	return nil
+}
+
+func (m *recordingMailer) IsEnabled() bool { return true }
+
+func newUser(email string) *entity.User {
+	return &entity.User{ID: entity.NewID(), Email: email, Name: "Test User"}
+}
+
+func digestItem(title string, occurredAt time.Time) dto.DigestItem {
+	return dto.DigestItem{Kind: dto.DigestKindAssignment, Title: title, OccurredAt: occurredAt}
+}
+
+func TestDigestService_RecordAndDrain(t *testing.T) {
+	ctx := context.Background()
+	user := newUser("jane@example.com")
+	digests := service.NewDigestService(newMemoryCache(), &memoryUsers{users: []*entity.User{user}},
+		config.DigestConfig{MaxItems: 2}, nil)
+
+	start := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, digests.Record(ctx, user.ID, digestItem(fmt.Sprintf("Alert %d", i), start.Add(time.Duration(i)*time.Second))))
+	}
+
+	digest, err := digests.Drain(ctx, user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, digest)
+
+	// The oldest item is dropped beyond the limit
+	assert.Equal(t, 2, digest.Total)
+	assert.Equal(t, "Alert 1", digest.Items[0].Title)
+	assert.Equal(t, "Alert 2", digest.Items[1].Title)
+	assert.Equal(t, start.Add(time.Second), digest.Since)
+
+	digest, err = digests.Drain(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Nil(t, digest)
+}
+
+func TestDigestService_SendEmailDigests(t *testing.T) {
+	ctx := context.Background()
+	jane := newUser("jane@example.com")
+	john := newUser("john@example.com")
+	mailer := &recordingMailer{}
+	digests := service.NewDigestService(newMemoryCache(), &memoryUsers{users: []*entity.User{jane, john}},
+		config.DigestConfig{}, mailer)
+
+	start := time.Now().UTC()
+	require.NoError(t, digests.Record(ctx, jane.ID, digestItem("First", start)))
+	require.NoError(t, digests.Record(ctx, john.ID, digestItem("Other", start)))
+
+	sent, err := digests.SendEmailDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+
+	// Items already emailed are not sent again
+	sent, err = digests.SendEmailDigests(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	// Only the new items are sent, and drained digests are skipped
+	require.NoError(t, digests.Record(ctx, jane.ID, digestItem("Second", start.Add(time.Second))))
+	_, err = digests.Drain(ctx, john.ID)
+	require.NoError(t, err)
+
+	sent, err = digests.SendEmailDigests(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	last := mailer.sent[len(mailer.sent)-1]
+	assert.Equal(t, "jane@example.com", last.to)
+	assert.Contains(t, last.body, "Second")
+	assert.NotContains(t, last.body, "First")
+
+	// The emailed items are still shown on the next login
+	digest, err := digests.Drain(ctx, jane.ID)
+	require.NoError(t, err)
+	require.NotNil(t, digest)
+	assert.Equal(t, 2, digest.Total)
+}
+
+func TestDigestService_ConcurrentRecordsKeepEveryUser(t *testing.T) {
+	const users, perUser = 20, 10
+
+	ctx := context.Background()
+	mailer := &recordingMailer{}
+	repo := &memoryUsers{}
+	for i := 0; i < users; i++ {
+		repo.users = append(repo.users, newUser(fmt.Sprintf("user%d@example.com", i)))
+	}
+	digests := service.NewDigestService(newMemoryCache(), repo, config.DigestConfig{}, mailer)
+
+	var wg sync.WaitGroup
+	for _, user := range repo.users {
+		for j := 0; j < perUser; j++ {
+			wg.Add(1)
+			go func(userID entity.ID, j int) {
+				defer wg.Done()
+				assert.NoError(t, digests.Record(ctx, userID, digestItem(fmt.Sprintf("Alert %d", j), time.Time{})))
+			}(user.ID, j)
+		}
+	}
+	wg.Wait()
+
+	sent, err := digests.SendEmailDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, users, sent)
+
+	for _, mail := range mailer.sent {
+		assert.Contains(t, mail.subject, fmt.Sprintf("%d update(s)", perUser))
+		assert.Equal(t, perUser, strings.Count(mail.body, "- [assignment]"))
+	}
+}
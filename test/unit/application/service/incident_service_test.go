@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// memoryIncidents holds a single incident and its timeline.
+type memoryIncidents struct {
+	repository.IncidentRepository
+	incident *entity.Incident
+	events   []*entity.IncidentEvent
+}
+
+func (m *memoryIncidents) GetByID(_ context.Context, id entity.ID) (*entity.Incident, error) {
+	if m.incident == nil || m.incident.ID != id {
+		return nil, repository.ErrNotFound
+	}
+	return m.incident, nil
+}
+
+func (m *memoryIncidents) AddEvent(_ context.Context, event *entity.IncidentEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+// userEvent is an event published to a user.
+type userEvent struct {
+	userID entity.ID
+	item   dto.DigestItem
+}
+
+// recordingUserPublisher keeps the events published to users.
+type recordingUserPublisher struct {
+	events []userEvent
+}
+
+func (p *recordingUserPublisher) PublishToUser(_ context.Context, userID entity.ID, item dto.DigestItem) {
+	p.events = append(p.events, userEvent{userID: userID, item: item})
+}
+
+func TestIncidentService_AddNoteNotifiesMentions(t *testing.T) {
+	jane := newUser("jane@example.com")
+	john := newUser("john@example.com")
+	author := newUser("author@example.com")
+
+	incident, err := entity.NewIncident("Database outage", "", entity.AlertSeverityCritical, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		note     string
+		notified []entity.ID
+	}{
+		{
+			name:     "mentioned users",
+			note:     "@jane@example.com and @John@Example.com, please have a look.",
+			notified: []entity.ID{jane.ID, john.ID},
+		},
+		{
+			name:     "mentioned once",
+			note:     "@jane@example.com @jane@example.com",
+			notified: []entity.ID{jane.ID},
+		},
+		{
+			name: "unknown user",
+			note: "@nobody@example.com",
+		},
+		{
+			name: "author",
+			note: "Note to self @author@example.com",
+		},
+		{
+			name: "plain email",
+			note: "Mailed jane@example.com about it",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			publisher := &recordingUserPublisher{}
+			incidents := service.NewIncidentService(&memoryIncidents{incident: incident},
+				&memoryUsers{users: []*entity.User{jane, john, author}}, nil, nil)
+			incidents.SetUserNotifier(publisher)
+
+			event, err := incidents.AddNote(context.Background(), incident.ID, tc.note, &author.ID)
+			require.NoError(t, err)
+
+			notified := make([]entity.ID, len(publisher.events))
+			for i, published := range publisher.events {
+				notified[i] = published.userID
+				assert.Equal(t, dto.DigestKindMention, published.item.Kind)
+				assert.Equal(t, incident.ID.String(), published.item.IncidentID)
+				assert.Equal(t, tc.note, published.item.Message)
+				assert.Equal(t, event.CreatedAt, published.item.OccurredAt)
+			}
+			assert.ElementsMatch(t, tc.notified, notified)
+		})
+	}
+}
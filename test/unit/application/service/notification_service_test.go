@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// digestNotifier counts the notifications delivered in digests, failing
+// every third send.
+type digestNotifier struct {
+	mu        sync.Mutex
+	sends     int
+	delivered int
+}
+
+func (n *digestNotifier) Send(_ context.Context, msg notification.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.sends++
+	if n.sends%3 == 0 {
+		return errors.New("channel unavailable")
+	}
+
+	queued, err := strconv.Atoi(msg.Fields["Queued"])
+	if err != nil {
+		return err
+	}
+	n.delivered += queued
+	return nil
+}
+
+func (n *digestNotifier) Name() string    { return "slack" }
+func (n *digestNotifier) IsEnabled() bool { return true }
+
+// unlockedCache lets every flush through the once-per-interval lock.
+type unlockedCache struct {
+	*memoryCache
+}
+
+func (c unlockedCache) SetNX(context.Context, string, interface{}, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func TestNotificationService_BatchingConcurrentQueueAndFlush(t *testing.T) {
+	const senders, perSender = 8, 50
+
+	notifier := &digestNotifier{}
+	notifications := service.NewNotificationService(config.NotificationConfig{
+		MinSeverity:        "info",
+		RateLimitPerMinute: senders * perSender,
+	}, notifier)
+
+	batch, err := valueobject.NewNotificationBatch("slack", "critical", time.Minute)
+	require.NoError(t, err)
+	notifications.SetBatching(unlockedCache{newMemoryCache()}, batch)
+
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func(sender int) {
+			defer wg.Done()
+			for j := 0; j < perSender; j++ {
+				err := notifications.Notify(ctx, notification.Message{
+					Title:    fmt.Sprintf("Alert %d-%d", sender, j),
+					Severity: "low",
+					AlertID:  fmt.Sprintf("%d-%d", sender, j),
+				})
+				assert.NoError(t, err)
+			}
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Flush while notifications are being queued
+	for flushing := true; flushing; {
+		select {
+		case <-done:
+			flushing = false
+		default:
+			_, _ = notifications.FlushQueues(ctx)
+		}
+	}
+
+	// Drain what is left, including digests put back after a failed send
+	for i := 0; i < 10; i++ {
+		_, _ = notifications.FlushQueues(ctx)
+	}
+
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	assert.Equal(t, senders*perSender, notifier.delivered)
+}
@@ -0,0 +1,234 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+)
+
+// memoryRules returns a fixed set of enabled rules.
+type memoryRules struct {
+	repository.AlertRuleRepository
+	rules []*entity.AlertRule
+}
+
+func (m *memoryRules) ListEnabled(context.Context) ([]*entity.AlertRule, error) {
+	return m.rules, nil
+}
+
+// ruleAlerts stores the alerts rules fire, or fails with err.
+type ruleAlerts struct {
+	repository.AlertRepository
+	mu      sync.Mutex
+	created []*entity.Alert
+	err     error
+}
+
+func (r *ruleAlerts) Create(_ context.Context, alert *entity.Alert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.created = append(r.created, alert)
+	return nil
+}
+
+func newRule(condition entity.RuleCondition, cooldownMinutes int) *entity.AlertRule {
+	return &entity.AlertRule{
+		ID:              entity.NewID(),
+		Name:            "High CPU",
+		Condition:       condition,
+		Severity:        entity.AlertSeverityCritical,
+		IsEnabled:       true,
+		CooldownMinutes: cooldownMinutes,
+	}
+}
+
+func newRuleEngine(cache *memoryCache, alerts *ruleAlerts, rules ...*entity.AlertRule) *service.RuleEngine {
+	return service.NewRuleEngine(&memoryRules{rules: rules}, cache, service.NewAlertService(alerts, nil, nil))
+}
+
+func cpuSample(value float64, host string) service.MetricSample {
+	return service.MetricSample{Metric: "cpu", Value: value, Labels: map[string]string{"host": host}}
+}
+
+func TestRuleEngine_Matching(t *testing.T) {
+	threshold := entity.RuleCondition{Metric: "cpu", Operator: ">", Threshold: 90}
+
+	testCases := []struct {
+		name         string
+		condition    entity.RuleCondition
+		batches      [][]service.MetricSample
+		wantOutcomes []string
+		wantAlerts   int
+	}{
+		{
+			name:         "condition met",
+			condition:    threshold,
+			batches:      [][]service.MetricSample{{cpuSample(95, "a")}},
+			wantOutcomes: []string{service.RuleOutcomeFired},
+			wantAlerts:   1,
+		},
+		{
+			name:         "condition not met",
+			condition:    threshold,
+			batches:      [][]service.MetricSample{{cpuSample(50, "a")}},
+			wantOutcomes: []string{service.RuleOutcomeNotMet},
+		},
+		{
+			name:         "last sample of a series in a batch",
+			condition:    threshold,
+			batches:      [][]service.MetricSample{{cpuSample(95, "a"), cpuSample(50, "a")}},
+			wantOutcomes: []string{service.RuleOutcomeNotMet},
+		},
+		{
+			name:         "each series",
+			condition:    threshold,
+			batches:      [][]service.MetricSample{{cpuSample(95, "a"), cpuSample(50, "b")}},
+			wantOutcomes: []string{service.RuleOutcomeFired, service.RuleOutcomeNotMet},
+			wantAlerts:   1,
+		},
+		{
+			name:         "consecutive evaluations pending",
+			condition:    entity.RuleCondition{Metric: "cpu", Operator: ">", Threshold: 90, Consecutive: 2},
+			batches:      [][]service.MetricSample{{cpuSample(95, "a")}},
+			wantOutcomes: []string{service.RuleOutcomePending},
+		},
+		{
+			name:      "consecutive evaluations held",
+			condition: entity.RuleCondition{Metric: "cpu", Operator: ">", Threshold: 90, Consecutive: 2},
+			batches: [][]service.MetricSample{
+				{cpuSample(95, "a")},
+				{cpuSample(95, "a")},
+			},
+			wantOutcomes: []string{service.RuleOutcomeFired},
+			wantAlerts:   1,
+		},
+		{
+			name:      "consecutive evaluations interrupted",
+			condition: entity.RuleCondition{Metric: "cpu", Operator: ">", Threshold: 90, Consecutive: 2},
+			batches: [][]service.MetricSample{
+				{cpuSample(95, "a")},
+				{cpuSample(50, "a")},
+				{cpuSample(95, "a")},
+			},
+			wantOutcomes: []string{service.RuleOutcomePending},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alerts := &ruleAlerts{}
+			engine := newRuleEngine(newMemoryCache(), alerts, newRule(tc.condition, 0))
+
+			var evaluations []service.RuleEvaluation
+			for _, batch := range tc.batches {
+				var err error
+				evaluations, err = engine.Evaluate(context.Background(), batch)
+				require.NoError(t, err)
+			}
+
+			outcomes := make([]string, len(evaluations))
+			for i, evaluation := range evaluations {
+				outcomes[i] = evaluation.Outcome
+			}
+			assert.Equal(t, tc.wantOutcomes, outcomes)
+			assert.Len(t, alerts.created, tc.wantAlerts)
+		})
+	}
+}
+
+func TestRuleEngine_Cooldown(t *testing.T) {
+	alerts := &ruleAlerts{}
+	rule := newRule(entity.RuleCondition{Metric: "cpu", Operator: ">", Threshold: 90}, 5)
+	engine := newRuleEngine(newMemoryCache(), alerts, rule)
+
+	testCases := []struct {
+		name        string
+		sample      service.MetricSample
+		wantOutcome string
+	}{
+		{name: "first match fires", sample: cpuSample(95, "a"), wantOutcome: service.RuleOutcomeFired},
+		{name: "next match is in cooldown", sample: cpuSample(97, "a"), wantOutcome: service.RuleOutcomeCooldown},
+		{name: "other series fires", sample: cpuSample(95, "b"), wantOutcome: service.RuleOutcomeFired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			evaluations, err := engine.Evaluate(context.Background(), []service.MetricSample{tc.sample})
+			require.NoError(t, err)
+			require.Len(t, evaluations, 1)
+			assert.Equal(t, tc.wantOutcome, evaluations[0].Outcome)
+		})
+	}
+
+	assert.Len(t, alerts.created, 2)
+}
+
+func TestRuleEngine_CooldownReleasedWhenCreateFails(t *testing.T) {
+	failure := errors.New("database unavailable")
+	alerts := &ruleAlerts{err: failure}
+	rule := newRule(entity.RuleCondition{Metric: "cpu", Operator: ">", Threshold: 90}, 5)
+	engine := newRuleEngine(newMemoryCache(), alerts, rule)
+
+	_, err := engine.Evaluate(context.Background(), []service.MetricSample{cpuSample(95, "a")})
+	require.ErrorIs(t, err, failure)
+
+	alerts.err = nil
+	evaluations, err := engine.Evaluate(context.Background(), []service.MetricSample{cpuSample(95, "a")})
+	require.NoError(t, err)
+	require.Len(t, evaluations, 1)
+	assert.Equal(t, service.RuleOutcomeFired, evaluations[0].Outcome)
+	assert.Len(t, alerts.created, 1)
+}
+
+func TestRuleEngine_HistoryKeepsConcurrentSamples(t *testing.T) {
+	cache := newMemoryCache()
+	alerts := &ruleAlerts{}
+	rule := newRule(entity.RuleCondition{
+		Type:          entity.ConditionAnomaly,
+		Metric:        "cpu",
+		Operator:      ">",
+		Deviations:    3,
+		WindowSeconds: 600,
+	}, 0)
+	engine := newRuleEngine(cache, alerts, rule)
+
+	const samples = 2 * entity.MinBaselineSamples
+	var wg sync.WaitGroup
+	for i := 0; i < samples; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := engine.Evaluate(context.Background(), []service.MetricSample{cpuSample(40, "a")})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	var lists [][][]byte
+	for key, entries := range cache.lists {
+		if strings.HasPrefix(key, "rule_samples:") {
+			lists = append(lists, entries)
+		}
+	}
+	require.Len(t, lists, 1)
+	assert.Len(t, lists[0], samples)
+	assert.Empty(t, alerts.created)
+
+	// The baseline is built from every sample recorded so far
+	evaluations, err := engine.Evaluate(context.Background(), []service.MetricSample{cpuSample(95, "a")})
+	require.NoError(t, err)
+	require.Len(t, evaluations, 1)
+	assert.Equal(t, service.RuleOutcomeFired, evaluations[0].Outcome)
+}
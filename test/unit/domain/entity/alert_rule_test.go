@@ -1,6 +1,7 @@
 package entity_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -79,6 +80,36 @@ func TestNewAlertRule_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestAlertRule_Validate_ExternalID(t *testing.T) {
+	rule, err := entity.NewAlertRule("High CPU", "", entity.RuleCondition{Metric: "cpu", Operator: ">", Threshold: 90}, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+
+	rule.ExternalID = "prod/high-cpu"
+	assert.NoError(t, rule.Validate())
+
+	rule.ExternalID = strings.Repeat("x", 256)
+	assert.ErrorIs(t, rule.Validate(), entity.ErrRuleExternalIDTooLong)
+}
+
+func TestAlertRule_Validate_Runbook(t *testing.T) {
+	rule, err := entity.NewAlertRule("High CPU", "", entity.RuleCondition{Metric: "cpu", Operator: ">", Threshold: 90}, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+
+	rule.RunbookURL = "https://wiki.example.com/runbooks/high-cpu"
+	rule.Remediation = "Restart the worker pool"
+	assert.NoError(t, rule.Validate())
+
+	rule.RunbookURL = "wiki/runbooks/high-cpu"
+	assert.ErrorIs(t, rule.Validate(), entity.ErrRuleInvalidRunbookURL)
+
+	rule.RunbookURL = "ftp://wiki.example.com/high-cpu"
+	assert.ErrorIs(t, rule.Validate(), entity.ErrRuleInvalidRunbookURL)
+
+	rule.RunbookURL = ""
+	rule.Remediation = strings.Repeat("x", 4001)
+	assert.ErrorIs(t, rule.Validate(), entity.ErrRuleRemediationTooLong)
+}
+
 func TestAlertRule_Evaluate(t *testing.T) {
 	testCases := []struct {
 		name      string
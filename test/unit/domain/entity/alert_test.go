@@ -159,6 +159,32 @@ func TestAlert_Resolve_AlreadyResolved(t *testing.T) {
 	assert.ErrorIs(t, err, entity.ErrAlertAlreadyResolved)
 }
 
+func TestAlert_ResolveBySource(t *testing.T) {
+	// Arrange
+	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityMedium, "source")
+
+	// Act
+	err := alert.ResolveBySource()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, entity.AlertStatusResolved, alert.Status)
+	assert.Nil(t, alert.ResolvedBy)
+	assert.NotNil(t, alert.ResolvedAt)
+}
+
+func TestAlert_ResolveBySource_AlreadyResolved(t *testing.T) {
+	// Arrange
+	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityMedium, "source")
+	_ = alert.ResolveBySource()
+
+	// Act
+	err := alert.ResolveBySource()
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrAlertAlreadyResolved)
+}
+
 func TestAlert_Expire(t *testing.T) {
 	// Arrange
 	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityMedium, "source")
@@ -188,6 +214,19 @@ func TestAlert_IsExpired(t *testing.T) {
 	assert.True(t, alert.IsExpired())
 }
 
+func TestAlert_IsDeleted(t *testing.T) {
+	// Arrange
+	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityMedium, "source")
+
+	// Not deleted by default
+	assert.False(t, alert.IsDeleted())
+
+	// Moved to the trash
+	deletedAt := time.Now()
+	alert.DeletedAt = &deletedAt
+	assert.True(t, alert.IsDeleted())
+}
+
 func TestAlert_AddMetadata(t *testing.T) {
 	// Arrange
 	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityMedium, "source")
@@ -230,3 +269,106 @@ func TestAlert_NeedsImmediateAttention(t *testing.T) {
 		})
 	}
 }
+
+func TestAlert_SetOrigin(t *testing.T) {
+	t.Run("stamps empty origin", func(t *testing.T) {
+		alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityHigh, "source")
+
+		alert.SetOrigin("eu-west-1", "primary")
+
+		assert.Equal(t, "eu-west-1", alert.Region)
+		assert.Equal(t, "primary", alert.Cluster)
+	})
+
+	t.Run("keeps existing origin", func(t *testing.T) {
+		alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityHigh, "source")
+		alert.Region = "us-east-1"
+
+		alert.SetOrigin("eu-west-1", "primary")
+
+		assert.Equal(t, "us-east-1", alert.Region)
+		assert.Equal(t, "primary", alert.Cluster)
+	})
+}
+
+func TestAlert_Fingerprint(t *testing.T) {
+	t.Run("uses the dedup key", func(t *testing.T) {
+		alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityHigh, "source")
+		alert.AddMetadata(entity.MetadataDedupKey, "disk-full-db1")
+
+		assert.Equal(t, "disk-full-db1", alert.Fingerprint())
+	})
+
+	t.Run("falls back to source and title", func(t *testing.T) {
+		a, _ := entity.NewAlert("Test", "First", entity.AlertSeverityHigh, "source")
+		b, _ := entity.NewAlert("Test", "Second", entity.AlertSeverityLow, "source")
+		c, _ := entity.NewAlert("Other", "First", entity.AlertSeverityHigh, "source")
+
+		assert.Equal(t, a.Fingerprint(), b.Fingerprint())
+		assert.NotEqual(t, a.Fingerprint(), c.Fingerprint())
+	})
+}
+
+func TestAlert_MarkFlapping(t *testing.T) {
+	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityHigh, "source")
+	assert.False(t, alert.IsFlapping())
+
+	alert.MarkFlapping()
+
+	assert.True(t, alert.IsFlapping())
+}
+
+func TestAlert_MarkDownstream(t *testing.T) {
+	upstream, _ := entity.NewAlert("Database down", "Message", entity.AlertSeverityCritical, "orders-db")
+	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityHigh, "checkout-api")
+	assert.False(t, alert.IsDownstream())
+
+	alert.MarkDownstream(upstream)
+
+	assert.True(t, alert.IsDownstream())
+	assert.Equal(t, upstream.ID.String(), alert.Metadata[entity.MetadataUpstreamAlertID])
+}
+
+func TestAlert_Runbook(t *testing.T) {
+	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityHigh, "source")
+	assert.Empty(t, alert.RunbookURL())
+	assert.Empty(t, alert.Remediation())
+
+	alert.AddMetadata(entity.MetadataRunbookURL, "https://wiki.example.com/runbooks/test")
+	alert.AddMetadata(entity.MetadataRemediation, "Restart the service")
+
+	assert.Equal(t, "https://wiki.example.com/runbooks/test", alert.RunbookURL())
+	assert.Equal(t, "Restart the service", alert.Remediation())
+}
+
+func TestAlert_ExternalIncidents(t *testing.T) {
+	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityHigh, "source")
+	assert.Nil(t, alert.ExternalIncidents())
+
+	// Decoded from JSON, as loaded from the database
+	alert.AddMetadata(entity.MetadataExternalIncidents, map[string]interface{}{
+		"opsgenie":      "70413a06-38d6-4c85-92b8-5ebc900d42e2",
+		"splunk_oncall": alert.ID.String(),
+	})
+
+	assert.Equal(t, map[string]string{
+		"opsgenie":      "70413a06-38d6-4c85-92b8-5ebc900d42e2",
+		"splunk_oncall": alert.ID.String(),
+	}, alert.ExternalIncidents())
+}
+
+func TestAlert_TicketKey(t *testing.T) {
+	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityHigh, "source")
+	assert.Empty(t, alert.TicketKey())
+
+	alert.AddMetadata(entity.MetadataTicketKey, "OPS-123")
+	assert.Equal(t, "OPS-123", alert.TicketKey())
+}
+
+func TestAlert_IsVoiceEscalated(t *testing.T) {
+	alert, _ := entity.NewAlert("Test", "Message", entity.AlertSeverityCritical, "source")
+	assert.False(t, alert.IsVoiceEscalated())
+
+	alert.AddMetadata(entity.MetadataVoiceEscalation, map[string]interface{}{"to": "+14155550100"})
+	assert.True(t, alert.IsVoiceEscalated())
+}
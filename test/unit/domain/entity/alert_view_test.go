@@ -0,0 +1,76 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewAlertView_Success(t *testing.T) {
+	// Arrange
+	ownerID := entity.NewID()
+	teamID := entity.NewID()
+	criteria := entity.AlertViewCriteria{
+		Statuses:   []entity.AlertStatus{entity.AlertStatusActive},
+		Severities: []entity.AlertSeverity{entity.AlertSeverityCritical, entity.AlertSeverityHigh},
+		Labels:     map[string]string{"service": "payments"},
+		Search:     "timeout",
+	}
+
+	// Act
+	view, err := entity.NewAlertView("Payments on fire", criteria, ownerID, &teamID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, view.ID)
+	assert.Equal(t, "Payments on fire", view.Name)
+	assert.Equal(t, criteria, view.Criteria)
+	assert.Equal(t, ownerID, view.OwnerID)
+	assert.Equal(t, &teamID, view.TeamID)
+	assert.True(t, view.IsShared())
+	assert.False(t, view.CreatedAt.IsZero())
+}
+
+func TestNewAlertView_Private(t *testing.T) {
+	view, err := entity.NewAlertView("Everything", entity.AlertViewCriteria{}, entity.NewID(), nil)
+
+	require.NoError(t, err)
+	assert.False(t, view.IsShared())
+}
+
+func TestNewAlertView_ValidationErrors(t *testing.T) {
+	tooManyLabels := make(map[string]string)
+	for i := 0; i <= entity.AlertViewMaxLabels; i++ {
+		tooManyLabels[strings.Repeat("k", i+1)] = "v"
+	}
+
+	testCases := []struct {
+		name     string
+		viewName string
+		criteria entity.AlertViewCriteria
+		expected error
+	}{
+		{"empty name", "", entity.AlertViewCriteria{}, entity.ErrAlertViewNameRequired},
+		{"name too long", strings.Repeat("a", 101), entity.AlertViewCriteria{}, entity.ErrAlertViewNameTooLong},
+		{"invalid status", "View", entity.AlertViewCriteria{Statuses: []entity.AlertStatus{"open"}}, entity.ErrAlertViewInvalidStatus},
+		{"invalid severity", "View", entity.AlertViewCriteria{Severities: []entity.AlertSeverity{"urgent"}}, entity.ErrAlertViewInvalidSeverity},
+		{"empty label name", "View", entity.AlertViewCriteria{Labels: map[string]string{"": "x"}}, entity.ErrAlertViewInvalidLabels},
+		{"too many labels", "View", entity.AlertViewCriteria{Labels: tooManyLabels}, entity.ErrAlertViewInvalidLabels},
+		{"search too long", "View", entity.AlertViewCriteria{Search: strings.Repeat("a", 256)}, entity.ErrAlertViewSearchTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			view, err := entity.NewAlertView(tc.viewName, tc.criteria, entity.NewID(), nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, view)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewAssignmentRule_Success(t *testing.T) {
+	// Arrange
+	teamID := entity.NewID()
+	match := entity.AssignmentRuleMatcher{Labels: map[string]string{"service": "payments"}}
+
+	// Act
+	rule, err := entity.NewAssignmentRule("payments", match, &teamID, nil, 0, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, rule.ID)
+	assert.True(t, rule.IsEnabled)
+	assert.Equal(t, &teamID, rule.TeamID)
+}
+
+func TestNewAssignmentRule_ValidationErrors(t *testing.T) {
+	teamID := entity.NewID()
+	payments := entity.AssignmentRuleMatcher{Sources: []string{"payments-api"}}
+
+	testCases := []struct {
+		name     string
+		ruleName string
+		match    entity.AssignmentRuleMatcher
+		teamID   *entity.ID
+		expected error
+	}{
+		{"empty name", "", payments, &teamID, entity.ErrAssignmentRuleNameRequired},
+		{"name too long", strings.Repeat("a", 101), payments, &teamID, entity.ErrAssignmentRuleNameTooLong},
+		{"empty match", "rule", entity.AssignmentRuleMatcher{}, &teamID, entity.ErrAssignmentRuleMatchRequired},
+		{
+			"empty label name", "rule",
+			entity.AssignmentRuleMatcher{Labels: map[string]string{"": "x"}},
+			&teamID, entity.ErrAssignmentRuleInvalidLabels,
+		},
+		{"no assignee", "rule", payments, nil, entity.ErrAssignmentRuleAssigneeRequired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			rule, err := entity.NewAssignmentRule(tc.ruleName, tc.match, tc.teamID, nil, 0, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, rule)
+		})
+	}
+}
+
+func TestAlert_Assign(t *testing.T) {
+	t.Run("assigns team and user", func(t *testing.T) {
+		// Arrange
+		alert, err := entity.NewAlert("Latency", "p99 above 2s", entity.AlertSeverityHigh, "payments-api")
+		require.NoError(t, err)
+		teamID, userID := entity.NewID(), entity.NewID()
+
+		// Act
+		alert.Assign(&teamID, &userID, "payments")
+
+		// Assert
+		assert.Equal(t, &teamID, alert.TeamID)
+		assert.Equal(t, &userID, alert.AssigneeID())
+		assert.Equal(t, "payments", alert.Metadata[entity.MetadataAssignmentRule])
+	})
+
+	t.Run("keeps the team set by the sender", func(t *testing.T) {
+		// Arrange
+		alert, err := entity.NewAlert("Latency", "p99 above 2s", entity.AlertSeverityHigh, "payments-api")
+		require.NoError(t, err)
+		senderTeam, ruleTeam := entity.NewID(), entity.NewID()
+		alert.TeamID = &senderTeam
+
+		// Act
+		alert.Assign(&ruleTeam, nil, "payments")
+
+		// Assert
+		assert.Equal(t, &senderTeam, alert.TeamID)
+		assert.Nil(t, alert.AssigneeID())
+	})
+}
@@ -0,0 +1,122 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func newTestCheck(t *testing.T, failureThreshold int) *entity.Check {
+	t.Helper()
+	check, err := entity.NewCheck("api", "https://api.example.com/health", "GET", 200, 60, 10,
+		failureThreshold, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	return check
+}
+
+func TestNewCheck_Success(t *testing.T) {
+	// Act
+	check := newTestCheck(t, 3)
+
+	// Assert
+	assert.NotEqual(t, entity.ID{}, check.ID)
+	assert.Equal(t, entity.CheckStatusUnknown, check.Status)
+	assert.True(t, check.IsEnabled)
+	assert.Nil(t, check.LastCheckedAt)
+	assert.Equal(t, "check:"+check.ID.String(), check.DedupKey())
+}
+
+func TestNewCheck_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modify   func(c *entity.Check)
+		expected error
+	}{
+		{"empty name", func(c *entity.Check) { c.Name = "" }, entity.ErrCheckNameRequired},
+		{"name too long", func(c *entity.Check) { c.Name = strings.Repeat("a", 101) }, entity.ErrCheckNameTooLong},
+		{"relative URL", func(c *entity.Check) { c.URL = "/health" }, entity.ErrCheckInvalidURL},
+		{"unsupported scheme", func(c *entity.Check) { c.URL = "ftp://example.com" }, entity.ErrCheckInvalidURL},
+		{"invalid method", func(c *entity.Check) { c.Method = "DELETE" }, entity.ErrCheckInvalidMethod},
+		{"invalid status", func(c *entity.Check) { c.ExpectedStatus = 600 }, entity.ErrCheckInvalidStatus},
+		{"interval too short", func(c *entity.Check) { c.IntervalSeconds = 10 }, entity.ErrCheckInvalidInterval},
+		{"timeout too long", func(c *entity.Check) { c.TimeoutSeconds = 61 }, entity.ErrCheckInvalidTimeout},
+		{"timeout not shorter than interval", func(c *entity.Check) {
+			c.IntervalSeconds = 30
+			c.TimeoutSeconds = 30
+		}, entity.ErrCheckInvalidTimeout},
+		{"threshold too high", func(c *entity.Check) { c.FailureThreshold = 11 }, entity.ErrCheckInvalidThreshold},
+		{"invalid severity", func(c *entity.Check) { c.Severity = "urgent" }, entity.ErrCheckInvalidSeverity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			check := newTestCheck(t, 1)
+			tc.modify(check)
+
+			// Act & Assert
+			assert.ErrorIs(t, check.Validate(), tc.expected)
+		})
+	}
+}
+
+func TestCheck_IsDue(t *testing.T) {
+	// Arrange
+	check := newTestCheck(t, 1)
+	now := time.Now()
+
+	// Act & Assert
+	assert.True(t, check.IsDue(now), "a check that never ran is due")
+
+	check.Record(entity.CheckResult{Success: true, CheckedAt: now})
+	assert.False(t, check.IsDue(now.Add(59*time.Second)))
+	assert.True(t, check.IsDue(now.Add(60*time.Second)))
+
+	check.Disable()
+	assert.False(t, check.IsDue(now.Add(time.Hour)))
+}
+
+func TestCheck_Record(t *testing.T) {
+	// Arrange
+	check := newTestCheck(t, 3)
+	now := time.Now()
+	failure := entity.CheckResult{Success: false, CheckedAt: now}
+	success := entity.CheckResult{Success: true, CheckedAt: now}
+
+	// Act & Assert: down after three consecutive failures, once
+	assert.Equal(t, entity.CheckUnchanged, check.Record(success))
+	assert.Equal(t, entity.CheckStatusUp, check.Status)
+
+	assert.Equal(t, entity.CheckUnchanged, check.Record(failure))
+	assert.Equal(t, entity.CheckUnchanged, check.Record(failure))
+	assert.Equal(t, entity.CheckStatusUp, check.Status)
+	assert.Equal(t, entity.CheckWentDown, check.Record(failure))
+	assert.Equal(t, entity.CheckStatusDown, check.Status)
+	assert.Equal(t, entity.CheckUnchanged, check.Record(failure))
+	assert.Equal(t, 4, check.ConsecutiveFailures)
+
+	assert.Equal(t, entity.CheckRecovered, check.Record(success))
+	assert.Equal(t, entity.CheckStatusUp, check.Status)
+	assert.Zero(t, check.ConsecutiveFailures)
+	assert.Equal(t, now, *check.LastCheckedAt)
+}
+
+func TestCheck_Record_FailureResetBySuccess(t *testing.T) {
+	// Arrange
+	check := newTestCheck(t, 2)
+	now := time.Now()
+
+	// Act
+	check.Record(entity.CheckResult{Success: false, CheckedAt: now})
+	check.Record(entity.CheckResult{Success: true, CheckedAt: now})
+	transition := check.Record(entity.CheckResult{Success: false, CheckedAt: now})
+
+	// Assert
+	assert.Equal(t, entity.CheckUnchanged, transition)
+	assert.Equal(t, entity.CheckStatusUp, check.Status)
+}
@@ -0,0 +1,49 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewFailedEvent(t *testing.T) {
+	e := entity.NewFailedEvent("evt-1", "alert.created", json.RawMessage(`{"id":"a"}`), 3, "boom")
+
+	assert.Equal(t, "evt-1", e.EventID)
+	assert.Equal(t, entity.FailedEventStatusPending, e.Status)
+	assert.Equal(t, "boom", e.LastError)
+	assert.Nil(t, e.ProcessedAt)
+}
+
+func TestFailedEvent_MarkIgnored(t *testing.T) {
+	e := entity.NewFailedEvent("evt-1", "alert.created", nil, 3, "")
+
+	require.NoError(t, e.MarkIgnored())
+	assert.Equal(t, entity.FailedEventStatusIgnored, e.Status)
+	assert.NotNil(t, e.ProcessedAt)
+
+	assert.ErrorIs(t, e.MarkIgnored(), entity.ErrFailedEventNotPending)
+}
+
+func TestFailedEvent_MarkRetried(t *testing.T) {
+	e := entity.NewFailedEvent("evt-1", "alert.created", nil, 3, "")
+	require.NoError(t, e.MarkIgnored())
+
+	// Ignored events may still be retried, but only once
+	require.NoError(t, e.MarkRetried())
+	assert.Equal(t, entity.FailedEventStatusRetried, e.Status)
+
+	assert.ErrorIs(t, e.MarkRetried(), entity.ErrFailedEventAlreadyRetried)
+	assert.ErrorIs(t, e.MarkIgnored(), entity.ErrFailedEventNotPending)
+}
+
+func TestFailedEventStatus_IsValid(t *testing.T) {
+	assert.True(t, entity.FailedEventStatusPending.IsValid())
+	assert.True(t, entity.FailedEventStatusRetried.IsValid())
+	assert.True(t, entity.FailedEventStatusIgnored.IsValid())
+	assert.False(t, entity.FailedEventStatus("processed").IsValid())
+}
@@ -0,0 +1,127 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewHeartbeat_Success(t *testing.T) {
+	// Act
+	heartbeat, err := entity.NewHeartbeat("nightly-backup", "hash", 3600, 300, entity.AlertSeverityHigh, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, heartbeat.ID)
+	assert.Equal(t, entity.HeartbeatStatusNew, heartbeat.Status)
+	assert.True(t, heartbeat.IsEnabled)
+	assert.Nil(t, heartbeat.LastPingAt)
+}
+
+func TestNewHeartbeat_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hbName   string
+		interval int
+		grace    int
+		severity entity.AlertSeverity
+		expected error
+	}{
+		{"empty name", "", 3600, 0, entity.AlertSeverityHigh, entity.ErrHeartbeatNameRequired},
+		{"name too long", strings.Repeat("a", 101), 3600, 0, entity.AlertSeverityHigh, entity.ErrHeartbeatNameTooLong},
+		{"interval too short", "job", 59, 0, entity.AlertSeverityHigh, entity.ErrHeartbeatInvalidInterval},
+		{"interval too long", "job", entity.MaxHeartbeatInterval + 1, 0, entity.AlertSeverityHigh, entity.ErrHeartbeatInvalidInterval},
+		{"negative grace", "job", 3600, -1, entity.AlertSeverityHigh, entity.ErrHeartbeatInvalidGrace},
+		{"invalid severity", "job", 3600, 0, entity.AlertSeverity("urgent"), entity.ErrHeartbeatInvalidSeverity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			heartbeat, err := entity.NewHeartbeat(tc.hbName, "hash", tc.interval, tc.grace, tc.severity, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, heartbeat)
+		})
+	}
+}
+
+func TestHeartbeat_Deadline(t *testing.T) {
+	// Arrange
+	heartbeat, err := entity.NewHeartbeat("job", "hash", 600, 60, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	pingedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Act & Assert: no deadline before the first ping
+	_, ok := heartbeat.Deadline()
+	assert.False(t, ok)
+	assert.False(t, heartbeat.IsOverdue(pingedAt.Add(24*time.Hour)))
+
+	heartbeat.Ping(pingedAt)
+	deadline, ok := heartbeat.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, pingedAt.Add(11*time.Minute), deadline)
+	assert.False(t, heartbeat.IsOverdue(deadline))
+	assert.True(t, heartbeat.IsOverdue(deadline.Add(time.Second)))
+
+	heartbeat.Disable()
+	assert.False(t, heartbeat.IsOverdue(deadline.Add(time.Second)))
+}
+
+func TestHeartbeat_PingRecovers(t *testing.T) {
+	// Arrange
+	heartbeat, err := entity.NewHeartbeat("job", "hash", 600, 0, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	now := time.Now()
+
+	// Act & Assert
+	assert.False(t, heartbeat.Ping(now))
+	assert.Equal(t, entity.HeartbeatStatusUp, heartbeat.Status)
+
+	heartbeat.MarkDown()
+	assert.False(t, heartbeat.IsOverdue(now.Add(time.Hour)), "a down heartbeat is not overdue again")
+
+	assert.True(t, heartbeat.Ping(now.Add(time.Hour)))
+	assert.Equal(t, entity.HeartbeatStatusUp, heartbeat.Status)
+	assert.Equal(t, now.Add(time.Hour), *heartbeat.LastPingAt)
+}
+
+func TestHeartbeat_Enable(t *testing.T) {
+	t.Run("up heartbeat waits for its next ping", func(t *testing.T) {
+		heartbeat, err := entity.NewHeartbeat("job", "hash", 600, 0, entity.AlertSeverityHigh, nil)
+		require.NoError(t, err)
+		heartbeat.Ping(time.Now().Add(-time.Hour))
+		heartbeat.Disable()
+
+		heartbeat.Enable()
+
+		assert.True(t, heartbeat.IsEnabled)
+		assert.Equal(t, entity.HeartbeatStatusNew, heartbeat.Status)
+		assert.False(t, heartbeat.IsOverdue(time.Now()))
+	})
+
+	t.Run("down heartbeat stays down", func(t *testing.T) {
+		heartbeat, err := entity.NewHeartbeat("job", "hash", 600, 0, entity.AlertSeverityHigh, nil)
+		require.NoError(t, err)
+		heartbeat.Ping(time.Now().Add(-time.Hour))
+		heartbeat.MarkDown()
+		heartbeat.Disable()
+
+		heartbeat.Enable()
+
+		assert.Equal(t, entity.HeartbeatStatusDown, heartbeat.Status)
+	})
+}
+
+func TestHeartbeat_DedupKey(t *testing.T) {
+	heartbeat, err := entity.NewHeartbeat("job", "hash", 600, 0, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "heartbeat:"+heartbeat.ID.String(), heartbeat.DedupKey())
+}
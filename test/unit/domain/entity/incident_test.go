@@ -0,0 +1,113 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func newTestIncident(t *testing.T, severity entity.AlertSeverity) *entity.Incident {
+	t.Helper()
+	incident, err := entity.NewIncident("Checkout is down", "", severity, nil)
+	require.NoError(t, err)
+	return incident
+}
+
+func TestNewIncident_Success(t *testing.T) {
+	// Act
+	incident := newTestIncident(t, entity.AlertSeverityHigh)
+
+	// Assert
+	assert.NotEqual(t, entity.ID{}, incident.ID)
+	assert.Equal(t, entity.IncidentStatusOpen, incident.Status)
+	assert.Nil(t, incident.CommanderID)
+	assert.Nil(t, incident.MitigatedAt)
+	assert.Nil(t, incident.ClosedAt)
+}
+
+func TestNewIncident_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		title    string
+		summary  string
+		severity entity.AlertSeverity
+		expected error
+	}{
+		{"empty title", "", "", entity.AlertSeverityHigh, entity.ErrIncidentTitleRequired},
+		{"title too long", strings.Repeat("a", 256), "", entity.AlertSeverityHigh, entity.ErrIncidentTitleTooLong},
+		{"summary too long", "Down", strings.Repeat("a", 10001), entity.AlertSeverityHigh, entity.ErrIncidentSummaryTooLong},
+		{"invalid severity", "Down", "", entity.AlertSeverity("urgent"), entity.ErrIncidentInvalidSeverity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			_, err := entity.NewIncident(tc.title, tc.summary, tc.severity, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestIncident_Lifecycle(t *testing.T) {
+	// Arrange
+	incident := newTestIncident(t, entity.AlertSeverityHigh)
+
+	// Act & Assert: open -> mitigated
+	require.NoError(t, incident.Mitigate())
+	assert.Equal(t, entity.IncidentStatusMitigated, incident.Status)
+	assert.NotNil(t, incident.MitigatedAt)
+	assert.ErrorIs(t, incident.Mitigate(), entity.ErrIncidentNotOpen)
+
+	// mitigated -> closed
+	require.NoError(t, incident.Close())
+	assert.True(t, incident.IsClosed())
+	assert.NotNil(t, incident.ClosedAt)
+	assert.ErrorIs(t, incident.Close(), entity.ErrIncidentClosed)
+
+	// closed -> open
+	require.NoError(t, incident.Reopen())
+	assert.Equal(t, entity.IncidentStatusOpen, incident.Status)
+	assert.Nil(t, incident.ClosedAt)
+	assert.ErrorIs(t, incident.Reopen(), entity.ErrIncidentNotReopenable)
+}
+
+func TestIncident_AssignCommander(t *testing.T) {
+	// Arrange
+	incident := newTestIncident(t, entity.AlertSeverityHigh)
+	commanderID := entity.NewID()
+
+	// Act & Assert
+	require.NoError(t, incident.AssignCommander(&commanderID))
+	assert.Equal(t, &commanderID, incident.CommanderID)
+
+	require.NoError(t, incident.AssignCommander(nil))
+	assert.Nil(t, incident.CommanderID)
+
+	require.NoError(t, incident.Close())
+	assert.ErrorIs(t, incident.AssignCommander(&commanderID), entity.ErrIncidentClosed)
+}
+
+func TestIncident_RaiseSeverity(t *testing.T) {
+	// Arrange
+	incident := newTestIncident(t, entity.AlertSeverityMedium)
+
+	// Act & Assert
+	assert.False(t, incident.RaiseSeverity(entity.AlertSeverityLow))
+	assert.False(t, incident.RaiseSeverity(entity.AlertSeverityMedium))
+	assert.Equal(t, entity.AlertSeverityMedium, incident.Severity)
+
+	assert.True(t, incident.RaiseSeverity(entity.AlertSeverityCritical))
+	assert.Equal(t, entity.AlertSeverityCritical, incident.Severity)
+}
+
+func TestValidateIncidentNote(t *testing.T) {
+	assert.NoError(t, entity.ValidateIncidentNote("Rolled back the deploy"))
+	assert.ErrorIs(t, entity.ValidateIncidentNote(""), entity.ErrIncidentNoteRequired)
+	assert.ErrorIs(t, entity.ValidateIncidentNote(strings.Repeat("a", 10001)), entity.ErrIncidentNoteTooLong)
+}
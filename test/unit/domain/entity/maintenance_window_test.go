@@ -0,0 +1,84 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewMaintenanceWindow_Success(t *testing.T) {
+	// Arrange
+	location := time.FixedZone("UTC+2", 2*60*60)
+	startsAt := time.Date(2026, 3, 1, 22, 0, 0, 0, location)
+
+	// Act
+	window, err := entity.NewMaintenanceWindow("Database upgrade", "Postgres 17", startsAt, startsAt.Add(2*time.Hour), nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, window.ID)
+	assert.Equal(t, time.UTC, window.StartsAt.Location())
+	assert.True(t, window.StartsAt.Equal(startsAt))
+	assert.Equal(t, 2*time.Hour, window.EndsAt.Sub(window.StartsAt))
+}
+
+func TestNewMaintenanceWindow_ValidationErrors(t *testing.T) {
+	startsAt := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		title       string
+		description string
+		duration    time.Duration
+		expected    error
+	}{
+		{"empty title", "", "", time.Hour, entity.ErrMaintenanceTitleRequired},
+		{"title too long", strings.Repeat("a", 256), "", time.Hour, entity.ErrMaintenanceTitleTooLong},
+		{"description too long", "Upgrade", strings.Repeat("a", 10001), time.Hour, entity.ErrMaintenanceDescriptionTooLong},
+		{"ends when it starts", "Upgrade", "", 0, entity.ErrMaintenanceInvalidPeriod},
+		{"ends before it starts", "Upgrade", "", -time.Hour, entity.ErrMaintenanceInvalidPeriod},
+		{"too long", "Upgrade", "", entity.MaxMaintenanceDuration + time.Minute, entity.ErrMaintenanceInvalidPeriod},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			window, err := entity.NewMaintenanceWindow(tc.title, tc.description, startsAt, startsAt.Add(tc.duration), nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, window)
+		})
+	}
+}
+
+func TestMaintenanceWindow_Status(t *testing.T) {
+	// Arrange
+	startsAt := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	window, err := entity.NewMaintenanceWindow("Database upgrade", "", startsAt, startsAt.Add(2*time.Hour), nil)
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.Equal(t, entity.MaintenanceStatusScheduled, window.Status(startsAt.Add(-time.Second)))
+	assert.Equal(t, entity.MaintenanceStatusActive, window.Status(startsAt))
+	assert.Equal(t, entity.MaintenanceStatusActive, window.Status(startsAt.Add(time.Hour)))
+	assert.Equal(t, entity.MaintenanceStatusCompleted, window.Status(startsAt.Add(2*time.Hour)))
+}
+
+func TestMaintenanceWindow_Overlaps(t *testing.T) {
+	// Arrange
+	startsAt := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	window, err := entity.NewMaintenanceWindow("Database upgrade", "", startsAt, startsAt.Add(2*time.Hour), nil)
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.True(t, window.Overlaps(startsAt.Add(-time.Hour), startsAt.Add(time.Minute)))
+	assert.True(t, window.Overlaps(startsAt.Add(time.Hour), startsAt.Add(24*time.Hour)))
+	assert.False(t, window.Overlaps(startsAt.Add(-time.Hour), startsAt), "ends when the window starts")
+	assert.False(t, window.Overlaps(startsAt.Add(2*time.Hour), startsAt.Add(3*time.Hour)), "starts when the window ends")
+}
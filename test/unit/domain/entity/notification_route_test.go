@@ -0,0 +1,59 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+)
+
+func TestNewNotificationRoute_ValidationErrors(t *testing.T) {
+	_, err := entity.NewNotificationRoute("", nil, entity.RouteMatcher{}, nil, false, 0, nil)
+	assert.ErrorIs(t, err, entity.ErrRouteNameRequired)
+
+	_, err = entity.NewNotificationRoute("route", nil, entity.RouteMatcher{}, []string{""}, false, 0, nil)
+	assert.ErrorIs(t, err, entity.ErrRouteChannelInvalid)
+
+	match := entity.RouteMatcher{Severities: []entity.AlertSeverity{"urgent"}}
+	_, err = entity.NewNotificationRoute("route", nil, match, nil, false, 0, nil)
+	assert.ErrorIs(t, err, entity.ErrRouteSeverityInvalid)
+
+	route, err := entity.NewNotificationRoute("route", nil, entity.RouteMatcher{}, nil, false, 0, nil)
+	assert.NoError(t, err)
+	route.ExternalID = strings.Repeat("x", 256)
+	assert.ErrorIs(t, route.Validate(), entity.ErrRouteExternalIDTooLong)
+}
+
+func TestRouteMatcher_Matches(t *testing.T) {
+	teamID := entity.NewID()
+	match := entity.RouteMatcher{
+		Severities: []entity.AlertSeverity{entity.AlertSeverityCritical, entity.AlertSeverityHigh},
+		Sources:    []string{"prometheus"},
+		Labels:     map[string]string{"env": "production"},
+		TeamID:     &teamID,
+	}
+	msg := notification.Message{
+		Severity: "high",
+		Source:   "prometheus",
+		Labels:   map[string]string{"env": "production", "host": "api-01"},
+		TeamID:   teamID.String(),
+	}
+
+	assert.True(t, match.Matches(msg))
+	assert.True(t, entity.RouteMatcher{}.Matches(notification.Message{}))
+
+	other := msg
+	other.Severity = "low"
+	assert.False(t, match.Matches(other))
+
+	other = msg
+	other.Labels = map[string]string{"env": "staging"}
+	assert.False(t, match.Matches(other))
+
+	other = msg
+	other.TeamID = ""
+	assert.False(t, match.Matches(other))
+}
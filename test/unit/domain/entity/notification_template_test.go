@@ -0,0 +1,82 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+)
+
+func TestNewNotificationTemplate_Success(t *testing.T) {
+	tmpl, err := entity.NewNotificationTemplate(entity.ChannelTypeSlack, "[{{.Severity}}] {{.Title}}", "{{.Text}}", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.ChannelTypeSlack, tmpl.Channel)
+	assert.False(t, tmpl.IsDefault())
+}
+
+func TestNewNotificationTemplate_ValidationErrors(t *testing.T) {
+	_, err := entity.NewNotificationTemplate("pager", "{{.Title}}", "{{.Text}}", nil)
+	assert.ErrorIs(t, err, entity.ErrChannelInvalidType)
+
+	_, err = entity.NewNotificationTemplate(entity.ChannelTypeSlack, "", "{{.Text}}", nil)
+	assert.ErrorIs(t, err, entity.ErrTemplateTitleRequired)
+
+	_, err = entity.NewNotificationTemplate(entity.ChannelTypeSlack, "{{.Title}}", "", nil)
+	assert.ErrorIs(t, err, entity.ErrTemplateBodyRequired)
+
+	long := strings.Repeat("x", entity.NotificationTitleTemplateMaxLength+1)
+	_, err = entity.NewNotificationTemplate(entity.ChannelTypeSlack, long, "{{.Text}}", nil)
+	assert.ErrorIs(t, err, entity.ErrTemplateTooLong)
+
+	// Does not parse
+	_, err = entity.NewNotificationTemplate(entity.ChannelTypeSlack, "{{.Title", "{{.Text}}", nil)
+	assert.ErrorIs(t, err, entity.ErrTemplateInvalid)
+
+	// Parses but fails the dry-render
+	_, err = entity.NewNotificationTemplate(entity.ChannelTypeSlack, "{{.Unknown}}", "{{.Text}}", nil)
+	assert.ErrorIs(t, err, entity.ErrTemplateInvalid)
+}
+
+func TestNotificationTemplate_Render(t *testing.T) {
+	tmpl, err := entity.NewNotificationTemplate(
+		entity.ChannelTypeSlack,
+		"[{{.Severity}}] {{.Title}}",
+		"{{.Text}} on {{.Labels.host}}{{with .Links.alert}} - {{.}}{{end}}",
+		nil,
+	)
+	require.NoError(t, err)
+
+	data := notification.NewTemplateData(notification.Message{
+		Title:    "Disk full",
+		Text:     "Disk usage at 100%",
+		Severity: "critical",
+		Labels:   map[string]string{"host": "db-01"},
+	}, nil)
+
+	title, body, err := tmpl.Render(data)
+	require.NoError(t, err)
+	assert.Equal(t, "[critical] Disk full", title)
+	assert.Equal(t, "Disk usage at 100% on db-01", body)
+}
+
+func TestNotificationTemplate_UpdateKeepsValidTemplate(t *testing.T) {
+	tmpl, err := entity.NewNotificationTemplate(entity.ChannelTypeEmail, "{{.Title}}", "{{.Text}}", nil)
+	require.NoError(t, err)
+
+	err = tmpl.Update("{{.Title", "{{.Text}}")
+
+	assert.ErrorIs(t, err, entity.ErrTemplateInvalid)
+	assert.Equal(t, "{{.Title}}", tmpl.Title)
+}
+
+func TestDefaultNotificationTemplate(t *testing.T) {
+	tmpl := entity.DefaultNotificationTemplate(entity.ChannelTypeWebhook)
+
+	assert.True(t, tmpl.IsDefault())
+	assert.NoError(t, tmpl.Validate())
+}
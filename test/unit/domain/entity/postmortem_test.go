@@ -0,0 +1,76 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewPostmortem_Success(t *testing.T) {
+	// Act
+	postmortem, err := entity.NewPostmortem("Checkout outage", "## What happened", "All checkouts failed", "A bad deploy", nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, postmortem.ID)
+	assert.Empty(t, postmortem.ActionItems)
+	assert.Nil(t, postmortem.IncidentID)
+}
+
+func TestPostmortem_Validate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modify   func(p *entity.Postmortem)
+		expected error
+	}{
+		{"empty title", func(p *entity.Postmortem) { p.Title = "" }, entity.ErrPostmortemTitleRequired},
+		{"title too long", func(p *entity.Postmortem) { p.Title = strings.Repeat("a", 256) }, entity.ErrPostmortemTitleTooLong},
+		{"root cause too long", func(p *entity.Postmortem) {
+			p.RootCause = strings.Repeat("a", 50001)
+		}, entity.ErrPostmortemSectionTooLong},
+		{"too many action items", func(p *entity.Postmortem) {
+			p.ActionItems = make([]entity.PostmortemActionItem, 51)
+			for i := range p.ActionItems {
+				p.ActionItems[i].Description = "Fix it"
+			}
+		}, entity.ErrPostmortemTooManyActionItems},
+		{"blank action item", func(p *entity.Postmortem) {
+			p.ActionItems = []entity.PostmortemActionItem{{Description: "  "}}
+		}, entity.ErrPostmortemActionItemInvalid},
+		{"too many alerts", func(p *entity.Postmortem) {
+			p.AlertIDs = make([]entity.ID, 101)
+		}, entity.ErrPostmortemTooManyAlerts},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			postmortem, err := entity.NewPostmortem("Checkout outage", "", "", "", nil)
+			require.NoError(t, err)
+
+			// Act
+			tc.modify(postmortem)
+
+			// Assert
+			assert.ErrorIs(t, postmortem.Validate(), tc.expected)
+		})
+	}
+}
+
+func TestPostmortem_OpenActionItems(t *testing.T) {
+	// Arrange
+	postmortem, err := entity.NewPostmortem("Checkout outage", "", "", "", nil)
+	require.NoError(t, err)
+	postmortem.ActionItems = []entity.PostmortemActionItem{
+		{Description: "Roll back", Done: true},
+		{Description: "Add a canary stage"},
+		{Description: "Alert on error rate"},
+	}
+
+	// Act & Assert
+	assert.Equal(t, 2, postmortem.OpenActionItems())
+}
@@ -0,0 +1,249 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func threshold(metric, operator string, value float64) entity.RuleCondition {
+	return entity.RuleCondition{Metric: metric, Operator: operator, Threshold: value}
+}
+
+func TestRuleCondition_Validate(t *testing.T) {
+	nested := threshold("cpu", ">", 90)
+	for i := 0; i < entity.MaxConditionDepth; i++ {
+		nested = entity.RuleCondition{Type: entity.ConditionNot, Conditions: []entity.RuleCondition{nested}}
+	}
+
+	testCases := []struct {
+		name        string
+		condition   entity.RuleCondition
+		expectedErr error
+	}{
+		{
+			name:      "threshold",
+			condition: threshold("cpu", ">", 90),
+		},
+		{
+			name:      "rate",
+			condition: entity.RuleCondition{Type: entity.ConditionRate, Metric: "errors", Operator: ">", Threshold: 5},
+		},
+		{
+			name:      "absent",
+			condition: entity.RuleCondition{Type: entity.ConditionAbsent, Metric: "heartbeat", ForSeconds: 300},
+		},
+		{
+			name:      "anomaly",
+			condition: entity.RuleCondition{Type: entity.ConditionAnomaly, Metric: "latency", Deviations: 3, WindowSeconds: 3600},
+		},
+		{
+			name:        "anomaly without deviations",
+			condition:   entity.RuleCondition{Type: entity.ConditionAnomaly, Metric: "latency", WindowSeconds: 3600},
+			expectedErr: entity.ErrRuleAnomalyInvalid,
+		},
+		{
+			name:        "anomaly with short window",
+			condition:   entity.RuleCondition{Type: entity.ConditionAnomaly, Metric: "latency", Deviations: 3, WindowSeconds: 30},
+			expectedErr: entity.ErrRuleAnomalyInvalid,
+		},
+		{
+			name: "anomaly with equality",
+			condition: entity.RuleCondition{
+				Type: entity.ConditionAnomaly, Metric: "latency", Operator: "==", Deviations: 3, WindowSeconds: 3600,
+			},
+			expectedErr: entity.ErrRuleInvalidOperator,
+		},
+		{
+			name: "composite",
+			condition: entity.RuleCondition{Type: entity.ConditionAnd, Conditions: []entity.RuleCondition{
+				threshold("cpu", ">", 90),
+				{Type: entity.ConditionNot, Conditions: []entity.RuleCondition{threshold("maintenance", "==", 1)}},
+			}},
+		},
+		{
+			name:        "unknown type",
+			condition:   entity.RuleCondition{Type: "xor", Metric: "cpu", Operator: ">"},
+			expectedErr: entity.ErrRuleInvalidConditionType,
+		},
+		{
+			name:        "and with one operand",
+			condition:   entity.RuleCondition{Type: entity.ConditionAnd, Conditions: []entity.RuleCondition{threshold("cpu", ">", 90)}},
+			expectedErr: entity.ErrRuleConditionOperands,
+		},
+		{
+			name:        "not without operand",
+			condition:   entity.RuleCondition{Type: entity.ConditionNot},
+			expectedErr: entity.ErrRuleConditionOperands,
+		},
+		{
+			name: "comparison with operands",
+			condition: entity.RuleCondition{Metric: "cpu", Operator: ">", Conditions: []entity.RuleCondition{
+				threshold("mem", ">", 90),
+			}},
+			expectedErr: entity.ErrRuleConditionOperands,
+		},
+		{
+			name: "invalid operand",
+			condition: entity.RuleCondition{Type: entity.ConditionOr, Conditions: []entity.RuleCondition{
+				threshold("cpu", ">", 90),
+				threshold("mem", "~", 90),
+			}},
+			expectedErr: entity.ErrRuleInvalidOperator,
+		},
+		{
+			name:        "absent without window",
+			condition:   entity.RuleCondition{Type: entity.ConditionAbsent, Metric: "heartbeat"},
+			expectedErr: entity.ErrRuleAbsenceWindowInvalid,
+		},
+		{
+			name:        "too deep",
+			condition:   nested,
+			expectedErr: entity.ErrRuleConditionTooDeep,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.condition.Validate()
+
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRuleCondition_JSON(t *testing.T) {
+	condition := entity.RuleCondition{
+		Type:        entity.ConditionOr,
+		Consecutive: 2,
+		Conditions: []entity.RuleCondition{
+			threshold("cpu", ">", 90),
+			{Type: entity.ConditionAbsent, Metric: "heartbeat", ForSeconds: 60},
+		},
+	}
+
+	data, err := json.Marshal(condition)
+	require.NoError(t, err)
+
+	var decoded entity.RuleCondition
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, condition.Equal(decoded))
+
+	// Conditions stored before composite conditions are thresholds
+	var legacy entity.RuleCondition
+	require.NoError(t, json.Unmarshal([]byte(`{"metric":"cpu","operator":">","threshold":90,"consecutive":0}`), &legacy))
+	require.NoError(t, legacy.Validate())
+	assert.True(t, legacy.Equal(threshold("cpu", ">", 90)))
+}
+
+func TestRuleCondition_Matches(t *testing.T) {
+	now := time.Now()
+	states := map[string]entity.MetricState{
+		"cpu":       {Value: 95, SeenAt: now},
+		"mem":       {Value: 40, SeenAt: now},
+		"errors":    {Value: 120, Rate: 8, HasRate: true, SeenAt: now},
+		"heartbeat": {Value: 1, SeenAt: now.Add(-10 * time.Minute)},
+	}
+
+	testCases := []struct {
+		name      string
+		condition entity.RuleCondition
+		expected  bool
+	}{
+		{"threshold met", threshold("cpu", ">", 90), true},
+		{"threshold not met", threshold("mem", ">", 90), false},
+		{"threshold of unseen metric", threshold("disk", ">", 0), false},
+		{"rate met", entity.RuleCondition{Type: entity.ConditionRate, Metric: "errors", Operator: ">", Threshold: 5}, true},
+		{"rate unknown", entity.RuleCondition{Type: entity.ConditionRate, Metric: "cpu", Operator: ">", Threshold: 0}, false},
+		{"absent for long", entity.RuleCondition{Type: entity.ConditionAbsent, Metric: "heartbeat", ForSeconds: 300}, true},
+		{"absent not for long", entity.RuleCondition{Type: entity.ConditionAbsent, Metric: "heartbeat", ForSeconds: 900}, false},
+		{"absent never seen", entity.RuleCondition{Type: entity.ConditionAbsent, Metric: "disk", ForSeconds: 60}, true},
+		{"and", entity.RuleCondition{Type: entity.ConditionAnd, Conditions: []entity.RuleCondition{
+			threshold("cpu", ">", 90), threshold("mem", ">", 90),
+		}}, false},
+		{"or", entity.RuleCondition{Type: entity.ConditionOr, Conditions: []entity.RuleCondition{
+			threshold("cpu", ">", 90), threshold("mem", ">", 90),
+		}}, true},
+		{"not", entity.RuleCondition{Type: entity.ConditionNot, Conditions: []entity.RuleCondition{
+			threshold("mem", ">", 90),
+		}}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.condition.Matches(states, now))
+		})
+	}
+}
+
+func TestRuleCondition_MetricsAndString(t *testing.T) {
+	condition := entity.RuleCondition{Type: entity.ConditionOr, Conditions: []entity.RuleCondition{
+		{Type: entity.ConditionAnd, Conditions: []entity.RuleCondition{
+			threshold("cpu", ">", 90),
+			{Type: entity.ConditionRate, Metric: "errors", Operator: ">", Threshold: 5},
+		}},
+		{Type: entity.ConditionNot, Conditions: []entity.RuleCondition{threshold("cpu", "<", 10)}},
+		{Type: entity.ConditionAbsent, Metric: "heartbeat", ForSeconds: 300},
+	}}
+
+	assert.Equal(t, []string{"cpu", "errors", "heartbeat"}, condition.Metrics())
+	assert.Equal(t, "(cpu > 90 AND rate(errors) > 5) OR (NOT cpu < 10) OR absent(heartbeat, 300s)", condition.String())
+}
+
+func TestNewBaseline(t *testing.T) {
+	now := time.Now()
+	points := []entity.MetricPoint{
+		{Value: 100, At: now.Add(-2 * time.Hour)},
+		{Value: 2, At: now.Add(-30 * time.Minute)},
+		{Value: 4, At: now.Add(-20 * time.Minute)},
+		{Value: 4, At: now.Add(-10 * time.Minute)},
+		{Value: 6, At: now},
+	}
+
+	baseline := entity.NewBaseline(points, now.Add(-time.Hour))
+
+	assert.Equal(t, 4, baseline.Samples)
+	assert.InDelta(t, 4, baseline.Mean, 1e-9)
+	assert.InDelta(t, 1.41421356, baseline.StdDev, 1e-6)
+
+	assert.True(t, baseline.Deviates(7, 2, ""))
+	assert.True(t, baseline.Deviates(1, 2, ""))
+	assert.False(t, baseline.Deviates(6, 2, ""))
+	assert.True(t, baseline.Deviates(7, 2, ">"))
+	assert.False(t, baseline.Deviates(1, 2, ">"))
+	assert.True(t, baseline.Deviates(1, 2, "<"))
+}
+
+func TestRuleCondition_Matches_Anomaly(t *testing.T) {
+	now := time.Now()
+	history := make([]entity.MetricPoint, 0, entity.MinBaselineSamples)
+	for i := 0; i < entity.MinBaselineSamples; i++ {
+		history = append(history, entity.MetricPoint{
+			Value: float64(100 + i%2*10),
+			At:    now.Add(-time.Duration(entity.MinBaselineSamples-i) * time.Minute),
+		})
+	}
+	condition := entity.RuleCondition{
+		Type: entity.ConditionAnomaly, Metric: "latency", Operator: ">", Deviations: 3, WindowSeconds: 3600,
+	}
+
+	spike := map[string]entity.MetricState{"latency": {Value: 200, SeenAt: now, History: history}}
+	normal := map[string]entity.MetricState{"latency": {Value: 108, SeenAt: now, History: history}}
+	drop := map[string]entity.MetricState{"latency": {Value: 0, SeenAt: now, History: history}}
+	young := map[string]entity.MetricState{"latency": {Value: 200, SeenAt: now, History: history[1:]}}
+
+	assert.True(t, condition.Matches(spike, now))
+	assert.False(t, condition.Matches(normal, now))
+	assert.False(t, condition.Matches(drop, now))
+	assert.False(t, condition.Matches(young, now), "too few samples for a baseline")
+	assert.Equal(t, "anomaly(latency, >3σ, 3600s)", condition.String())
+}
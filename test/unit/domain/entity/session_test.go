@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewSession(t *testing.T) {
+	userID := entity.NewID()
+
+	session := entity.NewSession(userID, "curl/8.0", "10.0.0.1", time.Hour)
+
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, "curl/8.0", session.Device)
+	assert.Equal(t, "10.0.0.1", session.IP)
+	assert.Equal(t, session.IssuedAt, session.LastSeenAt)
+	assert.Equal(t, session.IssuedAt.Add(time.Hour), session.ExpiresAt)
+	assert.True(t, session.IsOwnedBy(userID))
+	assert.False(t, session.IsOwnedBy(entity.NewID()))
+}
+
+func TestNewSession_TruncatesDevice(t *testing.T) {
+	session := entity.NewSession(entity.NewID(), strings.Repeat("a", 300), "", time.Hour)
+
+	assert.Len(t, session.Device, entity.SessionDeviceMaxLength)
+}
+
+func TestSession_Refresh(t *testing.T) {
+	session := entity.NewSession(entity.NewID(), "curl/8.0", "10.0.0.1", time.Minute)
+	issuedAt := session.IssuedAt
+
+	session.Refresh("10.0.0.2", time.Hour)
+
+	assert.Equal(t, issuedAt, session.IssuedAt)
+	assert.Equal(t, "10.0.0.2", session.IP)
+	assert.True(t, session.ExpiresAt.After(issuedAt.Add(time.Minute)))
+
+	session.Refresh("", time.Hour)
+	assert.Equal(t, "10.0.0.2", session.IP)
+}
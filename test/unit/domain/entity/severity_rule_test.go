@@ -0,0 +1,83 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewSeverityRule_Success(t *testing.T) {
+	// Arrange
+	match := entity.SeverityRuleMatcher{Sources: []string{"staging"}}
+
+	// Act
+	rule, err := entity.NewSeverityRule("staging", match, entity.AlertSeverityMedium, 10, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, rule.ID)
+	assert.True(t, rule.IsEnabled)
+	assert.Equal(t, 10, rule.Position)
+}
+
+func TestNewSeverityRule_ValidationErrors(t *testing.T) {
+	staging := entity.SeverityRuleMatcher{Sources: []string{"staging"}}
+
+	testCases := []struct {
+		name     string
+		ruleName string
+		match    entity.SeverityRuleMatcher
+		severity entity.AlertSeverity
+		expected error
+	}{
+		{"empty name", "", staging, entity.AlertSeverityLow, entity.ErrSeverityRuleNameRequired},
+		{"name too long", strings.Repeat("a", 101), staging, entity.AlertSeverityLow, entity.ErrSeverityRuleNameTooLong},
+		{"empty match", "rule", entity.SeverityRuleMatcher{}, entity.AlertSeverityLow, entity.ErrSeverityRuleMatchRequired},
+		{
+			"invalid match severity", "rule",
+			entity.SeverityRuleMatcher{Severities: []entity.AlertSeverity{"urgent"}},
+			entity.AlertSeverityLow, entity.ErrSeverityRuleInvalidMatchSeverity,
+		},
+		{
+			"invalid pattern", "rule",
+			entity.SeverityRuleMatcher{TitlePattern: "disk ("},
+			entity.AlertSeverityLow, entity.ErrSeverityRuleInvalidPattern,
+		},
+		{
+			"empty label name", "rule",
+			entity.SeverityRuleMatcher{Labels: map[string]string{"": "x"}},
+			entity.AlertSeverityLow, entity.ErrSeverityRuleInvalidLabels,
+		},
+		{"invalid severity", "rule", staging, entity.AlertSeverity("urgent"), entity.ErrSeverityRuleInvalidSeverity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			rule, err := entity.NewSeverityRule(tc.ruleName, tc.match, tc.severity, 0, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, rule)
+		})
+	}
+}
+
+func TestAlert_Reclassify(t *testing.T) {
+	// Arrange
+	alert, err := entity.NewAlert("CPU high", "cpu at 99%", entity.AlertSeverityCritical, "staging")
+	require.NoError(t, err)
+
+	// Act
+	alert.Reclassify(entity.AlertSeverityMedium, "staging")
+	alert.Reclassify(entity.AlertSeverityLow, "night")
+
+	// Assert
+	assert.Equal(t, entity.AlertSeverityLow, alert.Severity)
+	assert.Equal(t, "critical", alert.Metadata[entity.MetadataOriginalSeverity])
+	assert.Equal(t, "night", alert.Metadata[entity.MetadataSeverityRule])
+}
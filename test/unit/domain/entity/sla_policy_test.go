@@ -0,0 +1,166 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewSLAPolicy_Success(t *testing.T) {
+	// Act
+	policy, err := entity.NewSLAPolicy("critical", 300, 3600, entity.AlertSeverityHigh, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, policy.ID)
+	assert.True(t, policy.IsEnabled)
+	assert.Equal(t, []entity.SLATarget{entity.SLATargetAck, entity.SLATargetResolve}, policy.Targets())
+}
+
+func TestNewSLAPolicy_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		slaName  string
+		ack      int
+		resolve  int
+		severity entity.AlertSeverity
+		expected error
+	}{
+		{"empty name", "", 300, 0, entity.AlertSeverityHigh, entity.ErrSLAPolicyNameRequired},
+		{"name too long", strings.Repeat("a", 101), 300, 0, entity.AlertSeverityHigh, entity.ErrSLAPolicyNameTooLong},
+		{"no target", "sla", 0, 0, entity.AlertSeverityHigh, entity.ErrSLAPolicyTargetRequired},
+		{"negative target", "sla", -1, 3600, entity.AlertSeverityHigh, entity.ErrSLAPolicyInvalidTarget},
+		{"target too long", "sla", 0, entity.MaxSLATarget + 1, entity.AlertSeverityHigh, entity.ErrSLAPolicyInvalidTarget},
+		{"ack after resolve", "sla", 3600, 300, entity.AlertSeverityHigh, entity.ErrSLAPolicyInvalidTarget},
+		{"invalid breach severity", "sla", 300, 0, entity.AlertSeverity("urgent"), entity.ErrSLAPolicyInvalidSeverity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			policy, err := entity.NewSLAPolicy(tc.slaName, tc.ack, tc.resolve, tc.severity, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, policy)
+		})
+	}
+}
+
+func TestSLAPolicy_Matches(t *testing.T) {
+	// Arrange
+	policy, err := entity.NewSLAPolicy("payments", 300, 0, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	teamID := entity.NewID()
+	policy.Severity = entity.AlertSeverityCritical
+	policy.TeamID = &teamID
+	policy.Source = "payments-api"
+
+	newAlert := func(severity entity.AlertSeverity, team *entity.ID, source string) *entity.Alert {
+		alert, err := entity.NewAlert("Errors", "5xx rate", severity, source)
+		require.NoError(t, err)
+		alert.TeamID = team
+		alert.CreatedAt = policy.CreatedAt.Add(time.Minute)
+		return alert
+	}
+	earlier := newAlert(entity.AlertSeverityCritical, &teamID, "payments-api")
+	earlier.CreatedAt = policy.CreatedAt.Add(-time.Minute)
+
+	// Act & Assert
+	assert.True(t, policy.Matches(newAlert(entity.AlertSeverityCritical, &teamID, "payments-api")))
+	assert.False(t, policy.Matches(newAlert(entity.AlertSeverityHigh, &teamID, "payments-api")))
+	assert.False(t, policy.Matches(newAlert(entity.AlertSeverityCritical, nil, "payments-api")))
+	assert.False(t, policy.Matches(newAlert(entity.AlertSeverityCritical, &teamID, "checkout-api")))
+	assert.False(t, policy.Matches(earlier), "alerts created before the policy")
+}
+
+func TestSLAPolicy_MatchesIgnoresBreachAlerts(t *testing.T) {
+	// Arrange
+	policy, err := entity.NewSLAPolicy("all", 300, 0, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	alert, err := entity.NewAlert("SLA breached: all", "late", entity.AlertSeverityHigh, entity.SLABreachSource)
+	require.NoError(t, err)
+
+	// Act & Assert
+	assert.False(t, policy.Matches(alert))
+}
+
+func TestSLAPolicy_IsBreached(t *testing.T) {
+	// Arrange
+	policy, err := entity.NewSLAPolicy("critical", 300, 3600, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	createdAt := policy.CreatedAt.Add(time.Minute)
+	at := func(d time.Duration) *time.Time {
+		ts := createdAt.Add(d)
+		return &ts
+	}
+
+	testCases := []struct {
+		name           string
+		acknowledgedAt *time.Time
+		resolvedAt     *time.Time
+		now            time.Duration
+		target         entity.SLATarget
+		expected       bool
+	}{
+		{"ack not due yet", nil, nil, 4 * time.Minute, entity.SLATargetAck, false},
+		{"ack missed", nil, nil, 6 * time.Minute, entity.SLATargetAck, true},
+		{"acknowledged in time", at(time.Minute), nil, 6 * time.Minute, entity.SLATargetAck, false},
+		{"acknowledged late", at(10 * time.Minute), nil, 20 * time.Minute, entity.SLATargetAck, true},
+		{"resolved before ack was due", nil, at(2 * time.Minute), 6 * time.Minute, entity.SLATargetAck, false},
+		{"resolve not due yet", at(time.Minute), nil, 30 * time.Minute, entity.SLATargetResolve, false},
+		{"acknowledged but resolve missed", at(time.Minute), nil, 2 * time.Hour, entity.SLATargetResolve, true},
+		{"resolved in time", at(time.Minute), at(50 * time.Minute), 2 * time.Hour, entity.SLATargetResolve, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alert, err := entity.NewAlert("Errors", "5xx rate", entity.AlertSeverityCritical, "api")
+			require.NoError(t, err)
+			alert.CreatedAt = createdAt
+			alert.AcknowledgedAt = tc.acknowledgedAt
+			alert.ResolvedAt = tc.resolvedAt
+
+			// Act
+			breached := policy.IsBreached(alert, tc.target, createdAt.Add(tc.now))
+
+			// Assert
+			assert.Equal(t, tc.expected, breached)
+		})
+	}
+}
+
+func TestSLAPolicy_IsBreachedWithoutTarget(t *testing.T) {
+	// Arrange
+	policy, err := entity.NewSLAPolicy("resolve-only", 0, 3600, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	alert, err := entity.NewAlert("Errors", "5xx rate", entity.AlertSeverityCritical, "api")
+	require.NoError(t, err)
+	alert.CreatedAt = policy.CreatedAt
+
+	// Act & Assert
+	assert.Equal(t, []entity.SLATarget{entity.SLATargetResolve}, policy.Targets())
+	assert.False(t, policy.IsBreached(alert, entity.SLATargetAck, alert.CreatedAt.Add(24*time.Hour)))
+}
+
+func TestNewSLABreach(t *testing.T) {
+	// Arrange
+	policy, err := entity.NewSLAPolicy("critical", 300, 3600, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	alert, err := entity.NewAlert("Errors", "5xx rate", entity.AlertSeverityCritical, "api")
+	require.NoError(t, err)
+	now := alert.CreatedAt.Add(2 * time.Hour)
+
+	// Act
+	breach := entity.NewSLABreach(policy, alert, entity.SLATargetResolve, now)
+
+	// Assert
+	assert.Equal(t, alert.CreatedAt.Add(time.Hour), breach.DueAt)
+	assert.Equal(t, now, breach.BreachedAt)
+	assert.Equal(t, "sla:"+policy.ID.String()+":"+alert.ID.String()+":resolve", breach.DedupKey())
+}
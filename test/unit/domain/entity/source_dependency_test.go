@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewSourceDependency_Success(t *testing.T) {
+	// Arrange
+	createdBy := entity.NewID()
+
+	// Act
+	dependency, err := entity.NewSourceDependency("checkout-api", "orders-db", &createdBy)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, dependency.ID)
+	assert.Equal(t, "checkout-api", dependency.Source)
+	assert.Equal(t, "orders-db", dependency.DependsOn)
+	assert.Equal(t, &createdBy, dependency.CreatedBy)
+	assert.False(t, dependency.CreatedAt.IsZero())
+}
+
+func TestNewSourceDependency_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name      string
+		source    string
+		dependsOn string
+		expected  error
+	}{
+		{"empty source", "", "orders-db", entity.ErrSourceDependencySourceRequired},
+		{"empty depends on", "checkout-api", "", entity.ErrSourceDependencySourceRequired},
+		{"source too long", strings.Repeat("a", 256), "orders-db", entity.ErrSourceDependencySourceTooLong},
+		{"depends on too long", "checkout-api", strings.Repeat("a", 256), entity.ErrSourceDependencySourceTooLong},
+		{"depends on itself", "orders-db", "orders-db", entity.ErrSourceDependencySelf},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			dependency, err := entity.NewSourceDependency(tc.source, tc.dependsOn, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, dependency)
+		})
+	}
+}
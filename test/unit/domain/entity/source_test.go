@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewSource_Success(t *testing.T) {
+	// Act
+	source, err := entity.NewSource("grafana-prod", entity.SourceTypeGrafana, 3600, entity.AlertSeverityHigh, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, source.ID)
+	assert.Equal(t, entity.SourceStatusReporting, source.Status)
+	assert.Nil(t, source.SilentSince)
+	assert.True(t, source.IsMonitored())
+}
+
+func TestNewSource_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sourceName string
+		sourceType entity.SourceType
+		heartbeat  int
+		severity   entity.AlertSeverity
+		expected   error
+	}{
+		{"empty name", "", entity.SourceTypeAPI, 0, entity.AlertSeverityHigh, entity.ErrSourceNameRequired},
+		{"name too long", strings.Repeat("a", 101), entity.SourceTypeAPI, 0, entity.AlertSeverityHigh, entity.ErrSourceNameTooLong},
+		{"invalid type", "src", entity.SourceType("pagerduty"), 0, entity.AlertSeverityHigh, entity.ErrSourceInvalidType},
+		{"heartbeat too short", "src", entity.SourceTypeAPI, 59, entity.AlertSeverityHigh, entity.ErrSourceInvalidHeartbeat},
+		{"heartbeat too long", "src", entity.SourceTypeAPI, entity.MaxHeartbeatInterval + 1, entity.AlertSeverityHigh, entity.ErrSourceInvalidHeartbeat},
+		{"invalid severity", "src", entity.SourceTypeAPI, 0, entity.AlertSeverity("urgent"), entity.ErrSourceInvalidSeverity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			source, err := entity.NewSource(tc.sourceName, tc.sourceType, tc.heartbeat, tc.severity, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, source)
+		})
+	}
+}
+
+func TestSource_IsSilent(t *testing.T) {
+	// Arrange
+	source, err := entity.NewSource("src", entity.SourceTypeWebhook, 600, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	registeredAt := source.CreatedAt
+	lastAlertAt := registeredAt.Add(time.Hour)
+
+	// Act & Assert: counted from registration until the first alert
+	assert.False(t, source.IsSilent(nil, registeredAt.Add(10*time.Minute)))
+	assert.True(t, source.IsSilent(nil, registeredAt.Add(11*time.Minute)))
+
+	assert.False(t, source.IsSilent(&lastAlertAt, lastAlertAt.Add(10*time.Minute)))
+	assert.True(t, source.IsSilent(&lastAlertAt, lastAlertAt.Add(11*time.Minute)))
+
+	source.ExpectedHeartbeatSeconds = 0
+	assert.False(t, source.IsSilent(&lastAlertAt, lastAlertAt.Add(24*time.Hour)))
+}
+
+func TestSource_MarkSilentAndReporting(t *testing.T) {
+	// Arrange
+	source, err := entity.NewSource("src", entity.SourceTypeWebhook, 600, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+	now := time.Now()
+
+	// Act & Assert
+	source.MarkSilent(now)
+	assert.Equal(t, entity.SourceStatusSilent, source.Status)
+	assert.Equal(t, &now, source.SilentSince)
+
+	source.MarkReporting()
+	assert.Equal(t, entity.SourceStatusReporting, source.Status)
+	assert.Nil(t, source.SilentSince)
+	assert.Equal(t, "source:"+source.ID.String(), source.DedupKey())
+}
@@ -0,0 +1,164 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+var subscriptionSecret = strings.Repeat("s", entity.SubscriptionSecretMinLength)
+
+func TestNewSubscription_Success(t *testing.T) {
+	// Act
+	subscription, err := entity.NewSubscription("siem", "https://siem.example.com/hooks", subscriptionSecret,
+		[]string{entity.SubscriptionEventAlertCreated}, entity.SubscriptionFilter{}, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, entity.ID{}, subscription.ID)
+	assert.True(t, subscription.IsEnabled)
+	assert.True(t, subscription.Receives(entity.SubscriptionEventAlertCreated))
+	assert.False(t, subscription.Receives(entity.SubscriptionEventAlertResolved))
+}
+
+func TestNewSubscription_ValidationErrors(t *testing.T) {
+	created := []string{entity.SubscriptionEventAlertCreated}
+
+	testCases := []struct {
+		name       string
+		subName    string
+		url        string
+		secret     string
+		eventTypes []string
+		filter     entity.SubscriptionFilter
+		expected   error
+	}{
+		{"empty name", "", "https://example.com", subscriptionSecret, created, entity.SubscriptionFilter{}, entity.ErrSubscriptionNameRequired},
+		{"name too long", strings.Repeat("a", 101), "https://example.com", subscriptionSecret, created, entity.SubscriptionFilter{}, entity.ErrSubscriptionNameTooLong},
+		{"relative url", "siem", "/hooks", subscriptionSecret, created, entity.SubscriptionFilter{}, entity.ErrSubscriptionInvalidURL},
+		{"unsupported scheme", "siem", "ftp://example.com", subscriptionSecret, created, entity.SubscriptionFilter{}, entity.ErrSubscriptionInvalidURL},
+		{"short secret", "siem", "https://example.com", "secret", created, entity.SubscriptionFilter{}, entity.ErrSubscriptionSecretTooShort},
+		{"no event type", "siem", "https://example.com", subscriptionSecret, nil, entity.SubscriptionFilter{}, entity.ErrSubscriptionEventTypesRequired},
+		{"unknown event type", "siem", "https://example.com", subscriptionSecret, []string{"user.created"}, entity.SubscriptionFilter{}, entity.ErrSubscriptionInvalidEventType},
+		{
+			"unknown severity", "siem", "https://example.com", subscriptionSecret, created,
+			entity.SubscriptionFilter{Severities: []entity.AlertSeverity{"urgent"}},
+			entity.ErrSubscriptionInvalidFilter,
+		},
+		{
+			"empty label name", "siem", "https://example.com", subscriptionSecret, created,
+			entity.SubscriptionFilter{Labels: map[string]string{"": "x"}},
+			entity.ErrSubscriptionInvalidFilter,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			subscription, err := entity.NewSubscription(tc.subName, tc.url, tc.secret, tc.eventTypes, tc.filter, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Nil(t, subscription)
+		})
+	}
+}
+
+func TestSubscriptionFilter_Matches(t *testing.T) {
+	filter := entity.SubscriptionFilter{
+		Severities: []entity.AlertSeverity{entity.AlertSeverityCritical, entity.AlertSeverityHigh},
+		Sources:    []string{"payments-api"},
+		Labels:     map[string]string{"env": "prod"},
+	}
+	prod := map[string]string{"env": "prod", "service": "payments"}
+
+	testCases := []struct {
+		name     string
+		severity entity.AlertSeverity
+		source   string
+		labels   map[string]string
+		expected bool
+	}{
+		{"every criterion matches", entity.AlertSeverityHigh, "payments-api", prod, true},
+		{"other severity", entity.AlertSeverityLow, "payments-api", prod, false},
+		{"other source", entity.AlertSeverityHigh, "search-api", prod, false},
+		{"missing label", entity.AlertSeverityHigh, "payments-api", map[string]string{"service": "payments"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act & Assert
+			assert.Equal(t, tc.expected, filter.Matches(tc.severity, tc.source, tc.labels))
+		})
+	}
+
+	t.Run("empty filter matches every alert", func(t *testing.T) {
+		assert.True(t, entity.SubscriptionFilter{}.Matches(entity.AlertSeverityInfo, "any", nil))
+	})
+}
+
+func TestSubscriptionDelivery_Attempts(t *testing.T) {
+	t.Run("success marks the delivery delivered", func(t *testing.T) {
+		// Arrange
+		delivery := entity.NewSubscriptionDelivery(entity.NewID(), entity.SubscriptionEventAlertCreated, []byte(`{}`))
+		now := time.Now().UTC()
+
+		// Act
+		delivery.RecordSuccess(204, now)
+
+		// Assert
+		assert.Equal(t, entity.DeliveryStatusDelivered, delivery.Status)
+		assert.Equal(t, 1, delivery.Attempts)
+		assert.Equal(t, &now, delivery.DeliveredAt)
+	})
+
+	t.Run("failure schedules a retry", func(t *testing.T) {
+		// Arrange
+		delivery := entity.NewSubscriptionDelivery(entity.NewID(), entity.SubscriptionEventAlertCreated, []byte(`{}`))
+		now := time.Now().UTC()
+
+		// Act
+		failed := delivery.RecordFailure(503, "unexpected status 503", now, time.Minute, 3)
+
+		// Assert
+		assert.False(t, failed)
+		assert.Equal(t, entity.DeliveryStatusPending, delivery.Status)
+		assert.Equal(t, now.Add(time.Minute), delivery.NextAttemptAt)
+		assert.Equal(t, 503, delivery.LastStatusCode)
+	})
+
+	t.Run("last attempt dead-letters the delivery", func(t *testing.T) {
+		// Arrange
+		delivery := entity.NewSubscriptionDelivery(entity.NewID(), entity.SubscriptionEventAlertCreated, []byte(`{}`))
+		now := time.Now().UTC()
+		delivery.RecordFailure(0, "timed out", now, time.Minute, 2)
+
+		// Act
+		failed := delivery.RecordFailure(0, "timed out", now, time.Minute, 2)
+
+		// Assert
+		assert.True(t, failed)
+		assert.Equal(t, entity.DeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+	})
+
+	t.Run("redeliver resets the attempts", func(t *testing.T) {
+		// Arrange
+		delivery := entity.NewSubscriptionDelivery(entity.NewID(), entity.SubscriptionEventAlertCreated, []byte(`{}`))
+		now := time.Now().UTC()
+		delivery.RecordFailure(500, "unexpected status 500", now, time.Minute, 1)
+
+		// Act
+		delivery.Redeliver(now)
+
+		// Assert
+		assert.Equal(t, entity.DeliveryStatusPending, delivery.Status)
+		assert.Equal(t, 0, delivery.Attempts)
+		assert.Equal(t, now, delivery.NextAttemptAt)
+	})
+}
@@ -0,0 +1,62 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewTeam(t *testing.T) {
+	team, err := entity.NewTeam("payments", "Payments platform")
+	require.NoError(t, err)
+
+	assert.Equal(t, "payments", team.Name)
+	assert.Equal(t, "Payments platform", team.Description)
+	assert.False(t, team.CreatedAt.IsZero())
+}
+
+func TestNewTeam_Validation(t *testing.T) {
+	_, err := entity.NewTeam("", "")
+	assert.ErrorIs(t, err, entity.ErrTeamNameRequired)
+
+	_, err = entity.NewTeam(strings.Repeat("a", 101), "")
+	assert.ErrorIs(t, err, entity.ErrTeamNameTooLong)
+
+	_, err = entity.NewTeam("payments", strings.Repeat("a", 501))
+	assert.ErrorIs(t, err, entity.ErrTeamDescriptionTooLong)
+}
+
+func TestTeam_Update(t *testing.T) {
+	team, err := entity.NewTeam("payments", "")
+	require.NoError(t, err)
+
+	require.NoError(t, team.Update("billing", "Billing platform"))
+	assert.Equal(t, "billing", team.Name)
+	assert.Equal(t, "Billing platform", team.Description)
+
+	// Invalid updates leave the team unchanged
+	assert.ErrorIs(t, team.Update("", "ignored"), entity.ErrTeamNameRequired)
+	assert.Equal(t, "billing", team.Name)
+	assert.Equal(t, "Billing platform", team.Description)
+}
+
+func TestNewTeamMember(t *testing.T) {
+	teamID, userID := entity.NewID(), entity.NewID()
+
+	owner, err := entity.NewTeamMember(teamID, userID, entity.TeamRoleOwner)
+	require.NoError(t, err)
+	assert.True(t, owner.IsOwner())
+	assert.Equal(t, teamID, owner.TeamID)
+	assert.Equal(t, userID, owner.UserID)
+
+	member, err := entity.NewTeamMember(teamID, userID, entity.TeamRoleMember)
+	require.NoError(t, err)
+	assert.False(t, member.IsOwner())
+
+	_, err = entity.NewTeamMember(teamID, userID, entity.TeamRole("admin"))
+	assert.ErrorIs(t, err, entity.ErrTeamRoleInvalid)
+}
@@ -0,0 +1,72 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewUserIdentity_Success(t *testing.T) {
+	userID := entity.NewID()
+
+	identity, err := entity.NewUserIdentity(userID, entity.IdentityProviderSlack, "U024BE7LH", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, userID, identity.UserID)
+	assert.Equal(t, entity.IdentityProviderSlack, identity.Provider)
+	assert.Equal(t, "U024BE7LH", identity.ExternalID)
+}
+
+func TestNewUserIdentity_Phone(t *testing.T) {
+	identity, err := entity.NewUserIdentity(entity.NewID(), entity.IdentityProviderPhone, "+14155550100", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "+14155550100", identity.ExternalID)
+}
+
+func TestIsIdentityProvider(t *testing.T) {
+	assert.True(t, entity.IsIdentityProvider(entity.IdentityProviderSlack))
+	assert.True(t, entity.IsIdentityProvider(entity.IdentityProviderTelegram))
+	assert.True(t, entity.IsIdentityProvider(entity.IdentityProviderPhone))
+	assert.False(t, entity.IsIdentityProvider("discord"))
+}
+
+func TestNewUserIdentity_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		provider   string
+		externalID string
+		wantErr    error
+	}{
+		{"unknown provider", "discord", "U024BE7LH", entity.ErrIdentityProviderInvalid},
+		{"missing external ID", entity.IdentityProviderSlack, "", entity.ErrIdentityExternalIDRequired},
+		{"external ID too long", entity.IdentityProviderSlack, strings.Repeat("U", 101), entity.ErrIdentityExternalIDTooLong},
+		{"phone without country code", entity.IdentityProviderPhone, "4155550100", entity.ErrIdentityPhoneInvalid},
+		{"phone with separators", entity.IdentityProviderPhone, "+1 415-555-0100", entity.ErrIdentityPhoneInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := entity.NewUserIdentity(entity.NewID(), tt.provider, tt.externalID, nil)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestUserIdentity_Relink(t *testing.T) {
+	identity, err := entity.NewUserIdentity(entity.NewID(), entity.IdentityProviderSlack, "U024BE7LH", nil)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, identity.Relink(""), entity.ErrIdentityExternalIDRequired)
+	assert.Equal(t, "U024BE7LH", identity.ExternalID)
+
+	require.NoError(t, identity.Relink("W012A3CDE"))
+	assert.Equal(t, "W012A3CDE", identity.ExternalID)
+}
@@ -0,0 +1,42 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewWebhookSecret_Success(t *testing.T) {
+	secret := strings.Repeat("s", entity.WebhookSecretMinLength)
+
+	ws, err := entity.NewWebhookSecret(entity.WebhookIntegrationAlertManager, secret, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.WebhookIntegrationAlertManager, ws.Integration)
+	assert.Equal(t, secret, ws.Secret)
+}
+
+func TestNewWebhookSecret_ValidationErrors(t *testing.T) {
+	secret := strings.Repeat("s", entity.WebhookSecretMinLength)
+
+	_, err := entity.NewWebhookSecret("pagerduty", secret, nil)
+	assert.ErrorIs(t, err, entity.ErrWebhookIntegrationInvalid)
+
+	_, err = entity.NewWebhookSecret(entity.WebhookIntegrationGrafana, "short", nil)
+	assert.ErrorIs(t, err, entity.ErrWebhookSecretTooShort)
+}
+
+func TestWebhookSecret_Rotate(t *testing.T) {
+	ws, err := entity.NewWebhookSecret(entity.WebhookIntegrationDatadog, strings.Repeat("a", 32), nil)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, ws.Rotate("short"), entity.ErrWebhookSecretTooShort)
+	assert.Equal(t, strings.Repeat("a", 32), ws.Secret)
+
+	require.NoError(t, ws.Rotate(strings.Repeat("b", 32)))
+	assert.Equal(t, strings.Repeat("b", 32), ws.Secret)
+}
@@ -0,0 +1,55 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+)
+
+func TestNewWebhookSource_Success(t *testing.T) {
+	source, err := entity.NewWebhookSource("nagios", "hash", entity.WebhookMapping{Title: "$.title"}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "nagios", source.Name)
+	assert.True(t, source.IsEnabled)
+}
+
+func TestNewWebhookSource_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sourceName  string
+		mapping     entity.WebhookMapping
+		expectedErr error
+	}{
+		{"empty name", "", entity.WebhookMapping{Title: "$.title"}, entity.ErrWebhookSourceNameRequired},
+		{"missing title", "nagios", entity.WebhookMapping{}, entity.ErrWebhookMappingTitleRequired},
+		{
+			"invalid default severity", "nagios",
+			entity.WebhookMapping{Title: "$.title", DefaultSeverity: "urgent"},
+			entity.ErrWebhookMappingInvalidSeverity,
+		},
+		{
+			"invalid mapped severity", "nagios",
+			entity.WebhookMapping{Title: "$.title", SeverityMap: map[string]entity.AlertSeverity{"P1": "urgent"}},
+			entity.ErrWebhookMappingInvalidSeverity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := entity.NewWebhookSource(tc.sourceName, "hash", tc.mapping, nil)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestWebhookMapping_IsResolvedStatus(t *testing.T) {
+	mapping := entity.WebhookMapping{Title: "$.title", ResolvedValues: []string{"ok", "resolved"}}
+
+	assert.True(t, mapping.IsResolvedStatus("ok"))
+	assert.False(t, mapping.IsResolvedStatus("firing"))
+	assert.False(t, mapping.IsResolvedStatus(""))
+}
@@ -0,0 +1,68 @@
+package valueobject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func newFilterAlert(t *testing.T) *entity.Alert {
+	t.Helper()
+	alert, err := entity.NewAlert("Payment gateway timeout", "Requests to the PSP time out", entity.AlertSeverityCritical, "grafana")
+	require.NoError(t, err)
+	alert.Metadata["labels"] = map[string]interface{}{"service": "payments", "env": "production"}
+	return alert
+}
+
+func TestAlertFilter_Matches(t *testing.T) {
+	teamID := entity.NewID()
+
+	testCases := []struct {
+		name     string
+		filter   valueobject.AlertFilter
+		expected bool
+	}{
+		{"empty filter", valueobject.NewAlertFilter(), true},
+		{"status", valueobject.NewAlertFilter().WithStatuses(entity.AlertStatusActive), true},
+		{"other status", valueobject.NewAlertFilter().WithStatuses(entity.AlertStatusResolved), false},
+		{"severity", valueobject.NewAlertFilter().WithSeverities(entity.AlertSeverityHigh, entity.AlertSeverityCritical), true},
+		{"other severity", valueobject.NewAlertFilter().WithSeverities(entity.AlertSeverityLow), false},
+		{"source", valueobject.NewAlertFilter().WithSource("grafana"), true},
+		{"other source", valueobject.NewAlertFilter().WithSource("datadog"), false},
+		{"labels", valueobject.NewAlertFilter().WithLabels(map[string]string{"service": "payments"}), true},
+		{"other label value", valueobject.NewAlertFilter().WithLabels(map[string]string{"service": "orders"}), false},
+		{"missing label", valueobject.NewAlertFilter().WithLabels(map[string]string{"region": "eu"}), false},
+		{"search in title ignoring case", valueobject.NewAlertFilter().WithSearch("GATEWAY"), true},
+		{"search in message", valueobject.NewAlertFilter().WithSearch("psp"), true},
+		{"search mismatch", valueobject.NewAlertFilter().WithSearch("disk"), false},
+		{"team", valueobject.NewAlertFilter().WithTeamID(teamID), false},
+		{"date range", valueobject.NewAlertFilter().WithDateRange(time.Now().Add(-time.Hour), time.Now().Add(time.Hour)), true},
+		{"before range", valueobject.NewAlertFilter().WithDateRange(time.Now().Add(time.Hour), time.Now().Add(2*time.Hour)), false},
+		{"deleted only", valueobject.NewAlertFilter().DeletedOnly(), false},
+		{"hidden", valueobject.NewAlertFilter().WithVisibility(
+			valueobject.NewVisibilityPolicy(valueobject.VisibilityRule{Source: "grafana"}), entity.UserRoleViewer), false},
+		{"other tenant", valueobject.NewAlertFilter().WithTenant(valueobject.TeamsScope(teamID)), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alert := newFilterAlert(t)
+			assert.Equal(t, tc.expected, tc.filter.Matches(alert))
+		})
+	}
+}
+
+func TestAlertFilter_MatchesTeamAlerts(t *testing.T) {
+	teamID := entity.NewID()
+	alert := newFilterAlert(t)
+	alert.TeamID = &teamID
+
+	assert.True(t, valueobject.NewAlertFilter().WithTeamID(teamID).Matches(alert))
+	assert.True(t, valueobject.NewAlertFilter().WithTenant(valueobject.TeamsScope(teamID)).Matches(alert))
+	assert.False(t, valueobject.NewAlertFilter().WithTenant(valueobject.TeamsScope(entity.NewID())).Matches(alert))
+}
@@ -0,0 +1,70 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func newAssignmentRule(t *testing.T, name string, position int, match entity.AssignmentRuleMatcher) *entity.AssignmentRule {
+	t.Helper()
+
+	teamID := entity.NewID()
+	rule, err := entity.NewAssignmentRule(name, match, &teamID, nil, position, nil)
+	require.NoError(t, err)
+	return rule
+}
+
+func TestAssignmentPolicy_EmptyDoesNotMatch(t *testing.T) {
+	var policy valueobject.AssignmentPolicy
+
+	alert := newLabeledAlert(t, "Latency", "payments-api", entity.AlertSeverityHigh, nil)
+
+	assert.True(t, policy.IsEmpty())
+	assert.Nil(t, policy.Match(alert))
+}
+
+func TestAssignmentPolicy_Match(t *testing.T) {
+	payments := newAssignmentRule(t, "payments", 1, entity.AssignmentRuleMatcher{
+		Labels: map[string]string{"service": "payments"},
+	})
+	paymentsProd := newAssignmentRule(t, "payments-prod", 0, entity.AssignmentRuleMatcher{
+		Sources: []string{"prometheus"},
+		Labels:  map[string]string{"service": "payments", "env": "prod"},
+	})
+	disabled := newAssignmentRule(t, "disabled", -1, entity.AssignmentRuleMatcher{
+		Sources: []string{"prometheus"},
+	})
+	disabled.IsEnabled = false
+
+	policy := valueobject.NewAssignmentPolicy([]*entity.AssignmentRule{payments, paymentsProd, disabled})
+
+	testCases := []struct {
+		name     string
+		alert    *entity.Alert
+		expected *entity.AssignmentRule
+	}{
+		{"label", newLabeledAlert(t, "Latency", "grafana", entity.AlertSeverityHigh, map[string]string{"service": "payments"}), payments},
+		{
+			"lower position first",
+			newLabeledAlert(t, "Latency", "prometheus", entity.AlertSeverityHigh, map[string]string{"service": "payments", "env": "prod"}),
+			paymentsProd,
+		},
+		{
+			"source mismatch falls through",
+			newLabeledAlert(t, "Latency", "grafana", entity.AlertSeverityHigh, map[string]string{"service": "payments", "env": "prod"}),
+			payments,
+		},
+		{"no match", newLabeledAlert(t, "Latency", "prometheus", entity.AlertSeverityHigh, map[string]string{"service": "orders"}), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, policy.Match(tc.alert))
+		})
+	}
+}
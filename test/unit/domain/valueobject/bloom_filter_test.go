@@ -0,0 +1,54 @@
+package valueobject_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestBloomFilter(t *testing.T) {
+	t.Run("contains added items", func(t *testing.T) {
+		filter := valueobject.NewBloomFilter(5000, 0.01)
+		for i := 0; i < 5000; i++ {
+			filter.Add(fmt.Sprintf("token-%d", i))
+		}
+
+		for i := 0; i < 5000; i++ {
+			assert.True(t, filter.MayContain(fmt.Sprintf("token-%d", i)))
+		}
+	})
+
+	t.Run("empty filter contains nothing", func(t *testing.T) {
+		filter := valueobject.NewBloomFilter(0, 0.01)
+
+		assert.False(t, filter.MayContain("token"))
+		assert.False(t, filter.MayContain(""))
+	})
+
+	t.Run("false positives near the configured rate", func(t *testing.T) {
+		filter := valueobject.NewBloomFilter(5000, 0.01)
+		for i := 0; i < 5000; i++ {
+			filter.Add(fmt.Sprintf("token-%d", i))
+		}
+
+		falsePositives := 0
+		for i := 0; i < 10000; i++ {
+			if filter.MayContain(fmt.Sprintf("other-%d", i)) {
+				falsePositives++
+			}
+		}
+
+		assert.Less(t, falsePositives, 300)
+	})
+
+	t.Run("invalid rate falls back to the default", func(t *testing.T) {
+		filter := valueobject.NewBloomFilter(10, 2)
+		filter.Add("token")
+
+		assert.True(t, filter.MayContain("token"))
+		assert.False(t, filter.MayContain("other"))
+	})
+}
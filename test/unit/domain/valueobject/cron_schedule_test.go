@@ -0,0 +1,98 @@
+package valueobject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestParseCronSchedule_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr error
+	}{
+		{"every minute", "* * * * *", nil},
+		{"weekday names", "0 9 * * mon-fri", valueobject.ErrCronFieldInvalid},
+		{"lists ranges and steps", "0,30 9-17 */2 1-6/2 1-5", nil},
+		{"sunday as seven", "0 0 * * 7", nil},
+		{"descriptor", "@daily", nil},
+		{"interval", "@every 30s", nil},
+		{"too few fields", "* * * *", valueobject.ErrCronScheduleInvalid},
+		{"out of range", "60 * * * *", valueobject.ErrCronFieldInvalid},
+		{"reversed range", "* 10-2 * * *", valueobject.ErrCronFieldInvalid},
+		{"zero step", "*/0 * * * *", valueobject.ErrCronFieldInvalid},
+		{"unknown descriptor", "@fortnightly", valueobject.ErrCronDescriptorUnknown},
+		{"interval too short", "@every 10ms", valueobject.ErrCronIntervalInvalid},
+		{"impossible date", "0 0 30 2 *", valueobject.ErrCronScheduleNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := valueobject.ParseCronSchedule(tt.expr)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Monday 6 January 2025, 10:07:30 UTC
+	from := time.Date(2025, 1, 6, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2025, 1, 6, 10, 8, 0, 0, time.UTC)},
+		{"every fifteen minutes", "*/15 * * * *", time.Date(2025, 1, 6, 10, 15, 0, 0, time.UTC)},
+		{"hourly", "@hourly", time.Date(2025, 1, 6, 11, 0, 0, 0, time.UTC)},
+		{"daily", "30 2 * * *", time.Date(2025, 1, 7, 2, 30, 0, 0, time.UTC)},
+		{"weekly on sunday", "0 0 * * 7", time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"monthly", "@monthly", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"day of month or week", "0 0 15 * 3", time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			schedule, err := valueobject.ParseCronSchedule(tt.expr)
+			require.NoError(t, err)
+
+			// Act
+			next := schedule.Next(from)
+
+			// Assert
+			assert.Equal(t, tt.want, next)
+		})
+	}
+}
+
+func TestCronSchedule_NextIsStrictlyAfter(t *testing.T) {
+	schedule, err := valueobject.ParseCronSchedule("0 * * * *")
+	require.NoError(t, err)
+
+	at := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, at.Add(time.Hour), schedule.Next(at))
+}
+
+func TestCronSchedule_EveryIsAligned(t *testing.T) {
+	// Arrange
+	schedule, err := valueobject.ParseCronSchedule("@every 30s")
+	require.NoError(t, err)
+
+	// Act
+	first := schedule.Next(time.Date(2025, 1, 6, 10, 0, 12, 0, time.UTC))
+	second := schedule.Next(time.Date(2025, 1, 6, 10, 0, 29, 0, time.UTC))
+
+	// Assert
+	assert.Equal(t, time.Date(2025, 1, 6, 10, 0, 30, 0, time.UTC), first)
+	assert.Equal(t, first, second)
+	assert.Equal(t, "@every 30s", schedule.String())
+}
@@ -0,0 +1,57 @@
+package valueobject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestNewDeprecation(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := valueobject.NewDeprecation("GET", "", since, time.Time{}, "")
+	assert.ErrorIs(t, err, valueobject.ErrDeprecationPathRequired)
+
+	_, err = valueobject.NewDeprecation("GET", "/api/v1/alerts", time.Time{}, time.Time{}, "")
+	assert.ErrorIs(t, err, valueobject.ErrDeprecationSinceRequired)
+
+	_, err = valueobject.NewDeprecation("GET", "/api/v1/alerts", since, since, "")
+	assert.ErrorIs(t, err, valueobject.ErrDeprecationSunsetInvalid)
+
+	notice, err := valueobject.NewDeprecation("get", "/api/v1/alerts", since, since.AddDate(0, 6, 0), "")
+	require.NoError(t, err)
+	assert.Equal(t, "GET", notice.Method)
+}
+
+func TestDeprecation_Matches(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	exact, err := valueobject.NewDeprecation("GET", "/api/v1/alerts/statistics", since, time.Time{}, "")
+	require.NoError(t, err)
+	assert.True(t, exact.Matches("GET", "/api/v1/alerts/statistics"))
+	assert.False(t, exact.Matches("POST", "/api/v1/alerts/statistics"))
+	assert.False(t, exact.Matches("GET", "/api/v1/alerts/statistics/extra"))
+
+	prefix, err := valueobject.NewDeprecation("", "/api/v1/legacy/*", since, time.Time{}, "")
+	require.NoError(t, err)
+	assert.True(t, prefix.Matches("DELETE", "/api/v1/legacy/items/1"))
+	assert.False(t, prefix.Matches("GET", "/api/v1/alerts"))
+}
+
+func TestDeprecation_IsSunset(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := since.AddDate(0, 6, 0)
+
+	notice, err := valueobject.NewDeprecation("", "/api/v1/legacy", since, sunset, "")
+	require.NoError(t, err)
+	assert.False(t, notice.IsSunset(sunset.Add(-time.Second)))
+	assert.True(t, notice.IsSunset(sunset))
+
+	unscheduled, err := valueobject.NewDeprecation("", "/api/v1/legacy", since, time.Time{}, "")
+	require.NoError(t, err)
+	assert.False(t, unscheduled.IsSunset(sunset))
+}
@@ -0,0 +1,51 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestResolveFeature(t *testing.T) {
+	enabled, disabled := true, false
+
+	testCases := []struct {
+		name       string
+		configured bool
+		deployment *bool
+		org        *bool
+		enabled    bool
+		source     string
+	}{
+		{"configured only", true, nil, nil, true, valueobject.FeatureSourceConfig},
+		{"deployment override", false, &enabled, nil, true, valueobject.FeatureSourceDeployment},
+		{"org override wins", true, &enabled, &disabled, false, valueobject.FeatureSourceOrg},
+		{"org override without deployment", false, nil, &enabled, true, valueobject.FeatureSourceOrg},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flag := valueobject.ResolveFeature("sse", tc.configured, tc.deployment, tc.org)
+			assert.Equal(t, "sse", flag.Name)
+			assert.Equal(t, tc.enabled, flag.Enabled)
+			assert.Equal(t, tc.source, flag.Source)
+		})
+	}
+}
+
+func TestNewFeatureDefaults(t *testing.T) {
+	defaults, err := valueobject.NewFeatureDefaults(map[string]bool{"graphql": true, "bulk-export": true})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"bulk-export", "graphql", "incidents", "sse"}, defaults.Names())
+	assert.True(t, defaults["graphql"])
+	assert.False(t, defaults["incidents"])
+	assert.True(t, defaults.Has("bulk-export"))
+	assert.False(t, defaults.Has("unknown"))
+
+	_, err = valueobject.NewFeatureDefaults(map[string]bool{"Bad Name": true})
+	assert.ErrorIs(t, err, valueobject.ErrFeatureNameInvalid)
+}
@@ -0,0 +1,53 @@
+package valueobject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestNewFlappingPolicy(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		policy, err := valueobject.NewFlappingPolicy(6, 30*time.Minute, 30*time.Minute)
+
+		require.NoError(t, err)
+		assert.True(t, policy.IsEnabled())
+	})
+
+	t.Run("zero threshold disables detection", func(t *testing.T) {
+		policy, err := valueobject.NewFlappingPolicy(0, 0, 0)
+
+		require.NoError(t, err)
+		assert.False(t, policy.IsEnabled())
+	})
+
+	t.Run("negative threshold", func(t *testing.T) {
+		_, err := valueobject.NewFlappingPolicy(-1, time.Minute, time.Minute)
+
+		assert.ErrorIs(t, err, valueobject.ErrFlappingThresholdInvalid)
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		_, err := valueobject.NewFlappingPolicy(6, 0, time.Minute)
+		assert.ErrorIs(t, err, valueobject.ErrFlappingWindowInvalid)
+
+		_, err = valueobject.NewFlappingPolicy(6, time.Minute, 0)
+		assert.ErrorIs(t, err, valueobject.ErrFlappingWindowInvalid)
+	})
+}
+
+func TestFlappingPolicy_IsFlapping(t *testing.T) {
+	policy, err := valueobject.NewFlappingPolicy(4, time.Minute, time.Minute)
+	require.NoError(t, err)
+
+	assert.False(t, policy.IsFlapping(0))
+	assert.False(t, policy.IsFlapping(3))
+	assert.True(t, policy.IsFlapping(4))
+	assert.True(t, policy.IsFlapping(10))
+
+	assert.False(t, valueobject.FlappingPolicy{}.IsFlapping(10))
+}
@@ -0,0 +1,87 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func newCorrelatedAlert(t *testing.T, severity entity.AlertSeverity) *entity.Alert {
+	t.Helper()
+	alert, err := entity.NewAlert("High latency", "p99 above 2s", severity, "checkout")
+	require.NoError(t, err)
+	return alert
+}
+
+func TestNewIncidentCorrelationPolicy(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		policy, err := valueobject.NewIncidentCorrelationPolicy([]string{"rule", " team "}, entity.AlertSeverityHigh)
+
+		require.NoError(t, err)
+		assert.True(t, policy.IsEnabled())
+		assert.Equal(t, []string{"rule", "team"}, policy.GroupBy)
+	})
+
+	t.Run("no attributes disable correlation", func(t *testing.T) {
+		policy, err := valueobject.NewIncidentCorrelationPolicy([]string{""}, "")
+
+		require.NoError(t, err)
+		assert.False(t, policy.IsEnabled())
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		_, err := valueobject.NewIncidentCorrelationPolicy([]string{"host"}, entity.AlertSeverityHigh)
+
+		assert.ErrorIs(t, err, valueobject.ErrCorrelationAttributeInvalid)
+	})
+
+	t.Run("invalid severity", func(t *testing.T) {
+		_, err := valueobject.NewIncidentCorrelationPolicy([]string{"source"}, "urgent")
+
+		assert.ErrorIs(t, err, valueobject.ErrCorrelationSeverityInvalid)
+	})
+}
+
+func TestIncidentCorrelationPolicy_Key(t *testing.T) {
+	policy, err := valueobject.NewIncidentCorrelationPolicy([]string{"source", "team"}, entity.AlertSeverityHigh)
+	require.NoError(t, err)
+
+	t.Run("alert with every attribute", func(t *testing.T) {
+		// Arrange
+		alert := newCorrelatedAlert(t, entity.AlertSeverityHigh)
+		teamID := entity.NewID()
+		alert.TeamID = &teamID
+
+		// Act
+		key, ok := policy.Key(alert)
+
+		// Assert
+		assert.True(t, ok)
+		assert.Equal(t, "source=checkout,team="+teamID.String(), key)
+	})
+
+	t.Run("alert missing an attribute", func(t *testing.T) {
+		_, ok := policy.Key(newCorrelatedAlert(t, entity.AlertSeverityHigh))
+
+		assert.False(t, ok)
+	})
+
+	t.Run("disabled policy", func(t *testing.T) {
+		_, ok := valueobject.IncidentCorrelationPolicy{}.Key(newCorrelatedAlert(t, entity.AlertSeverityHigh))
+
+		assert.False(t, ok)
+	})
+}
+
+func TestIncidentCorrelationPolicy_Opens(t *testing.T) {
+	policy, err := valueobject.NewIncidentCorrelationPolicy([]string{"source"}, entity.AlertSeverityHigh)
+	require.NoError(t, err)
+
+	assert.True(t, policy.Opens(newCorrelatedAlert(t, entity.AlertSeverityCritical)))
+	assert.True(t, policy.Opens(newCorrelatedAlert(t, entity.AlertSeverityHigh)))
+	assert.False(t, policy.Opens(newCorrelatedAlert(t, entity.AlertSeverityMedium)))
+}
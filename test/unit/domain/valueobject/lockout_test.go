@@ -0,0 +1,69 @@
+package valueobject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestNewLockoutPolicy(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		policy, err := valueobject.NewLockoutPolicy(5, time.Minute, time.Hour, 24*time.Hour)
+
+		require.NoError(t, err)
+		assert.True(t, policy.IsEnabled())
+	})
+
+	t.Run("zero attempts disables lockouts", func(t *testing.T) {
+		policy, err := valueobject.NewLockoutPolicy(0, 0, 0, 0)
+
+		require.NoError(t, err)
+		assert.False(t, policy.IsEnabled())
+	})
+
+	t.Run("negative attempts", func(t *testing.T) {
+		_, err := valueobject.NewLockoutPolicy(-1, time.Minute, time.Hour, time.Hour)
+
+		assert.ErrorIs(t, err, valueobject.ErrLockoutAttemptsInvalid)
+	})
+
+	t.Run("invalid delays", func(t *testing.T) {
+		_, err := valueobject.NewLockoutPolicy(5, 0, time.Hour, time.Hour)
+		assert.ErrorIs(t, err, valueobject.ErrLockoutDelayInvalid)
+
+		_, err = valueobject.NewLockoutPolicy(5, time.Hour, time.Minute, time.Hour)
+		assert.ErrorIs(t, err, valueobject.ErrLockoutDelayInvalid)
+
+		_, err = valueobject.NewLockoutPolicy(5, time.Minute, time.Hour, 0)
+		assert.ErrorIs(t, err, valueobject.ErrLockoutDelayInvalid)
+	})
+}
+
+func TestLockoutPolicy_DelayAfter(t *testing.T) {
+	policy, err := valueobject.NewLockoutPolicy(3, time.Minute, 10*time.Minute, time.Hour)
+	require.NoError(t, err)
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{2, 0},
+		{3, time.Minute},
+		{4, 2 * time.Minute},
+		{5, 4 * time.Minute},
+		{6, 8 * time.Minute},
+		{7, 10 * time.Minute},
+		{100, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, policy.DelayAfter(tt.failures), "after %d failures", tt.failures)
+	}
+
+	assert.Zero(t, valueobject.LockoutPolicy{}.DelayAfter(10))
+}
@@ -0,0 +1,71 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestNewNetworkACL(t *testing.T) {
+	t.Run("CIDR ranges and addresses", func(t *testing.T) {
+		acl, err := valueobject.NewNetworkACL([]string{"10.8.0.0/16", " 192.168.1.7 ", "fd00::/8"}, []string{"10.8.1.0/24"})
+
+		require.NoError(t, err)
+		assert.True(t, acl.IsEnabled())
+		assert.Len(t, acl.Allow, 3)
+		assert.Len(t, acl.Deny, 1)
+	})
+
+	t.Run("empty lists", func(t *testing.T) {
+		acl, err := valueobject.NewNetworkACL(nil, []string{""})
+
+		require.NoError(t, err)
+		assert.False(t, acl.IsEnabled())
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		_, err := valueobject.NewNetworkACL([]string{"10.8.0.0/33"}, nil)
+		assert.ErrorIs(t, err, valueobject.ErrNetworkInvalid)
+
+		_, err = valueobject.NewNetworkACL(nil, []string{"vpn"})
+		assert.ErrorIs(t, err, valueobject.ErrNetworkInvalid)
+	})
+}
+
+func TestNetworkACL_Allows(t *testing.T) {
+	t.Run("zero ACL allows everyone", func(t *testing.T) {
+		assert.True(t, valueobject.NetworkACL{}.Allows("203.0.113.5"))
+		assert.True(t, valueobject.NetworkACL{}.Allows("not an ip"))
+	})
+
+	t.Run("allow list", func(t *testing.T) {
+		acl, err := valueobject.NewNetworkACL([]string{"10.8.0.0/16", "192.168.1.7"}, nil)
+		require.NoError(t, err)
+
+		assert.True(t, acl.Allows("10.8.3.4"))
+		assert.True(t, acl.Allows("192.168.1.7"))
+		assert.True(t, acl.Allows("::ffff:10.8.3.4"))
+		assert.False(t, acl.Allows("192.168.1.8"))
+		assert.False(t, acl.Allows("203.0.113.5"))
+		assert.False(t, acl.Allows("not an ip"))
+	})
+
+	t.Run("deny wins over allow", func(t *testing.T) {
+		acl, err := valueobject.NewNetworkACL([]string{"10.8.0.0/16"}, []string{"10.8.1.0/24"})
+		require.NoError(t, err)
+
+		assert.True(t, acl.Allows("10.8.2.1"))
+		assert.False(t, acl.Allows("10.8.1.1"))
+	})
+
+	t.Run("deny list only", func(t *testing.T) {
+		acl, err := valueobject.NewNetworkACL(nil, []string{"203.0.113.0/24"})
+		require.NoError(t, err)
+
+		assert.True(t, acl.Allows("198.51.100.1"))
+		assert.False(t, acl.Allows("203.0.113.5"))
+	})
+}
@@ -0,0 +1,42 @@
+package valueobject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestNewNotificationBatch_Validation(t *testing.T) {
+	_, err := valueobject.NewNotificationBatch("", "low", time.Minute)
+	assert.ErrorIs(t, err, valueobject.ErrBatchChannelRequired)
+
+	_, err = valueobject.NewNotificationBatch("slack", "urgent", time.Minute)
+	assert.ErrorIs(t, err, valueobject.ErrBatchSeverityInvalid)
+
+	_, err = valueobject.NewNotificationBatch("slack", "low", 30*time.Second)
+	assert.ErrorIs(t, err, valueobject.ErrBatchIntervalInvalid)
+
+	batch, err := valueobject.NewNotificationBatch("slack", "", 15*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "slack", batch.Channel())
+	assert.Equal(t, 15*time.Minute, batch.Interval())
+}
+
+func TestNotificationBatch_Includes(t *testing.T) {
+	batch, err := valueobject.NewNotificationBatch("slack", "", 15*time.Minute)
+	require.NoError(t, err)
+
+	assert.True(t, batch.Includes("low"))
+	assert.True(t, batch.Includes("info"))
+	assert.False(t, batch.Includes("medium"))
+	assert.False(t, batch.Includes("critical"))
+
+	medium, err := valueobject.NewNotificationBatch("slack", "medium", 15*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, medium.Includes("medium"))
+	assert.False(t, medium.Includes("high"))
+}
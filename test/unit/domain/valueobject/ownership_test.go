@@ -0,0 +1,40 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestActor_AdminCanModifyEverything(t *testing.T) {
+	admin := valueobject.NewActor(entity.NewID(), entity.UserRoleAdmin)
+	other := entity.NewID()
+
+	assert.True(t, admin.IsAdmin())
+	assert.True(t, admin.CanModify(&other))
+	assert.True(t, admin.CanModify(&admin.UserID))
+	assert.True(t, admin.CanModify(nil))
+}
+
+func TestActor_OthersCanOnlyModifyTheirOwn(t *testing.T) {
+	for _, role := range []entity.UserRole{entity.UserRoleOperator, entity.UserRoleViewer} {
+		t.Run(string(role), func(t *testing.T) {
+			actor := valueobject.NewActor(entity.NewID(), role)
+			creator := actor.UserID
+			other := entity.NewID()
+
+			assert.False(t, actor.IsAdmin())
+			assert.True(t, actor.CanModify(&creator))
+			assert.False(t, actor.CanModify(&other))
+		})
+	}
+}
+
+func TestActor_ResourcesWithoutCreatorAreAdminOnly(t *testing.T) {
+	operator := valueobject.NewActor(entity.NewID(), entity.UserRoleOperator)
+
+	assert.False(t, operator.CanModify(nil))
+}
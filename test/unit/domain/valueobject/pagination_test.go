@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
 )
@@ -114,3 +115,43 @@ func TestNewPaginatedResult(t *testing.T) {
 	assert.True(t, result.HasNext)
 	assert.False(t, result.HasPrevious)
 }
+
+func TestNewPaginationPolicy(t *testing.T) {
+	policy, err := valueobject.NewPaginationPolicy(50, 500)
+	require.NoError(t, err)
+
+	assert.Equal(t, 50, policy.DefaultPageSize())
+	assert.Equal(t, 500, policy.MaxPageSize())
+	assert.Equal(t, 50, policy.Paginate(1, 0).PageSize())
+	assert.Equal(t, 500, policy.Paginate(1, 500).PageSize())
+	assert.Equal(t, 500, policy.Paginate(1, 800).PageSize())
+}
+
+func TestNewPaginationPolicy_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		defaultSize int
+		maxSize     int
+		expectedErr error
+	}{
+		{"zero default", 0, 100, valueobject.ErrDefaultPageSizeInvalid},
+		{"max below default", 50, 20, valueobject.ErrMaxPageSizeInvalid},
+		{"max above ceiling", 20, valueobject.PageSizeCeiling + 1, valueobject.ErrMaxPageSizeInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := valueobject.NewPaginationPolicy(tc.defaultSize, tc.maxSize)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestPaginationPolicy_ZeroValue(t *testing.T) {
+	var policy valueobject.PaginationPolicy
+
+	pagination := policy.Paginate(0, 1000)
+
+	assert.Equal(t, valueobject.DefaultPage, pagination.Page())
+	assert.Equal(t, valueobject.MaxPageSize, pagination.PageSize())
+}
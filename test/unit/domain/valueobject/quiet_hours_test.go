@@ -0,0 +1,97 @@
+package valueobject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestNewQuietHours_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		channel  string
+		start    string
+		end      string
+		timezone string
+		days     []string
+		bypass   string
+		wantErr  error
+	}{
+		{"valid", "slack", "22:00", "07:00", "Europe/Madrid", []string{"mon", "Friday"}, "critical", nil},
+		{"missing channel", "", "22:00", "07:00", "", nil, "", valueobject.ErrQuietHoursChannelRequired},
+		{"invalid start", "slack", "25:00", "07:00", "", nil, "", valueobject.ErrQuietHoursTimeInvalid},
+		{"invalid end", "slack", "22:00", "7am", "", nil, "", valueobject.ErrQuietHoursTimeInvalid},
+		{"empty window", "slack", "08:00", "08:00", "", nil, "", valueobject.ErrQuietHoursEmpty},
+		{"invalid timezone", "slack", "22:00", "07:00", "Mars/Olympus", nil, "", valueobject.ErrQuietHoursTimezoneInvalid},
+		{"invalid day", "slack", "22:00", "07:00", "", []string{"someday"}, "", valueobject.ErrQuietHoursDayInvalid},
+		{"invalid bypass", "slack", "22:00", "07:00", "", nil, "urgent", valueobject.ErrQuietHoursSeverityInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := valueobject.NewQuietHours(tt.channel, tt.start, tt.end, tt.timezone, tt.days, tt.bypass)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestQuietHours_IsQuietSameDay(t *testing.T) {
+	q, err := valueobject.NewQuietHours("slack", "12:00", "13:30", "", nil, "")
+	require.NoError(t, err)
+
+	assert.False(t, q.IsQuiet(time.Date(2025, 1, 6, 11, 59, 0, 0, time.UTC)))
+	assert.True(t, q.IsQuiet(time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, q.IsQuiet(time.Date(2025, 1, 6, 13, 29, 0, 0, time.UTC)))
+	assert.False(t, q.IsQuiet(time.Date(2025, 1, 6, 13, 30, 0, 0, time.UTC)))
+}
+
+func TestQuietHours_IsQuietAcrossMidnight(t *testing.T) {
+	q, err := valueobject.NewQuietHours("slack", "22:00", "07:00", "", nil, "")
+	require.NoError(t, err)
+
+	assert.True(t, q.IsQuiet(time.Date(2025, 1, 6, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, q.IsQuiet(time.Date(2025, 1, 7, 6, 59, 0, 0, time.UTC)))
+	assert.False(t, q.IsQuiet(time.Date(2025, 1, 7, 7, 0, 0, 0, time.UTC)))
+	assert.False(t, q.IsQuiet(time.Date(2025, 1, 7, 21, 59, 0, 0, time.UTC)))
+}
+
+func TestQuietHours_DaysApplyToWindowStart(t *testing.T) {
+	// Friday night only: 2025-01-10 is a Friday
+	q, err := valueobject.NewQuietHours("slack", "22:00", "07:00", "", []string{"fri"}, "")
+	require.NoError(t, err)
+
+	assert.True(t, q.IsQuiet(time.Date(2025, 1, 10, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, q.IsQuiet(time.Date(2025, 1, 11, 6, 0, 0, 0, time.UTC)))
+	assert.False(t, q.IsQuiet(time.Date(2025, 1, 10, 6, 0, 0, 0, time.UTC)))
+	assert.False(t, q.IsQuiet(time.Date(2025, 1, 11, 23, 0, 0, 0, time.UTC)))
+}
+
+func TestQuietHours_UsesTimezone(t *testing.T) {
+	q, err := valueobject.NewQuietHours("slack", "22:00", "07:00", "America/New_York", nil, "")
+	require.NoError(t, err)
+
+	// 03:00 UTC is 22:00 in New York (EST, UTC-5)
+	assert.True(t, q.IsQuiet(time.Date(2025, 1, 7, 3, 0, 0, 0, time.UTC)))
+	// 23:00 UTC is 18:00 in New York
+	assert.False(t, q.IsQuiet(time.Date(2025, 1, 6, 23, 0, 0, 0, time.UTC)))
+}
+
+func TestQuietHours_Bypass(t *testing.T) {
+	q, err := valueobject.NewQuietHours("slack", "00:00", "23:59", "", nil, "high")
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, q.Bypasses("critical"))
+	assert.True(t, q.Bypasses("high"))
+	assert.False(t, q.Bypasses("medium"))
+	assert.False(t, q.Suppresses(now, "critical"))
+	assert.True(t, q.Suppresses(now, "low"))
+
+	noBypass, err := valueobject.NewQuietHours("slack", "00:00", "23:59", "", nil, "")
+	require.NoError(t, err)
+	assert.False(t, noBypass.Bypasses("critical"))
+}
@@ -0,0 +1,58 @@
+package valueobject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestParseQuotaSubject(t *testing.T) {
+	teamID := entity.NewID()
+
+	subject, err := valueobject.ParseQuotaSubject("team:" + teamID.String())
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.TeamQuotaSubject(teamID), subject)
+	assert.Equal(t, "team:"+teamID.String(), subject.String())
+
+	for _, raw := range []string{"", "team", "user:" + teamID.String(), "source:not-an-id"} {
+		_, err := valueobject.ParseQuotaSubject(raw)
+		assert.ErrorIs(t, err, valueobject.ErrQuotaSubjectInvalid, raw)
+	}
+}
+
+func TestQuotaPolicy_LimitsFor(t *testing.T) {
+	_, err := valueobject.NewQuotaLimits(-1, 0)
+	assert.ErrorIs(t, err, valueobject.ErrQuotaInvalid)
+
+	defaults, err := valueobject.NewQuotaLimits(10000, 500)
+	require.NoError(t, err)
+	premium, err := valueobject.NewQuotaLimits(0, 5000)
+	require.NoError(t, err)
+
+	team := valueobject.TeamQuotaSubject(entity.NewID())
+	source := valueobject.SourceQuotaSubject(entity.NewID())
+	policy := valueobject.QuotaPolicy{
+		Default:   defaults,
+		Overrides: map[valueobject.QuotaSubject]valueobject.QuotaLimits{team: premium},
+	}
+
+	assert.True(t, policy.IsEnabled())
+	assert.Equal(t, 0, policy.LimitsFor(team).For(valueobject.QuotaRequests))
+	assert.Equal(t, 5000, policy.LimitsFor(team).For(valueobject.QuotaAlerts))
+	assert.Equal(t, 10000, policy.LimitsFor(source).For(valueobject.QuotaRequests))
+	assert.Equal(t, 500, policy.LimitsFor(source).For(valueobject.QuotaAlerts))
+
+	assert.False(t, valueobject.QuotaPolicy{}.IsEnabled())
+}
+
+func TestQuotaDay(t *testing.T) {
+	late := time.Date(2026, 3, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+
+	assert.Equal(t, "2026-04-01", valueobject.QuotaDay(late))
+	assert.Equal(t, time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC), valueobject.QuotaResetAt(late))
+}
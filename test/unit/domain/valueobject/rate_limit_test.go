@@ -0,0 +1,75 @@
+package valueobject_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestNewRateLimit(t *testing.T) {
+	_, err := valueobject.NewRateLimit(-1, time.Minute, nil)
+	assert.ErrorIs(t, err, valueobject.ErrRateLimitInvalid)
+
+	_, err = valueobject.NewRateLimit(100, 0, nil)
+	assert.ErrorIs(t, err, valueobject.ErrRateLimitWindowInvalid)
+
+	_, err = valueobject.NewRateLimit(100, time.Minute, map[entity.UserRole]int{"root": 10})
+	assert.ErrorIs(t, err, entity.ErrUserInvalidRole)
+
+	_, err = valueobject.NewRateLimit(100, time.Minute, map[entity.UserRole]int{entity.UserRoleAdmin: -1})
+	assert.ErrorIs(t, err, valueobject.ErrRateLimitInvalid)
+
+	disabled, err := valueobject.NewRateLimit(0, 0, nil)
+	require.NoError(t, err)
+	assert.False(t, disabled.IsEnabled())
+}
+
+func TestRateLimit_For(t *testing.T) {
+	limit, err := valueobject.NewRateLimit(100, time.Minute, map[entity.UserRole]int{
+		entity.UserRoleAdmin:    0,
+		entity.UserRoleOperator: 300,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, limit.IsEnabled())
+	assert.Equal(t, 100, limit.For(""))
+	assert.Equal(t, 100, limit.For(entity.UserRoleViewer))
+	assert.Equal(t, 300, limit.For(entity.UserRoleOperator))
+	assert.Equal(t, 0, limit.For(entity.UserRoleAdmin))
+}
+
+func TestRateLimit_IsEnabled_RolesOnly(t *testing.T) {
+	limit, err := valueobject.NewRateLimit(0, time.Minute, map[entity.UserRole]int{entity.UserRoleViewer: 10})
+	require.NoError(t, err)
+
+	assert.True(t, limit.IsEnabled())
+	assert.Equal(t, 0, limit.For(""))
+	assert.Equal(t, 10, limit.For(entity.UserRoleViewer))
+}
+
+func TestRouteRateLimit_Matches(t *testing.T) {
+	limit, err := valueobject.NewRateLimit(30, time.Minute, nil)
+	require.NoError(t, err)
+
+	_, err = valueobject.NewRouteRateLimit("POST", "", limit)
+	assert.ErrorIs(t, err, valueobject.ErrRateLimitPathRequired)
+
+	exact, err := valueobject.NewRouteRateLimit("post", "/api/v1/alerts", limit)
+	require.NoError(t, err)
+	assert.Equal(t, "POST /api/v1/alerts", exact.Name())
+	assert.True(t, exact.Matches("POST", "/api/v1/alerts"))
+	assert.False(t, exact.Matches("GET", "/api/v1/alerts"))
+	assert.False(t, exact.Matches("POST", "/api/v1/alerts/123/acknowledge"))
+
+	prefix, err := valueobject.NewRouteRateLimit("", "/api/v1/admin/*", limit)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/admin/*", prefix.Name())
+	assert.True(t, prefix.Matches("GET", "/api/v1/admin/runtime"))
+	assert.True(t, prefix.Matches("DELETE", "/api/v1/admin/alerts"))
+	assert.False(t, prefix.Matches("GET", "/api/v1/alerts"))
+}
@@ -0,0 +1,89 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/notification"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func newRoute(t *testing.T, name string, parent *entity.NotificationRoute, position int, match entity.RouteMatcher, continueMatching bool, channels ...string) *entity.NotificationRoute {
+	t.Helper()
+
+	var parentID *entity.ID
+	if parent != nil {
+		parentID = &parent.ID
+	}
+
+	route, err := entity.NewNotificationRoute(name, parentID, match, channels, continueMatching, position, nil)
+	require.NoError(t, err)
+	return route
+}
+
+func TestRoutingTree_EmptyDoesNotRoute(t *testing.T) {
+	var tree valueobject.RoutingTree
+
+	_, ok := tree.Resolve(notification.Message{Severity: "critical"})
+
+	assert.True(t, tree.IsEmpty())
+	assert.False(t, ok)
+}
+
+func TestRoutingTree_FirstMatchWins(t *testing.T) {
+	critical := newRoute(t, "critical", nil, 0, entity.RouteMatcher{Severities: []entity.AlertSeverity{"critical"}}, false, "pagerduty")
+	catchAll := newRoute(t, "catch-all", nil, 1, entity.RouteMatcher{}, false, "slack")
+	tree := valueobject.NewRoutingTree([]*entity.NotificationRoute{catchAll, critical})
+
+	channels, ok := tree.Resolve(notification.Message{Severity: "critical"})
+	require.True(t, ok)
+	assert.Equal(t, []string{"pagerduty"}, channels)
+
+	channels, ok = tree.Resolve(notification.Message{Severity: "low"})
+	require.True(t, ok)
+	assert.Equal(t, []string{"slack"}, channels)
+}
+
+func TestRoutingTree_ContinueCollectsSiblings(t *testing.T) {
+	audit := newRoute(t, "audit", nil, 0, entity.RouteMatcher{}, true, "email")
+	database := newRoute(t, "database", nil, 1, entity.RouteMatcher{Sources: []string{"postgres"}}, false, "slack", "email")
+	tree := valueobject.NewRoutingTree([]*entity.NotificationRoute{audit, database})
+
+	channels, ok := tree.Resolve(notification.Message{Source: "postgres"})
+
+	require.True(t, ok)
+	assert.Equal(t, []string{"email", "slack"}, channels)
+}
+
+func TestRoutingTree_ChildrenOverrideParent(t *testing.T) {
+	teamID := entity.NewID()
+	team := newRoute(t, "team", nil, 0, entity.RouteMatcher{TeamID: &teamID}, false, "slack")
+	production := newRoute(t, "production", team, 0, entity.RouteMatcher{Labels: map[string]string{"env": "production"}}, false, "sms")
+	tree := valueobject.NewRoutingTree([]*entity.NotificationRoute{production, team})
+
+	channels, ok := tree.Resolve(notification.Message{TeamID: teamID.String(), Labels: map[string]string{"env": "production"}})
+	require.True(t, ok)
+	assert.Equal(t, []string{"sms"}, channels)
+
+	// No child matches: the parent's channels apply
+	channels, ok = tree.Resolve(notification.Message{TeamID: teamID.String(), Labels: map[string]string{"env": "staging"}})
+	require.True(t, ok)
+	assert.Equal(t, []string{"slack"}, channels)
+
+	// The parent does not match: children are not evaluated
+	_, ok = tree.Resolve(notification.Message{Labels: map[string]string{"env": "production"}})
+	assert.False(t, ok)
+}
+
+func TestRoutingTree_EmptyChannelsDrop(t *testing.T) {
+	mute := newRoute(t, "mute info", nil, 0, entity.RouteMatcher{Severities: []entity.AlertSeverity{"info"}}, false)
+	tree := valueobject.NewRoutingTree([]*entity.NotificationRoute{mute})
+
+	channels, ok := tree.Resolve(notification.Message{Severity: "info"})
+
+	require.True(t, ok)
+	assert.Empty(t, channels)
+}
@@ -0,0 +1,77 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func newSeverityRule(t *testing.T, name string, position int, match entity.SeverityRuleMatcher, severity entity.AlertSeverity) *entity.SeverityRule {
+	t.Helper()
+
+	rule, err := entity.NewSeverityRule(name, match, severity, position, nil)
+	require.NoError(t, err)
+	return rule
+}
+
+func newLabeledAlert(t *testing.T, title, source string, severity entity.AlertSeverity, labels map[string]string) *entity.Alert {
+	t.Helper()
+
+	alert, err := entity.NewAlert(title, "message", severity, source)
+	require.NoError(t, err)
+	if labels != nil {
+		alert.AddMetadata(entity.MetadataLabels, labels)
+	}
+	return alert
+}
+
+func TestSeverityPolicy_EmptyDoesNotMatch(t *testing.T) {
+	var policy valueobject.SeverityPolicy
+
+	alert := newLabeledAlert(t, "CPU high", "staging", entity.AlertSeverityCritical, nil)
+
+	assert.True(t, policy.IsEmpty())
+	assert.Nil(t, policy.Match(alert))
+}
+
+func TestSeverityPolicy_Match(t *testing.T) {
+	staging := newSeverityRule(t, "staging", 0, entity.SeverityRuleMatcher{
+		Sources:    []string{"staging"},
+		Severities: []entity.AlertSeverity{entity.AlertSeverityCritical},
+	}, entity.AlertSeverityMedium)
+	diskFull := newSeverityRule(t, "disk-full", 1, entity.SeverityRuleMatcher{
+		TitlePattern: "^DiskFull",
+	}, entity.AlertSeverityHigh)
+	payments := newSeverityRule(t, "payments", 2, entity.SeverityRuleMatcher{
+		Labels: map[string]string{"service": "payments"},
+	}, entity.AlertSeverityCritical)
+	disabled := newSeverityRule(t, "disabled", -1, entity.SeverityRuleMatcher{
+		Sources: []string{"staging"},
+	}, entity.AlertSeverityInfo)
+	disabled.IsEnabled = false
+
+	policy := valueobject.NewSeverityPolicy([]*entity.SeverityRule{payments, diskFull, staging, disabled})
+
+	testCases := []struct {
+		name     string
+		alert    *entity.Alert
+		expected *entity.SeverityRule
+	}{
+		{"source and severity", newLabeledAlert(t, "CPU high", "staging", entity.AlertSeverityCritical, nil), staging},
+		{"source without severity", newLabeledAlert(t, "CPU high", "staging", entity.AlertSeverityLow, nil), nil},
+		{"title pattern", newLabeledAlert(t, "DiskFull /var", "prod", entity.AlertSeverityLow, nil), diskFull},
+		{"lower position first", newLabeledAlert(t, "DiskFull /var", "staging", entity.AlertSeverityCritical, nil), staging},
+		{"labels", newLabeledAlert(t, "Latency", "prod", entity.AlertSeverityLow, map[string]string{"service": "payments", "env": "prod"}), payments},
+		{"other label value", newLabeledAlert(t, "Latency", "prod", entity.AlertSeverityLow, map[string]string{"service": "orders"}), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, policy.Match(tc.alert))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestParseComponentStatus(t *testing.T) {
+	status, err := valueobject.ParseComponentStatus("partial_outage")
+	require.NoError(t, err)
+	assert.Equal(t, valueobject.ComponentPartialOutage, status)
+
+	_, err = valueobject.ParseComponentStatus("down")
+	assert.ErrorIs(t, err, valueobject.ErrComponentStatusInvalid)
+}
+
+func TestComponentStatusFor(t *testing.T) {
+	testCases := []struct {
+		severity entity.AlertSeverity
+		status   valueobject.ComponentStatus
+	}{
+		{entity.AlertSeverityCritical, valueobject.ComponentMajorOutage},
+		{entity.AlertSeverityHigh, valueobject.ComponentPartialOutage},
+		{entity.AlertSeverityMedium, valueobject.ComponentDegradedPerformance},
+		{entity.AlertSeverityLow, valueobject.ComponentOperational},
+		{"", valueobject.ComponentOperational},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.severity), func(t *testing.T) {
+			assert.Equal(t, tc.status, valueobject.ComponentStatusFor(tc.severity))
+		})
+	}
+}
+
+func TestResolveComponent(t *testing.T) {
+	component := valueobject.ResolveComponent("abc", []string{"api"}, valueobject.ComponentMajorOutage, "")
+	assert.Equal(t, valueobject.ComponentMajorOutage, component.Status)
+
+	component = valueobject.ResolveComponent("abc", []string{"api"},
+		valueobject.ComponentMajorOutage, valueobject.ComponentUnderMaintenance)
+	assert.Equal(t, valueobject.ComponentMajorOutage, component.AlertStatus)
+	assert.Equal(t, valueobject.ComponentUnderMaintenance, component.Status)
+}
+
+func TestNewStatusPageComponents(t *testing.T) {
+	components, err := valueobject.NewStatusPageComponents([]string{
+		"api-gateway=abc", "checkout = def", "Payments=abc",
+	})
+	require.NoError(t, err)
+
+	component, ok := components.ComponentOf("Payments")
+	assert.True(t, ok)
+	assert.Equal(t, "abc", component)
+	_, ok = components.ComponentOf("payments")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"Payments", "api-gateway"}, components.Sources("abc"))
+	assert.Equal(t, []string{"checkout"}, components.Sources("def"))
+	assert.Nil(t, components.Sources("ghi"))
+	assert.Equal(t, []string{"abc", "def"}, components.Components())
+	assert.False(t, components.IsEmpty())
+}
+
+func TestNewStatusPageComponents_Invalid(t *testing.T) {
+	_, err := valueobject.NewStatusPageComponents([]string{"api-gateway"})
+	assert.ErrorIs(t, err, valueobject.ErrComponentMappingInvalid)
+
+	_, err = valueobject.NewStatusPageComponents([]string{"api=abc", "api=def"})
+	assert.ErrorIs(t, err, valueobject.ErrComponentMappingDuplicate)
+
+	components, err := valueobject.NewStatusPageComponents(nil)
+	require.NoError(t, err)
+	assert.True(t, components.IsEmpty())
+}
@@ -0,0 +1,75 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func TestTenantScope_ZeroValueOnlyAllowsShared(t *testing.T) {
+	var scope valueobject.TenantScope
+	teamID := entity.NewID()
+
+	assert.False(t, scope.IsAll())
+	assert.True(t, scope.Allows(nil))
+	assert.False(t, scope.Allows(&teamID))
+	assert.Equal(t, "shared", scope.Key())
+}
+
+func TestTenantScope_AllTenants(t *testing.T) {
+	scope := valueobject.AllTenants()
+	teamID := entity.NewID()
+
+	assert.True(t, scope.IsAll())
+	assert.True(t, scope.Allows(nil))
+	assert.True(t, scope.Allows(&teamID))
+	assert.Equal(t, "all", scope.Key())
+}
+
+func TestTenantScope_Teams(t *testing.T) {
+	teamA, teamB, other := entity.NewID(), entity.NewID(), entity.NewID()
+	scope := valueobject.TeamsScope(teamA, teamB)
+
+	assert.True(t, scope.Allows(nil))
+	assert.True(t, scope.Allows(&teamA))
+	assert.True(t, scope.Allows(&teamB))
+	assert.False(t, scope.Allows(&other))
+	assert.Len(t, scope.Teams(), 2)
+
+	// The key does not depend on the order of the teams
+	assert.Equal(t, scope.Key(), valueobject.TeamsScope(teamB, teamA).Key())
+	assert.NotEqual(t, scope.Key(), valueobject.TeamsScope(teamA).Key())
+}
+
+func TestTenantScope_Narrow(t *testing.T) {
+	teamA, teamB := entity.NewID(), entity.NewID()
+
+	narrowed, ok := valueobject.TeamsScope(teamA, teamB).Narrow(teamA)
+	require.True(t, ok)
+	assert.Equal(t, []entity.ID{teamA}, narrowed.Teams())
+
+	_, ok = valueobject.TeamsScope(teamA).Narrow(teamB)
+	assert.False(t, ok)
+
+	narrowed, ok = valueobject.AllTenants().Narrow(teamB)
+	require.True(t, ok)
+	assert.False(t, narrowed.IsAll())
+	assert.False(t, narrowed.Allows(&teamA))
+}
+
+func TestAlertFilter_WithTenant(t *testing.T) {
+	teamID := entity.NewID()
+
+	filter := valueobject.NewAlertFilter().WithTenant(valueobject.TeamsScope(teamID))
+	require.NotNil(t, filter.Tenant)
+	assert.True(t, filter.IsEmpty())
+
+	filter = filter.WithTenant(valueobject.AllTenants())
+	assert.Nil(t, filter.Tenant)
+
+	assert.False(t, valueobject.NewAlertFilter().WithTeamID(teamID).IsEmpty())
+}
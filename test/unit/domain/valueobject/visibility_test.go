@@ -0,0 +1,91 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+)
+
+func newVisibilityAlert(t *testing.T, source string, labels map[string]interface{}) *entity.Alert {
+	t.Helper()
+	alert, err := entity.NewAlert("Alert", "message", entity.AlertSeverityHigh, source)
+	require.NoError(t, err)
+	if labels != nil {
+		alert.Metadata["labels"] = labels
+	}
+	return alert
+}
+
+func TestVisibilityRule_Matches(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     valueobject.VisibilityRule
+		source   string
+		labels   map[string]interface{}
+		expected bool
+	}{
+		{"exact source", valueobject.VisibilityRule{Source: "security"}, "security", nil, true},
+		{"source prefix", valueobject.VisibilityRule{Source: "security"}, "security:ids-1", nil, true},
+		{"other source", valueobject.VisibilityRule{Source: "security"}, "securityhub", nil, false},
+		{"label match", valueobject.VisibilityRule{Labels: map[string]string{"team": "sec"}}, "grafana", map[string]interface{}{"team": "sec"}, true},
+		{"label mismatch", valueobject.VisibilityRule{Labels: map[string]string{"team": "sec"}}, "grafana", map[string]interface{}{"team": "ops"}, false},
+		{"missing labels", valueobject.VisibilityRule{Labels: map[string]string{"team": "sec"}}, "grafana", nil, false},
+		{"source and label", valueobject.VisibilityRule{Source: "grafana", Labels: map[string]string{"team": "sec"}}, "datadog", map[string]interface{}{"team": "sec"}, false},
+		{"no criteria", valueobject.VisibilityRule{}, "security", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alert := newVisibilityAlert(t, tc.source, tc.labels)
+			assert.Equal(t, tc.expected, tc.rule.Matches(alert))
+		})
+	}
+}
+
+func TestVisibilityPolicy_CanView(t *testing.T) {
+	policy := valueobject.NewVisibilityPolicy(valueobject.VisibilityRule{
+		Name:   "security",
+		Source: "security",
+		Roles:  []entity.UserRole{entity.UserRoleOperator},
+	})
+
+	restricted := newVisibilityAlert(t, "security:ids", nil)
+	public := newVisibilityAlert(t, "grafana", nil)
+
+	assert.True(t, policy.CanView(restricted, entity.UserRoleAdmin))
+	assert.True(t, policy.CanView(restricted, entity.UserRoleOperator))
+	assert.False(t, policy.CanView(restricted, entity.UserRoleViewer))
+	assert.False(t, policy.CanView(restricted, ""))
+	assert.True(t, policy.CanView(public, entity.UserRoleViewer))
+}
+
+func TestVisibilityPolicy_HiddenFor(t *testing.T) {
+	policy := valueobject.NewVisibilityPolicy(valueobject.VisibilityRule{
+		Source: "security",
+		Roles:  []entity.UserRole{entity.UserRoleOperator},
+	})
+
+	assert.Empty(t, policy.HiddenFor(entity.UserRoleAdmin))
+	assert.Empty(t, policy.HiddenFor(entity.UserRoleOperator))
+	assert.Len(t, policy.HiddenFor(entity.UserRoleViewer), 1)
+
+	filter := valueobject.NewAlertFilter().WithVisibility(policy, entity.UserRoleViewer)
+	assert.Len(t, filter.Hidden, 1)
+	assert.True(t, filter.IsEmpty())
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	labels, err := valueobject.ParseLabelSelector([]string{"team=security", "env="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "security", "env": ""}, labels)
+
+	_, err = valueobject.ParseLabelSelector([]string{"team"})
+	assert.Error(t, err)
+
+	_, err = valueobject.ParseLabelSelector([]string{"=value"})
+	assert.Error(t, err)
+}
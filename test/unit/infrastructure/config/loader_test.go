@@ -0,0 +1,43 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+)
+
+// Without a config file, as in env-only deployments, the defaults apply.
+func TestLoad_EventBusDefaultsWithoutConfigFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+
+	bus := cfg.EventBus
+	assert.Equal(t, "redis-stream", bus.Driver)
+	assert.Equal(t, 3, bus.MaxRetries)
+	assert.Equal(t, 100*time.Millisecond, bus.InitialBackoff)
+	assert.Equal(t, 30*time.Second, bus.MaxBackoff)
+	assert.Equal(t, time.Minute, bus.ClaimMinIdle)
+	assert.Equal(t, 30*time.Second, bus.ClaimInterval)
+	assert.Equal(t, 1, bus.Workers)
+	assert.Equal(t, 24*time.Hour, bus.IdempotencyTTL)
+	assert.Equal(t, 15*time.Second, bus.LagInterval)
+	assert.Equal(t, "high", cfg.Notification.MinSeverity)
+}
+
+func TestLoad_EventBusEnvOverridesDefaults(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("EVENT_BUS_CLAIM_INTERVAL", "5s")
+	t.Setenv("EVENT_BUS_IDEMPOTENCY_TTL", "0s")
+
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, 5*time.Second, cfg.EventBus.ClaimInterval)
+	assert.Zero(t, cfg.EventBus.IdempotencyTTL)
+}
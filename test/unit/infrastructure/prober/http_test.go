@@ -0,0 +1,63 @@
+package prober_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/prober"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	testCases := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fd00:ec2::254", false},
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.addr, func(t *testing.T) {
+			assert.Equal(t, tc.public, prober.IsPublicAddr(netip.MustParseAddr(tc.addr)))
+		})
+	}
+}
+
+func TestHTTPProber_RefusesInternalAddresses(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requested = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	check, err := entity.NewCheck("internal", server.URL, http.MethodGet, http.StatusOK,
+		60, 5, 1, entity.AlertSeverityHigh, nil)
+	require.NoError(t, err)
+
+	result := prober.NewHTTPProber().Probe(context.Background(), check)
+
+	assert.False(t, result.Success)
+	assert.Equal(t, prober.ErrAddressNotAllowed.Error(), result.Error)
+	assert.False(t, requested)
+}
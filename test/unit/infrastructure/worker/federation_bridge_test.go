@@ -0,0 +1,176 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/event"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/config"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/messaging"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/infrastructure/worker"
+)
+
+const (
+	testRegion  = "eu-west-1"
+	testCluster = "primary"
+)
+
+// flakyPublisher stands for the remote deployment, failing its first publishes.
+type flakyPublisher struct {
+	mu        sync.Mutex
+	failures  int
+	published []*event.Event
+}
+
+func (p *flakyPublisher) Publish(_ context.Context, evt *event.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("remote deployment unavailable")
+	}
+	p.published = append(p.published, evt)
+	return nil
+}
+
+func (p *flakyPublisher) PublishToStream(ctx context.Context, _ string, evt *event.Event) error {
+	return p.Publish(ctx, evt)
+}
+
+func (p *flakyPublisher) events() []*event.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*event.Event(nil), p.published...)
+}
+
+// startBridge runs a bridge forwarding created alerts from an in-memory bus
+// retrying failed events deadLetterAfter-1 times.
+func startBridge(t *testing.T, target event.Publisher, deadLetterAfter int) *messaging.MemoryBus {
+	t.Helper()
+
+	source := messaging.NewMemoryBus()
+	source.SetOrigin(testRegion, testCluster)
+	source.SetRetryPolicy(messaging.RetryConfig{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+	}, deadLetterAfter)
+
+	bridge := worker.NewFederationBridge(source, target, config.FederationConfig{
+		EventTypes: []string{string(event.AlertCreated)},
+	}, testRegion, testCluster)
+	require.NoError(t, bridge.Start())
+	t.Cleanup(func() { _ = bridge.Stop() })
+
+	return source
+}
+
+func TestFederationBridge_RetriesRemoteFailures(t *testing.T) {
+	target := &flakyPublisher{failures: 2}
+	source := startBridge(t, target, 5)
+
+	// Another consumer group failing once re-publishes the event too
+	var failedOnce sync.Once
+	require.NoError(t, source.Subscribe(context.Background(), event.StreamAlerts, "other",
+		func(_ context.Context, _ *event.Event) error {
+			var err error
+			failedOnce.Do(func() { err = errors.New("other consumer failed") })
+			return err
+		}))
+
+	evt, err := event.NewEvent(event.AlertCreated, map[string]string{"severity": "critical"})
+	require.NoError(t, err)
+	require.NoError(t, source.Publish(context.Background(), evt))
+
+	require.Eventually(t, func() bool { return len(target.events()) > 0 }, time.Second, 5*time.Millisecond)
+
+	// Retries of the other group are not forwarded again
+	time.Sleep(50 * time.Millisecond)
+	forwarded := target.events()
+	require.Len(t, forwarded, 1)
+	assert.Equal(t, evt.ID, forwarded[0].ID)
+	assert.Zero(t, forwarded[0].Retries)
+	assert.Empty(t, forwarded[0].LastError)
+}
+
+func TestFederationBridge_DeadLettersUnforwardedEvents(t *testing.T) {
+	target := &flakyPublisher{failures: 100}
+	source := startBridge(t, target, 3)
+
+	deadLetters := make(chan *event.Event, 1)
+	require.NoError(t, source.Subscribe(context.Background(), event.StreamDeadLetter, "test",
+		func(_ context.Context, evt *event.Event) error {
+			deadLetters <- evt
+			return nil
+		}))
+
+	evt, err := event.NewEvent(event.AlertCreated, map[string]string{"severity": "critical"})
+	require.NoError(t, err)
+	require.NoError(t, source.Publish(context.Background(), evt))
+
+	select {
+	case dead := <-deadLetters:
+		assert.Equal(t, evt.ID, dead.ID)
+		assert.Equal(t, 3, dead.Retries)
+		assert.True(t, strings.HasPrefix(dead.LastError, worker.ErrForwardFailed.Error()))
+		assert.Equal(t, event.GroupFederationForwarders, dead.FailedGroup)
+	case <-time.After(time.Second):
+		t.Fatal("event was not moved to the dead letter queue")
+	}
+
+	assert.Empty(t, target.events())
+}
+
+func TestFederationBridge_ForwardsOnlyItsOwnRetries(t *testing.T) {
+	testCases := []struct {
+		name        string
+		failedGroup string
+		lastError   string
+		wantForward bool
+	}{
+		{
+			name:        "retry of the bridge",
+			failedGroup: event.GroupFederationForwarders,
+			lastError:   "remote deployment unavailable",
+			wantForward: true,
+		},
+		{
+			name:        "retry of another group quoting the bridge error",
+			failedGroup: "other",
+			lastError:   worker.ErrForwardFailed.Error() + ": remote deployment unavailable",
+			wantForward: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target := &flakyPublisher{}
+			source := startBridge(t, target, 5)
+
+			evt, err := event.NewEvent(event.AlertCreated, map[string]string{"severity": "critical"})
+			require.NoError(t, err)
+			evt.Retries = 1
+			evt.LastError = tc.lastError
+			evt.FailedGroup = tc.failedGroup
+			require.NoError(t, source.Publish(context.Background(), evt))
+
+			if tc.wantForward {
+				require.Eventually(t, func() bool { return len(target.events()) == 1 }, time.Second, 5*time.Millisecond)
+				assert.Zero(t, target.events()[0].Retries)
+				assert.Empty(t, target.events()[0].FailedGroup)
+				return
+			}
+
+			time.Sleep(50 * time.Millisecond)
+			assert.Empty(t, target.events())
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/dto"
+	"github.com/daniel-caso-github/realtime-alerting-system/pkg/client"
+)
+
+// recordedRequest is what a test server saw of a request.
+type recordedRequest struct {
+	method        string
+	path          string
+	query         url.Values
+	authorization string
+	contentType   string
+}
+
+// serve starts a server answering every request with status and body, and
+// records the requests it receives.
+func serve(t *testing.T, status int, body string) (*httptest.Server, *[]recordedRequest) {
+	t.Helper()
+
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, recordedRequest{
+			method:        r.Method,
+			path:          r.URL.EscapedPath(),
+			query:         r.URL.Query(),
+			authorization: r.Header.Get("Authorization"),
+			contentType:   r.Header.Get("Content-Type"),
+		})
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestClient_AuthorizationHeader(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []client.Option
+		want string
+	}{
+		{name: "no token", want: ""},
+		{name: "with token", opts: []client.Option{client.WithToken("secret")}, want: "Bearer secret"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, requests := serve(t, http.StatusOK, `{}`)
+
+			_, err := client.New(server.URL+"/", tc.opts...).GetAlert(context.Background(), "a/1")
+			require.NoError(t, err)
+
+			require.Len(t, *requests, 1)
+			assert.Equal(t, "/api/v1/alerts/a%2F1", (*requests)[0].path)
+			assert.Equal(t, tc.want, (*requests)[0].authorization)
+		})
+	}
+}
+
+func TestClient_LoginSetsToken(t *testing.T) {
+	server, requests := serve(t, http.StatusOK, `{"access_token":"issued","user":{"email":"operator@example.com"}}`)
+	c := client.New(server.URL)
+
+	resp, err := c.Login(context.Background(), "operator@example.com", "Passw0rd!")
+	require.NoError(t, err)
+	assert.Equal(t, "operator@example.com", resp.User.Email)
+
+	_, err = c.GetAlert(context.Background(), "1")
+	require.NoError(t, err)
+
+	require.Len(t, *requests, 2)
+	assert.Equal(t, http.MethodPost, (*requests)[0].method)
+	assert.Equal(t, "application/json", (*requests)[0].contentType)
+	assert.Empty(t, (*requests)[0].authorization)
+	assert.Equal(t, "Bearer issued", (*requests)[1].authorization)
+}
+
+func TestClient_ErrorDecoding(t *testing.T) {
+	testCases := []struct {
+		name    string
+		status  int
+		body    string
+		want    client.APIError
+		message string
+	}{
+		{
+			name:    "error response",
+			status:  http.StatusConflict,
+			body:    `{"error":"alert is already resolved","code":"ALERT_RESOLVED","request_id":"req-1"}`,
+			want:    client.APIError{StatusCode: http.StatusConflict, Code: "ALERT_RESOLVED", Message: "alert is already resolved", RequestID: "req-1"},
+			message: "api error 409 (ALERT_RESOLVED): alert is already resolved",
+		},
+		{
+			name:    "plain text",
+			status:  http.StatusBadGateway,
+			body:    "upstream unavailable\n",
+			want:    client.APIError{StatusCode: http.StatusBadGateway, Message: "upstream unavailable"},
+			message: "api error 502: upstream unavailable",
+		},
+		{
+			name:    "JSON without error message",
+			status:  http.StatusUnauthorized,
+			body:    `{"code":"UNAUTHORIZED"}`,
+			want:    client.APIError{StatusCode: http.StatusUnauthorized, Message: `{"code":"UNAUTHORIZED"}`},
+			message: `api error 401: {"code":"UNAUTHORIZED"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, _ := serve(t, tc.status, tc.body)
+
+			_, err := client.New(server.URL).ResolveAlert(context.Background(), "1")
+
+			var apiErr *client.APIError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tc.want, *apiErr)
+			assert.EqualError(t, err, tc.message)
+		})
+	}
+}
+
+func TestClient_ListAlertsPagination(t *testing.T) {
+	page := dto.PaginatedResponse[dto.AlertResponse]{
+		Items:       []dto.AlertResponse{{ID: "3"}, {ID: "4"}},
+		TotalItems:  5,
+		TotalPages:  3,
+		CurrentPage: 2,
+		PageSize:    2,
+		HasNext:     true,
+		HasPrevious: true,
+	}
+	body, err := json.Marshal(page)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		params    client.ListAlertsParams
+		wantQuery url.Values
+	}{
+		{
+			name:      "zero values are left out",
+			params:    client.ListAlertsParams{},
+			wantQuery: url.Values{},
+		},
+		{
+			name: "filters and page",
+			params: client.ListAlertsParams{
+				Status:   []string{"active", "acknowledged"},
+				Severity: []string{"critical"},
+				Search:   "disk full",
+				Page:     2,
+				PageSize: 2,
+			},
+			wantQuery: url.Values{
+				"status":    {"active", "acknowledged"},
+				"severity":  {"critical"},
+				"search":    {"disk full"},
+				"page":      {"2"},
+				"page_size": {"2"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server, requests := serve(t, http.StatusOK, string(body))
+
+			result, err := client.New(server.URL).ListAlerts(context.Background(), tc.params)
+			require.NoError(t, err)
+			assert.Equal(t, page, *result)
+
+			require.Len(t, *requests, 1)
+			assert.Equal(t, "/api/v1/alerts", (*requests)[0].path)
+			assert.Equal(t, tc.wantQuery, (*requests)[0].query)
+		})
+	}
+}
+
+func TestClient_ListFailedEventsPagination(t *testing.T) {
+	server, requests := serve(t, http.StatusOK, `{"items":[{"id":"e1"}],"total_items":1,"total_pages":1,"current_page":1}`)
+
+	result, err := client.New(server.URL).ListFailedEvents(context.Background(), 1, 50)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "e1", result.Items[0].ID)
+	assert.False(t, result.HasNext)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, url.Values{"page": {"1"}, "page_size": {"50"}}, (*requests)[0].query)
+}
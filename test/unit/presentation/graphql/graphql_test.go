@@ -0,0 +1,325 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/graphql"
+)
+
+type testAlert struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Severity  string `json:"severity"`
+	CreatedAt string `json:"created_at"`
+}
+
+// testSchema serves a list of alerts filtered by severity, and an alert by ID.
+func testSchema() *graphql.Schema {
+	alerts := []testAlert{
+		{ID: "1", Title: "Disk full", Severity: "critical", CreatedAt: "2024-01-01T00:00:00Z"},
+		{ID: "2", Title: "High latency", Severity: "medium", CreatedAt: "2024-01-02T00:00:00Z"},
+	}
+	alertType := graphql.NewObject("Alert", "id", "title", "severity", "created_at")
+
+	return &graphql.Schema{
+		Query: &graphql.Object{
+			Name: "Query",
+			Fields: map[string]*graphql.Field{
+				"alerts": {
+					Type: alertType,
+					Args: []string{"severity"},
+					Resolve: func(_ context.Context, args graphql.Args) (interface{}, error) {
+						severities := args.Strings("severity")
+						matched := []testAlert{}
+						for _, a := range alerts {
+							if len(severities) == 0 || severities[0] == a.Severity {
+								matched = append(matched, a)
+							}
+						}
+						return matched, nil
+					},
+				},
+				"alert": {
+					Type: alertType,
+					Args: []string{"id"},
+					Resolve: func(_ context.Context, args graphql.Args) (interface{}, error) {
+						for _, a := range alerts {
+							if a.ID == args.String("id") {
+								return a, nil
+							}
+						}
+						return nil, errors.New("alert not found")
+					},
+				},
+			},
+		},
+	}
+}
+
+// run prepares and executes a request, returning the response as JSON.
+func run(t *testing.T, req graphql.Request) string {
+	t.Helper()
+
+	op, err := graphql.Prepare(testSchema(), req)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(op.Execute(context.Background()))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestExecute_SelectsFields(t *testing.T) {
+	response := run(t, graphql.Request{Query: `{ alerts { id createdAt } }`})
+
+	assert.JSONEq(t, `{"data":{"alerts":[
+		{"id":"1","createdAt":"2024-01-01T00:00:00Z"},
+		{"id":"2","createdAt":"2024-01-02T00:00:00Z"}
+	]}}`, response)
+}
+
+func TestExecute_AliasesArgumentsAndDirectives(t *testing.T) {
+	response := run(t, graphql.Request{Query: `
+		# Aliases select the same field twice
+		query Dashboard {
+			critical: alerts(severity: critical) { title __typename }
+			medium: alerts(severity: "medium") { title severity @skip(if: true) }
+		}`})
+
+	assert.JSONEq(t, `{"data":{
+		"critical":[{"title":"Disk full","__typename":"Alert"}],
+		"medium":[{"title":"High latency"}]
+	}}`, response)
+}
+
+func TestExecute_Variables(t *testing.T) {
+	query := `query Alert($id: ID!, $withSeverity: Boolean = false) {
+		alert(id: $id) { title severity @include(if: $withSeverity) }
+	}`
+
+	response := run(t, graphql.Request{Query: query, Variables: map[string]interface{}{"id": "1"}})
+	assert.JSONEq(t, `{"data":{"alert":{"title":"Disk full"}}}`, response)
+
+	response = run(t, graphql.Request{Query: query, Variables: map[string]interface{}{"id": "2", "withSeverity": true}})
+	assert.JSONEq(t, `{"data":{"alert":{"title":"High latency","severity":"medium"}}}`, response)
+
+	_, err := graphql.Prepare(testSchema(), graphql.Request{Query: query})
+	assert.EqualError(t, err, `variable "$id" of required type was not provided`)
+}
+
+func TestExecute_OperationName(t *testing.T) {
+	query := `query First { alert(id: "1") { id } } query Second { alert(id: "2") { id } }`
+
+	response := run(t, graphql.Request{Query: query, OperationName: "Second"})
+	assert.JSONEq(t, `{"data":{"alert":{"id":"2"}}}`, response)
+
+	_, err := graphql.Prepare(testSchema(), graphql.Request{Query: query})
+	assert.Error(t, err)
+}
+
+func TestExecute_FieldErrorShape(t *testing.T) {
+	response := run(t, graphql.Request{Query: `{ found: alert(id: "1") { id } missing: alert(id: "9") { id } }`})
+
+	assert.JSONEq(t, `{
+		"data":{"found":{"id":"1"},"missing":null},
+		"errors":[{"message":"alert not found","path":["missing"]}]
+	}`, response)
+}
+
+func TestPrepare_InvalidDocuments(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{"syntax error", `{ alerts { id }`, "syntax error at 15: expected name, found <EOF>"},
+		{"unknown field", `{ alerts { owner } }`, `cannot query field "owner" on type "Alert"`},
+		{"unknown argument", `{ alert(key: "1") { id } }`, `unknown argument "key" on field "Query.alert"`},
+		{"missing selection", `{ alerts }`, `field "alerts" of type "Alert" must have a selection of subfields`},
+		{"fragment spread", `{ alerts { ...Fields } }`, "fragments are not supported"},
+		{"fragment definition", `fragment Fields on Alert { id } { alerts { ...Fields } }`, "fragments are not supported"},
+		{"mutation", `mutation { alerts { id } }`, "mutation operations are not supported"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := graphql.Prepare(testSchema(), graphql.Request{Query: tc.query})
+			assert.EqualError(t, err, tc.message)
+		})
+	}
+}
+
+func TestPrepare_DepthLimit(t *testing.T) {
+	nested := func(open, close string, depth int) string {
+		return strings.Repeat(open, depth) + strings.Repeat(close, depth)
+	}
+
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{"list value", `{ alerts(severity: ` + nested("[", "]", graphql.MaxDepth+1) + `) { id } }`},
+		{"object value", `{ alerts(severity: ` + strings.Repeat("{a: ", graphql.MaxDepth+1) + "1" +
+			strings.Repeat("}", graphql.MaxDepth+1) + `) { id } }`},
+		{"list type", `query ($s: ` + nested("[", "]", graphql.MaxDepth+1) + `) { alerts { id } }`},
+		{"selection set", strings.Repeat("{ alerts ", graphql.MaxDepth) + "{ id }" + strings.Repeat(" }", graphql.MaxDepth)},
+		// Unterminated nesting fails at the limit rather than exhausting the stack
+		{"unterminated", `{ alerts(severity: ` + strings.Repeat("[", 1<<15)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := graphql.Prepare(testSchema(), graphql.Request{Query: tc.query})
+			assert.ErrorIs(t, err, graphql.ErrDocumentTooDeep)
+		})
+	}
+
+	// Values nested within the limit are accepted
+	_, err := graphql.Prepare(testSchema(), graphql.Request{
+		Query: `{ alerts(severity: ` + nested("[", "]", graphql.MaxDepth-1) + `) { id } }`,
+	})
+	assert.NoError(t, err)
+}
+
+func TestPrepare_DocumentSizeLimit(t *testing.T) {
+	query := `{ alerts { id } }` + strings.Repeat(" ", graphql.MaxDocumentSize)
+
+	_, err := graphql.Prepare(testSchema(), graphql.Request{Query: query})
+	assert.ErrorIs(t, err, graphql.ErrDocumentTooLarge)
+}
+
+func TestPrepare_ComplexityLimit(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("{")
+	for i := 0; i < 50; i++ {
+		b.WriteString(" a")
+		b.WriteString(strings.Repeat("x", i))
+		b.WriteString(": alerts { id title }")
+	}
+	b.WriteString(" }")
+
+	_, err := graphql.Prepare(testSchema(), graphql.Request{Query: b.String()})
+	assert.ErrorIs(t, err, graphql.ErrTooComplex)
+
+	_, err = graphql.Prepare(testSchema(), graphql.Request{Query: `{ a: alerts { id } b: alerts { id } }`})
+	assert.NoError(t, err)
+}
+
+func TestPrepare_MalformedDocuments(t *testing.T) {
+	testCases := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{"empty document", ``, "document does not contain any operation"},
+		{"only a comment", "  # nothing to run\n", "document does not contain any operation"},
+		{"empty selection set", `{ }`, `syntax error at 2: expected field, found "}"`},
+		{"unterminated string", `{ alert(id: "1) { id } }`, "syntax error at 12: unterminated string"},
+		{"unterminated block string", `{ alert(id: """1) { id } }`, "syntax error at 12: unterminated string"},
+		{"string across lines", "{ alert(id: \"1\n\") { id } }", "syntax error at 12: unterminated string"},
+		{"trailing escape", `{ alerts(severity: "x\`, "syntax error at 19: unterminated string"},
+		{"invalid escape", `{ alert(id: "\q") { id } }`, "syntax error at 12: invalid string"},
+		{"float without fraction", `{ alerts(severity: 1.) { id } }`, "syntax error at 19: invalid number"},
+		{"lone minus", `{ alerts(severity: -) { id } }`, "syntax error at 19: invalid number"},
+		{"int overflow", `{ alerts(severity: 99999999999999999999) { id } }`, "syntax error at 19: invalid int 99999999999999999999"},
+		{"unexpected character", `{ alerts { id } } ?`, "syntax error at 18: unexpected character '?'"},
+		{"alias without field", `{ alerts { id: } }`, `syntax error at 15: expected name, found "}"`},
+		{"unclosed variable definitions", `query Q($id: ID! { alert(id: $id) { id } }`, `syntax error at 17: expected "$", found "{"`},
+		{"unknown directive", `{ alerts { id @deprecated } }`, `unknown directive "@deprecated"`},
+		{"typename selection", `{ __typename { id } }`, `field "__typename" must not have a selection since type is scalar`},
+		{"unsupported subscription", `subscription { alerts { id } }`, "subscription operations are not supported"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := graphql.Prepare(testSchema(), graphql.Request{Query: tc.query})
+			assert.EqualError(t, err, tc.message)
+		})
+	}
+}
+
+func TestExecute_EdgeCases(t *testing.T) {
+	testCases := []struct {
+		name     string
+		req      graphql.Request
+		response string
+	}{
+		{
+			name:     "block string argument",
+			req:      graphql.Request{Query: `{ alert(id: """ 1 """) { id } }`},
+			response: `{"data":{"alert":{"id":"1"}}}`,
+		},
+		{
+			name:     "unicode escape",
+			req:      graphql.Request{Query: `{ alert(id: "\u0032") { id } }`},
+			response: `{"data":{"alert":{"id":"2"}}}`,
+		},
+		{
+			name:     "commas and comments are ignored",
+			req:      graphql.Request{Query: "{ alert(id: \"1\",), # the first\n { id, title, } }"},
+			response: `{"data":{"alert":{"id":"1","title":"Disk full"}}}`,
+		},
+		{
+			name:     "null variable uses the default",
+			req:      graphql.Request{Query: `query ($id: ID = "2") { alert(id: $id) { id } }`, Variables: map[string]interface{}{"id": nil}},
+			response: `{"data":{"alert":{"id":"2"}}}`,
+		},
+		{
+			name:     "undefined variable",
+			req:      graphql.Request{Query: `{ alert(id: $missing) { id } }`},
+			response: `{"data":{"alert":null},"errors":[{"message":"variable \"$missing\" is not defined","path":["alert"]}]}`,
+		},
+		{
+			name:     "no match",
+			req:      graphql.Request{Query: `{ alerts(severity: "é") { id } }`},
+			response: `{"data":{"alerts":[]}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.JSONEq(t, tc.response, run(t, tc.req))
+		})
+	}
+
+	_, err := graphql.Prepare(testSchema(), graphql.Request{Query: `{ alerts { id } }`, OperationName: "Missing"})
+	assert.EqualError(t, err, `unknown operation named "Missing"`)
+}
+
+// FuzzPrepare checks that any document is either refused or runs to a
+// response that encodes, without panicking or exhausting the stack.
+func FuzzPrepare(f *testing.F) {
+	for _, seed := range []string{
+		`{ alerts { id createdAt } }`,
+		`query Dashboard { critical: alerts(severity: critical) { title __typename } }`,
+		`query Alert($id: ID!, $withSeverity: Boolean = false) { alert(id: $id) { title severity @include(if: $withSeverity) } }`,
+		`query First { alert(id: "1") { id } } query Second { alert(id: "2") { id } }`,
+		`{ alerts(severity: [1, 2.5e3, -4, "x", """y""", {a: [null, true]}]) { id } }`,
+		`{ alert(id: "1\n") { id } }`,
+		`subscription { alerts { id } }`,
+		`fragment Fields on Alert { id }`,
+		`{ alerts(severity: [[[[`,
+	} {
+		f.Add(seed)
+	}
+
+	schema := testSchema()
+	f.Fuzz(func(t *testing.T, query string) {
+		op, err := graphql.Prepare(schema, graphql.Request{Query: query, Variables: map[string]interface{}{"id": "1"}})
+		if err != nil {
+			return
+		}
+		if op.IsSubscription() {
+			return
+		}
+		if _, err := json.Marshal(op.Execute(context.Background())); err != nil {
+			t.Fatalf("response of %q does not encode: %v", query, err)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/handler"
+)
+
+const allowedTopic = "arn:aws:sns:us-east-1:123456789012:alarms"
+
+// cloudWatchApp serves the CloudWatch webhook accepting the given topics.
+func cloudWatchApp(topics []string) *fiber.App {
+	webhooks := handler.NewWebhookHandler(nil)
+	webhooks.SetCloudWatchTopics(topics)
+
+	app := fiber.New()
+	app.Post("/webhooks/cloudwatch", webhooks.CloudWatchWebhookHandler)
+	return app
+}
+
+func TestCloudWatchWebhook_TopicAllowList(t *testing.T) {
+	confirmation := func(topicARN string) handler.SNSMessage {
+		return handler.SNSMessage{
+			Type:             "SubscriptionConfirmation",
+			MessageID:        "message-1",
+			TopicArn:         topicARN,
+			SubscribeURL:     "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription",
+			SignatureVersion: "1",
+			Signature:        "c2lnbmF0dXJl",
+			SigningCertURL:   "https://attacker.example.com/cert.pem",
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		topics     []string
+		msg        handler.SNSMessage
+		wantStatus int
+	}{
+		{
+			name:       "no topics configured",
+			msg:        confirmation(allowedTopic),
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:       "unknown topic",
+			topics:     []string{allowedTopic},
+			msg:        confirmation("arn:aws:sns:us-east-1:999999999999:attacker"),
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:       "allowed topic still needs a valid signature",
+			topics:     []string{allowedTopic},
+			msg:        confirmation(allowedTopic),
+			wantStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := json.Marshal(tc.msg)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(fiber.MethodPost, "/webhooks/cloudwatch", bytes.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMETextPlain)
+
+			resp, err := cloudWatchApp(tc.topics).Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+		})
+	}
+}
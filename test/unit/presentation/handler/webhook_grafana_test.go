@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/handler"
+)
+
+// dedupAlerts keeps the open alerts by dedup key.
+type dedupAlerts struct {
+	repository.AlertRepository
+	open map[string]*entity.Alert
+}
+
+func (d *dedupAlerts) GetOpenByDedupKey(_ context.Context, dedupKey string) (*entity.Alert, error) {
+	if alert, ok := d.open[dedupKey]; ok {
+		return alert, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (d *dedupAlerts) CreateDeduplicated(_ context.Context, alert *entity.Alert) (*entity.Alert, bool, error) {
+	if existing, ok := d.open[alert.Fingerprint()]; ok {
+		return existing, false, nil
+	}
+	d.open[alert.Fingerprint()] = alert
+	return alert, true, nil
+}
+
+// postGrafana posts Grafana payloads to a fresh handler and returns the
+// dedup keys of the open alerts.
+func postGrafana(t *testing.T, payloads ...handler.GrafanaWebhook) map[string]*entity.Alert {
+	t.Helper()
+
+	alerts := &dedupAlerts{open: make(map[string]*entity.Alert)}
+	webhooks := handler.NewWebhookHandler(service.NewAlertService(alerts, nil, nil))
+
+	app := fiber.New()
+	app.Post("/webhooks/grafana", webhooks.GrafanaWebhookHandler)
+
+	for _, payload := range payloads {
+		body, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(fiber.MethodPost, "/webhooks/grafana", bytes.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	}
+
+	return alerts.open
+}
+
+func TestGrafanaWebhook_FallbackFingerprint(t *testing.T) {
+	legacy := func(tags map[string]string) handler.GrafanaWebhook {
+		return handler.GrafanaWebhook{RuleName: "High CPU", State: "alerting", Tags: tags}
+	}
+	unified := func(labels map[string]string) handler.GrafanaWebhook {
+		return handler.GrafanaWebhook{
+			Status: "firing",
+			Alerts: []handler.GrafanaAlert{{Status: "firing", Labels: labels}},
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		payloads  []handler.GrafanaWebhook
+		wantOpen  int
+		forbidKey string
+	}{
+		{
+			name:      "legacy alerts without rule ID and different tags",
+			payloads:  []handler.GrafanaWebhook{legacy(map[string]string{"host": "a"}), legacy(map[string]string{"host": "b"})},
+			wantOpen:  2,
+			forbidKey: "grafana:0",
+		},
+		{
+			name:     "legacy alerts without rule ID and the same tags",
+			payloads: []handler.GrafanaWebhook{legacy(map[string]string{"host": "a"}), legacy(map[string]string{"host": "a"})},
+			wantOpen: 1,
+		},
+		{
+			name: "unified alerts without fingerprint and different labels",
+			payloads: []handler.GrafanaWebhook{
+				unified(map[string]string{"alertname": "DiskFull", "instance": "db-1"}),
+				unified(map[string]string{"alertname": "DiskFull", "instance": "db-2"}),
+			},
+			wantOpen:  2,
+			forbidKey: "grafana:DiskFull",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			open := postGrafana(t, tc.payloads...)
+
+			assert.Len(t, open, tc.wantOpen)
+			if tc.forbidKey != "" {
+				assert.NotContains(t, open, tc.forbidKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handler_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/handler"
+)
+
+const sentrySecret = "sentry-client-secret"
+
+// sentryApp serves the Sentry webhook with the given client secret.
+func sentryApp(secret string) *fiber.App {
+	webhooks := handler.NewWebhookHandler(nil)
+	webhooks.SetSentryClientSecret(secret, time.Minute)
+
+	app := fiber.New()
+	app.Post("/webhooks/sentry", webhooks.SentryWebhookHandler)
+	return app
+}
+
+func signSentry(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSentryWebhook_Verification(t *testing.T) {
+	body := []byte(`{"action":"created","data":{}}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	testCases := []struct {
+		name       string
+		secret     string
+		signature  string
+		timestamp  string
+		wantStatus int
+	}{
+		{
+			name:       "valid signature",
+			secret:     sentrySecret,
+			signature:  signSentry(sentrySecret, body),
+			timestamp:  now,
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "no client secret configured",
+			secret:     "",
+			signature:  signSentry("", body),
+			timestamp:  now,
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "missing signature",
+			secret:     sentrySecret,
+			timestamp:  now,
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "bad signature",
+			secret:     sentrySecret,
+			signature:  signSentry("another-secret", body),
+			timestamp:  now,
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "missing timestamp",
+			secret:     sentrySecret,
+			signature:  signSentry(sentrySecret, body),
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "stale timestamp",
+			secret:     sentrySecret,
+			signature:  signSentry(sentrySecret, body),
+			timestamp:  strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10),
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "future timestamp",
+			secret:     sentrySecret,
+			signature:  signSentry(sentrySecret, body),
+			timestamp:  strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10),
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name:       "malformed timestamp",
+			secret:     sentrySecret,
+			signature:  signSentry(sentrySecret, body),
+			timestamp:  "yesterday",
+			wantStatus: fiber.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, "/webhooks/sentry", bytes.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tc.signature != "" {
+				req.Header.Set("Sentry-Hook-Signature", tc.signature)
+			}
+			if tc.timestamp != "" {
+				req.Header.Set("Sentry-Hook-Timestamp", tc.timestamp)
+			}
+
+			resp, err := sentryApp(tc.secret).Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+		})
+	}
+}
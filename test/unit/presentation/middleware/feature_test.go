@@ -0,0 +1,110 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/repository"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/valueobject"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/http/middleware"
+)
+
+// memoryCache stores feature flag overrides in memory.
+type memoryCache struct {
+	repository.CacheRepository
+	values map[string][]byte
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: map[string][]byte{}}
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.values[key] = data
+	return nil
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, dest interface{}) error {
+	data, ok := m.values[key]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (m *memoryCache) Delete(_ context.Context, key string) error {
+	delete(m.values, key)
+	return nil
+}
+
+// featureApp serves a route gated by the SSE flag, with the organization
+// resolved from a header the way the tenant middleware would.
+func featureApp(flags *service.FeatureFlagService) *fiber.App {
+	app := fiber.New()
+	app.Get("/stream",
+		func(c *fiber.Ctx) error {
+			if orgID := c.Get("X-Org-ID"); orgID != "" {
+				c.Locals("orgID", orgID)
+			}
+			return c.Next()
+		},
+		middleware.RequireFeature(flags, valueobject.FeatureSSE),
+		func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) },
+	)
+	return app
+}
+
+func status(t *testing.T, app *fiber.App, orgID string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodGet, "/stream", nil)
+	if orgID != "" {
+		req.Header.Set("X-Org-ID", orgID)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	return resp.StatusCode
+}
+
+func TestRequireFeature_OrgOverride(t *testing.T) {
+	ctx := context.Background()
+	flags := service.NewFeatureFlagService(newMemoryCache(), nil, "test")
+	app := featureApp(flags)
+
+	// Built-in flags are disabled by default
+	assert.Equal(t, fiber.StatusNotFound, status(t, app, ""))
+	assert.Equal(t, fiber.StatusNotFound, status(t, app, "org-a"))
+
+	// Enabling the flag for one organization leaves the others untouched
+	_, err := flags.Set(ctx, valueobject.FeatureSSE, "org-a", true)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, status(t, app, "org-a"))
+	assert.Equal(t, fiber.StatusNotFound, status(t, app, "org-b"))
+	assert.Equal(t, fiber.StatusNotFound, status(t, app, ""))
+
+	// An organization override wins over the deployment override
+	_, err = flags.Set(ctx, valueobject.FeatureSSE, "", true)
+	require.NoError(t, err)
+	_, err = flags.Set(ctx, valueobject.FeatureSSE, "org-b", false)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, status(t, app, ""))
+	assert.Equal(t, fiber.StatusOK, status(t, app, "org-a"))
+	assert.Equal(t, fiber.StatusNotFound, status(t, app, "org-b"))
+
+	// Resetting the override falls back to the deployment
+	_, err = flags.Reset(ctx, valueobject.FeatureSSE, "org-b")
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, status(t, app, "org-b"))
+}
@@ -0,0 +1,139 @@
+package websocket_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	fastws "github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	fiberws "github.com/gofiber/websocket/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/application/service"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/domain/entity"
+	"github.com/daniel-caso-github/realtime-alerting-system/internal/presentation/websocket"
+)
+
+// stubAuthenticator accepts tokens unless it was told to reject them, and
+// counts its checks.
+type stubAuthenticator struct {
+	err    error
+	checks atomic.Int64
+}
+
+func (a *stubAuthenticator) ValidateToken(_ context.Context, _ string) (*service.JWTClaims, error) {
+	a.checks.Add(1)
+	if a.err != nil {
+		return nil, a.err
+	}
+	return &service.JWTClaims{Role: "viewer"}, nil
+}
+
+// serveHub serves a hub's WebSocket endpoint to clients authenticated with a
+// token expiring at expiresAt, and returns its URL.
+func serveHub(t *testing.T, hub *websocket.Hub, expiresAt time.Time) string {
+	t.Helper()
+
+	wsHandler := websocket.NewHandler(hub)
+	userID := entity.NewID()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use("/ws", func(c *fiber.Ctx) error {
+		c.Locals("userID", userID)
+		c.Locals("userRole", "viewer")
+		c.Locals("accessToken", "token")
+		c.Locals("tokenExpiresAt", expiresAt)
+		return wsHandler.Upgrade(c)
+	})
+	app.Get("/ws", fiberws.New(wsHandler.Handle))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	return "ws://" + listener.Addr().String() + "/ws"
+}
+
+func TestHub_TokenChecksDisconnectClients(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expiresAt  time.Time
+		authErr    error
+		wantReason string
+	}{
+		{
+			name:       "expired token",
+			expiresAt:  time.Now().Add(100 * time.Millisecond),
+			wantReason: "token expired",
+		},
+		{
+			name:       "revoked token",
+			expiresAt:  time.Now().Add(time.Hour),
+			authErr:    errors.New("token revoked"),
+			wantReason: "token revoked",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := websocket.NewHub()
+			go hub.Run()
+			hub.SetAuthenticator(&stubAuthenticator{err: tc.authErr}, 20*time.Millisecond)
+			t.Cleanup(func() {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				_ = hub.Shutdown(ctx)
+			})
+
+			conn, _, err := fastws.DefaultDialer.Dial(serveHub(t, hub, tc.expiresAt), nil)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+			for {
+				_, _, err = conn.ReadMessage()
+				if err != nil {
+					break
+				}
+			}
+
+			var closeErr *fastws.CloseError
+			require.ErrorAs(t, err, &closeErr)
+			assert.Equal(t, fastws.ClosePolicyViolation, closeErr.Code)
+			assert.Equal(t, tc.wantReason, closeErr.Text)
+		})
+	}
+}
+
+func TestHub_SetAuthenticatorStopsPreviousChecks(t *testing.T) {
+	hub := websocket.NewHub()
+	go hub.Run()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = hub.Shutdown(ctx)
+	})
+
+	previous := &stubAuthenticator{}
+	hub.SetAuthenticator(previous, 10*time.Millisecond)
+
+	conn, _, err := fastws.DefaultDialer.Dial(serveHub(t, hub, time.Now().Add(time.Hour)), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.Eventually(t, func() bool { return previous.checks.Load() > 0 }, time.Second, 5*time.Millisecond)
+
+	current := &stubAuthenticator{}
+	hub.SetAuthenticator(current, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return current.checks.Load() > 0 }, time.Second, 5*time.Millisecond)
+
+	checks := previous.checks.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, checks, previous.checks.Load())
+}